@@ -37,3 +37,10 @@ pushd conmon
 git checkout --detach d532caebc788fafdd2a305b68cd1983b4039bea4
 git archive --prefix "conmon/" --format "tar.gz" HEAD -o "../build/conmon.tar.gz"
 popd
+if [ ! -d dnsname ]; then
+    git clone -n --quiet https://github.com/containers/dnsname
+fi
+pushd dnsname
+git checkout --detach v1.1.1
+git archive --prefix "dnsname/" --format "tar.gz" HEAD -o "../build/dnsname.tar.gz"
+popd
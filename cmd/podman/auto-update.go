@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/containers/common/pkg/auth"
 	"github.com/containers/common/pkg/completion"
@@ -27,7 +28,8 @@ var (
 		RunE:              autoUpdate,
 		ValidArgsFunction: completion.AutocompleteNone,
 		Example: `podman auto-update
-  podman auto-update --authfile ~/authfile.json`,
+  podman auto-update --authfile ~/authfile.json
+  podman auto-update --rollback-window 5m`,
 	}
 )
 
@@ -42,6 +44,12 @@ func init() {
 	authfileFlagName := "authfile"
 	flags.StringVar(&autoUpdateOptions.Authfile, authfileFlagName, auth.GetDefaultAuthFile(), "Path to the authentication file. Use REGISTRY_AUTH_FILE environment variable to override")
 	_ = autoUpdateCommand.RegisterFlagCompletionFunc(authfileFlagName, completion.AutocompleteDefault)
+
+	flags.BoolVar(&autoUpdateOptions.Rollback, "rollback", true, "Rollback to the previous image if the restarted container does not become healthy")
+
+	rollbackWindowFlagName := "rollback-window"
+	flags.DurationVar(&autoUpdateOptions.RollbackWindow, rollbackWindowFlagName, 2*time.Minute, "Time a restarted container has to become healthy before the update is rolled back")
+	_ = autoUpdateCommand.RegisterFlagCompletionFunc(rollbackWindowFlagName, completion.AutocompleteNone)
 }
 
 func autoUpdate(cmd *cobra.Command, args []string) error {
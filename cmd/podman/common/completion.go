@@ -161,6 +161,28 @@ func getVolumes(cmd *cobra.Command, toComplete string) ([]string, cobra.ShellCom
 	return suggestions, cobra.ShellCompDirectiveNoFileComp
 }
 
+func getSecrets(cmd *cobra.Command, toComplete string) ([]string, cobra.ShellCompDirective) {
+	suggestions := []string{}
+
+	engine, err := setupContainerEngine(cmd)
+	if err != nil {
+		cobra.CompErrorln(err.Error())
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	secrets, err := engine.SecretList(registry.GetContext())
+	if err != nil {
+		cobra.CompErrorln(err.Error())
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	for _, s := range secrets {
+		if strings.HasPrefix(s.Spec.Name, toComplete) {
+			suggestions = append(suggestions, s.Spec.Name)
+		}
+	}
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
 func getImages(cmd *cobra.Command, toComplete string) ([]string, cobra.ShellCompDirective) {
 	suggestions := []string{}
 	listOptions := entities.ImageListOptions{}
@@ -327,6 +349,17 @@ func AutocompleteContainers(cmd *cobra.Command, args []string, toComplete string
 	return getContainers(cmd, toComplete, completeDefault)
 }
 
+// AutocompleteContainerOneArg - Autocomplete containers as first arg.
+func AutocompleteContainerOneArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if !validCurrentCmdLine(cmd, args, toComplete) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	if len(args) == 0 {
+		return getContainers(cmd, toComplete, completeDefault)
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteContainersCreated - Autocomplete only created container names.
 func AutocompleteContainersCreated(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	if !validCurrentCmdLine(cmd, args, toComplete) {
@@ -375,6 +408,17 @@ func AutocompletePods(cmd *cobra.Command, args []string, toComplete string) ([]s
 	return getPods(cmd, toComplete, completeDefault)
 }
 
+// AutocompletePodOneArg - Autocomplete one pod name.
+func AutocompletePodOneArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if !validCurrentCmdLine(cmd, args, toComplete) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	if len(args) == 0 {
+		return getPods(cmd, toComplete, completeDefault)
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompletePodsRunning - Autocomplete only running pod names.
 // It considers degraded as running.
 func AutocompletePodsRunning(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -384,6 +428,23 @@ func AutocompletePodsRunning(cmd *cobra.Command, args []string, toComplete strin
 	return getPods(cmd, toComplete, completeDefault, "running", "degraded")
 }
 
+// AutocompletePodsPaused - Autocomplete only paused pod names.
+func AutocompletePodsPaused(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if !validCurrentCmdLine(cmd, args, toComplete) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return getPods(cmd, toComplete, completeDefault, "paused")
+}
+
+// AutocompletePodsStartable - Autocomplete only pod names which can be started.
+// Degraded pods are included since their stopped containers can be started.
+func AutocompletePodsStartable(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if !validCurrentCmdLine(cmd, args, toComplete) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return getPods(cmd, toComplete, completeDefault, "created", "exited", "stopped", "degraded")
+}
+
 // AutocompleteContainersAndPods - Autocomplete container names and pod names.
 func AutocompleteContainersAndPods(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	if !validCurrentCmdLine(cmd, args, toComplete) {
@@ -412,6 +473,14 @@ func AutocompleteVolumes(cmd *cobra.Command, args []string, toComplete string) (
 	return getVolumes(cmd, toComplete)
 }
 
+// AutocompleteSecrets - Autocomplete secrets.
+func AutocompleteSecrets(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if !validCurrentCmdLine(cmd, args, toComplete) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return getSecrets(cmd, toComplete)
+}
+
 // AutocompleteImages - Autocomplete images.
 func AutocompleteImages(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	if !validCurrentCmdLine(cmd, args, toComplete) {
@@ -471,6 +540,21 @@ func AutocompleteCommitCommand(cmd *cobra.Command, args []string, toComplete str
 	return nil, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteVolumeImportCommand - Autocomplete podman volume import command args.
+func AutocompleteVolumeImportCommand(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if !validCurrentCmdLine(cmd, args, toComplete) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	if len(args) == 0 {
+		return getVolumes(cmd, toComplete)
+	}
+	if len(args) == 1 {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	// don't complete more than 2 args
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteCpCommand - Autocomplete podman cp command args.
 func AutocompleteCpCommand(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	if !validCurrentCmdLine(cmd, args, toComplete) {
@@ -682,7 +766,7 @@ func AutocompleteLogOpt(cmd *cobra.Command, args []string, toComplete string) ([
 // AutocompletePullOption - Autocomplete pull options for create and run command.
 // -> "always", "missing", "never"
 func AutocompletePullOption(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	pullOptions := []string{"always", "missing", "never"}
+	pullOptions := []string{"always", "missing", "never", "newer"}
 	return pullOptions, cobra.ShellCompDirectiveNoFileComp
 }
 
@@ -817,6 +901,7 @@ func AutocompleteNetworkFlag(cmd *cobra.Command, args []string, toComplete strin
 				"allow_host_loopback=": getBoolCompletion,
 				"cidr=":                nil,
 				"enable_ipv6=":         getBoolCompletion,
+				"mtu=":                 nil,
 				"outbound_addr=":       nil,
 				"outbound_addr6=":      nil,
 				"port_handler=": func(_ string) ([]string, cobra.ShellCompDirective) {
@@ -834,6 +919,29 @@ func AutocompleteNetworkFlag(cmd *cobra.Command, args []string, toComplete strin
 	return append(networks, suggestions...), dir
 }
 
+// AutocompleteHostsFile - Autocomplete hosts file options.
+// -> "image", "none", paths
+func AutocompleteHostsFile(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{"image", "none"}, cobra.ShellCompDirectiveDefault
+}
+
+// AutocompletePresets - Autocomplete presets of containers.conf.d.
+// -> preset names
+func AutocompletePresets(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	extra, err := util.LoadExtraConfig()
+	if err != nil {
+		cobra.CompErrorln(err.Error())
+		return nil, cobra.ShellCompDirectiveError
+	}
+	names := make([]string, 0, len(extra.Presets))
+	for name := range extra.Presets {
+		if strings.HasPrefix(name, toComplete) {
+			names = append(names, name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteJSONFormat - Autocomplete format flag option.
 // -> "json"
 func AutocompleteJSONFormat(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -841,11 +949,11 @@ func AutocompleteJSONFormat(cmd *cobra.Command, args []string, toComplete string
 }
 
 // AutocompleteEventFilter - Autocomplete event filter flag options.
-// -> "container=", "event=", "image=", "pod=", "volume=", "type="
+// -> "container=", "event=", "image=", "pod=", "volume=", "type=", "correlation="
 func AutocompleteEventFilter(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	eventTypes := func(_ string) ([]string, cobra.ShellCompDirective) {
 		return []string{"attach", "checkpoint", "cleanup", "commit", "create", "exec",
-			"export", "import", "init", "kill", "mount", "pause", "prune", "remove",
+			"export", "import", "init", "kill", "mount", "pause", "prune", "reboot", "remove",
 			"restart", "restore", "start", "stop", "sync", "unmount", "unpause",
 			"pull", "push", "save", "tag", "untag", "refresh", "renumber",
 		}, cobra.ShellCompDirectiveNoFileComp
@@ -857,6 +965,9 @@ func AutocompleteEventFilter(cmd *cobra.Command, args []string, toComplete strin
 		"volume=":    func(s string) ([]string, cobra.ShellCompDirective) { return getVolumes(cmd, s) },
 		"event=":     eventTypes,
 		"type=":      eventTypes,
+		"correlation=": func(_ string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
 	}
 	return completeKeyValues(toComplete, kv)
 }
@@ -909,6 +1020,13 @@ func AutocompletePodShareNamespace(cmd *cobra.Command, args []string, toComplete
 	return namespaces, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompletePodUserNamespace - Autocomplete pod create --userns flag option.
+// -> "host", "keep-id"
+func AutocompletePodUserNamespace(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	modes := []string{"host", "keep-id"}
+	return modes, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompletePodPsSort - Autocomplete images sort options.
 // -> "created", "id", "name", "status", "number"
 func AutocompletePodPsSort(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -931,9 +1049,9 @@ func AutocompleteImageSaveFormat(cmd *cobra.Command, args []string, toComplete s
 }
 
 // AutocompleteWaitCondition - Autocomplete wait condition options.
-// -> "unknown", "configured", "created", "running", "stopped", "paused", "exited", "removing"
+// -> "unknown", "configured", "created", "running", "stopped", "paused", "exited", "removing", "healthy", "unhealthy", "removed"
 func AutocompleteWaitCondition(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	states := []string{"unknown", "configured", "created", "running", "stopped", "paused", "exited", "removing"}
+	states := []string{"unknown", "configured", "created", "running", "stopped", "paused", "exited", "removing", "healthy", "unhealthy", "removed"}
 	return states, cobra.ShellCompDirectiveNoFileComp
 }
 
@@ -964,6 +1082,13 @@ func AutocompleteSDNotify(cmd *cobra.Command, args []string, toComplete string)
 	return types, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteInitCtr - Autocomplete init container types.
+// -> "always", "once"
+func AutocompleteInitCtr(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	types := []string{define.AlwaysInitContainer, define.OneShotInitContainer}
+	return types, cobra.ShellCompDirectiveNoFileComp
+}
+
 var containerStatuses = []string{"created", "running", "paused", "stopped", "exited", "unknown"}
 
 // AutocompletePsFilters - Autocomplete ps filter options.
@@ -982,9 +1107,13 @@ func AutocompletePsFilters(cmd *cobra.Command, args []string, toComplete string)
 			return []string{define.HealthCheckHealthy,
 				define.HealthCheckUnhealthy}, cobra.ShellCompDirectiveNoFileComp
 		},
+		"restart-policy=": func(_ string) ([]string, cobra.ShellCompDirective) {
+			return AutocompleteRestartOption(cmd, nil, "")
+		},
 		"label=":  nil,
 		"exited=": nil,
 		"until=":  nil,
+		"owner=":  nil,
 	}
 	return completeKeyValues(toComplete, kv)
 }
@@ -1000,11 +1129,14 @@ func AutocompletePodPsFilters(cmd *cobra.Command, args []string, toComplete stri
 		},
 		"ctr-ids=":    func(s string) ([]string, cobra.ShellCompDirective) { return getContainers(cmd, s, completeIDs) },
 		"ctr-names=":  func(s string) ([]string, cobra.ShellCompDirective) { return getContainers(cmd, s, completeNames) },
+		"ctr-count=":  nil,
 		"ctr-number=": nil,
 		"ctr-status=": func(_ string) ([]string, cobra.ShellCompDirective) {
 			return containerStatuses, cobra.ShellCompDirectiveNoFileComp
 		},
 		"label=": nil,
+		"owner=": nil,
+		"since=": func(s string) ([]string, cobra.ShellCompDirective) { return getPods(cmd, s, completeDefault) },
 	}
 	return completeKeyValues(toComplete, kv)
 }
@@ -1032,6 +1164,15 @@ func AutocompleteNetworkFilters(cmd *cobra.Command, args []string, toComplete st
 	return completeKeyValues(toComplete, kv)
 }
 
+// AutocompleteNetworkPruneFilters - Autocomplete network prune --filter options.
+func AutocompleteNetworkPruneFilters(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	kv := keyValueCompletion{
+		"until=": nil,
+		"label=": nil,
+	}
+	return completeKeyValues(toComplete, kv)
+}
+
 // AutocompleteVolumeFilters - Autocomplete volume ls --filter options.
 func AutocompleteVolumeFilters(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	local := func(_ string) ([]string, cobra.ShellCompDirective) {
@@ -230,6 +230,14 @@ func DefineCreateFlags(cmd *cobra.Command, cf *ContainerCLIOpts) {
 		"This is a Docker specific option and is a NOOP",
 	)
 
+	if !registry.IsRemote() {
+		createFlags.BoolVar(
+			&cf.DryRun,
+			"dry-run", false,
+			"Validate the options and print the OCI spec of the container without creating it",
+		)
+	}
+
 	entrypointFlagName := "entrypoint"
 	createFlags.String(entrypointFlagName, "",
 		"Overwrite the default ENTRYPOINT of the image",
@@ -456,6 +464,16 @@ func DefineCreateFlags(cmd *cobra.Command, cf *ContainerCLIOpts) {
 	)
 	_ = cmd.RegisterFlagCompletionFunc(nameFlagName, completion.AutocompleteNone)
 
+	createFlags.BoolVar(
+		&cf.NoDefaultDevices,
+		"no-default-devices", false,
+		"Do not add the default devices from containers.conf to the container",
+	)
+	createFlags.BoolVar(
+		&cf.NoDefaultMounts,
+		"no-default-mounts", false,
+		"Do not mount the default mounts from mounts.conf into the container",
+	)
 	createFlags.BoolVar(
 		&cf.NoHealthCheck,
 		"no-healthcheck", false,
@@ -475,6 +493,14 @@ func DefineCreateFlags(cmd *cobra.Command, cf *ContainerCLIOpts) {
 	)
 	_ = cmd.RegisterFlagCompletionFunc(oomScoreAdjFlagName, completion.AutocompleteNone)
 
+	archFlagName := "arch"
+	createFlags.StringVar(
+		&cf.OverrideArch,
+		archFlagName, "",
+		"use `ARCH` instead of the architecture of the machine for choosing images",
+	)
+	_ = cmd.RegisterFlagCompletionFunc(archFlagName, completion.AutocompleteNone)
+
 	overrideArchFlagName := "override-arch"
 	createFlags.StringVar(
 		&cf.OverrideArch,
@@ -483,6 +509,14 @@ func DefineCreateFlags(cmd *cobra.Command, cf *ContainerCLIOpts) {
 	)
 	_ = cmd.RegisterFlagCompletionFunc(overrideArchFlagName, completion.AutocompleteNone)
 
+	osFlagName := "os"
+	createFlags.StringVar(
+		&cf.OverrideOS,
+		osFlagName, "",
+		"use `OS` instead of the running OS for choosing images",
+	)
+	_ = cmd.RegisterFlagCompletionFunc(osFlagName, completion.AutocompleteNone)
+
 	overrideOSFlagName := "override-os"
 	createFlags.StringVar(
 		&cf.OverrideOS,
@@ -491,6 +525,14 @@ func DefineCreateFlags(cmd *cobra.Command, cf *ContainerCLIOpts) {
 	)
 	_ = cmd.RegisterFlagCompletionFunc(overrideOSFlagName, completion.AutocompleteNone)
 
+	variantFlagName := "variant"
+	createFlags.StringVar(
+		&cf.OverrideVariant,
+		variantFlagName, "",
+		"Use `VARIANT` instead of the running architecture variant for choosing images",
+	)
+	_ = cmd.RegisterFlagCompletionFunc(variantFlagName, completion.AutocompleteNone)
+
 	overrideVariantFlagName := "override-variant"
 	createFlags.StringVar(
 		&cf.OverrideVariant,
@@ -506,6 +548,14 @@ func DefineCreateFlags(cmd *cobra.Command, cf *ContainerCLIOpts) {
 	)
 	_ = cmd.RegisterFlagCompletionFunc(pidFlagName, AutocompleteNamespace)
 
+	pidfileFlagName := "pidfile"
+	createFlags.StringVar(
+		&cf.PIDFile,
+		pidfileFlagName, "",
+		"Write the container process ID to the file",
+	)
+	_ = cmd.RegisterFlagCompletionFunc(pidfileFlagName, completion.AutocompleteDefault)
+
 	pidsLimitFlagName := "pids-limit"
 	createFlags.Int64(
 		pidsLimitFlagName, pidsLimit(),
@@ -517,7 +567,7 @@ func DefineCreateFlags(cmd *cobra.Command, cf *ContainerCLIOpts) {
 	createFlags.StringVar(
 		&cf.Platform,
 		platformFlagName, "",
-		"Specify the platform for selecting the image.  (Conflicts with override-arch and override-os)",
+		"Specify the `OS/ARCH[/VARIANT]` platform for selecting the image.  (Conflicts with arch, os and variant)",
 	)
 	_ = cmd.RegisterFlagCompletionFunc(platformFlagName, completion.AutocompleteNone)
 
@@ -537,6 +587,14 @@ func DefineCreateFlags(cmd *cobra.Command, cf *ContainerCLIOpts) {
 	)
 	_ = cmd.RegisterFlagCompletionFunc(podIDFileFlagName, completion.AutocompleteDefault)
 
+	presetFlagName := "preset"
+	createFlags.StringArrayVar(
+		&cf.Presets,
+		presetFlagName, []string{},
+		"Apply the flags of a preset of containers.conf.d, can be given multiple times",
+	)
+	_ = cmd.RegisterFlagCompletionFunc(presetFlagName, AutocompletePresets)
+
 	createFlags.BoolVar(
 		&cf.Privileged,
 		"privileged", false,
@@ -552,7 +610,7 @@ func DefineCreateFlags(cmd *cobra.Command, cf *ContainerCLIOpts) {
 	createFlags.StringVar(
 		&cf.Pull,
 		pullFlagName, policy(),
-		`Pull image before creating ("always"|"missing"|"never")`,
+		`Pull image before creating ("always"|"missing"|"never"|"newer")`,
 	)
 	_ = cmd.RegisterFlagCompletionFunc(pullFlagName, AutocompletePullOption)
 
@@ -577,6 +635,30 @@ func DefineCreateFlags(cmd *cobra.Command, cf *ContainerCLIOpts) {
 		`If a container with the same name exists, replace it`,
 	)
 
+	requiresFlagName := "requires"
+	createFlags.StringSliceVar(
+		&cf.Requires,
+		requiresFlagName, []string{},
+		"Add one or more requirement containers that must be started before this container will start",
+	)
+	_ = cmd.RegisterFlagCompletionFunc(requiresFlagName, AutocompleteContainers)
+
+	requiresHealthyFlagName := "requires-healthy"
+	createFlags.StringSliceVar(
+		&cf.RequiresHealthy,
+		requiresHealthyFlagName, []string{},
+		"Add one or more requirement containers that must report healthy before this container will start",
+	)
+	_ = cmd.RegisterFlagCompletionFunc(requiresHealthyFlagName, AutocompleteContainers)
+
+	requiresHealthyTimeoutFlagName := "requires-healthy-timeout"
+	createFlags.UintVar(
+		&cf.RequiresTimeout,
+		requiresHealthyTimeoutFlagName, 60,
+		"Seconds to wait for the --requires-healthy containers to become healthy (0 waits indefinitely)",
+	)
+	_ = cmd.RegisterFlagCompletionFunc(requiresHealthyTimeoutFlagName, completion.AutocompleteNone)
+
 	restartFlagName := "restart"
 	createFlags.StringVar(
 		&cf.Restart,
@@ -604,6 +686,14 @@ func DefineCreateFlags(cmd *cobra.Command, cf *ContainerCLIOpts) {
 	)
 	_ = cmd.RegisterFlagCompletionFunc(sdnotifyFlagName, AutocompleteSDNotify)
 
+	secretFlagName := "secret"
+	createFlags.StringArrayVar(
+		&cf.Secrets,
+		secretFlagName, []string{},
+		"Add secret to container",
+	)
+	_ = cmd.RegisterFlagCompletionFunc(secretFlagName, AutocompleteSecrets)
+
 	securityOptFlagName := "security-opt"
 	createFlags.StringArrayVar(
 		&cf.SecurityOpt,
@@ -619,6 +709,14 @@ func DefineCreateFlags(cmd *cobra.Command, cf *ContainerCLIOpts) {
 	)
 	_ = cmd.RegisterFlagCompletionFunc(shmSizeFlagName, completion.AutocompleteNone)
 
+	shmSizeSystemdFlagName := "shm-size-systemd"
+	createFlags.StringVar(
+		&cf.ShmSizeSystemd,
+		shmSizeSystemdFlagName, "",
+		"Size of the systemd-specific tmpfs mounts (/run, /run/lock, /tmp, /var/log/journal) "+sizeWithUnitFormat,
+	)
+	_ = cmd.RegisterFlagCompletionFunc(shmSizeSystemdFlagName, completion.AutocompleteNone)
+
 	stopSignalFlagName := "stop-signal"
 	createFlags.StringVar(
 		&cf.SignaturePolicy,
@@ -632,6 +730,14 @@ func DefineCreateFlags(cmd *cobra.Command, cf *ContainerCLIOpts) {
 	)
 	_ = cmd.RegisterFlagCompletionFunc(stopSignalFlagName, AutocompleteStopSignal)
 
+	stopSignalsFlagName := "stop-signals"
+	createFlags.StringSliceVar(
+		&cf.StopSignals,
+		stopSignalsFlagName, []string{},
+		"Signal escalation chain to stop a container, as `SIGNAL:SECONDS` steps",
+	)
+	_ = cmd.RegisterFlagCompletionFunc(stopSignalsFlagName, completion.AutocompleteNone)
+
 	stopTimeoutFlagName := "stop-timeout"
 	createFlags.UintVar(
 		&cf.StopTimeout,
@@ -690,6 +796,14 @@ func DefineCreateFlags(cmd *cobra.Command, cf *ContainerCLIOpts) {
 	)
 	_ = cmd.RegisterFlagCompletionFunc(tmpfsFlagName, completion.AutocompleteDefault)
 
+	timeoutFlagName := "timeout"
+	createFlags.UintVar(
+		&cf.Timeout,
+		timeoutFlagName, 0,
+		"Maximum time (in seconds) a container may run before it is killed",
+	)
+	_ = cmd.RegisterFlagCompletionFunc(timeoutFlagName, completion.AutocompleteNone)
+
 	createFlags.BoolVarP(
 		&cf.TTY,
 		"tty", "t", false,
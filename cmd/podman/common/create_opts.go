@@ -41,6 +41,7 @@ type ContainerCLIOpts struct {
 	DeviceReadIOPs    []string
 	DeviceWriteBPs    []string
 	DeviceWriteIOPs   []string
+	DryRun            bool
 	Entrypoint        *string
 	Env               []string
 	EnvHost           bool
@@ -57,6 +58,7 @@ type ContainerCLIOpts struct {
 	HTTPProxy         bool
 	ImageVolume       string
 	Init              bool
+	InitContainerType string
 	InitPath          string
 	Interactive       bool
 	IPC               string
@@ -70,6 +72,8 @@ type ContainerCLIOpts struct {
 	MemorySwap        string
 	MemorySwappiness  int64
 	Name              string
+	NoDefaultDevices  bool
+	NoDefaultMounts   bool
 	NoHealthCheck     bool
 	OOMKillDisable    bool
 	OOMScoreAdj       int
@@ -77,10 +81,12 @@ type ContainerCLIOpts struct {
 	OverrideOS        string
 	OverrideVariant   string
 	PID               string
+	PIDFile           string
 	PIDsLimit         *int64
 	Platform          string
 	Pod               string
 	PodIDFile         string
+	Presets           []string
 	PreserveFDs       uint
 	Privileged        bool
 	PublishAll        bool
@@ -90,19 +96,26 @@ type ContainerCLIOpts struct {
 	ReadOnlyTmpFS     bool
 	Restart           string
 	Replace           bool
+	Requires          []string
+	RequiresHealthy   []string
+	RequiresTimeout   uint
 	Rm                bool
 	RootFS            bool
+	Secrets           []string
 	SecurityOpt       []string
 	SdNotifyMode      string
 	ShmSize           string
+	ShmSizeSystemd    string
 	SignaturePolicy   string
 	StopSignal        string
+	StopSignals       []string
 	StopTimeout       uint
 	StorageOpt        []string
 	SubUIDName        string
 	SubGIDName        string
 	Sysctl            []string
 	Systemd           string
+	Timeout           uint
 	TmpFS             []string
 	TTY               bool
 	Timezone          string
@@ -289,6 +302,11 @@ func ContainerCreateToContainerCLIOpts(cc handlers.CreateContainerConfig, cgroup
 					staticIP := net.ParseIP(ep.IPAddress)
 					netInfo.StaticIP = &staticIP
 				}
+				// if IPv6 address is provided
+				if ep.IPAMConfig != nil && len(ep.IPAMConfig.IPv6Address) > 0 {
+					staticIPv6 := net.ParseIP(ep.IPAMConfig.IPv6Address)
+					netInfo.StaticIPv6 = &staticIPv6
+				}
 				// If MAC address is provided
 				if len(ep.MacAddress) > 0 {
 					staticMac, err := net.ParseMAC(ep.MacAddress)
@@ -299,6 +317,39 @@ func ContainerCreateToContainerCLIOpts(cc handlers.CreateContainerConfig, cgroup
 				}
 				break
 			}
+		} else {
+			for netName, ep := range endpointsConfig {
+				if ep == nil {
+					continue
+				}
+				ip := ep.IPAddress
+				if ip == "" && ep.IPAMConfig != nil {
+					ip = ep.IPAMConfig.IPv4Address
+					if ip == "" {
+						ip = ep.IPAMConfig.IPv6Address
+					}
+				}
+				if len(ip) > 0 {
+					staticIP := net.ParseIP(ip)
+					if staticIP == nil {
+						return nil, nil, fmt.Errorf("invalid IP address %q for network %s", ip, netName)
+					}
+					if netInfo.StaticIPs == nil {
+						netInfo.StaticIPs = make(map[string]net.IP)
+					}
+					netInfo.StaticIPs[netName] = staticIP
+				}
+				if len(ep.MacAddress) > 0 {
+					staticMac, err := net.ParseMAC(ep.MacAddress)
+					if err != nil {
+						return nil, nil, err
+					}
+					if netInfo.StaticMACs == nil {
+						netInfo.StaticMACs = make(map[string]net.HardwareAddr)
+					}
+					netInfo.StaticMACs[netName] = staticMac
+				}
+			}
 		}
 		netInfo.Aliases = aliases
 		netInfo.CNINetworks = cniNetworks
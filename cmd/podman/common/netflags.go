@@ -51,6 +51,13 @@ func DefineNetFlags(cmd *cobra.Command) {
 	)
 	_ = cmd.RegisterFlagCompletionFunc(ipFlagName, completion.AutocompleteNone)
 
+	ip6FlagName := "ip6"
+	netFlags.String(
+		ip6FlagName, "",
+		"Specify a static IPv6 address for the container",
+	)
+	_ = cmd.RegisterFlagCompletionFunc(ip6FlagName, completion.AutocompleteNone)
+
 	macAddressFlagName := "mac-address"
 	netFlags.String(
 		macAddressFlagName, "",
@@ -83,6 +90,13 @@ func DefineNetFlags(cmd *cobra.Command) {
 		"no-hosts", false,
 		"Do not create /etc/hosts within the container, instead use the version from the image",
 	)
+
+	hostsFileFlagName := "hosts-file"
+	netFlags.String(
+		hostsFileFlagName, "",
+		`Base file to create the /etc/hosts file inside the container, or one of the special values. ("image"|"none")`,
+	)
+	_ = cmd.RegisterFlagCompletionFunc(hostsFileFlagName, AutocompleteHostsFile)
 }
 
 func NetFlagsToNetOptions(cmd *cobra.Command) (*entities.NetOptions, error) {
@@ -167,7 +181,7 @@ func NetFlagsToNetOptions(cmd *cobra.Command) (*entities.NetOptions, error) {
 		return nil, err
 	}
 	if len(inputPorts) > 0 {
-		opts.PublishPorts, err = createPortBindings(inputPorts)
+		opts.PublishPorts, err = CreatePortBindings(inputPorts)
 		if err != nil {
 			return nil, err
 		}
@@ -188,11 +202,37 @@ func NetFlagsToNetOptions(cmd *cobra.Command) (*entities.NetOptions, error) {
 		opts.StaticIP = &staticIP
 	}
 
+	ip6, err := cmd.Flags().GetString("ip6")
+	if err != nil {
+		return nil, err
+	}
+	if ip6 != "" {
+		staticIPv6 := net.ParseIP(ip6)
+		if staticIPv6 == nil {
+			return nil, errors.Errorf("%s is not an ip address", ip6)
+		}
+		if staticIPv6.To4() != nil {
+			return nil, errors.Wrapf(define.ErrInvalidArg, "%s is not an IPv6 address", ip6)
+		}
+		if opts.StaticIP != nil {
+			return nil, errors.Errorf("--ip and --ip6 cannot be used together")
+		}
+		opts.StaticIPv6 = &staticIPv6
+	}
+
 	opts.NoHosts, err = cmd.Flags().GetBool("no-hosts")
 	if err != nil {
 		return nil, err
 	}
 
+	opts.HostsFile, err = cmd.Flags().GetString("hosts-file")
+	if err != nil {
+		return nil, err
+	}
+	if opts.NoHosts && opts.HostsFile != "" {
+		return nil, errors.Errorf("--no-hosts and --hosts-file cannot be set together")
+	}
+
 	if cmd.Flags().Changed("network") {
 		network, err := cmd.Flags().GetString("network")
 		if err != nil {
@@ -206,10 +246,17 @@ func NetFlagsToNetOptions(cmd *cobra.Command) (*entities.NetOptions, error) {
 			return nil, err
 		}
 
-		if len(parts) > 1 {
+		switch {
+		case len(cniNets) > 0:
+			// A list of CNI networks, which can be followed by
+			// their static addresses.
+			cniNets, opts.StaticIPs, opts.StaticMACs, err = specgen.ParseCNINetworks(network)
+			if err != nil {
+				return nil, err
+			}
+		case len(parts) > 1:
 			opts.NetworkOptions = make(map[string][]string)
 			opts.NetworkOptions[parts[0]] = strings.Split(parts[1], ",")
-			cniNets = nil
 		}
 		opts.Network = ns
 		opts.CNINetworks = cniNets
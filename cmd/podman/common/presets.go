@@ -0,0 +1,88 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/containers/podman/v2/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+)
+
+// ApplyPresets sets the flags bundled in the given presets of
+// containers.conf.d.  Flags set on the command line override the presets, and
+// later presets override earlier ones.
+func ApplyPresets(flags *pflag.FlagSet, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	extra, err := util.LoadExtraConfig()
+	if err != nil {
+		return err
+	}
+	return applyPresets(flags, extra.Presets, names)
+}
+
+func applyPresets(flags *pflag.FlagSet, presets map[string]util.ContainerPreset, names []string) error {
+	merged := make(map[string]interface{})
+	for _, name := range names {
+		preset, ok := presets[name]
+		if !ok {
+			return errors.Errorf("no such preset %q", name)
+		}
+		for flagName, value := range preset {
+			merged[flagName] = value
+		}
+	}
+
+	flagNames := make([]string, 0, len(merged))
+	for flagName := range merged {
+		flagNames = append(flagNames, flagName)
+	}
+	sort.Strings(flagNames)
+	for _, flagName := range flagNames {
+		flag := flags.Lookup(flagName)
+		if flag == nil || flagName == "preset" {
+			return errors.Errorf("invalid option %q in presets: not a flag of this command", flagName)
+		}
+		if flag.Changed {
+			continue
+		}
+		values, err := presetValues(merged[flagName])
+		if err != nil {
+			return errors.Wrapf(err, "invalid option %q in presets", flagName)
+		}
+		valueType := flag.Value.Type()
+		if len(values) != 1 && !strings.HasSuffix(valueType, "Slice") && !strings.HasSuffix(valueType, "Array") {
+			return errors.Errorf("invalid option %q in presets: takes a single value", flagName)
+		}
+		for _, v := range values {
+			if err := flags.Set(flagName, v); err != nil {
+				return errors.Wrapf(err, "invalid option %q in presets", flagName)
+			}
+		}
+	}
+	return nil
+}
+
+// presetValues converts the value of a preset option to the values of the
+// flag, one per element of arrays.
+func presetValues(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case string, bool, int64, float64:
+		return []string{fmt.Sprint(v)}, nil
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, elem := range v {
+			switch elem.(type) {
+			case string, bool, int64, float64:
+				values = append(values, fmt.Sprint(elem))
+			default:
+				return nil, errors.Errorf("unsupported array element %v", elem)
+			}
+		}
+		return values, nil
+	}
+	return nil, errors.Errorf("unsupported value %v", value)
+}
@@ -0,0 +1,72 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/containers/podman/v2/pkg/util"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func presetTestFlags() *pflag.FlagSet {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Bool("read-only", false, "")
+	flags.String("network", "", "")
+	flags.StringArray("env", []string{}, "")
+	flags.StringSlice("cap-drop", []string{}, "")
+	flags.Int("memory-swappiness", -1, "")
+	flags.StringArray("preset", []string{}, "")
+	return flags
+}
+
+var testPresets = map[string]util.ContainerPreset{
+	"hardened": {
+		"read-only":         true,
+		"cap-drop":          []interface{}{"all"},
+		"memory-swappiness": int64(0),
+	},
+	"web": {
+		"network": "frontend",
+		"env":     []interface{}{"A=1", "B=2"},
+	},
+	"backend": {
+		"network": "backend",
+	},
+}
+
+func TestApplyPresets(t *testing.T) {
+	flags := presetTestFlags()
+	require.NoError(t, flags.Parse([]string{"--env", "C=3"}))
+	require.NoError(t, applyPresets(flags, testPresets, []string{"hardened", "web", "backend"}))
+
+	readOnly, _ := flags.GetBool("read-only")
+	assert.True(t, readOnly)
+	capDrop, _ := flags.GetStringSlice("cap-drop")
+	assert.Equal(t, []string{"all"}, capDrop)
+	swappiness, _ := flags.GetInt("memory-swappiness")
+	assert.Equal(t, 0, swappiness)
+	// later presets override earlier ones
+	network, _ := flags.GetString("network")
+	assert.Equal(t, "backend", network)
+	// flags on the command line override presets
+	env, _ := flags.GetStringArray("env")
+	assert.Equal(t, []string{"C=3"}, env)
+}
+
+func TestApplyPresetsInvalid(t *testing.T) {
+	for _, preset := range []util.ContainerPreset{
+		{"no-such-flag": true},
+		{"preset": "web"},
+		{"network": []interface{}{"a", "b"}},
+		{"read-only": "maybe"},
+		{"network": map[string]interface{}{"a": "b"}},
+	} {
+		flags := presetTestFlags()
+		err := applyPresets(flags, map[string]util.ContainerPreset{"bad": preset}, []string{"bad"})
+		assert.Error(t, err, preset)
+	}
+
+	err := applyPresets(presetTestFlags(), testPresets, []string{"unknown"})
+	assert.Error(t, err)
+}
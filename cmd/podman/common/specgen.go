@@ -3,6 +3,8 @@ package common
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
 	"time"
@@ -223,6 +225,18 @@ func setNamespaces(s *specgen.SpecGenerator, c *ContainerCLIOpts) error {
 	return nil
 }
 
+// podmanCNINetwork is the name of the default network created by podman.
+const podmanCNINetwork = "podman"
+
+// joinsDefaultCNINetwork returns whether the container of s joins the default
+// CNI network if no networks are set.
+func joinsDefaultCNINetwork(s *specgen.SpecGenerator) bool {
+	if s.NetNS.IsDefault() {
+		return containerConfig.NetNS() == string(specgen.Bridge)
+	}
+	return s.NetNS.NSMode == specgen.Bridge
+}
+
 func FillOutSpecGen(s *specgen.SpecGenerator, c *ContainerCLIOpts, args []string) error {
 	var (
 		err error
@@ -233,6 +247,10 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *ContainerCLIOpts, args []string
 		return err
 	}
 
+	if c.OverrideOS != "" || c.OverrideArch != "" || c.OverrideVariant != "" {
+		s.ImagePlatform = imagePlatform(c.OverrideOS, c.OverrideArch, c.OverrideVariant)
+	}
+
 	s.User = c.User
 	inputCommand := args[1:]
 	if len(c.HealthCmd) > 0 {
@@ -301,6 +319,14 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *ContainerCLIOpts, args []string
 		s.StopSignal = &stopSignal
 	}
 
+	if len(c.StopSignals) > 0 {
+		stopSignals, err := util.ParseStopSignals(c.StopSignals)
+		if err != nil {
+			return err
+		}
+		s.StopSignals = stopSignals
+	}
+
 	// ENVIRONMENT VARIABLES
 	//
 	// Precedence order (higher index wins):
@@ -395,10 +421,29 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *ContainerCLIOpts, args []string
 		}
 		s.ShmSize = &shmSize
 	}
+	if c.ShmSizeSystemd != "" {
+		shmSizeSystemd, err := units.FromHumanSize(c.ShmSizeSystemd)
+		if err != nil {
+			return errors.Wrapf(err, "unable to translate --shm-size-systemd")
+		}
+		s.ShmSizeSystemd = &shmSizeSystemd
+	}
 	s.CNINetworks = c.Net.CNINetworks
 
 	// Network aliases
 	if len(c.Net.Aliases) > 0 {
+		// Containers without networks join the default network of
+		// containers.conf.  The aliases are set in it unless it is the
+		// network created by podman, which does not resolve container
+		// names.
+		if len(s.CNINetworks) == 0 && joinsDefaultCNINetwork(s) {
+			if def := containerConfig.Network.DefaultNetwork; def != "" && def != podmanCNINetwork {
+				s.CNINetworks = []string{def}
+			}
+		}
+		if len(s.CNINetworks) == 0 {
+			return errors.New("network-scoped aliases are only supported for containers in user-defined networks")
+		}
 		// build a map of aliases where key=cniName
 		aliases := make(map[string][]string, len(s.CNINetworks))
 		for _, cniNetwork := range s.CNINetworks {
@@ -413,9 +458,13 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *ContainerCLIOpts, args []string
 	s.DNSSearch = c.Net.DNSSearch
 	s.DNSOptions = c.Net.DNSOptions
 	s.StaticIP = c.Net.StaticIP
+	s.StaticIPv6 = c.Net.StaticIPv6
 	s.StaticMAC = c.Net.StaticMAC
+	s.StaticIPs = c.Net.StaticIPs
+	s.StaticMACs = c.Net.StaticMACs
 	s.NetworkOptions = c.Net.NetworkOptions
 	s.UseImageHosts = c.Net.NoHosts
+	s.BaseHostsFile = c.Net.HostsFile
 
 	s.ImageVolumeMode = c.ImageVolume
 	if s.ImageVolumeMode == "bind" {
@@ -424,6 +473,10 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *ContainerCLIOpts, args []string
 
 	s.Systemd = c.Systemd
 	s.SdNotifyMode = c.SdNotifyMode
+	s.InitContainerType = c.InitContainerType
+	s.DependencyContainers = c.Requires
+	s.HealthyDependencyContainers = c.RequiresHealthy
+	s.HealthyDependencyTimeout = c.RequiresTimeout
 	if s.ResourceLimits == nil {
 		s.ResourceLimits = &specs.LinuxResources{}
 	}
@@ -486,6 +539,15 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *ContainerCLIOpts, args []string
 	s.Privileged = c.Privileged
 	s.ReadOnlyFilesystem = c.ReadOnly
 	s.ConmonPidFile = c.ConmonPIDFile
+	if c.PIDFile != "" {
+		// The PID file is written by the OCI runtime, which does not
+		// run in the working directory of the user.
+		pidFile, err := filepath.Abs(c.PIDFile)
+		if err != nil {
+			return errors.Wrapf(err, "error resolving path of PID file %q", c.PIDFile)
+		}
+		s.PidFile = pidFile
+	}
 
 	// TODO
 	// outside of specgen and oci though
@@ -561,9 +623,18 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *ContainerCLIOpts, args []string
 	s.OverlayVolumes = overlayVolumes
 	s.ImageVolumes = imageVolumes
 
+	if len(c.Secrets) > 0 {
+		s.Secrets, s.EnvSecrets, err = parseSecrets(c.Secrets)
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, dev := range c.Devices {
 		s.Devices = append(s.Devices, specs.LinuxDevice{Path: dev})
 	}
+	s.NoDefaultDevices = c.NoDefaultDevices
+	s.NoDefaultMounts = c.NoDefaultMounts
 
 	s.Init = c.Init
 	s.InitPath = c.InitPath
@@ -641,6 +712,7 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *ContainerCLIOpts, args []string
 	s.Remove = c.Rm
 	s.StopTimeout = &c.StopTimeout
 	s.Timezone = c.Timezone
+	s.Timeout = c.Timeout
 	s.Umask = c.Umask
 
 	return nil
@@ -728,6 +800,63 @@ func parseWeightDevices(s *specgen.SpecGenerator, weightDevs []string) error {
 	return nil
 }
 
+// imagePlatform returns the OS/ARCH[/VARIANT] platform selected with
+// --platform, --os, --arch and --variant, defaulting to the host.
+func imagePlatform(imgOS, arch, variant string) string {
+	if imgOS == "" {
+		imgOS = runtime.GOOS
+	}
+	if arch == "" {
+		arch = runtime.GOARCH
+	}
+	platform := imgOS + "/" + arch
+	if variant != "" {
+		platform += "/" + variant
+	}
+	return platform
+}
+
+// parseSecrets parses the --secret options, which have the format
+// SECRET[,type=mount|env][,target=TARGET], into the secrets mounted into the
+// container and the secrets exposed as environment variables.
+func parseSecrets(secrets []string) ([]specgen.Secret, map[string]string, error) {
+	var mountSecrets []specgen.Secret
+	envSecrets := make(map[string]string)
+	for _, val := range secrets {
+		split := strings.Split(val, ",")
+		if split[0] == "" || strings.Contains(split[0], "=") {
+			return nil, nil, errors.Errorf("invalid secret %q: the first option must be the name or ID of the secret", val)
+		}
+		secretType, target := "mount", ""
+		for _, opt := range split[1:] {
+			kv := strings.SplitN(opt, "=", 2)
+			if len(kv) != 2 {
+				return nil, nil, errors.Errorf("invalid secret option %q: must be in the form of option=value", opt)
+			}
+			switch kv[0] {
+			case "type":
+				if kv[1] != "mount" && kv[1] != "env" {
+					return nil, nil, errors.Errorf("invalid secret type %q: must be mount or env", kv[1])
+				}
+				secretType = kv[1]
+			case "target":
+				target = kv[1]
+			default:
+				return nil, nil, errors.Errorf("unknown secret option %q", kv[0])
+			}
+		}
+		if secretType == "env" {
+			if target == "" {
+				target = split[0]
+			}
+			envSecrets[target] = split[0]
+			continue
+		}
+		mountSecrets = append(mountSecrets, specgen.Secret{Source: split[0], Target: target})
+	}
+	return mountSecrets, envSecrets, nil
+}
+
 func parseThrottleBPSDevices(bpsDevices []string) (map[string]specs.LinuxThrottleDevice, error) {
 	td := make(map[string]specs.LinuxThrottleDevice)
 	for _, val := range bpsDevices {
@@ -32,7 +32,8 @@ var (
 		ValidArgsFunction: common.AutocompleteContainersRunning,
 		Example: `podman container checkpoint --keep ctrID
   podman container checkpoint --all
-  podman container checkpoint --leave-running --latest`,
+  podman container checkpoint --leave-running --latest
+  podman container checkpoint --create-image quay.io/example/checkpoint ctrID`,
 	}
 )
 
@@ -52,11 +53,16 @@ func init() {
 	flags.BoolVar(&checkpointOptions.TCPEstablished, "tcp-established", false, "Checkpoint a container with established TCP connections")
 	flags.BoolVarP(&checkpointOptions.All, "all", "a", false, "Checkpoint all running containers")
 
+	createImageFlagName := "create-image"
+	flags.StringVar(&checkpointOptions.CreateImage, createImageFlagName, "", "Create an image holding the checkpoint, which can be pushed to a registry and restored from")
+	_ = checkpointCommand.RegisterFlagCompletionFunc(createImageFlagName, completion.AutocompleteNone)
+
 	exportFlagName := "export"
 	flags.StringVarP(&checkpointOptions.Export, exportFlagName, "e", "", "Export the checkpoint image to a tar.gz")
 	_ = checkpointCommand.RegisterFlagCompletionFunc(exportFlagName, completion.AutocompleteDefault)
 
 	flags.BoolVar(&checkpointOptions.IgnoreRootFS, "ignore-rootfs", false, "Do not include root file-system changes when exporting")
+	flags.BoolVar(&checkpointOptions.IgnoreVolumes, "ignore-volumes", false, "Do not export volumes associated with container")
 	validate.AddLatestFlag(checkpointCommand, &checkpointOptions.Latest)
 }
 
@@ -65,8 +71,15 @@ func checkpoint(cmd *cobra.Command, args []string) error {
 	if rootless.IsRootless() {
 		return errors.New("checkpointing a container requires root")
 	}
-	if checkpointOptions.Export == "" && checkpointOptions.IgnoreRootFS {
-		return errors.Errorf("--ignore-rootfs can only be used with --export")
+	exporting := checkpointOptions.Export != "" || checkpointOptions.CreateImage != ""
+	if !exporting && checkpointOptions.IgnoreRootFS {
+		return errors.Errorf("--ignore-rootfs can only be used with --export or --create-image")
+	}
+	if !exporting && checkpointOptions.IgnoreVolumes {
+		return errors.Errorf("--ignore-volumes can only be used with --export or --create-image")
+	}
+	if checkpointOptions.CreateImage != "" && checkpointOptions.All {
+		return errors.Errorf("--create-image cannot be used with --all")
 	}
 	responses, err := registry.ContainerEngine().ContainerCheckpoint(context.Background(), args, checkpointOptions)
 	if err != nil {
@@ -26,12 +26,19 @@ var (
 		Long:  cleanupDescription,
 		RunE:  cleanup,
 		Args: func(cmd *cobra.Command, args []string) error {
+			if cleanupOptions.Watch {
+				if len(args) > 0 || cleanupOptions.All || cleanupOptions.Latest {
+					return errors.New("--watch cannot be used with containers, --all or --latest")
+				}
+				return nil
+			}
 			return validate.CheckAllLatestAndCIDFile(cmd, args, false, false)
 		},
 		ValidArgsFunction: common.AutocompleteContainersExited,
 		Example: `podman container cleanup --latest
   podman container cleanup ctrID1 ctrID2 ctrID3
-  podman container cleanup --all`,
+  podman container cleanup --all
+  podman container cleanup --watch`,
 	}
 )
 
@@ -54,6 +61,7 @@ func init() {
 
 	flags.BoolVar(&cleanupOptions.Remove, "rm", false, "After cleanup, remove the container entirely")
 	flags.BoolVar(&cleanupOptions.RemoveImage, "rmi", false, "After cleanup, remove the image entirely")
+	flags.BoolVar(&cleanupOptions.Watch, "watch", false, "Keep running and clean up containers as they exit, replacing their own cleanup processes")
 	validate.AddLatestFlag(cleanupCommand, &cleanupOptions.Latest)
 }
 
@@ -62,6 +70,10 @@ func cleanup(cmd *cobra.Command, args []string) error {
 		errs utils.OutputErrors
 	)
 
+	if cleanupOptions.Watch && (cleanupOptions.Exec != "" || cleanupOptions.Remove || cleanupOptions.RemoveImage) {
+		return errors.Errorf("watch option conflicts with exec, rm and rmi options")
+	}
+
 	if cleanupOptions.Exec != "" {
 		switch {
 		case cleanupOptions.All:
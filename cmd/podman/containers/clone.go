@@ -0,0 +1,95 @@
+package containers
+
+import (
+	"fmt"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v2/cmd/podman/common"
+	"github.com/containers/podman/v2/cmd/podman/registry"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/containers/podman/v2/pkg/util"
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cloneDescription = `Creates a new container from the configuration of an existing container.
+
+  The clone gets a new name, new storage and new anonymous volumes.  The name, the image and the resource limits of the clone can be changed.`
+
+	cloneCommand = &cobra.Command{
+		Use:               "clone [options] CONTAINER",
+		Short:             "Create a copy of an existing container",
+		Long:              cloneDescription,
+		RunE:              clone,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteContainers,
+		Example: `podman container clone ctrID
+  podman container clone --name ctr2 --memory 1g --run ctr1`,
+	}
+)
+
+var (
+	cloneOptions   entities.ContainerCloneOptions
+	cloneCPUs      float64
+	cloneMemoryCLI string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Mode:    []entities.EngineMode{entities.ABIMode, entities.TunnelMode},
+		Command: cloneCommand,
+		Parent:  containerCmd,
+	})
+	flags := cloneCommand.Flags()
+
+	nameFlagName := "name"
+	flags.StringVar(&cloneOptions.Name, nameFlagName, "", "Assign a name to the clone")
+	_ = cloneCommand.RegisterFlagCompletionFunc(nameFlagName, completion.AutocompleteNone)
+
+	imageFlagName := "image"
+	flags.StringVar(&cloneOptions.Image, imageFlagName, "", "Create the root filesystem of the clone from `IMAGE`")
+	_ = cloneCommand.RegisterFlagCompletionFunc(imageFlagName, common.AutocompleteImages)
+
+	flags.BoolVar(&cloneOptions.Run, "run", false, "Start the clone after creating it")
+
+	cpuSharesFlagName := "cpu-shares"
+	flags.Uint64Var(&cloneOptions.CPUShares, cpuSharesFlagName, 0, "CPU shares (relative weight)")
+	_ = cloneCommand.RegisterFlagCompletionFunc(cpuSharesFlagName, completion.AutocompleteNone)
+
+	cpusFlagName := "cpus"
+	flags.Float64Var(&cloneCPUs, cpusFlagName, 0, "Number of CPUs. The default is to keep the limit of the container")
+	_ = cloneCommand.RegisterFlagCompletionFunc(cpusFlagName, completion.AutocompleteNone)
+
+	cpusetCpusFlagName := "cpuset-cpus"
+	flags.StringVar(&cloneOptions.CPUSetCPUs, cpusetCpusFlagName, "", "CPUs in which to allow execution (0-3, 0,1)")
+	_ = cloneCommand.RegisterFlagCompletionFunc(cpusetCpusFlagName, completion.AutocompleteNone)
+
+	memoryFlagName := "memory"
+	flags.StringVarP(&cloneMemoryCLI, memoryFlagName, "m", "", "Memory limit (format: <number>[<unit>], where unit = b (bytes), k (kilobytes), m (megabytes), or g (gigabytes))")
+	_ = cloneCommand.RegisterFlagCompletionFunc(memoryFlagName, completion.AutocompleteNone)
+}
+
+func clone(cmd *cobra.Command, args []string) error {
+	if cloneCPUs < 0 {
+		return errors.Errorf("invalid value for --cpus: %f", cloneCPUs)
+	}
+	if cloneCPUs > 0 {
+		cloneOptions.CPUPeriod, cloneOptions.CPUQuota = util.CoresToPeriodAndQuota(cloneCPUs)
+	}
+	if cloneMemoryCLI != "" {
+		memory, err := units.RAMInBytes(cloneMemoryCLI)
+		if err != nil {
+			return errors.Wrapf(err, "invalid value for --memory")
+		}
+		cloneOptions.Memory = memory
+	}
+
+	report, err := registry.ContainerEngine().ContainerClone(registry.GetContext(), args[0], cloneOptions)
+	if err != nil {
+		return err
+	}
+	fmt.Println(report.Id)
+	return nil
+}
@@ -6,11 +6,13 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/containers/common/pkg/completion"
 	"github.com/containers/podman/v2/cmd/podman/common"
 	"github.com/containers/podman/v2/cmd/podman/registry"
 	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/containers/podman/v2/pkg/util"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -49,7 +51,8 @@ var (
 	commitOptions = entities.CommitOptions{
 		ImageName: "",
 	}
-	iidFile string
+	iidFile   string
+	timestamp int64
 )
 
 func commitFlags(cmd *cobra.Command) {
@@ -78,6 +81,10 @@ func commitFlags(cmd *cobra.Command) {
 	flags.BoolVarP(&commitOptions.Pause, "pause", "p", false, "Pause container during commit")
 	flags.BoolVarP(&commitOptions.Quiet, "quiet", "q", false, "Suppress output")
 	flags.BoolVar(&commitOptions.IncludeVolumes, "include-volumes", false, "Include container volumes as image volumes")
+
+	timestampFlagName := "timestamp"
+	flags.Int64Var(&timestamp, timestampFlagName, 0, "Set created timestamp to the specified epoch seconds to allow for reproducible images, defaults to $SOURCE_DATE_EPOCH or the current time")
+	_ = cmd.RegisterFlagCompletionFunc(timestampFlagName, completion.AutocompleteNone)
 }
 
 func init() {
@@ -103,6 +110,16 @@ func commit(cmd *cobra.Command, args []string) error {
 	if !commitOptions.Quiet {
 		commitOptions.Writer = os.Stderr
 	}
+	if cmd.Flags().Changed("timestamp") {
+		t := time.Unix(timestamp, 0).UTC()
+		commitOptions.Timestamp = &t
+	} else {
+		t, err := util.SourceDateEpoch()
+		if err != nil {
+			return err
+		}
+		commitOptions.Timestamp = t
+	}
 
 	response, err := registry.ContainerEngine().ContainerCommit(context.Background(), container, commitOptions)
 	if err != nil {
@@ -1,8 +1,10 @@
 package containers
 
 import (
+	"archive/tar"
+	"bufio"
+	"bytes"
 	"io"
-	"io/ioutil"
 	"os"
 	"os/user"
 	"path/filepath"
@@ -25,11 +27,11 @@ import (
 var (
 	cpDescription = `Copy the contents of SRC_PATH to the DEST_PATH.
 
-  You can copy from the container's file system to the local machine or the reverse, from the local filesystem to the container. If "-" is specified for either the SRC_PATH or DEST_PATH, you can also stream a tar archive from STDIN or to STDOUT. The CONTAINER can be a running or stopped container. The SRC_PATH or DEST_PATH can be a file or a directory.
+  You can copy from the container's file system to the local machine or the reverse, from the local filesystem to the container, or from one container to another. If "-" is specified for either the SRC_PATH or DEST_PATH, you can also stream a tar archive from STDIN or to STDOUT. The CONTAINER can be a running or stopped container. The SRC_PATH or DEST_PATH can be a file or a directory.
 `
 	cpCommand = &cobra.Command{
 		Use:               "cp [CONTAINER:]SRC_PATH [CONTAINER:]DEST_PATH",
-		Short:             "Copy files/folders between a container and the local filesystem",
+		Short:             "Copy files/folders between a container and the local filesystem or between two containers",
 		Long:              cpDescription,
 		Args:              cobra.ExactArgs(2),
 		RunE:              cp,
@@ -54,6 +56,9 @@ var (
 
 func cpFlags(cmd *cobra.Command) {
 	flags := cmd.Flags()
+	flags.BoolVarP(&cpOpts.Archive, "archive", "a", true, "Chown copied files to the primary uid/gid of the destination container.")
+
+	// Deprecated flags (both are NOPs): exist for backwards compat
 	flags.BoolVar(&cpOpts.Extract, "extract", false, "Deprecated...")
 	flags.BoolVar(&cpOpts.Pause, "pause", true, "Deprecated")
 	_ = flags.MarkHidden("extract")
@@ -82,6 +87,9 @@ func cp(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if len(sourceContainerStr) > 0 && len(destContainerStr) > 0 {
+		return copyContainerToContainer(sourceContainerStr, sourcePath, destContainerStr, destPath)
+	}
 	if len(sourceContainerStr) > 0 {
 		return copyFromContainer(sourceContainerStr, sourcePath, destPath)
 	}
@@ -102,16 +110,19 @@ func containerMustExist(container string) error {
 	return nil
 }
 
-// doCopy executes the two functions in parallel to copy data from A to B and
-// joins the errors if any.
-func doCopy(funcA func() error, funcB func() error) error {
-	errChan := make(chan error)
-	go func() {
-		errChan <- funcA()
-	}()
+// doCopy executes the functions in parallel to copy data from A to B (and
+// possibly further on to C) and joins the errors if any.
+func doCopy(funcs ...func() error) error {
+	errChan := make(chan error, len(funcs))
+	for _, f := range funcs {
+		go func(f func() error) {
+			errChan <- f()
+		}(f)
+	}
 	var copyErrors []error
-	copyErrors = append(copyErrors, funcB())
-	copyErrors = append(copyErrors, <-errChan)
+	for range funcs {
+		copyErrors = append(copyErrors, <-errChan)
+	}
 	return errorhandling.JoinErrors(copyErrors)
 }
 
@@ -233,80 +244,31 @@ func copyToContainer(container string, containerPath string, hostPath string) er
 		return errors.Wrapf(err, "%q could not be found on the host", hostPath)
 	}
 
-	// If the path on the container does not exist.  We need to make sure
-	// that it's parent directory exists.  The destination may be created
-	// while copying.
-	var containerBaseName string
-	containerInfo, containerInfoErr := registry.ContainerEngine().ContainerStat(registry.GetContext(), container, containerPath)
-	if containerInfoErr != nil {
-		if strings.HasSuffix(containerPath, "/") {
-			return errors.Wrapf(containerInfoErr, "%q could not be found on container %s", containerPath, container)
-		}
-		if isStdin {
-			return errors.New("destination must be a directory when copying from stdin")
-		}
-		// NOTE: containerInfo may actually be set.  That happens when
-		// the container path is a symlink into nirvana.  In that case,
-		// we must use the symlinked path instead.
-		path := containerPath
-		if containerInfo != nil {
-			containerBaseName = filepath.Base(containerInfo.LinkTarget)
-			path = containerInfo.LinkTarget
-		} else {
-			containerBaseName = filepath.Base(containerPath)
-		}
-
-		parentDir, err := containerParentDir(container, path)
-		if err != nil {
-			return errors.Wrapf(err, "could not determine parent dir of %q on container %s", path, container)
-		}
-		containerInfo, err = registry.ContainerEngine().ContainerStat(registry.GetContext(), container, parentDir)
-		if err != nil {
-			return errors.Wrapf(err, "%q could not be found on container %s", containerPath, container)
-		}
-	} else {
-		// If the specified path exists on the container, we must use
-		// its base path as it may have changed due to symlink
-		// evaluations.
-		containerBaseName = filepath.Base(containerInfo.LinkTarget)
+	containerBaseName, containerInfo, resolvedToParentDir, err := resolvePathOnDestinationContainer(container, containerPath, isStdin)
+	if err != nil {
+		return err
 	}
 
-	var stdinFile string
+	var stdin io.Reader
 	if isStdin {
 		if !containerInfo.IsDir {
 			return errors.New("destination must be a directory when copying from stdin")
 		}
 
-		// Copy from stdin to a temporary file *before* throwing it
+		// Make sure that stdin is a tar archive *before* throwing it
 		// over the wire.  This allows for proper client-side error
-		// reporting.
-		tmpFile, err := ioutil.TempFile("", "")
-		if err != nil {
-			return err
-		}
-		_, err = io.Copy(tmpFile, os.Stdin)
+		// reporting without buffering the archive in a temporary file.
+		stdin, err = checkArchive(os.Stdin)
 		if err != nil {
 			return err
 		}
-		if err = tmpFile.Close(); err != nil {
-			return err
-		}
-		if !archive.IsArchivePath(tmpFile.Name()) {
-			return errors.New("source must be a (compressed) tar archive when copying from stdin")
-		}
-		stdinFile = tmpFile.Name()
 	}
 
 	reader, writer := io.Pipe()
 	hostCopy := func() error {
 		defer writer.Close()
 		if isStdin {
-			stream, err := os.Open(stdinFile)
-			if err != nil {
-				return err
-			}
-			defer stream.Close()
-			_, err = io.Copy(writer, stream)
+			_, err := io.Copy(writer, stdin)
 			return err
 		}
 
@@ -314,7 +276,7 @@ func copyToContainer(container string, containerPath string, hostPath string) er
 			// Unless the specified path ends with ".", we want to copy the base directory.
 			KeepDirectoryNames: !strings.HasSuffix(hostPath, "."),
 		}
-		if !hostInfo.IsDir && (!containerInfo.IsDir || containerInfoErr != nil) {
+		if !hostInfo.IsDir && (!containerInfo.IsDir || resolvedToParentDir) {
 			// If we're having a file-to-file copy, make sure to
 			// rename accordingly.
 			getOptions.Rename = map[string]string{filepath.Base(hostInfo.LinkTarget): containerBaseName}
@@ -332,7 +294,7 @@ func copyToContainer(container string, containerPath string, hostPath string) er
 			target = filepath.Dir(target)
 		}
 
-		copyFunc, err := registry.ContainerEngine().ContainerCopyFromArchive(registry.GetContext(), container, target, reader)
+		copyFunc, err := registry.ContainerEngine().ContainerCopyFromArchive(registry.GetContext(), container, target, reader, entities.CopyOptions{Chown: cpOpts.Archive})
 		if err != nil {
 			return err
 		}
@@ -345,6 +307,180 @@ func copyToContainer(container string, containerPath string, hostPath string) er
 	return doCopy(hostCopy, containerCopy)
 }
 
+// copyContainerToContainer copies the sourcePath on the sourceContainer to
+// destPath on the destContainer.  The data is streamed through the client, so
+// the containers may be on the same or, in case of the remote client, a remote
+// host.
+func copyContainerToContainer(sourceContainer string, sourcePath string, destContainer string, destPath string) error {
+	if err := containerMustExist(sourceContainer); err != nil {
+		return err
+	}
+	if err := containerMustExist(destContainer); err != nil {
+		return err
+	}
+
+	sourceContainerInfo, err := registry.ContainerEngine().ContainerStat(registry.GetContext(), sourceContainer, sourcePath)
+	if err != nil {
+		return errors.Wrapf(err, "%q could not be found on container %s", sourcePath, sourceContainer)
+	}
+
+	destContainerBaseName, destContainerInfo, destResolvedToParentDir, err := resolvePathOnDestinationContainer(destContainer, destPath, false)
+	if err != nil {
+		return err
+	}
+
+	sourceReader, sourceWriter := io.Pipe()
+	destReader, destWriter := io.Pipe()
+
+	sourceContainerCopy := func() error {
+		defer sourceWriter.Close()
+		copyFunc, err := registry.ContainerEngine().ContainerCopyToArchive(registry.GetContext(), sourceContainer, sourceContainerInfo.LinkTarget, sourceWriter)
+		if err != nil {
+			return err
+		}
+		if err := copyFunc(); err != nil {
+			return errors.Wrap(err, "error copying from container")
+		}
+		return nil
+	}
+
+	renameCopy := func() error {
+		defer sourceReader.Close()
+		defer destWriter.Close()
+		rename := map[string]string{}
+		if !sourceContainerInfo.IsDir && (!destContainerInfo.IsDir || destResolvedToParentDir) {
+			// If we're having a file-to-file copy, make sure to
+			// rename accordingly.
+			rename[filepath.Base(sourceContainerInfo.LinkTarget)] = destContainerBaseName
+		}
+		return renameArchive(sourceReader, destWriter, rename)
+	}
+
+	destContainerCopy := func() error {
+		defer destReader.Close()
+		target := destContainerInfo.FileInfo.LinkTarget
+		if !destContainerInfo.IsDir {
+			target = filepath.Dir(target)
+		}
+
+		copyFunc, err := registry.ContainerEngine().ContainerCopyFromArchive(registry.GetContext(), destContainer, target, destReader, entities.CopyOptions{Chown: cpOpts.Archive})
+		if err != nil {
+			return err
+		}
+		if err := copyFunc(); err != nil {
+			return errors.Wrap(err, "error copying to container")
+		}
+		return nil
+	}
+
+	return doCopy(sourceContainerCopy, renameCopy, destContainerCopy)
+}
+
+// resolvePathOnDestinationContainer resolves the specified path on the
+// container.  If the path does not exist, its parent directory is resolved
+// instead as the path may be created while copying.  It returns the base name
+// of the destination, the file info of the path or its parent directory, and
+// whether the info refers to the parent directory.
+func resolvePathOnDestinationContainer(container string, containerPath string, isStdin bool) (string, *entities.ContainerStatReport, bool, error) {
+	containerInfo, containerInfoErr := registry.ContainerEngine().ContainerStat(registry.GetContext(), container, containerPath)
+	if containerInfoErr == nil {
+		// If the specified path exists on the container, we must use
+		// its base path as it may have changed due to symlink
+		// evaluations.
+		return filepath.Base(containerInfo.LinkTarget), containerInfo, false, nil
+	}
+
+	if strings.HasSuffix(containerPath, "/") {
+		return "", nil, false, errors.Wrapf(containerInfoErr, "%q could not be found on container %s", containerPath, container)
+	}
+	if isStdin {
+		return "", nil, false, errors.New("destination must be a directory when copying from stdin")
+	}
+
+	// NOTE: containerInfo may actually be set.  That happens when
+	// the container path is a symlink into nirvana.  In that case,
+	// we must use the symlinked path instead.
+	var containerBaseName string
+	path := containerPath
+	if containerInfo != nil {
+		containerBaseName = filepath.Base(containerInfo.LinkTarget)
+		path = containerInfo.LinkTarget
+	} else {
+		containerBaseName = filepath.Base(containerPath)
+	}
+
+	parentDir, err := containerParentDir(container, path)
+	if err != nil {
+		return "", nil, false, errors.Wrapf(err, "could not determine parent dir of %q on container %s", path, container)
+	}
+	containerInfo, err = registry.ContainerEngine().ContainerStat(registry.GetContext(), container, parentDir)
+	if err != nil {
+		return "", nil, false, errors.Wrapf(err, "%q could not be found on container %s", containerPath, container)
+	}
+	return containerBaseName, containerInfo, true, nil
+}
+
+// checkArchive makes sure that the data read from reader is a (compressed) tar
+// archive.  It returns a reader of the decompressed archive which includes the
+// already inspected data.
+func checkArchive(reader io.Reader) (io.Reader, error) {
+	errNoArchive := errors.New("source must be a (compressed) tar archive when copying from stdin")
+	decompressed, err := archive.DecompressStream(reader)
+	if err != nil {
+		return nil, errNoArchive
+	}
+	buffered := bufio.NewReader(decompressed)
+	header, err := buffered.Peek(512)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if _, err := tar.NewReader(bytes.NewReader(header)).Next(); err != nil {
+		return nil, errNoArchive
+	}
+	return buffered, nil
+}
+
+// renameArchive streams the tar archive from reader to writer and renames the
+// entries according to rename, which maps the old to the new base names.
+func renameArchive(reader io.Reader, writer io.Writer, rename map[string]string) error {
+	if len(rename) == 0 {
+		_, err := io.Copy(writer, reader)
+		return err
+	}
+
+	renamePath := func(path string) string {
+		for oldName, newName := range rename {
+			if path == oldName || strings.HasPrefix(path, oldName+"/") {
+				return newName + strings.TrimPrefix(path, oldName)
+			}
+		}
+		return path
+	}
+
+	tarReader := tar.NewReader(reader)
+	tarWriter := tar.NewWriter(writer)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		header.Name = renamePath(header.Name)
+		if header.Typeflag == tar.TypeLink {
+			header.Linkname = renamePath(header.Linkname)
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tarWriter, tarReader); err != nil {
+			return err
+		}
+	}
+	return tarWriter.Close()
+}
+
 // containerParentDir returns the parent directory of the specified path on the
 // container.  If the path is relative, it will be resolved relative to the
 // container's working directory (or "/" if the work dir isn't set).
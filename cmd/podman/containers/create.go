@@ -18,7 +18,6 @@ import (
 	"github.com/containers/podman/v2/pkg/specgen"
 	"github.com/containers/podman/v2/pkg/util"
 	"github.com/pkg/errors"
-	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -62,6 +61,14 @@ func createFlags(cmd *cobra.Command) {
 	common.DefineCreateFlags(cmd, &cliVals)
 	common.DefineNetFlags(cmd)
 
+	initContainerFlagName := "init-ctr"
+	flags.StringVar(
+		&cliVals.InitContainerType,
+		initContainerFlagName, "",
+		`Make this an init container of the pod, which is run before the other containers ("always"|"once")`,
+	)
+	_ = cmd.RegisterFlagCompletionFunc(initContainerFlagName, common.AutocompleteInitCtr)
+
 	flags.SetNormalizeFunc(utils.AliasFlags)
 
 	_ = flags.MarkHidden("signature-policy")
@@ -89,6 +96,9 @@ func create(cmd *cobra.Command, args []string) error {
 	var (
 		err error
 	)
+	if err := common.ApplyPresets(cmd.Flags(), cliVals.Presets); err != nil {
+		return err
+	}
 	cliVals.Net, err = common.NetFlagsToNetOptions(cmd)
 	if err != nil {
 		return err
@@ -114,6 +124,10 @@ func create(cmd *cobra.Command, args []string) error {
 	}
 	s.RawImageName = rawImageName
 
+	if cliVals.DryRun {
+		return dryRun(s)
+	}
+
 	if _, err := createPodIfNecessary(s, cliVals.Net); err != nil {
 		return err
 	}
@@ -139,6 +153,21 @@ func create(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// dryRun prints the OCI spec and the volumes of the container which would be
+// created for s.
+func dryRun(s *specgen.SpecGenerator) error {
+	report, err := registry.ContainerEngine().ContainerCreateDryRun(registry.GetContext(), s)
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
+
 func replaceContainer(name string) error {
 	if len(name) == 0 {
 		return errors.New("cannot replace container without --name being set")
@@ -169,6 +198,22 @@ func createInit(c *cobra.Command) error {
 	if c.Flag("no-hosts").Changed && c.Flag("add-host").Changed {
 		return errors.Errorf("--no-hosts and --add-host cannot be set together")
 	}
+	if cliVals.DryRun && strings.HasPrefix(cliVals.Pod, "new:") {
+		return errors.Errorf("--dry-run cannot be used with --pod new:")
+	}
+	// The default of --device are the default devices from
+	// containers.conf.
+	if cliVals.NoDefaultDevices && !c.Flags().Changed("device") {
+		cliVals.Devices = nil
+	}
+	if c.Flags().Changed("init-ctr") {
+		if cliVals.InitContainerType != define.AlwaysInitContainer && cliVals.InitContainerType != define.OneShotInitContainer {
+			return errors.Errorf("invalid value for --init-ctr: must be %q or %q", define.AlwaysInitContainer, define.OneShotInitContainer)
+		}
+		if !c.Flags().Changed("pod") && !c.Flags().Changed("pod-id-file") {
+			return errors.Errorf("--init-ctr requires --pod")
+		}
+	}
 	cliVals.UserNS = c.Flag("userns").Value.String()
 	// if user did not modify --userns flag and did turn on
 	// uid/gid mappings, set userns flag to "private"
@@ -216,7 +261,7 @@ func createInit(c *cobra.Command) error {
 }
 
 func pullImage(imageName string) (string, error) {
-	pullPolicy, err := config.ValidatePullPolicy(cliVals.Pull)
+	pullPolicy, err := util.ValidatePullType(cliVals.Pull)
 	if err != nil {
 		return "", err
 	}
@@ -238,22 +283,29 @@ func pullImage(imageName string) (string, error) {
 	}
 
 	if cliVals.Platform != "" {
-		if cliVals.OverrideArch != "" || cliVals.OverrideOS != "" {
-			return "", errors.Errorf("--platform option can not be specified with --override-arch or --override-os")
+		if cliVals.OverrideArch != "" || cliVals.OverrideOS != "" || cliVals.OverrideVariant != "" {
+			return "", errors.Errorf("--platform option can not be specified with --arch, --os or --variant")
 		}
-		split := strings.SplitN(cliVals.Platform, "/", 2)
-		cliVals.OverrideOS = split[0]
-		if len(split) > 1 {
-			cliVals.OverrideArch = split[1]
+		cliVals.OverrideOS, cliVals.OverrideArch, cliVals.OverrideVariant, err = util.ParsePlatform(cliVals.Platform)
+		if err != nil {
+			return "", err
 		}
-		if pullPolicy != config.PullImageAlways {
-			logrus.Info("--platform causes the pull policy to be \"always\"")
-			pullPolicy = config.PullImageAlways
+	}
+	// A dry run must not modify the storage, so images are never pulled.
+	if cliVals.DryRun {
+		if imageMissing {
+			return "", errors.Wrapf(define.ErrNoSuchImage, "image %s is not present locally and --dry-run does not pull images", imageName)
 		}
+		return imageName, nil
 	}
-
-	if imageMissing || pullPolicy == config.PullImageAlways {
-		if pullPolicy == config.PullImageNever {
+	// A local image of another platform must not be used, so let the pull
+	// decide based on the requested platform.
+	platformChoice := cliVals.OverrideOS != "" || cliVals.OverrideArch != "" || cliVals.OverrideVariant != ""
+
+	// With the "newer" policy, the registry is checked for a different
+	// image even if the image is present locally.
+	if imageMissing || platformChoice || pullPolicy == config.PullImageAlways || pullPolicy == util.PullImageNewer {
+		if imageMissing && pullPolicy == config.PullImageNever {
 			return "", errors.Wrapf(define.ErrNoSuchImage, "unable to find a name and tag match for %s in repotags", imageName)
 		}
 		pullReport, pullErr := registry.ImageEngine().Pull(registry.GetContext(), imageName, entities.ImagePullOptions{
@@ -0,0 +1,83 @@
+package containers
+
+import (
+	"fmt"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v2/cmd/podman/common"
+	"github.com/containers/podman/v2/cmd/podman/registry"
+	"github.com/containers/podman/v2/cmd/podman/utils"
+	"github.com/containers/podman/v2/cmd/podman/validate"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dropCachesDescription = `Reclaims memory of one or more running or paused containers, mostly page cache, without restarting them.
+
+  By default as much memory as possible is reclaimed.  With --amount, up to the given amount is reclaimed, which requires cgroup v2.  On cgroup v2, Linux 5.19 or newer is required.`
+
+	dropCachesCommand = &cobra.Command{
+		Use:   "drop-caches [options] CONTAINER [CONTAINER...]",
+		Short: "Drop the page cache of one or more containers",
+		Long:  dropCachesDescription,
+		RunE:  dropCaches,
+		Args: func(cmd *cobra.Command, args []string) error {
+			return validate.CheckAllLatestAndCIDFile(cmd, args, false, false)
+		},
+		ValidArgsFunction: common.AutocompleteContainersRunning,
+		Example: `podman container drop-caches mywebserver
+  podman container drop-caches --amount 512m mywebserver
+  podman container drop-caches --all`,
+	}
+)
+
+var (
+	dropCachesOptions   entities.ContainerDropCachesOptions
+	dropCachesAmountCLI string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Mode:    []entities.EngineMode{entities.ABIMode, entities.TunnelMode},
+		Command: dropCachesCommand,
+		Parent:  containerCmd,
+	})
+	flags := dropCachesCommand.Flags()
+	flags.BoolVarP(&dropCachesOptions.All, "all", "a", false, "Drop the caches of all running and paused containers")
+
+	amountFlagName := "amount"
+	flags.StringVar(&dropCachesAmountCLI, amountFlagName, "", "Amount of memory to reclaim (format: <number>[<unit>], where unit = b (bytes), k (kilobytes), m (megabytes), or g (gigabytes))")
+	_ = dropCachesCommand.RegisterFlagCompletionFunc(amountFlagName, completion.AutocompleteNone)
+
+	validate.AddLatestFlag(dropCachesCommand, &dropCachesOptions.Latest)
+}
+
+func dropCaches(cmd *cobra.Command, args []string) error {
+	var errs utils.OutputErrors
+	if dropCachesAmountCLI != "" {
+		amount, err := units.RAMInBytes(dropCachesAmountCLI)
+		if err != nil {
+			return errors.Wrapf(err, "invalid value for --amount")
+		}
+		if amount <= 0 {
+			return errors.Errorf("invalid value for --amount: %s must be greater than 0", dropCachesAmountCLI)
+		}
+		dropCachesOptions.Amount = uint64(amount)
+	}
+
+	reports, err := registry.ContainerEngine().ContainerDropCaches(registry.GetContext(), args, dropCachesOptions)
+	if err != nil {
+		return err
+	}
+	for _, r := range reports {
+		if r.Err == nil {
+			fmt.Println(r.Id)
+		} else {
+			errs = append(errs, r.Err)
+		}
+	}
+	return errs.PrintErrors()
+}
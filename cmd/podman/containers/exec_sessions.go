@@ -0,0 +1,130 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v2/cmd/podman/common"
+	"github.com/containers/podman/v2/cmd/podman/parse"
+	"github.com/containers/podman/v2/cmd/podman/registry"
+	"github.com/containers/podman/v2/cmd/podman/validate"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	execSessionsDescription = `Lists the exec sessions of the given containers, or of all containers if none are given.
+
+  Exec sessions are created by podman exec and by the exec endpoints of the API, and are kept until they are removed.`
+
+	execSessionsCommand = &cobra.Command{
+		Use:               "exec-sessions [options] [CONTAINER...]",
+		Short:             "List the exec sessions of containers",
+		Long:              execSessionsDescription,
+		RunE:              execSessions,
+		ValidArgsFunction: common.AutocompleteContainersRunning,
+		Example: `podman container exec-sessions
+  podman container exec-sessions --running ctrID
+  podman container exec-sessions --format json --latest`,
+	}
+)
+
+var (
+	execSessionsOptions entities.ContainerExecSessionsOptions
+	execSessionsFormat  string
+	execSessionsQuiet   bool
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Mode:    []entities.EngineMode{entities.ABIMode, entities.TunnelMode},
+		Command: execSessionsCommand,
+		Parent:  containerCmd,
+	})
+	flags := execSessionsCommand.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&execSessionsFormat, formatFlagName, "{{.ID}}\t{{.ContainerName}}\t{{.Command}}\t{{.Status}}\t{{.Pid}}\n", "Format exec session output using JSON or a Go template")
+	_ = execSessionsCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteJSONFormat)
+
+	flags.BoolVarP(&execSessionsQuiet, "quiet", "q", false, "Print the IDs of the exec sessions only")
+	flags.BoolVar(&execSessionsOptions.Running, "running", false, "List running exec sessions only")
+	validate.AddLatestFlag(execSessionsCommand, &execSessionsOptions.Latest)
+}
+
+// execSessionReporter adds the columns of the default format.
+type execSessionReporter struct {
+	*entities.ContainerExecSessionReport
+}
+
+func (e execSessionReporter) Command() string {
+	if e.ProcessConfig == nil {
+		return ""
+	}
+	return strings.Join(append([]string{e.ProcessConfig.Entrypoint}, e.ProcessConfig.Arguments...), " ")
+}
+
+func (e execSessionReporter) Status() string {
+	if e.Running {
+		return "running"
+	}
+	return fmt.Sprintf("exited (%d)", e.ExitCode)
+}
+
+func execSessions(cmd *cobra.Command, args []string) error {
+	if execSessionsOptions.Latest && len(args) > 0 {
+		return errors.New("--latest and containers cannot be used together")
+	}
+	if execSessionsQuiet && cmd.Flag("format").Changed {
+		return errors.New("quiet and format flags cannot be used together")
+	}
+
+	responses, err := registry.ContainerEngine().ContainerExecSessions(context.Background(), args, execSessionsOptions)
+	if err != nil {
+		return err
+	}
+
+	if report.IsJSON(execSessionsFormat) {
+		b, err := json.MarshalIndent(responses, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	headers := report.Headers(entities.ContainerExecSessionReport{}, map[string]string{
+		"ContainerName": "CONTAINER",
+		"Command":       "COMMAND",
+		"Status":        "STATUS",
+	})
+	row := report.NormalizeFormat(execSessionsFormat)
+	if execSessionsQuiet {
+		row = "{{.ID}}\n"
+	}
+	format := parse.EnforceRange(row)
+
+	tmpl, err := template.New("list exec sessions").Parse(format)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 12, 2, 2, ' ', 0)
+	defer w.Flush()
+
+	if !execSessionsQuiet && !cmd.Flag("format").Changed {
+		if err := tmpl.Execute(w, headers); err != nil {
+			return errors.Wrapf(err, "failed to write report column headers")
+		}
+	}
+	reporters := make([]execSessionReporter, 0, len(responses))
+	for _, r := range responses {
+		reporters = append(reporters, execSessionReporter{r})
+	}
+	return tmpl.Execute(w, reporters)
+}
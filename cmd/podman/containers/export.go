@@ -51,6 +51,8 @@ func exportFlags(cmd *cobra.Command) {
 	outputFlagName := "output"
 	flags.StringVarP(&exportOpts.Output, outputFlagName, "o", "", "Write to a specified file (default: stdout, which must be redirected)")
 	_ = cmd.RegisterFlagCompletionFunc(outputFlagName, completion.AutocompleteDefault)
+
+	flags.BoolVar(&exportOpts.IncludeVolumes, "include-volumes", false, "Include the contents of the named volumes of the container")
 }
 
 func init() {
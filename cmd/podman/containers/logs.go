@@ -18,7 +18,9 @@ import (
 type logsOptionsWrapper struct {
 	entities.ContainerLogsOptions
 
-	SinceRaw string
+	SinceRaw   string
+	StdoutOnly bool
+	StderrOnly bool
 }
 
 var (
@@ -37,6 +39,8 @@ var (
 				return errors.New(cmd.Name() + " does not support 'latest' when run remotely")
 			case registry.IsRemote() && len(args) > 1:
 				return errors.New(cmd.Name() + " does not support multiple containers when run remotely")
+			case logsOptions.StdoutOnly && logsOptions.StderrOnly:
+				return errors.New("--stdout-only and --stderr-only cannot be used together")
 			case logsOptions.Latest && len(args) > 0:
 				return errors.New("--latest and containers cannot be used together")
 			case !logsOptions.Latest && len(args) < 1:
@@ -109,6 +113,8 @@ func logsFlags(cmd *cobra.Command) {
 
 	flags.BoolVarP(&logsOptions.Timestamps, "timestamps", "t", false, "Output the timestamps in the log")
 	flags.BoolVarP(&logsOptions.Names, "names", "n", false, "Output the container name in the log")
+	flags.BoolVar(&logsOptions.StdoutOnly, "stdout-only", false, "Only output the log lines the container wrote to stdout")
+	flags.BoolVar(&logsOptions.StderrOnly, "stderr-only", false, "Only output the log lines the container wrote to stderr")
 	flags.SetInterspersed(false)
 	_ = flags.MarkHidden("details")
 }
@@ -124,5 +130,12 @@ func logs(_ *cobra.Command, args []string) error {
 	}
 	logsOptions.StdoutWriter = os.Stdout
 	logsOptions.StderrWriter = os.Stderr
+	// a nil writer skips the lines of its stream
+	if logsOptions.StderrOnly {
+		logsOptions.StdoutWriter = nil
+	}
+	if logsOptions.StdoutOnly {
+		logsOptions.StderrWriter = nil
+	}
 	return registry.ContainerEngine().ContainerLogs(registry.GetContext(), args, logsOptions.ContainerLogsOptions)
 }
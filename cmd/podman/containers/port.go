@@ -2,6 +2,7 @@ package containers
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -122,6 +123,16 @@ func port(_ *cobra.Command, args []string) error {
 	var found bool
 	// Iterate mappings
 	for _, report := range reports {
+		// If not searching by port or port/proto, then dump what we see
+		if port == "" {
+			for _, r := range portRanges(report.Ports) {
+				if portOpts.All {
+					fmt.Printf("%s\t", report.Id[:12])
+				}
+				fmt.Println(r)
+			}
+			continue
+		}
 		for _, v := range report.Ports {
 			hostIP := v.HostIP
 			// Set host IP to 0.0.0.0 if blank
@@ -131,11 +142,6 @@ func port(_ *cobra.Command, args []string) error {
 			if portOpts.All {
 				fmt.Printf("%s\t", report.Id[:12])
 			}
-			// If not searching by port or port/proto, then dump what we see
-			if port == "" {
-				fmt.Printf("%d/%s -> %s:%d\n", v.ContainerPort, v.Protocol, hostIP, v.HostPort)
-				continue
-			}
 			if v.ContainerPort == userPort.ContainerPort {
 				fmt.Printf("%s:%d\n", hostIP, v.HostPort)
 				found = true
@@ -148,3 +154,45 @@ func port(_ *cobra.Command, args []string) error {
 	}
 	return nil
 }
+
+// portRanges returns the port mappings as strings, mappings of consecutive
+// container ports to consecutive host ports being merged into a range.
+func portRanges(ports []ocicni.PortMapping) []string {
+	sorted := make([]ocicni.PortMapping, len(ports))
+	copy(sorted, ports)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Protocol != sorted[j].Protocol {
+			return sorted[i].Protocol < sorted[j].Protocol
+		}
+		if sorted[i].HostIP != sorted[j].HostIP {
+			return sorted[i].HostIP < sorted[j].HostIP
+		}
+		return sorted[i].ContainerPort < sorted[j].ContainerPort
+	})
+
+	ranges := []string{}
+	for i := 0; i < len(sorted); {
+		first := sorted[i]
+		n := 1
+		for ; i+n < len(sorted); n++ {
+			next := sorted[i+n]
+			if next.Protocol != first.Protocol || next.HostIP != first.HostIP ||
+				next.ContainerPort != first.ContainerPort+int32(n) || next.HostPort != first.HostPort+int32(n) {
+				break
+			}
+		}
+		hostIP := first.HostIP
+		// Set host IP to 0.0.0.0 if blank
+		if hostIP == "" {
+			hostIP = "0.0.0.0"
+		}
+		if n == 1 {
+			ranges = append(ranges, fmt.Sprintf("%d/%s -> %s:%d", first.ContainerPort, first.Protocol, hostIP, first.HostPort))
+		} else {
+			last := sorted[i+n-1]
+			ranges = append(ranges, fmt.Sprintf("%d-%d/%s -> %s:%d-%d", first.ContainerPort, last.ContainerPort, first.Protocol, hostIP, first.HostPort, last.HostPort))
+		}
+		i += n
+	}
+	return ranges
+}
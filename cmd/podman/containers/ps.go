@@ -132,8 +132,8 @@ func checkFlags(c *cobra.Command) error {
 	}
 	cfg := registry.PodmanConfig()
 	if cfg.Engine.Namespace != "" {
-		if c.Flag("storage").Changed && listOpts.Storage {
-			return errors.New("--namespace and --storage flags can not both be set")
+		if c.Flag("external").Changed && listOpts.Storage {
+			return errors.New("--namespace and --external flags can not both be set")
 		}
 		listOpts.Storage = false
 	}
@@ -192,6 +192,8 @@ func ps(cmd *cobra.Command, _ []string) error {
 		}
 		listOpts.Filters[split[0]] = append(listOpts.Filters[split[0]], split[1])
 	}
+	// Reading the pids cgroup is only worth it if the user asked for it
+	listOpts.Pids = strings.Contains(listOpts.Format, ".Pids")
 	listContainers, err := getResponses()
 	if err != nil {
 		return err
@@ -387,6 +389,19 @@ func (l psReporter) Size() string {
 	return fmt.Sprintf("%s (virtual %s)", s, virt)
 }
 
+// Pids returns the number of processes and the pids limit of the
+// container in the form current/limit
+func (l psReporter) Pids() string {
+	if l.ListContainer.State != "running" && l.ListContainer.State != "paused" {
+		return ""
+	}
+	limit := "max"
+	if l.ListContainer.PidsLimit > 0 {
+		limit = strconv.FormatUint(l.ListContainer.PidsLimit, 10)
+	}
+	return fmt.Sprintf("%d/%s", l.ListContainer.PidsCurrent, limit)
+}
+
 // Names returns the container name in string format
 func (l psReporter) Names() string {
 	return l.ListContainer.Names[0]
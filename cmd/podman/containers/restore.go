@@ -3,12 +3,14 @@ package containers
 import (
 	"context"
 	"fmt"
+	"net"
 
 	"github.com/containers/common/pkg/completion"
 	"github.com/containers/podman/v2/cmd/podman/common"
 	"github.com/containers/podman/v2/cmd/podman/registry"
 	"github.com/containers/podman/v2/cmd/podman/utils"
 	"github.com/containers/podman/v2/cmd/podman/validate"
+	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/pkg/domain/entities"
 	"github.com/containers/podman/v2/pkg/rootless"
 	"github.com/pkg/errors"
@@ -20,24 +22,29 @@ var (
    podman container restore
 
    Restores a container from a checkpoint. The container name or ID can be used.
+   Containers checkpointed with --create-image are restored by giving the name or ID of the checkpoint image.
 `
 	restoreCommand = &cobra.Command{
-		Use:   "restore [options] CONTAINER [CONTAINER...]",
+		Use:   "restore [options] CONTAINER|IMAGE [CONTAINER|IMAGE...]",
 		Short: "Restores one or more containers from a checkpoint",
 		Long:  restoreDescription,
 		RunE:  restore,
 		Args: func(cmd *cobra.Command, args []string) error {
 			return validate.CheckAllLatestAndCIDFile(cmd, args, true, false)
 		},
-		ValidArgsFunction: common.AutocompleteContainers,
+		ValidArgsFunction: common.AutocompleteContainersAndImages,
 		Example: `podman container restore ctrID
+  podman container restore quay.io/example/checkpoint
   podman container restore --latest
   podman container restore --all`,
 	}
 )
 
 var (
-	restoreOptions entities.RestoreOptions
+	restoreOptions   entities.RestoreOptions
+	restoreIP        string
+	restoreMAC       string
+	restorePublished []string
 )
 
 func init() {
@@ -62,6 +69,19 @@ func init() {
 	flags.BoolVar(&restoreOptions.IgnoreRootFS, "ignore-rootfs", false, "Do not apply root file-system changes when importing from exported checkpoint")
 	flags.BoolVar(&restoreOptions.IgnoreStaticIP, "ignore-static-ip", false, "Ignore IP address set via --static-ip")
 	flags.BoolVar(&restoreOptions.IgnoreStaticMAC, "ignore-static-mac", false, "Ignore MAC address set via --mac-address")
+	flags.BoolVar(&restoreOptions.IgnoreVolumes, "ignore-volumes", false, "Do not restore the content of volumes associated with the container when importing from exported checkpoint")
+
+	ipFlagName := "ip"
+	flags.StringVar(&restoreIP, ipFlagName, "", "Restore the container with this IPv4 address instead of the checkpointed one")
+	_ = restoreCommand.RegisterFlagCompletionFunc(ipFlagName, completion.AutocompleteNone)
+
+	macAddressFlagName := "mac-address"
+	flags.StringVar(&restoreMAC, macAddressFlagName, "", "Restore the container with this MAC address instead of the checkpointed one")
+	_ = restoreCommand.RegisterFlagCompletionFunc(macAddressFlagName, completion.AutocompleteNone)
+
+	publishFlagName := "publish"
+	flags.StringSliceVarP(&restorePublished, publishFlagName, "p", []string{}, "Publish these ports instead of the checkpointed ones when importing from exported checkpoint")
+	_ = restoreCommand.RegisterFlagCompletionFunc(publishFlagName, completion.AutocompleteNone)
 	validate.AddLatestFlag(restoreCommand, &restoreOptions.Latest)
 }
 
@@ -73,12 +93,51 @@ func restore(_ *cobra.Command, args []string) error {
 	if restoreOptions.Import == "" && restoreOptions.IgnoreRootFS {
 		return errors.Errorf("--ignore-rootfs can only be used with --import")
 	}
+	if restoreOptions.Import == "" && restoreOptions.IgnoreVolumes {
+		return errors.Errorf("--ignore-volumes can only be used with --import")
+	}
 	if restoreOptions.Import == "" && restoreOptions.Name != "" {
 		return errors.Errorf("--name can only be used with --import")
 	}
 	if restoreOptions.Name != "" && restoreOptions.TCPEstablished {
 		return errors.Errorf("--tcp-established cannot be used with --name")
 	}
+	if restoreIP != "" {
+		if restoreOptions.IgnoreStaticIP {
+			return errors.Errorf("--ip cannot be used with --ignore-static-ip")
+		}
+		if restoreOptions.TCPEstablished {
+			return errors.Errorf("--tcp-established cannot be used with --ip")
+		}
+		staticIP := net.ParseIP(restoreIP)
+		if staticIP == nil {
+			return errors.Errorf("%s is not an ip address", restoreIP)
+		}
+		if staticIP.To4() == nil {
+			return errors.Wrapf(define.ErrInvalidArg, "%s is not an IPv4 address", restoreIP)
+		}
+		restoreOptions.StaticIP = &staticIP
+	}
+	if restoreMAC != "" {
+		if restoreOptions.IgnoreStaticMAC {
+			return errors.Errorf("--mac-address cannot be used with --ignore-static-mac")
+		}
+		mac, err := net.ParseMAC(restoreMAC)
+		if err != nil {
+			return err
+		}
+		restoreOptions.StaticMAC = &mac
+	}
+	if len(restorePublished) > 0 {
+		if restoreOptions.All || restoreOptions.Latest {
+			return errors.Errorf("--publish cannot be used with --all or --latest")
+		}
+		ports, err := common.CreatePortBindings(restorePublished)
+		if err != nil {
+			return err
+		}
+		restoreOptions.PublishPorts = ports
+	}
 
 	argLen := len(args)
 	if restoreOptions.Import != "" {
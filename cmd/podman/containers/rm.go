@@ -69,8 +69,9 @@ func rmFlags(cmd *cobra.Command) {
 	_ = cmd.RegisterFlagCompletionFunc(cidfileFlagName, completion.AutocompleteDefault)
 
 	if !registry.IsRemote() {
+		flags.BoolVar(&rmOptions.External, "external", false, "Remove containers in storage not controlled by Podman")
 		// This option is deprecated, but needs to still exists for backwards compatibility
-		flags.Bool("storage", false, "Remove container from storage library")
+		flags.BoolVar(&rmOptions.External, "storage", false, "Remove container from storage library")
 		_ = flags.MarkHidden("storage")
 	}
 }
@@ -93,6 +94,9 @@ func init() {
 }
 
 func rm(_ *cobra.Command, args []string) error {
+	if rmOptions.External && (rmOptions.Latest || rmOptions.Volumes) {
+		return errors.New("--external cannot be used with --latest or --volumes")
+	}
 	return removeContainers(args, rmOptions, true)
 }
 
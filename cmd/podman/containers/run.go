@@ -101,6 +101,9 @@ func init() {
 
 func run(cmd *cobra.Command, args []string) error {
 	var err error
+	if err := common.ApplyPresets(cmd.Flags(), cliVals.Presets); err != nil {
+		return err
+	}
 	cliVals.Net, err = common.NetFlagsToNetOptions(cmd)
 	if err != nil {
 		return err
@@ -134,7 +137,7 @@ func run(cmd *cobra.Command, args []string) error {
 		imageName = name
 	}
 
-	if cliVals.Replace {
+	if cliVals.Replace && !cliVals.DryRun {
 		if err := replaceContainer(cliVals.Name); err != nil {
 			return err
 		}
@@ -174,6 +177,10 @@ func run(cmd *cobra.Command, args []string) error {
 	s.RawImageName = rawImageName
 	runOpts.Spec = s
 
+	if cliVals.DryRun {
+		return dryRun(s)
+	}
+
 	if _, err := createPodIfNecessary(s, cliVals.Net); err != nil {
 		return err
 	}
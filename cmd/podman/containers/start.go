@@ -56,10 +56,6 @@ func startFlags(cmd *cobra.Command) {
 
 	flags.BoolVarP(&startOptions.Interactive, "interactive", "i", false, "Keep STDIN open even if not attached")
 	flags.BoolVar(&startOptions.SigProxy, "sig-proxy", false, "Proxy received signals to the process (default true if attaching, false otherwise)")
-
-	if registry.IsRemote() {
-		_ = flags.MarkHidden("sig-proxy")
-	}
 }
 func init() {
 	registry.Commands = append(registry.Commands, registry.CliCommand{
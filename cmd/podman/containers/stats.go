@@ -154,6 +154,7 @@ func outputStats(reports []define.ContainerStats) error {
 		"NetIO":    "NET IO",
 		"BlockIO":  "BLOCK IO",
 		"PIDS":     "PIDS",
+		"OOMKills": "OOM KILLS",
 	})
 	if !statsOptions.NoReset {
 		tm.Clear()
@@ -252,6 +253,7 @@ func outputJSON(stats []containerStats) error {
 		NetIO      string `json:"net_io"`
 		BlockIO    string `json:"block_io"`
 		Pids       string `json:"pids"`
+		OOMKills   string `json:"oom_kills"`
 	}
 	jstats := make([]jstat, 0, len(stats))
 	for _, j := range stats {
@@ -264,6 +266,7 @@ func outputJSON(stats []containerStats) error {
 			NetIO:      j.NetIO(),
 			BlockIO:    j.BlockIO(),
 			Pids:       j.PIDS(),
+			OOMKills:   fmt.Sprintf("%d", j.OOMKills),
 		})
 	}
 	b, err := json.MarshalIndent(jstats, "", " ")
@@ -10,6 +10,8 @@ import (
 	"github.com/containers/podman/v2/cmd/podman/utils"
 	"github.com/containers/podman/v2/cmd/podman/validate"
 	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/containers/podman/v2/pkg/util"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -49,12 +51,14 @@ var (
 var (
 	stopOptions = entities.StopOptions{}
 	stopTimeout uint
+	stopSignals []string
 )
 
 func stopFlags(cmd *cobra.Command) {
 	flags := cmd.Flags()
 
 	flags.BoolVarP(&stopOptions.All, "all", "a", false, "Stop all running containers")
+	flags.BoolVarP(&stopOptions.Force, "force", "f", false, "Also stop the running containers requiring the container")
 	flags.BoolVarP(&stopOptions.Ignore, "ignore", "i", false, "Ignore errors when a specified container is missing")
 
 	cidfileFlagName := "cidfile"
@@ -65,9 +69,15 @@ func stopFlags(cmd *cobra.Command) {
 	flags.UintVarP(&stopTimeout, timeFlagName, "t", containerConfig.Engine.StopTimeout, "Seconds to wait for stop before killing the container")
 	_ = cmd.RegisterFlagCompletionFunc(timeFlagName, completion.AutocompleteNone)
 
+	signalsFlagName := "signals"
+	flags.StringSliceVar(&stopSignals, signalsFlagName, nil, "Signal escalation chain to stop the container, as `SIGNAL:SECONDS` steps")
+	_ = cmd.RegisterFlagCompletionFunc(signalsFlagName, completion.AutocompleteNone)
+
 	if registry.IsRemote() {
 		_ = flags.MarkHidden("cidfile")
+		_ = flags.MarkHidden("force")
 		_ = flags.MarkHidden("ignore")
+		_ = flags.MarkHidden(signalsFlagName)
 	}
 	flags.SetNormalizeFunc(utils.AliasFlags)
 }
@@ -94,8 +104,18 @@ func stop(cmd *cobra.Command, args []string) error {
 		errs utils.OutputErrors
 	)
 	if cmd.Flag("time").Changed {
+		if cmd.Flag("signals").Changed {
+			return errors.New("--time and --signals cannot be used together")
+		}
 		stopOptions.Timeout = &stopTimeout
 	}
+	if cmd.Flag("signals").Changed {
+		steps, err := util.ParseStopSignals(stopSignals)
+		if err != nil {
+			return err
+		}
+		stopOptions.Signals = steps
+	}
 
 	responses, err := registry.ContainerEngine().ContainerStop(context.Background(), args, stopOptions)
 	if err != nil {
@@ -0,0 +1,199 @@
+package containers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v2/cmd/podman/common"
+	"github.com/containers/podman/v2/cmd/podman/parse"
+	"github.com/containers/podman/v2/cmd/podman/registry"
+	"github.com/containers/podman/v2/pkg/cgroups"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/containers/podman/v2/pkg/rootless"
+	"github.com/containers/podman/v2/pkg/util"
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	updateDescription = `Updates the cgroup resource limits, labels and annotations of a container.
+
+  The limits of a running container are changed immediately and are kept when the container is restarted.  Limits, labels and annotations which are not specified are not changed.`
+
+	updateCommand = &cobra.Command{
+		Use:               "update [options] CONTAINER",
+		Short:             "Update the resource limits, labels and annotations of a container",
+		Long:              updateDescription,
+		RunE:              update,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteContainerOneArg,
+		Example: `podman update --memory 2g --cpus 1.5 ctrID
+  podman update --pids-limit 100 mywebserver
+  podman update --label stage=prod --label-rm owner mywebserver`,
+	}
+
+	containerUpdateCommand = &cobra.Command{
+		Use:               updateCommand.Use,
+		Short:             updateCommand.Short,
+		Long:              updateCommand.Long,
+		RunE:              updateCommand.RunE,
+		Args:              updateCommand.Args,
+		ValidArgsFunction: updateCommand.ValidArgsFunction,
+		Example: `podman container update --memory 2g --cpus 1.5 ctrID
+  podman container update --pids-limit 100 mywebserver
+  podman container update --label stage=prod --label-rm owner mywebserver`,
+	}
+)
+
+var (
+	updateOptions       entities.ContainerUpdateOptions
+	updateCPUs          float64
+	updateMemoryCLI     string
+	updateMemorySwapCLI string
+	updateLabels        []string
+	updateAnnotations   []string
+)
+
+func updateFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+
+	annotationFlagName := "annotation"
+	flags.StringSliceVar(&updateAnnotations, annotationFlagName, []string{}, "Add or change an OCI annotation (key=value)")
+	_ = cmd.RegisterFlagCompletionFunc(annotationFlagName, completion.AutocompleteNone)
+
+	annotationRmFlagName := "annotation-rm"
+	flags.StringSliceVar(&updateOptions.RemoveAnnotations, annotationRmFlagName, []string{}, "Remove the OCI annotation with the given key")
+	_ = cmd.RegisterFlagCompletionFunc(annotationRmFlagName, completion.AutocompleteNone)
+
+	blkioWeightFlagName := "blkio-weight"
+	flags.Uint16Var(&updateOptions.BlkioWeight, blkioWeightFlagName, 0, "Block IO weight (relative weight) accepts a weight value between 10 and 1000.")
+	_ = cmd.RegisterFlagCompletionFunc(blkioWeightFlagName, completion.AutocompleteNone)
+
+	cpuSharesFlagName := "cpu-shares"
+	flags.Uint64Var(&updateOptions.CPUShares, cpuSharesFlagName, 0, "CPU shares (relative weight)")
+	_ = cmd.RegisterFlagCompletionFunc(cpuSharesFlagName, completion.AutocompleteNone)
+
+	cpusFlagName := "cpus"
+	flags.Float64Var(&updateCPUs, cpusFlagName, 0, "Number of CPUs")
+	_ = cmd.RegisterFlagCompletionFunc(cpusFlagName, completion.AutocompleteNone)
+
+	cpusetCpusFlagName := "cpuset-cpus"
+	flags.StringVar(&updateOptions.CPUSetCPUs, cpusetCpusFlagName, "", "CPUs in which to allow execution (0-3, 0,1)")
+	_ = cmd.RegisterFlagCompletionFunc(cpusetCpusFlagName, completion.AutocompleteNone)
+
+	cpusetMemsFlagName := "cpuset-mems"
+	flags.StringVar(&updateOptions.CPUSetMems, cpusetMemsFlagName, "", "Memory nodes (MEMs) in which to allow execution (0-3, 0,1). Only effective on NUMA systems.")
+	_ = cmd.RegisterFlagCompletionFunc(cpusetMemsFlagName, completion.AutocompleteNone)
+
+	labelFlagName := "label"
+	flags.StringArrayVar(&updateLabels, labelFlagName, []string{}, "Add or change a label (key=value)")
+	_ = cmd.RegisterFlagCompletionFunc(labelFlagName, completion.AutocompleteNone)
+
+	labelRmFlagName := "label-rm"
+	flags.StringSliceVar(&updateOptions.RemoveLabels, labelRmFlagName, []string{}, "Remove the label with the given key")
+	_ = cmd.RegisterFlagCompletionFunc(labelRmFlagName, completion.AutocompleteNone)
+
+	memoryFlagName := "memory"
+	flags.StringVarP(&updateMemoryCLI, memoryFlagName, "m", "", "Memory limit (format: <number>[<unit>], where unit = b (bytes), k (kilobytes), m (megabytes), or g (gigabytes))")
+	_ = cmd.RegisterFlagCompletionFunc(memoryFlagName, completion.AutocompleteNone)
+
+	memorySwapFlagName := "memory-swap"
+	flags.StringVar(&updateMemorySwapCLI, memorySwapFlagName, "", "Swap limit equal to memory plus swap: '-1' to enable unlimited swap")
+	_ = cmd.RegisterFlagCompletionFunc(memorySwapFlagName, completion.AutocompleteNone)
+
+	pidsLimitFlagName := "pids-limit"
+	flags.Int64Var(&updateOptions.PidsLimit, pidsLimitFlagName, 0, "Tune container pids limit (set -1 for unlimited)")
+	_ = cmd.RegisterFlagCompletionFunc(pidsLimitFlagName, completion.AutocompleteNone)
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Mode:    []entities.EngineMode{entities.ABIMode, entities.TunnelMode},
+		Command: updateCommand,
+	})
+	updateFlags(updateCommand)
+
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Mode:    []entities.EngineMode{entities.ABIMode, entities.TunnelMode},
+		Command: containerUpdateCommand,
+		Parent:  containerCmd,
+	})
+	updateFlags(containerUpdateCommand)
+}
+
+func update(cmd *cobra.Command, args []string) error {
+	if rootless.IsRootless() && !registry.IsRemote() {
+		cgroupv2, _ := cgroups.IsCgroup2UnifiedMode()
+		if !cgroupv2 {
+			return errors.New("update is not supported for cgroupv1 rootless containers")
+		}
+	}
+
+	if updateCPUs < 0 {
+		return errors.Errorf("invalid value for --cpus: %f", updateCPUs)
+	}
+	if updateCPUs > 0 {
+		updateOptions.CPUPeriod, updateOptions.CPUQuota = util.CoresToPeriodAndQuota(updateCPUs)
+	}
+	if updateMemoryCLI != "" {
+		memory, err := units.RAMInBytes(updateMemoryCLI)
+		if err != nil {
+			return errors.Wrapf(err, "invalid value for --memory")
+		}
+		updateOptions.Memory = memory
+		// Same default as for podman create.
+		if updateMemorySwapCLI == "" {
+			updateOptions.MemorySwap = 2 * memory
+		}
+	}
+	if updateMemorySwapCLI == "-1" {
+		updateOptions.MemorySwap = -1
+	} else if updateMemorySwapCLI != "" {
+		memorySwap, err := units.RAMInBytes(updateMemorySwapCLI)
+		if err != nil {
+			return errors.Wrapf(err, "invalid value for --memory-swap")
+		}
+		updateOptions.MemorySwap = memorySwap
+	}
+	// A zero value keeps the current limit of the container, so it cannot
+	// be set explicitly.
+	if updateOptions.PidsLimit < -1 || (cmd.Flags().Changed("pids-limit") && updateOptions.PidsLimit == 0) {
+		return errors.Errorf("invalid value for --pids-limit: %d, use -1 for unlimited", updateOptions.PidsLimit)
+	}
+	if cmd.Flags().Changed("blkio-weight") && (updateOptions.BlkioWeight < 10 || updateOptions.BlkioWeight > 1000) {
+		return errors.Errorf("invalid value for --blkio-weight: %d, must be between 10 and 1000", updateOptions.BlkioWeight)
+	}
+	if len(updateLabels) > 0 {
+		labels, err := parse.GetAllLabels(nil, updateLabels)
+		if err != nil {
+			return errors.Wrapf(err, "invalid value for --label")
+		}
+		updateOptions.Labels = labels
+	}
+	if len(updateAnnotations) > 0 {
+		updateOptions.Annotations = make(map[string]string, len(updateAnnotations))
+		for _, annotation := range updateAnnotations {
+			split := strings.SplitN(annotation, "=", 2)
+			if len(split) < 2 {
+				return errors.Errorf("Annotations must be formatted KEY=VALUE")
+			}
+			updateOptions.Annotations[split[0]] = split[1]
+		}
+	}
+	if !cmd.Flags().Changed("blkio-weight") && !cmd.Flags().Changed("cpu-shares") && !cmd.Flags().Changed("cpus") &&
+		!cmd.Flags().Changed("cpuset-cpus") && !cmd.Flags().Changed("cpuset-mems") && !cmd.Flags().Changed("memory") &&
+		!cmd.Flags().Changed("memory-swap") && !cmd.Flags().Changed("pids-limit") &&
+		!cmd.Flags().Changed("label") && !cmd.Flags().Changed("label-rm") &&
+		!cmd.Flags().Changed("annotation") && !cmd.Flags().Changed("annotation-rm") {
+		return errors.New("at least one resource limit, label or annotation must be specified")
+	}
+
+	report, err := registry.ContainerEngine().ContainerUpdate(registry.GetContext(), args[0], updateOptions)
+	if err != nil {
+		return err
+	}
+	fmt.Println(report.Id)
+	return nil
+}
@@ -17,7 +17,7 @@ import (
 )
 
 var (
-	waitDescription = `Block until one or more containers stop and then print their exit codes.
+	waitDescription = `Block until one or more containers stop, or meet one of the given conditions, and then print their exit codes.
 `
 	waitCommand = &cobra.Command{
 		Use:               "wait [options] CONTAINER [CONTAINER...]",
@@ -26,7 +26,8 @@ var (
 		RunE:              wait,
 		ValidArgsFunction: common.AutocompleteContainers,
 		Example: `podman wait --interval 5s ctrID
-  podman wait ctrID1 ctrID2`,
+  podman wait ctrID1 ctrID2
+  podman wait --condition healthy ctrID1 ctrID2`,
 	}
 
 	containerWaitCommand = &cobra.Command{
@@ -36,13 +37,14 @@ var (
 		RunE:              waitCommand.RunE,
 		ValidArgsFunction: waitCommand.ValidArgsFunction,
 		Example: `podman container wait --interval 5s ctrID
-  podman container wait ctrID1 ctrID2`,
+  podman container wait ctrID1 ctrID2
+  podman container wait --condition healthy ctrID1 ctrID2`,
 	}
 )
 
 var (
 	waitOptions   = entities.WaitOptions{}
-	waitCondition string
+	waitCondition []string
 	waitInterval  string
 )
 
@@ -54,7 +56,7 @@ func waitFlags(cmd *cobra.Command) {
 	_ = cmd.RegisterFlagCompletionFunc(intervalFlagName, completion.AutocompleteNone)
 
 	conditionFlagName := "condition"
-	flags.StringVar(&waitCondition, conditionFlagName, "stopped", "Condition to wait on")
+	flags.StringSliceVar(&waitCondition, conditionFlagName, []string{"stopped"}, "Conditions to wait on, the first one met ends the wait")
 	_ = cmd.RegisterFlagCompletionFunc(conditionFlagName, common.AutocompleteWaitCondition)
 
 }
@@ -95,10 +97,12 @@ func wait(cmd *cobra.Command, args []string) error {
 		return errors.New("--latest and containers cannot be used together")
 	}
 
-	waitOptions.Condition, err = define.StringToContainerStatus(waitCondition)
-	if err != nil {
-		return err
+	for _, condition := range waitCondition {
+		if err := define.ValidateWaitCondition(condition); err != nil {
+			return err
+		}
 	}
+	waitOptions.Condition = waitCondition
 
 	responses, err := registry.ContainerEngine().ContainerWait(context.Background(), args, waitOptions)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/containers/buildah"
 	"github.com/containers/buildah/imagebuildah"
@@ -15,6 +16,7 @@ import (
 	"github.com/containers/podman/v2/cmd/podman/registry"
 	"github.com/containers/podman/v2/cmd/podman/utils"
 	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/containers/podman/v2/pkg/util"
 	"github.com/docker/go-units"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -377,6 +379,18 @@ func buildFlagsWrapperToOptions(c *cobra.Command, contextDir string, flags *buil
 		return nil, errors.Errorf("unrecognized image type %q", flags.Format)
 	}
 
+	// --timestamp takes precedence over SOURCE_DATE_EPOCH
+	var timestamp *time.Time
+	if c.Flag("timestamp").Changed {
+		t := time.Unix(flags.Timestamp, 0).UTC()
+		timestamp = &t
+	} else if timestamp, err = util.SourceDateEpoch(); err != nil {
+		return nil, err
+	}
+	if timestamp != nil && format == buildah.Dockerv2ImageManifest {
+		logrus.Warnf("images in the docker format record the IDs of the build containers, use the oci format for reproducible image digests")
+	}
+
 	runtimeFlags := []string{}
 	for _, arg := range flags.RuntimeFlags {
 		runtimeFlags = append(runtimeFlags, "--"+arg)
@@ -444,6 +458,7 @@ func buildFlagsWrapperToOptions(c *cobra.Command, contextDir string, flags *buil
 		Squash:                  flags.Squash,
 		SystemContext:           systemContext,
 		Target:                  flags.Target,
+		Timestamp:               timestamp,
 		TransientMounts:         flags.Volumes,
 	}
 
@@ -9,14 +9,15 @@ import (
 
 var (
 	existsCmd = &cobra.Command{
-		Use:               "exists IMAGE",
+		Use:               "exists IMAGE [IMAGE...]",
 		Short:             "Check if an image exists in local storage",
-		Long:              `If the named image exists in local storage, podman image exists exits with 0, otherwise the exit code will be 1.`,
-		Args:              cobra.ExactArgs(1),
+		Long:              `If all named images exist in local storage, podman image exists exits with 0, otherwise the exit code will be 1.`,
+		Args:              cobra.MinimumNArgs(1),
 		RunE:              exists,
 		ValidArgsFunction: common.AutocompleteImages,
 		Example: `podman image exists ID
-  podman image exists IMAGE && podman pull IMAGE`,
+  podman image exists IMAGE && podman pull IMAGE
+  podman image exists IMAGE1 IMAGE2`,
 		DisableFlagsInUseLine: true,
 	}
 )
@@ -30,6 +31,20 @@ func init() {
 }
 
 func exists(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		// Look up all images at once instead of one by one.
+		reports, err := registry.ImageEngine().Lookup(registry.GetContext(), args, entities.ImageLookupOptions{})
+		if err != nil {
+			return err
+		}
+		for _, r := range reports {
+			if !r.Exists {
+				registry.SetExitCode(1)
+			}
+		}
+		return nil
+	}
+
 	found, err := registry.ImageEngine().Exists(registry.GetContext(), args[0])
 	if err != nil {
 		return err
@@ -162,7 +162,7 @@ func (h historyReporter) Size() string {
 }
 
 func (h historyReporter) CreatedBy() string {
-	if len(h.ImageHistoryLayer.CreatedBy) > 45 {
+	if !opts.noTrunc && len(h.ImageHistoryLayer.CreatedBy) > 45 {
 		return h.ImageHistoryLayer.CreatedBy[:45-3] + "..."
 	}
 	return h.ImageHistoryLayer.CreatedBy
@@ -174,3 +174,10 @@ func (h historyReporter) ID() string {
 	}
 	return h.ImageHistoryLayer.ID
 }
+
+func (h historyReporter) Layer() string {
+	if !opts.noTrunc && len(h.ImageHistoryLayer.Layer) >= 12 {
+		return h.ImageHistoryLayer.Layer[0:12]
+	}
+	return h.ImageHistoryLayer.Layer
+}
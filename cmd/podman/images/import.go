@@ -75,6 +75,8 @@ func importFlags(cmd *cobra.Command) {
 	flags.StringVarP(&importOpts.Message, messageFlagName, "m", "", "Set commit message for imported image")
 	_ = cmd.RegisterFlagCompletionFunc(messageFlagName, completion.AutocompleteNone)
 
+	flags.BoolVar(&importOpts.IncludeVolumes, "include-volumes", false, "Re-create the volumes of a container exported with --include-volumes")
+	flags.BoolVar(&importOpts.VolumeOptions, "volume-options", false, "Also restore the drivers and mount options of the volumes imported with --include-volumes")
 	flags.BoolVarP(&importOpts.Quiet, "quiet", "q", false, "Suppress output")
 	flags.StringVar(&importOpts.SignaturePolicy, "signature-policy", "", "Path to a signature-policy file")
 	_ = flags.MarkHidden("signature-policy")
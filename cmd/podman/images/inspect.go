@@ -1,10 +1,18 @@
 package images
 
 import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/containers/common/pkg/report"
 	"github.com/containers/podman/v2/cmd/podman/common"
 	"github.com/containers/podman/v2/cmd/podman/inspect"
 	"github.com/containers/podman/v2/cmd/podman/registry"
 	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -21,6 +29,8 @@ var (
   podman inspect --format "image: {{.ImageName}} driver: {{.Driver}}" myctr`,
 	}
 	inspectOpts *entities.InspectOptions
+
+	explainResolution bool
 )
 
 func init() {
@@ -35,9 +45,61 @@ func init() {
 	formatFlagName := "format"
 	flags.StringVarP(&inspectOpts.Format, formatFlagName, "f", "json", "Format the output to a Go template or json")
 	_ = inspectCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteJSONFormat)
+
+	flags.BoolVar(&explainResolution, "explain-resolution", false, "Show how the image names are resolved to local images instead of inspecting them")
 }
 
 func inspectExec(cmd *cobra.Command, args []string) error {
+	if explainResolution {
+		return explainResolutionExec(args)
+	}
 	inspectOpts.Type = inspect.ImageType
 	return inspect.Inspect(args, *inspectOpts)
 }
+
+func explainResolutionExec(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no names specified")
+	}
+	reports, err := registry.ImageEngine().ExplainResolution(registry.GetContext(), args)
+	if err != nil {
+		return err
+	}
+
+	if report.IsJSON(inspectOpts.Format) {
+		b, err := json.MarshalIndent(reports, "", "    ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+	} else {
+		format := report.NormalizeFormat(inspectOpts.Format)
+		if !strings.HasSuffix(format, "\n") {
+			format += "\n"
+		}
+		tmpl, err := template.New("explain resolution").Parse(format)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 8, 2, 2, ' ', 0)
+		for _, r := range reports {
+			if err := tmpl.Execute(w, r); err != nil {
+				return err
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	var unresolved []string
+	for _, r := range reports {
+		if r.Error != "" {
+			unresolved = append(unresolved, r.Input)
+		}
+	}
+	if len(unresolved) > 0 {
+		return errors.Errorf("unable to resolve %s", strings.Join(unresolved, ", "))
+	}
+	return nil
+}
@@ -61,6 +61,8 @@ func init() {
 func loadFlags(cmd *cobra.Command) {
 	flags := cmd.Flags()
 
+	flags.BoolVar(&loadOpts.Delta, "delta", false, "Load a delta archive created with 'podman save --delta-base', taking the omitted layers from local storage")
+
 	inputFlagName := "input"
 	flags.StringVarP(&loadOpts.Input, inputFlagName, "i", "", "Read from specified archive file (default: stdin)")
 	_ = cmd.RegisterFlagCompletionFunc(inputFlagName, completion.AutocompleteDefault)
@@ -3,7 +3,6 @@ package images
 import (
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/containers/common/pkg/auth"
 	"github.com/containers/common/pkg/completion"
@@ -22,6 +21,7 @@ type pullOptionsWrapper struct {
 	entities.ImagePullOptions
 	TLSVerifyCLI   bool // CLI only
 	CredentialsCLI string
+	RateLimitCLI   string
 }
 
 var (
@@ -84,6 +84,18 @@ func pullFlags(cmd *cobra.Command) {
 	flags.StringVar(&pullOptions.CredentialsCLI, credsFlagName, "", "`Credentials` (USERNAME:PASSWORD) to use for authenticating to a registry")
 	_ = cmd.RegisterFlagCompletionFunc(credsFlagName, completion.AutocompleteNone)
 
+	archFlagName := "arch"
+	flags.StringVar(&pullOptions.OverrideArch, archFlagName, "", "Use `ARCH` instead of the architecture of the machine for choosing images")
+	_ = cmd.RegisterFlagCompletionFunc(archFlagName, completion.AutocompleteNone)
+
+	osFlagName := "os"
+	flags.StringVar(&pullOptions.OverrideOS, osFlagName, "", "Use `OS` instead of the running OS for choosing images")
+	_ = cmd.RegisterFlagCompletionFunc(osFlagName, completion.AutocompleteNone)
+
+	variantFlagName := "variant"
+	flags.StringVar(&pullOptions.OverrideVariant, variantFlagName, "", "Use `VARIANT` instead of the running architecture variant for choosing images")
+	_ = cmd.RegisterFlagCompletionFunc(variantFlagName, completion.AutocompleteNone)
+
 	overrideArchFlagName := "override-arch"
 	flags.StringVar(&pullOptions.OverrideArch, overrideArchFlagName, "", "Use `ARCH` instead of the architecture of the machine for choosing images")
 	_ = cmd.RegisterFlagCompletionFunc(overrideArchFlagName, completion.AutocompleteNone)
@@ -97,7 +109,7 @@ func pullFlags(cmd *cobra.Command) {
 	_ = cmd.RegisterFlagCompletionFunc(overrideVariantFlagName, completion.AutocompleteNone)
 
 	platformFlagName := "platform"
-	flags.String(platformFlagName, "", "Specify the platform for selecting the image.  (Conflicts with override-arch and override-os)")
+	flags.String(platformFlagName, "", "Specify the `OS/ARCH[/VARIANT]` platform for selecting the image.  (Conflicts with arch, os and variant)")
 	_ = cmd.RegisterFlagCompletionFunc(platformFlagName, completion.AutocompleteNone)
 
 	flags.Bool("disable-content-trust", false, "This is a Docker specific option and is a NOOP")
@@ -115,6 +127,9 @@ func pullFlags(cmd *cobra.Command) {
 		flags.StringVar(&pullOptions.CertDir, certDirFlagName, "", "`Pathname` of a directory containing TLS certificates and keys")
 		_ = cmd.RegisterFlagCompletionFunc(certDirFlagName, completion.AutocompleteDefault)
 
+		rateLimitFlagName := "rate-limit"
+		flags.StringVar(&pullOptions.RateLimitCLI, rateLimitFlagName, "", "Limit the bandwidth used for pulling the image to `RATE` per second (e.g., 10m)")
+		_ = cmd.RegisterFlagCompletionFunc(rateLimitFlagName, completion.AutocompleteNone)
 	}
 	_ = flags.MarkHidden("signature-policy")
 }
@@ -138,14 +153,21 @@ func imagePull(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	if platform != "" {
-		if pullOptions.OverrideArch != "" || pullOptions.OverrideOS != "" {
-			return errors.Errorf("--platform option can not be specified with --override-arch or --override-os")
+		if pullOptions.OverrideArch != "" || pullOptions.OverrideOS != "" || pullOptions.OverrideVariant != "" {
+			return errors.Errorf("--platform option can not be specified with --arch, --os or --variant")
+		}
+		pullOptions.OverrideOS, pullOptions.OverrideArch, pullOptions.OverrideVariant, err = util.ParsePlatform(platform)
+		if err != nil {
+			return err
 		}
-		split := strings.SplitN(platform, "/", 2)
-		pullOptions.OverrideOS = split[0]
-		if len(split) > 1 {
-			pullOptions.OverrideArch = split[1]
+	}
+
+	if pullOptions.RateLimitCLI != "" {
+		rateLimit, err := util.ParseRateLimit(pullOptions.RateLimitCLI)
+		if err != nil {
+			return err
 		}
+		pullOptions.RateLimit = rateLimit
 	}
 
 	if pullOptions.CredentialsCLI != "" {
@@ -19,6 +19,7 @@ type pushOptionsWrapper struct {
 	entities.ImagePushOptions
 	TLSVerifyCLI   bool // CLI only
 	CredentialsCLI string
+	RateLimitCLI   string
 }
 
 var (
@@ -100,6 +101,11 @@ func pushFlags(cmd *cobra.Command) {
 	_ = cmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteManifestFormat)
 
 	flags.BoolVarP(&pushOptions.Quiet, "quiet", "q", false, "Suppress output information when pushing images")
+
+	rateLimitFlagName := "rate-limit"
+	flags.StringVar(&pushOptions.RateLimitCLI, rateLimitFlagName, "", "Limit the bandwidth used for pushing the image to `RATE` per second (e.g., 10m)")
+	_ = cmd.RegisterFlagCompletionFunc(rateLimitFlagName, completion.AutocompleteNone)
+
 	flags.BoolVar(&pushOptions.RemoveSignatures, "remove-signatures", false, "Discard any pre-existing signatures in the image")
 	flags.StringVar(&pushOptions.SignaturePolicy, "signature-policy", "", "Path to a signature-policy file")
 
@@ -113,6 +119,7 @@ func pushFlags(cmd *cobra.Command) {
 		_ = flags.MarkHidden("cert-dir")
 		_ = flags.MarkHidden("compress")
 		_ = flags.MarkHidden("quiet")
+		_ = flags.MarkHidden("rate-limit")
 	}
 	_ = flags.MarkHidden("signature-policy")
 }
@@ -136,6 +143,14 @@ func imagePush(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if pushOptions.RateLimitCLI != "" {
+		rateLimit, err := util.ParseRateLimit(pushOptions.RateLimitCLI)
+		if err != nil {
+			return err
+		}
+		pushOptions.RateLimit = rateLimit
+	}
+
 	if pushOptions.CredentialsCLI != "" {
 		creds, err := util.ParseRegistryCreds(pushOptions.CredentialsCLI)
 		if err != nil {
@@ -2,6 +2,7 @@ package images
 
 import (
 	"context"
+	"io/ioutil"
 	"os"
 	"strings"
 
@@ -62,7 +63,8 @@ var (
 )
 
 var (
-	saveOpts entities.ImageSaveOptions
+	saveOpts  entities.ImageSaveOptions
+	deltaBase string
 )
 
 func init() {
@@ -85,6 +87,10 @@ func saveFlags(cmd *cobra.Command) {
 
 	flags.BoolVar(&saveOpts.Compress, "compress", false, "Compress tarball image layers when saving to a directory using the 'dir' transport. (default is same compression type as source)")
 
+	deltaBaseFlagName := "delta-base"
+	flags.StringVar(&deltaBase, deltaBaseFlagName, "", "Write a delta archive omitting the layers whose digests are listed in the `file`")
+	_ = cmd.RegisterFlagCompletionFunc(deltaBaseFlagName, completion.AutocompleteDefault)
+
 	formatFlagName := "format"
 	flags.StringVar(&saveOpts.Format, formatFlagName, define.V2s2Archive, "Save image to oci-archive, oci-dir (directory with oci manifest type), docker-archive, docker-dir (directory with v2s2 manifest type)")
 	_ = cmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteImageSaveFormat)
@@ -105,6 +111,19 @@ func save(cmd *cobra.Command, args []string) (finalErr error) {
 	if cmd.Flag("compress").Changed && (saveOpts.Format != define.OCIManifestDir && saveOpts.Format != define.V2s2ManifestDir && saveOpts.Format == "") {
 		return errors.Errorf("--compress can only be set when --format is either 'oci-dir' or 'docker-dir'")
 	}
+	if cmd.Flag("delta-base").Changed {
+		if cmd.Flag("format").Changed && saveOpts.Format != define.V2s2Archive {
+			return errors.Errorf("--delta-base can only be used with the %s format", define.V2s2Archive)
+		}
+		if len(args) > 1 || saveOpts.MultiImageArchive {
+			return errors.New("--delta-base can only be used to save a single image")
+		}
+		layers, err := readDeltaBase(deltaBase)
+		if err != nil {
+			return err
+		}
+		saveOpts.DeltaBase = layers
+	}
 	if len(saveOpts.Output) == 0 {
 		saveOpts.Quiet = true
 		fi := os.Stdout
@@ -141,3 +160,14 @@ func save(cmd *cobra.Command, args []string) (finalErr error) {
 	}
 	return err
 }
+
+// readDeltaBase reads the layer digests listed in path, separated by
+// whitespace.
+func readDeltaBase(path string) ([]string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading delta base %s", path)
+	}
+	// An empty file still selects a delta archive, so never return nil.
+	return append([]string{}, strings.Fields(string(content))...), nil
+}
@@ -26,6 +26,13 @@ type searchOptionsWrapper struct {
 	Format       string // For go templating
 }
 
+// listTagsReport is the JSON output of --list-tags.
+type listTagsReport struct {
+	Name   string
+	Tag    string
+	Digest string `json:",omitempty"`
+}
+
 var (
 	searchOptions     = searchOptionsWrapper{}
 	searchDescription = `Search registries for a given image. Can search all the default registries or a specific registry.
@@ -100,6 +107,12 @@ func searchFlags(cmd *cobra.Command) {
 	flags.StringVar(&searchOptions.Authfile, authfileFlagName, auth.GetDefaultAuthFile(), "Path of the authentication file. Use REGISTRY_AUTH_FILE environment variable to override")
 	_ = cmd.RegisterFlagCompletionFunc(authfileFlagName, completion.AutocompleteDefault)
 
+	if !registry.IsRemote() {
+		certDirFlagName := "cert-dir"
+		flags.StringVar(&searchOptions.CertDir, certDirFlagName, "", "`Pathname` of a directory containing TLS certificates and keys")
+		_ = cmd.RegisterFlagCompletionFunc(certDirFlagName, completion.AutocompleteDefault)
+	}
+
 	flags.BoolVar(&searchOptions.TLSVerifyCLI, "tls-verify", true, "Require HTTPS and verify certificates when contacting registries")
 	flags.BoolVar(&searchOptions.ListTags, "list-tags", false, "List the tags of the input registry")
 }
@@ -132,6 +145,12 @@ func imageSearch(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Looking up the digests requires a request per tag, so only do it
+	// when they are part of the output.
+	if searchOptions.ListTags && report.IsJSON(searchOptions.Format) {
+		searchOptions.TagDigests = true
+	}
+
 	searchReport, err := registry.ImageEngine().Search(registry.GetContext(), searchTerm, searchOptions.ImageSearchOptions)
 	if err != nil {
 		return err
@@ -145,10 +164,18 @@ func imageSearch(cmd *cobra.Command, args []string) error {
 	renderHeaders := true
 	var row string
 	switch {
-	case searchOptions.ListTags:
-		if len(searchOptions.Filters) != 0 {
-			return errors.Errorf("filters are not applicable to list tags result")
+	case searchOptions.ListTags && report.IsJSON(searchOptions.Format):
+		tagsReport := make([]listTagsReport, 0, len(searchReport))
+		for _, r := range searchReport {
+			tagsReport = append(tagsReport, listTagsReport{Name: r.Name, Tag: r.Tag, Digest: r.Digest})
 		}
+		prettyJSON, err := json.MarshalIndent(tagsReport, "", "    ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(prettyJSON))
+		return nil
+	case searchOptions.ListTags:
 		row = "{{.Name}}\t{{.Tag}}\n"
 	case report.IsJSON(searchOptions.Format):
 		prettyJSON, err := json.MarshalIndent(searchReport, "", "    ")
@@ -6,19 +6,22 @@ import (
 	"github.com/containers/podman/v2/cmd/podman/common"
 	"github.com/containers/podman/v2/cmd/podman/registry"
 	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 var (
 	treeDescription = "Prints layer hierarchy of an image in a tree format"
 	treeCmd         = &cobra.Command{
-		Use:               "tree [options] IMAGE",
-		Args:              cobra.ExactArgs(1),
+		Use:               "tree [options] [IMAGE]",
+		Args:              cobra.MaximumNArgs(1),
 		Short:             treeDescription,
 		Long:              treeDescription,
 		RunE:              tree,
 		ValidArgsFunction: common.AutocompleteImages,
-		Example:           "podman image tree alpine:latest",
+		Example: `podman image tree alpine:latest
+  podman image tree --shared alpine:latest
+  podman image tree --shared`,
 	}
 	treeOpts entities.ImageTreeOptions
 )
@@ -29,11 +32,23 @@ func init() {
 		Command: treeCmd,
 		Parent:  imageCmd,
 	})
-	treeCmd.Flags().BoolVar(&treeOpts.WhatRequires, "whatrequires", false, "Show all child images and layers of the specified image")
+	flags := treeCmd.Flags()
+	flags.BoolVar(&treeOpts.WhatRequires, "whatrequires", false, "Show all child images and layers of the specified image")
+	flags.BoolVar(&treeOpts.Shared, "shared", false, "Show the other images using the layers of the specified image, or of all images, and the size reclaimed by removing it")
 }
 
 func tree(_ *cobra.Command, args []string) error {
-	results, err := registry.ImageEngine().Tree(registry.Context(), args[0], treeOpts)
+	if treeOpts.WhatRequires && treeOpts.Shared {
+		return errors.New("--whatrequires and --shared cannot be used together")
+	}
+	var nameOrID string
+	switch {
+	case len(args) > 0:
+		nameOrID = args[0]
+	case !treeOpts.Shared:
+		return errors.New("an image must be specified unless --shared is set")
+	}
+	results, err := registry.ImageEngine().Tree(registry.Context(), nameOrID, treeOpts)
 	if err != nil {
 		return err
 	}
@@ -2,21 +2,29 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/containers/common/pkg/auth"
 	"github.com/containers/common/pkg/completion"
+	"github.com/containers/image/v5/pkg/docker/config"
 	"github.com/containers/image/v5/types"
 	"github.com/containers/podman/v2/cmd/podman/common"
 	"github.com/containers/podman/v2/cmd/podman/registry"
 	"github.com/containers/podman/v2/pkg/domain/entities"
 	"github.com/containers/podman/v2/pkg/registries"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 type loginOptionsWrapper struct {
 	auth.LoginOptions
 	tlsVerify bool
+	verbose   bool
 }
 
 var (
@@ -52,6 +60,7 @@ func init() {
 
 	// Podman flags.
 	flags.BoolVarP(&loginOptions.tlsVerify, "tls-verify", "", false, "Require HTTPS and verify certificates when contacting registries")
+	flags.BoolVarP(&loginOptions.verbose, "verbose", "v", false, "Write more detailed information to stdout")
 	loginOptions.Stdin = os.Stdin
 	loginOptions.Stdout = os.Stdout
 	loginOptions.AcceptUnspecifiedRegistry = true
@@ -72,5 +81,116 @@ func login(cmd *cobra.Command, args []string) error {
 		SystemRegistriesConfPath:    registries.SystemRegistriesConfPath(),
 	}
 	loginOptions.GetLoginSet = cmd.Flag("get-login").Changed
-	return auth.Login(context.Background(), &sysCtx, &loginOptions.LoginOptions, args)
+
+	if len(args) == 1 {
+		done, err := loginWithIdentityToken(&sysCtx, registryName(args[0]), args)
+		if done || err != nil {
+			return err
+		}
+	}
+
+	if err := auth.Login(context.Background(), &sysCtx, &loginOptions.LoginOptions, args); err != nil {
+		return err
+	}
+	if loginOptions.verbose && !loginOptions.GetLoginSet {
+		server := ""
+		if len(args) == 1 {
+			server = registryName(args[0])
+		}
+		fmt.Fprintf(loginOptions.Stdout, "Used: %s\n", credentialsDestination(&sysCtx, server))
+	}
+	return nil
+}
+
+// loginWithIdentityToken handles credentials of the registry which consist of
+// an identity token (e.g., written by `docker login` for some registries).
+// The auth package refuses to log in with such credentials, so either report
+// the existing login or replace the token once the new credentials have been
+// accepted by the registry.
+// It returns true if the login has been handled.
+func loginWithIdentityToken(sysCtx *types.SystemContext, server string, args []string) (bool, error) {
+	authConfig, err := config.GetCredentials(sysCtx, server)
+	if err != nil {
+		return false, errors.Wrapf(err, "error reading auth file")
+	}
+	if authConfig.IdentityToken == "" {
+		return false, nil
+	}
+	if loginOptions.GetLoginSet {
+		return true, errors.Errorf("logged into %s with an identity token, no username available", server)
+	}
+	if loginOptions.Username == "" && loginOptions.Password == "" && !loginOptions.StdinPassword {
+		fmt.Fprintln(loginOptions.Stdout, "Existing identity token found. Already logged in to", server)
+		return true, nil
+	}
+
+	// Log in with an empty auth file, so the identity token is kept if the
+	// registry rejects the new credentials.  The empty credentials hide the
+	// identity token from the auth package.
+	tmpDir, err := ioutil.TempDir("", "podman-login")
+	if err != nil {
+		return true, err
+	}
+	defer os.RemoveAll(tmpDir)
+	tmpOptions := loginOptions.LoginOptions
+	tmpOptions.AuthFile = filepath.Join(tmpDir, "auth.json")
+	tmpCtx := *sysCtx
+	tmpCtx.AuthFilePath = tmpOptions.AuthFile
+	tmpCtx.DockerAuthConfig = &types.DockerAuthConfig{}
+	if err := auth.Login(context.Background(), &tmpCtx, &tmpOptions, args); err != nil {
+		return true, err
+	}
+	tmpCtx.DockerAuthConfig = nil
+	newConfig, err := config.GetCredentials(&tmpCtx, server)
+	if err != nil {
+		return true, errors.Wrapf(err, "error reading credentials for %s", server)
+	}
+
+	if loginOptions.verbose {
+		fmt.Fprintf(loginOptions.Stdout, "Replacing identity token for %s\n", server)
+	}
+	if err := config.RemoveAuthentication(sysCtx, server); err != nil {
+		return true, errors.Wrapf(err, "error removing identity token for %s", server)
+	}
+	if err := config.SetAuthentication(sysCtx, server, newConfig.Username, newConfig.Password); err != nil {
+		return true, err
+	}
+	if loginOptions.verbose {
+		fmt.Fprintf(loginOptions.Stdout, "Used: %s\n", credentialsDestination(sysCtx, server))
+	}
+	return true, nil
+}
+
+// registryName strips the scheme and the repository from the specified
+// server, i.e., "https://quay.io/foo/bar" becomes "quay.io".
+func registryName(server string) string {
+	server = strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+	return strings.Split(server, "/")[0]
+}
+
+// credentialsDestination returns a description of where the credentials for
+// the server are stored, i.e., the credential helper configured for the
+// server in the auth file or the auth file itself.
+func credentialsDestination(sysCtx *types.SystemContext, server string) string {
+	authFile := sysCtx.AuthFilePath
+	if authFile == "" {
+		authFile = auth.GetDefaultAuthFile()
+	}
+	if authFile == "" {
+		// The default auth file is chosen by containers/image.
+		return "default auth file"
+	}
+	if server != "" {
+		var authContent struct {
+			CredHelpers map[string]string `json:"credHelpers,omitempty"`
+		}
+		if content, err := ioutil.ReadFile(authFile); err == nil {
+			if err := json.Unmarshal(content, &authContent); err == nil {
+				if helper, ok := authContent.CredHelpers[server]; ok {
+					return fmt.Sprintf("credential helper docker-credential-%s", helper)
+				}
+			}
+		}
+	}
+	return authFile
 }
@@ -10,10 +10,12 @@ import (
 	_ "github.com/containers/podman/v2/cmd/podman/healthcheck"
 	_ "github.com/containers/podman/v2/cmd/podman/images"
 	_ "github.com/containers/podman/v2/cmd/podman/manifest"
+	_ "github.com/containers/podman/v2/cmd/podman/names"
 	_ "github.com/containers/podman/v2/cmd/podman/networks"
 	_ "github.com/containers/podman/v2/cmd/podman/play"
 	_ "github.com/containers/podman/v2/cmd/podman/pods"
 	"github.com/containers/podman/v2/cmd/podman/registry"
+	_ "github.com/containers/podman/v2/cmd/podman/secrets"
 	_ "github.com/containers/podman/v2/cmd/podman/system"
 	_ "github.com/containers/podman/v2/cmd/podman/system/connection"
 	_ "github.com/containers/podman/v2/cmd/podman/volumes"
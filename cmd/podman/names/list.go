@@ -0,0 +1,135 @@
+package names
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"text/template"
+	"time"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v2/cmd/podman/common"
+	"github.com/containers/podman/v2/cmd/podman/parse"
+	"github.com/containers/podman/v2/cmd/podman/registry"
+	"github.com/containers/podman/v2/cmd/podman/validate"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lsDescription = `
+podman name ls
+
+List the reserved names. The output can be changed to JSON or a user specified Go template.`
+	lsCommand = &cobra.Command{
+		Use:               "ls [options]",
+		Aliases:           []string{"list"},
+		Args:              validate.NoArgs,
+		Short:             "List reserved names",
+		Long:              lsDescription,
+		RunE:              list,
+		ValidArgsFunction: completion.AutocompleteNone,
+	}
+)
+
+var (
+	lsOpts = struct {
+		Format    string
+		NoHeading bool
+		Quiet     bool
+	}{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Mode:    []entities.EngineMode{entities.ABIMode, entities.TunnelMode},
+		Command: lsCommand,
+		Parent:  nameCmd,
+	})
+	flags := lsCommand.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&lsOpts.Format, formatFlagName, "{{.Name}}\t{{.Owner}}\t{{.Created}}\t{{.Expires}}\n", "Format reserved name output using Go template")
+	_ = lsCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteJSONFormat)
+
+	flags.BoolVarP(&lsOpts.NoHeading, "noheading", "n", false, "Do not print headers")
+	flags.BoolVarP(&lsOpts.Quiet, "quiet", "q", false, "Print reserved names only")
+}
+
+func list(cmd *cobra.Command, args []string) error {
+	if lsOpts.Quiet && cmd.Flag("format").Changed {
+		return errors.New("quiet and format flags cannot be used together")
+	}
+	responses, err := registry.ContainerEngine().NameList(registry.Context())
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case report.IsJSON(lsOpts.Format):
+		return outputJSON(responses)
+	case lsOpts.Quiet:
+		for _, r := range responses {
+			fmt.Println(r.Name)
+		}
+		return nil
+	}
+
+	lsReports := make([]lsReporter, 0, len(responses))
+	for _, r := range responses {
+		lsReports = append(lsReports, lsReporter{r})
+	}
+
+	headers := report.Headers(lsReporter{}, map[string]string{
+		"Name":    "NAME",
+		"Owner":   "OWNER",
+		"Created": "CREATED",
+		"Expires": "EXPIRES",
+	})
+	renderHeaders := !lsOpts.NoHeading
+	if cmd.Flags().Changed("format") {
+		renderHeaders = renderHeaders && parse.HasTable(lsOpts.Format)
+	}
+	format := parse.EnforceRange(report.NormalizeFormat(lsOpts.Format))
+
+	tmpl, err := template.New("list names").Parse(format)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 12, 2, 2, ' ', 0)
+	defer w.Flush()
+
+	if renderHeaders {
+		if err := tmpl.Execute(w, headers); err != nil {
+			return errors.Wrapf(err, "failed to write report column headers")
+		}
+	}
+	return tmpl.Execute(w, lsReports)
+}
+
+func outputJSON(responses []*entities.NameReservationReport) error {
+	b, err := json.MarshalIndent(responses, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
+
+type lsReporter struct {
+	*entities.NameReservationReport
+}
+
+func (n lsReporter) Created() string {
+	return units.HumanDuration(time.Since(n.NameReservationReport.Created)) + " ago"
+}
+
+func (n lsReporter) Expires() string {
+	if n.NameReservationReport.Expires.IsZero() {
+		return "never"
+	}
+	return "in " + units.HumanDuration(time.Until(n.NameReservationReport.Expires))
+}
@@ -0,0 +1,28 @@
+package names
+
+import (
+	"github.com/containers/podman/v2/cmd/podman/registry"
+	"github.com/containers/podman/v2/cmd/podman/validate"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Pull in configured json library
+	json = registry.JSONLibrary()
+
+	// Command: podman _name_
+	nameCmd = &cobra.Command{
+		Use:   "name",
+		Short: "Manage reserved names",
+		Long:  "Reserve names for containers and pods to be created later, so that generated names never take them in the meantime",
+		RunE:  validate.SubCommandExists,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Mode:    []entities.EngineMode{entities.ABIMode, entities.TunnelMode},
+		Command: nameCmd,
+	})
+}
@@ -0,0 +1,51 @@
+package names
+
+import (
+	"fmt"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v2/cmd/podman/registry"
+	"github.com/containers/podman/v2/cmd/podman/utils"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	releaseDescription = `Release one or more reserved names.`
+	releaseCommand     = &cobra.Command{
+		Use:               "release NAME [NAME...]",
+		Aliases:           []string{"rm"},
+		Short:             "Release one or more reserved names",
+		Long:              releaseDescription,
+		RunE:              release,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example:           `podman name release web`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Mode:    []entities.EngineMode{entities.ABIMode, entities.TunnelMode},
+		Command: releaseCommand,
+		Parent:  nameCmd,
+	})
+}
+
+func release(cmd *cobra.Command, args []string) error {
+	var (
+		errs utils.OutputErrors
+	)
+	responses, err := registry.ContainerEngine().NameRelease(registry.Context(), args)
+	if err != nil {
+		return err
+	}
+	for _, r := range responses {
+		if r.Err == nil {
+			fmt.Println(r.Name)
+		} else {
+			errs = append(errs, r.Err)
+		}
+	}
+	return errs.PrintErrors()
+}
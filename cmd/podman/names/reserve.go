@@ -0,0 +1,55 @@
+package names
+
+import (
+	"fmt"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v2/cmd/podman/registry"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reserveDescription = `Reserve a name for a container or pod.
+
+  A name is generated with the name template of containers.conf if none is given. Generated names never use a reserved name. Only the user who reserved the name can create a container or pod with it, which consumes the reservation.`
+	reserveCommand = &cobra.Command{
+		Use:               "reserve [options] [NAME]",
+		Short:             "Reserve a name",
+		Long:              reserveDescription,
+		RunE:              reserve,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman name reserve web
+  podman name reserve --ttl 10m`,
+	}
+)
+
+var (
+	reserveOptions = entities.NameReserveOptions{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Mode:    []entities.EngineMode{entities.ABIMode, entities.TunnelMode},
+		Command: reserveCommand,
+		Parent:  nameCmd,
+	})
+	flags := reserveCommand.Flags()
+
+	ttlFlagName := "ttl"
+	flags.DurationVar(&reserveOptions.TTL, ttlFlagName, 0, "How long the name is reserved, 0 reserves it until it is released or used")
+	_ = reserveCommand.RegisterFlagCompletionFunc(ttlFlagName, completion.AutocompleteNone)
+}
+
+func reserve(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		reserveOptions.Name = args[0]
+	}
+	report, err := registry.ContainerEngine().NameReserve(registry.Context(), reserveOptions)
+	if err != nil {
+		return err
+	}
+	fmt.Println(report.Name)
+	return nil
+}
@@ -31,6 +31,9 @@ var (
 	networkCreateOptions entities.NetworkCreateOptions
 	labels               []string
 	opts                 []string
+	subnets              []string
+	gateways             []string
+	ipRanges             []string
 )
 
 func networkCreateFlags(cmd *cobra.Command) {
@@ -45,13 +48,13 @@ func networkCreateFlags(cmd *cobra.Command) {
 	_ = cmd.RegisterFlagCompletionFunc(optFlagName, completion.AutocompleteNone)
 
 	gatewayFlagName := "gateway"
-	flags.IPVar(&networkCreateOptions.Gateway, gatewayFlagName, nil, "IPv4 or IPv6 gateway for the subnet")
+	flags.StringArrayVar(&gateways, gatewayFlagName, nil, "IPv4 or IPv6 gateway for the subnet")
 	_ = cmd.RegisterFlagCompletionFunc(gatewayFlagName, completion.AutocompleteNone)
 
 	flags.BoolVar(&networkCreateOptions.Internal, "internal", false, "restrict external access from this network")
 
 	ipRangeFlagName := "ip-range"
-	flags.IPNetVar(&networkCreateOptions.Range, ipRangeFlagName, net.IPNet{}, "allocate container IP from range")
+	flags.StringArrayVar(&ipRanges, ipRangeFlagName, nil, "allocate container IP from range")
 	_ = cmd.RegisterFlagCompletionFunc(ipRangeFlagName, completion.AutocompleteNone)
 
 	macvlanFlagName := "macvlan"
@@ -68,7 +71,7 @@ func networkCreateFlags(cmd *cobra.Command) {
 	flags.BoolVar(&networkCreateOptions.IPv6, "ipv6", false, "enable IPv6 networking")
 
 	subnetFlagName := "subnet"
-	flags.IPNetVar(&networkCreateOptions.Subnet, subnetFlagName, net.IPNet{}, "subnet in CIDR format")
+	flags.StringArrayVar(&subnets, subnetFlagName, nil, "subnet in CIDR format")
 	_ = cmd.RegisterFlagCompletionFunc(subnetFlagName, completion.AutocompleteNone)
 
 	flags.BoolVar(&networkCreateOptions.DisableDNS, "disable-dns", false, "disable dns plugin")
@@ -102,6 +105,10 @@ func networkCreate(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return errors.Wrapf(err, "unable to process options")
 	}
+	networkCreateOptions.Subnets, err = parseSubnets(subnets, gateways, ipRanges)
+	if err != nil {
+		return err
+	}
 	response, err := registry.ContainerEngine().NetworkCreate(registry.Context(), name, networkCreateOptions)
 	if err != nil {
 		return err
@@ -109,3 +116,63 @@ func networkCreate(cmd *cobra.Command, args []string) error {
 	fmt.Println(response.Filename)
 	return nil
 }
+
+// parseSubnets pairs every gateway and ip range with the subnet containing it.
+// If only one subnet is given, gateways and ip ranges belong to it and are
+// validated when creating the network.
+func parseSubnets(subnets, gateways, ipRanges []string) ([]entities.NetworkSubnet, error) {
+	var networkSubnets []entities.NetworkSubnet
+	for _, s := range subnets {
+		_, subnet, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid subnet %q", s)
+		}
+		networkSubnets = append(networkSubnets, entities.NetworkSubnet{Subnet: *subnet})
+	}
+	if len(networkSubnets) == 0 && (len(gateways) > 0 || len(ipRanges) > 0) {
+		networkSubnets = append(networkSubnets, entities.NetworkSubnet{})
+	}
+
+	// subnetFor returns the subnet the ip belongs to
+	subnetFor := func(ip net.IP, option, value string) (*entities.NetworkSubnet, error) {
+		if len(networkSubnets) == 1 {
+			return &networkSubnets[0], nil
+		}
+		for i := range networkSubnets {
+			if networkSubnets[i].Subnet.Contains(ip) {
+				return &networkSubnets[i], nil
+			}
+		}
+		return nil, errors.Errorf("%s %s does not belong to any subnet", option, value)
+	}
+
+	for _, g := range gateways {
+		gateway := net.ParseIP(g)
+		if gateway == nil {
+			return nil, errors.Errorf("invalid gateway %q", g)
+		}
+		s, err := subnetFor(gateway, "gateway", g)
+		if err != nil {
+			return nil, err
+		}
+		if s.Gateway != nil {
+			return nil, errors.Errorf("only one gateway can be set for subnet %s", s.Subnet.String())
+		}
+		s.Gateway = gateway
+	}
+	for _, r := range ipRanges {
+		_, ipRange, err := net.ParseCIDR(r)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid ip-range %q", r)
+		}
+		s, err := subnetFor(ipRange.IP, "ip-range", r)
+		if err != nil {
+			return nil, err
+		}
+		if s.Range.IP != nil {
+			return nil, errors.Errorf("only one ip-range can be set for subnet %s", s.Subnet.String())
+		}
+		s.Range = *ipRange
+	}
+	return networkSubnets, nil
+}
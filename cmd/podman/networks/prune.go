@@ -0,0 +1,102 @@
+package network
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v2/cmd/podman/common"
+	"github.com/containers/podman/v2/cmd/podman/registry"
+	"github.com/containers/podman/v2/cmd/podman/utils"
+	"github.com/containers/podman/v2/cmd/podman/validate"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	networkPruneDescription = `Prune unused networks
+
+  Networks that are not used by any container, running or not, are removed.
+  The default network is never removed.`
+	networkPruneCommand = &cobra.Command{
+		Use:               "prune [options]",
+		Short:             "Prune unused networks",
+		Long:              networkPruneDescription,
+		RunE:              networkPrune,
+		Example:           `podman network prune`,
+		Args:              validate.NoArgs,
+		ValidArgsFunction: completion.AutocompleteNone,
+	}
+)
+
+var (
+	networkPruneOptions entities.NetworkPruneOptions
+	networkPruneFilter  []string
+	force               bool
+)
+
+func networkPruneFlags(flags *pflag.FlagSet) {
+	flags.BoolVarP(&force, "force", "f", false, "do not prompt for confirmation")
+	flags.BoolVar(&networkPruneOptions.Leaked, "leaked", false, "release network resources of containers that no longer exist instead of removing unused networks")
+	filterFlagName := "filter"
+	flags.StringArrayVar(&networkPruneFilter, filterFlagName, []string{}, "Provide filter values (e.g. 'label=<key>=<value>')")
+	_ = networkPruneCommand.RegisterFlagCompletionFunc(filterFlagName, common.AutocompleteNetworkPruneFilters)
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Mode:    []entities.EngineMode{entities.ABIMode, entities.TunnelMode},
+		Command: networkPruneCommand,
+		Parent:  networkCmd,
+	})
+	networkPruneFlags(networkPruneCommand.Flags())
+}
+
+func networkPrune(cmd *cobra.Command, _ []string) error {
+	var errs utils.OutputErrors
+	if networkPruneOptions.Leaked && len(networkPruneFilter) > 0 {
+		return errors.New("--leaked and --filter cannot be used together")
+	}
+	if !force {
+		reader := bufio.NewReader(os.Stdin)
+		if networkPruneOptions.Leaked {
+			fmt.Println("WARNING! This will release the network resources of all containers that no longer exist.")
+		} else {
+			fmt.Println("WARNING! This will remove all networks not used by at least one container.")
+		}
+		fmt.Print("Are you sure you want to continue? [y/N] ")
+		answer, err := reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		if strings.ToLower(answer)[0] != 'y' {
+			return nil
+		}
+	}
+	networkPruneOptions.Filters = make(map[string][]string)
+	for _, f := range networkPruneFilter {
+		split := strings.SplitN(f, "=", 2)
+		if len(split) == 1 {
+			return errors.Errorf("invalid filter %q", f)
+		}
+		networkPruneOptions.Filters[split[0]] = append(networkPruneOptions.Filters[split[0]], split[1])
+	}
+	responses, err := registry.ContainerEngine().NetworkPrune(registry.Context(), networkPruneOptions)
+	if err != nil {
+		setExitCode(err)
+		return err
+	}
+	for _, r := range responses {
+		if r.Error == nil {
+			fmt.Println(r.Name)
+		} else {
+			setExitCode(r.Error)
+			errs = append(errs, r.Error)
+		}
+	}
+	return errs.PrintErrors()
+}
@@ -12,6 +12,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/containers/podman/v2/libpod/define"
 	"github.com/pkg/errors"
 )
 
@@ -52,6 +53,10 @@ func ValidateExtraHost(val string) (string, error) { // nolint
 	if len(arr) != 2 || len(arr[0]) == 0 {
 		return "", fmt.Errorf("bad format for add-host: %q", val)
 	}
+	// host-gateway is replaced by the address of the host
+	if arr[1] == define.HostGateway {
+		return val, nil
+	}
 	if _, err := validateIPAddress(arr[1]); err != nil {
 		return "", fmt.Errorf("invalid IP address in add-host: %q", arr[1])
 	}
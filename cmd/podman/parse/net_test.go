@@ -49,6 +49,7 @@ func TestValidateExtraHost(t *testing.T) {
 		{name: "noname-ipv4", args: args{val: ":192.168.1.1"}, want: "", wantErr: true},
 		{name: "noip", args: args{val: "foobar:"}, want: "", wantErr: true},
 		{name: "noip", args: args{val: "foobar"}, want: "", wantErr: true},
+		{name: "host-gateway", args: args{val: "foobar:host-gateway"}, want: "foobar:host-gateway", wantErr: false},
 		{name: "good-ipv6", args: args{val: "foobar:2001:0db8:85a3:0000:0000:8a2e:0370:7334"}, want: "foobar:2001:0db8:85a3:0000:0000:8a2e:0370:7334", wantErr: false},
 		{name: "bad-ipv6", args: args{val: "foobar:0db8:85a3:0000:0000:8a2e:0370:7334"}, want: "", wantErr: true},
 		{name: "bad-ipv6", args: args{val: "foobar:0db8:85a3:0000:0000:8a2e:0370:7334.0000.0000.000"}, want: "", wantErr: true},
@@ -88,7 +88,7 @@ func init() {
 		_ = kubeCmd.RegisterFlagCompletionFunc(seccompProfileRootFlagName, completion.AutocompleteDefault)
 
 		configmapFlagName := "configmap"
-		flags.StringSliceVar(&kubeOptions.ConfigMaps, configmapFlagName, []string{}, "`Pathname` of a YAML file containing a kubernetes configmap")
+		flags.StringSliceVar(&kubeOptions.ConfigMaps, configmapFlagName, []string{}, "`Pathname` of a YAML file containing kubernetes configmaps or secrets")
 		_ = kubeCmd.RegisterFlagCompletionFunc(configmapFlagName, completion.AutocompleteDefault)
 	}
 	_ = flags.MarkHidden("signature-policy")
@@ -130,6 +130,15 @@ func kube(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if len(report.Volumes) > 0 {
+		fmt.Printf("Volumes:\n")
+		for _, volume := range report.Volumes {
+			fmt.Println(volume)
+		}
+		// Empty line for space for next block
+		fmt.Println()
+	}
+
 	for _, pod := range report.Pods {
 		fmt.Printf("Pod:\n")
 		fmt.Println(pod.ID)
@@ -145,6 +154,12 @@ func kube(cmd *cobra.Command, args []string) error {
 		for _, ctr := range pod.Containers {
 			fmt.Println(ctr)
 		}
+		if len(pod.InitContainers) > 0 {
+			fmt.Printf("Init Containers:\n")
+			for _, ctr := range pod.InitContainers {
+				fmt.Println(ctr)
+			}
+		}
 		// Empty line for space for next block
 		fmt.Println()
 	}
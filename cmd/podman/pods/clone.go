@@ -0,0 +1,115 @@
+package pods
+
+import (
+	"fmt"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v2/cmd/podman/common"
+	"github.com/containers/podman/v2/cmd/podman/parse"
+	"github.com/containers/podman/v2/cmd/podman/registry"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/containers/podman/v2/pkg/util"
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	podCloneDescription = `Creates a new pod with the configuration of an existing pod.
+
+  The infra container and the other containers of the pod are recreated in the new pod with their configuration.  The ports published by the pod are not published by the new pod unless given again.  The ID of the new pod is printed.`
+	cloneCommand = &cobra.Command{
+		Use:               "clone [options] POD",
+		Short:             "Create a copy of an existing pod",
+		Long:              podCloneDescription,
+		RunE:              clone,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompletePods,
+		Example: `podman pod clone mypod
+  podman pod clone --name mypod-copy --start mypod
+  podman pod clone --publish 8081:80 --member-memory 512m mypod`,
+	}
+)
+
+var (
+	cloneOptions      entities.PodCloneOptions
+	cloneLabels       []string
+	clonePublished    []string
+	cloneMemberCPUs   float64
+	cloneMemberMemory string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Mode:    []entities.EngineMode{entities.ABIMode, entities.TunnelMode},
+		Command: cloneCommand,
+		Parent:  podCmd,
+	})
+
+	flags := cloneCommand.Flags()
+	nameFlagName := "name"
+	flags.StringVarP(&cloneOptions.Name, nameFlagName, "n", "", "Assign a name to the clone")
+	_ = cloneCommand.RegisterFlagCompletionFunc(nameFlagName, completion.AutocompleteNone)
+
+	hostnameFlagName := "hostname"
+	flags.StringVar(&cloneOptions.Hostname, hostnameFlagName, "", "Set a hostname to the clone")
+	_ = cloneCommand.RegisterFlagCompletionFunc(hostnameFlagName, completion.AutocompleteNone)
+
+	labelFlagName := "label"
+	flags.StringSliceVarP(&cloneLabels, labelFlagName, "l", []string{}, "Add metadata to the clone, replacing the labels of the pod with the same key")
+	_ = cloneCommand.RegisterFlagCompletionFunc(labelFlagName, completion.AutocompleteNone)
+
+	publishFlagName := "publish"
+	flags.StringSliceVarP(&clonePublished, publishFlagName, "p", []string{}, "Publish a port of the clone to the host")
+	_ = cloneCommand.RegisterFlagCompletionFunc(publishFlagName, completion.AutocompleteNone)
+
+	memberCPUSharesFlagName := "member-cpu-shares"
+	flags.Uint64Var(&cloneOptions.Members.CPUShares, memberCPUSharesFlagName, 0, "CPU shares (relative weight) of each container of the clone")
+	_ = cloneCommand.RegisterFlagCompletionFunc(memberCPUSharesFlagName, completion.AutocompleteNone)
+
+	memberCPUsFlagName := "member-cpus"
+	flags.Float64Var(&cloneMemberCPUs, memberCPUsFlagName, 0, "Number of CPUs of each container of the clone. The default is to keep the limits of the containers")
+	_ = cloneCommand.RegisterFlagCompletionFunc(memberCPUsFlagName, completion.AutocompleteNone)
+
+	memberCpusetCpusFlagName := "member-cpuset-cpus"
+	flags.StringVar(&cloneOptions.Members.CPUSetCPUs, memberCpusetCpusFlagName, "", "CPUs in which the containers of the clone may execute (0-3, 0,1)")
+	_ = cloneCommand.RegisterFlagCompletionFunc(memberCpusetCpusFlagName, completion.AutocompleteNone)
+
+	memberMemoryFlagName := "member-memory"
+	flags.StringVar(&cloneMemberMemory, memberMemoryFlagName, "", "Memory limit of each container of the clone (format: <number>[<unit>], where unit = b (bytes), k (kilobytes), m (megabytes), or g (gigabytes))")
+	_ = cloneCommand.RegisterFlagCompletionFunc(memberMemoryFlagName, completion.AutocompleteNone)
+
+	flags.BoolVar(&cloneOptions.Start, "start", false, "Start the clone after creating it")
+}
+
+func clone(cmd *cobra.Command, args []string) error {
+	var err error
+	cloneOptions.Labels, err = parse.GetAllLabels([]string{}, cloneLabels)
+	if err != nil {
+		return errors.Wrapf(err, "unable to process labels")
+	}
+	cloneOptions.PublishPorts, err = common.CreatePortBindings(clonePublished)
+	if err != nil {
+		return err
+	}
+	if cloneMemberCPUs < 0 {
+		return errors.Errorf("invalid value for --member-cpus: %f", cloneMemberCPUs)
+	}
+	if cloneMemberCPUs > 0 {
+		cloneOptions.Members.CPUPeriod, cloneOptions.Members.CPUQuota = util.CoresToPeriodAndQuota(cloneMemberCPUs)
+	}
+	if cloneMemberMemory != "" {
+		memory, err := units.RAMInBytes(cloneMemberMemory)
+		if err != nil {
+			return errors.Wrapf(err, "invalid value for --member-memory")
+		}
+		cloneOptions.Members.Memory = memory
+	}
+
+	report, err := registry.ContainerEngine().PodClone(registry.GetContext(), args[0], cloneOptions)
+	if err != nil {
+		return err
+	}
+	fmt.Println(report.Id)
+	return nil
+}
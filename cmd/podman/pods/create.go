@@ -16,6 +16,7 @@ import (
 	"github.com/containers/podman/v2/pkg/errorhandling"
 	"github.com/containers/podman/v2/pkg/specgen"
 	"github.com/containers/podman/v2/pkg/util"
+	"github.com/docker/go-units"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -40,9 +41,12 @@ var (
 var (
 	createOptions     entities.PodCreateOptions
 	labels, labelFile []string
+	memoryStr         string
 	podIDFile         string
 	replace           bool
 	share             string
+	shmSizeStr        string
+	userns            string
 )
 
 func init() {
@@ -60,6 +64,10 @@ func init() {
 	flags.StringVar(&createOptions.CGroupParent, cgroupParentflagName, "", "Set parent cgroup for the pod")
 	_ = createCommand.RegisterFlagCompletionFunc(cgroupParentflagName, completion.AutocompleteDefault)
 
+	cpusFlagName := "cpus"
+	flags.Float64Var(&createOptions.CPUS, cpusFlagName, 0, "Number of CPUs the containers of the pod can use together. The default is no limit.")
+	_ = createCommand.RegisterFlagCompletionFunc(cpusFlagName, completion.AutocompleteNone)
+
 	flags.BoolVar(&createOptions.Infra, "infra", true, "Create an infra container associated with the pod to share namespaces with")
 
 	infraConmonPidfileFlagName := "infra-conmon-pidfile"
@@ -74,6 +82,10 @@ func init() {
 	flags.String(infraCommandFlagName, containerConfig.Engine.InfraCommand, "The command to run on the infra container when the pod is started")
 	_ = createCommand.RegisterFlagCompletionFunc(infraCommandFlagName, completion.AutocompleteNone)
 
+	infraNameFlagName := "infra-name"
+	flags.StringVar(&createOptions.InfraName, infraNameFlagName, "", "The name of the infra container of the pod")
+	_ = createCommand.RegisterFlagCompletionFunc(infraNameFlagName, completion.AutocompleteNone)
+
 	labelFileFlagName := "label-file"
 	flags.StringSliceVar(&labelFile, labelFileFlagName, []string{}, "Read in a line delimited file of labels")
 	_ = createCommand.RegisterFlagCompletionFunc(labelFileFlagName, completion.AutocompleteDefault)
@@ -82,6 +94,10 @@ func init() {
 	flags.StringSliceVarP(&labels, labelFlagName, "l", []string{}, "Set metadata on pod (default [])")
 	_ = createCommand.RegisterFlagCompletionFunc(labelFlagName, completion.AutocompleteNone)
 
+	memoryFlagName := "memory"
+	flags.StringVarP(&memoryStr, memoryFlagName, "m", "", "Memory limit of the containers of the pod together (format: `<number>[<unit>]`, where unit = b (bytes), k (kilobytes), m (megabytes), or g (gigabytes))")
+	_ = createCommand.RegisterFlagCompletionFunc(memoryFlagName, completion.AutocompleteNone)
+
 	nameFlagName := "name"
 	flags.StringVarP(&createOptions.Name, nameFlagName, "n", "", "Assign a name to the pod")
 	_ = createCommand.RegisterFlagCompletionFunc(nameFlagName, completion.AutocompleteNone)
@@ -90,6 +106,10 @@ func init() {
 	flags.StringVarP(&createOptions.Hostname, hostnameFlagName, "", "", "Set a hostname to the pod")
 	_ = createCommand.RegisterFlagCompletionFunc(hostnameFlagName, completion.AutocompleteNone)
 
+	pidsLimitFlagName := "pids-limit"
+	flags.Int64Var(&createOptions.PidsLimit, pidsLimitFlagName, 0, "Maximum number of processes of the containers of the pod together. The default is no limit.")
+	_ = createCommand.RegisterFlagCompletionFunc(pidsLimitFlagName, completion.AutocompleteNone)
+
 	podIDFileFlagName := "pod-id-file"
 	flags.StringVar(&podIDFile, podIDFileFlagName, "", "Write the pod ID to the file")
 	_ = createCommand.RegisterFlagCompletionFunc(podIDFileFlagName, completion.AutocompleteDefault)
@@ -100,6 +120,14 @@ func init() {
 	flags.StringVar(&share, shareFlagName, specgen.DefaultKernelNamespaces, "A comma delimited list of kernel namespaces the pod will share")
 	_ = createCommand.RegisterFlagCompletionFunc(shareFlagName, common.AutocompletePodShareNamespace)
 
+	shmSizeFlagName := "shm-size"
+	flags.StringVar(&shmSizeStr, shmSizeFlagName, "", "Size of the /dev/shm shared by the containers of the pod (format: `<number>[<unit>]`, where unit = b (bytes), k (kilobytes), m (megabytes), or g (gigabytes))")
+	_ = createCommand.RegisterFlagCompletionFunc(shmSizeFlagName, completion.AutocompleteNone)
+
+	usernsFlagName := "userns"
+	flags.StringVar(&userns, usernsFlagName, "", "User namespace of the infra container, which is joined by the containers of the pod sharing it (host or keep-id)")
+	_ = createCommand.RegisterFlagCompletionFunc(usernsFlagName, common.AutocompletePodUserNamespace)
+
 	flags.SetNormalizeFunc(aliasNetworkFlag)
 }
 
@@ -131,12 +159,23 @@ func create(cmd *cobra.Command, args []string) error {
 		if cmd.Flag("infra-image").Changed {
 			return errors.New("cannot set infra-image without an infra container")
 		}
+		if cmd.Flag("infra-name").Changed {
+			return errors.New("cannot set infra-name without an infra container")
+		}
+		if cmd.Flag("shm-size").Changed {
+			return errors.New("cannot set shm-size without an infra container")
+		}
+		if cmd.Flag("userns").Changed {
+			return errors.New("cannot set userns without an infra container")
+		}
 		createOptions.InfraImage = ""
 
+		// Without infra container, the namespaces are only shared if
+		// explicitly requested and are held by the first container.
+		createOptions.Share = nil
 		if cmd.Flag("share").Changed && share != "none" && share != "" {
-			return fmt.Errorf("cannot set share(%s) namespaces without an infra container", cmd.Flag("share").Value)
+			createOptions.Share = strings.Split(share, ",")
 		}
-		createOptions.Share = nil
 	} else {
 		createOptions.Share = strings.Split(share, ",")
 		if cmd.Flag("infra-command").Changed {
@@ -155,6 +194,37 @@ func create(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if userns != "" {
+		createOptions.Userns, err = specgen.ParseUserNamespace(userns)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cmd.Flag("shm-size").Changed {
+		shmSize, err := units.FromHumanSize(shmSizeStr)
+		if err != nil {
+			return errors.Wrapf(err, "unable to translate --shm-size")
+		}
+		createOptions.ShmSize = &shmSize
+	}
+
+	if createOptions.CPUS < 0 {
+		return errors.New("--cpus must not be negative")
+	}
+	if cmd.Flag("memory").Changed {
+		createOptions.Memory, err = units.RAMInBytes(memoryStr)
+		if err != nil {
+			return errors.Wrapf(err, "unable to translate --memory")
+		}
+		if createOptions.Memory <= 0 {
+			return errors.New("--memory must be greater than zero")
+		}
+	}
+	if createOptions.PidsLimit < 0 {
+		return errors.New("--pids-limit must not be negative")
+	}
+
 	if cmd.Flag("pod-id-file").Changed {
 		podIDFD, err = util.OpenExclusiveFile(podIDFile)
 		if err != nil && os.IsExist(err) {
@@ -194,6 +264,9 @@ func create(cmd *cobra.Command, args []string) error {
 		default:
 			// Container and NS mode are presently unsupported
 			n.NSMode = specgen.Bridge
+			if len(createOptions.Net.StaticIPs) > 0 || len(createOptions.Net.StaticMACs) > 0 {
+				return errors.Errorf("static addresses cannot be set for each network of a pod, use --ip and --mac-address")
+			}
 			createOptions.Net.CNINetworks = strings.Split(netInput, ",")
 		}
 		createOptions.Net.Network = n
@@ -27,6 +27,7 @@ var (
 		ValidArgsFunction: common.AutocompletePodsRunning,
 		Example: `podman pod kill podID
   podman pod kill --signal TERM mywebserver
+  podman pod kill --signal HUP --exclude-infra mywebserver
   podman pod kill --latest`,
 	}
 )
@@ -43,6 +44,7 @@ func init() {
 	})
 	flags := killCommand.Flags()
 	flags.BoolVarP(&killOpts.All, "all", "a", false, "Kill all containers in all pods")
+	flags.BoolVar(&killOpts.ExcludeInfra, "exclude-infra", false, "Do not send the signal to the infra container of the pod")
 
 	signalFlagName := "signal"
 	flags.StringVarP(&killOpts.Signal, signalFlagName, "s", "KILL", "Signal to send to the containers in the pod")
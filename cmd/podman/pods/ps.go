@@ -69,7 +69,7 @@ func init() {
 	flags.BoolVarP(&psInput.Quiet, "quiet", "q", false, "Print the numeric IDs of the pods only")
 
 	sortFlagName := "sort"
-	flags.StringVar(&psInput.Sort, sortFlagName, "created", "Sort output by created, id, name, or number")
+	flags.StringVar(&psInput.Sort, sortFlagName, "created", "Sort output by created, id, name, number, or status")
 	_ = psCmd.RegisterFlagCompletionFunc(sortFlagName, common.AutocompletePodPsSort)
 
 	validate.AddLatestFlag(psCmd, &psInput.Latest)
@@ -79,14 +79,17 @@ func pods(cmd *cobra.Command, _ []string) error {
 	if psInput.Quiet && len(psInput.Format) > 0 {
 		return errors.New("quiet and format cannot be used together")
 	}
+	if _, ok := podPsSorts[psInput.Sort]; !ok {
+		return errors.Errorf("invalid option for --sort, options are: created, id, name, number, or status")
+	}
 	if cmd.Flag("filter").Changed {
 		psInput.Filters = make(map[string][]string)
 		for _, f := range inputFilters {
-			split := strings.SplitN(f, "=", 2)
-			if len(split) < 2 {
-				return errors.Errorf("filter input must be in the form of filter=value: %s is invalid", f)
+			key, value, err := parsePodPsFilter(f)
+			if err != nil {
+				return err
 			}
-			psInput.Filters[split[0]] = append(psInput.Filters[split[0]], split[1])
+			psInput.Filters[key] = append(psInput.Filters[key], value)
 		}
 	}
 	responses, err := registry.ContainerEngine().PodPs(context.Background(), psInput)
@@ -94,9 +97,7 @@ func pods(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	if err := sortPodPsOutput(psInput.Sort, responses); err != nil {
-		return err
-	}
+	sort.Sort(podPsSorts[psInput.Sort](responses))
 
 	switch {
 	case report.IsJSON(psInput.Format):
@@ -127,6 +128,7 @@ func pods(cmd *cobra.Command, _ []string) error {
 		"NumberOfContainers": "# OF CONTAINERS",
 		"Created":            "CREATED",
 		"InfraID":            "INFRA ID",
+		"ContainerSummary":   "CONTAINERS",
 	})
 	renderHeaders := true
 	row := podPsFormat()
@@ -176,6 +178,20 @@ func podPsFormat() string {
 	return strings.Join(row, "\t") + "\n"
 }
 
+// parsePodPsFilter splits a filter into its key and value.  The filters on
+// numbers also accept comparisons, e.g., ctr-count>2, whose value keeps the
+// comparison operator.
+func parsePodPsFilter(filter string) (string, string, error) {
+	i := strings.IndexAny(filter, "=<>")
+	if i <= 0 {
+		return "", "", errors.Errorf("filter input must be in the form of filter=value: %s is invalid", filter)
+	}
+	if filter[i] == '=' {
+		return filter[:i], filter[i+1:], nil
+	}
+	return filter[:i], filter[i:], nil
+}
+
 // ListPodReporter is a struct for pod ps output
 type ListPodReporter struct {
 	*entities.ListPodsReport
@@ -255,22 +271,32 @@ func (l ListPodReporter) ContainerStatuses() string {
 	return strings.Join(statuses, ",")
 }
 
-func sortPodPsOutput(sortBy string, lprs []*entities.ListPodsReport) error {
-	switch sortBy {
-	case "created":
-		sort.Sort(podPsSortedCreated{lprs})
-	case "id":
-		sort.Sort(podPsSortedID{lprs})
-	case "name":
-		sort.Sort(podPsSortedName{lprs})
-	case "number":
-		sort.Sort(podPsSortedNumber{lprs})
-	case "status":
-		sort.Sort(podPsSortedStatus{lprs})
-	default:
-		return errors.Errorf("invalid option for --sort, options are: id, names, or number")
+// ContainerSummary returns the number of containers of the pod in each
+// status, e.g., "2 running, 1 exited"
+func (l ListPodReporter) ContainerSummary() string {
+	counts := make(map[string]int)
+	statuses := make([]string, 0, len(l.Containers))
+	for _, c := range l.Containers {
+		if counts[c.Status] == 0 {
+			statuses = append(statuses, c.Status)
+		}
+		counts[c.Status]++
 	}
-	return nil
+	sort.Strings(statuses)
+	summary := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		summary = append(summary, fmt.Sprintf("%d %s", counts[status], status))
+	}
+	return strings.Join(summary, ", ")
+}
+
+// podPsSorts are the sorts of --sort
+var podPsSorts = map[string]func(lprSort) sort.Interface{
+	"created": func(l lprSort) sort.Interface { return podPsSortedCreated{l} },
+	"id":      func(l lprSort) sort.Interface { return podPsSortedID{l} },
+	"name":    func(l lprSort) sort.Interface { return podPsSortedName{l} },
+	"number":  func(l lprSort) sort.Interface { return podPsSortedNumber{l} },
+	"status":  func(l lprSort) sort.Interface { return podPsSortedStatus{l} },
 }
 
 type lprSort []*entities.ListPodsReport
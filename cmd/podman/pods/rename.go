@@ -0,0 +1,38 @@
+package pods
+
+import (
+	"github.com/containers/podman/v2/cmd/podman/common"
+	"github.com/containers/podman/v2/cmd/podman/registry"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	podRenameDescription = `Changes the name of an existing pod.
+
+  The pod can be referred to by its ID, the old name is no longer valid afterwards.  The containers of the pod keep their names.`
+
+	renameCommand = &cobra.Command{
+		Use:               "rename POD NAME",
+		Short:             "Rename an existing pod",
+		Long:              podRenameDescription,
+		RunE:              rename,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: common.AutocompletePodOneArg,
+		Example: `podman pod rename mypod webpod
+  podman pod rename 860a4b23 webpod`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Mode:    []entities.EngineMode{entities.ABIMode, entities.TunnelMode},
+		Command: renameCommand,
+		Parent:  podCmd,
+	})
+}
+
+func rename(cmd *cobra.Command, args []string) error {
+	options := entities.PodRenameOptions{NewName: args[1]}
+	return registry.ContainerEngine().PodRename(registry.GetContext(), args[0], options)
+}
@@ -0,0 +1,102 @@
+package pods
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v2/cmd/podman/common"
+	"github.com/containers/podman/v2/cmd/podman/registry"
+	"github.com/containers/podman/v2/cmd/podman/utils"
+	"github.com/containers/podman/v2/cmd/podman/validate"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/containers/podman/v2/pkg/rootless"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	podRestoreDescription = `The pod name or ID can be used.
+
+  The checkpointed containers within each specified pod are restored, beginning with the infra container which sets up the namespaces shared by the pod.`
+	restoreCommand = &cobra.Command{
+		Use:   "restore [options] POD [POD...]",
+		Short: "Restore one or more pods from a checkpoint",
+		Long:  podRestoreDescription,
+		RunE:  restore,
+		Args: func(cmd *cobra.Command, args []string) error {
+			return validate.CheckAllLatestAndCIDFile(cmd, args, true, false)
+		},
+		ValidArgsFunction: common.AutocompletePods,
+		Example: `podman pod restore podID
+  podman pod restore --latest
+  podman pod restore --import /tmp/pod.tar`,
+	}
+)
+
+var (
+	restoreOptions entities.PodRestoreOptions
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Mode:    []entities.EngineMode{entities.ABIMode},
+		Command: restoreCommand,
+		Parent:  podCmd,
+	})
+	flags := restoreCommand.Flags()
+	flags.BoolVarP(&restoreOptions.All, "all", "a", false, "Restore all checkpointed pods")
+	flags.BoolVarP(&restoreOptions.Keep, "keep", "k", false, "Keep all temporary checkpoint files")
+	flags.BoolVar(&restoreOptions.TCPEstablished, "tcp-established", false, "Restore a pod with established TCP connections")
+
+	importFlagName := "import"
+	flags.StringVarP(&restoreOptions.Import, importFlagName, "i", "", "Restore the pod from an exported pod checkpoint archive")
+	_ = restoreCommand.RegisterFlagCompletionFunc(importFlagName, completion.AutocompleteDefault)
+
+	flags.BoolVar(&restoreOptions.IgnoreRootFS, "ignore-rootfs", false, "Do not apply root file-system changes when importing from exported checkpoint")
+	flags.BoolVar(&restoreOptions.IgnoreVolumes, "ignore-volumes", false, "Do not restore the content of volumes associated with the containers when importing from exported checkpoint")
+	validate.AddLatestFlag(restoreCommand, &restoreOptions.Latest)
+}
+
+func restore(_ *cobra.Command, args []string) error {
+	var (
+		errs utils.OutputErrors
+	)
+	if rootless.IsRootless() {
+		return errors.New("restoring a pod requires root")
+	}
+	if restoreOptions.Import == "" && restoreOptions.IgnoreRootFS {
+		return errors.Errorf("--ignore-rootfs can only be used with --import")
+	}
+	if restoreOptions.Import == "" && restoreOptions.IgnoreVolumes {
+		return errors.Errorf("--ignore-volumes can only be used with --import")
+	}
+	argLen := len(args)
+	if restoreOptions.Import != "" {
+		if restoreOptions.All || restoreOptions.Latest {
+			return errors.Errorf("Cannot use --import with --all or --latest")
+		}
+		if argLen > 0 {
+			return errors.Errorf("Cannot use --import with positional arguments")
+		}
+	}
+	if (restoreOptions.All || restoreOptions.Latest) && argLen > 0 {
+		return errors.Errorf("--all or --latest and pods cannot be used together")
+	}
+	if argLen < 1 && !restoreOptions.All && !restoreOptions.Latest && restoreOptions.Import == "" {
+		return errors.Errorf("you must provide at least one name or id")
+	}
+	responses, err := registry.ContainerEngine().PodRestore(context.Background(), args, restoreOptions)
+	if err != nil {
+		return err
+	}
+	// in the cli, first we print out all the successful attempts
+	for _, r := range responses {
+		if len(r.Errs) == 0 {
+			fmt.Println(r.Id)
+		} else {
+			errs = append(errs, r.Errs...)
+		}
+	}
+	return errs.PrintErrors()
+}
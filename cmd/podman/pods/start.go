@@ -32,7 +32,7 @@ var (
 		Args: func(cmd *cobra.Command, args []string) error {
 			return validate.CheckAllLatestAndPodIDFile(cmd, args, false, true)
 		},
-		ValidArgsFunction: common.AutocompletePods,
+		ValidArgsFunction: common.AutocompletePodsStartable,
 		Example: `podman pod start podID
   podman pod start --latest
   podman pod start --all`,
@@ -42,7 +42,8 @@ var (
 		Example: `podman pod stats
   podman pod stats a69b23034235 named-pod
   podman pod stats --latest
-  podman pod stats --all`,
+  podman pod stats --all
+  podman pod stats --sum mypod`,
 	}
 )
 
@@ -62,6 +63,7 @@ func init() {
 
 	flags.BoolVar(&statsOptions.NoReset, "no-reset", false, "Disable resetting the screen when streaming")
 	flags.BoolVar(&statsOptions.NoStream, "no-stream", false, "Disable streaming stats and only pull the first result")
+	flags.BoolVar(&statsOptions.Sum, "sum", false, "Show the sum of the stats of the containers of each pod before the stats of its containers")
 	validate.AddLatestFlag(statsCmd, &statsOptions.Latest)
 }
 
@@ -0,0 +1,68 @@
+package pods
+
+import (
+	"fmt"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v2/cmd/podman/common"
+	"github.com/containers/podman/v2/cmd/podman/registry"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	podUpdateDescription = `Changes the ports published by a pod.
+
+  The ports are published by the infra container of the pod.  If it is running, its port forwarding is set up again with the new ports; rootless pods publish the new ports when they are restarted.`
+	updateCommand = &cobra.Command{
+		Use:               "update [options] POD",
+		Short:             "Change the published ports of a pod",
+		Long:              podUpdateDescription,
+		RunE:              update,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompletePods,
+		Example: `podman pod update --publish 8443:443 mypod
+  podman pod update --publish-rm 8080 --publish 8081:80 mypod`,
+	}
+)
+
+var (
+	updateOptions   entities.PodUpdateOptions
+	updatePublished []string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Mode:    []entities.EngineMode{entities.ABIMode, entities.TunnelMode},
+		Command: updateCommand,
+		Parent:  podCmd,
+	})
+
+	flags := updateCommand.Flags()
+	publishFlagName := "publish"
+	flags.StringSliceVarP(&updatePublished, publishFlagName, "p", []string{}, "Publish an additional port of the pod to the host")
+	_ = updateCommand.RegisterFlagCompletionFunc(publishFlagName, completion.AutocompleteNone)
+
+	publishRmFlagName := "publish-rm"
+	flags.StringSliceVar(&updateOptions.RemovePorts, publishRmFlagName, []string{}, "Stop publishing the host port (PORT or PORT/PROTOCOL)")
+	_ = updateCommand.RegisterFlagCompletionFunc(publishRmFlagName, completion.AutocompleteNone)
+}
+
+func update(cmd *cobra.Command, args []string) error {
+	if len(updatePublished) == 0 && len(updateOptions.RemovePorts) == 0 {
+		return errors.New("at least one port to publish or remove must be specified")
+	}
+	ports, err := common.CreatePortBindings(updatePublished)
+	if err != nil {
+		return err
+	}
+	updateOptions.PublishPorts = ports
+
+	report, err := registry.ContainerEngine().PodUpdate(registry.GetContext(), args[0], updateOptions)
+	if err != nil {
+		return err
+	}
+	fmt.Println(report.Id)
+	return nil
+}
@@ -292,6 +292,10 @@ func rootFlags(cmd *cobra.Command, opts *entities.PodmanConfig) {
 	lFlags.StringVar(&opts.Identity, identityFlagName, ident, "path to SSH identity file, (CONTAINER_SSHKEY)")
 	_ = cmd.RegisterFlagCompletionFunc(identityFlagName, completion.AutocompleteDefault)
 
+	authTokenFileFlagName := "auth-token-file"
+	lFlags.StringVar(&opts.AuthTokenFile, authTokenFileFlagName, "", "path to the auth token file of tcp connections, (CONTAINER_AUTH_TOKEN_FILE)")
+	_ = cmd.RegisterFlagCompletionFunc(authTokenFileFlagName, completion.AutocompleteDefault)
+
 	lFlags.BoolVarP(&opts.Remote, "remote", "r", false, "Access remote Podman service (default false)")
 	pFlags := cmd.PersistentFlags()
 	if registry.IsRemote() {
@@ -0,0 +1,70 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v2/cmd/podman/registry"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/containers/podman/v2/pkg/secrets"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	createDescription = `Create a secret from the contents of FILE, or from stdin if FILE is "-".
+
+  The data of the secret is stored by the secrets driver and is never part of the configuration of containers using the secret.`
+
+	createCommand = &cobra.Command{
+		Use:               "create [options] NAME FILE|-",
+		Short:             "Create a new secret",
+		Long:              createDescription,
+		RunE:              create,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completion.AutocompleteDefault,
+		Example: `podman secret create mysecret ./secret.txt
+  printf "mydata" | podman secret create mysecret -`,
+	}
+)
+
+var (
+	createOpts = entities.SecretCreateOptions{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Mode:    []entities.EngineMode{entities.ABIMode, entities.TunnelMode},
+		Command: createCommand,
+		Parent:  secretCmd,
+	})
+	flags := createCommand.Flags()
+
+	driverFlagName := "driver"
+	flags.StringVar(&createOpts.Driver, driverFlagName, secrets.FileDriver, "Specify secrets driver name")
+	_ = createCommand.RegisterFlagCompletionFunc(driverFlagName, completion.AutocompleteNone)
+}
+
+func create(cmd *cobra.Command, args []string) error {
+	var reader io.Reader
+	name, path := args[0], args[1]
+	if path == "-" {
+		reader = os.Stdin
+	} else {
+		file, err := os.Open(path)
+		if err != nil {
+			return errors.Wrapf(err, "error opening secret file %s", path)
+		}
+		defer file.Close()
+		reader = file
+	}
+	report, err := registry.ContainerEngine().SecretCreate(context.Background(), name, reader, createOpts)
+	if err != nil {
+		return err
+	}
+	fmt.Println(report.ID)
+	return nil
+}
@@ -0,0 +1,83 @@
+package secrets
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v2/cmd/podman/common"
+	"github.com/containers/podman/v2/cmd/podman/parse"
+	"github.com/containers/podman/v2/cmd/podman/registry"
+	"github.com/containers/podman/v2/cmd/podman/utils"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	inspectDescription = `Display detailed information on one or more secrets.
+
+  Use a Go template to change the format from JSON.  The data of the secrets is never displayed.`
+	inspectCommand = &cobra.Command{
+		Use:               "inspect [options] SECRET [SECRET...]",
+		Short:             "Display detailed information on one or more secrets",
+		Long:              inspectDescription,
+		RunE:              inspect,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: common.AutocompleteSecrets,
+		Example: `podman secret inspect mysecret
+  podman secret inspect --format "{{.Spec.Driver.Name}}" mysecret`,
+	}
+)
+
+var (
+	inspectFormat string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Mode:    []entities.EngineMode{entities.ABIMode, entities.TunnelMode},
+		Command: inspectCommand,
+		Parent:  secretCmd,
+	})
+	flags := inspectCommand.Flags()
+
+	formatFlagName := "format"
+	flags.StringVarP(&inspectFormat, formatFlagName, "f", "json", "Format secret output using Go template")
+	_ = inspectCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteJSONFormat)
+}
+
+func inspect(cmd *cobra.Command, args []string) error {
+	responses, errs, err := registry.ContainerEngine().SecretInspect(registry.Context(), args)
+	if err != nil {
+		return err
+	}
+
+	if report.IsJSON(inspectFormat) {
+		b, err := json.MarshalIndent(responses, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+	} else {
+		format := parse.EnforceRange(report.NormalizeFormat(inspectFormat))
+		tmpl, err := template.New("inspect secrets").Parse(format)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 12, 2, 2, ' ', 0)
+		if err := tmpl.Execute(w, responses); err != nil {
+			return err
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if len(errs) > 0 {
+		registry.SetExitCode(1)
+		return utils.OutputErrors(errs).PrintErrors()
+	}
+	return nil
+}
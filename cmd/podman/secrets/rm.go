@@ -0,0 +1,82 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/containers/podman/v2/cmd/podman/common"
+	"github.com/containers/podman/v2/cmd/podman/registry"
+	"github.com/containers/podman/v2/cmd/podman/utils"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/containers/podman/v2/pkg/secrets"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rmDescription = `Remove one or more existing secrets.
+
+  Secrets used by containers cannot be removed.`
+	rmCommand = &cobra.Command{
+		Use:               "rm [options] SECRET [SECRET...]",
+		Aliases:           []string{"remove"},
+		Short:             "Remove one or more secrets",
+		Long:              rmDescription,
+		RunE:              rm,
+		ValidArgsFunction: common.AutocompleteSecrets,
+		Example: `podman secret rm mysecret1 mysecret2
+  podman secret rm --all`,
+	}
+)
+
+var (
+	rmOptions = entities.SecretRmOptions{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Mode:    []entities.EngineMode{entities.ABIMode, entities.TunnelMode},
+		Command: rmCommand,
+		Parent:  secretCmd,
+	})
+	flags := rmCommand.Flags()
+	flags.BoolVarP(&rmOptions.All, "all", "a", false, "Remove all secrets")
+}
+
+func rm(cmd *cobra.Command, args []string) error {
+	var (
+		errs utils.OutputErrors
+	)
+	if (len(args) > 0 && rmOptions.All) || (len(args) < 1 && !rmOptions.All) {
+		return errors.New("choose either one or more secrets or all")
+	}
+	responses, err := registry.ContainerEngine().SecretRm(context.Background(), args, rmOptions)
+	if err != nil {
+		setExitCode(err)
+		return err
+	}
+	for _, r := range responses {
+		if r.Err == nil {
+			fmt.Println(r.ID)
+		} else {
+			setExitCode(r.Err)
+			errs = append(errs, r.Err)
+		}
+	}
+	return errs.PrintErrors()
+}
+
+func setExitCode(err error) {
+	cause := errors.Cause(err)
+	switch {
+	case cause == secrets.ErrNoSuchSecret:
+		registry.SetExitCode(1)
+	case strings.Contains(cause.Error(), secrets.ErrNoSuchSecret.Error()):
+		registry.SetExitCode(1)
+	case cause == secrets.ErrSecretInUse:
+		registry.SetExitCode(2)
+	case strings.Contains(cause.Error(), secrets.ErrSecretInUse.Error()):
+		registry.SetExitCode(2)
+	}
+}
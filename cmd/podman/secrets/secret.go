@@ -0,0 +1,28 @@
+package secrets
+
+import (
+	"github.com/containers/podman/v2/cmd/podman/registry"
+	"github.com/containers/podman/v2/cmd/podman/validate"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Pull in configured json library
+	json = registry.JSONLibrary()
+
+	// Command: podman _secret_
+	secretCmd = &cobra.Command{
+		Use:   "secret",
+		Short: "Manage secrets",
+		Long:  "Secrets hold sensitive data which can be made available to containers without being part of their image or configuration",
+		RunE:  validate.SubCommandExists,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Mode:    []entities.EngineMode{entities.ABIMode, entities.TunnelMode},
+		Command: secretCmd,
+	})
+}
@@ -0,0 +1,88 @@
+package system
+
+import (
+	"fmt"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v2/cmd/podman/registry"
+	"github.com/containers/podman/v2/cmd/podman/utils"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupDescription = `
+        podman system backup FILE
+
+        Write an archive of the configuration of all containers, pods, volumes and
+        networks, and of the names of all secrets, to FILE. The archive can be
+        restored with podman system backup restore on another host.
+`
+
+	backupCommand = &cobra.Command{
+		Use:               "backup [options] FILE",
+		Args:              cobra.ExactArgs(1),
+		Short:             "Back up containers, pods, volumes and networks",
+		Long:              backupDescription,
+		RunE:              backup,
+		ValidArgsFunction: completion.AutocompleteDefault,
+		Example: `podman system backup host.tar
+  podman system backup --volumes host.tar`,
+	}
+
+	backupRestoreDescription = `
+        podman system backup restore FILE
+
+        Re-create the containers, pods, volumes and networks of an archive written
+        by podman system backup.
+`
+
+	backupRestoreCommand = &cobra.Command{
+		Use:               "restore FILE",
+		Args:              cobra.ExactArgs(1),
+		Short:             "Restore a backup of containers, pods, volumes and networks",
+		Long:              backupRestoreDescription,
+		RunE:              backupRestore,
+		ValidArgsFunction: completion.AutocompleteDefault,
+		Example:           `podman system backup restore host.tar`,
+	}
+
+	backupOptions entities.SystemBackupOptions
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Mode:    []entities.EngineMode{entities.ABIMode},
+		Command: backupCommand,
+		Parent:  systemCmd,
+	})
+	flags := backupCommand.Flags()
+	flags.BoolVar(&backupOptions.Volumes, "volumes", false, "Include the contents of the volumes")
+
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Mode:    []entities.EngineMode{entities.ABIMode},
+		Command: backupRestoreCommand,
+		Parent:  backupCommand,
+	})
+}
+
+func backup(cmd *cobra.Command, args []string) error {
+	return registry.ContainerEngine().SystemBackup(registry.Context(), args[0], backupOptions)
+}
+
+func backupRestore(cmd *cobra.Command, args []string) error {
+	var errs utils.OutputErrors
+	reports, err := registry.ContainerEngine().SystemRestoreBackup(registry.Context(), args[0])
+	if err != nil {
+		return err
+	}
+	for _, r := range reports {
+		if r.Err == nil {
+			fmt.Println(r.Resource)
+		} else {
+			errs = append(errs, errors.Wrapf(r.Err, "error restoring %s", r.Resource))
+		}
+	}
+	return errs.PrintErrors()
+}
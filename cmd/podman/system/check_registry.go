@@ -0,0 +1,84 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v2/cmd/podman/common"
+	"github.com/containers/podman/v2/cmd/podman/registry"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/containers/podman/v2/pkg/registries"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	checkRegistryDescription = `Test the TLS connection to a registry using the per-registry certificates.
+
+  The CA and client certificates are loaded from the certs.d directory of the registry (e.g., /etc/containers/certs.d/REGISTRY) or from --cert-dir. If the connection fails, the likely cause is explained.`
+	checkRegistryCommand = &cobra.Command{
+		Use:               "check-registry [options] REGISTRY",
+		Args:              cobra.ExactArgs(1),
+		Short:             "Test the TLS connection to a registry",
+		Long:              checkRegistryDescription,
+		RunE:              checkRegistry,
+		ValidArgsFunction: common.AutocompleteRegistries,
+		Example: `podman system check-registry quay.io
+  podman system check-registry --cert-dir ./certs localhost:5000`,
+	}
+
+	checkRegistryCertDir string
+)
+
+func init() {
+	// The check is done by the client in both modes, as the certificates
+	// are looked up locally.
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Mode:    []entities.EngineMode{entities.ABIMode, entities.TunnelMode},
+		Command: checkRegistryCommand,
+		Parent:  systemCmd,
+	})
+	flags := checkRegistryCommand.Flags()
+
+	certDirFlagName := "cert-dir"
+	flags.StringVar(&checkRegistryCertDir, certDirFlagName, "", "`Pathname` of a directory containing TLS certificates and keys")
+	_ = checkRegistryCommand.RegisterFlagCompletionFunc(certDirFlagName, completion.AutocompleteDefault)
+}
+
+func checkRegistry(cmd *cobra.Command, args []string) error {
+	// Only the host (and port) are relevant for the connection.
+	server := strings.TrimPrefix(strings.TrimPrefix(args[0], "https://"), "http://")
+	server = strings.Split(server, "/")[0]
+
+	report, err := registries.CheckTLS(registry.GetContext(), server, checkRegistryCertDir)
+	if err != nil {
+		return err
+	}
+
+	certDir := report.CertDir
+	if certDir == "" {
+		certDir = "none (using the system CA pool)"
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Registry:\t%s\n", report.Registry)
+	fmt.Fprintf(w, "Certificates:\t%s\n", certDir)
+	fmt.Fprintf(w, "Insecure:\t%t\n", report.Insecure)
+	if !report.NotAfter.IsZero() {
+		fmt.Fprintf(w, "Expires:\t%s\n", report.NotAfter.Format("2006-01-02 15:04:05 MST"))
+	}
+	if report.Error == nil {
+		fmt.Fprintf(w, "Status:\tOK\n")
+		return w.Flush()
+	}
+	fmt.Fprintf(w, "Status:\tFAILED\n")
+	if report.Hint != "" {
+		fmt.Fprintf(w, "Hint:\t%s\n", report.Hint)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return errors.Wrapf(report.Error, "error connecting to %s", report.Registry)
+}
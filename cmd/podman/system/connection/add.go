@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"os"
 	"os/user"
+	"path/filepath"
 	"regexp"
 
 	"github.com/containers/common/pkg/completion"
@@ -33,21 +34,24 @@ var (
 		Short: "Record destination for the Podman service",
 		Long: `Add destination to podman configuration.
   "destination" is of the form [user@]hostname or
-  an URI of the form ssh://[user@]hostname[:port]
+  an URI of the form ssh://[user@]hostname[:port],
+  tcp://hostname:port or unix://path
 `,
 		RunE:              add,
 		ValidArgsFunction: completion.AutocompleteNone,
 		Example: `podman system connection add laptop server.fubar.com
   podman system connection add --identity ~/.ssh/dev_rsa testing ssh://root@server.fubar.com:2222
   podman system connection add --identity ~/.ssh/dev_rsa --port 22 production root@server.fubar.com
+  podman system connection add --token-file ~/.config/podman/token build tcp://server.fubar.com:8080
   `,
 	}
 
 	cOpts = struct {
-		Identity string
-		Port     int
-		UDSPath  string
-		Default  bool
+		Identity  string
+		Port      int
+		UDSPath   string
+		TokenFile string
+		Default   bool
 	}{}
 )
 
@@ -72,6 +76,10 @@ func init() {
 	flags.StringVar(&cOpts.UDSPath, socketPathFlagName, "", "path to podman socket on remote host. (default '/run/podman/podman.sock' or '/run/user/{uid}/podman/podman.sock)")
 	_ = addCmd.RegisterFlagCompletionFunc(socketPathFlagName, completion.AutocompleteDefault)
 
+	tokenFileFlagName := "token-file"
+	flags.StringVar(&cOpts.TokenFile, tokenFileFlagName, "", "path to the file holding the auth token of a tcp destination")
+	_ = addCmd.RegisterFlagCompletionFunc(tokenFileFlagName, completion.AutocompleteDefault)
+
 	flags.BoolVarP(&cOpts.Default, "default", "d", false, "Set connection to be default")
 }
 
@@ -89,29 +97,56 @@ func add(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if uri.User.Username() == "" {
-		if uri.User, err = getUserInfo(uri); err != nil {
-			return err
+	dst := config.Destination{}
+	switch uri.Scheme {
+	case "ssh":
+		if cmd.Flags().Changed("token-file") {
+			return errors.New("--token-file can only be used with tcp destinations")
 		}
-	}
-
-	if cmd.Flags().Changed("socket-path") {
-		uri.Path = cmd.Flag("socket-path").Value.String()
-	}
-
-	if cmd.Flags().Changed("port") {
-		uri.Host = net.JoinHostPort(uri.Hostname(), cmd.Flag("port").Value.String())
-	}
-
-	if uri.Port() == "" {
-		uri.Host = net.JoinHostPort(uri.Hostname(), cmd.Flag("port").DefValue)
-	}
-
-	if uri.Path == "" || uri.Path == "/" {
-		if uri.Path, err = getUDS(cmd, uri); err != nil {
+		if err := completeSSHURI(cmd, uri); err != nil {
 			return err
 		}
+		if cmd.Flags().Changed("identity") {
+			dst.Identity = cOpts.Identity
+		}
+	case "tcp":
+		if cmd.Flags().Changed("identity") || cmd.Flags().Changed("socket-path") {
+			return errors.New("--identity and --socket-path cannot be used with tcp destinations")
+		}
+		if cmd.Flags().Changed("port") {
+			uri.Host = net.JoinHostPort(uri.Hostname(), cmd.Flag("port").Value.String())
+		}
+		if uri.Port() == "" {
+			return errors.Errorf("tcp destination %q requires a port", dest)
+		}
+		if cmd.Flags().Changed("token-file") {
+			tokenFile, err := filepath.Abs(cOpts.TokenFile)
+			if err != nil {
+				return err
+			}
+			if _, err := os.Stat(tokenFile); err != nil {
+				return errors.Wrapf(err, "invalid token file")
+			}
+			// The SSH identity of the destination is not used for
+			// tcp connections, the token file is recorded in the URI.
+			query := uri.Query()
+			query.Set("token-file", tokenFile)
+			uri.RawQuery = query.Encode()
+		}
+	case "unix":
+		if cmd.Flags().Changed("identity") || cmd.Flags().Changed("port") || cmd.Flags().Changed("token-file") {
+			return errors.New("--identity, --port and --token-file cannot be used with unix destinations")
+		}
+		if cmd.Flags().Changed("socket-path") {
+			uri.Path = cOpts.UDSPath
+		}
+		if uri.Path == "" {
+			return errors.Errorf("unix destination %q requires a socket path", dest)
+		}
+	default:
+		return errors.Errorf("%q is not a supported destination schema", uri.Scheme)
 	}
+	dst.URI = uri.String()
 
 	cfg, err := config.ReadCustomConfig()
 	if err != nil {
@@ -124,14 +159,6 @@ func add(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	dst := config.Destination{
-		URI: uri.String(),
-	}
-
-	if cmd.Flags().Changed("identity") {
-		dst.Identity = cOpts.Identity
-	}
-
 	if cfg.Engine.ServiceDestinations == nil {
 		cfg.Engine.ServiceDestinations = map[string]config.Destination{
 			args[0]: dst,
@@ -143,6 +170,36 @@ func add(cmd *cobra.Command, args []string) error {
 	return cfg.Write()
 }
 
+// completeSSHURI fills in the user, port and socket path of an ssh destination
+// which were not given.  The socket path is queried from the destination.
+func completeSSHURI(cmd *cobra.Command, uri *url.URL) error {
+	var err error
+	if uri.User.Username() == "" {
+		if uri.User, err = getUserInfo(uri); err != nil {
+			return err
+		}
+	}
+
+	if cmd.Flags().Changed("socket-path") {
+		uri.Path = cmd.Flag("socket-path").Value.String()
+	}
+
+	if cmd.Flags().Changed("port") {
+		uri.Host = net.JoinHostPort(uri.Hostname(), cmd.Flag("port").Value.String())
+	}
+
+	if uri.Port() == "" {
+		uri.Host = net.JoinHostPort(uri.Hostname(), cmd.Flag("port").DefValue)
+	}
+
+	if uri.Path == "" || uri.Path == "/" {
+		if uri.Path, err = getUDS(cmd, uri); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func getUserInfo(uri *url.URL) (*url.Userinfo, error) {
 	var (
 		usr *user.User
@@ -1,45 +1,60 @@
 package connection
 
 import (
+	"context"
 	"os"
+	"sync"
 	"text/tabwriter"
 	"text/template"
+	"time"
 
 	"github.com/containers/common/pkg/completion"
 	"github.com/containers/common/pkg/config"
 	"github.com/containers/podman/v2/cmd/podman/registry"
 	"github.com/containers/podman/v2/cmd/podman/system"
 	"github.com/containers/podman/v2/cmd/podman/validate"
+	"github.com/containers/podman/v2/pkg/bindings"
 	"github.com/containers/podman/v2/pkg/domain/entities"
 	"github.com/spf13/cobra"
 )
 
 var (
 	listCmd = &cobra.Command{
-		Use:                   "list",
-		Aliases:               []string{"ls"},
-		Args:                  validate.NoArgs,
-		Short:                 "List destination for the Podman service(s)",
-		Long:                  `List destination information for the Podman service(s) in podman configuration`,
-		DisableFlagsInUseLine: true,
+		Use:     "list [options]",
+		Aliases: []string{"ls"},
+		Args:    validate.NoArgs,
+		Short:   "List destination for the Podman service(s)",
+		Long:    `List destination information for the Podman service(s) in podman configuration`,
 		Example: `podman system connection list
-  podman system connection ls`,
+  podman system connection ls
+  podman system connection ls --check`,
 		ValidArgsFunction: completion.AutocompleteNone,
 		RunE:              list,
 		TraverseChildren:  false,
 	}
+
+	listOpts = struct {
+		Check bool
+	}{}
 )
 
+// checkTimeout is the time a destination has to answer the health check.
+const checkTimeout = 10 * time.Second
+
 func init() {
 	registry.Commands = append(registry.Commands, registry.CliCommand{
 		Mode:    []entities.EngineMode{entities.ABIMode, entities.TunnelMode},
 		Command: listCmd,
 		Parent:  system.ConnectionCmd,
 	})
+
+	flags := listCmd.Flags()
+	flags.BoolVar(&listOpts.Check, "check", false, "Check whether the Podman services are reachable")
 }
 
 type namedDestination struct {
-	Name string
+	Name   string
+	Status string
 	config.Destination
 }
 
@@ -56,6 +71,7 @@ func list(_ *cobra.Command, _ []string) error {
 	hdrs := []map[string]string{{
 		"Identity": "Identity",
 		"Name":     "Name",
+		"Status":   "Status",
 		"URI":      "URI",
 	}}
 
@@ -75,8 +91,21 @@ func list(_ *cobra.Command, _ []string) error {
 		rows = append(rows, r)
 	}
 
-	// TODO: Allow user to override format
 	format := "{{range . }}{{.Name}}\t{{.Identity}}\t{{.URI}}\n{{end}}"
+	if listOpts.Check {
+		var wg sync.WaitGroup
+		for i := range rows {
+			wg.Add(1)
+			go func(r *namedDestination) {
+				defer wg.Done()
+				r.Status = checkConnection(r.Destination)
+			}(&rows[i])
+		}
+		wg.Wait()
+		format = "{{range . }}{{.Name}}\t{{.Identity}}\t{{.URI}}\t{{.Status}}\n{{end}}"
+	}
+
+	// TODO: Allow user to override format
 	tmpl, err := template.New("connection").Parse(format)
 	if err != nil {
 		return err
@@ -88,3 +117,23 @@ func list(_ *cobra.Command, _ []string) error {
 	_ = tmpl.Execute(w, hdrs)
 	return tmpl.Execute(w, rows)
 }
+
+// checkConnection returns "ok" if the Podman service at the given destination
+// answers, and the error encountered otherwise.
+func checkConnection(dst config.Destination) string {
+	errChan := make(chan error, 1)
+	go func() {
+		_, err := bindings.NewConnectionWithIdentity(context.Background(), dst.URI, dst.Identity)
+		errChan <- err
+	}()
+
+	select {
+	case err := <-errChan:
+		if err != nil {
+			return err.Error()
+		}
+		return "ok"
+	case <-time.After(checkTimeout):
+		return "timed out"
+	}
+}
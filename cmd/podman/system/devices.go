@@ -0,0 +1,114 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/common/pkg/report"
+	"github.com/containers/podman/v2/cmd/podman/common"
+	"github.com/containers/podman/v2/cmd/podman/parse"
+	"github.com/containers/podman/v2/cmd/podman/registry"
+	"github.com/containers/podman/v2/cmd/podman/validate"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/containers/podman/v2/pkg/hostdevices"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	devicesDescription = `
+	podman system devices
+
+	List the devices of the host commonly passed into containers, like GPUs, /dev/net/tun, USB serial adapters, /dev/fuse and /dev/kvm.
+
+	For each device the rule for --device-cgroup-rule, whether the current user can access it and the CDI devices providing it are shown.  A device can be passed into a container with --device PATH.  Rootless containers can only use devices the user can access.
+	`
+	devicesCommand = &cobra.Command{
+		Use:               "devices [options]",
+		Args:              validate.NoArgs,
+		Short:             "List host devices for use in containers",
+		Long:              devicesDescription,
+		RunE:              devices,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman system devices
+  podman system devices --format json
+  podman system devices --format "{{.Path}} {{.CgroupRule}}"`,
+	}
+)
+
+var (
+	devicesOptions entities.SystemDevicesOptions
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Mode:    []entities.EngineMode{entities.ABIMode, entities.TunnelMode},
+		Command: devicesCommand,
+		Parent:  systemCmd,
+	})
+	flags := devicesCommand.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&devicesOptions.Format, formatFlagName, "{{.Path}}\t{{.Class}}\t{{.CgroupRule}}\t{{.Access}}\t{{.CDI}}\n", "Change the output to JSON or a Go template")
+	_ = devicesCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteJSONFormat)
+}
+
+func devices(cmd *cobra.Command, args []string) error {
+	devicesReport, err := registry.ContainerEngine().SystemDevices(registry.Context(), devicesOptions)
+	if err != nil {
+		return err
+	}
+
+	if report.IsJSON(devicesOptions.Format) {
+		b, err := json.MarshalIndent(devicesReport.Devices, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	devicesReports := make([]devicesReporter, 0, len(devicesReport.Devices))
+	for _, d := range devicesReport.Devices {
+		devicesReports = append(devicesReports, devicesReporter{d})
+	}
+
+	headers := report.Headers(devicesReporter{}, map[string]string{
+		"CgroupRule": "CGROUP RULE",
+		"CDI":        "CDI DEVICES",
+	})
+	renderHeaders := true
+	if cmd.Flags().Changed("format") {
+		renderHeaders = parse.HasTable(devicesOptions.Format)
+	}
+	format := parse.EnforceRange(report.NormalizeFormat(devicesOptions.Format))
+
+	tmpl, err := template.New("list devices").Parse(format)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 12, 2, 2, ' ', 0)
+	defer w.Flush()
+
+	if renderHeaders {
+		if err := tmpl.Execute(w, headers); err != nil {
+			return errors.Wrapf(err, "failed to write report column headers")
+		}
+	}
+	return tmpl.Execute(w, devicesReports)
+}
+
+type devicesReporter struct {
+	*hostdevices.Device
+}
+
+func (d devicesReporter) CDI() string {
+	if len(d.CDIDevices) == 0 {
+		return "-"
+	}
+	return strings.Join(d.CDIDevices, ",")
+}
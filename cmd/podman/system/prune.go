@@ -47,6 +47,7 @@ func init() {
 	flags.BoolVarP(&force, "force", "f", false, "Do not prompt for confirmation.  The default is false")
 	flags.BoolVarP(&pruneOptions.All, "all", "a", false, "Remove all unused data")
 	flags.BoolVar(&pruneOptions.Volume, "volumes", false, "Prune volumes")
+	flags.BoolVar(&pruneOptions.Interrupted, "interrupted", false, "Remove the temporary files and the unused layers left by interrupted pulls")
 	filterFlagName := "filter"
 	flags.StringArrayVar(&filters, filterFlagName, []string{}, "Provide filter values (e.g. 'label=<key>=<value>')")
 	_ = pruneCommand.RegisterFlagCompletionFunc(filterFlagName, completion.AutocompleteNone)
@@ -63,6 +64,10 @@ func prune(cmd *cobra.Command, args []string) error {
 		if pruneOptions.Volume {
 			volumeString = `
         - all volumes not used by at least one container`
+		}
+		if pruneOptions.Interrupted {
+			volumeString += `
+        - all layers and temporary files left by interrupted pulls`
 		}
 		fmt.Printf(`
 WARNING! This will remove:
@@ -106,6 +111,12 @@ Are you sure you want to continue? [y/N] `, volumeString)
 			return err
 		}
 	}
+	// Print interrupted pulls prune results
+	if pruneOptions.Interrupted {
+		if err := utils.PrintInterruptedPullsPruneResults(response.InterruptedPullsPruneReport, true); err != nil {
+			return err
+		}
+	}
 	// Print Images prune results
 	return utils.PrintImagePruneResults(response.ImagePruneReport, true)
 }
@@ -0,0 +1,56 @@
+package system
+
+import (
+	"fmt"
+
+	"github.com/containers/common/pkg/completion"
+	"github.com/containers/podman/v2/cmd/podman/registry"
+	"github.com/containers/podman/v2/cmd/podman/utils"
+	"github.com/containers/podman/v2/cmd/podman/validate"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	restoreDescription = `
+        podman system restore
+
+        Start all containers with the always restart policy, and all containers with
+        the unless-stopped restart policy that were not stopped by the user, together
+        with the containers they depend on. Meant to be run at boot.
+`
+
+	restoreCommand = &cobra.Command{
+		Use:               "restore",
+		Args:              validate.NoArgs,
+		Short:             "Start containers with a restart policy after a system restart",
+		Long:              restoreDescription,
+		RunE:              restore,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example:           `podman system restore`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Mode:    []entities.EngineMode{entities.ABIMode},
+		Command: restoreCommand,
+		Parent:  systemCmd,
+	})
+}
+
+func restore(cmd *cobra.Command, args []string) error {
+	var errs utils.OutputErrors
+	reports, err := registry.ContainerEngine().SystemRestore(registry.Context())
+	if err != nil {
+		return err
+	}
+	for _, r := range reports {
+		if r.Err == nil {
+			fmt.Println(r.Id)
+		} else {
+			errs = append(errs, r.Err)
+		}
+	}
+	return errs.PrintErrors()
+}
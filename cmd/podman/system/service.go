@@ -38,7 +38,8 @@ Enable a listening service for API access to Podman commands.
 	}
 
 	srvArgs = struct {
-		Timeout int64
+		AuthTokenFile string
+		Timeout       int64
 	}{}
 )
 
@@ -55,6 +56,10 @@ func init() {
 	flags.Int64VarP(&srvArgs.Timeout, timeFlagName, "t", 5, "Time until the service session expires in seconds.  Use 0 to disable the timeout")
 	_ = srvCmd.RegisterFlagCompletionFunc(timeFlagName, completion.AutocompleteNone)
 
+	authTokenFileFlagName := "auth-token-file"
+	flags.StringVar(&srvArgs.AuthTokenFile, authTokenFileFlagName, "", "Require clients to authenticate with the bearer token in `file`")
+	_ = srvCmd.RegisterFlagCompletionFunc(authTokenFileFlagName, completion.AutocompleteDefault)
+
 	flags.SetNormalizeFunc(aliasTimeoutFlag)
 }
 
@@ -90,8 +95,9 @@ func service(cmd *cobra.Command, args []string) error {
 	}
 
 	opts := entities.ServiceOptions{
-		URI:     apiURI,
-		Command: cmd,
+		URI:           apiURI,
+		Command:       cmd,
+		AuthTokenFile: srvArgs.AuthTokenFile,
 	}
 
 	opts.Timeout = time.Duration(srvArgs.Timeout) * time.Second
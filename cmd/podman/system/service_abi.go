@@ -4,6 +4,7 @@ package system
 
 import (
 	"context"
+	"io/ioutil"
 	"net"
 	"os"
 	"strings"
@@ -23,6 +24,18 @@ func restService(opts entities.ServiceOptions, flags *pflag.FlagSet, cfg *entiti
 		err      error
 	)
 
+	authToken := ""
+	if opts.AuthTokenFile != "" {
+		content, err := ioutil.ReadFile(opts.AuthTokenFile)
+		if err != nil {
+			return errors.Wrapf(err, "unable to read auth token")
+		}
+		authToken = strings.TrimSpace(string(content))
+		if authToken == "" {
+			return errors.Errorf("auth token file %s is empty", opts.AuthTokenFile)
+		}
+	}
+
 	if opts.URI != "" {
 		fields := strings.Split(opts.URI, ":")
 		if len(fields) == 1 {
@@ -34,6 +47,9 @@ func restService(opts entities.ServiceOptions, flags *pflag.FlagSet, cfg *entiti
 			return errors.Wrapf(err, "unable to create socket")
 		}
 		listener = &l
+		if fields[0] == "tcp" && authToken == "" {
+			logrus.Warnf("API service listening on %s does not require clients to authenticate, see --auth-token-file", opts.URI)
+		}
 	}
 
 	// Close stdin, so shortnames will not prompt
@@ -55,6 +71,9 @@ func restService(opts entities.ServiceOptions, flags *pflag.FlagSet, cfg *entiti
 	if err != nil {
 		return err
 	}
+	if authToken != "" {
+		server.RequireAuthToken(authToken)
+	}
 	defer func() {
 		if err := server.Shutdown(); err != nil {
 			logrus.Warnf("Error when stopping API service: %s", err)
@@ -3,7 +3,9 @@ package utils
 import (
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/pkg/domain/entities"
 )
 
@@ -60,16 +62,49 @@ func PrintVolumePruneResults(volumePruneReport []*entities.VolumePruneReport, he
 	if heading && len(volumePruneReport) > 0 {
 		fmt.Println("Deleted Volumes")
 	}
+	var size uint64
 	for _, r := range volumePruneReport {
 		if r.Err == nil {
-			fmt.Println(r.Id)
+			// Volumes of stopped containers are only pruned with
+			// --force-unused, list the containers removed with them.
+			if len(r.Containers) > 0 {
+				fmt.Printf("%s (removed with stopped containers %s)\n", r.Id, strings.Join(r.Containers, ", "))
+			} else {
+				fmt.Println(r.Id)
+			}
+			size += r.Size
 		} else {
 			errs = append(errs, r.Err)
 		}
 	}
+	if size > 0 {
+		fmt.Fprintf(os.Stdout, "Size: %d\n", size)
+	}
 	return errs.PrintErrors()
 }
 
+func PrintInterruptedPullsPruneResults(report *define.InterruptedPullsPruneReport, heading bool) error {
+	if report == nil {
+		return nil
+	}
+	if heading && len(report.Pulls) > 0 {
+		fmt.Println("Cleared Interrupted Pulls")
+	}
+	for _, p := range report.Pulls {
+		fmt.Println(p)
+	}
+	if heading && len(report.Layers) > 0 {
+		fmt.Println("Deleted Layers")
+	}
+	for _, l := range report.Layers {
+		fmt.Println(l)
+	}
+	if report.Size > 0 {
+		fmt.Fprintf(os.Stdout, "Size: %d\n", report.Size)
+	}
+	return nil
+}
+
 func PrintImagePruneResults(imagePruneReport *entities.ImagePruneReport, heading bool) error {
 	if heading && (len(imagePruneReport.Report.Id) > 0 || len(imagePruneReport.Report.Err) > 0) {
 		fmt.Println("Deleted Images")
@@ -0,0 +1,42 @@
+package volumes
+
+import (
+	"context"
+
+	"github.com/containers/podman/v2/cmd/podman/common"
+	"github.com/containers/podman/v2/cmd/podman/registry"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	volumeImportDescription = `Import the contents of a tar archive into a volume, reading from stdin if FILE is "-".
+
+  Existing contents of the volume are kept unless they are overwritten by the archive.`
+	importCommand = &cobra.Command{
+		Use:               "import VOLUME FILE|-",
+		Short:             "Import a tar archive into a volume",
+		Long:              volumeImportDescription,
+		RunE:              importVolume,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: common.AutocompleteVolumeImportCommand,
+		Example: `podman volume import myvol myvol.tar
+  cat myvol.tar | podman volume import myvol -`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Mode:    []entities.EngineMode{entities.ABIMode, entities.TunnelMode},
+		Command: importCommand,
+		Parent:  volumeCmd,
+	})
+}
+
+func importVolume(cmd *cobra.Command, args []string) error {
+	opts := entities.VolumeImportOptions{Input: args[1]}
+	if opts.Input == "-" {
+		opts.Input = "/dev/stdin"
+	}
+	return registry.ContainerEngine().VolumeImport(context.Background(), args[0], opts)
+}
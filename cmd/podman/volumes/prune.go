@@ -20,6 +20,7 @@ import (
 var (
 	volumePruneDescription = `Volumes that are not currently owned by a container will be removed.
 
+  With --force-unused, volumes owned only by stopped containers are removed as well, together with these containers.
   The command prompts for confirmation which can be overridden with the --force flag.
   Note all data will be destroyed.`
 	pruneCommand = &cobra.Command{
@@ -30,7 +31,8 @@ var (
 		RunE:              prune,
 		ValidArgsFunction: completion.AutocompleteNone,
 	}
-	filter = []string{}
+	filter       = []string{}
+	pruneOptions = entities.VolumePruneOptions{}
 )
 
 func init() {
@@ -45,12 +47,10 @@ func init() {
 	flags.StringArrayVar(&filter, filterFlagName, []string{}, "Provide filter values (e.g. 'label=<key>=<value>')")
 	_ = pruneCommand.RegisterFlagCompletionFunc(filterFlagName, common.AutocompleteVolumeFilters)
 	flags.BoolP("force", "f", false, "Do not prompt for confirmation")
+	flags.BoolVar(&pruneOptions.ForceUnused, "force-unused", false, "Also remove volumes used only by stopped containers, and these containers")
 }
 
 func prune(cmd *cobra.Command, args []string) error {
-	var (
-		pruneOptions = entities.VolumePruneOptions{}
-	)
 	// Prompt for confirmation if --force is not set
 	force, err := cmd.Flags().GetBool("force")
 	if err != nil {
@@ -58,7 +58,11 @@ func prune(cmd *cobra.Command, args []string) error {
 	}
 	if !force {
 		reader := bufio.NewReader(os.Stdin)
-		fmt.Println("WARNING! This will remove all volumes not used by at least one container.")
+		if pruneOptions.ForceUnused {
+			fmt.Println("WARNING! This will remove all volumes not used by at least one running or paused container, and the stopped containers using them.")
+		} else {
+			fmt.Println("WARNING! This will remove all volumes not used by at least one container.")
+		}
 		fmt.Print("Are you sure you want to continue? [y/N] ")
 		answer, err := reader.ReadString('\n')
 		if err != nil {
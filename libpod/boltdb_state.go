@@ -19,7 +19,7 @@ var json = jsoniter.ConfigCompatibleWithStandardLibrary
 type BoltState struct {
 	valid          bool
 	dbPath         string
-	dbLock         sync.Mutex
+	dbLock         sync.RWMutex
 	namespace      string
 	namespaceBytes []byte
 	runtime        *Runtime
@@ -95,6 +95,7 @@ func NewBoltState(path string, runtime *Runtime) (State, error) {
 		allVolsBkt,
 		execBkt,
 		runtimeConfigBkt,
+		reservedNamesBkt,
 	}
 
 	// Does the DB need an update?
@@ -353,11 +354,11 @@ func (s *BoltState) GetDBConfig() (*DBConfig, error) {
 
 	cfg := new(DBConfig)
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return nil, err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	err = db.View(func(tx *bolt.Tx) error {
 		configBucket, err := getRuntimeConfigBucket(tx)
@@ -440,11 +441,11 @@ func (s *BoltState) GetName(id string) (string, error) {
 
 	idBytes := []byte(id)
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return "", err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	name := ""
 
@@ -497,11 +498,11 @@ func (s *BoltState) Container(id string) (*Container, error) {
 	ctr.config = new(ContainerConfig)
 	ctr.state = new(ContainerState)
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return nil, err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	err = db.View(func(tx *bolt.Tx) error {
 		ctrBucket, err := getCtrBucket(tx)
@@ -529,11 +530,11 @@ func (s *BoltState) LookupContainerID(idOrName string) (string, error) {
 		return "", define.ErrDBClosed
 	}
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return "", err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	var id []byte
 	err = db.View(func(tx *bolt.Tx) error {
@@ -587,11 +588,11 @@ func (s *BoltState) LookupContainer(idOrName string) (*Container, error) {
 	ctr.config = new(ContainerConfig)
 	ctr.state = new(ContainerState)
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return nil, err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	err = db.View(func(tx *bolt.Tx) error {
 		ctrBucket, err := getCtrBucket(tx)
@@ -635,11 +636,11 @@ func (s *BoltState) HasContainer(id string) (bool, error) {
 
 	ctrID := []byte(id)
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return false, err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	exists := false
 
@@ -688,6 +689,39 @@ func (s *BoltState) AddContainer(ctr *Container) error {
 	return s.addContainer(ctr, nil)
 }
 
+// AddContainers adds multiple containers to the state in a single transaction
+// None of the containers being added can belong to a pod
+func (s *BoltState) AddContainers(ctrs []*Container) error {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	for _, ctr := range ctrs {
+		if !ctr.valid {
+			return define.ErrCtrRemoved
+		}
+
+		if ctr.config.Pod != "" {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot add container %s that belongs to a pod with AddContainers - use AddContainerToPod", ctr.ID())
+		}
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	return db.Update(func(tx *bolt.Tx) error {
+		for _, ctr := range ctrs {
+			if err := s.addContainerTx(tx, ctr, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // RemoveContainer removes a container from the state
 // Only removes containers not in pods - for containers that are a member of a
 // pod, use RemoveContainerFromPod
@@ -726,16 +760,18 @@ func (s *BoltState) UpdateContainer(ctr *Container) error {
 		return errors.Wrapf(define.ErrNSMismatch, "container %s is in namespace %q, does not match our namespace %q", ctr.ID(), ctr.config.Namespace, s.namespace)
 	}
 
-	newState := new(ContainerState)
-	netNSPath := ""
+	var (
+		newState  *ContainerState
+		netNSPath string
+	)
 
 	ctrID := []byte(ctr.ID())
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	err = db.View(func(tx *bolt.Tx) error {
 		ctrBucket, err := getCtrBucket(tx)
@@ -743,27 +779,11 @@ func (s *BoltState) UpdateContainer(ctr *Container) error {
 			return err
 		}
 
-		ctrToUpdate := ctrBucket.Bucket(ctrID)
-		if ctrToUpdate == nil {
+		newState, netNSPath, err = getContainerStateDB(ctrID, ctrBucket)
+		if errors.Cause(err) == define.ErrNoSuchCtr {
 			ctr.valid = false
-			return errors.Wrapf(define.ErrNoSuchCtr, "container %s does not exist in database", ctr.ID())
-		}
-
-		newStateBytes := ctrToUpdate.Get(stateKey)
-		if newStateBytes == nil {
-			return errors.Wrapf(define.ErrInternal, "container %s does not have a state key in DB", ctr.ID())
-		}
-
-		if err := json.Unmarshal(newStateBytes, newState); err != nil {
-			return errors.Wrapf(err, "error unmarshalling container %s state", ctr.ID())
 		}
-
-		netNSBytes := ctrToUpdate.Get(netNSKey)
-		if netNSBytes != nil {
-			netNSPath = string(netNSBytes)
-		}
-
-		return nil
+		return err
 	})
 	if err != nil {
 		return err
@@ -863,11 +883,11 @@ func (s *BoltState) ContainerInUse(ctr *Container) ([]string, error) {
 
 	depCtrs := []string{}
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return nil, err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	err = db.View(func(tx *bolt.Tx) error {
 		ctrBucket, err := getCtrBucket(tx)
@@ -908,17 +928,27 @@ func (s *BoltState) ContainerInUse(ctr *Container) ([]string, error) {
 
 // AllContainers retrieves all the containers in the database
 func (s *BoltState) AllContainers() ([]*Container, error) {
+	return s.allContainers(false)
+}
+
+// AllContainersWithState retrieves all the containers in the database
+// including their state, which is read in the same transaction.
+func (s *BoltState) AllContainersWithState() ([]*Container, error) {
+	return s.allContainers(true)
+}
+
+func (s *BoltState) allContainers(loadState bool) ([]*Container, error) {
 	if !s.valid {
 		return nil, define.ErrDBClosed
 	}
 
 	ctrs := []*Container{}
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return nil, err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	err = db.View(func(tx *bolt.Tx) error {
 		allCtrsBucket, err := getAllCtrsBucket(tx)
@@ -956,10 +986,26 @@ func (s *BoltState) AllContainers() ([]*Container, error) {
 					// could render libpod unusable.
 					logrus.Errorf("Error retrieving container %s from the database: %v", string(id), err)
 				}
-			} else {
-				ctrs = append(ctrs, ctr)
+				return nil
 			}
 
+			if loadState {
+				newState, netNSPath, err := getContainerStateDB(id, ctrBucket)
+				if err != nil {
+					logrus.Errorf("Error retrieving state of container %s from the database: %v", string(id), err)
+					return nil
+				}
+				// Handle network namespace.
+				if os.Geteuid() == 0 {
+					if err := replaceNetNS(netNSPath, ctr, newState); err != nil {
+						logrus.Errorf("Error retrieving network namespace of container %s: %v", string(id), err)
+						return nil
+					}
+				}
+				ctr.state = newState
+			}
+			ctrs = append(ctrs, ctr)
+
 			return nil
 
 		})
@@ -987,11 +1033,11 @@ func (s *BoltState) GetNetworks(ctr *Container) ([]string, error) {
 
 	ctrID := []byte(ctr.ID())
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return nil, err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	networks := []string{}
 
@@ -1044,11 +1090,11 @@ func (s *BoltState) GetNetworkAliases(ctr *Container, network string) ([]string,
 
 	ctrID := []byte(ctr.ID())
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return nil, err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	aliases := []string{}
 
@@ -1116,11 +1162,11 @@ func (s *BoltState) GetAllNetworkAliases(ctr *Container) (map[string][]string, e
 
 	ctrID := []byte(ctr.ID())
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return nil, err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	aliases := make(map[string][]string)
 
@@ -1336,11 +1382,11 @@ func (s *BoltState) GetContainerConfig(id string) (*ContainerConfig, error) {
 
 	config := new(ContainerConfig)
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return nil, err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	err = db.View(func(tx *bolt.Tx) error {
 		ctrBucket, err := getCtrBucket(tx)
@@ -1426,11 +1472,11 @@ func (s *BoltState) GetExecSession(id string) (string, error) {
 		return "", define.ErrEmptyID
 	}
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return "", err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	ctrID := ""
 	err = db.View(func(tx *bolt.Tx) error {
@@ -1527,11 +1573,11 @@ func (s *BoltState) GetContainerExecSessions(ctr *Container) ([]string, error) {
 		return nil, define.ErrCtrRemoved
 	}
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return nil, err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	ctrID := []byte(ctr.ID())
 	sessions := []string{}
@@ -1682,6 +1728,124 @@ func (s *BoltState) RewriteContainerConfig(ctr *Container, newCfg *ContainerConf
 	return err
 }
 
+// SafeRewriteContainerConfig rewrites a container's configuration and changes
+// its name.  Unlike RewriteContainerConfig, it is safe to change the name with
+// this function, as all name indexes are updated in the same transaction.
+func (s *BoltState) SafeRewriteContainerConfig(ctr *Container, oldName, newName string, newCfg *ContainerConfig) error {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	if !ctr.valid {
+		return define.ErrCtrRemoved
+	}
+
+	if newName != "" && newCfg.Name != newName {
+		return errors.Wrapf(define.ErrInvalidArg, "new name %s for container %s must match name in given container config", newName, ctr.ID())
+	}
+	if newName != "" && oldName == "" {
+		return errors.Wrapf(define.ErrInvalidArg, "must provide old name for container %s if doing a rename", ctr.ID())
+	}
+
+	newCfgJSON, err := json.Marshal(newCfg)
+	if err != nil {
+		return errors.Wrapf(err, "error marshalling new configuration JSON for container %s", ctr.ID())
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		if newName != "" {
+			idBkt, err := getIDBucket(tx)
+			if err != nil {
+				return err
+			}
+			namesBkt, err := getNamesBucket(tx)
+			if err != nil {
+				return err
+			}
+			allCtrsBkt, err := getAllCtrsBucket(tx)
+			if err != nil {
+				return err
+			}
+
+			needsRename := true
+			if exists := namesBkt.Get([]byte(newName)); exists != nil {
+				if string(exists) == ctr.ID() {
+					// Name already set to the new name.
+					needsRename = false
+				} else {
+					err := define.ErrCtrExists
+					if allCtrsBkt.Get(exists) == nil {
+						err = define.ErrPodExists
+					}
+					return errors.Wrapf(err, "name %q is in use", newName)
+				}
+			}
+
+			if needsRename {
+				if err := claimNameTx(tx, newName, newCfg.Owner); err != nil {
+					return err
+				}
+				// We do have to remove the old name. The other
+				// buckets are ID-indexed so we just need to
+				// overwrite the values there.
+				if err := namesBkt.Delete([]byte(oldName)); err != nil {
+					return errors.Wrapf(err, "error deleting container %s old name from DB for rename", ctr.ID())
+				}
+				if err := idBkt.Put([]byte(ctr.ID()), []byte(newName)); err != nil {
+					return errors.Wrapf(err, "error renaming container %s in ID bucket in DB", ctr.ID())
+				}
+				if err := namesBkt.Put([]byte(newName), []byte(ctr.ID())); err != nil {
+					return errors.Wrapf(err, "error adding new name %s for container %s to DB", newName, ctr.ID())
+				}
+				if err := allCtrsBkt.Put([]byte(ctr.ID()), []byte(newName)); err != nil {
+					return errors.Wrapf(err, "error renaming container %s in all containers bucket in DB", ctr.ID())
+				}
+				if ctr.config.Pod != "" {
+					podsBkt, err := getPodBucket(tx)
+					if err != nil {
+						return err
+					}
+					podBkt := podsBkt.Bucket([]byte(ctr.config.Pod))
+					if podBkt == nil {
+						return errors.Wrapf(define.ErrInternal, "bucket for pod %s does not exist", ctr.config.Pod)
+					}
+					podCtrBkt := podBkt.Bucket(containersBkt)
+					if podCtrBkt == nil {
+						return errors.Wrapf(define.ErrInternal, "pod %s does not have a containers bucket", ctr.config.Pod)
+					}
+					if err := podCtrBkt.Put([]byte(ctr.ID()), []byte(newName)); err != nil {
+						return errors.Wrapf(err, "error renaming container %s in pod %s members bucket", ctr.ID(), ctr.config.Pod)
+					}
+				}
+			}
+		}
+
+		ctrBkt, err := getCtrBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		ctrDB := ctrBkt.Bucket([]byte(ctr.ID()))
+		if ctrDB == nil {
+			ctr.valid = false
+			return errors.Wrapf(define.ErrNoSuchCtr, "no container with ID %s found in DB", ctr.ID())
+		}
+
+		if err := ctrDB.Put(configKey, newCfgJSON); err != nil {
+			return errors.Wrapf(err, "error updating container %s config JSON", ctr.ID())
+		}
+
+		return nil
+	})
+	return err
+}
+
 // RewritePodConfig rewrites a pod's configuration.
 // WARNING: This function is DANGEROUS. Do not use without reading the full
 // comment on this function in state.go.
@@ -1726,6 +1890,107 @@ func (s *BoltState) RewritePodConfig(pod *Pod, newCfg *PodConfig) error {
 	return err
 }
 
+// SafeRewritePodConfig rewrites a pod's configuration and changes its name.
+// Unlike RewritePodConfig, it is safe to change the name with this function,
+// as all name indexes are updated in the same transaction.
+func (s *BoltState) SafeRewritePodConfig(pod *Pod, oldName, newName string, newCfg *PodConfig) error {
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	if !pod.valid {
+		return define.ErrPodRemoved
+	}
+
+	if newName != "" && newCfg.Name != newName {
+		return errors.Wrapf(define.ErrInvalidArg, "new name %s for pod %s must match name in given pod config", newName, pod.ID())
+	}
+	if newName != "" && oldName == "" {
+		return errors.Wrapf(define.ErrInvalidArg, "must provide old name for pod %s if doing a rename", pod.ID())
+	}
+
+	newCfgJSON, err := json.Marshal(newCfg)
+	if err != nil {
+		return errors.Wrapf(err, "error marshalling new configuration JSON for pod %s", pod.ID())
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		if newName != "" {
+			idBkt, err := getIDBucket(tx)
+			if err != nil {
+				return err
+			}
+			namesBkt, err := getNamesBucket(tx)
+			if err != nil {
+				return err
+			}
+			allPodsBkt, err := getAllPodsBucket(tx)
+			if err != nil {
+				return err
+			}
+
+			needsRename := true
+			if exists := namesBkt.Get([]byte(newName)); exists != nil {
+				if string(exists) == pod.ID() {
+					// Name already set to the new name.
+					needsRename = false
+				} else {
+					err := define.ErrPodExists
+					if allPodsBkt.Get(exists) == nil {
+						err = define.ErrCtrExists
+					}
+					return errors.Wrapf(err, "name %q is in use", newName)
+				}
+			}
+
+			if needsRename {
+				if err := claimNameTx(tx, newName, newCfg.Owner); err != nil {
+					return err
+				}
+				// We do have to remove the old name. The other
+				// buckets are ID-indexed so we just need to
+				// overwrite the values there.
+				if err := namesBkt.Delete([]byte(oldName)); err != nil {
+					return errors.Wrapf(err, "error deleting pod %s old name from DB for rename", pod.ID())
+				}
+				if err := idBkt.Put([]byte(pod.ID()), []byte(newName)); err != nil {
+					return errors.Wrapf(err, "error renaming pod %s in ID bucket in DB", pod.ID())
+				}
+				if err := namesBkt.Put([]byte(newName), []byte(pod.ID())); err != nil {
+					return errors.Wrapf(err, "error adding new name %s for pod %s to DB", newName, pod.ID())
+				}
+				if err := allPodsBkt.Put([]byte(pod.ID()), []byte(newName)); err != nil {
+					return errors.Wrapf(err, "error renaming pod %s in all pods bucket in DB", pod.ID())
+				}
+			}
+		}
+
+		podBkt, err := getPodBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		podDB := podBkt.Bucket([]byte(pod.ID()))
+		if podDB == nil {
+			pod.valid = false
+			return errors.Wrapf(define.ErrNoSuchPod, "no pod with ID %s found in DB", pod.ID())
+		}
+
+		if err := podDB.Put(configKey, newCfgJSON); err != nil {
+			return errors.Wrapf(err, "error updating pod %s config JSON", pod.ID())
+		}
+
+		return nil
+	})
+	return err
+}
+
 // RewriteVolumeConfig rewrites a volume's configuration.
 // WARNING: This function is DANGEROUS. Do not use without reading the full
 // comment on this function in state.go.
@@ -1786,11 +2051,11 @@ func (s *BoltState) Pod(id string) (*Pod, error) {
 	pod.config = new(PodConfig)
 	pod.state = new(podState)
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return nil, err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	err = db.View(func(tx *bolt.Tx) error {
 		podBkt, err := getPodBucket(tx)
@@ -1821,11 +2086,11 @@ func (s *BoltState) LookupPod(idOrName string) (*Pod, error) {
 	pod.config = new(PodConfig)
 	pod.state = new(podState)
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return nil, err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	err = db.View(func(tx *bolt.Tx) error {
 		podBkt, err := getPodBucket(tx)
@@ -1928,11 +2193,11 @@ func (s *BoltState) HasPod(id string) (bool, error) {
 
 	exists := false
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return false, err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	err = db.View(func(tx *bolt.Tx) error {
 		podBkt, err := getPodBucket(tx)
@@ -1984,11 +2249,11 @@ func (s *BoltState) PodHasContainer(pod *Pod, id string) (bool, error) {
 
 	exists := false
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return false, err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	err = db.View(func(tx *bolt.Tx) error {
 		podBkt, err := getPodBucket(tx)
@@ -2046,11 +2311,11 @@ func (s *BoltState) PodContainersByID(pod *Pod) ([]string, error) {
 
 	ctrs := []string{}
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return nil, err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	err = db.View(func(tx *bolt.Tx) error {
 		podBkt, err := getPodBucket(tx)
@@ -2108,11 +2373,11 @@ func (s *BoltState) PodContainers(pod *Pod) ([]*Container, error) {
 
 	ctrs := []*Container{}
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return nil, err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	err = db.View(func(tx *bolt.Tx) error {
 		podBkt, err := getPodBucket(tx)
@@ -2337,11 +2602,11 @@ func (s *BoltState) UpdateVolume(volume *Volume) error {
 	newState := new(VolumeState)
 	volumeName := []byte(volume.Name())
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	err = db.View(func(tx *bolt.Tx) error {
 		volBucket, err := getVolBucket(tx)
@@ -2429,11 +2694,11 @@ func (s *BoltState) AllVolumes() ([]*Volume, error) {
 
 	volumes := []*Volume{}
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return nil, err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	err = db.View(func(tx *bolt.Tx) error {
 		allVolsBucket, err := getAllVolsBucket(tx)
@@ -2492,11 +2757,11 @@ func (s *BoltState) Volume(name string) (*Volume, error) {
 	volume.config = new(VolumeConfig)
 	volume.state = new(VolumeState)
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return nil, err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	err = db.View(func(tx *bolt.Tx) error {
 		volBkt, err := getVolBucket(tx)
@@ -2529,11 +2794,11 @@ func (s *BoltState) LookupVolume(name string) (*Volume, error) {
 	volume.config = new(VolumeConfig)
 	volume.state = new(VolumeState)
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return nil, err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	err = db.View(func(tx *bolt.Tx) error {
 		volBkt, err := getVolBucket(tx)
@@ -2596,11 +2861,11 @@ func (s *BoltState) HasVolume(name string) (bool, error) {
 
 	exists := false
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return false, err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	err = db.View(func(tx *bolt.Tx) error {
 		volBkt, err := getVolBucket(tx)
@@ -2636,11 +2901,11 @@ func (s *BoltState) VolumeInUse(volume *Volume) ([]string, error) {
 
 	depCtrs := []string{}
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return nil, err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	err = db.View(func(tx *bolt.Tx) error {
 		volBucket, err := getVolBucket(tx)
@@ -2771,6 +3036,9 @@ func (s *BoltState) AddPod(pod *Pod) error {
 			}
 			return errors.Wrapf(err, "name \"%s\" is in use", pod.Name())
 		}
+		if err := claimNameTx(tx, pod.Name(), pod.config.Owner); err != nil {
+			return err
+		}
 
 		// We are good to add the pod
 		// Make a bucket for it
@@ -3123,11 +3391,11 @@ func (s *BoltState) UpdatePod(pod *Pod) error {
 
 	newState := new(podState)
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	podID := []byte(pod.ID())
 
@@ -3225,11 +3493,11 @@ func (s *BoltState) AllPods() ([]*Pod, error) {
 
 	pods := []*Pod{}
 
-	db, err := s.getDBCon()
+	db, err := s.getDBConReadOnly()
 	if err != nil {
 		return nil, err
 	}
-	defer s.deferredCloseDBCon(db)
+	defer s.deferredCloseDBConReadOnly(db)
 
 	err = db.View(func(tx *bolt.Tx) error {
 		allPodsBucket, err := getAllPodsBucket(tx)
@@ -3272,3 +3540,136 @@ func (s *BoltState) AllPods() ([]*Pod, error) {
 
 	return pods, nil
 }
+
+// ReserveName reserves a name for a container or pod to be created later.
+// The name must be neither used by a container or pod nor reserved already.
+// Expired reservations are removed.
+func (s *BoltState) ReserveName(reservation *define.NameReservation) error {
+	if reservation.Name == "" {
+		return define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	name := []byte(reservation.Name)
+
+	reservationJSON, err := json.Marshal(reservation)
+	if err != nil {
+		return errors.Wrapf(err, "error marshalling reservation of name %s to JSON", reservation.Name)
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		namesBkt, err := getNamesBucket(tx)
+		if err != nil {
+			return err
+		}
+		reservedBkt, err := getReservedNamesBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		if namesBkt.Get(name) != nil {
+			return errors.Wrapf(define.ErrCtrExists, "the name %q is used by a container or pod", reservation.Name)
+		}
+		if err := pruneNameReservationsTx(reservedBkt); err != nil {
+			return err
+		}
+		if reservedBkt.Get(name) != nil {
+			return errors.Wrapf(define.ErrNameReserved, "name %q", reservation.Name)
+		}
+
+		if err := reservedBkt.Put(name, reservationJSON); err != nil {
+			return errors.Wrapf(err, "error adding reservation of name %s to DB", reservation.Name)
+		}
+		return nil
+	})
+	return err
+}
+
+// ReleaseName removes the reservation of a name.  Only the owner of an
+// unexpired reservation may release it.
+func (s *BoltState) ReleaseName(name, owner string) error {
+	if name == "" {
+		return define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		reservedBkt, err := getReservedNamesBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		reservationBytes := reservedBkt.Get([]byte(name))
+		if reservationBytes == nil {
+			return errors.Wrapf(define.ErrNameNotReserved, "name %q", name)
+		}
+		reservation := new(define.NameReservation)
+		if err := json.Unmarshal(reservationBytes, reservation); err != nil {
+			return errors.Wrapf(err, "error unmarshalling reservation of name %s from DB", name)
+		}
+		if !nameReservationExpired(reservation) && reservation.Owner != owner {
+			return errors.Wrapf(define.ErrNameReserved, "name %q is reserved by %q", name, reservation.Owner)
+		}
+		if err := reservedBkt.Delete([]byte(name)); err != nil {
+			return errors.Wrapf(err, "error removing reservation of name %s from DB", name)
+		}
+		return nil
+	})
+	return err
+}
+
+// NameReservations returns the unexpired name reservations.
+func (s *BoltState) NameReservations() ([]define.NameReservation, error) {
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	reservations := []define.NameReservation{}
+
+	db, err := s.getDBConReadOnly()
+	if err != nil {
+		return nil, err
+	}
+	defer s.deferredCloseDBConReadOnly(db)
+
+	err = db.View(func(tx *bolt.Tx) error {
+		reservedBkt, err := getReservedNamesBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		return reservedBkt.ForEach(func(name, reservationBytes []byte) error {
+			reservation := define.NameReservation{}
+			if err := json.Unmarshal(reservationBytes, &reservation); err != nil {
+				return errors.Wrapf(err, "error unmarshalling reservation of name %s from DB", string(name))
+			}
+			if !nameReservationExpired(&reservation) {
+				reservations = append(reservations, reservation)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
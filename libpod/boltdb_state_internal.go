@@ -28,6 +28,7 @@ const (
 	execName          = "exec"
 	aliasesName       = "aliases"
 	runtimeConfigName = "runtime-config"
+	reservedNamesName = "reserved-names"
 
 	configName         = "config"
 	stateName          = "state"
@@ -64,6 +65,7 @@ var (
 	dependenciesBkt    = []byte(dependenciesName)
 	volDependenciesBkt = []byte(volCtrDependencies)
 	networksBkt        = []byte(networksName)
+	reservedNamesBkt   = []byte(reservedNamesName)
 
 	configKey     = []byte(configName)
 	stateKey      = []byte(stateName)
@@ -248,6 +250,24 @@ func (s *BoltState) getDBCon() (*bolt.DB, error) {
 
 	db, err := bolt.Open(s.dbPath, 0600, nil)
 	if err != nil {
+		s.dbLock.Unlock()
+		return nil, errors.Wrapf(err, "error opening database %s", s.dbPath)
+	}
+
+	return db, nil
+}
+
+// getDBConReadOnly opens a read-only connection to the database.  Read-only
+// connections only hold a shared lock on the database, so readers such as
+// podman ps do not serialize against each other.  Only View() transactions can
+// be used on it.
+// MUST be closed with closeDBConReadOnly.
+func (s *BoltState) getDBConReadOnly() (*bolt.DB, error) {
+	s.dbLock.RLock()
+
+	db, err := bolt.Open(s.dbPath, 0600, &bolt.Options{ReadOnly: true})
+	if err != nil {
+		s.dbLock.RUnlock()
 		return nil, errors.Wrapf(err, "error opening database %s", s.dbPath)
 	}
 
@@ -263,6 +283,14 @@ func (s *BoltState) deferredCloseDBCon(db *bolt.DB) {
 	}
 }
 
+// deferredCloseDBConReadOnly is the read-only equivalent of
+// deferredCloseDBCon.
+func (s *BoltState) deferredCloseDBConReadOnly(db *bolt.DB) {
+	if err := s.closeDBConReadOnly(db); err != nil {
+		logrus.Errorf("failed to close libpod db: %q", err)
+	}
+}
+
 // Close a connection to the database.
 // MUST be used in place of `db.Close()` to ensure proper unlocking of the
 // state.
@@ -274,6 +302,17 @@ func (s *BoltState) closeDBCon(db *bolt.DB) error {
 	return err
 }
 
+// Close a read-only connection to the database.
+// MUST be used in place of `db.Close()` to ensure proper unlocking of the
+// state.
+func (s *BoltState) closeDBConReadOnly(db *bolt.DB) error {
+	err := db.Close()
+
+	s.dbLock.RUnlock()
+
+	return err
+}
+
 func getIDBucket(tx *bolt.Tx) (*bolt.Bucket, error) {
 	bkt := tx.Bucket(idRegistryBkt)
 	if bkt == nil {
@@ -362,6 +401,63 @@ func getRuntimeConfigBucket(tx *bolt.Tx) (*bolt.Bucket, error) {
 	return bkt, nil
 }
 
+func getReservedNamesBucket(tx *bolt.Tx) (*bolt.Bucket, error) {
+	bkt := tx.Bucket(reservedNamesBkt)
+	if bkt == nil {
+		return nil, errors.Wrapf(define.ErrDBBadConfig, "reserved names bucket not found in DB")
+	}
+	return bkt, nil
+}
+
+// claimNameTx checks that the name of a container or pod of the given owner
+// which is added to the DB is not reserved by another owner, and consumes a
+// reservation of the name by the owner.  Expired reservations are removed.
+func claimNameTx(tx *bolt.Tx, name, owner string) error {
+	namesBkt, err := getReservedNamesBucket(tx)
+	if err != nil {
+		return err
+	}
+	reservationBytes := namesBkt.Get([]byte(name))
+	if reservationBytes == nil {
+		return nil
+	}
+	reservation := new(define.NameReservation)
+	if err := json.Unmarshal(reservationBytes, reservation); err != nil {
+		return errors.Wrapf(err, "error unmarshalling reservation of name %s from DB", name)
+	}
+	if !nameReservationExpired(reservation) && reservation.Owner != owner {
+		return errors.Wrapf(define.ErrNameReserved, "name %q is reserved by %q", name, reservation.Owner)
+	}
+	if err := namesBkt.Delete([]byte(name)); err != nil {
+		return errors.Wrapf(err, "error removing reservation of name %s from DB", name)
+	}
+	return nil
+}
+
+// pruneNameReservationsTx removes the expired name reservations.
+func pruneNameReservationsTx(reservedBkt *bolt.Bucket) error {
+	var expired [][]byte
+	err := reservedBkt.ForEach(func(name, reservationBytes []byte) error {
+		reservation := new(define.NameReservation)
+		if err := json.Unmarshal(reservationBytes, reservation); err != nil {
+			return errors.Wrapf(err, "error unmarshalling reservation of name %s from DB", string(name))
+		}
+		if nameReservationExpired(reservation) {
+			expired = append(expired, append([]byte{}, name...))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, name := range expired {
+		if err := reservedBkt.Delete(name); err != nil {
+			return errors.Wrapf(err, "error removing reservation of name %s from DB", string(name))
+		}
+	}
+	return nil
+}
+
 func (s *BoltState) getContainerConfigFromDB(id []byte, config *ContainerConfig, ctrsBkt *bolt.Bucket) error {
 	ctrBkt := ctrsBkt.Bucket(id)
 	if ctrBkt == nil {
@@ -387,6 +483,33 @@ func (s *BoltState) getContainerConfigFromDB(id []byte, config *ContainerConfig,
 	return nil
 }
 
+// getContainerStateDB reads the state of the container with the given ID from
+// the containers bucket.  It returns the state and the path of the container's
+// network namespace, which is not part of the state.
+func getContainerStateDB(id []byte, ctrsBkt *bolt.Bucket) (*ContainerState, string, error) {
+	ctrBkt := ctrsBkt.Bucket(id)
+	if ctrBkt == nil {
+		return nil, "", errors.Wrapf(define.ErrNoSuchCtr, "container %s does not exist in database", string(id))
+	}
+
+	stateBytes := ctrBkt.Get(stateKey)
+	if stateBytes == nil {
+		return nil, "", errors.Wrapf(define.ErrInternal, "container %s does not have a state key in DB", string(id))
+	}
+
+	state := new(ContainerState)
+	if err := json.Unmarshal(stateBytes, state); err != nil {
+		return nil, "", errors.Wrapf(err, "error unmarshalling container %s state", string(id))
+	}
+
+	netNSPath := ""
+	if netNSBytes := ctrBkt.Get(netNSKey); netNSBytes != nil {
+		netNSPath = string(netNSBytes)
+	}
+
+	return state, netNSPath, nil
+}
+
 func (s *BoltState) getContainerFromDB(id []byte, ctr *Container, ctrsBkt *bolt.Bucket) error {
 	if err := s.getContainerConfigFromDB(id, ctr.config, ctrsBkt); err != nil {
 		return err
@@ -513,6 +636,20 @@ func (s *BoltState) getVolumeFromDB(name []byte, volume *Volume, volBkt *bolt.Bu
 // Add a container to the DB
 // If pod is not nil, the container is added to the pod as well
 func (s *BoltState) addContainer(ctr *Container, pod *Pod) error {
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	return db.Update(func(tx *bolt.Tx) error {
+		return s.addContainerTx(tx, ctr, pod)
+	})
+}
+
+// Add a container to the DB as part of the given transaction
+// If pod is not nil, the container is added to the pod as well
+func (s *BoltState) addContainerTx(tx *bolt.Tx, ctr *Container, pod *Pod) error {
 	if s.namespace != "" && s.namespace != ctr.config.Namespace {
 		return errors.Wrapf(define.ErrNSMismatch, "cannot add container %s as it is in namespace %q and we are in namespace %q",
 			ctr.ID(), s.namespace, ctr.config.Namespace)
@@ -539,248 +676,242 @@ func (s *BoltState) addContainer(ctr *Container, pod *Pod) error {
 		ctrNamespace = []byte(ctr.config.Namespace)
 	}
 
-	db, err := s.getDBCon()
+	idsBucket, err := getIDBucket(tx)
 	if err != nil {
 		return err
 	}
-	defer s.deferredCloseDBCon(db)
 
-	err = db.Update(func(tx *bolt.Tx) error {
-		idsBucket, err := getIDBucket(tx)
-		if err != nil {
-			return err
-		}
+	namesBucket, err := getNamesBucket(tx)
+	if err != nil {
+		return err
+	}
 
-		namesBucket, err := getNamesBucket(tx)
-		if err != nil {
-			return err
-		}
+	nsBucket, err := getNSBucket(tx)
+	if err != nil {
+		return err
+	}
 
-		nsBucket, err := getNSBucket(tx)
-		if err != nil {
-			return err
-		}
+	ctrBucket, err := getCtrBucket(tx)
+	if err != nil {
+		return err
+	}
 
-		ctrBucket, err := getCtrBucket(tx)
-		if err != nil {
-			return err
-		}
+	allCtrsBucket, err := getAllCtrsBucket(tx)
+	if err != nil {
+		return err
+	}
 
-		allCtrsBucket, err := getAllCtrsBucket(tx)
-		if err != nil {
-			return err
-		}
+	volBkt, err := getVolBucket(tx)
+	if err != nil {
+		return err
+	}
 
-		volBkt, err := getVolBucket(tx)
+	// If a pod was given, check if it exists
+	var podDB *bolt.Bucket
+	var podCtrs *bolt.Bucket
+	if pod != nil {
+		podBucket, err := getPodBucket(tx)
 		if err != nil {
 			return err
 		}
 
-		// If a pod was given, check if it exists
-		var podDB *bolt.Bucket
-		var podCtrs *bolt.Bucket
-		if pod != nil {
-			podBucket, err := getPodBucket(tx)
-			if err != nil {
-				return err
-			}
-
-			podID := []byte(pod.ID())
+		podID := []byte(pod.ID())
 
-			podDB = podBucket.Bucket(podID)
-			if podDB == nil {
-				pod.valid = false
-				return errors.Wrapf(define.ErrNoSuchPod, "pod %s does not exist in database", pod.ID())
-			}
-			podCtrs = podDB.Bucket(containersBkt)
-			if podCtrs == nil {
-				return errors.Wrapf(define.ErrInternal, "pod %s does not have a containers bucket", pod.ID())
-			}
+		podDB = podBucket.Bucket(podID)
+		if podDB == nil {
+			pod.valid = false
+			return errors.Wrapf(define.ErrNoSuchPod, "pod %s does not exist in database", pod.ID())
+		}
+		podCtrs = podDB.Bucket(containersBkt)
+		if podCtrs == nil {
+			return errors.Wrapf(define.ErrInternal, "pod %s does not have a containers bucket", pod.ID())
+		}
 
-			podNS := podDB.Get(namespaceKey)
-			if !bytes.Equal(podNS, ctrNamespace) {
-				return errors.Wrapf(define.ErrNSMismatch, "container %s is in namespace %s and pod %s is in namespace %s",
-					ctr.ID(), ctr.config.Namespace, pod.ID(), pod.config.Namespace)
-			}
+		podNS := podDB.Get(namespaceKey)
+		if !bytes.Equal(podNS, ctrNamespace) {
+			return errors.Wrapf(define.ErrNSMismatch, "container %s is in namespace %s and pod %s is in namespace %s",
+				ctr.ID(), ctr.config.Namespace, pod.ID(), pod.config.Namespace)
 		}
+	}
 
-		// Check if we already have a container with the given ID and name
-		idExist := idsBucket.Get(ctrID)
-		if idExist != nil {
-			err = define.ErrCtrExists
-			if allCtrsBucket.Get(idExist) == nil {
-				err = define.ErrPodExists
-			}
-			return errors.Wrapf(err, "ID \"%s\" is in use", ctr.ID())
+	// Check if we already have a container with the given ID and name
+	idExist := idsBucket.Get(ctrID)
+	if idExist != nil {
+		err = define.ErrCtrExists
+		if allCtrsBucket.Get(idExist) == nil {
+			err = define.ErrPodExists
 		}
-		nameExist := namesBucket.Get(ctrName)
-		if nameExist != nil {
-			err = define.ErrCtrExists
-			if allCtrsBucket.Get(nameExist) == nil {
-				err = define.ErrPodExists
-			}
-			return errors.Wrapf(err, "name \"%s\" is in use", ctr.Name())
+		return errors.Wrapf(err, "ID \"%s\" is in use", ctr.ID())
+	}
+	nameExist := namesBucket.Get(ctrName)
+	if nameExist != nil {
+		err = define.ErrCtrExists
+		if allCtrsBucket.Get(nameExist) == nil {
+			err = define.ErrPodExists
 		}
+		return errors.Wrapf(err, "name \"%s\" is in use", ctr.Name())
+	}
+	if err := claimNameTx(tx, ctr.Name(), ctr.config.Owner); err != nil {
+		return err
+	}
 
-		allNets := make(map[string]bool)
+	allNets := make(map[string]bool)
 
-		// Check that we don't have any empty network names
-		for _, net := range ctr.config.Networks {
-			if net == "" {
-				return errors.Wrapf(define.ErrInvalidArg, "network names cannot be an empty string")
-			}
-			allNets[net] = true
+	// Check that we don't have any empty network names
+	for _, net := range ctr.config.Networks {
+		if net == "" {
+			return errors.Wrapf(define.ErrInvalidArg, "network names cannot be an empty string")
 		}
+		allNets[net] = true
+	}
 
-		// Each network we have aliases for, must exist in networks
-		for net := range ctr.config.NetworkAliases {
-			if !allNets[net] {
-				return errors.Wrapf(define.ErrNoSuchNetwork, "container %s has network aliases for network %q but is not part of that network", ctr.ID(), net)
-			}
+	// Each network we have aliases for, must exist in networks
+	for net := range ctr.config.NetworkAliases {
+		if !allNets[net] {
+			return errors.Wrapf(define.ErrNoSuchNetwork, "container %s has network aliases for network %q but is not part of that network", ctr.ID(), net)
 		}
+	}
 
-		// No overlapping containers
-		// Add the new container to the DB
-		if err := idsBucket.Put(ctrID, ctrName); err != nil {
-			return errors.Wrapf(err, "error adding container %s ID to DB", ctr.ID())
-		}
-		if err := namesBucket.Put(ctrName, ctrID); err != nil {
-			return errors.Wrapf(err, "error adding container %s name (%s) to DB", ctr.ID(), ctr.Name())
-		}
-		if ctrNamespace != nil {
-			if err := nsBucket.Put(ctrID, ctrNamespace); err != nil {
-				return errors.Wrapf(err, "error adding container %s namespace (%q) to DB", ctr.ID(), ctr.Namespace())
-			}
-		}
-		if err := allCtrsBucket.Put(ctrID, ctrName); err != nil {
-			return errors.Wrapf(err, "error adding container %s to all containers bucket in DB", ctr.ID())
+	// No overlapping containers
+	// Add the new container to the DB
+	if err := idsBucket.Put(ctrID, ctrName); err != nil {
+		return errors.Wrapf(err, "error adding container %s ID to DB", ctr.ID())
+	}
+	if err := namesBucket.Put(ctrName, ctrID); err != nil {
+		return errors.Wrapf(err, "error adding container %s name (%s) to DB", ctr.ID(), ctr.Name())
+	}
+	if ctrNamespace != nil {
+		if err := nsBucket.Put(ctrID, ctrNamespace); err != nil {
+			return errors.Wrapf(err, "error adding container %s namespace (%q) to DB", ctr.ID(), ctr.Namespace())
 		}
+	}
+	if err := allCtrsBucket.Put(ctrID, ctrName); err != nil {
+		return errors.Wrapf(err, "error adding container %s to all containers bucket in DB", ctr.ID())
+	}
 
-		newCtrBkt, err := ctrBucket.CreateBucket(ctrID)
-		if err != nil {
-			return errors.Wrapf(err, "error adding container %s bucket to DB", ctr.ID())
-		}
+	newCtrBkt, err := ctrBucket.CreateBucket(ctrID)
+	if err != nil {
+		return errors.Wrapf(err, "error adding container %s bucket to DB", ctr.ID())
+	}
 
-		if err := newCtrBkt.Put(configKey, configJSON); err != nil {
-			return errors.Wrapf(err, "error adding container %s config to DB", ctr.ID())
+	if err := newCtrBkt.Put(configKey, configJSON); err != nil {
+		return errors.Wrapf(err, "error adding container %s config to DB", ctr.ID())
+	}
+	if err := newCtrBkt.Put(stateKey, stateJSON); err != nil {
+		return errors.Wrapf(err, "error adding container %s state to DB", ctr.ID())
+	}
+	if ctrNamespace != nil {
+		if err := newCtrBkt.Put(namespaceKey, ctrNamespace); err != nil {
+			return errors.Wrapf(err, "error adding container %s namespace to DB", ctr.ID())
 		}
-		if err := newCtrBkt.Put(stateKey, stateJSON); err != nil {
-			return errors.Wrapf(err, "error adding container %s state to DB", ctr.ID())
+	}
+	if pod != nil {
+		if err := newCtrBkt.Put(podIDKey, []byte(pod.ID())); err != nil {
+			return errors.Wrapf(err, "error adding container %s pod to DB", ctr.ID())
 		}
-		if ctrNamespace != nil {
-			if err := newCtrBkt.Put(namespaceKey, ctrNamespace); err != nil {
-				return errors.Wrapf(err, "error adding container %s namespace to DB", ctr.ID())
-			}
+	}
+	if netNSPath != "" {
+		if err := newCtrBkt.Put(netNSKey, []byte(netNSPath)); err != nil {
+			return errors.Wrapf(err, "error adding container %s netns path to DB", ctr.ID())
 		}
-		if pod != nil {
-			if err := newCtrBkt.Put(podIDKey, []byte(pod.ID())); err != nil {
-				return errors.Wrapf(err, "error adding container %s pod to DB", ctr.ID())
-			}
+	}
+	if ctr.config.Networks != nil {
+		ctrNetworksBkt, err := newCtrBkt.CreateBucket(networksBkt)
+		if err != nil {
+			return errors.Wrapf(err, "error creating networks bucket for container %s", ctr.ID())
 		}
-		if netNSPath != "" {
-			if err := newCtrBkt.Put(netNSKey, []byte(netNSPath)); err != nil {
-				return errors.Wrapf(err, "error adding container %s netns path to DB", ctr.ID())
+		for _, network := range ctr.config.Networks {
+			if err := ctrNetworksBkt.Put([]byte(network), ctrID); err != nil {
+				return errors.Wrapf(err, "error adding network %q to networks bucket for container %s", network, ctr.ID())
 			}
 		}
-		if ctr.config.Networks != nil {
-			ctrNetworksBkt, err := newCtrBkt.CreateBucket(networksBkt)
-			if err != nil {
-				return errors.Wrapf(err, "error creating networks bucket for container %s", ctr.ID())
-			}
-			for _, network := range ctr.config.Networks {
-				if err := ctrNetworksBkt.Put([]byte(network), ctrID); err != nil {
-					return errors.Wrapf(err, "error adding network %q to networks bucket for container %s", network, ctr.ID())
-				}
-			}
+	}
+	if ctr.config.NetworkAliases != nil {
+		ctrAliasesBkt, err := newCtrBkt.CreateBucket(aliasesBkt)
+		if err != nil {
+			return errors.Wrapf(err, "error creating network aliases bucket for container %s", ctr.ID())
 		}
-		if ctr.config.NetworkAliases != nil {
-			ctrAliasesBkt, err := newCtrBkt.CreateBucket(aliasesBkt)
+		for net, aliases := range ctr.config.NetworkAliases {
+			netAliasesBkt, err := ctrAliasesBkt.CreateBucket([]byte(net))
 			if err != nil {
-				return errors.Wrapf(err, "error creating network aliases bucket for container %s", ctr.ID())
+				return errors.Wrapf(err, "error creating network aliases bucket for network %q in container %s", net, ctr.ID())
 			}
-			for net, aliases := range ctr.config.NetworkAliases {
-				netAliasesBkt, err := ctrAliasesBkt.CreateBucket([]byte(net))
-				if err != nil {
-					return errors.Wrapf(err, "error creating network aliases bucket for network %q in container %s", net, ctr.ID())
-				}
-				for _, alias := range aliases {
-					if err := netAliasesBkt.Put([]byte(alias), ctrID); err != nil {
-						return errors.Wrapf(err, "error creating network alias %q in network %q for container %s", alias, net, ctr.ID())
-					}
+			for _, alias := range aliases {
+				if err := netAliasesBkt.Put([]byte(alias), ctrID); err != nil {
+					return errors.Wrapf(err, "error creating network alias %q in network %q for container %s", alias, net, ctr.ID())
 				}
 			}
 		}
+	}
 
-		if _, err := newCtrBkt.CreateBucket(dependenciesBkt); err != nil {
-			return errors.Wrapf(err, "error creating dependencies bucket for container %s", ctr.ID())
-		}
+	if _, err := newCtrBkt.CreateBucket(dependenciesBkt); err != nil {
+		return errors.Wrapf(err, "error creating dependencies bucket for container %s", ctr.ID())
+	}
 
-		// Add dependencies for the container
-		for _, dependsCtr := range dependsCtrs {
-			depCtrID := []byte(dependsCtr)
+	// Add dependencies for the container
+	for _, dependsCtr := range dependsCtrs {
+		depCtrID := []byte(dependsCtr)
 
-			depCtrBkt := ctrBucket.Bucket(depCtrID)
-			if depCtrBkt == nil {
-				return errors.Wrapf(define.ErrNoSuchCtr, "container %s depends on container %s, but it does not exist in the DB", ctr.ID(), dependsCtr)
-			}
-
-			depCtrPod := depCtrBkt.Get(podIDKey)
-			if pod != nil {
-				// If we're part of a pod, make sure the dependency is part of the same pod
-				if depCtrPod == nil {
-					return errors.Wrapf(define.ErrInvalidArg, "container %s depends on container %s which is not in pod %s", ctr.ID(), dependsCtr, pod.ID())
-				}
+		depCtrBkt := ctrBucket.Bucket(depCtrID)
+		if depCtrBkt == nil {
+			return errors.Wrapf(define.ErrNoSuchCtr, "container %s depends on container %s, but it does not exist in the DB", ctr.ID(), dependsCtr)
+		}
 
-				if string(depCtrPod) != pod.ID() {
-					return errors.Wrapf(define.ErrInvalidArg, "container %s depends on container %s which is in a different pod (%s)", ctr.ID(), dependsCtr, string(depCtrPod))
-				}
-			} else if depCtrPod != nil {
-				// If we're not part of a pod, we cannot depend on containers in a pod
-				return errors.Wrapf(define.ErrInvalidArg, "container %s depends on container %s which is in a pod - containers not in pods cannot depend on containers in pods", ctr.ID(), dependsCtr)
+		depCtrPod := depCtrBkt.Get(podIDKey)
+		if pod != nil {
+			// If we're part of a pod, make sure the dependency is part of the same pod
+			if depCtrPod == nil {
+				return errors.Wrapf(define.ErrInvalidArg, "container %s depends on container %s which is not in pod %s", ctr.ID(), dependsCtr, pod.ID())
 			}
 
-			depNamespace := depCtrBkt.Get(namespaceKey)
-			if !bytes.Equal(ctrNamespace, depNamespace) {
-				return errors.Wrapf(define.ErrNSMismatch, "container %s in namespace %q depends on container %s in namespace %q - namespaces must match", ctr.ID(), ctr.config.Namespace, dependsCtr, string(depNamespace))
+			if string(depCtrPod) != pod.ID() {
+				return errors.Wrapf(define.ErrInvalidArg, "container %s depends on container %s which is in a different pod (%s)", ctr.ID(), dependsCtr, string(depCtrPod))
 			}
+		} else if depCtrPod != nil {
+			// If we're not part of a pod, we cannot depend on containers in a pod
+			return errors.Wrapf(define.ErrInvalidArg, "container %s depends on container %s which is in a pod - containers not in pods cannot depend on containers in pods", ctr.ID(), dependsCtr)
+		}
 
-			depCtrDependsBkt := depCtrBkt.Bucket(dependenciesBkt)
-			if depCtrDependsBkt == nil {
-				return errors.Wrapf(define.ErrInternal, "container %s does not have a dependencies bucket", dependsCtr)
-			}
-			if err := depCtrDependsBkt.Put(ctrID, ctrName); err != nil {
-				return errors.Wrapf(err, "error adding ctr %s as dependency of container %s", ctr.ID(), dependsCtr)
-			}
+		depNamespace := depCtrBkt.Get(namespaceKey)
+		if !bytes.Equal(ctrNamespace, depNamespace) {
+			return errors.Wrapf(define.ErrNSMismatch, "container %s in namespace %q depends on container %s in namespace %q - namespaces must match", ctr.ID(), ctr.config.Namespace, dependsCtr, string(depNamespace))
 		}
 
-		// Add ctr to pod
-		if pod != nil && podCtrs != nil {
-			if err := podCtrs.Put(ctrID, ctrName); err != nil {
-				return errors.Wrapf(err, "error adding container %s to pod %s", ctr.ID(), pod.ID())
-			}
+		depCtrDependsBkt := depCtrBkt.Bucket(dependenciesBkt)
+		if depCtrDependsBkt == nil {
+			return errors.Wrapf(define.ErrInternal, "container %s does not have a dependencies bucket", dependsCtr)
 		}
+		if err := depCtrDependsBkt.Put(ctrID, ctrName); err != nil {
+			return errors.Wrapf(err, "error adding ctr %s as dependency of container %s", ctr.ID(), dependsCtr)
+		}
+	}
 
-		// Add container to named volume dependencies buckets
-		for _, vol := range ctr.config.NamedVolumes {
-			volDB := volBkt.Bucket([]byte(vol.Name))
-			if volDB == nil {
-				return errors.Wrapf(define.ErrNoSuchVolume, "no volume with name %s found in database when adding container %s", vol.Name, ctr.ID())
-			}
+	// Add ctr to pod
+	if pod != nil && podCtrs != nil {
+		if err := podCtrs.Put(ctrID, ctrName); err != nil {
+			return errors.Wrapf(err, "error adding container %s to pod %s", ctr.ID(), pod.ID())
+		}
+	}
 
-			ctrDepsBkt, err := volDB.CreateBucketIfNotExists(volDependenciesBkt)
-			if err != nil {
-				return errors.Wrapf(err, "error creating volume %s dependencies bucket to add container %s", vol.Name, ctr.ID())
-			}
-			if depExists := ctrDepsBkt.Get(ctrID); depExists == nil {
-				if err := ctrDepsBkt.Put(ctrID, ctrID); err != nil {
-					return errors.Wrapf(err, "error adding container %s to volume %s dependencies", ctr.ID(), vol.Name)
-				}
+	// Add container to named volume dependencies buckets
+	for _, vol := range ctr.config.NamedVolumes {
+		volDB := volBkt.Bucket([]byte(vol.Name))
+		if volDB == nil {
+			return errors.Wrapf(define.ErrNoSuchVolume, "no volume with name %s found in database when adding container %s", vol.Name, ctr.ID())
+		}
+
+		ctrDepsBkt, err := volDB.CreateBucketIfNotExists(volDependenciesBkt)
+		if err != nil {
+			return errors.Wrapf(err, "error creating volume %s dependencies bucket to add container %s", vol.Name, ctr.ID())
+		}
+		if depExists := ctrDepsBkt.Get(ctrID); depExists == nil {
+			if err := ctrDepsBkt.Put(ctrID, ctrID); err != nil {
+				return errors.Wrapf(err, "error adding container %s to volume %s dependencies", ctr.ID(), vol.Name)
 			}
 		}
+	}
 
-		return nil
-	})
-	return err
+	return nil
 }
 
 // Remove a container from the DB
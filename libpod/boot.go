@@ -0,0 +1,107 @@
+package libpod
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// bootIDPath holds a random ID generated by the kernel at each boot.
+	bootIDPath = "/proc/sys/kernel/random/boot_id"
+	// procStatPath holds the boot time of the system, as btime.
+	procStatPath = "/proc/stat"
+)
+
+// currentBootID returns the ID of the current boot, or "" if the system does
+// not provide one.
+func currentBootID() string {
+	data, err := ioutil.ReadFile(bootIDPath)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// bootTime returns when the system booted.
+func bootTime() (time.Time, error) {
+	f, err := os.Open(procStatPath)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == "btime" {
+			secs, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return time.Time{}, errors.Wrapf(err, "invalid boot time in %s", procStatPath)
+			}
+			return time.Unix(secs, 0), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return time.Time{}, err
+	}
+	return time.Time{}, errors.Errorf("no boot time in %s", procStatPath)
+}
+
+// checkAliveFile returns whether the system rebooted since the state was last
+// refreshed, which wrote the runtime alive file at alivePath.  A reboot is
+// detected when the alive file does not exist, as the tmp dir is usually on a
+// tmpfs, or when it records the ID of another boot, as the tmp dir may persist
+// across reboots.
+// The alive files of older versions record no boot ID: the current boot ID is
+// then recorded, as there was no reboot since they were written.
+func checkAliveFile(alivePath, bootID string) (bool, error) {
+	data, err := ioutil.ReadFile(alivePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	recorded := strings.TrimSpace(string(data))
+	if recorded == "" {
+		if bootID == "" {
+			return false, nil
+		}
+		return false, writeAliveFile(alivePath, bootID)
+	}
+	return bootID != "" && recorded != bootID, nil
+}
+
+// writeAliveFile writes the runtime alive file at alivePath, recording that
+// the state was refreshed during the boot bootID.
+func writeAliveFile(alivePath, bootID string) error {
+	return ioutil.WriteFile(alivePath, []byte(bootID), 0644)
+}
+
+// removeIfBeforeBoot removes the file at path if it was written before the
+// system booted, e.g., a PID file of a previous boot whose PID may have been
+// reused since.
+func removeIfBeforeBoot(path string) error {
+	st, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	booted, err := bootTime()
+	if err != nil {
+		return err
+	}
+	if st.ModTime().Before(booted) {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package libpod
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckAliveFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "alive")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	alivePath := filepath.Join(dir, "alive")
+
+	// First use, or a tmp dir cleared by the reboot.
+	rebooted, err := checkAliveFile(alivePath, "boot1")
+	require.NoError(t, err)
+	assert.True(t, rebooted)
+
+	require.NoError(t, writeAliveFile(alivePath, "boot1"))
+	rebooted, err = checkAliveFile(alivePath, "boot1")
+	require.NoError(t, err)
+	assert.False(t, rebooted)
+
+	// A tmp dir persisting across the reboot.
+	rebooted, err = checkAliveFile(alivePath, "boot2")
+	require.NoError(t, err)
+	assert.True(t, rebooted)
+
+	// Without a boot ID, only a cleared tmp dir is detected.
+	rebooted, err = checkAliveFile(alivePath, "")
+	require.NoError(t, err)
+	assert.False(t, rebooted)
+
+	// The alive files of older versions are empty, and get the boot ID.
+	require.NoError(t, ioutil.WriteFile(alivePath, nil, 0644))
+	rebooted, err = checkAliveFile(alivePath, "boot2")
+	require.NoError(t, err)
+	assert.False(t, rebooted)
+	data, err := ioutil.ReadFile(alivePath)
+	require.NoError(t, err)
+	assert.Equal(t, "boot2", string(data))
+}
+
+func TestRemoveIfBeforeBoot(t *testing.T) {
+	booted, err := bootTime()
+	if err != nil {
+		t.Skipf("boot time is not available: %v", err)
+	}
+	dir, err := ioutil.TempDir("", "pidfile")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	stale := filepath.Join(dir, "stale.pid")
+	require.NoError(t, ioutil.WriteFile(stale, []byte("1234"), 0644))
+	require.NoError(t, os.Chtimes(stale, booted.Add(-time.Hour), booted.Add(-time.Hour)))
+	current := filepath.Join(dir, "current.pid")
+	require.NoError(t, ioutil.WriteFile(current, []byte("5678"), 0644))
+
+	require.NoError(t, removeIfBeforeBoot(stale))
+	require.NoError(t, removeIfBeforeBoot(current))
+	require.NoError(t, removeIfBeforeBoot(filepath.Join(dir, "missing.pid")))
+	_, err = os.Stat(stale)
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(current)
+	assert.NoError(t, err)
+}
+
+func TestResetStateExitedByReboot(t *testing.T) {
+	tests := []struct {
+		state          define.ContainerStatus
+		expected       define.ContainerStatus
+		exitedByReboot bool
+	}{
+		{define.ContainerStateRunning, define.ContainerStateExited, true},
+		{define.ContainerStatePaused, define.ContainerStateExited, true},
+		{define.ContainerStateExited, define.ContainerStateExited, false},
+		{define.ContainerStateStopped, define.ContainerStateConfigured, false},
+		{define.ContainerStateCreated, define.ContainerStateConfigured, false},
+	}
+	for _, test := range tests {
+		state := &ContainerState{State: test.state, PID: 1234, ConmonPID: 1233, ExitCode: 3}
+		resetState(state)
+		assert.Equal(t, test.expected, state.State, test.state.String())
+		assert.Equal(t, test.exitedByReboot, state.ExitedByReboot, test.state.String())
+		assert.Zero(t, state.PID)
+		assert.Zero(t, state.ConmonPID)
+		if test.exitedByReboot {
+			assert.Equal(t, int32(-1), state.ExitCode)
+			assert.True(t, state.Exited)
+		}
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/libpod/lock"
 	"github.com/containers/podman/v2/pkg/rootless"
+	"github.com/containers/podman/v2/pkg/secrets"
 	"github.com/containers/storage"
 	"github.com/cri-o/ocicni/pkg/ocicni"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
@@ -97,7 +98,8 @@ const (
 	RestartPolicyOnFailure = "on-failure"
 	// RestartPolicyUnlessStopped unconditionally restarts unless stopped
 	// by the user. It is identical to Always except with respect to
-	// handling of system restart, which Podman does not yet support.
+	// handling of system restart: containers stopped by the user are not
+	// started by StartRestartPolicyContainers.
 	RestartPolicyUnlessStopped = "unless-stopped"
 )
 
@@ -120,6 +122,14 @@ type Container struct {
 	// Batch() operation
 	// Functions called on a batched container will not lock or sync
 	batched bool
+	// Snapshot indicates that the container was retrieved as part of a
+	// read-only snapshot of the state (see Runtime.GetContainersSnapshot).
+	// It is always batched and MUST only be used for reading.
+	snapshot bool
+	// deferSaves indicates that state changes are not written to the
+	// database until the current operation is done.  See
+	// withDeferredSaves.
+	deferSaves bool
 
 	valid      bool
 	lock       lock.Locker
@@ -143,6 +153,10 @@ type Container struct {
 
 	// This is true if a container is restored from a checkpoint.
 	restoreFromCheckpoint bool
+	// holdsPodNamespaces is set if the container is created to hold the
+	// namespaces shared by its pod without infra container.  It is not
+	// persisted.
+	holdsPodNamespaces bool
 }
 
 // ContainerState contains the current state of the container
@@ -171,6 +185,16 @@ type ContainerState struct {
 	// OOMKilled indicates that the container was killed as it ran out of
 	// memory
 	OOMKilled bool `json:"oomKilled,omitempty"`
+	// OOMKillCount is the number of processes of the container killed by
+	// the OOM killer in its previous runs
+	OOMKillCount uint64 `json:"oomKillCount,omitempty"`
+	// TimedOut indicates that the container was killed as it ran longer
+	// than its timeout
+	TimedOut bool `json:"timedOut,omitempty"`
+	// ExitedByReboot indicates that the container was running when the
+	// system rebooted, and was marked as exited when the state was
+	// refreshed
+	ExitedByReboot bool `json:"exitedByReboot,omitempty"`
 	// PID is the PID of a running container
 	PID int `json:"pid,omitempty"`
 	// ConmonPID is the PID of the container's conmon
@@ -193,6 +217,10 @@ type ContainerState struct {
 	// This maps the path the file will be mounted to in the container to
 	// the path of the file on disk outside the container
 	BindMounts map[string]string `json:"bindMounts,omitempty"`
+	// DefaultMounts lists the destinations of the bind mounts in
+	// BindMounts that were added from the default mounts files
+	// (mounts.conf).
+	DefaultMounts []string `json:"defaultMounts,omitempty"`
 	// StoppedByUser indicates whether the container was stopped by an
 	// explicit call to the Stop() API.
 	StoppedByUser bool `json:"stoppedByUser,omitempty"`
@@ -203,6 +231,10 @@ type ContainerState struct {
 	// restart policy. This is NOT incremented by normal container restarts
 	// (only by restart policy).
 	RestartCount uint `json:"restartCount,omitempty"`
+	// ConsoleSize is the most recently requested size of the container's
+	// TTY. Resize requests that arrive before the TTY has been created are
+	// stored here and applied when the OCI spec is generated.
+	ConsoleSize *spec.Box `json:"consoleSize,omitempty"`
 
 	// ExtensionStageHooks holds hooks which will be executed by libpod
 	// and not delegated to the OCI runtime.
@@ -248,6 +280,20 @@ type ContainerImageVolume struct {
 	Dest string `json:"dest"`
 	// ReadWrite sets the volume writable.
 	ReadWrite bool `json:"rw"`
+	// ImageID is the ID of the image Source referred to when the container
+	// was created.  It is empty for containers created by older versions.
+	ImageID string `json:"imageID,omitempty"`
+}
+
+// ContainerSecret is a secret mounted into a container.  Its data is written
+// to the run directory of the container, which usually is on a tmpfs, and is
+// then bind-mounted read-only into the container.
+type ContainerSecret struct {
+	// Secret is the secret.  Its data is looked up when the container is
+	// started.
+	Secret *secrets.Secret `json:"secret"`
+	// Target is the absolute path of the mount in the container.
+	Target string `json:"target"`
 }
 
 // ContainerNetworkDescriptions describes the relationship between the CNI
@@ -441,6 +487,24 @@ func (c *Container) Dependencies() []string {
 	return depends
 }
 
+// RequiredContainers returns the IDs of the containers this container
+// requires.  Unlike namespace dependencies, required containers are started
+// together with the container and cannot be stopped while it is running.
+func (c *Container) RequiredContainers() []string {
+	required := make([]string, len(c.config.Dependencies))
+	copy(required, c.config.Dependencies)
+	return required
+}
+
+// HealthyDependencies returns the IDs of the required containers which must
+// report healthy before this container is started, and the number of seconds
+// to wait for them.
+func (c *Container) HealthyDependencies() ([]string, uint) {
+	healthy := make([]string, len(c.config.HealthyDependencies))
+	copy(healthy, c.config.HealthyDependencies)
+	return healthy, c.config.HealthyDependencyTimeout
+}
+
 // NewNetNS returns whether the container will create a new network namespace
 func (c *Container) NewNetNS() bool {
 	return c.config.CreateNetNS
@@ -541,6 +605,16 @@ func (c *Container) StopTimeout() uint {
 	return c.config.StopTimeout
 }
 
+// StopSignals returns the signal escalation chain used to stop the container.
+// If no chain was set, the container's stop signal is followed by SIGKILL
+// after its stop timeout.
+func (c *Container) StopSignals() []define.StopStep {
+	if len(c.config.StopSignals) > 0 {
+		return append([]define.StopStep{}, c.config.StopSignals...)
+	}
+	return []define.StopStep{{Signal: c.config.StopSignal, Timeout: c.config.StopTimeout}}
+}
+
 // CreatedTime gets the time when the container was created
 func (c *Container) CreatedTime() time.Time {
 	return c.config.CreatedTime
@@ -695,6 +769,25 @@ func (c *Container) ExitCode() (int32, bool, error) {
 	return c.state.ExitCode, c.state.Exited, nil
 }
 
+// Timeout returns the maximum number of seconds the container may run before
+// it is killed. 0 means that the runtime of the container is not limited.
+func (c *Container) Timeout() uint {
+	return c.config.Timeout
+}
+
+// TimedOut returns whether the container was killed as it ran longer than its
+// timeout
+func (c *Container) TimedOut() (bool, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return false, errors.Wrapf(err, "error updating container %s state", c.ID())
+		}
+	}
+	return c.state.TimedOut, nil
+}
+
 // OOMKilled returns whether the container was killed by an OOM condition
 func (c *Container) OOMKilled() (bool, error) {
 	if !c.batched {
@@ -707,6 +800,49 @@ func (c *Container) OOMKilled() (bool, error) {
 	return c.state.OOMKilled, nil
 }
 
+// OOMKillCount returns the number of processes of the container killed by the
+// OOM killer, over all its runs
+func (c *Container) OOMKillCount() (uint64, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return 0, errors.Wrapf(err, "error updating container %s state", c.ID())
+		}
+	}
+	return c.oomKillCount(), nil
+}
+
+// RestartCount returns how many times the container was restarted by its
+// restart policy since it was last started by the user
+func (c *Container) RestartCount() (uint, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return 0, errors.Wrapf(err, "error updating container %s state", c.ID())
+		}
+	}
+	return c.state.RestartCount, nil
+}
+
+// PidsUsage returns the number of processes in the container and the limit on
+// it set by its pids cgroup, which is 0 if there is none.  Both are 0 if the
+// container is neither running nor paused.
+func (c *Container) PidsUsage() (uint64, uint64, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return 0, 0, errors.Wrapf(err, "error updating container %s state", c.ID())
+		}
+	}
+	if !c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+		return 0, 0, nil
+	}
+	return c.cgroupPids()
+}
+
 // PID returns the PID of the container.
 // If the container is not running, a pid of 0 will be returned. No error will
 // occur.
@@ -1035,6 +1171,17 @@ func (c *Container) IsInfra() bool {
 	return c.config.IsInfra
 }
 
+// Owner returns the user who created the container.  It is empty for
+// containers created before owners were recorded.
+func (c *Container) Owner() string {
+	return c.config.Owner
+}
+
+// IsInitCtr returns whether the container is an init container of a pod
+func (c *Container) IsInitCtr() bool {
+	return c.config.InitContainerType != ""
+}
+
 // IsReadOnly returns whether the container is running in read only mode
 func (c *Container) IsReadOnly() bool {
 	return c.config.Spec.Root.Readonly
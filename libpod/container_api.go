@@ -2,14 +2,20 @@ package libpod
 
 import (
 	"context"
+	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/libpod/events"
+	"github.com/containers/podman/v2/pkg/copy"
 	"github.com/containers/podman/v2/pkg/signal"
+	"github.com/containers/podman/v2/pkg/util"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -87,6 +93,10 @@ func (c *Container) Start(ctx context.Context, recursive bool) error {
 	span.SetTag("struct", "container")
 	defer span.Finish()
 
+	if err := c.startAndWaitForDependencies(ctx, recursive); err != nil {
+		return err
+	}
+
 	if !c.batched {
 		c.lock.Lock()
 		defer c.lock.Unlock()
@@ -95,12 +105,23 @@ func (c *Container) Start(ctx context.Context, recursive bool) error {
 			return err
 		}
 	}
-	if err := c.prepareToStart(ctx, recursive); err != nil {
+	// Containers run with --rm are usually short-lived, so their state is
+	// only written once they are running instead of after every step.
+	if c.AutoRemove() {
+		return c.withDeferredSaves(func() error {
+			return c.prepareAndStart(ctx)
+		})
+	}
+	return c.prepareAndStart(ctx)
+}
+
+func (c *Container) prepareAndStart(ctx context.Context) error {
+	if err := c.prepareToStart(ctx); err != nil {
 		return err
 	}
 
 	// Start the container
-	return c.start()
+	return c.start(ctx)
 }
 
 // StartAndAttach starts a container and attaches to it.
@@ -110,6 +131,10 @@ func (c *Container) Start(ctx context.Context, recursive bool) error {
 // In overall functionality, it is identical to the Start call, with the added
 // side effect that an attach session will also be started.
 func (c *Container) StartAndAttach(ctx context.Context, streams *define.AttachStreams, keys string, resize <-chan remotecommand.TerminalSize, recursive bool) (<-chan error, error) {
+	if err := c.startAndWaitForDependencies(ctx, recursive); err != nil {
+		return nil, err
+	}
+
 	if !c.batched {
 		c.lock.Lock()
 		defer c.lock.Unlock()
@@ -119,7 +144,21 @@ func (c *Container) StartAndAttach(ctx context.Context, streams *define.AttachSt
 		}
 	}
 
-	if err := c.prepareToStart(ctx, recursive); err != nil {
+	// As in Start, the state of containers run with --rm is only written
+	// once they are running.
+	if c.AutoRemove() {
+		var attachChan <-chan error
+		err := c.withDeferredSaves(func() (err error) {
+			attachChan, err = c.startAndAttach(ctx, streams, keys, resize)
+			return err
+		})
+		return attachChan, err
+	}
+	return c.startAndAttach(ctx, streams, keys, resize)
+}
+
+func (c *Container) startAndAttach(ctx context.Context, streams *define.AttachStreams, keys string, resize <-chan remotecommand.TerminalSize) (<-chan error, error) {
+	if err := c.prepareToStart(ctx); err != nil {
 		return nil, err
 	}
 	attachChan := make(chan error)
@@ -130,7 +169,7 @@ func (c *Container) StartAndAttach(ctx context.Context, streams *define.AttachSt
 
 	// Attach to the container before starting it
 	go func() {
-		if err := c.attach(streams, keys, resize, true, startedChan, nil); err != nil {
+		if err := c.attach(ctx, streams, keys, resize, true, startedChan, nil); err != nil {
 			attachChan <- err
 		}
 		close(attachChan)
@@ -140,7 +179,7 @@ func (c *Container) StartAndAttach(ctx context.Context, streams *define.AttachSt
 	case err := <-attachChan:
 		return nil, err
 	case <-startedChan:
-		c.newContainerEvent(events.Attach)
+		c.newContainerEvent(ctx, events.Attach)
 	}
 
 	return attachChan, nil
@@ -148,6 +187,12 @@ func (c *Container) StartAndAttach(ctx context.Context, streams *define.AttachSt
 
 // RestartWithTimeout restarts a running container and takes a given timeout in uint
 func (c *Container) RestartWithTimeout(ctx context.Context, timeout uint) error {
+	// Wait for the dependencies to become healthy before locking the
+	// container, as this can take a while.
+	if err := c.waitForHealthyDependencies(ctx); err != nil {
+		return err
+	}
+
 	if !c.batched {
 		c.lock.Lock()
 		defer c.lock.Unlock()
@@ -164,20 +209,31 @@ func (c *Container) RestartWithTimeout(ctx context.Context, timeout uint) error
 	return c.restartWithTimeout(ctx, timeout)
 }
 
-// Stop uses the container's stop signal (or SIGTERM if no signal was specified)
+// Stop uses the container's stop signal escalation chain, if one was set, or
+// its stop signal (or SIGTERM if no signal was specified)
 // to stop the container, and if it has not stopped after container's stop
 // timeout, SIGKILL is used to attempt to forcibly stop the container
 // Default stop timeout is 10 seconds, but can be overridden when the container
 // is created
 func (c *Container) Stop() error {
-	// Stop with the container's given timeout
-	return c.StopWithTimeout(c.config.StopTimeout)
+	// Stop with the container's given signal escalation chain, or its
+	// stop signal and timeout
+	return c.StopWithSignals(c.StopSignals())
 }
 
 // StopWithTimeout is a version of Stop that allows a timeout to be specified
 // manually. If timeout is 0, SIGKILL will be used immediately to kill the
 // container.
 func (c *Container) StopWithTimeout(timeout uint) error {
+	return c.StopWithSignals([]define.StopStep{{Signal: c.config.StopSignal, Timeout: timeout}})
+}
+
+// StopWithSignals is a version of Stop that allows the signal escalation chain
+// to be specified manually. The signal of each step is sent in order, and the
+// container is given the step's timeout to exit before the next step is taken.
+// If the container is still running after the last step, SIGKILL will be used
+// to kill it.
+func (c *Container) StopWithSignals(steps []define.StopStep) error {
 	if !c.batched {
 		c.lock.Lock()
 		defer c.lock.Unlock()
@@ -195,7 +251,58 @@ func (c *Container) StopWithTimeout(timeout uint) error {
 		return errors.Wrapf(define.ErrCtrStateInvalid, "can only stop created or running containers. %s is in state %s", c.ID(), c.state.State.String())
 	}
 
-	return c.stop(timeout)
+	return c.stopWithSignals(steps)
+}
+
+// RequiredBy returns the containers which require this container, as set
+// with WithDependencyCtrs.  Containers only depending on a namespace of this
+// container are not included.
+func (c *Container) RequiredBy() ([]*Container, error) {
+	if !c.valid {
+		return nil, define.ErrCtrRemoved
+	}
+
+	depIDs, err := c.runtime.state.ContainerInUse(c)
+	if err != nil {
+		return nil, err
+	}
+
+	requiredBy := make([]*Container, 0, len(depIDs))
+	for _, id := range depIDs {
+		dep, err := c.runtime.state.Container(id)
+		if err != nil {
+			return nil, err
+		}
+		if util.StringInSlice(c.ID(), dep.config.Dependencies) {
+			requiredBy = append(requiredBy, dep)
+		}
+	}
+	return requiredBy, nil
+}
+
+// RequiredByRunning returns the IDs of the running or paused containers which
+// require this container.  The container should not be stopped as long as
+// there are any.
+func (c *Container) RequiredByRunning() ([]string, error) {
+	requiredBy, err := c.RequiredBy()
+	if err != nil {
+		return nil, err
+	}
+
+	running := []string{}
+	for _, dep := range requiredBy {
+		state, err := dep.State()
+		if err != nil {
+			if errors.Cause(err) == define.ErrNoSuchCtr || errors.Cause(err) == define.ErrCtrRemoved {
+				continue
+			}
+			return nil, err
+		}
+		if state == define.ContainerStateRunning || state == define.ContainerStatePaused {
+			running = append(running, dep.ID())
+		}
+	}
+	return running, nil
 }
 
 // Kill sends a signal to a container
@@ -221,7 +328,7 @@ func (c *Container) Kill(signal uint) error {
 
 	c.state.StoppedByUser = true
 
-	c.newContainerEvent(events.Kill)
+	c.newContainerEvent(context.Background(), events.Kill)
 
 	return c.save()
 }
@@ -259,8 +366,8 @@ func (c *Container) Attach(streams *define.AttachStreams, keys string, resize <-
 		}()
 	}
 
-	c.newContainerEvent(events.Attach)
-	return c.attach(streams, keys, resize, false, nil, attachRdy)
+	c.newContainerEvent(context.Background(), events.Attach)
+	return c.attach(context.Background(), streams, keys, resize, false, nil, attachRdy)
 }
 
 // HTTPAttach forwards an attach session over a hijacked HTTP session.
@@ -306,7 +413,7 @@ func (c *Container) HTTPAttach(r *http.Request, w http.ResponseWriter, streams *
 
 	logrus.Infof("Performing HTTP Hijack attach to container %s", c.ID())
 
-	c.newContainerEvent(events.Attach)
+	c.newContainerEvent(context.Background(), events.Attach)
 	return c.ociRuntime.HTTPAttach(c, r, w, streams, detachKeys, cancel, hijackDone, streamAttach, streamLogs)
 }
 
@@ -322,13 +429,29 @@ func (c *Container) AttachResize(newSize remotecommand.TerminalSize) error {
 		}
 	}
 
+	if !c.ensureState(define.ContainerStateConfigured, define.ContainerStateCreated, define.ContainerStateRunning, define.ContainerStateStopped, define.ContainerStateExited) {
+		return errors.Wrapf(define.ErrCtrStateInvalid, "can only resize configured, created, running, or stopped containers")
+	}
+
+	c.state.ConsoleSize = &spec.Box{
+		Height: uint(newSize.Height),
+		Width:  uint(newSize.Width),
+	}
+
+	// The TTY is only created when the container is initialized. Save the
+	// requested size so it will be used when the container starts.
 	if !c.ensureState(define.ContainerStateCreated, define.ContainerStateRunning) {
-		return errors.Wrapf(define.ErrCtrStateInvalid, "can only resize created or running containers")
+		logrus.Infof("Queueing TTY resize of container %s until it is started", c.ID())
+		return c.save()
 	}
 
 	logrus.Infof("Resizing TTY of container %s", c.ID())
 
-	return c.ociRuntime.AttachResize(c, newSize)
+	if err := c.ociRuntime.AttachResize(c, newSize); err != nil {
+		return err
+	}
+
+	return c.save()
 }
 
 // Mount mounts a container's filesystem on the host
@@ -347,7 +470,7 @@ func (c *Container) Mount() (string, error) {
 		return "", errors.Wrapf(define.ErrCtrStateInvalid, "cannot mount container %s as it is being removed", c.ID())
 	}
 
-	defer c.newContainerEvent(events.Mount)
+	defer c.newContainerEvent(context.Background(), events.Mount)
 	return c.mount()
 }
 
@@ -381,7 +504,7 @@ func (c *Container) Unmount(force bool) error {
 			return errors.Wrapf(define.ErrInternal, "can't unmount %s last mount, it is still in use", c.ID())
 		}
 	}
-	defer c.newContainerEvent(events.Unmount)
+	defer c.newContainerEvent(context.Background(), events.Unmount)
 	return c.unmount(force)
 }
 
@@ -402,7 +525,7 @@ func (c *Container) Pause() error {
 	if c.state.State != define.ContainerStateRunning {
 		return errors.Wrapf(define.ErrCtrStateInvalid, "%q is not running, can't pause", c.state.State)
 	}
-	defer c.newContainerEvent(events.Pause)
+	defer c.newContainerEvent(context.Background(), events.Pause)
 	return c.pause()
 }
 
@@ -420,10 +543,137 @@ func (c *Container) Unpause() error {
 	if c.state.State != define.ContainerStatePaused {
 		return errors.Wrapf(define.ErrCtrStateInvalid, "%q is not paused, can't unpause", c.ID())
 	}
-	defer c.newContainerEvent(events.Unpause)
+	defer c.newContainerEvent(context.Background(), events.Unpause)
 	return c.unpause()
 }
 
+// Update updates the cgroup resource limits of a container.  The limits of a
+// running or paused container are changed immediately by the OCI runtime.
+// The new limits are also stored in the container's configuration, so they
+// are kept when the container is restarted.
+func (c *Container) Update(resources *spec.LinuxResources) error {
+	if !c.valid {
+		return define.ErrCtrRemoved
+	}
+
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	if c.state.State == define.ContainerStateRemoving {
+		return errors.Wrapf(define.ErrCtrStateInvalid, "cannot update container %s as it is being removed", c.ID())
+	}
+
+	switch c.state.State {
+	case define.ContainerStateCreated, define.ContainerStateRunning, define.ContainerStatePaused:
+		if err := c.ociRuntime.UpdateContainer(c, resources); err != nil {
+			return err
+		}
+		// Keep the OCI spec of the running container in sync, it is
+		// used by inspect.
+		ociSpec, err := c.specFromState()
+		if err != nil {
+			return err
+		}
+		if ociSpec.Linux == nil {
+			ociSpec.Linux = new(spec.Linux)
+		}
+		ociSpec.Linux.Resources = resources
+		if err := c.saveSpec(ociSpec); err != nil {
+			return err
+		}
+	}
+
+	return c.rewriteConfig(func(config *ContainerConfig) error {
+		if config.Spec.Linux == nil {
+			config.Spec.Linux = new(spec.Linux)
+		}
+		config.Spec.Linux.Resources = resources
+		return nil
+	})
+}
+
+// UpdateLabels adds the given labels to the container, replacing the values of
+// labels which are already set, and removes the labels with the given keys.
+// The labels of the container are changed without recreating it.
+func (c *Container) UpdateLabels(add map[string]string, remove []string) error {
+	if !c.valid {
+		return define.ErrCtrRemoved
+	}
+
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	return c.rewriteConfig(func(config *ContainerConfig) error {
+		config.Labels = updateMap(config.Labels, add, remove)
+		return nil
+	})
+}
+
+// UpdateAnnotations adds the given OCI annotations to the container, replacing
+// the values of annotations which are already set, and removes the annotations
+// with the given keys.  The annotations podman uses to record the settings of
+// the container cannot be changed.  The OCI runtime is passed the changed
+// annotations the next time the container is started.
+func (c *Container) UpdateAnnotations(add map[string]string, remove []string) error {
+	for key := range add {
+		if strings.HasPrefix(key, define.InspectAnnotationPrefix) {
+			return errors.Wrapf(define.ErrInvalidArg, "annotation %s is reserved", key)
+		}
+	}
+	for _, key := range remove {
+		if strings.HasPrefix(key, define.InspectAnnotationPrefix) {
+			return errors.Wrapf(define.ErrInvalidArg, "annotation %s is reserved", key)
+		}
+	}
+
+	if !c.valid {
+		return define.ErrCtrRemoved
+	}
+
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	if err := c.rewriteConfig(func(config *ContainerConfig) error {
+		config.Spec.Annotations = updateMap(config.Spec.Annotations, add, remove)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	switch c.state.State {
+	case define.ContainerStateCreated, define.ContainerStateRunning, define.ContainerStatePaused:
+		// Keep the OCI spec of the created container in sync, it is
+		// used by inspect.
+		ociSpec, err := c.specFromState()
+		if err != nil {
+			return err
+		}
+		ociSpec.Annotations = updateMap(ociSpec.Annotations, add, remove)
+		if err := c.saveSpec(ociSpec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Export exports a container's root filesystem as a tar archive
 // The archive will be saved as a file at the given path
 func (c *Container) Export(path string) error {
@@ -440,10 +690,111 @@ func (c *Container) Export(path string) error {
 		return errors.Wrapf(define.ErrCtrStateInvalid, "cannot mount container %s as it is being removed", c.ID())
 	}
 
-	defer c.newContainerEvent(events.Mount)
+	defer c.newContainerEvent(context.Background(), events.Mount)
 	return c.export(path)
 }
 
+// ExportWithVolumes exports a container's root filesystem together with the
+// contents of its named volumes as a tar archive, which can be imported with
+// Runtime.ImportWithVolumes.
+// The archive will be saved as a file at the given path
+func (c *Container) ExportWithVolumes(path string) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	if c.state.State == define.ContainerStateRemoving {
+		return errors.Wrapf(define.ErrCtrStateInvalid, "cannot mount container %s as it is being removed", c.ID())
+	}
+
+	defer c.newContainerEvent(context.Background(), events.Mount)
+	return c.exportWithVolumes(path)
+}
+
+// CopyFromArchive returns a function which extracts the tar archive read from
+// reader to containerPath inside the container.  Only the parent directory of
+// containerPath must exist, the path itself may be created while copying.
+// If chown is set, the extracted files are owned by the primary uid/gid of the
+// container, otherwise the ownership recorded in the archive is mapped into
+// the user namespace of the container.  If noOverwriteDirNonDir is set,
+// directories are not replaced by non-directories and vice versa.
+// The container's file system is mounted until the returned function is
+// executed, which must hence always happen.
+func (c *Container) CopyFromArchive(ctx context.Context, containerPath string, chown, noOverwriteDirNonDir bool, reader io.Reader) (func() error, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.state.State == define.ContainerStateRemoving {
+		return nil, errors.Wrapf(define.ErrCtrStateInvalid, "cannot copy to container %s as it is being removed", c.ID())
+	}
+
+	return c.copyFromArchive(ctx, containerPath, chown, noOverwriteDirNonDir, reader)
+}
+
+// CopyToArchive returns a function which writes containerPath inside the
+// container as a tar archive to writer.  The container's file system is
+// mounted until the returned function is executed, which must hence always
+// happen.
+func (c *Container) CopyToArchive(ctx context.Context, containerPath string, writer io.Writer) (func() error, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.state.State == define.ContainerStateRemoving {
+		return nil, errors.Wrapf(define.ErrCtrStateInvalid, "cannot copy from container %s as it is being removed", c.ID())
+	}
+
+	return c.copyToArchive(ctx, containerPath, writer)
+}
+
+// Stat returns information about containerPath inside the container.  Relative
+// paths are resolved against the container's working directory.  Note that the
+// returned FileInfo may be set even if an error is returned, which is the case
+// for symlinks pointing to a non-existent target.
+func (c *Container) Stat(ctx context.Context, containerPath string) (*copy.FileInfo, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.state.State == define.ContainerStateRemoving {
+		return nil, errors.Wrapf(define.ErrCtrStateInvalid, "cannot stat files of container %s as it is being removed", c.ID())
+	}
+
+	mountPoint, err := c.mount()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := c.unmount(false); err != nil {
+			logrus.Errorf("Error unmounting container %s: %v", c.ID(), err)
+		}
+	}()
+
+	info, _, _, err := c.stat(ctx, mountPoint, containerPath)
+	return info, err
+}
+
 // AddArtifact creates and writes to an artifact file for the container
 func (c *Container) AddArtifact(name string, data []byte) error {
 	if !c.valid {
@@ -506,24 +857,96 @@ func (c *Container) WaitWithInterval(waitTimeout time.Duration) (int32, error) {
 	}
 }
 
+// WaitForConditionWithInterval blocks until the container is in the given
+// state.  See WaitForConditions.
 func (c *Container) WaitForConditionWithInterval(waitTimeout time.Duration, condition define.ContainerStatus) (int32, error) {
+	return c.WaitForConditions(context.Background(), waitTimeout, []string{condition.String()})
+}
+
+// WaitForConditions blocks until the container meets one of the given
+// conditions, or ctx is cancelled, checking the container every interval.
+// The conditions are validated with define.ValidateWaitCondition and default
+// to stopped.  The exit code of the container is returned if it is known, -1
+// otherwise.
+func (c *Container) WaitForConditions(ctx context.Context, interval time.Duration, conditions []string) (int32, error) {
 	if !c.valid {
 		return -1, define.ErrCtrRemoved
 	}
-	if condition == define.ContainerStateStopped || condition == define.ContainerStateExited {
-		return c.WaitWithInterval(waitTimeout)
+	if len(conditions) == 0 {
+		conditions = []string{define.ContainerStateStopped.String()}
+	}
+
+	onlyStopped := true
+	for _, condition := range conditions {
+		if err := define.ValidateWaitCondition(condition); err != nil {
+			return -1, err
+		}
+		switch condition {
+		case define.HealthCheckHealthy, define.HealthCheckUnhealthy:
+			if !c.HasHealthCheck() {
+				return -1, errors.Wrapf(define.ErrInvalidArg, "container %s has no healthcheck, cannot wait for it to be %s", c.ID(), condition)
+			}
+		}
+		if condition != define.ContainerStateStopped.String() && condition != define.ContainerStateExited.String() {
+			onlyStopped = false
+		}
+	}
+	// Waiting for the container to stop does not need to poll, the exit
+	// file is watched instead.
+	if onlyStopped {
+		return c.WaitWithInterval(interval)
 	}
+
+	exitCode := int32(-1)
 	for {
 		state, err := c.State()
 		if err != nil {
+			if (errors.Cause(err) == define.ErrNoSuchCtr || errors.Cause(err) == define.ErrCtrRemoved) &&
+				util.StringInSlice(define.ContainerConditionRemoved, conditions) {
+				return exitCode, nil
+			}
 			return -1, err
 		}
-		if state == condition {
-			break
+		if state == define.ContainerStateStopped || state == define.ContainerStateExited {
+			if code, exited, err := c.ExitCode(); err == nil && exited {
+				exitCode = code
+			}
+		}
+
+		for _, condition := range conditions {
+			switch condition {
+			case define.ContainerConditionRemoved:
+				// Met once retrieving the state fails, see above.
+			case define.HealthCheckHealthy, define.HealthCheckUnhealthy:
+				if state != define.ContainerStateRunning {
+					continue
+				}
+				status, err := c.HealthCheckStatus()
+				if err != nil {
+					return -1, err
+				}
+				if status == condition {
+					return exitCode, nil
+				}
+			case define.ContainerStateStopped.String(), define.ContainerStateExited.String():
+				// As in WaitWithInterval, a container which is
+				// not running or paused is stopped.
+				if state != define.ContainerStateRunning && state != define.ContainerStatePaused {
+					return exitCode, nil
+				}
+			default:
+				if state.String() == condition {
+					return exitCode, nil
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return -1, ctx.Err()
+		case <-time.After(interval):
 		}
-		time.Sleep(waitTimeout)
 	}
-	return -1, nil
 }
 
 // Cleanup unmounts all mount points in container and cleans up container storage
@@ -565,7 +988,7 @@ func (c *Container) Cleanup(ctx context.Context) error {
 		return errors.Wrapf(define.ErrCtrStateInvalid, "container %s has active exec sessions, refusing to clean up", c.ID())
 	}
 
-	defer c.newContainerEvent(events.Cleanup)
+	defer c.newContainerEvent(ctx, events.Cleanup)
 	return c.cleanup(ctx)
 }
 
@@ -582,7 +1005,12 @@ func (c *Container) Cleanup(ctx context.Context) error {
 // As Batch normally disables updating the current state of the container, the
 // Sync() function is provided to enable container state to be updated and
 // checked within Batch.
+// Containers of a snapshot are neither locked nor synced by Batch.
 func (c *Container) Batch(batchFunc func(*Container) error) error {
+	if c.snapshot {
+		return batchFunc(c)
+	}
+
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
@@ -635,7 +1063,7 @@ func (c *Container) Sync() error {
 		}
 	}
 
-	defer c.newContainerEvent(events.Sync)
+	defer c.newContainerEvent(context.Background(), events.Sync)
 	return nil
 }
 
@@ -703,6 +1131,16 @@ type ContainerCheckpointOptions struct {
 	// important to be able to restore a container multiple
 	// times with '--import --name'.
 	IgnoreStaticMAC bool
+	// IgnoreVolumes tells the API to not export the content of the
+	// container's named volumes (or to not import it)
+	IgnoreVolumes bool
+	// StaticIP tells the API to restore the container with this IP
+	// address instead of the one it had when it was checkpointed, for
+	// example to fit the addressing of the host it is migrated to.
+	StaticIP net.IP
+	// StaticMAC tells the API to restore the container with this MAC
+	// address instead of the one it had when it was checkpointed.
+	StaticMAC net.HardwareAddr
 }
 
 // Checkpoint checkpoints a container
@@ -737,7 +1175,7 @@ func (c *Container) Restore(ctx context.Context, options ContainerCheckpointOpti
 			return err
 		}
 	}
-	defer c.newContainerEvent(events.Restore)
+	defer c.newContainerEvent(ctx, events.Restore)
 	return c.restore(ctx, options)
 }
 
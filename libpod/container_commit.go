@@ -71,6 +71,7 @@ func (c *Container) Commit(ctx context.Context, destImage string, options Contai
 		ReportWriter:          options.ReportWriter,
 		SystemContext:         sc,
 		PreferredManifestType: options.PreferredManifestType,
+		HistoryTimestamp:      options.HistoryTimestamp,
 	}
 	importBuilder, err := buildah.ImportBuilder(ctx, c.runtime.store, builderOptions)
 	if err != nil {
@@ -205,6 +206,6 @@ func (c *Container) Commit(ctx context.Context, destImage string, options Contai
 	if err != nil {
 		return nil, err
 	}
-	defer c.newContainerEvent(events.Commit)
+	defer c.newContainerEvent(ctx, events.Commit)
 	return c.runtime.imageRuntime.NewFromLocal(id)
 }
@@ -5,7 +5,9 @@ import (
 	"time"
 
 	"github.com/containers/image/v5/manifest"
+	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/pkg/namespaces"
+	"github.com/containers/podman/v2/pkg/secrets"
 	"github.com/containers/storage"
 	"github.com/cri-o/ocicni/pkg/ocicni"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
@@ -76,6 +78,13 @@ type ContainerConfig struct {
 	// Dependencies are the IDs of dependency containers.
 	// These containers must be started before this container is started.
 	Dependencies []string
+	// HealthyDependencies are the IDs of dependency containers which must
+	// report healthy before this container is started.  They are also
+	// included in Dependencies.
+	HealthyDependencies []string `json:"healthyDependencies,omitempty"`
+	// HealthyDependencyTimeout is the number of seconds to wait for the
+	// HealthyDependencies to become healthy.  0 waits indefinitely.
+	HealthyDependencyTimeout uint `json:"healthyDependencyTimeout,omitempty"`
 
 	// embedded sub-configs
 	ContainerRootFSConfig
@@ -114,6 +123,9 @@ type ContainerRootFSConfig struct {
 	// ShmSize is the size of the container's SHM. Only used if ShmDir was
 	// not set manually at time of creation.
 	ShmSize int64 `json:"shmSize"`
+	// ShmSizeSystemd is the size of the tmpfs mounts added for containers
+	// running systemd. If 0, the tmpfs default size is used.
+	ShmSizeSystemd int64 `json:"shmSizeSystemd,omitempty"`
 	// Static directory for container content that will persist across
 	// reboot.
 	// StaticDir is a persistent directory for Libpod files that will
@@ -142,10 +154,20 @@ type ContainerRootFSConfig struct {
 	// moved out of Libpod into pkg/specgen).
 	// Please DO NOT re-use the `imageVolumes` name in container JSON again.
 	ImageVolumes []*ContainerImageVolume `json:"ctrImageVolumes,omitempty"`
+	// Secrets lists the secrets to mount into the container.  The data of
+	// the secrets is only written to the run directory of the container
+	// when it is started.
+	Secrets []*ContainerSecret `json:"secrets,omitempty"`
+	// EnvSecrets maps the names of environment variables to the secrets
+	// whose data they are set to when the container is started.
+	EnvSecrets map[string]*secrets.Secret `json:"envSecrets,omitempty"`
 	// CreateWorkingDir indicates that Libpod should create the container's
 	// working directory if it does not exist. Some OCI runtimes do this by
 	// default, but others do not.
 	CreateWorkingDir bool `json:"createWorkingDir,omitempty"`
+	// NoDefaultMounts indicates that the default mounts from the mounts
+	// files (mounts.conf) should not be mounted into the container.
+	NoDefaultMounts bool `json:"noDefaultMounts,omitempty"`
 }
 
 // ContainerSecurityConfig is an embedded sub-config providing security configuration
@@ -213,10 +235,30 @@ type ContainerNetworkConfig struct {
 	// This cannot be set unless CreateNetNS is set.
 	// If not set, the container will be dynamically assigned an IP by CNI.
 	StaticIP net.IP `json:"staticIP"`
+	// StaticIPv6 is a static IPv6 address to request for the container.
+	// This cannot be set unless CreateNetNS is set, and cannot be set
+	// together with StaticIP.
+	// If not set, the container will be dynamically assigned an IPv6
+	// address by CNI if the network has an IPv6 subnet.
+	StaticIPv6 net.IP `json:"staticIPv6,omitempty"`
 	// StaticMAC is a static MAC to request for the container.
 	// This cannot be set unless CreateNetNS is set.
 	// If not set, the container will be dynamically assigned a MAC by CNI.
+	// If the container joins more than one CNI network, it is requested
+	// in the first one.
 	StaticMAC net.HardwareAddr `json:"staticMAC"`
+	// StaticIPs are static IPv4 or IPv6 addresses to request for the
+	// container in the given CNI networks.
+	// This cannot be set unless CreateNetNS is set. Formatted as map of
+	// network name to address. All network names must be present in the
+	// Networks list, and cannot be the network of StaticIP or StaticIPv6.
+	StaticIPs map[string]net.IP `json:"staticIPs,omitempty"`
+	// StaticMACs are static MACs to request for the container in the given
+	// CNI networks.
+	// This cannot be set unless CreateNetNS is set. Formatted as map of
+	// network name to MAC. All network names must be present in the
+	// Networks list, and cannot be the network of StaticMAC.
+	StaticMACs map[string]net.HardwareAddr `json:"staticMACs,omitempty"`
 	// PortMappings are the ports forwarded to the container's network
 	// namespace
 	// These are not used unless CreateNetNS is true
@@ -238,6 +280,12 @@ type ContainerNetworkConfig struct {
 	// bind-mounted inside the container.
 	// Conflicts with HostAdd.
 	UseImageHosts bool
+	// BaseHostsFile is the base of the /etc/hosts file managed by Podman:
+	// "image" for the /etc/hosts file of the image, "none" for no base
+	// file or the path of a file on the host. If empty, base_hosts_file
+	// of containers.conf is used, which defaults to the /etc/hosts file of
+	// the host.
+	BaseHostsFile string `json:"baseHostsFile,omitempty"`
 	// Hosts to add in container
 	// Will be appended to host's host file
 	HostAdd []string `json:"hostsAdd,omitempty"`
@@ -293,6 +341,13 @@ type ContainerMiscConfig struct {
 	StopSignal uint `json:"stopSignal,omitempty"`
 	// StopTimeout is the signal that will be used to stop the container
 	StopTimeout uint `json:"stopTimeout,omitempty"`
+	// StopSignals is the signal escalation chain that will be used to
+	// stop the container. If set, it takes precedence over StopSignal and
+	// StopTimeout.
+	StopSignals []define.StopStep `json:"stopSignals,omitempty"`
+	// Timeout is the maximum number of seconds the container may run
+	// before it is killed. 0 means that the runtime is not limited.
+	Timeout uint `json:"timeout,omitempty"`
 	// Time container was created
 	CreatedTime time.Time `json:"createdTime"`
 	// CgroupManager is the cgroup manager used to create this container.
@@ -316,6 +371,9 @@ type ContainerMiscConfig struct {
 	LogDriver string `json:"logDriver"`
 	// File containing the conmon PID
 	ConmonPidFile string `json:"conmonPidFile,omitempty"`
+	// File receiving the PID of the container process, instead of the PID
+	// file in the run directory of the container
+	PidFile string `json:"pidFile,omitempty"`
 	// RestartPolicy indicates what action the container will take upon
 	// exiting naturally.
 	// Allowed options are "no" (take no action), "on-failure" (restart on
@@ -344,6 +402,12 @@ type ContainerMiscConfig struct {
 	// IsInfra is a bool indicating whether this container is an infra container used for
 	// sharing kernel namespaces in a pod
 	IsInfra bool `json:"pause"`
+	// InitContainerType is set for init containers of a pod, which run to
+	// completion before the other containers of the pod are started.
+	// Either "always" or "once".
+	InitContainerType string `json:"init_container_type,omitempty"`
+	// Owner is the user who created the container.
+	Owner string `json:"owner,omitempty"`
 	// SdNotifyMode tells libpod what to do with a NOTIFY_SOCKET if passed
 	SdNotifyMode string `json:"sdnotifyMode,omitempty"`
 	// Systemd tells libpod to setup the container in systemd mode
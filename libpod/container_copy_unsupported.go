@@ -0,0 +1,23 @@
+// +build !linux
+
+package libpod
+
+import (
+	"context"
+	"io"
+
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/containers/podman/v2/pkg/copy"
+)
+
+func (c *Container) copyFromArchive(ctx context.Context, containerPath string, chown, noOverwriteDirNonDir bool, reader io.Reader) (func() error, error) {
+	return nil, define.ErrNotImplemented
+}
+
+func (c *Container) copyToArchive(ctx context.Context, containerPath string, writer io.Writer) (func() error, error) {
+	return nil, define.ErrNotImplemented
+}
+
+func (c *Container) stat(ctx context.Context, containerMountPoint string, containerPath string) (*copy.FileInfo, string, string, error) {
+	return nil, "", "", define.ErrNotImplemented
+}
@@ -1,6 +1,7 @@
 package libpod
 
 import (
+	"context"
 	"io/ioutil"
 	"net/http"
 	"os"
@@ -12,6 +13,7 @@ import (
 	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/libpod/events"
 	"github.com/containers/storage/pkg/stringid"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"k8s.io/client-go/tools/remotecommand"
@@ -24,6 +26,9 @@ type ExecConfig struct {
 	Command []string `json:"command"`
 	// Terminal is whether the exec session will allocate a pseudoterminal.
 	Terminal bool `json:"terminal,omitempty"`
+	// ConsoleSize is the initial size of the exec session's TTY. Only used
+	// if Terminal is set.
+	ConsoleSize *spec.Box `json:"consoleSize,omitempty"`
 	// AttachStdin is whether the STDIN stream will be forwarded to the exec
 	// session's first process when attaching. Only available if Terminal is
 	// false.
@@ -265,7 +270,7 @@ func (c *Container) ExecStart(sessionID string) error {
 		return err
 	}
 
-	c.newContainerEvent(events.Exec)
+	c.newContainerEvent(context.Background(), events.Exec)
 	logrus.Debugf("Successfully started exec session %s in container %s", session.ID(), c.ID())
 
 	// Update and save session to reflect PID/running
@@ -314,7 +319,7 @@ func (c *Container) ExecStartAndAttach(sessionID string, streams *define.AttachS
 		return err
 	}
 
-	c.newContainerEvent(events.Exec)
+	c.newContainerEvent(context.Background(), events.Exec)
 	logrus.Debugf("Successfully started exec session %s in container %s", session.ID(), c.ID())
 
 	var lastErr error
@@ -445,7 +450,7 @@ func (c *Container) ExecHTTPStartAndAttach(sessionID string, r *http.Request, w
 	// TODO: Investigate whether more of this can be made common with
 	// ExecStartAndAttach
 
-	c.newContainerEvent(events.Exec)
+	c.newContainerEvent(context.Background(), events.Exec)
 	logrus.Debugf("Successfully started exec session %s in container %s", session.ID(), c.ID())
 
 	var lastErr error
@@ -692,6 +697,16 @@ func (c *Container) ExecResize(sessionID string, newSize remotecommand.TerminalS
 
 	logrus.Infof("Resizing container %s exec session %s to %+v", c.ID(), session.ID(), newSize)
 
+	// The TTY of the exec session does not exist until it is started.
+	// Remember the size so it is used when the session starts.
+	if session.State == define.ExecStateCreated {
+		session.Config.ConsoleSize = &spec.Box{
+			Height: uint(newSize.Height),
+			Width:  uint(newSize.Width),
+		}
+		return c.save()
+	}
+
 	if session.State != define.ExecStateRunning {
 		return errors.Wrapf(define.ErrExecSessionStateInvalid, "cannot resize container %s exec session %s as it is not running", c.ID(), session.ID())
 	}
@@ -989,6 +1004,7 @@ func prepareForExec(c *Container, session *ExecSession) (*ExecOptions, error) {
 	opts.CapAdd = capList
 	opts.Env = session.Config.Environment
 	opts.Terminal = session.Config.Terminal
+	opts.ConsoleSize = session.Config.ConsoleSize
 	opts.Cwd = session.Config.WorkDir
 	opts.User = session.Config.User
 	opts.PreserveFDs = session.Config.PreserveFDs
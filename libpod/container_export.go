@@ -0,0 +1,276 @@
+package libpod
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/containers/podman/v2/pkg/util"
+	"github.com/containers/storage/pkg/archive"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// An archive written by ExportWithVolumes contains the root filesystem of the
+// container as exported by Export, a list of the named volumes of the
+// container and a tar archive of the contents of each volume.
+const (
+	exportRootfsFile  = "rootfs.tar"
+	exportVolumesFile = "volumes.json"
+	exportVolumesDir  = "volumes"
+)
+
+// exportedVolume describes a named volume in an archive written by
+// ExportWithVolumes.
+type exportedVolume struct {
+	// Name of the volume.
+	Name string `json:"name"`
+	// Dest is where the volume was mounted in the exported container.
+	Dest string `json:"dest"`
+	// Driver of the volume.
+	Driver string `json:"driver"`
+	// Labels of the volume.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Options of the volume.
+	Options map[string]string `json:"options,omitempty"`
+	// UID the volume was created as.
+	UID int `json:"uid"`
+	// GID the volume was created as.
+	GID int `json:"gid"`
+	// Size quota of the volume in bytes.
+	Size uint64 `json:"size,omitempty"`
+	// Anonymous is whether the volume is an anonymous volume of a
+	// container.
+	Anonymous bool `json:"anonymous,omitempty"`
+}
+
+// exportWithVolumes writes the archive of ExportWithVolumes to path.
+// Must be called with the container locked.
+func (c *Container) exportWithVolumes(path string) error {
+	tmpdir, err := ioutil.TempDir(util.Tmpdir(), "podman-export")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.RemoveAll(tmpdir); err != nil {
+			logrus.Errorf("error removing %s: %v", tmpdir, err)
+		}
+	}()
+
+	if err := c.export(filepath.Join(tmpdir, exportRootfsFile)); err != nil {
+		return err
+	}
+
+	if err := os.Mkdir(filepath.Join(tmpdir, exportVolumesDir), 0700); err != nil {
+		return err
+	}
+	volumes := make([]exportedVolume, 0, len(c.config.NamedVolumes))
+	for _, v := range c.config.NamedVolumes {
+		vol, err := c.runtime.state.Volume(v.Name)
+		if err != nil {
+			return errors.Wrapf(err, "error retrieving volume %s", v.Name)
+		}
+		if vol.config.LockID == c.config.LockID {
+			return errors.Wrapf(define.ErrWillDeadlock, "container %s and volume %s share lock ID %d", c.ID(), vol.Name(), c.config.LockID)
+		}
+		// The volume may have been chowned to the user of the first
+		// container mounting it.
+		uid, err := vol.UID()
+		if err != nil {
+			return err
+		}
+		gid, err := vol.GID()
+		if err != nil {
+			return err
+		}
+		volumes = append(volumes, exportedVolume{
+			Name:      vol.Name(),
+			Dest:      v.Dest,
+			Driver:    vol.config.Driver,
+			Labels:    vol.config.Labels,
+			Options:   vol.config.Options,
+			UID:       uid,
+			GID:       gid,
+			Size:      vol.config.Size,
+			Anonymous: vol.config.IsAnon,
+		})
+
+		volumeTarPath := filepath.Join(tmpdir, exportVolumesDir, vol.Name()+".tar")
+		f, err := os.Create(volumeTarPath)
+		if err != nil {
+			return errors.Wrapf(err, "error creating volume archive %q", volumeTarPath)
+		}
+		if err := vol.Export(f); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+	volumesJSON, err := json.Marshal(volumes)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(tmpdir, exportVolumesFile), volumesJSON, 0600); err != nil {
+		return err
+	}
+
+	input, err := archive.Tar(tmpdir, archive.Uncompressed)
+	if err != nil {
+		return errors.Wrapf(err, "error creating export archive of container %q", c.ID())
+	}
+	defer input.Close()
+
+	outFile, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "error creating file %q", path)
+	}
+	defer outFile.Close()
+
+	_, err = io.Copy(outFile, input)
+	return err
+}
+
+// ImportWithVolumes imports the root filesystem of an archive written by
+// ExportWithVolumes as an image, like Import, and re-creates the volumes of
+// the exported container with their contents. It fails if any of the volumes
+// already exists.
+// The archive is not trusted: only the local driver and the UID, GID, size
+// and labels of the volumes are restored, unless volumeOptions is set to also
+// restore their driver and mount options, which may mount host paths.
+func (r *Runtime) ImportWithVolumes(ctx context.Context, source, reference, signaturePolicyPath string, changes []string, history string, quiet bool, volumeOptions bool) (string, error) {
+	tmpdir, err := ioutil.TempDir(util.Tmpdir(), "podman-import")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := os.RemoveAll(tmpdir); err != nil {
+			logrus.Errorf("error removing %s: %v", tmpdir, err)
+		}
+	}()
+
+	if u, err := url.ParseRequestURI(source); err == nil && u.Scheme != "" {
+		file, err := downloadFromURL(source)
+		if err != nil {
+			return "", err
+		}
+		defer os.Remove(file)
+		source = file
+	}
+	var input io.Reader = os.Stdin
+	if source != "-" {
+		f, err := os.Open(source)
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+		input = f
+	}
+	if err := archive.Untar(input, tmpdir, &archive.TarOptions{NoLchown: true}); err != nil {
+		return "", errors.Wrapf(err, "error extracting %s", source)
+	}
+
+	volumesJSON, err := ioutil.ReadFile(filepath.Join(tmpdir, exportVolumesFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", errors.Errorf("%s is not an archive of a container exported with its volumes", source)
+		}
+		return "", err
+	}
+	var volumes []exportedVolume
+	if err := json.Unmarshal(volumesJSON, &volumes); err != nil {
+		return "", errors.Wrapf(err, "error parsing %s of %s", exportVolumesFile, source)
+	}
+	volumesCreateOptions := make([][]VolumeCreateOption, len(volumes))
+	for i, v := range volumes {
+		options, err := exportedVolumeOptions(v, volumeOptions)
+		if err != nil {
+			return "", err
+		}
+		volumesCreateOptions[i] = options
+		exists, err := r.HasVolume(v.Name)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			return "", errors.Wrapf(define.ErrVolumeExists, "volume with name %s already exists", v.Name)
+		}
+	}
+
+	id, err := r.Import(ctx, filepath.Join(tmpdir, exportRootfsFile), reference, signaturePolicyPath, changes, history, quiet)
+	if err != nil {
+		return "", err
+	}
+
+	for i, v := range volumes {
+		if err := r.importExportedVolume(ctx, v, volumesCreateOptions[i], filepath.Join(tmpdir, exportVolumesDir, v.Name+".tar")); err != nil {
+			return "", err
+		}
+	}
+	return id, nil
+}
+
+// importExportedVolume re-creates the exported volume v with the options
+// given by exportedVolumeOptions and the contents of the archive at path.
+func (r *Runtime) importExportedVolume(ctx context.Context, v exportedVolume, options []VolumeCreateOption, path string) error {
+	vol, err := r.NewVolume(ctx, options...)
+	if err != nil {
+		return errors.Wrapf(err, "error re-creating volume %s", v.Name)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "error opening archive of volume %s", v.Name)
+	}
+	defer f.Close()
+	return vol.Import(f)
+}
+
+// exportedVolumeInfoOptions are the options of a local volume only recording
+// its UID, GID and size, which are restored without mounting anything.
+var exportedVolumeInfoOptions = map[string]bool{
+	"UID":  true,
+	"GID":  true,
+	"SIZE": true,
+}
+
+// exportedVolumeOptions returns the options to re-create the exported volume
+// v.  Unless volumeOptions is set, volumes of other drivers than the local
+// one, or with mount options, are rejected.
+func exportedVolumeOptions(v exportedVolume, volumeOptions bool) ([]VolumeCreateOption, error) {
+	if !volumeOptions {
+		if v.Driver != "" && v.Driver != define.VolumeDriverLocal {
+			return nil, errors.Wrapf(define.ErrInvalidArg, "volume %s uses the driver %q, which is only restored with --volume-options", v.Name, v.Driver)
+		}
+		for key := range v.Options {
+			if !exportedVolumeInfoOptions[key] {
+				return nil, errors.Wrapf(define.ErrInvalidArg, "volume %s has the mount option %q, which is only restored with --volume-options", v.Name, key)
+			}
+		}
+	}
+
+	options := []VolumeCreateOption{
+		WithVolumeName(v.Name),
+		WithVolumeLabels(v.Labels),
+		WithVolumeUID(v.UID),
+		WithVolumeGID(v.GID),
+	}
+	if v.Driver != "" {
+		options = append(options, WithVolumeDriver(v.Driver))
+	}
+	if len(v.Options) > 0 {
+		options = append(options, WithVolumeOptions(v.Options))
+	}
+	if v.Size > 0 {
+		options = append(options, WithVolumeSize(v.Size))
+	}
+	if v.Anonymous {
+		options = append(options, withSetAnon())
+	}
+	return options, nil
+}
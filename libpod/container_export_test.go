@@ -0,0 +1,33 @@
+package libpod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportedVolumeOptions(t *testing.T) {
+	tests := []struct {
+		name          string
+		volume        exportedVolume
+		volumeOptions bool
+		expectError   bool
+	}{
+		{"local", exportedVolume{Name: "a", Driver: "local", UID: 1000, Options: map[string]string{"UID": "1000"}}, false, false},
+		{"default driver", exportedVolume{Name: "a"}, false, false},
+		{"bind mount", exportedVolume{Name: "a", Driver: "local", Options: map[string]string{"type": "none", "o": "bind", "device": "/etc"}}, false, true},
+		{"bind mount requested", exportedVolume{Name: "a", Driver: "local", Options: map[string]string{"type": "none", "o": "bind", "device": "/etc"}}, true, false},
+		{"plugin", exportedVolume{Name: "a", Driver: "plugin"}, false, true},
+		{"plugin requested", exportedVolume{Name: "a", Driver: "plugin"}, true, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := exportedVolumeOptions(test.volume, test.volumeOptions)
+			if test.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
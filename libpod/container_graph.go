@@ -91,6 +91,30 @@ func BuildContainerGraph(ctrs []*Container) (*ContainerGraph, error) {
 	return graph, nil
 }
 
+// dependencyOrder returns the containers of the graph ordered so that every
+// container comes after all containers it depends on.
+func (cg *ContainerGraph) dependencyOrder() []*Container {
+	ordered := make([]*Container, 0, len(cg.nodes))
+	visited := make(map[string]bool)
+
+	var visit func(*containerNode)
+	visit = func(node *containerNode) {
+		if visited[node.id] {
+			return
+		}
+		visited[node.id] = true
+		for _, dep := range node.dependsOn {
+			visit(dep)
+		}
+		ordered = append(ordered, node.container)
+	}
+
+	for _, node := range cg.nodes {
+		visit(node)
+	}
+	return ordered
+}
+
 // Detect cycles in a container graph using Tarjan's strongly connected
 // components algorithm
 // Return true if a cycle is found, false otherwise
@@ -247,6 +271,15 @@ func startNode(ctx context.Context, node *containerNode, setError bool, ctrError
 		ctrErrored = true
 	}
 
+	// Wait for dependencies which must be healthy before we start.
+	// Also done without the container locked, as this can take a while.
+	if !ctrErrored {
+		if err := node.container.waitForHealthyDependencies(ctx); err != nil {
+			ctrErrors[node.id] = err
+			ctrErrored = true
+		}
+	}
+
 	// Lock before we start
 	node.container.lock.Lock()
 
@@ -259,7 +292,8 @@ func startNode(ctx context.Context, node *containerNode, setError bool, ctrError
 	}
 
 	// Start the container (only if it is not running)
-	if !ctrErrored {
+	// Init containers are run separately before the pod is started.
+	if !ctrErrored && !node.container.IsInitCtr() {
 		if !restart && node.container.state.State != define.ContainerStateRunning {
 			if err := node.container.initAndStart(ctx); err != nil {
 				ctrErrored = true
@@ -2,6 +2,7 @@ package libpod
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/containers/common/pkg/config"
@@ -106,14 +107,20 @@ func (c *Container) getContainerInspectData(size bool, driverData *driver.Data)
 			Status:     runtimeInfo.State.String(),
 			Running:    runtimeInfo.State == define.ContainerStateRunning,
 			Paused:     runtimeInfo.State == define.ContainerStatePaused,
-			OOMKilled:  runtimeInfo.OOMKilled,
-			Dead:       runtimeInfo.State.String() == "bad state",
-			Pid:        runtimeInfo.PID,
-			ConmonPid:  runtimeInfo.ConmonPID,
-			ExitCode:   runtimeInfo.ExitCode,
-			Error:      "", // can't get yet
-			StartedAt:  runtimeInfo.StartedTime,
-			FinishedAt: runtimeInfo.FinishedTime,
+			// The container exited and will be restarted by the cleanup
+			// process according to its restart policy.
+			Restarting:     c.ensureState(define.ContainerStateStopped, define.ContainerStateExited) && c.shouldRestart(),
+			OOMKilled:      runtimeInfo.OOMKilled,
+			OOMKills:       c.oomKillCount(),
+			TimedOut:       runtimeInfo.TimedOut,
+			ExitedByReboot: runtimeInfo.ExitedByReboot,
+			Dead:           runtimeInfo.State.String() == "bad state",
+			Pid:            runtimeInfo.PID,
+			ConmonPid:      runtimeInfo.ConmonPID,
+			ExitCode:       runtimeInfo.ExitCode,
+			Error:          "", // can't get yet
+			StartedAt:      runtimeInfo.StartedTime,
+			FinishedAt:     runtimeInfo.FinishedTime,
 		},
 		Image:           config.RootfsImageID,
 		ImageName:       config.RootfsImageName,
@@ -127,6 +134,7 @@ func (c *Container) getContainerInspectData(size bool, driverData *driver.Data)
 		StaticDir:       config.StaticDir,
 		OCIRuntime:      config.OCIRuntime,
 		ConmonPidFile:   config.ConmonPidFile,
+		PidFile:         config.PidFile,
 		Name:            config.Name,
 		RestartCount:    int32(runtimeInfo.RestartCount),
 		Driver:          driverData.Name,
@@ -140,6 +148,7 @@ func (c *Container) getContainerInspectData(size bool, driverData *driver.Data)
 		Mounts:          inspectMounts,
 		Dependencies:    c.Dependencies(),
 		IsInfra:         c.IsInfra(),
+		Owner:           config.Owner,
 	}
 
 	if c.state.ConfigPath != "" {
@@ -189,16 +198,35 @@ func (c *Container) getContainerInspectData(size bool, driverData *driver.Data)
 }
 
 // Get inspect-formatted mounts list.
-// Only includes user-specified mounts. Only includes bind mounts and named
-// volumes, not tmpfs volumes.
+// Only includes user-specified mounts and the default mounts from the mounts
+// files. Only includes bind mounts and named volumes, not tmpfs volumes.
 func (c *Container) getInspectMounts(namedVolumes []*ContainerNamedVolume, imageVolumes []*ContainerImageVolume, mounts []spec.Mount) ([]define.InspectMount, error) {
 	inspectMounts := []define.InspectMount{}
 
 	// No mounts, return early
-	if len(c.config.UserVolumes) == 0 {
+	if len(c.config.UserVolumes) == 0 && len(c.state.DefaultMounts) == 0 {
 		return inspectMounts, nil
 	}
 
+	// User mounts override default mounts at the same destination.
+	for _, dest := range c.state.DefaultMounts {
+		if util.StringInSlice(dest, c.config.UserVolumes) {
+			continue
+		}
+		source, ok := c.state.BindMounts[dest]
+		if !ok {
+			continue
+		}
+		mountStruct := define.InspectMount{}
+		mountStruct.Type = "bind"
+		mountStruct.Source = source
+		mountStruct.Destination = dest
+		mountStruct.RW = !c.IsReadOnly()
+		mountStruct.Propagation = "rprivate"
+
+		inspectMounts = append(inspectMounts, mountStruct)
+	}
+
 	for _, volume := range namedVolumes {
 		mountStruct := define.InspectMount{}
 		mountStruct.Type = "volume"
@@ -219,6 +247,19 @@ func (c *Container) getInspectMounts(namedVolumes []*ContainerNamedVolume, image
 		inspectMounts = append(inspectMounts, mountStruct)
 	}
 
+	// Writes to overlay volumes go to a temporary upper layer, the
+	// source on the host is not modified.
+	for _, volume := range c.config.OverlayVolumes {
+		mountStruct := define.InspectMount{}
+		mountStruct.Type = "overlay"
+		mountStruct.Destination = volume.Dest
+		mountStruct.Source = volume.Source
+		mountStruct.RW = true
+		mountStruct.Options = []string{"O"}
+
+		inspectMounts = append(inspectMounts, mountStruct)
+	}
+
 	for _, volume := range imageVolumes {
 		mountStruct := define.InspectMount{}
 		mountStruct.Type = "image"
@@ -293,7 +334,14 @@ func (c *Container) generateInspectContainerConfig(spec *spec.Spec) *define.Insp
 	if spec.Process != nil {
 		ctrConfig.Tty = spec.Process.Terminal
 		ctrConfig.Env = []string{}
-		ctrConfig.Env = append(ctrConfig.Env, spec.Process.Env...)
+		for _, env := range spec.Process.Env {
+			// Do not show the values of secrets.
+			name := strings.SplitN(env, "=", 2)[0]
+			if _, isSecret := c.config.EnvSecrets[name]; isSecret {
+				continue
+			}
+			ctrConfig.Env = append(ctrConfig.Env, env)
+		}
 		ctrConfig.WorkingDir = spec.Process.Cwd
 	}
 
@@ -327,6 +375,33 @@ func (c *Container) generateInspectContainerConfig(spec *spec.Spec) *define.Insp
 	}
 
 	ctrConfig.StopSignal = c.config.StopSignal
+	ctrConfig.Timeout = c.config.Timeout
+	if len(c.config.StopSignals) > 0 {
+		ctrConfig.StopSignals = append([]define.StopStep{}, c.config.StopSignals...)
+	}
+	for _, secret := range c.config.Secrets {
+		ctrConfig.Secrets = append(ctrConfig.Secrets, &define.InspectSecret{
+			Name:   secret.Secret.Name,
+			ID:     secret.Secret.ID,
+			Type:   "mount",
+			Target: secret.Target,
+		})
+	}
+	envNames := make([]string, 0, len(c.config.EnvSecrets))
+	for name := range c.config.EnvSecrets {
+		envNames = append(envNames, name)
+	}
+	sort.Strings(envNames)
+	for _, name := range envNames {
+		secret := c.config.EnvSecrets[name]
+		ctrConfig.Secrets = append(ctrConfig.Secrets, &define.InspectSecret{
+			Name:   secret.Name,
+			ID:     secret.ID,
+			Type:   "env",
+			Target: name,
+		})
+	}
+
 	// TODO: should JSON deep copy this to ensure internal pointers don't
 	// leak.
 	ctrConfig.Healthcheck = c.config.HealthCheckConfig
@@ -865,11 +940,18 @@ func (c *Container) generateInspectContainerHostConfig(ctrSpec *spec.Spec, named
 		}
 	}
 
-	// Terminal size
-	// We can't actually get this for now...
-	// So default to something sane.
-	// TODO: Populate this.
+	// Terminal size, as height and width like Docker.
+	// Prefer the last size requested, falling back to the initial size
+	// the container was created with.
 	hostConfig.ConsoleSize = []uint{0, 0}
+	if ctrSpec.Process != nil && ctrSpec.Process.Terminal {
+		switch {
+		case c.state.ConsoleSize != nil:
+			hostConfig.ConsoleSize = []uint{c.state.ConsoleSize.Height, c.state.ConsoleSize.Width}
+		case ctrSpec.Process.ConsoleSize != nil:
+			hostConfig.ConsoleSize = []uint{ctrSpec.Process.ConsoleSize.Height, ctrSpec.Process.ConsoleSize.Width}
+		}
+	}
 
 	return hostConfig, nil
 }
@@ -14,6 +14,7 @@ import (
 
 	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/libpod/events"
+	"github.com/containers/podman/v2/libpod/image"
 	"github.com/containers/podman/v2/pkg/cgroups"
 	"github.com/containers/podman/v2/pkg/ctime"
 	"github.com/containers/podman/v2/pkg/hooks"
@@ -23,6 +24,7 @@ import (
 	"github.com/containers/storage"
 	"github.com/containers/storage/pkg/archive"
 	"github.com/containers/storage/pkg/idtools"
+	"github.com/containers/storage/pkg/ioutils"
 	"github.com/containers/storage/pkg/mount"
 	"github.com/coreos/go-systemd/v22/daemon"
 	securejoin "github.com/cyphar/filepath-securejoin"
@@ -37,6 +39,9 @@ const (
 	// name of the directory holding the artifacts
 	artifactsDir      = "artifacts"
 	execDirPermission = 0755
+	// how often the health of dependencies is checked when waiting for
+	// them to become healthy
+	healthyDependencyPollInterval = 250 * time.Millisecond
 )
 
 // rootFsSize gets the size of the container's root filesystem
@@ -193,9 +198,27 @@ func (c *Container) handleExitFile(exitFile string, fi os.FileInfo) error {
 	}
 	c.state.ExitCode = int32(statusCode)
 
+	// Conmon creates the OOM file when the OOM killer killed a process of
+	// the container.
 	oomFilePath := filepath.Join(c.bundlePath(), "oom")
-	if _, err = os.Stat(oomFilePath); err == nil {
-		c.state.OOMKilled = true
+	_, err = os.Stat(oomFilePath)
+	c.state.OOMKilled = err == nil
+	if c.state.OOMKilled {
+		// The memory cgroup counts the killed processes, unless it is
+		// gone already.
+		kills, err := c.cgroupOOMKills()
+		if err != nil || kills == 0 {
+			kills = 1
+		}
+		c.state.OOMKillCount += kills
+		c.newContainerEvent(context.Background(), events.OOM)
+	}
+
+	// Conmon kills the container when its timeout expires, which is only
+	// visible in how long the container ran.
+	c.state.TimedOut = c.config.Timeout > 0 && c.state.FinishedTime.Sub(c.state.StartedTime) >= time.Duration(c.config.Timeout)*time.Second
+	if c.state.TimedOut {
+		c.newContainerEvent(context.Background(), events.Timeout)
 	}
 
 	c.state.Exited = true
@@ -206,6 +229,18 @@ func (c *Container) handleExitFile(exitFile string, fi os.FileInfo) error {
 	return nil
 }
 
+// oomKillCount returns the number of processes of the container killed by the
+// OOM killer, including the ones of its current run.
+func (c *Container) oomKillCount() uint64 {
+	count := c.state.OOMKillCount
+	if c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+		if kills, err := c.cgroupOOMKills(); err == nil {
+			count += kills
+		}
+	}
+	return count
+}
+
 func (c *Container) shouldRestart() bool {
 	// If we did not get a restart policy match, return false
 	// Do the same if we're not a policy that restarts.
@@ -254,7 +289,7 @@ func (c *Container) handleRestartPolicy(ctx context.Context) (_ bool, retErr err
 		return false, errors.Wrapf(define.ErrInternal, "invalid container state encountered in restart attempt!")
 	}
 
-	c.newContainerEvent(events.Restart)
+	c.newContainerEvent(ctx, events.Restart)
 
 	// Increment restart count
 	c.state.RestartCount += 1
@@ -285,7 +320,7 @@ func (c *Container) handleRestartPolicy(ctx context.Context) (_ bool, retErr err
 			return false, err
 		}
 	}
-	if err := c.start(); err != nil {
+	if err := c.start(ctx); err != nil {
 		return false, err
 	}
 	return true, nil
@@ -311,6 +346,14 @@ func (c *Container) syncContainer() error {
 	if err := c.runtime.state.UpdateContainer(c); err != nil {
 		return err
 	}
+	// A process that died while starting the container may have left a
+	// more recent state than the database.  Starting begins in one of
+	// these states.
+	if !c.deferSaves && c.ensureState(define.ContainerStateConfigured, define.ContainerStateCreated, define.ContainerStateStopped, define.ContainerStateExited) {
+		if err := c.recoverDeferredState(); err != nil {
+			return err
+		}
+	}
 	// If runtime knows about the container, update its status in runtime
 	// And then save back to disk
 	if c.ensureState(define.ContainerStateCreated, define.ContainerStateRunning, define.ContainerStateStopped, define.ContainerStatePaused) {
@@ -343,6 +386,47 @@ func (c *Container) syncContainer() error {
 	return nil
 }
 
+// snapshotCopy returns a read-only copy of the container for
+// Runtime.GetContainersSnapshot.  The state of the container must already be
+// loaded.  If the container exited in the meantime, or a process died while
+// starting it, it is locked and synced to handle its exit file or deferred
+// state.
+func (c *Container) snapshotCopy() (*Container, error) {
+	needsSync := false
+	if c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+		exitFile, err := c.exitFilePath()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(exitFile); err == nil {
+			needsSync = true
+		}
+	} else if c.state.RunDir != "" {
+		if _, err := os.Stat(c.deferredStatePath()); err == nil {
+			needsSync = true
+		}
+	}
+	if needsSync {
+		c.lock.Lock()
+		err := c.syncContainer()
+		c.lock.Unlock()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	snapshot := new(Container)
+	snapshot.config = c.config
+	snapshot.state = c.state
+	snapshot.runtime = c.runtime
+	snapshot.ociRuntime = c.ociRuntime
+	snapshot.lock = c.lock
+	snapshot.valid = true
+	snapshot.batched = true
+	snapshot.snapshot = true
+	return snapshot, nil
+}
+
 func (c *Container) setupStorageMapping(dest, from *storage.IDMappingOptions) {
 	if c.config.Rootfs != "" {
 		return
@@ -537,19 +621,30 @@ func (c *Container) teardownStorage() error {
 
 // Reset resets state fields to default values.
 // It is performed before a refresh and clears the state after a reboot.
+// StoppedByUser is kept, so containers with the unless-stopped restart policy
+// that were stopped by the user are not started after the reboot.
+// Containers that were running are marked as exited by the reboot.
 // It does not save the results - assumes the database will do that for us.
 func resetState(state *ContainerState) {
 	state.PID = 0
 	state.ConmonPID = 0
 	state.Mountpoint = ""
 	state.Mounted = false
-	if state.State != define.ContainerStateExited {
+	switch state.State {
+	case define.ContainerStateExited:
+	case define.ContainerStateRunning, define.ContainerStatePaused:
+		// The exit code of the container is lost with its conmon.
+		state.State = define.ContainerStateExited
+		state.Exited = true
+		state.ExitCode = -1
+		state.FinishedTime = time.Now()
+		state.ExitedByReboot = true
+	default:
 		state.State = define.ContainerStateConfigured
 	}
 	state.ExecSessions = make(map[string]*ExecSession)
 	state.LegacyExecSessions = nil
 	state.BindMounts = make(map[string]string)
-	state.StoppedByUser = false
 	state.RestartPolicyMatch = false
 	state.RestartCount = 0
 }
@@ -763,29 +858,126 @@ func (c *Container) isStopped() (bool, error) {
 
 // save container state to the database
 func (c *Container) save() error {
+	if c.deferSaves {
+		return c.writeDeferredState()
+	}
 	if err := c.runtime.state.SaveContainer(c); err != nil {
 		return errors.Wrapf(err, "error saving container %s state", c.ID())
 	}
 	return nil
 }
 
+// withDeferredSaves runs fn and writes the state of the container to the
+// database once afterwards, instead of every time it changes.  The state is
+// written even if fn fails, so it reflects any cleanup done by fn.  The
+// container must be locked, other processes only see the state once fn is
+// done.
+// Meanwhile, the state is written to the deferred state file of the
+// container, which is cheap as it lives in the run directory.  If the process
+// dies before fn is done, the next process syncing the container recovers the
+// state from it.
+func (c *Container) withDeferredSaves(fn func() error) error {
+	c.deferSaves = true
+	err := fn()
+	c.deferSaves = false
+
+	if saveErr := c.save(); saveErr != nil {
+		if err != nil {
+			logrus.Errorf("Error saving container %s state: %v", c.ID(), saveErr)
+			return err
+		}
+		return saveErr
+	}
+	if err := os.Remove(c.deferredStatePath()); err != nil && !os.IsNotExist(err) {
+		logrus.Errorf("Error removing deferred state of container %s: %v", c.ID(), err)
+	}
+	return err
+}
+
+// deferredState is the state of a container written to its deferred state
+// file by withDeferredSaves.
+type deferredState struct {
+	State *ContainerState `json:"state"`
+	// NetNSPath is the path of the network namespace, which is not part
+	// of the encoded state.
+	NetNSPath string `json:"netNSPath,omitempty"`
+}
+
+// deferredStatePath returns the path of the deferred state file of the
+// container.
+func (c *Container) deferredStatePath() string {
+	return filepath.Join(c.state.RunDir, "deferred-state.json")
+}
+
+// writeDeferredState writes the state of the container to its deferred state
+// file.  It is replaced atomically, but not synced: the file only has to
+// survive the process, not a reboot.
+func (c *Container) writeDeferredState() error {
+	if c.state.RunDir == "" {
+		return nil
+	}
+	data, err := json.Marshal(deferredState{State: c.state, NetNSPath: getNetNSPath(c)})
+	if err != nil {
+		return errors.Wrapf(err, "error encoding container %s state", c.ID())
+	}
+	f, err := ioutils.NewAtomicFileWriterWithOpts(c.deferredStatePath(), 0600, &ioutils.AtomicFileWriterOptions{NoSync: true})
+	if err != nil {
+		return errors.Wrapf(err, "error writing deferred state of container %s", c.ID())
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "error writing deferred state of container %s", c.ID())
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrapf(err, "error writing deferred state of container %s", c.ID())
+	}
+	return nil
+}
+
+// recoverDeferredState saves the state left in the deferred state file of the
+// container by a process that died while deferring saves, so that the
+// database reflects the resources the process had set up, e.g., the network
+// namespace, mounts or the container created in the OCI runtime.  The state
+// must have just been updated from the database, and the container must be
+// locked.
+func (c *Container) recoverDeferredState() error {
+	path := c.deferredStatePath()
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "error reading deferred state of container %s", c.ID())
+	}
+	deferred := deferredState{State: new(ContainerState)}
+	if err := json.Unmarshal(data, &deferred); err != nil {
+		return errors.Wrapf(err, "error decoding deferred state of container %s", c.ID())
+	}
+	if err := replaceNetNS(deferred.NetNSPath, c, deferred.State); err != nil {
+		return err
+	}
+	logrus.Infof("Recovering state of container %s left by an interrupted start", c.ID())
+	c.state = deferred.State
+	if err := c.save(); err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "error removing deferred state of container %s", c.ID())
+	}
+	return nil
+}
+
 // Checks the container is in the right state, then initializes the container in preparation to start the container.
-// If recursive is true, each of the containers dependencies will be started.
-// Otherwise, this function will return with error if there are dependencies of this container that aren't running.
-func (c *Container) prepareToStart(ctx context.Context, recursive bool) (retErr error) {
+// This function will return with error if there are dependencies of this container that aren't running, they must
+// have been started with startAndWaitForDependencies if needed.
+func (c *Container) prepareToStart(ctx context.Context) (retErr error) {
 	// Container must be created or stopped to be started
 	if !c.ensureState(define.ContainerStateConfigured, define.ContainerStateCreated, define.ContainerStateStopped, define.ContainerStateExited) {
 		return errors.Wrapf(define.ErrCtrStateInvalid, "container %s must be in Created or Stopped state to be started", c.ID())
 	}
 
-	if !recursive {
-		if err := c.checkDependenciesAndHandleError(); err != nil {
-			return err
-		}
-	} else {
-		if err := c.startDependencies(ctx); err != nil {
-			return err
-		}
+	if err := c.checkDependenciesAndHandleError(); err != nil {
+		return err
 	}
 
 	defer func() {
@@ -949,6 +1141,68 @@ func (c *Container) checkDependenciesRunning() ([]string, error) {
 	return notRunning, nil
 }
 
+// startAndWaitForDependencies starts the dependencies of the container if
+// recursive is set, then waits until the dependencies which were set with
+// WithHealthyDependencyCtrs report healthy.  It is called before the container
+// is locked, as waiting can take a while.
+func (c *Container) startAndWaitForDependencies(ctx context.Context, recursive bool) error {
+	if recursive {
+		if err := c.startDependencies(ctx); err != nil {
+			return err
+		}
+	}
+	return c.waitForHealthyDependencies(ctx)
+}
+
+// waitForHealthyDependencies waits until the dependencies which were set with
+// WithHealthyDependencyCtrs report healthy, or the timeout of the container
+// expires.  The dependencies must be running already.
+func (c *Container) waitForHealthyDependencies(ctx context.Context) error {
+	if len(c.config.HealthyDependencies) == 0 {
+		return nil
+	}
+
+	var timeout <-chan time.Time
+	if c.config.HealthyDependencyTimeout > 0 {
+		timer := time.NewTimer(time.Duration(c.config.HealthyDependencyTimeout) * time.Second)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	for _, dep := range c.config.HealthyDependencies {
+		depCtr, err := c.runtime.state.Container(dep)
+		if err != nil {
+			return errors.Wrapf(err, "error retrieving dependency %s of container %s from state", dep, c.ID())
+		}
+		for {
+			state, err := depCtr.State()
+			if err != nil {
+				return errors.Wrapf(err, "error retrieving state of dependency %s of container %s", dep, c.ID())
+			}
+			if state != define.ContainerStateRunning {
+				return errors.Wrapf(define.ErrCtrStateInvalid, "dependency %s of container %s is not running, it cannot become healthy", dep, c.ID())
+			}
+			status, err := depCtr.HealthCheckStatus()
+			if err != nil {
+				return errors.Wrapf(err, "error retrieving health of dependency %s of container %s", dep, c.ID())
+			}
+			if status == define.HealthCheckHealthy {
+				break
+			}
+			logrus.Debugf("Waiting for dependency %s of container %s to become healthy, status is %q", dep, c.ID(), status)
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-timeout:
+				return errors.Wrapf(define.ErrCtrStateInvalid, "dependency %s of container %s did not become healthy within %d seconds", dep, c.ID(), c.config.HealthyDependencyTimeout)
+			case <-time.After(healthyDependencyPollInterval):
+			}
+		}
+	}
+	return nil
+}
+
 func (c *Container) completeNetworkSetup() error {
 	var outResolvConf []string
 	netDisabled, err := c.NetworkDisabled()
@@ -958,9 +1212,6 @@ func (c *Container) completeNetworkSetup() error {
 	if !c.config.PostConfigureNetNS || netDisabled {
 		return nil
 	}
-	if err := c.syncContainer(); err != nil {
-		return err
-	}
 	if rootless.IsRootless() {
 		return c.runtime.setupRootlessNetNS(c)
 	} else if c.config.NetMode.IsSlirp4netns() {
@@ -1014,11 +1265,32 @@ func (c *Container) completeNetworkSetup() error {
 	return ioutil.WriteFile(resolvBindMount, []byte(strings.Join(outResolvConf, "\n")), 0644)
 }
 
+// cniHosts returns the hosts entries depending on the CNI networks of the
+// container: its own address and the address of the host, which is the
+// gateway of its first network.
 func (c *Container) cniHosts() string {
 	var hosts string
 	if len(c.state.NetworkStatus) > 0 && len(c.state.NetworkStatus[0].IPs) > 0 {
 		ipAddress := strings.Split(c.state.NetworkStatus[0].IPs[0].Address.String(), "/")[0]
 		hosts += fmt.Sprintf("%s\t%s %s\n", ipAddress, c.Hostname(), c.Config().Name)
+		if gateway := c.state.NetworkStatus[0].IPs[0].Gateway; gateway != nil {
+			hosts += c.hostGatewayHosts(gateway.String())
+		}
+	}
+	return hosts
+}
+
+// hostGatewayHosts returns the host.containers.internal entry and the
+// --add-host entries using the host-gateway IP for hostIP, the address of
+// the host as seen from the container.
+func (c *Container) hostGatewayHosts(hostIP string) string {
+	hosts := fmt.Sprintf("%s\t%s\n", hostIP, define.HostContainersInternal)
+	for _, host := range c.config.HostAdd {
+		// the host format has already been verified at this point
+		fields := strings.SplitN(host, ":", 2)
+		if fields[1] == define.HostGateway {
+			hosts += fmt.Sprintf("%s %s\n", hostIP, fields[0])
+		}
 	}
 	return hosts
 }
@@ -1076,6 +1348,7 @@ func (c *Container) init(ctx context.Context, retainRetries bool) error {
 
 	c.state.ExitCode = 0
 	c.state.Exited = false
+	c.state.ExitedByReboot = false
 	c.state.State = define.ContainerStateCreated
 	c.state.StoppedByUser = false
 	c.state.RestartPolicyMatch = false
@@ -1093,7 +1366,7 @@ func (c *Container) init(ctx context.Context, retainRetries bool) error {
 		}
 	}
 
-	defer c.newContainerEvent(events.Init)
+	defer c.newContainerEvent(ctx, events.Init)
 	return c.completeNetworkSetup()
 }
 
@@ -1207,11 +1480,11 @@ func (c *Container) initAndStart(ctx context.Context) (retErr error) {
 	}
 
 	// Now start the container
-	return c.start()
+	return c.start(ctx)
 }
 
 // Internal, non-locking function to start a container
-func (c *Container) start() error {
+func (c *Container) start(ctx context.Context) error {
 	if c.config.Spec.Process != nil {
 		logrus.Debugf("Starting container %s with command %v", c.ID(), c.config.Spec.Process.Args)
 	}
@@ -1245,14 +1518,20 @@ func (c *Container) start() error {
 		}
 	}
 
-	defer c.newContainerEvent(events.Start)
+	defer c.newContainerEvent(ctx, events.Start)
 
 	return c.save()
 }
 
 // Internal, non-locking function to stop container
 func (c *Container) stop(timeout uint) error {
-	logrus.Debugf("Stopping ctr %s (timeout %d)", c.ID(), timeout)
+	return c.stopWithSignals([]define.StopStep{{Signal: c.config.StopSignal, Timeout: timeout}})
+}
+
+// Internal, non-locking function to stop container with a signal escalation
+// chain
+func (c *Container) stopWithSignals(steps []define.StopStep) error {
+	logrus.Debugf("Stopping ctr %s (steps %v)", c.ID(), steps)
 
 	// If the container is running in a PID Namespace, then killing the
 	// primary pid is enough to kill the container.  If it is not running in
@@ -1284,11 +1563,11 @@ func (c *Container) stop(timeout uint) error {
 		return err
 	}
 
-	if err := c.ociRuntime.StopContainer(c, timeout, all); err != nil {
+	if err := c.ociRuntime.StopContainer(c, steps, all); err != nil {
 		return err
 	}
 
-	c.newContainerEvent(events.Stop)
+	c.newContainerEvent(context.Background(), events.Stop)
 
 	c.state.PID = 0
 	c.state.ConmonPID = 0
@@ -1370,7 +1649,7 @@ func (c *Container) restartWithTimeout(ctx context.Context, timeout uint) (retEr
 		return errors.Wrapf(define.ErrCtrStateInvalid, "unable to restart a container in a paused or unknown state")
 	}
 
-	c.newContainerEvent(events.Restart)
+	c.newContainerEvent(ctx, events.Restart)
 
 	if c.state.State == define.ContainerStateRunning {
 		conmonPID := c.state.ConmonPID
@@ -1424,7 +1703,7 @@ func (c *Container) restartWithTimeout(ctx context.Context, timeout uint) (retEr
 			return err
 		}
 	}
-	return c.start()
+	return c.start(ctx)
 }
 
 // mountStorage sets up the container's root filesystem
@@ -1610,6 +1889,25 @@ func (c *Container) chownVolume(volumeName string) error {
 	return nil
 }
 
+// imageVolumeImage returns the image of the image volume v.
+func (c *Container) imageVolumeImage(v *ContainerImageVolume) (*image.Image, error) {
+	if v.ImageID != "" {
+		return c.runtime.ImageRuntime().NewFromLocal(v.ImageID)
+	}
+	return c.runtime.ImageRuntime().NewFromLocal(v.Source)
+}
+
+// usesImageVolume returns whether the image with the given ID is mounted into
+// the container as an image volume.
+func (c *Container) usesImageVolume(imageID string) bool {
+	for _, v := range c.config.ImageVolumes {
+		if v.ImageID == imageID {
+			return true
+		}
+	}
+	return false
+}
+
 // cleanupStorage unmounts and cleans up the container's root filesystem
 func (c *Container) cleanupStorage() error {
 	if !c.state.Mounted {
@@ -1728,6 +2026,14 @@ func (c *Container) cleanup(ctx context.Context) error {
 		}
 	}
 
+	// Remove the PID file requested by the user, the process it refers to
+	// is gone.
+	if c.config.PidFile != "" {
+		if err := os.Remove(c.config.PidFile); err != nil && !os.IsNotExist(err) {
+			logrus.Errorf("Error removing container %s PID file: %v", c.ID(), err)
+		}
+	}
+
 	// Unmount storage
 	if err := c.cleanupStorage(); err != nil {
 		if lastError != nil {
@@ -1739,7 +2045,7 @@ func (c *Container) cleanup(ctx context.Context) error {
 
 	// Unmount image volumes
 	for _, v := range c.config.ImageVolumes {
-		img, err := c.runtime.ImageRuntime().NewFromLocal(v.Source)
+		img, err := c.imageVolumeImage(v)
 		if err != nil {
 			if lastError == nil {
 				lastError = err
@@ -1877,6 +2183,45 @@ func (c *Container) appendStringToRundir(destFile, output string) (string, error
 	return filepath.Join(c.state.RunDir, destFile), nil
 }
 
+// rewriteConfig changes the configuration of the container with the given
+// function, and writes the changed configuration to the database.
+func (c *Container) rewriteConfig(change func(config *ContainerConfig) error) error {
+	if c.state.State == define.ContainerStateRemoving {
+		return errors.Wrapf(define.ErrCtrStateInvalid, "cannot update container %s as it is being removed", c.ID())
+	}
+
+	newConfig := new(ContainerConfig)
+	if err := JSONDeepCopy(c.config, newConfig); err != nil {
+		return errors.Wrapf(err, "error copying configuration of container %s", c.ID())
+	}
+	if err := change(newConfig); err != nil {
+		return err
+	}
+	if err := c.runtime.state.RewriteContainerConfig(c, newConfig); err != nil {
+		return err
+	}
+	c.config = newConfig
+
+	c.newContainerEvent(context.Background(), events.Update)
+	return nil
+}
+
+// updateMap returns a copy of the given map, with the given keys added or
+// replaced and the keys in remove deleted.
+func updateMap(m map[string]string, add map[string]string, remove []string) map[string]string {
+	updated := make(map[string]string, len(m)+len(add))
+	for k, v := range m {
+		updated[k] = v
+	}
+	for _, k := range remove {
+		delete(updated, k)
+	}
+	for k, v := range add {
+		updated[k] = v
+	}
+	return updated
+}
+
 // saveSpec saves the OCI spec to disk, replacing any existing specs for the container
 func (c *Container) saveSpec(spec *spec.Spec) error {
 	// If the OCI spec already exists, we need to replace it
@@ -1899,7 +2244,13 @@ func (c *Container) saveSpec(spec *spec.Spec) error {
 	if err != nil {
 		return errors.Wrapf(err, "error exporting runtime spec for container %s to JSON", c.ID())
 	}
-	if err := ioutil.WriteFile(jsonPath, fileJSON, 0644); err != nil {
+	// The spec holds the values of the environment variables set from
+	// secrets, so only the owner may read it then.
+	var mode os.FileMode = 0644
+	if len(c.config.EnvSecrets) > 0 {
+		mode = 0600
+	}
+	if err := ioutil.WriteFile(jsonPath, fileJSON, mode); err != nil {
 		return errors.Wrapf(err, "error writing runtime spec JSON for container %s to disk", c.ID())
 	}
 
@@ -37,6 +37,7 @@ import (
 	"github.com/containers/podman/v2/utils"
 	"github.com/containers/podman/v2/version"
 	"github.com/containers/storage/pkg/archive"
+	"github.com/containers/storage/pkg/chrootarchive"
 	"github.com/containers/storage/pkg/idtools"
 	securejoin "github.com/cyphar/filepath-securejoin"
 	runcuser "github.com/opencontainers/runc/libcontainer/user"
@@ -336,6 +337,15 @@ func (c *Container) generateSpec(ctx context.Context) (*spec.Spec, error) {
 	g.SetProcessSelinuxLabel(c.ProcessLabel())
 	g.SetLinuxMountLabel(c.MountLabel())
 
+	// Apply any resize that was requested before the TTY existed, so the
+	// container does not start with the default 80x24 console.
+	if c.state.ConsoleSize != nil && g.Config.Process != nil && g.Config.Process.Terminal {
+		g.Config.Process.ConsoleSize = &spec.Box{
+			Height: c.state.ConsoleSize.Height,
+			Width:  c.state.ConsoleSize.Width,
+		}
+	}
+
 	// Add named volumes
 	for _, namedVol := range c.config.NamedVolumes {
 		volume, err := c.runtime.GetVolume(namedVol.Name)
@@ -386,7 +396,7 @@ func (c *Container) generateSpec(ctx context.Context) (*spec.Spec, error) {
 	// Add image volumes as overlay mounts
 	for _, volume := range c.config.ImageVolumes {
 		// Mount the specified image.
-		img, err := c.runtime.ImageRuntime().NewFromLocal(volume.Source)
+		img, err := c.imageVolumeImage(volume)
 		if err != nil {
 			return nil, errors.Wrapf(err, "error creating image volume %q:%q", volume.Source, volume.Dest)
 		}
@@ -412,6 +422,10 @@ func (c *Container) generateSpec(ctx context.Context) (*spec.Spec, error) {
 		g.AddMount(overlayMount)
 	}
 
+	if err := c.addSecrets(&g); err != nil {
+		return nil, err
+	}
+
 	hasHomeSet := false
 	for _, s := range c.config.Spec.Process.Env {
 		if strings.HasPrefix(s, "HOME=") {
@@ -648,6 +662,9 @@ func (c *Container) generateSpec(ctx context.Context) (*spec.Spec, error) {
 // It also expects to be able to write to /sys/fs/cgroup/systemd and /var/log/journal
 func (c *Container) setupSystemd(mounts []spec.Mount, g generate.Generator) error {
 	options := []string{"rw", "rprivate", "nosuid", "nodev"}
+	if c.config.ShmSizeSystemd > 0 {
+		options = append(options, fmt.Sprintf("size=%d", c.config.ShmSizeSystemd))
+	}
 	for _, dest := range []string{"/run", "/run/lock"} {
 		if MountExists(mounts, dest) {
 			continue
@@ -767,10 +784,8 @@ func (c *Container) addNamespaceContainer(g *generate.Generator, ns LinuxNS, ctr
 	return nil
 }
 
-func (c *Container) exportCheckpoint(dest string, ignoreRootfs bool) error {
-	if (len(c.config.NamedVolumes) > 0) || (len(c.Dependencies()) > 0) {
-		return errors.Errorf("Cannot export checkpoints of containers with named volumes or dependencies")
-	}
+func (c *Container) exportCheckpoint(options ContainerCheckpointOptions) error {
+	dest := options.TargetFile
 	logrus.Debugf("Exporting checkpoint image of container %q to %q", c.ID(), dest)
 
 	includeFiles := []string{
@@ -784,7 +799,7 @@ func (c *Container) exportCheckpoint(dest string, ignoreRootfs bool) error {
 	// Get root file-system changes included in the checkpoint archive
 	rootfsDiffPath := filepath.Join(c.bundlePath(), "rootfs-diff.tar")
 	deleteFilesList := filepath.Join(c.bundlePath(), "deleted.files")
-	if !ignoreRootfs {
+	if !options.IgnoreRootfs {
 		// To correctly track deleted files, let's go through the output of 'podman diff'
 		tarFiles, err := c.runtime.GetDiff("", c.ID())
 		if err != nil {
@@ -847,6 +862,23 @@ func (c *Container) exportCheckpoint(dest string, ignoreRootfs bool) error {
 		}
 	}
 
+	// Archive the content of each named volume, so that the volumes can be
+	// re-created with the same content on the destination host.
+	volumesDir := filepath.Join(c.bundlePath(), "volumes")
+	if !options.IgnoreVolumes && len(c.config.NamedVolumes) > 0 {
+		if err := os.MkdirAll(volumesDir, 0700); err != nil {
+			return errors.Wrapf(err, "error creating volumes export directory %q", volumesDir)
+		}
+		defer os.RemoveAll(volumesDir)
+
+		for _, v := range c.config.NamedVolumes {
+			if err := c.exportCheckpointVolume(v.Name, volumesDir); err != nil {
+				return err
+			}
+			includeFiles = append(includeFiles, filepath.Join("volumes", v.Name+".tar"))
+		}
+	}
+
 	input, err := archive.TarWithOptions(c.bundlePath(), &archive.TarOptions{
 		Compression:      archive.Gzip,
 		IncludeSourceDir: true,
@@ -878,6 +910,70 @@ func (c *Container) exportCheckpoint(dest string, ignoreRootfs bool) error {
 	return nil
 }
 
+// exportCheckpointVolume writes the content of the named volume to a tar
+// archive named after the volume in dir.
+func (c *Container) exportCheckpointVolume(name, dir string) error {
+	vol, err := c.runtime.state.Volume(name)
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving volume %s", name)
+	}
+	volumeTarPath := filepath.Join(dir, name+".tar")
+	input, err := archive.TarWithOptions(vol.MountPoint(), &archive.TarOptions{
+		Compression:      archive.Uncompressed,
+		IncludeSourceDir: true,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "error reading volume %s", name)
+	}
+	defer input.Close()
+	volumeTarFile, err := os.Create(volumeTarPath)
+	if err != nil {
+		return errors.Wrapf(err, "error creating volume archive %q", volumeTarPath)
+	}
+	defer volumeTarFile.Close()
+	if _, err := io.Copy(volumeTarFile, input); err != nil {
+		return errors.Wrapf(err, "error exporting volume %s to %q", name, volumeTarPath)
+	}
+	return nil
+}
+
+// importCheckpointVolumes restores the content of the named volumes from the
+// archives of an imported checkpoint.  Volumes without an archive, e.g. if the
+// checkpoint was exported with --ignore-volumes, are left untouched.
+func (c *Container) importCheckpointVolumes() error {
+	volumesDir := filepath.Join(c.bundlePath(), "volumes")
+	for _, v := range c.config.NamedVolumes {
+		if err := c.importCheckpointVolume(filepath.Join(volumesDir, v.Name+".tar"), v.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importCheckpointVolume restores the named volume from its archive in the
+// checkpoint, if the checkpoint contains one.
+func (c *Container) importCheckpointVolume(volumeTarPath, name string) error {
+	volumeTarFile, err := os.Open(volumeTarPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to open volume archive %q", volumeTarPath)
+	}
+	defer volumeTarFile.Close()
+	vol, err := c.runtime.state.Volume(name)
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving volume %s", name)
+	}
+	// The checkpoint may come from an untrusted source: extract the
+	// archive chrooted into the volume so that it cannot escape the mount
+	// point.
+	if err := chrootarchive.UntarUncompressed(volumeTarFile, vol.MountPoint(), &archive.TarOptions{}); err != nil {
+		return errors.Wrapf(err, "failed to restore volume %s from %q", name, volumeTarPath)
+	}
+	return nil
+}
+
 func (c *Container) checkpointRestoreSupported() error {
 	if !criu.CheckForCriu() {
 		return errors.Errorf("Checkpoint/Restore requires at least CRIU %d", criu.MinCriuVersion)
@@ -914,6 +1010,19 @@ func (c *Container) checkpoint(ctx context.Context, options ContainerCheckpointO
 		return errors.Wrapf(define.ErrCtrStateInvalid, "%q is not running, cannot checkpoint", c.state.State)
 	}
 
+	// The dependencies of the container would be missing where the
+	// checkpoint is imported.  Pods are exported with all their
+	// containers instead.
+	if options.TargetFile != "" && len(c.Dependencies()) > 0 {
+		return errors.Errorf("Cannot export checkpoints of containers with dependencies")
+	}
+
+	return c.dumpCheckpoint(ctx, options)
+}
+
+// dumpCheckpoint writes the checkpoint of the container, which must be running,
+// or paused when its whole pod is checkpointed.
+func (c *Container) dumpCheckpoint(ctx context.Context, options ContainerCheckpointOptions) error {
 	if c.AutoRemove() && options.TargetFile == "" {
 		return errors.Errorf("Cannot checkpoint containers that have been started with '--rm' unless '--export' is used")
 	}
@@ -937,10 +1046,10 @@ func (c *Container) checkpoint(ctx context.Context, options ContainerCheckpointO
 		return err
 	}
 
-	defer c.newContainerEvent(events.Checkpoint)
+	defer c.newContainerEvent(ctx, events.Checkpoint)
 
 	if options.TargetFile != "" {
-		if err = c.exportCheckpoint(options.TargetFile, options.IgnoreRootfs); err != nil {
+		if err = c.exportCheckpoint(options); err != nil {
 			return err
 		}
 	}
@@ -1013,6 +1122,22 @@ func (c *Container) restore(ctx context.Context, options ContainerCheckpointOpti
 		return errors.Wrapf(define.ErrCtrStateInvalid, "container %s is running or paused, cannot restore", c.ID())
 	}
 
+	// The addresses of the container are assigned by CNI when its network
+	// namespace is set up, before CRIU restores the processes.  Sockets
+	// bound to an address are restored with it, so established TCP
+	// connections cannot be moved to a new address.
+	if options.StaticIP != nil || options.StaticMAC != nil {
+		if !c.config.CreateNetNS {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot change the IP or MAC address of container %s, it does not create its own network namespace", c.ID())
+		}
+		if len(c.config.Networks) > 1 {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot change the IP or MAC address of container %s, it joins more than one CNI network", c.ID())
+		}
+	}
+	if options.StaticIP != nil && options.TCPEstablished {
+		return errors.Wrapf(define.ErrInvalidArg, "cannot restore established TCP connections of container %s with a different IP address", c.ID())
+	}
+
 	if options.TargetFile != "" {
 		if err := c.importCheckpoint(options.TargetFile); err != nil {
 			return err
@@ -1035,6 +1160,8 @@ func (c *Container) restore(ctx context.Context, options ContainerCheckpointOpti
 	// process to ignore the static IP with '--ignore-static-ip'
 	if options.IgnoreStaticIP {
 		c.config.StaticIP = nil
+		c.config.StaticIPv6 = nil
+		c.config.StaticIPs = nil
 	}
 
 	// If a container is restored multiple times from an exported checkpoint with
@@ -1044,6 +1171,7 @@ func (c *Container) restore(ctx context.Context, options ContainerCheckpointOpti
 	// '--ignore-static-mac'
 	if options.IgnoreStaticMAC {
 		c.config.StaticMAC = nil
+		c.config.StaticMACs = nil
 	}
 
 	// Read network configuration from checkpoint
@@ -1101,6 +1229,15 @@ func (c *Container) restore(ctx context.Context, options ContainerCheckpointOpti
 		}
 	}
 
+	// Addresses requested for the restore take precedence over the
+	// checkpointed ones.
+	if options.StaticIP != nil {
+		c.requestedIP = options.StaticIP
+	}
+	if options.StaticMAC != nil {
+		c.requestedMAC = options.StaticMAC
+	}
+
 	defer func() {
 		if retErr != nil {
 			if err := c.cleanup(ctx); err != nil {
@@ -1205,6 +1342,14 @@ func (c *Container) restore(ctx context.Context, options ContainerCheckpointOpti
 		}
 	}
 
+	// The named volumes are mounted by now, so their content can be
+	// restored before the container is started.
+	if options.TargetFile != "" && !options.IgnoreVolumes {
+		if err := c.importCheckpointVolumes(); err != nil {
+			return err
+		}
+	}
+
 	if err := c.ociRuntime.CreateContainer(c, &options); err != nil {
 		return err
 	}
@@ -1222,6 +1367,10 @@ func (c *Container) restore(ctx context.Context, options ContainerCheckpointOpti
 		if err != nil {
 			logrus.Debugf("Non-fatal: removal of checkpoint directory (%s) failed: %v", c.CheckpointPath(), err)
 		}
+		volumesDir := filepath.Join(c.bundlePath(), "volumes")
+		if err := os.RemoveAll(volumesDir); err != nil {
+			logrus.Debugf("Non-fatal: removal of checkpoint volumes directory (%s) failed: %v", volumesDir, err)
+		}
 		cleanup := [...]string{"restore.log", "dump.log", "stats-dump", "stats-restore", "network.status", "rootfs-diff.tar", "deleted.files"}
 		for _, del := range cleanup {
 			file := filepath.Join(c.bundlePath(), del)
@@ -1344,7 +1493,7 @@ func (c *Container) makeBindMounts() error {
 			}
 
 			if !c.config.UseImageHosts {
-				newHosts, err := c.generateHosts("/etc/hosts")
+				newHosts, err := c.generateHosts()
 				if err != nil {
 					return errors.Wrapf(err, "error creating hosts file for container %s", c.ID())
 				}
@@ -1365,7 +1514,7 @@ func (c *Container) makeBindMounts() error {
 		}
 	} else {
 		if !c.config.UseImageHosts && c.state.BindMounts["/etc/hosts"] == "" {
-			newHosts, err := c.generateHosts("/etc/hosts")
+			newHosts, err := c.generateHosts()
 			if err != nil {
 				return errors.Wrapf(err, "error creating hosts file for container %s", c.ID())
 			}
@@ -1410,18 +1559,9 @@ func (c *Container) makeBindMounts() error {
 	// Make /etc/localtime
 	if c.Timezone() != "" {
 		if _, ok := c.state.BindMounts["/etc/localtime"]; !ok {
-			var zonePath string
-			if c.Timezone() == "local" {
-				zonePath, err = filepath.EvalSymlinks("/etc/localtime")
-				if err != nil {
-					return errors.Wrapf(err, "error finding local timezone for container %s", c.ID())
-				}
-			} else {
-				zone := filepath.Join("/usr/share/zoneinfo", c.Timezone())
-				zonePath, err = filepath.EvalSymlinks(zone)
-				if err != nil {
-					return errors.Wrapf(err, "error setting timezone for container %s", c.ID())
-				}
+			zonePath, err := timezonePath(c.Timezone())
+			if err != nil {
+				return errors.Wrapf(err, "error setting timezone for container %s", c.ID())
 			}
 			localtimePath, err := c.copyTimezoneFile(zonePath)
 			if err != nil {
@@ -1459,16 +1599,94 @@ rootless=%d
 	}
 
 	// Add Secret Mounts
-	secretMounts := subscriptions.MountsWithUIDGID(c.config.MountLabel, c.state.RunDir, c.runtime.config.Containers.DefaultMountsFile, c.state.Mountpoint, c.RootUID(), c.RootGID(), rootless.IsRootless(), false)
-	for _, mount := range secretMounts {
-		if _, ok := c.state.BindMounts[mount.Destination]; !ok {
-			c.state.BindMounts[mount.Destination] = mount.Source
+	// The default mounts of the previous start of the container are
+	// replaced, other bind mounts at the same destination take precedence.
+	previousDefaultMounts := c.state.DefaultMounts
+	c.state.DefaultMounts = nil
+	if !c.config.NoDefaultMounts {
+		secretMounts := subscriptions.MountsWithUIDGID(c.config.MountLabel, c.state.RunDir, c.runtime.config.Containers.DefaultMountsFile, c.state.Mountpoint, c.RootUID(), c.RootGID(), rootless.IsRootless(), false)
+		for _, mount := range secretMounts {
+			if _, ok := c.state.BindMounts[mount.Destination]; !ok || util.StringInSlice(mount.Destination, previousDefaultMounts) {
+				c.state.BindMounts[mount.Destination] = mount.Source
+				c.state.DefaultMounts = append(c.state.DefaultMounts, mount.Destination)
+			}
+		}
+	}
+
+	return nil
+}
+
+// addSecrets adds the secrets of the container to g.  The data of the secrets
+// to mount is written to the run directory of the container, which is usually
+// on a tmpfs, and is bind-mounted read-only into the container.  The
+// environment variables set to secrets are only added to the OCI spec, not to
+// the configuration of the container.
+func (c *Container) addSecrets(g *generate.Generator) error {
+	if len(c.config.Secrets) == 0 && len(c.config.EnvSecrets) == 0 {
+		return nil
+	}
+	manager, err := c.runtime.SecretsManager()
+	if err != nil {
+		return err
+	}
+
+	secretsDir := filepath.Join(c.state.RunDir, "secrets")
+	if len(c.config.Secrets) > 0 {
+		if err := os.MkdirAll(secretsDir, 0700); err != nil {
+			return errors.Wrapf(err, "error creating secrets directory of container %s", c.ID())
+		}
+	}
+	for _, secret := range c.config.Secrets {
+		_, data, err := manager.LookupSecretData(secret.Secret.ID)
+		if err != nil {
+			return errors.Wrapf(err, "error looking up secret %s of container %s", secret.Secret.Name, c.ID())
 		}
+		secretPath := filepath.Join(secretsDir, secret.Secret.ID)
+		if err := writeSecretToPath(secretPath, data, c.config.MountLabel, c.RootUID(), c.RootGID()); err != nil {
+			return errors.Wrapf(err, "error writing secret %s of container %s", secret.Secret.Name, c.ID())
+		}
+		if MountExists(g.Mounts(), secret.Target) {
+			logrus.Infof("User mount overriding secret %s at %q", secret.Secret.Name, secret.Target)
+			continue
+		}
+		g.AddMount(spec.Mount{
+			Type:        "bind",
+			Source:      secretPath,
+			Destination: secret.Target,
+			Options:     []string{"bind", "rprivate", "ro", "nosuid", "noexec", "nodev"},
+		})
 	}
 
+	for name, secret := range c.config.EnvSecrets {
+		_, data, err := manager.LookupSecretData(secret.ID)
+		if err != nil {
+			return errors.Wrapf(err, "error looking up secret %s of container %s", secret.Name, c.ID())
+		}
+		g.AddProcessEnv(name, string(data))
+	}
 	return nil
 }
 
+// writeSecretToPath writes the data of a secret to path, which is readable by
+// everybody in the container and owned by its root user.
+func writeSecretToPath(path string, data []byte, mountLabel string, uid, gid int) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0444)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := f.Chown(uid, gid); err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		return err
+	}
+	return label.Relabel(path, mountLabel, false)
+}
+
 // generateResolvConf generates a containers resolv.conf
 func (c *Container) generateResolvConf() (string, error) {
 	var (
@@ -1551,7 +1769,7 @@ func (c *Container) generateResolvConf() (string, error) {
 		nameservers = resolvconf.GetNameservers(resolv.Content)
 		// slirp4netns has a built in DNS server.
 		if c.config.NetMode.IsSlirp4netns() {
-			nameservers = append([]string{"10.0.2.3"}, nameservers...)
+			nameservers = append([]string{c.slirp4netnsAddresses().dns.String()}, nameservers...)
 		}
 	}
 
@@ -1573,12 +1791,10 @@ func (c *Container) generateResolvConf() (string, error) {
 		options = resolvconf.GetOptions(resolv.Content)
 	}
 
+	// resolv.conf is rewritten in place, so that running containers see
+	// the changes when their networks change.
 	destPath := filepath.Join(c.state.RunDir, "resolv.conf")
 
-	if err := os.Remove(destPath); err != nil && !os.IsNotExist(err) {
-		return "", errors.Wrapf(err, "container %s", c.ID())
-	}
-
 	// Build resolv.conf
 	if _, err = resolvconf.Build(destPath, nameservers, search, options); err != nil {
 		return "", errors.Wrapf(err, "error building resolv.conf for container %s", c.ID())
@@ -1592,17 +1808,76 @@ func (c *Container) generateResolvConf() (string, error) {
 	return filepath.Join(c.state.RunDir, "resolv.conf"), nil
 }
 
+// localhostHosts are the localhost entries of hosts files without a base file.
+const localhostHosts = "127.0.0.1\tlocalhost localhost.localdomain localhost4 localhost4.localdomain4\n::1\tlocalhost localhost.localdomain localhost6 localhost6.localdomain6\n"
+
 // generateHosts creates a containers hosts file
-func (c *Container) generateHosts(path string) (string, error) {
-	orig, err := ioutil.ReadFile(path)
+func (c *Container) generateHosts() (string, error) {
+	hosts, err := c.baseHosts()
 	if err != nil {
 		return "", err
 	}
-	hosts := string(orig)
+	if hosts != "" && !strings.HasSuffix(hosts, "\n") {
+		hosts += "\n"
+	}
 	hosts += c.getHosts()
 	return c.writeStringToRundir("hosts", hosts)
 }
 
+// baseHosts returns the contents of the base of the hosts file of the
+// container, as set by --hosts-file or base_hosts_file in containers.conf.
+// Must be called with the container mounted.
+func (c *Container) baseHosts() (string, error) {
+	file := c.config.BaseHostsFile
+	if file == "" {
+		file = c.runtime.extraConfig.BaseHostsFile
+	}
+
+	switch file {
+	case define.BaseHostsFileNone:
+		return localhostHosts, nil
+	case define.BaseHostsFileImage:
+		path, err := securejoin.SecureJoin(c.state.Mountpoint, "/etc/hosts")
+		if err != nil {
+			return "", errors.Wrapf(err, "error resolving /etc/hosts of the image of container %s", c.ID())
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return localhostHosts, nil
+			}
+			return "", errors.Wrapf(err, "error reading /etc/hosts of the image of container %s", c.ID())
+		}
+		return string(b), nil
+	}
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", errors.Wrapf(err, "error reading base hosts file %s", file)
+	}
+	return string(b), nil
+}
+
+// slirp4netnsHostIP returns the address of the host as seen from containers
+// using slirp4netns.  The gateway of slirp4netns only reaches the host if
+// allow_host_loopback is set, otherwise the first global address of the host
+// is used.
+func (c *Container) slirp4netnsHostIP() string {
+	if netOptions, err := parseSlirp4netnsNetworkOptions(c.slirp4netnsOptions()); err == nil && !netOptions.disableHostLoopback {
+		return c.slirp4netnsAddresses().gateway.String()
+	}
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		logrus.Debugf("Error listing the addresses of the host: %v", err)
+		return ""
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.To4() != nil && ipNet.IP.IsGlobalUnicast() {
+			return ipNet.IP.String()
+		}
+	}
+	return ""
+}
+
 // appendHosts appends a container's config and state pertaining to hosts to a container's
 // local hosts file. netCtr is the container from which the netNS information is
 // taken.
@@ -1621,6 +1896,11 @@ func (c *Container) getHosts() string {
 		for _, host := range c.config.HostAdd {
 			// the host format has already been verified at this point
 			fields := strings.SplitN(host, ":", 2)
+			// host-gateway entries are added with the address of
+			// the host below.
+			if fields[1] == define.HostGateway {
+				continue
+			}
 			hosts += fmt.Sprintf("%s %s\n", fields[1], fields[0])
 		}
 	}
@@ -1631,7 +1911,10 @@ func (c *Container) getHosts() string {
 	if c.Hostname() != "" {
 		if c.config.NetMode.IsSlirp4netns() {
 			// When using slirp4netns, the interface gets a static IP
-			hosts += fmt.Sprintf("# used by slirp4netns\n%s\t%s %s\n", "10.0.2.100", c.Hostname(), c.config.Name)
+			hosts += fmt.Sprintf("# used by slirp4netns\n%s\t%s %s\n", c.slirp4netnsAddresses().container, c.Hostname(), c.config.Name)
+			if hostIP := c.slirp4netnsHostIP(); hostIP != "" {
+				hosts += c.hostGatewayHosts(hostIP)
+			}
 		} else {
 			hasNetNS := false
 			netNone := false
@@ -1648,6 +1931,8 @@ func (c *Container) getHosts() string {
 				// 127.0.1.1 and host's hostname to match Docker
 				osHostname, _ := os.Hostname()
 				hosts += fmt.Sprintf("127.0.1.1 %s %s %s\n", osHostname, c.Hostname(), c.config.Name)
+				// The container shares the network of the host.
+				hosts += c.hostGatewayHosts("127.0.0.1")
 			}
 			if netNone {
 				hosts += fmt.Sprintf("127.0.1.1 %s %s\n", c.Hostname(), c.config.Name)
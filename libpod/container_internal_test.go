@@ -10,8 +10,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/containers/podman/v2/libpod/define"
 	rspec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // hookPath is the path to an example hook executable.
@@ -75,6 +77,106 @@ func TestPostDeleteHooks(t *testing.T) {
 	}
 }
 
+// getDeferredSavesTestContainer returns a configured container added to an
+// empty BoltDB state, whose run directory is the directory of the state.
+func getDeferredSavesTestContainer(t *testing.T) (*Container, State, string) {
+	state, path, manager, err := getEmptyBoltState()
+	require.NoError(t, err)
+	ctr, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	ctr.state = &ContainerState{
+		State:  define.ContainerStateConfigured,
+		RunDir: path,
+	}
+	require.NoError(t, state.AddContainer(ctr))
+	ctr.runtime.state = state
+	return ctr, state, path
+}
+
+// getDBState returns the state of the container in the database.
+func getDBState(t *testing.T, state State, id string) *ContainerState {
+	dbCtr, err := state.Container(id)
+	require.NoError(t, err)
+	require.NoError(t, state.UpdateContainer(dbCtr))
+	return dbCtr.state
+}
+
+func TestWithDeferredSaves(t *testing.T) {
+	ctr, state, path := getDeferredSavesTestContainer(t)
+	defer os.RemoveAll(path)
+	defer state.Close()
+
+	err := ctr.withDeferredSaves(func() error {
+		ctr.state.State = define.ContainerStateCreated
+		require.NoError(t, ctr.save())
+		ctr.state.State = define.ContainerStateRunning
+		ctr.state.PID = 1234
+		require.NoError(t, ctr.save())
+
+		// Other processes do not see the intermediate states.
+		assert.Equal(t, define.ContainerStateConfigured, getDBState(t, state, ctr.ID()).State)
+		_, err := os.Stat(ctr.deferredStatePath())
+		assert.NoError(t, err)
+		return nil
+	})
+	require.NoError(t, err)
+
+	dbState := getDBState(t, state, ctr.ID())
+	assert.Equal(t, define.ContainerStateRunning, dbState.State)
+	assert.Equal(t, 1234, dbState.PID)
+	_, err = os.Stat(ctr.deferredStatePath())
+	assert.True(t, os.IsNotExist(err))
+
+	// The state is written when starting fails too, after the cleanup.
+	err = ctr.withDeferredSaves(func() error {
+		ctr.state.State = define.ContainerStateStopped
+		ctr.state.PID = 0
+		require.NoError(t, ctr.save())
+		return define.ErrInternal
+	})
+	assert.Equal(t, define.ErrInternal, err)
+	dbState = getDBState(t, state, ctr.ID())
+	assert.Equal(t, define.ContainerStateStopped, dbState.State)
+	assert.Zero(t, dbState.PID)
+}
+
+func TestRecoverDeferredState(t *testing.T) {
+	ctr, state, path := getDeferredSavesTestContainer(t)
+	defer os.RemoveAll(path)
+	defer state.Close()
+
+	// The process starting the container dies after it mounted the
+	// storage and started the container, before saving the state.
+	ctr.deferSaves = true
+	ctr.state.Mounted = true
+	ctr.state.Mountpoint = filepath.Join(path, "merged")
+	require.NoError(t, ctr.save())
+	ctr.state.State = define.ContainerStateRunning
+	ctr.state.PID = 1234
+	require.NoError(t, ctr.save())
+
+	// The next process syncing the container recovers the state.
+	next, err := state.Container(ctr.ID())
+	require.NoError(t, err)
+	next.runtime.state = state
+	require.NoError(t, state.UpdateContainer(next))
+	assert.Equal(t, define.ContainerStateConfigured, next.state.State)
+	require.NoError(t, next.recoverDeferredState())
+	assert.Equal(t, define.ContainerStateRunning, next.state.State)
+
+	dbState := getDBState(t, state, ctr.ID())
+	assert.Equal(t, define.ContainerStateRunning, dbState.State)
+	assert.Equal(t, 1234, dbState.PID)
+	assert.True(t, dbState.Mounted)
+	assert.Equal(t, filepath.Join(path, "merged"), dbState.Mountpoint)
+	_, err = os.Stat(ctr.deferredStatePath())
+	assert.True(t, os.IsNotExist(err))
+
+	// Without a deferred state, there is nothing to recover.
+	require.NoError(t, next.recoverDeferredState())
+	assert.Equal(t, define.ContainerStateRunning, next.state.State)
+}
+
 func init() {
 	if runtime.GOOS != "windows" {
 		hookPath = "/bin/sh"
@@ -79,6 +79,9 @@ func (c *Container) readFromLogFile(ctx context.Context, options *logs.LogOption
 				logrus.Error(err)
 				continue
 			}
+			if !options.WantsDevice(nll.Device) {
+				continue
+			}
 			if nll.Partial() {
 				partial += nll.Msg
 				continue
@@ -51,6 +51,18 @@ func (c *Container) readFromJournal(ctx context.Context, options *logs.LogOption
 		Field: "CONTAINER_ID_FULL",
 		Value: c.ID(),
 	})
+	// conmon logs stdout and stderr with different priorities, match
+	// them so the tail only counts the selected streams
+	if options.Stdout != options.Stderr {
+		priority := journaldLogOut
+		if options.Stderr {
+			priority = journaldLogErr
+		}
+		config.Matches = append(config.Matches, journal.Match{
+			Field: "PRIORITY",
+			Value: priority,
+		})
+	}
 	options.WaitGroup.Add(1)
 
 	r, err := journal.NewJournalReader(config)
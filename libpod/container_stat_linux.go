@@ -1,4 +1,6 @@
-package abi
+// +build linux
+
+package libpod
 
 import (
 	"context"
@@ -7,21 +9,17 @@ import (
 	"strings"
 
 	buildahCopiah "github.com/containers/buildah/copier"
-	"github.com/containers/podman/v2/libpod"
 	"github.com/containers/podman/v2/pkg/copy"
-	"github.com/containers/podman/v2/pkg/domain/entities"
 	securejoin "github.com/cyphar/filepath-securejoin"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
-func (ic *ContainerEngine) containerStat(container *libpod.Container, containerPath string) (*entities.ContainerStatReport, string, string, error) {
-	containerMountPoint, err := container.Mount()
-	if err != nil {
-		return nil, "", "", err
-	}
-
+// stat stats containerPath on the container mounted at containerMountPoint.
+// Besides the file info, it returns the resolved root and path which may point
+// into a volume or bind mount.
+func (c *Container) stat(ctx context.Context, containerMountPoint string, containerPath string) (*copy.FileInfo, string, string, error) {
 	// Make sure that "/" copies the *contents* of the mount point and not
 	// the directory.
 	if containerPath == "/" {
@@ -30,7 +28,7 @@ func (ic *ContainerEngine) containerStat(container *libpod.Container, containerP
 
 	// Now resolve the container's path.  It may hit a volume, it may hit a
 	// bind mount, it may be relative.
-	resolvedRoot, resolvedContainerPath, err := resolveContainerPaths(container, containerMountPoint, containerPath)
+	resolvedRoot, resolvedContainerPath, err := c.resolvePath(containerMountPoint, containerPath)
 	if err != nil {
 		return nil, "", "", err
 	}
@@ -85,27 +83,12 @@ func (ic *ContainerEngine) containerStat(container *libpod.Container, containerP
 		LinkTarget: absContainerPath,
 	}
 
-	return &entities.ContainerStatReport{FileInfo: info}, resolvedRoot, resolvedContainerPath, statInfoErr
+	return &info, resolvedRoot, resolvedContainerPath, statInfoErr
 }
 
-func (ic *ContainerEngine) ContainerStat(ctx context.Context, nameOrID string, containerPath string) (*entities.ContainerStatReport, error) {
-	container, err := ic.Libpod.LookupContainer(nameOrID)
-	if err != nil {
-		return nil, err
-	}
-
-	defer func() {
-		if err := container.Unmount(false); err != nil {
-			logrus.Errorf("Error unmounting container: %v", err)
-		}
-	}()
-
-	statReport, _, _, err := ic.containerStat(container, containerPath)
-	return statReport, err
-}
 
-// resolveContainerPaths resolves the container's mount point and the container
-// path as specified by the user.  Both may resolve to paths outside of the
+// resolvePath resolves the container's mount point and the container path as
+// specified by the user.  Both may resolve to paths outside of the
 // container's mount point when the container path hits a volume or bind mount.
 //
 // NOTE: We must take volumes and bind mounts into account as, regrettably, we
@@ -113,7 +96,7 @@ func (ic *ContainerEngine) ContainerStat(ctx context.Context, nameOrID string, c
 // mounts are not present.  For running containers, the runtime (e.g., runc or
 // crun) takes care of these mounts.  For stopped ones, we need to do quite
 // some dance, as done below.
-func resolveContainerPaths(container *libpod.Container, mountPoint string, containerPath string) (string, string, error) {
+func (c *Container) resolvePath(mountPoint string, containerPath string) (string, string, error) {
 	// Let's first make sure we have a path relative to the mount point.
 	pathRelativeToContainerMountPoint := containerPath
 	if !filepath.IsAbs(containerPath) {
@@ -121,7 +104,7 @@ func resolveContainerPaths(container *libpod.Container, mountPoint string, conta
 		// container's working dir.  To be extra careful, let's first
 		// join the working dir with "/", and the add the containerPath
 		// to it.
-		pathRelativeToContainerMountPoint = filepath.Join(filepath.Join("/", container.WorkingDir()), containerPath)
+		pathRelativeToContainerMountPoint = filepath.Join(filepath.Join("/", c.WorkingDir()), containerPath)
 	}
 	resolvedPathOnTheContainerMountPoint := filepath.Join(mountPoint, pathRelativeToContainerMountPoint)
 	pathRelativeToContainerMountPoint = strings.TrimPrefix(pathRelativeToContainerMountPoint, mountPoint)
@@ -138,7 +121,7 @@ func resolveContainerPaths(container *libpod.Container, mountPoint string, conta
 
 	searchPath := pathRelativeToContainerMountPoint
 	for {
-		volume, err := findVolume(container, searchPath)
+		volume, err := findVolume(c, searchPath)
 		if err != nil {
 			return "", "", err
 		}
@@ -156,7 +139,7 @@ func resolveContainerPaths(container *libpod.Container, mountPoint string, conta
 			return volume.MountPoint(), absolutePathOnTheVolumeMount, nil
 		}
 
-		if mount := findBindMount(container, searchPath); mount != nil {
+		if mount := findBindMount(c, searchPath); mount != nil {
 			logrus.Debugf("Container path %q resolved to bind mount %q:%q on path %q", containerPath, mount.Source, mount.Destination, searchPath)
 			// We found a matching bind mount for searchPath.  We
 			// now need to first find the relative path of our
@@ -186,10 +169,10 @@ func resolveContainerPaths(container *libpod.Container, mountPoint string, conta
 
 // findVolume checks if the specified container path matches a volume inside
 // the container.  It returns a matching volume or nil.
-func findVolume(c *libpod.Container, containerPath string) (*libpod.Volume, error) {
-	runtime := c.Runtime()
+func findVolume(c *Container, containerPath string) (*Volume, error) {
+	runtime := c.runtime
 	cleanedContainerPath := filepath.Clean(containerPath)
-	for _, vol := range c.Config().NamedVolumes {
+	for _, vol := range c.config.NamedVolumes {
 		if cleanedContainerPath == filepath.Clean(vol.Dest) {
 			return runtime.GetVolume(vol.Name)
 		}
@@ -199,9 +182,9 @@ func findVolume(c *libpod.Container, containerPath string) (*libpod.Volume, erro
 
 // findBindMount checks if the specified container path matches a bind mount
 // inside the container.  It returns a matching mount or nil.
-func findBindMount(c *libpod.Container, containerPath string) *specs.Mount {
+func findBindMount(c *Container, containerPath string) *specs.Mount {
 	cleanedPath := filepath.Clean(containerPath)
-	for _, m := range c.Config().Spec.Mounts {
+	for _, m := range c.config.Spec.Mounts {
 		if m.Type != "bind" {
 			continue
 		}
@@ -68,13 +68,20 @@ func (c *Container) validate() error {
 	}
 
 	// Can only set static IP or MAC is creating a network namespace.
-	if !c.config.CreateNetNS && (c.config.StaticIP != nil || c.config.StaticMAC != nil) {
+	if !c.config.CreateNetNS && (c.config.StaticIP != nil || c.config.StaticIPv6 != nil || c.config.StaticMAC != nil ||
+		len(c.config.StaticIPs) > 0 || len(c.config.StaticMACs) > 0) {
 		return errors.Wrapf(define.ErrInvalidArg, "cannot set static IP or MAC address if not creating a network namespace")
 	}
 
-	// Cannot set static IP or MAC if joining >1 CNI network.
-	if len(c.config.Networks) > 1 && (c.config.StaticIP != nil || c.config.StaticMAC != nil) {
-		return errors.Wrapf(define.ErrInvalidArg, "cannot set static IP or MAC address if joining more than one CNI network")
+	// Cannot set a single static IP if joining >1 CNI network, it must be
+	// set for each network.
+	if len(c.config.Networks) > 1 && (c.config.StaticIP != nil || c.config.StaticIPv6 != nil) {
+		return errors.Wrapf(define.ErrInvalidArg, "cannot set static IP address if joining more than one CNI network, set it for each network instead")
+	}
+
+	// The CNI host-local IPAM plugin accepts a single requested address.
+	if c.config.StaticIP != nil && c.config.StaticIPv6 != nil {
+		return errors.Wrapf(define.ErrInvalidArg, "cannot set both a static IPv4 and a static IPv6 address, only one address can be requested from CNI")
 	}
 
 	// Using image resolv.conf conflicts with various DNS settings.
@@ -88,6 +95,10 @@ func (c *Container) validate() error {
 		return errors.Wrapf(define.ErrInvalidArg, "cannot add to /etc/hosts if using image's /etc/hosts")
 	}
 
+	if c.config.UseImageHosts && c.config.BaseHostsFile != "" {
+		return errors.Wrapf(define.ErrInvalidArg, "cannot set the base hosts file if using image's /etc/hosts")
+	}
+
 	// Check named volume, overlay volume and image volume destination conflist
 	destinations := make(map[string]bool)
 	for _, vol := range c.config.NamedVolumes {
@@ -125,6 +136,27 @@ func (c *Container) validate() error {
 			return errors.Wrapf(define.ErrNoSuchNetwork, "container tried to set network aliases for network %s but is not connected to the network", net)
 		}
 	}
+	for net := range c.config.StaticIPs {
+		if _, ok := ctrNets[net]; !ok {
+			return errors.Wrapf(define.ErrNoSuchNetwork, "container tried to set a static IP address for network %s but is not connected to the network", net)
+		}
+	}
+	for net := range c.config.StaticMACs {
+		if _, ok := ctrNets[net]; !ok {
+			return errors.Wrapf(define.ErrNoSuchNetwork, "container tried to set a static MAC address for network %s but is not connected to the network", net)
+		}
+	}
+
+	// The single static IP and MAC are requested in the first network.
+	if len(c.config.Networks) > 0 {
+		first := c.config.Networks[0]
+		if _, ok := c.config.StaticIPs[first]; ok && (c.config.StaticIP != nil || c.config.StaticIPv6 != nil) {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot set two static IP addresses for network %s", first)
+		}
+		if _, ok := c.config.StaticMACs[first]; ok && c.config.StaticMAC != nil {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot set two static MAC addresses for network %s", first)
+		}
+	}
 
 	return nil
 }
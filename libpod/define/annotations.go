@@ -59,6 +59,11 @@ const (
 	// used in the output of Inspect().
 	InspectAnnotationApparmor = "io.podman.annotations.apparmor"
 
+	// InspectAnnotationPrefix is the prefix of the annotations used by
+	// Inspect.  These annotations are set by podman when a container is
+	// created and cannot be changed afterwards.
+	InspectAnnotationPrefix = "io.podman.annotations."
+
 	// InspectResponseTrue is a boolean True response for an inspect
 	// annotation.
 	InspectResponseTrue = "TRUE"
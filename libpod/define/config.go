@@ -85,5 +85,60 @@ const (
 	SdNotifyModeIgnore    = "ignore"
 )
 
+// Types of init containers, set with --init-ctr
+const (
+	// AlwaysInitContainer is an init container that is run every time
+	// the pod is started.
+	AlwaysInitContainer = "always"
+	// OneShotInitContainer is an init container that is run only the first
+	// time the pod is started and removed afterwards.
+	OneShotInitContainer = "once"
+)
+
+// Owner modes, set with owner_mode in containers.conf
+const (
+	// OwnerModeShared records the owner of containers and pods but does
+	// not restrict access.
+	OwnerModeShared = "shared"
+	// OwnerModeFilter lists only the containers and pods of the calling
+	// user by default.
+	OwnerModeFilter = "filter"
+	// OwnerModeEnforce hides the containers and pods of other users and
+	// refuses to remove them.
+	OwnerModeEnforce = "enforce"
+)
+
+// Image platform policies, set with image_platform_policy in containers.conf
+const (
+	// ImagePlatformPolicyIgnore runs containers from images of another
+	// platform without notice.
+	ImagePlatformPolicyIgnore = "ignore"
+	// ImagePlatformPolicyWarn warns when creating containers from images
+	// of another platform.
+	ImagePlatformPolicyWarn = "warn"
+	// ImagePlatformPolicyError refuses to create containers from images
+	// of another platform.
+	ImagePlatformPolicyError = "error"
+)
+
+// Base hosts files, set with base_hosts_file in containers.conf or
+// --hosts-file.  Any other value is the path of a file on the host.
+const (
+	// BaseHostsFileImage uses the /etc/hosts file of the image as the
+	// base of the /etc/hosts file of containers.
+	BaseHostsFileImage = "image"
+	// BaseHostsFileNone uses no base file, only localhost and the entries
+	// added by Podman are in the /etc/hosts file of containers.
+	BaseHostsFileNone = "none"
+)
+
+// HostContainersInternal is the name resolving to the host in the /etc/hosts
+// file of containers.
+const HostContainersInternal = "host.containers.internal"
+
+// HostGateway is the special IP of --add-host resolving to the address of the
+// host as seen from the container.
+const HostGateway = "host-gateway"
+
 // DefaultRlimitValue is the value set by default for nofile and nproc
 const RLimitDefaultValue = uint64(1048576)
@@ -49,6 +49,11 @@ type InspectContainerConfig struct {
 	Annotations map[string]string `json:"Annotations"`
 	// Container stop signal
 	StopSignal uint `json:"StopSignal"`
+	// Container stop signal escalation chain, if one was set
+	StopSignals []StopStep `json:"StopSignals,omitempty"`
+	// Maximum number of seconds the container may run before it is
+	// killed, 0 if unlimited
+	Timeout uint `json:"Timeout"`
 	// Configured healthcheck for the container
 	Healthcheck *manifest.Schema2HealthConfig `json:"Healthcheck,omitempty"`
 	// CreateCommand is the full command plus arguments of the process the
@@ -63,6 +68,23 @@ type InspectContainerConfig struct {
 	SystemdMode bool `json:"SystemdMode,omitempty"`
 	// Umask is the umask inside the container.
 	Umask string `json:"Umask,omitempty"`
+	// Secrets are the secrets made available to the container.
+	Secrets []*InspectSecret `json:"Secrets,omitempty"`
+}
+
+// InspectSecret contains information on a secret made available to a
+// container.
+type InspectSecret struct {
+	// Name is the name of the secret.
+	Name string `json:"Name"`
+	// ID is the ID of the secret.
+	ID string `json:"ID"`
+	// Type is "mount" for secrets mounted into the container and "env"
+	// for secrets exposed as environment variables.
+	Type string `json:"Type"`
+	// Target is the path the secret is mounted at or the name of the
+	// environment variable set to it.
+	Target string `json:"Target"`
 }
 
 // InspectRestartPolicy holds information about the container's restart policy.
@@ -154,7 +176,7 @@ type InspectHostPort struct {
 // included, and tmpfs volumes are not included even if the user specified them.
 type InspectMount struct {
 	// Whether the mount is a volume or bind mount. Allowed values are
-	// "volume" and "bind".
+	// "volume", "bind", "image" and "overlay".
 	Type string `json:"Type"`
 	// The name of the volume. Empty for bind mounts.
 	Name string `json:"Name,omitempty"`
@@ -184,20 +206,23 @@ type InspectMount struct {
 // Docker, but here we see more fields that are unused (nonsensical in the
 // context of Libpod).
 type InspectContainerState struct {
-	OciVersion  string             `json:"OciVersion"`
-	Status      string             `json:"Status"`
-	Running     bool               `json:"Running"`
-	Paused      bool               `json:"Paused"`
-	Restarting  bool               `json:"Restarting"` // TODO
-	OOMKilled   bool               `json:"OOMKilled"`
-	Dead        bool               `json:"Dead"`
-	Pid         int                `json:"Pid"`
-	ConmonPid   int                `json:"ConmonPid,omitempty"`
-	ExitCode    int32              `json:"ExitCode"`
-	Error       string             `json:"Error"` // TODO
-	StartedAt   time.Time          `json:"StartedAt"`
-	FinishedAt  time.Time          `json:"FinishedAt"`
-	Healthcheck HealthCheckResults `json:"Healthcheck,omitempty"`
+	OciVersion     string             `json:"OciVersion"`
+	Status         string             `json:"Status"`
+	Running        bool               `json:"Running"`
+	Paused         bool               `json:"Paused"`
+	Restarting     bool               `json:"Restarting"`
+	OOMKilled      bool               `json:"OOMKilled"`
+	OOMKills       uint64             `json:"OOMKills"`
+	TimedOut       bool               `json:"TimedOut"`
+	ExitedByReboot bool               `json:"ExitedByReboot,omitempty"`
+	Dead           bool               `json:"Dead"`
+	Pid            int                `json:"Pid"`
+	ConmonPid      int                `json:"ConmonPid,omitempty"`
+	ExitCode       int32              `json:"ExitCode"`
+	Error          string             `json:"Error"` // TODO
+	StartedAt      time.Time          `json:"StartedAt"`
+	FinishedAt     time.Time          `json:"FinishedAt"`
+	Healthcheck    HealthCheckResults `json:"Healthcheck,omitempty"`
 }
 
 // HealthCheckResults describes the results/logs from a healthcheck
@@ -387,9 +412,8 @@ type InspectContainerHostConfig struct {
 	// support non-OCI runtimes.
 	Runtime string `json:"Runtime"`
 	// ConsoleSize is an array of 2 integers showing the size of the
-	// container's console.
+	// container's console, as height and width.
 	// It is only set if the container is creating a terminal.
-	// TODO.
 	ConsoleSize []uint `json:"ConsoleSize"`
 	// Isolation is presently unused and provided solely for Docker
 	// compatibility.
@@ -626,6 +650,7 @@ type InspectContainerData struct {
 	OCIConfigPath   string                      `json:"OCIConfigPath,omitempty"`
 	OCIRuntime      string                      `json:"OCIRuntime,omitempty"`
 	ConmonPidFile   string                      `json:"ConmonPidFile"`
+	PidFile         string                      `json:"PidFile"`
 	Name            string                      `json:"Name"`
 	RestartCount    int32                       `json:"RestartCount"`
 	Driver          string                      `json:"Driver"`
@@ -644,6 +669,7 @@ type InspectContainerData struct {
 	ExitCommand     []string                    `json:"ExitCommand"`
 	Namespace       string                      `json:"Namespace"`
 	IsInfra         bool                        `json:"IsInfra"`
+	Owner           string                      `json:"Owner,omitempty"`
 	Config          *InspectContainerConfig     `json:"Config"`
 	HostConfig      *InspectContainerHostConfig `json:"HostConfig"`
 }
@@ -79,6 +79,24 @@ func StringToContainerStatus(status string) (ContainerStatus, error) {
 	}
 }
 
+// ContainerConditionRemoved is a wait condition which is met once the
+// container has been removed.
+const ContainerConditionRemoved = "removed"
+
+// ValidateWaitCondition returns an error if the given condition cannot be
+// waited for.  Valid conditions are the container states, the health states
+// HealthCheckHealthy and HealthCheckUnhealthy, and ContainerConditionRemoved.
+func ValidateWaitCondition(condition string) error {
+	switch condition {
+	case HealthCheckHealthy, HealthCheckUnhealthy, ContainerConditionRemoved:
+		return nil
+	}
+	if _, err := StringToContainerStatus(condition); err != nil {
+		return errors.Wrapf(ErrInvalidArg, "unknown wait condition: %s", condition)
+	}
+	return nil
+}
+
 // ContainerExecStatus is the status of an exec session within a container.
 type ContainerExecStatus int
 
@@ -130,4 +148,5 @@ type ContainerStats struct {
 	BlockInput    uint64
 	BlockOutput   uint64
 	PIDs          uint64
+	OOMKills      uint64
 }
@@ -17,6 +17,10 @@ var (
 	// ErrMultipleImages found multiple name and tag matches
 	ErrMultipleImages = errors.New("found multiple name and tag matches")
 
+	// ErrPlatformMismatch indicates that an image was built for another
+	// OS, architecture or variant than requested
+	ErrPlatformMismatch = errors.New("image does not match the requested platform")
+
 	// ErrNoSuchTag indicates the requested image tag does not exist
 	ErrNoSuchTag = errors.New("no such tag")
 
@@ -39,6 +43,10 @@ var (
 	ErrCtrExists = errors.New("container already exists")
 	// ErrPodExists indicates a pod with the same name or ID already exists
 	ErrPodExists = errors.New("pod already exists")
+	// ErrNameReserved indicates a name is already reserved
+	ErrNameReserved = errors.New("name is already reserved")
+	// ErrNameNotReserved indicates a name is not reserved
+	ErrNameNotReserved = errors.New("name is not reserved")
 	// ErrImageExists indicates an image with the same ID already exists
 	ErrImageExists = errors.New("image already exists")
 	// ErrVolumeExists indicates a volume with the same name already exists
@@ -84,6 +92,11 @@ var (
 	// successful, and some containers within the pod failed.
 	ErrPodPartialFail = errors.New("some containers failed")
 
+	// ErrPodNamespaceHolderExists indicates that a container created to
+	// hold the namespaces of a pod without infra container cannot be added
+	// to the pod, as another container holds them by now.
+	ErrPodNamespaceHolderExists = errors.New("pod namespaces are already held by another container")
+
 	// ErrDetach indicates that an attach session was manually detached by
 	// the user.
 	ErrDetach = errors.New("detached from container")
@@ -110,6 +123,10 @@ var (
 	// ErrCtrStopped indicates that the requested container is not running
 	// and the requested operation cannot be performed until it is started
 	ErrCtrStopped = errors.New("container is stopped")
+	// ErrCtrRequired indicates that the requested container is required by
+	// running containers and the requested operation cannot be performed
+	// until they are stopped
+	ErrCtrRequired = errors.New("container is required by running containers")
 
 	// ErrCtrRemoved indicates that the container has already been removed
 	// and no further operations can be performed on it
@@ -181,4 +198,8 @@ var (
 
 	// ErrNoNetwork indicates that a container has no net namespace, like network=none
 	ErrNoNetwork = errors.New("container has no network namespace")
+
+	// ErrNotOwner indicates that a container or pod is owned by another
+	// user and the owner mode does not allow the operation.
+	ErrNotOwner = errors.New("owned by another user")
 )
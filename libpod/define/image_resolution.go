@@ -0,0 +1,52 @@
+package define
+
+// Methods used to look up a local image while resolving its name.
+const (
+	// ImageResolveTransport strips the transport of the name.
+	ImageResolveTransport = "transport"
+	// ImageResolveIDOrName looks up a full name, an ID or a short ID.
+	ImageResolveIDOrName = "id-or-name"
+	// ImageResolveStorageReference looks up a containers-storage reference.
+	ImageResolveStorageReference = "storage-reference"
+	// ImageResolveShortName looks up a candidate of the short-name expansion
+	// configured in registries.conf.
+	ImageResolveShortName = "short-name"
+	// ImageResolveDockerNormalization looks up the name normalized to
+	// docker.io.
+	ImageResolveDockerNormalization = "docker.io-normalization"
+	// ImageResolveRepoTag looks up the name in the repotags of all images.
+	ImageResolveRepoTag = "repotag"
+)
+
+// ImageResolutionStep is a single lookup made while resolving an image name.
+type ImageResolutionStep struct {
+	// Method is how the image was looked up, e.g. ImageResolveShortName.
+	Method string
+	// Name is the name looked up.
+	Name string
+	// Found is set if the lookup found an image.
+	Found bool
+	// ImageID is the ID of the image found.
+	ImageID string `json:",omitempty"`
+	// ReadOnly is set if the image found is in a read-only store.
+	ReadOnly bool `json:",omitempty"`
+	// Note explains the outcome of the step, e.g. why a match was chosen
+	// or rejected.
+	Note string `json:",omitempty"`
+}
+
+// ImageResolutionTrace records how an image name was resolved to a local image.
+type ImageResolutionTrace struct {
+	// Input is the name which was resolved.
+	Input string
+	// Steps are the lookups in the order they were made.
+	Steps []ImageResolutionStep
+	// ResolvedName is the name the image was found with.
+	ResolvedName string `json:",omitempty"`
+	// ImageID is the ID of the image chosen.
+	ImageID string `json:",omitempty"`
+	// Digest is the digest of the image chosen.
+	Digest string `json:",omitempty"`
+	// Error is set if the name could not be resolved.
+	Error string `json:",omitempty"`
+}
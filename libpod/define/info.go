@@ -37,6 +37,7 @@ type HostInfo struct {
 	MemTotal       int64                  `json:"memTotal"`
 	OCIRuntime     *OCIRuntimeInfo        `json:"ociRuntime"`
 	OS             string                 `json:"os"`
+	PingGroupRange string                 `json:"pingGroupRange,omitempty"`
 	RemoteSocket   *RemoteSocket          `json:"remoteSocket,omitempty"`
 	RuntimeInfo    map[string]interface{} `json:"runtimeInfo,omitempty"`
 	Security       SecurityInfo           `json:"security"`
@@ -59,6 +60,9 @@ type SlirpInfo struct {
 	Executable string `json:"executable"`
 	Package    string `json:"package"`
 	Version    string `json:"version"`
+	// PingWarning explains why ping from containers cannot reach other
+	// hosts, if it cannot.
+	PingWarning string `json:"pingWarning,omitempty"`
 }
 
 // IDMappings describe the GID and UID mappings
@@ -98,9 +102,11 @@ type StoreInfo struct {
 	GraphDriverName string                 `json:"graphDriverName"`
 	GraphOptions    map[string]interface{} `json:"graphOptions"`
 	GraphRoot       string                 `json:"graphRoot"`
+	GraphRootFsType string                 `json:"graphRootFsType,omitempty"`
 	GraphStatus     map[string]string      `json:"graphStatus"`
 	ImageStore      ImageStore             `json:"imageStore"`
 	RunRoot         string                 `json:"runRoot"`
+	StorageFallback string                 `json:"storageFallback,omitempty"`
 	VolumePath      string                 `json:"volumePath"`
 }
 
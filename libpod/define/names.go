@@ -0,0 +1,21 @@
+package define
+
+import "time"
+
+// ProjectLabel is the label of containers and pods whose value is used as
+// the project by the name template set in containers.conf.
+const ProjectLabel = "io.podman.project"
+
+// NameReservation describes a name reserved for a container or pod to be
+// created later.  Names of containers and pods that are generated never use
+// a reserved name.
+type NameReservation struct {
+	// Name is the reserved name.
+	Name string `json:"Name"`
+	// Owner is the user who reserved the name.
+	Owner string `json:"Owner,omitempty"`
+	// Created is the time the name was reserved.
+	Created time.Time `json:"Created"`
+	// Expires is the time the reservation expires, zero if it does not.
+	Expires time.Time `json:"Expires,omitempty"`
+}
@@ -19,6 +19,8 @@ type InspectPodData struct {
 	// CreateCommand is the full command plus arguments of the process the
 	// container has been created with.
 	CreateCommand []string `json:"CreateCommand,omitempty"`
+	// Owner is the user who created the pod.
+	Owner string `json:"Owner,omitempty"`
 	// State represents the current state of the pod.
 	State string `json:"State"`
 	// Hostname is the hostname that the pod will set.
@@ -33,6 +35,16 @@ type InspectPodData struct {
 	CgroupParent string `json:"CgroupParent,omitempty"`
 	// CgroupPath is the path to the pod's CGroup.
 	CgroupPath string `json:"CgroupPath,omitempty"`
+	// CPUPeriod is the CPU CFS period of the pod's CGroup, in
+	// microseconds.
+	CPUPeriod uint64 `json:"CPUPeriod,omitempty"`
+	// CPUQuota is the CPU CFS quota of the pod's CGroup, in microseconds
+	// per CPUPeriod.
+	CPUQuota int64 `json:"CPUQuota,omitempty"`
+	// MemoryLimit is the memory limit of the pod's CGroup, in bytes.
+	MemoryLimit int64 `json:"MemoryLimit,omitempty"`
+	// PidsLimit is the maximum number of processes in the pod's CGroup.
+	PidsLimit int64 `json:"PidsLimit,omitempty"`
 	// CreateInfra is whether this pod will create an infra container to
 	// share namespaces.
 	CreateInfra bool
@@ -42,8 +54,13 @@ type InspectPodData struct {
 	// InfraConfig is the configuration of the infra container of the pod.
 	// Will only be set if CreateInfra is true.
 	InfraConfig *InspectPodInfraConfig `json:"InfraConfig,omitempty"`
+	// NamespaceHolderID is the ID of the container holding the namespaces
+	// shared by a pod without infra container, the first container created
+	// in the pod.
+	NamespaceHolderID string `json:"NamespaceHolderID,omitempty"`
 	// SharedNamespaces contains a list of namespaces that will be shared by
-	// containers within the pod. Can only be set if CreateInfra is true.
+	// containers within the pod. If CreateInfra is false, the namespaces
+	// are held by the first container created in the pod.
 	SharedNamespaces []string `json:"SharedNamespaces,omitempty"`
 	// NumContainers is the number of containers in the pod, including the
 	// infra container.
@@ -65,6 +82,9 @@ type InspectPodInfraConfig struct {
 	// StaticIP is a static IPv4 that will be assigned to the infra
 	// container and then used by the pod.
 	StaticIP net.IP
+	// StaticIPv6 is a static IPv6 address that will be assigned to the
+	// infra container and then used by the pod.
+	StaticIPv6 net.IP
 	// StaticMAC is a static MAC address that will be assigned to the infra
 	// container and then used by the pod.
 	StaticMAC string
@@ -91,6 +111,16 @@ type InspectPodInfraConfig struct {
 	Networks []string
 	// NetworkOptions are additional options for each network
 	NetworkOptions map[string][]string
+	// Image is the image of the infra container, if not the default set
+	// in containers.conf.
+	Image string `json:"Image,omitempty"`
+	// Command is the command of the infra container, if not the default
+	// set in containers.conf.
+	Command []string `json:"Command,omitempty"`
+	// Userns is the user namespace mode of the infra container, which is
+	// joined by the containers of the pod if the pod shares its user
+	// namespace.
+	Userns string `json:"Userns,omitempty"`
 }
 
 // InspectPodContainerInfo contains information on a container in a pod.
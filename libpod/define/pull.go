@@ -0,0 +1,12 @@
+package define
+
+// InterruptedPullsPruneReport describes what was removed of the pulls which
+// were interrupted.
+type InterruptedPullsPruneReport struct {
+	// Pulls are the names of the images of the interrupted pulls.
+	Pulls []string
+	// Layers are the IDs of the layers committed by the interrupted pulls.
+	Layers []string
+	// Size is the disk space reclaimed.
+	Size int64
+}
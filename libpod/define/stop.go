@@ -0,0 +1,13 @@
+package define
+
+// StopStep is a single step of the signal escalation chain used to stop a
+// container.  Signal is sent to the container, which is then given Timeout
+// seconds to exit before the next step is taken.  Once all steps have been
+// taken, the container is killed with SIGKILL.
+type StopStep struct {
+	// Signal is the signal to send.  If 0, SIGTERM is used.
+	Signal uint `json:"signal"`
+	// Timeout is the number of seconds to wait for the container to exit
+	// after sending Signal.  If 0, the step is skipped.
+	Timeout uint `json:"timeout"`
+}
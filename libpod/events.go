@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/containers/podman/v2/libpod/events"
+	"github.com/containers/storage/pkg/stringid"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
@@ -19,9 +20,23 @@ func (r *Runtime) newEventer() (events.Eventer, error) {
 	return events.NewEventer(options)
 }
 
+// StartEventCorrelation returns a copy of ctx carrying a new correlation ID,
+// so the events of the compound operation ctx is passed to, such as play kube,
+// can be grouped by consumers.  If ctx is already part of a compound operation,
+// it is returned unchanged and the operations share the correlation ID.
+// Returns the context and the correlation ID.
+func (r *Runtime) StartEventCorrelation(ctx context.Context) (context.Context, string) {
+	if id := events.CorrelationID(ctx); id != "" {
+		return ctx, id
+	}
+	id := stringid.GenerateNonCryptoID()
+	return events.WithCorrelationID(ctx, id), id
+}
+
 // newContainerEvent creates a new event based on a container
-func (c *Container) newContainerEvent(status events.Status) {
+func (c *Container) newContainerEvent(ctx context.Context, status events.Status) {
 	e := events.NewEvent(status)
+	e.CorrelationID = events.CorrelationID(ctx)
 	e.ID = c.ID()
 	e.Name = c.Name()
 	e.Image = c.config.RootfsImageName
@@ -37,6 +52,12 @@ func (c *Container) newContainerEvent(status events.Status) {
 	}
 }
 
+// NewContainerEvent creates a new event based on a container for events which
+// are not caused by libpod itself, such as auto updates.
+func (c *Container) NewContainerEvent(ctx context.Context, status events.Status) {
+	c.newContainerEvent(ctx, status)
+}
+
 // newContainerExitedEvent creates a new event for a container's death
 func (c *Container) newContainerExitedEvent(exitCode int32) {
 	e := events.NewEvent(events.Exited)
@@ -50,6 +71,24 @@ func (c *Container) newContainerExitedEvent(exitCode int32) {
 	}
 }
 
+// newContainerRebootEvent creates a new event for a container exited by a
+// reboot, with the unknown exit code of its state
+func (c *Container) newContainerRebootEvent() {
+	e := events.NewEvent(events.Reboot)
+	e.ID = c.ID()
+	e.Name = c.Name()
+	e.Image = c.config.RootfsImageName
+	e.Type = events.Container
+	e.ContainerExitCode = int(c.state.ExitCode)
+	e.Details = events.Details{
+		ID:         e.ID,
+		Attributes: c.Labels(),
+	}
+	if err := c.runtime.eventer.Write(e); err != nil {
+		logrus.Errorf("unable to write container event: %q", err)
+	}
+}
+
 // netNetworkEvent creates a new event based on a network connect/disconnect
 func (c *Container) newNetworkEvent(status events.Status, netName string) {
 	e := events.NewEvent(status)
@@ -63,8 +102,9 @@ func (c *Container) newNetworkEvent(status events.Status, netName string) {
 }
 
 // newPodEvent creates a new event for a libpod pod
-func (p *Pod) newPodEvent(status events.Status) {
+func (p *Pod) newPodEvent(ctx context.Context, status events.Status) {
 	e := events.NewEvent(status)
+	e.CorrelationID = events.CorrelationID(ctx)
 	e.ID = p.ID()
 	e.Name = p.Name()
 	e.Type = events.Pod
@@ -84,8 +124,9 @@ func (r *Runtime) newSystemEvent(status events.Status) {
 }
 
 // newVolumeEvent creates a new event for a libpod volume
-func (v *Volume) newVolumeEvent(status events.Status) {
+func (v *Volume) newVolumeEvent(ctx context.Context, status events.Status) {
 	e := events.NewEvent(status)
+	e.CorrelationID = events.CorrelationID(ctx)
 	e.Name = v.Name()
 	e.Type = events.Volume
 	if err := v.runtime.eventer.Write(e); err != nil {
@@ -38,6 +38,9 @@ type Event struct {
 	Time time.Time
 	// Type of event that occurred
 	Type Type
+	// CorrelationID is shared by all the events of a compound operation,
+	// such as play kube, so they can be grouped
+	CorrelationID string `json:"correlationID,omitempty"`
 
 	Details
 }
@@ -111,8 +114,15 @@ const (
 
 	// Attach ...
 	Attach Status = "attach"
-	// AutoUpdate ...
+	// AutoUpdate indicates that the image of a container was updated and
+	// its systemd unit is restarted.
 	AutoUpdate Status = "auto-update"
+	// AutoUpdateFailure indicates that a container restarted by an auto
+	// update failed to become healthy.
+	AutoUpdateFailure Status = "auto-update-failure"
+	// AutoUpdateSuccess indicates that a container restarted by an auto
+	// update is healthy.
+	AutoUpdateSuccess Status = "auto-update-success"
 	// Build ...
 	Build Status = "build"
 	// Checkpoint ...
@@ -147,6 +157,8 @@ const (
 	NetworkConnect Status = "connect"
 	// NetworkDisconnect
 	NetworkDisconnect Status = "disconnect"
+	// OOM indicates that the OOM killer killed a process of a container.
+	OOM Status = "oom"
 	// Pause ...
 	Pause Status = "pause"
 	// Prune ...
@@ -155,11 +167,16 @@ const (
 	Pull Status = "pull"
 	// Push ...
 	Push Status = "push"
+	// Reboot indicates that a container was running when the system
+	// rebooted, and was marked as exited when the state was refreshed.
+	Reboot Status = "reboot"
 	// Refresh indicates that the system refreshed the state after a
 	// reboot.
 	Refresh Status = "refresh"
 	// Remove ...
 	Remove Status = "remove"
+	// Rename indicates that a container or a pod was renamed.
+	Rename Status = "rename"
 	// Renumber indicates that lock numbers were reallocated at user
 	// request.
 	Renumber Status = "renumber"
@@ -167,6 +184,9 @@ const (
 	Restart Status = "restart"
 	// Restore ...
 	Restore Status = "restore"
+	// Rollback indicates that a failed auto update of a container was
+	// rolled back to the previous image.
+	Rollback Status = "rollback"
 	// Save ...
 	Save Status = "save"
 	// Start ...
@@ -177,12 +197,18 @@ const (
 	Sync Status = "sync"
 	// Tag ...
 	Tag Status = "tag"
+	// Timeout indicates that a container was killed as it ran longer
+	// than its timeout.
+	Timeout Status = "timeout"
 	// Unmount ...
 	Unmount Status = "unmount"
 	// Unpause ...
 	Unpause Status = "unpause"
 	// Untag ...
 	Untag Status = "untag"
+	// Update indicates that the resource limits, labels or annotations
+	// of a container were updated.
+	Update Status = "update"
 )
 
 // EventFilter for filtering events
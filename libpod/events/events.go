@@ -1,6 +1,7 @@
 package events
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -51,6 +52,27 @@ func NewEvent(status Status) Event {
 	}
 }
 
+// correlationKey is the context key of the correlation ID of a compound
+// operation.
+type correlationKey struct{}
+
+// WithCorrelationID returns a copy of ctx carrying the correlation ID of a
+// compound operation.  The events written for the operations ctx is passed to
+// are tagged with it.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationKey{}, id)
+}
+
+// CorrelationID returns the correlation ID carried by ctx, or an empty string
+// if ctx is not part of a compound operation.
+func CorrelationID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(correlationKey{}).(string)
+	return id
+}
+
 // Recycle checks if the event log has reach a limit and if so
 // renames the current log and starts a new one.  The remove bool
 // indicates the old log file should be deleted.
@@ -86,6 +108,9 @@ func (e *Event) ToHumanReadable() string {
 	case Volume:
 		humanFormat = fmt.Sprintf("%s %s %s %s", e.Time, e.Type, e.Status, e.Name)
 	}
+	if e.CorrelationID != "" {
+		humanFormat += fmt.Sprintf(" (correlation=%s)", e.CorrelationID)
+	}
 	return humanFormat
 }
 
@@ -138,6 +163,12 @@ func StringToStatus(name string) (Status, error) {
 	switch name {
 	case Attach.String():
 		return Attach, nil
+	case AutoUpdate.String():
+		return AutoUpdate, nil
+	case AutoUpdateFailure.String():
+		return AutoUpdateFailure, nil
+	case AutoUpdateSuccess.String():
+		return AutoUpdateSuccess, nil
 	case Build.String():
 		return Build, nil
 	case Checkpoint.String():
@@ -170,6 +201,8 @@ func StringToStatus(name string) (Status, error) {
 		return NetworkConnect, nil
 	case NetworkDisconnect.String():
 		return NetworkDisconnect, nil
+	case OOM.String():
+		return OOM, nil
 	case Pause.String():
 		return Pause, nil
 	case Prune.String():
@@ -178,16 +211,22 @@ func StringToStatus(name string) (Status, error) {
 		return Pull, nil
 	case Push.String():
 		return Push, nil
+	case Reboot.String():
+		return Reboot, nil
 	case Refresh.String():
 		return Refresh, nil
 	case Remove.String():
 		return Remove, nil
+	case Rename.String():
+		return Rename, nil
 	case Renumber.String():
 		return Renumber, nil
 	case Restart.String():
 		return Restart, nil
 	case Restore.String():
 		return Restore, nil
+	case Rollback.String():
+		return Rollback, nil
 	case Save.String():
 		return Save, nil
 	case Start.String():
@@ -198,12 +237,16 @@ func StringToStatus(name string) (Status, error) {
 		return Sync, nil
 	case Tag.String():
 		return Tag, nil
+	case Timeout.String():
+		return Timeout, nil
 	case Unmount.String():
 		return Unmount, nil
 	case Unpause.String():
 		return Unpause, nil
 	case Untag.String():
 		return Untag, nil
+	case Update.String():
+		return Update, nil
 	}
 	return "", errors.Errorf("unknown event status %q", name)
 }
@@ -55,6 +55,10 @@ func generateEventFilter(filter, filterValue string) (func(e *Event) bool, error
 		return func(e *Event) bool {
 			return string(e.Type) == filterValue
 		}, nil
+	case "CORRELATION":
+		return func(e *Event) bool {
+			return e.CorrelationID == filterValue
+		}, nil
 
 	case "LABEL":
 		return func(e *Event) bool {
@@ -35,6 +35,9 @@ func (e EventJournalD) Write(ee Event) error {
 	m["PODMAN_EVENT"] = ee.Status.String()
 	m["PODMAN_TYPE"] = ee.Type.String()
 	m["PODMAN_TIME"] = ee.Time.Format(time.RFC3339Nano)
+	if ee.CorrelationID != "" {
+		m["PODMAN_CORRELATION_ID"] = ee.CorrelationID
+	}
 
 	// Add specialized information based on the podman type
 	switch ee.Type {
@@ -186,6 +189,7 @@ func newEventFromJournalEntry(entry *sdjournal.JournalEntry) (*Event, error) { /
 	newEvent.Time = eventTime
 	newEvent.Status = eventStatus
 	newEvent.Name = entry.Fields["PODMAN_NAME"]
+	newEvent.CorrelationID = entry.Fields["PODMAN_CORRELATION_ID"]
 
 	switch eventType {
 	case Container, Pod:
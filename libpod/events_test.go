@@ -0,0 +1,58 @@
+package libpod
+
+import (
+	"context"
+	"testing"
+
+	"github.com/containers/podman/v2/libpod/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingEventer records the events written to it.
+type recordingEventer struct {
+	events []events.Event
+}
+
+func (r *recordingEventer) Write(e events.Event) error {
+	r.events = append(r.events, e)
+	return nil
+}
+
+func (r *recordingEventer) Read(ctx context.Context, options events.ReadOptions) error {
+	return nil
+}
+
+func (r *recordingEventer) String() string {
+	return "recording"
+}
+
+func TestEventCorrelation(t *testing.T) {
+	recorder := &recordingEventer{}
+	r := &Runtime{eventer: recorder}
+	pod := &Pod{config: &PodConfig{ID: "pod"}, runtime: r}
+
+	ctx := context.Background()
+	pod.newPodEvent(ctx, events.Create)
+
+	correlated, id := r.StartEventCorrelation(ctx)
+	require.NotEmpty(t, id)
+	pod.newPodEvent(correlated, events.Start)
+	// A nested operation shares the correlation ID.
+	nested, nestedID := r.StartEventCorrelation(correlated)
+	assert.Equal(t, id, nestedID)
+	pod.newPodEvent(nested, events.Stop)
+
+	// Operations running at the same time with another context are not
+	// tagged.
+	pod.newPodEvent(ctx, events.Remove)
+
+	_, otherID := r.StartEventCorrelation(ctx)
+	assert.NotEqual(t, id, otherID)
+
+	require.Len(t, recorder.events, 4)
+	assert.Empty(t, recorder.events[0].CorrelationID)
+	assert.Equal(t, id, recorder.events[1].CorrelationID)
+	assert.Equal(t, id, recorder.events[2].CorrelationID)
+	assert.Empty(t, recorder.events[3].CorrelationID)
+}
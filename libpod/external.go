@@ -0,0 +1,196 @@
+package libpod
+
+import (
+	"strings"
+	"time"
+
+	"github.com/containers/buildah"
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/containers/storage"
+	"github.com/pkg/errors"
+)
+
+// ExternalContainer describes a container of the storage which was not created
+// by Podman, but by another engine sharing the storage such as Buildah or
+// CRI-O.  Podman can list, inspect and remove such containers, but cannot run
+// them.
+type ExternalContainer struct {
+	// ID of the container in the storage.
+	ID string
+	// Names of the container in the storage.
+	Names []string
+	// Image is the name of the image of the container, if known.
+	Image string
+	// ImageID is the ID of the image of the container.
+	ImageID string
+	// Engine is the name of the engine which created the container, or
+	// "storage" if it is not known.
+	Engine string
+	// Pod is the name of the pod of the container, for engines with pods.
+	Pod string
+	// Created is when the container was created.
+	Created time.Time
+}
+
+// ManagedByEngine returns true if the container was created by an engine which
+// manages it beyond the storage, e.g., CRI-O, and not by Buildah or an engine
+// which is not identified.  Removing such a container behind the back of its
+// engine breaks the engine.
+func (e *ExternalContainer) ManagedByEngine() bool {
+	return e.Engine != externalBuildahEngine && e.Engine != externalStorageEngine
+}
+
+// ExternalEngine identifies the containers of the storage created by another
+// container engine.
+type ExternalEngine interface {
+	// Name returns the name of the engine, e.g., "buildah".
+	Name() string
+	// Identify returns true if the engine created the storage container,
+	// and completes ext with what the engine knows about it.
+	Identify(store storage.Store, ctr *storage.Container, ext *ExternalContainer) (bool, error)
+}
+
+// externalStorageEngine is the engine of the external containers which are
+// not identified by any engine.
+const externalStorageEngine = "storage"
+
+// externalBuildahEngine is the engine of the working containers of Buildah.
+const externalBuildahEngine = "buildah"
+
+// externalEngines are the engines identifying external containers, in the
+// order they are tried.
+var externalEngines = []ExternalEngine{buildahEngine{}, crioEngine{}}
+
+// RegisterExternalEngine adds an engine identifying external containers.  It
+// is tried before the engines already registered.  It must be called before
+// the runtime is used, e.g., in an init function.
+func RegisterExternalEngine(engine ExternalEngine) {
+	externalEngines = append([]ExternalEngine{engine}, externalEngines...)
+}
+
+// buildahEngine identifies the working containers of Buildah.
+type buildahEngine struct{}
+
+func (buildahEngine) Name() string {
+	return externalBuildahEngine
+}
+
+func (buildahEngine) Identify(store storage.Store, ctr *storage.Container, ext *ExternalContainer) (bool, error) {
+	isBuildah, err := buildah.IsContainer(ctr.ID, store)
+	if err != nil || !isBuildah {
+		return false, err
+	}
+	builder, err := buildah.OpenBuilder(store, ctr.ID)
+	if err != nil {
+		return false, errors.Wrapf(err, "error reading buildah container %s", ctr.ID)
+	}
+	ext.Image = builder.FromImage
+	if ext.Image == "" && ctr.ImageID == "" {
+		ext.Image = "scratch"
+	}
+	return true, nil
+}
+
+// crioEngine identifies the containers of CRI-O, which records the pod of
+// its containers in their metadata.
+type crioEngine struct{}
+
+func (crioEngine) Name() string {
+	return "cri-o"
+}
+
+func (crioEngine) Identify(store storage.Store, ctr *storage.Container, ext *ExternalContainer) (bool, error) {
+	var metadata struct {
+		PodName   string `json:"pod-name"`
+		PodID     string `json:"pod-id"`
+		ImageName string `json:"image-name"`
+	}
+	if ctr.Metadata == "" || json.Unmarshal([]byte(ctr.Metadata), &metadata) != nil {
+		return false, nil
+	}
+	if metadata.PodID == "" {
+		return false, nil
+	}
+	ext.Image = metadata.ImageName
+	ext.Pod = metadata.PodName
+	return true, nil
+}
+
+// ExternalContainers returns the containers of the storage which were not
+// created by Podman.
+func (r *Runtime) ExternalContainers() ([]*ExternalContainer, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	ctrs, err := r.StorageContainers()
+	if err != nil {
+		return nil, err
+	}
+	externals := make([]*ExternalContainer, 0, len(ctrs))
+	for i := range ctrs {
+		ext, err := r.newExternalContainer(&ctrs[i])
+		if err != nil {
+			return nil, err
+		}
+		externals = append(externals, ext)
+	}
+	return externals, nil
+}
+
+// LookupExternalContainer returns the container of the storage not created by
+// Podman with the given name, ID or unique ID prefix.
+func (r *Runtime) LookupExternalContainer(nameOrID string) (*ExternalContainer, error) {
+	externals, err := r.ExternalContainers()
+	if err != nil {
+		return nil, err
+	}
+	var found *ExternalContainer
+	for _, ext := range externals {
+		if ext.ID == nameOrID {
+			return ext, nil
+		}
+		for _, name := range ext.Names {
+			if name == nameOrID {
+				return ext, nil
+			}
+		}
+		if strings.HasPrefix(ext.ID, nameOrID) {
+			if found != nil {
+				return nil, errors.Wrapf(define.ErrInvalidArg, "more than one external container matches %q", nameOrID)
+			}
+			found = ext
+		}
+	}
+	if found == nil || nameOrID == "" {
+		return nil, errors.Wrapf(define.ErrNoSuchCtr, "no external container with name or ID %q found", nameOrID)
+	}
+	return found, nil
+}
+
+// newExternalContainer describes the storage container with the first engine
+// identifying it.
+func (r *Runtime) newExternalContainer(ctr *storage.Container) (*ExternalContainer, error) {
+	ext := &ExternalContainer{
+		ID:      ctr.ID,
+		Names:   ctr.Names,
+		ImageID: ctr.ImageID,
+		Engine:  externalStorageEngine,
+		Created: ctr.Created,
+	}
+	for _, engine := range externalEngines {
+		ok, err := engine.Identify(r.store, ctr, ext)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error identifying engine of container %s", ctr.ID)
+		}
+		if ok {
+			ext.Engine = engine.Name()
+			break
+		}
+	}
+	if ext.Image == "" && ctr.ImageID != "" {
+		if img, err := r.store.Image(ctr.ImageID); err == nil && len(img.Names) > 0 {
+			ext.Image = img.Names[0]
+		}
+	}
+	return ext, nil
+}
@@ -0,0 +1,40 @@
+package libpod
+
+import (
+	"testing"
+
+	"github.com/containers/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrioEngineIdentify(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata string
+		expected bool
+		image    string
+		pod      string
+	}{
+		{"NoMetadata", "", false, "", ""},
+		{"InvalidMetadata", "{", false, "", ""},
+		{"PodmanMetadata", `{"image-name":"alpine","name":"ctr"}`, false, "", ""},
+		{"CrioMetadata", `{"pod-name":"mypod","pod-id":"1234","image-name":"alpine","name":"ctr"}`, true, "alpine", "mypod"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			ext := &ExternalContainer{}
+			ok, err := crioEngine{}.Identify(nil, &storage.Container{ID: "1234", Metadata: test.metadata}, ext)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, ok)
+			assert.Equal(t, test.image, ext.Image)
+			assert.Equal(t, test.pod, ext.Pod)
+		})
+	}
+}
+
+func TestExternalContainerManagedByEngine(t *testing.T) {
+	assert.False(t, (&ExternalContainer{Engine: externalBuildahEngine}).ManagedByEngine())
+	assert.False(t, (&ExternalContainer{Engine: externalStorageEngine}).ManagedByEngine())
+	assert.True(t, (&ExternalContainer{Engine: crioEngine{}.Name()}).ManagedByEngine())
+}
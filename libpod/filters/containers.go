@@ -171,6 +171,27 @@ func GenerateContainerFilterFuncs(filter string, filterValues []string, r *libpo
 			}
 			return false
 		}, nil
+	case "restart-policy":
+		invalidPolicyNames := []string{}
+		for _, policy := range filterValues {
+			switch policy {
+			case libpod.RestartPolicyNone, libpod.RestartPolicyNo, libpod.RestartPolicyOnFailure, libpod.RestartPolicyAlways, libpod.RestartPolicyUnlessStopped:
+			default:
+				invalidPolicyNames = append(invalidPolicyNames, policy)
+			}
+		}
+		if len(invalidPolicyNames) > 0 {
+			return nil, errors.Errorf("unrecognized restart policy: %s", strings.Join(invalidPolicyNames, ", "))
+		}
+		return func(c *libpod.Container) bool {
+			for _, policy := range filterValues {
+				// Containers created without a policy use "no".
+				if c.RestartPolicy() == policy || (policy == libpod.RestartPolicyNo && c.RestartPolicy() == libpod.RestartPolicyNone) {
+					return true
+				}
+			}
+			return false
+		}, nil
 	case "health":
 		return func(c *libpod.Container) bool {
 			hcStatus, err := c.HealthCheckStatus()
@@ -233,7 +254,10 @@ func GenerateContainerFilterFuncs(filter string, filterValues []string, r *libpo
 			}
 			return false
 		}, nil
-
+	case "owner":
+		return func(c *libpod.Container) bool {
+			return util.StringInSlice(c.Owner(), filterValues)
+		}, nil
 	}
 	return nil, errors.Errorf("%s is an invalid filter", filter)
 }
@@ -3,6 +3,8 @@ package lpfilters
 import (
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/containers/podman/v2/libpod"
 	"github.com/containers/podman/v2/libpod/define"
@@ -12,44 +14,42 @@ import (
 
 // GeneratePodFilterFunc takes a filter and filtervalue (key, value)
 // and generates a libpod function that can be used to filter
-// pods
-func GeneratePodFilterFunc(filter string, filterValues []string) (
+// pods.  The filters on the containers of the pods use members, the
+// containers of the pods by pod ID, which callers look up once for all the
+// pods with Runtime.PodContainersSnapshot.
+func GeneratePodFilterFunc(filter string, filterValues []string, r *libpod.Runtime, members map[string][]*libpod.Container) (
 	func(pod *libpod.Pod) bool, error) {
 	switch filter {
 	case "ctr-ids":
 		return func(p *libpod.Pod) bool {
-			ctrIds, err := p.AllContainersByID()
-			if err != nil {
-				return false
-			}
-			for _, id := range ctrIds {
-				return util.StringMatchRegexSlice(id, filterValues)
+			for _, ctr := range members[p.ID()] {
+				if util.StringMatchRegexSlice(ctr.ID(), filterValues) {
+					return true
+				}
 			}
 			return false
 		}, nil
 	case "ctr-names":
 		return func(p *libpod.Pod) bool {
-			ctrs, err := p.AllContainers()
-			if err != nil {
-				return false
-			}
-			for _, ctr := range ctrs {
-				return util.StringMatchRegexSlice(ctr.Name(), filterValues)
+			for _, ctr := range members[p.ID()] {
+				if util.StringMatchRegexSlice(ctr.Name(), filterValues) {
+					return true
+				}
 			}
 			return false
 		}, nil
-	case "ctr-number":
-		return func(p *libpod.Pod) bool {
-			ctrIds, err := p.AllContainersByID()
+	case "ctr-count", "ctr-number":
+		counts := make([]func(int) bool, 0, len(filterValues))
+		for _, filterValue := range filterValues {
+			count, err := parseCountFilter(filterValue)
 			if err != nil {
-				return false
+				return nil, err
 			}
-			for _, filterValue := range filterValues {
-				fVint, err2 := strconv.Atoi(filterValue)
-				if err2 != nil {
-					return false
-				}
-				if len(ctrIds) == fVint {
+			counts = append(counts, count)
+		}
+		return func(p *libpod.Pod) bool {
+			for _, count := range counts {
+				if count(len(members[p.ID()])) {
 					return true
 				}
 			}
@@ -62,11 +62,11 @@ func GeneratePodFilterFunc(filter string, filterValues []string) (
 			}
 		}
 		return func(p *libpod.Pod) bool {
-			ctrStatuses, err := p.Status()
-			if err != nil {
-				return false
-			}
-			for _, ctrStatus := range ctrStatuses {
+			for _, ctr := range members[p.ID()] {
+				ctrStatus, err := ctr.State()
+				if err != nil {
+					return false
+				}
 				state := ctrStatus.String()
 				if ctrStatus == define.ContainerStateConfigured {
 					state = "created"
@@ -99,7 +99,7 @@ func GeneratePodFilterFunc(filter string, filterValues []string) (
 			}
 		}
 		return func(p *libpod.Pod) bool {
-			status, err := p.GetPodStatus()
+			status, err := libpod.PodStatusFromContainers(members[p.ID()])
 			if err != nil {
 				return false
 			}
@@ -134,6 +134,47 @@ func GeneratePodFilterFunc(filter string, filterValues []string) (
 			}
 			return true
 		}, nil
+	case "since":
+		var createTime time.Time
+		for _, filterValue := range filterValues {
+			pod, err := r.LookupPod(filterValue)
+			if err != nil {
+				return nil, err
+			}
+			if createTime.IsZero() || createTime.After(pod.CreatedTime()) {
+				createTime = pod.CreatedTime()
+			}
+		}
+		return func(p *libpod.Pod) bool {
+			return createTime.Before(p.CreatedTime())
+		}, nil
+	case "owner":
+		return func(p *libpod.Pod) bool {
+			return util.StringInSlice(p.Owner(), filterValues)
+		}, nil
 	}
 	return nil, errors.Errorf("%s is an invalid filter", filter)
 }
+
+// parseCountFilter parses the value of a filter on a number, "N", "=N",
+// "<N", "<=N", ">N" or ">=N", into a function matching the numbers.
+func parseCountFilter(filterValue string) (func(int) bool, error) {
+	op := strings.TrimRightFunc(filterValue, unicode.IsDigit)
+	n, err := strconv.Atoi(filterValue[len(op):])
+	if err != nil || n < 0 {
+		return nil, errors.Errorf("invalid number %q, must be N, <N, <=N, >N or >=N", filterValue)
+	}
+	switch op {
+	case "", "=":
+		return func(count int) bool { return count == n }, nil
+	case "<":
+		return func(count int) bool { return count < n }, nil
+	case "<=":
+		return func(count int) bool { return count <= n }, nil
+	case ">":
+		return func(count int) bool { return count > n }, nil
+	case ">=":
+		return func(count int) bool { return count >= n }, nil
+	}
+	return nil, errors.Errorf("invalid number %q, must be N, <N, <=N, >N or >=N", filterValue)
+}
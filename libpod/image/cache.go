@@ -0,0 +1,157 @@
+package image
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/containers/image/v5/types"
+	"github.com/containers/storage/pkg/ioutils"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// metadataCacheFile is the file in the run root of the store which caches
+// the parsed configurations of images across invocations, so that listing or
+// inspecting images does not read the configuration of every image from disk
+// each time.
+const metadataCacheFile = "image-metadata-cache.json"
+
+// imageMetadata is the cached metadata of a single image.  The metadata is
+// derived from the manifest and configuration of the image, which do not
+// change for a given image ID.
+type imageMetadata struct {
+	Config  *ociv1.Image            `json:"config,omitempty"`
+	Inspect *types.ImageInspectInfo `json:"inspect,omitempty"`
+}
+
+// metadataCache caches the metadata of images.  It is only valid for the
+// generation of the image store it was loaded for, and is discarded when
+// another process modified the image store since.
+type metadataCache struct {
+	lock       sync.Mutex
+	generation string
+	images     map[string]*imageMetadata
+	dirty      bool
+}
+
+// metadataCacheContents is the on-disk format of a metadataCache.
+type metadataCacheContents struct {
+	Generation string                    `json:"generation"`
+	Images     map[string]*imageMetadata `json:"images"`
+}
+
+// loadMetadataCache loads the cache at path if it was written for the given
+// generation of the image store, and returns an empty cache otherwise.
+func loadMetadataCache(path, generation string) *metadataCache {
+	cache := &metadataCache{
+		generation: generation,
+		images:     make(map[string]*imageMetadata),
+	}
+	if generation == "" {
+		return cache
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logrus.Debugf("Error reading image metadata cache %s: %v", path, err)
+		}
+		return cache
+	}
+	var contents metadataCacheContents
+	if err := json.Unmarshal(b, &contents); err != nil {
+		logrus.Debugf("Error decoding image metadata cache %s: %v", path, err)
+		return cache
+	}
+	if contents.Generation != generation || contents.Images == nil {
+		return cache
+	}
+	cache.images = contents.Images
+	return cache
+}
+
+// get returns the cached metadata of the image with the given ID.
+func (c *metadataCache) get(id string) imageMetadata {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if m, ok := c.images[id]; ok {
+		return *m
+	}
+	return imageMetadata{}
+}
+
+// update applies fn to the cached metadata of the image with the given ID.
+func (c *metadataCache) update(id string, fn func(m *imageMetadata)) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	m, ok := c.images[id]
+	if !ok {
+		m = &imageMetadata{}
+		c.images[id] = m
+	}
+	fn(m)
+	c.dirty = true
+}
+
+// save writes the cache to path if it was modified, unless the image store
+// is now at a different generation than the one the cache was loaded for.
+func (c *metadataCache) save(path, generation string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if !c.dirty || c.generation == "" || c.generation != generation {
+		return nil
+	}
+	b, err := json.Marshal(metadataCacheContents{
+		Generation: c.generation,
+		Images:     c.images,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "error encoding image metadata cache")
+	}
+	if err := ioutils.AtomicWriteFile(path, b, 0600); err != nil {
+		return errors.Wrapf(err, "error writing image metadata cache %s", path)
+	}
+	c.dirty = false
+	return nil
+}
+
+// metadataCachePath returns the path of the metadata cache of the store.
+func (ir *Runtime) metadataCachePath() string {
+	return filepath.Join(ir.store.RunRoot(), metadataCacheFile)
+}
+
+// storeGeneration returns a token which changes whenever the image store is
+// modified.  containers/storage writes a random token to the lock file of
+// the image store on every modification, so that other processes can tell
+// whether to reload it; we use the same token.  An empty string is returned
+// if the token cannot be read, which disables the cache.
+func (ir *Runtime) storeGeneration() string {
+	lockPath := filepath.Join(ir.store.GraphRoot(), ir.store.GraphDriverName()+"-images", "images.lock")
+	b, err := ioutil.ReadFile(lockPath)
+	if err != nil {
+		logrus.Debugf("Error reading image store lock file %s: %v", lockPath, err)
+		return ""
+	}
+	return string(b)
+}
+
+// metadata returns the metadata cache, loading it on first use.
+func (ir *Runtime) metadata() *metadataCache {
+	ir.metadataOnce.Do(func() {
+		ir.metadataCache = loadMetadataCache(ir.metadataCachePath(), ir.storeGeneration())
+	})
+	return ir.metadataCache
+}
+
+// SaveMetadataCache writes the metadata of images read by this runtime to
+// disk, for use by the next invocation.  Nothing is written if the image
+// store was modified since the cache was loaded.
+func (ir *Runtime) SaveMetadataCache() error {
+	if ir.metadataCache == nil {
+		return nil
+	}
+	return ir.metadataCache.save(ir.metadataCachePath(), ir.storeGeneration())
+}
@@ -0,0 +1,46 @@
+package image
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/image/v5/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "metadata-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, metadataCacheFile)
+
+	cache := loadMetadataCache(path, "gen1")
+	assert.Nil(t, cache.get("id1").Inspect)
+	cache.update("id1", func(m *imageMetadata) {
+		m.Inspect = &types.ImageInspectInfo{Os: "linux"}
+	})
+	require.NoError(t, cache.save(path, "gen1"))
+
+	// The cache is reused for the same generation of the store
+	cache = loadMetadataCache(path, "gen1")
+	require.NotNil(t, cache.get("id1").Inspect)
+	assert.Equal(t, "linux", cache.get("id1").Inspect.Os)
+
+	// and discarded when the store was modified.
+	cache = loadMetadataCache(path, "gen2")
+	assert.Nil(t, cache.get("id1").Inspect)
+
+	// A cache is not written if the store was modified since it was
+	// loaded.
+	cache.update("id2", func(m *imageMetadata) {
+		m.Inspect = &types.ImageInspectInfo{Os: "linux"}
+	})
+	require.NoError(t, cache.save(path, "gen3"))
+	cache = loadMetadataCache(path, "gen2")
+	assert.Nil(t, cache.get("id2").Inspect)
+	cache = loadMetadataCache(path, "gen1")
+	assert.NotNil(t, cache.get("id1").Inspect)
+}
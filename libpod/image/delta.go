@@ -0,0 +1,216 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/containers/common/pkg/retry"
+	"github.com/containers/image/v5/directory"
+	"github.com/containers/image/v5/manifest"
+	"github.com/containers/podman/v2/libpod/events"
+	"github.com/containers/podman/v2/pkg/util"
+	"github.com/containers/storage"
+	"github.com/containers/storage/pkg/archive"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// deltaInfoFile is the file of a delta archive describing its content.
+const deltaInfoFile = "podman-delta.json"
+
+// deltaInfo describes the content of a delta archive.  A delta archive is a
+// tarball of an image saved in the docker-dir format, from which the blobs of
+// the layers present on the target have been omitted.
+type deltaInfo struct {
+	// Name is the name the image is loaded as.  It is empty for images
+	// saved by ID.
+	Name string `json:"name,omitempty"`
+	// OmittedLayers are the digests of the layers omitted from the
+	// archive, which must be present in the storage of the target.
+	OmittedLayers []digest.Digest `json:"omittedLayers"`
+}
+
+// SaveDelta saves the image as a delta archive to output.  The layers with
+// the uncompressed digests in knownLayers are expected to be present on the
+// target and are omitted from the archive.
+func (i *Image) SaveDelta(ctx context.Context, source, output string, knownLayers []digest.Digest, quiet bool) error {
+	tmpdir, err := ioutil.TempDir(util.Tmpdir(), "podman-delta")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.RemoveAll(tmpdir); err != nil {
+			logrus.Errorf("error removing %s: %v", tmpdir, err)
+		}
+	}()
+
+	// Layers are saved uncompressed, so the digests of their blobs are
+	// the uncompressed digests of the layers in the storage.
+	if err := i.Save(ctx, source, "docker-dir", tmpdir, nil, quiet, false, true); err != nil {
+		return err
+	}
+	manifestBlob, err := ioutil.ReadFile(filepath.Join(tmpdir, "manifest.json"))
+	if err != nil {
+		return errors.Wrapf(err, "error reading manifest of %q", source)
+	}
+	m, err := manifest.Schema2FromManifest(manifestBlob)
+	if err != nil {
+		return errors.Wrapf(err, "error parsing manifest of %q", source)
+	}
+
+	known := make(map[digest.Digest]bool, len(knownLayers))
+	for _, d := range knownLayers {
+		known[d] = true
+	}
+	info := deltaInfo{
+		Name:          imageNameForSaveDestination(i, source),
+		OmittedLayers: []digest.Digest{},
+	}
+	for _, layer := range m.LayerInfos() {
+		if !known[layer.Digest] {
+			continue
+		}
+		if err := os.Remove(filepath.Join(tmpdir, layer.Digest.Hex())); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "error omitting layer %s", layer.Digest)
+		}
+		info.OmittedLayers = append(info.OmittedLayers, layer.Digest)
+	}
+	infoBlob, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(tmpdir, deltaInfoFile), infoBlob, 0644); err != nil {
+		return err
+	}
+
+	tarball, err := archive.Tar(tmpdir, archive.Uncompressed)
+	if err != nil {
+		return errors.Wrapf(err, "error creating delta archive of %q", source)
+	}
+	defer tarball.Close()
+	f, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, tarball); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "error writing delta archive to %s", output)
+	}
+	return f.Close()
+}
+
+// LoadDelta loads the image from the delta archive at input.  The layers
+// omitted from the archive are taken from the local storage.
+func (ir *Runtime) LoadDelta(ctx context.Context, input, signaturePolicyPath string, writer io.Writer) (*Image, error) {
+	if signaturePolicyPath == "" {
+		signaturePolicyPath = ir.SignaturePolicyPath
+	}
+	tmpdir, err := ioutil.TempDir(util.Tmpdir(), "podman-delta")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := os.RemoveAll(tmpdir); err != nil {
+			logrus.Errorf("error removing %s: %v", tmpdir, err)
+		}
+	}()
+
+	f, err := os.Open(input)
+	if err != nil {
+		return nil, err
+	}
+	err = archive.Untar(f, tmpdir, &archive.TarOptions{NoLchown: true})
+	f.Close()
+	if err != nil {
+		return nil, errors.Wrapf(err, "error extracting delta archive %s", input)
+	}
+	infoBlob, err := ioutil.ReadFile(filepath.Join(tmpdir, deltaInfoFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Errorf("%s is not a delta archive", input)
+		}
+		return nil, err
+	}
+	var info deltaInfo
+	if err := json.Unmarshal(infoBlob, &info); err != nil {
+		return nil, errors.Wrapf(err, "error parsing %s of delta archive %s", deltaInfoFile, input)
+	}
+	for _, d := range info.OmittedLayers {
+		if err := ir.restoreDeltaLayer(d, filepath.Join(tmpdir, d.Hex())); err != nil {
+			return nil, err
+		}
+	}
+	if err := os.Remove(filepath.Join(tmpdir, deltaInfoFile)); err != nil {
+		return nil, err
+	}
+
+	srcRef, err := directory.NewReference(tmpdir)
+	if err != nil {
+		return nil, err
+	}
+	sc := GetSystemContext(signaturePolicyPath, "", false)
+	destName := info.Name
+	if destName == "" {
+		destName, err = getImageDigest(ctx, srcRef, sc)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		ref, err := NormalizedTag(destName)
+		if err != nil {
+			return nil, err
+		}
+		destName = ref.String()
+	}
+	goal, err := ir.getSinglePullRefPairGoal(srcRef, destName)
+	if err != nil {
+		return nil, err
+	}
+	defer goal.cleanUp()
+	imageNames, err := ir.doPullImage(ctx, sc, *goal, writer, SigningOptions{}, &DockerRegistryOptions{}, &retry.RetryOptions{}, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to load delta archive %s", input)
+	}
+	newImage, err := ir.NewFromLocal(imageNames[0])
+	if err != nil {
+		return nil, errors.Wrapf(err, "error retrieving local image after loading %s", input)
+	}
+	ir.newImageEvent(ctx, events.LoadFromArchive, "")
+	return newImage, nil
+}
+
+// restoreDeltaLayer writes the uncompressed content of the local layer with
+// the uncompressed digest d to path.
+func (ir *Runtime) restoreDeltaLayer(d digest.Digest, path string) error {
+	layers, err := ir.store.LayersByUncompressedDigest(d)
+	if err != nil || len(layers) == 0 {
+		return errors.Errorf("layer %s is omitted from the delta archive but not present in local storage", d)
+	}
+	uncompressed := archive.Uncompressed
+	diff, err := ir.store.Diff("", layers[0].ID, &storage.DiffOptions{Compression: &uncompressed})
+	if err != nil {
+		return errors.Wrapf(err, "error reading layer %s", d)
+	}
+	defer diff.Close()
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	verifier := d.Verifier()
+	if _, err := io.Copy(f, io.TeeReader(diff, verifier)); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "error restoring layer %s", d)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if !verifier.Verified() {
+		return errors.Errorf("content of local layer %s does not match its digest", d)
+	}
+	return nil
+}
@@ -34,6 +34,10 @@ type DockerRegistryOptions struct {
 	VariantChoice string
 	// RegistriesConfPath can be used to override the default path of registries.conf.
 	RegistriesConfPath string
+	// RateLimit limits reading the blobs of an image copied from or to a
+	// registry to the specified number of bytes per second.  Zero means
+	// unlimited.
+	RateLimit int64
 }
 
 // GetSystemContext constructs a new system context from a parent context. the values in the DockerRegistryOptions, and other parameters.
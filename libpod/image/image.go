@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -30,8 +31,10 @@ import (
 	"github.com/containers/image/v5/transports"
 	"github.com/containers/image/v5/transports/alltransports"
 	"github.com/containers/image/v5/types"
+	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/libpod/driver"
 	"github.com/containers/podman/v2/libpod/events"
+	"github.com/containers/podman/v2/pkg/admission"
 	"github.com/containers/podman/v2/pkg/inspect"
 	"github.com/containers/podman/v2/pkg/registries"
 	"github.com/containers/podman/v2/pkg/util"
@@ -42,6 +45,7 @@ import (
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
 )
 
 // Image is the primary struct for dealing with images
@@ -63,9 +67,16 @@ type Image struct {
 type Runtime struct {
 	store               storage.Store
 	SignaturePolicyPath string
+	AdmissionPolicyPath string
 	EventsLogFilePath   string
 	EventsLogger        string
 	Eventer             events.Eventer
+	copyRateLimiter     *rate.Limiter
+	metadataOnce        sync.Once
+	metadataCache       *metadataCache
+	admissionOnce       sync.Once
+	admissionPolicy     *admission.Policy
+	admissionErr        error
 }
 
 // InfoImage keep information of Image along with all associated layers
@@ -149,13 +160,43 @@ func (ir *Runtime) New(ctx context.Context, name, signaturePolicyPath, authfile
 	span.SetTag("type", "runtime")
 	defer span.Finish()
 
+	// The local image of another platform, which must be kept next to the
+	// image pulled for the requested platform.
+	var (
+		otherPlatformImage   *Image
+		otherPlatformDigests []string
+	)
+
 	// We don't know if the image is local or not ... check local first
-	if pullType != util.PullImageAlways {
+	if pullType != util.PullImageAlways || hasPlatformChoice(dockeroptions) {
 		newImage, err := ir.NewFromLocal(name)
 		if err == nil {
+			err = newImage.checkPlatform(ctx, dockeroptions)
+			if errors.Cause(err) == define.ErrPlatformMismatch {
+				otherPlatformImage = newImage
+				digests, digestErr := newImage.RepoDigests()
+				if digestErr != nil {
+					return nil, digestErr
+				}
+				otherPlatformDigests = digests
+			}
+		}
+		switch {
+		case err != nil:
+			if pullType == util.PullImageNever {
+				return nil, err
+			}
+		case pullType == util.PullImageNewer:
+			newer, err := ir.hasNewerRemoteImage(ctx, newImage, name, signaturePolicyPath, authfile, dockeroptions)
+			if err != nil {
+				logrus.Warnf("Unable to check registry for a newer version of %s, using the local image: %v", name, err)
+				return newImage, nil
+			}
+			if !newer {
+				return newImage, nil
+			}
+		case pullType != util.PullImageAlways:
 			return newImage, nil
-		} else if pullType == util.PullImageNever {
-			return nil, err
 		}
 	}
 
@@ -163,6 +204,18 @@ func (ir *Runtime) New(ctx context.Context, name, signaturePolicyPath, authfile
 	if signaturePolicyPath == "" {
 		signaturePolicyPath = ir.SignaturePolicyPath
 	}
+	// An image denied by the admission policy is removed again, unless it
+	// was in storage before the pull.
+	var storedIDs map[string]bool
+	policy, err := ir.admission()
+	if err != nil {
+		return nil, err
+	}
+	if policy != nil && len(policy.Rules) > 0 {
+		if storedIDs, err = ir.storedImageIDs(); err != nil {
+			return nil, err
+		}
+	}
 	imageName, err := ir.pullImageFromHeuristicSource(ctx, name, writer, authfile, signaturePolicyPath, signingoptions, dockeroptions, &retry.RetryOptions{MaxRetry: maxRetry}, label)
 	if err != nil {
 		return nil, err
@@ -172,9 +225,63 @@ func (ir *Runtime) New(ctx context.Context, name, signaturePolicyPath, authfile
 	if err != nil {
 		return nil, errors.Wrapf(err, "error retrieving local image after pulling %s", name)
 	}
+	// A registry serving a single image rather than a manifest list
+	// ignores the requested platform.
+	if err := newImage.checkPlatform(ctx, dockeroptions); err != nil {
+		return nil, err
+	}
+	if err := newImage.CheckAdmission(ctx); err != nil {
+		if errors.Cause(err) == admission.ErrImageDenied && !storedIDs[newImage.ID()] {
+			if rmErr := newImage.Remove(ctx, false); rmErr != nil {
+				logrus.Warnf("Unable to remove image %s denied by the admission policy: %v", newImage.ID(), rmErr)
+			}
+		}
+		return nil, err
+	}
+	if otherPlatformImage != nil && otherPlatformImage.ID() != newImage.ID() {
+		if err := otherPlatformImage.keepNames(otherPlatformDigests); err != nil {
+			logrus.Warnf("Unable to keep image %s for another platform: %v", otherPlatformImage.ID(), err)
+		}
+	}
 	return newImage, nil
 }
 
+// storedImageIDs returns the set of the IDs of the images in storage.
+func (ir *Runtime) storedImageIDs() (map[string]bool, error) {
+	images, err := ir.store.Images()
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]bool, len(images))
+	for _, img := range images {
+		ids[img.ID] = true
+	}
+	return ids, nil
+}
+
+// keepNames adds the given names to the image, unless it already has them.
+// It is used after pulling an image for another platform, which took over the
+// tags of this image, so that it remains accessible via its repo digests
+// instead of becoming dangling.
+func (i *Image) keepNames(names []string) error {
+	if err := i.reloadImage(); err != nil {
+		return err
+	}
+	newNames := i.Names()
+	for _, name := range names {
+		if !util.StringInSlice(name, newNames) {
+			newNames = append(newNames, name)
+		}
+	}
+	if len(newNames) == len(i.Names()) {
+		return nil
+	}
+	if err := i.imageruntime.store.SetNames(i.ID(), newNames); err != nil {
+		return err
+	}
+	return i.reloadImage()
+}
+
 // SaveImages stores one more images in a multi-image archive.
 // Note that only `docker-archive` supports storing multiple
 // image.
@@ -337,7 +444,7 @@ func (ir *Runtime) LoadAllImagesFromDockerArchive(ctx context.Context, fileName
 		}
 		newImages = append(newImages, newImage)
 	}
-	ir.newImageEvent(events.LoadFromArchive, "")
+	ir.newImageEvent(ctx, events.LoadFromArchive, "")
 	return newImages, nil
 }
 
@@ -361,7 +468,7 @@ func (ir *Runtime) LoadFromArchiveReference(ctx context.Context, srcRef types.Im
 		}
 		newImages = append(newImages, newImage)
 	}
-	ir.newImageEvent(events.LoadFromArchive, "")
+	ir.newImageEvent(ctx, events.LoadFromArchive, "")
 	return newImages, nil
 }
 
@@ -405,6 +512,12 @@ func stripSha256(name string) string {
 // getLocalImage resolves an unknown input describing an image and
 // returns an updated input name, and a storage.Image, or an error. It is used by NewFromLocal.
 func (ir *Runtime) getLocalImage(inputName string) (string, *storage.Image, error) {
+	return ir.resolveLocalImage(inputName, nil)
+}
+
+// resolveLocalImage implements getLocalImage and records the lookups in trace,
+// which may be nil.
+func (ir *Runtime) resolveLocalImage(inputName string, trace *define.ImageResolutionTrace) (string, *storage.Image, error) {
 	imageError := fmt.Sprintf("unable to find '%s' in local storage", inputName)
 	if inputName == "" {
 		return "", nil, errors.Errorf("input name is blank")
@@ -413,14 +526,17 @@ func (ir *Runtime) getLocalImage(inputName string) (string, *storage.Image, erro
 	// Check if the input name has a transport and if so strip it
 	dest, err := alltransports.ParseImageName(inputName)
 	if err == nil && dest.DockerReference() != nil {
+		traceStep(trace, define.ImageResolveTransport, inputName, nil, "using "+dest.DockerReference().String())
 		inputName = dest.DockerReference().String()
 	}
 
 	// Early check for fully-qualified images and (short) IDs.
 	img, err := ir.store.Image(stripSha256(inputName))
 	if err == nil {
+		traceStep(trace, define.ImageResolveIDOrName, inputName, img, "")
 		return inputName, img, nil
 	}
+	traceStep(trace, define.ImageResolveIDOrName, inputName, nil, "")
 
 	// Note that it's crucial to first decompose the image and check if
 	// it's a fully-qualified one or a "short name".  The latter requires
@@ -435,9 +551,11 @@ func (ir *Runtime) getLocalImage(inputName string) (string, *storage.Image, erro
 		if ref, err := is.Transport.ParseStoreReference(ir.store, inputName); err == nil {
 			img, err = is.Transport.GetStoreImage(ir.store, ref)
 			if err == nil {
+				traceStep(trace, define.ImageResolveStorageReference, inputName, img, "")
 				return inputName, img, nil
 			}
 		}
+		traceStep(trace, define.ImageResolveStorageReference, inputName, nil, "not a valid image reference")
 		return "", nil, err
 	}
 
@@ -449,9 +567,11 @@ func (ir *Runtime) getLocalImage(inputName string) (string, *storage.Image, erro
 		if ref, err := is.Transport.ParseStoreReference(ir.store, inputName); err == nil {
 			img, err = is.Transport.GetStoreImage(ir.store, ref)
 			if err == nil {
+				traceStep(trace, define.ImageResolveDockerNormalization, inputName, img, "fully-qualified name")
 				return inputName, img, nil
 			}
 		}
+		traceStep(trace, define.ImageResolveDockerNormalization, inputName, nil, "fully-qualified name, no further lookups")
 		return "", nil, errors.Wrapf(ErrNoSuchImage, imageError)
 	}
 
@@ -467,8 +587,10 @@ func (ir *Runtime) getLocalImage(inputName string) (string, *storage.Image, erro
 	for _, candidate := range candidates {
 		img, err := ir.store.Image(candidate.String())
 		if err == nil {
+			traceStep(trace, define.ImageResolveShortName, candidate.String(), img, "")
 			return candidate.String(), img, nil
 		}
+		traceStep(trace, define.ImageResolveShortName, candidate.String(), nil, "")
 	}
 
 	// Backwards compat: normalize to docker.io as some users may very well
@@ -477,9 +599,11 @@ func (ir *Runtime) getLocalImage(inputName string) (string, *storage.Image, erro
 	if err == nil {
 		img, err = is.Transport.GetStoreImage(ir.store, ref)
 		if err == nil {
+			traceStep(trace, define.ImageResolveDockerNormalization, inputName, img, "")
 			return inputName, img, nil
 		}
 	}
+	traceStep(trace, define.ImageResolveDockerNormalization, inputName, nil, "")
 
 	// Last resort: look at the repotags of all images and try to find a
 	// match.
@@ -492,7 +616,7 @@ func (ir *Runtime) getLocalImage(inputName string) (string, *storage.Image, erro
 	if err != nil {
 		return "", nil, err
 	}
-	repoImage, err := findImageInRepotags(decomposedImage, images)
+	repoImage, err := findImageInRepotags(decomposedImage, images, trace)
 	if err == nil {
 		return inputName, repoImage, nil
 	}
@@ -500,6 +624,154 @@ func (ir *Runtime) getLocalImage(inputName string) (string, *storage.Image, erro
 	return "", nil, errors.Wrapf(ErrNoSuchImage, err.Error())
 }
 
+// LookupImages resolves the given names or IDs to images in local storage.
+// The returned images and errors are in the same order as the names; an
+// image is nil if its name could not be resolved, in which case the error is
+// set (e.g., ErrNoSuchImage).  In contrast to calling NewFromLocal for each
+// name, the images in storage are only listed once.
+func (ir *Runtime) LookupImages(names []string) ([]*Image, []error, error) {
+	images, err := ir.GetImages()
+	if err != nil {
+		return nil, nil, err
+	}
+	byName := make(map[string]*Image)
+	for _, img := range images {
+		for _, name := range img.Names() {
+			byName[name] = img
+		}
+	}
+	sys := &types.SystemContext{
+		SystemRegistriesConfPath: registries.SystemRegistriesConfPath(),
+	}
+
+	found := make([]*Image, len(names))
+	errs := make([]error, len(names))
+	for i, name := range names {
+		img, err := ir.lookupImageInList(sys, name, images, byName)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		found[i] = ir.newImage(name, img.image)
+	}
+	return found, errs, nil
+}
+
+// lookupImageInList resolves inputName against the given list of images.  It
+// follows the same rules as getLocalImage.
+func (ir *Runtime) lookupImageInList(sys *types.SystemContext, inputName string, images []*Image, byName map[string]*Image) (*Image, error) {
+	if inputName == "" {
+		return nil, errors.Errorf("input name is blank")
+	}
+
+	// Check if the input name has a transport and if so strip it
+	dest, err := alltransports.ParseImageName(inputName)
+	if err == nil && dest.DockerReference() != nil {
+		inputName = dest.DockerReference().String()
+	}
+
+	// Fully-qualified names first, then IDs and short IDs.  Only hex input
+	// may be an ID; an ambiguous short ID is only reported if the input
+	// does not resolve as a name either.
+	if img, ok := byName[inputName]; ok {
+		return img, nil
+	}
+	id := stripSha256(inputName)
+	if isHexID(id) {
+		var idMatch *Image
+		ambiguous := false
+		for _, img := range images {
+			if img.ID() == id {
+				return img, nil
+			}
+			if strings.HasPrefix(img.ID(), id) {
+				if idMatch != nil {
+					ambiguous = true
+				}
+				idMatch = img
+			}
+		}
+		if idMatch != nil && !ambiguous {
+			return idMatch, nil
+		}
+		if ambiguous {
+			img, err := ir.lookupImageNameInList(sys, inputName, images, byName)
+			if errors.Cause(err) == ErrNoSuchImage {
+				return nil, errors.Wrapf(define.ErrMultipleImages, "short ID %q is ambiguous", id)
+			}
+			return img, err
+		}
+	}
+	return ir.lookupImageNameInList(sys, inputName, images, byName)
+}
+
+// isHexID returns whether s is a non-empty lowercase hex string, i.e., may be
+// a (short) image ID.
+func isHexID(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// lookupImageNameInList resolves inputName, which is not an image ID, against
+// the given list of images.
+func (ir *Runtime) lookupImageNameInList(sys *types.SystemContext, inputName string, images []*Image, byName map[string]*Image) (*Image, error) {
+	decomposedImage, err := decompose(inputName)
+	if err != nil {
+		// Possibly a storage reference, which cannot be resolved from
+		// the list of images.
+		_, img, err := ir.getLocalImage(inputName)
+		if err != nil {
+			return nil, err
+		}
+		return ir.newFromStorage(img), nil
+	}
+
+	if !decomposedImage.hasRegistry {
+		candidates, err := shortnames.ResolveLocally(sys, inputName)
+		if err != nil {
+			return nil, err
+		}
+		for _, candidate := range candidates {
+			if img, ok := byName[candidate.String()]; ok {
+				return img, nil
+			}
+		}
+	}
+
+	// Normalize to docker.io and the latest tag as some users may very well
+	// rely on that: `foo` -> `docker.io/library/foo:latest`
+	if named, err := reference.ParseNormalizedNamed(inputName); err == nil {
+		if img, ok := byName[reference.TagNameOnly(named).String()]; ok {
+			return img, nil
+		}
+	}
+	if decomposedImage.hasRegistry {
+		return nil, errors.Wrapf(ErrNoSuchImage, "unable to find '%s' in local storage", inputName)
+	}
+
+	// Last resort: look at the repotags of all images.
+	repoImage, err := findImageInRepotags(decomposedImage, images, nil)
+	if err != nil {
+		if errors.Cause(err) == define.ErrMultipleImages {
+			return nil, err
+		}
+		return nil, errors.Wrapf(ErrNoSuchImage, err.Error())
+	}
+	for _, img := range images {
+		if img.ID() == repoImage.ID {
+			return img, nil
+		}
+	}
+	return nil, errors.Wrapf(ErrNoSuchImage, "unable to find '%s' in local storage", inputName)
+}
+
 // ID returns the image ID as a string
 func (i *Image) ID() string {
 	return i.image.ID
@@ -621,6 +893,17 @@ func (i *Image) TopLayer() string {
 // TODO: the force param does nothing as of now. Need to move container
 // handling logic here eventually.
 func (i *Image) Remove(ctx context.Context, force bool) error {
+	// Mounted images, e.g. image volumes of running containers, must not
+	// be pulled from under their users.
+	if !force && i.TopLayer() != "" {
+		mounted, _, err := i.Mounted()
+		if err != nil {
+			return err
+		}
+		if mounted {
+			return errors.Wrapf(define.ErrImageInUse, "image %s is mounted", i.ID())
+		}
+	}
 	parent, err := i.GetParent(ctx)
 	if err != nil {
 		return err
@@ -628,7 +911,7 @@ func (i *Image) Remove(ctx context.Context, force bool) error {
 	if _, err := i.imageruntime.store.DeleteImage(i.ID(), true); err != nil {
 		return err
 	}
-	i.newImageEvent(events.Remove)
+	i.newImageEvent(ctx, events.Remove)
 	for parent != nil {
 		nextParent, err := parent.GetParent(ctx)
 		if err != nil {
@@ -777,7 +1060,7 @@ func (i *Image) TagImage(tag string) error {
 	if err := i.reloadImage(); err != nil {
 		return err
 	}
-	i.newImageEvent(events.Tag)
+	i.newImageEvent(context.Background(), events.Tag)
 	return nil
 }
 
@@ -811,7 +1094,7 @@ func (i *Image) UntagImage(tag string) error {
 	if err := i.reloadImage(); err != nil {
 		return err
 	}
-	i.newImageEvent(events.Untag)
+	i.newImageEvent(context.Background(), events.Untag)
 	return nil
 }
 
@@ -861,7 +1144,7 @@ func (i *Image) PushImageToReference(ctx context.Context, dest types.ImageRefere
 	copyOptions := getCopyOptions(sc, writer, nil, dockerRegistryOptions, signingOptions, manifestMIMEType, additionalDockerArchiveTags)
 	copyOptions.DestinationCtx.SystemRegistriesConfPath = registries.SystemRegistriesConfPath() // FIXME: Set this more globally.  Probably no reason not to have it in every types.SystemContext, and to compute the value just once in one place.
 	// Copy the image to the remote destination
-	manifestBytes, err := cp.Image(ctx, policyContext, dest, src, copyOptions)
+	manifestBytes, err := cp.Image(ctx, policyContext, dest, i.imageruntime.rateLimitSource(src, dockerRegistryOptions), copyOptions)
 	if err != nil {
 		return errors.Wrapf(err, "error copying image to the remote destination")
 	}
@@ -877,7 +1160,7 @@ func (i *Image) PushImageToReference(ctx context.Context, dest types.ImageRefere
 			return errors.Wrapf(err, "failed to write digest to file %q", digestFile)
 		}
 	}
-	i.newImageEvent(events.Push)
+	i.newImageEvent(ctx, events.Push)
 	return nil
 }
 
@@ -989,6 +1272,13 @@ type History struct {
 	Size      int64      `json:"size"`
 	Comment   string     `json:"comment"`
 	Tags      []string   `json:"tags"`
+	// EmptyLayer is set if the history entry did not create a layer.
+	EmptyLayer bool `json:"emptyLayer"`
+	// Layer is the ID of the storage layer the entry maps to.  It is
+	// empty for empty layers and for layers missing in local storage.
+	Layer string `json:"layer,omitempty"`
+	// LayerDigest is the uncompressed digest (diff ID) of the layer.
+	LayerDigest string `json:"layerDigest,omitempty"`
 }
 
 // History gets the history of an image and the IDs of images that are part of
@@ -1050,14 +1340,19 @@ func (i *Image) History(ctx context.Context) ([]*History, error) {
 			}
 		}
 		h := History{
-			ID:        id,
-			Created:   oci.History[x].Created,
-			CreatedBy: oci.History[x].CreatedBy,
-			Size:      size,
-			Comment:   oci.History[x].Comment,
+			ID:         id,
+			Created:    oci.History[x].Created,
+			CreatedBy:  oci.History[x].CreatedBy,
+			Size:       size,
+			Comment:    oci.History[x].Comment,
+			EmptyLayer: oci.History[x].EmptyLayer,
 		}
 		if layer != nil {
 			h.Tags = layer.Names
+			if !oci.History[x].EmptyLayer {
+				h.Layer = layer.ID
+				h.LayerDigest = layer.UncompressedDigest.String()
+			}
 		}
 		allHistory = append(allHistory, &h)
 
@@ -1176,18 +1471,49 @@ func (i *Image) Annotations(ctx context.Context) (map[string]string, error) {
 	return annotations, nil
 }
 
+// metadataCache returns the metadata cache of the image runtime, or nil if
+// the metadata of the image must not be cached.  Images in read-only stores
+// are not cached, as their changes are not tracked by the generation of the
+// image store.
+func (i *Image) metadataCache() *metadataCache {
+	if i.imageruntime == nil || i.image == nil || i.IsReadOnly() {
+		return nil
+	}
+	return i.imageruntime.metadata()
+}
+
 // ociv1Image converts an image to an imgref and then returns its config blob
 // converted to an ociv1 image type
 func (i *Image) ociv1Image(ctx context.Context) (*ociv1.Image, error) {
+	cache := i.metadataCache()
+	if cache != nil {
+		if config := cache.get(i.ID()).Config; config != nil {
+			return config, nil
+		}
+	}
 	imgRef, err := i.toImageRef(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return imgRef.OCIConfig(ctx)
+	config, err := imgRef.OCIConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if cache != nil {
+		cache.update(i.ID(), func(m *imageMetadata) { m.Config = config })
+	}
+	return config, nil
 }
 
 func (i *Image) imageInspectInfo(ctx context.Context) (*types.ImageInspectInfo, error) {
 	if i.inspectInfo == nil {
+		cache := i.metadataCache()
+		if cache != nil {
+			if info := cache.get(i.ID()).Inspect; info != nil {
+				i.inspectInfo = info
+				return i.inspectInfo, nil
+			}
+		}
 		ic, err := i.toImageRef(ctx)
 		if err != nil {
 			return nil, err
@@ -1197,6 +1523,9 @@ func (i *Image) imageInspectInfo(ctx context.Context) (*types.ImageInspectInfo,
 			return nil, err
 		}
 		i.inspectInfo = imgInspect
+		if cache != nil {
+			cache.update(i.ID(), func(m *imageMetadata) { m.Inspect = imgInspect })
+		}
 	}
 	return i.inspectInfo, nil
 }
@@ -1361,7 +1690,7 @@ func (ir *Runtime) Import(ctx context.Context, path, reference string, writer io
 	}
 	newImage, err := ir.NewFromLocal(reference)
 	if err == nil {
-		newImage.newImageEvent(events.Import)
+		newImage.newImageEvent(ctx, events.Import)
 	}
 	return newImage, err
 }
@@ -1652,7 +1981,7 @@ func (i *Image) Save(ctx context.Context, source, format, output string, moreTag
 	if err := i.PushImageToReference(ctx, destRef, manifestType, "", "", "", writer, compress, SigningOptions{RemoveSignatures: removeSignatures}, &DockerRegistryOptions{}, additionaltags); err != nil {
 		return errors.Wrapf(err, "unable to save %q", source)
 	}
-	i.newImageEvent(events.Save)
+	i.newImageEvent(ctx, events.Save)
 	return nil
 }
 
@@ -1703,8 +2032,9 @@ func (i *Image) GetHealthCheck(ctx context.Context) (*manifest.Schema2HealthConf
 }
 
 // newImageEvent creates a new event based on an image
-func (ir *Runtime) newImageEvent(status events.Status, name string) {
+func (ir *Runtime) newImageEvent(ctx context.Context, status events.Status, name string) {
 	e := events.NewEvent(status)
+	e.CorrelationID = events.CorrelationID(ctx)
 	e.Type = events.Image
 	e.Name = name
 	if err := ir.Eventer.Write(e); err != nil {
@@ -1713,8 +2043,9 @@ func (ir *Runtime) newImageEvent(status events.Status, name string) {
 }
 
 // newImageEvent creates a new event based on an image
-func (i *Image) newImageEvent(status events.Status) {
+func (i *Image) newImageEvent(ctx context.Context, status events.Status) {
 	e := events.NewEvent(status)
+	e.CorrelationID = events.CorrelationID(ctx)
 	e.ID = i.ID()
 	e.Type = events.Image
 	if len(i.Names()) > 0 {
@@ -1728,13 +2059,13 @@ func (i *Image) newImageEvent(status events.Status) {
 // Mount mounts a image's filesystem on the host
 // The path where the image has been mounted is returned
 func (i *Image) Mount(options []string, mountLabel string) (string, error) {
-	defer i.newImageEvent(events.Mount)
+	defer i.newImageEvent(context.Background(), events.Mount)
 	return i.mount(options, mountLabel)
 }
 
 // Unmount unmounts a image's filesystem on the host
 func (i *Image) Unmount(force bool) error {
-	defer i.newImageEvent(events.Unmount)
+	defer i.newImageEvent(context.Background(), events.Unmount)
 	return i.unmount(force)
 }
 
@@ -1868,3 +2199,53 @@ func BuildImageHierarchyMap(imageInfo *InfoImage, layerMap map[string]*LayerInfo
 	imageInfo.Layers = append(imageInfo.Layers, *ll)
 	return nil
 }
+
+// admission returns the admission policy of the runtime, loading it on first
+// use.  It is nil if the runtime has no admission policy.
+func (ir *Runtime) admission() (*admission.Policy, error) {
+	ir.admissionOnce.Do(func() {
+		if ir.AdmissionPolicyPath == "" {
+			return
+		}
+		ir.admissionPolicy, ir.admissionErr = admission.LoadPolicy(ir.AdmissionPolicyPath)
+	})
+	return ir.admissionPolicy, ir.admissionErr
+}
+
+// CheckAdmission evaluates the admission policy of the runtime for the image.
+// Warnings of the policy are logged, an error wrapping
+// admission.ErrImageDenied is returned if the policy denies the image.
+func (i *Image) CheckAdmission(ctx context.Context) error {
+	policy, err := i.imageruntime.admission()
+	if err != nil {
+		return err
+	}
+	if policy == nil || len(policy.Rules) == 0 {
+		return nil
+	}
+	data, err := i.InspectNoSize(ctx)
+	if err != nil {
+		return err
+	}
+	img := &admission.Image{Names: i.Names()}
+	if data.Config != nil {
+		img.User = data.Config.User
+		img.Labels = data.Config.Labels
+		for port := range data.Config.ExposedPorts {
+			img.ExposedPorts = append(img.ExposedPorts, port)
+		}
+		sort.Strings(img.ExposedPorts)
+	}
+	name := i.InputName
+	if name == "" {
+		name = i.ID()
+	}
+	decision := policy.Evaluate(img)
+	switch decision.Action {
+	case admission.ActionWarn:
+		logrus.Warnf("Image %s violates the admission policy: %s", name, strings.Join(decision.Reasons, ", "))
+	case admission.ActionDeny:
+		return errors.Wrapf(admission.ErrImageDenied, "image %s: %s", name, strings.Join(decision.Reasons, ", "))
+	}
+	return nil
+}
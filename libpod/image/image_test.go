@@ -7,11 +7,14 @@ import (
 	"os"
 	"testing"
 
+	"github.com/containers/image/v5/types"
+	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/libpod/events"
 	"github.com/containers/podman/v2/pkg/util"
 	"github.com/containers/storage"
 	"github.com/containers/storage/pkg/reexec"
 	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -299,3 +302,52 @@ func TestNormalizedTag(t *testing.T) {
 		}
 	}
 }
+
+func TestLookupImageInList(t *testing.T) {
+	registriesConf, err := ioutil.TempFile("", "registries.conf")
+	assert.NoError(t, err)
+	defer os.Remove(registriesConf.Name())
+	registriesConf.Close()
+	sys := &types.SystemContext{SystemRegistriesConfPath: registriesConf.Name()}
+
+	newTestImage := func(id string, names ...string) *Image {
+		return &Image{image: &storage.Image{ID: id, Names: names}}
+	}
+	named := newTestImage("abc1000000000000000000000000000000000000000000000000000000000000", "docker.io/library/abc:latest")
+	other := newTestImage("abc2000000000000000000000000000000000000000000000000000000000000", "docker.io/library/busybox:latest")
+	hexName := newTestImage("0123000000000000000000000000000000000000000000000000000000000000", "localhost/abc2:latest")
+	images := []*Image{named, other, hexName}
+	byName := make(map[string]*Image)
+	for _, img := range images {
+		for _, name := range img.Names() {
+			byName[name] = img
+		}
+	}
+
+	ir := &Runtime{}
+	for _, c := range []struct {
+		input    string
+		expected *Image
+		err      error
+	}{
+		{"docker.io/library/abc:latest", named, nil},
+		{named.ID(), named, nil},
+		{"sha256:" + other.ID(), other, nil},
+		{"abc1", named, nil},
+		// Hex-like names prefixing several IDs resolve as names.
+		{"abc", named, nil},
+		{"localhost/abc2:latest", hexName, nil},
+		// Only unresolvable names are reported as ambiguous short IDs.
+		{"ab", nil, define.ErrMultipleImages},
+		{"abc1g", nil, ErrNoSuchImage},
+		{"nosuchimage", nil, ErrNoSuchImage},
+	} {
+		img, err := ir.lookupImageInList(sys, c.input, images, byName)
+		if c.err != nil {
+			assert.Equal(t, c.err, errors.Cause(err), c.input)
+			continue
+		}
+		assert.NoError(t, err, c.input)
+		assert.Equal(t, c.expected, img, c.input)
+	}
+}
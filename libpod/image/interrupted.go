@@ -0,0 +1,422 @@
+package image
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/containers/buildah/pkg/parse"
+	"github.com/containers/image/v5/types"
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/containers/storage"
+	"github.com/containers/storage/pkg/directory"
+	"github.com/containers/storage/pkg/ioutils"
+	"github.com/containers/storage/pkg/lockfile"
+	digest "github.com/opencontainers/go-digest"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// pullRecordsDir is the directory in the graph root of the store which holds a
+// record of every pull in progress.  A record outliving the process which
+// wrote it is left by a pull which was interrupted.
+const pullRecordsDir = "libpod/pulls"
+
+// pullRecord is the on-disk record of a pull in progress.
+type pullRecord struct {
+	// PID is the process doing the pull.
+	PID int `json:"pid"`
+	// Image is the name of the image being pulled.
+	Image string `json:"image"`
+	// Started is when the pull started.
+	Started time.Time `json:"started"`
+	// TempDir holds the blobs of the pull which are not committed to the
+	// store yet.
+	TempDir string `json:"tempDir"`
+	// Layers are the IDs of the layers of the image, from the bottom up.
+	// They are recorded once the configuration of the image is pulled.
+	Layers []string `json:"layers,omitempty"`
+	// NewLayers are the IDs of the layers which the pull commits to the
+	// store, as they were not in the store yet.
+	NewLayers []string `json:"newLayers,omitempty"`
+}
+
+// pullTracker tracks a pull in progress.
+type pullTracker struct {
+	recordPath string
+	tempDir    string
+	record     pullRecord
+}
+
+// interruptedPulls is what clearInterruptedPulls found of the pulls which were
+// interrupted.
+type interruptedPulls struct {
+	// images are the names of the images of the interrupted pulls.
+	images []string
+	// layers are the IDs of the layers committed by the interrupted pulls.
+	layers []string
+	// size is the disk space reclaimed by removing their temporary
+	// directories.
+	size int64
+	// inProgress is set if other pulls are still in progress.
+	inProgress bool
+	// liveLayers are the IDs of the layers of the pulls in progress.
+	liveLayers map[string]bool
+}
+
+// startPull records a pull of image in recordsDir, and creates the
+// temporary directory in which the pull stores its blobs until they are
+// committed to the store.
+func startPull(recordsDir, image string) (*pullTracker, error) {
+	if err := os.MkdirAll(recordsDir, 0700); err != nil {
+		return nil, errors.Wrapf(err, "error creating directory %s", recordsDir)
+	}
+	tempDir, err := ioutil.TempDir(parse.GetTempDir(), "podman-pull")
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating temporary directory to pull %s", image)
+	}
+	record := pullRecord{
+		PID:     os.Getpid(),
+		Image:   image,
+		Started: time.Now(),
+		TempDir: tempDir,
+	}
+	tracker := &pullTracker{
+		recordPath: filepath.Join(recordsDir, filepath.Base(tempDir)+".json"),
+		tempDir:    tempDir,
+		record:     record,
+	}
+	if err := tracker.write(); err != nil {
+		_ = os.RemoveAll(tempDir)
+		return nil, errors.Wrapf(err, "error recording pull of %s", image)
+	}
+	return tracker, nil
+}
+
+// write writes the record of the pull.
+func (t *pullTracker) write() error {
+	b, err := json.Marshal(t.record)
+	if err != nil {
+		return err
+	}
+	return ioutils.AtomicWriteFile(t.recordPath, b, 0600)
+}
+
+// recordLayers records in the record of the pull the layers of the image with
+// the given configuration, and which of them are not in the store yet.  The
+// records lock is held, so that PruneInterruptedPulls does not remove a layer
+// the pull reuses.
+func (t *pullTracker) recordLayers(store storage.Store, config []byte) error {
+	var image imgspecv1.Image
+	if err := json.Unmarshal(config, &image); err != nil {
+		return errors.Wrapf(err, "error parsing image configuration")
+	}
+	lock, err := pullRecordsLock(filepath.Dir(t.recordPath))
+	if err != nil {
+		return err
+	}
+	lock.Lock()
+	defer lock.Unlock()
+
+	t.record.Layers = layerIDs(image.RootFS.DiffIDs)
+	t.record.NewLayers = nil
+	for _, id := range t.record.Layers {
+		if _, err := store.Layer(id); err != nil {
+			if errors.Cause(err) != storage.ErrLayerUnknown {
+				return err
+			}
+			t.record.NewLayers = append(t.record.NewLayers, id)
+		}
+	}
+	return t.write()
+}
+
+// layerIDs returns the IDs which the containers-storage transport gives to the
+// layers with the given diff IDs, from the bottom up.
+func layerIDs(diffIDs []digest.Digest) []string {
+	ids := make([]string, 0, len(diffIDs))
+	parent := ""
+	for _, diffID := range diffIDs {
+		id := diffID.Hex()
+		if parent != "" {
+			id = digest.Canonical.FromBytes([]byte(parent + "+" + diffID.Hex())).Hex()
+		}
+		ids = append(ids, id)
+		parent = id
+	}
+	return ids
+}
+
+// finish removes the temporary directory and the record of the pull.  The
+// layers committed to the store by a pull which failed are kept, so pulling
+// the image again reuses them instead of downloading them again.
+func (t *pullTracker) finish() {
+	if err := os.RemoveAll(t.tempDir); err != nil {
+		logrus.Errorf("Error removing temporary directory %s: %v", t.tempDir, err)
+	}
+	if err := os.Remove(t.recordPath); err != nil && !os.IsNotExist(err) {
+		logrus.Errorf("Error removing pull record %s: %v", t.recordPath, err)
+	}
+}
+
+// processAlive returns true if the process with the given PID exists.
+func processAlive(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	err = process.Signal(syscall.Signal(0))
+	return err == nil || err == syscall.EPERM
+}
+
+// readPullRecords returns the records of the pulls in recordsDir, indexed by
+// the paths of the records.
+func readPullRecords(recordsDir string) (map[string]pullRecord, error) {
+	entries, err := ioutil.ReadDir(recordsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	records := make(map[string]pullRecord)
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(recordsDir, entry.Name())
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var record pullRecord
+		if err := json.Unmarshal(b, &record); err != nil {
+			logrus.Debugf("Ignoring invalid pull record %s: %v", path, err)
+			continue
+		}
+		records[path] = record
+	}
+	return records, nil
+}
+
+// clearInterruptedPulls removes the temporary directories and the records of
+// the pulls in recordsDir whose process no longer exists.  It returns the
+// names of the images of these pulls and the layers they committed, the disk
+// space reclaimed, and the layers of the other pulls still in progress.
+func clearInterruptedPulls(recordsDir string) (*interruptedPulls, error) {
+	records, err := readPullRecords(recordsDir)
+	if err != nil {
+		return nil, err
+	}
+	pulls := &interruptedPulls{liveLayers: make(map[string]bool)}
+	for path, record := range records {
+		if record.PID == os.Getpid() || processAlive(record.PID) {
+			pulls.inProgress = true
+			for _, id := range record.Layers {
+				pulls.liveLayers[id] = true
+			}
+			continue
+		}
+		if record.TempDir != "" {
+			if dirSize, err := directory.Size(record.TempDir); err == nil {
+				pulls.size += dirSize
+			}
+			if err := os.RemoveAll(record.TempDir); err != nil {
+				return nil, errors.Wrapf(err, "error removing temporary directory %s", record.TempDir)
+			}
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		pulls.images = append(pulls.images, record.Image)
+		pulls.layers = append(pulls.layers, record.NewLayers...)
+	}
+	return pulls, nil
+}
+
+// pullRecordsLock returns the lock of the records of the pulls in recordsDir.
+func pullRecordsLock(recordsDir string) (lockfile.Locker, error) {
+	if err := os.MkdirAll(recordsDir, 0700); err != nil {
+		return nil, errors.Wrapf(err, "error creating directory %s", recordsDir)
+	}
+	return storage.GetLockfile(filepath.Join(recordsDir, "pulls.lock"))
+}
+
+// pullRecordsDir returns the directory of the records of the pulls in
+// progress.
+func (ir *Runtime) pullRecordsDir() string {
+	return filepath.Join(ir.store.GraphRoot(), pullRecordsDir)
+}
+
+// PruneInterruptedPulls removes what is left of the pulls which were
+// interrupted, for instance by a signal or a crash: the blobs they did not
+// commit to the store yet, the partial blobs they did not finish downloading,
+// and the layers they committed without creating the image.  Only the layers
+// recorded by the interrupted pulls are removed, and only if no image,
+// container or pull in progress uses them.  The partial blobs are only removed
+// while no other pull is in progress, as they cannot be told apart from those
+// of a pull which did not create its image yet.
+func (ir *Runtime) PruneInterruptedPulls() (*define.InterruptedPullsPruneReport, error) {
+	recordsDir := ir.pullRecordsDir()
+	lock, err := pullRecordsLock(recordsDir)
+	if err != nil {
+		return nil, err
+	}
+	// Pulls record the layers they reuse while holding the lock.
+	lock.Lock()
+	defer lock.Unlock()
+
+	pulls, err := clearInterruptedPulls(recordsDir)
+	if err != nil {
+		return nil, err
+	}
+	report := &define.InterruptedPullsPruneReport{Pulls: pulls.images, Size: pulls.size}
+	if pulls.inProgress {
+		logrus.Debugf("Not removing partial blobs as a pull is in progress")
+	} else {
+		partialSize, err := clearPartialBlobs(ir.partialBlobsDir())
+		if err != nil {
+			return nil, err
+		}
+		report.Size += partialSize
+	}
+
+	used, err := ir.usedLayers()
+	if err != nil {
+		return nil, err
+	}
+	// The layers of a pull are recorded from the bottom up, so remove them
+	// in reverse, as a layer cannot be removed before its children.
+	for i := len(pulls.layers) - 1; i >= 0; i-- {
+		id := pulls.layers[i]
+		if used[id] || pulls.liveLayers[id] {
+			continue
+		}
+		layer, err := ir.store.Layer(id)
+		if err != nil {
+			if errors.Cause(err) == storage.ErrLayerUnknown {
+				// The pull was interrupted before committing it.
+				continue
+			}
+			return nil, err
+		}
+		if layer.ReadOnly {
+			continue
+		}
+		if err := ir.store.DeleteLayer(id); err != nil {
+			switch errors.Cause(err) {
+			case storage.ErrLayerUnknown:
+				continue
+			case storage.ErrLayerUsedByImage, storage.ErrLayerUsedByContainer, storage.ErrLayerHasChildren:
+				// An image, a container or another layer was
+				// created on top of it since.
+				logrus.Debugf("Not removing layer %s: %v", id, err)
+				continue
+			}
+			return nil, errors.Wrapf(err, "error removing layer %s", id)
+		}
+		used[id] = true
+		report.Layers = append(report.Layers, id)
+		if layer.UncompressedSize > 0 {
+			report.Size += layer.UncompressedSize
+		}
+	}
+	return report, nil
+}
+
+// trackedReference is a destination reference whose pulls record the layers
+// of the image in their pull record.
+type trackedReference struct {
+	types.ImageReference
+	tracker *pullTracker
+	store   storage.Store
+}
+
+// trackedDestination is an image destination which records the layers of the
+// image in the pull record once the configuration of the image is written.
+type trackedDestination struct {
+	types.ImageDestination
+	tracker *pullTracker
+	store   storage.Store
+}
+
+// trackLayers returns dest, recording the layers the pull commits to the store
+// in the record of tracker.
+func (ir *Runtime) trackLayers(dest types.ImageReference, tracker *pullTracker) types.ImageReference {
+	return &trackedReference{ImageReference: dest, tracker: tracker, store: ir.store}
+}
+
+// NewImageDestination returns an image destination which records the layers
+// of the image.
+func (r *trackedReference) NewImageDestination(ctx context.Context, sys *types.SystemContext) (types.ImageDestination, error) {
+	dest, err := r.ImageReference.NewImageDestination(ctx, sys)
+	if err != nil {
+		return nil, err
+	}
+	return &trackedDestination{ImageDestination: dest, tracker: r.tracker, store: r.store}, nil
+}
+
+// PutBlob writes the blob, and records the layers of the image if the blob is
+// its configuration.  The layers are only committed to the store afterwards.
+func (d *trackedDestination) PutBlob(ctx context.Context, stream io.Reader, info types.BlobInfo, cache types.BlobInfoCache, isConfig bool) (types.BlobInfo, error) {
+	if !isConfig {
+		return d.ImageDestination.PutBlob(ctx, stream, info, cache, isConfig)
+	}
+	var config bytes.Buffer
+	blob, err := d.ImageDestination.PutBlob(ctx, io.TeeReader(stream, &config), info, cache, isConfig)
+	if err != nil {
+		return blob, err
+	}
+	if err := d.tracker.recordLayers(d.store, config.Bytes()); err != nil {
+		logrus.Debugf("Error recording the layers of %s: %v", d.tracker.record.Image, err)
+	}
+	return blob, nil
+}
+
+// usedLayers returns the IDs of the layers used by the images and the
+// containers of the store, and of their parents.
+func (ir *Runtime) usedLayers() (map[string]bool, error) {
+	layers, err := ir.store.Layers()
+	if err != nil {
+		return nil, err
+	}
+	parents := make(map[string]string, len(layers))
+	for _, layer := range layers {
+		parents[layer.ID] = layer.Parent
+	}
+
+	used := make(map[string]bool)
+	markUsed := func(id string) {
+		for id != "" && !used[id] {
+			used[id] = true
+			id = parents[id]
+		}
+	}
+
+	images, err := ir.store.Images()
+	if err != nil {
+		return nil, err
+	}
+	for _, image := range images {
+		markUsed(image.TopLayer)
+		for _, id := range image.MappedTopLayers {
+			markUsed(id)
+		}
+	}
+	containers, err := ir.store.Containers()
+	if err != nil {
+		return nil, err
+	}
+	for _, ctr := range containers {
+		markUsed(ctr.LayerID)
+	}
+	return used, nil
+}
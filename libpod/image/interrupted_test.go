@@ -0,0 +1,76 @@
+package image
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	digest "github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClearInterruptedPulls(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pull-records")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// A pull of this process is in progress.
+	tracker, err := startPull(dir, "quay.io/libpod/alpine:latest")
+	require.NoError(t, err)
+	defer tracker.finish()
+	tracker.record.Layers = []string{"base"}
+	require.NoError(t, tracker.write())
+	_, err = os.Stat(tracker.tempDir)
+	require.NoError(t, err)
+
+	// A pull of a process which no longer exists was interrupted.
+	tempDir, err := ioutil.TempDir("", "podman-pull")
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(tempDir, "blob"), []byte("partial"), 0600))
+	cmd := exec.Command("true")
+	require.NoError(t, cmd.Run())
+	b, err := json.Marshal(pullRecord{PID: cmd.Process.Pid, Image: "quay.io/libpod/busybox:latest", Started: time.Now(), TempDir: tempDir, Layers: []string{"base", "top"}, NewLayers: []string{"top"}})
+	require.NoError(t, err)
+	recordPath := filepath.Join(dir, "interrupted.json")
+	require.NoError(t, ioutil.WriteFile(recordPath, b, 0600))
+
+	pulls, err := clearInterruptedPulls(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"quay.io/libpod/busybox:latest"}, pulls.images)
+	assert.Equal(t, []string{"top"}, pulls.layers)
+	assert.True(t, pulls.size > 0)
+	assert.True(t, pulls.inProgress)
+	assert.Equal(t, map[string]bool{"base": true}, pulls.liveLayers)
+	_, err = os.Stat(tempDir)
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(recordPath)
+	assert.True(t, os.IsNotExist(err))
+
+	// The pull in progress is untouched.
+	_, err = os.Stat(tracker.tempDir)
+	assert.NoError(t, err)
+	tracker.finish()
+	_, err = os.Stat(tracker.recordPath)
+	assert.True(t, os.IsNotExist(err))
+
+	pulls, err = clearInterruptedPulls(dir)
+	require.NoError(t, err)
+	assert.Empty(t, pulls.images)
+	assert.Empty(t, pulls.layers)
+	assert.False(t, pulls.inProgress)
+}
+
+func TestLayerIDs(t *testing.T) {
+	base := digest.FromString("base")
+	top := digest.FromString("top")
+	ids := layerIDs([]digest.Digest{base, top})
+	require.Len(t, ids, 2)
+	assert.Equal(t, base.Hex(), ids[0])
+	assert.Equal(t, digest.Canonical.FromString(base.Hex()+"+"+top.Hex()).Hex(), ids[1])
+	assert.Empty(t, layerIDs(nil))
+}
@@ -0,0 +1,60 @@
+package image
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/pkg/errors"
+)
+
+// platformString formats the OS, architecture and variant as used by the
+// --platform flag (e.g., "linux/arm64/v8").  Empty trailing values are
+// omitted.
+func platformString(os, arch, variant string) string {
+	platform := os
+	if arch != "" {
+		platform = fmt.Sprintf("%s/%s", platform, arch)
+	}
+	if variant != "" {
+		platform = fmt.Sprintf("%s/%s", platform, variant)
+	}
+	return platform
+}
+
+// hasPlatformChoice returns true if the options select a platform other than
+// the one of the local machine.
+func hasPlatformChoice(options *DockerRegistryOptions) bool {
+	return options != nil && (options.OSChoice != "" || options.ArchitectureChoice != "" || options.VariantChoice != "")
+}
+
+// Platform returns the OS, architecture and variant of the image, formatted
+// as "os/arch[/variant]".
+func (i *Image) Platform(ctx context.Context) (string, error) {
+	info, err := i.imageInspectInfo(ctx)
+	if err != nil {
+		return "", err
+	}
+	return platformString(info.Os, info.Architecture, info.Variant), nil
+}
+
+// checkPlatform returns an ErrPlatformMismatch error if the image does not
+// match the platform selected in the options.  Values which are not set in
+// the options match any value of the image.
+func (i *Image) checkPlatform(ctx context.Context, options *DockerRegistryOptions) error {
+	if !hasPlatformChoice(options) {
+		return nil
+	}
+	info, err := i.imageInspectInfo(ctx)
+	if err != nil {
+		return err
+	}
+	if (options.OSChoice == "" || options.OSChoice == info.Os) &&
+		(options.ArchitectureChoice == "" || options.ArchitectureChoice == info.Architecture) &&
+		(options.VariantChoice == "" || options.VariantChoice == info.Variant) {
+		return nil
+	}
+	return errors.Wrapf(define.ErrPlatformMismatch, "image %s is for platform %q, not %q", i.InputName,
+		platformString(info.Os, info.Architecture, info.Variant),
+		platformString(options.OSChoice, options.ArchitectureChoice, options.VariantChoice))
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/libpod/events"
 	"github.com/containers/podman/v2/pkg/timetype"
 	"github.com/containers/storage"
@@ -148,9 +149,13 @@ func (ir *Runtime) PruneImages(ctx context.Context, all bool, filter []string) (
 					logrus.Warnf("Failed to prune image %s as it is in use: %v.\nA container associated with containers/storage (e.g., Buildah, CRI-O, etc.) maybe associated with this image.\nUsing the rmi command with the --force option will remove the container and image, but may cause failures for other dependent systems.", img.ID(), err)
 					continue
 				}
+				if errors.Cause(err) == define.ErrImageInUse {
+					logrus.Debugf("Not pruning image %s: %v", img.ID(), err)
+					continue
+				}
 				return nil, errors.Wrap(err, "failed to prune image")
 			}
-			defer img.newImageEvent(events.Prune)
+			defer img.newImageEvent(ctx, events.Prune)
 			nameOrID := img.ID()
 			if len(repotags) > 0 {
 				nameOrID = repotags[0]
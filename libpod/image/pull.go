@@ -341,11 +341,22 @@ func (ir *Runtime) doPullImage(ctx context.Context, sc *types.SystemContext, goa
 				return nil, err
 			}
 		}
+		// Keep the blobs of the pull in a directory of its own, so that
+		// what is left of it if podman is interrupted can be found by
+		// `podman system prune --interrupted`.
+		tracker, err := startPull(ir.pullRecordsDir(), imageInfo.image)
+		if err != nil {
+			return nil, err
+		}
+		copyOptions.SourceCtx.BigFilesTemporaryDir = tracker.tempDir
+		copyOptions.DestinationCtx.BigFilesTemporaryDir = tracker.tempDir
 		imageInfo := imageInfo
-		if err = retry.RetryIfNecessary(ctx, func() error {
-			_, err = cp.Image(ctx, policyContext, imageInfo.dstRef, imageInfo.srcRef, copyOptions)
+		err = retry.RetryIfNecessary(ctx, func() error {
+			_, err = cp.Image(ctx, policyContext, ir.trackLayers(imageInfo.dstRef, tracker), ir.rateLimitSource(ir.resumableSource(imageInfo.srcRef), dockerOptions), copyOptions)
 			return err
-		}, retryOptions); err != nil {
+		}, retryOptions)
+		tracker.finish()
+		if err != nil {
 			pullErrors = append(pullErrors, err)
 			logrus.Debugf("Error pulling image ref %s: %v", imageInfo.srcRef.StringWithinTransport(), err)
 			if writer != nil {
@@ -358,7 +369,7 @@ func (ir *Runtime) doPullImage(ctx context.Context, sc *types.SystemContext, goa
 				}
 			}
 			if !goal.pullAllPairs {
-				ir.newImageEvent(events.Pull, "")
+				ir.newImageEvent(ctx, events.Pull, "")
 				return []string{imageInfo.image}, nil
 			}
 			images = append(images, imageInfo.image)
@@ -373,7 +384,7 @@ func (ir *Runtime) doPullImage(ctx context.Context, sc *types.SystemContext, goa
 		return nil, errorhandling.JoinErrors(pullErrors)
 	}
 
-	ir.newImageEvent(events.Pull, images[0])
+	ir.newImageEvent(ctx, events.Pull, images[0])
 	return images, nil
 }
 
@@ -438,3 +449,59 @@ func checkRemoteImageForLabel(ctx context.Context, label string, imageInfo pullR
 	}
 	return errors.Errorf("%s has no label %s in %q", imageInfo.image, label, remoteInspect.Labels)
 }
+
+// hasNewerRemoteImage checks if the registry the local image was pulled from
+// serves a different image for inputName.  Only the manifest digest of the
+// remote image is requested (a HEAD request for registries), so no layer data
+// is transferred.  Images without a registry name, e.g. locally built ones,
+// are never considered outdated.
+func (ir *Runtime) hasNewerRemoteImage(ctx context.Context, img *Image, inputName, signaturePolicyPath, authfile string, dockerOptions *DockerRegistryOptions) (bool, error) {
+	search, err := decompose(inputName)
+	if err != nil {
+		// Most likely an image ID, which can't be checked remotely.
+		return false, nil
+	}
+	_, searchName, searchTagValue := search.suspiciousRefNameTagValuesForSearch()
+
+	var remoteName string
+	for _, name := range img.Names() {
+		d, err := decompose(name)
+		if err != nil {
+			continue
+		}
+		_, dName, dTagValue := d.suspiciousRefNameTagValuesForSearch()
+		if dTagValue != searchTagValue {
+			continue
+		}
+		if dName == searchName || strings.HasSuffix(dName, "/"+searchName) {
+			remoteName = name
+			break
+		}
+	}
+	if remoteName == "" || strings.HasPrefix(remoteName, DefaultLocalRegistry+"/") {
+		return false, nil
+	}
+
+	srcRef, err := docker.ParseReference("//" + remoteName)
+	if err != nil {
+		return false, err
+	}
+	sc := GetSystemContext(signaturePolicyPath, authfile, false)
+	if dockerOptions != nil {
+		sc = dockerOptions.GetSystemContext(sc, nil)
+	}
+	sc.SystemRegistriesConfPath = registries.SystemRegistriesConfPath()
+
+	remoteDigest, err := docker.GetDigest(ctx, sc, srcRef)
+	if err != nil {
+		return false, errors.Wrapf(err, "error getting digest of %s", remoteName)
+	}
+	for _, d := range img.Digests() {
+		if d == remoteDigest {
+			logrus.Debugf("Local image %s is up to date with %s", img.ID(), remoteName)
+			return false, nil
+		}
+	}
+	logrus.Debugf("Registry serves a different image for %s (%s)", remoteName, remoteDigest)
+	return true, nil
+}
@@ -0,0 +1,103 @@
+package image
+
+import (
+	"context"
+	"io"
+
+	"github.com/containers/image/v5/types"
+	"golang.org/x/time/rate"
+)
+
+// rateLimitedReference wraps the source reference of a copy operation and
+// throttles reading the blobs (i.e., the layers) of the image.  Limiting the
+// source throttles pulls as well as pushes.
+type rateLimitedReference struct {
+	types.ImageReference
+	limiters []*rate.Limiter
+}
+
+// rateLimitedSource is the image source of a rateLimitedReference.
+type rateLimitedSource struct {
+	types.ImageSource
+	limiters []*rate.Limiter
+}
+
+// rateLimitedReader throttles reading from the underlying reader by all
+// limiters.
+type rateLimitedReader struct {
+	io.ReadCloser
+	ctx      context.Context
+	limiters []*rate.Limiter
+}
+
+// newRateLimiter returns a limiter allowing to read bytesPerSecond bytes per
+// second.  Returns nil if bytesPerSecond is not positive.
+func newRateLimiter(bytesPerSecond int64) *rate.Limiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(bytesPerSecond), int(bytesPerSecond))
+}
+
+// SetCopyRateLimit sets the global limit, in bytes per second, for reading the
+// blobs of images copied by the runtime, e.g., during pull and push.  The
+// limit is shared by all concurrent copy operations.  Zero removes the limit.
+func (ir *Runtime) SetCopyRateLimit(bytesPerSecond int64) {
+	ir.copyRateLimiter = newRateLimiter(bytesPerSecond)
+}
+
+// rateLimitSource wraps src such that reading its blobs is limited by the
+// global limit of the runtime and the per-operation limit in dockerOptions.
+// src is returned unchanged if no limit is set.
+func (ir *Runtime) rateLimitSource(src types.ImageReference, dockerOptions *DockerRegistryOptions) types.ImageReference {
+	var limiters []*rate.Limiter
+	if ir.copyRateLimiter != nil {
+		limiters = append(limiters, ir.copyRateLimiter)
+	}
+	if dockerOptions != nil {
+		if limiter := newRateLimiter(dockerOptions.RateLimit); limiter != nil {
+			limiters = append(limiters, limiter)
+		}
+	}
+	if len(limiters) == 0 {
+		return src
+	}
+	return &rateLimitedReference{ImageReference: src, limiters: limiters}
+}
+
+// NewImageSource returns a rate-limited image source for the reference.
+func (r *rateLimitedReference) NewImageSource(ctx context.Context, sys *types.SystemContext) (types.ImageSource, error) {
+	src, err := r.ImageReference.NewImageSource(ctx, sys)
+	if err != nil {
+		return nil, err
+	}
+	return &rateLimitedSource{ImageSource: src, limiters: r.limiters}, nil
+}
+
+// GetBlob returns a rate-limited stream for the blob.
+func (s *rateLimitedSource) GetBlob(ctx context.Context, info types.BlobInfo, cache types.BlobInfoCache) (io.ReadCloser, int64, error) {
+	reader, size, err := s.ImageSource.GetBlob(ctx, info, cache)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &rateLimitedReader{ReadCloser: reader, ctx: ctx, limiters: s.limiters}, size, nil
+}
+
+// Read reads at most as many bytes as the most restrictive limiter allows at
+// once and waits for all limiters before returning.
+func (r *rateLimitedReader) Read(p []byte) (int, error) {
+	for _, limiter := range r.limiters {
+		if burst := limiter.Burst(); len(p) > burst {
+			p = p[:burst]
+		}
+	}
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		for _, limiter := range r.limiters {
+			if waitErr := limiter.WaitN(r.ctx, n); waitErr != nil {
+				return n, waitErr
+			}
+		}
+	}
+	return n, err
+}
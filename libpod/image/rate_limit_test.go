@@ -0,0 +1,50 @@
+package image
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/containers/image/v5/directory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestRateLimitedReader(t *testing.T) {
+	data := make([]byte, 2048)
+	reader := &rateLimitedReader{
+		ReadCloser: ioutil.NopCloser(bytes.NewReader(data)),
+		ctx:        context.Background(),
+		limiters:   []*rate.Limiter{newRateLimiter(1024)},
+	}
+
+	// The first second is covered by the burst, the remaining 1024 bytes
+	// take another second.
+	start := time.Now()
+	read, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, data, read)
+	assert.True(t, time.Since(start) >= 900*time.Millisecond, "reading took %v", time.Since(start))
+}
+
+func TestRateLimitSource(t *testing.T) {
+	src, err := directory.NewReference("/tmp")
+	require.NoError(t, err)
+
+	ir := &Runtime{}
+	assert.Equal(t, src, ir.rateLimitSource(src, nil))
+	assert.Equal(t, src, ir.rateLimitSource(src, &DockerRegistryOptions{}))
+
+	limited, ok := ir.rateLimitSource(src, &DockerRegistryOptions{RateLimit: 1024}).(*rateLimitedReference)
+	require.True(t, ok)
+	assert.Len(t, limited.limiters, 1)
+	assert.Equal(t, src.StringWithinTransport(), limited.StringWithinTransport())
+
+	ir.SetCopyRateLimit(4096)
+	limited, ok = ir.rateLimitSource(src, &DockerRegistryOptions{RateLimit: 1024}).(*rateLimitedReference)
+	require.True(t, ok)
+	assert.Len(t, limited.limiters, 2)
+}
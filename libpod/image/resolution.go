@@ -0,0 +1,43 @@
+package image
+
+import (
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/containers/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// traceStep records a lookup of name with method in trace, which may be nil.
+// img is the image found, if any.  Steps are logged at debug level, so they
+// also show up with --log-level=debug.
+func traceStep(trace *define.ImageResolutionTrace, method, name string, img *storage.Image, note string) {
+	s := define.ImageResolutionStep{Method: method, Name: name, Note: note}
+	if img != nil {
+		s.Found = true
+		s.ImageID = img.ID
+		s.ReadOnly = img.ReadOnly
+	}
+	if note != "" {
+		logrus.Debugf("Image resolution: %s lookup of %q: found=%t id=%q read-only=%t (%s)", method, name, s.Found, s.ImageID, s.ReadOnly, note)
+	} else {
+		logrus.Debugf("Image resolution: %s lookup of %q: found=%t id=%q read-only=%t", method, name, s.Found, s.ImageID, s.ReadOnly)
+	}
+	if trace != nil {
+		trace.Steps = append(trace.Steps, s)
+	}
+}
+
+// ExplainResolution resolves name to a local image like NewFromLocal and
+// returns how it was resolved.  The trace is returned even if the name cannot
+// be resolved, with Error set.
+func (ir *Runtime) ExplainResolution(name string) *define.ImageResolutionTrace {
+	trace := &define.ImageResolutionTrace{Input: name}
+	resolvedName, img, err := ir.resolveLocalImage(name, trace)
+	if err != nil {
+		trace.Error = err.Error()
+		return trace
+	}
+	trace.ResolvedName = resolvedName
+	trace.ImageID = img.ID
+	trace.Digest = img.Digest.String()
+	return trace
+}
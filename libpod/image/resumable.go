@@ -0,0 +1,446 @@
+package image
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/image/v5/pkg/docker/config"
+	"github.com/containers/image/v5/pkg/sysregistriesv2"
+	"github.com/containers/image/v5/pkg/tlsclientconfig"
+	"github.com/containers/image/v5/types"
+	"github.com/containers/storage/pkg/directory"
+	"github.com/docker/distribution/registry/client/auth"
+	"github.com/docker/distribution/registry/client/auth/challenge"
+	"github.com/docker/distribution/registry/client/transport"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// partialBlobsDir is the directory in the graph root of the store which holds
+// the blobs whose download from a registry was interrupted, named after their
+// digest.  Pulling a blob again resumes its download where it stopped.
+const partialBlobsDir = "libpod/partial-blobs"
+
+// resumableBlobMinSize is the size from which the download of a blob can be
+// resumed.  Smaller blobs are quicker to download again than to write twice.
+const resumableBlobMinSize = 32 * 1024 * 1024
+
+// resumableReference wraps the source reference of a pull from a registry and
+// keeps the large blobs of the image on disk while they are downloaded, so
+// that the download of a blob which is interrupted is resumed by the next pull
+// of the blob with an HTTP range request.
+type resumableReference struct {
+	types.ImageReference
+	dir string
+}
+
+// resumableSource is the image source of a resumableReference.
+type resumableSource struct {
+	types.ImageSource
+	named reference.Named
+	dir   string
+	sys   *types.SystemContext
+}
+
+// resumableReader reads the downloaded part of a blob followed by the rest of
+// the blob, which it appends to the partial blob.  The partial blob is removed
+// once the blob is read completely and matches its digest.
+type resumableReader struct {
+	reader   io.Reader
+	body     io.ReadCloser
+	file     *os.File
+	prefix   *os.File
+	path     string
+	digest   digest.Digest
+	digester digest.Digester
+	unlock   func()
+}
+
+// partialBlobsDir returns the directory of the partial blobs.
+func (ir *Runtime) partialBlobsDir() string {
+	return filepath.Join(ir.store.GraphRoot(), partialBlobsDir)
+}
+
+// resumableSource wraps src such that the download of its large blobs can be
+// resumed.  src is returned unchanged if it is not a reference to a registry.
+func (ir *Runtime) resumableSource(src types.ImageReference) types.ImageReference {
+	if src.Transport().Name() != DockerTransport || src.DockerReference() == nil {
+		return src
+	}
+	return &resumableReference{ImageReference: src, dir: ir.partialBlobsDir()}
+}
+
+// NewImageSource returns a resumable image source for the reference.
+func (r *resumableReference) NewImageSource(ctx context.Context, sys *types.SystemContext) (types.ImageSource, error) {
+	src, err := r.ImageReference.NewImageSource(ctx, sys)
+	if err != nil {
+		return nil, err
+	}
+	return &resumableSource{ImageSource: src, named: r.DockerReference(), dir: r.dir, sys: sys}, nil
+}
+
+// GetBlob returns a stream for the blob.  The download of a large blob resumes
+// from its partial blob, if any.  If the registry cannot resume it, the blob is
+// downloaded from the start.
+func (s *resumableSource) GetBlob(ctx context.Context, info types.BlobInfo, cache types.BlobInfoCache) (io.ReadCloser, int64, error) {
+	if info.Size < resumableBlobMinSize || len(info.URLs) > 0 || info.Digest.Validate() != nil {
+		return s.ImageSource.GetBlob(ctx, info, cache)
+	}
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		logrus.Debugf("Not keeping partial blob of %s: %v", info.Digest, err)
+		return s.ImageSource.GetBlob(ctx, info, cache)
+	}
+	path := filepath.Join(s.dir, info.Digest.Algorithm().String()+"-"+info.Digest.Hex())
+	unlock, ok := lockPartialBlob(path)
+	if !ok {
+		// Another pull is downloading the same blob.
+		return s.ImageSource.GetBlob(ctx, info, cache)
+	}
+
+	var (
+		offset int64
+		body   io.ReadCloser
+	)
+	if st, err := os.Stat(path); err == nil && st.Size() > 0 && st.Size() < info.Size {
+		offset = st.Size()
+		body, err = s.getBlobFrom(ctx, info.Digest, offset)
+		if err != nil {
+			logrus.Debugf("Downloading %s from the start: %v", info.Digest, err)
+			offset = 0
+		} else {
+			logrus.Debugf("Resuming download of %s at byte %d", info.Digest, offset)
+		}
+	}
+	if body == nil {
+		var err error
+		body, _, err = s.ImageSource.GetBlob(ctx, info, cache)
+		if err != nil {
+			unlock()
+			return nil, 0, err
+		}
+	}
+
+	reader, err := newResumableReader(path, offset, body, info.Digest, unlock)
+	if err != nil {
+		logrus.Debugf("Not keeping partial blob of %s: %v", info.Digest, err)
+		unlock()
+		if offset > 0 {
+			body.Close()
+			return s.ImageSource.GetBlob(ctx, info, cache)
+		}
+		return body, info.Size, nil
+	}
+	return reader, info.Size, nil
+}
+
+// newResumableReader returns a reader of the first offset bytes of the partial
+// blob at path followed by body, which is appended to the partial blob.
+func newResumableReader(path string, offset int64, body io.ReadCloser, d digest.Digest, unlock func()) (*resumableReader, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := file.Truncate(offset); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+	r := &resumableReader{
+		body:     body,
+		file:     file,
+		path:     path,
+		digest:   d,
+		digester: d.Algorithm().Digester(),
+		unlock:   unlock,
+	}
+	r.reader = io.TeeReader(body, file)
+	if offset > 0 {
+		prefix, err := os.Open(path)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		r.prefix = prefix
+		r.reader = io.MultiReader(io.LimitReader(prefix, offset), r.reader)
+	}
+	return r, nil
+}
+
+// Read reads the blob and verifies its digest once it is read completely.
+func (r *resumableReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		_, _ = r.digester.Hash().Write(p[:n])
+	}
+	if err == io.EOF {
+		if removeErr := os.Remove(r.path); removeErr != nil && !os.IsNotExist(removeErr) {
+			logrus.Errorf("Error removing partial blob %s: %v", r.path, removeErr)
+		}
+		if r.digester.Digest() != r.digest {
+			return n, errors.Errorf("error downloading blob %s: got digest %s", r.digest, r.digester.Digest())
+		}
+	}
+	return n, err
+}
+
+// Close closes the stream of the blob and keeps what was downloaded of it.
+func (r *resumableReader) Close() error {
+	err := r.body.Close()
+	if syncErr := r.file.Sync(); syncErr != nil && !os.IsNotExist(syncErr) {
+		logrus.Debugf("Error syncing partial blob %s: %v", r.path, syncErr)
+	}
+	r.file.Close()
+	if r.prefix != nil {
+		r.prefix.Close()
+	}
+	r.unlock()
+	return err
+}
+
+// lockPartialBlob prevents concurrent pulls from writing the partial blob at
+// path.  It returns false if the partial blob is locked by a running process.
+func lockPartialBlob(path string) (func(), bool) {
+	lockPath := path + ".lock"
+	for i := 0; i < 2; i++ {
+		file, err := os.OpenFile(lockPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err == nil {
+			_, _ = file.WriteString(strconv.Itoa(os.Getpid()))
+			file.Close()
+			return func() {
+				if err := os.Remove(lockPath); err != nil && !os.IsNotExist(err) {
+					logrus.Errorf("Error removing lock of partial blob %s: %v", path, err)
+				}
+			}, true
+		}
+		if !os.IsExist(err) {
+			logrus.Debugf("Error locking partial blob %s: %v", path, err)
+			return nil, false
+		}
+		// Take over the lock of a process which no longer exists.
+		b, err := ioutil.ReadFile(lockPath)
+		if err != nil {
+			return nil, false
+		}
+		if pid, err := strconv.Atoi(string(b)); err == nil && processAlive(pid) {
+			return nil, false
+		}
+		if err := os.Remove(lockPath); err != nil && !os.IsNotExist(err) {
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// clearPartialBlobs removes the partial blobs in dir and returns the disk space
+// reclaimed.
+func clearPartialBlobs(dir string) (int64, error) {
+	size, err := directory.Size(dir)
+	if err != nil {
+		if os.IsNotExist(errors.Cause(err)) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return 0, errors.Wrapf(err, "error removing partial blobs in %s", dir)
+	}
+	return size, nil
+}
+
+// getBlobFrom requests the blob starting at offset from the endpoints the
+// image is pulled from, as configured in registries.conf: the mirrors of the
+// registry first, then the registry itself.
+func (s *resumableSource) getBlobFrom(ctx context.Context, d digest.Digest, offset int64) (io.ReadCloser, error) {
+	registry, err := sysregistriesv2.FindRegistry(s.sys, s.named.Name())
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading registries configuration")
+	}
+	if registry == nil {
+		registry = &sysregistriesv2.Registry{
+			Endpoint: sysregistriesv2.Endpoint{Location: s.named.String()},
+			Prefix:   s.named.String(),
+		}
+	}
+	pullSources, err := registry.PullSourcesFromReference(s.named)
+	if err != nil {
+		return nil, err
+	}
+	err = errors.Errorf("no endpoint configured for %s", s.named)
+	for _, pullSource := range pullSources {
+		var body io.ReadCloser
+		if body, err = s.getBlobFromEndpoint(ctx, pullSource, d, offset); err == nil {
+			return body, nil
+		}
+		logrus.Debugf("Resuming download of %s from %s failed: %v", d, pullSource.Reference, err)
+	}
+	return nil, err
+}
+
+// getBlobFromEndpoint requests the blob starting at offset from one endpoint
+// of the registry.  Insecure endpoints are tried with HTTP if HTTPS fails.
+func (s *resumableSource) getBlobFromEndpoint(ctx context.Context, pullSource sysregistriesv2.PullSource, d digest.Digest, offset int64) (io.ReadCloser, error) {
+	registry := reference.Domain(pullSource.Reference)
+	host := registry
+	if host == "docker.io" {
+		host = "registry-1.docker.io"
+	}
+	insecure := pullSource.Endpoint.Insecure
+	if s.sys != nil && s.sys.DockerInsecureSkipTLSVerify != types.OptionalBoolUndefined {
+		insecure = s.sys.DockerInsecureSkipTLSVerify == types.OptionalBoolTrue
+	}
+	base, err := registryTransport(s.sys, registry, insecure)
+	if err != nil {
+		return nil, err
+	}
+	schemes := []string{"https"}
+	if insecure {
+		schemes = append(schemes, "http")
+	}
+	for _, scheme := range schemes {
+		endpoint := scheme + "://" + host
+		var client *http.Client
+		if client, err = s.registryClient(ctx, base, endpoint, pullSource.Reference); err != nil {
+			logrus.Debugf("Error pinging registry %s: %v", endpoint, err)
+			continue
+		}
+		return rangeRequest(ctx, client, fmt.Sprintf("%s/v2/%s/blobs/%s", endpoint, reference.Path(pullSource.Reference), d), offset)
+	}
+	return nil, err
+}
+
+// rangeRequest requests url starting at offset and returns the body of the
+// response if the server returned the requested range.
+func rangeRequest(ctx context.Context, client *http.Client, url string, offset int64) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusPartialContent {
+		res.Body.Close()
+		return nil, errors.Errorf("%s does not resume the download: %s", req.URL.Host, res.Status)
+	}
+	if start, ok := contentRangeStart(res.Header.Get("Content-Range")); !ok || start != offset {
+		res.Body.Close()
+		return nil, errors.Errorf("%s returned an unexpected range %q", req.URL.Host, res.Header.Get("Content-Range"))
+	}
+	return res.Body, nil
+}
+
+// contentRangeStart returns the first byte of a Content-Range header.
+func contentRangeStart(header string) (int64, bool) {
+	if !strings.HasPrefix(header, "bytes ") {
+		return 0, false
+	}
+	split := strings.SplitN(strings.TrimPrefix(header, "bytes "), "-", 2)
+	if len(split) != 2 {
+		return 0, false
+	}
+	start, err := strconv.ParseInt(split[0], 10, 64)
+	return start, err == nil
+}
+
+// registryTransport returns an HTTP transport for the registry configured with
+// the certificates of the registry.
+func registryTransport(sys *types.SystemContext, registry string, insecure bool) (*http.Transport, error) {
+	tlsConfig := &tls.Config{}
+	if err := tlsclientconfig.SetupCertificates(registryCertDir(sys, registry), tlsConfig); err != nil {
+		return nil, err
+	}
+	tlsConfig.InsecureSkipVerify = insecure
+	transport := tlsclientconfig.NewTransport()
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
+
+// registryCertDir returns the directory of the certificates of the registry.
+func registryCertDir(sys *types.SystemContext, registry string) string {
+	if sys != nil && sys.DockerCertPath != "" {
+		return sys.DockerCertPath
+	}
+	if sys != nil && sys.DockerPerHostCertDirPath != "" {
+		return filepath.Join(sys.DockerPerHostCertDirPath, registry)
+	}
+	dir := filepath.Join("/etc/containers/certs.d", registry)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		dir = filepath.Join("/etc/docker/certs.d", registry)
+	}
+	return dir
+}
+
+// registryClient pings the registry at endpoint and returns an HTTP client
+// answering the authentication challenges of the registry for pulling named.
+// The credentials given in the system context are only sent to the registry
+// of the image, not to its mirrors.
+func (s *resumableSource) registryClient(ctx context.Context, base http.RoundTripper, endpoint string, named reference.Named) (*http.Client, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/v2/", nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := (&http.Client{Transport: base}).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	res.Body.Close()
+	challenges := challenge.NewSimpleManager()
+	if err := challenges.AddResponse(res); err != nil {
+		return nil, err
+	}
+
+	sys := s.sys
+	if sys != nil && reference.Domain(named) != reference.Domain(s.named) {
+		mirrorSys := *sys
+		mirrorSys.DockerAuthConfig = nil
+		mirrorSys.DockerBearerRegistryToken = ""
+		sys = &mirrorSys
+	}
+	if sys != nil && sys.DockerBearerRegistryToken != "" {
+		header := http.Header{"Authorization": {"Bearer " + sys.DockerBearerRegistryToken}}
+		return &http.Client{Transport: transport.NewTransport(base, transport.NewHeaderRequestModifier(header))}, nil
+	}
+	creds, err := config.GetCredentials(sys, reference.Domain(named))
+	if err != nil {
+		return nil, err
+	}
+	store := registryCredentials(creds)
+	authorizer := auth.NewAuthorizer(challenges,
+		auth.NewTokenHandler(base, store, reference.Path(named), "pull"),
+		auth.NewBasicHandler(store))
+	return &http.Client{Transport: transport.NewTransport(base, authorizer)}, nil
+}
+
+// registryCredentials are the credentials of the user for a registry, used to
+// answer its authentication challenges.
+type registryCredentials types.DockerAuthConfig
+
+// Basic returns the user name and password for basic authentication.
+func (c registryCredentials) Basic(*url.URL) (string, string) {
+	return c.Username, c.Password
+}
+
+// RefreshToken returns the identity token, used as OAuth2 refresh token.
+func (c registryCredentials) RefreshToken(*url.URL, string) string {
+	return c.IdentityToken
+}
+
+// SetRefreshToken ignores the refresh tokens returned by the registry, which
+// are not stored.
+func (c registryCredentials) SetRefreshToken(*url.URL, string, string) {}
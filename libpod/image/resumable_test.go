@@ -0,0 +1,181 @@
+package image
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/image/v5/types"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// interruptedSource is an image source whose blob downloads fail halfway.
+type interruptedSource struct {
+	types.ImageSource
+	data []byte
+}
+
+func (s *interruptedSource) GetBlob(ctx context.Context, info types.BlobInfo, cache types.BlobInfoCache) (io.ReadCloser, int64, error) {
+	reader := io.MultiReader(bytes.NewReader(s.data[:len(s.data)/2]), &failingReader{})
+	return ioutil.NopCloser(reader), int64(len(s.data)), nil
+}
+
+type failingReader struct{}
+
+func (*failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset by peer")
+}
+
+// newTestRegistry starts a registry serving data as the blob of foo/bar,
+// which requires a bearer token and records the ranges requested.
+func newTestRegistry(data []byte, useTLS bool) (*httptest.Server, *[]string) {
+	d := digest.FromBytes(data)
+	var ranges []string
+	var server *httptest.Server
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			fmt.Fprint(w, `{"token": "secret"}`)
+		case r.URL.Path == "/v2/" || r.URL.Path == "/v2/foo/bar/blobs/"+d.String():
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test"`, server.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if r.URL.Path == "/v2/" {
+				return
+			}
+			ranges = append(ranges, r.Header.Get("Range"))
+			http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(data))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	if useTLS {
+		server = httptest.NewTLSServer(handler)
+	} else {
+		server = httptest.NewServer(handler)
+	}
+	return server, &ranges
+}
+
+// testResumeBlob interrupts the download of data from the image source named
+// and checks that the next download resumes it with a range request.
+func testResumeBlob(t *testing.T, data []byte, name string, sys *types.SystemContext, ranges *[]string) {
+	named, err := reference.ParseNormalizedNamed(name)
+	require.NoError(t, err)
+	dir, err := ioutil.TempDir("", "partial-blobs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	sys.AuthFilePath = filepath.Join(dir, "auth.json")
+	src := &resumableSource{
+		ImageSource: &interruptedSource{data: data},
+		named:       named,
+		dir:         dir,
+		sys:         sys,
+	}
+	d := digest.FromBytes(data)
+	info := types.BlobInfo{Digest: d, Size: int64(len(data))}
+	partial := filepath.Join(dir, "sha256-"+d.Hex())
+
+	// The first download is interrupted halfway and kept.
+	reader, _, err := src.GetBlob(context.Background(), info, nil)
+	require.NoError(t, err)
+	_, err = ioutil.ReadAll(reader)
+	assert.Error(t, err)
+	require.NoError(t, reader.Close())
+	st, err := os.Stat(partial)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(data)/2), st.Size())
+
+	// The second download resumes from the registry.
+	reader, size, err := src.GetBlob(context.Background(), info, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(data)), size)
+	read, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	assert.True(t, bytes.Equal(data, read))
+	assert.Equal(t, []string{fmt.Sprintf("bytes=%d-", len(data)/2)}, *ranges)
+	_, err = os.Stat(partial)
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(partial + ".lock")
+	assert.True(t, os.IsNotExist(err))
+}
+
+// writeRegistriesConf writes a registries.conf with the given content to a
+// temporary file and returns its path.
+func writeRegistriesConf(t *testing.T, content string) string {
+	file, err := ioutil.TempFile("", "registries.conf")
+	require.NoError(t, err)
+	defer file.Close()
+	_, err = file.WriteString(content)
+	require.NoError(t, err)
+	return file.Name()
+}
+
+func testBlobData() []byte {
+	data := make([]byte, resumableBlobMinSize+1024)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	return data
+}
+
+func TestResumableSourceGetBlob(t *testing.T) {
+	data := testBlobData()
+	server, ranges := newTestRegistry(data, true)
+	defer server.Close()
+
+	sys := &types.SystemContext{DockerInsecureSkipTLSVerify: types.OptionalBoolTrue}
+	testResumeBlob(t, data, strings.TrimPrefix(server.URL, "https://")+"/foo/bar", sys, ranges)
+}
+
+func TestResumableSourceGetBlobMirror(t *testing.T) {
+	data := testBlobData()
+	server, ranges := newTestRegistry(data, true)
+	defer server.Close()
+
+	conf := writeRegistriesConf(t, fmt.Sprintf(`
+[[registry]]
+location = "registry.invalid"
+
+[[registry.mirror]]
+location = "%s"
+insecure = true
+`, strings.TrimPrefix(server.URL, "https://")))
+	defer os.Remove(conf)
+
+	sys := &types.SystemContext{SystemRegistriesConfPath: conf, SystemRegistriesConfDirPath: "/does/not/exist"}
+	testResumeBlob(t, data, "registry.invalid/foo/bar", sys, ranges)
+}
+
+func TestResumableSourceGetBlobInsecure(t *testing.T) {
+	data := testBlobData()
+	server, ranges := newTestRegistry(data, false)
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	conf := writeRegistriesConf(t, fmt.Sprintf(`
+[[registry]]
+location = "%s"
+insecure = true
+`, host))
+	defer os.Remove(conf)
+
+	sys := &types.SystemContext{SystemRegistriesConfPath: conf, SystemRegistriesConfDirPath: "/does/not/exist"}
+	testResumeBlob(t, data, host+"/foo/bar", sys, ranges)
+}
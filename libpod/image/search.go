@@ -8,6 +8,7 @@ import (
 	"sync"
 
 	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/docker/reference"
 	"github.com/containers/image/v5/transports/alltransports"
 	"github.com/containers/image/v5/types"
 	sysreg "github.com/containers/podman/v2/pkg/registries"
@@ -38,6 +39,9 @@ type SearchResult struct {
 	Automated string
 	// Tag is the image tag
 	Tag string
+	// Digest is the manifest digest of the tag. Only set when listing
+	// tags with TagDigests.
+	Digest string
 }
 
 // SearchOptions are used to control the behaviour of SearchImages.
@@ -51,10 +55,16 @@ type SearchOptions struct {
 	NoTrunc bool
 	// Authfile is the path to the authentication file.
 	Authfile string
+	// CertDirPath is the path to a directory with the TLS certificates of
+	// the registry.  Overrides the per-registry certs.d directories.
+	CertDirPath string
 	// InsecureSkipTLSVerify allows to skip TLS verification.
 	InsecureSkipTLSVerify types.OptionalBool
 	// ListTags returns the search result with available tags
 	ListTags bool
+	// TagDigests looks up the manifest digests of the listed tags.  Only
+	// used in combination with ListTags.
+	TagDigests bool
 }
 
 // SearchFilter allows filtering the results of SearchImages.
@@ -149,6 +159,7 @@ func searchImageInRegistry(term string, registry string, options SearchOptions)
 
 	sc := GetSystemContext("", options.Authfile, false)
 	sc.DockerInsecureSkipTLSVerify = options.InsecureSkipTLSVerify
+	sc.DockerCertPath = options.CertDirPath
 	// FIXME: Set this more globally.  Probably no reason not to have it in
 	// every types.SystemContext, and to compute the value just once in one
 	// place.
@@ -233,6 +244,8 @@ func searchRepositoryTags(registry, term string, sc *types.SystemContext, option
 			return nil, errors.Errorf("reference %q must be a docker reference", term)
 		}
 	}
+	// GetRepositoryTags follows the "Link" header of the registry, so we
+	// get all tags even if the registry caps the size of a page.
 	tags, err := docker.GetRepositoryTags(context.TODO(), sc, imageRef)
 	if err != nil {
 		return nil, errors.Errorf("error getting repository tags: %v", err)
@@ -255,9 +268,52 @@ func searchRepositoryTags(registry, term string, sc *types.SystemContext, option
 		}
 		paramsArr = append(paramsArr, params)
 	}
+	if options.TagDigests {
+		lookupTagDigests(imageRef.DockerReference(), sc, paramsArr)
+	}
 	return paramsArr, nil
 }
 
+// lookupTagDigests sets the digests of the specified results.  The digests are
+// looked up with HEAD requests in parallel.  Registries not sending the digest
+// in the response headers are skipped, as we do not want to pull each manifest.
+func lookupTagDigests(named reference.Named, sc *types.SystemContext, results []SearchResult) {
+	sem := semaphore.NewWeighted(maxParallelSearches)
+	wg := sync.WaitGroup{}
+
+	ctx := context.Background()
+	lookupDigestHelper := func(index int) {
+		defer sem.Release(1)
+		defer wg.Done()
+		tagged, err := reference.WithTag(named, results[index].Tag)
+		if err != nil {
+			logrus.Debugf("error parsing tag %q: %v", results[index].Tag, err)
+			return
+		}
+		ref, err := docker.NewReference(tagged)
+		if err != nil {
+			logrus.Debugf("error creating reference for %q: %v", tagged.String(), err)
+			return
+		}
+		digest, err := docker.GetDigest(ctx, sc, ref)
+		if err != nil {
+			logrus.Debugf("error looking up digest of %q: %v", tagged.String(), err)
+			return
+		}
+		results[index].Digest = digest.String()
+	}
+
+	for i := range results {
+		if err := sem.Acquire(ctx, 1); err != nil {
+			logrus.Errorf("error acquiring semaphore: %v", err)
+			break
+		}
+		wg.Add(1)
+		go lookupDigestHelper(i)
+	}
+	wg.Wait()
+}
+
 // ParseSearchFilter turns the filter into a SearchFilter that can be used for
 // searching images.
 func ParseSearchFilter(filter []string) (*SearchFilter, error) {
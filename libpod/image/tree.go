@@ -3,8 +3,10 @@ package image
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/containers/podman/v2/libpod/define"
 	"github.com/docker/go-units"
 	"github.com/pkg/errors"
 )
@@ -19,35 +21,108 @@ type tree struct {
 	img       *Image
 	imageInfo *InfoImage
 	layerInfo map[string]*LayerInfo
-	sb        *strings.Builder
+	// sharedWith maps the layers to the names of the other images using
+	// them.  It is only set for the report of shared layers.
+	sharedWith map[string][]string
+	sb         *strings.Builder
 }
 
 // GenerateTree creates an image tree string representation for displaying it
-// to the user.
-func (i *Image) GenerateTree(whatRequires bool) (string, error) {
+// to the user.  If shared is set, the layers of the image are listed with the
+// other images using them, along with the size of the layers used by no other
+// image, which is reclaimed when removing the image.
+func (i *Image) GenerateTree(whatRequires, shared bool) (string, error) {
+	if whatRequires && shared {
+		return "", errors.Wrap(define.ErrInvalidArg, "the child images and the shared layers of an image cannot be shown together")
+	}
 	// Fetch map of image-layers, which is used for printing output.
 	layerInfo, err := GetLayersMapWithImageInfo(i.imageruntime)
 	if err != nil {
 		return "", errors.Wrapf(err, "error while retrieving layers of image %q", i.InputName)
 	}
-
-	// Create an imageInfo and fill the image and layer info
-	imageInfo := &InfoImage{
-		ID:   i.ID(),
-		Tags: i.Names(),
+	var users map[string][]layerUser
+	if shared {
+		if users, err = layerUsers(i.imageruntime, layerInfo); err != nil {
+			return "", err
+		}
 	}
-
-	if err := BuildImageHierarchyMap(imageInfo, layerInfo, i.TopLayer()); err != nil {
+	tree, err := newTree(i, layerInfo, users)
+	if err != nil {
 		return "", err
 	}
-	sb := &strings.Builder{}
-	tree := &tree{i, imageInfo, layerInfo, sb}
 	if err := tree.print(whatRequires); err != nil {
 		return "", err
 	}
 	return tree.string(), nil
 }
 
+// GenerateSharedTree creates the image tree string representations of all
+// images in the store, with the layers of each image listed with the other
+// images using them.  The images are sorted by the size of the layers used by
+// no other image, which is reclaimed when removing the image, largest first.
+func (ir *Runtime) GenerateSharedTree() (string, error) {
+	imgs, err := ir.GetImages()
+	if err != nil {
+		return "", err
+	}
+	layerInfo, err := GetLayersMapWithImageInfo(ir)
+	if err != nil {
+		return "", errors.Wrapf(err, "error while retrieving layers of images")
+	}
+	users, err := layerUsers(ir, layerInfo)
+	if err != nil {
+		return "", err
+	}
+	trees := make([]*tree, 0, len(imgs))
+	for _, img := range imgs {
+		tree, err := newTree(img, layerInfo, users)
+		if err != nil {
+			return "", err
+		}
+		trees = append(trees, tree)
+	}
+	sort.SliceStable(trees, func(i, j int) bool {
+		return trees[i].uniqueSize() > trees[j].uniqueSize()
+	})
+	sb := &strings.Builder{}
+	for n, tree := range trees {
+		if err := tree.print(false); err != nil {
+			return "", err
+		}
+		if n > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(tree.string())
+	}
+	return sb.String(), nil
+}
+
+// newTree returns the tree of img.  If users is set, the layers of the image
+// are listed with the other images using them.
+func newTree(img *Image, layerInfo map[string]*LayerInfo, users map[string][]layerUser) (*tree, error) {
+	// Create an imageInfo and fill the image and layer info
+	imageInfo := &InfoImage{
+		ID:   img.ID(),
+		Tags: img.Names(),
+	}
+
+	if err := BuildImageHierarchyMap(imageInfo, layerInfo, img.TopLayer()); err != nil {
+		return nil, err
+	}
+	tree := &tree{img: img, imageInfo: imageInfo, layerInfo: layerInfo, sb: &strings.Builder{}}
+	if users != nil {
+		tree.sharedWith = make(map[string][]string)
+		for _, l := range imageInfo.Layers {
+			for _, user := range users[l.ID] {
+				if user.id != img.ID() {
+					tree.sharedWith[l.ID] = append(tree.sharedWith[l.ID], user.name)
+				}
+			}
+		}
+	}
+	return tree, nil
+}
+
 func (t *tree) string() string {
 	return t.sb.String()
 }
@@ -61,6 +136,9 @@ func (t *tree) print(whatRequires bool) error {
 	fmt.Fprintf(t.sb, "Image ID: %s\n", t.imageInfo.ID[:12])
 	fmt.Fprintf(t.sb, "Tags:     %s\n", t.imageInfo.Tags)
 	fmt.Fprintf(t.sb, "Size:     %v\n", units.HumanSizeWithPrecision(float64(*size), 4))
+	if t.sharedWith != nil {
+		fmt.Fprintf(t.sb, "Unique:   %v\n", units.HumanSizeWithPrecision(float64(t.uniqueSize()), 4))
+	}
 	if t.img.TopLayer() != "" {
 		fmt.Fprintf(t.sb, "Image Layers\n")
 	} else {
@@ -133,6 +211,52 @@ func (t *tree) printImageHierarchy(imageInfo *InfoImage) {
 		if count == len(imageInfo.Layers)-1 {
 			intend = lastItem
 		}
+		if users := t.sharedWith[l.ID]; len(users) > 0 {
+			tags += fmt.Sprintf(" Shared with: %s", strings.Join(users, ", "))
+		}
 		fmt.Fprintf(t.sb, "%s ID: %s Size: %7v%s\n", intend, l.ID[:12], units.HumanSizeWithPrecision(float64(l.Size), 4), tags)
 	}
 }
+
+// uniqueSize returns the size of the layers of the image which are used by no
+// other image.
+func (t *tree) uniqueSize() int64 {
+	var unique int64
+	for _, l := range t.imageInfo.Layers {
+		if len(t.sharedWith[l.ID]) == 0 {
+			unique += l.Size
+		}
+	}
+	return unique
+}
+
+// layerUser is an image built on a layer.
+type layerUser struct {
+	id   string
+	name string
+}
+
+// layerUsers maps the layers in the store to the images which are built on
+// them.  Images without a name are listed by their short ID.
+func layerUsers(ir *Runtime, layerMap map[string]*LayerInfo) (map[string][]layerUser, error) {
+	imgs, err := ir.store.Images()
+	if err != nil {
+		return nil, err
+	}
+	users := make(map[string][]layerUser)
+	for _, img := range imgs {
+		user := layerUser{id: img.ID, name: img.ID[:12]}
+		if len(img.Names) > 0 {
+			user.name = img.Names[0]
+		}
+		for layerID := img.TopLayer; layerID != ""; {
+			ll, ok := layerMap[layerID]
+			if !ok {
+				return nil, fmt.Errorf("lookup error: layerid  %s not found", layerID)
+			}
+			users[layerID] = append(users[layerID], user)
+			layerID = ll.ParentID
+		}
+	}
+	return users, nil
+}
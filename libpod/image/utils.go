@@ -17,8 +17,9 @@ import (
 )
 
 // findImageInRepotags takes an imageParts struct and searches images' repotags for
-// a match on name:tag
-func findImageInRepotags(search imageParts, images []*Image) (*storage.Image, error) {
+// a match on name:tag.  The candidates considered are recorded in trace, which
+// may be nil.
+func findImageInRepotags(search imageParts, images []*Image, trace *define.ImageResolutionTrace) (*storage.Image, error) {
 	_, searchName, searchSuspiciousTagValueForSearch := search.suspiciousRefNameTagValuesForSearch()
 	type Candidate struct {
 		name  string
@@ -41,10 +42,12 @@ func findImageInRepotags(search imageParts, images []*Image) (*storage.Image, er
 					name:  name,
 					image: image,
 				})
+				traceStep(trace, define.ImageResolveRepoTag, name, image.image, "candidate")
 			}
 		}
 	}
 	if len(candidates) == 0 {
+		traceStep(trace, define.ImageResolveRepoTag, searchName, nil, "no repotag matches")
 		return nil, errors.Errorf("unable to find a name and tag match for %s in repotags", searchName)
 	}
 
@@ -66,6 +69,7 @@ func findImageInRepotags(search imageParts, images []*Image) (*storage.Image, er
 		}
 		// If only one name used and have read/write image return it
 		if len(names) == 1 && rwImageCnt == 1 {
+			traceStep(trace, define.ImageResolveRepoTag, candidates[0].name, rwImage.image, "chosen as the only read/write image of the candidates")
 			return rwImage.image, nil
 		}
 		keys := []string{}
@@ -73,11 +77,14 @@ func findImageInRepotags(search imageParts, images []*Image) (*storage.Image, er
 			keys = append(keys, k)
 		}
 		if rwImageCnt > 1 {
+			traceStep(trace, define.ImageResolveRepoTag, searchName, nil, "ambiguous: multiple read/write images match")
 			return nil, errors.Wrapf(define.ErrMultipleImages, "found multiple read/write images %s", strings.Join(keys, ","))
 		} else {
+			traceStep(trace, define.ImageResolveRepoTag, searchName, nil, "ambiguous: multiple read-only images match")
 			return nil, errors.Wrapf(define.ErrMultipleImages, "found multiple read/only images %s", strings.Join(keys, ","))
 		}
 	}
+	traceStep(trace, define.ImageResolveRepoTag, candidates[0].name, candidates[0].image.image, "chosen as the only candidate")
 	return candidates[0].image.image, nil
 }
 
@@ -7,6 +7,7 @@ import (
 	"github.com/containers/podman/v2/pkg/registrar"
 	"github.com/containers/storage/pkg/truncindex"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 // TODO: Maybe separate idIndex for pod/containers
@@ -34,6 +35,8 @@ type InMemoryState struct {
 	ctrNetworks   map[string][]string
 	// Maps container ID to network name to list of aliases.
 	ctrNetworkAliases map[string]map[string][]string
+	// Maps reserved name to its reservation.
+	nameReservations map[string]*define.NameReservation
 	// Global name registry - ensures name uniqueness and performs lookups.
 	nameIndex *registrar.Registrar
 	// Global ID registry - ensures ID uniqueness and performs lookups.
@@ -71,6 +74,8 @@ func NewInMemoryState() (State, error) {
 	state.ctrNetworks = make(map[string][]string)
 	state.ctrNetworkAliases = make(map[string]map[string][]string)
 
+	state.nameReservations = make(map[string]*define.NameReservation)
+
 	state.nameIndex = registrar.NewRegistrar()
 	state.idIndex = truncindex.NewTruncIndex([]string{})
 
@@ -325,6 +330,11 @@ func (s *InMemoryState) AddContainer(ctr *Container) error {
 		return errors.Wrapf(err, "error registering container name %s", ctr.Name())
 	}
 
+	if err := s.claimName(ctr.Name(), ctr.config.Owner); err != nil {
+		s.nameIndex.Release(ctr.Name())
+		return err
+	}
+
 	if err := s.idIndex.Add(ctr.ID()); err != nil {
 		s.nameIndex.Release(ctr.Name())
 		return errors.Wrapf(err, "error registering container ID %s", ctr.ID())
@@ -377,6 +387,22 @@ func (s *InMemoryState) AddContainer(ctr *Container) error {
 	return nil
 }
 
+// AddContainers adds multiple containers to the state
+// If adding any of them fails, the containers already added are removed again
+func (s *InMemoryState) AddContainers(ctrs []*Container) error {
+	for i, ctr := range ctrs {
+		if err := s.AddContainer(ctr); err != nil {
+			for j := i - 1; j >= 0; j-- {
+				if rmErr := s.RemoveContainer(ctrs[j]); rmErr != nil {
+					logrus.Errorf("Error removing container %s from state: %v", ctrs[j].ID(), rmErr)
+				}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
 // RemoveContainer removes a container from the state
 // The container will only be removed from the state, not from the pod the container belongs to
 func (s *InMemoryState) RemoveContainer(ctr *Container) error {
@@ -520,6 +546,12 @@ func (s *InMemoryState) AllContainers() ([]*Container, error) {
 	return ctrs, nil
 }
 
+// AllContainersWithState retrieves all containers from the state.  The state
+// of the in-memory containers is always loaded.
+func (s *InMemoryState) AllContainersWithState() ([]*Container, error) {
+	return s.AllContainers()
+}
+
 // Get all networks this container is present in.
 func (s *InMemoryState) GetNetworks(ctr *Container) ([]string, error) {
 	if !ctr.valid {
@@ -828,6 +860,55 @@ func (s *InMemoryState) RewriteContainerConfig(ctr *Container, newCfg *Container
 	return nil
 }
 
+// SafeRewriteContainerConfig rewrites a container's configuration and changes
+// its name.  It is safe to change the name with this function.
+func (s *InMemoryState) SafeRewriteContainerConfig(ctr *Container, oldName, newName string, newCfg *ContainerConfig) error {
+	if !ctr.valid {
+		return define.ErrCtrRemoved
+	}
+
+	if newName != "" && newCfg.Name != newName {
+		return errors.Wrapf(define.ErrInvalidArg, "new name %s for container %s must match name in given container config", newName, ctr.ID())
+	}
+	if newName != "" && oldName == "" {
+		return errors.Wrapf(define.ErrInvalidArg, "must provide old name for container %s if doing a rename", ctr.ID())
+	}
+
+	// If the container does not exist, return error
+	stateCtr, ok := s.containers[ctr.ID()]
+	if !ok {
+		ctr.valid = false
+		return errors.Wrapf(define.ErrNoSuchCtr, "container with ID %s not found in state", ctr.ID())
+	}
+
+	if newName != "" && newName != oldName {
+		if err := s.nameIndex.Reserve(newName, ctr.ID()); err != nil {
+			return errors.Wrapf(err, "error registering container name %s", newName)
+		}
+		if err := s.claimName(newName, newCfg.Owner); err != nil {
+			s.nameIndex.Release(newName)
+			return err
+		}
+		if ctr.config.Namespace != "" {
+			nsIndex, ok := s.namespaceIndexes[ctr.config.Namespace]
+			if !ok {
+				s.nameIndex.Release(newName)
+				return errors.Wrapf(define.ErrInternal, "namespace %s does not exist", ctr.config.Namespace)
+			}
+			if err := nsIndex.nameIndex.Reserve(newName, ctr.ID()); err != nil {
+				s.nameIndex.Release(newName)
+				return errors.Wrapf(err, "error registering container name %s", newName)
+			}
+			nsIndex.nameIndex.Release(oldName)
+		}
+		s.nameIndex.Release(oldName)
+	}
+
+	stateCtr.config = newCfg
+
+	return nil
+}
+
 // RewritePodConfig rewrites a pod's configuration.
 // This function is DANGEROUS, even with in-memory state.
 // Please read the full comment on it in state.go before using it.
@@ -848,6 +929,55 @@ func (s *InMemoryState) RewritePodConfig(pod *Pod, newCfg *PodConfig) error {
 	return nil
 }
 
+// SafeRewritePodConfig rewrites a pod's configuration and changes its name.
+// It's safe to use for renaming pods, unlike RewritePodConfig.
+func (s *InMemoryState) SafeRewritePodConfig(pod *Pod, oldName, newName string, newCfg *PodConfig) error {
+	if !pod.valid {
+		return define.ErrPodRemoved
+	}
+
+	if newName != "" && newCfg.Name != newName {
+		return errors.Wrapf(define.ErrInvalidArg, "new name %s for pod %s must match name in given pod config", newName, pod.ID())
+	}
+	if newName != "" && oldName == "" {
+		return errors.Wrapf(define.ErrInvalidArg, "must provide old name for pod %s if doing a rename", pod.ID())
+	}
+
+	// If the pod does not exist, return error
+	statePod, ok := s.pods[pod.ID()]
+	if !ok {
+		pod.valid = false
+		return errors.Wrapf(define.ErrNoSuchPod, "pod with ID %s not found in state", pod.ID())
+	}
+
+	if newName != "" && newName != oldName {
+		if err := s.nameIndex.Reserve(newName, pod.ID()); err != nil {
+			return errors.Wrapf(err, "error registering pod name %s", newName)
+		}
+		if err := s.claimName(newName, newCfg.Owner); err != nil {
+			s.nameIndex.Release(newName)
+			return err
+		}
+		if pod.config.Namespace != "" {
+			nsIndex, ok := s.namespaceIndexes[pod.config.Namespace]
+			if !ok {
+				s.nameIndex.Release(newName)
+				return errors.Wrapf(define.ErrInternal, "namespace %s does not exist", pod.config.Namespace)
+			}
+			if err := nsIndex.nameIndex.Reserve(newName, pod.ID()); err != nil {
+				s.nameIndex.Release(newName)
+				return errors.Wrapf(err, "error registering pod name %s", newName)
+			}
+			nsIndex.nameIndex.Release(oldName)
+		}
+		s.nameIndex.Release(oldName)
+	}
+
+	statePod.config = newCfg
+
+	return nil
+}
+
 // RewriteVolumeConfig rewrites a volume's configuration.
 // This function is DANGEROUS, even with in-memory state.
 // Please read the full comment in state.go before using it.
@@ -1185,6 +1315,11 @@ func (s *InMemoryState) AddPod(pod *Pod) error {
 		return errors.Wrapf(err, "error registering pod name %s", pod.Name())
 	}
 
+	if err := s.claimName(pod.Name(), pod.config.Owner); err != nil {
+		s.nameIndex.Release(pod.Name())
+		return err
+	}
+
 	if err := s.idIndex.Add(pod.ID()); err != nil {
 		s.nameIndex.Release(pod.Name())
 		return errors.Wrapf(err, "error registering pod ID %s", pod.ID())
@@ -1390,6 +1525,11 @@ func (s *InMemoryState) AddContainerToPod(pod *Pod, ctr *Container) error {
 		return errors.Wrapf(err, "error reserving container name %s", ctr.Name())
 	}
 
+	if err := s.claimName(ctr.Name(), ctr.config.Owner); err != nil {
+		s.nameIndex.Release(ctr.Name())
+		return err
+	}
+
 	if err := s.idIndex.Add(ctr.ID()); err != nil {
 		s.nameIndex.Release(ctr.Name())
 		return errors.Wrapf(err, "error releasing container ID %s", ctr.ID())
@@ -1587,8 +1727,74 @@ func (s *InMemoryState) AllPods() ([]*Pod, error) {
 	return pods, nil
 }
 
+// ReserveName reserves a name for a container or pod to be created later
+func (s *InMemoryState) ReserveName(reservation *define.NameReservation) error {
+	if reservation.Name == "" {
+		return define.ErrEmptyID
+	}
+
+	if _, err := s.nameIndex.Get(reservation.Name); err == nil {
+		return errors.Wrapf(define.ErrCtrExists, "the name %q is used by a container or pod", reservation.Name)
+	}
+
+	if existing, ok := s.nameReservations[reservation.Name]; ok && !nameReservationExpired(existing) {
+		return errors.Wrapf(define.ErrNameReserved, "name %q", reservation.Name)
+	}
+
+	newReservation := *reservation
+	s.nameReservations[reservation.Name] = &newReservation
+
+	return nil
+}
+
+// ReleaseName removes the reservation of a name
+func (s *InMemoryState) ReleaseName(name, owner string) error {
+	if name == "" {
+		return define.ErrEmptyID
+	}
+
+	reservation, ok := s.nameReservations[name]
+	if !ok {
+		return errors.Wrapf(define.ErrNameNotReserved, "name %q", name)
+	}
+	if !nameReservationExpired(reservation) && reservation.Owner != owner {
+		return errors.Wrapf(define.ErrNameReserved, "name %q is reserved by %q", name, reservation.Owner)
+	}
+
+	delete(s.nameReservations, name)
+
+	return nil
+}
+
+// NameReservations retrieves all unexpired name reservations
+func (s *InMemoryState) NameReservations() ([]define.NameReservation, error) {
+	reservations := make([]define.NameReservation, 0, len(s.nameReservations))
+	for _, reservation := range s.nameReservations {
+		if !nameReservationExpired(reservation) {
+			reservations = append(reservations, *reservation)
+		}
+	}
+
+	return reservations, nil
+}
+
 // Internal Functions
 
+// Consume the reservation of the name of a container or pod which is added to
+// the state by the given owner.  A name reserved by another owner cannot be
+// used.
+func (s *InMemoryState) claimName(name, owner string) error {
+	reservation, ok := s.nameReservations[name]
+	if !ok {
+		return nil
+	}
+	if !nameReservationExpired(reservation) && reservation.Owner != owner {
+		return errors.Wrapf(define.ErrNameReserved, "name %q is reserved by %q", name, reservation.Owner)
+	}
+	delete(s.nameReservations, name)
+	return nil
+}
+
 // Add a container to the dependency mappings
 func (s *InMemoryState) addCtrToDependsMap(ctrID, dependsID string) {
 	if dependsID != "" {
@@ -20,6 +20,7 @@ import (
 	"github.com/containers/podman/v2/pkg/cgroups"
 	registries2 "github.com/containers/podman/v2/pkg/registries"
 	"github.com/containers/podman/v2/pkg/rootless"
+	"github.com/containers/podman/v2/pkg/util"
 	"github.com/containers/storage"
 	"github.com/containers/storage/pkg/system"
 	"github.com/opencontainers/selinux/go-selinux"
@@ -124,6 +125,12 @@ func (r *Runtime) hostInfo() (*define.HostInfo, error) {
 	}
 	info.CGroupsVersion = cgroupVersion
 
+	if pingGroupRange, err := util.HostPingGroupRange(); err == nil {
+		info.PingGroupRange = pingGroupRange
+	} else {
+		logrus.Debugf("Error reading %s: %v", util.PingGroupRangeSysctl, err)
+	}
+
 	if rootless.IsRootless() {
 		if path, err := exec.LookPath("slirp4netns"); err == nil {
 			version, err := programVersion(path)
@@ -131,9 +138,10 @@ func (r *Runtime) hostInfo() (*define.HostInfo, error) {
 				logrus.Warnf("Failed to retrieve program version for %s: %v", path, err)
 			}
 			program := define.SlirpInfo{
-				Executable: path,
-				Package:    packageVersion(path),
-				Version:    version,
+				Executable:  path,
+				Package:     packageVersion(path),
+				Version:     version,
+				PingWarning: slirp4netnsPingWarning(),
 			}
 			info.Slirp4NetNS = program
 		}
@@ -261,6 +269,8 @@ func (r *Runtime) storeInfo() (*define.StoreInfo, error) {
 		GraphOptions:    nil,
 		VolumePath:      r.config.Engine.VolumePath,
 		ConfigFile:      configFile,
+		GraphRootFsType: r.graphRootFSType,
+		StorageFallback: r.storageFallback,
 	}
 	graphOptions := map[string]interface{}{}
 	for _, o := range r.store.GraphOptions() {
@@ -342,3 +352,24 @@ func (r *Runtime) GetHostDistributionInfo() define.DistributionInfo {
 	}
 	return dist
 }
+
+// slirp4netnsPingWarning returns why ping from rootless containers using
+// slirp4netns cannot reach other hosts, or "" if it can.  slirp4netns forwards
+// ICMP echo requests with unprivileged ICMP sockets of the host, which the
+// kernel only allows to the groups in net.ipv4.ping_group_range of the host.
+func slirp4netnsPingWarning() string {
+	pingGroupRange, err := util.HostPingGroupRange()
+	if err != nil {
+		return fmt.Sprintf("cannot read %s of the host: %v", util.PingGroupRangeSysctl, err)
+	}
+	gid := rootless.GetRootlessGID()
+	allowed, err := util.PingGroupRangeContains(pingGroupRange, uint32(gid))
+	if err != nil {
+		return err.Error()
+	}
+	if allowed {
+		return ""
+	}
+	return fmt.Sprintf("ping from containers using slirp4netns cannot reach other hosts: GID %d is not in %s %q of the host; to allow it run: sysctl -w \"%s=0 %d\"",
+		gid, util.PingGroupRangeSysctl, pingGroupRange, util.PingGroupRangeSysctl, util.MaxPingGroupRangeGID)
+}
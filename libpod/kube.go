@@ -3,6 +3,7 @@ package libpod
 import (
 	"math/rand"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -171,7 +172,25 @@ func (p *Pod) podWithContainers(containers []*Container, ports []v1.ContainerPor
 	deDupPodVolumes := make(map[string]*v1.Volume)
 	first := true
 	podContainers := make([]v1.Container, 0, len(containers))
+	var podInitContainers []v1.Container
+	// Init containers are run in the order they were created.
+	sort.Slice(containers, func(i, j int) bool {
+		return containers[i].CreatedTime().Before(containers[j].CreatedTime())
+	})
 	for _, ctr := range containers {
+		if ctr.IsInitCtr() {
+			initCtr, volumes, err := containerToV1Container(ctr)
+			if err != nil {
+				return nil, err
+			}
+			initCtr.Ports = nil
+			podInitContainers = append(podInitContainers, initCtr)
+			for _, vol := range volumes {
+				vol := vol
+				deDupPodVolumes[vol.Name] = &vol
+			}
+			continue
+		}
 		if !ctr.IsInfra() {
 			ctr, volumes, err := containerToV1Container(ctr)
 			if err != nil {
@@ -203,7 +222,9 @@ func (p *Pod) podWithContainers(containers []*Container, ports []v1.ContainerPor
 		podVolumes = append(podVolumes, *vol)
 	}
 
-	return addContainersAndVolumesToPodObject(podContainers, podVolumes, p.Name()), nil
+	pod := addContainersAndVolumesToPodObject(podContainers, podVolumes, p.Name())
+	pod.Spec.InitContainers = podInitContainers
+	return pod, nil
 }
 
 func addContainersAndVolumesToPodObject(containers []v1.Container, volumes []v1.Volume, podName string) *v1.Pod {
@@ -39,6 +39,27 @@ type LogOptions struct {
 	Multi      bool
 	WaitGroup  *sync.WaitGroup
 	UseName    bool
+	// Stdout and Stderr select the streams to read the log lines of.
+	// Both are read if neither is set.  Tail counts the selected lines
+	// only.
+	Stdout bool
+	Stderr bool
+}
+
+// WantsDevice returns whether log lines of device are selected by the
+// Stdout and Stderr options.
+func (o *LogOptions) WantsDevice(device string) bool {
+	if !o.Stdout && !o.Stderr {
+		return true
+	}
+	switch device {
+	case "stdout":
+		return o.Stdout
+	case "stderr":
+		return o.Stderr
+	}
+	// let unknown devices through so they are reported
+	return true
 }
 
 // LogLine describes the information for each line of a log
@@ -63,7 +84,7 @@ func GetLogFile(path string, options *LogOptions) (*tail.Tail, []*LogLine, error
 		whence = 2
 	}
 	if options.Tail > 0 {
-		logTail, err = getTailLog(path, int(options.Tail))
+		logTail, err = getTailLog(path, int(options.Tail), options)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -77,7 +98,7 @@ func GetLogFile(path string, options *LogOptions) (*tail.Tail, []*LogLine, error
 	return t, logTail, err
 }
 
-func getTailLog(path string, tail int) ([]*LogLine, error) {
+func getTailLog(path string, tail int, options *LogOptions) ([]*LogLine, error) {
 	var (
 		nlls       []*LogLine
 		nllCounter int
@@ -132,6 +153,9 @@ func getTailLog(path string, tail int) ([]*LogLine, error) {
 			if err != nil {
 				return nil, err
 			}
+			if !options.WantsDevice(nll.Device) {
+				continue
+			}
 			nlls = append(nlls, nll)
 			if !nll.Partial() {
 				nllCounter++
@@ -0,0 +1,56 @@
+package logs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWantsDevice(t *testing.T) {
+	all := &LogOptions{}
+	assert.True(t, all.WantsDevice("stdout"))
+	assert.True(t, all.WantsDevice("stderr"))
+
+	stdout := &LogOptions{Stdout: true}
+	assert.True(t, stdout.WantsDevice("stdout"))
+	assert.False(t, stdout.WantsDevice("stderr"))
+
+	stderr := &LogOptions{Stderr: true}
+	assert.False(t, stderr.WantsDevice("stdout"))
+	assert.True(t, stderr.WantsDevice("stderr"))
+
+	both := &LogOptions{Stdout: true, Stderr: true}
+	assert.True(t, both.WantsDevice("stdout"))
+	assert.True(t, both.WantsDevice("stderr"))
+}
+
+func TestGetTailLogStream(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "ctr.log")
+	content := `2021-01-01T00:00:00.000000000+00:00 stdout F out1
+2021-01-01T00:00:01.000000000+00:00 stderr F err1
+2021-01-01T00:00:02.000000000+00:00 stdout F out2
+2021-01-01T00:00:03.000000000+00:00 stderr F err2
+2021-01-01T00:00:04.000000000+00:00 stdout F out3
+`
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+
+	lines, err := getTailLog(path, 2, &LogOptions{Stderr: true})
+	require.NoError(t, err)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "err1", lines[0].Msg)
+	assert.Equal(t, "err2", lines[1].Msg)
+
+	lines, err = getTailLog(path, 2, &LogOptions{})
+	require.NoError(t, err)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "err2", lines[0].Msg)
+	assert.Equal(t, "out3", lines[1].Msg)
+}
@@ -14,10 +14,12 @@ import (
 	"github.com/containers/podman/v2/pkg/rootless"
 	"github.com/containers/podman/v2/pkg/util"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
-// Create the CNI network
-func Create(name string, options entities.NetworkCreateOptions, runtimeConfig *config.Config) (*entities.NetworkCreateReport, error) {
+// Create the CNI network.  stateRoot is the directory the persistent state is
+// relocated to by the [state] table of containers.conf, if any.
+func Create(name string, options entities.NetworkCreateOptions, runtimeConfig *config.Config, stateRoot string) (*entities.NetworkCreateReport, error) {
 	var fileName string
 	if err := isSupportedDriver(options.Driver); err != nil {
 		return nil, err
@@ -31,7 +33,7 @@ func Create(name string, options entities.NetworkCreateOptions, runtimeConfig *c
 	if len(options.MacVLAN) > 0 {
 		fileName, err = createMacVLAN(name, options, runtimeConfig)
 	} else {
-		fileName, err = createBridge(name, options, runtimeConfig)
+		fileName, err = createBridge(name, options, runtimeConfig, stateRoot)
 	}
 	if err != nil {
 		return nil, err
@@ -39,38 +41,69 @@ func Create(name string, options entities.NetworkCreateOptions, runtimeConfig *c
 	return &entities.NetworkCreateReport{Filename: fileName}, nil
 }
 
+// networkSubnets returns all subnets requested for the network, the one given
+// by the Subnet, Range and Gateway options first.
+func networkSubnets(options entities.NetworkCreateOptions) []entities.NetworkSubnet {
+	var subnets []entities.NetworkSubnet
+	if options.Subnet.IP != nil || options.Range.IP != nil || options.Gateway != nil {
+		subnets = append(subnets, entities.NetworkSubnet{
+			Subnet:  options.Subnet,
+			Gateway: options.Gateway,
+			Range:   options.Range,
+		})
+	}
+	return append(subnets, options.Subnets...)
+}
+
 // validateBridgeOptions validate the bridge networking options
 func validateBridgeOptions(options entities.NetworkCreateOptions) error {
-	subnet := &options.Subnet
-	ipRange := &options.Range
-	gateway := options.Gateway
-	// if IPv6 is set an IPv6 subnet MUST be specified
-	if options.IPv6 && ((subnet.IP == nil) || (subnet.IP != nil && !IsIPv6(subnet.IP))) {
-		return errors.Errorf("ipv6 option requires an IPv6 --subnet to be provided")
-	}
-	// range and gateway depend on subnet
-	if subnet.IP == nil && (ipRange.IP != nil || gateway != nil) {
-		return errors.Errorf("every ip-range or gateway must have a corresponding subnet")
-	}
+	subnets := networkSubnets(options)
+	hasIPv6 := false
+	for i := range subnets {
+		subnet := &subnets[i].Subnet
+		ipRange := &subnets[i].Range
+		gateway := subnets[i].Gateway
+		// range and gateway depend on subnet
+		if subnet.IP == nil {
+			if ipRange.IP != nil || gateway != nil {
+				return errors.Errorf("every ip-range or gateway must have a corresponding subnet")
+			}
+			continue
+		}
+		if IsIPv6(subnet.IP) {
+			hasIPv6 = true
+		}
 
-	// if a range is given, we need to ensure it is "in" the network range.
-	if ipRange.IP != nil {
-		firstIP, err := FirstIPInSubnet(ipRange)
-		if err != nil {
-			return errors.Wrapf(err, "failed to get first IP address from ip-range")
+		// subnets of the same network must not overlap
+		for j := range subnets[:i] {
+			if subnets[j].Subnet.IP != nil && networkIntersect(subnet, &subnets[j].Subnet) {
+				return errors.Errorf("subnet %s overlaps with subnet %s", subnet.String(), subnets[j].Subnet.String())
+			}
 		}
-		lastIP, err := LastIPInSubnet(ipRange)
-		if err != nil {
-			return errors.Wrapf(err, "failed to get last IP address from ip-range")
+
+		// if a range is given, we need to ensure it is "in" the network range.
+		if ipRange.IP != nil {
+			firstIP, err := FirstIPInSubnet(ipRange)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get first IP address from ip-range")
+			}
+			lastIP, err := LastIPInSubnet(ipRange)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get last IP address from ip-range")
+			}
+			if !subnet.Contains(firstIP) || !subnet.Contains(lastIP) {
+				return errors.Errorf("the ip range %s does not fall within the subnet range %s", ipRange.String(), subnet.String())
+			}
 		}
-		if !subnet.Contains(firstIP) || !subnet.Contains(lastIP) {
-			return errors.Errorf("the ip range %s does not fall within the subnet range %s", ipRange.String(), subnet.String())
+
+		// if network is provided and if gateway is provided, make sure it is "in" network
+		if gateway != nil && !subnet.Contains(gateway) {
+			return errors.Errorf("gateway %s is not in valid for subnet %s", gateway.String(), subnet.String())
 		}
 	}
-
-	// if network is provided and if gateway is provided, make sure it is "in" network
-	if gateway != nil && !subnet.Contains(gateway) {
-		return errors.Errorf("gateway %s is not in valid for subnet %s", gateway.String(), subnet.String())
+	// if IPv6 is set an IPv6 subnet MUST be specified
+	if options.IPv6 && !hasIPv6 {
+		return errors.Errorf("ipv6 option requires an IPv6 --subnet to be provided")
 	}
 
 	return nil
@@ -101,7 +134,7 @@ func parseVlan(vlan string) (int, error) {
 }
 
 // createBridge creates a CNI network
-func createBridge(name string, options entities.NetworkCreateOptions, runtimeConfig *config.Config) (string, error) {
+func createBridge(name string, options entities.NetworkCreateOptions, runtimeConfig *config.Config, stateRoot string) (string, error) {
 	var (
 		ipamRanges [][]IPAMLocalHostRangeConf
 		err        error
@@ -117,32 +150,41 @@ func createBridge(name string, options entities.NetworkCreateOptions, runtimeCon
 
 	// For compatibility with the docker implementation:
 	// if IPv6 is enabled (it really means dual-stack) then an IPv6 subnet has to be provided, and one free network is allocated for IPv4
-	// if IPv6 is not specified the subnet may be specified and can be either IPv4 or IPv6 (podman, unlike docker, allows IPv6 only networks)
+	// unless an IPv4 subnet is provided as well
+	// if IPv6 is not specified the subnets may be specified and can be either IPv4 or IPv6 (podman, unlike docker, allows IPv6 only networks)
 	// If not subnet is specified an IPv4 subnet will be allocated
-	subnet := &options.Subnet
-	ipRange := &options.Range
-	gateway := options.Gateway
-	if subnet.IP != nil {
+	hasIPv4 := false
+	defaultRoutes := make(map[bool]bool)
+	for _, s := range networkSubnets(options) {
+		subnet := s.Subnet
+		ipRange := s.Range
+		isIPv6 := IsIPv6(subnet.IP)
 		// if network is provided, does it conflict with existing CNI or live networks
-		err = ValidateUserNetworkIsAvailable(runtimeConfig, subnet)
+		err = ValidateUserNetworkIsAvailable(runtimeConfig, &subnet)
 		if err != nil {
 			return "", err
 		}
-		// obtain CNI subnet default route
-		defaultRoute, err := NewIPAMDefaultRoute(IsIPv6(subnet.IP))
-		if err != nil {
-			return "", err
+		// obtain CNI subnet default route, once per IP family
+		if !defaultRoutes[isIPv6] {
+			defaultRoute, err := NewIPAMDefaultRoute(isIPv6)
+			if err != nil {
+				return "", err
+			}
+			routes = append(routes, defaultRoute)
+			defaultRoutes[isIPv6] = true
+		}
+		if !isIPv6 {
+			hasIPv4 = true
 		}
-		routes = append(routes, defaultRoute)
 		// obtain CNI range
-		ipamRange, err := NewIPAMLocalHostRange(subnet, ipRange, gateway)
+		ipamRange, err := NewIPAMLocalHostRange(&subnet, &ipRange, s.Gateway)
 		if err != nil {
 			return "", err
 		}
 		ipamRanges = append(ipamRanges, ipamRange)
 	}
-	// if no network is provided or IPv6 flag used, figure out the IPv4 network
-	if options.IPv6 || len(routes) == 0 {
+	// if no network is provided or IPv6 flag used without an IPv4 subnet, figure out the IPv4 network
+	if (options.IPv6 && !hasIPv4) || len(routes) == 0 {
 		subnetV4, err := GetFreeNetwork(runtimeConfig)
 		if err != nil {
 			return "", err
@@ -167,6 +209,10 @@ func createBridge(name string, options entities.NetworkCreateOptions, runtimeCon
 	if err != nil {
 		return "", err
 	}
+	// Keep the address allocations with the rest of the relocated state.
+	if stateRoot != "" && !rootless.IsRootless() {
+		ipamConfig.DataDir = filepath.Join(stateRoot, "cni", "networks")
+	}
 
 	if options.Internal {
 		isGateway = false
@@ -228,6 +274,8 @@ func createBridge(name string, options entities.NetworkCreateOptions, runtimeCon
 	if (HasDNSNamePlugin(runtimeConfig.Network.CNIPluginDirs) || rootless.IsRootless()) && !options.DisableDNS {
 		// Note: in the future we might like to allow for dynamic domain names
 		plugins = append(plugins, NewDNSNamePlugin(DefaultPodmanDomainName))
+	} else if !options.DisableDNS {
+		logrus.Warnf("dnsname CNI plugin not found in %v, containers in network %s cannot resolve each other by name", runtimeConfig.Network.CNIPluginDirs, name)
 	}
 	ncList["plugins"] = plugins
 	b, err := json.MarshalIndent(ncList, "", "   ")
@@ -129,3 +129,83 @@ func Test_validateBridgeOptions(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateBridgeOptionsSubnets(t *testing.T) {
+	subnetV4 := net.IPNet{IP: net.IPv4(192, 168, 0, 0), Mask: net.IPv4Mask(255, 255, 255, 0)}
+	subnetV6 := net.IPNet{IP: net.ParseIP("2001:DB8::"), Mask: net.IPMask(net.ParseIP("ffff:ffff:ffff::"))}
+
+	tests := []struct {
+		name    string
+		options entities.NetworkCreateOptions
+		wantErr bool
+	}{
+		{
+			name: "IPv4 and IPv6 subnets",
+			options: entities.NetworkCreateOptions{
+				Subnets: []entities.NetworkSubnet{{Subnet: subnetV4}, {Subnet: subnetV6}},
+				IPv6:    true,
+			},
+		},
+		{
+			name: "IPv4 and IPv6 subnets with gateways and ranges",
+			options: entities.NetworkCreateOptions{
+				Subnets: []entities.NetworkSubnet{
+					{
+						Subnet:  subnetV4,
+						Gateway: net.ParseIP("192.168.0.10"),
+						Range:   net.IPNet{IP: net.IPv4(192, 168, 0, 128), Mask: net.IPv4Mask(255, 255, 255, 128)},
+					},
+					{
+						Subnet:  subnetV6,
+						Gateway: net.ParseIP("2001:DB8::2"),
+						Range:   net.IPNet{IP: net.ParseIP("2001:DB8:0:0:1::"), Mask: net.IPMask(net.ParseIP("ffff:ffff:ffff:ffff::"))},
+					},
+				},
+			},
+		},
+		{
+			name: "Subnet option and additional subnet",
+			options: entities.NetworkCreateOptions{
+				Subnet:  subnetV6,
+				Subnets: []entities.NetworkSubnet{{Subnet: subnetV4}},
+				IPv6:    true,
+			},
+		},
+		{
+			name: "IPv6 required but only IPv4 subnets",
+			options: entities.NetworkCreateOptions{
+				Subnets: []entities.NetworkSubnet{{Subnet: subnetV4}},
+				IPv6:    true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "overlapping subnets",
+			options: entities.NetworkCreateOptions{
+				Subnets: []entities.NetworkSubnet{
+					{Subnet: subnetV4},
+					{Subnet: net.IPNet{IP: net.IPv4(192, 168, 0, 128), Mask: net.IPv4Mask(255, 255, 255, 128)}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "gateway out of its subnet",
+			options: entities.NetworkCreateOptions{
+				Subnets: []entities.NetworkSubnet{
+					{Subnet: subnetV4, Gateway: net.ParseIP("2001:DB8::2")},
+					{Subnet: subnetV6},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateBridgeOptions(tt.options); (err != nil) != tt.wantErr {
+				t.Errorf("validateBridgeOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
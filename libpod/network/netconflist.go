@@ -5,8 +5,11 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/containernetworking/cni/libcni"
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v2/pkg/timetype"
 	"github.com/containers/podman/v2/pkg/util"
 	"github.com/pkg/errors"
 )
@@ -251,3 +254,45 @@ func IfPassesFilter(netconf *libcni.NetworkConfigList, filters map[string][]stri
 	}
 	return result, nil
 }
+
+// IfPassesPruneFilter filters networks for prune.  Only the label and until
+// filters are supported; until matches networks whose configuration file was
+// last modified before the given time.
+func IfPassesPruneFilter(config *config.Config, netconf *libcni.NetworkConfigList, filters map[string][]string) (bool, error) {
+	for key, filterValues := range filters {
+		switch strings.ToLower(key) {
+		case "label":
+			ok, err := IfPassesFilter(netconf, map[string][]string{key: filterValues})
+			if err != nil || !ok {
+				return false, err
+			}
+
+		case "until":
+			cniPath, err := GetCNIConfigPathByNameOrID(config, netconf.Name)
+			if err != nil {
+				return false, err
+			}
+			info, err := os.Stat(cniPath)
+			if err != nil {
+				return false, err
+			}
+			for _, filterValue := range filterValues {
+				ts, err := timetype.GetTimestamp(filterValue, time.Now())
+				if err != nil {
+					return false, err
+				}
+				seconds, nanoseconds, err := timetype.ParseTimestamps(ts, 0)
+				if err != nil {
+					return false, err
+				}
+				if !info.ModTime().Before(time.Unix(seconds, nanoseconds)) {
+					return false, nil
+				}
+			}
+
+		default:
+			return false, errors.Errorf("invalid filter %q for network prune", key)
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,245 @@
+// +build linux
+
+package libpod
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/containers/podman/v2/pkg/netns"
+	"github.com/containers/podman/v2/pkg/rootless"
+	"github.com/containers/storage/pkg/ioutils"
+	"github.com/cri-o/ocicni/pkg/ocicni"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// networkRecordsDir is the directory, in the static directory of the runtime,
+// which holds a record of the network resources set up for each container.
+// A record outliving its container is left by a container which was removed
+// without tearing down its network.
+const networkRecordsDir = "network-records"
+
+// networkRecord is the on-disk record of the network resources set up for a
+// container.
+type networkRecord struct {
+	// NetNS is the path of the network namespace of the container.
+	NetNS string `json:"netns"`
+	// Networks are the CNI networks the container is attached to.
+	Networks []string `json:"networks"`
+}
+
+// networkRecordPath returns the path of the network record of a container.
+func (r *Runtime) networkRecordPath(ctrID string) string {
+	return filepath.Join(r.config.Engine.StaticDir, networkRecordsDir, ctrID+".json")
+}
+
+// recordNetworks records that the network namespace at nsPath of a container
+// is attached to the given CNI networks, before the networks are set up.
+func (r *Runtime) recordNetworks(ctrID, nsPath string, networks []string) error {
+	if rootless.IsRootless() {
+		return nil
+	}
+	path := r.networkRecordPath(ctrID)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errors.Wrapf(err, "error creating directory %s", filepath.Dir(path))
+	}
+	b, err := json.Marshal(networkRecord{NetNS: nsPath, Networks: networks})
+	if err != nil {
+		return err
+	}
+	if err := ioutils.AtomicWriteFile(path, b, 0600); err != nil {
+		return errors.Wrapf(err, "error recording networks of container %s", ctrID)
+	}
+	return nil
+}
+
+// removeNetworkRecord removes the network record of a container once its
+// network is torn down.
+func (r *Runtime) removeNetworkRecord(ctrID string) {
+	path := r.networkRecordPath(ctrID)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		logrus.Errorf("Error removing network record %s: %v", path, err)
+	}
+}
+
+// PruneLeakedNetworks releases network resources that belong to containers
+// which no longer exist, usually because the container was removed while its
+// cleanup process was killed or the system crashed. Only the resources
+// recorded when the network of a container was set up are released: leaked
+// network namespaces are unmounted, which also destroys their veth pairs, and
+// leaked CNI address allocations are torn down so their IPs and firewall rules
+// are released. The returned map is keyed by a description of each released
+// resource.
+func (r *Runtime) PruneLeakedNetworks() (map[string]error, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	if rootless.IsRootless() {
+		return nil, errors.Wrapf(define.ErrRootless, "leaked network resources can only be pruned as root")
+	}
+
+	// Read the records before the containers, so every record found
+	// belongs to a container that was already in the database at that
+	// point.
+	dir := filepath.Join(r.config.Engine.StaticDir, networkRecordsDir)
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrapf(err, "error reading network records")
+	}
+	records := make(map[string]networkRecord, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var record networkRecord
+		if err := json.Unmarshal(b, &record); err != nil {
+			logrus.Debugf("Ignoring invalid network record %s: %v", path, err)
+			continue
+		}
+		records[strings.TrimSuffix(e.Name(), ".json")] = record
+	}
+
+	ctrs, err := r.state.AllContainers()
+	if err != nil {
+		return nil, errors.Wrapf(err, "error retrieving containers from state")
+	}
+	for _, ctr := range ctrs {
+		delete(records, ctr.ID())
+	}
+
+	reports := make(map[string]error)
+	for ctrID, record := range records {
+		released := true
+		if record.NetNS != "" {
+			if _, err := os.Stat(record.NetNS); err == nil {
+				logrus.Debugf("Removing leaked network namespace %s", record.NetNS)
+				err := netns.UnmountNSPath(record.NetNS)
+				reports[fmt.Sprintf("network namespace %s", record.NetNS)] = err
+				released = released && err == nil
+			}
+		}
+		allocations, err := r.cniAllocations(ctrID, record.Networks)
+		if err != nil {
+			return nil, err
+		}
+		for _, alloc := range allocations {
+			desc := fmt.Sprintf("address %s of container %s in network %s", alloc.ip, alloc.ctrID, alloc.network)
+			logrus.Debugf("Releasing leaked %s", desc)
+			err := r.releaseCNIAllocation(alloc)
+			reports[desc] = err
+			released = released && err == nil
+		}
+		if released {
+			r.removeNetworkRecord(ctrID)
+		}
+	}
+
+	return reports, nil
+}
+
+// cniAllocation is an address reserved by the host-local IPAM plugin.
+type cniAllocation struct {
+	network string
+	ip      string
+	ctrID   string
+	path    string
+}
+
+// cniAllocations lists the addresses reserved by the host-local IPAM plugin
+// for a container in the given CNI networks.
+func (r *Runtime) cniAllocations(ctrID string, networks []string) ([]cniAllocation, error) {
+	networksDir, err := getCNINetworksDir()
+	if err != nil {
+		return nil, err
+	}
+
+	allocations := []cniAllocation{}
+	for _, name := range networks {
+		dir := filepath.Join(networksDir, name)
+		entries, err := ioutil.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "error reading address allocations of network %s", name)
+		}
+		for _, e := range entries {
+			if e.IsDir() || e.Name() == "lock" || strings.HasPrefix(e.Name(), "last_reserved_ip") {
+				continue
+			}
+			path := filepath.Join(dir, e.Name())
+			owner, err := readAllocationOwner(path)
+			if err != nil {
+				logrus.Debugf("Error reading address allocation %s: %v", path, err)
+				continue
+			}
+			if owner != ctrID {
+				continue
+			}
+			allocations = append(allocations, cniAllocation{
+				network: name,
+				ip:      e.Name(),
+				ctrID:   ctrID,
+				path:    path,
+			})
+		}
+	}
+	return allocations, nil
+}
+
+// readAllocationOwner returns the container ID stored in the first line of a
+// host-local IPAM allocation file.
+func readAllocationOwner(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", errors.Errorf("allocation file %s is empty", path)
+	}
+	return strings.TrimSpace(scanner.Text()), nil
+}
+
+// releaseCNIAllocation runs the CNI teardown for a container that no longer
+// exists. The namespace is gone, but the plugins still remove the port
+// forwarding and firewall rules and the address reservation of the container.
+// If the teardown fails the address is released by removing its reservation.
+func (r *Runtime) releaseCNIAllocation(alloc cniAllocation) error {
+	podNetwork := ocicni.PodNetwork{
+		Name:      alloc.ctrID,
+		Namespace: alloc.ctrID,
+		ID:        alloc.ctrID,
+		Networks:  []ocicni.NetAttachment{{Name: alloc.network}},
+	}
+	teardownErr := r.netPlugin.TearDownPod(podNetwork)
+	if err := os.Remove(alloc.path); err != nil && !os.IsNotExist(err) {
+		if teardownErr != nil {
+			logrus.Errorf("Error tearing down CNI configuration of container %s: %v", alloc.ctrID, teardownErr)
+		}
+		return errors.Wrapf(err, "error removing address allocation %s", alloc.path)
+	}
+	if teardownErr != nil {
+		return errors.Wrapf(teardownErr, "error tearing down CNI configuration of container %s, released the address only", alloc.ctrID)
+	}
+	return nil
+}
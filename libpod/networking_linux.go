@@ -15,6 +15,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -36,7 +37,9 @@ import (
 )
 
 // Get an OCICNI network config
-func (r *Runtime) getPodNetwork(id, name, nsPath string, networks []string, ports []ocicni.PortMapping, staticIP net.IP, staticMAC net.HardwareAddr, netDescriptions ContainerNetworkDescriptions) ocicni.PodNetwork {
+// staticIPs and staticMACs are the static addresses to request, formatted as
+// map of network name to address.
+func (r *Runtime) getPodNetwork(id, name, nsPath string, networks []string, ports []ocicni.PortMapping, staticIPs map[string]net.IP, staticMACs map[string]net.HardwareAddr, netDescriptions ContainerNetworkDescriptions) ocicni.PodNetwork {
 	var networkKey string
 	if len(networks) > 0 {
 		// This is inconsistent for >1 ctrNetwork, but it's probably the
@@ -66,7 +69,16 @@ func (r *Runtime) getPodNetwork(id, name, nsPath string, networks []string, port
 		}
 	}
 
-	if staticIP != nil || staticMAC != nil {
+	staticNetworks := networks
+	if len(staticNetworks) == 0 {
+		staticNetworks = []string{networkKey}
+	}
+	for _, netName := range staticNetworks {
+		staticIP := staticIPs[netName]
+		staticMAC := staticMACs[netName]
+		if staticIP == nil && staticMAC == nil {
+			continue
+		}
 		// For static IP or MAC, we need to populate networks even if
 		// it's just the default.
 		if len(networks) == 0 {
@@ -74,41 +86,66 @@ func (r *Runtime) getPodNetwork(id, name, nsPath string, networks []string, port
 			// default ctrNetwork.
 			ctrNetwork.Networks = []ocicni.NetAttachment{{Name: networkKey}}
 		}
-		var rt ocicni.RuntimeConfig = ocicni.RuntimeConfig{PortMappings: ports}
+		rt := ctrNetwork.RuntimeConfig[netName]
 		if staticIP != nil {
 			rt.IP = staticIP.String()
 		}
 		if staticMAC != nil {
 			rt.MAC = staticMAC.String()
 		}
-		ctrNetwork.RuntimeConfig = map[string]ocicni.RuntimeConfig{
-			networkKey: rt,
-		}
+		ctrNetwork.RuntimeConfig[netName] = rt
 	}
 
 	return ctrNetwork
 }
 
-// Create and configure a new network namespace for a container
-func (r *Runtime) configureNetNS(ctr *Container, ctrNS ns.NetNS) ([]*cnitypes.Result, error) {
-	var requestedIP net.IP
-	if ctr.requestedIP != nil {
-		requestedIP = ctr.requestedIP
-		// cancel request for a specific IP in case the container is reused later
-		ctr.requestedIP = nil
-	} else {
-		requestedIP = ctr.config.StaticIP
+// staticIP returns the static IPv4 or IPv6 address requested for the
+// container, if any.
+func (c *Container) staticIP() net.IP {
+	if c.config.StaticIP != nil {
+		return c.config.StaticIP
 	}
+	return c.config.StaticIPv6
+}
 
-	var requestedMAC net.HardwareAddr
-	if ctr.requestedMAC != nil {
-		requestedMAC = ctr.requestedMAC
-		// cancel request for a specific MAC in case the container is reused later
-		ctr.requestedMAC = nil
-	} else {
-		requestedMAC = ctr.config.StaticMAC
+// staticAddresses returns the static IP and MAC addresses to request for the
+// container in the given networks, formatted as map of network name to
+// address.  The addresses requested for a restore, or else the static IP and
+// MAC of the container, are requested in the first network.  The requests
+// for a restore are cancelled in case the container is reused later.
+func (c *Container) staticAddresses(networks []string) (map[string]net.IP, map[string]net.HardwareAddr) {
+	staticIPs := make(map[string]net.IP, len(c.config.StaticIPs)+1)
+	for netName, ip := range c.config.StaticIPs {
+		staticIPs[netName] = ip
+	}
+	staticMACs := make(map[string]net.HardwareAddr, len(c.config.StaticMACs)+1)
+	for netName, mac := range c.config.StaticMACs {
+		staticMACs[netName] = mac
 	}
 
+	requestedIP := c.staticIP()
+	if c.requestedIP != nil {
+		requestedIP = c.requestedIP
+		c.requestedIP = nil
+	}
+	requestedMAC := c.config.StaticMAC
+	if c.requestedMAC != nil {
+		requestedMAC = c.requestedMAC
+		c.requestedMAC = nil
+	}
+	if len(networks) > 0 {
+		if requestedIP != nil {
+			staticIPs[networks[0]] = requestedIP
+		}
+		if requestedMAC != nil {
+			staticMACs[networks[0]] = requestedMAC
+		}
+	}
+	return staticIPs, staticMACs
+}
+
+// Create and configure a new network namespace for a container
+func (r *Runtime) configureNetNS(ctr *Container, ctrNS ns.NetNS) ([]*cnitypes.Result, error) {
 	podName := getCNIPodName(ctr)
 
 	networks, _, err := ctr.networks()
@@ -125,7 +162,8 @@ func (r *Runtime) configureNetNS(ctr *Container, ctrNS ns.NetNS) ([]*cnitypes.Re
 	if err := ctr.setupNetworkDescriptions(networks); err != nil {
 		return nil, err
 	}
-	podNetwork := r.getPodNetwork(ctr.ID(), podName, ctrNS.Path(), networks, ctr.config.PortMappings, requestedIP, requestedMAC, ctr.state.NetInterfaceDescriptions)
+	staticIPs, staticMACs := ctr.staticAddresses(networks)
+	podNetwork := r.getPodNetwork(ctr.ID(), podName, ctrNS.Path(), networks, ctr.config.PortMappings, staticIPs, staticMACs, ctr.state.NetInterfaceDescriptions)
 	aliases, err := ctr.runtime.state.GetAllNetworkAliases(ctr)
 	if err != nil {
 		return nil, err
@@ -134,6 +172,9 @@ func (r *Runtime) configureNetNS(ctr *Container, ctrNS ns.NetNS) ([]*cnitypes.Re
 		podNetwork.Aliases = aliases
 	}
 
+	if err := r.recordNetworks(ctr.ID(), ctrNS.Path(), networks); err != nil {
+		return nil, err
+	}
 	results, err := r.netPlugin.SetUpPod(podNetwork)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error configuring network namespace for container %s", ctr.ID())
@@ -246,7 +287,6 @@ func (r *Runtime) setupRootlessNetNS(ctr *Container) error {
 // setupSlirp4netns can be called in rootful as well as in rootless
 func (r *Runtime) setupSlirp4netns(ctr *Container) error {
 	path := r.config.Engine.NetworkCmdPath
-	slirpOptions := r.config.Engine.NetworkCmdOptions
 	if path == "" {
 		var err error
 		path, err = exec.LookPath("slirp4netns")
@@ -256,6 +296,10 @@ func (r *Runtime) setupSlirp4netns(ctr *Container) error {
 		}
 	}
 
+	if warning := slirp4netnsPingWarning(); warning != "" {
+		logrus.Info(warning)
+	}
+
 	syncR, syncW, err := os.Pipe()
 	if err != nil {
 		return errors.Wrapf(err, "failed to open pipe")
@@ -266,78 +310,9 @@ func (r *Runtime) setupSlirp4netns(ctr *Container) error {
 	havePortMapping := len(ctr.Config().PortMappings) > 0
 	logPath := filepath.Join(ctr.runtime.config.Engine.TmpDir, fmt.Sprintf("slirp4netns-%s.log", ctr.config.ID))
 
-	cidr := ""
-	isSlirpHostForward := false
-	disableHostLoopback := true
-	enableIPv6 := false
-	outboundAddr := ""
-	outboundAddr6 := ""
-
-	if ctr.config.NetworkOptions != nil {
-		slirpOptions = append(slirpOptions, ctr.config.NetworkOptions["slirp4netns"]...)
-	}
-
-	for _, o := range slirpOptions {
-		parts := strings.SplitN(o, "=", 2)
-		if len(parts) < 2 {
-			return errors.Errorf("unknown option for slirp4netns: %q", o)
-		}
-		option, value := parts[0], parts[1]
-		switch option {
-		case "cidr":
-			ipv4, _, err := net.ParseCIDR(value)
-			if err != nil || ipv4.To4() == nil {
-				return errors.Errorf("invalid cidr %q", value)
-			}
-			cidr = value
-		case "port_handler":
-			switch value {
-			case "slirp4netns":
-				isSlirpHostForward = true
-			case "rootlesskit":
-				isSlirpHostForward = false
-			default:
-				return errors.Errorf("unknown port_handler for slirp4netns: %q", value)
-			}
-		case "allow_host_loopback":
-			switch value {
-			case "true":
-				disableHostLoopback = false
-			case "false":
-				disableHostLoopback = true
-			default:
-				return errors.Errorf("invalid value of allow_host_loopback for slirp4netns: %q", value)
-			}
-		case "enable_ipv6":
-			switch value {
-			case "true":
-				enableIPv6 = true
-			case "false":
-				enableIPv6 = false
-			default:
-				return errors.Errorf("invalid value of enable_ipv6 for slirp4netns: %q", value)
-			}
-		case "outbound_addr":
-			ipv4 := net.ParseIP(value)
-			if ipv4 == nil || ipv4.To4() == nil {
-				_, err := net.InterfaceByName(value)
-				if err != nil {
-					return errors.Errorf("invalid outbound_addr %q", value)
-				}
-			}
-			outboundAddr = value
-		case "outbound_addr6":
-			ipv6 := net.ParseIP(value)
-			if ipv6 == nil || ipv6.To4() != nil {
-				_, err := net.InterfaceByName(value)
-				if err != nil {
-					return errors.Errorf("invalid outbound_addr6: %q", value)
-				}
-			}
-			outboundAddr6 = value
-		default:
-			return errors.Errorf("unknown option for slirp4netns: %q", o)
-		}
+	netOptions, err := parseSlirp4netnsNetworkOptions(ctr.slirp4netnsOptions())
+	if err != nil {
+		return err
 	}
 
 	cmdArgs := []string{}
@@ -345,11 +320,13 @@ func (r *Runtime) setupSlirp4netns(ctr *Container) error {
 	if err != nil {
 		return errors.Wrapf(err, "error checking slirp4netns binary %s: %q", path, err)
 	}
-	if disableHostLoopback && slirpFeatures.HasDisableHostLoopback {
+	if netOptions.disableHostLoopback && slirpFeatures.HasDisableHostLoopback {
 		cmdArgs = append(cmdArgs, "--disable-host-loopback")
 	}
 	if slirpFeatures.HasMTU {
-		cmdArgs = append(cmdArgs, "--mtu", "65520")
+		cmdArgs = append(cmdArgs, "--mtu", strconv.Itoa(netOptions.mtu))
+	} else if netOptions.mtu != slirp4netnsDefaultMTU {
+		return errors.Errorf("mtu not supported")
 	}
 	if slirpFeatures.HasEnableSandbox {
 		cmdArgs = append(cmdArgs, "--enable-sandbox")
@@ -358,39 +335,36 @@ func (r *Runtime) setupSlirp4netns(ctr *Container) error {
 		cmdArgs = append(cmdArgs, "--enable-seccomp")
 	}
 
-	if cidr != "" {
+	if netOptions.cidr != "" {
 		if !slirpFeatures.HasCIDR {
 			return errors.Errorf("cidr not supported")
 		}
-		cmdArgs = append(cmdArgs, fmt.Sprintf("--cidr=%s", cidr))
+		cmdArgs = append(cmdArgs, fmt.Sprintf("--cidr=%s", netOptions.cidr))
 	}
 
-	if enableIPv6 {
+	if netOptions.enableIPv6 {
 		if !slirpFeatures.HasIPv6 {
 			return errors.Errorf("enable_ipv6 not supported")
 		}
 		cmdArgs = append(cmdArgs, "--enable-ipv6")
 	}
 
-	if outboundAddr != "" {
+	if netOptions.outboundAddr != "" {
 		if !slirpFeatures.HasOutboundAddr {
 			return errors.Errorf("outbound_addr not supported")
 		}
-		cmdArgs = append(cmdArgs, fmt.Sprintf("--outbound-addr=%s", outboundAddr))
+		cmdArgs = append(cmdArgs, fmt.Sprintf("--outbound-addr=%s", netOptions.outboundAddr))
 	}
 
-	if outboundAddr6 != "" {
+	if netOptions.outboundAddr6 != "" {
 		if !slirpFeatures.HasOutboundAddr || !slirpFeatures.HasIPv6 {
 			return errors.Errorf("outbound_addr6 not supported")
 		}
-		if !enableIPv6 {
-			return errors.Errorf("enable_ipv6=true is required for outbound_addr6")
-		}
-		cmdArgs = append(cmdArgs, fmt.Sprintf("--outbound-addr6=%s", outboundAddr6))
+		cmdArgs = append(cmdArgs, fmt.Sprintf("--outbound-addr6=%s", netOptions.outboundAddr6))
 	}
 
 	var apiSocket string
-	if havePortMapping && isSlirpHostForward {
+	if havePortMapping && netOptions.isSlirpHostForward {
 		apiSocket = filepath.Join(ctr.runtime.config.Engine.TmpDir, fmt.Sprintf("%s.net", ctr.config.ID))
 		cmdArgs = append(cmdArgs, "--api-socket", apiSocket)
 	}
@@ -458,7 +432,7 @@ func (r *Runtime) setupSlirp4netns(ctr *Container) error {
 	}
 
 	if havePortMapping {
-		if isSlirpHostForward {
+		if netOptions.isSlirpHostForward {
 			return r.setupRootlessPortMappingViaSlirp(ctr, cmd, apiSocket)
 		} else {
 			return r.setupRootlessPortMappingViaRLK(ctr, netnsPath)
@@ -621,59 +595,70 @@ func (r *Runtime) setupRootlessPortMappingViaSlirp(ctr *Container, cmd *exec.Cmd
 	// for each port we want to add we need to open a connection to the slirp4netns control socket
 	// and send the add_hostfwd command.
 	for _, i := range ctr.config.PortMappings {
-		conn, err := net.Dial("unix", apiSocket)
-		if err != nil {
-			return errors.Wrapf(err, "cannot open connection to %s", apiSocket)
-		}
-		defer func() {
-			if err := conn.Close(); err != nil {
-				logrus.Errorf("unable to close connection: %q", err)
-			}
-		}()
-		hostIP := i.HostIP
-		if hostIP == "" {
-			hostIP = "0.0.0.0"
-		}
-		apiCmd := slirp4netnsCmd{
-			Execute: "add_hostfwd",
-			Args: slirp4netnsCmdArg{
-				Proto:     i.Protocol,
-				HostAddr:  hostIP,
-				HostPort:  i.HostPort,
-				GuestPort: i.ContainerPort,
-			},
-		}
-		// create the JSON payload and send it.  Mark the end of request shutting down writes
-		// to the socket, as requested by slirp4netns.
-		data, err := json.Marshal(&apiCmd)
-		if err != nil {
-			return errors.Wrapf(err, "cannot marshal JSON for slirp4netns")
-		}
-		if _, err := conn.Write([]byte(fmt.Sprintf("%s\n", data))); err != nil {
-			return errors.Wrapf(err, "cannot write to control socket %s", apiSocket)
-		}
-		if err := conn.(*net.UnixConn).CloseWrite(); err != nil {
-			return errors.Wrapf(err, "cannot shutdown the socket %s", apiSocket)
-		}
-		buf := make([]byte, 2048)
-		readLength, err := conn.Read(buf)
-		if err != nil {
-			return errors.Wrapf(err, "cannot read from control socket %s", apiSocket)
-		}
-		// if there is no 'error' key in the received JSON data, then the operation was
-		// successful.
-		var y map[string]interface{}
-		if err := json.Unmarshal(buf[0:readLength], &y); err != nil {
-			return errors.Wrapf(err, "error parsing error status from slirp4netns")
-		}
-		if e, found := y["error"]; found {
-			return errors.Errorf("error from slirp4netns while setting up port redirection: %v", e)
+		if err := slirp4netnsAddHostFwd(apiSocket, i); err != nil {
+			return err
 		}
 	}
 	logrus.Debug("slirp4netns port-forwarding setup via add_hostfwd is ready")
 	return nil
 }
 
+// slirp4netnsAddHostFwd forwards the host port of the given port mapping with
+// the add_hostfwd command of the slirp4netns API.  Every command needs its
+// own connection, which is closed before returning so forwarding a large
+// range of ports does not keep a connection open per port.
+func slirp4netnsAddHostFwd(apiSocket string, port ocicni.PortMapping) error {
+	conn, err := net.Dial("unix", apiSocket)
+	if err != nil {
+		return errors.Wrapf(err, "cannot open connection to %s", apiSocket)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			logrus.Errorf("unable to close connection: %q", err)
+		}
+	}()
+	hostIP := port.HostIP
+	if hostIP == "" {
+		hostIP = "0.0.0.0"
+	}
+	apiCmd := slirp4netnsCmd{
+		Execute: "add_hostfwd",
+		Args: slirp4netnsCmdArg{
+			Proto:     port.Protocol,
+			HostAddr:  hostIP,
+			HostPort:  port.HostPort,
+			GuestPort: port.ContainerPort,
+		},
+	}
+	// create the JSON payload and send it.  Mark the end of request shutting down writes
+	// to the socket, as requested by slirp4netns.
+	data, err := json.Marshal(&apiCmd)
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal JSON for slirp4netns")
+	}
+	if _, err := conn.Write([]byte(fmt.Sprintf("%s\n", data))); err != nil {
+		return errors.Wrapf(err, "cannot write to control socket %s", apiSocket)
+	}
+	if err := conn.(*net.UnixConn).CloseWrite(); err != nil {
+		return errors.Wrapf(err, "cannot shutdown the socket %s", apiSocket)
+	}
+	buf := make([]byte, 2048)
+	readLength, err := conn.Read(buf)
+	if err != nil {
+		return errors.Wrapf(err, "cannot read from control socket %s", apiSocket)
+	}
+	// if there is no 'error' key in the received JSON data, then the operation was
+	// successful.
+	var y map[string]interface{}
+	if err := json.Unmarshal(buf[0:readLength], &y); err != nil {
+		return errors.Wrapf(err, "error parsing error status from slirp4netns")
+	}
+	if e, found := y["error"]; found {
+		return errors.Errorf("error from slirp4netns while setting up port redirection: %v", e)
+	}
+	return nil
+}
+
 // Configure the network namespace using the container process
 func (r *Runtime) setupNetNS(ctr *Container) error {
 	nsProcess := fmt.Sprintf("/proc/%d/ns/net", ctr.state.PID)
@@ -759,25 +744,8 @@ func (r *Runtime) teardownCNI(ctr *Container) error {
 
 	// rootless containers do not use the CNI plugin directly
 	if !rootless.IsRootless() && !ctr.config.NetMode.IsSlirp4netns() && len(networks) > 0 {
-		var requestedIP net.IP
-		if ctr.requestedIP != nil {
-			requestedIP = ctr.requestedIP
-			// cancel request for a specific IP in case the container is reused later
-			ctr.requestedIP = nil
-		} else {
-			requestedIP = ctr.config.StaticIP
-		}
-
-		var requestedMAC net.HardwareAddr
-		if ctr.requestedMAC != nil {
-			requestedMAC = ctr.requestedMAC
-			// cancel request for a specific MAC in case the container is reused later
-			ctr.requestedMAC = nil
-		} else {
-			requestedMAC = ctr.config.StaticMAC
-		}
-
-		podNetwork := r.getPodNetwork(ctr.ID(), ctr.Name(), ctr.state.NetNS.Path(), networks, ctr.config.PortMappings, requestedIP, requestedMAC, ContainerNetworkDescriptions{})
+		staticIPs, staticMACs := ctr.staticAddresses(networks)
+		podNetwork := r.getPodNetwork(ctr.ID(), ctr.Name(), ctr.state.NetNS.Path(), networks, ctr.config.PortMappings, staticIPs, staticMACs, ContainerNetworkDescriptions{})
 
 		if err := r.netPlugin.TearDownPod(podNetwork); err != nil {
 			return errors.Wrapf(err, "error tearing down CNI namespace configuration for container %s", ctr.ID())
@@ -815,6 +783,7 @@ func (r *Runtime) teardownNetNS(ctr *Container) error {
 	}
 
 	ctr.state.NetNS = nil
+	r.removeNetworkRecord(ctr.ID())
 
 	return nil
 }
@@ -1137,7 +1106,8 @@ func (c *Container) NetworkDisconnect(nameOrID, netName string, force bool) erro
 		return errors.Wrapf(define.ErrNoNetwork, "unable to disconnect %s from %s", nameOrID, netName)
 	}
 
-	podConfig := c.runtime.getPodNetwork(c.ID(), c.Name(), c.state.NetNS.Path(), []string{netName}, c.config.PortMappings, nil, nil, c.state.NetInterfaceDescriptions)
+	oldHosts := c.cniHosts()
+	podConfig := c.runtime.getPodNetwork(c.ID(), c.Name(), c.state.NetNS.Path(), []string{netName}, c.config.PortMappings, c.config.StaticIPs, c.config.StaticMACs, c.state.NetInterfaceDescriptions)
 	if err := c.runtime.netPlugin.TearDownPod(podConfig); err != nil {
 		return err
 	}
@@ -1145,14 +1115,17 @@ func (c *Container) NetworkDisconnect(nameOrID, netName string, force bool) erro
 	// update network status if container is not running
 	networkStatus := c.state.NetworkStatus
 	// clip out the index of the network
-	tmpNetworkStatus := make([]*cnitypes.Result, len(networkStatus)-1)
+	tmpNetworkStatus := make([]*cnitypes.Result, 0, len(networkStatus))
 	for k, v := range networkStatus {
 		if index != k {
 			tmpNetworkStatus = append(tmpNetworkStatus, v)
 		}
 	}
 	c.state.NetworkStatus = tmpNetworkStatus
-	return c.save()
+	if err := c.save(); err != nil {
+		return err
+	}
+	return c.updateNetworkFiles(oldHosts)
 }
 
 // ConnectNetwork connects a container to a given network
@@ -1187,6 +1160,7 @@ func (c *Container) NetworkConnect(nameOrID, netName string, aliases []string) e
 		return errors.Wrapf(define.ErrNoNetwork, "unable to connect %s to %s", nameOrID, netName)
 	}
 
+	oldHosts := c.cniHosts()
 	ctrNetworks, _, err := c.networks()
 	if err != nil {
 		return err
@@ -1195,9 +1169,12 @@ func (c *Container) NetworkConnect(nameOrID, netName string, aliases []string) e
 	if err := c.setupNetworkDescriptions(ctrNetworks); err != nil {
 		return err
 	}
-	podConfig := c.runtime.getPodNetwork(c.ID(), c.Name(), c.state.NetNS.Path(), []string{netName}, c.config.PortMappings, nil, nil, c.state.NetInterfaceDescriptions)
+	podConfig := c.runtime.getPodNetwork(c.ID(), c.Name(), c.state.NetNS.Path(), []string{netName}, c.config.PortMappings, c.config.StaticIPs, c.config.StaticMACs, c.state.NetInterfaceDescriptions)
 	podConfig.Aliases = make(map[string][]string, 1)
 	podConfig.Aliases[netName] = aliases
+	if err := c.runtime.recordNetworks(c.ID(), c.state.NetNS.Path(), ctrNetworks); err != nil {
+		return err
+	}
 	results, err := c.runtime.netPlugin.SetUpPod(podConfig)
 	if err != nil {
 		return err
@@ -1240,13 +1217,52 @@ func (c *Container) NetworkConnect(nameOrID, netName string, aliases []string) e
 		networkStatus[index] = networkResults[0]
 		c.state.NetworkStatus = networkStatus
 	}
-	return c.save()
+	if err := c.save(); err != nil {
+		return err
+	}
+	return c.updateNetworkFiles(oldHosts)
+}
+
+// updateNetworkFiles updates the hosts and resolv.conf files of a running
+// container after it was connected to or disconnected from a network, so that
+// they list the address and the DNS servers of its current networks. oldHosts
+// is the hosts entry of the container before the change. The files are
+// rewritten in place, as they are bind mounted into the container.
+func (c *Container) updateNetworkFiles(oldHosts string) error {
+	// Containers joining the network namespace of another container use
+	// its files.
+	if c.config.NetNsCtr != "" {
+		return nil
+	}
+
+	if hostsPath := c.state.BindMounts["/etc/hosts"]; hostsPath == filepath.Join(c.state.RunDir, "hosts") {
+		b, err := ioutil.ReadFile(hostsPath)
+		if err != nil {
+			return errors.Wrapf(err, "error reading hosts file of container %s", c.ID())
+		}
+		hosts := string(b)
+		if oldHosts != "" && strings.Contains(hosts, oldHosts) {
+			hosts = strings.Replace(hosts, oldHosts, c.cniHosts(), 1)
+		} else {
+			hosts += c.cniHosts()
+		}
+		if err := ioutil.WriteFile(hostsPath, []byte(hosts), 0644); err != nil {
+			return errors.Wrapf(err, "error updating hosts file of container %s", c.ID())
+		}
+	}
+
+	if resolvPath := c.state.BindMounts["/etc/resolv.conf"]; resolvPath == filepath.Join(c.state.RunDir, "resolv.conf") {
+		if _, err := c.generateResolvConf(); err != nil {
+			return errors.Wrapf(err, "error updating resolv.conf of container %s", c.ID())
+		}
+	}
+	return nil
 }
 
 // DisconnectContainerFromNetwork removes a container from its CNI network
 func (r *Runtime) DisconnectContainerFromNetwork(nameOrID, netName string, force bool) error {
 	if rootless.IsRootless() {
-		return errors.New("network connect is not enabled for rootless containers")
+		return errors.New("network disconnect is not enabled for rootless containers")
 	}
 	ctr, err := r.LookupContainer(nameOrID)
 	if err != nil {
@@ -1258,7 +1274,7 @@ func (r *Runtime) DisconnectContainerFromNetwork(nameOrID, netName string, force
 // ConnectContainerToNetwork connects a container to a CNI network
 func (r *Runtime) ConnectContainerToNetwork(nameOrID, netName string, aliases []string) error {
 	if rootless.IsRootless() {
-		return errors.New("network disconnect is not enabled for rootless containers")
+		return errors.New("network connect is not enabled for rootless containers")
 	}
 	ctr, err := r.LookupContainer(nameOrID)
 	if err != nil {
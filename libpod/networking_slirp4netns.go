@@ -0,0 +1,203 @@
+package libpod
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// slirp4netnsDefaultMTU is the MTU of the tap device of slirp4netns
+	// unless set with the mtu option.
+	slirp4netnsDefaultMTU = 65520
+	// slirp4netnsMinMTU and slirp4netnsMaxMTU are the bounds of the MTU
+	// accepted by slirp4netns.
+	slirp4netnsMinMTU = 68
+	slirp4netnsMaxMTU = 65521
+	// slirp4netnsDefaultCIDR is the network of slirp4netns unless set
+	// with the cidr option.
+	slirp4netnsDefaultCIDR = "10.0.2.0/24"
+)
+
+// slirp4netnsAddresses are the well-known addresses of a slirp4netns network.
+type slirp4netnsAddresses struct {
+	// container is the address of the container.
+	container net.IP
+	// gateway is the address of the gateway, which reaches the loopback
+	// of the host if allow_host_loopback is set.
+	gateway net.IP
+	// dns is the address of the built-in DNS server.
+	dns net.IP
+}
+
+// slirp4netnsNetworkOptions are the options of a slirp4netns network, set in
+// containers.conf and with --network slirp4netns:OPTIONS.
+type slirp4netnsNetworkOptions struct {
+	cidr                string
+	disableHostLoopback bool
+	enableIPv6          bool
+	// isSlirpHostForward is set if ports are forwarded by slirp4netns
+	// instead of rootlessport.
+	isSlirpHostForward bool
+	mtu                int
+	outboundAddr       string
+	outboundAddr6      string
+}
+
+// parseSlirp4netnsNetworkOptions parses the slirp4netns options.  Later
+// options override earlier ones, so options of a container can be appended to
+// the defaults of containers.conf.
+func parseSlirp4netnsNetworkOptions(options []string) (*slirp4netnsNetworkOptions, error) {
+	opts := &slirp4netnsNetworkOptions{
+		disableHostLoopback: true,
+		mtu:                 slirp4netnsDefaultMTU,
+	}
+	for _, o := range options {
+		parts := strings.SplitN(o, "=", 2)
+		if len(parts) < 2 {
+			return nil, errors.Errorf("unknown option for slirp4netns: %q", o)
+		}
+		option, value := parts[0], parts[1]
+		switch option {
+		case "cidr":
+			ipv4, _, err := net.ParseCIDR(value)
+			if err != nil || ipv4.To4() == nil {
+				return nil, errors.Errorf("invalid cidr %q", value)
+			}
+			opts.cidr = value
+		case "port_handler":
+			switch value {
+			case "slirp4netns":
+				opts.isSlirpHostForward = true
+			case "rootlesskit", "rootlessport":
+				opts.isSlirpHostForward = false
+			default:
+				return nil, errors.Errorf("unknown port_handler for slirp4netns: %q", value)
+			}
+		case "allow_host_loopback":
+			switch value {
+			case "true":
+				opts.disableHostLoopback = false
+			case "false":
+				opts.disableHostLoopback = true
+			default:
+				return nil, errors.Errorf("invalid value of allow_host_loopback for slirp4netns: %q", value)
+			}
+		case "enable_ipv6":
+			switch value {
+			case "true":
+				opts.enableIPv6 = true
+			case "false":
+				opts.enableIPv6 = false
+			default:
+				return nil, errors.Errorf("invalid value of enable_ipv6 for slirp4netns: %q", value)
+			}
+		case "mtu":
+			mtu, err := strconv.Atoi(value)
+			if err != nil || mtu < slirp4netnsMinMTU || mtu > slirp4netnsMaxMTU {
+				return nil, errors.Errorf("invalid mtu %q for slirp4netns: must be between %d and %d", value, slirp4netnsMinMTU, slirp4netnsMaxMTU)
+			}
+			opts.mtu = mtu
+		case "outbound_addr":
+			ipv4 := net.ParseIP(value)
+			if ipv4 == nil || ipv4.To4() == nil {
+				_, err := net.InterfaceByName(value)
+				if err != nil {
+					return nil, errors.Errorf("invalid outbound_addr %q", value)
+				}
+			}
+			opts.outboundAddr = value
+		case "outbound_addr6":
+			ipv6 := net.ParseIP(value)
+			if ipv6 == nil || ipv6.To4() != nil {
+				_, err := net.InterfaceByName(value)
+				if err != nil {
+					return nil, errors.Errorf("invalid outbound_addr6: %q", value)
+				}
+			}
+			opts.outboundAddr6 = value
+		default:
+			return nil, errors.Errorf("unknown option for slirp4netns: %q", o)
+		}
+	}
+	if opts.outboundAddr6 != "" && !opts.enableIPv6 {
+		return nil, errors.Errorf("enable_ipv6=true is required for outbound_addr6")
+	}
+	return opts, nil
+}
+
+// addresses returns the addresses of the container, the gateway and the DNS
+// server in the network of slirp4netns, which are at fixed offsets in the
+// network set with the cidr option.
+func (o *slirp4netnsNetworkOptions) addresses() (*slirp4netnsAddresses, error) {
+	cidr := o.cidr
+	if cidr == "" {
+		cidr = slirp4netnsDefaultCIDR
+	}
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid cidr %q", cidr)
+	}
+	network := ipNet.IP.To4()
+	if network == nil {
+		return nil, errors.Errorf("invalid cidr %q", cidr)
+	}
+	nth := func(n byte) net.IP {
+		ip := make(net.IP, len(network))
+		copy(ip, network)
+		ip[3] += n
+		return ip
+	}
+	return &slirp4netnsAddresses{
+		container: nth(100),
+		gateway:   nth(2),
+		dns:       nth(3),
+	}, nil
+}
+
+// slirp4netnsAddresses returns the addresses of the slirp4netns network of
+// the container, falling back to the default network if its options are
+// invalid.
+func (c *Container) slirp4netnsAddresses() *slirp4netnsAddresses {
+	netOptions, err := parseSlirp4netnsNetworkOptions(c.slirp4netnsOptions())
+	if err != nil {
+		logrus.Debugf("Error parsing slirp4netns options of container %s: %v", c.ID(), err)
+		netOptions = &slirp4netnsNetworkOptions{}
+	}
+	addrs, err := netOptions.addresses()
+	if err != nil {
+		logrus.Debugf("Error computing slirp4netns addresses of container %s: %v", c.ID(), err)
+		addrs, _ = (&slirp4netnsNetworkOptions{}).addresses()
+	}
+	return addrs
+}
+
+// slirp4netnsOptions returns the slirp4netns options of the container, the
+// defaults of containers.conf followed by the options of the container.
+func (c *Container) slirp4netnsOptions() []string {
+	var options []string
+	if c.config.NetworkOptions != nil {
+		options = c.config.NetworkOptions["slirp4netns"]
+	}
+	return c.runtime.slirp4netnsOptions(options)
+}
+
+// slirp4netnsOptions returns the defaults of containers.conf followed by the
+// given slirp4netns options.
+func (r *Runtime) slirp4netnsOptions(options []string) []string {
+	return append(append([]string{}, r.config.Engine.NetworkCmdOptions...), options...)
+}
+
+// validateSlirp4netnsOptions checks the given slirp4netns options together
+// with the defaults of containers.conf, as they are used when the network is
+// set up.
+func (r *Runtime) validateSlirp4netnsOptions(options []string) error {
+	if _, err := parseSlirp4netnsNetworkOptions(r.slirp4netnsOptions(options)); err != nil {
+		return errors.Wrapf(define.ErrInvalidArg, "%v", err)
+	}
+	return nil
+}
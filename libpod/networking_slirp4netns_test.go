@@ -0,0 +1,90 @@
+package libpod
+
+import (
+	"testing"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSlirp4netnsNetworkOptionsDefaults(t *testing.T) {
+	opts, err := parseSlirp4netnsNetworkOptions(nil)
+	require.NoError(t, err)
+	assert.True(t, opts.disableHostLoopback)
+	assert.False(t, opts.isSlirpHostForward)
+	assert.Equal(t, slirp4netnsDefaultMTU, opts.mtu)
+}
+
+func TestParseSlirp4netnsNetworkOptions(t *testing.T) {
+	opts, err := parseSlirp4netnsNetworkOptions([]string{
+		"port_handler=slirp4netns",
+		"allow_host_loopback=true",
+		"mtu=1500",
+		"cidr=10.10.0.0/24",
+		"enable_ipv6=true",
+		"outbound_addr6=::1",
+	})
+	require.NoError(t, err)
+	assert.True(t, opts.isSlirpHostForward)
+	assert.False(t, opts.disableHostLoopback)
+	assert.Equal(t, 1500, opts.mtu)
+	assert.Equal(t, "10.10.0.0/24", opts.cidr)
+	assert.True(t, opts.enableIPv6)
+	assert.Equal(t, "::1", opts.outboundAddr6)
+}
+
+func TestParseSlirp4netnsNetworkOptionsOverride(t *testing.T) {
+	// options of the container follow the defaults of containers.conf
+	opts, err := parseSlirp4netnsNetworkOptions([]string{"port_handler=slirp4netns", "port_handler=rootlessport"})
+	require.NoError(t, err)
+	assert.False(t, opts.isSlirpHostForward)
+}
+
+func TestParseSlirp4netnsNetworkOptionsInvalid(t *testing.T) {
+	for _, options := range [][]string{
+		{"mtu"},
+		{"mtu=67"},
+		{"mtu=65522"},
+		{"mtu=large"},
+		{"port_handler=socat"},
+		{"allow_host_loopback=yes"},
+		{"cidr=fd00::/64"},
+		{"outbound_addr6=::1"},
+		{"unknown=1"},
+	} {
+		_, err := parseSlirp4netnsNetworkOptions(options)
+		assert.Error(t, err, options)
+	}
+}
+
+func TestSlirp4netnsAddresses(t *testing.T) {
+	opts, err := parseSlirp4netnsNetworkOptions(nil)
+	require.NoError(t, err)
+	addrs, err := opts.addresses()
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.2.100", addrs.container.String())
+	assert.Equal(t, "10.0.2.2", addrs.gateway.String())
+	assert.Equal(t, "10.0.2.3", addrs.dns.String())
+
+	opts, err = parseSlirp4netnsNetworkOptions([]string{"cidr=10.10.0.0/24"})
+	require.NoError(t, err)
+	addrs, err = opts.addresses()
+	require.NoError(t, err)
+	assert.Equal(t, "10.10.0.100", addrs.container.String())
+	assert.Equal(t, "10.10.0.2", addrs.gateway.String())
+	assert.Equal(t, "10.10.0.3", addrs.dns.String())
+}
+
+func TestValidateSlirp4netnsOptionsDefaults(t *testing.T) {
+	r := &Runtime{config: &config.Config{}}
+	r.config.Engine.NetworkCmdOptions = []string{"enable_ipv6=true"}
+
+	// outbound_addr6 relies on enable_ipv6 from containers.conf
+	require.NoError(t, r.validateSlirp4netnsOptions([]string{"outbound_addr6=::1"}))
+
+	r.config.Engine.NetworkCmdOptions = nil
+	assert.Equal(t, define.ErrInvalidArg, errors.Cause(r.validateSlirp4netnsOptions([]string{"outbound_addr6=::1"})))
+}
@@ -38,3 +38,7 @@ func (r *Runtime) reloadContainerNetwork(ctr *Container) ([]*cnitypes.Result, er
 func getCNINetworksDir() (string, error) {
 	return "", define.ErrNotImplemented
 }
+
+func (r *Runtime) PruneLeakedNetworks() (map[string]error, error) {
+	return nil, define.ErrNotImplemented
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/containers/podman/v2/libpod/define"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"k8s.io/client-go/tools/remotecommand"
 )
 
@@ -27,6 +28,13 @@ type OCIRuntime interface {
 	CreateContainer(ctr *Container, restoreOptions *ContainerCheckpointOptions) error
 	// UpdateContainerStatus updates the status of the given container.
 	UpdateContainerStatus(ctr *Container) error
+	// ListContainerStatuses returns the status of all the containers of
+	// the runtime, by container ID, with a single query of the runtime.
+	// An error is returned if the runtime cannot list its containers.
+	ListContainerStatuses() (map[string]*spec.State, error)
+	// UpdateContainerStatusFrom updates the status of the given container
+	// from its status returned by ListContainerStatuses.
+	UpdateContainerStatusFrom(ctr *Container, status *spec.State) error
 	// StartContainer starts the given container.
 	StartContainer(ctr *Container) error
 	// KillContainer sends the given signal to the given container.
@@ -34,21 +42,25 @@ type OCIRuntime interface {
 	// otherwise, only init will be signalled.
 	KillContainer(ctr *Container, signal uint, all bool) error
 	// StopContainer stops the given container.
-	// The container's stop signal (or SIGTERM if unspecified) will be sent
-	// first.
-	// After the given timeout, SIGKILL will be sent.
-	// If the given timeout is 0, SIGKILL will be sent immediately, and the
-	// stop signal will be omitted.
+	// The signal of each of the given steps (or SIGTERM if unspecified)
+	// will be sent in order, each followed by waiting for the step's
+	// timeout for the container to exit.
+	// After the last step, SIGKILL will be sent.
+	// Steps with a timeout of 0 are omitted, so if no step has a timeout,
+	// SIGKILL will be sent immediately.
 	// If all is set, we will attempt to use the --all flag will `kill` in
 	// the OCI runtime to kill all processes in the container, including
 	// exec sessions. This is only supported if the container has cgroups.
-	StopContainer(ctr *Container, timeout uint, all bool) error
+	StopContainer(ctr *Container, steps []define.StopStep, all bool) error
 	// DeleteContainer deletes the given container from the OCI runtime.
 	DeleteContainer(ctr *Container) error
 	// PauseContainer pauses the given container.
 	PauseContainer(ctr *Container) error
 	// UnpauseContainer unpauses the given container.
 	UnpauseContainer(ctr *Container) error
+	// UpdateContainer updates the cgroup resource limits of the given
+	// container.
+	UpdateContainer(ctr *Container, resources *spec.LinuxResources) error
 
 	// HTTPAttach performs an attach intended to be transported over HTTP.
 	// For terminal attach, the container's output will be directly streamed
@@ -157,6 +169,9 @@ type ExecOptions struct {
 	Env map[string]string
 	// Terminal is whether to create a new TTY for the exec session.
 	Terminal bool
+	// ConsoleSize is the initial size of the TTY. Only used if Terminal is
+	// set.
+	ConsoleSize *spec.Box
 	// Cwd is the working directory for the executed command. If unset, the
 	// working directory of the container will be used.
 	Cwd string
@@ -318,6 +318,50 @@ func (r *ConmonOCIRuntime) UpdateContainerStatus(ctr *Container) error {
 	if err := json.NewDecoder(bytes.NewBuffer(out)).Decode(state); err != nil {
 		return errors.Wrapf(err, "error decoding container status for container %s", ctr.ID())
 	}
+
+	return r.updateContainerStatusFrom(ctr, oldState, state, exitFile)
+}
+
+// ListContainerStatuses returns the status of all the containers of the
+// runtime, by container ID, using the list command of the runtime.
+func (r *ConmonOCIRuntime) ListContainerStatuses() (map[string]*spec.State, error) {
+	runtimeDir, err := util.GetRuntimeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(r.path, "list", "--format", "json")
+	cmd.Env = append(cmd.Env, fmt.Sprintf("XDG_RUNTIME_DIR=%s", runtimeDir))
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "error listing containers of OCI runtime %s", r.name)
+	}
+
+	// runc prints null if there are no containers.
+	var list []*spec.State
+	if err := json.Unmarshal(out, &list); err != nil {
+		return nil, errors.Wrapf(err, "error decoding containers of OCI runtime %s", r.name)
+	}
+	statuses := make(map[string]*spec.State, len(list))
+	for _, state := range list {
+		statuses[state.ID] = state
+	}
+	return statuses, nil
+}
+
+// UpdateContainerStatusFrom updates the status of the given container from
+// its status returned by ListContainerStatuses.
+func (r *ConmonOCIRuntime) UpdateContainerStatusFrom(ctr *Container, status *spec.State) error {
+	exitFile, err := r.ExitFilePath(ctr)
+	if err != nil {
+		return err
+	}
+	return r.updateContainerStatusFrom(ctr, ctr.state.State, status, exitFile)
+}
+
+// updateContainerStatusFrom updates the status of the given container, whose
+// status was oldState, from the status returned by the runtime.
+func (r *ConmonOCIRuntime) updateContainerStatusFrom(ctr *Container, oldState define.ContainerStatus, state *spec.State, exitFile string) error {
 	ctr.state.PID = state.Pid
 
 	switch state.Status {
@@ -408,13 +452,14 @@ func (r *ConmonOCIRuntime) KillContainer(ctr *Container, signal uint, all bool)
 	return nil
 }
 
-// StopContainer stops a container, first using its given stop signal (or
-// SIGTERM if no signal was specified), then using SIGKILL.
-// Timeout is given in seconds. If timeout is 0, the container will be
-// immediately kill with SIGKILL.
+// StopContainer stops a container, first using the signal of each of the
+// given steps in order (or SIGTERM if a step has no signal), then using
+// SIGKILL.
+// Timeouts are given in seconds. Steps with a timeout of 0 are skipped, so if
+// no step has a timeout the container will be immediately killed with SIGKILL.
 // Does not set finished time for container, assumes you will run updateStatus
 // after to pull the exit code.
-func (r *ConmonOCIRuntime) StopContainer(ctr *Container, timeout uint, all bool) error {
+func (r *ConmonOCIRuntime) StopContainer(ctr *Container, steps []define.StopStep, all bool) error {
 	logrus.Debugf("Stopping container %s (PID %d)", ctr.ID(), ctr.state.PID)
 
 	// Ping the container to see if it's alive
@@ -424,12 +469,16 @@ func (r *ConmonOCIRuntime) StopContainer(ctr *Container, timeout uint, all bool)
 		return nil
 	}
 
-	stopSignal := ctr.config.StopSignal
-	if stopSignal == 0 {
-		stopSignal = uint(syscall.SIGTERM)
-	}
+	for _, step := range steps {
+		if step.Timeout == 0 {
+			continue
+		}
+
+		stopSignal := step.Signal
+		if stopSignal == 0 {
+			stopSignal = uint(syscall.SIGTERM)
+		}
 
-	if timeout > 0 {
 		if err := r.KillContainer(ctr, stopSignal, all); err != nil {
 			// Is the container gone?
 			// If so, it probably died between the first check and
@@ -443,14 +492,15 @@ func (r *ConmonOCIRuntime) StopContainer(ctr *Container, timeout uint, all bool)
 			return err
 		}
 
-		if err := waitContainerStop(ctr, time.Duration(timeout)*time.Second); err != nil {
-			logrus.Infof("Timed out stopping container %s, resorting to SIGKILL: %v", ctr.ID(), err)
+		if err := waitContainerStop(ctr, time.Duration(step.Timeout)*time.Second); err != nil {
+			logrus.Infof("Timed out stopping container %s with signal %d: %v", ctr.ID(), stopSignal, err)
 		} else {
 			// No error, the container is dead
 			return nil
 		}
 	}
 
+	logrus.Debugf("Sending SIGKILL to container %s", ctr.ID())
 	if err := r.KillContainer(ctr, 9, all); err != nil {
 		// Again, check if the container is gone. If it is, exit cleanly.
 		err := unix.Kill(ctr.state.PID, 0)
@@ -499,6 +549,21 @@ func (r *ConmonOCIRuntime) UnpauseContainer(ctr *Container) error {
 	return utils.ExecCmdWithStdStreams(os.Stdin, os.Stdout, os.Stderr, env, r.path, append(r.runtimeFlags, "resume", ctr.ID())...)
 }
 
+// UpdateContainer updates the cgroup resource limits of the given container.
+// The resources are passed to the runtime's update command as JSON on stdin.
+func (r *ConmonOCIRuntime) UpdateContainer(ctr *Container, resources *spec.LinuxResources) error {
+	resourcesJSON, err := json.Marshal(resources)
+	if err != nil {
+		return errors.Wrapf(err, "error encoding resources of container %s", ctr.ID())
+	}
+	runtimeDir, err := util.GetRuntimeDir()
+	if err != nil {
+		return err
+	}
+	env := []string{fmt.Sprintf("XDG_RUNTIME_DIR=%s", runtimeDir)}
+	return utils.ExecCmdWithStdStreams(bytes.NewReader(resourcesJSON), os.Stdout, os.Stderr, env, r.path, append(r.runtimeFlags, "update", "--resources", "-", ctr.ID())...)
+}
+
 // HTTPAttach performs an attach for the HTTP API.
 // The caller must handle closing the HTTP connection after this returns.
 // The cancel channel is not closed; it is up to the caller to do so after
@@ -1015,7 +1080,12 @@ func (r *ConmonOCIRuntime) createOCIContainer(ctr *Container, restoreOptions *Co
 		}
 	}
 
-	args := r.sharedConmonArgs(ctr, ctr.ID(), ctr.bundlePath(), filepath.Join(ctr.state.RunDir, "pidfile"), ctr.LogPath(), r.exitsDir, ociLog, ctr.LogDriver(), logTag)
+	pidfile := ctr.config.PidFile
+	if pidfile == "" {
+		pidfile = filepath.Join(ctr.state.RunDir, "pidfile")
+	}
+
+	args := r.sharedConmonArgs(ctr, ctr.ID(), ctr.bundlePath(), pidfile, ctr.LogPath(), r.exitsDir, ociLog, ctr.LogDriver(), logTag)
 
 	if ctr.config.Spec.Process.Terminal {
 		args = append(args, "-t")
@@ -1034,7 +1104,15 @@ func (r *ConmonOCIRuntime) createOCIContainer(ctr *Container, restoreOptions *Co
 		args = append(args, "--no-pivot")
 	}
 
-	if len(ctr.config.ExitCommand) > 0 {
+	// Conmon kills the container when its timeout expires.
+	if ctr.config.Timeout > 0 {
+		args = append(args, fmt.Sprintf("--timeout=%d", ctr.config.Timeout))
+	}
+
+	// A running cleanup monitor takes care of the container when it exits.
+	if len(ctr.config.ExitCommand) > 0 && cleanupMonitorRunning(r.tmpDir) {
+		logrus.Debugf("Cleanup monitor is running, not setting exit command for container %s", ctr.ID())
+	} else if len(ctr.config.ExitCommand) > 0 {
 		args = append(args, "--exit-command", ctr.config.ExitCommand[0])
 		for _, arg := range ctr.config.ExitCommand[1:] {
 			args = append(args, []string{"--exit-command-arg", arg}...)
@@ -1185,7 +1263,7 @@ func (r *ConmonOCIRuntime) createOCIContainer(ctr *Container, restoreOptions *Co
 
 // prepareProcessExec returns the path of the process.json used in runc exec -p
 // caller is responsible to close the returned *os.File if needed.
-func prepareProcessExec(c *Container, cmd, env []string, tty bool, cwd, user, sessionID string) (*os.File, error) {
+func prepareProcessExec(c *Container, cmd, env []string, tty bool, consoleSize *spec.Box, cwd, user, sessionID string) (*os.File, error) {
 	f, err := ioutil.TempFile(c.execBundlePath(sessionID), "exec-process-")
 	if err != nil {
 		return nil, err
@@ -1196,8 +1274,10 @@ func prepareProcessExec(c *Container, cmd, env []string, tty bool, cwd, user, se
 	// We need to default this to false else it will inherit terminal as true
 	// from the container.
 	pspec.Terminal = false
+	pspec.ConsoleSize = nil
 	if tty {
 		pspec.Terminal = true
+		pspec.ConsoleSize = consoleSize
 	}
 	if len(env) > 0 {
 		pspec.Env = append(pspec.Env, env...)
@@ -1247,6 +1327,16 @@ func prepareProcessExec(c *Container, cmd, env []string, tty bool, cwd, user, se
 		pspec.User = processUser
 	}
 
+	// Exec sessions use the umask of the container's init process.
+	if c.config.Umask != "" {
+		decVal, err := strconv.ParseUint(c.config.Umask, 8, 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Invalid Umask Value")
+		}
+		umask := uint32(decVal)
+		pspec.User.Umask = &umask
+	}
+
 	hasHomeSet := false
 	for _, s := range pspec.Env {
 		if strings.HasPrefix(s, "HOME=") {
@@ -0,0 +1,45 @@
+// +build linux
+
+package libpod
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListContainerStatuses(t *testing.T) {
+	dir, err := ioutil.TempDir("", "libpod_test_")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	output := filepath.Join(dir, "list.json")
+	script := filepath.Join(dir, "runtime")
+	require.NoError(t, ioutil.WriteFile(script, []byte("#!/bin/sh\n[ \"$1 $2 $3\" = \"list --format json\" ] && exec cat "+output+"\nexit 1\n"), 0700))
+	r := &ConmonOCIRuntime{name: "test", path: script}
+
+	require.NoError(t, ioutil.WriteFile(output, []byte(`[
+  {"ociVersion": "1.0.2", "id": "abc", "pid": 1234, "status": "running", "bundle": "/b", "created": "2020-01-01T00:00:00Z", "owner": "root"},
+  {"ociVersion": "1.0.2", "id": "def", "pid": 0, "status": "stopped", "bundle": "/b", "created": "2020-01-01T00:00:00Z", "owner": "root"}
+]`), 0600))
+	statuses, err := r.ListContainerStatuses()
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.Equal(t, 1234, statuses["abc"].Pid)
+	assert.Equal(t, "running", string(statuses["abc"].Status))
+	assert.Equal(t, "stopped", string(statuses["def"].Status))
+
+	// runc prints null without containers.
+	require.NoError(t, ioutil.WriteFile(output, []byte("null\n"), 0600))
+	statuses, err = r.ListContainerStatuses()
+	require.NoError(t, err)
+	assert.Empty(t, statuses)
+
+	// Runtimes without the list command fail.
+	require.NoError(t, os.Remove(output))
+	_, err = r.ListContainerStatuses()
+	assert.Error(t, err)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/containers/common/pkg/config"
 
 	"github.com/containers/podman/v2/libpod/define"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 )
 
 const (
@@ -41,6 +42,16 @@ func (r *ConmonOCIRuntime) UpdateContainerStatus(ctr *Container, useRuntime bool
 	return define.ErrNotImplemented
 }
 
+// ListContainerStatuses is not supported on this OS.
+func (r *ConmonOCIRuntime) ListContainerStatuses() (map[string]*spec.State, error) {
+	return nil, define.ErrNotImplemented
+}
+
+// UpdateContainerStatusFrom is not supported on this OS.
+func (r *ConmonOCIRuntime) UpdateContainerStatusFrom(ctr *Container, status *spec.State) error {
+	return define.ErrNotImplemented
+}
+
 // StartContainer is not supported on this OS.
 func (r *ConmonOCIRuntime) StartContainer(ctr *Container) error {
 	return define.ErrNotImplemented
@@ -52,7 +63,7 @@ func (r *ConmonOCIRuntime) KillContainer(ctr *Container, signal uint, all bool)
 }
 
 // StopContainer is not supported on this OS.
-func (r *ConmonOCIRuntime) StopContainer(ctr *Container, timeout uint, all bool) error {
+func (r *ConmonOCIRuntime) StopContainer(ctr *Container, steps []define.StopStep, all bool) error {
 	return define.ErrNotImplemented
 }
 
@@ -71,6 +82,11 @@ func (r *ConmonOCIRuntime) UnpauseContainer(ctr *Container) error {
 	return define.ErrNotImplemented
 }
 
+// UpdateContainer is not supported on this OS.
+func (r *ConmonOCIRuntime) UpdateContainer(ctr *Container, resources *spec.LinuxResources) error {
+	return define.ErrNotImplemented
+}
+
 // ExecContainer is not supported on this OS.
 func (r *ConmonOCIRuntime) ExecContainer(ctr *Container, sessionID string, options *ExecOptions) (int, chan error, error) {
 	return -1, nil, define.ErrNotImplemented
@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/containers/podman/v2/libpod/define"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"k8s.io/client-go/tools/remotecommand"
@@ -76,6 +77,16 @@ func (r *MissingRuntime) UpdateContainerStatus(ctr *Container) error {
 	return r.printError()
 }
 
+// ListContainerStatuses is not available as the runtime is missing
+func (r *MissingRuntime) ListContainerStatuses() (map[string]*spec.State, error) {
+	return nil, r.printError()
+}
+
+// UpdateContainerStatusFrom is not available as the runtime is missing
+func (r *MissingRuntime) UpdateContainerStatusFrom(ctr *Container, status *spec.State) error {
+	return r.printError()
+}
+
 // StartContainer is not available as the runtime is missing
 func (r *MissingRuntime) StartContainer(ctr *Container) error {
 	return r.printError()
@@ -90,7 +101,7 @@ func (r *MissingRuntime) KillContainer(ctr *Container, signal uint, all bool) er
 }
 
 // StopContainer is not available as the runtime is missing
-func (r *MissingRuntime) StopContainer(ctr *Container, timeout uint, all bool) error {
+func (r *MissingRuntime) StopContainer(ctr *Container, steps []define.StopStep, all bool) error {
 	return r.printError()
 }
 
@@ -109,6 +120,11 @@ func (r *MissingRuntime) UnpauseContainer(ctr *Container) error {
 	return r.printError()
 }
 
+// UpdateContainer is not available as the runtime is missing
+func (r *MissingRuntime) UpdateContainer(ctr *Container, resources *spec.LinuxResources) error {
+	return r.printError()
+}
+
 // HTTPAttach is not available as the runtime is missing
 func (r *MissingRuntime) HTTPAttach(ctr *Container, req *http.Request, w http.ResponseWriter, streams *HTTPAttachStreams, detachKeys *string, cancel <-chan bool, hijackDone chan<- bool, streamAttach, streamLogs bool) error {
 	return r.printError()
@@ -14,10 +14,12 @@ import (
 	"github.com/containers/podman/v2/libpod/events"
 	"github.com/containers/podman/v2/pkg/namespaces"
 	"github.com/containers/podman/v2/pkg/rootless"
+	"github.com/containers/podman/v2/pkg/secrets"
 	"github.com/containers/podman/v2/pkg/util"
 	"github.com/containers/storage"
 	"github.com/containers/storage/pkg/idtools"
 	"github.com/cri-o/ocicni/pkg/ocicni"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/opencontainers/runtime-tools/generate"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -597,6 +599,35 @@ func WithShmSize(size int64) CtrCreateOption {
 	}
 }
 
+// WithShmSizeSystemd sets the size of the tmpfs mounts created for
+// containers running systemd.
+func WithShmSizeSystemd(size int64) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		ctr.config.ShmSizeSystemd = size
+		return nil
+	}
+}
+
+// WithResources sets the resource limits (CPU, memory, etc.) of the
+// container, replacing the limits in the OCI spec.
+func WithResources(resources *spec.LinuxResources) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		if ctr.config.Spec.Linux == nil {
+			ctr.config.Spec.Linux = new(spec.Linux)
+		}
+		ctr.config.Spec.Linux.Resources = resources
+		return nil
+	}
+}
+
 // WithPrivileged sets the privileged flag in the container runtime.
 func WithPrivileged(privileged bool) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -751,6 +782,28 @@ func WithStopTimeout(timeout uint) CtrCreateOption {
 	}
 }
 
+// WithStopSignals sets the signal escalation chain used to stop the
+// container. Each signal is sent in order, and the container is given the
+// step's timeout to exit before the next signal is sent. SIGKILL is sent
+// once all signals have been sent.
+func WithStopSignals(steps []define.StopStep) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		for _, step := range steps {
+			if step.Signal > 64 {
+				return errors.Wrapf(define.ErrInvalidArg, "stop signal cannot be greater than 64 (SIGRTMAX)")
+			}
+		}
+
+		ctr.config.StopSignals = append([]define.StopStep{}, steps...)
+
+		return nil
+	}
+}
+
 // WithIDMappings sets the idmappings for the container
 func WithIDMappings(idmappings storage.IDMappingOptions) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -777,6 +830,23 @@ func WithExitCommand(exitCommand []string) CtrCreateOption {
 	}
 }
 
+// WithPodNamespaceHolder indicates that the container holds the namespaces
+// shared by its pod, which has no infra container and no container holding
+// them yet.  Creating the container fails with
+// define.ErrPodNamespaceHolderExists if another container holds them by the
+// time the container is added to the pod.
+func WithPodNamespaceHolder() CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		ctr.holdsPodNamespaces = true
+
+		return nil
+	}
+}
+
 // WithUTSNSFromPod indicates the the container should join the UTS namespace of
 // its pod
 func WithUTSNSFromPod(p *Pod) CtrCreateOption {
@@ -789,7 +859,7 @@ func WithUTSNSFromPod(p *Pod) CtrCreateOption {
 			return err
 		}
 
-		infraContainer, err := p.InfraContainerID()
+		infraContainer, err := p.NamespaceHolderID()
 		if err != nil {
 			return err
 		}
@@ -989,6 +1059,40 @@ func WithDependencyCtrs(ctrs []*Container) CtrCreateOption {
 	}
 }
 
+// WithHealthyDependencyCtrs sets containers which must report healthy before
+// this container is started, waiting at most timeout seconds for them (0
+// waits indefinitely).  The containers must have a healthcheck and are added
+// to the dependencies of the container, so this must be given after
+// WithDependencyCtrs.
+func WithHealthyDependencyCtrs(ctrs []*Container, timeout uint) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		healthy := make([]string, 0, len(ctrs))
+
+		for _, dep := range ctrs {
+			if err := checkDependencyContainer(dep, ctr); err != nil {
+				return err
+			}
+			if !dep.HasHealthCheck() {
+				return errors.Wrapf(define.ErrInvalidArg, "container %s has no healthcheck, cannot wait for it to become healthy", dep.ID())
+			}
+
+			healthy = append(healthy, dep.ID())
+			if !util.StringInSlice(dep.ID(), ctr.config.Dependencies) {
+				ctr.config.Dependencies = append(ctr.config.Dependencies, dep.ID())
+			}
+		}
+
+		ctr.config.HealthyDependencies = healthy
+		ctr.config.HealthyDependencyTimeout = timeout
+
+		return nil
+	}
+}
+
 // WithNetNS indicates that the container should be given a new network
 // namespace with a minimal configuration.
 // An optional array of port mappings can be provided.
@@ -1027,6 +1131,23 @@ func WithStaticIP(ip net.IP) CtrCreateOption {
 	}
 }
 
+// WithStaticIPv6 indicates that the container should request a static IPv6
+// address from the CNI plugins.
+// It cannot be set unless WithNetNS has already been passed.
+// Further, it cannot be set if additional CNI networks to join have been
+// specified, or together with WithStaticIP.
+func WithStaticIPv6(ip net.IP) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		ctr.config.StaticIPv6 = ip
+
+		return nil
+	}
+}
+
 // WithNetworkOptions sets additional options for the networks.
 func WithNetworkOptions(options map[string][]string) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -1034,6 +1155,10 @@ func WithNetworkOptions(options map[string][]string) CtrCreateOption {
 			return define.ErrCtrFinalized
 		}
 
+		if err := ctr.runtime.validateSlirp4netnsOptions(options["slirp4netns"]); err != nil {
+			return err
+		}
+
 		ctr.config.NetworkOptions = options
 
 		return nil
@@ -1043,8 +1168,8 @@ func WithNetworkOptions(options map[string][]string) CtrCreateOption {
 // WithStaticMAC indicates that the container should request a static MAC from
 // the CNI plugins.
 // It cannot be set unless WithNetNS has already been passed.
-// Further, it cannot be set if additional CNI networks to join have been
-// specified.
+// If additional CNI networks to join have been specified, the MAC is
+// requested in the first one.
 func WithStaticMAC(mac net.HardwareAddr) CtrCreateOption {
 	return func(ctr *Container) error {
 		if ctr.valid {
@@ -1057,6 +1182,39 @@ func WithStaticMAC(mac net.HardwareAddr) CtrCreateOption {
 	}
 }
 
+// WithNetworkStaticIPs indicates that the container should request the given
+// static IPv4 or IPv6 addresses, formatted as map of network name to address,
+// from the CNI plugins.
+// It cannot be set unless WithNetNS has already been passed, and all network
+// names must be networks the container joins.
+func WithNetworkStaticIPs(ips map[string]net.IP) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		ctr.config.StaticIPs = ips
+
+		return nil
+	}
+}
+
+// WithNetworkStaticMACs indicates that the container should request the given
+// static MACs, formatted as map of network name to MAC, from the CNI plugins.
+// It cannot be set unless WithNetNS has already been passed, and all network
+// names must be networks the container joins.
+func WithNetworkStaticMACs(macs map[string]net.HardwareAddr) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		ctr.config.StaticMACs = macs
+
+		return nil
+	}
+}
+
 // WithLogDriver sets the log driver for the container
 func WithLogDriver(driver string) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -1207,6 +1365,20 @@ func WithHosts(hosts []string) CtrCreateOption {
 	}
 }
 
+// WithBaseHostsFile sets the base of the /etc/hosts file of the container:
+// "image" for the /etc/hosts file of the image, "none" for no base file or the
+// path of a file on the host.
+func WithBaseHostsFile(file string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		ctr.config.BaseHostsFile = file
+		return nil
+	}
+}
+
 // WithConmonPidFile specifies the path to the file that receives the pid of
 // conmon.
 func WithConmonPidFile(path string) CtrCreateOption {
@@ -1219,6 +1391,18 @@ func WithConmonPidFile(path string) CtrCreateOption {
 	}
 }
 
+// WithPidFile specifies the path to the file that receives the pid of the
+// container process.
+func WithPidFile(path string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.PidFile = path
+		return nil
+	}
+}
+
 // WithGroups sets additional groups for the container, which are defined by
 // the user.
 func WithGroups(groups []string) CtrCreateOption {
@@ -1348,6 +1532,34 @@ func WithUseImageHosts() CtrCreateOption {
 	}
 }
 
+// WithTimeout sets the maximum number of seconds the container may run before
+// it is killed.
+func WithTimeout(timeout uint) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		ctr.config.Timeout = timeout
+
+		return nil
+	}
+}
+
+// WithNoDefaultMounts tells the container not to mount the default mounts from
+// the mounts files (mounts.conf).
+func WithNoDefaultMounts() CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		ctr.config.NoDefaultMounts = true
+
+		return nil
+	}
+}
+
 // WithRestartPolicy sets the container's restart policy. Valid values are
 // "no", "on-failure", and "always". The empty string is allowed, and will be
 // equivalent to "no".
@@ -1397,6 +1609,25 @@ func withIsInfra() CtrCreateOption {
 	}
 }
 
+// WithInitCtrType sets the container to be an init container of its pod.
+// Init containers are run to completion before the other containers of the
+// pod are started.  containerType "always" runs the container every time the
+// pod is started, "once" only the first time, after which it is removed.
+func WithInitCtrType(containerType string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		if containerType != define.AlwaysInitContainer && containerType != define.OneShotInitContainer {
+			return errors.Wrapf(define.ErrInvalidArg, "%q is not a valid init container type, must be %q or %q", containerType, define.AlwaysInitContainer, define.OneShotInitContainer)
+		}
+		ctr.config.InitContainerType = containerType
+
+		return nil
+	}
+}
+
 // WithNamedVolumes adds the given named volumes to the container.
 func WithNamedVolumes(volumes []*ContainerNamedVolume) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -1459,6 +1690,50 @@ func WithImageVolumes(volumes []*ContainerImageVolume) CtrCreateOption {
 	}
 }
 
+// WithSecrets adds secrets to mount into the container.  The targets must be
+// absolute paths.
+func WithSecrets(ctrSecrets []*ContainerSecret) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		for _, secret := range ctrSecrets {
+			if !filepath.IsAbs(secret.Target) {
+				return errors.Wrapf(define.ErrInvalidArg, "target of secret %s must be an absolute path", secret.Secret.Name)
+			}
+			ctr.config.Secrets = append(ctr.config.Secrets, &ContainerSecret{
+				Secret: secret.Secret,
+				Target: filepath.Clean(secret.Target),
+			})
+		}
+
+		return nil
+	}
+}
+
+// WithEnvSecrets sets environment variables of the container to the data of
+// secrets.  The secrets are mapped by the names of the variables.
+func WithEnvSecrets(envSecrets map[string]*secrets.Secret) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		if ctr.config.EnvSecrets == nil {
+			ctr.config.EnvSecrets = make(map[string]*secrets.Secret, len(envSecrets))
+		}
+		for name, secret := range envSecrets {
+			if name == "" || strings.Contains(name, "=") {
+				return errors.Wrapf(define.ErrInvalidArg, "invalid environment variable name %q for secret %s", name, secret.Name)
+			}
+			ctr.config.EnvSecrets[name] = secret
+		}
+
+		return nil
+	}
+}
+
 // WithHealthCheck adds the healthcheck to the container config
 func WithHealthCheck(healthCheck *manifest.Schema2HealthConfig) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -1593,7 +1868,7 @@ func WithVolumeOptions(options map[string]string) VolumeCreateOption {
 		volume.config.Options = make(map[string]string)
 		for key, value := range options {
 			switch key {
-			case "type", "device", "o", "UID", "GID":
+			case "type", "device", "o", "UID", "GID", "SIZE":
 				volume.config.Options[key] = value
 			default:
 				return errors.Wrapf(define.ErrInvalidArg, "unrecognized volume option %q is not supported with local driver", key)
@@ -1614,6 +1889,9 @@ func WithVolumeUID(uid int) VolumeCreateOption {
 		}
 
 		volume.config.UID = uid
+		// An explicitly set owner must not be replaced by the user of
+		// the first container mounting the volume.
+		volume.state.NeedsChown = false
 
 		return nil
 	}
@@ -1627,6 +1905,22 @@ func WithVolumeGID(gid int) VolumeCreateOption {
 		}
 
 		volume.config.GID = gid
+		volume.state.NeedsChown = false
+
+		return nil
+	}
+}
+
+// WithVolumeSize sets the size quota of the volume in bytes.
+// Size quotas require root privileges and the volume path to be on an XFS or
+// ext4 filesystem with project quotas enabled.
+func WithVolumeSize(size uint64) VolumeCreateOption {
+	return func(volume *Volume) error {
+		if volume.valid {
+			return define.ErrVolumeFinalized
+		}
+
+		volume.config.Size = size
 
 		return nil
 	}
@@ -1645,6 +1939,12 @@ func WithVolumeNeedsChown() VolumeCreateOption {
 	}
 }
 
+// WithVolumeAnonymous makes the volume anonymous: it is removed along with the
+// containers using it when their volumes are removed, and with their pod.
+func WithVolumeAnonymous() VolumeCreateOption {
+	return withSetAnon()
+}
+
 // withSetAnon sets a bool notifying libpod that this volume is anonymous and
 // should be removed when containers using it are removed and volumes are
 // specified for removal.
@@ -1667,16 +1967,9 @@ func WithTimezone(path string) CtrCreateOption {
 			return define.ErrCtrFinalized
 		}
 		if path != "local" {
-			zone := filepath.Join("/usr/share/zoneinfo", path)
-
-			file, err := os.Stat(zone)
-			if err != nil {
+			if _, err := timezonePath(path); err != nil {
 				return err
 			}
-			//We don't want to mount a timezone directory
-			if file.IsDir() {
-				return errors.New("Invalid timezone: is a directory")
-			}
 		}
 
 		ctr.config.Timezone = path
@@ -1717,6 +2010,45 @@ func WithInfraImage(img string) PodCreateOption {
 	}
 }
 
+// WithPodShmSize sets the size of the /dev/shm tmpfs of the pod's infra
+// container, which is shared by all containers joining the pod's IPC
+// namespace.
+func WithPodShmSize(size int64) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+
+		if !pod.config.InfraContainer.HasInfraContainer {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot set pod shm size as no infra container is being created")
+		}
+
+		pod.config.InfraContainer.ShmSize = size
+
+		return nil
+	}
+}
+
+// WithPodKeepID creates the user namespace of the pod's infra container so
+// that the user who creates the pod is mapped to the same UID and GID, and
+// makes the containers of the pod join it.
+func WithPodKeepID() PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+
+		if !pod.config.InfraContainer.HasInfraContainer {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot set pod user namespace as no infra container is being created")
+		}
+
+		pod.config.InfraContainer.KeepID = true
+		pod.config.UsePodUser = true
+
+		return nil
+	}
+}
+
 // WithInfraCommand sets the command to
 // run on pause container start up.
 func WithInfraCommand(cmd []string) PodCreateOption {
@@ -1730,6 +2062,29 @@ func WithInfraCommand(cmd []string) PodCreateOption {
 	}
 }
 
+// WithInfraName sets the name of the pod's infra container.
+// If not set, the name is made of the first characters of the pod ID followed
+// by "-infra".
+func WithInfraName(name string) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+
+		if !pod.config.InfraContainer.HasInfraContainer {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot set infra container name as no infra container is being created")
+		}
+
+		if !define.NameRegex.MatchString(name) {
+			return define.RegexError
+		}
+
+		pod.config.InfraContainer.InfraName = name
+
+		return nil
+	}
+}
+
 // WithPodName sets the name of the pod.
 func WithPodName(name string) PodCreateOption {
 	return func(pod *Pod) error {
@@ -1835,6 +2190,21 @@ func WithPodCgroups() PodCreateOption {
 	}
 }
 
+// WithPodResourceLimits sets the CPU, memory and pids limits of the pod's
+// CGroup. The limits are shared by all containers in the pod.
+// Requires the pod to use a pod CGroup.
+func WithPodResourceLimits(resources *spec.LinuxResources) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+
+		pod.config.ResourceLimits = resources
+
+		return nil
+	}
+}
+
 // WithPodNamespace sets the namespace for the created pod.
 // Namespaces are used to create separate views of Podman's state - runtimes can
 // join a specific namespace and see only containers and pods in that namespace.
@@ -1906,8 +2276,6 @@ func WithPodMount() PodCreateOption {
 // created for this pod.
 // Containers in a pod will inherit the kernel namespaces from the
 // first container added.
-// TODO implement WithUserNSFrom, so WithUserNsFromPod functions properly
-// Then this option can be added on the pod level
 func WithPodUser() PodCreateOption {
 	return func(pod *Pod) error {
 		if pod.valid {
@@ -2020,6 +2388,31 @@ func WithPodStaticIP(ip net.IP) PodCreateOption {
 	}
 }
 
+// WithPodStaticIPv6 sets a static IPv6 address for the pod.
+func WithPodStaticIPv6(ip net.IP) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+
+		if !pod.config.InfraContainer.HasInfraContainer {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot set pod static IPv6 address as no infra container is being created")
+		}
+
+		if pod.config.InfraContainer.HostNetwork {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot set static IPv6 address if host network is specified")
+		}
+
+		if len(pod.config.InfraContainer.Networks) > 1 {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot set a static IPv6 address if joining more than 1 CNI network")
+		}
+
+		pod.config.InfraContainer.StaticIPv6 = ip
+
+		return nil
+	}
+}
+
 // WithPodStaticMAC sets a static MAC address for the pod.
 func WithPodStaticMAC(mac net.HardwareAddr) PodCreateOption {
 	return func(pod *Pod) error {
@@ -2154,6 +2547,29 @@ func WithPodUseImageHosts() PodCreateOption {
 	}
 }
 
+// WithPodBaseHostsFile sets the base of the pod's /etc/hosts: "image" for the
+// /etc/hosts file of the infra image, "none" for no base file or the path of a
+// file on the host.
+func WithPodBaseHostsFile(file string) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+
+		if !pod.config.InfraContainer.HasInfraContainer {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot configure pod hosts as no infra container is being created")
+		}
+
+		if pod.config.InfraContainer.UseImageHosts {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot set the base hosts file if container is using image hosts")
+		}
+
+		pod.config.InfraContainer.BaseHostsFile = file
+
+		return nil
+	}
+}
+
 // WithPodHosts adds additional entries to the pod's /etc/hosts
 func WithPodHosts(hosts []string) PodCreateOption {
 	return func(pod *Pod) error {
@@ -2186,7 +2602,7 @@ func WithPodNetworks(networks []string) PodCreateOption {
 			return errors.Wrapf(define.ErrInvalidArg, "cannot configure pod CNI networks as no infra container is being created")
 		}
 
-		if (pod.config.InfraContainer.StaticIP != nil || pod.config.InfraContainer.StaticMAC != nil) &&
+		if (pod.config.InfraContainer.StaticIP != nil || pod.config.InfraContainer.StaticIPv6 != nil || pod.config.InfraContainer.StaticMAC != nil) &&
 			len(networks) > 1 {
 			return errors.Wrapf(define.ErrInvalidArg, "cannot join more than one CNI network if setting a static IP or MAC address")
 		}
@@ -2214,6 +2630,7 @@ func WithPodHostNetwork() PodCreateOption {
 
 		if len(pod.config.InfraContainer.PortBindings) > 0 ||
 			pod.config.InfraContainer.StaticIP != nil ||
+			pod.config.InfraContainer.StaticIPv6 != nil ||
 			pod.config.InfraContainer.StaticMAC != nil ||
 			len(pod.config.InfraContainer.Networks) > 0 {
 			return errors.Wrapf(define.ErrInvalidArg, "cannot set host network if network-related configuration is specified")
@@ -2258,6 +2675,9 @@ func WithPodSlirp4netns(networkOptions map[string][]string) PodCreateOption {
 		if pod.config.InfraContainer.HostNetwork {
 			return errors.Wrapf(define.ErrInvalidArg, "cannot set both HostNetwork and Slirp4netns")
 		}
+		if err := pod.runtime.validateSlirp4netnsOptions(networkOptions["slirp4netns"]); err != nil {
+			return err
+		}
 		pod.config.InfraContainer.Slirp4netns = true
 		pod.config.InfraContainer.NetworkOptions = networkOptions
 
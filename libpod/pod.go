@@ -7,6 +7,7 @@ import (
 	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/libpod/lock"
 	"github.com/cri-o/ocicni/pkg/ocicni"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
 )
 
@@ -48,6 +49,10 @@ type PodConfig struct {
 	// If true, all containers joined to the pod will use the pod cgroup as
 	// their cgroup parent, and cannot set a different cgroup parent
 	UsePodCgroup bool `json:"sharesCgroup,omitempty"`
+	// ResourceLimits are the CPU, memory and pids limits of the pod's
+	// CGroup, shared by all containers in the pod.
+	// Requires UsePodCgroup.
+	ResourceLimits *spec.LinuxResources `json:"resourceLimits,omitempty"`
 
 	// The following UsePod{kernelNamespace} indicate whether the containers
 	// in the pod will inherit the namespace from the first container in the pod.
@@ -64,6 +69,9 @@ type PodConfig struct {
 	// Time pod was created
 	CreatedTime time.Time `json:"created"`
 
+	// Owner is the user who created the pod.
+	Owner string `json:"owner,omitempty"`
+
 	// CreateCommand is the full command plus arguments of the process the
 	// container has been created with.
 	CreateCommand []string `json:"CreateCommand,omitempty"`
@@ -79,6 +87,9 @@ type podState struct {
 	// InfraContainerID is the container that holds pod namespace information
 	// Most often an infra container
 	InfraContainerID string
+	// NamespaceHolderID is the container holding the namespaces shared by a
+	// pod without infra container: the first container created in the pod.
+	NamespaceHolderID string `json:"namespaceHolderID,omitempty"`
 }
 
 // InfraContainerConfig is the configuration for the pod's infra container.
@@ -96,19 +107,24 @@ type InfraContainerConfig struct {
 	HostNetwork        bool                 `json:"infraHostNetwork,omitempty"`
 	PortBindings       []ocicni.PortMapping `json:"infraPortBindings"`
 	StaticIP           net.IP               `json:"staticIP,omitempty"`
+	StaticIPv6         net.IP               `json:"staticIPv6,omitempty"`
 	StaticMAC          net.HardwareAddr     `json:"staticMAC,omitempty"`
 	UseImageResolvConf bool                 `json:"useImageResolvConf,omitempty"`
 	DNSServer          []string             `json:"dnsServer,omitempty"`
 	DNSSearch          []string             `json:"dnsSearch,omitempty"`
 	DNSOption          []string             `json:"dnsOption,omitempty"`
 	UseImageHosts      bool                 `json:"useImageHosts,omitempty"`
+	BaseHostsFile      string               `json:"baseHostsFile,omitempty"`
 	HostAdd            []string             `json:"hostsAdd,omitempty"`
 	Networks           []string             `json:"networks,omitempty"`
 	ExitCommand        []string             `json:"exitCommand,omitempty"`
 	InfraImage         string               `json:"infraImage,omitempty"`
 	InfraCommand       []string             `json:"infraCommand,omitempty"`
+	InfraName          string               `json:"infraName,omitempty"`
 	Slirp4netns        bool                 `json:"slirp4netns,omitempty"`
 	NetworkOptions     map[string][]string  `json:"network_options,omitempty"`
+	ShmSize            int64                `json:"shmSize,omitempty"`
+	KeepID             bool                 `json:"keepID,omitempty"`
 }
 
 // ID retrieves the pod's ID
@@ -137,6 +153,12 @@ func (p *Pod) Labels() map[string]string {
 	return labels
 }
 
+// Owner returns the user who created the pod.  It is empty for pods created
+// before owners were recorded.
+func (p *Pod) Owner() string {
+	return p.config.Owner
+}
+
 // CreatedTime gets the time when the pod was created
 func (p *Pod) CreatedTime() time.Time {
 	return p.config.CreatedTime
@@ -183,6 +205,12 @@ func (p *Pod) SharesUser() bool {
 	return p.config.UsePodUser
 }
 
+// KeepID returns whether the user namespace of the pod maps the user who
+// created it to the same UID and GID.
+func (p *Pod) KeepID() bool {
+	return p.config.InfraContainer.KeepID
+}
+
 // SharesUTS returns whether containers in pod
 // default to use UTS namespace of first container in pod
 func (p *Pod) SharesUTS() bool {
@@ -270,6 +298,24 @@ func (p *Pod) InfraContainerID() (string, error) {
 	return p.state.InfraContainerID, nil
 }
 
+// NamespaceHolderID returns the ID of the container holding the namespaces
+// shared by the pod: its infra container or, in a pod without infra container,
+// the first container created in the pod.
+// If the container returned is "", no container holds the namespaces yet.
+func (p *Pod) NamespaceHolderID() (string, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if err := p.updatePod(); err != nil {
+		return "", err
+	}
+
+	if p.HasInfraContainer() {
+		return p.state.InfraContainerID, nil
+	}
+	return p.state.NamespaceHolderID, nil
+}
+
 // InfraContainer returns the infra container.
 func (p *Pod) InfraContainer() (*Container, error) {
 	if !p.HasInfraContainer() {
@@ -328,6 +374,50 @@ func (p *Pod) GetPodStats(previousContainerStats map[string]*define.ContainerSta
 	return newContainerStats, nil
 }
 
+// SumPodStats adds up the stats of the containers of the pod, as returned by
+// GetPodStats. The network I/O of containers joining the network namespace of
+// another container is not counted again. The memory limit is the limit of
+// the pod cgroup if it is lower than the limits of the containers.
+func (p *Pod) SumPodStats(ctrStats map[string]*define.ContainerStats) (*define.ContainerStats, error) {
+	sum := &define.ContainerStats{
+		ContainerID: p.ID(),
+		Name:        p.Name(),
+	}
+	for id, stats := range ctrStats {
+		ctr, err := p.runtime.state.Container(id)
+		if err != nil {
+			if errors.Cause(err) == define.ErrNoSuchCtr {
+				continue
+			}
+			return nil, err
+		}
+		sum.CPU += stats.CPU
+		sum.CPUNano += stats.CPUNano
+		sum.CPUSystemNano += stats.CPUSystemNano
+		sum.MemUsage += stats.MemUsage
+		if stats.MemLimit > sum.MemLimit {
+			sum.MemLimit = stats.MemLimit
+		}
+		if ctr.config.NetNsCtr == "" {
+			sum.NetInput += stats.NetInput
+			sum.NetOutput += stats.NetOutput
+		}
+		sum.BlockInput += stats.BlockInput
+		sum.BlockOutput += stats.BlockOutput
+		sum.PIDs += stats.PIDs
+		sum.OOMKills += stats.OOMKills
+	}
+	if limits := p.config.ResourceLimits; limits != nil && limits.Memory != nil && limits.Memory.Limit != nil {
+		if podLimit := *limits.Memory.Limit; podLimit > 0 && (sum.MemLimit == 0 || uint64(podLimit) < sum.MemLimit) {
+			sum.MemLimit = uint64(podLimit)
+		}
+	}
+	if sum.MemLimit > 0 {
+		sum.MemPerc = float64(sum.MemUsage) / float64(sum.MemLimit) * 100
+	}
+	return sum, nil
+}
+
 // ProcessLabel returns the SELinux label associated with the pod
 func (p *Pod) ProcessLabel() (string, error) {
 	if !p.HasInfraContainer() {
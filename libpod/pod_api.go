@@ -8,6 +8,7 @@ import (
 	"github.com/containers/podman/v2/pkg/cgroups"
 	"github.com/containers/podman/v2/pkg/parallel"
 	"github.com/containers/podman/v2/pkg/rootless"
+	"github.com/cri-o/ocicni/pkg/ocicni"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
@@ -17,6 +18,8 @@ import (
 // If a container has already been initialized it will be started,
 // otherwise it will be initialized then started.
 // Containers that are already running or have been paused are ignored
+// Init containers are run to completion first, one after another; if one of
+// them fails, no other container is started.
 // All containers are started independently, in order dictated by their
 // dependencies.
 // An error and a map[string]error are returned.
@@ -34,6 +37,12 @@ func (p *Pod) Start(ctx context.Context) (map[string]error, error) {
 		return nil, define.ErrPodRemoved
 	}
 
+	// All init containers must have run successfully before the other
+	// containers of the pod are started.
+	if err := p.startInitContainers(ctx); err != nil {
+		return nil, err
+	}
+
 	allCtrs, err := p.runtime.state.PodContainers(p)
 	if err != nil {
 		return nil, err
@@ -62,7 +71,7 @@ func (p *Pod) Start(ctx context.Context) (map[string]error, error) {
 	if len(ctrErrors) > 0 {
 		return ctrErrors, errors.Wrapf(define.ErrPodPartialFail, "error starting some containers")
 	}
-	defer p.newPodEvent(events.Start)
+	defer p.newPodEvent(ctx, events.Start)
 	return nil, nil
 }
 
@@ -132,7 +141,7 @@ func (p *Pod) StopWithTimeout(ctx context.Context, cleanup bool, timeout int) (m
 		ctrErrChan[c.ID()] = retChan
 	}
 
-	p.newPodEvent(events.Stop)
+	p.newPodEvent(ctx, events.Stop)
 
 	ctrErrors := make(map[string]error)
 
@@ -210,6 +219,8 @@ func (p *Pod) Cleanup(ctx context.Context) (map[string]error, error) {
 // Pause pauses all containers within a pod that are running.
 // Only running containers will be paused. Paused, stopped, or created
 // containers will be ignored.
+// The cgroup of the pod is frozen while its containers are paused, so that
+// all of them stop at the same time.
 // All containers are paused independently. An error pausing one container
 // will not prevent other containers being paused.
 // An error and a map[string]error are returned.
@@ -242,6 +253,25 @@ func (p *Pod) Pause(ctx context.Context) (map[string]error, error) {
 		return nil, err
 	}
 
+	// The pod cgroup is only frozen while the containers are paused, as it
+	// also holds their conmon processes.  The containers stay frozen in
+	// their own cgroups afterwards.
+	if p.state.CgroupPath != "" {
+		podCgroup, err := cgroups.Load(p.state.CgroupPath)
+		if err == nil {
+			err = podCgroup.Freeze()
+		}
+		if err != nil {
+			logrus.Debugf("Pausing the containers of pod %s one by one as its cgroup cannot be frozen: %v", p.ID(), err)
+		} else {
+			defer func() {
+				if err := podCgroup.Thaw(); err != nil {
+					logrus.Errorf("Error thawing the cgroup of pod %s: %v", p.ID(), err)
+				}
+			}()
+		}
+	}
+
 	ctrErrChan := make(map[string]<-chan error)
 
 	// Enqueue a function for each container with the parallel executor.
@@ -253,7 +283,7 @@ func (p *Pod) Pause(ctx context.Context) (map[string]error, error) {
 		ctrErrChan[c.ID()] = retChan
 	}
 
-	p.newPodEvent(events.Pause)
+	p.newPodEvent(ctx, events.Pause)
 
 	ctrErrors := make(map[string]error)
 
@@ -309,7 +339,7 @@ func (p *Pod) Unpause(ctx context.Context) (map[string]error, error) {
 		ctrErrChan[c.ID()] = retChan
 	}
 
-	p.newPodEvent(events.Unpause)
+	p.newPodEvent(ctx, events.Unpause)
 
 	ctrErrors := make(map[string]error)
 
@@ -378,14 +408,15 @@ func (p *Pod) Restart(ctx context.Context) (map[string]error, error) {
 	if len(ctrErrors) > 0 {
 		return ctrErrors, errors.Wrapf(define.ErrPodPartialFail, "error stopping some containers")
 	}
-	p.newPodEvent(events.Stop)
-	p.newPodEvent(events.Start)
+	p.newPodEvent(ctx, events.Stop)
+	p.newPodEvent(ctx, events.Start)
 	return nil, nil
 }
 
 // Kill sends a signal to all running containers within a pod.
 // Signals will only be sent to running containers. Containers that are not
-// running will be ignored. All signals are sent independently, and sending will
+// running will be ignored. If excludeInfra is set, no signal is sent to the
+// infra container. All signals are sent independently, and sending will
 // continue even if some containers encounter errors.
 // An error and a map[string]error are returned.
 // If the error is not nil and the map is nil, an error was encountered before
@@ -394,7 +425,7 @@ func (p *Pod) Restart(ctx context.Context) (map[string]error, error) {
 // containers. The container ID is mapped to the error encountered. The error is
 // set to ErrPodPartialFail.
 // If both error and the map are nil, all containers were signalled successfully.
-func (p *Pod) Kill(ctx context.Context, signal uint) (map[string]error, error) {
+func (p *Pod) Kill(ctx context.Context, signal uint, excludeInfra bool) (map[string]error, error) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
@@ -412,6 +443,9 @@ func (p *Pod) Kill(ctx context.Context, signal uint) (map[string]error, error) {
 	// Enqueue a function for each container with the parallel executor.
 	for _, ctr := range allCtrs {
 		c := ctr
+		if excludeInfra && c.IsInfra() {
+			continue
+		}
 		logrus.Debugf("Adding parallel job to kill container %s", c.ID())
 		retChan := parallel.Enqueue(ctx, func() error {
 			return c.Kill(signal)
@@ -420,7 +454,7 @@ func (p *Pod) Kill(ctx context.Context, signal uint) (map[string]error, error) {
 		ctrErrChan[c.ID()] = retChan
 	}
 
-	p.newPodEvent(events.Kill)
+	p.newPodEvent(ctx, events.Kill)
 
 	ctrErrors := make(map[string]error)
 
@@ -535,6 +569,7 @@ func (p *Pod) Inspect() (*define.InspectPodData, error) {
 		infraConfig = new(define.InspectPodInfraConfig)
 		infraConfig.HostNetwork = p.config.InfraContainer.HostNetwork
 		infraConfig.StaticIP = p.config.InfraContainer.StaticIP
+		infraConfig.StaticIPv6 = p.config.InfraContainer.StaticIPv6
 		infraConfig.StaticMAC = p.config.InfraContainer.StaticMAC.String()
 		infraConfig.NoManageResolvConf = p.config.InfraContainer.UseImageResolvConf
 		infraConfig.NoManageHosts = p.config.InfraContainer.UseImageHosts
@@ -560,28 +595,118 @@ func (p *Pod) Inspect() (*define.InspectPodData, error) {
 			infraConfig.Networks = append(infraConfig.Networks, p.config.InfraContainer.Networks...)
 		}
 		infraConfig.NetworkOptions = p.config.InfraContainer.NetworkOptions
+		infraConfig.Image = p.config.InfraContainer.InfraImage
+		infraConfig.Command = p.config.InfraContainer.InfraCommand
 		infraConfig.PortBindings = makeInspectPortBindings(p.config.InfraContainer.PortBindings)
+		if p.config.InfraContainer.KeepID {
+			infraConfig.Userns = "keep-id"
+		}
+	}
+
+	var (
+		cpuPeriod   uint64
+		cpuQuota    int64
+		memoryLimit int64
+		pidsLimit   int64
+	)
+	if res := p.config.ResourceLimits; res != nil {
+		if res.CPU != nil {
+			if res.CPU.Period != nil {
+				cpuPeriod = *res.CPU.Period
+			}
+			if res.CPU.Quota != nil {
+				cpuQuota = *res.CPU.Quota
+			}
+		}
+		if res.Memory != nil && res.Memory.Limit != nil {
+			memoryLimit = *res.Memory.Limit
+		}
+		if res.Pids != nil {
+			pidsLimit = res.Pids.Limit
+		}
 	}
 
 	inspectData := define.InspectPodData{
-		ID:               p.ID(),
-		Name:             p.Name(),
-		Namespace:        p.Namespace(),
-		Created:          p.CreatedTime(),
-		CreateCommand:    p.config.CreateCommand,
-		State:            podState,
-		Hostname:         p.config.Hostname,
-		Labels:           p.Labels(),
-		CreateCgroup:     p.config.UsePodCgroup,
-		CgroupParent:     p.CgroupParent(),
-		CgroupPath:       p.state.CgroupPath,
-		CreateInfra:      infraConfig != nil,
-		InfraContainerID: p.state.InfraContainerID,
-		InfraConfig:      infraConfig,
-		SharedNamespaces: sharesNS,
-		NumContainers:    uint(len(containers)),
-		Containers:       ctrs,
+		ID:                p.ID(),
+		Name:              p.Name(),
+		Namespace:         p.Namespace(),
+		Created:           p.CreatedTime(),
+		CreateCommand:     p.config.CreateCommand,
+		Owner:             p.config.Owner,
+		State:             podState,
+		Hostname:          p.config.Hostname,
+		Labels:            p.Labels(),
+		CreateCgroup:      p.config.UsePodCgroup,
+		CgroupParent:      p.CgroupParent(),
+		CgroupPath:        p.state.CgroupPath,
+		CPUPeriod:         cpuPeriod,
+		CPUQuota:          cpuQuota,
+		MemoryLimit:       memoryLimit,
+		PidsLimit:         pidsLimit,
+		CreateInfra:       infraConfig != nil,
+		InfraContainerID:  p.state.InfraContainerID,
+		InfraConfig:       infraConfig,
+		NamespaceHolderID: p.state.NamespaceHolderID,
+		SharedNamespaces:  sharesNS,
+		NumContainers:     uint(len(containers)),
+		Containers:        ctrs,
 	}
 
 	return &inspectData, nil
 }
+
+// SetPortMappings replaces the ports published by the pod, which are part of
+// the configuration of its infra container.  If the infra container is
+// running, its port forwarding is set up again with the new ports, which
+// preserves its IP address if it joined a single network.  Rootless pods and
+// pods using slirp4netns publish the new ports when they are restarted.
+func (p *Pod) SetPortMappings(ports []ocicni.PortMapping) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if err := p.updatePod(); err != nil {
+		return err
+	}
+	if !p.HasInfraContainer() {
+		return errors.Wrapf(define.ErrInvalidArg, "pod %s has no infra container, its ports cannot be changed", p.ID())
+	}
+
+	infra, err := p.runtime.state.Container(p.state.InfraContainerID)
+	if err != nil {
+		return err
+	}
+	infra.lock.Lock()
+	defer infra.lock.Unlock()
+	if err := infra.syncContainer(); err != nil {
+		return err
+	}
+	if len(ports) > 0 && !infra.config.CreateNetNS {
+		return errors.Wrapf(define.ErrInvalidArg, "pod %s has no network namespace of its own, it cannot publish ports", p.ID())
+	}
+
+	if err := infra.rewriteConfig(func(config *ContainerConfig) error {
+		config.PortMappings = ports
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	newConfig := new(PodConfig)
+	if err := JSONDeepCopy(p.config, newConfig); err != nil {
+		return errors.Wrapf(err, "error copying configuration of pod %s", p.ID())
+	}
+	newConfig.InfraContainer.PortBindings = ports
+	if err := p.runtime.state.RewritePodConfig(p, newConfig); err != nil {
+		return err
+	}
+	p.config = newConfig
+
+	if !infra.config.CreateNetNS || !infra.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+		return nil
+	}
+	if rootless.IsRootless() || infra.config.NetMode.IsSlirp4netns() {
+		logrus.Warnf("The new ports of pod %s are published when it is restarted", p.ID())
+		return nil
+	}
+	return infra.reloadNetwork()
+}
@@ -0,0 +1,301 @@
+// +build linux
+
+package libpod
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/containers/podman/v2/libpod/events"
+	"github.com/containers/storage/pkg/archive"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// An exported pod checkpoint is an archive holding the configuration of the
+// pod in pod.dump, the IDs of its checkpointed containers in the order they
+// have to be restored in containers.dump, and the exported checkpoint of
+// each of these containers in <ID>.tar.gz.
+
+// Checkpoint checkpoints all running containers within a pod.
+// All running containers are frozen before the first one is checkpointed, so
+// that their checkpoints are consistent with each other.  Containers are
+// checkpointed before the containers they depend on, so the infra container,
+// which holds the namespaces shared by the pod, is checkpointed last, and its
+// namespaces are checkpointed once with it.
+// If options.TargetFile is set, the checkpoints of all containers and the
+// configuration of the pod are exported to a single archive, which can be
+// imported with the checkpoint package.
+// An error and a map[string]error are returned.
+// If the error is not nil and the map is nil, an error was encountered before
+// any containers were checkpointed.
+// If map is not nil, an error was encountered when checkpointing one or more
+// containers. The container ID is mapped to the error encountered. The error is
+// set to ErrPodPartialFail.
+// If both error and the map are nil, all containers were checkpointed without
+// error.
+func (p *Pod) Checkpoint(ctx context.Context, options ContainerCheckpointOptions) (map[string]error, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if !p.valid {
+		return nil, define.ErrPodRemoved
+	}
+
+	ctrs, err := p.lockContainersInDependencyOrder()
+	if err != nil {
+		return nil, err
+	}
+	defer unlockContainers(ctrs)
+
+	running := make([]*Container, 0, len(ctrs))
+	for _, ctr := range ctrs {
+		if ctr.state.State != define.ContainerStateRunning {
+			continue
+		}
+		if err := ctr.checkpointRestoreSupported(); err != nil {
+			return nil, err
+		}
+		running = append(running, ctr)
+	}
+	if len(running) == 0 {
+		return nil, errors.Wrapf(define.ErrCtrStateInvalid, "pod %s has no running containers to checkpoint", p.ID())
+	}
+
+	exportDir := ""
+	if options.TargetFile != "" {
+		exportDir, err = ioutil.TempDir("", "pod-checkpoint")
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err := os.RemoveAll(exportDir); err != nil {
+				logrus.Errorf("Error removing %s: %v", exportDir, err)
+			}
+		}()
+		for _, ctr := range running {
+			if err := ctr.prepareCheckpointExport(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Freeze all containers first, so that none of them can change the
+	// state it shares with the others while they are checkpointed one
+	// after another.
+	for i, ctr := range running {
+		if err := ctr.pause(); err != nil {
+			thawContainers(running[:i])
+			return nil, errors.Wrapf(err, "error freezing container %s of pod %s", ctr.ID(), p.ID())
+		}
+	}
+
+	ctrErrors := make(map[string]error)
+	for i := len(running) - 1; i >= 0; i-- {
+		ctr := running[i]
+		ctrOptions := options
+		if exportDir != "" {
+			ctrOptions.TargetFile = filepath.Join(exportDir, ctr.ID()+".tar.gz")
+		}
+		if err := ctr.dumpCheckpoint(ctx, ctrOptions); err != nil {
+			ctrErrors[ctr.ID()] = err
+		}
+	}
+
+	// Containers which were left running, or failed to be checkpointed,
+	// are still frozen.
+	thawContainers(running)
+
+	if len(ctrErrors) > 0 {
+		return ctrErrors, errors.Wrapf(define.ErrPodPartialFail, "error checkpointing some containers")
+	}
+
+	if exportDir != "" {
+		if err := p.exportCheckpoint(exportDir, running, options.TargetFile); err != nil {
+			return nil, err
+		}
+	}
+
+	p.newPodEvent(ctx, events.Checkpoint)
+	return nil, nil
+}
+
+// Restore restores all checkpointed containers within a pod.
+// Containers are restored after the containers they depend on, so the infra
+// container re-creates the namespaces shared by the pod before the other
+// containers join them.  Containers without a checkpoint are ignored.
+// If options.TargetFile is set, the containers are restored from the pod
+// checkpoint archive it names.  The pod and its containers must have been
+// created from that archive with the checkpoint package.
+// An error and a map[string]error are returned.
+// If the error is not nil and the map is nil, an error was encountered before
+// any containers were restored.
+// If map is not nil, an error was encountered when restoring one or more
+// containers. The container ID is mapped to the error encountered. The error is
+// set to ErrPodPartialFail.
+// If both error and the map are nil, all containers were restored without
+// error.
+func (p *Pod) Restore(ctx context.Context, options ContainerCheckpointOptions) (map[string]error, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if !p.valid {
+		return nil, define.ErrPodRemoved
+	}
+
+	ctrs, err := p.lockContainersInDependencyOrder()
+	if err != nil {
+		return nil, err
+	}
+	defer unlockContainers(ctrs)
+
+	importDir := ""
+	if options.TargetFile != "" {
+		importDir, err = ioutil.TempDir("", "pod-checkpoint")
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err := os.RemoveAll(importDir); err != nil {
+				logrus.Errorf("Error removing %s: %v", importDir, err)
+			}
+		}()
+		archiveFile, err := os.Open(options.TargetFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to open pod checkpoint archive for import")
+		}
+		defer archiveFile.Close()
+		if err := archive.Untar(archiveFile, importDir, nil); err != nil {
+			return nil, errors.Wrapf(err, "Unpacking of pod checkpoint archive %s failed", options.TargetFile)
+		}
+	}
+
+	ctrErrors := make(map[string]error)
+	restored := 0
+	for _, ctr := range ctrs {
+		ctrOptions := options
+		checkpoint := filepath.Join(ctr.CheckpointPath(), "inventory.img")
+		if importDir != "" {
+			ctrOptions.TargetFile = filepath.Join(importDir, ctr.ID()+".tar.gz")
+			checkpoint = ctrOptions.TargetFile
+		}
+		if _, err := os.Stat(checkpoint); os.IsNotExist(err) {
+			logrus.Debugf("Container %s of pod %s has no checkpoint, not restoring it", ctr.ID(), p.ID())
+			continue
+		}
+		if err := ctr.restore(ctx, ctrOptions); err != nil {
+			ctrErrors[ctr.ID()] = err
+			continue
+		}
+		ctr.newContainerEvent(ctx, events.Restore)
+		restored++
+	}
+
+	if len(ctrErrors) > 0 {
+		return ctrErrors, errors.Wrapf(define.ErrPodPartialFail, "error restoring some containers")
+	}
+	if restored == 0 {
+		return nil, errors.Wrapf(define.ErrCtrStateInvalid, "pod %s has no checkpointed containers to restore", p.ID())
+	}
+
+	p.newPodEvent(ctx, events.Restore)
+	return nil, nil
+}
+
+// lockContainersInDependencyOrder locks and syncs all containers of the pod,
+// and returns them ordered so that every container comes after the
+// containers it depends on.  The caller has to unlock them with
+// unlockContainers.
+func (p *Pod) lockContainersInDependencyOrder() ([]*Container, error) {
+	allCtrs, err := p.runtime.state.PodContainers(p)
+	if err != nil {
+		return nil, err
+	}
+
+	graph, err := BuildContainerGraph(allCtrs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error generating dependency graph for pod %s", p.ID())
+	}
+	ctrs := graph.dependencyOrder()
+
+	for i, ctr := range ctrs {
+		ctr.lock.Lock()
+		if err := ctr.syncContainer(); err != nil {
+			unlockContainers(ctrs[:i+1])
+			return nil, err
+		}
+	}
+	return ctrs, nil
+}
+
+// unlockContainers unlocks the given containers.
+func unlockContainers(ctrs []*Container) {
+	for _, ctr := range ctrs {
+		ctr.lock.Unlock()
+	}
+}
+
+// thawContainers unpauses those of the given containers which are paused.
+// Errors are logged, as the containers are thawed after a failure or on the
+// way out of a pod checkpoint.
+func thawContainers(ctrs []*Container) {
+	for _, ctr := range ctrs {
+		if ctr.state.State != define.ContainerStatePaused {
+			continue
+		}
+		if err := ctr.unpause(); err != nil {
+			logrus.Errorf("Error unpausing container %s: %v", ctr.ID(), err)
+		}
+	}
+}
+
+// exportCheckpoint writes the pod checkpoint archive dest from the exported
+// checkpoints of the given containers in dir.  The containers are listed in
+// the order they have to be restored in.
+func (p *Pod) exportCheckpoint(dir string, ctrs []*Container, dest string) error {
+	podJSON, err := json.MarshalIndent(p.config, "", "     ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "pod.dump"), podJSON, 0600); err != nil {
+		return err
+	}
+
+	ctrIDs := make([]string, 0, len(ctrs))
+	for _, ctr := range ctrs {
+		ctrIDs = append(ctrIDs, ctr.ID())
+	}
+	ctrsJSON, err := json.MarshalIndent(ctrIDs, "", "     ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "containers.dump"), ctrsJSON, 0600); err != nil {
+		return err
+	}
+
+	// The checkpoints of the containers are compressed already.
+	input, err := archive.TarWithOptions(dir, &archive.TarOptions{
+		Compression: archive.Uncompressed,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "error reading checkpoints of pod %s", p.ID())
+	}
+	defer input.Close()
+
+	outFile, err := os.Create(dest)
+	if err != nil {
+		return errors.Wrapf(err, "error creating pod checkpoint export file %q", dest)
+	}
+	defer outFile.Close()
+
+	if err := os.Chmod(dest, 0600); err != nil {
+		return err
+	}
+
+	_, err = io.Copy(outFile, input)
+	return err
+}
@@ -0,0 +1,19 @@
+// +build !linux
+
+package libpod
+
+import (
+	"context"
+
+	"github.com/containers/podman/v2/libpod/define"
+)
+
+// Checkpoint is exclusive to linux
+func (p *Pod) Checkpoint(ctx context.Context, options ContainerCheckpointOptions) (map[string]error, error) {
+	return nil, define.ErrNotImplemented
+}
+
+// Restore is exclusive to linux
+func (p *Pod) Restore(ctx context.Context, options ContainerCheckpointOptions) (map[string]error, error) {
+	return nil, define.ErrNotImplemented
+}
@@ -1,8 +1,10 @@
 package libpod
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/containers/common/pkg/config"
@@ -68,13 +70,18 @@ func (p *Pod) refresh() error {
 	if p.config.UsePodCgroup {
 		switch p.runtime.config.Engine.CgroupManager {
 		case config.SystemdCgroupsManager:
-			cgroupPath, err := systemdSliceFromPath(p.config.CgroupParent, fmt.Sprintf("libpod_pod_%s", p.ID()))
+			cgroupPath, err := systemdSliceFromPath(p.config.CgroupParent, fmt.Sprintf("libpod_pod_%s", p.ID()), p.config.ResourceLimits)
 			if err != nil {
 				logrus.Errorf("Error creating CGroup for pod %s: %v", p.ID(), err)
 			}
 			p.state.CgroupPath = cgroupPath
 		case config.CgroupfsCgroupsManager:
 			p.state.CgroupPath = filepath.Join(p.config.CgroupParent, p.ID())
+			if p.config.ResourceLimits != nil {
+				if err := makeCgroupfsCgroup(p.state.CgroupPath, p.config.ResourceLimits); err != nil {
+					logrus.Errorf("Error creating CGroup for pod %s: %v", p.ID(), err)
+				}
+			}
 
 			logrus.Debugf("setting pod cgroup to %s", p.state.CgroupPath)
 		default:
@@ -85,3 +92,105 @@ func (p *Pod) refresh() error {
 	// Save changes
 	return p.save()
 }
+
+// checkNamespaceHolder returns define.ErrPodNamespaceHolderExists if the given
+// container, which is about to be added to the pod, was created to hold the
+// namespaces shared by the pod but another container holds them by now.
+// The pod must be locked.
+func (p *Pod) checkNamespaceHolder(ctr *Container) error {
+	if !ctr.holdsPodNamespaces || p.HasInfraContainer() {
+		return nil
+	}
+	if err := p.updatePod(); err != nil {
+		return err
+	}
+	if p.state.NamespaceHolderID != "" {
+		return errors.Wrapf(define.ErrPodNamespaceHolderExists, "container %s holds the namespaces of pod %s", p.state.NamespaceHolderID, p.ID())
+	}
+	return nil
+}
+
+// setNamespaceHolder records the given container, which was just added to the
+// pod, as the container holding the namespaces shared by the pod if the pod
+// has no infra container and no container holds them yet.
+// The pod must be locked.
+func (p *Pod) setNamespaceHolder(ctr *Container) error {
+	if p.HasInfraContainer() || !p.SharesNamespaces() {
+		return nil
+	}
+	if err := p.updatePod(); err != nil {
+		return err
+	}
+	if p.state.NamespaceHolderID != "" {
+		return nil
+	}
+	p.state.NamespaceHolderID = ctr.ID()
+	return p.save()
+}
+
+// clearNamespaceHolder forgets the container holding the namespaces shared by
+// a pod without infra container once it is removed from the pod, so the next
+// container created in the pod holds them.
+// The pod must be locked.
+func (p *Pod) clearNamespaceHolder(ctr *Container) error {
+	if p.HasInfraContainer() || p.state.NamespaceHolderID != ctr.ID() {
+		return nil
+	}
+	p.state.NamespaceHolderID = ""
+	return p.save()
+}
+
+// initContainers returns the init containers of the pod, sorted by the time
+// they were created.  The pod must be locked.
+func (p *Pod) initContainers() ([]*Container, error) {
+	ctrs, err := p.allContainers()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(ctrs, func(i, j int) bool {
+		return ctrs[i].CreatedTime().Before(ctrs[j].CreatedTime())
+	})
+	initCtrs := make([]*Container, 0, len(ctrs))
+	for _, ctr := range ctrs {
+		if ctr.IsInitCtr() {
+			initCtrs = append(initCtrs, ctr)
+		}
+	}
+	return initCtrs, nil
+}
+
+// startInitContainers runs the init containers of the pod one after another.
+// Each init container must exit successfully before the next one is started.
+// Init containers of type "once" are removed after they have run.  The pod
+// must be locked; it is unlocked while waiting for an init container to exit,
+// so the other operations on the pod are not blocked meanwhile.
+func (p *Pod) startInitContainers(ctx context.Context) error {
+	initCtrs, err := p.initContainers()
+	if err != nil {
+		return err
+	}
+	for _, initCtr := range initCtrs {
+		if err := initCtr.Start(ctx, true); err != nil {
+			return errors.Wrapf(err, "error starting init container %s", initCtr.ID())
+		}
+		p.lock.Unlock()
+		exitCode, err := initCtr.Wait()
+		p.lock.Lock()
+		if err != nil {
+			return errors.Wrapf(err, "error waiting for init container %s", initCtr.ID())
+		}
+		if exitCode != 0 {
+			return errors.Errorf("init container %s exited with code %d", initCtr.ID(), exitCode)
+		}
+		if err := p.updatePod(); err != nil {
+			return err
+		}
+		if initCtr.config.InitContainerType != define.OneShotInitContainer {
+			continue
+		}
+		if err := p.runtime.removeContainerInLockedPod(ctx, initCtr, p, false, true); err != nil {
+			return errors.Wrapf(err, "error removing init container %s", initCtr.ID())
+		}
+	}
+	return nil
+}
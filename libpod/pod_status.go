@@ -13,6 +13,20 @@ func (p *Pod) GetPodStatus() (string, error) {
 	return createPodStatusResults(ctrStatuses)
 }
 
+// PodStatusFromContainers determines the status of a pod based on the
+// statuses of its containers, e.g., the containers of PodContainersSnapshot.
+func PodStatusFromContainers(ctrs []*Container) (string, error) {
+	ctrStatuses := make(map[string]define.ContainerStatus, len(ctrs))
+	for _, ctr := range ctrs {
+		state, err := ctr.State()
+		if err != nil {
+			return define.PodStateErrored, err
+		}
+		ctrStatuses[ctr.ID()] = state
+	}
+	return createPodStatusResults(ctrStatuses)
+}
+
 func createPodStatusResults(ctrStatuses map[string]define.ContainerStatus) (string, error) {
 	ctrNum := len(ctrStatuses)
 	if ctrNum == 0 {
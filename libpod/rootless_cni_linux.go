@@ -58,6 +58,13 @@ func AllocRootlessCNI(ctx context.Context, c *Container) (ns.NetNS, []*cnitypes.
 		return nil, nil, err
 	}
 	k8sPodName := getCNIPodName(c) // passed to CNI as K8S_POD_NAME
+	aliases, err := c.runtime.state.GetAllNetworkAliases(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(aliases) > 0 {
+		logrus.Warnf("network aliases are not supported by rootless CNI, container %s can only be resolved by name", c.ID())
+	}
 	cniResults := make([]*cnitypes.Result, len(networks))
 	for i, nw := range networks {
 		cniRes, err := rootlessCNIInfraCallAlloc(infra, c.ID(), nw, k8sPodName)
@@ -21,10 +21,10 @@ import (
 	"github.com/containers/podman/v2/pkg/cgroups"
 	"github.com/containers/podman/v2/pkg/registries"
 	"github.com/containers/podman/v2/pkg/rootless"
+	"github.com/containers/podman/v2/pkg/secrets"
 	"github.com/containers/podman/v2/pkg/util"
 	"github.com/containers/storage"
 	"github.com/cri-o/ocicni/pkg/ocicni"
-	"github.com/docker/docker/pkg/namesgenerator"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
@@ -47,6 +47,9 @@ type Runtime struct {
 	config        *config.Config
 	storageConfig storage.StoreOptions
 	storageSet    storageSet
+	// extraConfig holds the settings of containers.conf not known to
+	// containers/common, loaded once when the runtime is set up.
+	extraConfig *util.ExtraConfig
 
 	state             State
 	store             storage.Store
@@ -60,6 +63,10 @@ type Runtime struct {
 	imageRuntime      *image.Runtime
 	lockManager       lock.Manager
 
+	// bootID is the ID of the current boot, recorded in the runtime alive
+	// file when the state is refreshed.  Empty if the system does not
+	// provide one.
+	bootID string
 	// doRenumber indicates that the runtime should perform a lock renumber
 	// during initialization.
 	// Once the runtime has been initialized and returned, this variable is
@@ -84,6 +91,29 @@ type Runtime struct {
 
 	// noStore indicates whether we need to interact with a store or not
 	noStore bool
+
+	// graphRootFSType is the file system of the graph root.
+	graphRootFSType string
+	// storageFallback describes why the storage driver or graph root were
+	// changed from the configured ones.  Empty if they were not changed.
+	storageFallback string
+
+	// owner is the user recorded as the owner of new containers and pods.
+	owner string
+	// ownerRestricted is set if the owner mode applies to the user, i.e.,
+	// if an administrator runs Podman through sudo.
+	ownerRestricted bool
+	// ownerMode is one of the define.OwnerMode* constants.
+	ownerMode string
+
+	// nameGenerator generates the names of containers and pods created
+	// without one.
+	nameGenerator *nameGenerator
+
+	// secretsManager manages the secrets of the runtime.  It is created
+	// on first use by SecretsManager().
+	secretsManager     *secrets.SecretsManager
+	secretsManagerLock sync.Mutex
 }
 
 // SetXdgDirs ensures the XDG_RUNTIME_DIR env and XDG_CONFIG_HOME variables are set.
@@ -166,6 +196,12 @@ func newRuntimeFromConfig(ctx context.Context, conf *config.Config, options ...R
 		return nil, err
 	}
 
+	extraConfig, err := util.LoadExtraConfig()
+	if err != nil {
+		return nil, err
+	}
+	runtime.extraConfig = extraConfig
+
 	storeOpts, err := storage.DefaultStoreOptions(rootless.IsRootless(), rootless.GetRootlessUID())
 	if err != nil {
 		return nil, err
@@ -179,6 +215,10 @@ func newRuntimeFromConfig(ctx context.Context, conf *config.Config, options ...R
 		}
 	}
 
+	if err := runtime.relocateState(); err != nil {
+		return nil, err
+	}
+
 	if err := shutdown.Start(); err != nil {
 		return nil, errors.Wrapf(err, "error starting shutdown signal handler")
 	}
@@ -264,6 +304,12 @@ func makeRuntime(ctx context.Context, runtime *Runtime) (retErr error) {
 	}
 	runtime.conmonPath = cPath
 
+	// Fail early, naming the path, on hosts with a read-only root file
+	// system whose state was not relocated.
+	if err := runtime.checkStateWritable(); err != nil {
+		return err
+	}
+
 	// Make the static files directory if it does not exist
 	if err := os.MkdirAll(runtime.config.Engine.StaticDir, 0700); err != nil {
 		// The directory is allowed to exist
@@ -307,6 +353,18 @@ func makeRuntime(ctx context.Context, runtime *Runtime) (retErr error) {
 
 	runtime.mergeDBConfig(dbConfig)
 
+	// Switch to a compatible storage configuration before it is recorded
+	// in the database.
+	if err := runtime.checkStorageFilesystem(dbConfig); err != nil {
+		return err
+	}
+
+	runtime.setupOwner()
+
+	if runtime.nameGenerator, err = newNameGenerator(runtime.extraConfig.Names); err != nil {
+		return err
+	}
+
 	logrus.Debugf("Using graph driver %s", runtime.storageConfig.GraphDriverName)
 	logrus.Debugf("Using graph root %s", runtime.storageConfig.GraphRoot)
 	logrus.Debugf("Using run root %s", runtime.storageConfig.RunRoot)
@@ -445,7 +503,8 @@ func makeRuntime(ctx context.Context, runtime *Runtime) (retErr error) {
 	}
 
 	// We now need to see if the system has restarted
-	// We check for the presence of a file in our tmp directory to verify this
+	// We check for the presence of a file in our tmp directory, and for the
+	// boot ID it records, to verify this
 	// This check must be locked to prevent races
 	runtimeAliveLock := filepath.Join(runtime.config.Engine.TmpDir, "alive.lck")
 	runtimeAliveFile := filepath.Join(runtime.config.Engine.TmpDir, "alive")
@@ -458,15 +517,23 @@ func makeRuntime(ctx context.Context, runtime *Runtime) (retErr error) {
 	// TODO: we can't close the FD in this lock, so we should keep it around
 	// and use it to lock important operations
 	aliveLock.Lock()
-	doRefresh := false
 	defer func() {
 		if aliveLock.Locked() {
 			aliveLock.Unlock()
 		}
 	}()
 
-	_, err = os.Stat(runtimeAliveFile)
+	// If the file doesn't exist or records another boot, we need to
+	// refresh the state
+	// This will trigger on first use as well, but refreshing an
+	// empty state only creates a single file
+	// As such, it's not really a performance concern
+	runtime.bootID = currentBootID()
+	doRefresh, err := checkAliveFile(runtimeAliveFile, runtime.bootID)
 	if err != nil {
+		return errors.Wrapf(err, "error reading runtime status file %s", runtimeAliveFile)
+	}
+	if doRefresh {
 		// If we need to refresh, then it is safe to assume there are
 		// no containers running.  Create immediately a namespace, as
 		// we will need to access the storage.
@@ -476,6 +543,12 @@ func makeRuntime(ctx context.Context, runtime *Runtime) (retErr error) {
 			if err != nil {
 				return errors.Wrapf(err, "could not get pause process pid file path")
 			}
+			// The tmp dir may persist across reboots, and the PID
+			// of the pause process of the previous boot may have
+			// been reused.
+			if err := removeIfBeforeBoot(pausePid); err != nil {
+				logrus.Warnf("Error removing stale pause process pid file %s: %v", pausePid, err)
+			}
 			became, ret, err := rootless.BecomeRootInUserNS(pausePid)
 			if err != nil {
 				return err
@@ -485,15 +558,6 @@ func makeRuntime(ctx context.Context, runtime *Runtime) (retErr error) {
 			}
 
 		}
-		// If the file doesn't exist, we need to refresh the state
-		// This will trigger on first use as well, but refreshing an
-		// empty state only creates a single file
-		// As such, it's not really a performance concern
-		if os.IsNotExist(err) {
-			doRefresh = true
-		} else {
-			return errors.Wrapf(err, "error reading runtime status file %s", runtimeAliveFile)
-		}
 	}
 
 	runtime.lockManager, err = getLockManager(runtime)
@@ -547,6 +611,21 @@ func (r *Runtime) TmpDir() (string, error) {
 	return r.config.Engine.TmpDir, nil
 }
 
+// PortAllocationLock returns the lock serializing the allocation of random
+// host ports between processes.  It must be held from picking the ports until
+// the container publishing them is added to the state, so concurrent creates
+// do not pick the same ports.
+func (r *Runtime) PortAllocationLock() (storage.Locker, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	lock, err := storage.GetLockfile(filepath.Join(r.config.Engine.TmpDir, "ports.lck"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error acquiring port allocation lock")
+	}
+	return lock, nil
+}
+
 // GetConfig returns a copy of the configuration used by the runtime
 func (r *Runtime) GetConfig() (*config.Config, error) {
 	r.lock.RLock()
@@ -566,6 +645,12 @@ func (r *Runtime) GetConfig() (*config.Config, error) {
 	return config, nil
 }
 
+// ExtraConfig returns a copy of the settings of containers.conf not known to
+// containers/common, as loaded when the runtime was set up.
+func (r *Runtime) ExtraConfig() util.ExtraConfig {
+	return *r.extraConfig
+}
+
 // DeferredShutdown shuts down the runtime without exposing any
 // errors. This is only meant to be used when the runtime is being
 // shutdown within a defer statement; else use Shutdown
@@ -601,6 +686,12 @@ func (r *Runtime) Shutdown(force bool) error {
 		}
 	}
 
+	if r.imageRuntime != nil {
+		if err := r.imageRuntime.SaveMetadataCache(); err != nil {
+			logrus.Debugf("Error saving image metadata cache: %v", err)
+		}
+	}
+
 	var lastError error
 	// If no store was requested, it can be nil and there is no need to
 	// attempt to shut it down
@@ -625,6 +716,19 @@ func (r *Runtime) Shutdown(force bool) error {
 func (r *Runtime) refresh(alivePath string) error {
 	logrus.Debugf("Podman detected system restart - performing state refresh")
 
+	// The containers running when the system rebooted are marked as
+	// exited by the refresh of the database, and reported afterwards.
+	ctrsBefore, err := r.state.AllContainersWithState()
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving all containers from state")
+	}
+	rebooted := make(map[string]bool)
+	for _, ctr := range ctrsBefore {
+		if ctr.state.State == define.ContainerStateRunning || ctr.state.State == define.ContainerStatePaused {
+			rebooted[ctr.ID()] = true
+		}
+	}
+
 	// First clear the state in the database
 	if err := r.state.Refresh(); err != nil {
 		return err
@@ -657,6 +761,12 @@ func (r *Runtime) refresh(alivePath string) error {
 			logrus.Errorf("Error refreshing container %s: %v", ctr.ID(), err)
 		}
 	}
+	var exitedByReboot []*Container
+	for _, ctr := range ctrs {
+		if rebooted[ctr.ID()] && ctr.state.ExitedByReboot {
+			exitedByReboot = append(exitedByReboot, ctr)
+		}
+	}
 	for _, pod := range pods {
 		if err := pod.refresh(); err != nil {
 			logrus.Errorf("Error refreshing pod %s: %v", pod.ID(), err)
@@ -669,13 +779,16 @@ func (r *Runtime) refresh(alivePath string) error {
 	}
 
 	// Create a file indicating the runtime is alive and ready
-	file, err := os.OpenFile(alivePath, os.O_RDONLY|os.O_CREATE, 0644)
-	if err != nil {
+	if err := writeAliveFile(alivePath, r.bootID); err != nil {
 		return errors.Wrap(err, "error creating runtime status file")
 	}
-	defer file.Close()
 
 	r.newSystemEvent(events.Refresh)
+	// Report the containers exited by the reboot, e.g., for their
+	// restart policy to be applied by podman system restore.
+	for _, ctr := range exitedByReboot {
+		ctr.newContainerRebootEvent()
+	}
 
 	return nil
 }
@@ -685,27 +798,6 @@ func (r *Runtime) Info() (*define.Info, error) {
 	return r.info()
 }
 
-// generateName generates a unique name for a container or pod.
-func (r *Runtime) generateName() (string, error) {
-	for {
-		name := namesgenerator.GetRandomName(0)
-		// Make sure container with this name does not exist
-		if _, err := r.state.LookupContainer(name); err == nil {
-			continue
-		} else if errors.Cause(err) != define.ErrNoSuchCtr {
-			return "", err
-		}
-		// Make sure pod with this name does not exist
-		if _, err := r.state.LookupPod(name); err == nil {
-			continue
-		} else if errors.Cause(err) != define.ErrNoSuchPod {
-			return "", err
-		}
-		return name, nil
-	}
-	// The code should never reach here.
-}
-
 // Configure store and image runtime
 func (r *Runtime) configureStore() error {
 	store, err := storage.GetStore(r.storageConfig)
@@ -725,6 +817,9 @@ func (r *Runtime) configureStore() error {
 	ir.EventsLogFilePath = r.config.Engine.EventsLogFilePath
 	ir.EventsLogger = r.config.Engine.EventsLogger
 
+	ir.SetCopyRateLimit(r.extraConfig.ImageCopyRateLimit)
+	ir.AdmissionPolicyPath = r.extraConfig.ImageAdmissionPolicy
+
 	r.imageRuntime = ir
 
 	return nil
@@ -752,6 +847,21 @@ func (r *Runtime) StorageConfig() storage.StoreOptions {
 	return r.storageConfig
 }
 
+// SecretsManager returns the manager of the secrets, which are stored below
+// the graph root.
+func (r *Runtime) SecretsManager() (*secrets.SecretsManager, error) {
+	r.secretsManagerLock.Lock()
+	defer r.secretsManagerLock.Unlock()
+	if r.secretsManager == nil {
+		manager, err := secrets.NewManager(filepath.Join(r.storageConfig.GraphRoot, "secrets"))
+		if err != nil {
+			return nil, err
+		}
+		r.secretsManager = manager
+	}
+	return r.secretsManager, nil
+}
+
 // GetStore returns the runtime stores
 func (r *Runtime) GetStore() storage.Store {
 	return r.store
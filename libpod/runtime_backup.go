@@ -0,0 +1,473 @@
+package libpod
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/containernetworking/cni/libcni"
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/containers/podman/v2/libpod/image"
+	"github.com/containers/podman/v2/libpod/network"
+	"github.com/containers/podman/v2/pkg/util"
+	"github.com/containers/storage/pkg/archive"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// An archive written by Backup contains a description of all backed up
+// resources in backup.json, the CNI configuration of each network in
+// networks/<name>.conflist and, if requested, a tar archive of the contents of
+// each volume in volumes/<name>.tar.  Images are not part of the archive, they
+// are pulled again when the containers are restored.
+const (
+	backupManifestFile = "backup.json"
+	backupNetworksDir  = "networks"
+	backupVolumesDir   = "volumes"
+)
+
+// backupManifest describes the resources in an archive written by Backup.
+type backupManifest struct {
+	// Networks are the names of the backed up networks.
+	Networks []string `json:"networks,omitempty"`
+	// Volumes are the backed up volumes.
+	Volumes []exportedVolume `json:"volumes,omitempty"`
+	// VolumeData is whether the contents of the volumes are backed up.
+	VolumeData bool `json:"volumeData,omitempty"`
+	// Secrets are the backed up secrets, without their data.
+	Secrets []backupSecret `json:"secrets,omitempty"`
+	// Pods are the backed up pods.
+	Pods []backupPod `json:"pods,omitempty"`
+	// Containers are the configurations of the backed up containers, in
+	// the order they were created in, so each container comes after the
+	// containers it depends on.
+	Containers []*ContainerConfig `json:"containers,omitempty"`
+}
+
+// backupSecret describes a secret in an archive written by Backup.
+type backupSecret struct {
+	// Name of the secret.
+	Name string `json:"name"`
+	// ID of the secret on the backed up host.
+	ID string `json:"id"`
+	// Driver storing the data of the secret.
+	Driver string `json:"driver"`
+}
+
+// backupPod describes a pod in an archive written by Backup.
+type backupPod struct {
+	// Config is the configuration of the pod.
+	Config *PodConfig `json:"config"`
+	// InfraContainerID is the ID of the infra container of the pod, or of
+	// the container holding the namespaces of a pod without infra
+	// container.
+	InfraContainerID string `json:"infraContainerID,omitempty"`
+}
+
+// Backup writes an archive of the configuration of all containers, pods,
+// volumes and networks, and of the metadata of all secrets, to path.  If
+// volumeData is set, the contents of the volumes are included.  Images and
+// the data of secrets are not.  The archive can be restored with RestoreBackup
+// on another host to re-create the same workloads.
+func (r *Runtime) Backup(ctx context.Context, path string, volumeData bool) error {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+
+	tmpdir, err := ioutil.TempDir(util.Tmpdir(), "podman-backup")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.RemoveAll(tmpdir); err != nil {
+			logrus.Errorf("error removing %s: %v", tmpdir, err)
+		}
+	}()
+
+	manifest := backupManifest{VolumeData: volumeData}
+
+	if err := os.Mkdir(filepath.Join(tmpdir, backupNetworksDir), 0700); err != nil {
+		return err
+	}
+	netNames, err := network.GetNetworkNamesFromFileSystem(r.config)
+	if err != nil {
+		return errors.Wrapf(err, "error listing networks")
+	}
+	sort.Strings(netNames)
+	for _, name := range netNames {
+		conf, err := network.ReadRawCNIConfByName(r.config, name)
+		if err != nil {
+			return errors.Wrapf(err, "error reading configuration of network %s", name)
+		}
+		if err := ioutil.WriteFile(filepath.Join(tmpdir, backupNetworksDir, name+".conflist"), conf, 0600); err != nil {
+			return err
+		}
+		manifest.Networks = append(manifest.Networks, name)
+	}
+
+	if err := os.Mkdir(filepath.Join(tmpdir, backupVolumesDir), 0700); err != nil {
+		return err
+	}
+	vols, err := r.state.AllVolumes()
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving volumes")
+	}
+	sort.Slice(vols, func(i, j int) bool { return vols[i].Name() < vols[j].Name() })
+	for _, vol := range vols {
+		exported, err := vol.exported()
+		if err != nil {
+			return err
+		}
+		manifest.Volumes = append(manifest.Volumes, exported)
+		if !volumeData {
+			continue
+		}
+		if err := exportVolumeToFile(vol, filepath.Join(tmpdir, backupVolumesDir, vol.Name()+".tar")); err != nil {
+			return err
+		}
+	}
+
+	manager, err := r.SecretsManager()
+	if err != nil {
+		return err
+	}
+	secretList, err := manager.List()
+	if err != nil {
+		return errors.Wrapf(err, "error listing secrets")
+	}
+	for _, secret := range secretList {
+		manifest.Secrets = append(manifest.Secrets, backupSecret{
+			Name:   secret.Name,
+			ID:     secret.ID,
+			Driver: secret.Driver,
+		})
+	}
+	sort.Slice(manifest.Secrets, func(i, j int) bool { return manifest.Secrets[i].Name < manifest.Secrets[j].Name })
+
+	pods, err := r.state.AllPods()
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving pods")
+	}
+	for _, pod := range pods {
+		infraID, err := pod.InfraContainerID()
+		if err != nil {
+			return err
+		}
+		config := new(PodConfig)
+		if err := JSONDeepCopy(pod.config, config); err != nil {
+			return errors.Wrapf(err, "error copying configuration of pod %s", pod.ID())
+		}
+		manifest.Pods = append(manifest.Pods, backupPod{
+			Config:           config,
+			InfraContainerID: infraID,
+		})
+	}
+	sort.Slice(manifest.Pods, func(i, j int) bool {
+		return manifest.Pods[i].Config.CreatedTime.Before(manifest.Pods[j].Config.CreatedTime)
+	})
+
+	ctrs, err := r.state.AllContainers()
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving containers")
+	}
+	sort.Slice(ctrs, func(i, j int) bool { return ctrs[i].CreatedTime().Before(ctrs[j].CreatedTime()) })
+	for _, ctr := range ctrs {
+		config := ctr.Config()
+		if config == nil {
+			return errors.Wrapf(define.ErrInternal, "error copying configuration of container %s", ctr.ID())
+		}
+		manifest.Containers = append(manifest.Containers, config)
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "     ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(tmpdir, backupManifestFile), manifestJSON, 0600); err != nil {
+		return err
+	}
+
+	input, err := archive.Tar(tmpdir, archive.Uncompressed)
+	if err != nil {
+		return errors.Wrapf(err, "error creating backup archive")
+	}
+	defer input.Close()
+
+	outFile, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "error creating file %q", path)
+	}
+	defer outFile.Close()
+
+	_, err = io.Copy(outFile, input)
+	return err
+}
+
+// exported describes the volume for an archive.
+func (v *Volume) exported() (exportedVolume, error) {
+	// The volume may have been chowned to the user of the first
+	// container mounting it.
+	uid, err := v.UID()
+	if err != nil {
+		return exportedVolume{}, err
+	}
+	gid, err := v.GID()
+	if err != nil {
+		return exportedVolume{}, err
+	}
+	return exportedVolume{
+		Name:      v.Name(),
+		Driver:    v.config.Driver,
+		Labels:    v.config.Labels,
+		Options:   v.config.Options,
+		UID:       uid,
+		GID:       gid,
+		Size:      v.config.Size,
+		Anonymous: v.config.IsAnon,
+	}, nil
+}
+
+// exportVolumeToFile writes a tar archive of the contents of the volume to
+// path.
+func exportVolumeToFile(vol *Volume, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "error creating volume archive %q", path)
+	}
+	if err := vol.Export(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// RestoreBackup re-creates the networks, volumes, pods and containers of an
+// archive written by Backup.  Networks, volumes and secrets that already exist
+// are used as they are, pods and containers that already exist are not
+// restored.  Secrets are not re-created as their data is not part of the
+// archive: containers use the secret of the same name on this host, which
+// must be created before they are started.  The images of the containers are
+// pulled if they are missing.
+// The returned map is keyed by a description of each resource in the archive
+// and holds the error encountered restoring it, if any.
+func (r *Runtime) RestoreBackup(ctx context.Context, path string) (map[string]error, error) {
+	tmpdir, err := ioutil.TempDir(util.Tmpdir(), "podman-restore")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := os.RemoveAll(tmpdir); err != nil {
+			logrus.Errorf("error removing %s: %v", tmpdir, err)
+		}
+	}()
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if err := archive.Untar(f, tmpdir, &archive.TarOptions{NoLchown: true}); err != nil {
+		return nil, errors.Wrapf(err, "error extracting %s", path)
+	}
+
+	manifestJSON, err := ioutil.ReadFile(filepath.Join(tmpdir, backupManifestFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Errorf("%s is not a backup archive", path)
+		}
+		return nil, err
+	}
+	manifest := backupManifest{}
+	if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+		return nil, errors.Wrapf(err, "error parsing %s of %s", backupManifestFile, path)
+	}
+
+	report := make(map[string]error)
+
+	// The names come from the archive and are used in paths: check them
+	// before joining them.
+	for _, name := range manifest.Networks {
+		desc := fmt.Sprintf("network %s", name)
+		if !define.NameRegex.MatchString(name) {
+			report[desc] = errors.Wrapf(define.RegexError, "invalid network name %q", name)
+			continue
+		}
+		report[desc] = r.restoreBackupNetwork(name, filepath.Join(tmpdir, backupNetworksDir, name+".conflist"))
+	}
+
+	for _, v := range manifest.Volumes {
+		if !define.NameRegex.MatchString(v.Name) {
+			report[fmt.Sprintf("volume %s", v.Name)] = errors.Wrapf(define.RegexError, "invalid volume name %q", v.Name)
+			continue
+		}
+		dataPath := ""
+		if manifest.VolumeData {
+			dataPath = filepath.Join(tmpdir, backupVolumesDir, v.Name+".tar")
+		}
+		report[fmt.Sprintf("volume %s", v.Name)] = r.restoreBackupVolume(ctx, v, dataPath)
+	}
+
+	manager, err := r.SecretsManager()
+	if err != nil {
+		return nil, err
+	}
+	// Map the IDs of the backed up secrets to the IDs of the secrets
+	// with the same names on this host.
+	secretIDs := make(map[string]string, len(manifest.Secrets))
+	for _, s := range manifest.Secrets {
+		secret, err := manager.Lookup(s.Name)
+		if err != nil {
+			report[fmt.Sprintf("secret %s", s.Name)] = errors.Wrapf(err, "the data of secrets is not backed up, create the secret before starting the containers using it")
+			continue
+		}
+		secretIDs[s.ID] = secret.ID
+		report[fmt.Sprintf("secret %s", s.Name)] = nil
+	}
+
+	failedPods := make(map[string]bool)
+	for _, p := range manifest.Pods {
+		desc := fmt.Sprintf("pod %s", p.Config.Name)
+		if _, err := r.RestorePod(ctx, p.Config, p.InfraContainerID); err != nil {
+			report[desc] = err
+			failedPods[p.Config.ID] = true
+			continue
+		}
+		report[desc] = nil
+	}
+
+	for _, config := range manifest.Containers {
+		desc := fmt.Sprintf("container %s", config.Name)
+		if config.Pod != "" && failedPods[config.Pod] {
+			report[desc] = errors.Errorf("pod %s of the container was not restored", config.Pod)
+			continue
+		}
+		for _, secret := range config.Secrets {
+			if id, ok := secretIDs[secret.Secret.ID]; ok {
+				secret.Secret.ID = id
+			}
+		}
+		for _, secret := range config.EnvSecrets {
+			if id, ok := secretIDs[secret.ID]; ok {
+				secret.ID = id
+			}
+		}
+		_, err := r.recreateContainer(ctx, config)
+		report[desc] = err
+	}
+
+	return report, nil
+}
+
+// restoreBackupNetwork writes the backed up configuration of the network name
+// at path to the CNI configuration directory, unless a network of the same
+// name exists.  The configuration must describe the network name.
+func (r *Runtime) restoreBackupNetwork(name, path string) error {
+	exists, err := network.Exists(r.config, name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		logrus.Debugf("Network %s exists, not restoring it", name)
+		return nil
+	}
+	conf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	confList, err := libcni.ConfListFromBytes(conf)
+	if err != nil {
+		return errors.Wrapf(err, "error parsing configuration of network %s", name)
+	}
+	if confList.Name != name {
+		return errors.Wrapf(define.ErrInvalidArg, "configuration of network %s describes network %q", name, confList.Name)
+	}
+	dir := network.GetCNIConfDir(r.config)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, name+".conflist"), conf, 0644)
+}
+
+// restoreBackupVolume re-creates the backed up volume v, unless a volume of the
+// same name exists.  If dataPath is set, the contents of the volume are
+// imported from the archive at dataPath.
+func (r *Runtime) restoreBackupVolume(ctx context.Context, v exportedVolume, dataPath string) error {
+	exists, err := r.HasVolume(v.Name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		logrus.Debugf("Volume %s exists, not restoring it", v.Name)
+		return nil
+	}
+	// A backup re-creates the containers with their whole configuration,
+	// including their mounts, so the volumes are restored with their
+	// driver and mount options.
+	options, err := exportedVolumeOptions(v, true)
+	if err != nil {
+		return err
+	}
+	if dataPath == "" {
+		_, err := r.NewVolume(ctx, options...)
+		return err
+	}
+	return r.importExportedVolume(ctx, v, options, dataPath)
+}
+
+// recreateContainer creates a container from the configuration of a backed up
+// container.  The container keeps its ID and name, and gets new storage from
+// its image, which is pulled if it is missing.
+func (r *Runtime) recreateContainer(ctx context.Context, config *ContainerConfig) (*Container, error) {
+	if config.RootfsImageName != "" {
+		img, err := r.imageRuntime.New(ctx, config.RootfsImageName, r.config.Engine.SignaturePolicyPath, "", nil, nil, image.SigningOptions{}, nil, util.PullImageMissing)
+		if err != nil {
+			return nil, err
+		}
+		config.RootfsImageID = img.ID()
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	if strings.HasPrefix(config.ConmonPidFile, r.storageConfig.RunRoot) {
+		config.ConmonPidFile = ""
+	}
+
+	// The shm directory is created in the bundle path of the container,
+	// or of the container whose IPC namespace it joins, which may differ
+	// on this host.
+	if oldShmDir := config.ShmDir; oldShmDir != "" && (config.IPCNsCtr != "" || oldShmDir == filepath.Join(config.StaticDir, "shm")) {
+		config.ShmDir = ""
+		if config.IPCNsCtr != "" {
+			dep, err := r.state.Container(config.IPCNsCtr)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error retrieving container %s whose IPC namespace container %s joins", config.IPCNsCtr, config.ID)
+			}
+			config.ShmDir = dep.config.ShmDir
+		}
+		mounts := make([]string, 0, len(config.Mounts))
+		for _, mount := range config.Mounts {
+			if mount != oldShmDir {
+				mounts = append(mounts, mount)
+			}
+		}
+		config.Mounts = mounts
+	}
+
+	ctr, err := r.initContainerVariables(config.Spec, config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error initializing container variables")
+	}
+	return r.setupContainer(ctx, ctr)
+}
@@ -0,0 +1,32 @@
+package libpod
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreBackupNetwork(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	confDir := filepath.Join(dir, "cni")
+	r := &Runtime{config: &config.Config{Network: config.NetworkConfig{NetworkConfigDir: confDir}}}
+
+	backedUp := filepath.Join(dir, "backedup.conflist")
+	require.NoError(t, ioutil.WriteFile(backedUp, []byte(`{"cniVersion": "0.4.0", "name": "net1", "plugins": [{"type": "bridge"}]}`), 0600))
+
+	// The configuration must describe the network of the manifest.
+	assert.Error(t, r.restoreBackupNetwork("net2", backedUp))
+	_, err = os.Stat(filepath.Join(confDir, "net2.conflist"))
+	assert.True(t, os.IsNotExist(err))
+
+	require.NoError(t, r.restoreBackupNetwork("net1", backedUp))
+	_, err = os.Stat(filepath.Join(confDir, "net1.conflist"))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,146 @@
+// +build linux
+
+package libpod
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// cleanupMonitorLockFile is the file in the tmp dir which is locked by the
+// running cleanup monitor.  The kernel releases the lock when the monitor
+// exits, so a stale file is never mistaken for a running monitor.
+const cleanupMonitorLockFile = "cleanup-monitor.lock"
+
+// cleanupMonitorRunning returns whether a cleanup monitor is running for the
+// given tmp dir, in which case containers started now do not need a cleanup
+// process of their own.
+func cleanupMonitorRunning(tmpDir string) bool {
+	f, err := os.Open(filepath.Join(tmpDir, cleanupMonitorLockFile))
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	if err := unix.Flock(int(f.Fd()), unix.LOCK_SH|unix.LOCK_NB); err != nil {
+		return err == unix.EWOULDBLOCK
+	}
+	_ = unix.Flock(int(f.Fd()), unix.LOCK_UN)
+	return false
+}
+
+// RunCleanupMonitor cleans up containers as soon as they exit, until ctx is
+// cancelled.  While it runs, containers started with the same tmp dir do not
+// spawn a `podman container cleanup` process when they exit, which saves a
+// process per exiting container on hosts running many containers.
+// Containers which exited while the monitor was not running are cleaned up
+// when it starts.
+func (r *Runtime) RunCleanupMonitor(ctx context.Context) error {
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+
+	tmpDir := r.config.Engine.TmpDir
+	lockFile, err := os.OpenFile(filepath.Join(tmpDir, cleanupMonitorLockFile), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "error opening cleanup monitor lock file")
+	}
+	defer lockFile.Close()
+	if err := unix.Flock(int(lockFile.Fd()), unix.LOCK_EX|unix.LOCK_NB); err != nil {
+		if err == unix.EWOULDBLOCK {
+			return errors.Wrapf(define.ErrInvalidArg, "a cleanup monitor is already running for %s", tmpDir)
+		}
+		return errors.Wrapf(err, "error locking cleanup monitor lock file")
+	}
+
+	exitsDir := filepath.Join(tmpDir, "exits")
+	if err := os.MkdirAll(exitsDir, 0750); err != nil {
+		return errors.Wrapf(err, "error creating OCI runtime exit files directory")
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrapf(err, "error creating inotify watcher")
+	}
+	defer watcher.Close()
+	if err := watcher.Add(exitsDir); err != nil {
+		return errors.Wrapf(err, "error watching %s", exitsDir)
+	}
+
+	// Catch up with containers which exited before we started watching.
+	files, err := ioutil.ReadDir(exitsDir)
+	if err != nil {
+		return errors.Wrapf(err, "error reading %s", exitsDir)
+	}
+	for _, file := range files {
+		r.cleanupExitedContainer(ctx, file.Name())
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			// Conmon writes the exit file to a temporary file and
+			// renames it, which is reported as creation.
+			if event.Op&fsnotify.Create == 0 {
+				continue
+			}
+			r.cleanupExitedContainer(ctx, filepath.Base(event.Name))
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			logrus.Errorf("Error watching %s: %v", exitsDir, err)
+		}
+	}
+}
+
+// cleanupExitedContainer cleans up the container with the given ID if it
+// exited and was not cleaned up yet, and removes it if it was created with
+// --rm.  Files in the exits directory which do not belong to a container are
+// ignored.
+func (r *Runtime) cleanupExitedContainer(ctx context.Context, id string) {
+	ctr, err := r.state.Container(id)
+	if err != nil {
+		if errors.Cause(err) != define.ErrNoSuchCtr {
+			logrus.Errorf("Error retrieving container %s: %v", id, err)
+		}
+		return
+	}
+
+	// A container which exited is still mounted until it was cleaned up,
+	// possibly by its own cleanup process.
+	needsCleanup := false
+	if err := ctr.Batch(func(c *Container) error {
+		needsCleanup = c.ensureState(define.ContainerStateStopped, define.ContainerStateExited) && c.state.Mounted
+		return nil
+	}); err != nil {
+		if errors.Cause(err) != define.ErrNoSuchCtr && errors.Cause(err) != define.ErrCtrRemoved {
+			logrus.Errorf("Error syncing container %s: %v", id, err)
+		}
+		return
+	}
+	if !needsCleanup {
+		return
+	}
+
+	logrus.Debugf("Cleaning up exited container %s", id)
+	if ctr.AutoRemove() && !ctr.ShouldRestart(ctx) {
+		err = r.RemoveContainer(ctx, ctr, false, true)
+	} else {
+		err = ctr.Cleanup(ctx)
+	}
+	if err != nil && errors.Cause(err) != define.ErrNoSuchCtr && errors.Cause(err) != define.ErrCtrRemoved {
+		logrus.Errorf("Error cleaning up container %s: %v", id, err)
+	}
+}
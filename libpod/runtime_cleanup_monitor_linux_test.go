@@ -0,0 +1,128 @@
+// +build linux
+
+package libpod
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+// cleanupMonitorHelperEnv makes TestCleanupMonitorHelper lock the cleanup
+// monitor lock file in the tmp dir it names, as a running monitor does.
+const cleanupMonitorHelperEnv = "LIBPOD_TEST_CLEANUP_MONITOR_DIR"
+
+func waitForCleanupMonitor(t *testing.T, tmpDir string, running bool) {
+	for i := 0; i < 500; i++ {
+		if cleanupMonitorRunning(tmpDir) == running {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("cleanup monitor running is not %v", running)
+}
+
+func newCleanupMonitorTestRuntime(t *testing.T) (*Runtime, func()) {
+	dir, err := ioutil.TempDir("", "cleanup-monitor")
+	require.NoError(t, err)
+	state, err := NewInMemoryState()
+	require.NoError(t, err)
+	r := &Runtime{
+		state:  state,
+		config: &config.Config{Engine: config.EngineConfig{TmpDir: dir}},
+		valid:  true,
+	}
+	return r, func() { os.RemoveAll(dir) }
+}
+
+func TestRunCleanupMonitor(t *testing.T) {
+	r, cleanup := newCleanupMonitorTestRuntime(t)
+	defer cleanup()
+	tmpDir := r.config.Engine.TmpDir
+	assert.False(t, cleanupMonitorRunning(tmpDir))
+
+	// Exit files of unknown containers are ignored, before and after the
+	// monitor starts.
+	exitsDir := filepath.Join(tmpDir, "exits")
+	require.NoError(t, os.MkdirAll(exitsDir, 0750))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(exitsDir, "before"), []byte("0"), 0600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- r.RunCleanupMonitor(ctx)
+	}()
+	waitForCleanupMonitor(t, tmpDir, true)
+
+	// Only one monitor runs per tmp dir.
+	err := r.RunCleanupMonitor(context.Background())
+	assert.Equal(t, define.ErrInvalidArg, errors.Cause(err))
+
+	for i := 0; i < 200; i++ {
+		name := filepath.Join(exitsDir, fmt.Sprintf("%064x", i))
+		require.NoError(t, ioutil.WriteFile(name, []byte("0"), 0600))
+	}
+	assert.True(t, cleanupMonitorRunning(tmpDir))
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("cleanup monitor did not stop")
+	}
+	assert.False(t, cleanupMonitorRunning(tmpDir))
+}
+
+func TestCleanupMonitorKilled(t *testing.T) {
+	r, cleanup := newCleanupMonitorTestRuntime(t)
+	defer cleanup()
+	tmpDir := r.config.Engine.TmpDir
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestCleanupMonitorHelper$")
+	cmd.Env = append(os.Environ(), cleanupMonitorHelperEnv+"="+tmpDir)
+	require.NoError(t, cmd.Start())
+	waitForCleanupMonitor(t, tmpDir, true)
+
+	// Containers started now rely on the monitor, which dies.
+	require.NoError(t, cmd.Process.Signal(syscall.SIGKILL))
+	_ = cmd.Wait()
+
+	// The kernel released the lock of the dead monitor: containers started
+	// now get their own cleanup process, and a new monitor can start.
+	assert.False(t, cleanupMonitorRunning(tmpDir))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- r.RunCleanupMonitor(ctx)
+	}()
+	waitForCleanupMonitor(t, tmpDir, true)
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+// TestCleanupMonitorHelper holds the lock of a cleanup monitor until it is
+// killed, when run by TestCleanupMonitorKilled.
+func TestCleanupMonitorHelper(t *testing.T) {
+	tmpDir := os.Getenv(cleanupMonitorHelperEnv)
+	if tmpDir == "" {
+		t.Skip("only run by TestCleanupMonitorKilled")
+	}
+	f, err := os.OpenFile(filepath.Join(tmpDir, cleanupMonitorLockFile), os.O_RDWR|os.O_CREATE, 0600)
+	require.NoError(t, err)
+	require.NoError(t, unix.Flock(int(f.Fd()), unix.LOCK_EX))
+	time.Sleep(time.Minute)
+}
@@ -0,0 +1,14 @@
+// +build !linux
+
+package libpod
+
+import (
+	"context"
+
+	"github.com/containers/podman/v2/libpod/define"
+)
+
+// RunCleanupMonitor is not supported on this platform.
+func (r *Runtime) RunCleanupMonitor(ctx context.Context) error {
+	return define.ErrNotImplemented
+}
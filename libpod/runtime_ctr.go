@@ -46,6 +46,47 @@ func (r *Runtime) NewContainer(ctx context.Context, rSpec *spec.Spec, options ..
 	return r.newContainer(ctx, rSpec, options...)
 }
 
+// CtrBatchEntry describes one container created by NewContainers.
+type CtrBatchEntry struct {
+	// Spec is the OCI runtime spec of the container.
+	Spec *spec.Spec
+	// Options are the create options of the container.
+	Options []CtrCreateOption
+}
+
+// NewContainers creates multiple containers at once.  All containers are added
+// to the state in a single transaction, which is considerably faster than
+// creating them one by one.  Either all or none of the containers are
+// created.  The containers cannot be part of a pod.
+func (r *Runtime) NewContainers(ctx context.Context, entries []CtrBatchEntry) ([]*Container, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	span, _ := opentracing.StartSpanFromContext(ctx, "newContainers")
+	span.SetTag("type", "runtime")
+	defer span.Finish()
+
+	ctrs := make([]*Container, 0, len(entries))
+	for _, entry := range entries {
+		ctr, err := r.initContainerVariables(entry.Spec, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error initializing container variables")
+		}
+
+		for _, option := range entry.Options {
+			if err := option(ctr); err != nil {
+				return nil, errors.Wrapf(err, "error running container create option")
+			}
+		}
+		ctrs = append(ctrs, ctr)
+	}
+
+	return r.setupContainers(ctx, ctrs)
+}
+
 // RestoreContainer re-creates a container from an imported checkpoint
 func (r *Runtime) RestoreContainer(ctx context.Context, rSpec *spec.Spec, config *ContainerConfig) (*Container, error) {
 	r.lock.Lock()
@@ -58,6 +99,7 @@ func (r *Runtime) RestoreContainer(ctx context.Context, rSpec *spec.Spec, config
 	if err != nil {
 		return nil, errors.Wrapf(err, "error initializing container variables")
 	}
+	ctr.restoreFromCheckpoint = true
 	// For an imported checkpoint no one has ever set the StartedTime. Set it now.
 	ctr.state.StartedTime = time.Now()
 
@@ -71,6 +113,76 @@ func (r *Runtime) RestoreContainer(ctx context.Context, rSpec *spec.Spec, config
 	return r.setupContainer(ctx, ctr)
 }
 
+// CloneContainer creates a new container from the configuration of an
+// existing container.  The clone gets a new name (unless set in the options),
+// new storage and new anonymous volumes.  Static IP and MAC addresses are not
+// copied as they would conflict with the source container.  The options are
+// applied on top of the copied configuration, e.g., to change the image or the
+// resource limits of the clone.
+func (r *Runtime) CloneContainer(ctx context.Context, source *Container, options ...CtrCreateOption) (*Container, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	if source.IsInfra() {
+		return nil, errors.Wrapf(define.ErrInvalidArg, "cannot clone infra container %s", source.ID())
+	}
+
+	config := source.Config()
+	if config == nil {
+		return nil, errors.Wrapf(define.ErrInternal, "error copying configuration of container %s", source.ID())
+	}
+	config.ID = ""
+	config.Name = ""
+	config.CreateCommand = nil
+	config.StaticIP = nil
+	config.StaticIPv6 = nil
+	config.StaticMAC = nil
+	if strings.HasPrefix(config.ConmonPidFile, r.storageConfig.RunRoot) {
+		config.ConmonPidFile = ""
+	}
+
+	// The shm directory of the source container is created in its bundle
+	// path.  Let the clone create its own.
+	if sourceShmDir := filepath.Join(source.bundlePath(), "shm"); config.ShmDir == sourceShmDir {
+		config.ShmDir = ""
+		mounts := make([]string, 0, len(config.Mounts))
+		for _, mount := range config.Mounts {
+			if mount != sourceShmDir {
+				mounts = append(mounts, mount)
+			}
+		}
+		config.Mounts = mounts
+	}
+
+	// Anonymous volumes belong to the source container, the clone gets new
+	// ones.
+	for _, vol := range config.NamedVolumes {
+		dbVol, err := r.state.Volume(vol.Name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error retrieving volume %s of container %s", vol.Name, source.ID())
+		}
+		if dbVol.Anonymous() {
+			vol.Name = ""
+		}
+	}
+
+	ctr, err := r.initContainerVariables(config.Spec, config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error initializing container variables")
+	}
+
+	for _, option := range options {
+		if err := option(ctr); err != nil {
+			return nil, errors.Wrapf(err, "error running container create option")
+		}
+	}
+
+	return r.setupContainer(ctx, ctr)
+}
+
 func (r *Runtime) initContainerVariables(rSpec *spec.Spec, config *ContainerConfig) (*Container, error) {
 	if rSpec == nil {
 		return nil, errors.Wrapf(define.ErrInvalidArg, "must provide a valid runtime spec to create container")
@@ -89,16 +201,18 @@ func (r *Runtime) initContainerVariables(rSpec *spec.Spec, config *ContainerConf
 		ctr.config.StopSignal = 15
 		ctr.config.StopTimeout = r.config.Engine.StopTimeout
 	} else {
-		// This is a restore from an imported checkpoint
-		ctr.restoreFromCheckpoint = true
+		// This is a restore from an imported checkpoint or a clone of
+		// an existing container
 		if err := JSONDeepCopy(config, ctr.config); err != nil {
-			return nil, errors.Wrapf(err, "error copying container config for restore")
+			return nil, errors.Wrapf(err, "error copying container config")
 		}
 		// If the ID is empty a new name for the restored container was requested
 		if ctr.config.ID == "" {
 			ctr.config.ID = stringid.GenerateNonCryptoID()
 			// Fixup ExitCommand with new ID
-			ctr.config.ExitCommand[len(ctr.config.ExitCommand)-1] = ctr.config.ID
+			if len(ctr.config.ExitCommand) > 0 {
+				ctr.config.ExitCommand[len(ctr.config.ExitCommand)-1] = ctr.config.ID
+			}
 		}
 		// Reset the log path to point to the default
 		ctr.config.LogPath = ""
@@ -109,6 +223,7 @@ func (r *Runtime) initContainerVariables(rSpec *spec.Spec, config *ContainerConf
 		return nil, errors.Wrapf(err, "error copying runtime spec while creating container")
 	}
 	ctr.config.CreatedTime = time.Now()
+	ctr.config.Owner = r.owner
 
 	ctr.state.BindMounts = make(map[string]string)
 
@@ -144,20 +259,20 @@ func (r *Runtime) newContainer(ctx context.Context, rSpec *spec.Spec, options ..
 	return r.setupContainer(ctx, ctr)
 }
 
-func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Container, retErr error) {
+// prepareContainer validates a new container and sets up its lock, storage and
+// named volumes so it can be added to the state.  It returns the pod of the
+// container, if any, and its named volumes, which must be locked while adding
+// the container to the state.
+func (r *Runtime) prepareContainer(ctx context.Context, ctr *Container) (_ *Pod, _ []*Volume, retErr error) {
 	// Validate the container
 	if err := ctr.validate(); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	// Inhibit shutdown until creation succeeds
-	shutdown.Inhibit()
-	defer shutdown.Uninhibit()
-
 	// Allocate a lock for the container
 	lock, err := r.lockManager.AllocateLock()
 	if err != nil {
-		return nil, errors.Wrapf(err, "error allocating lock for new container")
+		return nil, nil, errors.Wrapf(err, "error allocating lock for new container")
 	}
 	ctr.lock = lock
 	ctr.config.LockID = ctr.lock.ID()
@@ -180,7 +295,7 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Contai
 	} else {
 		ociRuntime, ok := r.ociRuntimes[ctr.config.OCIRuntime]
 		if !ok {
-			return nil, errors.Wrapf(define.ErrInvalidArg, "requested OCI runtime %s is not available", ctr.config.OCIRuntime)
+			return nil, nil, errors.Wrapf(define.ErrInvalidArg, "requested OCI runtime %s is not available", ctr.config.OCIRuntime)
 		}
 		ctr.ociRuntime = ociRuntime
 	}
@@ -188,7 +303,7 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Contai
 	// Check NoCgroups support
 	if ctr.config.NoCgroups {
 		if !ctr.ociRuntime.SupportsNoCgroups() {
-			return nil, errors.Wrapf(define.ErrInvalidArg, "requested OCI runtime %s is not compatible with NoCgroups", ctr.ociRuntime.Name())
+			return nil, nil, errors.Wrapf(define.ErrInvalidArg, "requested OCI runtime %s is not compatible with NoCgroups", ctr.ociRuntime.Name())
 		}
 	}
 
@@ -197,14 +312,16 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Contai
 		// Get the pod from state
 		pod, err = r.state.Pod(ctr.config.Pod)
 		if err != nil {
-			return nil, errors.Wrapf(err, "cannot add container %s to pod %s", ctr.ID(), ctr.config.Pod)
+			return nil, nil, errors.Wrapf(err, "cannot add container %s to pod %s", ctr.ID(), ctr.config.Pod)
 		}
+	} else if ctr.config.InitContainerType != "" {
+		return nil, nil, errors.Wrapf(define.ErrInvalidArg, "init containers must be part of a pod")
 	}
 
 	if ctr.config.Name == "" {
-		name, err := r.generateName()
+		name, err := r.generateName("container", ctr.config.Labels)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		ctr.config.Name = name
@@ -220,17 +337,17 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Contai
 				if pod != nil && pod.config.UsePodCgroup {
 					podCgroup, err := pod.CgroupPath()
 					if err != nil {
-						return nil, errors.Wrapf(err, "error retrieving pod %s cgroup", pod.ID())
+						return nil, nil, errors.Wrapf(err, "error retrieving pod %s cgroup", pod.ID())
 					}
 					if podCgroup == "" {
-						return nil, errors.Wrapf(define.ErrInternal, "pod %s cgroup is not set", pod.ID())
+						return nil, nil, errors.Wrapf(define.ErrInternal, "pod %s cgroup is not set", pod.ID())
 					}
 					ctr.config.CgroupParent = podCgroup
 				} else {
 					ctr.config.CgroupParent = CgroupfsDefaultCgroupParent
 				}
 			} else if strings.HasSuffix(path.Base(ctr.config.CgroupParent), ".slice") {
-				return nil, errors.Wrapf(define.ErrInvalidArg, "systemd slice received as cgroup parent when using cgroupfs")
+				return nil, nil, errors.Wrapf(define.ErrInvalidArg, "systemd slice received as cgroup parent when using cgroupfs")
 			}
 		case config.SystemdCgroupsManager:
 			if ctr.config.CgroupParent == "" {
@@ -238,7 +355,7 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Contai
 				case pod != nil && pod.config.UsePodCgroup:
 					podCgroup, err := pod.CgroupPath()
 					if err != nil {
-						return nil, errors.Wrapf(err, "error retrieving pod %s cgroup", pod.ID())
+						return nil, nil, errors.Wrapf(err, "error retrieving pod %s cgroup", pod.ID())
 					}
 					ctr.config.CgroupParent = podCgroup
 				case rootless.IsRootless() && ctr.config.CgroupsMode != cgroupSplit:
@@ -247,10 +364,10 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Contai
 					ctr.config.CgroupParent = SystemdDefaultCgroupParent
 				}
 			} else if len(ctr.config.CgroupParent) < 6 || !strings.HasSuffix(path.Base(ctr.config.CgroupParent), ".slice") {
-				return nil, errors.Wrapf(define.ErrInvalidArg, "did not receive systemd slice as cgroup parent when using systemd to manage cgroups")
+				return nil, nil, errors.Wrapf(define.ErrInvalidArg, "did not receive systemd slice as cgroup parent when using systemd to manage cgroups")
 			}
 		default:
-			return nil, errors.Wrapf(define.ErrInvalidArg, "unsupported CGroup manager: %s - cannot validate cgroup parent", r.config.Engine.CgroupManager)
+			return nil, nil, errors.Wrapf(define.ErrInvalidArg, "unsupported CGroup manager: %s - cannot validate cgroup parent", r.config.Engine.CgroupManager)
 		}
 	}
 
@@ -270,14 +387,24 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Contai
 		// container ID.
 		cgroupPath, err := ctr.getOCICgroupPath()
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		g.SetLinuxCgroupsPath(cgroupPath)
 	}
 
+	// Pin image volumes to the images their sources refer to now, so
+	// the images cannot be removed or replaced under the container.
+	for _, vol := range ctr.config.ImageVolumes {
+		img, err := r.imageRuntime.NewFromLocal(vol.Source)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "error looking up image %q of image volume %q", vol.Source, vol.Dest)
+		}
+		vol.ImageID = img.ID()
+	}
+
 	// Set up storage for the container
 	if err := ctr.setupStorage(ctx); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer func() {
 		if retErr != nil {
@@ -310,7 +437,7 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Contai
 				// The volume exists, we're good
 				continue
 			} else if errors.Cause(err) != define.ErrNoSuchVolume {
-				return nil, errors.Wrapf(err, "error retrieving named volume %s for new container", vol.Name)
+				return nil, nil, errors.Wrapf(err, "error retrieving named volume %s for new container", vol.Name)
 			}
 		}
 
@@ -323,7 +450,7 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Contai
 		}
 		newVol, err := r.newVolume(ctx, volOptions...)
 		if err != nil {
-			return nil, errors.Wrapf(err, "error creating named volume %q", vol.Name)
+			return nil, nil, errors.Wrapf(err, "error creating named volume %q", vol.Name)
 		}
 
 		ctrNamedVolumes = append(ctrNamedVolumes, newVol)
@@ -337,12 +464,30 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Contai
 		ctr.config.ShmDir = filepath.Join(ctr.bundlePath(), "shm")
 		if err := os.MkdirAll(ctr.config.ShmDir, 0700); err != nil {
 			if !os.IsExist(err) {
-				return nil, errors.Wrap(err, "unable to create shm dir")
+				return nil, nil, errors.Wrap(err, "unable to create shm dir")
 			}
 		}
 		ctr.config.Mounts = append(ctr.config.Mounts, ctr.config.ShmDir)
 	}
 
+	return pod, ctrNamedVolumes, nil
+}
+
+func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Container, retErr error) {
+	// Inhibit shutdown until creation succeeds
+	shutdown.Inhibit()
+	defer shutdown.Uninhibit()
+
+	pod, ctrNamedVolumes, err := r.prepareContainer(ctx, ctr)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if retErr != nil {
+			r.cleanupPreparedContainer(ctr)
+		}
+	}()
+
 	// Lock all named volumes we are adding ourself to, to ensure we can't
 	// use a volume being removed.
 	volsLocked := make(map[string]bool)
@@ -366,16 +511,88 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Contai
 		pod.lock.Lock()
 		defer pod.lock.Unlock()
 
+		if err := pod.checkNamespaceHolder(ctr); err != nil {
+			return nil, err
+		}
 		if err := r.state.AddContainerToPod(pod, ctr); err != nil {
 			return nil, err
 		}
+		if err := pod.setNamespaceHolder(ctr); err != nil {
+			return nil, errors.Wrapf(err, "error recording container %s as namespace holder of pod %s", ctr.ID(), pod.ID())
+		}
 	} else if err := r.state.AddContainer(ctr); err != nil {
 		return nil, err
 	}
-	ctr.newContainerEvent(events.Create)
+	ctr.newContainerEvent(ctx, events.Create)
 	return ctr, nil
 }
 
+// setupContainers sets up multiple containers and adds them to the state at
+// once.  Either all or none of the containers are created.  The containers
+// cannot be part of a pod.
+func (r *Runtime) setupContainers(ctx context.Context, ctrs []*Container) (_ []*Container, retErr error) {
+	for _, ctr := range ctrs {
+		if ctr.config.Pod != "" {
+			return nil, errors.Wrapf(define.ErrInvalidArg, "container %s is part of a pod and cannot be created in a batch", ctr.ID())
+		}
+	}
+
+	// Inhibit shutdown until creation succeeds
+	shutdown.Inhibit()
+	defer shutdown.Uninhibit()
+
+	prepared := make([]*Container, 0, len(ctrs))
+	defer func() {
+		if retErr != nil {
+			for _, ctr := range prepared {
+				r.cleanupPreparedContainer(ctr)
+			}
+		}
+	}()
+
+	var namedVolumes []*Volume
+	for _, ctr := range ctrs {
+		_, ctrNamedVolumes, err := r.prepareContainer(ctx, ctr)
+		if err != nil {
+			return nil, err
+		}
+		prepared = append(prepared, ctr)
+		namedVolumes = append(namedVolumes, ctrNamedVolumes...)
+	}
+
+	// Lock all named volumes used by the containers, to ensure we can't
+	// use a volume being removed.
+	volsLocked := make(map[string]bool)
+	for _, namedVol := range namedVolumes {
+		toLock := namedVol
+		if volsLocked[namedVol.Name()] {
+			continue
+		}
+		volsLocked[namedVol.Name()] = true
+		toLock.lock.Lock()
+		defer toLock.lock.Unlock()
+	}
+
+	if err := r.state.AddContainers(ctrs); err != nil {
+		return nil, err
+	}
+	for _, ctr := range ctrs {
+		ctr.newContainerEvent(ctx, events.Create)
+	}
+	return ctrs, nil
+}
+
+// cleanupPreparedContainer releases the storage and the lock of a container
+// set up by prepareContainer that could not be added to the state.
+func (r *Runtime) cleanupPreparedContainer(ctr *Container) {
+	if err := ctr.teardownStorage(); err != nil {
+		logrus.Errorf("Error removing partially-created container root filesystem: %s", err)
+	}
+	if err := ctr.lock.Free(); err != nil {
+		logrus.Errorf("Error freeing lock for container after creation failed: %v", err)
+	}
+}
+
 // RemoveContainer removes the given container
 // If force is specified, the container will be stopped first
 // If removeVolume is specified, named volumes used by the container will
@@ -405,19 +622,14 @@ func (r *Runtime) removeContainer(ctx context.Context, c *Container, force bool,
 		}
 	}
 
-	logrus.Debugf("Removing container %s", c.ID())
-
 	// We need to lock the pod before we lock the container.
 	// To avoid races around removing a container and the pod it is in.
 	// Don't need to do this in pod removal case - we're evicting the entire
 	// pod.
-	var pod *Pod
-	var err error
-	runtime := c.runtime
 	if c.config.Pod != "" && !removePod {
-		pod, err = r.state.Pod(c.config.Pod)
+		pod, err := r.state.Pod(c.config.Pod)
 		if err != nil {
-			return errors.Wrapf(err, "container %s is in pod %s, but pod cannot be retrieved", c.ID(), pod.ID())
+			return errors.Wrapf(err, "container %s is in pod %s, but pod cannot be retrieved", c.ID(), c.config.Pod)
 		}
 
 		// Lock the pod while we're removing container
@@ -429,13 +641,43 @@ func (r *Runtime) removeContainer(ctx context.Context, c *Container, force bool,
 		if err := pod.updatePod(); err != nil {
 			return err
 		}
+		return r.removeContainerInLockedPod(ctx, c, pod, force, removeVolume)
+	}
 
-		infraID := pod.state.InfraContainerID
-		if c.ID() == infraID {
-			return errors.Errorf("container %s is the infra container of pod %s and cannot be removed without removing the pod", c.ID(), pod.ID())
+	return r.removeLockedPodContainer(ctx, c, nil, force, removeVolume, removePod)
+}
+
+// removeContainerInLockedPod removes a container of a pod whose lock is
+// already held by the caller.  The container is locked, checked and removed
+// from the database as by removeContainer.
+func (r *Runtime) removeContainerInLockedPod(ctx context.Context, c *Container, pod *Pod, force bool, removeVolume bool) error {
+	if c.ID() == pod.state.InfraContainerID && pod.HasInfraContainer() {
+		return errors.Errorf("container %s is the infra container of pod %s and cannot be removed without removing the pod", c.ID(), pod.ID())
+	}
+	return r.removeLockedPodContainer(ctx, c, pod, force, removeVolume, false)
+}
+
+// removeLockedPodContainer removes a container once its pod, if any, is
+// locked.  pod is the pod of the container, nil when it is not in a pod or
+// when the whole pod is removed.
+func (r *Runtime) removeLockedPodContainer(ctx context.Context, c *Container, pod *Pod, force bool, removeVolume bool, removePod bool) error {
+	if !c.valid {
+		if ok, _ := r.state.HasContainer(c.ID()); !ok {
+			// Container probably already removed
+			return nil
 		}
 	}
 
+	logrus.Debugf("Removing container %s", c.ID())
+
+	if !removePod {
+		if err := r.checkOwner(c.config.Owner, "container "+c.ID()); err != nil {
+			return err
+		}
+	}
+
+	runtime := c.runtime
+
 	// For pod removal, the container is already locked by the caller
 	if !removePod {
 		c.lock.Lock()
@@ -483,7 +725,7 @@ func (r *Runtime) removeContainer(ctx context.Context, c *Container, force bool,
 	if c.state.State == define.ContainerStateRunning {
 		// Ignore ErrConmonDead - we couldn't retrieve the container's
 		// exit code properly, but it's still stopped.
-		if err := c.stop(c.StopTimeout()); err != nil && errors.Cause(err) != define.ErrConmonDead {
+		if err := c.stopWithSignals(c.StopSignals()); err != nil && errors.Cause(err) != define.ErrConmonDead {
 			return errors.Wrapf(err, "cannot remove container %s as it could not be stopped", c.ID())
 		}
 	}
@@ -560,6 +802,8 @@ func (r *Runtime) removeContainer(ctx context.Context, c *Container, force bool,
 				} else {
 					logrus.Errorf("Error removing container %s from database: %v", c.ID(), err)
 				}
+			} else if err := pod.clearNamespaceHolder(c); err != nil {
+				logrus.Errorf("Error clearing namespace holder of pod %s: %v", pod.ID(), err)
 			}
 		}
 	} else {
@@ -584,7 +828,7 @@ func (r *Runtime) removeContainer(ctx context.Context, c *Container, force bool,
 	// Set container as invalid so it can no longer be used
 	c.valid = false
 
-	c.newContainerEvent(events.Remove)
+	c.newContainerEvent(ctx, events.Remove)
 
 	if !removeVolume {
 		return cleanupErr
@@ -604,6 +848,76 @@ func (r *Runtime) removeContainer(ctx context.Context, c *Container, force bool,
 	return cleanupErr
 }
 
+// RenameContainer renames the given container.  The name is changed in the
+// database and in c/storage.  Running containers can be renamed as well, the
+// hostname of the container is not changed.
+func (r *Runtime) RenameContainer(ctx context.Context, ctr *Container, newName string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+
+	if !define.NameRegex.MatchString(newName) {
+		return define.RegexError
+	}
+
+	if ctr.config.IsInfra {
+		return errors.Wrapf(define.ErrInvalidArg, "cannot rename infra container %s", ctr.ID())
+	}
+
+	ctr.lock.Lock()
+	defer ctr.lock.Unlock()
+
+	if err := ctr.syncContainer(); err != nil {
+		return err
+	}
+
+	if ctr.state.State == define.ContainerStateRemoving {
+		return errors.Wrapf(define.ErrCtrStateInvalid, "cannot rename container %s as it is being removed", ctr.ID())
+	}
+
+	// Another process may have renamed the container since we retrieved
+	// it, so work on the current config from the database.
+	newConf, err := r.state.GetContainerConfig(ctr.ID())
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving container %s configuration", ctr.ID())
+	}
+	oldName := newConf.Name
+	if newName == oldName {
+		return errors.Wrapf(define.ErrInvalidArg, "container %s is already named %s", ctr.ID(), newName)
+	}
+
+	logrus.Infof("Renaming container %s from %q to %q", ctr.ID(), oldName, newName)
+
+	// Rename the c/storage container first, which fails if the name is in
+	// use by a container of another tool such as Buildah.  It is reverted
+	// if the database can not be updated.  Containers created from a
+	// rootfs have no c/storage container.
+	renamedStorage := true
+	if err := r.store.SetNames(ctr.ID(), []string{newName}); err != nil {
+		if errors.Cause(err) != storage.ErrContainerUnknown {
+			return errors.Wrapf(err, "error renaming storage for container %s", ctr.ID())
+		}
+		renamedStorage = false
+	}
+
+	newConf.Name = newName
+	if err := r.state.SafeRewriteContainerConfig(ctr, oldName, newName, newConf); err != nil {
+		if renamedStorage {
+			if revertErr := r.store.SetNames(ctr.ID(), []string{oldName}); revertErr != nil {
+				logrus.Errorf("Error restoring name %q of storage for container %s: %v", oldName, ctr.ID(), revertErr)
+			}
+		}
+		return errors.Wrapf(err, "error renaming container %s", ctr.ID())
+	}
+	ctr.config = newConf
+
+	ctr.newContainerEvent(ctx, events.Rename)
+	return nil
+}
+
 // EvictContainer removes the given container partial or full ID or name, and
 // returns the full ID of the evicted container and any error encountered.
 // It should be used to remove a container when obtaining a Container struct
@@ -693,7 +1007,7 @@ func (r *Runtime) evictContainer(ctx context.Context, idOrName string, removeVol
 		}
 
 		infraID := pod.state.InfraContainerID
-		if c.ID() == infraID {
+		if c.ID() == infraID && pod.HasInfraContainer() {
 			return id, errors.Errorf("container %s is the infra container of pod %s and cannot be removed without removing the pod", c.ID(), pod.ID())
 		}
 	}
@@ -705,6 +1019,8 @@ func (r *Runtime) evictContainer(ctx context.Context, idOrName string, removeVol
 		// from the state elsewhere
 		if err := r.state.RemoveContainerFromPod(pod, c); err != nil {
 			cleanupErr = err
+		} else if err := pod.clearNamespaceHolder(c); err != nil {
+			logrus.Errorf("Error clearing namespace holder of pod %s: %v", pod.ID(), err)
 		}
 	} else {
 		if err := r.state.RemoveContainer(c); err != nil {
@@ -815,6 +1131,134 @@ func (r *Runtime) GetContainersWithoutLock(filters ...ContainerFilter) ([]*Conta
 	return ctrsFiltered, nil
 }
 
+// GetContainersSnapshot is the same as GetContainers, but the containers and
+// their state are read from the database in a single read-only transaction
+// without locking the containers.  Listing the containers hence does not wait
+// for operations on individual containers, e.g., a slow container start.
+// Only containers whose conmon has written an exit file in the meantime are
+// locked and synced to report their proper state.
+// The returned containers are a snapshot: they are always batched and MUST
+// only be used for reading.
+func (r *Runtime) GetContainersSnapshot(filters ...ContainerFilter) ([]*Container, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	ctrs, err := r.state.AllContainersWithState()
+	if err != nil {
+		return nil, err
+	}
+
+	ctrsFiltered := make([]*Container, 0, len(ctrs))
+	for _, ctr := range ctrs {
+		snapshot, err := ctr.snapshotCopy()
+		if err != nil {
+			if errors.Cause(err) == define.ErrNoSuchCtr || errors.Cause(err) == define.ErrCtrRemoved {
+				// Removed in the meantime.
+				continue
+			}
+			return nil, err
+		}
+
+		include := true
+		for _, filter := range filters {
+			include = include && filter(snapshot)
+		}
+		if include {
+			ctrsFiltered = append(ctrsFiltered, snapshot)
+		}
+	}
+
+	return ctrsFiltered, nil
+}
+
+// SyncContainers syncs the state of the given containers with the OCI runtime,
+// as Sync does for each of them, but queries each OCI runtime once for the
+// status of all the containers if it can list them.  The containers are locked
+// and synced one at a time; the listed status of a container is only used if
+// it matches the state of the container, as the container may have changed
+// since it was listed.  Containers removed in the meantime are skipped.
+func (r *Runtime) SyncContainers(ctrs []*Container) error {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+
+	for _, ctr := range ctrs {
+		if ctr.snapshot {
+			return errors.Wrapf(define.ErrInvalidArg, "container %s is part of a snapshot and cannot be synced", ctr.ID())
+		}
+	}
+
+	statuses := make(map[OCIRuntime]map[string]*spec.State)
+	seen := make(map[string]bool, len(ctrs))
+	for _, ctr := range ctrs {
+		if seen[ctr.ID()] {
+			continue
+		}
+		seen[ctr.ID()] = true
+		if err := ctr.syncWithStatuses(statuses); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// syncWithStatuses syncs the state of the container with its OCI runtime, as
+// Sync does, using the status of the container listed by the OCI runtime in
+// statuses if it matches.  The statuses of an OCI runtime are listed when they
+// are first needed.  Removed containers are skipped.
+func (c *Container) syncWithStatuses(statuses map[OCIRuntime]map[string]*spec.State) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+	}
+
+	if err := c.runtime.state.UpdateContainer(c); err != nil {
+		if errors.Cause(err) == define.ErrNoSuchCtr || errors.Cause(err) == define.ErrCtrRemoved {
+			return nil
+		}
+		return err
+	}
+
+	if c.ensureState(define.ContainerStateCreated, define.ContainerStateRunning, define.ContainerStatePaused, define.ContainerStateStopped) {
+		ociStatuses, ok := statuses[c.ociRuntime]
+		if !ok {
+			var err error
+			if ociStatuses, err = c.ociRuntime.ListContainerStatuses(); err != nil {
+				logrus.Debugf("Querying the status of containers of OCI runtime %s one by one: %v", c.ociRuntime.Name(), err)
+			}
+			statuses[c.ociRuntime] = ociStatuses
+		}
+
+		oldState := c.state.State
+		var err error
+		if status, ok := ociStatuses[c.ID()]; ok && string(status.Status) == oldState.String() {
+			err = c.ociRuntime.UpdateContainerStatusFrom(c, status)
+		} else {
+			err = c.ociRuntime.UpdateContainerStatus(c)
+		}
+		if err != nil {
+			return err
+		}
+		// Only save back to DB if state changed
+		if c.state.State != oldState {
+			if err := c.save(); err != nil {
+				return err
+			}
+		}
+	}
+
+	c.newContainerEvent(context.Background(), events.Sync)
+	return nil
+}
+
 // GetAllContainers is a helper function for GetContainers
 func (r *Runtime) GetAllContainers() ([]*Container, error) {
 	return r.state.AllContainers()
@@ -892,6 +1336,10 @@ func (r *Runtime) PruneContainers(filterFuncs []ContainerFilter) (map[string]int
 		if c.PodID() != "" {
 			return false
 		}
+		// Containers of other users are not pruned.
+		if !r.OwnerVisible(c.Owner()) {
+			return false
+		}
 		state, err := c.State()
 		if err != nil {
 			logrus.Error(err)
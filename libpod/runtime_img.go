@@ -47,7 +47,7 @@ func (r *Runtime) RemoveImage(ctx context.Context, img *image.Image, force bool)
 	}
 	imageCtrs := []*Container{}
 	for _, ctr := range ctrs {
-		if ctr.config.RootfsImageID == img.ID() {
+		if ctr.config.RootfsImageID == img.ID() || ctr.usesImageVolume(img.ID()) {
 			imageCtrs = append(imageCtrs, ctr)
 		}
 	}
@@ -0,0 +1,223 @@
+package libpod
+
+import (
+	"bytes"
+	"math/rand"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/containers/podman/v2/pkg/util"
+	"github.com/docker/docker/pkg/namesgenerator"
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultNameTemplate renders the names of the builtin name generator.
+	defaultNameTemplate = "{{.Adjective}}_{{.Noun}}"
+	// maxNameAttempts is the number of names rendered from the name
+	// template before giving up to find a free one.
+	maxNameAttempts = 10000
+)
+
+// nameTemplateData is passed to the name template set in containers.conf.
+type nameTemplateData struct {
+	// Kind is "container" or "pod".
+	Kind string
+	// Adjective and Noun are picked at random for every name.
+	Adjective string
+	Noun      string
+	// User is the owner of the container or pod.
+	User string
+	// Project is the value of the io.podman.project label.
+	Project string
+	// Seq counts up from 1 until a free name is found.
+	Seq int
+}
+
+// nameGenerator renders the names of containers and pods created without
+// one from the template set in the [names] table of containers.conf.
+type nameGenerator struct {
+	template   *template.Template
+	adjectives []string
+	nouns      []string
+}
+
+// newNameGenerator sets up the name generator configured in the [names] table
+// of containers.conf.  A template that renders the same name every time is
+// rejected.
+func newNameGenerator(conf util.NameGeneration) (*nameGenerator, error) {
+	if conf.Template == "" {
+		conf.Template = defaultNameTemplate
+	}
+	tmpl, err := template.New("name").Option("missingkey=error").Parse(conf.Template)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid template in the [names] table of containers.conf")
+	}
+	g := &nameGenerator{
+		template:   tmpl,
+		adjectives: conf.Adjectives,
+		nouns:      conf.Nouns,
+	}
+
+	first, err := g.render(nameTemplateData{Kind: "container", Adjective: "a", Noun: "b", User: "user", Project: "project", Seq: 1})
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid template in the [names] table of containers.conf")
+	}
+	second, err := g.render(nameTemplateData{Kind: "container", Adjective: "c", Noun: "d", User: "user", Project: "project", Seq: 2})
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid template in the [names] table of containers.conf")
+	}
+	if first == second {
+		return nil, errors.Errorf("the template %q in the [names] table of containers.conf must use .Adjective, .Noun or .Seq", conf.Template)
+	}
+	return g, nil
+}
+
+func (g *nameGenerator) render(data nameTemplateData) (string, error) {
+	var name bytes.Buffer
+	if err := g.template.Execute(&name, data); err != nil {
+		return "", err
+	}
+	return name.String(), nil
+}
+
+// pick returns a random adjective and noun from the configured lists, or
+// from the builtin ones if they are not set.
+func (g *nameGenerator) pick() (string, string) {
+	// The builtin names are formatted as adjective_noun.
+	builtin := strings.SplitN(namesgenerator.GetRandomName(0), "_", 2)
+	adjective, noun := builtin[0], builtin[1]
+	if len(g.adjectives) > 0 {
+		adjective = g.adjectives[rand.Intn(len(g.adjectives))]
+	}
+	if len(g.nouns) > 0 {
+		noun = g.nouns[rand.Intn(len(g.nouns))]
+	}
+	return adjective, noun
+}
+
+// generateName generates a name for a container or pod, which is neither
+// used by an existing container or pod nor reserved.  The name is not
+// reserved: adding the container or pod to the state fails if another one
+// took the name in the meantime.
+func (r *Runtime) generateName(kind string, labels map[string]string) (string, error) {
+	reservations, err := r.state.NameReservations()
+	if err != nil {
+		return "", err
+	}
+	return r.freeName(kind, labels, reservations)
+}
+
+// freeName renders names from the name template until one is found which is
+// neither used nor reserved.
+func (r *Runtime) freeName(kind string, labels map[string]string, reservations []define.NameReservation) (string, error) {
+	reserved := make(map[string]bool, len(reservations))
+	for _, reservation := range reservations {
+		reserved[reservation.Name] = true
+	}
+	data := nameTemplateData{
+		Kind:    kind,
+		User:    r.owner,
+		Project: labels[define.ProjectLabel],
+	}
+	for data.Seq = 1; data.Seq <= maxNameAttempts; data.Seq++ {
+		data.Adjective, data.Noun = r.nameGenerator.pick()
+		name, err := r.nameGenerator.render(data)
+		if err != nil {
+			return "", errors.Wrapf(err, "error rendering the template in the [names] table of containers.conf")
+		}
+		if !define.NameRegex.MatchString(name) {
+			return "", errors.Wrapf(define.ErrInvalidArg, "name %q generated from the template in the [names] table of containers.conf is invalid", name)
+		}
+		if reserved[name] {
+			continue
+		}
+		used, err := r.nameUsed(name)
+		if err != nil {
+			return "", err
+		}
+		if !used {
+			return name, nil
+		}
+	}
+	return "", errors.Errorf("unable to find a free name for the %s in %d attempts", kind, maxNameAttempts)
+}
+
+// nameUsed returns whether a container or pod with the given name exists.
+func (r *Runtime) nameUsed(name string) (bool, error) {
+	if _, err := r.state.LookupContainer(name); err == nil {
+		return true, nil
+	} else if errors.Cause(err) != define.ErrNoSuchCtr {
+		return false, err
+	}
+	if _, err := r.state.LookupPod(name); err == nil {
+		return true, nil
+	} else if errors.Cause(err) != define.ErrNoSuchPod {
+		return false, err
+	}
+	return false, nil
+}
+
+// ReserveName reserves a name for a container or pod to be created later,
+// for ttl or until it is released if ttl is zero.  If name is empty, a name
+// is generated as for a container.  The reservation is consumed by creating a
+// container or pod with the name as the same user; other users cannot use the
+// name, and generated names never use a reserved one.
+func (r *Runtime) ReserveName(name string, ttl time.Duration) (*define.NameReservation, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	if ttl < 0 {
+		return nil, errors.Wrapf(define.ErrInvalidArg, "the time to live of a name reservation must not be negative")
+	}
+	if name != "" && !define.NameRegex.MatchString(name) {
+		return nil, errors.Wrapf(define.RegexError, "invalid name %q", name)
+	}
+
+	if name == "" {
+		var err error
+		if name, err = r.generateName("container", nil); err != nil {
+			return nil, err
+		}
+	}
+	reservation := &define.NameReservation{Name: name, Owner: r.owner, Created: time.Now()}
+	if ttl > 0 {
+		reservation.Expires = reservation.Created.Add(ttl)
+	}
+	if err := r.state.ReserveName(reservation); err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// ReleaseName releases the reservation of a name.  Only the user who reserved
+// the name may release it.
+func (r *Runtime) ReleaseName(name string) error {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+
+	return r.state.ReleaseName(name, r.owner)
+}
+
+// ReservedNames returns the names which are reserved.
+func (r *Runtime) ReservedNames() ([]define.NameReservation, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	return r.state.NameReservations()
+}
+
+// nameReservationExpired returns whether a name reservation expired.
+func nameReservationExpired(reservation *define.NameReservation) bool {
+	return !reservation.Expires.IsZero() && !reservation.Expires.After(time.Now())
+}
@@ -0,0 +1,132 @@
+package libpod
+
+import (
+	"testing"
+	"time"
+
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/containers/podman/v2/libpod/lock"
+	"github.com/containers/podman/v2/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newNamesTestRuntime(t *testing.T, names util.NameGeneration) *Runtime {
+	state, err := NewInMemoryState()
+	require.NoError(t, err)
+	generator, err := newNameGenerator(names)
+	require.NoError(t, err)
+	return &Runtime{
+		owner:         "alice",
+		state:         state,
+		nameGenerator: generator,
+		valid:         true,
+	}
+}
+
+func TestNameTemplateStatic(t *testing.T) {
+	_, err := newNameGenerator(util.NameGeneration{Template: "{{.User}}-web"})
+	assert.Error(t, err)
+}
+
+func TestGenerateNameSequential(t *testing.T) {
+	r := newNamesTestRuntime(t, util.NameGeneration{Template: "{{.User}}-{{.Kind}}-{{.Seq}}"})
+
+	name, err := r.generateName("container", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "alice-container-1", name)
+	// Generated names are not reserved.
+	name, err = r.generateName("container", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "alice-container-1", name)
+
+	_, err = r.ReserveName("alice-container-1", 0)
+	require.NoError(t, err)
+	name, err = r.generateName("container", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "alice-container-2", name)
+}
+
+func TestGenerateNameLists(t *testing.T) {
+	r := newNamesTestRuntime(t, util.NameGeneration{
+		Template:   "{{.Project}}-{{.Adjective}}-{{.Noun}}",
+		Adjectives: []string{"red"},
+		Nouns:      []string{"fox"},
+	})
+
+	name, err := r.generateName("pod", map[string]string{define.ProjectLabel: "shop"})
+	require.NoError(t, err)
+	assert.Equal(t, "shop-red-fox", name)
+	// Only one name can be rendered, and it is reserved.
+	_, err = r.ReserveName(name, 0)
+	require.NoError(t, err)
+	_, err = r.generateName("pod", map[string]string{define.ProjectLabel: "shop"})
+	assert.Error(t, err)
+	// An empty project renders an invalid name.
+	_, err = r.generateName("pod", nil)
+	assert.Error(t, err)
+}
+
+func TestReserveName(t *testing.T) {
+	r := newNamesTestRuntime(t, util.NameGeneration{})
+
+	reservation, err := r.ReserveName("web", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "web", reservation.Name)
+	assert.Equal(t, "alice", reservation.Owner)
+	assert.True(t, reservation.Expires.IsZero())
+
+	_, err = r.ReserveName("web", 0)
+	assert.Error(t, err)
+	_, err = r.ReserveName("-invalid", 0)
+	assert.Error(t, err)
+
+	generated, err := r.ReserveName("", time.Hour)
+	require.NoError(t, err)
+	assert.NotEmpty(t, generated.Name)
+	assert.False(t, generated.Expires.IsZero())
+
+	reserved, err := r.ReservedNames()
+	require.NoError(t, err)
+	assert.Len(t, reserved, 2)
+
+	require.NoError(t, r.ReleaseName("web"))
+	assert.Error(t, r.ReleaseName("web"))
+
+	// Expired reservations are dropped.
+	_, err = r.ReserveName("short", time.Nanosecond)
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	reserved, err = r.ReservedNames()
+	require.NoError(t, err)
+	require.Len(t, reserved, 1)
+	assert.Equal(t, generated.Name, reserved[0].Name)
+
+	// Names reserved by other users cannot be released.
+	require.NoError(t, r.state.ReserveName(&define.NameReservation{Name: "other", Owner: "bob", Created: time.Now()}))
+	assert.Equal(t, define.ErrNameReserved, errors.Cause(r.ReleaseName("other")))
+}
+
+func TestReserveNameOwner(t *testing.T) {
+	r := newNamesTestRuntime(t, util.NameGeneration{})
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+
+	_, err = r.ReserveName("test1", 0)
+	require.NoError(t, err)
+
+	// Another user cannot use the reserved name.
+	ctr, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	ctr.config.Owner = "bob"
+	err = r.state.AddContainer(ctr)
+	assert.Equal(t, define.ErrNameReserved, errors.Cause(err))
+
+	// The user who reserved it consumes the reservation.
+	ctr.config.Owner = "alice"
+	require.NoError(t, r.state.AddContainer(ctr))
+	reserved, err := r.ReservedNames()
+	require.NoError(t, err)
+	assert.Empty(t, reserved)
+}
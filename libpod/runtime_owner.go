@@ -0,0 +1,56 @@
+package libpod
+
+import (
+	"os"
+	"os/user"
+	"strconv"
+
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/pkg/errors"
+)
+
+// setupOwner determines the user recorded as the owner of new containers and
+// pods.  Administrators sharing the storage of root usually run Podman
+// through sudo, so the user invoking sudo is the owner.  The owner mode only
+// applies to them, root itself is not restricted.
+func (r *Runtime) setupOwner() {
+	r.ownerMode = r.extraConfig.OwnerMode
+
+	if sudoUser := os.Getenv("SUDO_USER"); sudoUser != "" && os.Geteuid() == 0 {
+		r.owner = sudoUser
+		r.ownerRestricted = r.ownerMode != define.OwnerModeShared
+		return
+	}
+	if u, err := user.Current(); err == nil {
+		r.owner = u.Username
+	} else {
+		r.owner = strconv.Itoa(os.Getuid())
+	}
+}
+
+// Owner returns the user recorded as the owner of containers and pods
+// created by this runtime.
+func (r *Runtime) Owner() string {
+	return r.owner
+}
+
+// OwnerMode returns the owner mode set in containers.conf, one of
+// define.OwnerModeShared, define.OwnerModeFilter and define.OwnerModeEnforce.
+func (r *Runtime) OwnerMode() string {
+	return r.ownerMode
+}
+
+// OwnerVisible returns whether containers and pods of owner are listed by
+// default.  Objects without an owner are visible to everyone.
+func (r *Runtime) OwnerVisible(owner string) bool {
+	return !r.ownerRestricted || owner == "" || owner == r.owner
+}
+
+// checkOwner returns define.ErrNotOwner if the owner mode does not allow the
+// user to modify an object of owner.
+func (r *Runtime) checkOwner(owner, description string) error {
+	if r.ownerMode != define.OwnerModeEnforce || r.OwnerVisible(owner) {
+		return nil
+	}
+	return errors.Wrapf(define.ErrNotOwner, "%s is owned by %s", description, owner)
+}
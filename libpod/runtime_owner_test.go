@@ -0,0 +1,33 @@
+package libpod
+
+import (
+	"testing"
+
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOwnerVisible(t *testing.T) {
+	r := &Runtime{owner: "alice", ownerMode: define.OwnerModeFilter, ownerRestricted: true}
+	assert.True(t, r.OwnerVisible("alice"))
+	assert.True(t, r.OwnerVisible(""))
+	assert.False(t, r.OwnerVisible("bob"))
+	// Filtering does not prevent removal.
+	assert.Nil(t, r.checkOwner("bob", "container 123"))
+
+	r.ownerRestricted = false
+	assert.True(t, r.OwnerVisible("bob"))
+}
+
+func TestCheckOwner(t *testing.T) {
+	r := &Runtime{owner: "alice", ownerMode: define.OwnerModeEnforce, ownerRestricted: true}
+	assert.Nil(t, r.checkOwner("alice", "container 123"))
+	assert.Nil(t, r.checkOwner("", "container 123"))
+	err := r.checkOwner("bob", "container 123")
+	assert.Equal(t, define.ErrNotOwner, errors.Cause(err))
+
+	// Root is not restricted.
+	r.ownerRestricted = false
+	assert.Nil(t, r.checkOwner("bob", "container 123"))
+}
@@ -5,8 +5,10 @@ import (
 	"time"
 
 	"github.com/containers/podman/v2/libpod/define"
+	"github.com/containers/podman/v2/libpod/events"
 	"github.com/containers/podman/v2/pkg/util"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 // Contains the public Runtime API for pods
@@ -45,9 +47,58 @@ func (r *Runtime) RemovePod(ctx context.Context, p *Pod, removeCtrs, force bool)
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
+	// Group the events of the containers removed with the pod.
+	ctx, _ = r.StartEventCorrelation(ctx)
+
 	return r.removePod(ctx, p, removeCtrs, force)
 }
 
+// RenamePod renames the given pod in the database.  Running pods can be
+// renamed as well; the hostname of the pod and the names of its containers,
+// including the infra container, are not changed.
+func (r *Runtime) RenamePod(ctx context.Context, p *Pod, newName string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+
+	if !define.NameRegex.MatchString(newName) {
+		return define.RegexError
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if !p.valid {
+		return define.ErrPodRemoved
+	}
+
+	// Another process may have renamed the pod since we retrieved it, so
+	// work on the current config from the database.
+	dbPod, err := r.state.Pod(p.ID())
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving pod %s configuration", p.ID())
+	}
+	newConf := dbPod.config
+	oldName := newConf.Name
+	if newName == oldName {
+		return errors.Wrapf(define.ErrInvalidArg, "pod %s is already named %s", p.ID(), newName)
+	}
+
+	logrus.Infof("Renaming pod %s from %q to %q", p.ID(), oldName, newName)
+
+	newConf.Name = newName
+	if err := r.state.SafeRewritePodConfig(p, oldName, newName, newConf); err != nil {
+		return errors.Wrapf(err, "error renaming pod %s", p.ID())
+	}
+	p.config = newConf
+
+	p.newPodEvent(ctx, events.Rename)
+	return nil
+}
+
 // GetPod retrieves a pod by its ID
 func (r *Runtime) GetPod(id string) (*Pod, error) {
 	r.lock.RLock()
@@ -109,6 +160,24 @@ func (r *Runtime) Pods(filters ...PodFilter) ([]*Pod, error) {
 	return podsFiltered, nil
 }
 
+// PodContainersSnapshot returns the containers of all the pods, by pod ID,
+// read with their state in a single transaction as in GetContainersSnapshot.
+// Listing many pods with their containers hence reads the database once,
+// instead of once per pod.  The containers MUST only be used for reading.
+func (r *Runtime) PodContainersSnapshot() (map[string][]*Container, error) {
+	ctrs, err := r.GetContainersSnapshot(func(c *Container) bool {
+		return c.PodID() != ""
+	})
+	if err != nil {
+		return nil, err
+	}
+	members := make(map[string][]*Container)
+	for _, ctr := range ctrs {
+		members[ctr.PodID()] = append(members[ctr.PodID()], ctr)
+	}
+	return members, nil
+}
+
 // GetAllPods retrieves all pods
 func (r *Runtime) GetAllPods() ([]*Pod, error) {
 	r.lock.RLock()
@@ -180,6 +249,10 @@ func (r *Runtime) PrunePods(ctx context.Context) (map[string]error, error) {
 	response := make(map[string]error)
 	states := []string{define.PodStateStopped, define.PodStateExited}
 	filterFunc := func(p *Pod) bool {
+		// Pods of other users are not pruned.
+		if !r.OwnerVisible(p.Owner()) {
+			return false
+		}
 		state, _ := p.GetPodStatus()
 		for _, status := range states {
 			if state == status {
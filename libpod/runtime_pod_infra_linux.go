@@ -61,6 +61,27 @@ func (r *Runtime) makeInfraContainer(ctx context.Context, p *Pod, imgName, rawIm
 
 	isRootless := rootless.IsRootless()
 
+	// The user namespace of the infra container is joined by the containers
+	// of the pod sharing it.  As for containers, keep-id as root does not
+	// need a user namespace.
+	userNS := p.config.InfraContainer.KeepID && isRootless
+	if userNS {
+		idMappings, _, _, err := util.GetKeepIDMapping()
+		if err != nil {
+			return nil, err
+		}
+		if err := g.AddOrReplaceLinuxNamespace(string(spec.UserNamespace), ""); err != nil {
+			return nil, err
+		}
+		for _, uidmap := range idMappings.UIDMap {
+			g.AddLinuxUIDMapping(uint32(uidmap.HostID), uint32(uidmap.ContainerID), uint32(uidmap.Size))
+		}
+		for _, gidmap := range idMappings.GIDMap {
+			g.AddLinuxGIDMapping(uint32(gidmap.HostID), uint32(gidmap.ContainerID), uint32(gidmap.Size))
+		}
+		options = append(options, WithIDMappings(*idMappings))
+	}
+
 	// I've seen circumstances where config is being passed as nil.
 	// Let's err on the side of safety and make sure it's safe to use.
 	if config != nil {
@@ -94,7 +115,6 @@ func (r *Runtime) makeInfraContainer(ctx context.Context, p *Pod, imgName, rawIm
 			}
 		}
 
-		// Since user namespace sharing is not implemented, we only need to check if it's rootless
 		if !p.config.InfraContainer.HostNetwork {
 			netmode := "bridge"
 			if isRootless || p.config.InfraContainer.Slirp4netns {
@@ -103,9 +123,19 @@ func (r *Runtime) makeInfraContainer(ctx context.Context, p *Pod, imgName, rawIm
 					options = append(options, WithNetworkOptions(p.config.InfraContainer.NetworkOptions))
 				}
 			}
-			// PostConfigureNetNS should not be set since user namespace sharing is not implemented
-			// and rootless networking no longer supports post configuration setup
-			options = append(options, WithNetNS(p.config.InfraContainer.PortBindings, false, netmode, p.config.InfraContainer.Networks))
+			// The network namespace is configured after it is created
+			// by the OCI runtime if it belongs to the user namespace
+			// of the pod.
+			options = append(options, WithNetNS(p.config.InfraContainer.PortBindings, userNS, netmode, p.config.InfraContainer.Networks))
+
+			// Let all users of the pod ping if configured in containers.conf.
+			if r.extraConfig.UnprivilegedPing {
+				pingGroupRange, err := util.UserNSPingGroupRange(nil)
+				if err != nil {
+					return nil, errors.Wrapf(err, "error computing %s for unprivileged_ping", util.PingGroupRangeSysctl)
+				}
+				g.AddLinuxSysctl(util.PingGroupRangeSysctl, pingGroupRange)
+			}
 		} else if err := g.RemoveLinuxNamespace(string(spec.NetworkNamespace)); err != nil {
 			return nil, errors.Wrapf(err, "error removing network namespace from pod %s infra container", p.ID())
 		}
@@ -116,6 +146,9 @@ func (r *Runtime) makeInfraContainer(ctx context.Context, p *Pod, imgName, rawIm
 		if p.config.InfraContainer.StaticIP != nil {
 			options = append(options, WithStaticIP(p.config.InfraContainer.StaticIP))
 		}
+		if p.config.InfraContainer.StaticIPv6 != nil {
+			options = append(options, WithStaticIPv6(p.config.InfraContainer.StaticIPv6))
+		}
 		if p.config.InfraContainer.StaticMAC != nil {
 			options = append(options, WithStaticMAC(p.config.InfraContainer.StaticMAC))
 		}
@@ -134,12 +167,18 @@ func (r *Runtime) makeInfraContainer(ctx context.Context, p *Pod, imgName, rawIm
 		if p.config.InfraContainer.UseImageHosts {
 			options = append(options, WithUseImageHosts())
 		}
+		if p.config.InfraContainer.BaseHostsFile != "" {
+			options = append(options, WithBaseHostsFile(p.config.InfraContainer.BaseHostsFile))
+		}
 		if len(p.config.InfraContainer.HostAdd) > 0 {
 			options = append(options, WithHosts(p.config.InfraContainer.HostAdd))
 		}
 		if len(p.config.InfraContainer.ExitCommand) > 0 {
 			options = append(options, WithExitCommand(p.config.InfraContainer.ExitCommand))
 		}
+		if p.config.InfraContainer.ShmSize > 0 {
+			options = append(options, WithShmSize(p.config.InfraContainer.ShmSize))
+		}
 	}
 
 	g.SetRootReadonly(true)
@@ -159,7 +198,10 @@ func (r *Runtime) makeInfraContainer(ctx context.Context, p *Pod, imgName, rawIm
 		g.AddMount(devPts)
 	}
 
-	containerName := p.ID()[:IDTruncLength] + "-infra"
+	containerName := p.config.InfraContainer.InfraName
+	if containerName == "" {
+		containerName = p.ID()[:IDTruncLength] + "-infra"
+	}
 	options = append(options, r.WithPod(p))
 	options = append(options, WithRootFSFromImage(imgID, imgName, rawImageName))
 	options = append(options, WithName(containerName))
@@ -195,5 +237,5 @@ func (r *Runtime) createInfraContainer(ctx context.Context, p *Pod) (*Container,
 	imageName := newImage.Names()[0]
 	imageID := data.ID
 
-	return r.makeInfraContainer(ctx, p, imageName, r.config.Engine.InfraImage, imageID, data.Config)
+	return r.makeInfraContainer(ctx, p, imageName, img, imageID, data.Config)
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/containers/common/pkg/config"
@@ -29,6 +30,7 @@ func (r *Runtime) NewPod(ctx context.Context, options ...PodCreateOption) (_ *Po
 	}
 
 	pod := newPod(r)
+	pod.config.Owner = r.owner
 
 	// Set default namespace to runtime's namespace
 	// Do so before options run so they can override it
@@ -43,7 +45,7 @@ func (r *Runtime) NewPod(ctx context.Context, options ...PodCreateOption) (_ *Po
 	}
 
 	if pod.config.Name == "" {
-		name, err := r.generateName()
+		name, err := r.generateName("pod", pod.config.Labels)
 		if err != nil {
 			return nil, err
 		}
@@ -54,10 +56,189 @@ func (r *Runtime) NewPod(ctx context.Context, options ...PodCreateOption) (_ *Po
 		pod.config.Hostname = pod.config.Name
 	}
 
+	if err := r.setupPod(pod); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if deferredErr != nil {
+			if err := r.removePod(ctx, pod, true, true); err != nil {
+				logrus.Errorf("Error removing pod after pause container creation failure: %v", err)
+			}
+		}
+	}()
+
+	if pod.HasInfraContainer() {
+		ctr, err := r.createInfraContainer(ctx, pod)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error adding Infra Container")
+		}
+		pod.state.InfraContainerID = ctr.ID()
+		if err := pod.save(); err != nil {
+			return nil, err
+		}
+	}
+	pod.newPodEvent(ctx, events.Create)
+	return pod, nil
+}
+
+// RestorePod re-creates a pod from the configuration of a pod which was
+// checkpointed and exported with its containers, or backed up.  The pod keeps
+// its ID and name.  Its infra container, whose ID is given, is not created; it
+// has to be restored from its checkpoint or backup like the other containers
+// of the pod.
+func (r *Runtime) RestorePod(ctx context.Context, config *PodConfig, infraID string) (*Pod, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	pod := newPod(r)
+	pod.config = config
+	pod.state.InfraContainerID = infraID
+
+	if err := r.setupPod(pod); err != nil {
+		return nil, err
+	}
+	pod.newPodEvent(ctx, events.Create)
+	return pod, nil
+}
+
+// ClonePod creates a new pod from the configuration of an existing pod, and
+// clones the containers of the source pod into it.  The clone gets a new ID, a
+// new name unless set in the options, and a new infra container created from
+// the infra configuration of the source pod.  Static IP and MAC addresses and
+// published ports are not copied as they would conflict with the source pod.
+// The options are applied on top of the copied configuration.
+// The cloned containers join the namespaces of the new infra container, and
+// their dependencies on other containers of the source pod are changed to the
+// corresponding clones.  If memberOptions is not nil, the options it returns
+// for a container of the source pod are applied to its clone.
+func (r *Runtime) ClonePod(ctx context.Context, source *Pod, memberOptions func(ctr *Container) []CtrCreateOption, options ...PodCreateOption) (_ *Pod, deferredErr error) {
+	config := new(PodConfig)
+	if err := JSONDeepCopy(source.config, config); err != nil {
+		return nil, errors.Wrapf(err, "error copying configuration of pod %s", source.ID())
+	}
+	config.Name = ""
+	if config.Hostname == source.config.Name {
+		// The hostname defaults to the name of the pod.
+		config.Hostname = ""
+	}
+	config.CreateCommand = nil
+	config.InfraContainer.InfraName = ""
+	config.InfraContainer.StaticIP = nil
+	config.InfraContainer.StaticIPv6 = nil
+	config.InfraContainer.StaticMAC = nil
+	config.InfraContainer.PortBindings = nil
+	if strings.HasPrefix(config.InfraContainer.ConmonPidFile, r.storageConfig.RunRoot) {
+		config.InfraContainer.ConmonPidFile = ""
+	}
+
+	sourceCtrs, err := source.AllContainers()
+	if err != nil {
+		return nil, err
+	}
+	// Clone the containers in the order they were created in, so the
+	// containers they depend on are cloned first.
+	sort.Slice(sourceCtrs, func(i, j int) bool {
+		return sourceCtrs[i].config.CreatedTime.Before(sourceCtrs[j].config.CreatedTime)
+	})
+	sourceCgroup, err := source.CgroupPath()
+	if err != nil {
+		return nil, err
+	}
+
+	copyConfig := func(pod *Pod) error {
+		config.ID = pod.config.ID
+		config.CreatedTime = pod.config.CreatedTime
+		config.Owner = pod.config.Owner
+		pod.config = config
+		return nil
+	}
+	pod, err := r.NewPod(ctx, append([]PodCreateOption{copyConfig}, options...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if deferredErr != nil {
+			if err := r.RemovePod(ctx, pod, true, true); err != nil {
+				logrus.Errorf("Error removing pod %s after failing to clone pod %s: %v", pod.ID(), source.ID(), err)
+			}
+		}
+	}()
+
+	// Maps the IDs of the containers of the source pod to the IDs of their
+	// clones.
+	clones := make(map[string]string, len(sourceCtrs))
+	for _, ctr := range sourceCtrs {
+		if ctr.IsInfra() {
+			infraID, err := pod.InfraContainerID()
+			if err != nil {
+				return nil, err
+			}
+			clones[ctr.ID()] = infraID
+		}
+	}
+	for _, ctr := range sourceCtrs {
+		if ctr.IsInfra() {
+			continue
+		}
+		ctrOptions := []CtrCreateOption{r.withClonedPodMember(pod, sourceCgroup, clones)}
+		if memberOptions != nil {
+			ctrOptions = append(ctrOptions, memberOptions(ctr)...)
+		}
+		clone, err := r.CloneContainer(ctx, ctr, ctrOptions...)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error cloning container %s of pod %s", ctr.ID(), source.ID())
+		}
+		clones[ctr.ID()] = clone.ID()
+	}
+
+	return pod, nil
+}
+
+// withClonedPodMember moves the clone of a container of a pod into pod, the
+// clone of the pod.  The containers the clone depends on are replaced by their
+// clones, as given in clones.
+func (r *Runtime) withClonedPodMember(pod *Pod, sourceCgroup string, clones map[string]string) CtrCreateOption {
+	return func(ctr *Container) error {
+		ctr.config.Pod = pod.ID()
+		if sourceCgroup != "" && ctr.config.CgroupParent == sourceCgroup {
+			ctr.config.CgroupParent = ""
+		}
+
+		// A container joining the IPC namespace of another container
+		// uses its shm directory.
+		if clone, ok := clones[ctr.config.IPCNsCtr]; ok && ctr.config.ShmDir != "" {
+			ipcCtr, err := r.state.Container(clone)
+			if err != nil {
+				return errors.Wrapf(err, "error retrieving container %s whose IPC namespace container %s joins", clone, ctr.ID())
+			}
+			ctr.config.ShmDir = ipcCtr.config.ShmDir
+		}
+
+		for _, nsCtr := range []*string{&ctr.config.IPCNsCtr, &ctr.config.MountNsCtr, &ctr.config.NetNsCtr, &ctr.config.PIDNsCtr, &ctr.config.UserNsCtr, &ctr.config.UTSNsCtr, &ctr.config.CgroupNsCtr} {
+			if clone, ok := clones[*nsCtr]; ok {
+				*nsCtr = clone
+			}
+		}
+		for i, dep := range ctr.config.Dependencies {
+			if clone, ok := clones[dep]; ok {
+				ctr.config.Dependencies[i] = clone
+			}
+		}
+		return nil
+	}
+}
+
+// setupPod allocates a lock for the configured pod, sets up its cgroup parent
+// and adds it to the state.
+func (r *Runtime) setupPod(pod *Pod) (deferredErr error) {
 	// Allocate a lock for the pod
 	lock, err := r.lockManager.AllocateLock()
 	if err != nil {
-		return nil, errors.Wrapf(err, "error allocating lock for new pod")
+		return errors.Wrapf(err, "error allocating lock for new pod")
 	}
 	pod.lock = lock
 	pod.config.LockID = pod.lock.ID()
@@ -72,20 +253,40 @@ func (r *Runtime) NewPod(ctx context.Context, options ...PodCreateOption) (_ *Po
 
 	pod.valid = true
 
+	if pod.config.ResourceLimits != nil {
+		if !pod.config.UsePodCgroup {
+			return errors.Wrapf(define.ErrInvalidArg, "resource limits can only be set on pods with a pod cgroup")
+		}
+		if rootless.IsRootless() {
+			cgroup2, err := cgroups.IsCgroup2UnifiedMode()
+			if err != nil {
+				return err
+			}
+			if !cgroup2 || r.config.Engine.CgroupManager != config.SystemdCgroupsManager {
+				return errors.Wrapf(define.ErrRootless, "resource limits of pods require cgroups v2 and the systemd cgroup manager")
+			}
+		}
+	}
+
 	// Check CGroup parent sanity, and set it if it was not set
 	switch r.config.Engine.CgroupManager {
 	case config.CgroupfsCgroupsManager:
 		if pod.config.CgroupParent == "" {
 			pod.config.CgroupParent = CgroupfsDefaultCgroupParent
 		} else if strings.HasSuffix(path.Base(pod.config.CgroupParent), ".slice") {
-			return nil, errors.Wrapf(define.ErrInvalidArg, "systemd slice received as cgroup parent when using cgroupfs")
+			return errors.Wrapf(define.ErrInvalidArg, "systemd slice received as cgroup parent when using cgroupfs")
 		}
 		// If we are set to use pod cgroups, set the cgroup parent that
 		// all containers in the pod will share
 		// No need to create it with cgroupfs - the first container to
-		// launch should do it for us
+		// launch should do it for us - unless it has resource limits
 		if pod.config.UsePodCgroup {
 			pod.state.CgroupPath = filepath.Join(pod.config.CgroupParent, pod.ID())
+			if pod.config.ResourceLimits != nil {
+				if err := makeCgroupfsCgroup(pod.state.CgroupPath, pod.config.ResourceLimits); err != nil {
+					return errors.Wrapf(err, "unable to create pod cgroup for pod %s", pod.ID())
+				}
+			}
 		}
 	case config.SystemdCgroupsManager:
 		if pod.config.CgroupParent == "" {
@@ -95,54 +296,35 @@ func (r *Runtime) NewPod(ctx context.Context, options ...PodCreateOption) (_ *Po
 				pod.config.CgroupParent = SystemdDefaultCgroupParent
 			}
 		} else if len(pod.config.CgroupParent) < 6 || !strings.HasSuffix(path.Base(pod.config.CgroupParent), ".slice") {
-			return nil, errors.Wrapf(define.ErrInvalidArg, "did not receive systemd slice as cgroup parent when using systemd to manage cgroups")
+			return errors.Wrapf(define.ErrInvalidArg, "did not receive systemd slice as cgroup parent when using systemd to manage cgroups")
 		}
 		// If we are set to use pod cgroups, set the cgroup parent that
 		// all containers in the pod will share
 		if pod.config.UsePodCgroup {
-			cgroupPath, err := systemdSliceFromPath(pod.config.CgroupParent, fmt.Sprintf("libpod_pod_%s", pod.ID()))
+			cgroupPath, err := systemdSliceFromPath(pod.config.CgroupParent, fmt.Sprintf("libpod_pod_%s", pod.ID()), pod.config.ResourceLimits)
 			if err != nil {
-				return nil, errors.Wrapf(err, "unable to create pod cgroup for pod %s", pod.ID())
+				return errors.Wrapf(err, "unable to create pod cgroup for pod %s", pod.ID())
 			}
 			pod.state.CgroupPath = cgroupPath
 		}
 	default:
-		return nil, errors.Wrapf(define.ErrInvalidArg, "unsupported CGroup manager: %s - cannot validate cgroup parent", r.config.Engine.CgroupManager)
+		return errors.Wrapf(define.ErrInvalidArg, "unsupported CGroup manager: %s - cannot validate cgroup parent", r.config.Engine.CgroupManager)
 	}
 
 	if pod.config.UsePodCgroup {
 		logrus.Debugf("Got pod cgroup as %s", pod.state.CgroupPath)
 	}
 	if !pod.HasInfraContainer() && pod.SharesNamespaces() {
-		return nil, errors.Errorf("Pods must have an infra container to share namespaces")
+		logrus.Debugf("Pod has no infra container, the first container created in the pod will hold the shared namespaces")
 	}
 	if pod.HasInfraContainer() && !pod.SharesNamespaces() {
 		logrus.Infof("Pod has an infra container, but shares no namespaces")
 	}
 
 	if err := r.state.AddPod(pod); err != nil {
-		return nil, errors.Wrapf(err, "error adding pod to state")
+		return errors.Wrapf(err, "error adding pod to state")
 	}
-	defer func() {
-		if deferredErr != nil {
-			if err := r.removePod(ctx, pod, true, true); err != nil {
-				logrus.Errorf("Error removing pod after pause container creation failure: %v", err)
-			}
-		}
-	}()
-
-	if pod.HasInfraContainer() {
-		ctr, err := r.createInfraContainer(ctx, pod)
-		if err != nil {
-			return nil, errors.Wrapf(err, "error adding Infra Container")
-		}
-		pod.state.InfraContainerID = ctr.ID()
-		if err := pod.save(); err != nil {
-			return nil, err
-		}
-	}
-	pod.newPodEvent(events.Create)
-	return pod, nil
+	return nil
 }
 
 func (r *Runtime) removePod(ctx context.Context, p *Pod, removeCtrs, force bool) error {
@@ -150,6 +332,10 @@ func (r *Runtime) removePod(ctx context.Context, p *Pod, removeCtrs, force bool)
 		return err
 	}
 
+	if err := r.checkOwner(p.config.Owner, "pod "+p.ID()); err != nil {
+		return err
+	}
+
 	ctrs, err := r.state.PodContainers(p)
 	if err != nil {
 		return err
@@ -259,7 +445,10 @@ func (r *Runtime) removePod(ctx context.Context, p *Pod, removeCtrs, force bool)
 			continue
 		}
 		if err := r.removeVolume(ctx, volume, false); err != nil {
-			if errors.Cause(err) == define.ErrNoSuchVolume || errors.Cause(err) == define.ErrVolumeRemoved {
+			// An anonymous volume may be shared with the
+			// containers of another pod, e.g. the volume of a
+			// Secret used by several pods.
+			if errors.Cause(err) == define.ErrNoSuchVolume || errors.Cause(err) == define.ErrVolumeRemoved || errors.Cause(err) == define.ErrVolumeBeingUsed {
 				continue
 			}
 			logrus.Errorf("Error removing volume %s: %v", volName, err)
@@ -341,7 +530,7 @@ func (r *Runtime) removePod(ctx context.Context, p *Pod, removeCtrs, force bool)
 
 	// Mark pod invalid
 	p.valid = false
-	p.newPodEvent(events.Remove)
+	p.newPodEvent(ctx, events.Remove)
 
 	// Deallocate the pod lock
 	if err := p.lock.Free(); err != nil {
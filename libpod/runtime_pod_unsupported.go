@@ -13,6 +13,16 @@ func (r *Runtime) NewPod(ctx context.Context, options ...PodCreateOption) (*Pod,
 	return nil, define.ErrOSNotSupported
 }
 
+// RestorePod re-creates a checkpointed pod
+func (r *Runtime) RestorePod(ctx context.Context, config *PodConfig, infraID string) (*Pod, error) {
+	return nil, define.ErrOSNotSupported
+}
+
+// ClonePod creates a new pod from the configuration of an existing pod
+func (r *Runtime) ClonePod(ctx context.Context, source *Pod, memberOptions func(ctr *Container) []CtrCreateOption, options ...PodCreateOption) (*Pod, error) {
+	return nil, define.ErrOSNotSupported
+}
+
 func (r *Runtime) removePod(ctx context.Context, p *Pod, removeCtrs, force bool) error {
 	return define.ErrOSNotSupported
 }
@@ -0,0 +1,101 @@
+package libpod
+
+import (
+	"context"
+
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/pkg/errors"
+)
+
+// StartRestartPolicyContainers starts all containers with the always restart
+// policy, all containers with the unless-stopped restart policy that were
+// not stopped by the user, and all containers with the on-failure restart
+// policy that were exited by the reboot, as is done after a system restart.
+// The containers they depend on are started too, and all containers are
+// started in the order dictated by their dependencies.
+// The IDs of the containers that were not running are returned, mapped to the
+// error encountered starting them, if any.
+func (r *Runtime) StartRestartPolicyContainers(ctx context.Context) (map[string]error, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	allCtrs, err := r.state.AllContainersWithState()
+	if err != nil {
+		return nil, err
+	}
+	ctrsByID := make(map[string]*Container, len(allCtrs))
+	for _, ctr := range allCtrs {
+		ctrsByID[ctr.ID()] = ctr
+	}
+
+	report := make(map[string]error)
+	selected := make(map[string]*Container)
+	var addWithDeps func(ctr *Container) error
+	addWithDeps = func(ctr *Container) error {
+		if _, ok := selected[ctr.ID()]; ok {
+			return nil
+		}
+		for _, depID := range ctr.Dependencies() {
+			dep, ok := ctrsByID[depID]
+			if !ok {
+				return errors.Wrapf(define.ErrNoSuchCtr, "container %s depends on container %s which does not exist", ctr.ID(), depID)
+			}
+			if err := addWithDeps(dep); err != nil {
+				return err
+			}
+		}
+		selected[ctr.ID()] = ctr
+		return nil
+	}
+	for _, ctr := range allCtrs {
+		switch ctr.config.RestartPolicy {
+		case RestartPolicyAlways:
+		case RestartPolicyUnlessStopped:
+			if ctr.state.StoppedByUser {
+				continue
+			}
+		case RestartPolicyOnFailure:
+			if !ctr.state.ExitedByReboot {
+				continue
+			}
+		default:
+			continue
+		}
+		if err := addWithDeps(ctr); err != nil {
+			report[ctr.ID()] = err
+		}
+	}
+	if len(selected) == 0 {
+		return report, nil
+	}
+
+	ctrs := make([]*Container, 0, len(selected))
+	for _, ctr := range selected {
+		// Containers that are already running are left alone by the
+		// graph traversal and not reported.
+		if ctr.state.State != define.ContainerStateRunning && ctr.state.State != define.ContainerStatePaused && !ctr.IsInitCtr() {
+			report[ctr.ID()] = nil
+		}
+		ctrs = append(ctrs, ctr)
+	}
+
+	graph, err := BuildContainerGraph(ctrs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error generating dependency graph of containers to start")
+	}
+
+	ctrErrors := make(map[string]error)
+	ctrsVisited := make(map[string]bool)
+	for _, node := range graph.noDepNodes {
+		startNode(ctx, node, false, ctrErrors, ctrsVisited, false)
+	}
+	for id, err := range ctrErrors {
+		report[id] = err
+	}
+
+	return report, nil
+}
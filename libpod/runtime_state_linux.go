@@ -0,0 +1,184 @@
+// +build linux
+
+package libpod
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/containers/podman/v2/libpod/network"
+	"github.com/containers/podman/v2/pkg/rootless"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// relocateState moves the mutable state of the runtime below the directories
+// set by root and runroot in the [state] table of containers.conf, for hosts
+// with a read-only root file system.  Paths set explicitly through runtime
+// options are kept.  Rootless Podman keeps its state in the home directory of
+// the user and ignores the table.
+func (r *Runtime) relocateState() error {
+	root, runRoot := r.extraConfig.StateRoot, r.extraConfig.StateRunRoot
+	if root == "" && runRoot == "" {
+		return nil
+	}
+	if rootless.IsRootless() {
+		logrus.Debugf("Ignoring the [state] table of containers.conf in rootless mode")
+		return nil
+	}
+
+	c := &r.config.Engine
+	if root != "" {
+		if !r.storageSet.GraphRootSet {
+			r.storageConfig.GraphRoot = filepath.Join(root, "storage")
+			r.storageSet.GraphRootSet = true
+		}
+		if !r.storageSet.StaticDirSet {
+			c.StaticDir = filepath.Join(root, "libpod")
+			r.storageSet.StaticDirSet = true
+		}
+		if !r.storageSet.VolumePathSet {
+			c.VolumePath = filepath.Join(root, "volumes")
+			r.storageSet.VolumePathSet = true
+		}
+		// Only the default CNI configuration directory is relocated, a
+		// custom one is expected to be writable.
+		if confDir := r.config.Network.NetworkConfigDir; confDir == "" || filepath.Clean(confDir) == network.CNIConfigDir {
+			relocated := filepath.Join(root, "cni", "net.d")
+			if err := seedNetworkConfigDir(network.CNIConfigDir, relocated, r.cniDataDir()); err != nil {
+				return err
+			}
+			r.config.Network.NetworkConfigDir = relocated
+		}
+	}
+	if runRoot != "" {
+		if !r.storageSet.RunRootSet {
+			r.storageConfig.RunRoot = filepath.Join(runRoot, "storage")
+			r.storageSet.RunRootSet = true
+		}
+		if !r.storageSet.TmpDirSet {
+			c.TmpDir = filepath.Join(runRoot, "libpod")
+			c.EventsLogFilePath = filepath.Join(c.TmpDir, "events", "events.log")
+			r.storageSet.TmpDirSet = true
+		}
+	}
+	return nil
+}
+
+// seedNetworkConfigDir creates the relocated CNI configuration directory dest
+// with a copy of the network configurations in src, so that the networks
+// installed with Podman, like the default one, are still found.  The address
+// allocations of the host-local IPAM plugin of the copies are kept in dataDir,
+// as the default directory may be read-only too.  Nothing is done if dest
+// exists.
+func seedNetworkConfigDir(src, dest, dataDir string) error {
+	if _, err := os.Stat(dest); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return errors.Wrapf(err, "error creating CNI configuration directory %s", dest)
+	}
+	files, err := filepath.Glob(filepath.Join(src, "*.conflist"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		content, err = relocateIPAMDataDir(content, dataDir)
+		if err != nil {
+			return errors.Wrapf(err, "error parsing CNI configuration %s", file)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dest, filepath.Base(file)), content, 0644); err != nil {
+			return errors.Wrapf(err, "error copying CNI configuration %s to %s", file, dest)
+		}
+	}
+	return nil
+}
+
+// relocateIPAMDataDir sets the data directory of the host-local IPAM plugins
+// of the given CNI configuration list to dataDir.
+func relocateIPAMDataDir(content []byte, dataDir string) ([]byte, error) {
+	var conflist map[string]interface{}
+	if err := json.Unmarshal(content, &conflist); err != nil {
+		return nil, err
+	}
+	plugins, _ := conflist["plugins"].([]interface{})
+	relocated := false
+	for _, plugin := range plugins {
+		pluginConf, _ := plugin.(map[string]interface{})
+		ipam, _ := pluginConf["ipam"].(map[string]interface{})
+		if ipam["type"] != "host-local" {
+			continue
+		}
+		ipam["dataDir"] = dataDir
+		relocated = true
+	}
+	if !relocated {
+		return content, nil
+	}
+	return json.MarshalIndent(conflist, "", "   ")
+}
+
+// cniDataDir returns the directory holding the address allocations of the
+// host-local IPAM plugin for the networks created by Podman.
+func (r *Runtime) cniDataDir() string {
+	if r.extraConfig.StateRoot != "" && !rootless.IsRootless() {
+		return filepath.Join(r.extraConfig.StateRoot, "cni", "networks")
+	}
+	return "/var/lib/cni/networks"
+}
+
+// checkStateWritable returns an error naming the first path of the mutable
+// state of the runtime that is on a read-only file system.  Paths that do not
+// exist yet are checked against their nearest existing parent directory.
+func (r *Runtime) checkStateWritable() error {
+	paths := []struct {
+		name string
+		path string
+	}{
+		{"graph root", r.storageConfig.GraphRoot},
+		{"run root", r.storageConfig.RunRoot},
+		{"static dir", r.config.Engine.StaticDir},
+		{"tmp dir", r.config.Engine.TmpDir},
+		{"volume path", r.config.Engine.VolumePath},
+	}
+	if !rootless.IsRootless() {
+		paths = append(paths, struct {
+			name string
+			path string
+		}{"CNI configuration directory", network.GetCNIConfDir(r.config)}, struct {
+			name string
+			path string
+		}{"CNI IPAM data directory", r.cniDataDir()})
+	}
+	for _, p := range paths {
+		if p.path == "" {
+			continue
+		}
+		if onReadOnlyFS(p.path) {
+			return errors.Errorf("the %s %s is on a read-only file system: relocate it with the [state] table of containers.conf", p.name, p.path)
+		}
+	}
+	return nil
+}
+
+// onReadOnlyFS returns whether path, or its nearest existing parent directory,
+// is on a read-only file system.
+func onReadOnlyFS(path string) bool {
+	for {
+		if _, err := os.Stat(path); err == nil {
+			break
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			break
+		}
+		path = parent
+	}
+	return unix.Access(path, unix.W_OK) == unix.EROFS
+}
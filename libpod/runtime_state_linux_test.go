@@ -0,0 +1,73 @@
+package libpod
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeedNetworkConfigDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cni")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	src := filepath.Join(dir, "src")
+	require.NoError(t, os.Mkdir(src, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(src, "87-podman-bridge.conflist"), []byte("{}"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(src, "README"), []byte("ignored"), 0644))
+
+	dest := filepath.Join(dir, "state", "cni", "net.d")
+	dataDir := filepath.Join(dir, "state", "cni", "networks")
+	require.NoError(t, seedNetworkConfigDir(src, dest, dataDir))
+	files, err := ioutil.ReadDir(dest)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "87-podman-bridge.conflist", files[0].Name())
+
+	// An existing directory is left alone
+	require.NoError(t, ioutil.WriteFile(filepath.Join(src, "88-other.conflist"), []byte("{}"), 0644))
+	require.NoError(t, seedNetworkConfigDir(src, dest, dataDir))
+	files, err = ioutil.ReadDir(dest)
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+}
+
+func TestRelocateIPAMDataDir(t *testing.T) {
+	conflist := `{"cniVersion":"0.4.0","name":"podman","plugins":[{"type":"bridge","ipam":{"type":"host-local","ranges":[[{"subnet":"10.88.0.0/16"}]]}},{"type":"portmap"}]}`
+	content, err := relocateIPAMDataDir([]byte(conflist), "/state/cni/networks")
+	require.NoError(t, err)
+	var relocated struct {
+		Name    string `json:"name"`
+		Plugins []struct {
+			Type string `json:"type"`
+			IPAM struct {
+				Type    string `json:"type"`
+				DataDir string `json:"dataDir"`
+			} `json:"ipam"`
+		} `json:"plugins"`
+	}
+	require.NoError(t, json.Unmarshal(content, &relocated))
+	assert.Equal(t, "podman", relocated.Name)
+	require.Len(t, relocated.Plugins, 2)
+	assert.Equal(t, "/state/cni/networks", relocated.Plugins[0].IPAM.DataDir)
+	assert.Equal(t, "", relocated.Plugins[1].IPAM.DataDir)
+
+	// A configuration without host-local IPAM is copied as is
+	content, err = relocateIPAMDataDir([]byte("{}"), "/state/cni/networks")
+	require.NoError(t, err)
+	assert.Equal(t, "{}", string(content))
+
+	_, err = relocateIPAMDataDir([]byte("{"), "/state/cni/networks")
+	assert.Error(t, err)
+}
+
+func TestOnReadOnlyFS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "state")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert.False(t, onReadOnlyFS(dir))
+	assert.False(t, onReadOnlyFS(filepath.Join(dir, "does", "not", "exist")))
+}
@@ -0,0 +1,13 @@
+// +build !linux
+
+package libpod
+
+// relocateState is a no-op on systems other than Linux.
+func (r *Runtime) relocateState() error {
+	return nil
+}
+
+// checkStateWritable is a no-op on systems other than Linux.
+func (r *Runtime) checkStateWritable() error {
+	return nil
+}
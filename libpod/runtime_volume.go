@@ -6,6 +6,7 @@ import (
 	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/libpod/events"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 // Contains the public Runtime API for volumes
@@ -132,23 +133,86 @@ func (r *Runtime) GetAllVolumes() ([]*Volume, error) {
 	return r.state.AllVolumes()
 }
 
-// PruneVolumes removes unused volumes from the system
-func (r *Runtime) PruneVolumes(ctx context.Context, filterFuncs []VolumeFilter) (map[string]error, error) {
-	reports := make(map[string]error)
+// PrunedVolume describes a volume removed by PruneVolumes, or the error
+// removing it.
+type PrunedVolume struct {
+	// Name of the volume.
+	Name string
+	// Size is the disk space used by the contents of the volume in bytes.
+	Size uint64
+	// Containers lists the stopped containers which used the volume and
+	// were removed with it.
+	Containers []string
+	// Err is the error removing the volume, if any.
+	Err error
+}
+
+// PruneVolumes removes unused volumes from the system. Volumes used by
+// containers are kept, unless forceUnused is set and none of these containers
+// is running or paused, in which case the containers are removed with the
+// volume.
+func (r *Runtime) PruneVolumes(ctx context.Context, filterFuncs []VolumeFilter, forceUnused bool) ([]*PrunedVolume, error) {
 	vols, err := r.Volumes(filterFuncs...)
 	if err != nil {
 		return nil, err
 	}
 
+	reports := make([]*PrunedVolume, 0, len(vols))
 	for _, vol := range vols {
-		if err := r.RemoveVolume(ctx, vol, false); err != nil {
+		users, err := vol.VolumeInUse()
+		if err != nil {
+			if errors.Cause(err) != define.ErrVolumeRemoved {
+				reports = append(reports, &PrunedVolume{Name: vol.Name(), Err: err})
+			}
+			continue
+		}
+		if len(users) > 0 {
+			if !forceUnused {
+				continue
+			}
+			stopped, err := r.containersStopped(users)
+			if err != nil {
+				reports = append(reports, &PrunedVolume{Name: vol.Name(), Err: err})
+				continue
+			}
+			if !stopped {
+				continue
+			}
+		}
+		size, err := vol.diskUsage()
+		if err != nil {
+			logrus.Debugf("Error computing the disk usage of volume %s: %v", vol.Name(), err)
+		}
+		if err := r.RemoveVolume(ctx, vol, len(users) > 0); err != nil {
 			if errors.Cause(err) != define.ErrVolumeBeingUsed && errors.Cause(err) != define.ErrVolumeRemoved {
-				reports[vol.Name()] = err
+				reports = append(reports, &PrunedVolume{Name: vol.Name(), Err: err})
 			}
 			continue
 		}
-		vol.newVolumeEvent(events.Prune)
-		reports[vol.Name()] = nil
+		vol.newVolumeEvent(ctx, events.Prune)
+		reports = append(reports, &PrunedVolume{Name: vol.Name(), Size: size, Containers: users})
 	}
 	return reports, nil
 }
+
+// containersStopped returns whether none of the given containers is running
+// or paused.
+func (r *Runtime) containersStopped(ids []string) (bool, error) {
+	for _, id := range ids {
+		ctr, err := r.state.Container(id)
+		if err != nil {
+			if errors.Cause(err) == define.ErrNoSuchCtr || errors.Cause(err) == define.ErrCtrRemoved {
+				continue
+			}
+			return false, err
+		}
+		state, err := ctr.State()
+		if err != nil {
+			return false, err
+		}
+		if state == define.ContainerStateRunning || state == define.ContainerStatePaused {
+			return false, nil
+		}
+	}
+	return true, nil
+}
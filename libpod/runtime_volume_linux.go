@@ -11,9 +11,12 @@ import (
 
 	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/libpod/events"
+	"github.com/containers/podman/v2/pkg/rootless"
+	"github.com/containers/storage/drivers/quota"
 	"github.com/containers/storage/pkg/stringid"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
 )
 
 // NewVolume creates a new empty volume
@@ -58,13 +61,27 @@ func (r *Runtime) newVolume(ctx context.Context, options ...VolumeCreateOption)
 		// Validate options
 		for key := range volume.config.Options {
 			switch key {
-			case "device", "o", "type", "UID", "GID":
+			case "device", "o", "type", "UID", "GID", "SIZE":
 				// Do nothing, valid keys
 			default:
 				return nil, errors.Wrapf(define.ErrInvalidArg, "invalid mount option %s for driver 'local'", key)
 			}
 		}
 	}
+	// The owner of a mounted filesystem is set by its mount options.
+	if volume.needsMount() {
+		volume.state.NeedsChown = false
+	}
+
+	// Check that size quotas are available before creating the volume
+	// directories, so that an unsupported size leaves nothing behind.
+	var quotaControl *quota.Control
+	if volume.config.Size > 0 {
+		quotaControl, err = r.volumeQuotaControl()
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	// Create the mountpoint of this volume
 	volPathRoot := filepath.Join(r.config.Engine.VolumePath, volume.config.Name)
@@ -81,6 +98,14 @@ func (r *Runtime) newVolume(ctx context.Context, options ...VolumeCreateOption)
 	if err := os.Chown(fullVolPath, volume.config.UID, volume.config.GID); err != nil {
 		return nil, errors.Wrapf(err, "error chowning volume directory %q to %d:%d", fullVolPath, volume.config.UID, volume.config.GID)
 	}
+	if quotaControl != nil {
+		if err := quotaControl.SetQuota(fullVolPath, quota.Quota{Size: volume.config.Size}); err != nil {
+			if rmErr := os.RemoveAll(volPathRoot); rmErr != nil {
+				logrus.Errorf("Error removing volume directory %q after failing to set its size quota: %v", volPathRoot, rmErr)
+			}
+			return nil, errors.Wrapf(err, "error setting size quota of volume directory %q", fullVolPath)
+		}
+	}
 	if err := LabelVolumePath(fullVolPath); err != nil {
 		return nil, err
 	}
@@ -107,10 +132,45 @@ func (r *Runtime) newVolume(ctx context.Context, options ...VolumeCreateOption)
 	if err := r.state.AddVolume(volume); err != nil {
 		return nil, errors.Wrapf(err, "error adding volume to state")
 	}
-	defer volume.newVolumeEvent(events.Create)
+	defer volume.newVolumeEvent(ctx, events.Create)
 	return volume, nil
 }
 
+// volumeQuotaControl returns the project quota control of the volume path.
+// Project quotas require root privileges and an XFS or ext4 filesystem with
+// project quotas enabled. The returned errors explain which of these
+// requirements is not met.
+func (r *Runtime) volumeQuotaControl() (*quota.Control, error) {
+	volumePath := r.config.Engine.VolumePath
+	if rootless.IsRootless() {
+		return nil, errors.Wrapf(define.ErrInvalidArg, "volume size quotas require root privileges")
+	}
+
+	var fs unix.Statfs_t
+	if err := unix.Statfs(volumePath, &fs); err != nil {
+		return nil, errors.Wrapf(err, "error examining filesystem of volume path %q", volumePath)
+	}
+	var fsName string
+	switch fs.Type {
+	case unix.XFS_SUPER_MAGIC:
+		fsName = "XFS"
+	case unix.EXT4_SUPER_MAGIC:
+		fsName = "ext4"
+	default:
+		return nil, errors.Wrapf(define.ErrInvalidArg, "volume size quotas require the volume path %q to be on an XFS or ext4 filesystem (filesystem type 0x%x)", volumePath, fs.Type)
+	}
+
+	q, err := quota.NewControl(volumePath)
+	if err != nil {
+		hint := "mount it with the prjquota option"
+		if fsName == "ext4" {
+			hint = "enable the project and quota features with tune2fs -O project,quota and mount it with the prjquota option"
+		}
+		return nil, errors.Wrapf(err, "volume size quotas are not available on the %s filesystem of volume path %q, %s", fsName, volumePath, hint)
+	}
+	return q, nil
+}
+
 // removeVolume removes the specified volume from state as well tears down its mountpoint and storage
 func (r *Runtime) removeVolume(ctx context.Context, v *Volume, force bool) error {
 	if !v.valid {
@@ -200,7 +260,7 @@ func (r *Runtime) removeVolume(ctx context.Context, v *Volume, force bool) error
 		}
 	}
 
-	defer v.newVolumeEvent(events.Remove)
+	defer v.newVolumeEvent(ctx, events.Remove)
 	logrus.Debugf("Removed volume %s", v.Name())
 	return removalErr
 }
@@ -1,5 +1,7 @@
 package libpod
 
+import "github.com/containers/podman/v2/libpod/define"
+
 // State is a storage backend for libpod's current state.
 // A State is only initialized once per instance of libpod.
 // As such, initialization methods for State implementations may safely assume
@@ -73,6 +75,12 @@ type State interface {
 	// All containers this container depends on must be part of the same
 	// namespace and must not be joined to a pod.
 	AddContainer(ctr *Container) error
+	// Adds multiple containers to state at once.
+	// Either all or none of the containers are added.
+	// The same restrictions as for AddContainer apply to each container.
+	// Containers may depend on containers added before them in the same
+	// call.
+	AddContainers(ctrs []*Container) error
 	// Removes container from state.
 	// Containers that are part of pods must use RemoveContainerFromPod.
 	// The container must be part of the set namespace.
@@ -97,6 +105,11 @@ type State interface {
 	// If a namespace is set, only containers within the namespace will be
 	// returned.
 	AllContainers() ([]*Container, error)
+	// Retrieves all containers presently in state including their state,
+	// which is read in a single transaction.
+	// If a namespace is set, only containers within the namespace will be
+	// returned.
+	AllContainersWithState() ([]*Container, error)
 
 	// Get networks the container is currently connected to.
 	GetNetworks(ctr *Container) ([]string, error)
@@ -155,12 +168,23 @@ type State interface {
 	// answer is this: use this only very sparingly, and only if you really
 	// know what you're doing.
 	RewriteContainerConfig(ctr *Container, newCfg *ContainerConfig) error
+	// This is a variant of RewriteContainerConfig which also changes the
+	// name of the container from oldName to newName.  The name is changed
+	// in all indexes of the state atomically.  If newName is already in
+	// use by another container or pod, an error is returned and nothing
+	// is changed.
+	SafeRewriteContainerConfig(ctr *Container, oldName, newName string, newCfg *ContainerConfig) error
 	// PLEASE READ THE DESCRIPTION FOR RewriteContainerConfig BEFORE USING.
 	// This function is identical to RewriteContainerConfig, save for the
 	// fact that it is used with pods instead.
 	// It is subject to the same conditions as RewriteContainerConfig.
 	// Please do not use this unless you know what you're doing.
 	RewritePodConfig(pod *Pod, newCfg *PodConfig) error
+	// This is a variant of RewritePodConfig which also changes the name of
+	// the pod from oldName to newName.  The name is changed in all indexes
+	// of the state atomically.  If newName is already in use by another
+	// pod or container, an error is returned and nothing is changed.
+	SafeRewritePodConfig(pod *Pod, oldName, newName string, newCfg *PodConfig) error
 	// PLEASE READ THE DESCRIPTION FOR RewriteContainerConfig BEFORE USING.
 	// This function is identical to RewriteContainerConfig, save for the
 	// fact that it is used with volumes instead.
@@ -170,6 +194,19 @@ type State interface {
 	// Please do not use this unless you know what you're doing.
 	RewriteVolumeConfig(volume *Volume, newCfg *VolumeConfig) error
 
+	// Reserve a name for a container or pod to be created later.
+	// The name must not be used by a container or pod, or be reserved
+	// already.  Adding a container or pod with a reserved name, or renaming
+	// one to it, consumes the reservation if the owner of the container or
+	// pod matches the owner of the reservation, and fails otherwise.
+	// Expired reservations are ignored.
+	ReserveName(reservation *define.NameReservation) error
+	// Remove the reservation of a name.  The reservation must be owned by
+	// the given owner, unless it has expired.
+	ReleaseName(name, owner string) error
+	// Retrieve all unexpired name reservations.
+	NameReservations() ([]define.NameReservation, error)
+
 	// Accepts full ID of pod.
 	// If the pod given is not in the set namespace, an error will be
 	// returned.
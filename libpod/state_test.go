@@ -12,6 +12,7 @@ import (
 	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/libpod/lock"
 	"github.com/containers/storage"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -143,6 +144,42 @@ func TestAddAndGetContainerFromMultiple(t *testing.T) {
 	})
 }
 
+func TestAddContainers(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+		testCtr1, err := getTestCtr1(manager)
+		assert.NoError(t, err)
+		testCtr2, err := getTestCtr2(manager)
+		assert.NoError(t, err)
+
+		err = state.AddContainers([]*Container{testCtr1, testCtr2})
+		assert.NoError(t, err)
+
+		retrievedCtr1, err := state.Container(testCtr1.ID())
+		assert.NoError(t, err)
+		testContainersEqual(t, retrievedCtr1, testCtr1, true)
+
+		retrievedCtr2, err := state.Container(testCtr2.ID())
+		assert.NoError(t, err)
+		testContainersEqual(t, retrievedCtr2, testCtr2, true)
+	})
+}
+
+func TestAddContainersDuplicateNameAddsNone(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+		testCtr1, err := getTestCtr1(manager)
+		assert.NoError(t, err)
+		testCtr2, err := getTestContainer(strings.Repeat("2", 32), testCtr1.Name(), manager)
+		assert.NoError(t, err)
+
+		err = state.AddContainers([]*Container{testCtr1, testCtr2})
+		assert.Error(t, err)
+
+		ctrs, err := state.AllContainers()
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(ctrs))
+	})
+}
+
 func TestGetContainerPodSameIDFails(t *testing.T) {
 	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
 		testPod, err := getTestPod1(manager)
@@ -965,6 +1002,28 @@ func TestGetAllContainersTwoContainers(t *testing.T) {
 	})
 }
 
+func TestGetAllContainersWithStateLoadsState(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+		testCtr, err := getTestCtr1(manager)
+		assert.NoError(t, err)
+
+		err = state.AddContainer(testCtr)
+		assert.NoError(t, err)
+
+		testCtr.state.State = define.ContainerStateExited
+		testCtr.state.ExitCode = 42
+		err = state.SaveContainer(testCtr)
+		assert.NoError(t, err)
+
+		ctrs, err := state.AllContainersWithState()
+		assert.NoError(t, err)
+		require.Len(t, ctrs, 1)
+
+		assert.Equal(t, define.ContainerStateExited, ctrs[0].state.State)
+		assert.Equal(t, int32(42), ctrs[0].state.ExitCode)
+	})
+}
+
 func TestGetAllContainersNoContainerInNamespace(t *testing.T) {
 	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
 		testCtr, err := getTestCtr1(manager)
@@ -1413,6 +1472,114 @@ func TestRewriteContainerConfigRewritesConfig(t *testing.T) {
 	})
 }
 
+func TestSafeRewriteContainerConfigRenamesContainer(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+		testCtr, err := getTestCtr1(manager)
+		assert.NoError(t, err)
+
+		err = state.AddContainer(testCtr)
+		assert.NoError(t, err)
+
+		oldName := testCtr.Name()
+		newCfg := *testCtr.config
+		newCfg.Name = "newname"
+
+		err = state.SafeRewriteContainerConfig(testCtr, oldName, newCfg.Name, &newCfg)
+		assert.NoError(t, err)
+
+		ctrFromState, err := state.LookupContainer("newname")
+		assert.NoError(t, err)
+		assert.Equal(t, testCtr.ID(), ctrFromState.ID())
+		assert.Equal(t, "newname", ctrFromState.Name())
+
+		_, err = state.LookupContainer(oldName)
+		assert.Error(t, err)
+	})
+}
+
+func TestSafeRewriteContainerConfigNameInUse(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+		testCtr1, err := getTestCtr1(manager)
+		assert.NoError(t, err)
+		testCtr2, err := getTestCtr2(manager)
+		assert.NoError(t, err)
+
+		err = state.AddContainer(testCtr1)
+		assert.NoError(t, err)
+		err = state.AddContainer(testCtr2)
+		assert.NoError(t, err)
+
+		oldName := testCtr1.Name()
+		newCfg := *testCtr1.config
+		newCfg.Name = testCtr2.Name()
+
+		err = state.SafeRewriteContainerConfig(testCtr1, oldName, newCfg.Name, &newCfg)
+		assert.Error(t, err)
+
+		ctrFromState, err := state.LookupContainer(oldName)
+		assert.NoError(t, err)
+		assert.Equal(t, testCtr1.ID(), ctrFromState.ID())
+
+		ctrFromState, err = state.LookupContainer(testCtr2.Name())
+		assert.NoError(t, err)
+		assert.Equal(t, testCtr2.ID(), ctrFromState.ID())
+	})
+}
+
+func TestSafeRewritePodConfigRenamesPod(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+		testPod, err := getTestPod1(manager)
+		assert.NoError(t, err)
+
+		err = state.AddPod(testPod)
+		assert.NoError(t, err)
+
+		oldName := testPod.Name()
+		newCfg := *testPod.config
+		newCfg.Name = "newname"
+
+		err = state.SafeRewritePodConfig(testPod, oldName, newCfg.Name, &newCfg)
+		assert.NoError(t, err)
+
+		podFromState, err := state.LookupPod("newname")
+		assert.NoError(t, err)
+		assert.Equal(t, testPod.ID(), podFromState.ID())
+		assert.Equal(t, "newname", podFromState.Name())
+
+		_, err = state.LookupPod(oldName)
+		assert.Error(t, err)
+	})
+}
+
+func TestSafeRewritePodConfigNameInUse(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+		testPod1, err := getTestPod1(manager)
+		assert.NoError(t, err)
+		testPod2, err := getTestPod2(manager)
+		assert.NoError(t, err)
+
+		err = state.AddPod(testPod1)
+		assert.NoError(t, err)
+		err = state.AddPod(testPod2)
+		assert.NoError(t, err)
+
+		oldName := testPod1.Name()
+		newCfg := *testPod1.config
+		newCfg.Name = testPod2.Name()
+
+		err = state.SafeRewritePodConfig(testPod1, oldName, newCfg.Name, &newCfg)
+		assert.Error(t, err)
+
+		podFromState, err := state.LookupPod(oldName)
+		assert.NoError(t, err)
+		assert.Equal(t, testPod1.ID(), podFromState.ID())
+
+		podFromState, err = state.LookupPod(testPod2.Name())
+		assert.NoError(t, err)
+		assert.Equal(t, testPod2.ID(), podFromState.ID())
+	})
+}
+
 func TestRewritePodConfigDoesNotExist(t *testing.T) {
 	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
 		err := state.RewritePodConfig(&Pod{}, &PodConfig{})
@@ -3731,3 +3898,117 @@ func TestGetContainerConfigNonExistentIDFails(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestReserveNameUsedNameFails(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+		testCtr, err := getTestCtr1(manager)
+		assert.NoError(t, err)
+
+		err = state.AddContainer(testCtr)
+		assert.NoError(t, err)
+
+		err = state.ReserveName(&define.NameReservation{Name: testCtr.Name(), Owner: "alice", Created: time.Now()})
+		assert.Error(t, err)
+
+		reservations, err := state.NameReservations()
+		assert.NoError(t, err)
+		assert.Empty(t, reservations)
+	})
+}
+
+func TestReserveAndReleaseName(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+		err := state.ReserveName(&define.NameReservation{Name: "web", Owner: "alice", Created: time.Now()})
+		assert.NoError(t, err)
+
+		err = state.ReserveName(&define.NameReservation{Name: "web", Owner: "bob", Created: time.Now()})
+		assert.Equal(t, define.ErrNameReserved, errors.Cause(err))
+
+		reservations, err := state.NameReservations()
+		assert.NoError(t, err)
+		require.Len(t, reservations, 1)
+		assert.Equal(t, "web", reservations[0].Name)
+		assert.Equal(t, "alice", reservations[0].Owner)
+
+		err = state.ReleaseName("web", "bob")
+		assert.Equal(t, define.ErrNameReserved, errors.Cause(err))
+
+		err = state.ReleaseName("web", "alice")
+		assert.NoError(t, err)
+		err = state.ReleaseName("web", "alice")
+		assert.Equal(t, define.ErrNameNotReserved, errors.Cause(err))
+	})
+}
+
+func TestReserveNameExpiredSucceeds(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+		now := time.Now()
+		err := state.ReserveName(&define.NameReservation{Name: "web", Owner: "alice", Created: now, Expires: now.Add(-time.Second)})
+		assert.NoError(t, err)
+
+		reservations, err := state.NameReservations()
+		assert.NoError(t, err)
+		assert.Empty(t, reservations)
+
+		err = state.ReserveName(&define.NameReservation{Name: "web", Owner: "bob", Created: now})
+		assert.NoError(t, err)
+	})
+}
+
+func TestAddContainerNameReservedByOtherOwnerFails(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+		testCtr, err := getTestCtr1(manager)
+		assert.NoError(t, err)
+		testCtr.config.Owner = "alice"
+
+		err = state.ReserveName(&define.NameReservation{Name: testCtr.Name(), Owner: "bob", Created: time.Now()})
+		assert.NoError(t, err)
+
+		err = state.AddContainer(testCtr)
+		assert.Equal(t, define.ErrNameReserved, errors.Cause(err))
+
+		ctrs, err := state.AllContainers()
+		assert.NoError(t, err)
+		assert.Empty(t, ctrs)
+
+		reservations, err := state.NameReservations()
+		assert.NoError(t, err)
+		assert.Len(t, reservations, 1)
+	})
+}
+
+func TestAddContainerConsumesNameReservation(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+		testCtr, err := getTestCtr1(manager)
+		assert.NoError(t, err)
+		testCtr.config.Owner = "alice"
+
+		err = state.ReserveName(&define.NameReservation{Name: testCtr.Name(), Owner: "alice", Created: time.Now()})
+		assert.NoError(t, err)
+
+		err = state.AddContainer(testCtr)
+		assert.NoError(t, err)
+
+		reservations, err := state.NameReservations()
+		assert.NoError(t, err)
+		assert.Empty(t, reservations)
+	})
+}
+
+func TestAddPodNameReservedByOtherOwnerFails(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, manager lock.Manager) {
+		testPod, err := getTestPod1(manager)
+		assert.NoError(t, err)
+		testPod.config.Owner = "alice"
+
+		err = state.ReserveName(&define.NameReservation{Name: testPod.Name(), Owner: "bob", Created: time.Now()})
+		assert.NoError(t, err)
+
+		err = state.AddPod(testPod)
+		assert.Equal(t, define.ErrNameReserved, errors.Cause(err))
+
+		pods, err := state.AllPods()
+		assert.NoError(t, err)
+		assert.Empty(t, pods)
+	})
+}
@@ -69,6 +69,7 @@ func (c *Container) GetContainerStats(previousStats *define.ContainerStats) (*de
 	stats.CPUSystemNano = cgroupStats.CPU.Usage.Kernel
 	stats.SystemNano = now
 	stats.PerCPU = cgroupStats.CPU.Usage.PerCPU
+	stats.OOMKills = c.state.OOMKillCount + cgroupStats.Memory.OOMKills
 	// Handle case where the container is not in a network namespace
 	if netStats != nil {
 		stats.NetInput = netStats.TxBytes
@@ -81,6 +82,44 @@ func (c *Container) GetContainerStats(previousStats *define.ContainerStats) (*de
 	return stats, nil
 }
 
+// cgroupOOMKills returns the number of processes of the current run of the
+// container killed by the OOM killer, as counted by its memory cgroup.
+func (c *Container) cgroupOOMKills() (uint64, error) {
+	if c.config.NoCgroups {
+		return 0, errors.Wrapf(define.ErrNoCgroups, "container %s did not create a cgroup", c.ID())
+	}
+	cgroupPath, err := c.cGroupPath()
+	if err != nil {
+		return 0, err
+	}
+	cgroup, err := cgroups.Load(cgroupPath)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to load cgroup at %s", cgroupPath)
+	}
+	cgroupStats, err := cgroup.Stat()
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to obtain cgroup stats")
+	}
+	return cgroupStats.Memory.OOMKills, nil
+}
+
+// cgroupPids returns the number of processes of the running container and the
+// limit set by its pids cgroup, 0 if there is none.
+func (c *Container) cgroupPids() (uint64, uint64, error) {
+	if c.config.NoCgroups {
+		return 0, 0, errors.Wrapf(define.ErrNoCgroups, "container %s did not create a cgroup", c.ID())
+	}
+	cgroupPath, err := c.cGroupPath()
+	if err != nil {
+		return 0, 0, err
+	}
+	cgroup, err := cgroups.Load(cgroupPath)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "unable to load cgroup at %s", cgroupPath)
+	}
+	return cgroup.Pids()
+}
+
 // getMemory limit returns the memory limit for a given cgroup
 // If the configured memory limit is larger than the total memory on the sys, the
 // physical system memory size is returned
@@ -127,3 +166,36 @@ func calculateBlockIO(stats *cgroups.Metrics) (read uint64, write uint64) {
 	}
 	return
 }
+
+// DropCaches reclaims memory of the running or paused container, mostly page
+// cache, without restarting it.  Up to amount bytes are reclaimed, or as much
+// as possible if amount is 0.
+func (c *Container) DropCaches(amount uint64) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	if !c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+		return errors.Wrapf(define.ErrCtrStateInvalid, "cannot drop the caches of container %s as it is not running or paused", c.ID())
+	}
+	if c.config.NoCgroups {
+		return errors.Wrapf(define.ErrNoCgroups, "container %s did not create a cgroup", c.ID())
+	}
+	cgroupPath, err := c.cGroupPath()
+	if err != nil {
+		return err
+	}
+	cgroup, err := cgroups.Load(cgroupPath)
+	if err != nil {
+		return errors.Wrapf(err, "unable to load cgroup at %s", cgroupPath)
+	}
+	if err := cgroup.ReclaimMemory(amount); err != nil {
+		return errors.Wrapf(err, "error dropping the caches of container %s", c.ID())
+	}
+	return nil
+}
@@ -8,3 +8,17 @@ import "github.com/containers/podman/v2/libpod/define"
 func (c *Container) GetContainerStats(previousStats *define.ContainerStats) (*define.ContainerStats, error) {
 	return nil, define.ErrOSNotSupported
 }
+
+func (c *Container) cgroupOOMKills() (uint64, error) {
+	return 0, define.ErrOSNotSupported
+}
+
+func (c *Container) cgroupPids() (uint64, uint64, error) {
+	return 0, 0, define.ErrOSNotSupported
+}
+
+// DropCaches reclaims memory of the running or paused container, mostly page
+// cache, without restarting it.
+func (c *Container) DropCaches(amount uint64) error {
+	return define.ErrOSNotSupported
+}
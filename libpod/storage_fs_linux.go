@@ -0,0 +1,123 @@
+package libpod
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	graphdriver "github.com/containers/storage/drivers"
+	"github.com/sirupsen/logrus"
+)
+
+// fsMagicCifs is the magic number of CIFS mounts, which is not known to
+// containers/storage.
+const fsMagicCifs = graphdriver.FsMagic(0xFF534D42)
+
+// overlayUnsupportedFS are the file systems the overlay driver does not work
+// on.  The value is true if the file system is supported when using a mount
+// program (e.g., fuse-overlayfs).
+var overlayUnsupportedFS = map[graphdriver.FsMagic]bool{
+	graphdriver.FsMagicNfsFs:    false,
+	graphdriver.FsMagicSmbFs:    false,
+	fsMagicCifs:                 false,
+	graphdriver.FsMagicAufs:     true,
+	graphdriver.FsMagicZfs:      true,
+	graphdriver.FsMagicOverlay:  true,
+	graphdriver.FsMagicEcryptfs: true,
+}
+
+// fsMagicName returns the name of the file system.
+func fsMagicName(magic graphdriver.FsMagic) string {
+	if magic == fsMagicCifs {
+		return "cifs"
+	}
+	if name, ok := graphdriver.FsNames[magic]; ok {
+		return name
+	}
+	return fmt.Sprintf("0x%x", uint32(magic))
+}
+
+// pathFSMagic returns the file system of path or, if it does not exist yet,
+// of its closest existing parent directory.
+func pathFSMagic(path string) (graphdriver.FsMagic, error) {
+	for {
+		magic, err := graphdriver.GetFSMagic(path)
+		if err == nil || !os.IsNotExist(err) {
+			return magic, err
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return magic, err
+		}
+		path = parent
+	}
+}
+
+// overlaySupportedOn returns whether the overlay driver works on the file
+// system.
+func overlaySupportedOn(magic graphdriver.FsMagic, mountProgram bool) bool {
+	withMountProgram, unsupported := overlayUnsupportedFS[magic]
+	return !unsupported || (withMountProgram && mountProgram)
+}
+
+// checkStorageFilesystem checks whether the storage driver supports the file
+// system of the graph root.  If it does not, the fallback graph root from
+// containers.conf or, if not set, the vfs driver is used.  This is only done
+// for new storage where neither the driver nor the graph root have been
+// recorded in the database yet, and if the driver was not set explicitly.
+func (r *Runtime) checkStorageFilesystem(dbConfig *DBConfig) error {
+	driver := r.storageConfig.GraphDriverName
+	if driver != "" && driver != "overlay" && driver != "overlay2" {
+		return nil
+	}
+
+	magic, err := pathFSMagic(r.storageConfig.GraphRoot)
+	if err != nil {
+		logrus.Debugf("Unable to determine the file system of graph root %s: %v", r.storageConfig.GraphRoot, err)
+		return nil
+	}
+	r.graphRootFSType = fsMagicName(magic)
+
+	mountProgram := false
+	for _, option := range r.storageConfig.GraphDriverOptions {
+		if strings.HasSuffix(strings.SplitN(option, "=", 2)[0], "mount_program") {
+			mountProgram = true
+		}
+	}
+	if overlaySupportedOn(magic, mountProgram) {
+		return nil
+	}
+
+	reason := fmt.Sprintf("the overlay storage driver is not supported on %s (graph root %s)", r.graphRootFSType, r.storageConfig.GraphRoot)
+	if r.storageSet.GraphDriverNameSet || dbConfig.GraphDriver != "" || dbConfig.StorageRoot != "" {
+		logrus.Warnf("%s; use another storage driver (e.g., vfs) or graph root, which requires `podman system reset` for existing storage", reason)
+		return nil
+	}
+
+	fallbackGraphRoot := r.extraConfig.FallbackGraphRoot
+	if fallbackGraphRoot != "" && !r.storageSet.GraphRootSet {
+		fallbackMagic, err := pathFSMagic(fallbackGraphRoot)
+		if err == nil && overlaySupportedOn(fallbackMagic, mountProgram) {
+			r.storageFallback = fmt.Sprintf("%s, using graph root %s", reason, fallbackGraphRoot)
+			logrus.Infof("%s", r.storageFallback)
+			r.storageConfig.GraphRoot = fallbackGraphRoot
+			r.graphRootFSType = fsMagicName(fallbackMagic)
+			return nil
+		}
+		logrus.Warnf("The fallback graph root %s is not usable with the overlay storage driver", fallbackGraphRoot)
+	}
+
+	r.storageFallback = fmt.Sprintf("%s, using the vfs storage driver", reason)
+	logrus.Infof("%s", r.storageFallback)
+	r.storageConfig.GraphDriverName = "vfs"
+	// Options of the overlay driver are rejected by vfs.
+	var vfsOptions []string
+	for _, option := range r.storageConfig.GraphDriverOptions {
+		if strings.HasPrefix(option, "vfs.") {
+			vfsOptions = append(vfsOptions, option)
+		}
+	}
+	r.storageConfig.GraphDriverOptions = vfsOptions
+	return nil
+}
@@ -0,0 +1,38 @@
+package libpod
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	graphdriver "github.com/containers/storage/drivers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverlaySupportedOn(t *testing.T) {
+	assert.True(t, overlaySupportedOn(graphdriver.FsMagicXfs, false))
+	assert.True(t, overlaySupportedOn(graphdriver.FsMagicExtfs, false))
+	assert.False(t, overlaySupportedOn(graphdriver.FsMagicNfsFs, false))
+	assert.False(t, overlaySupportedOn(graphdriver.FsMagicNfsFs, true))
+	assert.False(t, overlaySupportedOn(fsMagicCifs, true))
+	assert.False(t, overlaySupportedOn(graphdriver.FsMagicOverlay, false))
+	assert.True(t, overlaySupportedOn(graphdriver.FsMagicOverlay, true))
+}
+
+func TestPathFSMagic(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fsmagic")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	magic, err := pathFSMagic(dir)
+	require.NoError(t, err)
+	// A path that does not exist yet is on the file system of its parent.
+	missing, err := pathFSMagic(filepath.Join(dir, "does", "not", "exist"))
+	require.NoError(t, err)
+	assert.Equal(t, magic, missing)
+
+	assert.Equal(t, "nfs", fsMagicName(graphdriver.FsMagicNfsFs))
+	assert.Equal(t, "cifs", fsMagicName(fsMagicCifs))
+}
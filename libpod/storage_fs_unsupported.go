@@ -0,0 +1,8 @@
+// +build !linux
+
+package libpod
+
+// checkStorageFilesystem is a no-op on systems other than Linux.
+func (r *Runtime) checkStorageFilesystem(dbConfig *DBConfig) error {
+	return nil
+}
@@ -29,6 +29,9 @@ const (
 	unknownPackage = "Unknown"
 )
 
+// zoneinfoDir is the directory of the timezone database on the host.
+const zoneinfoDir = "/usr/share/zoneinfo"
+
 // FuncTimer helps measure the execution time of a function
 // For debug purposes, do not leave in code
 // used like defer FuncTimer("foo")
@@ -329,3 +332,31 @@ func writeStringToPath(path, contents, mountLabel string, uid, gid int) error {
 
 	return nil
 }
+
+// timezonePath returns the path of the timezone file on the host for the
+// specified timezone, which is either "local" for the timezone of the host or
+// the name of a zone in the timezone database (e.g., "America/New_York").
+// Symlinks are evaluated.
+func timezonePath(tz string) (string, error) {
+	zone := "/etc/localtime"
+	if tz != "local" {
+		zone = filepath.Join(zoneinfoDir, tz)
+		// Do not allow escaping the timezone database via "..".
+		if !strings.HasPrefix(zone, zoneinfoDir+"/") {
+			return "", errors.Wrapf(define.ErrInvalidArg, "invalid timezone %q", tz)
+		}
+	}
+	zonePath, err := filepath.EvalSymlinks(zone)
+	if err != nil {
+		return "", err
+	}
+	file, err := os.Stat(zonePath)
+	if err != nil {
+		return "", err
+	}
+	// We don't want to mount a timezone directory
+	if file.IsDir() {
+		return "", errors.New("Invalid timezone: is a directory")
+	}
+	return zonePath, nil
+}
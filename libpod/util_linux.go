@@ -10,6 +10,7 @@ import (
 	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/pkg/cgroups"
 	"github.com/containers/podman/v2/pkg/rootless"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/opencontainers/selinux/go-selinux/label"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -17,9 +18,9 @@ import (
 )
 
 // systemdSliceFromPath makes a new systemd slice under the given parent with
-// the given name.
+// the given name and resource limits.
 // The parent must be a slice. The name must NOT include ".slice"
-func systemdSliceFromPath(parent, name string) (string, error) {
+func systemdSliceFromPath(parent, name string, resources *spec.LinuxResources) (string, error) {
 	cgroupPath, err := assembleSystemdCgroupName(parent, name)
 	if err != nil {
 		return "", err
@@ -27,7 +28,7 @@ func systemdSliceFromPath(parent, name string) (string, error) {
 
 	logrus.Debugf("Created cgroup path %s for parent %s and name %s", cgroupPath, parent, name)
 
-	if err := makeSystemdCgroup(cgroupPath); err != nil {
+	if err := makeSystemdCgroup(cgroupPath, resources); err != nil {
 		return "", errors.Wrapf(err, "error creating cgroup %s", cgroupPath)
 	}
 
@@ -43,17 +44,25 @@ func getDefaultSystemdCgroup() string {
 	return SystemdDefaultCgroupParent
 }
 
-// makeSystemdCgroup creates a systemd CGroup at the given location.
-func makeSystemdCgroup(path string) error {
+// makeSystemdCgroup creates a systemd CGroup at the given location with the
+// given resource limits.
+func makeSystemdCgroup(path string, resources *spec.LinuxResources) error {
 	controller, err := cgroups.NewSystemd(getDefaultSystemdCgroup())
 	if err != nil {
 		return err
 	}
 
 	if rootless.IsRootless() {
-		return controller.CreateSystemdUserUnit(path, rootless.GetRootlessUID())
+		return controller.CreateSystemdUserUnit(path, rootless.GetRootlessUID(), resources)
 	}
-	return controller.CreateSystemdUnit(path)
+	return controller.CreateSystemdUnit(path, resources)
+}
+
+// makeCgroupfsCgroup creates a cgroupfs CGroup at the given location with the
+// given resource limits.
+func makeCgroupfsCgroup(path string, resources *spec.LinuxResources) error {
+	_, err := cgroups.New(path, resources)
+	return err
 }
 
 // deleteSystemdCgroup deletes the systemd cgroup at the given location
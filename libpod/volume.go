@@ -45,6 +45,9 @@ type VolumeConfig struct {
 	UID int `json:"uid"`
 	// GID the volume will be created as.
 	GID int `json:"gid"`
+	// Size is the size quota of the volume in bytes. 0 means the size of
+	// the volume is not limited.
+	Size uint64 `json:"size,omitempty"`
 }
 
 // VolumeState holds the volume's mutable state.
@@ -0,0 +1,78 @@
+package libpod
+
+import (
+	"io"
+
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/containers/storage/pkg/archive"
+	"github.com/containers/storage/pkg/chrootarchive"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Export writes the contents of the volume as an uncompressed tar archive to
+// w. The volume is mounted while the archive is streamed, if necessary.
+func (v *Volume) Export(w io.Writer) (retErr error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if !v.valid {
+		return define.ErrVolumeRemoved
+	}
+
+	if err := v.mount(); err != nil {
+		return err
+	}
+	defer func() {
+		if err := v.unmount(false); err != nil {
+			if retErr == nil {
+				retErr = err
+			} else {
+				logrus.Errorf("Error unmounting volume %s: %v", v.Name(), err)
+			}
+		}
+	}()
+
+	tarball, err := archive.Tar(v.config.MountPoint, archive.Uncompressed)
+	if err != nil {
+		return errors.Wrapf(err, "error creating tar archive of volume %s", v.Name())
+	}
+	defer tarball.Close()
+
+	if _, err := io.Copy(w, tarball); err != nil {
+		return errors.Wrapf(err, "error exporting volume %s", v.Name())
+	}
+	return nil
+}
+
+// Import extracts the tar archive read from r into the volume. Existing
+// contents of the volume are kept unless overwritten by the archive. The
+// volume is mounted while the archive is extracted, if necessary.
+func (v *Volume) Import(r io.Reader) (retErr error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if !v.valid {
+		return define.ErrVolumeRemoved
+	}
+
+	if err := v.mount(); err != nil {
+		return err
+	}
+	defer func() {
+		if err := v.unmount(false); err != nil {
+			if retErr == nil {
+				retErr = err
+			} else {
+				logrus.Errorf("Error unmounting volume %s: %v", v.Name(), err)
+			}
+		}
+	}()
+
+	// The archive may come from an untrusted source: extract it chrooted
+	// into the volume so that it cannot escape the mount point.
+	if err := chrootarchive.Untar(r, v.config.MountPoint, &archive.TarOptions{}); err != nil {
+		return errors.Wrapf(err, "error importing into volume %s", v.Name())
+	}
+	return nil
+}
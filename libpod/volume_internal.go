@@ -17,6 +17,9 @@ func newVolume(runtime *Runtime) *Volume {
 	volume.config.Labels = make(map[string]string)
 	volume.config.Options = make(map[string]string)
 	volume.state.NeedsCopyUp = true
+	// Unless an owner is set, the volume is chowned to the user of the
+	// first container mounting it.
+	volume.state.NeedsChown = true
 	return volume
 }
 
@@ -26,9 +29,36 @@ func (v *Volume) teardownStorage() error {
 }
 
 // Volumes with options set, or a filesystem type, or a device to mount need to
-// be mounted and unmounted.
+// be mounted and unmounted. The UID, GID and SIZE options are implemented by
+// Podman itself and do not require a mount.
 func (v *Volume) needsMount() bool {
-	return len(v.config.Options) > 0 && v.config.Driver == define.VolumeDriverLocal
+	if v.config.Driver != define.VolumeDriverLocal {
+		return false
+	}
+	for key := range v.config.Options {
+		switch key {
+		case "UID", "GID", "SIZE":
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// diskUsage returns the disk space used by the contents of the volume in bytes.
+// Filesystems mounted on the volume do not use disk space of the volume path.
+func (v *Volume) diskUsage() (uint64, error) {
+	if v.config.Driver != define.VolumeDriverLocal || v.needsMount() {
+		return 0, nil
+	}
+	var size uint64
+	err := filepath.Walk(v.config.MountPoint, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			size += uint64(info.Size())
+		}
+		return err
+	})
+	return size, err
 }
 
 // update() updates the volume state from the DB.
@@ -0,0 +1,186 @@
+// Package admission implements the image admission policy, which decides
+// whether images may be pulled and used to create containers based on their
+// configuration.
+package admission
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultPolicyPath is the path of the admission policy if none is set with
+// image_admission_policy in containers.conf.
+const DefaultPolicyPath = "/etc/containers/image-admission.json"
+
+// Actions of admission rules.
+const (
+	// ActionAllow admits the image.
+	ActionAllow = "allow"
+	// ActionWarn admits the image with a warning.
+	ActionWarn = "warn"
+	// ActionDeny rejects the image.
+	ActionDeny = "deny"
+)
+
+// ErrImageDenied indicates that an image has been rejected by the admission
+// policy.
+var ErrImageDenied = errors.New("image denied by admission policy")
+
+// Policy is an ordered list of rules.  The first rule matching an image
+// decides about its admission, images not matched by any rule are allowed.
+type Policy struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Rule matches the images in its scope which violate at least one of its
+// checks, or all images in its scope if it has no checks.
+type Rule struct {
+	// Registries limits the rule to images with a name below one of the
+	// given registries or repositories, e.g., "docker.io" or
+	// "quay.io/myorg".  The rule applies to all images if empty.
+	Registries []string `json:"registries,omitempty"`
+	// Action is the action taken for matched images.
+	Action string `json:"action"`
+	// RootUser matches images running as root by default.
+	RootUser bool `json:"rootUser,omitempty"`
+	// RequiredLabels matches images missing one of the labels, given as
+	// KEY or KEY=VALUE.
+	RequiredLabels []string `json:"requiredLabels,omitempty"`
+	// ForbiddenPorts matches images exposing one of the ports, given as
+	// PORT/PROTOCOL or as PORT for all protocols.
+	ForbiddenPorts []string `json:"forbiddenPorts,omitempty"`
+}
+
+// Image holds the properties of an image evaluated by the policy.
+type Image struct {
+	// Names are the names of the image.
+	Names []string
+	// User is the default user of the image.
+	User string
+	// Labels are the labels of the image.
+	Labels map[string]string
+	// ExposedPorts are the ports exposed by the image as PORT/PROTOCOL.
+	ExposedPorts []string
+}
+
+// Decision is the result of evaluating the policy for an image.
+type Decision struct {
+	// Action is the action of the matching rule, or ActionAllow if no
+	// rule matches.
+	Action string
+	// Reasons explain why the rule matches the image.
+	Reasons []string
+}
+
+// LoadPolicy reads the policy at path.  A missing file is an empty policy.
+func LoadPolicy(path string) (*Policy, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Policy{}, nil
+		}
+		return nil, errors.Wrapf(err, "error reading admission policy")
+	}
+	policy := &Policy{}
+	if err := json.Unmarshal(content, policy); err != nil {
+		return nil, errors.Wrapf(err, "error parsing admission policy %s", path)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid admission policy %s", path)
+	}
+	return policy, nil
+}
+
+// Validate checks that the rules of the policy are valid.
+func (p *Policy) Validate() error {
+	for i, rule := range p.Rules {
+		switch rule.Action {
+		case ActionAllow, ActionWarn, ActionDeny:
+		default:
+			return errors.Errorf("rule %d: invalid action %q: must be %q, %q or %q", i, rule.Action, ActionAllow, ActionWarn, ActionDeny)
+		}
+		for _, label := range rule.RequiredLabels {
+			if strings.HasPrefix(label, "=") || label == "" {
+				return errors.Errorf("rule %d: invalid required label %q", i, label)
+			}
+		}
+		for _, port := range rule.ForbiddenPorts {
+			if port == "" || strings.HasPrefix(port, "/") {
+				return errors.Errorf("rule %d: invalid forbidden port %q", i, port)
+			}
+		}
+	}
+	return nil
+}
+
+// Evaluate returns the decision of the first rule matching the image.
+func (p *Policy) Evaluate(img *Image) *Decision {
+	for _, rule := range p.Rules {
+		if !rule.inScope(img) {
+			continue
+		}
+		reasons, matched := rule.check(img)
+		if matched {
+			return &Decision{Action: rule.Action, Reasons: reasons}
+		}
+	}
+	return &Decision{Action: ActionAllow}
+}
+
+// inScope returns whether the image is named below one of the registries of
+// the rule.
+func (r *Rule) inScope(img *Image) bool {
+	if len(r.Registries) == 0 {
+		return true
+	}
+	for _, name := range img.Names {
+		for _, scope := range r.Registries {
+			scope = strings.TrimSuffix(scope, "/")
+			if name == scope {
+				return true
+			}
+			if strings.HasPrefix(name, scope) && strings.ContainsAny(name[len(scope):len(scope)+1], "/:@") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// check returns the checks of the rule violated by the image and whether the
+// rule matches it.
+func (r *Rule) check(img *Image) ([]string, bool) {
+	if !r.RootUser && len(r.RequiredLabels) == 0 && len(r.ForbiddenPorts) == 0 {
+		return []string{"image is in the scope of the rule"}, true
+	}
+	var reasons []string
+	if r.RootUser && isRootUser(img.User) {
+		reasons = append(reasons, "image runs as root")
+	}
+	for _, label := range r.RequiredLabels {
+		split := strings.SplitN(label, "=", 2)
+		value, ok := img.Labels[split[0]]
+		if !ok || (len(split) == 2 && value != split[1]) {
+			reasons = append(reasons, "image is missing required label "+label)
+		}
+	}
+	for _, port := range r.ForbiddenPorts {
+		for _, exposed := range img.ExposedPorts {
+			if exposed == port || strings.HasPrefix(exposed, port+"/") {
+				reasons = append(reasons, "image exposes forbidden port "+exposed)
+			}
+		}
+	}
+	return reasons, len(reasons) > 0
+}
+
+// isRootUser returns whether the user, given as USER[:GROUP], is root.  The
+// user defaults to root if empty.
+func isRootUser(user string) bool {
+	user = strings.SplitN(user, ":", 2)[0]
+	return user == "" || user == "root" || user == "0"
+}
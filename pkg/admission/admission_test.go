@@ -0,0 +1,66 @@
+package admission
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluate(t *testing.T) {
+	policy := &Policy{Rules: []Rule{
+		{Registries: []string{"quay.io/trusted"}, Action: ActionAllow},
+		{Action: ActionDeny, RootUser: true},
+		{Registries: []string{"docker.io"}, Action: ActionWarn, RequiredLabels: []string{"maintainer", "tier=prod"}},
+		{Action: ActionDeny, ForbiddenPorts: []string{"22", "53/udp"}},
+	}}
+	for _, tc := range []struct {
+		name    string
+		img     Image
+		action  string
+		reasons int
+	}{
+		{"trusted repository", Image{Names: []string{"quay.io/trusted/app:1"}, User: "root"}, ActionAllow, 1},
+		{"repository prefix is no scope", Image{Names: []string{"quay.io/trustedapp:1"}, User: "root"}, ActionDeny, 1},
+		{"root by default", Image{Names: []string{"quay.io/app:1"}}, ActionDeny, 1},
+		{"root by UID", Image{Names: []string{"quay.io/app:1"}, User: "0:100"}, ActionDeny, 1},
+		{"missing labels", Image{Names: []string{"docker.io/library/app:1"}, User: "app", Labels: map[string]string{"tier": "dev"}}, ActionWarn, 2},
+		{"required labels", Image{Names: []string{"docker.io/library/app:1"}, User: "app", Labels: map[string]string{"maintainer": "me", "tier": "prod"}}, ActionAllow, 0},
+		{"labels out of scope", Image{Names: []string{"quay.io/app:1"}, User: "app"}, ActionAllow, 0},
+		{"forbidden port", Image{User: "app", ExposedPorts: []string{"22/tcp", "80/tcp"}}, ActionDeny, 1},
+		{"forbidden protocol", Image{User: "app", ExposedPorts: []string{"53/tcp", "53/udp"}}, ActionDeny, 1},
+		{"allowed port", Image{User: "app", ExposedPorts: []string{"53/tcp", "220/tcp"}}, ActionAllow, 0},
+	} {
+		decision := policy.Evaluate(&tc.img)
+		assert.Equal(t, tc.action, decision.Action, tc.name)
+		assert.Len(t, decision.Reasons, tc.reasons, tc.name)
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "admission")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	policy, err := LoadPolicy(filepath.Join(dir, "missing.json"))
+	require.NoError(t, err)
+	assert.Len(t, policy.Rules, 0)
+
+	path := filepath.Join(dir, "policy.json")
+	err = ioutil.WriteFile(path, []byte(`{"rules": [{"registries": ["docker.io"], "action": "deny", "rootUser": true}]}`), 0644)
+	require.NoError(t, err)
+	policy, err = LoadPolicy(path)
+	require.NoError(t, err)
+	require.Len(t, policy.Rules, 1)
+	assert.Equal(t, []string{"docker.io"}, policy.Rules[0].Registries)
+	assert.Equal(t, ActionDeny, policy.Rules[0].Action)
+	assert.True(t, policy.Rules[0].RootUser)
+
+	err = ioutil.WriteFile(path, []byte(`{"rules": [{"action": "reject"}]}`), 0644)
+	require.NoError(t, err)
+	_, err = LoadPolicy(path)
+	assert.Error(t, err)
+}
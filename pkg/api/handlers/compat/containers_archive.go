@@ -2,6 +2,7 @@ package compat
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/pkg/api/handlers/utils"
 	"github.com/containers/podman/v2/pkg/copy"
+	"github.com/containers/podman/v2/pkg/domain/entities"
 	"github.com/containers/podman/v2/pkg/domain/infra/abi"
 	"github.com/gorilla/schema"
 	"github.com/pkg/errors"
@@ -31,7 +33,8 @@ func Archive(w http.ResponseWriter, r *http.Request) {
 
 func handleHeadAndGet(w http.ResponseWriter, r *http.Request, decoder *schema.Decoder, runtime *libpod.Runtime) {
 	query := struct {
-		Path string `schema:"path"`
+		Path   string `schema:"path"`
+		Offset int64  `schema:"offset"`
 	}{}
 
 	err := decoder.Decode(&query, r.URL.Query())
@@ -78,7 +81,18 @@ func handleHeadAndGet(w http.ResponseWriter, r *http.Request, decoder *schema.De
 		return
 	}
 
-	copyFunc, err := containerEngine.ContainerCopyToArchive(r.Context(), containerName, query.Path, w)
+	// The archive is generated anew for each request.  As long as the path
+	// in the container does not change, clients can resume an interrupted
+	// transfer by skipping the bytes they have received already.
+	var writer io.Writer = w
+	if query.Offset < 0 {
+		utils.Error(w, "Bad Request.", http.StatusBadRequest, errors.Errorf("invalid offset %d", query.Offset))
+		return
+	} else if query.Offset > 0 {
+		writer = &skipWriter{writer: w, skip: query.Offset}
+	}
+
+	copyFunc, err := containerEngine.ContainerCopyToArchive(r.Context(), containerName, query.Path, writer)
 	if err != nil {
 		utils.Error(w, "Something went wrong", http.StatusInternalServerError, err)
 		return
@@ -92,11 +106,14 @@ func handleHeadAndGet(w http.ResponseWriter, r *http.Request, decoder *schema.De
 
 func handlePut(w http.ResponseWriter, r *http.Request, decoder *schema.Decoder, runtime *libpod.Runtime) {
 	query := struct {
-		Path string `schema:"path"`
-		// TODO handle params below
-		NoOverwriteDirNonDir bool `schema:"noOverwriteDirNonDir"`
-		CopyUIDGID           bool `schema:"copyUIDGID"`
-	}{}
+		Path                 string `schema:"path"`
+		Chown                bool   `schema:"chown"`
+		NoOverwriteDirNonDir bool   `schema:"noOverwriteDirNonDir"`
+		CopyUIDGID           bool   `schema:"copyUIDGID"`
+	}{
+		// override any golang type defaults
+		Chown: true,
+	}
 
 	err := decoder.Decode(&query, r.URL.Query())
 	if err != nil {
@@ -107,7 +124,17 @@ func handlePut(w http.ResponseWriter, r *http.Request, decoder *schema.Decoder,
 	containerName := utils.GetName(r)
 	containerEngine := abi.ContainerEngine{Libpod: runtime}
 
-	copyFunc, err := containerEngine.ContainerCopyFromArchive(r.Context(), containerName, query.Path, r.Body)
+	// Docker only changes the ownership of the copied files on request
+	// while Podman does so by default.
+	options := entities.CopyOptions{
+		Chown:                query.CopyUIDGID,
+		NoOverwriteDirNonDir: query.NoOverwriteDirNonDir,
+	}
+	if utils.IsLibpodRequest(r) {
+		options.Chown = query.Chown
+	}
+
+	copyFunc, err := containerEngine.ContainerCopyFromArchive(r.Context(), containerName, query.Path, r.Body, options)
 	if errors.Cause(err) == define.ErrNoSuchCtr || os.IsNotExist(err) {
 		// 404 is returned for an absent container and path.  The
 		// clients must deal with it accordingly.
@@ -123,3 +150,23 @@ func handlePut(w http.ResponseWriter, r *http.Request, decoder *schema.Decoder,
 		logrus.Error(err.Error())
 	}
 }
+
+// skipWriter discards the first skip bytes written to it and writes the
+// remaining bytes to writer.
+type skipWriter struct {
+	writer io.Writer
+	skip   int64
+}
+
+func (s *skipWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	if s.skip >= int64(n) {
+		s.skip -= int64(n)
+		return n, nil
+	}
+	if _, err := s.writer.Write(p[s.skip:]); err != nil {
+		return 0, err
+	}
+	s.skip = 0
+	return n, nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/containers/podman/v2/pkg/domain/infra/abi"
 	"github.com/containers/podman/v2/pkg/specgen"
 	"github.com/gorilla/schema"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
 )
 
@@ -84,6 +85,12 @@ func CreateContainer(w http.ResponseWriter, r *http.Request) {
 		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, errors.Wrap(err, "fill out specgen"))
 		return
 	}
+	if sg.Terminal && body.HostConfig.ConsoleSize[0] > 0 && body.HostConfig.ConsoleSize[1] > 0 {
+		sg.ConsoleSize = &spec.Box{
+			Height: body.HostConfig.ConsoleSize[0],
+			Width:  body.HostConfig.ConsoleSize[1],
+		}
+	}
 
 	ic := abi.ContainerEngine{Libpod: runtime}
 	report, err := ic.ContainerCreate(r.Context(), sg)
@@ -86,6 +86,8 @@ func LogsFromContainer(w http.ResponseWriter, r *http.Request) {
 		Since:      since,
 		Tail:       tail,
 		Timestamps: query.Timestamps,
+		Stdout:     query.Stdout,
+		Stderr:     query.Stderr,
 	}
 
 	var wg sync.WaitGroup
@@ -0,0 +1,53 @@
+package compat
+
+import (
+	"net/http"
+
+	"github.com/containers/podman/v2/libpod"
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/containers/podman/v2/pkg/api/handlers/utils"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/containers/podman/v2/pkg/domain/infra/abi"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
+)
+
+// RenameContainer renames a container.
+func RenameContainer(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+
+	// /{version}/containers/(name)/rename
+	query := struct {
+		Name string `schema:"name"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	if query.Name == "" {
+		utils.Error(w, "Something went wrong.", http.StatusBadRequest, errors.Errorf("the new name of the container must be specified"))
+		return
+	}
+
+	name := utils.GetName(r)
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	if err := containerEngine.ContainerRename(r.Context(), name, entities.ContainerRenameOptions{NewName: query.Name}); err != nil {
+		switch errors.Cause(err) {
+		case define.ErrNoSuchCtr:
+			utils.ContainerNotFound(w, name, err)
+		case define.ErrCtrExists, define.ErrPodExists:
+			utils.Error(w, "Something went wrong.", http.StatusConflict, err)
+		case define.ErrInvalidArg:
+			utils.Error(w, "Something went wrong.", http.StatusBadRequest, err)
+		default:
+			utils.InternalServerError(w, err)
+		}
+		return
+	}
+	// Success
+	utils.WriteResponse(w, http.StatusNoContent, nil)
+}
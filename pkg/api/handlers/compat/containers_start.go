@@ -42,7 +42,9 @@ func StartContainer(w http.ResponseWriter, r *http.Request) {
 		utils.WriteResponse(w, http.StatusNotModified, nil)
 		return
 	}
-	if err := con.Start(r.Context(), len(con.PodID()) > 0); err != nil {
+	// Dependencies of containers in a pod or requiring other containers
+	// are started as well.
+	if err := con.Start(r.Context(), len(con.PodID()) > 0 || len(con.RequiredContainers()) > 0); err != nil {
 		utils.InternalServerError(w, err)
 		return
 	}
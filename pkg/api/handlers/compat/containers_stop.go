@@ -2,6 +2,7 @@ package compat
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/containers/podman/v2/libpod"
 	"github.com/containers/podman/v2/libpod/define"
@@ -44,6 +45,17 @@ func StopContainer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	requiredBy, err := con.RequiredByRunning()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if len(requiredBy) > 0 {
+		utils.Error(w, "container is required", http.StatusConflict,
+			errors.Wrapf(define.ErrCtrRequired, "container %s is required by running containers which must be stopped before it: %s", con.ID(), strings.Join(requiredBy, ", ")))
+		return
+	}
+
 	var stopError error
 	if query.Timeout > 0 {
 		stopError = con.StopWithTimeout(uint(query.Timeout))
@@ -13,6 +13,7 @@ import (
 	"github.com/containers/podman/v2/pkg/api/server/idle"
 	"github.com/containers/podman/v2/pkg/specgen/generate"
 	"github.com/gorilla/mux"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
@@ -37,6 +38,12 @@ func ExecCreateHandler(w http.ResponseWriter, r *http.Request) {
 	libpodConfig := new(libpod.ExecConfig)
 	libpodConfig.Command = input.Cmd
 	libpodConfig.Terminal = input.Tty
+	if input.ConsoleSize != nil {
+		libpodConfig.ConsoleSize = &spec.Box{
+			Height: input.ConsoleSize[0],
+			Width:  input.ConsoleSize[1],
+		}
+	}
 	libpodConfig.AttachStdin = input.AttachStdin
 	libpodConfig.AttachStderr = input.AttachStderr
 	libpodConfig.AttachStdout = input.AttachStdout
@@ -18,6 +18,7 @@ import (
 	"github.com/containers/podman/v2/pkg/api/handlers/utils"
 	"github.com/containers/podman/v2/pkg/auth"
 	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/containers/podman/v2/pkg/util"
 	"github.com/docker/docker/api/types"
 	"github.com/gorilla/schema"
 	"github.com/opencontainers/go-digest"
@@ -260,6 +261,7 @@ func CreateImageFromImage(w http.ResponseWriter, r *http.Request) {
 	query := struct {
 		FromImage string `schema:"fromImage"`
 		Tag       string `schema:"tag"`
+		Platform  string `schema:"platform"`
 	}{
 		// This is where you can override the golang default value for one of fields
 	}
@@ -279,6 +281,13 @@ func CreateImageFromImage(w http.ResponseWriter, r *http.Request) {
 	defer auth.RemoveAuthfile(authfile)
 
 	registryOpts := image2.DockerRegistryOptions{DockerRegistryCreds: authConf}
+	if query.Platform != "" {
+		registryOpts.OSChoice, registryOpts.ArchitectureChoice, registryOpts.VariantChoice, err = util.ParsePlatform(query.Platform)
+		if err != nil {
+			utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, err)
+			return
+		}
+	}
 	if sys := runtime.SystemContext(); sys != nil {
 		registryOpts.DockerCertPath = sys.DockerCertPath
 	}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/containers/buildah"
 	"github.com/containers/buildah/imagebuildah"
@@ -87,6 +88,7 @@ func BuildImage(w http.ResponseWriter, r *http.Request) {
 		Squash      bool     `schema:"squash"`
 		Tag         []string `schema:"t"`
 		Target      string   `schema:"target"`
+		Timestamp   int64    `schema:"timestamp"`
 	}{
 		Dockerfile: "Dockerfile",
 		Registry:   "docker.io",
@@ -197,6 +199,10 @@ func BuildImage(w http.ResponseWriter, r *http.Request) {
 		ForceRmIntermediateCtrs: query.ForceRm,
 		Target:                  query.Target,
 	}
+	if _, found := r.URL.Query()["timestamp"]; found {
+		ts := time.Unix(query.Timestamp, 0).UTC()
+		buildOptions.Timestamp = &ts
+	}
 
 	runtime := r.Context().Value("runtime").(*libpod.Runtime)
 	runCtx, cancel := context.WithCancel(context.Background())
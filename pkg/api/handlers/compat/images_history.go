@@ -34,6 +34,11 @@ func HistoryImage(w http.ResponseWriter, r *http.Request) {
 			Size:      h.Size,
 			Comment:   h.Comment,
 		}
+		if utils.IsLibpodRequest(r) {
+			l.EmptyLayer = h.EmptyLayer
+			l.Layer = h.Layer
+			l.LayerDigest = h.LayerDigest
+		}
 		allHistory = append(allHistory, l)
 	}
 	utils.WriteResponse(w, http.StatusOK, allHistory)
@@ -101,6 +101,7 @@ func getNetworkResourceByNameOrID(nameOrID string, runtime *libpod.Runtime, filt
 	if err != nil {
 		return nil, err
 	}
+	enableIPv6 := false
 	for _, outer := range bridge.IPAM.Ranges {
 		for _, n := range outer {
 			ipamConfig := dockerNetwork.IPAMConfig{
@@ -108,6 +109,9 @@ func getNetworkResourceByNameOrID(nameOrID string, runtime *libpod.Runtime, filt
 				Gateway: n.Gateway,
 			}
 			ipamConfigs = append(ipamConfigs, ipamConfig)
+			if ip, _, err := net.ParseCIDR(n.Subnet); err == nil && network.IsIPv6(ip) {
+				enableIPv6 = true
+			}
 		}
 	}
 
@@ -133,7 +137,7 @@ func getNetworkResourceByNameOrID(nameOrID string, runtime *libpod.Runtime, filt
 		Created:    time.Unix(int64(stat.Ctim.Sec), int64(stat.Ctim.Nsec)), // nolint: unconvert
 		Scope:      "local",
 		Driver:     network.DefaultNetworkDriver,
-		EnableIPv6: false,
+		EnableIPv6: enableIPv6,
 		IPAM: dockerNetwork.IPAM{
 			Driver:  "default",
 			Options: nil,
@@ -238,31 +242,31 @@ func CreateNetwork(w http.ResponseWriter, r *http.Request) {
 		Driver:   network.DefaultNetworkDriver,
 		Internal: networkCreate.Internal,
 		Labels:   networkCreate.Labels,
-	}
-	if networkCreate.IPAM != nil && len(networkCreate.IPAM.Config) > 0 {
-		if len(networkCreate.IPAM.Config) > 1 {
-			utils.InternalServerError(w, errors.New("compat network create can only support one IPAM config"))
-			return
-		}
-
-		if len(networkCreate.IPAM.Config[0].Subnet) > 0 {
-			_, subnet, err := net.ParseCIDR(networkCreate.IPAM.Config[0].Subnet)
-			if err != nil {
-				utils.InternalServerError(w, err)
-				return
+		IPv6:     networkCreate.EnableIPv6,
+	}
+	if networkCreate.IPAM != nil {
+		for _, ipamConfig := range networkCreate.IPAM.Config {
+			var subnet entities.NetworkSubnet
+			if len(ipamConfig.Subnet) > 0 {
+				_, ipNet, err := net.ParseCIDR(ipamConfig.Subnet)
+				if err != nil {
+					utils.InternalServerError(w, err)
+					return
+				}
+				subnet.Subnet = *ipNet
 			}
-			ncOptions.Subnet = *subnet
-		}
-		if len(networkCreate.IPAM.Config[0].Gateway) > 0 {
-			ncOptions.Gateway = net.ParseIP(networkCreate.IPAM.Config[0].Gateway)
-		}
-		if len(networkCreate.IPAM.Config[0].IPRange) > 0 {
-			_, IPRange, err := net.ParseCIDR(networkCreate.IPAM.Config[0].IPRange)
-			if err != nil {
-				utils.InternalServerError(w, err)
-				return
+			if len(ipamConfig.Gateway) > 0 {
+				subnet.Gateway = net.ParseIP(ipamConfig.Gateway)
+			}
+			if len(ipamConfig.IPRange) > 0 {
+				_, IPRange, err := net.ParseCIDR(ipamConfig.IPRange)
+				if err != nil {
+					utils.InternalServerError(w, err)
+					return
+				}
+				subnet.Range = *IPRange
 			}
-			ncOptions.Range = *IPRange
+			ncOptions.Subnets = append(ncOptions.Subnets, subnet)
 		}
 	}
 	ce := abi.ContainerEngine{Libpod: runtime}
@@ -388,3 +392,40 @@ func Disconnect(w http.ResponseWriter, r *http.Request) {
 	}
 	utils.WriteResponse(w, http.StatusOK, "OK")
 }
+
+// Prune removes all unused networks
+func Prune(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Filters map[string][]string `schema:"filters"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, "Something went wrong.", http.StatusBadRequest, errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+
+	ic := abi.ContainerEngine{Libpod: runtime}
+	pruneOptions := entities.NetworkPruneOptions{
+		Filters: query.Filters,
+	}
+	pruneReports, err := ic.NetworkPrune(r.Context(), pruneOptions)
+	if err != nil {
+		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, err)
+		return
+	}
+	type response struct {
+		NetworksDeleted []string
+	}
+	prunedNetworks := []string{}
+	for _, pr := range pruneReports {
+		if pr.Error != nil {
+			logrus.Error(pr.Error)
+			continue
+		}
+		prunedNetworks = append(prunedNetworks, pr.Name)
+	}
+	utils.WriteResponse(w, http.StatusOK, response{NetworksDeleted: prunedNetworks})
+}
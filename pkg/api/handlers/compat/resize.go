@@ -20,8 +20,8 @@ func ResizeTTY(w http.ResponseWriter, r *http.Request) {
 
 	// /containers/{id}/resize
 	query := struct {
-		height uint16 `schema:"h"`
-		width  uint16 `schema:"w"`
+		Height uint16 `schema:"h"`
+		Width  uint16 `schema:"w"`
 	}{
 		// override any golang type defaults
 	}
@@ -33,8 +33,8 @@ func ResizeTTY(w http.ResponseWriter, r *http.Request) {
 	}
 
 	sz := remotecommand.TerminalSize{
-		Width:  query.width,
-		Height: query.height,
+		Width:  query.Width,
+		Height: query.Height,
 	}
 
 	var status int
@@ -46,15 +46,23 @@ func ResizeTTY(w http.ResponseWriter, r *http.Request) {
 			utils.ContainerNotFound(w, name, err)
 			return
 		}
-		if state, err := ctnr.State(); err != nil {
-			utils.InternalServerError(w, errors.Wrapf(err, "cannot obtain container state"))
-			return
-		} else if state != define.ContainerStateRunning {
-			utils.Error(w, "Container not running", http.StatusConflict,
-				fmt.Errorf("container %q in wrong state %q", name, state.String()))
-			return
+		// Libpod queues resizes of containers that have not been
+		// started yet, so the TTY has the right size when it is created.
+		if !utils.IsLibpodRequest(r) {
+			if state, err := ctnr.State(); err != nil {
+				utils.InternalServerError(w, errors.Wrapf(err, "cannot obtain container state"))
+				return
+			} else if state != define.ContainerStateRunning {
+				utils.Error(w, "Container not running", http.StatusConflict,
+					fmt.Errorf("container %q in wrong state %q", name, state.String()))
+				return
+			}
 		}
 		if err := ctnr.AttachResize(sz); err != nil {
+			if errors.Cause(err) == define.ErrCtrStateInvalid {
+				utils.Error(w, "Container in wrong state", http.StatusConflict, err)
+				return
+			}
 			utils.InternalServerError(w, errors.Wrapf(err, "cannot resize container"))
 			return
 		}
@@ -77,6 +85,10 @@ func ResizeTTY(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if err := ctnr.ExecResize(name, sz); err != nil {
+			if errors.Cause(err) == define.ErrExecSessionStateInvalid {
+				utils.Error(w, "Exec session in wrong state", http.StatusConflict, err)
+				return
+			}
 			utils.InternalServerError(w, errors.Wrapf(err, "cannot resize session"))
 			return
 		}
@@ -77,3 +77,12 @@ type swagCompatNetworkDisconnectRequest struct {
 	// in:body
 	Body struct{ types.NetworkDisconnect }
 }
+
+// Network prune
+// swagger:response CompatNetworkPrune
+type swagCompatNetworkPrune struct {
+	// in:body
+	Body struct {
+		NetworksDeleted []string
+	}
+}
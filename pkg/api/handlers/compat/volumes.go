@@ -263,21 +263,21 @@ func PruneVolumes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pruned, err := runtime.PruneVolumes(r.Context(), filterFuncs)
+	pruned, err := runtime.PruneVolumes(r.Context(), filterFuncs, false)
 	if err != nil {
 		utils.InternalServerError(w, err)
 		return
 	}
 	prunedIds := make([]string, 0, len(pruned))
-	for k := range pruned {
+	var reclaimed uint64
+	for _, p := range pruned {
 		// XXX: This drops any pruning per-volume error messages on the floor
-		prunedIds = append(prunedIds, k)
+		prunedIds = append(prunedIds, p.Name)
+		reclaimed += p.Size
 	}
 	pruneResponse := docker_api_types.VolumesPruneReport{
 		VolumesDeleted: prunedIds,
-		// TODO: We don't have any insight into how much space was reclaimed
-		// from `PruneVolumes()` but it's not nullable
-		SpaceReclaimed: 0,
+		SpaceReclaimed: reclaimed,
 	}
 
 	utils.WriteResponse(w, http.StatusOK, pruneResponse)
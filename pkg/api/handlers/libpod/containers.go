@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/containers/podman/v2/libpod"
 	"github.com/containers/podman/v2/libpod/define"
@@ -63,10 +64,12 @@ func ListContainers(w http.ResponseWriter, r *http.Request) {
 	decoder := r.Context().Value("decoder").(*schema.Decoder)
 	query := struct {
 		All       bool                `schema:"all"`
+		External  bool                `schema:"external"`
 		Filters   map[string][]string `schema:"filters"`
 		Last      int                 `schema:"last"` // alias for limit
 		Limit     int                 `schema:"limit"`
 		Namespace bool                `schema:"namespace"`
+		Pids      bool                `schema:"pids"`
 		Size      bool                `schema:"size"`
 		Sync      bool                `schema:"sync"`
 	}{
@@ -97,7 +100,9 @@ func ListContainers(w http.ResponseWriter, r *http.Request) {
 		Size:      query.Size,
 		Sort:      "",
 		Namespace: query.Namespace,
+		Pids:      query.Pids,
 		Pod:       true,
+		Storage:   query.External,
 		Sync:      query.Sync,
 	}
 	pss, err := ps.GetContainerLists(runtime, opts)
@@ -208,6 +213,7 @@ func Checkpoint(w http.ResponseWriter, r *http.Request) {
 		TCPEstablished bool `schema:"tcpEstablished"`
 		Export         bool `schema:"export"`
 		IgnoreRootFS   bool `schema:"ignoreRootFS"`
+		IgnoreVolumes  bool `schema:"ignoreVolumes"`
 	}{
 		// override any golang type defaults
 	}
@@ -242,6 +248,7 @@ func Checkpoint(w http.ResponseWriter, r *http.Request) {
 		KeepRunning:    query.LeaveRunning,
 		TCPEstablished: query.TCPEstablished,
 		IgnoreRootfs:   query.IgnoreRootFS,
+		IgnoreVolumes:  query.IgnoreVolumes,
 	}
 	if query.Export {
 		options.TargetFile = targetFile
@@ -277,6 +284,7 @@ func Restore(w http.ResponseWriter, r *http.Request) {
 		IgnoreRootFS    bool   `schema:"ignoreRootFS"`
 		IgnoreStaticIP  bool   `schema:"ignoreStaticIP"`
 		IgnoreStaticMAC bool   `schema:"ignoreStaticMAC"`
+		IgnoreVolumes   bool   `schema:"ignoreVolumes"`
 	}{
 		// override any golang type defaults
 	}
@@ -312,6 +320,7 @@ func Restore(w http.ResponseWriter, r *http.Request) {
 		IgnoreRootfs:    query.IgnoreRootFS,
 		IgnoreStaticIP:  query.IgnoreStaticIP,
 		IgnoreStaticMAC: query.IgnoreStaticMAC,
+		IgnoreVolumes:   query.IgnoreVolumes,
 	}
 	if query.Import {
 		options.TargetFile = targetFile
@@ -345,6 +354,162 @@ func InitContainer(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, http.StatusNoContent, "")
 }
 
+func CloneContainer(w http.ResponseWriter, r *http.Request) {
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Name       string `schema:"name"`
+		Image      string `schema:"image"`
+		Run        bool   `schema:"run"`
+		CPUPeriod  uint64 `schema:"cpuPeriod"`
+		CPUQuota   int64  `schema:"cpuQuota"`
+		CPUShares  uint64 `schema:"cpuShares"`
+		CPUSetCPUs string `schema:"cpusetCpus"`
+		Memory     int64  `schema:"memory"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+
+	name := utils.GetName(r)
+	options := entities.ContainerCloneOptions{
+		Name:  query.Name,
+		Image: query.Image,
+		Run:   query.Run,
+		CloneResourceOptions: entities.CloneResourceOptions{
+			CPUPeriod:  query.CPUPeriod,
+			CPUQuota:   query.CPUQuota,
+			CPUShares:  query.CPUShares,
+			CPUSetCPUs: query.CPUSetCPUs,
+			Memory:     query.Memory,
+		},
+	}
+	report, err := containerEngine.ContainerClone(r.Context(), name, options)
+	if err != nil {
+		if errors.Cause(err) == define.ErrNoSuchCtr {
+			utils.ContainerNotFound(w, name, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusCreated, report)
+}
+
+func UpdateContainer(w http.ResponseWriter, r *http.Request) {
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		CPUPeriod         uint64   `schema:"cpuPeriod"`
+		CPUQuota          int64    `schema:"cpuQuota"`
+		CPUShares         uint64   `schema:"cpuShares"`
+		CPUSetCPUs        string   `schema:"cpusetCpus"`
+		CPUSetMems        string   `schema:"cpusetMems"`
+		Memory            int64    `schema:"memory"`
+		MemorySwap        int64    `schema:"memorySwap"`
+		PidsLimit         int64    `schema:"pidsLimit"`
+		BlkioWeight       uint16   `schema:"blkioWeight"`
+		Labels            []string `schema:"labels"`
+		RemoveLabels      []string `schema:"removeLabels"`
+		Annotations       []string `schema:"annotations"`
+		RemoveAnnotations []string `schema:"removeAnnotations"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+
+	name := utils.GetName(r)
+	options := entities.ContainerUpdateOptions{
+		CPUPeriod:   query.CPUPeriod,
+		CPUQuota:    query.CPUQuota,
+		CPUShares:   query.CPUShares,
+		CPUSetCPUs:  query.CPUSetCPUs,
+		CPUSetMems:  query.CPUSetMems,
+		Memory:      query.Memory,
+		MemorySwap:  query.MemorySwap,
+		PidsLimit:   query.PidsLimit,
+		BlkioWeight: query.BlkioWeight,
+
+		RemoveLabels:      query.RemoveLabels,
+		RemoveAnnotations: query.RemoveAnnotations,
+	}
+	var err error
+	if options.Labels, err = parseKeyValues(query.Labels); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, err)
+		return
+	}
+	if options.Annotations, err = parseKeyValues(query.Annotations); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, err)
+		return
+	}
+	report, err := containerEngine.ContainerUpdate(r.Context(), name, options)
+	if err != nil {
+		if errors.Cause(err) == define.ErrNoSuchCtr {
+			utils.ContainerNotFound(w, name, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+// parseKeyValues parses a list of key=value pairs into a map.
+func parseKeyValues(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	m := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		split := strings.SplitN(pair, "=", 2)
+		if len(split) != 2 || split[0] == "" {
+			return nil, errors.Errorf("invalid key=value pair %q", pair)
+		}
+		m[split[0]] = split[1]
+	}
+	return m, nil
+}
+
+func DropCachesContainer(w http.ResponseWriter, r *http.Request) {
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Amount uint64 `schema:"amount"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	if err := ctr.DropCaches(query.Amount); err != nil {
+		if errors.Cause(err) == define.ErrCtrStateInvalid {
+			utils.ContainerNotRunning(w, ctr.ID(), err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusNoContent, "")
+}
+
 func ShouldRestart(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value("runtime").(*libpod.Runtime)
 	// Now use the ABI implementation to prevent us from having duplicate
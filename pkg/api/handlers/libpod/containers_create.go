@@ -10,6 +10,7 @@ import (
 	"github.com/containers/podman/v2/pkg/domain/entities"
 	"github.com/containers/podman/v2/pkg/specgen"
 	"github.com/containers/podman/v2/pkg/specgen/generate"
+	"github.com/gorilla/schema"
 	"github.com/pkg/errors"
 )
 
@@ -35,3 +36,45 @@ func CreateContainer(w http.ResponseWriter, r *http.Request) {
 	response := entities.ContainerCreateResponse{ID: ctr.ID(), Warnings: warn}
 	utils.WriteJSON(w, http.StatusCreated, response)
 }
+
+// CreateContainerBatch takes a specgenerator and makes a batch of containers
+// from it.
+func CreateContainerBatch(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Count       uint   `schema:"count"`
+		NamePattern string `schema:"namepattern"`
+		BatchSize   uint   `schema:"batchsize"`
+	}{
+		Count: 1,
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, "Something went wrong.", http.StatusBadRequest, errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	var sg specgen.SpecGenerator
+	if err := json.NewDecoder(r.Body).Decode(&sg); err != nil {
+		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, errors.Wrap(err, "Decode()"))
+		return
+	}
+	warn, err := generate.CompleteSpec(r.Context(), runtime, &sg)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	ctrs, err := generate.MakeContainerBatch(context.Background(), runtime, &sg, query.Count, query.NamePattern, query.BatchSize)
+	if err != nil {
+		if errors.Cause(err) == specgen.ErrInvalidSpecConfig {
+			utils.Error(w, "Something went wrong.", http.StatusBadRequest, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	response := entities.ContainerCreateBatchResponse{IDs: make([]string, 0, len(ctrs)), Warnings: warn}
+	for _, ctr := range ctrs {
+		response.IDs = append(response.IDs, ctr.ID())
+	}
+	utils.WriteJSON(w, http.StatusCreated, response)
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/containers/buildah"
 	"github.com/containers/image/v5/manifest"
@@ -57,12 +58,43 @@ func ImageExists(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, http.StatusNoContent, "")
 }
 
+// ImagesLookup looks up multiple images in local storage at once.
+func ImagesLookup(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Names []string `schema:"names"`
+	}{
+		// override any golang type defaults
+	}
+
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+	if len(query.Names) == 0 {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.New("names parameter cannot be empty"))
+		return
+	}
+
+	ir := abi.ImageEngine{Libpod: runtime}
+	reports, err := ir.Lookup(r.Context(), query.Names, entities.ImageLookupOptions{})
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, reports)
+}
+
 func ImageTree(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value("runtime").(*libpod.Runtime)
 	name := utils.GetName(r)
 	decoder := r.Context().Value("decoder").(*schema.Decoder)
 	query := struct {
 		WhatRequires bool `schema:"whatrequires"`
+		Shared       bool `schema:"shared"`
 	}{
 		WhatRequires: false,
 	}
@@ -72,13 +104,17 @@ func ImageTree(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	ir := abi.ImageEngine{Libpod: runtime}
-	options := entities.ImageTreeOptions{WhatRequires: query.WhatRequires}
+	options := entities.ImageTreeOptions{WhatRequires: query.WhatRequires, Shared: query.Shared}
 	report, err := ir.Tree(r.Context(), name, options)
 	if err != nil {
 		if errors.Cause(err) == define.ErrNoSuchImage {
 			utils.Error(w, "Something went wrong.", http.StatusNotFound, errors.Wrapf(err, "failed to find image %s", name))
 			return
 		}
+		if errors.Cause(err) == define.ErrInvalidArg {
+			utils.Error(w, "Something went wrong.", http.StatusBadRequest, err)
+			return
+		}
 		utils.Error(w, "Server error", http.StatusInternalServerError, errors.Wrapf(err, "failed to generate image tree for %s", name))
 		return
 	}
@@ -511,6 +547,7 @@ func CommitContainer(w http.ResponseWriter, r *http.Request) {
 		Pause     bool     `schema:"pause"`
 		Repo      string   `schema:"repo"`
 		Tag       string   `schema:"tag"`
+		Timestamp int64    `schema:"timestamp"`
 	}{
 		Format: "oci",
 	}
@@ -548,6 +585,14 @@ func CommitContainer(w http.ResponseWriter, r *http.Request) {
 		SystemContext:         sc,
 		PreferredManifestType: mimeType,
 	}
+	if _, found := r.URL.Query()["timestamp"]; found {
+		if query.Timestamp < 0 {
+			utils.Error(w, "Something went wrong.", http.StatusBadRequest, errors.Errorf("invalid timestamp %d", query.Timestamp))
+			return
+		}
+		timestamp := time.Unix(query.Timestamp, 0).UTC()
+		options.HistoryTimestamp = &timestamp
+	}
 
 	if len(query.Tag) > 0 {
 		tag = query.Tag
@@ -619,12 +664,13 @@ func UntagImage(w http.ResponseWriter, r *http.Request) {
 func SearchImages(w http.ResponseWriter, r *http.Request) {
 	decoder := r.Context().Value("decoder").(*schema.Decoder)
 	query := struct {
-		Term      string              `json:"term"`
-		Limit     int                 `json:"limit"`
-		NoTrunc   bool                `json:"noTrunc"`
-		Filters   map[string][]string `json:"filters"`
-		TLSVerify bool                `json:"tlsVerify"`
-		ListTags  bool                `json:"listTags"`
+		Term       string              `json:"term"`
+		Limit      int                 `json:"limit"`
+		NoTrunc    bool                `json:"noTrunc"`
+		Filters    map[string][]string `json:"filters"`
+		TLSVerify  bool                `json:"tlsVerify"`
+		ListTags   bool                `json:"listTags"`
+		TagDigests bool                `json:"tagDigests"`
 	}{
 		// This is where you can override the golang default value for one of fields
 	}
@@ -662,10 +708,11 @@ func SearchImages(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	options := image.SearchOptions{
-		Limit:    query.Limit,
-		NoTrunc:  query.NoTrunc,
-		ListTags: query.ListTags,
-		Filter:   filter,
+		Limit:      query.Limit,
+		NoTrunc:    query.NoTrunc,
+		ListTags:   query.ListTags,
+		TagDigests: query.TagDigests,
+		Filter:     filter,
 	}
 
 	if _, found := r.URL.Query()["tlsVerify"]; found {
@@ -697,6 +744,7 @@ func SearchImages(w http.ResponseWriter, r *http.Request) {
 		reports[i].Official = searchResults[i].Official
 		reports[i].Automated = searchResults[i].Automated
 		reports[i].Tag = searchResults[i].Tag
+		reports[i].Digest = searchResults[i].Digest
 	}
 
 	utils.WriteResponse(w, http.StatusOK, reports)
@@ -36,8 +36,10 @@ func ImagesPull(w http.ResponseWriter, r *http.Request) {
 		OverrideVariant string `schema:"overrideVariant"`
 		TLSVerify       bool   `schema:"tlsVerify"`
 		AllTags         bool   `schema:"allTags"`
+		Policy          string `schema:"policy"`
 	}{
 		TLSVerify: true,
+		Policy:    "always",
 	}
 
 	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
@@ -51,6 +53,17 @@ func ImagesPull(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	pullPolicy, err := util.ValidatePullType(query.Policy)
+	if err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, err)
+		return
+	}
+	if query.AllTags && pullPolicy != util.PullImageAlways {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.New("the all-tags option requires the \"always\" pull policy"))
+		return
+	}
+
 	imageRef, err := utils.ParseDockerReference(query.Reference)
 	if err != nil {
 		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, err)
@@ -136,7 +149,7 @@ func ImagesPull(w http.ResponseWriter, r *http.Request) {
 				&dockerRegistryOptions,
 				image.SigningOptions{},
 				nil,
-				util.PullImageAlways)
+				pullPolicy)
 			if err != nil {
 				stderr.Write([]byte(err.Error() + "\n"))
 			} else {
@@ -0,0 +1,83 @@
+package libpod
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/containers/podman/v2/libpod"
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/containers/podman/v2/pkg/api/handlers/utils"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/containers/podman/v2/pkg/domain/infra/abi"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
+)
+
+func ReserveName(w http.ResponseWriter, r *http.Request) {
+	var (
+		runtime = r.Context().Value("runtime").(*libpod.Runtime)
+		decoder = r.Context().Value("decoder").(*schema.Decoder)
+	)
+	query := struct {
+		Name string `schema:"name"`
+		TTL  int64  `schema:"ttl"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	options := entities.NameReserveOptions{Name: query.Name, TTL: time.Duration(query.TTL) * time.Second}
+	report, err := containerEngine.NameReserve(r.Context(), options)
+	if err != nil {
+		nameError(w, query.Name, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+func ListReservedNames(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	reports, err := containerEngine.NameList(r.Context())
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, reports)
+}
+
+func ReleaseName(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	name := utils.GetName(r)
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	reports, err := containerEngine.NameRelease(r.Context(), []string{name})
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if reports[0].Err != nil {
+		nameError(w, name, reports[0].Err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusNoContent, "")
+}
+
+// nameError writes the error of an operation on a reserved name with the
+// matching status code.
+func nameError(w http.ResponseWriter, name string, err error) {
+	switch errors.Cause(err) {
+	case define.ErrNameNotReserved:
+		utils.Error(w, "Name not reserved: "+name, http.StatusNotFound, err)
+	case define.ErrNameReserved, define.ErrCtrExists:
+		utils.Error(w, http.StatusText(http.StatusConflict), http.StatusConflict, err)
+	case define.ErrInvalidArg, define.RegexError:
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, err)
+	default:
+		utils.InternalServerError(w, err)
+	}
+}
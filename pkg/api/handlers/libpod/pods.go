@@ -262,51 +262,131 @@ func PodPruneHelper(w http.ResponseWriter, r *http.Request) ([]*entities.PodPrun
 }
 
 func PodPause(w http.ResponseWriter, r *http.Request) {
-	var errs []error //nolint
 	runtime := r.Context().Value("runtime").(*libpod.Runtime)
 	name := utils.GetName(r)
-	pod, err := runtime.LookupPod(name)
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	reports, err := containerEngine.PodPause(r.Context(), []string{name}, entities.PodPauseOptions{})
 	if err != nil {
-		utils.PodNotFound(w, name, err)
+		if errors.Cause(err) == define.ErrNoSuchPod {
+			utils.PodNotFound(w, name, err)
+			return
+		}
+		utils.InternalServerError(w, err)
 		return
 	}
-	responses, err := pod.Pause(r.Context())
-	if err != nil && errors.Cause(err) != define.ErrPodPartialFail {
-		utils.Error(w, "Something went wrong", http.StatusInternalServerError, err)
+	// The pod could not be paused at all
+	if report := reports[0]; len(report.Errs) > 0 && len(report.Containers) == 0 {
+		utils.Error(w, "Something went wrong", http.StatusInternalServerError, report.Errs[0])
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, reports[0])
+}
+
+func PodUnpause(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	name := utils.GetName(r)
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	reports, err := containerEngine.PodUnpause(r.Context(), []string{name}, entities.PodunpauseOptions{})
+	if err != nil {
+		if errors.Cause(err) == define.ErrNoSuchPod {
+			utils.PodNotFound(w, name, err)
+			return
+		}
+		utils.InternalServerError(w, err)
 		return
 	}
-	for id, v := range responses {
-		errs = append(errs, errors.Wrapf(v, "error pausing container %s", id))
+	// The pod could not be unpaused at all
+	if report := reports[0]; len(report.Errs) > 0 && len(report.Containers) == 0 {
+		utils.Error(w, "failed to pause pod", http.StatusInternalServerError, report.Errs[0])
+		return
 	}
-	report := entities.PodPauseReport{
-		Errs: errs,
-		Id:   pod.ID(),
+	utils.WriteResponse(w, http.StatusOK, reports[0])
+}
+
+func PodUpdate(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	var options entities.PodUpdateOptions
+	if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, errors.Wrap(err, "Decode()"))
+		return
+	}
+	name := utils.GetName(r)
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	report, err := containerEngine.PodUpdate(r.Context(), name, options)
+	if err != nil {
+		switch errors.Cause(err) {
+		case define.ErrNoSuchPod:
+			utils.PodNotFound(w, name, err)
+		case define.ErrInvalidArg:
+			utils.Error(w, "Something went wrong.", http.StatusBadRequest, err)
+		default:
+			utils.InternalServerError(w, err)
+		}
+		return
 	}
 	utils.WriteResponse(w, http.StatusOK, report)
 }
 
-func PodUnpause(w http.ResponseWriter, r *http.Request) {
-	var errs []error //nolint
+func PodClone(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	var options entities.PodCloneOptions
+	if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+		utils.Error(w, "Something went wrong.", http.StatusInternalServerError, errors.Wrap(err, "Decode()"))
+		return
+	}
 	name := utils.GetName(r)
-	pod, err := runtime.LookupPod(name)
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	report, err := containerEngine.PodClone(r.Context(), name, options)
 	if err != nil {
-		utils.PodNotFound(w, name, err)
+		switch errors.Cause(err) {
+		case define.ErrNoSuchPod:
+			utils.PodNotFound(w, name, err)
+		case define.ErrPodExists, define.ErrCtrExists:
+			utils.Error(w, "Something went wrong.", http.StatusConflict, err)
+		case define.ErrInvalidArg:
+			utils.Error(w, "Something went wrong.", http.StatusBadRequest, err)
+		default:
+			utils.InternalServerError(w, err)
+		}
 		return
 	}
-	responses, err := pod.Unpause(r.Context())
-	if err != nil && errors.Cause(err) != define.ErrPodPartialFail {
-		utils.Error(w, "failed to pause pod", http.StatusInternalServerError, err)
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+func PodRename(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	decoder := r.Context().Value("decoder").(*schema.Decoder)
+	query := struct {
+		Name string `schema:"name"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
 		return
 	}
-	for id, v := range responses {
-		errs = append(errs, errors.Wrapf(v, "error unpausing container %s", id))
+	if query.Name == "" {
+		utils.Error(w, "Something went wrong.", http.StatusBadRequest, errors.Errorf("the new name of the pod must be specified"))
+		return
 	}
-	report := entities.PodUnpauseReport{
-		Errs: errs,
-		Id:   pod.ID(),
+
+	name := utils.GetName(r)
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	if err := containerEngine.PodRename(r.Context(), name, entities.PodRenameOptions{NewName: query.Name}); err != nil {
+		switch errors.Cause(err) {
+		case define.ErrNoSuchPod:
+			utils.PodNotFound(w, name, err)
+		case define.ErrPodExists, define.ErrCtrExists:
+			utils.Error(w, "Something went wrong.", http.StatusConflict, err)
+		case define.ErrInvalidArg:
+			utils.Error(w, "Something went wrong.", http.StatusBadRequest, err)
+		default:
+			utils.InternalServerError(w, err)
+		}
+		return
 	}
-	utils.WriteResponse(w, http.StatusOK, &report)
+	utils.WriteResponse(w, http.StatusNoContent, nil)
 }
 
 func PodTop(w http.ResponseWriter, r *http.Request) {
@@ -356,10 +436,10 @@ func PodKill(w http.ResponseWriter, r *http.Request) {
 		runtime = r.Context().Value("runtime").(*libpod.Runtime)
 		decoder = r.Context().Value("decoder").(*schema.Decoder)
 		signal  = "SIGKILL"
-		errs    []error //nolint
 	)
 	query := struct {
-		Signal string `schema:"signal"`
+		Signal       string `schema:"signal"`
+		ExcludeInfra bool   `schema:"excludeInfra"`
 	}{
 		// override any golang type defaults
 	}
@@ -368,7 +448,7 @@ func PodKill(w http.ResponseWriter, r *http.Request) {
 			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
 		return
 	}
-	if _, found := r.URL.Query()["signal"]; found {
+	if query.Signal != "" {
 		signal = query.Signal
 	}
 
@@ -402,22 +482,21 @@ func PodKill(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	responses, err := pod.Kill(r.Context(), uint(sig))
-	if err != nil && errors.Cause(err) != define.ErrPodPartialFail {
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	reports, err := containerEngine.PodKill(r.Context(), []string{pod.ID()}, entities.PodKillOptions{
+		ExcludeInfra: query.ExcludeInfra,
+		Signal:       signal,
+	})
+	if err != nil {
 		utils.Error(w, "failed to kill pod", http.StatusInternalServerError, err)
 		return
 	}
-
-	for _, v := range responses {
-		if v != nil {
-			errs = append(errs, v)
-		}
-	}
-	report := &entities.PodKillReport{
-		Errs: errs,
-		Id:   pod.ID(),
+	// The pod could not be killed at all
+	if report := reports[0]; len(report.Errs) > 0 && len(report.Containers) == 0 {
+		utils.Error(w, "failed to kill pod", http.StatusInternalServerError, report.Errs[0])
+		return
 	}
-	utils.WriteResponse(w, http.StatusOK, report)
+	utils.WriteResponse(w, http.StatusOK, reports[0])
 }
 
 func PodExists(w http.ResponseWriter, r *http.Request) {
@@ -438,6 +517,7 @@ func PodStats(w http.ResponseWriter, r *http.Request) {
 	query := struct {
 		NamesOrIDs []string `schema:"namesOrIDs"`
 		All        bool     `schema:"all"`
+		Sum        bool     `schema:"sum"`
 	}{
 		// default would go here
 	}
@@ -448,7 +528,7 @@ func PodStats(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Validate input.
-	options := entities.PodStatsOptions{All: query.All}
+	options := entities.PodStatsOptions{All: query.All, Sum: query.Sum}
 	if err := entities.ValidatePodStatsOptions(query.NamesOrIDs, &options); err != nil {
 		utils.InternalServerError(w, err)
 	}
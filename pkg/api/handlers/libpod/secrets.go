@@ -0,0 +1,98 @@
+package libpod
+
+import (
+	"net/http"
+
+	"github.com/containers/podman/v2/libpod"
+	"github.com/containers/podman/v2/pkg/api/handlers/utils"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/containers/podman/v2/pkg/domain/infra/abi"
+	"github.com/containers/podman/v2/pkg/secrets"
+	"github.com/gorilla/schema"
+	"github.com/pkg/errors"
+)
+
+func CreateSecret(w http.ResponseWriter, r *http.Request) {
+	var (
+		runtime = r.Context().Value("runtime").(*libpod.Runtime)
+		decoder = r.Context().Value("decoder").(*schema.Decoder)
+	)
+	query := struct {
+		Name   string `schema:"name"`
+		Driver string `schema:"driver"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest,
+			errors.Wrapf(err, "failed to parse parameters for %s", r.URL.String()))
+		return
+	}
+
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	options := entities.SecretCreateOptions{Driver: query.Driver}
+	report, err := containerEngine.SecretCreate(r.Context(), query.Name, r.Body, options)
+	if err != nil {
+		secretError(w, query.Name, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+func ListSecrets(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	reports, err := containerEngine.SecretList(r.Context())
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, reports)
+}
+
+func InspectSecret(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	name := utils.GetName(r)
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	reports, errs, err := containerEngine.SecretInspect(r.Context(), []string{name})
+	if err != nil {
+		secretError(w, name, err)
+		return
+	}
+	if len(errs) > 0 {
+		utils.Error(w, "No such secret: "+name, http.StatusNotFound, errs[0])
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, reports[0])
+}
+
+func RemoveSecret(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	name := utils.GetName(r)
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	reports, err := containerEngine.SecretRm(r.Context(), []string{name}, entities.SecretRmOptions{})
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if reports[0].Err != nil {
+		secretError(w, name, reports[0].Err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusNoContent, "")
+}
+
+// secretError writes the error of an operation on the named secret with the
+// matching status code.
+func secretError(w http.ResponseWriter, name string, err error) {
+	switch errors.Cause(err) {
+	case secrets.ErrNoSuchSecret:
+		utils.Error(w, "No such secret: "+name, http.StatusNotFound, err)
+	case secrets.ErrSecretNameInUse, secrets.ErrSecretInUse:
+		utils.Error(w, http.StatusText(http.StatusConflict), http.StatusConflict, err)
+	case secrets.ErrInvalidSecretName, secrets.ErrInvalidSecretData, secrets.ErrAmbiguousSecret, secrets.ErrUnknownDriver:
+		utils.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest, err)
+	default:
+		utils.InternalServerError(w, err)
+	}
+}
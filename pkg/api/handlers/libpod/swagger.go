@@ -14,6 +14,15 @@ import (
 // DefaultPodmanSwaggerSpec provides the default path to the podman swagger spec file
 const DefaultPodmanSwaggerSpec = "/usr/share/containers/podman/swagger.yaml"
 
+// Create a batch of containers
+// swagger:response ContainerCreateBatchResponse
+type swagCtrCreateBatchResponse struct {
+	// in:body
+	Body struct {
+		entities.ContainerCreateBatchResponse
+	}
+}
+
 // List Containers
 // swagger:response ListContainers
 type swagInspectPodResponse struct {
@@ -119,6 +128,13 @@ type swagNetworkCreateReport struct {
 	Body entities.NetworkCreateReport
 }
 
+// Network prune
+// swagger:response NetworkPruneResponse
+type swagNetworkPruneResponse struct {
+	// in:body
+	Body []entities.NetworkPruneReport
+}
+
 func ServeSwagger(w http.ResponseWriter, r *http.Request) {
 	path := DefaultPodmanSwaggerSpec
 	if p, found := os.LookupEnv("PODMAN_SWAGGER_SPEC"); found {
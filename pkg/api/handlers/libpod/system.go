@@ -20,8 +20,9 @@ func SystemPrune(w http.ResponseWriter, r *http.Request) {
 		systemPruneReport = new(entities.SystemPruneReport)
 	)
 	query := struct {
-		All     bool `schema:"all"`
-		Volumes bool `schema:"volumes"`
+		All         bool `schema:"all"`
+		Volumes     bool `schema:"volumes"`
+		Interrupted bool `schema:"interrupted"`
 	}{}
 
 	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
@@ -68,6 +69,15 @@ func SystemPrune(w http.ResponseWriter, r *http.Request) {
 		}
 		systemPruneReport.VolumePruneReport = volumePruneReport
 	}
+
+	if query.Interrupted {
+		report, err := runtime.ImageRuntime().PruneInterruptedPulls()
+		if err != nil {
+			utils.InternalServerError(w, err)
+			return
+		}
+		systemPruneReport.InterruptedPullsPruneReport = report
+	}
 	utils.WriteResponse(w, http.StatusOK, systemPruneReport)
 }
 
@@ -82,3 +92,14 @@ func DiskUsage(w http.ResponseWriter, r *http.Request) {
 	}
 	utils.WriteResponse(w, http.StatusOK, response)
 }
+
+func Devices(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value("runtime").(*libpod.Runtime)
+	ic := abi.ContainerEngine{Libpod: runtime}
+	response, err := ic.SystemDevices(r.Context(), entities.SystemDevicesOptions{})
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, response)
+}
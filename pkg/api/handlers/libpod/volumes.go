@@ -13,6 +13,7 @@ import (
 	"github.com/containers/podman/v2/pkg/domain/infra/abi/parse"
 	"github.com/gorilla/schema"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 func CreateVolume(w http.ResponseWriter, r *http.Request) {
@@ -184,7 +185,8 @@ func pruneVolumesHelper(r *http.Request) ([]*entities.VolumePruneReport, error)
 		decoder = r.Context().Value("decoder").(*schema.Decoder)
 	)
 	query := struct {
-		Filters map[string][]string `schema:"filters"`
+		Filters     map[string][]string `schema:"filters"`
+		ForceUnused bool                `schema:"forceUnused"`
 	}{
 		// override any golang type defaults
 	}
@@ -199,15 +201,17 @@ func pruneVolumesHelper(r *http.Request) ([]*entities.VolumePruneReport, error)
 		return nil, err
 	}
 
-	pruned, err := runtime.PruneVolumes(r.Context(), filterFuncs)
+	pruned, err := runtime.PruneVolumes(r.Context(), filterFuncs, query.ForceUnused)
 	if err != nil {
 		return nil, err
 	}
 	reports := make([]*entities.VolumePruneReport, 0, len(pruned))
-	for k, v := range pruned {
+	for _, p := range pruned {
 		reports = append(reports, &entities.VolumePruneReport{
-			Err: v,
-			Id:  k,
+			Err:        p.Err,
+			Id:         p.Name,
+			Size:       p.Size,
+			Containers: p.Containers,
 		})
 	}
 	return reports, nil
@@ -244,3 +248,57 @@ func RemoveVolume(w http.ResponseWriter, r *http.Request) {
 	}
 	utils.WriteResponse(w, http.StatusNoContent, "")
 }
+
+// headerWriter writes the response header when the first byte of the body is
+// written, so errors occurring before anything was streamed can still be
+// reported to the client.
+type headerWriter struct {
+	w       http.ResponseWriter
+	written bool
+}
+
+func (hw *headerWriter) Write(p []byte) (int, error) {
+	if !hw.written {
+		hw.w.Header().Set("Content-Type", "application/x-tar")
+		hw.w.WriteHeader(http.StatusOK)
+		hw.written = true
+	}
+	return hw.w.Write(p)
+}
+
+func ExportVolume(w http.ResponseWriter, r *http.Request) {
+	var (
+		runtime = r.Context().Value("runtime").(*libpod.Runtime)
+	)
+	name := utils.GetName(r)
+	vol, err := runtime.LookupVolume(name)
+	if err != nil {
+		utils.VolumeNotFound(w, name, err)
+		return
+	}
+	hw := &headerWriter{w: w}
+	if err := vol.Export(hw); err != nil {
+		if !hw.written {
+			utils.InternalServerError(w, err)
+			return
+		}
+		logrus.Errorf("Error exporting volume %s: %v", name, err)
+	}
+}
+
+func ImportVolume(w http.ResponseWriter, r *http.Request) {
+	var (
+		runtime = r.Context().Value("runtime").(*libpod.Runtime)
+	)
+	name := utils.GetName(r)
+	vol, err := runtime.LookupVolume(name)
+	if err != nil {
+		utils.VolumeNotFound(w, name, err)
+		return
+	}
+	if err := vol.Import(r.Body); err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusNoContent, "")
+}
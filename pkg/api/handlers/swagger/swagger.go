@@ -70,6 +70,13 @@ type swagImageDeleteResponse struct {
 	Body []image.ImageDeleteResponse
 }
 
+// Image lookup
+// swagger:response DocsImageLookupResponse
+type swagImageLookupResponse struct {
+	// in:body
+	Body []entities.ImageLookupReport
+}
+
 // Search results
 // swagger:response DocsSearchResponse
 type swagSearchResponse struct {
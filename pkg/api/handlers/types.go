@@ -153,6 +153,11 @@ type HistoryResponse struct {
 	Tags      []string
 	Size      int64
 	Comment   string
+	// EmptyLayer, Layer and LayerDigest are podman extensions and
+	// are ignored by Docker clients.
+	EmptyLayer  bool   `json:",omitempty"`
+	Layer       string `json:",omitempty"`
+	LayerDigest string `json:",omitempty"`
 }
 
 type ImageLayer struct{}
@@ -166,6 +171,9 @@ type ImageTreeResponse struct {
 
 type ExecCreateConfig struct {
 	docker.ExecConfig
+	// ConsoleSize is the initial size of the TTY as height and width.
+	// Only used if Tty is set.
+	ConsoleSize *[2]uint `json:",omitempty"`
 }
 
 type ExecCreateResponse struct {
@@ -29,15 +29,21 @@ func GetPods(w http.ResponseWriter, r *http.Request) ([]*entities.ListPodsReport
 		UnSupportedParameter("digests")
 	}
 
+	// The containers of all the pods are looked up at once, for both the
+	// filters and the reports.
+	members, err := runtime.PodContainersSnapshot()
+	if err != nil {
+		return nil, err
+	}
 	filters := make([]libpod.PodFilter, 0, len(query.Filters))
 	for k, v := range query.Filters {
-		f, err := lpfilters.GeneratePodFilterFunc(k, v)
+		f, err := lpfilters.GeneratePodFilterFunc(k, v, runtime, members)
 		if err != nil {
 			return nil, err
 		}
 		filters = append(filters, f)
 	}
-	pods, err := runtime.Pods(filters...)
+	pods, err = runtime.Pods(filters...)
 	if err != nil {
 		return nil, err
 	}
@@ -48,11 +54,8 @@ func GetPods(w http.ResponseWriter, r *http.Request) ([]*entities.ListPodsReport
 
 	lps := make([]*entities.ListPodsReport, 0, len(pods))
 	for _, pod := range pods {
-		status, err := pod.GetPodStatus()
-		if err != nil {
-			return nil, err
-		}
-		ctrs, err := pod.AllContainers()
+		ctrs := members[pod.ID()]
+		status, err := libpod.PodStatusFromContainers(ctrs)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RequireAuthToken makes the server reject all requests which do not carry
+// the given bearer token in their Authorization header.  It is meant for
+// services listening on TCP, where the permissions of the socket file do not
+// restrict who can connect.  It has to be called before Serve.
+func (s *APIServer) RequireAuthToken(token string) {
+	next := s.Server.Handler
+	s.Server.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !validAuthToken(r.Header.Get("Authorization"), token) {
+			logrus.Infof("Failed Request: (%d:%s) for %s:'%s'", http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), r.Method, r.URL.String())
+			w.Header().Set("WWW-Authenticate", `Bearer realm="podman"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// validAuthToken returns whether the Authorization header carries the given
+// bearer token.
+func validAuthToken(header, token string) bool {
+	given := strings.TrimPrefix(header, "Bearer ")
+	if given == header {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1
+}
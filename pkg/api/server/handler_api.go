@@ -65,7 +65,7 @@ func (s *APIServer) APIHandler(h http.HandlerFunc) http.HandlerFunc {
 			h(w, r)
 			logrus.Debugf("APIHandler(%s) -- %s %s END", rid, r.Method, r.URL.String())
 		}
-		fn(w, r)
+		s.metrics.instrument(fn)(w, r)
 	}
 }
 
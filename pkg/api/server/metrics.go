@@ -0,0 +1,133 @@
+package server
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// apiMetrics collects the request metrics of the API server
+type apiMetrics struct {
+	registry *prometheus.Registry
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	inFlight prometheus.Gauge
+}
+
+func newAPIMetrics() *apiMetrics {
+	m := apiMetrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "podman",
+			Subsystem: "api",
+			Name:      "requests_total",
+			Help:      "Number of API requests handled, partitioned by method, endpoint and status code.",
+		}, []string{"method", "endpoint", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "podman",
+			Subsystem: "api",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of API requests, partitioned by method and endpoint.",
+			Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+		}, []string{"method", "endpoint"}),
+		inFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "podman",
+			Subsystem: "api",
+			Name:      "requests_in_flight",
+			Help:      "Number of API requests currently being handled.",
+		}),
+	}
+	m.registry.MustRegister(m.requests, m.duration, m.inFlight)
+	m.registry.MustRegister(prometheus.NewGoCollector())
+	return &m
+}
+
+// handler serves the collected metrics in the Prometheus text format
+func (m *apiMetrics) handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// instrument wraps h to record the request in the metrics and, if a tracer
+// is registered via --trace, in a span named after the endpoint.  The span
+// is added to the request context, so work done by libpod shows up as child
+// spans.
+func (m *apiMetrics) instrument(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		endpoint := endpointTemplate(r)
+
+		m.inFlight.Inc()
+		defer m.inFlight.Dec()
+
+		if opentracing.IsGlobalTracerRegistered() {
+			tracer := opentracing.GlobalTracer()
+			parent, _ := tracer.Extract(opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(r.Header))
+			span := tracer.StartSpan(r.Method+" "+endpoint, ext.RPCServerOption(parent))
+			ext.HTTPMethod.Set(span, r.Method)
+			ext.HTTPUrl.Set(span, r.URL.String())
+			defer span.Finish()
+			r = r.WithContext(opentracing.ContextWithSpan(r.Context(), span))
+		}
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+		h(rec, r)
+
+		if span := opentracing.SpanFromContext(r.Context()); span != nil {
+			ext.HTTPStatusCode.Set(span, uint16(rec.status))
+		}
+		m.duration.WithLabelValues(r.Method, endpoint).Observe(time.Since(start).Seconds())
+		m.requests.WithLabelValues(r.Method, endpoint, strconv.Itoa(rec.status)).Inc()
+	}
+}
+
+// endpointTemplate returns the path template of the route matching r, without
+// the version prefix, to keep the number of metric labels bounded.
+func endpointTemplate(r *http.Request) string {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return "<unknown>"
+	}
+	path, err := route.GetPathTemplate()
+	if err != nil {
+		return "<unknown>"
+	}
+	return strings.TrimPrefix(path, VersionedPath(""))
+}
+
+// statusRecorder records the status code written by a handler.  Streaming and
+// attaching handlers rely on flushing and hijacking the connection, so these
+// are passed through.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(code int) {
+	s.status = code
+	s.ResponseWriter.WriteHeader(code)
+}
+
+func (s *statusRecorder) Flush() {
+	if flusher, ok := s.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (s *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := s.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("connection cannot be hijacked")
+	}
+	s.status = http.StatusSwitchingProtocols
+	return hijacker.Hijack()
+}
@@ -91,7 +91,7 @@ func (s *APIServer) registerArchiveHandlers(r *mux.Router) error {
 		Libpod
 	*/
 
-	// swagger:operation POST /libpod/containers/{name}/archive libpod libpodPutArchive
+	// swagger:operation PUT /libpod/containers/{name}/archive libpod libpodPutArchive
 	// ---
 	//  summary: Copy files into a container
 	//  description: Copy a tar archive of files into a container
@@ -111,10 +111,15 @@ func (s *APIServer) registerArchiveHandlers(r *mux.Router) error {
 	//     description: Path to a directory in the container to extract
 	//     required: true
 	//   - in: query
-	//     name: pause
+	//     name: chown
 	//     type: boolean
-	//     description: pause the container while copying (defaults to true)
+	//     description: change the ownership of the copied files to the primary uid/gid of the container
 	//     default: true
+	//   - in: query
+	//     name: noOverwriteDirNonDir
+	//     type: boolean
+	//     description: return an error instead of replacing an existing directory with a non-directory and vice versa
+	//     default: false
 	//   - in: body
 	//     name: request
 	//     description: tarfile of files to copy into the container
@@ -151,6 +156,11 @@ func (s *APIServer) registerArchiveHandlers(r *mux.Router) error {
 	//     type: string
 	//     description: Path to a directory in the container to extract
 	//     required: true
+	//   - in: query
+	//     name: offset
+	//     type: integer
+	//     description: skip the first bytes of the archive to resume an interrupted transfer. The path must not have changed in between.
+	//     default: 0
 	//  responses:
 	//    200:
 	//      description: no error
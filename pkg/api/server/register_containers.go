@@ -291,9 +291,37 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	r.HandleFunc(VersionedPath("/containers/{name}/pause"), s.APIHandler(compat.PauseContainer)).Methods(http.MethodPost)
 	// Added non version path to URI to support docker non versioned paths
 	r.HandleFunc("/containers/{name}/pause", s.APIHandler(compat.PauseContainer)).Methods(http.MethodPost)
-	r.HandleFunc(VersionedPath("/containers/{name}/rename"), s.APIHandler(compat.UnsupportedHandler)).Methods(http.MethodPost)
+	// swagger:operation POST /containers/{name}/rename compat renameContainer
+	// ---
+	// tags:
+	//   - containers (compat)
+	// summary: Rename an existing container
+	// description: Change the name of an existing container.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: Full or partial ID or full name of the container to rename
+	//  - in: query
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: New name for the container
+	// produces:
+	// - application/json
+	// responses:
+	//   204:
+	//     description: no error
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/containers/{name}/rename"), s.APIHandler(compat.RenameContainer)).Methods(http.MethodPost)
 	// Added non version path to URI to support docker non versioned paths
-	r.HandleFunc("/containers/{name}/rename", s.APIHandler(compat.UnsupportedHandler)).Methods(http.MethodPost)
+	r.HandleFunc("/containers/{name}/rename", s.APIHandler(compat.RenameContainer)).Methods(http.MethodPost)
 	// swagger:operation POST /containers/{name}/restart compat restartContainer
 	// ---
 	// tags:
@@ -405,6 +433,8 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//     $ref: "#/responses/ContainerAlreadyStoppedError"
 	//   404:
 	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
 	//   500:
 	//     $ref: "#/responses/InternalError"
 	r.HandleFunc(VersionedPath("/containers/{name}/stop"), s.APIHandler(compat.StopContainer)).Methods(http.MethodPost)
@@ -640,6 +670,47 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//     500:
 	//       $ref: "#/responses/InternalError"
 	r.HandleFunc(VersionedPath("/libpod/containers/create"), s.APIHandler(libpod.CreateContainer)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/containers/create/batch libpod libpodCreateContainerBatch
+	// ---
+	//   summary: Create a batch of containers
+	//   description: |
+	//     Create multiple containers from one spec, e.g. for scale testing.  The containers
+	//     are numbered from 0.  The number of a container is added to the host ports and
+	//     static IP and MAC addresses of the spec, so each container gets its own.
+	//   tags:
+	//    - containers
+	//   produces:
+	//   - application/json
+	//   parameters:
+	//    - in: query
+	//      name: count
+	//      type: integer
+	//      default: 1
+	//      description: number of containers to create, at most 1000
+	//    - in: query
+	//      name: namepattern
+	//      type: string
+	//      description: name of the containers, %d is replaced by the number of each container. Names are generated if not set.
+	//    - in: query
+	//      name: batchsize
+	//      type: integer
+	//      default: 0
+	//      description: number of containers added to the database in one transaction, 0 adds all of them at once. If a batch fails, the containers of earlier batches are removed again.
+	//    - in: body
+	//      name: create
+	//      description: attributes for creating the containers
+	//      schema:
+	//        $ref: "#/definitions/SpecGenerator"
+	//   responses:
+	//     201:
+	//       $ref: "#/responses/ContainerCreateBatchResponse"
+	//     400:
+	//       $ref: "#/responses/BadParamError"
+	//     409:
+	//       $ref: "#/responses/ConflictError"
+	//     500:
+	//       $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/create/batch"), s.APIHandler(libpod.CreateContainerBatch)).Methods(http.MethodPost)
 	// swagger:operation GET /libpod/containers/json libpod libpodListContainers
 	// ---
 	// tags:
@@ -666,6 +737,11 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//    default: false
 	//    description: Ignored. Previously included details on pod name and ID that are currently included by default.
 	//  - in: query
+	//    name: pids
+	//    type: boolean
+	//    default: false
+	//    description: Return the number of processes of running containers and their limit as fields PidsCurrent and PidsLimit.
+	//  - in: query
 	//    name: size
 	//    type: boolean
 	//    default: false
@@ -676,6 +752,11 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//    default: false
 	//    description: Sync container state with OCI runtime
 	//  - in: query
+	//    name: external
+	//    type: boolean
+	//    default: false
+	//    description: Also list the containers in storage not controlled by Podman, e.g., created by Buildah or CRI-O
+	//  - in: query
 	//    name: filters
 	//    type: string
 	//    description: |
@@ -1125,7 +1206,7 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	// tags:
 	//  - containers
 	// summary: Wait on a container
-	// description: Wait on a container to met a given condition
+	// description: Wait on a container to met one of the given conditions
 	// parameters:
 	//  - in: path
 	//    name: name
@@ -1134,20 +1215,28 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//    description: the name or ID of the container
 	//  - in: query
 	//    name: condition
-	//    type: string
+	//    type: array
+	//    items:
+	//      type: string
 	//    description: |
-	//      wait until container is to a given condition. default is stopped. valid conditions are:
+	//      wait until container meets one of the given conditions. default is stopped. valid conditions are:
 	//        - configured
 	//        - created
 	//        - exited
 	//        - paused
 	//        - running
 	//        - stopped
+	//        - removing
+	//        - healthy
+	//        - unhealthy
+	//        - removed
 	// produces:
 	// - application/json
 	// responses:
 	//   200:
 	//     $ref: "#/responses/ContainerWaitResponse"
+	//   400:
+	//     $ref: "#/responses/BadParamError"
 	//   404:
 	//     $ref: "#/responses/NoSuchContainer"
 	//   500:
@@ -1199,6 +1288,8 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//     $ref: "#/responses/ContainerAlreadyStoppedError"
 	//   404:
 	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
 	//   500:
 	//     $ref: "#/responses/InternalError"
 	r.HandleFunc(VersionedPath("/libpod/containers/{name}/stop"), s.APIHandler(compat.StopContainer)).Methods(http.MethodPost)
@@ -1262,7 +1353,9 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	// tags:
 	//  - containers
 	// summary: Resize a container's TTY
-	// description: Resize the terminal attached to a container (for use with Attach).
+	// description: |
+	//   Resize the terminal attached to a container (for use with Attach).
+	//   If the container has not been started yet, the size is saved and applied when the container starts.
 	// parameters:
 	//  - in: path
 	//    name: name
@@ -1286,6 +1379,8 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//     $ref: "#/responses/ok"
 	//   404:
 	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
 	//   500:
 	//     $ref: "#/responses/InternalError"
 	r.HandleFunc(VersionedPath("/libpod/containers/{name}/resize"), s.APIHandler(compat.ResizeTTY)).Methods(http.MethodPost)
@@ -1342,6 +1437,10 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//    name: ignoreRootFS
 	//    type: boolean
 	//    description: do not include root file-system changes when exporting
+	//  - in: query
+	//    name: ignoreVolumes
+	//    type: boolean
+	//    description: do not include the content of named volumes when exporting
 	// produces:
 	// - application/json
 	// responses:
@@ -1389,6 +1488,10 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//    type: boolean
 	//    description: do not include root file-system changes when exporting
 	//  - in: query
+	//    name: ignoreVolumes
+	//    type: boolean
+	//    description: do not restore the content of named volumes when importing
+	//  - in: query
 	//    name: ignoreStaticIP
 	//    type: boolean
 	//    description: ignore IP address if set statically
@@ -1463,5 +1566,203 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/InternalError"
 	r.HandleFunc(VersionedPath("/libpod/containers/{name}/init"), s.APIHandler(libpod.InitContainer)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/containers/{name}/clone libpod libpodCloneContainer
+	// ---
+	// tags:
+	//  - containers
+	// summary: Clone a container
+	// description: Create a new container from the configuration of an existing container.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container to clone
+	//  - in: query
+	//    name: name
+	//    type: string
+	//    description: the name of the clone
+	//  - in: query
+	//    name: image
+	//    type: string
+	//    description: create the root file-system of the clone from this image instead of the image of the container
+	//  - in: query
+	//    name: run
+	//    type: boolean
+	//    description: start the clone after creating it
+	//  - in: query
+	//    name: cpuPeriod
+	//    type: integer
+	//    description: CPU CFS period of the clone
+	//  - in: query
+	//    name: cpuQuota
+	//    type: integer
+	//    description: CPU CFS quota of the clone
+	//  - in: query
+	//    name: cpuShares
+	//    type: integer
+	//    description: CPU shares of the clone
+	//  - in: query
+	//    name: cpusetCpus
+	//    type: string
+	//    description: CPUs the clone may run on
+	//  - in: query
+	//    name: memory
+	//    type: integer
+	//    description: memory limit of the clone in bytes
+	// produces:
+	// - application/json
+	// responses:
+	//   201:
+	//     $ref: "#/responses/ContainerCreateResponse"
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/clone"), s.APIHandler(libpod.CloneContainer)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/containers/{name}/update libpod libpodUpdateContainer
+	// ---
+	// tags:
+	//  - containers
+	// summary: Update the resource limits, labels and annotations of a container
+	// description: Change the cgroup resource limits, labels or OCI annotations of a container.  The limits of a running container are changed immediately, and are kept when the container is restarted.  Changed annotations are passed to the OCI runtime when the container is started the next time.  Limits, labels and annotations that are not set are not changed.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: cpuPeriod
+	//    type: integer
+	//    description: CPU CFS period
+	//  - in: query
+	//    name: cpuQuota
+	//    type: integer
+	//    description: CPU CFS quota
+	//  - in: query
+	//    name: cpuShares
+	//    type: integer
+	//    description: CPU shares (relative weight)
+	//  - in: query
+	//    name: cpusetCpus
+	//    type: string
+	//    description: CPUs the container may run on
+	//  - in: query
+	//    name: cpusetMems
+	//    type: string
+	//    description: memory nodes the container may use
+	//  - in: query
+	//    name: memory
+	//    type: integer
+	//    description: memory limit in bytes
+	//  - in: query
+	//    name: memorySwap
+	//    type: integer
+	//    description: limit of memory plus swap in bytes, -1 is unlimited
+	//  - in: query
+	//    name: pidsLimit
+	//    type: integer
+	//    description: maximum number of processes, -1 is unlimited
+	//  - in: query
+	//    name: blkioWeight
+	//    type: integer
+	//    description: block IO weight (relative weight) between 10 and 1000
+	//  - in: query
+	//    name: labels
+	//    type: array
+	//    items:
+	//      type: string
+	//    description: labels to add to the container, as key=value pairs
+	//  - in: query
+	//    name: removeLabels
+	//    type: array
+	//    items:
+	//      type: string
+	//    description: keys of the labels to remove from the container
+	//  - in: query
+	//    name: annotations
+	//    type: array
+	//    items:
+	//      type: string
+	//    description: OCI annotations to add to the container, as key=value pairs
+	//  - in: query
+	//    name: removeAnnotations
+	//    type: array
+	//    items:
+	//      type: string
+	//    description: keys of the OCI annotations to remove from the container
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     description: the ID of the container
+	//     schema:
+	//       type: object
+	//       properties:
+	//         Id:
+	//           type: string
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/update"), s.APIHandler(libpod.UpdateContainer)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/containers/{name}/drop-caches libpod libpodDropCachesContainer
+	// ---
+	// tags:
+	//  - containers
+	// summary: Drop the caches of a container
+	// description: Reclaim memory of a running or paused container, mostly page cache, without restarting it.  On cgroup v2, this requires Linux 5.19 or newer.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: amount
+	//    type: integer
+	//    description: number of bytes to reclaim, as much as possible if not set.  Requires cgroup v2.
+	// produces:
+	// - application/json
+	// responses:
+	//   204:
+	//     description: no error
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/drop-caches"), s.APIHandler(libpod.DropCachesContainer)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/containers/{name}/rename libpod libpodRenameContainer
+	// ---
+	// tags:
+	//  - containers
+	// summary: Rename an existing container
+	// description: Change the name of an existing container.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: Full or partial ID or full name of the container to rename
+	//  - in: query
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: New name for the container
+	// produces:
+	// - application/json
+	// responses:
+	//   204:
+	//     description: no error
+	//   404:
+	//     $ref: "#/responses/NoSuchContainer"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/rename"), s.APIHandler(compat.RenameContainer)).Methods(http.MethodPost)
 	return nil
 }
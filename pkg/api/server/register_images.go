@@ -34,6 +34,10 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//    name: tag
 	//    type: string
 	//    description: needs description
+	//  - in: query
+	//    name: platform
+	//    type: string
+	//    description: Platform in the format os[/arch[/variant]] to pull the image for
 	//  - in: header
 	//    name: X-Registry-Auth
 	//    type: string
@@ -618,6 +622,13 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//      Target build stage
 	//      (As of version 1.xx)
 	//  - in: query
+	//    name: timestamp
+	//    type: integer
+	//    description: |
+	//      Set the created timestamp of the image and of the files in its layers
+	//      to the given number of seconds since the epoch, to allow for reproducible builds
+	//      (As of version 3.0)
+	//  - in: query
 	//    name: outputs
 	//    type: string
 	//    default:
@@ -689,6 +700,30 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: '#/responses/InternalError'
 	r.Handle(VersionedPath("/libpod/images/{name:.*}/push"), s.APIHandler(libpod.PushImage)).Methods(http.MethodPost)
+	// swagger:operation GET /libpod/images/lookup libpod libpodImageLookup
+	// ---
+	// tags:
+	//  - images
+	// summary: Look up images
+	// description: Check which of the given images exist in local store and return their IDs and digests
+	// parameters:
+	//  - in: query
+	//    name: names
+	//    type: array
+	//    items:
+	//      type: string
+	//    required: true
+	//    description: the names or IDs of the images
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/DocsImageLookupResponse"
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   500:
+	//     $ref: '#/responses/InternalError'
+	r.Handle(VersionedPath("/libpod/images/lookup"), s.APIHandler(libpod.ImagesLookup)).Methods(http.MethodGet)
 	// swagger:operation GET /libpod/images/{name:.*}/exists libpod libpodImageExists
 	// ---
 	// tags:
@@ -727,16 +762,44 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//    name: whatrequires
 	//    type: boolean
 	//    description: show all child images and layers of the specified image
+	//  - in: query
+	//    name: shared
+	//    type: boolean
+	//    description: show the other images using each layer of the specified image and the size of the layers used by no other image. Cannot be combined with whatrequires.
 	// produces:
 	// - application/json
 	// responses:
 	//   200:
 	//     $ref: '#/responses/LibpodImageTreeResponse'
+	//   400:
+	//     $ref: '#/responses/BadParamError'
 	//   404:
 	//     $ref: '#/responses/NoSuchImage'
 	//   500:
 	//     $ref: '#/responses/InternalError'
 	r.Handle(VersionedPath("/libpod/images/{name:.*}/tree"), s.APIHandler(libpod.ImageTree)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/images/tree libpod libpodImageTreeShared
+	// ---
+	// tags:
+	//  - images
+	// summary: Shared layers of all images
+	// description: Retrieve the image trees of all images with the other images using each layer, sorted by the size of the layers used by no other image, largest first
+	// parameters:
+	//  - in: query
+	//    name: shared
+	//    type: boolean
+	//    required: true
+	//    description: must be true
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: '#/responses/LibpodImageTreeResponse'
+	//   400:
+	//     $ref: '#/responses/BadParamError'
+	//   500:
+	//     $ref: '#/responses/InternalError'
+	r.Handle(VersionedPath("/libpod/images/tree"), s.APIHandler(libpod.ImageTree)).Methods(http.MethodGet)
 	// swagger:operation GET /libpod/images/{name:.*}/history libpod libpodImageHistory
 	// ---
 	// tags:
@@ -954,6 +1017,13 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//     name: allTags
 	//     description: Pull all tagged images in the repository.
 	//     type: boolean
+	//   - in: query
+	//     name: policy
+	//     description: |
+	//       Pull policy, one of "always", "missing", "never" or "newer".
+	//       "newer" only pulls if the registry serves a different image than the local one.
+	//     type: string
+	//     default: always
 	// produces:
 	// - application/json
 	// responses:
@@ -1016,6 +1086,14 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//        - `is-automated=(true|false)`
 	//        - `is-official=(true|false)`
 	//        - `stars=<number>` Matches images that has at least 'number' stars.
+	//  - in: query
+	//    name: listTags
+	//    type: boolean
+	//    description: list the available tags in the repository
+	//  - in: query
+	//    name: tagDigests
+	//    type: boolean
+	//    description: look up the digests of the listed tags (only with listTags)
 	// produces:
 	// - application/json
 	// responses:
@@ -1189,6 +1267,10 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//    name: format
 	//    type: string
 	//    description: format of the image manifest and metadata (default "oci")
+	//  - in: query
+	//    name: timestamp
+	//    type: integer
+	//    description: created timestamp of the image and of the files in the committed layer, in seconds since the epoch, to allow for reproducible image digests
 	// produces:
 	// - application/json
 	// responses:
@@ -1446,6 +1528,13 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//      Target build stage
 	//      (As of version 1.xx)
 	//  - in: query
+	//    name: timestamp
+	//    type: integer
+	//    description: |
+	//      Set the created timestamp of the image and of the files in its layers
+	//      to the given number of seconds since the epoch, to allow for reproducible builds
+	//      (As of version 3.0)
+	//  - in: query
 	//    name: outputs
 	//    type: string
 	//    default:
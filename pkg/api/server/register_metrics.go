@@ -0,0 +1,28 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func (s *APIServer) registerMetricsHandlers(r *mux.Router) error {
+	// swagger:operation GET /libpod/metrics libpod metrics
+	// ---
+	// tags:
+	//   - system
+	// summary: Show API metrics
+	// description: |
+	//   Return the number of handled requests and their latency per endpoint
+	//   in the Prometheus text format.  The counters are reset when the service
+	//   is restarted.
+	// produces:
+	// - text/plain
+	// responses:
+	//   200:
+	//     description: Metrics in the Prometheus text format
+	//     schema:
+	//       type: string
+	r.Handle(VersionedPath("/libpod/metrics"), s.APIHandler(s.metrics.handler().ServeHTTP)).Methods(http.MethodGet)
+	return nil
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/containers/podman/v2/pkg/api/handlers/libpod"
+	"github.com/gorilla/mux"
+)
+
+func (s *APIServer) registerNameHandlers(r *mux.Router) error {
+	// swagger:operation POST /libpod/names/reserve libpod libpodReserveName
+	// ---
+	// tags:
+	//  - names
+	// summary: Reserve a name
+	// description: Reserve a name for a container or pod to be created later.  Names generated for containers and pods never use a reserved name.  Only the user who reserved the name can create a container or pod with it, which consumes the reservation.
+	// parameters:
+	//  - in: query
+	//    name: name
+	//    type: string
+	//    description: the name to reserve, a name is generated with the name template of containers.conf if it is not set
+	//  - in: query
+	//    name: ttl
+	//    type: integer
+	//    description: number of seconds the name is reserved, zero reserves it until it is released or used
+	//    default: 0
+	// produces:
+	// - application/json
+	// responses:
+	//   '200':
+	//     description: the reserved name
+	//     schema:
+	//       type: object
+	//       properties:
+	//         Name:
+	//           type: string
+	//         Owner:
+	//           type: string
+	//         Created:
+	//           type: string
+	//         Expires:
+	//           type: string
+	//   '400':
+	//     $ref: "#/responses/BadParamError"
+	//   '409':
+	//     description: the name is reserved or used by a container or pod
+	//   '500':
+	//      "$ref": "#/responses/InternalError"
+	r.Handle(VersionedPath("/libpod/names/reserve"), s.APIHandler(libpod.ReserveName)).Methods(http.MethodPost)
+	// swagger:operation GET /libpod/names/json libpod libpodListReservedNames
+	// ---
+	// tags:
+	//  - names
+	// summary: List reserved names
+	// produces:
+	// - application/json
+	// responses:
+	//   '200':
+	//     description: the reserved names
+	//     schema:
+	//       type: array
+	//       items:
+	//         type: object
+	//         properties:
+	//           Name:
+	//             type: string
+	//           Owner:
+	//             type: string
+	//           Created:
+	//             type: string
+	//           Expires:
+	//             type: string
+	//   '500':
+	//      "$ref": "#/responses/InternalError"
+	r.Handle(VersionedPath("/libpod/names/json"), s.APIHandler(libpod.ListReservedNames)).Methods(http.MethodGet)
+	// swagger:operation DELETE /libpod/names/{name} libpod libpodReleaseName
+	// ---
+	// tags:
+	//  - names
+	// summary: Release a reserved name
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the reserved name
+	// produces:
+	// - application/json
+	// responses:
+	//   '204':
+	//     description: no error
+	//   '404':
+	//     description: the name is not reserved
+	//   '409':
+	//     description: the name is reserved by another user
+	//   '500':
+	//     "$ref": "#/responses/InternalError"
+	r.Handle(VersionedPath("/libpod/names/{name}"), s.APIHandler(libpod.ReleaseName)).Methods(http.MethodDelete)
+	return nil
+}
@@ -17,7 +17,17 @@ func (s *APIServer) registerPodsHandlers(r *mux.Router) error {
 	// - in: query
 	//   name: filters
 	//   type: string
-	//   description: needs description and plumbing for filters
+	//   description: |
+	//     JSON encoded value of the filters (a map[string][]string) to process on the pods list. Available filters:
+	//       - `id=<pod-id>` Matches all of pod id.
+	//       - `label=<key>` or `label=<key>=<value>` Matches pods based on the presence of a label alone or a label and a value.
+	//       - `name=<pod-name>` Matches all of pod name.
+	//       - `since=<pod id or name>` Pods created after the given pod.
+	//       - `status=<pod-status>` Pod's status: `stopped`, `running`, `paused`, `exited`, `dead`, `created`, `degraded`.
+	//       - `ctr-names=<pod-ctr-names>` Container name within the pod.
+	//       - `ctr-ids=<pod-ctr-ids>` Container ID within the pod.
+	//       - `ctr-status=<pod-ctr-status>` Container status within the pod.
+	//       - `ctr-count=<N>` Number of containers in the pod, or a comparison with `<N`, `<=N`, `>N` or `>=N`. `ctr-number` is an alias.
 	// responses:
 	//   200:
 	//     $ref: "#/responses/ListPodsResponse"
@@ -141,6 +151,11 @@ func (s *APIServer) registerPodsHandlers(r *mux.Router) error {
 	//    type: string
 	//    description: signal to be sent to pod
 	//    default: SIGKILL
+	//  - in: query
+	//    name: excludeInfra
+	//    type: boolean
+	//    description: do not send the signal to the infra container of the pod
+	//    default: false
 	// responses:
 	//   200:
 	//     $ref: "#/responses/PodKillReport"
@@ -259,6 +274,121 @@ func (s *APIServer) registerPodsHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/InternalError"
 	r.Handle(VersionedPath("/libpod/pods/{name}/unpause"), s.APIHandler(libpod.PodUnpause)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/pods/{name}/update pods updatePod
+	// ---
+	// summary: Change the published ports of a pod
+	// description: Publish additional ports of a pod or stop publishing some of its ports.  The ports are part of the configuration of the infra container; if it is running, its port forwarding is set up again with the new ports.  Rootless pods publish the new ports when they are restarted.
+	// produces:
+	// - application/json
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the pod
+	//  - in: body
+	//    name: update
+	//    description: the ports to publish, and the host ports to no longer publish as PORT or PORT/PROTOCOL
+	//    schema:
+	//      type: object
+	//      properties:
+	//        PublishPorts:
+	//          type: array
+	//          items:
+	//            type: object
+	//        RemovePorts:
+	//          type: array
+	//          items:
+	//            type: string
+	// responses:
+	//   200:
+	//     description: the ID of the pod
+	//     schema:
+	//       type: object
+	//       properties:
+	//         Id:
+	//           type: string
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   404:
+	//     $ref: "#/responses/NoSuchPod"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.Handle(VersionedPath("/libpod/pods/{name}/update"), s.APIHandler(libpod.PodUpdate)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/pods/{name}/clone pods clonePod
+	// ---
+	// summary: Clone a pod
+	// description: Create a new pod with the configuration of a pod, and recreate the containers of the pod in it.
+	// produces:
+	// - application/json
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the pod
+	//  - in: body
+	//    name: clone
+	//    description: the changes to the configuration of the clone
+	//    schema:
+	//      type: object
+	//      properties:
+	//        Name:
+	//          type: string
+	//        Hostname:
+	//          type: string
+	//        Labels:
+	//          type: object
+	//          additionalProperties:
+	//            type: string
+	//        Start:
+	//          type: boolean
+	// responses:
+	//   200:
+	//     description: the ID of the clone
+	//     schema:
+	//       type: object
+	//       properties:
+	//         Id:
+	//           type: string
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   404:
+	//     $ref: "#/responses/NoSuchPod"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.Handle(VersionedPath("/libpod/pods/{name}/clone"), s.APIHandler(libpod.PodClone)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/pods/{name}/rename pods renamePod
+	// ---
+	// summary: Rename a pod
+	// description: Change the name of a pod.  The containers of the pod are not changed.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the pod
+	//  - in: query
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the new name of the pod
+	// produces:
+	// - application/json
+	// responses:
+	//   204:
+	//     description: no error
+	//   400:
+	//     $ref: "#/responses/BadParamError"
+	//   404:
+	//     $ref: "#/responses/NoSuchPod"
+	//   409:
+	//     $ref: "#/responses/ConflictError"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.Handle(VersionedPath("/libpod/pods/{name}/rename"), s.APIHandler(libpod.PodRename)).Methods(http.MethodPost)
 	// swagger:operation GET /libpod/pods/{name}/top pods topPod
 	// ---
 	// summary: List processes
@@ -302,6 +432,10 @@ func (s *APIServer) registerPodsHandlers(r *mux.Router) error {
 	//    description: Provide statistics for all running pods.
 	//    type: boolean
 	//  - in: query
+	//    name: sum
+	//    description: Report each pod with the sum of the statistics of its containers, with "--" as container ID, before the statistics of its containers.
+	//    type: boolean
+	//  - in: query
 	//    name: namesOrIDs
 	//    description: Names or IDs of pods.
 	//    type: array
@@ -17,6 +17,19 @@ func (s *APIServer) registerSystemHandlers(r *mux.Router) error {
 	// tags:
 	//   - system
 	// summary: Prune unused data
+	// parameters:
+	//  - in: query
+	//    name: all
+	//    type: boolean
+	//    description: remove all unused images, not just dangling ones
+	//  - in: query
+	//    name: volumes
+	//    type: boolean
+	//    description: prune volumes
+	//  - in: query
+	//    name: interrupted
+	//    type: boolean
+	//    description: remove the temporary files and the unused layers left by interrupted pulls
 	// produces:
 	// - application/json
 	// responses:
@@ -41,5 +54,19 @@ func (s *APIServer) registerSystemHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/InternalError"
 	r.Handle(VersionedPath("/libpod/system/df"), s.APIHandler(libpod.DiskUsage)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/system/devices libpod devices
+	// ---
+	// tags:
+	//   - system
+	// summary: List host devices
+	// description: Return the device nodes of the host commonly passed into containers, like GPUs, /dev/net/tun, USB serial adapters, /dev/fuse and /dev/kvm, with their cgroup rules, the access of the user running the service and the CDI devices providing them
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: '#/responses/SystemDevices'
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.Handle(VersionedPath("/libpod/system/devices"), s.APIHandler(libpod.Devices)).Methods(http.MethodGet)
 	return nil
 }
@@ -57,8 +57,22 @@ func (s *APIServer) registerVolumeHandlers(r *mux.Router) error {
 	// tags:
 	//  - volumes
 	// summary: Prune volumes
+	// description: Remove the volumes not used by any container. The disk space reclaimed by removing each volume is reported.
 	// produces:
 	// - application/json
+	// parameters:
+	//  - in: query
+	//    name: filters
+	//    type: string
+	//    description: |
+	//      JSON encoded value of the filters (a map[string][]string) to process on the volumes to prune. Available filters:
+	//        - label=<key> or label=<key>=<value> Prune volumes with the label.
+	//        - label!=<key> or label!=<key>=<value> Prune volumes without the label.
+	//        - until=<timestamp> Prune volumes created before the timestamp.
+	//  - in: query
+	//    name: forceUnused
+	//    type: boolean
+	//    description: also prune volumes used only by stopped containers, which are removed with the volumes and listed in the report
 	// responses:
 	//   '200':
 	//      "$ref": "#/responses/VolumePruneResponse"
@@ -113,6 +127,56 @@ func (s *APIServer) registerVolumeHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/InternalError"
 	r.Handle(VersionedPath("/libpod/volumes/{name}"), s.APIHandler(libpod.RemoveVolume)).Methods(http.MethodDelete)
+	// swagger:operation GET /libpod/volumes/{name}/export libpod libpodExportVolume
+	// ---
+	// tags:
+	//  - volumes
+	// summary: Export volume
+	// description: Export the contents of a volume as a tarball.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the volume
+	// produces:
+	// - application/x-tar
+	// responses:
+	//   200:
+	//     description: tarball is returned in body
+	//   404:
+	//     $ref: "#/responses/NoSuchVolume"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.Handle(VersionedPath("/libpod/volumes/{name}/export"), s.APIHandler(libpod.ExportVolume)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/volumes/{name}/import libpod libpodImportVolume
+	// ---
+	// tags:
+	//  - volumes
+	// summary: Import volume
+	// description: Extract a tarball into a volume. Existing contents of the volume are kept unless overwritten by the tarball.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the volume
+	//  - in: body
+	//    name: request
+	//    description: tarball of the volume contents
+	//    schema:
+	//      type: string
+	//      format: binary
+	// produces:
+	// - application/json
+	// responses:
+	//   204:
+	//     description: no error
+	//   404:
+	//     $ref: "#/responses/NoSuchVolume"
+	//   500:
+	//     $ref: "#/responses/InternalError"
+	r.Handle(VersionedPath("/libpod/volumes/{name}/import"), s.APIHandler(libpod.ImportVolume)).Methods(http.MethodPost)
 
 	/*
 	 * Docker compatibility endpoints
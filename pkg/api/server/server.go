@@ -34,6 +34,7 @@ type APIServer struct {
 	context.CancelFunc               // Stop APIServer
 	idleTracker        *idle.Tracker // Track connections to support idle shutdown
 	pprof              *http.Server  // Sidecar http server for providing performance data
+	metrics            *apiMetrics   // Request metrics served at /libpod/metrics
 }
 
 // Number of seconds to wait for next request, if exceeded shutdown server
@@ -86,6 +87,7 @@ func newServer(runtime *libpod.Runtime, duration time.Duration, listener *net.Li
 		},
 		Decoder:     handlers.NewAPIDecoder(),
 		idleTracker: idle,
+		metrics:     newAPIMetrics(),
 		Listener:    *listener,
 		Runtime:     runtime,
 	}
@@ -118,12 +120,15 @@ func newServer(runtime *libpod.Runtime, duration time.Duration, listener *net.Li
 		server.registerImagesHandlers,
 		server.registerInfoHandlers,
 		server.registerManifestHandlers,
+		server.registerMetricsHandlers,
 		server.registerMonitorHandlers,
+		server.registerNameHandlers,
 		server.registerNetworkHandlers,
 		server.registerPingHandlers,
 		server.registerPlayHandlers,
 		server.registerPluginsHandlers,
 		server.registerPodsHandlers,
+		server.registerSecretHandlers,
 		server.RegisterSwaggerHandlers,
 		server.registerSwarmHandlers,
 		server.registerSystemHandlers,
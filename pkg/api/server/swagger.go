@@ -51,6 +51,15 @@ type swagErrNoSuchVolume struct {
 	}
 }
 
+// No such secret
+// swagger:response NoSuchSecret
+type swagErrNoSuchSecret struct {
+	// in:body
+	Body struct {
+		entities.ErrorModel
+	}
+}
+
 // No such pod
 // swagger:response NoSuchPod
 type swagErrNoSuchPod struct {
@@ -189,6 +198,31 @@ type swagVolumeListResponse struct {
 	Body []libpod.Volume
 }
 
+// Secret create response
+// swagger:response SecretCreateResponse
+type swagSecretCreateResponse struct {
+	// in:body
+	Body struct {
+		entities.SecretCreateReport
+	}
+}
+
+// Secret list response
+// swagger:response SecretListResponse
+type swagSecretListResponse struct {
+	// in:body
+	Body []entities.SecretInfoReport
+}
+
+// Secret inspect response
+// swagger:response SecretInspectResponse
+type swagSecretInspectResponse struct {
+	// in:body
+	Body struct {
+		entities.SecretInfoReport
+	}
+}
+
 // Healthcheck
 // swagger:response HealthcheckRun
 type swagHealthCheckRunResponse struct {
@@ -216,6 +250,15 @@ type swagDiskUseResponse struct {
 	}
 }
 
+// Host devices
+// swagger:response SystemDevices
+type swagDevicesResponse struct {
+	// in:body
+	Body struct {
+		entities.SystemDevicesReport
+	}
+}
+
 // Prune report
 // swagger:response SystemPruneReport
 type swagSystemPruneReport struct {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"sort"
+	"time"
 
 	"github.com/containers/image/v5/docker"
 	"github.com/containers/image/v5/docker/reference"
@@ -11,10 +12,12 @@ import (
 	"github.com/containers/image/v5/transports/alltransports"
 	"github.com/containers/podman/v2/libpod"
 	"github.com/containers/podman/v2/libpod/define"
+	"github.com/containers/podman/v2/libpod/events"
 	"github.com/containers/podman/v2/libpod/image"
 	"github.com/containers/podman/v2/pkg/systemd"
 	systemdGen "github.com/containers/podman/v2/pkg/systemd/generate"
 	"github.com/containers/podman/v2/pkg/util"
+	"github.com/coreos/go-systemd/v22/dbus"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
@@ -71,6 +74,13 @@ func LookupPolicy(s string) (Policy, error) {
 type Options struct {
 	// Authfile to use when contacting registries.
 	Authfile string
+	// Rollback an update by restarting the systemd unit with the previous
+	// image if the restarted container is not healthy within
+	// RollbackWindow.
+	Rollback bool
+	// RollbackWindow is the time a restarted container has to become
+	// healthy.  Containers without a healthcheck only have to be running.
+	RollbackWindow time.Duration
 }
 
 // ValidateImageReference checks if the specified imageName is a fully-qualified
@@ -104,9 +114,16 @@ func ValidateImageReference(imageName string) error {
 // differ, it pulls the remote image and restarts the systemd unit running the
 // container.
 //
+// If options.Rollback is set, the previous image is retained and the unit is
+// restarted again with it when the restarted container does not become
+// healthy within options.RollbackWindow.
+//
 // It returns a slice of successfully restarted systemd units and a slice of
 // errors encountered during auto update.
-func AutoUpdate(runtime *libpod.Runtime, options Options) ([]string, []error) {
+func AutoUpdate(ctx context.Context, runtime *libpod.Runtime, options Options) ([]string, []error) {
+	// Group the events of the images pulled and the containers updated.
+	ctx, _ = runtime.StartEventCorrelation(ctx)
+
 	// Create a map from `image ID -> []*Container`.
 	containerMap, errs := imageContainersMap(runtime)
 	if len(containerMap) == 0 {
@@ -134,6 +151,7 @@ func AutoUpdate(runtime *libpod.Runtime, options Options) ([]string, []error) {
 	// Update images.
 	containersToRestart := []*libpod.Container{}
 	updatedRawImages := make(map[string]bool)
+	previousImages := make(map[string]*image.Image)
 	for imageID, containers := range containerMap {
 		image, exists := imageMap[imageID]
 		if !exists {
@@ -163,20 +181,102 @@ func AutoUpdate(runtime *libpod.Runtime, options Options) ([]string, []error) {
 			}
 			logrus.Infof("Auto-updating container %q using image %q", ctr.ID(), rawImageName)
 			if _, updated := updatedRawImages[rawImageName]; !updated {
-				_, err = updateImage(runtime, rawImageName, options)
+				_, err = updateImage(ctx, runtime, rawImageName, options)
 				if err != nil {
 					errs = append(errs, errors.Wrapf(err, "error auto-updating container %q: image update for %q failed", ctr.ID(), rawImageName))
 					continue
 				}
 				updatedRawImages[rawImageName] = true
+				previousImages[rawImageName] = image
 			}
 			containersToRestart = append(containersToRestart, containers[i])
 		}
 	}
 
 	// Restart containers.
+	restarter := &systemdRestarter{conn: conn, runtime: runtime, previousImages: previousImages}
+	ctrs := make([]updatedContainer, len(containersToRestart))
+	for i := range containersToRestart {
+		ctrs[i] = containersToRestart[i]
+	}
+	updatedUnits, restartErrs := restartContainers(ctx, restarter, ctrs, options)
+	return updatedUnits, append(errs, restartErrs...)
+}
+
+// updatedContainer is a container whose image has been updated.  It is
+// implemented by *libpod.Container.
+type updatedContainer interface {
+	ID() string
+	RawImageName() string
+	Labels() map[string]string
+	NewContainerEvent(ctx context.Context, status events.Status)
+}
+
+// unitRestarter restarts the systemd units of updated containers and rolls
+// back their images.
+type unitRestarter interface {
+	// restartUnit restarts the systemd unit.
+	restartUnit(unit string) error
+	// waitForHealthy waits until the container created by the restarted
+	// unit to replace the container with the ID oldID is healthy.
+	waitForHealthy(unit, oldID string, window time.Duration) (updatedContainer, error)
+	// tagPreviousImage tags the image used before the update of
+	// rawImageName with rawImageName again.
+	tagPreviousImage(rawImageName string) error
+}
+
+// systemdRestarter restarts systemd units over DBus.
+type systemdRestarter struct {
+	conn    *dbus.Conn
+	runtime *libpod.Runtime
+	// previousImages maps the raw image names of the updated containers
+	// to the images they used before the update.
+	previousImages map[string]*image.Image
+}
+
+func (r *systemdRestarter) restartUnit(unit string) error {
+	_, err := r.conn.RestartUnit(unit, "replace", nil)
+	return err
+}
+
+func (r *systemdRestarter) waitForHealthy(unit, oldID string, window time.Duration) (updatedContainer, error) {
+	ctr, err := waitForHealthy(r.runtime, unit, oldID, window)
+	if ctr == nil {
+		return nil, err
+	}
+	return ctr, err
+}
+
+func (r *systemdRestarter) tagPreviousImage(rawImageName string) error {
+	previous, exists := r.previousImages[rawImageName]
+	if !exists {
+		return errors.Errorf("no previous image of %q", rawImageName)
+	}
+	if err := previous.TagImage(rawImageName); err != nil {
+		return errors.Wrapf(err, "tagging previous image %s as %q failed", previous.ID(), rawImageName)
+	}
+	return nil
+}
+
+// restartContainers restarts the systemd units of the updated containers.  If
+// options.Rollback is set, the update of a container that does not become
+// healthy is rolled back, and the other containers using the same image are
+// not restarted.
+//
+// It returns a slice of successfully restarted systemd units and a slice of
+// errors encountered.
+func restartContainers(ctx context.Context, restarter unitRestarter, ctrs []updatedContainer, options Options) ([]string, []error) {
+	errs := []error{}
 	updatedUnits := []string{}
-	for _, ctr := range containersToRestart {
+	rolledBack := make(map[string]bool)
+	for _, ctr := range ctrs {
+		rawImageName := ctr.RawImageName()
+		if rolledBack[rawImageName] {
+			// The update of another container using the image was
+			// rolled back, so the container keeps running the
+			// previous image.
+			continue
+		}
 		labels := ctr.Labels()
 		unit, exists := labels[systemdGen.EnvVariable]
 		if !exists {
@@ -184,11 +284,30 @@ func AutoUpdate(runtime *libpod.Runtime, options Options) ([]string, []error) {
 			errs = append(errs, errors.Errorf("error auto-updating container %q: no %s label found", ctr.ID(), systemdGen.EnvVariable))
 			continue
 		}
-		_, err := conn.RestartUnit(unit, "replace", nil)
-		if err != nil {
+		ctr.NewContainerEvent(ctx, events.AutoUpdate)
+		if err := restarter.restartUnit(unit); err != nil {
 			errs = append(errs, errors.Wrapf(err, "error auto-updating container %q: restarting systemd unit %q failed", ctr.ID(), unit))
 			continue
 		}
+		if options.Rollback {
+			newCtr, err := restarter.waitForHealthy(unit, ctr.ID(), options.RollbackWindow)
+			if newCtr == nil {
+				newCtr = ctr
+			}
+			if err != nil {
+				newCtr.NewContainerEvent(ctx, events.AutoUpdateFailure)
+				errs = append(errs, errors.Wrapf(err, "error auto-updating container %q", ctr.ID()))
+				rolledBack[rawImageName] = true
+				if err := rollback(restarter, unit, rawImageName); err != nil {
+					errs = append(errs, errors.Wrapf(err, "error rolling back container %q", ctr.ID()))
+					continue
+				}
+				newCtr.NewContainerEvent(ctx, events.Rollback)
+				logrus.Infof("Rolled back systemd unit %q to the previous image of %q", unit, rawImageName)
+				continue
+			}
+			newCtr.NewContainerEvent(ctx, events.AutoUpdateSuccess)
+		}
 		logrus.Infof("Successfully restarted systemd unit %q", unit)
 		updatedUnits = append(updatedUnits, unit)
 	}
@@ -196,6 +315,69 @@ func AutoUpdate(runtime *libpod.Runtime, options Options) ([]string, []error) {
 	return updatedUnits, errs
 }
 
+// waitForHealthy waits until the container created by the restarted systemd
+// unit to replace the container with the ID oldID is healthy.  Containers
+// without a healthcheck only have to be running.  The new container is
+// returned along with an error if it is not healthy within the window.
+func waitForHealthy(runtime *libpod.Runtime, unit, oldID string, window time.Duration) (*libpod.Container, error) {
+	var ctr *libpod.Container
+	for deadline := time.Now().Add(window); time.Now().Before(deadline); time.Sleep(time.Second) {
+		if ctr == nil {
+			ctrs, err := runtime.GetContainers(func(c *libpod.Container) bool {
+				return c.ID() != oldID && c.Labels()[systemdGen.EnvVariable] == unit
+			})
+			if err != nil {
+				return nil, err
+			}
+			if len(ctrs) == 0 {
+				continue
+			}
+			ctr = ctrs[0]
+		}
+
+		state, err := ctr.State()
+		if err != nil {
+			return ctr, err
+		}
+		switch state {
+		case define.ContainerStateRunning:
+			if !ctr.HasHealthCheck() {
+				return ctr, nil
+			}
+			status, err := ctr.HealthCheckStatus()
+			if err != nil {
+				return ctr, err
+			}
+			switch status {
+			case define.HealthCheckHealthy:
+				return ctr, nil
+			case define.HealthCheckUnhealthy:
+				return ctr, errors.Errorf("container %s is unhealthy", ctr.ID())
+			}
+		case define.ContainerStateStopped, define.ContainerStateExited:
+			return ctr, errors.Errorf("container %s exited", ctr.ID())
+		}
+	}
+
+	if ctr == nil {
+		return nil, errors.Errorf("systemd unit %q did not create a new container within %s", unit, window)
+	}
+	return ctr, errors.Errorf("container %s did not become healthy within %s", ctr.ID(), window)
+}
+
+// rollback tags the previous image with the raw image name of the updated
+// containers again and restarts the systemd unit, which then runs the
+// previous image.
+func rollback(restarter unitRestarter, unit string, rawImageName string) error {
+	if err := restarter.tagPreviousImage(rawImageName); err != nil {
+		return err
+	}
+	if err := restarter.restartUnit(unit); err != nil {
+		return errors.Wrapf(err, "restarting systemd unit %q failed", unit)
+	}
+	return nil
+}
+
 // imageContainersMap generates a map[image ID] -> [containers using the image]
 // of all containers with a valid auto-update policy.
 func imageContainersMap(runtime *libpod.Runtime) (map[string][]*libpod.Container, []error) {
@@ -283,7 +465,7 @@ func newerImageAvailable(runtime *libpod.Runtime, img *image.Image, origName str
 }
 
 // updateImage pulls the specified image.
-func updateImage(runtime *libpod.Runtime, name string, options Options) (*image.Image, error) {
+func updateImage(ctx context.Context, runtime *libpod.Runtime, name string, options Options) (*image.Image, error) {
 	sys := runtime.SystemContext()
 	registryOpts := image.DockerRegistryOptions{}
 	signaturePolicyPath := ""
@@ -295,7 +477,7 @@ func updateImage(runtime *libpod.Runtime, name string, options Options) (*image.
 		signaturePolicyPath = sys.SignaturePolicyPath
 	}
 
-	newImage, err := runtime.ImageRuntime().New(context.Background(),
+	newImage, err := runtime.ImageRuntime().New(ctx,
 		docker.Transport.Name()+"://"+name,
 		signaturePolicyPath,
 		options.Authfile,
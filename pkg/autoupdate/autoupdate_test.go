@@ -1,7 +1,15 @@
 package autoupdate
 
 import (
+	"context"
 	"testing"
+	"time"
+
+	"github.com/containers/podman/v2/libpod/events"
+	systemdGen "github.com/containers/podman/v2/pkg/systemd/generate"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestValidateImageReference(t *testing.T) {
@@ -48,3 +56,172 @@ func TestValidateImageReference(t *testing.T) {
 		}
 	}
 }
+
+// fakeContainer records the events of a container.
+type fakeContainer struct {
+	id           string
+	rawImageName string
+	unit         string
+	events       []events.Status
+}
+
+func (c *fakeContainer) ID() string {
+	return c.id
+}
+
+func (c *fakeContainer) RawImageName() string {
+	return c.rawImageName
+}
+
+func (c *fakeContainer) Labels() map[string]string {
+	return map[string]string{systemdGen.EnvVariable: c.unit}
+}
+
+func (c *fakeContainer) NewContainerEvent(ctx context.Context, status events.Status) {
+	c.events = append(c.events, status)
+}
+
+// fakeRestarter records the restarted units and the images tagged again.
+// The containers created by restarting a unit are taken from newCtrs, and
+// are unhealthy if listed in unhealthy.
+type fakeRestarter struct {
+	newCtrs    map[string][]*fakeContainer
+	unhealthy  map[string]bool
+	restartErr error
+	tagErr     error
+	restarted  []string
+	tagged     []string
+}
+
+func (r *fakeRestarter) restartUnit(unit string) error {
+	if r.restartErr != nil {
+		return r.restartErr
+	}
+	r.restarted = append(r.restarted, unit)
+	return nil
+}
+
+func (r *fakeRestarter) waitForHealthy(unit, oldID string, window time.Duration) (updatedContainer, error) {
+	if len(r.newCtrs[unit]) == 0 {
+		return nil, errors.Errorf("systemd unit %q did not create a new container within %s", unit, window)
+	}
+	ctr := r.newCtrs[unit][0]
+	r.newCtrs[unit] = r.newCtrs[unit][1:]
+	if r.unhealthy[ctr.id] {
+		return ctr, errors.Errorf("container %s is unhealthy", ctr.id)
+	}
+	return ctr, nil
+}
+
+func (r *fakeRestarter) tagPreviousImage(rawImageName string) error {
+	if r.tagErr != nil {
+		return r.tagErr
+	}
+	r.tagged = append(r.tagged, rawImageName)
+	return nil
+}
+
+func TestRestartContainersHealthy(t *testing.T) {
+	old := &fakeContainer{id: "old", rawImageName: "quay.io/foo/bar:latest", unit: "foo.service"}
+	updated := &fakeContainer{id: "new"}
+	restarter := &fakeRestarter{newCtrs: map[string][]*fakeContainer{"foo.service": {updated}}}
+
+	units, errs := restartContainers(context.Background(), restarter, []updatedContainer{old}, Options{Rollback: true, RollbackWindow: time.Minute})
+	assert.Empty(t, errs)
+	assert.Equal(t, []string{"foo.service"}, units)
+	assert.Equal(t, []string{"foo.service"}, restarter.restarted)
+	assert.Empty(t, restarter.tagged)
+	assert.Equal(t, []events.Status{events.AutoUpdate}, old.events)
+	assert.Equal(t, []events.Status{events.AutoUpdateSuccess}, updated.events)
+}
+
+func TestRestartContainersUnhealthy(t *testing.T) {
+	old := &fakeContainer{id: "old", rawImageName: "quay.io/foo/bar:latest", unit: "foo.service"}
+	updated := &fakeContainer{id: "new"}
+	restarter := &fakeRestarter{
+		newCtrs:   map[string][]*fakeContainer{"foo.service": {updated}},
+		unhealthy: map[string]bool{"new": true},
+	}
+
+	units, errs := restartContainers(context.Background(), restarter, []updatedContainer{old}, Options{Rollback: true, RollbackWindow: time.Minute})
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "container new is unhealthy")
+	assert.Empty(t, units)
+	// The previous image is tagged again before the unit is restarted
+	// with it.
+	assert.Equal(t, []string{"quay.io/foo/bar:latest"}, restarter.tagged)
+	assert.Equal(t, []string{"foo.service", "foo.service"}, restarter.restarted)
+	assert.Equal(t, []events.Status{events.AutoUpdate}, old.events)
+	assert.Equal(t, []events.Status{events.AutoUpdateFailure, events.Rollback}, updated.events)
+}
+
+func TestRestartContainersNoNewContainer(t *testing.T) {
+	old := &fakeContainer{id: "old", rawImageName: "quay.io/foo/bar:latest", unit: "foo.service"}
+	restarter := &fakeRestarter{}
+
+	units, errs := restartContainers(context.Background(), restarter, []updatedContainer{old}, Options{Rollback: true, RollbackWindow: time.Minute})
+	require.Len(t, errs, 1)
+	assert.Empty(t, units)
+	assert.Equal(t, []string{"quay.io/foo/bar:latest"}, restarter.tagged)
+	// Without a new container, the events are those of the old one.
+	assert.Equal(t, []events.Status{events.AutoUpdate, events.AutoUpdateFailure, events.Rollback}, old.events)
+}
+
+func TestRestartContainersRollbackFailure(t *testing.T) {
+	old := &fakeContainer{id: "old", rawImageName: "quay.io/foo/bar:latest", unit: "foo.service"}
+	updated := &fakeContainer{id: "new"}
+	restarter := &fakeRestarter{
+		newCtrs:   map[string][]*fakeContainer{"foo.service": {updated}},
+		unhealthy: map[string]bool{"new": true},
+		tagErr:    errors.New("tag failed"),
+	}
+
+	units, errs := restartContainers(context.Background(), restarter, []updatedContainer{old}, Options{Rollback: true, RollbackWindow: time.Minute})
+	require.Len(t, errs, 2)
+	assert.Contains(t, errs[1].Error(), "error rolling back container \"old\": tag failed")
+	assert.Empty(t, units)
+	assert.Equal(t, []string{"foo.service"}, restarter.restarted)
+	assert.Equal(t, []events.Status{events.AutoUpdateFailure}, updated.events)
+}
+
+func TestRestartContainersSkipRolledBackImage(t *testing.T) {
+	first := &fakeContainer{id: "first", rawImageName: "quay.io/foo/bar:latest", unit: "first.service"}
+	second := &fakeContainer{id: "second", rawImageName: "quay.io/foo/bar:latest", unit: "second.service"}
+	other := &fakeContainer{id: "other", rawImageName: "quay.io/foo/baz:latest", unit: "other.service"}
+	restarter := &fakeRestarter{
+		newCtrs: map[string][]*fakeContainer{
+			"first.service": {{id: "first-new"}},
+			"other.service": {{id: "other-new"}},
+		},
+		unhealthy: map[string]bool{"first-new": true},
+	}
+
+	units, errs := restartContainers(context.Background(), restarter, []updatedContainer{first, second, other}, Options{Rollback: true, RollbackWindow: time.Minute})
+	require.Len(t, errs, 1)
+	assert.Equal(t, []string{"other.service"}, units)
+	// The second container keeps running the previous image.
+	assert.Empty(t, second.events)
+	assert.Equal(t, []string{"first.service", "first.service", "other.service"}, restarter.restarted)
+}
+
+func TestRestartContainersNoRollback(t *testing.T) {
+	old := &fakeContainer{id: "old", rawImageName: "quay.io/foo/bar:latest", unit: "foo.service"}
+	restarter := &fakeRestarter{}
+
+	units, errs := restartContainers(context.Background(), restarter, []updatedContainer{old}, Options{})
+	assert.Empty(t, errs)
+	assert.Equal(t, []string{"foo.service"}, units)
+	assert.Empty(t, restarter.tagged)
+	assert.Equal(t, []events.Status{events.AutoUpdate}, old.events)
+}
+
+func TestRestartContainersRestartFailure(t *testing.T) {
+	old := &fakeContainer{id: "old", rawImageName: "quay.io/foo/bar:latest", unit: "foo.service"}
+	restarter := &fakeRestarter{restartErr: errors.New("no such unit")}
+
+	units, errs := restartContainers(context.Background(), restarter, []updatedContainer{old}, Options{Rollback: true, RollbackWindow: time.Minute})
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "restarting systemd unit \"foo.service\" failed: no such unit")
+	assert.Empty(t, units)
+	assert.Equal(t, []events.Status{events.AutoUpdate}, old.events)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/url"
@@ -71,7 +72,20 @@ func NewConnection(ctx context.Context, uri string) (context.Context, error) {
 // For example tcp://localhost:<port>
 // or unix:///run/podman/podman.sock
 // or ssh://<user>@<host>[:port]/run/podman/podman.sock?secure=True
+//
+// For ssh connections, identity is the path to the SSH key to authenticate
+// with.  It is not used by other connections.
 func NewConnectionWithIdentity(ctx context.Context, uri string, identity string) (context.Context, error) {
+	return NewConnectionWithAuthToken(ctx, uri, identity, "")
+}
+
+// NewConnectionWithAuthToken is NewConnectionWithIdentity for services
+// requiring clients to authenticate with a bearer token.  For tcp connections,
+// tokenFile is the path to the file holding the token.  If it is not given,
+// the file is taken from CONTAINER_AUTH_TOKEN_FILE or from the token-file
+// query parameter of the URI, e.g., tcp://<host>:<port>?token-file=<path>.
+// The token is sent in clear text, as tcp connections are not encrypted.
+func NewConnectionWithAuthToken(ctx context.Context, uri, identity, tokenFile string) (context.Context, error) {
 	var (
 		err    error
 		secure bool
@@ -80,10 +94,6 @@ func NewConnectionWithIdentity(ctx context.Context, uri string, identity string)
 		uri = v
 	}
 
-	if v, found := os.LookupEnv("CONTAINER_SSHKEY"); found && len(identity) == 0 {
-		identity = v
-	}
-
 	passPhrase := ""
 	if v, found := os.LookupEnv("CONTAINER_PASSPHRASE"); found {
 		passPhrase = v
@@ -102,6 +112,9 @@ func NewConnectionWithIdentity(ctx context.Context, uri string, identity string)
 		if err != nil {
 			secure = false
 		}
+		if v, found := os.LookupEnv("CONTAINER_SSHKEY"); found && len(identity) == 0 {
+			identity = v
+		}
 		connection, err = sshClient(_url, secure, passPhrase, identity)
 	case "unix":
 		if !strings.HasPrefix(uri, "unix:///") {
@@ -114,7 +127,13 @@ func NewConnectionWithIdentity(ctx context.Context, uri string, identity string)
 		if !strings.HasPrefix(uri, "tcp://") {
 			return nil, errors.New("tcp URIs should begin with tcp://")
 		}
-		connection = tcpClient(_url)
+		if v, found := os.LookupEnv("CONTAINER_AUTH_TOKEN_FILE"); found && len(tokenFile) == 0 {
+			tokenFile = v
+		}
+		if len(tokenFile) == 0 {
+			tokenFile = _url.Query().Get("token-file")
+		}
+		connection, err = tcpClient(_url, tokenFile)
 	default:
 		return nil, errors.Errorf("unable to create connection. %q is not a supported schema", _url.Scheme)
 	}
@@ -129,19 +148,53 @@ func NewConnectionWithIdentity(ctx context.Context, uri string, identity string)
 	return ctx, nil
 }
 
-func tcpClient(_url *url.URL) Connection {
+func tcpClient(_url *url.URL, tokenFile string) (Connection, error) {
 	connection := Connection{
 		URI: _url,
 	}
-	connection.Client = &http.Client{
-		Transport: &http.Transport{
-			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
-				return net.Dial("tcp", _url.Host)
-			},
-			DisableCompression: true,
+	var transport http.RoundTripper = &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "tcp", _url.Host)
 		},
+		DisableCompression: true,
 	}
-	return connection
+	if tokenFile != "" {
+		if !isLoopbackHost(_url.Hostname()) {
+			logrus.Warnf("Sending the auth token unencrypted to %s, use an ssh connection to protect it", _url.Host)
+		}
+		content, err := ioutil.ReadFile(tokenFile)
+		if err != nil {
+			return connection, errors.Wrapf(err, "failed to read auth token")
+		}
+		token := strings.TrimSpace(string(content))
+		if token == "" {
+			return connection, errors.Errorf("auth token file %s is empty", tokenFile)
+		}
+		transport = &bearerTokenTransport{token: token, base: transport}
+	}
+	connection.Client = &http.Client{Transport: transport}
+	return connection, nil
+}
+
+// isLoopbackHost returns whether host is a loopback address or localhost.
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// bearerTokenTransport authenticates each request with a bearer token.
+type bearerTokenTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *bearerTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.base.RoundTrip(req)
 }
 
 // pingNewConnection pings to make sure the RESTFUL service is up
@@ -176,6 +229,9 @@ func pingNewConnection(ctx context.Context) error {
 			return errors.Errorf("server API version is too old. Client %q server %q", APIVersion.String(), versionSrv.String())
 		}
 	}
+	if response.StatusCode == http.StatusUnauthorized {
+		return errors.New("service requires a valid auth token")
+	}
 	return errors.Errorf("ping response was %q", response.StatusCode)
 }
 
@@ -5,11 +5,13 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/containers/podman/v2/pkg/bindings"
 	"github.com/containers/podman/v2/pkg/copy"
 	"github.com/containers/podman/v2/pkg/domain/entities"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 // Stat checks if the specified path is on the container.  Note that the stat
@@ -49,12 +51,26 @@ func Stat(ctx context.Context, nameOrID string, path string) (*entities.Containe
 	return statReport, finalErr
 }
 
+// CopyFromArchive copies the tar archive read from reader to path in the
+// container.
 func CopyFromArchive(ctx context.Context, nameOrID string, path string, reader io.Reader) (entities.ContainerCopyFunc, error) {
+	return CopyFromArchiveWithOptions(ctx, nameOrID, path, reader, nil)
+}
+
+// CopyFromArchiveWithOptions copies the tar archive read from reader to path in
+// the container.
+func CopyFromArchiveWithOptions(ctx context.Context, nameOrID string, path string, reader io.Reader, options *CopyOptions) (entities.ContainerCopyFunc, error) {
+	if options == nil {
+		options = new(CopyOptions)
+	}
 	conn, err := bindings.GetClient(ctx)
 	if err != nil {
 		return nil, err
 	}
-	params := url.Values{}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
 	params.Set("path", path)
 
 	return func() error {
@@ -69,6 +85,9 @@ func CopyFromArchive(ctx context.Context, nameOrID string, path string, reader i
 	}, nil
 }
 
+// CopyToArchive writes path in the container as a tar archive to writer.  If
+// the transfer is interrupted, it is resumed where it stopped, as long as the
+// path in the container does not change in between.
 func CopyToArchive(ctx context.Context, nameOrID string, path string, writer io.Writer) (entities.ContainerCopyFunc, error) {
 	conn, err := bindings.GetClient(ctx)
 	if err != nil {
@@ -86,7 +105,42 @@ func CopyToArchive(ctx context.Context, nameOrID string, path string, writer io.
 	}
 
 	return func() error {
-		_, err := io.Copy(writer, response.Body)
-		return err
+		counter := &countingWriter{writer: writer}
+		for attempt := 1; ; attempt++ {
+			_, err := io.Copy(counter, response.Body)
+			response.Body.Close()
+			// Errors of the writer are not caused by the transfer
+			// and cannot be recovered by resuming it.
+			if err == nil || counter.err != nil || attempt >= maxCopyAttempts {
+				return err
+			}
+			logrus.Debugf("Resuming copy of %q from container %s at offset %d: %v", path, nameOrID, counter.written, err)
+			params.Set("offset", strconv.FormatInt(counter.written, 10))
+			response, err = conn.DoRequest(nil, http.MethodGet, "/containers/%s/archive", params, nil, nameOrID)
+			if err != nil {
+				return err
+			}
+			if response.StatusCode != http.StatusOK {
+				return response.Process(nil)
+			}
+		}
 	}, nil
 }
+
+// maxCopyAttempts is the number of times a transfer of an archive from a
+// container is attempted before giving up.
+const maxCopyAttempts = 3
+
+// countingWriter counts the bytes written to writer and records its errors.
+type countingWriter struct {
+	writer  io.Writer
+	written int64
+	err     error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.writer.Write(p)
+	c.written += int64(n)
+	c.err = err
+	return n, err
+}
@@ -339,6 +339,7 @@ func attachHandleResize(ctx, winCtx context.Context, winChange chan os.Signal, i
 			h, w, err := terminal.GetSize(int(file.Fd()))
 			if err != nil {
 				logrus.Warnf("failed to obtain TTY size: %v", err)
+				continue
 			}
 
 			var resizeErr error
@@ -348,7 +349,7 @@ func attachHandleResize(ctx, winCtx context.Context, winChange chan os.Signal, i
 				resizeErr = ResizeContainerTTY(ctx, id, new(ResizeTTYOptions).WithHeight(h).WithWidth(w))
 			}
 			if resizeErr != nil {
-				logrus.Warnf("failed to resize TTY: %v", err)
+				logrus.Warnf("failed to resize TTY: %v", resizeErr)
 			}
 		}
 	}
@@ -315,7 +315,8 @@ func Unpause(ctx context.Context, nameOrID string, options *UnpauseOptions) erro
 }
 
 // Wait blocks until the given container reaches a condition. If not provided, the condition will
-// default to stopped.  If the condition is stopped, an exit code for the container will be provided. The
+// default to stopped.  With Conditions, several conditions can be given and the first one met ends
+// the wait.  If the container stopped, an exit code for the container will be provided. The
 // nameOrID can be a container name or a partial/full ID.
 func Wait(ctx context.Context, nameOrID string, options *WaitOptions) (int32, error) { // nolint
 	if options == nil {
@@ -330,6 +331,9 @@ func Wait(ctx context.Context, nameOrID string, options *WaitOptions) (int32, er
 	if options.Changed("Condition") {
 		params.Set("condition", options.GetCondition().String())
 	}
+	for _, condition := range options.GetConditions() {
+		params.Add("condition", condition)
+	}
 	response, err := conn.DoRequest(nil, http.MethodPost, "/containers/%s/wait", params, nil, nameOrID)
 	if err != nil {
 		return exitCode, err
@@ -435,3 +439,90 @@ func ShouldRestart(ctx context.Context, nameOrID string, options *ShouldRestartO
 	}
 	return response.IsSuccess(), nil
 }
+
+// Clone creates a new container from the configuration of the container
+// identified by nameOrID.  Use the CloneOptions to set the name, the image or
+// the resource limits of the clone.
+func Clone(ctx context.Context, nameOrID string, options *CloneOptions) (*entities.ContainerCloneReport, error) {
+	var report entities.ContainerCloneReport
+	if options == nil {
+		options = new(CloneOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(nil, http.MethodPost, "/containers/%s/clone", params, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	return &report, response.Process(&report)
+}
+
+// Update updates the resource limits of the container identified by
+// nameOrID.  The limits of a running container are changed immediately.
+func Update(ctx context.Context, nameOrID string, options *UpdateOptions) (*entities.ContainerUpdateReport, error) {
+	var report entities.ContainerUpdateReport
+	if options == nil {
+		options = new(UpdateOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(nil, http.MethodPost, "/containers/%s/update", params, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	return &report, response.Process(&report)
+}
+
+// Rename renames the container with the given name or ID.
+func Rename(ctx context.Context, nameOrID string, options *RenameOptions) error {
+	if options == nil {
+		options = new(RenameOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return err
+	}
+	response, err := conn.DoRequest(nil, http.MethodPost, "/containers/%s/rename", params, nil, nameOrID)
+	if err != nil {
+		return err
+	}
+	return response.Process(nil)
+}
+
+// DropCaches reclaims memory of the running or paused container identified by
+// nameOrID, mostly page cache, without restarting it.  Up to the amount of
+// the options is reclaimed, or as much as possible if it is not set.
+func DropCaches(ctx context.Context, nameOrID string, options *DropCachesOptions) error {
+	if options == nil {
+		options = new(DropCachesOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return err
+	}
+	response, err := conn.DoRequest(nil, http.MethodPost, "/containers/%s/drop-caches", params, nil, nameOrID)
+	if err != nil {
+		return err
+	}
+	return response.Process(nil)
+}
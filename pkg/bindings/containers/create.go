@@ -32,3 +32,30 @@ func CreateWithSpec(ctx context.Context, s *specgen.SpecGenerator, options *Crea
 	}
 	return ccr, response.Process(&ccr)
 }
+
+// CreateBatchWithSpec creates a batch of containers from one spec.  The
+// containers are numbered from 0; see the name pattern option.
+func CreateBatchWithSpec(ctx context.Context, s *specgen.SpecGenerator, options *CreateBatchOptions) (entities.ContainerCreateBatchResponse, error) {
+	var ccr entities.ContainerCreateBatchResponse
+	if options == nil {
+		options = new(CreateBatchOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return ccr, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return ccr, err
+	}
+	specgenString, err := jsoniter.MarshalToString(s)
+	if err != nil {
+		return ccr, err
+	}
+	stringReader := strings.NewReader(specgenString)
+	response, err := conn.DoRequest(stringReader, http.MethodPost, "/containers/create/batch", params, nil)
+	if err != nil {
+		return ccr, err
+	}
+	return ccr, response.Process(&ccr)
+}
@@ -32,6 +32,9 @@ type CommitOptions struct {
 	Pause   *bool
 	Repo    *string
 	Tag     *string
+	// Timestamp is the created timestamp of the image in seconds since
+	// the epoch, to allow for reproducible image digests
+	Timestamp *int64
 }
 
 //go:generate go run ../generator/generator.go AttachOptions
@@ -47,6 +50,7 @@ type AttachOptions struct {
 type CheckpointOptions struct {
 	Export         *string
 	IgnoreRootfs   *bool
+	IgnoreVolumes  *bool
 	Keep           *bool
 	LeaveRunning   *bool
 	TCPEstablished *bool
@@ -58,16 +62,71 @@ type RestoreOptions struct {
 	IgnoreRootfs    *bool
 	IgnoreStaticIP  *bool
 	IgnoreStaticMAC *bool
+	IgnoreVolumes   *bool
 	ImportAchive    *string
 	Keep            *bool
 	Name            *string
 	TCPEstablished  *bool
 }
 
+//go:generate go run ../generator/generator.go CloneOptions
+// CloneOptions are optional options for cloning containers
+type CloneOptions struct {
+	CPUPeriod  *uint64
+	CPUQuota   *int64
+	CPUSetCPUs *string
+	CPUShares  *uint64
+	Image      *string
+	Memory     *int64
+	Name       *string
+	Run        *bool
+}
+
+//go:generate go run ../generator/generator.go UpdateOptions
+// UpdateOptions are optional options for updating the resource limits, labels
+// and annotations of containers.  Labels and annotations are given as
+// key=value pairs.
+type UpdateOptions struct {
+	Annotations       []string
+	BlkioWeight       *uint16
+	CPUPeriod         *uint64
+	CPUQuota          *int64
+	CPUSetCPUs        *string
+	CPUSetMems        *string
+	CPUShares         *uint64
+	Labels            []string
+	Memory            *int64
+	MemorySwap        *int64
+	PidsLimit         *int64
+	RemoveAnnotations []string
+	RemoveLabels      []string
+}
+
+//go:generate go run ../generator/generator.go DropCachesOptions
+// DropCachesOptions are optional options for dropping the caches of
+// containers
+type DropCachesOptions struct {
+	Amount *uint64
+}
+
+//go:generate go run ../generator/generator.go RenameOptions
+// RenameOptions are options for renaming containers
+type RenameOptions struct {
+	Name *string
+}
+
 //go:generate go run ../generator/generator.go CreateOptions
 // CreateOptions are optional options for creating containers
 type CreateOptions struct{}
 
+//go:generate go run ../generator/generator.go CreateBatchOptions
+// CreateBatchOptions are optional options for creating a batch of containers
+type CreateBatchOptions struct {
+	Count       *uint
+	NamePattern *string
+	BatchSize   *uint
+}
+
 //go:generate go run ../generator/generator.go DiffOptions
 // DiffOptions are optional options for creating containers
 type DiffOptions struct{}
@@ -106,9 +165,11 @@ type MountedContainerPathsOptions struct{}
 // ListOptions are optional options for listing containers
 type ListOptions struct {
 	All       *bool
+	External  *bool
 	Filters   map[string][]string
 	Last      *int
 	Namespace *bool
+	Pids      *bool
 	Size      *bool
 	Sync      *bool
 }
@@ -173,7 +234,8 @@ type UnpauseOptions struct{}
 //go:generate go run ../generator/generator.go WaitOptions
 // WaitOptions are optional options for waiting on containers
 type WaitOptions struct {
-	Condition *define.ContainerStatus
+	Condition  *define.ContainerStatus
+	Conditions []string
 }
 
 //go:generate go run ../generator/generator.go StopOptions
@@ -230,3 +292,14 @@ type ExecStartAndAttachOptions struct {
 	// If false, stdout will not be attached
 	AttachInput *bool
 }
+
+//go:generate go run ../generator/generator.go CopyOptions
+// CopyOptions are optional options for copying a tar archive into a container
+type CopyOptions struct {
+	// Chown changes the ownership of the copied files to the primary
+	// uid/gid of the container.  It defaults to true.
+	Chown *bool
+	// NoOverwriteDirNonDir returns an error instead of replacing a
+	// directory with a non-directory or vice versa.
+	NoOverwriteDirNonDir *bool
+}
@@ -119,6 +119,22 @@ func (o *CheckpointOptions) GetIgnoreRootfs() bool {
 	return *o.IgnoreRootfs
 }
 
+// WithIgnoreVolumes
+func (o *CheckpointOptions) WithIgnoreVolumes(value bool) *CheckpointOptions {
+	v := &value
+	o.IgnoreVolumes = v
+	return o
+}
+
+// GetIgnoreVolumes
+func (o *CheckpointOptions) GetIgnoreVolumes() bool {
+	var ignoreVolumes bool
+	if o.IgnoreVolumes == nil {
+		return ignoreVolumes
+	}
+	return *o.IgnoreVolumes
+}
+
 // WithKeep
 func (o *CheckpointOptions) WithKeep(value bool) *CheckpointOptions {
 	v := &value
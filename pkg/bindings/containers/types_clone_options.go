@@ -0,0 +1,216 @@
+package containers
+
+import (
+	"net/url"
+	"reflect"
+	"strconv"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+/*
+This file is generated automatically by go generate.  Do not edit.
+
+Created 2020-12-18 13:33:18.420656951 -0600 CST m=+0.000259662
+*/
+
+// Changed
+func (o *CloneOptions) Changed(fieldName string) bool {
+	r := reflect.ValueOf(o)
+	value := reflect.Indirect(r).FieldByName(fieldName)
+	return !value.IsNil()
+}
+
+// ToParams
+func (o *CloneOptions) ToParams() (url.Values, error) {
+	params := url.Values{}
+	if o == nil {
+		return params, nil
+	}
+	json := jsoniter.ConfigCompatibleWithStandardLibrary
+	s := reflect.ValueOf(o)
+	if reflect.Ptr == s.Kind() {
+		s = s.Elem()
+	}
+	sType := s.Type()
+	for i := 0; i < s.NumField(); i++ {
+		fieldName := sType.Field(i).Name
+		if !o.Changed(fieldName) {
+			continue
+		}
+		f := s.Field(i)
+		if reflect.Ptr == f.Kind() {
+			f = f.Elem()
+		}
+		switch f.Kind() {
+		case reflect.Bool:
+			params.Set(fieldName, strconv.FormatBool(f.Bool()))
+		case reflect.String:
+			params.Set(fieldName, f.String())
+		case reflect.Int, reflect.Int64:
+			// f.Int() is always an int64
+			params.Set(fieldName, strconv.FormatInt(f.Int(), 10))
+		case reflect.Uint, reflect.Uint64:
+			// f.Uint() is always an uint64
+			params.Set(fieldName, strconv.FormatUint(f.Uint(), 10))
+		case reflect.Slice:
+			typ := reflect.TypeOf(f.Interface()).Elem()
+			switch typ.Kind() {
+			case reflect.String:
+				sl := f.Slice(0, f.Len())
+				s, ok := sl.Interface().([]string)
+				if !ok {
+					return nil, errors.New("failed to convert to string slice")
+				}
+				for _, val := range s {
+					params.Add(fieldName, val)
+				}
+			default:
+				return nil, errors.Errorf("unknown slice type %s", f.Kind().String())
+			}
+		case reflect.Map:
+			lowerCaseKeys := make(map[string][]string)
+			iter := f.MapRange()
+			for iter.Next() {
+				lowerCaseKeys[iter.Key().Interface().(string)] = iter.Value().Interface().([]string)
+
+			}
+			s, err := json.MarshalToString(lowerCaseKeys)
+			if err != nil {
+				return nil, err
+			}
+
+			params.Set(fieldName, s)
+		}
+	}
+	return params, nil
+}
+
+// WithCPUPeriod
+func (o *CloneOptions) WithCPUPeriod(value uint64) *CloneOptions {
+	v := &value
+	o.CPUPeriod = v
+	return o
+}
+
+// GetCPUPeriod
+func (o *CloneOptions) GetCPUPeriod() uint64 {
+	var cPUPeriod uint64
+	if o.CPUPeriod == nil {
+		return cPUPeriod
+	}
+	return *o.CPUPeriod
+}
+
+// WithCPUQuota
+func (o *CloneOptions) WithCPUQuota(value int64) *CloneOptions {
+	v := &value
+	o.CPUQuota = v
+	return o
+}
+
+// GetCPUQuota
+func (o *CloneOptions) GetCPUQuota() int64 {
+	var cPUQuota int64
+	if o.CPUQuota == nil {
+		return cPUQuota
+	}
+	return *o.CPUQuota
+}
+
+// WithCPUSetCPUs
+func (o *CloneOptions) WithCPUSetCPUs(value string) *CloneOptions {
+	v := &value
+	o.CPUSetCPUs = v
+	return o
+}
+
+// GetCPUSetCPUs
+func (o *CloneOptions) GetCPUSetCPUs() string {
+	var cPUSetCPUs string
+	if o.CPUSetCPUs == nil {
+		return cPUSetCPUs
+	}
+	return *o.CPUSetCPUs
+}
+
+// WithCPUShares
+func (o *CloneOptions) WithCPUShares(value uint64) *CloneOptions {
+	v := &value
+	o.CPUShares = v
+	return o
+}
+
+// GetCPUShares
+func (o *CloneOptions) GetCPUShares() uint64 {
+	var cPUShares uint64
+	if o.CPUShares == nil {
+		return cPUShares
+	}
+	return *o.CPUShares
+}
+
+// WithImage
+func (o *CloneOptions) WithImage(value string) *CloneOptions {
+	v := &value
+	o.Image = v
+	return o
+}
+
+// GetImage
+func (o *CloneOptions) GetImage() string {
+	var image string
+	if o.Image == nil {
+		return image
+	}
+	return *o.Image
+}
+
+// WithMemory
+func (o *CloneOptions) WithMemory(value int64) *CloneOptions {
+	v := &value
+	o.Memory = v
+	return o
+}
+
+// GetMemory
+func (o *CloneOptions) GetMemory() int64 {
+	var memory int64
+	if o.Memory == nil {
+		return memory
+	}
+	return *o.Memory
+}
+
+// WithName
+func (o *CloneOptions) WithName(value string) *CloneOptions {
+	v := &value
+	o.Name = v
+	return o
+}
+
+// GetName
+func (o *CloneOptions) GetName() string {
+	var name string
+	if o.Name == nil {
+		return name
+	}
+	return *o.Name
+}
+
+// WithRun
+func (o *CloneOptions) WithRun(value bool) *CloneOptions {
+	v := &value
+	o.Run = v
+	return o
+}
+
+// GetRun
+func (o *CloneOptions) GetRun() bool {
+	var run bool
+	if o.Run == nil {
+		return run
+	}
+	return *o.Run
+}
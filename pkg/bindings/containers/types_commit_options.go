@@ -12,7 +12,7 @@ import (
 /*
 This file is generated automatically by go generate.  Do not edit.
 
-Created 2020-12-18 13:33:18.420656951 -0600 CST m=+0.000259662
+Created 2026-10-15 16:44:17.0856737 +0000 UTC m=+0.000672988
 */
 
 // Changed
@@ -198,3 +198,19 @@ func (o *CommitOptions) GetTag() string {
 	}
 	return *o.Tag
 }
+
+// WithTimestamp
+func (o *CommitOptions) WithTimestamp(value int64) *CommitOptions {
+	v := &value
+	o.Timestamp = v
+	return o
+}
+
+// GetTimestamp
+func (o *CommitOptions) GetTimestamp() int64 {
+	var timestamp int64
+	if o.Timestamp == nil {
+		return timestamp
+	}
+	return *o.Timestamp
+}
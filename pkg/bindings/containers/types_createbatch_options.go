@@ -0,0 +1,138 @@
+package containers
+
+import (
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+/*
+This file is generated automatically by go generate.  Do not edit.
+
+Created 2020-12-18 13:33:20.199081744 -0600 CST m=+0.000270626
+*/
+
+// Changed
+func (o *CreateBatchOptions) Changed(fieldName string) bool {
+	r := reflect.ValueOf(o)
+	value := reflect.Indirect(r).FieldByName(fieldName)
+	return !value.IsNil()
+}
+
+// ToParams
+func (o *CreateBatchOptions) ToParams() (url.Values, error) {
+	params := url.Values{}
+	if o == nil {
+		return params, nil
+	}
+	json := jsoniter.ConfigCompatibleWithStandardLibrary
+	s := reflect.ValueOf(o)
+	if reflect.Ptr == s.Kind() {
+		s = s.Elem()
+	}
+	sType := s.Type()
+	for i := 0; i < s.NumField(); i++ {
+		fieldName := sType.Field(i).Name
+		if !o.Changed(fieldName) {
+			continue
+		}
+		fieldName = strings.ToLower(fieldName)
+		f := s.Field(i)
+		if reflect.Ptr == f.Kind() {
+			f = f.Elem()
+		}
+		switch f.Kind() {
+		case reflect.Bool:
+			params.Set(fieldName, strconv.FormatBool(f.Bool()))
+		case reflect.String:
+			params.Set(fieldName, f.String())
+		case reflect.Int, reflect.Int64:
+			// f.Int() is always an int64
+			params.Set(fieldName, strconv.FormatInt(f.Int(), 10))
+		case reflect.Uint, reflect.Uint64:
+			// f.Uint() is always an uint64
+			params.Set(fieldName, strconv.FormatUint(f.Uint(), 10))
+		case reflect.Slice:
+			typ := reflect.TypeOf(f.Interface()).Elem()
+			switch typ.Kind() {
+			case reflect.String:
+				sl := f.Slice(0, f.Len())
+				s, ok := sl.Interface().([]string)
+				if !ok {
+					return nil, errors.New("failed to convert to string slice")
+				}
+				for _, val := range s {
+					params.Add(fieldName, val)
+				}
+			default:
+				return nil, errors.Errorf("unknown slice type %s", f.Kind().String())
+			}
+		case reflect.Map:
+			lowerCaseKeys := make(map[string][]string)
+			iter := f.MapRange()
+			for iter.Next() {
+				lowerCaseKeys[iter.Key().Interface().(string)] = iter.Value().Interface().([]string)
+
+			}
+			s, err := json.MarshalToString(lowerCaseKeys)
+			if err != nil {
+				return nil, err
+			}
+
+			params.Set(fieldName, s)
+		}
+	}
+	return params, nil
+}
+
+// WithCount
+func (o *CreateBatchOptions) WithCount(value uint) *CreateBatchOptions {
+	v := &value
+	o.Count = v
+	return o
+}
+
+// GetCount
+func (o *CreateBatchOptions) GetCount() uint {
+	var count uint
+	if o.Count == nil {
+		return count
+	}
+	return *o.Count
+}
+
+// WithNamePattern
+func (o *CreateBatchOptions) WithNamePattern(value string) *CreateBatchOptions {
+	v := &value
+	o.NamePattern = v
+	return o
+}
+
+// GetNamePattern
+func (o *CreateBatchOptions) GetNamePattern() string {
+	var namePattern string
+	if o.NamePattern == nil {
+		return namePattern
+	}
+	return *o.NamePattern
+}
+
+// WithBatchSize
+func (o *CreateBatchOptions) WithBatchSize(value uint) *CreateBatchOptions {
+	v := &value
+	o.BatchSize = v
+	return o
+}
+
+// GetBatchSize
+func (o *CreateBatchOptions) GetBatchSize() uint {
+	var batchSize uint
+	if o.BatchSize == nil {
+		return batchSize
+	}
+	return *o.BatchSize
+}
@@ -105,6 +105,22 @@ func (o *ListOptions) GetAll() bool {
 	return *o.All
 }
 
+// WithExternal
+func (o *ListOptions) WithExternal(value bool) *ListOptions {
+	v := &value
+	o.External = v
+	return o
+}
+
+// GetExternal
+func (o *ListOptions) GetExternal() bool {
+	var external bool
+	if o.External == nil {
+		return external
+	}
+	return *o.External
+}
+
 // WithFilters
 func (o *ListOptions) WithFilters(value map[string][]string) *ListOptions {
 	v := value
@@ -153,6 +169,22 @@ func (o *ListOptions) GetNamespace() bool {
 	return *o.Namespace
 }
 
+// WithPids
+func (o *ListOptions) WithPids(value bool) *ListOptions {
+	v := &value
+	o.Pids = v
+	return o
+}
+
+// GetPids
+func (o *ListOptions) GetPids() bool {
+	var pids bool
+	if o.Pids == nil {
+		return pids
+	}
+	return *o.Pids
+}
+
 // WithSize
 func (o *ListOptions) WithSize(value bool) *ListOptions {
 	v := &value
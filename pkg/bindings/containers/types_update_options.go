@@ -0,0 +1,296 @@
+package containers
+
+import (
+	"net/url"
+	"reflect"
+	"strconv"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+/*
+This file is generated automatically by go generate.  Do not edit.
+
+Created 2020-12-18 13:33:18.420656951 -0600 CST m=+0.000259662
+*/
+
+// Changed
+func (o *UpdateOptions) Changed(fieldName string) bool {
+	r := reflect.ValueOf(o)
+	value := reflect.Indirect(r).FieldByName(fieldName)
+	return !value.IsNil()
+}
+
+// ToParams
+func (o *UpdateOptions) ToParams() (url.Values, error) {
+	params := url.Values{}
+	if o == nil {
+		return params, nil
+	}
+	json := jsoniter.ConfigCompatibleWithStandardLibrary
+	s := reflect.ValueOf(o)
+	if reflect.Ptr == s.Kind() {
+		s = s.Elem()
+	}
+	sType := s.Type()
+	for i := 0; i < s.NumField(); i++ {
+		fieldName := sType.Field(i).Name
+		if !o.Changed(fieldName) {
+			continue
+		}
+		f := s.Field(i)
+		if reflect.Ptr == f.Kind() {
+			f = f.Elem()
+		}
+		switch f.Kind() {
+		case reflect.Bool:
+			params.Set(fieldName, strconv.FormatBool(f.Bool()))
+		case reflect.String:
+			params.Set(fieldName, f.String())
+		case reflect.Int, reflect.Int64:
+			// f.Int() is always an int64
+			params.Set(fieldName, strconv.FormatInt(f.Int(), 10))
+		case reflect.Uint, reflect.Uint64:
+			// f.Uint() is always an uint64
+			params.Set(fieldName, strconv.FormatUint(f.Uint(), 10))
+		case reflect.Slice:
+			typ := reflect.TypeOf(f.Interface()).Elem()
+			switch typ.Kind() {
+			case reflect.String:
+				sl := f.Slice(0, f.Len())
+				s, ok := sl.Interface().([]string)
+				if !ok {
+					return nil, errors.New("failed to convert to string slice")
+				}
+				for _, val := range s {
+					params.Add(fieldName, val)
+				}
+			default:
+				return nil, errors.Errorf("unknown slice type %s", f.Kind().String())
+			}
+		case reflect.Map:
+			lowerCaseKeys := make(map[string][]string)
+			iter := f.MapRange()
+			for iter.Next() {
+				lowerCaseKeys[iter.Key().Interface().(string)] = iter.Value().Interface().([]string)
+
+			}
+			s, err := json.MarshalToString(lowerCaseKeys)
+			if err != nil {
+				return nil, err
+			}
+
+			params.Set(fieldName, s)
+		}
+	}
+	return params, nil
+}
+
+// WithAnnotations
+func (o *UpdateOptions) WithAnnotations(value []string) *UpdateOptions {
+	v := value
+	o.Annotations = v
+	return o
+}
+
+// GetAnnotations
+func (o *UpdateOptions) GetAnnotations() []string {
+	var annotations []string
+	if o.Annotations == nil {
+		return annotations
+	}
+	return o.Annotations
+}
+
+// WithBlkioWeight
+func (o *UpdateOptions) WithBlkioWeight(value uint16) *UpdateOptions {
+	v := &value
+	o.BlkioWeight = v
+	return o
+}
+
+// GetBlkioWeight
+func (o *UpdateOptions) GetBlkioWeight() uint16 {
+	var blkioWeight uint16
+	if o.BlkioWeight == nil {
+		return blkioWeight
+	}
+	return *o.BlkioWeight
+}
+
+// WithCPUPeriod
+func (o *UpdateOptions) WithCPUPeriod(value uint64) *UpdateOptions {
+	v := &value
+	o.CPUPeriod = v
+	return o
+}
+
+// GetCPUPeriod
+func (o *UpdateOptions) GetCPUPeriod() uint64 {
+	var cPUPeriod uint64
+	if o.CPUPeriod == nil {
+		return cPUPeriod
+	}
+	return *o.CPUPeriod
+}
+
+// WithCPUQuota
+func (o *UpdateOptions) WithCPUQuota(value int64) *UpdateOptions {
+	v := &value
+	o.CPUQuota = v
+	return o
+}
+
+// GetCPUQuota
+func (o *UpdateOptions) GetCPUQuota() int64 {
+	var cPUQuota int64
+	if o.CPUQuota == nil {
+		return cPUQuota
+	}
+	return *o.CPUQuota
+}
+
+// WithCPUSetCPUs
+func (o *UpdateOptions) WithCPUSetCPUs(value string) *UpdateOptions {
+	v := &value
+	o.CPUSetCPUs = v
+	return o
+}
+
+// GetCPUSetCPUs
+func (o *UpdateOptions) GetCPUSetCPUs() string {
+	var cPUSetCPUs string
+	if o.CPUSetCPUs == nil {
+		return cPUSetCPUs
+	}
+	return *o.CPUSetCPUs
+}
+
+// WithCPUSetMems
+func (o *UpdateOptions) WithCPUSetMems(value string) *UpdateOptions {
+	v := &value
+	o.CPUSetMems = v
+	return o
+}
+
+// GetCPUSetMems
+func (o *UpdateOptions) GetCPUSetMems() string {
+	var cPUSetMems string
+	if o.CPUSetMems == nil {
+		return cPUSetMems
+	}
+	return *o.CPUSetMems
+}
+
+// WithCPUShares
+func (o *UpdateOptions) WithCPUShares(value uint64) *UpdateOptions {
+	v := &value
+	o.CPUShares = v
+	return o
+}
+
+// GetCPUShares
+func (o *UpdateOptions) GetCPUShares() uint64 {
+	var cPUShares uint64
+	if o.CPUShares == nil {
+		return cPUShares
+	}
+	return *o.CPUShares
+}
+
+// WithLabels
+func (o *UpdateOptions) WithLabels(value []string) *UpdateOptions {
+	v := value
+	o.Labels = v
+	return o
+}
+
+// GetLabels
+func (o *UpdateOptions) GetLabels() []string {
+	var labels []string
+	if o.Labels == nil {
+		return labels
+	}
+	return o.Labels
+}
+
+// WithMemory
+func (o *UpdateOptions) WithMemory(value int64) *UpdateOptions {
+	v := &value
+	o.Memory = v
+	return o
+}
+
+// GetMemory
+func (o *UpdateOptions) GetMemory() int64 {
+	var memory int64
+	if o.Memory == nil {
+		return memory
+	}
+	return *o.Memory
+}
+
+// WithMemorySwap
+func (o *UpdateOptions) WithMemorySwap(value int64) *UpdateOptions {
+	v := &value
+	o.MemorySwap = v
+	return o
+}
+
+// GetMemorySwap
+func (o *UpdateOptions) GetMemorySwap() int64 {
+	var memorySwap int64
+	if o.MemorySwap == nil {
+		return memorySwap
+	}
+	return *o.MemorySwap
+}
+
+// WithPidsLimit
+func (o *UpdateOptions) WithPidsLimit(value int64) *UpdateOptions {
+	v := &value
+	o.PidsLimit = v
+	return o
+}
+
+// GetPidsLimit
+func (o *UpdateOptions) GetPidsLimit() int64 {
+	var pidsLimit int64
+	if o.PidsLimit == nil {
+		return pidsLimit
+	}
+	return *o.PidsLimit
+}
+
+// WithRemoveAnnotations
+func (o *UpdateOptions) WithRemoveAnnotations(value []string) *UpdateOptions {
+	v := value
+	o.RemoveAnnotations = v
+	return o
+}
+
+// GetRemoveAnnotations
+func (o *UpdateOptions) GetRemoveAnnotations() []string {
+	var removeAnnotations []string
+	if o.RemoveAnnotations == nil {
+		return removeAnnotations
+	}
+	return o.RemoveAnnotations
+}
+
+// WithRemoveLabels
+func (o *UpdateOptions) WithRemoveLabels(value []string) *UpdateOptions {
+	v := value
+	o.RemoveLabels = v
+	return o
+}
+
+// GetRemoveLabels
+func (o *UpdateOptions) GetRemoveLabels() []string {
+	var removeLabels []string
+	if o.RemoveLabels == nil {
+		return removeLabels
+	}
+	return o.RemoveLabels
+}
@@ -13,7 +13,7 @@ import (
 /*
 This file is generated automatically by go generate.  Do not edit.
 
-Created 2020-12-18 13:33:21.809397978 -0600 CST m=+0.000267049
+Created 2026-10-15 12:44:53.008669383 +0000 UTC m=+0.000630795
 */
 
 // Changed
@@ -103,3 +103,19 @@ func (o *WaitOptions) GetCondition() define.ContainerStatus {
 	}
 	return *o.Condition
 }
+
+// WithConditions
+func (o *WaitOptions) WithConditions(value []string) *WaitOptions {
+	v := value
+	o.Conditions = v
+	return o
+}
+
+// GetConditions
+func (o *WaitOptions) GetConditions() []string {
+	var conditions []string
+	if o.Conditions == nil {
+		return conditions
+	}
+	return o.Conditions
+}
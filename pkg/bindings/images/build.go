@@ -99,6 +99,9 @@ func Build(ctx context.Context, containerFiles []string, options entities.BuildO
 	if options.CommonBuildOpts.HTTPProxy {
 		params.Set("httpproxy", "1")
 	}
+	if options.Timestamp != nil {
+		params.Set("timestamp", strconv.FormatInt(options.Timestamp.Unix(), 10))
+	}
 
 	var (
 		headers map[string]string
@@ -29,6 +29,31 @@ func Exists(ctx context.Context, nameOrID string) (bool, error) {
 	return response.IsSuccess(), nil
 }
 
+// Lookup checks which of the given images exist in local storage and returns
+// their IDs and digests.  All images are looked up with a single request.
+func Lookup(ctx context.Context, namesOrIDs []string, options *LookupOptions) ([]*entities.ImageLookupReport, error) {
+	if options == nil {
+		options = new(LookupOptions)
+	}
+	var reports []*entities.ImageLookupReport
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range namesOrIDs {
+		params.Add("names", name)
+	}
+	response, err := conn.DoRequest(nil, http.MethodGet, "/images/lookup", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	return reports, response.Process(&reports)
+}
+
 // List returns a list of images in local storage.  The all boolean and filters parameters are optional
 // ways to alter the image query.
 func List(ctx context.Context, options *ListOptions) ([]*entities.ImageSummary, error) {
@@ -87,6 +112,14 @@ func Tree(ctx context.Context, nameOrID string, options *TreeOptions) (*entities
 	if err != nil {
 		return nil, err
 	}
+	// The shared layers of all images are listed without a name
+	if nameOrID == "" {
+		response, err := conn.DoRequest(nil, http.MethodGet, "/images/tree", params, nil)
+		if err != nil {
+			return nil, err
+		}
+		return &report, response.Process(&report)
+	}
 	response, err := conn.DoRequest(nil, http.MethodGet, "/images/%s/tree", params, nil, nameOrID)
 	if err != nil {
 		return nil, err
@@ -14,6 +14,7 @@ import (
 	"github.com/containers/podman/v2/pkg/auth"
 	"github.com/containers/podman/v2/pkg/bindings"
 	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/containers/podman/v2/pkg/util"
 	"github.com/hashicorp/go-multierror"
 )
 
@@ -35,6 +36,11 @@ func Pull(ctx context.Context, rawImage string, options *PullOptions) ([]string,
 	}
 	params.Set("reference", rawImage)
 
+	if options.PullPolicy != nil {
+		params.Del("PullPolicy")
+		params.Set("policy", util.PullTypeToString(options.GetPullPolicy()))
+	}
+
 	if options.SkipTLSVerify != nil {
 		params.Del("SkipTLSVerify")
 		// Note: we have to verify if skipped is false.
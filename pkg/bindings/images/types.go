@@ -19,6 +19,11 @@ type RemoveOptions struct {
 type DiffOptions struct {
 }
 
+//go:generate go run ../generator/generator.go LookupOptions
+// LookupOptions are optional options for looking up images
+type LookupOptions struct {
+}
+
 //go:generate go run ../generator/generator.go ListOptions
 // ListOptions are optional options for listing images
 type ListOptions struct {
@@ -41,6 +46,8 @@ type GetOptions struct {
 type TreeOptions struct {
 	// WhatRequires ...
 	WhatRequires *bool
+	// Shared lists the other images using the layers of the image
+	Shared *bool
 }
 
 //go:generate go run ../generator/generator.go HistoryOptions
@@ -151,6 +158,8 @@ type SearchOptions struct {
 	SkipTLSVerify *bool
 	// ListTags search the available tags of the repository
 	ListTags *bool
+	// TagDigests looks up the digests of the listed tags
+	TagDigests *bool
 }
 
 //go:generate go run ../generator/generator.go PullOptions
@@ -182,3 +182,19 @@ func (o *SearchOptions) GetListTags() bool {
 	}
 	return *o.ListTags
 }
+
+// WithTagDigests
+func (o *SearchOptions) WithTagDigests(value bool) *SearchOptions {
+	v := &value
+	o.TagDigests = v
+	return o
+}
+
+// GetTagDigests
+func (o *SearchOptions) GetTagDigests() bool {
+	var tagDigests bool
+	if o.TagDigests == nil {
+		return tagDigests
+	}
+	return *o.TagDigests
+}
@@ -102,3 +102,19 @@ func (o *TreeOptions) GetWhatRequires() bool {
 	}
 	return *o.WhatRequires
 }
+
+// WithShared
+func (o *TreeOptions) WithShared(value bool) *TreeOptions {
+	v := &value
+	o.Shared = v
+	return o
+}
+
+// GetShared
+func (o *TreeOptions) GetShared() bool {
+	var shared bool
+	if o.Shared == nil {
+		return shared
+	}
+	return *o.Shared
+}
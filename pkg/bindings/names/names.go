@@ -0,0 +1,70 @@
+package names
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/containers/podman/v2/pkg/bindings"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+)
+
+// Reserve reserves a name for a container or pod to be created later.  A
+// name is generated if options does not set one.
+func Reserve(ctx context.Context, options *ReserveOptions) (*entities.NameReservationReport, error) {
+	var (
+		report entities.NameReservationReport
+	)
+	if options == nil {
+		options = new(ReserveOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(nil, http.MethodPost, "/names/reserve", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &report, response.Process(&report)
+}
+
+// Release releases a reserved name.
+func Release(ctx context.Context, name string, options *ReleaseOptions) error {
+	if options == nil {
+		options = new(ReleaseOptions)
+	}
+	_ = options
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	response, err := conn.DoRequest(nil, http.MethodDelete, "/names/%s", nil, nil, name)
+	if err != nil {
+		return err
+	}
+	return response.Process(nil)
+}
+
+// List returns the reserved names.
+func List(ctx context.Context, options *ListOptions) ([]*entities.NameReservationReport, error) {
+	var (
+		reservations []*entities.NameReservationReport
+	)
+	if options == nil {
+		options = new(ListOptions)
+	}
+	_ = options
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(nil, http.MethodGet, "/names/json", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return reservations, response.Process(&reservations)
+}
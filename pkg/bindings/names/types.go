@@ -0,0 +1,21 @@
+package names
+
+//go:generate go run ../generator/generator.go ReserveOptions
+// ReserveOptions are optional options for reserving names
+type ReserveOptions struct {
+	// Name is the name to reserve, a name is generated if it is not set
+	Name *string
+	// TTL is the number of seconds the name is reserved, zero means
+	// until it is released or used
+	TTL *int64
+}
+
+//go:generate go run ../generator/generator.go ReleaseOptions
+// ReleaseOptions are optional options for releasing reserved names
+type ReleaseOptions struct {
+}
+
+//go:generate go run ../generator/generator.go ListOptions
+// ListOptions are optional options for listing reserved names
+type ListOptions struct {
+}
@@ -167,3 +167,26 @@ func Connect(ctx context.Context, networkName string, ContainerNameOrId string,
 	}
 	return response.Process(nil)
 }
+
+// Prune removes unused CNI networks
+func Prune(ctx context.Context, options *PruneOptions) ([]*entities.NetworkPruneReport, error) {
+	var (
+		prunedNetworks []*entities.NetworkPruneReport
+	)
+	if options == nil {
+		options = new(PruneOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(nil, http.MethodPost, "/networks/prune", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	return prunedNetworks, response.Process(&prunedNetworks)
+}
@@ -68,3 +68,15 @@ type ConnectOptions struct {
 	// when using the dns plugin
 	Aliases *[]string
 }
+
+//go:generate go run ../generator/generator.go PruneOptions
+// PruneOptions are optional options for removing unused
+// CNI networks
+type PruneOptions struct {
+	// Filters are applied to the prune of networks to be more
+	// specific on choosing
+	Filters map[string][]string
+	// Leaked releases network resources of removed containers
+	// instead of removing unused networks
+	Leaked *bool
+}
@@ -296,6 +296,25 @@ func Unpause(ctx context.Context, nameOrID string, options *UnpauseOptions) (*en
 	return &report, response.Process(&report)
 }
 
+// Update changes the ports published by the pod identified by nameOrID.  The
+// port forwarding of a running pod is set up again with the new ports.
+func Update(ctx context.Context, nameOrID string, update *entities.PodUpdateOptions) (*entities.PodUpdateReport, error) {
+	var report entities.PodUpdateReport
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	updateString, err := jsoniter.MarshalToString(update)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(strings.NewReader(updateString), http.MethodPost, "/pods/%s/update", nil, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	return &report, response.Process(&report)
+}
+
 // Stats display resource-usage statistics of one or more pods.
 func Stats(ctx context.Context, namesOrIDs []string, options *StatsOptions) ([]*entities.PodStatsReport, error) {
 	if options == nil {
@@ -320,3 +339,42 @@ func Stats(ctx context.Context, namesOrIDs []string, options *StatsOptions) ([]*
 	}
 	return reports, response.Process(&reports)
 }
+
+// Clone creates a new pod from the configuration of the pod with the given
+// name or ID, with the containers of the pod recreated in the new pod.
+func Clone(ctx context.Context, nameOrID string, clone *entities.PodCloneOptions) (*entities.PodCloneReport, error) {
+	var report entities.PodCloneReport
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cloneString, err := jsoniter.MarshalToString(clone)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(strings.NewReader(cloneString), http.MethodPost, "/pods/%s/clone", nil, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	return &report, response.Process(&report)
+}
+
+// Rename renames the pod with the given name or ID.
+func Rename(ctx context.Context, nameOrID string, options *RenameOptions) error {
+	if options == nil {
+		options = new(RenameOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return err
+	}
+	response, err := conn.DoRequest(nil, http.MethodPost, "/pods/%s/rename", params, nil, nameOrID)
+	if err != nil {
+		return err
+	}
+	return response.Process(nil)
+}
@@ -13,7 +13,8 @@ type InspectOptions struct {
 //go:generate go run ../generator/generator.go KillOptions
 // KillOptions are optional options for killing pods
 type KillOptions struct {
-	Signal *string
+	Signal       *string
+	ExcludeInfra *bool
 }
 
 //go:generate go run ../generator/generator.go PauseOptions
@@ -63,6 +64,7 @@ type UnpauseOptions struct {
 // StatsOptions are optional options for getting stats of pods
 type StatsOptions struct {
 	All *bool
+	Sum *bool
 }
 
 //go:generate go run ../generator/generator.go RemoveOptions
@@ -70,3 +72,9 @@ type StatsOptions struct {
 type RemoveOptions struct {
 	Force *bool
 }
+
+//go:generate go run ../generator/generator.go RenameOptions
+// RenameOptions are options for renaming pods
+type RenameOptions struct {
+	Name *string
+}
@@ -102,3 +102,19 @@ func (o *KillOptions) GetSignal() string {
 	}
 	return *o.Signal
 }
+
+// WithExcludeInfra
+func (o *KillOptions) WithExcludeInfra(value bool) *KillOptions {
+	v := &value
+	o.ExcludeInfra = v
+	return o
+}
+
+// GetExcludeInfra
+func (o *KillOptions) GetExcludeInfra() bool {
+	var excludeInfra bool
+	if o.ExcludeInfra == nil {
+		return excludeInfra
+	}
+	return *o.ExcludeInfra
+}
@@ -102,3 +102,19 @@ func (o *StatsOptions) GetAll() bool {
 	}
 	return *o.All
 }
+
+// WithSum
+func (o *StatsOptions) WithSum(value bool) *StatsOptions {
+	v := &value
+	o.Sum = v
+	return o
+}
+
+// GetSum
+func (o *StatsOptions) GetSum() bool {
+	var sum bool
+	if o.Sum == nil {
+		return sum
+	}
+	return *o.Sum
+}
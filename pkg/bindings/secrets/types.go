@@ -0,0 +1,25 @@
+package secrets
+
+//go:generate go run ../generator/generator.go CreateOptions
+// CreateOptions are optional options for creating secrets
+type CreateOptions struct {
+	// Name is the name of the secret
+	Name *string
+	// Driver is the secrets driver storing the data of the secret
+	Driver *string
+}
+
+//go:generate go run ../generator/generator.go InspectOptions
+// InspectOptions are optional options for inspecting secrets
+type InspectOptions struct {
+}
+
+//go:generate go run ../generator/generator.go ListOptions
+// ListOptions are optional options for listing secrets
+type ListOptions struct {
+}
+
+//go:generate go run ../generator/generator.go RemoveOptions
+// RemoveOptions are optional options for removing secrets
+type RemoveOptions struct {
+}
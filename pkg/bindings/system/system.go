@@ -143,3 +143,22 @@ func DiskUsage(ctx context.Context, options *DiskOptions) (*entities.SystemDfRep
 	}
 	return &report, response.Process(&report)
 }
+
+// Devices returns the host devices commonly passed into containers, with their
+// cgroup rules and CDI devices
+func Devices(ctx context.Context, options *DevicesOptions) (*entities.SystemDevicesReport, error) {
+	var report entities.SystemDevicesReport
+	if options == nil {
+		options = new(DevicesOptions)
+	}
+	_ = options
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(nil, http.MethodGet, "/system/devices", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &report, response.Process(&report)
+}
@@ -12,9 +12,10 @@ type EventsOptions struct {
 //go:generate go run ../generator/generator.go PruneOptions
 // PruneOptions are optional options for pruning
 type PruneOptions struct {
-	All     *bool
-	Filters map[string][]string
-	Volumes *bool
+	All         *bool
+	Filters     map[string][]string
+	Volumes     *bool
+	Interrupted *bool
 }
 
 //go:generate go run ../generator/generator.go VersionOptions
@@ -27,6 +28,11 @@ type VersionOptions struct {
 type DiskOptions struct {
 }
 
+//go:generate go run ../generator/generator.go DevicesOptions
+// DevicesOptions are optional options for listing host devices
+type DevicesOptions struct {
+}
+
 //go:generate go run ../generator/generator.go InfoOptions
 // InfoOptions are optional options for getting info
 // about libpod
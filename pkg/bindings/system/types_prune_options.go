@@ -134,3 +134,19 @@ func (o *PruneOptions) GetVolumes() bool {
 	}
 	return *o.Volumes
 }
+
+// WithInterrupted
+func (o *PruneOptions) WithInterrupted(value bool) *PruneOptions {
+	v := &value
+	o.Interrupted = v
+	return o
+}
+
+// GetInterrupted
+func (o *PruneOptions) GetInterrupted() bool {
+	var interrupted bool
+	if o.Interrupted == nil {
+		return interrupted
+	}
+	return *o.Interrupted
+}
@@ -22,6 +22,8 @@ type ListOptions struct {
 type PruneOptions struct {
 	// Filters applied to the pruning of volumes
 	Filters map[string][]string
+	// ForceUnused also prunes volumes used only by stopped containers
+	ForceUnused *bool
 }
 
 //go:generate go run ../generator/generator.go RemoveOptions
@@ -30,3 +32,13 @@ type RemoveOptions struct {
 	// Force removes the volume even if it is being used
 	Force *bool
 }
+
+//go:generate go run ../generator/generator.go ExportOptions
+// ExportOptions are optional options for exporting volumes
+type ExportOptions struct {
+}
+
+//go:generate go run ../generator/generator.go ImportOptions
+// ImportOptions are optional options for importing volumes
+type ImportOptions struct {
+}
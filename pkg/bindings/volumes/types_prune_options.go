@@ -12,7 +12,7 @@ import (
 /*
 This file is generated automatically by go generate.  Do not edit.
 
-Created 2020-12-18 15:58:14.463307398 -0600 CST m=+0.000180868
+Created 2020-12-18 13:33:18.420656951 -0600 CST m=+0.000259662
 */
 
 // Changed
@@ -102,3 +102,19 @@ func (o *PruneOptions) GetFilters() map[string][]string {
 	}
 	return o.Filters
 }
+
+// WithForceUnused
+func (o *PruneOptions) WithForceUnused(value bool) *PruneOptions {
+	v := &value
+	o.ForceUnused = v
+	return o
+}
+
+// GetForceUnused
+func (o *PruneOptions) GetForceUnused() bool {
+	var forceUnused bool
+	if o.ForceUnused == nil {
+		return forceUnused
+	}
+	return *o.ForceUnused
+}
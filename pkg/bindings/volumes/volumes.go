@@ -2,6 +2,7 @@ package volumes
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"strings"
 
@@ -113,3 +114,41 @@ func Remove(ctx context.Context, nameOrID string, options *RemoveOptions) error
 	}
 	return response.Process(nil)
 }
+
+// Export writes the contents of a volume as a tar archive to w.
+func Export(ctx context.Context, nameOrID string, w io.Writer, options *ExportOptions) error {
+	if options == nil {
+		options = new(ExportOptions)
+	}
+	_ = options
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	response, err := conn.DoRequest(nil, http.MethodGet, "/volumes/%s/export", nil, nil, nameOrID)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode/100 == 2 {
+		_, err = io.Copy(w, response.Body)
+		return err
+	}
+	return response.Process(nil)
+}
+
+// Import extracts the tar archive read from r into a volume.
+func Import(ctx context.Context, nameOrID string, r io.Reader, options *ImportOptions) error {
+	if options == nil {
+		options = new(ImportOptions)
+	}
+	_ = options
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	response, err := conn.DoRequest(r, http.MethodPost, "/volumes/%s/import", nil, nil, nameOrID)
+	if err != nil {
+		return err
+	}
+	return response.Process(nil)
+}
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/containers/podman/v2/pkg/rootless"
 	systemdDbus "github.com/coreos/go-systemd/v22/dbus"
@@ -70,6 +71,9 @@ type BlkioMetrics struct {
 // MemoryMetrics keeps usage stats for the memory cgroup controller
 type MemoryMetrics struct {
 	Usage MemoryUsage
+	// OOMKills is the number of processes in the cgroup killed by the
+	// OOM killer.  It is always 0 on kernels which do not count them.
+	OOMKills uint64
 }
 
 // PidsMetrics keeps usage stats for the pids cgroup controller
@@ -111,6 +115,8 @@ const (
 	Pids = "pids"
 	// Blkio is the blkio controller
 	Blkio = "blkio"
+	// Freezer is the freezer controller
+	Freezer = "freezer"
 )
 
 var handlers map[string]controllerHandler
@@ -294,6 +300,12 @@ func New(path string, resources *spec.LinuxResources) (*CgroupControl, error) {
 		return nil, err
 	}
 
+	if resources != nil {
+		if err := control.Update(resources); err != nil {
+			return nil, errors.Wrapf(err, "error setting resource limits of cgroup %s", path)
+		}
+	}
+
 	return control, nil
 }
 
@@ -347,8 +359,8 @@ func Load(path string) (*CgroupControl, error) {
 	return control, nil
 }
 
-// CreateSystemdUnit creates the systemd cgroup
-func (c *CgroupControl) CreateSystemdUnit(path string) error {
+// CreateSystemdUnit creates the systemd cgroup with the specified resource limits
+func (c *CgroupControl) CreateSystemdUnit(path string, resources *spec.LinuxResources) error {
 	if !c.systemd {
 		return fmt.Errorf("the cgroup controller is not using systemd")
 	}
@@ -359,7 +371,7 @@ func (c *CgroupControl) CreateSystemdUnit(path string) error {
 	}
 	defer conn.Close()
 
-	return systemdCreate(path, conn)
+	return systemdCreate(path, resources, conn)
 }
 
 // GetUserConnection returns an user connection to D-BUS
@@ -369,8 +381,9 @@ func GetUserConnection(uid int) (*systemdDbus.Conn, error) {
 	})
 }
 
-// CreateSystemdUserUnit creates the systemd cgroup for the specified user
-func (c *CgroupControl) CreateSystemdUserUnit(path string, uid int) error {
+// CreateSystemdUserUnit creates the systemd cgroup for the specified user with
+// the specified resource limits
+func (c *CgroupControl) CreateSystemdUserUnit(path string, uid int, resources *spec.LinuxResources) error {
 	if !c.systemd {
 		return fmt.Errorf("the cgroup controller is not using systemd")
 	}
@@ -381,7 +394,7 @@ func (c *CgroupControl) CreateSystemdUserUnit(path string, uid int) error {
 	}
 	defer conn.Close()
 
-	return systemdCreate(path, conn)
+	return systemdCreate(path, resources, conn)
 }
 
 func dbusAuthConnection(uid int, createBus func(opts ...dbus.ConnOption) (*dbus.Conn, error)) (*dbus.Conn, error) {
@@ -533,6 +546,63 @@ func (c *CgroupControl) Stat() (*Metrics, error) {
 	return &m, nil
 }
 
+// Freeze freezes all processes in the cgroup and its descendants, and waits
+// until they are frozen.
+func (c *CgroupControl) Freeze() error {
+	return c.setFrozen(true)
+}
+
+// Thaw thaws the processes in the cgroup frozen by Freeze, and waits until
+// they are thawed.
+func (c *CgroupControl) Thaw() error {
+	return c.setFrozen(false)
+}
+
+func (c *CgroupControl) setFrozen(frozen bool) error {
+	var p, state string
+	if c.cgroup2 {
+		p = filepath.Join(cgroupRoot, c.path, "cgroup.freeze")
+		state = "0"
+		if frozen {
+			state = "1"
+		}
+	} else {
+		p = filepath.Join(c.getCgroupv1Path(Freezer), "freezer.state")
+		state = "THAWED"
+		if frozen {
+			state = "FROZEN"
+		}
+	}
+	if err := ioutil.WriteFile(p, []byte(state), 0644); err != nil {
+		return errors.Wrapf(err, "write %s", p)
+	}
+
+	// The kernel freezes the processes asynchronously.
+	for i := 0; i < 1000; i++ {
+		var current string
+		if c.cgroup2 {
+			events, err := readCgroup2MapFile(c, "cgroup.events")
+			if err != nil {
+				return err
+			}
+			if len(events["frozen"]) > 0 {
+				current = events["frozen"][0]
+			}
+		} else {
+			content, err := ioutil.ReadFile(p)
+			if err != nil {
+				return errors.Wrapf(err, "read %s", p)
+			}
+			current = cleanString(string(content))
+		}
+		if current == state {
+			return nil
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return errors.Errorf("timed out waiting for cgroup %s to change its freezer state to %s", c.path, state)
+}
+
 func readCgroup2MapPath(path string) (map[string][]string, error) {
 	ret := map[string][]string{}
 	f, err := os.Open(path)
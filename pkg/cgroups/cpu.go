@@ -54,7 +54,36 @@ func (c *cpuHandler) Apply(ctr *CgroupControl, res *spec.LinuxResources) error {
 	if res.CPU == nil {
 		return nil
 	}
-	return fmt.Errorf("cpu apply not implemented yet")
+	if res.CPU.Quota == nil && res.CPU.Period == nil {
+		return fmt.Errorf("cpu apply not implemented yet")
+	}
+	var period uint64 = 100000
+	if res.CPU.Period != nil && *res.CPU.Period != 0 {
+		period = *res.CPU.Period
+	}
+	if ctr.cgroup2 {
+		quota := "max"
+		if res.CPU.Quota != nil && *res.CPU.Quota > 0 {
+			quota = strconv.FormatInt(*res.CPU.Quota, 10)
+		}
+		p := filepath.Join(cgroupRoot, ctr.path, "cpu.max")
+		return ioutil.WriteFile(p, []byte(fmt.Sprintf("%s %d\n", quota, period)), 0644)
+	}
+
+	var quota int64 = -1
+	if res.CPU.Quota != nil && *res.CPU.Quota > 0 {
+		quota = *res.CPU.Quota
+	}
+	cpuRoot := ctr.getCgroupv1Path(CPU)
+	// Lift the quota first, the kernel refuses a period that would make
+	// the current quota exceed the limit of the parent.
+	if err := ioutil.WriteFile(filepath.Join(cpuRoot, "cpu.cfs_quota_us"), []byte("-1\n"), 0644); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(cpuRoot, "cpu.cfs_period_us"), []byte(fmt.Sprintf("%d\n", period)), 0644); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(cpuRoot, "cpu.cfs_quota_us"), []byte(fmt.Sprintf("%d\n", quota)), 0644)
 }
 
 // Create the cgroup
@@ -2,9 +2,15 @@ package cgroups
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
 	"path/filepath"
+	"strconv"
+	"syscall"
 
 	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 type memHandler struct {
@@ -19,7 +25,23 @@ func (c *memHandler) Apply(ctr *CgroupControl, res *spec.LinuxResources) error {
 	if res.Memory == nil {
 		return nil
 	}
-	return fmt.Errorf("memory apply not implemented yet")
+	if res.Memory.Limit == nil {
+		return fmt.Errorf("memory apply not implemented yet")
+	}
+	if ctr.cgroup2 {
+		limit := "max"
+		if *res.Memory.Limit > 0 {
+			limit = strconv.FormatInt(*res.Memory.Limit, 10)
+		}
+		p := filepath.Join(cgroupRoot, ctr.path, "memory.max")
+		return ioutil.WriteFile(p, []byte(limit+"\n"), 0644)
+	}
+	limit := *res.Memory.Limit
+	if limit <= 0 {
+		limit = -1
+	}
+	p := filepath.Join(ctr.getCgroupv1Path(Memory), "memory.limit_in_bytes")
+	return ioutil.WriteFile(p, []byte(fmt.Sprintf("%d\n", limit)), 0644)
 }
 
 // Create the cgroup
@@ -47,10 +69,12 @@ func (c *memHandler) Stat(ctr *CgroupControl, m *Metrics) error {
 		memoryRoot = filepath.Join(cgroupRoot, ctr.path)
 		filenames["usage"] = "memory.current"
 		filenames["limit"] = "memory.max"
+		filenames["events"] = "memory.events"
 	} else {
 		memoryRoot = ctr.getCgroupv1Path(Memory)
 		filenames["usage"] = "memory.usage_in_bytes"
 		filenames["limit"] = "memory.limit_in_bytes"
+		filenames["events"] = "memory.oom_control"
 	}
 	usage.Usage, err = readFileAsUint64(filepath.Join(memoryRoot, filenames["usage"]))
 	if err != nil {
@@ -61,6 +85,71 @@ func (c *memHandler) Stat(ctr *CgroupControl, m *Metrics) error {
 		return err
 	}
 
-	m.Memory = MemoryMetrics{Usage: usage}
+	m.Memory = MemoryMetrics{Usage: usage, OOMKills: readOOMKills(filepath.Join(memoryRoot, filenames["events"]))}
+	return nil
+}
+
+// readOOMKills returns the number of OOM kills listed as oom_kill in the
+// memory.events or memory.oom_control file at path.  Older kernels do not
+// list it, so 0 is returned if it cannot be read.
+func readOOMKills(path string) uint64 {
+	events, err := readCgroup2MapPath(path)
+	if err != nil {
+		logrus.Debugf("Error reading OOM kills from %s: %v", path, err)
+		return 0
+	}
+	val, found := events["oom_kill"]
+	if !found || len(val) == 0 {
+		return 0
+	}
+	oomKills, err := strconv.ParseUint(cleanString(val[0]), 10, 0)
+	if err != nil {
+		logrus.Debugf("Error parsing OOM kills from %s: %v", path, err)
+		return 0
+	}
+	return oomKills
+}
+
+// ReclaimMemory reclaims up to amount bytes of the memory charged to the
+// cgroup, mostly page cache, without killing any process.  If amount is 0, as
+// much memory as possible is reclaimed.  On cgroup v2 this writes to
+// memory.reclaim, which requires Linux 5.19 or newer, on cgroup v1 to
+// memory.force_empty, which does not support an amount.
+func (c *CgroupControl) ReclaimMemory(amount uint64) error {
+	if !c.cgroup2 {
+		if amount > 0 {
+			return errors.New("reclaiming a specific amount of memory requires cgroup v2")
+		}
+		p := filepath.Join(c.getCgroupv1Path(Memory), "memory.force_empty")
+		if err := ioutil.WriteFile(p, []byte("0"), 0644); err != nil {
+			return errors.Wrapf(err, "write %s", p)
+		}
+		return nil
+	}
+
+	memoryRoot := filepath.Join(cgroupRoot, c.path)
+	if amount == 0 {
+		usage, err := readFileAsUint64(filepath.Join(memoryRoot, "memory.current"))
+		if err != nil {
+			return err
+		}
+		if usage == 0 {
+			return nil
+		}
+		amount = usage
+	}
+	p := filepath.Join(memoryRoot, "memory.reclaim")
+	if err := ioutil.WriteFile(p, []byte(strconv.FormatUint(amount, 10)), 0644); err != nil {
+		if os.IsNotExist(err) {
+			return errors.Errorf("%s does not exist, reclaiming memory requires Linux 5.19 or newer", p)
+		}
+		// The kernel fails with EAGAIN if it reclaimed less than
+		// amount, which is expected when reclaiming as much as
+		// possible.
+		if errors.Is(err, syscall.EAGAIN) {
+			return nil
+		}
+		return errors.Wrapf(err, "write %s", p)
+	}
 	return nil
 }
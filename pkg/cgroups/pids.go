@@ -3,6 +3,7 @@ package cgroups
 import (
 	"fmt"
 	"io/ioutil"
+	"math"
 	"path/filepath"
 
 	spec "github.com/opencontainers/runtime-spec/specs-go"
@@ -64,3 +65,27 @@ func (c *pidHandler) Stat(ctr *CgroupControl, m *Metrics) error {
 	m.Pids = PidsMetrics{Current: current}
 	return nil
 }
+
+// Pids returns the number of processes in the cgroup and the limit set by
+// the pids controller, which is 0 if there is none.
+func (c *CgroupControl) Pids() (uint64, uint64, error) {
+	var PIDRoot string
+	if c.cgroup2 {
+		PIDRoot = filepath.Join(cgroupRoot, c.path)
+	} else {
+		PIDRoot = c.getCgroupv1Path(Pids)
+	}
+
+	current, err := readFileAsUint64(filepath.Join(PIDRoot, "pids.current"))
+	if err != nil {
+		return 0, 0, err
+	}
+	limit, err := readFileAsUint64(filepath.Join(PIDRoot, "pids.max"))
+	if err != nil {
+		return 0, 0, err
+	}
+	if limit == math.MaxUint64 {
+		limit = 0
+	}
+	return current, limit, nil
+}
@@ -7,12 +7,18 @@ import (
 
 	systemdDbus "github.com/coreos/go-systemd/v22/dbus"
 	"github.com/godbus/dbus/v5"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 )
 
-func systemdCreate(path string, c *systemdDbus.Conn) error {
+func systemdCreate(path string, resources *spec.LinuxResources, c *systemdDbus.Conn) error {
 	slice, name := filepath.Split(path)
 	slice = strings.TrimSuffix(slice, "/")
 
+	resourceProperties, err := systemdResourceProperties(resources)
+	if err != nil {
+		return err
+	}
+
 	var lastError error
 	for i := 0; i < 2; i++ {
 		properties := []systemdDbus.Property{
@@ -35,6 +41,7 @@ func systemdCreate(path string, c *systemdDbus.Conn) error {
 			}
 			properties = append(properties, p)
 		}
+		properties = append(properties, resourceProperties...)
 
 		ch := make(chan string)
 		_, err := c.StartTransientUnit(name, "replace", properties, ch)
@@ -48,6 +55,57 @@ func systemdCreate(path string, c *systemdDbus.Conn) error {
 	return lastError
 }
 
+// systemdResourceProperties converts the CPU quota, memory limit and pids
+// limit to the properties of a systemd unit.
+func systemdResourceProperties(resources *spec.LinuxResources) ([]systemdDbus.Property, error) {
+	var properties []systemdDbus.Property
+	if resources == nil {
+		return properties, nil
+	}
+	if resources.CPU != nil && resources.CPU.Quota != nil && *resources.CPU.Quota > 0 {
+		var period uint64 = 100000
+		if resources.CPU.Period != nil && *resources.CPU.Period != 0 {
+			period = *resources.CPU.Period
+		}
+		// systemd only supports a granularity of 10ms, round up as
+		// runc does.
+		cpuQuotaPerSecUSec := uint64(*resources.CPU.Quota) * 1000000 / period
+		if cpuQuotaPerSecUSec%10000 != 0 {
+			cpuQuotaPerSecUSec = (cpuQuotaPerSecUSec/10000 + 1) * 10000
+		}
+		properties = append(properties, systemdDbus.Property{
+			Name:  "CPUQuotaPerSecUSec",
+			Value: dbus.MakeVariant(cpuQuotaPerSecUSec),
+		})
+	}
+	if resources.Memory != nil && resources.Memory.Limit != nil && *resources.Memory.Limit > 0 {
+		cgroup2, err := IsCgroup2UnifiedMode()
+		if err != nil {
+			return nil, err
+		}
+		name := "MemoryLimit"
+		if cgroup2 {
+			name = "MemoryMax"
+		}
+		properties = append(properties, systemdDbus.Property{
+			Name:  name,
+			Value: dbus.MakeVariant(uint64(*resources.Memory.Limit)),
+		})
+	}
+	if resources.Pids != nil && resources.Pids.Limit > 0 {
+		properties = append(properties,
+			systemdDbus.Property{
+				Name:  "TasksAccounting",
+				Value: dbus.MakeVariant(true),
+			},
+			systemdDbus.Property{
+				Name:  "TasksMax",
+				Value: dbus.MakeVariant(uint64(resources.Pids.Limit)),
+			})
+	}
+	return properties, nil
+}
+
 /*
    systemdDestroyConn is copied from containerd/cgroups/systemd.go file, that
    has the following license:
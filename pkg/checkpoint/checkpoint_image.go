@@ -0,0 +1,123 @@
+package checkpoint
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/containers/buildah"
+	"github.com/containers/buildah/util"
+	is "github.com/containers/image/v5/storage"
+	"github.com/containers/podman/v2/libpod"
+	"github.com/containers/podman/v2/libpod/image"
+	"github.com/containers/podman/v2/pkg/errorhandling"
+	"github.com/containers/storage/pkg/archive"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// CheckpointAnnotationName is the annotation of checkpoint images
+	// which holds the name of the checkpointed container.  Its presence
+	// marks an image as a checkpoint image.
+	CheckpointAnnotationName = "io.podman.annotations.checkpoint.name"
+	// CheckpointAnnotationRootfsImageName is the annotation of checkpoint
+	// images which holds the name of the image of the checkpointed
+	// container, which is needed to restore it.
+	CheckpointAnnotationRootfsImageName = "io.podman.annotations.checkpoint.rootfsImageName"
+)
+
+// CRCreateImage creates an image named imageName which holds the contents
+// of the checkpoint archive of ctr.  The image has a single layer with the
+// files of the archive and can be pushed to a registry like any other
+// image, and restored from with podman container restore.
+func CRCreateImage(ctx context.Context, runtime *libpod.Runtime, ctr *libpod.Container, archivePath, imageName string) error {
+	store := runtime.GetStore()
+	builder, err := buildah.NewBuilder(ctx, store, buildah.BuilderOptions{
+		FromImage: "scratch",
+	})
+	if err != nil {
+		return errors.Wrapf(err, "error creating builder for checkpoint image")
+	}
+	defer func() {
+		if err := builder.Delete(); err != nil {
+			logrus.Errorf("Error removing builder container for checkpoint image: %v", err)
+		}
+	}()
+
+	if err := builder.Add("/", true, buildah.AddAndCopyOptions{}, archivePath); err != nil {
+		return errors.Wrapf(err, "error adding checkpoint archive %s to checkpoint image", archivePath)
+	}
+	_, rootfsImageName := ctr.Image()
+	builder.SetAnnotation(CheckpointAnnotationName, ctr.Name())
+	builder.SetAnnotation(CheckpointAnnotationRootfsImageName, rootfsImageName)
+
+	sc := image.GetSystemContext("", "", false)
+	candidates, _, _, err := util.ResolveName(imageName, "", sc, store)
+	if err != nil {
+		return errors.Wrapf(err, "error resolving name %q", imageName)
+	}
+	if len(candidates) == 0 {
+		return errors.Errorf("error parsing target image name %q", imageName)
+	}
+	imageRef, err := is.Transport.ParseStoreReference(store, candidates[0])
+	if err != nil {
+		return errors.Wrapf(err, "error parsing target image name %q", imageName)
+	}
+	if _, _, _, err := builder.Commit(ctx, imageRef, buildah.CommitOptions{
+		SystemContext:         sc,
+		PreferredManifestType: ociv1.MediaTypeImageManifest,
+	}); err != nil {
+		return errors.Wrapf(err, "error committing checkpoint image %q", imageName)
+	}
+	return nil
+}
+
+// IsCheckpointImage returns whether img was created by CRCreateImage.
+func IsCheckpointImage(ctx context.Context, img *image.Image) (bool, error) {
+	annotations, err := img.Annotations(ctx)
+	if err != nil {
+		return false, err
+	}
+	_, ok := annotations[CheckpointAnnotationName]
+	return ok, nil
+}
+
+// CRArchiveFromImage writes the contents of the checkpoint image img to a
+// temporary checkpoint archive, which can be restored from like an archive
+// created with podman container checkpoint --export.  The caller has to
+// remove the archive.
+func CRArchiveFromImage(img *image.Image) (string, error) {
+	mountPoint, err := img.Mount(nil, "")
+	if err != nil {
+		return "", errors.Wrapf(err, "error mounting checkpoint image %s", img.ID())
+	}
+	defer func() {
+		if err := img.Unmount(false); err != nil {
+			logrus.Errorf("Error unmounting checkpoint image %s: %v", img.ID(), err)
+		}
+	}()
+
+	input, err := archive.TarWithOptions(mountPoint, &archive.TarOptions{
+		Compression: archive.Gzip,
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "error reading checkpoint image %s", img.ID())
+	}
+	defer input.Close()
+
+	archiveFile, err := ioutil.TempFile("", "checkpoint")
+	if err != nil {
+		return "", err
+	}
+	defer errorhandling.CloseQuiet(archiveFile)
+	if _, err := io.Copy(archiveFile, input); err != nil {
+		if err := os.Remove(archiveFile.Name()); err != nil {
+			logrus.Errorf("Error removing %s: %v", archiveFile.Name(), err)
+		}
+		return "", errors.Wrapf(err, "error writing checkpoint archive of image %s", img.ID())
+	}
+	return archiveFile.Name(), nil
+}
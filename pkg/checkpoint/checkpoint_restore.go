@@ -11,6 +11,7 @@ import (
 	"github.com/containers/podman/v2/pkg/errorhandling"
 	"github.com/containers/podman/v2/pkg/util"
 	"github.com/containers/storage/pkg/archive"
+	"github.com/cri-o/ocicni/pkg/ocicni"
 	jsoniter "github.com/json-iterator/go"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
@@ -35,13 +36,111 @@ func crImportFromJSON(filePath string, v interface{}) error {
 }
 
 // CRImportCheckpoint it the function which imports the information
-// from checkpoint tarball and re-creates the container from that information
-func CRImportCheckpoint(ctx context.Context, runtime *libpod.Runtime, input string, name string) ([]*libpod.Container, error) {
+// from checkpoint tarball and re-creates the container from that information.
+// If portMappings is not nil, the container publishes these ports instead of
+// the ones it published when it was checkpointed.
+func CRImportCheckpoint(ctx context.Context, runtime *libpod.Runtime, input string, name string, portMappings []ocicni.PortMapping) ([]*libpod.Container, error) {
+	dumpSpec, config, err := crImportDefinition(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// This should not happen as checkpoints with these options are not exported.
+	if len(config.Dependencies) > 0 {
+		return nil, errors.Errorf("Cannot import checkpoints of containers with dependencies")
+	}
+
+	container, err := crCreateContainer(ctx, runtime, dumpSpec, config, name, portMappings)
+	if err != nil {
+		return nil, err
+	}
+	if container == nil {
+		return nil, nil
+	}
+	return []*libpod.Container{container}, nil
+}
+
+// CRImportPodCheckpoint re-creates the pod and the containers exported to the
+// pod checkpoint archive input by libpod's Pod.Checkpoint.  The containers of
+// the pod can then be restored from the same archive with Pod.Restore.
+func CRImportPodCheckpoint(ctx context.Context, runtime *libpod.Runtime, input string) (_ *libpod.Pod, retErr error) {
+	archiveFile, err := os.Open(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open pod checkpoint archive for import")
+	}
+	defer errorhandling.CloseQuiet(archiveFile)
+	dir, err := ioutil.TempDir("", "pod-checkpoint")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			logrus.Errorf("could not recursively remove %s: %q", dir, err)
+		}
+	}()
+	if err := archive.Untar(archiveFile, dir, nil); err != nil {
+		return nil, errors.Wrapf(err, "Unpacking of pod checkpoint archive %s failed", input)
+	}
+
+	podConfig := new(libpod.PodConfig)
+	if err := crImportFromJSON(filepath.Join(dir, "pod.dump"), podConfig); err != nil {
+		return nil, err
+	}
+	var ctrIDs []string
+	if err := crImportFromJSON(filepath.Join(dir, "containers.dump"), &ctrIDs); err != nil {
+		return nil, err
+	}
+
+	// The definitions of all containers are loaded before the pod is
+	// created, which needs to know its infra container.
+	dumpSpecs := make([]*spec.Spec, 0, len(ctrIDs))
+	configs := make([]*libpod.ContainerConfig, 0, len(ctrIDs))
+	infraID := ""
+	for _, id := range ctrIDs {
+		dumpSpec, config, err := crImportDefinition(filepath.Join(dir, id+".tar.gz"))
+		if err != nil {
+			return nil, err
+		}
+		if config.Pod != podConfig.ID {
+			return nil, errors.Errorf("container %s in the checkpoint archive of pod %s belongs to pod %s", config.ID, podConfig.ID, config.Pod)
+		}
+		if config.IsInfra {
+			infraID = config.ID
+		}
+		dumpSpecs = append(dumpSpecs, dumpSpec)
+		configs = append(configs, config)
+	}
+
+	pod, err := runtime.RestorePod(ctx, podConfig, infraID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if retErr != nil {
+			if err := runtime.RemovePod(ctx, pod, true, true); err != nil {
+				logrus.Errorf("Error removing pod %s after failed import: %v", pod.ID(), err)
+			}
+		}
+	}()
+
+	// The containers are listed in the order they have to be restored in,
+	// which is also the order they have to be created in.
+	for i := range configs {
+		if _, err := crCreateContainer(ctx, runtime, dumpSpecs[i], configs[i], "", nil); err != nil {
+			return nil, err
+		}
+	}
+	return pod, nil
+}
+
+// crImportDefinition reads the spec and the configuration of the container
+// from the checkpoint archive input.
+func crImportDefinition(input string) (*spec.Spec, *libpod.ContainerConfig, error) {
 	// First get the container definition from the
 	// tarball to a temporary directory
 	archiveFile, err := os.Open(input)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to open checkpoint archive for import")
+		return nil, nil, errors.Wrap(err, "failed to open checkpoint archive for import")
 	}
 	defer errorhandling.CloseQuiet(archiveFile)
 	options := &archive.TarOptions{
@@ -53,11 +152,12 @@ func CRImportCheckpoint(ctx context.Context, runtime *libpod.Runtime, input stri
 			"rootfs-diff.tar",
 			"network.status",
 			"deleted.files",
+			"volumes",
 		},
 	}
 	dir, err := ioutil.TempDir("", "checkpoint")
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer func() {
 		if err := os.RemoveAll(dir); err != nil {
@@ -66,26 +166,28 @@ func CRImportCheckpoint(ctx context.Context, runtime *libpod.Runtime, input stri
 	}()
 	err = archive.Untar(archiveFile, dir, options)
 	if err != nil {
-		return nil, errors.Wrapf(err, "Unpacking of checkpoint archive %s failed", input)
+		return nil, nil, errors.Wrapf(err, "Unpacking of checkpoint archive %s failed", input)
 	}
 
 	// Load spec.dump from temporary directory
 	dumpSpec := new(spec.Spec)
 	if err := crImportFromJSON(filepath.Join(dir, "spec.dump"), dumpSpec); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Load config.dump from temporary directory
 	config := new(libpod.ContainerConfig)
 	if err = crImportFromJSON(filepath.Join(dir, "config.dump"), config); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	// This should not happen as checkpoints with these options are not exported.
-	if (len(config.Dependencies) > 0) || (len(config.NamedVolumes) > 0) {
-		return nil, errors.Errorf("Cannot import checkpoints of containers with named volumes or dependencies")
-	}
+	return dumpSpec, config, nil
+}
 
+// crCreateContainer re-creates the container with the given spec and
+// configuration from a checkpoint archive.  If name is set, the container gets
+// a new name and ID.
+func crCreateContainer(ctx context.Context, runtime *libpod.Runtime, dumpSpec *spec.Spec, config *libpod.ContainerConfig, name string, portMappings []ocicni.PortMapping) (*libpod.Container, error) {
 	ctrID := config.ID
 	newName := false
 
@@ -98,6 +200,12 @@ func CRImportCheckpoint(ctx context.Context, runtime *libpod.Runtime, input stri
 
 	ctrName := config.Name
 
+	// The published ports are set up on the host when the network of the
+	// container is, so they can be changed freely.
+	if portMappings != nil {
+		config.PortMappings = portMappings
+	}
+
 	// The code to load the images is copied from create.go
 	// In create.go this only set if '--quiet' does not exist.
 	writer := os.Stderr
@@ -117,7 +225,6 @@ func CRImportCheckpoint(ctx context.Context, runtime *libpod.Runtime, input stri
 		return nil, err
 	}
 
-	var containers []*libpod.Container
 	if container == nil {
 		return nil, nil
 	}
@@ -140,6 +247,5 @@ func CRImportCheckpoint(ctx context.Context, runtime *libpod.Runtime, input stri
 		return nil, errors.Errorf("'ExitCommandID' uses ID %s instead of container ID %s", containerConfig.ExitCommand[len(containerConfig.ExitCommand)-1], containerConfig.ID)
 	}
 
-	containers = append(containers, container)
-	return containers, nil
+	return container, nil
 }
@@ -1,9 +1,16 @@
 package entities
 
+import "time"
+
 // AutoUpdateOptions are the options for running auto-update.
 type AutoUpdateOptions struct {
 	// Authfile to use when contacting registries.
 	Authfile string
+	// Rollback failed updates to the previous image.
+	Rollback bool
+	// RollbackWindow is the time a restarted container has to become
+	// healthy before its update is rolled back.
+	RollbackWindow time.Duration
 }
 
 // AutoUpdateReport contains the results from running auto-update.
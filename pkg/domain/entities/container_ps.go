@@ -43,8 +43,16 @@ type ListContainer struct {
 	// Namespaces the container belongs to.  Requires the
 	// namespace boolean to be true
 	Namespaces ListContainerNamespaces
+	// The user who created the container
+	Owner string
 	// The process id of the container
 	Pid int
+	// Number of processes in the container.  Requires the pids boolean
+	// to be true
+	PidsCurrent uint64 `json:",omitempty"`
+	// Limit on the number of processes in the container, 0 if there is
+	// none.  Requires the pids boolean to be true
+	PidsLimit uint64 `json:",omitempty"`
 	// If the container is part of Pod, the Pod ID. Requires the pod
 	// boolean to be set
 	Pod string
@@ -53,6 +61,8 @@ type ListContainer struct {
 	PodName string
 	// Port mappings
 	Ports []ocicni.PortMapping
+	// How many times the container was restarted by its restart policy
+	RestartCount uint
 	// Size of the container rootfs.  Requires the size boolean to be true
 	Size *define.ContainerSize
 	// Time when container started
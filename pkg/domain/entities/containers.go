@@ -2,6 +2,7 @@ package entities
 
 import (
 	"io"
+	"net"
 	"net/url"
 	"os"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/containers/podman/v2/pkg/copy"
 	"github.com/containers/podman/v2/pkg/specgen"
 	"github.com/cri-o/ocicni/pkg/ocicni"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
 )
 
 // ContainerRunlabelOptions are the options to execute container-runlabel.
@@ -51,7 +53,9 @@ type ContainerRunlabelReport struct {
 }
 
 type WaitOptions struct {
-	Condition define.ContainerStatus
+	// Condition are the conditions to wait for, one of them must be met.
+	// Defaults to stopped.
+	Condition []string
 	Interval  time.Duration
 	Latest    bool
 }
@@ -83,8 +87,10 @@ type PauseUnpauseReport struct {
 type StopOptions struct {
 	All      bool
 	CIDFiles []string
+	Force    bool
 	Ignore   bool
 	Latest   bool
+	Signals  []define.StopStep
 	Timeout  *uint
 }
 
@@ -130,6 +136,9 @@ type RestartReport struct {
 type RmOptions struct {
 	All      bool
 	CIDFiles []string
+	// External removes the containers of the storage not created by
+	// Podman instead of the containers of Podman.
+	External bool
 	Force    bool
 	Ignore   bool
 	Latest   bool
@@ -158,7 +167,10 @@ type CommitOptions struct {
 	Message        string
 	Pause          bool
 	Quiet          bool
-	Writer         io.Writer
+	// Timestamp, if set, is used as the created time of the image and of
+	// the files in the committed layer so the image digest is reproducible.
+	Timestamp *time.Time
+	Writer    io.Writer
 }
 
 type CommitReport struct {
@@ -166,13 +178,16 @@ type CommitReport struct {
 }
 
 type ContainerExportOptions struct {
-	Output string
+	IncludeVolumes bool
+	Output         string
 }
 
 type CheckpointOptions struct {
 	All            bool
+	CreateImage    string
 	Export         string
 	IgnoreRootFS   bool
+	IgnoreVolumes  bool
 	Keep           bool
 	Latest         bool
 	LeaveRunning   bool
@@ -189,10 +204,14 @@ type RestoreOptions struct {
 	IgnoreRootFS    bool
 	IgnoreStaticIP  bool
 	IgnoreStaticMAC bool
+	IgnoreVolumes   bool
 	Import          string
 	Keep            bool
 	Latest          bool
 	Name            string
+	PublishPorts    []specgen.PortMapping
+	StaticIP        *net.IP
+	StaticMAC       *net.HardwareAddr
 	TCPEstablished  bool
 }
 
@@ -205,6 +224,35 @@ type ContainerCreateReport struct {
 	Id string //nolint
 }
 
+// ContainerCreateDryRunReport describes the container which would be
+// created for a spec generator.
+type ContainerCreateDryRunReport struct {
+	// Spec is the OCI runtime spec of the container.
+	Spec *specs.Spec
+	// Volumes are the named volumes mounted into the container.
+	Volumes []*specgen.NamedVolume
+	// OverlayVolumes are the overlay volumes mounted into the container.
+	OverlayVolumes []*specgen.OverlayVolume
+}
+
+// ContainerCreateBatchOptions describes how to create a batch of containers
+// from one spec generator.
+type ContainerCreateBatchOptions struct {
+	// Count is the number of containers to create.
+	Count uint
+	// NamePattern is the name of the containers, with %d replaced by the
+	// index of each container.  Names are generated if empty.
+	NamePattern string
+	// BatchSize is the number of containers added to the database in one
+	// transaction.  All containers are added at once if 0.
+	BatchSize uint
+}
+
+// ContainerCreateBatchReport describes the containers created in a batch.
+type ContainerCreateBatchReport struct {
+	Ids []string //nolint
+}
+
 // AttachOptions describes the cli and other values
 // needed to perform an attach
 type AttachOptions struct {
@@ -254,6 +302,19 @@ type ExecOptions struct {
 	WorkDir     string
 }
 
+// ContainerExecSessionsOptions describes the cli values to list the exec
+// sessions of containers
+type ContainerExecSessionsOptions struct {
+	Latest  bool
+	Running bool
+}
+
+// ContainerExecSessionReport describes an exec session of a container
+type ContainerExecSessionReport struct {
+	define.InspectExecSession
+	ContainerName string
+}
+
 // ContainerExistsOptions describes the cli values to check if a container exists
 type ContainerExistsOptions struct {
 	External bool
@@ -290,6 +351,7 @@ type ContainerListOptions struct {
 	Last      int
 	Latest    bool
 	Namespace bool
+	Pids      bool
 	Pod       bool
 	Quiet     bool
 	Size      bool
@@ -329,6 +391,9 @@ type ContainerCleanupOptions struct {
 	Latest      bool
 	Remove      bool
 	RemoveImage bool
+	// Watch keeps running and cleans up containers as they exit, instead
+	// of cleaning up the given containers.
+	Watch bool
 }
 
 // ContainerCleanupReport describes the response from a
@@ -340,6 +405,102 @@ type ContainerCleanupReport struct {
 	RmiErr   error
 }
 
+// ContainerCloneOptions describes input options
+// for the container clone cli
+type ContainerCloneOptions struct {
+	// Name of the clone.  A name is generated if empty.
+	Name string
+	// Image to create the root filesystem of the clone from instead of
+	// the image of the source container.
+	Image string
+	// Run starts the clone after creating it.
+	Run bool
+	CloneResourceOptions
+}
+
+// CloneResourceOptions describes the resource limits of the clone of a
+// container.  Fields with a zero value keep the limit of the source
+// container.
+type CloneResourceOptions struct {
+	// CPUPeriod and CPUQuota limit the CPU usage of the clone if set.
+	CPUPeriod uint64
+	CPUQuota  int64
+	// CPUShares sets the relative CPU weight of the clone if set.
+	CPUShares uint64
+	// CPUSetCPUs sets the CPUs the clone may run on if set.
+	CPUSetCPUs string
+	// Memory sets the memory limit of the clone in bytes if set.
+	Memory int64
+}
+
+// ContainerCloneReport describes the results of a
+// container clone
+type ContainerCloneReport struct {
+	Id string //nolint
+}
+
+// ContainerUpdateOptions describes input options
+// for the container update cli.  Fields with a zero value
+// keep the current limit of the container.
+type ContainerUpdateOptions struct {
+	// CPUPeriod and CPUQuota limit the CPU usage of the container.
+	CPUPeriod uint64
+	CPUQuota  int64
+	// CPUShares sets the relative CPU weight of the container.
+	CPUShares uint64
+	// CPUSetCPUs sets the CPUs the container may run on.
+	CPUSetCPUs string
+	// CPUSetMems sets the memory nodes the container may use.
+	CPUSetMems string
+	// Memory sets the memory limit of the container in bytes.
+	Memory int64
+	// MemorySwap sets the limit of memory plus swap in bytes, -1 is
+	// unlimited.
+	MemorySwap int64
+	// PidsLimit sets the maximum number of processes, -1 is unlimited.
+	PidsLimit int64
+	// BlkioWeight sets the relative block IO weight (10-1000).
+	BlkioWeight uint16
+	// Labels are added to the labels of the container.
+	Labels map[string]string
+	// RemoveLabels are the keys of the labels removed from the container.
+	RemoveLabels []string
+	// Annotations are added to the OCI annotations of the container.
+	Annotations map[string]string
+	// RemoveAnnotations are the keys of the OCI annotations removed from
+	// the container.
+	RemoveAnnotations []string
+}
+
+// ContainerUpdateReport describes the results of a
+// container update
+type ContainerUpdateReport struct {
+	Id string //nolint
+}
+
+// ContainerRenameOptions describes input options for renaming a container.
+type ContainerRenameOptions struct {
+	// NewName is the new name of the container.
+	NewName string
+}
+
+// ContainerDropCachesOptions describes input options
+// for the container drop-caches cli
+type ContainerDropCachesOptions struct {
+	All    bool
+	Latest bool
+	// Amount is the number of bytes to reclaim, 0 reclaims as much as
+	// possible.
+	Amount uint64
+}
+
+// ContainerDropCachesReport describes the results of
+// dropping the caches of a container
+type ContainerDropCachesReport struct {
+	Err error
+	Id  string //nolint
+}
+
 // ContainerInitOptions describes input options
 // for the container init cli
 type ContainerInitOptions struct {
@@ -417,6 +578,20 @@ type ContainerCpOptions struct {
 	Pause bool
 	// Extract the tarfile into the destination directory.
 	Extract bool
+	// Archive changes the ownership of the files copied into a container
+	// to the primary uid/gid of the container.
+	Archive bool
+}
+
+// CopyOptions describes input options for copying a tar archive into a
+// container.
+type CopyOptions struct {
+	// Chown changes the ownership of the copied files to the primary
+	// uid/gid of the container.
+	Chown bool
+	// NoOverwriteDirNonDir returns an error instead of replacing a
+	// directory with a non-directory or vice versa.
+	NoOverwriteDirNonDir bool
 }
 
 // ContainerStatsOptions describes input options for getting
@@ -37,6 +37,7 @@ type PodmanConfig struct {
 	*config.Config
 	*pflag.FlagSet
 
+	AuthTokenFile  string           // auth token file for connecting to tcp server
 	CGroupUsage    string           // rootless code determines Usage message
 	ConmonPath     string           // --conmon flag will set Engine.ConmonPath
 	CPUProfile     string           // Hidden: Should CPU profile be taken
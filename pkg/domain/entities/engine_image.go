@@ -11,11 +11,13 @@ type ImageEngine interface {
 	Config(ctx context.Context) (*config.Config, error)
 	Diff(ctx context.Context, nameOrID string, options DiffOptions) (*DiffReport, error)
 	Exists(ctx context.Context, nameOrID string) (*BoolReport, error)
+	ExplainResolution(ctx context.Context, names []string) ([]*ImageResolutionReport, error)
 	History(ctx context.Context, nameOrID string, opts ImageHistoryOptions) (*ImageHistoryReport, error)
 	Import(ctx context.Context, opts ImageImportOptions) (*ImageImportReport, error)
 	Inspect(ctx context.Context, namesOrIDs []string, opts InspectOptions) ([]*ImageInspectReport, []error, error)
 	List(ctx context.Context, opts ImageListOptions) ([]*ImageSummary, error)
 	Load(ctx context.Context, opts ImageLoadOptions) (*ImageLoadReport, error)
+	Lookup(ctx context.Context, namesOrIDs []string, opts ImageLookupOptions) ([]*ImageLookupReport, error)
 	Mount(ctx context.Context, images []string, options ImageMountOptions) ([]*ImageMountReport, error)
 	Prune(ctx context.Context, opts ImagePruneOptions) (*ImagePruneReport, error)
 	Pull(ctx context.Context, rawImage string, opts ImagePullOptions) (*ImagePullReport, error)
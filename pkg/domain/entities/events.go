@@ -38,21 +38,26 @@ func ConvertToLibpodEvent(e Event) *libpodEvents.Event {
 		Status:            status,
 		Time:              time.Unix(e.Time, e.TimeNano),
 		Type:              t,
+		CorrelationID:     e.Actor.Attributes["correlationID"],
 	}
 }
 
 // ConvertToEntitiesEvent converts a libpod event to an entities one.
 func ConvertToEntitiesEvent(e libpodEvents.Event) *Event {
+	attributes := map[string]string{
+		"image":             e.Image,
+		"name":              e.Name,
+		"containerExitCode": strconv.Itoa(e.ContainerExitCode),
+	}
+	if e.CorrelationID != "" {
+		attributes["correlationID"] = e.CorrelationID
+	}
 	return &Event{dockerEvents.Message{
 		Type:   e.Type.String(),
 		Action: e.Status.String(),
 		Actor: dockerEvents.Actor{
-			ID: e.ID,
-			Attributes: map[string]string{
-				"image":             e.Image,
-				"name":              e.Name,
-				"containerExitCode": strconv.Itoa(e.ContainerExitCode),
-			},
+			ID:         e.ID,
+			Attributes: attributes,
 		},
 		Scope:    "local",
 		Time:     e.Time.Unix(),
@@ -6,6 +6,7 @@ import (
 	"github.com/containers/common/pkg/config"
 	"github.com/containers/image/v5/manifest"
 	"github.com/containers/image/v5/types"
+	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/pkg/inspect"
 	"github.com/containers/podman/v2/pkg/trust"
 	docker "github.com/docker/docker/api/types"
@@ -103,15 +104,36 @@ type ImageRemoveReport struct {
 	ExitCode int
 }
 
+// ImageLookupOptions are the options for looking up multiple images at once.
+type ImageLookupOptions struct{}
+
+// ImageLookupReport describes a single image of a lookup.
+type ImageLookupReport struct {
+	// Name is the name or ID of the image as given.
+	Name string
+	// Exists indicates if the image exists in local storage.
+	Exists bool
+	// ID of the local image.  Empty if the name matches more than one
+	// image.
+	ID string `json:"Id,omitempty"`
+	// Digest of the local image.
+	Digest string `json:",omitempty"`
+	// RepoDigests of the local image.
+	RepoDigests []string `json:",omitempty"`
+}
+
 type ImageHistoryOptions struct{}
 
 type ImageHistoryLayer struct {
-	ID        string    `json:"id"`
-	Created   time.Time `json:"created,omitempty"`
-	CreatedBy string    `json:",omitempty"`
-	Tags      []string  `json:"tags,omitempty"`
-	Size      int64     `json:"size"`
-	Comment   string    `json:"comment,omitempty"`
+	ID          string    `json:"id"`
+	Created     time.Time `json:"created,omitempty"`
+	CreatedBy   string    `json:",omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+	Size        int64     `json:"size"`
+	Comment     string    `json:"comment,omitempty"`
+	EmptyLayer  bool      `json:"emptyLayer"`
+	Layer       string    `json:"layer,omitempty"`
+	LayerDigest string    `json:"layerDigest,omitempty"`
 }
 
 type ImageHistoryReport struct {
@@ -140,6 +162,9 @@ type ImagePullOptions struct {
 	OverrideOS string
 	// OverrideVariant will overwrite the local variant for image pulls.
 	OverrideVariant string
+	// RateLimit limits the bandwidth of the pull, in bytes per second.
+	// Ignored for remote calls.
+	RateLimit int64
 	// Quiet can be specified to suppress pull progress when pulling.  Ignored
 	// for remote calls.
 	Quiet bool
@@ -189,6 +214,9 @@ type ImagePushOptions struct {
 	// Quiet can be specified to suppress pull progress when pulling.  Ignored
 	// for remote calls.
 	Quiet bool
+	// RateLimit limits the bandwidth of the push, in bytes per second.
+	// Ignored for remote calls.
+	RateLimit int64
 	// RemoveSignatures, discard any pre-existing signatures in the image.
 	// Ignored for remote calls.
 	RemoveSignatures bool
@@ -206,6 +234,9 @@ type ImageSearchOptions struct {
 	// Authfile is the path to the authentication file. Ignored for remote
 	// calls.
 	Authfile string
+	// CertDir is the path to certificate directories.  Ignored for remote
+	// calls.
+	CertDir string
 	// Filters for the search results.
 	Filters []string
 	// Limit the number of results.
@@ -216,6 +247,8 @@ type ImageSearchOptions struct {
 	SkipTLSVerify types.OptionalBool
 	// ListTags search the available tags of the repository
 	ListTags bool
+	// TagDigests looks up the digests of the listed tags.
+	TagDigests bool
 }
 
 // ImageSearchReport is the response from searching images.
@@ -234,6 +267,9 @@ type ImageSearchReport struct {
 	Automated string
 	// Tag is the repository tag
 	Tag string
+	// Digest is the manifest digest of the tag. Only set when listing tags
+	// and if the registry provides it.
+	Digest string `json:",omitempty"`
 }
 
 // Image List Options
@@ -255,12 +291,21 @@ type ImagePruneReport struct {
 type ImageTagOptions struct{}
 type ImageUntagOptions struct{}
 
+// ImageResolutionReport describes how an image name was resolved to a local
+// image.
+type ImageResolutionReport struct {
+	*define.ImageResolutionTrace
+}
+
 // ImageInspectReport is the data when inspecting an image.
 type ImageInspectReport struct {
 	*inspect.ImageData
 }
 
 type ImageLoadOptions struct {
+	// Delta denotes that the input is a delta archive created by saving
+	// an image with DeltaBase set.
+	Delta           bool
 	Name            string
 	Tag             string
 	Input           string
@@ -274,12 +319,16 @@ type ImageLoadReport struct {
 
 type ImageImportOptions struct {
 	Changes         []string
+	IncludeVolumes  bool
 	Message         string
 	Quiet           bool
 	Reference       string
 	SignaturePolicy string
 	Source          string
 	SourceIsURL     bool
+	// VolumeOptions restores the drivers and mount options of the
+	// volumes imported with IncludeVolumes.
+	VolumeOptions bool
 }
 
 type ImageImportReport struct {
@@ -290,6 +339,9 @@ type ImageImportReport struct {
 type ImageSaveOptions struct {
 	// Compress layers when saving to a directory.
 	Compress bool
+	// DeltaBase are the digests of the layers present on the target.  If
+	// set, a delta archive without these layers is written.
+	DeltaBase []string
 	// Format of saving the image: oci-archive, oci-dir (directory with oci
 	// manifest type), docker-archive, docker-dir (directory with v2s2
 	// manifest type).
@@ -309,6 +361,7 @@ type ImageSaveOptions struct {
 // ImageTreeOptions provides options for ImageEngine.Tree()
 type ImageTreeOptions struct {
 	WhatRequires bool // Show all child images and layers of the specified image
+	Shared       bool // Show the other images using the layers of the specified image
 }
 
 // ImageTreeReport provides results from ImageEngine.Tree()
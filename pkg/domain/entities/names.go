@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"time"
+)
+
+// NameReserveOptions describes input options for reserving a name.
+type NameReserveOptions struct {
+	// Name is the name to reserve.  A name is generated if it is empty.
+	Name string
+	// TTL is how long the name is reserved.  The name is reserved until
+	// it is released or used if it is zero.
+	TTL time.Duration
+}
+
+// NameReservationReport describes a name reserved for a container or pod.
+type NameReservationReport struct {
+	Name    string
+	Owner   string
+	Created time.Time
+	// Expires is zero if the reservation does not expire.
+	Expires time.Time
+}
+
+// NameReleaseReport describes the result of releasing a reserved name.
+type NameReleaseReport struct {
+	Name string
+	Err  error
+}
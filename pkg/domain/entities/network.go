@@ -22,6 +22,16 @@ type NetworkListReport struct {
 // NetworkInspectReport describes the results from inspect networks
 type NetworkInspectReport map[string]interface{}
 
+// NetworkContainerInfo describes a container attached to a network, listed
+// under the containers key of a NetworkInspectReport
+type NetworkContainerInfo struct {
+	Name        string
+	IPv4Address string
+	IPv6Address string
+	MacAddress  string
+	Aliases     []string
+}
+
 // NetworkReloadOptions describes options for reloading container network
 // configuration.
 type NetworkReloadOptions struct {
@@ -46,6 +56,20 @@ type NetworkRmReport struct {
 	Err  error
 }
 
+// NetworkPruneOptions describes options for pruning unused networks
+type NetworkPruneOptions struct {
+	Filters map[string][]string
+	// Leaked releases network resources of removed containers instead of
+	// removing unused networks
+	Leaked bool
+}
+
+// NetworkPruneReport describes the results of pruning a network
+type NetworkPruneReport struct {
+	Name  string
+	Error error
+}
+
 // NetworkCreateOptions describes options to create a network
 // swagger:model NetworkCreateOptions
 type NetworkCreateOptions struct {
@@ -57,11 +81,24 @@ type NetworkCreateOptions struct {
 	MacVLAN    string
 	Range      net.IPNet
 	Subnet     net.IPNet
-	IPv6       bool
+	// Subnets are the subnets of the network in addition to Subnet, e.g.
+	// the IPv4 and IPv6 subnets of a dual-stack network.
+	Subnets []NetworkSubnet
+	IPv6    bool
 	// Mapping of driver options and values.
 	Options map[string]string
 }
 
+// NetworkSubnet describes a subnet of a network
+type NetworkSubnet struct {
+	// Subnet in CIDR format.
+	Subnet net.IPNet
+	// Gateway of the subnet. Defaults to the first address of the subnet.
+	Gateway net.IP
+	// Range of the subnet to allocate container addresses from.
+	Range net.IPNet
+}
+
 // NetworkCreateReport describes a created network for the cli
 type NetworkCreateReport struct {
 	Filename string
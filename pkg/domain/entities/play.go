@@ -38,6 +38,8 @@ type PlayKubePod struct {
 	ID string
 	// Containers - the IDs of the containers running in the created pod.
 	Containers []string
+	// InitContainers - the IDs of the init containers of the created pod.
+	InitContainers []string
 	// Logs - non-fatal errors and log messages while processing.
 	Logs []string
 }
@@ -46,4 +48,6 @@ type PlayKubePod struct {
 type PlayKubeReport struct {
 	// Pods - pods created by play kube.
 	Pods []PlayKubePod
+	// Volumes - names of the volumes created for PersistentVolumeClaims.
+	Volumes []string
 }
@@ -7,17 +7,22 @@ import (
 
 	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/pkg/specgen"
+	"github.com/containers/podman/v2/pkg/util"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
 )
 
 type PodKillOptions struct {
-	All    bool
-	Latest bool
-	Signal string
+	All          bool
+	ExcludeInfra bool
+	Latest       bool
+	Signal       string
 }
 
 type PodKillReport struct {
-	Errs []error
-	Id   string //nolint
+	// Containers are the IDs of the containers which were signaled.
+	Containers []string
+	Errs       []error
+	Id         string //nolint
 }
 
 type ListPodsReport struct {
@@ -38,12 +43,45 @@ type ListPodContainer struct {
 	Status string
 }
 
+type PodCheckpointOptions struct {
+	All            bool
+	Export         string
+	IgnoreRootFS   bool
+	IgnoreVolumes  bool
+	Keep           bool
+	Latest         bool
+	LeaveRunning   bool
+	TCPEstablished bool
+}
+
+type PodCheckpointReport struct {
+	Errs []error
+	Id   string //nolint
+}
+
 type PodPauseOptions struct {
 	All    bool
 	Latest bool
 }
 
 type PodPauseReport struct {
+	// Containers are the IDs of the containers which were paused.
+	Containers []string
+	Errs       []error
+	Id         string //nolint
+}
+
+type PodRestoreOptions struct {
+	All            bool
+	IgnoreRootFS   bool
+	IgnoreVolumes  bool
+	Import         string
+	Keep           bool
+	Latest         bool
+	TCPEstablished bool
+}
+
+type PodRestoreReport struct {
 	Errs []error
 	Id   string //nolint
 }
@@ -54,8 +92,10 @@ type PodunpauseOptions struct {
 }
 
 type PodUnpauseReport struct {
-	Errs []error
-	Id   string //nolint
+	// Containers are the IDs of the containers which were unpaused.
+	Containers []string
+	Errs       []error
+	Id         string //nolint
 }
 
 type PodStopOptions struct {
@@ -104,16 +144,22 @@ type PodRmReport struct {
 
 type PodCreateOptions struct {
 	CGroupParent       string
+	CPUS               float64
 	CreateCommand      []string
 	Hostname           string
 	Infra              bool
 	InfraImage         string
 	InfraCommand       string
 	InfraConmonPidFile string
+	InfraName          string
 	Labels             map[string]string
+	Memory             int64
 	Name               string
 	Net                *NetOptions
+	PidsLimit          int64
 	Share              []string
+	ShmSize            *int64
+	Userns             specgen.Namespace
 }
 
 type PodCreateReport struct {
@@ -133,12 +179,16 @@ func (p PodCreateOptions) ToPodSpecGen(s *specgen.PodSpecGenerator) {
 		s.InfraConmonPidFile = p.InfraConmonPidFile
 	}
 	s.InfraImage = p.InfraImage
+	s.InfraName = p.InfraName
+	s.ShmSize = p.ShmSize
 	s.SharedNamespaces = p.Share
+	s.Userns = p.Userns
 	s.PodCreateCommand = p.CreateCommand
 
 	// Networking config
 	s.NetNS = p.Net.Network
 	s.StaticIP = p.Net.StaticIP
+	s.StaticIPv6 = p.Net.StaticIPv6
 	s.StaticMAC = p.Net.StaticMAC
 	s.PortMappings = p.Net.PublishPorts
 	s.CNINetworks = p.Net.CNINetworks
@@ -150,10 +200,31 @@ func (p PodCreateOptions) ToPodSpecGen(s *specgen.PodSpecGenerator) {
 	s.DNSSearch = p.Net.DNSSearch
 	s.DNSOption = p.Net.DNSOptions
 	s.NoManageHosts = p.Net.NoHosts
+	s.BaseHostsFile = p.Net.HostsFile
 	s.HostAdd = p.Net.AddHosts
 
 	// Cgroup
 	s.CgroupParent = p.CGroupParent
+	if p.CPUS > 0 || p.Memory > 0 || p.PidsLimit > 0 {
+		s.ResourceLimits = &specs.LinuxResources{}
+		if p.CPUS > 0 {
+			period, quota := util.CoresToPeriodAndQuota(p.CPUS)
+			s.ResourceLimits.CPU = &specs.LinuxCPU{
+				Period: &period,
+				Quota:  &quota,
+			}
+		}
+		if p.Memory > 0 {
+			s.ResourceLimits.Memory = &specs.LinuxMemory{
+				Limit: &p.Memory,
+			}
+		}
+		if p.PidsLimit > 0 {
+			s.ResourceLimits.Pids = &specs.LinuxPids{
+				Limit: p.PidsLimit,
+			}
+		}
+	}
 }
 
 type PodPruneOptions struct {
@@ -165,6 +236,53 @@ type PodPruneReport struct {
 	Id  string //nolint
 }
 
+// PodUpdateOptions describes the changes to the ports published by a pod
+type PodUpdateOptions struct {
+	// PublishPorts are published in addition to the current ports of the
+	// pod.
+	PublishPorts []specgen.PortMapping
+	// RemovePorts are the host ports, given as PORT or PORT/PROTOCOL, which
+	// are no longer published.
+	RemovePorts []string
+}
+
+type PodUpdateReport struct {
+	Id string //nolint
+}
+
+// PodCloneOptions describes the changes to the configuration of the clone
+// of a pod.
+type PodCloneOptions struct {
+	// Name of the clone.  A name is generated if empty.
+	Name string
+	// Hostname of the clone.  The hostname of the source pod is kept if
+	// empty, unless it is the name of the source pod.
+	Hostname string
+	// Labels are added to the labels of the source pod, replacing the
+	// labels with the same key.
+	Labels map[string]string
+	// PublishPorts are the ports published by the infra container of the
+	// clone.  The ports of the source pod are not published by the clone,
+	// as they cannot be bound by two pods at once.
+	PublishPorts []specgen.PortMapping
+	// Members are the resource limits of the clones of the containers of
+	// the pod, other than the infra container.
+	Members CloneResourceOptions
+	// Start starts the clone after creating it.
+	Start bool
+}
+
+// PodCloneReport describes the results of a pod clone.
+type PodCloneReport struct {
+	Id string //nolint
+}
+
+// PodRenameOptions describes input options for renaming a pod.
+type PodRenameOptions struct {
+	// NewName is the new name of the pod.
+	NewName string
+}
+
 type PodTopOptions struct {
 	// CLI flags.
 	ListDescriptors bool
@@ -206,6 +324,9 @@ type PodStatsOptions struct {
 	All bool
 	// Latest - provide stats for the latest pod.
 	Latest bool
+	// Sum - report each pod with the sum of the stats of its containers
+	// before the stats of the containers.
+	Sum bool
 }
 
 // PodStatsReport includes pod-resource statistics data.
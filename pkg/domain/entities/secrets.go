@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"time"
+)
+
+// SecretCreateOptions describes input options for creating a secret.
+type SecretCreateOptions struct {
+	// Driver is the driver storing the data of the secret.  The file
+	// driver is used if it is empty.
+	Driver string
+}
+
+// SecretCreateReport describes the result of creating a secret.
+type SecretCreateReport struct {
+	ID string
+}
+
+// SecretRmOptions describes input options for removing secrets.
+type SecretRmOptions struct {
+	// All removes all secrets.
+	All bool
+}
+
+// SecretRmReport describes the result of removing a secret.
+type SecretRmReport struct {
+	ID  string
+	Err error
+}
+
+// SecretInfoReport describes a secret.  Its data is never included.
+type SecretInfoReport struct {
+	ID        string
+	CreatedAt time.Time
+	Spec      SecretSpec
+}
+
+// SecretSpec describes the configuration of a secret.
+type SecretSpec struct {
+	Name   string
+	Driver SecretDriverSpec
+}
+
+// SecretDriverSpec describes the driver storing the data of a secret.
+type SecretDriverSpec struct {
+	Name    string
+	Options map[string]string
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/containers/podman/v2/libpod/define"
+	"github.com/containers/podman/v2/pkg/hostdevices"
 	"github.com/docker/docker/api/types"
 	"github.com/spf13/cobra"
 )
@@ -13,13 +14,17 @@ type ServiceOptions struct {
 	URI     string         // Path to unix domain socket service should listen on
 	Timeout time.Duration  // duration of inactivity the service should wait before shutting down
 	Command *cobra.Command // CLI command provided. Used in V1 code
+	// AuthTokenFile is the path to a file holding the bearer token clients
+	// have to authenticate with, if set
+	AuthTokenFile string
 }
 
 // SystemPruneOptions provides options to prune system.
 type SystemPruneOptions struct {
-	All     bool
-	Volume  bool
-	Filters map[string][]string `json:"filters" schema:"filters"`
+	All         bool
+	Volume      bool
+	Interrupted bool
+	Filters     map[string][]string `json:"filters" schema:"filters"`
 }
 
 // SystemPruneReport provides report after system prune is executed.
@@ -27,7 +32,8 @@ type SystemPruneReport struct {
 	PodPruneReport []*PodPruneReport
 	*ContainerPruneReport
 	*ImagePruneReport
-	VolumePruneReport []*VolumePruneReport
+	VolumePruneReport           []*VolumePruneReport
+	InterruptedPullsPruneReport *define.InterruptedPullsPruneReport
 }
 
 // SystemMigrateOptions describes the options needed for the
@@ -82,6 +88,37 @@ type SystemDfVolumeReport struct {
 	ReclaimableSize int64
 }
 
+// SystemDevicesOptions describes the options for listing host devices
+type SystemDevicesOptions struct {
+	Format string
+}
+
+// SystemDevicesReport describes the host devices commonly passed into
+// containers
+type SystemDevicesReport struct {
+	Devices []*hostdevices.Device
+}
+
+// SystemRestoreReport describes a container started by system restore
+type SystemRestoreReport struct {
+	Id  string //nolint
+	Err error
+}
+
+// SystemBackupOptions describes the options for backing up the containers,
+// pods, volumes, networks and secrets of the host
+type SystemBackupOptions struct {
+	// Volumes includes the contents of the volumes in the backup
+	Volumes bool
+}
+
+// SystemRestoreBackupReport describes a resource re-created from a backup
+type SystemRestoreBackupReport struct {
+	// Resource is the kind and name of the resource, e.g. "volume data"
+	Resource string
+	Err      error
+}
+
 // SystemResetOptions describes the options for resetting your
 // container runtime storage, etc
 type SystemResetOptions struct {
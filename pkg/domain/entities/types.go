@@ -40,9 +40,14 @@ type NetOptions struct {
 	DNSServers         []net.IP
 	Network            specgen.Namespace
 	NoHosts            bool
+	HostsFile          string
 	PublishPorts       []specgen.PortMapping
 	StaticIP           *net.IP
+	StaticIPv6         *net.IP
 	StaticMAC          *net.HardwareAddr
+	// StaticIPs and StaticMACs are the static addresses in each network
+	StaticIPs  map[string]net.IP
+	StaticMACs map[string]net.HardwareAddr
 	// NetworkOptions are additional options for each network
 	NetworkOptions map[string][]string
 }
@@ -90,6 +95,15 @@ type ContainerCreateResponse struct {
 	Warnings []string `json:"Warnings"`
 }
 
+// ContainerCreateBatchResponse is the response struct for creating a batch of
+// containers
+type ContainerCreateBatchResponse struct {
+	// IDs of the containers created
+	IDs []string `json:"Ids"`
+	// Warnings during container creation
+	Warnings []string `json:"Warnings"`
+}
+
 type ErrorModel struct {
 	// API root cause formatted for automated parsing
 	// example: API root cause
@@ -114,11 +114,19 @@ type VolumeInspectReport struct {
 // to prune a volume from the CLI
 type VolumePruneOptions struct {
 	Filters url.Values `json:"filters" schema:"filters"`
+	// ForceUnused also prunes volumes used only by stopped containers,
+	// which are removed with the volumes.
+	ForceUnused bool `json:"forceUnused" schema:"forceUnused"`
 }
 
 type VolumePruneReport struct {
 	Err error
 	Id  string //nolint
+	// Size is the disk space reclaimed by removing the volume in bytes.
+	Size uint64
+	// Containers lists the stopped containers which used the volume and
+	// were removed with it.
+	Containers []string `json:",omitempty"`
 }
 
 type VolumeListOptions struct {
@@ -129,6 +137,20 @@ type VolumeListReport struct {
 	VolumeConfigResponse
 }
 
+// VolumeExportOptions describes the options needed
+// to export the contents of a volume from the CLI
+type VolumeExportOptions struct {
+	// Output is the path of the tar archive written to
+	Output string
+}
+
+// VolumeImportOptions describes the options needed
+// to import a tar archive into a volume from the CLI
+type VolumeImportOptions struct {
+	// Input is the path of the tar archive read from
+	Input string
+}
+
 // VolumeListBody Volume list response
 // swagger:model VolumeListBody
 type VolumeListBody struct {
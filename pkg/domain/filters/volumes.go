@@ -3,8 +3,10 @@ package filters
 import (
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/containers/podman/v2/libpod"
+	"github.com/containers/podman/v2/pkg/timetype"
 	"github.com/pkg/errors"
 )
 
@@ -45,6 +47,34 @@ func GenerateVolumeFilters(filters url.Values) ([]libpod.VolumeFilter, error) {
 					}
 					return false
 				})
+			case "label!":
+				filterArray := strings.SplitN(val, "=", 2)
+				filterKey := filterArray[0]
+				var filterVal string
+				if len(filterArray) > 1 {
+					filterVal = filterArray[1]
+				}
+				vf = append(vf, func(v *libpod.Volume) bool {
+					for labelKey, labelValue := range v.Labels() {
+						if labelKey == filterKey && ("" == filterVal || labelValue == filterVal) {
+							return false
+						}
+					}
+					return true
+				})
+			case "until":
+				ts, err := timetype.GetTimestamp(val, time.Now())
+				if err != nil {
+					return nil, err
+				}
+				seconds, nanoseconds, err := timetype.ParseTimestamps(ts, 0)
+				if err != nil {
+					return nil, err
+				}
+				until := time.Unix(seconds, nanoseconds)
+				vf = append(vf, func(v *libpod.Volume) bool {
+					return v.CreatedTime().Before(until)
+				})
 			case "opt":
 				filterArray := strings.SplitN(val, "=", 2)
 				filterKey := filterArray[0]
@@ -11,7 +11,11 @@ func (ic *ContainerEngine) AutoUpdate(ctx context.Context, options entities.Auto
 	// Convert the entities options to the autoupdate ones.  We can't use
 	// them in the entities package as low-level packages must not leak
 	// into the remote client.
-	autoOpts := autoupdate.Options{Authfile: options.Authfile}
-	units, failures := autoupdate.AutoUpdate(ic.Libpod, autoOpts)
+	autoOpts := autoupdate.Options{
+		Authfile:       options.Authfile,
+		Rollback:       options.Rollback,
+		RollbackWindow: options.RollbackWindow,
+	}
+	units, failures := autoupdate.AutoUpdate(ctx, ic.Libpod, autoOpts)
 	return &entities.AutoUpdateReport{Units: units}, failures
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -31,6 +32,8 @@ import (
 	"github.com/containers/podman/v2/pkg/specgen/generate"
 	"github.com/containers/podman/v2/pkg/util"
 	"github.com/containers/storage"
+	"github.com/cri-o/ocicni/pkg/ocicni"
+	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
@@ -43,7 +46,10 @@ func getContainersAndInputByContext(all, latest bool, names []string, runtime *l
 
 	switch {
 	case all:
-		ctrs, err = runtime.GetAllContainers()
+		// Containers hidden by the owner mode are not included.
+		ctrs, err = runtime.GetContainers(func(c *libpod.Container) bool {
+			return runtime.OwnerVisible(c.Owner())
+		})
 	case latest:
 		ctr, err = runtime.GetLatestContainer()
 		if err == nil {
@@ -75,6 +81,102 @@ func getContainersByContext(all, latest bool, names []string, runtime *libpod.Ru
 	return
 }
 
+// addRequiringContainers adds the containers requiring any of ctrs, directly
+// or through other containers, to ctrs.  If running is set, only running or
+// paused containers are added.
+func addRequiringContainers(ctrs []*libpod.Container, running bool) ([]*libpod.Container, error) {
+	seen := make(map[string]bool, len(ctrs))
+	for _, c := range ctrs {
+		seen[c.ID()] = true
+	}
+	for i := 0; i < len(ctrs); i++ {
+		requiredBy, err := ctrs[i].RequiredBy()
+		if err != nil {
+			return nil, err
+		}
+		for _, dep := range requiredBy {
+			if seen[dep.ID()] {
+				continue
+			}
+			if running {
+				state, err := dep.State()
+				if err != nil {
+					return nil, err
+				}
+				if state != define.ContainerStateRunning && state != define.ContainerStatePaused {
+					continue
+				}
+			}
+			seen[dep.ID()] = true
+			ctrs = append(ctrs, dep)
+		}
+	}
+	return ctrs, nil
+}
+
+// requiringContainersFirst splits ctrs into batches to be processed one after
+// the other, so that each container is processed after the containers of ctrs
+// requiring it.
+func requiringContainersFirst(ctrs []*libpod.Container) [][]*libpod.Container {
+	remaining := make(map[string]bool, len(ctrs))
+	for _, c := range ctrs {
+		remaining[c.ID()] = true
+	}
+	requiredBy := make(map[string][]string, len(ctrs))
+	for _, c := range ctrs {
+		deps, err := c.RequiredBy()
+		if err != nil {
+			// The operation on the container reports the error.
+			logrus.Debugf("Error looking up containers requiring %s: %v", c.ID(), err)
+			continue
+		}
+		for _, dep := range deps {
+			requiredBy[c.ID()] = append(requiredBy[c.ID()], dep.ID())
+		}
+	}
+
+	batches := [][]*libpod.Container{}
+	for len(remaining) > 0 {
+		batch := []*libpod.Container{}
+		for _, c := range ctrs {
+			if !remaining[c.ID()] {
+				continue
+			}
+			ready := true
+			for _, id := range requiredBy[c.ID()] {
+				if remaining[id] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				batch = append(batch, c)
+			}
+		}
+		if len(batch) == 0 {
+			// Requirements cannot form a cycle, but make sure we
+			// terminate anyway.
+			for _, c := range ctrs {
+				if remaining[c.ID()] {
+					batch = append(batch, c)
+				}
+			}
+		}
+		for _, c := range batch {
+			delete(remaining, c.ID())
+		}
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+// startRecursive returns whether the dependencies of ctr are started together
+// with it, which is the case for containers in a pod and containers requiring
+// other containers.
+func startRecursive(ctr *libpod.Container) bool {
+	return ctr.PodID() != "" || len(ctr.RequiredContainers()) > 0
+}
+
 // ContainerExists returns whether the container exists in container storage
 func (ic *ContainerEngine) ContainerExists(ctx context.Context, nameOrID string, options entities.ContainerExistsOptions) (*entities.BoolReport, error) {
 	_, err := ic.Libpod.LookupContainer(nameOrID)
@@ -97,17 +199,24 @@ func (ic *ContainerEngine) ContainerWait(ctx context.Context, namesOrIds []strin
 	if err != nil {
 		return nil, err
 	}
-	responses := make([]entities.WaitReport, 0, len(ctrs))
-	for _, c := range ctrs {
-		response := entities.WaitReport{Id: c.ID()}
-		exitCode, err := c.WaitForConditionWithInterval(options.Interval, options.Condition)
-		if err != nil {
-			response.Error = err
-		} else {
-			response.ExitCode = exitCode
-		}
-		responses = append(responses, response)
+	// Wait for all containers at once instead of one after another.
+	responses := make([]entities.WaitReport, len(ctrs))
+	var wg sync.WaitGroup
+	for i, c := range ctrs {
+		wg.Add(1)
+		go func(i int, c *libpod.Container) {
+			defer wg.Done()
+			response := entities.WaitReport{Id: c.ID()}
+			exitCode, err := c.WaitForConditions(ctx, options.Interval, options.Condition)
+			if err != nil {
+				response.Error = err
+			} else {
+				response.ExitCode = exitCode
+			}
+			responses[i] = response
+		}(i, c)
 	}
+	wg.Wait()
 	return responses, nil
 }
 
@@ -132,6 +241,30 @@ func (ic *ContainerEngine) ContainerPause(ctx context.Context, namesOrIds []stri
 	return report, nil
 }
 
+func (ic *ContainerEngine) ContainerDropCaches(ctx context.Context, namesOrIds []string, options entities.ContainerDropCachesOptions) ([]*entities.ContainerDropCachesReport, error) {
+	ctrs, err := getContainersByContext(options.All, options.Latest, namesOrIds, ic.Libpod)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*entities.ContainerDropCachesReport, 0, len(ctrs))
+	for _, c := range ctrs {
+		// Only running and paused containers have caches to drop.
+		if options.All {
+			state, err := c.State()
+			if err != nil {
+				reports = append(reports, &entities.ContainerDropCachesReport{Id: c.ID(), Err: err})
+				continue
+			}
+			if state != define.ContainerStateRunning && state != define.ContainerStatePaused {
+				continue
+			}
+		}
+		err := c.DropCaches(options.Amount)
+		reports = append(reports, &entities.ContainerDropCachesReport{Id: c.ID(), Err: err})
+	}
+	return reports, nil
+}
+
 func (ic *ContainerEngine) ContainerUnpause(ctx context.Context, namesOrIds []string, options entities.PauseUnPauseOptions) ([]*entities.PauseUnpauseReport, error) {
 	var (
 		err error
@@ -166,33 +299,55 @@ func (ic *ContainerEngine) ContainerStop(ctx context.Context, namesOrIds []strin
 	if err != nil && !(options.Ignore && errors.Cause(err) == define.ErrNoSuchCtr) {
 		return nil, err
 	}
-	errMap, err := parallelctr.ContainerOp(ctx, ctrs, func(c *libpod.Container) error {
-		var err error
-		if options.Timeout != nil {
-			err = c.StopWithTimeout(*options.Timeout)
-		} else {
-			err = c.Stop()
-		}
+	if options.Force {
+		ctrs, err = addRequiringContainers(ctrs, true)
 		if err != nil {
+			return nil, err
+		}
+	}
+	// Containers are stopped after the containers requiring them.
+	errMap := make(map[*libpod.Container]error, len(ctrs))
+	for _, batch := range requiringContainersFirst(ctrs) {
+		batchErrMap, err := parallelctr.ContainerOp(ctx, batch, func(c *libpod.Container) error {
+			requiredBy, err := c.RequiredByRunning()
+			if err != nil {
+				return err
+			}
+			if len(requiredBy) > 0 {
+				return errors.Wrapf(define.ErrCtrRequired, "container %s is required by running containers which must be stopped before it: %s", c.ID(), strings.Join(requiredBy, ", "))
+			}
 			switch {
-			case errors.Cause(err) == define.ErrCtrStopped:
-				logrus.Debugf("Container %s is already stopped", c.ID())
-			case options.All && errors.Cause(err) == define.ErrCtrStateInvalid:
-				logrus.Debugf("Container %s is not running, could not stop", c.ID())
+			case len(options.Signals) > 0:
+				err = c.StopWithSignals(options.Signals)
+			case options.Timeout != nil:
+				err = c.StopWithTimeout(*options.Timeout)
 			default:
-				return err
+				err = c.Stop()
+			}
+			if err != nil {
+				switch {
+				case errors.Cause(err) == define.ErrCtrStopped:
+					logrus.Debugf("Container %s is already stopped", c.ID())
+				case options.All && errors.Cause(err) == define.ErrCtrStateInvalid:
+					logrus.Debugf("Container %s is not running, could not stop", c.ID())
+				default:
+					return err
+				}
+			}
+			if c.AutoRemove() {
+				// Issue #7384: if the container is configured for
+				// auto-removal, it might already have been removed at
+				// this point.
+				return nil
 			}
+			return c.Cleanup(ctx)
+		})
+		if err != nil {
+			return nil, err
 		}
-		if c.AutoRemove() {
-			// Issue #7384: if the container is configured for
-			// auto-removal, it might already have been removed at
-			// this point.
-			return nil
+		for c, err := range batchErrMap {
+			errMap[c] = err
 		}
-		return c.Cleanup(ctx)
-	})
-	if err != nil {
-		return nil, err
 	}
 	reports := make([]*entities.StopReport, 0, len(errMap))
 	for ctr, err := range errMap {
@@ -287,6 +442,49 @@ func (ic *ContainerEngine) ContainerRestart(ctx context.Context, namesOrIds []st
 	return reports, nil
 }
 
+// removeExternalContainers removes the containers of the storage not created by
+// Podman, e.g., by Buildah or CRI-O.  With --all, the containers of an engine
+// managing them, such as CRI-O, are skipped.
+func (ic *ContainerEngine) removeExternalContainers(namesOrIds []string, options entities.RmOptions) ([]*entities.RmReport, error) {
+	var ctrs []*libpod.ExternalContainer
+	if options.All {
+		all, err := ic.Libpod.ExternalContainers()
+		if err != nil {
+			return nil, err
+		}
+		for _, ctr := range all {
+			// The containers of an engine such as CRI-O are only
+			// removed when named explicitly.
+			if ctr.ManagedByEngine() {
+				logrus.Debugf("Not removing container %s of %s", ctr.ID, ctr.Engine)
+				continue
+			}
+			ctrs = append(ctrs, ctr)
+		}
+	} else {
+		for _, nameOrID := range namesOrIds {
+			ctr, err := ic.Libpod.LookupExternalContainer(nameOrID)
+			if err != nil {
+				if options.Ignore && errors.Cause(err) == define.ErrNoSuchCtr {
+					logrus.Debugf("Ignoring error (--allow-missing): %v", err)
+					continue
+				}
+				return nil, err
+			}
+			ctrs = append(ctrs, ctr)
+		}
+	}
+
+	reports := make([]*entities.RmReport, 0, len(ctrs))
+	for _, ctr := range ctrs {
+		reports = append(reports, &entities.RmReport{
+			Id:  ctr.ID,
+			Err: ic.Libpod.RemoveStorageContainer(ctr.ID, options.Force),
+		})
+	}
+	return reports, nil
+}
+
 func (ic *ContainerEngine) ContainerRm(ctx context.Context, namesOrIds []string, options entities.RmOptions) ([]*entities.RmReport, error) {
 	reports := []*entities.RmReport{}
 
@@ -300,6 +498,10 @@ func (ic *ContainerEngine) ContainerRm(ctx context.Context, namesOrIds []string,
 		names = append(names, id)
 	}
 
+	if options.External {
+		return ic.removeExternalContainers(names, options)
+	}
+
 	// Attempt to remove named containers directly from storage, if container is defined in libpod
 	// this will fail and code will fall through to removing the container from libpod.`
 	tmpNames := []string{}
@@ -343,25 +545,34 @@ func (ic *ContainerEngine) ContainerRm(ctx context.Context, namesOrIds []string,
 		return reports, nil
 	}
 
-	errMap, err := parallelctr.ContainerOp(ctx, ctrs, func(c *libpod.Container) error {
-		err := ic.Libpod.RemoveContainer(ctx, c, options.Force, options.Volumes)
+	if options.Force {
+		ctrs, err = addRequiringContainers(ctrs, false)
 		if err != nil {
-			if options.Ignore && errors.Cause(err) == define.ErrNoSuchCtr {
-				logrus.Debugf("Ignoring error (--allow-missing): %v", err)
-				return nil
-			}
-			logrus.Debugf("Failed to remove container %s: %s", c.ID(), err.Error())
+			return nil, err
 		}
-		return err
-	})
-	if err != nil {
-		return nil, err
 	}
-	for ctr, err := range errMap {
-		report := new(entities.RmReport)
-		report.Id = ctr.ID()
-		report.Err = err
-		reports = append(reports, report)
+	// Containers are removed after the containers requiring them.
+	for _, batch := range requiringContainersFirst(ctrs) {
+		errMap, err := parallelctr.ContainerOp(ctx, batch, func(c *libpod.Container) error {
+			err := ic.Libpod.RemoveContainer(ctx, c, options.Force, options.Volumes)
+			if err != nil {
+				if options.Ignore && errors.Cause(err) == define.ErrNoSuchCtr {
+					logrus.Debugf("Ignoring error (--allow-missing): %v", err)
+					return nil
+				}
+				logrus.Debugf("Failed to remove container %s: %s", c.ID(), err.Error())
+			}
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for ctr, err := range errMap {
+			report := new(entities.RmReport)
+			report.Id = ctr.ID()
+			report.Err = err
+			reports = append(reports, report)
+		}
 	}
 	return reports, nil
 }
@@ -435,6 +646,181 @@ func (ic *ContainerEngine) ContainerTop(ctx context.Context, options entities.To
 	return report, err
 }
 
+func (ic *ContainerEngine) ContainerClone(ctx context.Context, nameOrID string, options entities.ContainerCloneOptions) (*entities.ContainerCloneReport, error) {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	var createOptions []libpod.CtrCreateOption
+	if options.Name != "" {
+		createOptions = append(createOptions, libpod.WithName(options.Name))
+	}
+	if options.Image != "" {
+		newImage, err := ic.Libpod.ImageRuntime().NewFromLocal(options.Image)
+		if err != nil {
+			return nil, err
+		}
+		// Resolve the name the same way as when creating a container.
+		imgName := newImage.InputName
+		if options.Image == newImage.InputName && strings.HasPrefix(newImage.ID(), options.Image) {
+			if names := newImage.Names(); len(names) > 0 {
+				imgName = names[0]
+			}
+		}
+		createOptions = append(createOptions, libpod.WithRootFSFromImage(newImage.ID(), imgName, options.Image))
+	}
+
+	if resources := cloneResources(ctr, options.CloneResourceOptions); resources != nil {
+		createOptions = append(createOptions, libpod.WithResources(resources))
+	}
+
+	clone, err := ic.Libpod.CloneContainer(ctx, ctr, createOptions...)
+	if err != nil {
+		return nil, err
+	}
+	if options.Run {
+		if err := clone.Start(ctx, startRecursive(clone)); err != nil {
+			return nil, errors.Wrapf(err, "unable to start container %q", clone.ID())
+		}
+	}
+	return &entities.ContainerCloneReport{Id: clone.ID()}, nil
+}
+
+// cloneResources returns the resource limits of the clone of ctr, with the
+// limits set in options replacing the limits of ctr.  It returns nil if options
+// do not change any limit.
+func cloneResources(ctr *libpod.Container, options entities.CloneResourceOptions) *specs.LinuxResources {
+	resources := new(specs.LinuxResources)
+	if ctrSpec := ctr.Spec(); ctrSpec.Linux != nil && ctrSpec.Linux.Resources != nil {
+		resources = ctrSpec.Linux.Resources
+	}
+	changed := false
+	if options.CPUPeriod != 0 || options.CPUQuota != 0 || options.CPUShares != 0 || options.CPUSetCPUs != "" {
+		if resources.CPU == nil {
+			resources.CPU = new(specs.LinuxCPU)
+		}
+		if options.CPUPeriod != 0 || options.CPUQuota != 0 {
+			resources.CPU.Period = &options.CPUPeriod
+			resources.CPU.Quota = &options.CPUQuota
+		}
+		if options.CPUShares != 0 {
+			resources.CPU.Shares = &options.CPUShares
+		}
+		if options.CPUSetCPUs != "" {
+			resources.CPU.Cpus = options.CPUSetCPUs
+		}
+		changed = true
+	}
+	if options.Memory != 0 {
+		if resources.Memory == nil {
+			resources.Memory = new(specs.LinuxMemory)
+		}
+		resources.Memory.Limit = &options.Memory
+		// The memory+swap limit of the source can be lower than the
+		// new memory limit, reset it to the default of podman create
+		// unless swap is unlimited.
+		if resources.Memory.Swap != nil && *resources.Memory.Swap >= 0 {
+			swap := 2 * options.Memory
+			resources.Memory.Swap = &swap
+		}
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return resources
+}
+
+func (ic *ContainerEngine) ContainerUpdate(ctx context.Context, nameOrID string, options entities.ContainerUpdateOptions) (*entities.ContainerUpdateReport, error) {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := new(specs.LinuxResources)
+	if ctrSpec := ctr.Spec(); ctrSpec.Linux != nil && ctrSpec.Linux.Resources != nil {
+		resources = ctrSpec.Linux.Resources
+	}
+	if options.CPUPeriod != 0 || options.CPUQuota != 0 || options.CPUShares != 0 || options.CPUSetCPUs != "" || options.CPUSetMems != "" {
+		if resources.CPU == nil {
+			resources.CPU = new(specs.LinuxCPU)
+		}
+		if options.CPUPeriod != 0 || options.CPUQuota != 0 {
+			resources.CPU.Period = &options.CPUPeriod
+			resources.CPU.Quota = &options.CPUQuota
+		}
+		if options.CPUShares != 0 {
+			resources.CPU.Shares = &options.CPUShares
+		}
+		if options.CPUSetCPUs != "" {
+			resources.CPU.Cpus = options.CPUSetCPUs
+		}
+		if options.CPUSetMems != "" {
+			resources.CPU.Mems = options.CPUSetMems
+		}
+	}
+	if options.Memory != 0 || options.MemorySwap != 0 {
+		if resources.Memory == nil {
+			resources.Memory = new(specs.LinuxMemory)
+		}
+		if options.Memory != 0 {
+			resources.Memory.Limit = &options.Memory
+		}
+		if options.MemorySwap != 0 {
+			resources.Memory.Swap = &options.MemorySwap
+		}
+		if resources.Memory.Limit == nil && resources.Memory.Swap != nil {
+			return nil, errors.New("a memory limit must be set when setting a memory+swap limit")
+		}
+		if resources.Memory.Limit != nil && resources.Memory.Swap != nil && *resources.Memory.Swap >= 0 && *resources.Memory.Swap < *resources.Memory.Limit {
+			return nil, errors.New("the memory+swap limit must be larger than the memory limit")
+		}
+	}
+	if options.PidsLimit != 0 {
+		resources.Pids = &specs.LinuxPids{Limit: options.PidsLimit}
+	}
+	if options.BlkioWeight != 0 {
+		if options.BlkioWeight < 10 || options.BlkioWeight > 1000 {
+			return nil, errors.Errorf("invalid block IO weight %d: must be between 10 and 1000", options.BlkioWeight)
+		}
+		if resources.BlockIO == nil {
+			resources.BlockIO = new(specs.LinuxBlockIO)
+		}
+		resources.BlockIO.Weight = &options.BlkioWeight
+	}
+
+	updateLabels := len(options.Labels) > 0 || len(options.RemoveLabels) > 0
+	updateAnnotations := len(options.Annotations) > 0 || len(options.RemoveAnnotations) > 0
+	updateResources := options.CPUPeriod != 0 || options.CPUQuota != 0 || options.CPUShares != 0 || options.CPUSetCPUs != "" ||
+		options.CPUSetMems != "" || options.Memory != 0 || options.MemorySwap != 0 || options.PidsLimit != 0 || options.BlkioWeight != 0
+
+	if updateResources || (!updateLabels && !updateAnnotations) {
+		if err := ctr.Update(resources); err != nil {
+			return nil, errors.Wrapf(err, "unable to update container %q", ctr.ID())
+		}
+	}
+	if updateLabels {
+		if err := ctr.UpdateLabels(options.Labels, options.RemoveLabels); err != nil {
+			return nil, errors.Wrapf(err, "unable to update labels of container %q", ctr.ID())
+		}
+	}
+	if updateAnnotations {
+		if err := ctr.UpdateAnnotations(options.Annotations, options.RemoveAnnotations); err != nil {
+			return nil, errors.Wrapf(err, "unable to update annotations of container %q", ctr.ID())
+		}
+	}
+	return &entities.ContainerUpdateReport{Id: ctr.ID()}, nil
+}
+
+func (ic *ContainerEngine) ContainerRename(ctx context.Context, nameOrID string, options entities.ContainerRenameOptions) error {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return err
+	}
+	return ic.Libpod.RenameContainer(ctx, ctr, options.NewName)
+}
+
 func (ic *ContainerEngine) ContainerCommit(ctx context.Context, nameOrID string, options entities.CommitOptions) (*entities.CommitReport, error) {
 	var (
 		mimeType string
@@ -464,6 +850,7 @@ func (ic *ContainerEngine) ContainerCommit(ctx context.Context, nameOrID string,
 		ReportWriter:          options.Writer,
 		SystemContext:         sc,
 		PreferredManifestType: mimeType,
+		HistoryTimestamp:      options.Timestamp,
 	}
 	opts := libpod.ContainerCommitOptions{
 		CommitOptions:  coptions,
@@ -485,19 +872,24 @@ func (ic *ContainerEngine) ContainerExport(ctx context.Context, nameOrID string,
 	if err != nil {
 		return err
 	}
+	if options.IncludeVolumes {
+		return ctr.ExportWithVolumes(options.Output)
+	}
 	return ctr.Export(options.Output)
 }
 
 func (ic *ContainerEngine) ContainerCheckpoint(ctx context.Context, namesOrIds []string, options entities.CheckpointOptions) ([]*entities.CheckpointReport, error) {
 	var (
-		err  error
-		cons []*libpod.Container
+		err        error
+		cons       []*libpod.Container
+		tmpArchive string
 	)
 	checkOpts := libpod.ContainerCheckpointOptions{
 		Keep:           options.Keep,
 		TCPEstablished: options.TCPEstablished,
 		TargetFile:     options.Export,
 		IgnoreRootfs:   options.IgnoreRootFS,
+		IgnoreVolumes:  options.IgnoreVolumes,
 		KeepRunning:    options.LeaveRunning,
 	}
 
@@ -513,9 +905,42 @@ func (ic *ContainerEngine) ContainerCheckpoint(ctx context.Context, namesOrIds [
 	if err != nil {
 		return nil, err
 	}
+	if options.CreateImage != "" {
+		if len(cons) != 1 {
+			return nil, errors.Errorf("--create-image can only be used with a single container")
+		}
+		// The checkpoint image is created from the exported
+		// checkpoint, which is only kept if it was requested or
+		// the image cannot be created.
+		if checkOpts.TargetFile == "" {
+			tmpFile, err := ioutil.TempFile("", "checkpoint")
+			if err != nil {
+				return nil, err
+			}
+			tmpArchive = tmpFile.Name()
+			defer func() {
+				if tmpArchive != "" {
+					os.Remove(tmpArchive)
+				}
+			}()
+			if err := tmpFile.Close(); err != nil {
+				return nil, err
+			}
+			checkOpts.TargetFile = tmpArchive
+		}
+	}
 	reports := make([]*entities.CheckpointReport, 0, len(cons))
 	for _, con := range cons {
 		err = con.Checkpoint(ctx, checkOpts)
+		if err == nil && options.CreateImage != "" {
+			err = checkpoint.CRCreateImage(ctx, ic.Libpod, con, checkOpts.TargetFile, options.CreateImage)
+			if err != nil && tmpArchive != "" {
+				// Keep the checkpoint, the container may be
+				// gone and it can still be restored with --import.
+				err = errors.Wrapf(err, "checkpoint of container %s kept in %s", con.ID(), tmpArchive)
+				tmpArchive = ""
+			}
+		}
 		reports = append(reports, &entities.CheckpointReport{
 			Err: err,
 			Id:  con.ID(),
@@ -538,6 +963,23 @@ func (ic *ContainerEngine) ContainerRestore(ctx context.Context, namesOrIds []st
 		IgnoreRootfs:    options.IgnoreRootFS,
 		IgnoreStaticIP:  options.IgnoreStaticIP,
 		IgnoreStaticMAC: options.IgnoreStaticMAC,
+		IgnoreVolumes:   options.IgnoreVolumes,
+	}
+	if options.StaticIP != nil {
+		restoreOptions.StaticIP = *options.StaticIP
+	}
+	if options.StaticMAC != nil {
+		restoreOptions.StaticMAC = *options.StaticMAC
+	}
+
+	// The published ports are part of the configuration of the container,
+	// so they can only be changed when it is re-created from a checkpoint.
+	var portMappings []ocicni.PortMapping
+	if len(options.PublishPorts) > 0 {
+		portMappings, err = generate.ParsePortMappings(ic.Libpod, options.PublishPorts)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	filterFuncs := []libpod.ContainerFilter{
@@ -547,17 +989,68 @@ func (ic *ContainerEngine) ContainerRestore(ctx context.Context, namesOrIds []st
 		},
 	}
 
+	// Arguments which do not name a container may name checkpoint images.
+	var checkpointImages []*image.Image
 	switch {
 	case options.Import != "":
-		cons, err = checkpoint.CRImportCheckpoint(ctx, ic.Libpod, options.Import, options.Name)
+		cons, err = checkpoint.CRImportCheckpoint(ctx, ic.Libpod, options.Import, options.Name, portMappings)
 	case options.All:
 		cons, err = ic.Libpod.GetContainers(filterFuncs...)
 	default:
-		cons, err = getContainersByContext(false, options.Latest, namesOrIds, ic.Libpod)
+		ctrNames := make([]string, 0, len(namesOrIds))
+		for _, nameOrID := range namesOrIds {
+			if _, err := ic.Libpod.LookupContainer(nameOrID); err != nil {
+				if img, err := ic.Libpod.ImageRuntime().NewFromLocal(nameOrID); err == nil {
+					if ok, _ := checkpoint.IsCheckpointImage(ctx, img); ok {
+						checkpointImages = append(checkpointImages, img)
+						continue
+					}
+				}
+			}
+			ctrNames = append(ctrNames, nameOrID)
+		}
+		if len(ctrNames) > 0 || options.Latest {
+			cons, err = getContainersByContext(false, options.Latest, ctrNames, ic.Libpod)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if portMappings != nil && options.Import == "" && len(cons) > 0 {
+		return nil, errors.Errorf("--publish can only be used when restoring from an exported checkpoint or a checkpoint image")
+	}
+	reports := make([]*entities.RestoreReport, 0, len(cons)+len(checkpointImages))
+	for _, con := range cons {
+		err := con.Restore(ctx, restoreOptions)
+		reports = append(reports, &entities.RestoreReport{
+			Err: err,
+			Id:  con.ID(),
+		})
+	}
+	for _, img := range checkpointImages {
+		imageReports, err := restoreCheckpointImage(ctx, ic.Libpod, img, restoreOptions, portMappings)
+		if err != nil {
+			imageReports = []*entities.RestoreReport{{Err: err, Id: img.ID()}}
+		}
+		reports = append(reports, imageReports...)
 	}
+	return reports, nil
+}
+
+// restoreCheckpointImage re-creates and restores the container checkpointed
+// in the checkpoint image img.
+func restoreCheckpointImage(ctx context.Context, runtime *libpod.Runtime, img *image.Image, restoreOptions libpod.ContainerCheckpointOptions, portMappings []ocicni.PortMapping) ([]*entities.RestoreReport, error) {
+	archivePath, err := checkpoint.CRArchiveFromImage(img)
 	if err != nil {
 		return nil, err
 	}
+	defer os.Remove(archivePath)
+
+	cons, err := checkpoint.CRImportCheckpoint(ctx, runtime, archivePath, restoreOptions.Name, portMappings)
+	if err != nil {
+		return nil, err
+	}
+	restoreOptions.TargetFile = archivePath
 	reports := make([]*entities.RestoreReport, 0, len(cons))
 	for _, con := range cons {
 		err := con.Restore(ctx, restoreOptions)
@@ -585,6 +1078,40 @@ func (ic *ContainerEngine) ContainerCreate(ctx context.Context, s *specgen.SpecG
 	return &entities.ContainerCreateReport{Id: ctr.ID()}, nil
 }
 
+func (ic *ContainerEngine) ContainerCreateDryRun(ctx context.Context, s *specgen.SpecGenerator) (*entities.ContainerCreateDryRunReport, error) {
+	warn, err := generate.CompleteSpec(ctx, ic.Libpod, s)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range warn {
+		fmt.Fprintf(os.Stderr, "%s\n", w)
+	}
+	runtimeSpec, volumes, overlays, err := generate.DryRunContainer(ctx, ic.Libpod, s)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.ContainerCreateDryRunReport{Spec: runtimeSpec, Volumes: volumes, OverlayVolumes: overlays}, nil
+}
+
+func (ic *ContainerEngine) ContainerCreateBatch(ctx context.Context, s *specgen.SpecGenerator, options entities.ContainerCreateBatchOptions) (*entities.ContainerCreateBatchReport, error) {
+	warn, err := generate.CompleteSpec(ctx, ic.Libpod, s)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range warn {
+		fmt.Fprintf(os.Stderr, "%s\n", w)
+	}
+	ctrs, err := generate.MakeContainerBatch(ctx, ic.Libpod, s, options.Count, options.NamePattern, options.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	report := &entities.ContainerCreateBatchReport{Ids: make([]string, 0, len(ctrs))}
+	for _, ctr := range ctrs {
+		report.Ids = append(report.Ids, ctr.ID())
+	}
+	return report, nil
+}
+
 func (ic *ContainerEngine) ContainerAttach(ctx context.Context, nameOrID string, options entities.AttachOptions) error {
 	ctrs, err := getContainersByContext(false, options.Latest, []string{nameOrID}, ic.Libpod)
 	if err != nil {
@@ -705,6 +1232,48 @@ func (ic *ContainerEngine) ContainerExecDetached(ctx context.Context, nameOrID s
 	return id, nil
 }
 
+func (ic *ContainerEngine) ContainerExecSessions(ctx context.Context, namesOrIds []string, options entities.ContainerExecSessionsOptions) ([]*entities.ContainerExecSessionReport, error) {
+	all := len(namesOrIds) == 0 && !options.Latest
+	ctrs, err := getContainersByContext(all, options.Latest, namesOrIds, ic.Libpod)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := []*entities.ContainerExecSessionReport{}
+	for _, ctr := range ctrs {
+		ids, err := ctr.ExecSessions()
+		if err != nil {
+			// The container may have been removed in the meantime.
+			if all && (errors.Cause(err) == define.ErrNoSuchCtr || errors.Cause(err) == define.ErrCtrRemoved) {
+				continue
+			}
+			return nil, err
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			session, err := ctr.ExecSession(id)
+			if err != nil {
+				if errors.Cause(err) == define.ErrNoSuchExecSession {
+					continue
+				}
+				return nil, err
+			}
+			inspect, err := session.Inspect()
+			if err != nil {
+				return nil, err
+			}
+			if options.Running && !inspect.Running {
+				continue
+			}
+			reports = append(reports, &entities.ContainerExecSessionReport{
+				InspectExecSession: *inspect,
+				ContainerName:      ctr.Name(),
+			})
+		}
+	}
+	return reports, nil
+}
+
 func (ic *ContainerEngine) ContainerStart(ctx context.Context, namesOrIds []string, options entities.ContainerStartOptions) ([]*entities.ContainerStartReport, error) {
 	reports := []*entities.ContainerStartReport{}
 	var exitCode = define.ExecErrorCodeGeneric
@@ -723,7 +1292,7 @@ func (ic *ContainerEngine) ContainerStart(ctx context.Context, namesOrIds []stri
 		ctrRunning := ctrState == define.ContainerStateRunning
 
 		if options.Attach {
-			err = terminal.StartAttachCtr(ctx, ctr, options.Stdout, options.Stderr, options.Stdin, options.DetachKeys, options.SigProxy, !ctrRunning, ctr.PodID() != "")
+			err = terminal.StartAttachCtr(ctx, ctr, options.Stdout, options.Stderr, options.Stdin, options.DetachKeys, options.SigProxy, !ctrRunning, startRecursive(ctr))
 			if errors.Cause(err) == define.ErrDetach {
 				// User manually detached
 				// Exit cleanly immediately
@@ -793,13 +1362,14 @@ func (ic *ContainerEngine) ContainerStart(ctx context.Context, namesOrIds []stri
 		// Start the container if it's not running already.
 		if !ctrRunning {
 			// Handle non-attach start
-			// If the container is in a pod, also set to recursively start dependencies
+			// If the container is in a pod or requires other containers,
+			// also set to recursively start dependencies
 			report := &entities.ContainerStartReport{
 				Id:       ctr.ID(),
 				RawInput: rawInput,
 				ExitCode: 125,
 			}
-			if err := ctr.Start(ctx, ctr.PodID() != ""); err != nil {
+			if err := ctr.Start(ctx, startRecursive(ctr)); err != nil {
 				// if lastError != nil {
 				//	fmt.Fprintln(os.Stderr, lastError)
 				// }
@@ -860,10 +1430,7 @@ func (ic *ContainerEngine) ContainerRun(ctx context.Context, opts entities.Conta
 		}
 	}
 
-	var joinPod bool
-	if len(ctr.PodID()) > 0 {
-		joinPod = true
-	}
+	joinPod := startRecursive(ctr)
 	report := entities.ContainerRunReport{Id: ctr.ID()}
 
 	if logrus.GetLevel() == logrus.DebugLevel {
@@ -873,7 +1440,8 @@ func (ic *ContainerEngine) ContainerRun(ctx context.Context, opts entities.Conta
 		}
 	}
 	if opts.Detach {
-		// if the container was created as part of a pod, also start its dependencies, if any.
+		// if the container was created as part of a pod or requires
+		// other containers, also start its dependencies, if any.
 		if err := ctr.Start(ctx, joinPod); err != nil {
 			// This means the command did not exist
 			report.ExitCode = define.ExitCode(err)
@@ -883,7 +1451,8 @@ func (ic *ContainerEngine) ContainerRun(ctx context.Context, opts entities.Conta
 		return &report, nil
 	}
 
-	// if the container was created as part of a pod, also start its dependencies, if any.
+	// if the container was created as part of a pod or requires other
+	// containers, also start its dependencies, if any.
 	if err := terminal.StartAttachCtr(ctx, ctr, opts.OutputStream, opts.ErrorStream, opts.InputStream, opts.DetachKeys, opts.SigProxy, true, joinPod); err != nil {
 		// We've manually detached from the container
 		// Do not perform cleanup, or wait for container exit code
@@ -893,7 +1462,7 @@ func (ic *ContainerEngine) ContainerRun(ctx context.Context, opts entities.Conta
 			return &report, nil
 		}
 		if opts.Rm {
-			if deleteError := ic.Libpod.RemoveContainer(ctx, ctr, true, false); deleteError != nil {
+			if deleteError := ic.Libpod.RemoveContainer(ctx, ctr, true, true); deleteError != nil {
 				logrus.Debugf("unable to remove container %s after failing to start and attach to it", ctr.ID())
 			}
 		}
@@ -954,6 +1523,8 @@ func (ic *ContainerEngine) ContainerLogs(ctx context.Context, containers []strin
 		Timestamps: options.Timestamps,
 		UseName:    options.Names,
 		WaitGroup:  &wg,
+		Stdout:     options.StdoutWriter != nil,
+		Stderr:     options.StderrWriter != nil,
 	}
 
 	chSize := len(ctrs) * int(options.Tail)
@@ -979,6 +1550,9 @@ func (ic *ContainerEngine) ContainerLogs(ctx context.Context, containers []strin
 }
 
 func (ic *ContainerEngine) ContainerCleanup(ctx context.Context, namesOrIds []string, options entities.ContainerCleanupOptions) ([]*entities.ContainerCleanupReport, error) {
+	if options.Watch {
+		return nil, ic.Libpod.RunCleanupMonitor(ctx)
+	}
 	reports := []*entities.ContainerCleanupReport{}
 	ctrs, err := getContainersByContext(options.All, options.Latest, namesOrIds, ic.Libpod)
 	if err != nil {
@@ -1255,8 +1829,11 @@ func (ic *ContainerEngine) ContainerStats(ctx context.Context, namesOrIds []stri
 		containerFunc = func() ([]*libpod.Container, error) { return ic.Libpod.GetContainersByList(namesOrIds) }
 	default:
 		// No containers, no latest -> query all!
+		// The stats of all the containers are read from a snapshot of
+		// the state, so they do not wait for operations on individual
+		// containers.
 		queryAll = true
-		containerFunc = ic.Libpod.GetAllContainers
+		containerFunc = func() ([]*libpod.Container, error) { return ic.Libpod.GetContainersSnapshot() }
 	}
 
 	go func() {
@@ -49,6 +49,34 @@ func (ir *ImageEngine) Exists(_ context.Context, nameOrID string) (*entities.Boo
 	return &entities.BoolReport{Value: err == nil}, nil
 }
 
+func (ir *ImageEngine) Lookup(_ context.Context, namesOrIDs []string, _ entities.ImageLookupOptions) ([]*entities.ImageLookupReport, error) {
+	images, errs, err := ir.Libpod.ImageRuntime().LookupImages(namesOrIDs)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*entities.ImageLookupReport, 0, len(namesOrIDs))
+	for i, name := range namesOrIDs {
+		report := &entities.ImageLookupReport{Name: name}
+		switch errors.Cause(errs[i]) {
+		case nil:
+			report.Exists = true
+			report.ID = images[i].ID()
+			report.Digest = string(images[i].Digest())
+			report.RepoDigests, err = images[i].RepoDigests()
+			if err != nil {
+				return nil, err
+			}
+		case define.ErrMultipleImages:
+			report.Exists = true
+		case define.ErrNoSuchImage:
+		default:
+			return nil, errs[i]
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
 func (ir *ImageEngine) Prune(ctx context.Context, opts entities.ImagePruneOptions) (*entities.ImagePruneReport, error) {
 	results, err := ir.Libpod.ImageRuntime().PruneImages(ctx, opts.All, opts.Filter)
 	if err != nil {
@@ -217,9 +245,12 @@ func ToDomainHistoryLayer(layer *libpodImage.History) entities.ImageHistoryLayer
 	l.ID = layer.ID
 	l.Created = *layer.Created
 	l.CreatedBy = layer.CreatedBy
-	copy(l.Tags, layer.Tags)
+	l.Tags = layer.Tags
 	l.Size = layer.Size
 	l.Comment = layer.Comment
+	l.EmptyLayer = layer.EmptyLayer
+	l.Layer = layer.Layer
+	l.LayerDigest = layer.LayerDigest
 	return l
 }
 
@@ -252,6 +283,7 @@ func pull(ctx context.Context, runtime *image.Runtime, rawImage string, options
 		ArchitectureChoice:          options.OverrideArch,
 		VariantChoice:               options.OverrideVariant,
 		DockerInsecureSkipTLSVerify: options.SkipTLSVerify,
+		RateLimit:                   options.RateLimit,
 	}
 
 	if !options.AllTags {
@@ -307,6 +339,14 @@ func (ir *ImageEngine) Pull(ctx context.Context, rawImage string, options entiti
 	return pull(ctx, ir.Libpod.ImageRuntime(), rawImage, options, nil)
 }
 
+func (ir *ImageEngine) ExplainResolution(ctx context.Context, names []string) ([]*entities.ImageResolutionReport, error) {
+	reports := make([]*entities.ImageResolutionReport, 0, len(names))
+	for _, name := range names {
+		reports = append(reports, &entities.ImageResolutionReport{ImageResolutionTrace: ir.Libpod.ImageRuntime().ExplainResolution(name)})
+	}
+	return reports, nil
+}
+
 func (ir *ImageEngine) Inspect(ctx context.Context, namesOrIDs []string, opts entities.InspectOptions) ([]*entities.ImageInspectReport, []error, error) {
 	reports := []*entities.ImageInspectReport{}
 	errs := []error{}
@@ -362,6 +402,7 @@ func (ir *ImageEngine) Push(ctx context.Context, source string, destination stri
 		DockerRegistryCreds:         registryCreds,
 		DockerCertPath:              options.CertDir,
 		DockerInsecureSkipTLSVerify: options.SkipTLSVerify,
+		RateLimit:                   options.RateLimit,
 	}
 
 	signOptions := image.SigningOptions{
@@ -456,6 +497,18 @@ func (ir *ImageEngine) Load(ctx context.Context, opts entities.ImageLoadOptions)
 	if !opts.Quiet {
 		writer = os.Stderr
 	}
+	if opts.Delta {
+		newImage, err := ir.Libpod.ImageRuntime().LoadDelta(ctx, opts.Input, opts.SignaturePolicy, writer)
+		if err != nil {
+			return nil, err
+		}
+		if len(opts.Name) > 0 {
+			if err := newImage.TagImage(fmt.Sprintf("%s:%s", opts.Name, opts.Tag)); err != nil {
+				return nil, errors.Wrapf(err, "error adding %q to image %q", opts.Name, newImage.InputName)
+			}
+		}
+		return &entities.ImageLoadReport{Names: []string{newImage.InputName}}, nil
+	}
 	name, err := ir.Libpod.LoadImage(ctx, opts.Input, writer, opts.SignaturePolicy)
 	if err != nil {
 		return nil, err
@@ -476,7 +529,13 @@ func (ir *ImageEngine) Load(ctx context.Context, opts entities.ImageLoadOptions)
 }
 
 func (ir *ImageEngine) Import(ctx context.Context, opts entities.ImageImportOptions) (*entities.ImageImportReport, error) {
-	id, err := ir.Libpod.Import(ctx, opts.Source, opts.Reference, opts.SignaturePolicy, opts.Changes, opts.Message, opts.Quiet)
+	importFunc := ir.Libpod.Import
+	if opts.IncludeVolumes {
+		importFunc = func(ctx context.Context, source, reference, signaturePolicyPath string, changes []string, history string, quiet bool) (string, error) {
+			return ir.Libpod.ImportWithVolumes(ctx, source, reference, signaturePolicyPath, changes, history, quiet, opts.VolumeOptions)
+		}
+	}
+	id, err := importFunc(ctx, opts.Source, opts.Reference, opts.SignaturePolicy, opts.Changes, opts.Message, opts.Quiet)
 	if err != nil {
 		return nil, err
 	}
@@ -484,6 +543,21 @@ func (ir *ImageEngine) Import(ctx context.Context, opts entities.ImageImportOpti
 }
 
 func (ir *ImageEngine) Save(ctx context.Context, nameOrID string, tags []string, options entities.ImageSaveOptions) error {
+	if options.DeltaBase != nil {
+		knownLayers := make([]digest.Digest, 0, len(options.DeltaBase))
+		for _, d := range options.DeltaBase {
+			parsed, err := digest.Parse(d)
+			if err != nil {
+				return errors.Wrapf(err, "invalid layer digest %q", d)
+			}
+			knownLayers = append(knownLayers, parsed)
+		}
+		newImage, err := ir.Libpod.ImageRuntime().NewFromLocal(nameOrID)
+		if err != nil {
+			return err
+		}
+		return newImage.SaveDelta(ctx, nameOrID, options.Output, knownLayers, options.Quiet)
+	}
 	if options.MultiImageArchive {
 		nameOrIDs := append([]string{nameOrID}, tags...)
 		return ir.Libpod.ImageRuntime().SaveImages(ctx, nameOrIDs, options.Format, options.Output, options.Quiet, true)
@@ -511,11 +585,13 @@ func (ir *ImageEngine) Search(ctx context.Context, term string, opts entities.Im
 
 	searchOpts := image.SearchOptions{
 		Authfile:              opts.Authfile,
+		CertDirPath:           opts.CertDir,
 		Filter:                *filter,
 		Limit:                 opts.Limit,
 		NoTrunc:               opts.NoTrunc,
 		InsecureSkipTLSVerify: opts.SkipTLSVerify,
 		ListTags:              opts.ListTags,
+		TagDigests:            opts.TagDigests,
 	}
 
 	searchResults, err := image.SearchImages(term, searchOpts)
@@ -535,6 +611,7 @@ func (ir *ImageEngine) Search(ctx context.Context, term string, opts entities.Im
 		reports[i].Official = searchResults[i].Official
 		reports[i].Automated = searchResults[i].Automated
 		reports[i].Tag = searchResults[i].Tag
+		reports[i].Digest = searchResults[i].Digest
 	}
 
 	return reports, nil
@@ -555,11 +632,21 @@ func (ir *ImageEngine) Build(ctx context.Context, containerFiles []string, opts
 }
 
 func (ir *ImageEngine) Tree(ctx context.Context, nameOrID string, opts entities.ImageTreeOptions) (*entities.ImageTreeReport, error) {
+	if nameOrID == "" {
+		if !opts.Shared || opts.WhatRequires {
+			return nil, errors.Wrap(define.ErrInvalidArg, "an image must be specified unless the shared layers of all images are shown")
+		}
+		results, err := ir.Libpod.ImageRuntime().GenerateSharedTree()
+		if err != nil {
+			return nil, err
+		}
+		return &entities.ImageTreeReport{Tree: results}, nil
+	}
 	img, err := ir.Libpod.ImageRuntime().NewFromLocal(nameOrID)
 	if err != nil {
 		return nil, err
 	}
-	results, err := img.GenerateTree(opts.WhatRequires)
+	results, err := img.GenerateTree(opts.WhatRequires, opts.Shared)
 	if err != nil {
 		return nil, err
 	}
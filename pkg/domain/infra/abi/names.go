@@ -0,0 +1,45 @@
+package abi
+
+import (
+	"context"
+
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+)
+
+func (ic *ContainerEngine) NameReserve(ctx context.Context, options entities.NameReserveOptions) (*entities.NameReservationReport, error) {
+	reservation, err := ic.Libpod.ReserveName(options.Name, options.TTL)
+	if err != nil {
+		return nil, err
+	}
+	return nameReservationReport(reservation), nil
+}
+
+func (ic *ContainerEngine) NameRelease(ctx context.Context, names []string) ([]*entities.NameReleaseReport, error) {
+	reports := make([]*entities.NameReleaseReport, 0, len(names))
+	for _, name := range names {
+		reports = append(reports, &entities.NameReleaseReport{Name: name, Err: ic.Libpod.ReleaseName(name)})
+	}
+	return reports, nil
+}
+
+func (ic *ContainerEngine) NameList(ctx context.Context) ([]*entities.NameReservationReport, error) {
+	reservations, err := ic.Libpod.ReservedNames()
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*entities.NameReservationReport, 0, len(reservations))
+	for i := range reservations {
+		reports = append(reports, nameReservationReport(&reservations[i]))
+	}
+	return reports, nil
+}
+
+func nameReservationReport(reservation *define.NameReservation) *entities.NameReservationReport {
+	return &entities.NameReservationReport{
+		Name:    reservation.Name,
+		Owner:   reservation.Owner,
+		Created: reservation.Created,
+		Expires: reservation.Expires,
+	}
+}
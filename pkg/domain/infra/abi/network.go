@@ -2,7 +2,10 @@ package abi
 
 import (
 	"context"
+	"fmt"
+	"sort"
 
+	"github.com/containers/podman/v2/libpod"
 	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/libpod/network"
 	"github.com/containers/podman/v2/pkg/domain/entities"
@@ -43,6 +46,10 @@ func (ic *ContainerEngine) NetworkInspect(ctx context.Context, namesOrIds []stri
 	if err != nil {
 		return nil, nil, err
 	}
+	ctrs, err := ic.Libpod.GetAllContainers()
+	if err != nil {
+		return nil, nil, err
+	}
 	var errs []error
 	rawCNINetworks := make([]entities.NetworkInspectReport, 0, len(namesOrIds))
 	for _, name := range namesOrIds {
@@ -55,11 +62,65 @@ func (ic *ContainerEngine) NetworkInspect(ctx context.Context, namesOrIds []stri
 				return nil, nil, errors.Wrapf(err, "error inspecting network %s", name)
 			}
 		}
+		netName, _ := rawList["name"].(string)
+		attached, err := networkContainers(netName, ctrs)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "error listing containers of network %s", name)
+		}
+		rawList["containers"] = attached
 		rawCNINetworks = append(rawCNINetworks, rawList)
 	}
 	return rawCNINetworks, errs, nil
 }
 
+// networkContainers returns the containers attached to the given network,
+// with their addresses and aliases in it, keyed by container ID.
+func networkContainers(netName string, ctrs []*libpod.Container) (map[string]entities.NetworkContainerInfo, error) {
+	attached := make(map[string]entities.NetworkContainerInfo)
+	for _, ctr := range ctrs {
+		// Only containers with their own network namespace join CNI
+		// networks, the others share the namespace of a container
+		// listed on its own.
+		if !ctr.Config().CreateNetNS || ctr.Config().NetMode.IsSlirp4netns() {
+			continue
+		}
+		networks, isDefault, err := ctr.Networks()
+		if err != nil {
+			if errors.Cause(err) == define.ErrNoSuchCtr || errors.Cause(err) == define.ErrCtrRemoved {
+				continue
+			}
+			return nil, err
+		}
+		if !util.StringInSlice(netName, networks) {
+			continue
+		}
+		data, err := ctr.Inspect(false)
+		if err != nil {
+			if errors.Cause(err) == define.ErrNoSuchCtr || errors.Cause(err) == define.ErrCtrRemoved {
+				continue
+			}
+			return nil, err
+		}
+		info := entities.NetworkContainerInfo{Name: ctr.Name()}
+		var basic define.InspectBasicNetworkConfig
+		if isDefault {
+			basic = data.NetworkSettings.InspectBasicNetworkConfig
+		} else if netData, ok := data.NetworkSettings.Networks[netName]; ok {
+			basic = netData.InspectBasicNetworkConfig
+			info.Aliases = netData.Aliases
+		}
+		if basic.IPAddress != "" {
+			info.IPv4Address = fmt.Sprintf("%s/%d", basic.IPAddress, basic.IPPrefixLen)
+		}
+		if basic.GlobalIPv6Address != "" {
+			info.IPv6Address = fmt.Sprintf("%s/%d", basic.GlobalIPv6Address, basic.GlobalIPv6PrefixLen)
+		}
+		info.MacAddress = basic.MacAddress
+		attached[ctr.ID()] = info
+	}
+	return attached, nil
+}
+
 func (ic *ContainerEngine) NetworkReload(ctx context.Context, names []string, options entities.NetworkReloadOptions) ([]*entities.NetworkReloadReport, error) {
 	ctrs, err := getContainersByContext(options.All, options.Latest, names, ic.Libpod)
 	if err != nil {
@@ -124,12 +185,78 @@ func (ic *ContainerEngine) NetworkRm(ctx context.Context, namesOrIds []string, o
 	return reports, nil
 }
 
+// NetworkPrune removes all networks without containers that pass the
+// filters.  The default network is never removed.
+func (ic *ContainerEngine) NetworkPrune(ctx context.Context, options entities.NetworkPruneOptions) ([]*entities.NetworkPruneReport, error) {
+	if options.Leaked {
+		return ic.networkPruneLeaked()
+	}
+	config, err := ic.Libpod.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	networks, err := network.LoadCNIConfsFromDir(network.GetCNIConfDir(config))
+	if err != nil {
+		return nil, err
+	}
+	containers, err := ic.Libpod.GetAllContainers()
+	if err != nil {
+		return nil, err
+	}
+	// Networks in use by at least one container, running or not
+	used := map[string]bool{config.Network.DefaultNetwork: true}
+	for _, c := range containers {
+		for _, name := range c.Config().Networks {
+			used[name] = true
+		}
+	}
+
+	reports := []*entities.NetworkPruneReport{}
+	for _, n := range networks {
+		if used[n.Name] {
+			continue
+		}
+		ok, err := network.IfPassesPruneFilter(config, n, options.Filters)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		reports = append(reports, &entities.NetworkPruneReport{
+			Name:  n.Name,
+			Error: network.RemoveNetwork(config, n.Name),
+		})
+	}
+	return reports, nil
+}
+
+// networkPruneLeaked releases the network resources of containers that were
+// removed without cleaning them up
+func (ic *ContainerEngine) networkPruneLeaked() ([]*entities.NetworkPruneReport, error) {
+	released, err := ic.Libpod.PruneLeakedNetworks()
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*entities.NetworkPruneReport, 0, len(released))
+	for desc, err := range released {
+		reports = append(reports, &entities.NetworkPruneReport{
+			Name:  desc,
+			Error: err,
+		})
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Name < reports[j].Name
+	})
+	return reports, nil
+}
+
 func (ic *ContainerEngine) NetworkCreate(ctx context.Context, name string, options entities.NetworkCreateOptions) (*entities.NetworkCreateReport, error) {
 	runtimeConfig, err := ic.Libpod.GetConfig()
 	if err != nil {
 		return nil, err
 	}
-	return network.Create(name, options, runtimeConfig)
+	return network.Create(name, options, runtimeConfig, ic.Libpod.ExtraConfig().StateRoot)
 }
 
 // NetworkDisconnect removes a container from a given network
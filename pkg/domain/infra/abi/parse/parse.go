@@ -6,20 +6,24 @@ import (
 
 	"github.com/containers/podman/v2/libpod"
 	"github.com/containers/podman/v2/libpod/define"
+	"github.com/docker/go-units"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
 // Handle volume options from CLI.
-// Parse "o" option to find UID, GID.
+// Parse "o" option to find UID, GID and size.
+// Unless a filesystem is mounted on the volume, these are implemented by
+// Podman and removed from the mount options.
 func VolumeOptions(opts map[string]string) ([]libpod.VolumeCreateOption, error) {
 	libpodOptions := []libpod.VolumeCreateOption{}
 	volumeOptions := make(map[string]string)
+	mountsFilesystem := opts["type"] != "" || opts["device"] != ""
 
 	for key, value := range opts {
 		switch key {
 		case "o":
-			// o has special handling to parse out UID, GID.
+			// o has special handling to parse out UID, GID and size.
 			// These are separate Libpod options.
 			splitVal := strings.Split(value, ",")
 			finalVal := []string{}
@@ -38,7 +42,9 @@ func VolumeOptions(opts map[string]string) ([]libpod.VolumeCreateOption, error)
 					}
 					logrus.Debugf("Removing uid= from options and adding WithVolumeUID for UID %d", intUID)
 					libpodOptions = append(libpodOptions, libpod.WithVolumeUID(intUID))
-					finalVal = append(finalVal, o)
+					if mountsFilesystem {
+						finalVal = append(finalVal, o)
+					}
 					// set option "UID": "$uid"
 					volumeOptions["UID"] = splitO[1]
 				case "gid":
@@ -51,16 +57,51 @@ func VolumeOptions(opts map[string]string) ([]libpod.VolumeCreateOption, error)
 					}
 					logrus.Debugf("Removing gid= from options and adding WithVolumeGID for GID %d", intGID)
 					libpodOptions = append(libpodOptions, libpod.WithVolumeGID(intGID))
-					finalVal = append(finalVal, o)
+					if mountsFilesystem {
+						finalVal = append(finalVal, o)
+					}
 					// set option "GID": "$gid"
 					volumeOptions["GID"] = splitO[1]
+				case "size":
+					// The size of mounted filesystems like tmpfs
+					// is limited by their mount options.
+					if mountsFilesystem {
+						finalVal = append(finalVal, o)
+						break
+					}
+					if len(splitO) != 2 {
+						return nil, errors.Wrapf(define.ErrInvalidArg, "size option must provide a size")
+					}
+					size, err := parseSize(splitO[1])
+					if err != nil {
+						return nil, err
+					}
+					logrus.Debugf("Removing size= from options and adding WithVolumeSize for size %d", size)
+					libpodOptions = append(libpodOptions, libpod.WithVolumeSize(size))
+					// set option "SIZE": "$size"
+					volumeOptions["SIZE"] = splitO[1]
 				default:
+					if !mountsFilesystem {
+						return nil, errors.Wrapf(define.ErrInvalidArg, "mount option %s requires the type or device option", o)
+					}
 					finalVal = append(finalVal, o)
 				}
 			}
 			if len(finalVal) > 0 {
 				volumeOptions[key] = strings.Join(finalVal, ",")
 			}
+		case "size":
+			// size is a shorthand for the size option of o.
+			if mountsFilesystem {
+				return nil, errors.Wrapf(define.ErrInvalidArg, "size option cannot be used with the type or device options, set the size of the mounted filesystem with the o option")
+			}
+			size, err := parseSize(value)
+			if err != nil {
+				return nil, err
+			}
+			logrus.Debugf("Adding WithVolumeSize for size %d", size)
+			libpodOptions = append(libpodOptions, libpod.WithVolumeSize(size))
+			volumeOptions["SIZE"] = value
 		default:
 			volumeOptions[key] = value
 		}
@@ -72,3 +113,15 @@ func VolumeOptions(opts map[string]string) ([]libpod.VolumeCreateOption, error)
 
 	return libpodOptions, nil
 }
+
+// parseSize parses the size quota of a volume.
+func parseSize(value string) (uint64, error) {
+	size, err := units.RAMInBytes(value)
+	if err != nil {
+		return 0, errors.Wrapf(err, "cannot convert size %s to bytes", value)
+	}
+	if size <= 0 {
+		return 0, errors.Wrapf(define.ErrInvalidArg, "size %s must be greater than 0", value)
+	}
+	return uint64(size), nil
+}
@@ -1,15 +1,19 @@
 package abi
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/containers/image/v5/types"
 	"github.com/containers/podman/v2/libpod"
+	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/libpod/image"
 	"github.com/containers/podman/v2/pkg/domain/entities"
 	"github.com/containers/podman/v2/pkg/specgen/generate"
@@ -21,11 +25,20 @@ import (
 	"github.com/sirupsen/logrus"
 	v1apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
 )
 
-func (ic *ContainerEngine) PlayKube(ctx context.Context, path string, options entities.PlayKubeOptions) (*entities.PlayKubeReport, error) {
+func (ic *ContainerEngine) PlayKube(ctx context.Context, path string, options entities.PlayKubeOptions) (_ *entities.PlayKubeReport, retErr error) {
 	var (
-		kubeObject v1.ObjectReference
+		kubeObject  v1.ObjectReference
+		pods        []v1.Pod
+		deployments []v1apps.Deployment
+		daemonSets  []v1apps.DaemonSet
+		claims      []v1.PersistentVolumeClaim
+		services    []v1.Service
+		report      entities.PlayKubeReport
 	)
 
 	content, err := ioutil.ReadFile(path)
@@ -33,36 +46,178 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, path string, options en
 		return nil, err
 	}
 
-	if err := yaml.Unmarshal(content, &kubeObject); err != nil {
+	// Group the events of all the objects created.
+	ctx, _ = ic.Libpod.StartEventCorrelation(ctx)
+
+	documents, err := splitMultiDocYAML(content)
+	if err != nil {
 		return nil, errors.Wrapf(err, "unable to read %q as YAML", path)
 	}
 
-	// NOTE: pkg/bindings/play is also parsing the file.
-	// A pkg/kube would be nice to refactor and abstract
-	// parts of the K8s-related code.
-	switch kubeObject.Kind {
-	case "Pod":
-		var podYAML v1.Pod
-		var podTemplateSpec v1.PodTemplateSpec
-		if err := yaml.Unmarshal(content, &podYAML); err != nil {
-			return nil, errors.Wrapf(err, "unable to read YAML %q as Kube Pod", path)
-		}
-		podTemplateSpec.ObjectMeta = podYAML.ObjectMeta
-		podTemplateSpec.Spec = podYAML.Spec
-		return ic.playKubePod(ctx, podTemplateSpec.ObjectMeta.Name, &podTemplateSpec, options)
-	case "Deployment":
-		var deploymentYAML v1apps.Deployment
-		if err := yaml.Unmarshal(content, &deploymentYAML); err != nil {
-			return nil, errors.Wrapf(err, "unable to read YAML %q as Kube Deployment", path)
-		}
-		return ic.playKubeDeployment(ctx, &deploymentYAML, options)
-	default:
-		return nil, errors.Errorf("invalid YAML kind: %q. [Pod|Deployment] are the only supported Kubernetes Kinds", kubeObject.Kind)
+	// The YAML may hold the ConfigMaps and the Secrets used by the pods,
+	// in addition to the ones of --configmap.
+	configMaps := []v1.ConfigMap{}
+	secrets := []v1.Secret{}
+	for _, document := range documents {
+		if err := yaml.Unmarshal(document, &kubeObject); err != nil {
+			return nil, errors.Wrapf(err, "unable to read %q as YAML", path)
+		}
+
+		// NOTE: pkg/bindings/play is also parsing the file.
+		// A pkg/kube would be nice to refactor and abstract
+		// parts of the K8s-related code.
+		switch kubeObject.Kind {
+		case "Pod":
+			var pod v1.Pod
+			if err := yaml.Unmarshal(document, &pod); err != nil {
+				return nil, errors.Wrapf(err, "unable to read YAML %q as Kube Pod", path)
+			}
+			if err := kube.SetSeccompProfileAnnotations(&pod.ObjectMeta, document); err != nil {
+				return nil, errors.Wrapf(err, "unable to read the seccomp profiles of %q", path)
+			}
+			pods = append(pods, pod)
+		case "Deployment":
+			var deployment v1apps.Deployment
+			if err := yaml.Unmarshal(document, &deployment); err != nil {
+				return nil, errors.Wrapf(err, "unable to read YAML %q as Kube Deployment", path)
+			}
+			if err := kube.SetSeccompProfileAnnotations(&deployment.Spec.Template.ObjectMeta, document); err != nil {
+				return nil, errors.Wrapf(err, "unable to read the seccomp profiles of %q", path)
+			}
+			deployments = append(deployments, deployment)
+		case "DaemonSet":
+			var daemonSet v1apps.DaemonSet
+			if err := yaml.Unmarshal(document, &daemonSet); err != nil {
+				return nil, errors.Wrapf(err, "unable to read YAML %q as Kube DaemonSet", path)
+			}
+			if err := kube.SetSeccompProfileAnnotations(&daemonSet.Spec.Template.ObjectMeta, document); err != nil {
+				return nil, errors.Wrapf(err, "unable to read the seccomp profiles of %q", path)
+			}
+			daemonSets = append(daemonSets, daemonSet)
+		case "PersistentVolumeClaim":
+			var claim v1.PersistentVolumeClaim
+			if err := yaml.Unmarshal(document, &claim); err != nil {
+				return nil, errors.Wrapf(err, "unable to read YAML %q as Kube PersistentVolumeClaim", path)
+			}
+			claims = append(claims, claim)
+		case "Service":
+			var service v1.Service
+			if err := yaml.Unmarshal(document, &service); err != nil {
+				return nil, errors.Wrapf(err, "unable to read YAML %q as Kube Service", path)
+			}
+			services = append(services, service)
+		case "ConfigMap":
+			var cm v1.ConfigMap
+			if err := yaml.Unmarshal(document, &cm); err != nil {
+				return nil, errors.Wrapf(err, "unable to read YAML %q as Kube ConfigMap", path)
+			}
+			configMaps = append(configMaps, cm)
+		case "Secret":
+			var secret v1.Secret
+			if err := yaml.Unmarshal(document, &secret); err != nil {
+				return nil, errors.Wrapf(err, "unable to read YAML %q as Kube Secret", path)
+			}
+			secrets = append(secrets, secret)
+		default:
+			return nil, errors.Errorf("invalid YAML kind: %q. [Pod|Deployment|DaemonSet|PersistentVolumeClaim|Service|ConfigMap|Secret] are the only supported Kubernetes Kinds", kubeObject.Kind)
+		}
+	}
+	if len(pods)+len(deployments)+len(daemonSets)+len(claims) == 0 {
+		return nil, errors.Errorf("%q does not hold a Pod, a Deployment, a DaemonSet or a PersistentVolumeClaim", path)
 	}
 
+	for _, p := range options.ConfigMaps {
+		f, err := os.Open(p)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		cms, s, err := readConfigMapsAndSecretsFromFile(f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%q", p)
+		}
+
+		configMaps = append(configMaps, cms...)
+		secrets = append(secrets, s...)
+	}
+
+	// The pods and volumes already created are removed again if a later
+	// workload fails, so a YAML file is played completely or not at all.
+	defer func() {
+		if retErr != nil {
+			ic.playKubeTeardown(ctx, &report)
+		}
+	}()
+
+	// The volumes of the PersistentVolumeClaims are created before the
+	// pods mounting them, whatever the order of the documents.
+	for i := range claims {
+		created, err := ic.playKubePVC(ctx, &claims[i])
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			report.Volumes = append(report.Volumes, claims[i].Name)
+		}
+	}
+
+	svcs := newKubeServices(services)
+	for i := range pods {
+		podTemplateSpec := v1.PodTemplateSpec{
+			ObjectMeta: pods[i].ObjectMeta,
+			Spec:       pods[i].Spec,
+		}
+		podReport, err := ic.playKubePod(ctx, podTemplateSpec.ObjectMeta.Name, svcs.publish(&podTemplateSpec), configMaps, secrets, options)
+		if err != nil {
+			return nil, err
+		}
+		report.Pods = append(report.Pods, podReport.Pods...)
+	}
+	for i := range deployments {
+		deploymentReport, err := ic.playKubeDeployment(ctx, &deployments[i], svcs, configMaps, secrets, options)
+		report.Pods = append(report.Pods, deploymentReport.Pods...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i := range daemonSets {
+		daemonSetReport, err := ic.playKubeDaemonSet(ctx, &daemonSets[i], svcs, configMaps, secrets, options)
+		if err != nil {
+			return nil, err
+		}
+		report.Pods = append(report.Pods, daemonSetReport.Pods...)
+	}
+	return &report, nil
+}
+
+// playKubeTeardown removes the pods and the volumes of report, which were
+// created by a play kube that failed.
+func (ic *ContainerEngine) playKubeTeardown(ctx context.Context, report *entities.PlayKubeReport) {
+	for _, podReport := range report.Pods {
+		pod, err := ic.Libpod.LookupPod(podReport.ID)
+		if err == nil {
+			err = ic.Libpod.RemovePod(ctx, pod, true, true)
+		}
+		if err != nil {
+			logrus.Errorf("Error removing pod %s after play kube failed: %v", podReport.ID, err)
+		}
+	}
+	for _, name := range report.Volumes {
+		vol, err := ic.Libpod.LookupVolume(name)
+		if err == nil {
+			err = ic.Libpod.RemoveVolume(ctx, vol, true)
+		}
+		if err != nil {
+			logrus.Errorf("Error removing volume %s after play kube failed: %v", name, err)
+		}
+	}
 }
 
-func (ic *ContainerEngine) playKubeDeployment(ctx context.Context, deploymentYAML *v1apps.Deployment, options entities.PlayKubeOptions) (*entities.PlayKubeReport, error) {
+// playKubeDeployment creates the pods of the replicas of a Deployment.  If a
+// pod cannot be created, the report of the pods created so far is returned
+// along with the error.
+func (ic *ContainerEngine) playKubeDeployment(ctx context.Context, deploymentYAML *v1apps.Deployment, svcs *kubeServices, configMaps []v1.ConfigMap, secrets []v1.Secret, options entities.PlayKubeOptions) (*entities.PlayKubeReport, error) {
 	var (
 		deploymentName string
 		podSpec        v1.PodTemplateSpec
@@ -73,7 +228,7 @@ func (ic *ContainerEngine) playKubeDeployment(ctx context.Context, deploymentYAM
 
 	deploymentName = deploymentYAML.ObjectMeta.Name
 	if deploymentName == "" {
-		return nil, errors.Errorf("Deployment does not have a name")
+		return &report, errors.Errorf("Deployment does not have a name")
 	}
 	numReplicas = 1
 	if deploymentYAML.Spec.Replicas != nil {
@@ -84,16 +239,148 @@ func (ic *ContainerEngine) playKubeDeployment(ctx context.Context, deploymentYAM
 	// create "replicas" number of pods
 	for i = 0; i < numReplicas; i++ {
 		podName := fmt.Sprintf("%s-pod-%d", deploymentName, i)
-		podReport, err := ic.playKubePod(ctx, podName, &podSpec, options)
+		podReport, err := ic.playKubePod(ctx, podName, svcs.publish(&podSpec), configMaps, secrets, options)
 		if err != nil {
-			return nil, errors.Wrapf(err, "error encountered while bringing up pod %s", podName)
+			return &report, errors.Wrapf(err, "error encountered while bringing up pod %s", podName)
 		}
 		report.Pods = append(report.Pods, podReport.Pods...)
 	}
 	return &report, nil
 }
 
-func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podYAML *v1.PodTemplateSpec, options entities.PlayKubeOptions) (*entities.PlayKubeReport, error) {
+// playKubeDaemonSet creates the pod of a DaemonSet.  Podman runs on a single
+// node, so a DaemonSet has a single pod.
+func (ic *ContainerEngine) playKubeDaemonSet(ctx context.Context, daemonSetYAML *v1apps.DaemonSet, svcs *kubeServices, configMaps []v1.ConfigMap, secrets []v1.Secret, options entities.PlayKubeOptions) (*entities.PlayKubeReport, error) {
+	daemonSetName := daemonSetYAML.ObjectMeta.Name
+	if daemonSetName == "" {
+		return nil, errors.Errorf("DaemonSet does not have a name")
+	}
+	podName := fmt.Sprintf("%s-pod", daemonSetName)
+	report, err := ic.playKubePod(ctx, podName, svcs.publish(&daemonSetYAML.Spec.Template), configMaps, secrets, options)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error encountered while bringing up pod %s", podName)
+	}
+	return report, nil
+}
+
+// playKubePVC creates the named volume of a PersistentVolumeClaim, labeled with
+// the labels of the claim and configured by its annotations.  An existing
+// volume with the name of the claim is used as is.  It returns true if the
+// volume was created.
+func (ic *ContainerEngine) playKubePVC(ctx context.Context, claim *v1.PersistentVolumeClaim) (bool, error) {
+	if claim.Name == "" {
+		return false, errors.Errorf("PersistentVolumeClaim does not have a name")
+	}
+	exists, err := ic.Libpod.HasVolume(claim.Name)
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		return false, nil
+	}
+	volOptions, err := kube.VolumeOptionsFromPVC(claim)
+	if err != nil {
+		return false, err
+	}
+	opts := []libpod.VolumeCreateOption{libpod.WithVolumeName(claim.Name)}
+	if len(claim.Labels) > 0 {
+		opts = append(opts, libpod.WithVolumeLabels(claim.Labels))
+	}
+	if volOptions.Driver != "" {
+		opts = append(opts, libpod.WithVolumeDriver(volOptions.Driver))
+	}
+	if len(volOptions.Options) > 0 {
+		opts = append(opts, libpod.WithVolumeOptions(volOptions.Options))
+	}
+	if volOptions.UID != nil {
+		opts = append(opts, libpod.WithVolumeUID(*volOptions.UID))
+	}
+	if volOptions.GID != nil {
+		opts = append(opts, libpod.WithVolumeGID(*volOptions.GID))
+	}
+	if _, err := ic.Libpod.NewVolume(ctx, opts...); err != nil {
+		return false, errors.Wrapf(err, "error creating volume for PersistentVolumeClaim %s", claim.Name)
+	}
+	return true, nil
+}
+
+// kubeServices publishes the node ports of Services on the pods they select.
+// A port of the host can only be published once, so a node port is published
+// by the first pod selected.
+type kubeServices struct {
+	services  []v1.Service
+	published map[int32]bool
+}
+
+func newKubeServices(services []v1.Service) *kubeServices {
+	return &kubeServices{services: services, published: make(map[int32]bool)}
+}
+
+// publish returns a copy of the pod template with the host ports of the node
+// ports not published yet of the Services selecting the pod.
+func (s *kubeServices) publish(template *v1.PodTemplateSpec) *v1.PodTemplateSpec {
+	template = template.DeepCopy()
+	for _, service := range s.services {
+		if len(service.Spec.Selector) == 0 || !labels.SelectorFromSet(service.Spec.Selector).Matches(labels.Set(template.Labels)) {
+			continue
+		}
+		for _, port := range service.Spec.Ports {
+			if port.NodePort == 0 || s.published[port.NodePort] {
+				continue
+			}
+			if publishServicePort(&template.Spec, port) {
+				s.published[port.NodePort] = true
+			}
+		}
+	}
+	return template
+}
+
+// publishServicePort publishes the node port of a Service port on the target
+// port of the pod.  A numeric target port not declared by the containers is
+// added to the first container.
+func publishServicePort(spec *v1.PodSpec, port v1.ServicePort) bool {
+	protocol := port.Protocol
+	if protocol == "" {
+		protocol = v1.ProtocolTCP
+	}
+	targetPort := port.Port
+	if port.TargetPort.Type == intstr.Int && port.TargetPort.IntVal != 0 {
+		targetPort = port.TargetPort.IntVal
+	}
+	for i := range spec.Containers {
+		for j := range spec.Containers[i].Ports {
+			ctrPort := &spec.Containers[i].Ports[j]
+			ctrProtocol := ctrPort.Protocol
+			if ctrProtocol == "" {
+				ctrProtocol = v1.ProtocolTCP
+			}
+			if ctrProtocol != protocol || ctrPort.HostPort != 0 {
+				continue
+			}
+			if port.TargetPort.Type == intstr.String {
+				if ctrPort.Name != port.TargetPort.StrVal {
+					continue
+				}
+			} else if ctrPort.ContainerPort != targetPort {
+				continue
+			}
+			ctrPort.HostPort = port.NodePort
+			return true
+		}
+	}
+	if port.TargetPort.Type == intstr.String || len(spec.Containers) == 0 {
+		return false
+	}
+	spec.Containers[0].Ports = append(spec.Containers[0].Ports, v1.ContainerPort{
+		ContainerPort: targetPort,
+		HostPort:      port.NodePort,
+		Protocol:      protocol,
+	})
+	return true
+}
+
+func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podYAML *v1.PodTemplateSpec, configMaps []v1.ConfigMap, secrets []v1.Secret, options entities.PlayKubeOptions) (_ *entities.PlayKubeReport, retErr error) {
 	var (
 		registryCreds *types.DockerAuthConfig
 		writer        io.Writer
@@ -105,7 +392,7 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 	if podName == "" {
 		return nil, errors.Errorf("pod does not have a name")
 	}
-	for _, n := range podYAML.Spec.Containers {
+	for _, n := range append(podYAML.Spec.InitContainers, podYAML.Spec.Containers...) {
 		if n.Name == podName {
 			playKubePod.Logs = append(playKubePod.Logs,
 				fmt.Sprintf("a container exists with the same name (%q) as the pod in your YAML file; changing pod name to %s_pod\n", podName, podName))
@@ -137,6 +424,13 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 	if err != nil {
 		return nil, err
 	}
+	defer func() {
+		if retErr != nil {
+			if err := ic.Libpod.RemovePod(ctx, pod, true, true); err != nil {
+				logrus.Errorf("Error removing pod %s after creating it failed: %v", pod.ID(), err)
+			}
+		}
+	}()
 
 	podInfraID, err := pod.InfraContainerID()
 	if err != nil {
@@ -160,10 +454,17 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 		DockerInsecureSkipTLSVerify: options.SkipTLSVerify,
 	}
 
-	volumes, err := kube.InitializeVolumes(podYAML.Spec.Volumes)
+	volumes, err := kube.InitializeVolumes(podYAML.Spec.Volumes, configMaps, secrets)
 	if err != nil {
 		return nil, err
 	}
+	for _, volume := range volumes {
+		if volume.Type == kube.KubeVolumeTypeConfigMap || volume.Type == kube.KubeVolumeTypeSecret {
+			if err := ic.populateKubeDataVolume(ctx, volume); err != nil {
+				return nil, err
+			}
+		}
+	}
 
 	seccompPaths, err := kube.InitializeSeccompPaths(podYAML.ObjectMeta.Annotations, options.SeccompProfileRoot)
 	if err != nil {
@@ -182,24 +483,25 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 		ctrRestartPolicy = libpod.RestartPolicyAlways
 	}
 
-	configMaps := []v1.ConfigMap{}
-	for _, p := range options.ConfigMaps {
-		f, err := os.Open(p)
-		if err != nil {
-			return nil, err
-		}
-		defer f.Close()
-
-		cm, err := readConfigMapFromFile(f)
-		if err != nil {
-			return nil, errors.Wrapf(err, "%q", p)
-		}
+	// Init containers are created first, so they are run in the order of
+	// the YAML file when the pod is started.
+	allContainers := append(append([]v1.Container{}, podYAML.Spec.InitContainers...), podYAML.Spec.Containers...)
 
-		configMaps = append(configMaps, cm)
+	// Look up the images of all containers at once, which is much faster
+	// than resolving them one by one for pods with many containers.
+	imageNames := make([]string, 0, len(allContainers))
+	for _, container := range allContainers {
+		imageNames = append(imageNames, container.Image)
+	}
+	localImages, _, err := ic.Libpod.ImageRuntime().LookupImages(imageNames)
+	if err != nil {
+		return nil, err
 	}
 
+	initContainers := make([]*libpod.Container, 0, len(podYAML.Spec.InitContainers))
 	containers := make([]*libpod.Container, 0, len(podYAML.Spec.Containers))
-	for _, container := range podYAML.Spec.Containers {
+	for i, container := range allContainers {
+		isInitContainer := i < len(podYAML.Spec.InitContainers)
 		pullPolicy := util.PullImageMissing
 		if len(container.ImagePullPolicy) > 0 {
 			pullPolicy, err = util.ValidatePullType(string(container.ImagePullPolicy))
@@ -207,6 +509,11 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 				return nil, err
 			}
 		}
+		// Kubernetes resolves the image digest on the registry for
+		// the "Always" policy and only pulls if it changed.
+		if pullPolicy == util.PullImageAlways {
+			pullPolicy = util.PullImageNewer
+		}
 		named, err := reference.ParseNormalizedNamed(container.Image)
 		if err != nil {
 			return nil, errors.Wrapf(err, "Failed to parse image %q", container.Image)
@@ -216,38 +523,55 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 		// so do not attempt a pull.
 		if tagged, isTagged := named.(reference.NamedTagged); isTagged {
 			if tagged.Tag() == image.LatestTag && reference.Domain(named) != image.DefaultLocalRegistry {
-				pullPolicy = util.PullImageAlways
+				pullPolicy = util.PullImageNewer
 			}
 		}
 
 		// This ensures the image is the image store
-		newImage, err := ic.Libpod.ImageRuntime().New(ctx, container.Image, options.SignaturePolicy, options.Authfile, writer, &dockerRegistryOptions, image.SigningOptions{}, nil, pullPolicy)
-		if err != nil {
-			return nil, err
+		newImage := localImages[i]
+		if newImage == nil || (pullPolicy != util.PullImageMissing && pullPolicy != util.PullImageNever) {
+			newImage, err = ic.Libpod.ImageRuntime().New(ctx, container.Image, options.SignaturePolicy, options.Authfile, writer, &dockerRegistryOptions, image.SigningOptions{}, nil, pullPolicy)
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		specgenOpts := kube.CtrSpecGenOptions{
-			Container:     container,
-			Image:         newImage,
-			Volumes:       volumes,
-			PodID:         pod.ID(),
-			PodName:       podName,
-			PodInfraID:    podInfraID,
-			ConfigMaps:    configMaps,
-			SeccompPaths:  seccompPaths,
-			RestartPolicy: ctrRestartPolicy,
-			NetNSIsHost:   p.NetNS.IsHost(),
+			Container:          container,
+			Image:              newImage,
+			Volumes:            volumes,
+			PodID:              pod.ID(),
+			PodName:            podName,
+			PodInfraID:         podInfraID,
+			ConfigMaps:         configMaps,
+			Secrets:            secrets,
+			SeccompPaths:       seccompPaths,
+			RestartPolicy:      ctrRestartPolicy,
+			NetNSIsHost:        p.NetNS.IsHost(),
+			PodSecurityContext: podYAML.Spec.SecurityContext,
+		}
+		// Init containers run to completion every time the pod is
+		// started and must not be restarted.
+		if isInitContainer {
+			specgenOpts.RestartPolicy = libpod.RestartPolicyNo
 		}
 		specGen, err := kube.ToSpecGen(ctx, &specgenOpts)
 		if err != nil {
 			return nil, err
 		}
+		if isInitContainer {
+			specGen.InitContainerType = define.AlwaysInitContainer
+		}
 
 		ctr, err := generate.MakeContainer(ctx, ic.Libpod, specGen)
 		if err != nil {
 			return nil, err
 		}
-		containers = append(containers, ctr)
+		if isInitContainer {
+			initContainers = append(initContainers, ctr)
+		} else {
+			containers = append(containers, ctr)
+		}
 	}
 
 	if options.Start != types.OptionalBoolFalse {
@@ -273,28 +597,135 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 	for _, ctr := range containers {
 		playKubePod.Containers = append(playKubePod.Containers, ctr.ID())
 	}
+	for _, ctr := range initContainers {
+		playKubePod.InitContainers = append(playKubePod.InitContainers, ctr.ID())
+	}
 
 	report.Pods = append(report.Pods, playKubePod)
 
 	return &report, nil
 }
 
-// readConfigMapFromFile returns a kubernetes configMap obtained from --configmap flag
-func readConfigMapFromFile(r io.Reader) (v1.ConfigMap, error) {
-	var cm v1.ConfigMap
+// splitMultiDocYAML returns the documents of a YAML file separated by "---".
+func splitMultiDocYAML(content []byte) ([][]byte, error) {
+	var documents [][]byte
+	reader := k8syaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	for {
+		document, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		// Skip the empty documents, such as the one before a leading
+		// separator.
+		if len(bytes.TrimSpace(document)) == 0 {
+			continue
+		}
+		documents = append(documents, document)
+	}
+	return documents, nil
+}
+
+// readConfigMapsAndSecretsFromFile returns the kubernetes ConfigMaps and
+// Secrets obtained from --configmap flag.  The file may hold several of them
+// in YAML documents separated by "---".
+func readConfigMapsAndSecretsFromFile(r io.Reader) ([]v1.ConfigMap, []v1.Secret, error) {
+	var (
+		configMaps []v1.ConfigMap
+		secrets    []v1.Secret
+	)
 
 	content, err := ioutil.ReadAll(r)
 	if err != nil {
-		return cm, errors.Wrapf(err, "unable to read ConfigMap YAML content")
+		return nil, nil, errors.Wrapf(err, "unable to read ConfigMap YAML content")
 	}
 
-	if err := yaml.Unmarshal(content, &cm); err != nil {
-		return cm, errors.Wrapf(err, "unable to read YAML as Kube ConfigMap")
+	documents, err := splitMultiDocYAML(content)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "unable to read YAML as Kube ConfigMap")
+	}
+	for _, document := range documents {
+		var kubeObject v1.ObjectReference
+		if err := yaml.Unmarshal(document, &kubeObject); err != nil {
+			return nil, nil, errors.Wrapf(err, "unable to read YAML as Kube ConfigMap")
+		}
+		switch kubeObject.Kind {
+		case "ConfigMap":
+			var cm v1.ConfigMap
+			if err := yaml.Unmarshal(document, &cm); err != nil {
+				return nil, nil, errors.Wrapf(err, "unable to read YAML as Kube ConfigMap")
+			}
+			configMaps = append(configMaps, cm)
+		case "Secret":
+			var secret v1.Secret
+			if err := yaml.Unmarshal(document, &secret); err != nil {
+				return nil, nil, errors.Wrapf(err, "unable to read YAML as Kube Secret")
+			}
+			secrets = append(secrets, secret)
+		default:
+			return nil, nil, errors.Errorf("invalid YAML kind: %q. [ConfigMap|Secret] are the only supported by --configmap", kubeObject.Kind)
+		}
 	}
 
-	if cm.Kind != "ConfigMap" {
-		return cm, errors.Errorf("invalid YAML kind: %q. [ConfigMap] is the only supported by --configmap", cm.Kind)
+	return configMaps, secrets, nil
+}
+
+// populateKubeDataVolume creates the named volume of a ConfigMap or a Secret
+// volume if it does not exist, and replaces its files with the keys of the
+// ConfigMap or the Secret.  The volumes of Secrets are anonymous, so the
+// secret data does not outlive the pods using it.
+func (ic *ContainerEngine) populateKubeDataVolume(ctx context.Context, volume *kube.KubeVolume) error {
+	sourceLabel := volume.SourceLabel()
+	vol, err := ic.Libpod.GetVolume(volume.Source)
+	switch {
+	case errors.Cause(err) == define.ErrNoSuchVolume:
+		options := []libpod.VolumeCreateOption{
+			libpod.WithVolumeName(volume.Source),
+			libpod.WithVolumeLabels(map[string]string{kube.VolumeSourceLabel: sourceLabel}),
+		}
+		if volume.Type == kube.KubeVolumeTypeSecret {
+			options = append(options, libpod.WithVolumeAnonymous())
+		}
+		vol, err = ic.Libpod.NewVolume(ctx, options...)
+		if err != nil {
+			return errors.Wrapf(err, "error creating volume for %s", sourceLabel)
+		}
+	case err != nil:
+		return err
+	default:
+		// Do not overwrite the data of a volume created by the user.
+		if vol.Labels()[kube.VolumeSourceLabel] != sourceLabel {
+			return errors.Wrapf(define.ErrVolumeExists, "volume %q was not created for %s", volume.Source, sourceLabel)
+		}
 	}
 
-	return cm, nil
+	mountPoint := vol.MountPoint()
+	if mountPoint == "" {
+		return errors.Errorf("volume %q of %s has no mount point", volume.Source, sourceLabel)
+	}
+	entries, err := ioutil.ReadDir(mountPoint)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(mountPoint, entry.Name())); err != nil {
+			return err
+		}
+	}
+	for _, item := range volume.Items {
+		path := filepath.Join(mountPoint, item.Path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path, item.Data, item.Mode); err != nil {
+			return errors.Wrapf(err, "error writing %s of %s", item.Path, sourceLabel)
+		}
+		// Apply the mode regardless of the umask.
+		if err := os.Chmod(path, item.Mode); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -7,15 +7,17 @@ import (
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
 	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
-func TestReadConfigMapFromFile(t *testing.T) {
+func TestReadConfigMapsAndSecretsFromFile(t *testing.T) {
 	tests := []struct {
 		name             string
 		configMapContent string
 		expectError      bool
 		expectedErrorMsg string
-		expected         v1.ConfigMap
+		expected         []v1.ConfigMap
+		expectedSecrets  []v1.Secret
 	}{
 		{
 			"ValidConfigMap",
@@ -29,16 +31,73 @@ data:
 `,
 			false,
 			"",
-			v1.ConfigMap{
-				TypeMeta: v12.TypeMeta{
-					Kind:       "ConfigMap",
-					APIVersion: "v1",
+			[]v1.ConfigMap{
+				{
+					TypeMeta: v12.TypeMeta{
+						Kind:       "ConfigMap",
+						APIVersion: "v1",
+					},
+					ObjectMeta: v12.ObjectMeta{
+						Name: "foo",
+					},
+					Data: map[string]string{
+						"myvar": "foo",
+					},
 				},
-				ObjectMeta: v12.ObjectMeta{
-					Name: "foo",
+			},
+			nil,
+		},
+		{
+			"ConfigMapAndSecret",
+			`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+data:
+  myvar: foo
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: bar
+data:
+  password: c2VjcmV0
+stringData:
+  user: admin
+`,
+			false,
+			"",
+			[]v1.ConfigMap{
+				{
+					TypeMeta: v12.TypeMeta{
+						Kind:       "ConfigMap",
+						APIVersion: "v1",
+					},
+					ObjectMeta: v12.ObjectMeta{
+						Name: "foo",
+					},
+					Data: map[string]string{
+						"myvar": "foo",
+					},
 				},
-				Data: map[string]string{
-					"myvar": "foo",
+			},
+			[]v1.Secret{
+				{
+					TypeMeta: v12.TypeMeta{
+						Kind:       "Secret",
+						APIVersion: "v1",
+					},
+					ObjectMeta: v12.ObjectMeta{
+						Name: "bar",
+					},
+					// The values of data are base64-decoded.
+					Data: map[string][]byte{
+						"password": []byte("secret"),
+					},
+					StringData: map[string]string{
+						"user": "admin",
+					},
 				},
 			},
 		},
@@ -55,7 +114,8 @@ data:
 `,
 			true,
 			"unable to read YAML as Kube ConfigMap",
-			v1.ConfigMap{},
+			nil,
+			nil,
 		},
 		{
 			"InvalidKind",
@@ -69,7 +129,8 @@ data:
 `,
 			true,
 			"invalid YAML kind",
-			v1.ConfigMap{},
+			nil,
+			nil,
 		},
 	}
 
@@ -77,15 +138,60 @@ data:
 		test := test
 		t.Run(test.name, func(t *testing.T) {
 			buf := bytes.NewBufferString(test.configMapContent)
-			cm, err := readConfigMapFromFile(buf)
+			cms, secrets, err := readConfigMapsAndSecretsFromFile(buf)
 
 			if test.expectError {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), test.expectedErrorMsg)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, test.expected, cm)
+				assert.Equal(t, test.expected, cms)
+				assert.Equal(t, test.expectedSecrets, secrets)
 			}
 		})
 	}
 }
+
+func TestKubeServicesPublish(t *testing.T) {
+	svcs := newKubeServices([]v1.Service{
+		{
+			Spec: v1.ServiceSpec{
+				Selector: map[string]string{"app": "web"},
+				Ports: []v1.ServicePort{
+					{Port: 80, TargetPort: intstr.FromString("http"), NodePort: 30080},
+					{Port: 9090, NodePort: 30090},
+					{Port: 8443},
+				},
+			},
+		},
+	})
+	template := &v1.PodTemplateSpec{
+		ObjectMeta: v12.ObjectMeta{
+			Labels: map[string]string{"app": "web", "tier": "front"},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name:  "web",
+					Ports: []v1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+				},
+			},
+		},
+	}
+
+	published := svcs.publish(template)
+	assert.Equal(t, []v1.ContainerPort{
+		{Name: "http", ContainerPort: 8080, HostPort: 30080},
+		{ContainerPort: 9090, HostPort: 30090, Protocol: v1.ProtocolTCP},
+	}, published.Spec.Containers[0].Ports)
+	// The template is not modified.
+	assert.Equal(t, []v1.ContainerPort{{Name: "http", ContainerPort: 8080}}, template.Spec.Containers[0].Ports)
+
+	// The node ports are only published by the first pod.
+	assert.Equal(t, template.Spec, svcs.publish(template).Spec)
+
+	// Pods not selected by the Service are not modified.
+	other := template.DeepCopy()
+	other.Labels = map[string]string{"app": "db"}
+	assert.Equal(t, other.Spec, newKubeServices(svcs.services).publish(other).Spec)
+}
@@ -2,14 +2,18 @@ package abi
 
 import (
 	"context"
+	"strconv"
+	"strings"
 
 	"github.com/containers/podman/v2/libpod"
 	"github.com/containers/podman/v2/libpod/define"
 	lpfilters "github.com/containers/podman/v2/libpod/filters"
+	"github.com/containers/podman/v2/pkg/checkpoint"
 	"github.com/containers/podman/v2/pkg/domain/entities"
 	"github.com/containers/podman/v2/pkg/signal"
 	"github.com/containers/podman/v2/pkg/specgen"
 	"github.com/containers/podman/v2/pkg/specgen/generate"
+	"github.com/cri-o/ocicni/pkg/ocicni"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
@@ -19,7 +23,10 @@ import (
 func getPodsByContext(all, latest bool, pods []string, runtime *libpod.Runtime) ([]*libpod.Pod, error) {
 	var outpods []*libpod.Pod
 	if all {
-		return runtime.GetAllPods()
+		// Pods hidden by the owner mode are not included.
+		return runtime.Pods(func(p *libpod.Pod) bool {
+			return runtime.OwnerVisible(p.Owner())
+		})
 	}
 	if latest {
 		p, err := runtime.GetLatestPod()
@@ -66,19 +73,22 @@ func (ic *ContainerEngine) PodKill(ctx context.Context, namesOrIds []string, opt
 
 	for _, p := range pods {
 		report := entities.PodKillReport{Id: p.ID()}
-		conErrs, err := p.Kill(ctx, uint(sig))
-		if err != nil && errors.Cause(err) != define.ErrPodPartialFail {
+		ctrs, err := podContainersInState(p, define.ContainerStateRunning, options.ExcludeInfra)
+		if err != nil {
 			report.Errs = []error{err}
 			reports = append(reports, &report)
 			continue
 		}
-		if len(conErrs) > 0 {
-			for id, err := range conErrs {
-				report.Errs = append(report.Errs, errors.Wrapf(err, "error killing container %s", id))
-			}
+		conErrs, err := p.Kill(ctx, uint(sig), options.ExcludeInfra)
+		if err != nil && errors.Cause(err) != define.ErrPodPartialFail {
+			report.Errs = []error{err}
 			reports = append(reports, &report)
 			continue
 		}
+		report.Containers = succeededContainers(ctrs, conErrs)
+		for id, err := range conErrs {
+			report.Errs = append(report.Errs, errors.Wrapf(err, "error killing container %s", id))
+		}
 		reports = append(reports, &report)
 	}
 	return reports, nil
@@ -92,18 +102,140 @@ func (ic *ContainerEngine) PodPause(ctx context.Context, namesOrIds []string, op
 	}
 	for _, p := range pods {
 		report := entities.PodPauseReport{Id: p.ID()}
+		ctrs, err := podContainersInState(p, define.ContainerStateRunning, false)
+		if err != nil {
+			report.Errs = []error{err}
+			reports = append(reports, &report)
+			continue
+		}
 		errs, err := p.Pause(ctx)
 		if err != nil && errors.Cause(err) != define.ErrPodPartialFail {
 			report.Errs = []error{err}
+			reports = append(reports, &report)
 			continue
 		}
-		if len(errs) > 0 {
-			for id, v := range errs {
-				report.Errs = append(report.Errs, errors.Wrapf(v, "error pausing container %s", id))
-			}
+		report.Containers = succeededContainers(ctrs, errs)
+		for id, v := range errs {
+			report.Errs = append(report.Errs, errors.Wrapf(v, "error pausing container %s", id))
+		}
+		reports = append(reports, &report)
+	}
+	return reports, nil
+}
+
+// podContainersInState returns the IDs of the containers of the pod which are
+// in the given state, leaving out the infra container if excludeInfra is set.
+func podContainersInState(p *libpod.Pod, state define.ContainerStatus, excludeInfra bool) ([]string, error) {
+	statuses, err := p.Status()
+	if err != nil {
+		return nil, err
+	}
+	infraID := ""
+	if excludeInfra {
+		if infraID, err = p.InfraContainerID(); err != nil {
+			return nil, err
+		}
+	}
+	ids := make([]string, 0, len(statuses))
+	for id, status := range statuses {
+		if status == state && id != infraID {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// succeededContainers returns the IDs of the containers an operation on a pod
+// was applied to without error.
+func succeededContainers(ids []string, errs map[string]error) []string {
+	succeeded := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, failed := errs[id]; !failed {
+			succeeded = append(succeeded, id)
+		}
+	}
+	return succeeded
+}
+
+func (ic *ContainerEngine) PodCheckpoint(ctx context.Context, namesOrIds []string, options entities.PodCheckpointOptions) ([]*entities.PodCheckpointReport, error) {
+	reports := []*entities.PodCheckpointReport{}
+	pods, err := getPodsByContext(options.All, options.Latest, namesOrIds, ic.Libpod)
+	if err != nil {
+		return nil, err
+	}
+	if options.Export != "" && len(pods) != 1 {
+		return nil, errors.Errorf("exporting a checkpoint requires a single pod")
+	}
+	checkpointOptions := libpod.ContainerCheckpointOptions{
+		Keep:           options.Keep,
+		KeepRunning:    options.LeaveRunning,
+		TCPEstablished: options.TCPEstablished,
+		TargetFile:     options.Export,
+		IgnoreRootfs:   options.IgnoreRootFS,
+		IgnoreVolumes:  options.IgnoreVolumes,
+	}
+	for _, p := range pods {
+		report := entities.PodCheckpointReport{Id: p.ID()}
+		errs, err := p.Checkpoint(ctx, checkpointOptions)
+		// With --all, pods without running containers are skipped.
+		if options.All && errors.Cause(err) == define.ErrCtrStateInvalid {
+			continue
+		}
+		if err != nil && errors.Cause(err) != define.ErrPodPartialFail {
+			report.Errs = []error{err}
 			reports = append(reports, &report)
 			continue
 		}
+		for id, v := range errs {
+			report.Errs = append(report.Errs, errors.Wrapf(v, "error checkpointing container %s", id))
+		}
+		reports = append(reports, &report)
+	}
+	return reports, nil
+}
+
+func (ic *ContainerEngine) PodRestore(ctx context.Context, namesOrIds []string, options entities.PodRestoreOptions) ([]*entities.PodRestoreReport, error) {
+	var (
+		pods []*libpod.Pod
+		err  error
+	)
+	if options.Import != "" {
+		// The pod and its containers are re-created from the archive
+		// first, and then restored in place.
+		pod, err := checkpoint.CRImportPodCheckpoint(ctx, ic.Libpod, options.Import)
+		if err != nil {
+			return nil, err
+		}
+		pods = []*libpod.Pod{pod}
+	} else {
+		pods, err = getPodsByContext(options.All, options.Latest, namesOrIds, ic.Libpod)
+		if err != nil {
+			return nil, err
+		}
+	}
+	restoreOptions := libpod.ContainerCheckpointOptions{
+		Keep:           options.Keep,
+		TCPEstablished: options.TCPEstablished,
+		TargetFile:     options.Import,
+		IgnoreRootfs:   options.IgnoreRootFS,
+		IgnoreVolumes:  options.IgnoreVolumes,
+	}
+	reports := []*entities.PodRestoreReport{}
+	for _, p := range pods {
+		report := entities.PodRestoreReport{Id: p.ID()}
+		errs, err := p.Restore(ctx, restoreOptions)
+		// With --all, pods without checkpointed containers are skipped.
+		if options.All && errors.Cause(err) == define.ErrCtrStateInvalid {
+			continue
+		}
+		if err != nil && errors.Cause(err) != define.ErrPodPartialFail {
+			report.Errs = []error{err}
+			reports = append(reports, &report)
+			continue
+		}
+		for id, v := range errs {
+			report.Errs = append(report.Errs, errors.Wrapf(v, "error restoring container %s", id))
+		}
 		reports = append(reports, &report)
 	}
 	return reports, nil
@@ -117,18 +249,22 @@ func (ic *ContainerEngine) PodUnpause(ctx context.Context, namesOrIds []string,
 	}
 	for _, p := range pods {
 		report := entities.PodUnpauseReport{Id: p.ID()}
-		errs, err := p.Unpause(ctx)
-		if err != nil && errors.Cause(err) != define.ErrPodPartialFail {
+		ctrs, err := podContainersInState(p, define.ContainerStatePaused, false)
+		if err != nil {
 			report.Errs = []error{err}
+			reports = append(reports, &report)
 			continue
 		}
-		if len(errs) > 0 {
-			for id, v := range errs {
-				report.Errs = append(report.Errs, errors.Wrapf(v, "error unpausing container %s", id))
-			}
+		errs, err := p.Unpause(ctx)
+		if err != nil && errors.Cause(err) != define.ErrPodPartialFail {
+			report.Errs = []error{err}
 			reports = append(reports, &report)
 			continue
 		}
+		report.Containers = succeededContainers(ctrs, errs)
+		for id, v := range errs {
+			report.Errs = append(report.Errs, errors.Wrapf(v, "error unpausing container %s", id))
+		}
 		reports = append(reports, &report)
 	}
 	return reports, nil
@@ -258,6 +394,131 @@ func (ic *ContainerEngine) PodCreate(ctx context.Context, opts entities.PodCreat
 	return &entities.PodCreateReport{Id: pod.ID()}, nil
 }
 
+// PodUpdate changes the ports published by a pod.  The port forwarding of a
+// running pod is set up again with the new ports.
+func (ic *ContainerEngine) PodUpdate(ctx context.Context, nameOrID string, options entities.PodUpdateOptions) (*entities.PodUpdateReport, error) {
+	pod, err := ic.Libpod.LookupPod(nameOrID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to lookup pod %s", nameOrID)
+	}
+	if !pod.HasInfraContainer() {
+		return nil, errors.Wrapf(define.ErrInvalidArg, "pod %s has no infra container, its ports cannot be changed", pod.ID())
+	}
+	infra, err := pod.InfraContainer()
+	if err != nil {
+		return nil, err
+	}
+	current, err := infra.PortMappings()
+	if err != nil {
+		return nil, err
+	}
+
+	ports := make([]ocicni.PortMapping, 0, len(current))
+	removed := make(map[string]bool, len(options.RemovePorts))
+	for _, port := range current {
+		remove := false
+		for _, rm := range options.RemovePorts {
+			if portMatches(port, rm) {
+				removed[rm] = true
+				remove = true
+			}
+		}
+		if !remove {
+			ports = append(ports, port)
+		}
+	}
+	for _, rm := range options.RemovePorts {
+		if !removed[rm] {
+			return nil, errors.Wrapf(define.ErrInvalidArg, "pod %s does not publish host port %s", pod.ID(), rm)
+		}
+	}
+
+	added, err := generate.ParsePortMappings(ic.Libpod, options.PublishPorts)
+	if err != nil {
+		return nil, err
+	}
+	for _, add := range added {
+		for _, port := range ports {
+			if add.HostPort == port.HostPort && add.Protocol == port.Protocol && (add.HostIP == port.HostIP || add.HostIP == "" || port.HostIP == "") {
+				return nil, errors.Wrapf(define.ErrInvalidArg, "pod %s already publishes host port %d/%s", pod.ID(), port.HostPort, port.Protocol)
+			}
+		}
+		ports = append(ports, add)
+	}
+
+	if err := pod.SetPortMappings(ports); err != nil {
+		return nil, err
+	}
+	return &entities.PodUpdateReport{Id: pod.ID()}, nil
+}
+
+// portMatches returns whether the host port of the port mapping is the given
+// port, formatted as PORT or PORT/PROTOCOL.
+func portMatches(port ocicni.PortMapping, hostPort string) bool {
+	split := strings.SplitN(hostPort, "/", 2)
+	if split[0] != strconv.Itoa(int(port.HostPort)) {
+		return false
+	}
+	return len(split) == 1 || split[1] == port.Protocol
+}
+
+// PodClone creates a new pod from the configuration of an existing pod, and
+// clones the containers of the pod into it.
+func (ic *ContainerEngine) PodClone(ctx context.Context, nameOrID string, options entities.PodCloneOptions) (*entities.PodCloneReport, error) {
+	pod, err := ic.Libpod.LookupPod(nameOrID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to lookup pod %s", nameOrID)
+	}
+
+	var createOptions []libpod.PodCreateOption
+	if options.Name != "" {
+		createOptions = append(createOptions, libpod.WithPodName(options.Name))
+	}
+	if options.Hostname != "" {
+		createOptions = append(createOptions, libpod.WithPodHostname(options.Hostname))
+	}
+	if len(options.Labels) > 0 {
+		labels := pod.Labels()
+		for key, value := range options.Labels {
+			labels[key] = value
+		}
+		createOptions = append(createOptions, libpod.WithPodLabels(labels))
+	}
+	if len(options.PublishPorts) > 0 {
+		ports, err := generate.ParsePortMappings(ic.Libpod, options.PublishPorts)
+		if err != nil {
+			return nil, err
+		}
+		createOptions = append(createOptions, libpod.WithInfraContainerPorts(ports))
+	}
+	memberOptions := func(ctr *libpod.Container) []libpod.CtrCreateOption {
+		if resources := cloneResources(ctr, options.Members); resources != nil {
+			return []libpod.CtrCreateOption{libpod.WithResources(resources)}
+		}
+		return nil
+	}
+
+	clone, err := ic.Libpod.ClonePod(ctx, pod, memberOptions, createOptions...)
+	if err != nil {
+		return nil, err
+	}
+	if options.Start {
+		if _, err := clone.Start(ctx); err != nil {
+			return nil, errors.Wrapf(err, "unable to start pod %q", clone.ID())
+		}
+	}
+	return &entities.PodCloneReport{Id: clone.ID()}, nil
+}
+
+// PodRename changes the name of a pod.
+func (ic *ContainerEngine) PodRename(ctx context.Context, nameOrID string, options entities.PodRenameOptions) error {
+	pod, err := ic.Libpod.LookupPod(nameOrID)
+	if err != nil {
+		return err
+	}
+	return ic.Libpod.RenamePod(ctx, pod, options.NewName)
+}
+
 func (ic *ContainerEngine) PodTop(ctx context.Context, options entities.PodTopOptions) (*entities.StringSliceReport, error) {
 	var (
 		pod *libpod.Pod
@@ -286,14 +547,28 @@ func (ic *ContainerEngine) PodPs(ctx context.Context, options entities.PodPSOpti
 		pds = []*libpod.Pod{}
 	)
 
+	// The containers of all the pods are looked up at once, for both the
+	// filters and the reports.
+	members, err := ic.Libpod.PodContainersSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
 	filters := make([]libpod.PodFilter, 0, len(options.Filters))
 	for k, v := range options.Filters {
-		f, err := lpfilters.GeneratePodFilterFunc(k, v)
+		f, err := lpfilters.GeneratePodFilterFunc(k, v, ic.Libpod, members)
 		if err != nil {
 			return nil, err
 		}
 		filters = append(filters, f)
 	}
+	// The owner mode hides the pods of other users unless they are
+	// explicitly asked for, which is only allowed in the "filter" mode.
+	if _, ok := options.Filters["owner"]; !ok || ic.Libpod.OwnerMode() == define.OwnerModeEnforce {
+		filters = append(filters, func(p *libpod.Pod) bool {
+			return ic.Libpod.OwnerVisible(p.Owner())
+		})
+	}
 	if options.Latest {
 		pod, err := ic.Libpod.GetLatestPod()
 		if err != nil {
@@ -310,11 +585,8 @@ func (ic *ContainerEngine) PodPs(ctx context.Context, options entities.PodPSOpti
 	reports := make([]*entities.ListPodsReport, 0, len(pds))
 	for _, p := range pds {
 		var lpcs []*entities.ListPodContainer
-		status, err := p.GetPodStatus()
-		if err != nil {
-			return nil, err
-		}
-		cons, err := p.AllContainers()
+		cons := members[p.ID()]
+		status, err := libpod.PodStatusFromContainers(cons)
 		if err != nil {
 			return nil, err
 		}
@@ -3,8 +3,10 @@ package abi
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/containers/podman/v2/libpod"
+	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/pkg/cgroups"
 	"github.com/containers/podman/v2/pkg/domain/entities"
 	"github.com/containers/podman/v2/pkg/rootless"
@@ -30,11 +32,13 @@ func (ic *ContainerEngine) PodStats(ctx context.Context, namesOrIds []string, op
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get list of pods")
 	}
-	return ic.podsToStatsReport(pods)
+	return ic.podsToStatsReport(pods, options.Sum)
 }
 
-// podsToStatsReport converts a slice of pods into a corresponding slice of stats reports.
-func (ic *ContainerEngine) podsToStatsReport(pods []*libpod.Pod) ([]*entities.PodStatsReport, error) {
+// podsToStatsReport converts a slice of pods into a corresponding slice of stats
+// reports.  If sum is set, each pod is reported with the sum of the stats of its
+// containers, followed by the stats of each of its containers.
+func (ic *ContainerEngine) podsToStatsReport(pods []*libpod.Pod, sum bool) ([]*entities.PodStatsReport, error) {
 	reports := []*entities.PodStatsReport{}
 	for i := range pods { // Access by index to prevent potential loop-variable leaks.
 		podStats, err := pods[i].GetPodStats(nil)
@@ -42,25 +46,43 @@ func (ic *ContainerEngine) podsToStatsReport(pods []*libpod.Pod) ([]*entities.Po
 			return nil, err
 		}
 		podID := pods[i].ID()[:12]
-		for j := range podStats {
-			r := entities.PodStatsReport{
-				CPU:      floatToPercentString(podStats[j].CPU),
-				MemUsage: combineHumanValues(podStats[j].MemUsage, podStats[j].MemLimit),
-				Mem:      floatToPercentString(podStats[j].MemPerc),
-				NetIO:    combineHumanValues(podStats[j].NetInput, podStats[j].NetOutput),
-				BlockIO:  combineHumanValues(podStats[j].BlockInput, podStats[j].BlockOutput),
-				PIDS:     pidsToString(podStats[j].PIDs),
-				CID:      podStats[j].ContainerID[:12],
-				Name:     podStats[j].Name,
-				Pod:      podID,
+		if sum {
+			podSum, err := pods[i].SumPodStats(podStats)
+			if err != nil {
+				return nil, err
 			}
-			reports = append(reports, &r)
+			podReport := statsToPodStatsReport(podSum, podID)
+			podReport.CID = "--"
+			reports = append(reports, podReport)
+		}
+
+		ctrStats := make([]*define.ContainerStats, 0, len(podStats))
+		for _, stats := range podStats {
+			ctrStats = append(ctrStats, stats)
+		}
+		sort.Slice(ctrStats, func(i, j int) bool { return ctrStats[i].Name < ctrStats[j].Name })
+		for _, stats := range ctrStats {
+			reports = append(reports, statsToPodStatsReport(stats, podID))
 		}
 	}
 
 	return reports, nil
 }
 
+func statsToPodStatsReport(stats *define.ContainerStats, podID string) *entities.PodStatsReport {
+	return &entities.PodStatsReport{
+		CPU:      floatToPercentString(stats.CPU),
+		MemUsage: combineHumanValues(stats.MemUsage, stats.MemLimit),
+		Mem:      floatToPercentString(stats.MemPerc),
+		NetIO:    combineHumanValues(stats.NetInput, stats.NetOutput),
+		BlockIO:  combineHumanValues(stats.BlockInput, stats.BlockOutput),
+		PIDS:     pidsToString(stats.PIDs),
+		CID:      stats.ContainerID[:12],
+		Name:     stats.Name,
+		Pod:      podID,
+	}
+}
+
 func combineHumanValues(a, b uint64) string {
 	if a == 0 && b == 0 {
 		return "-- / --"
@@ -0,0 +1,143 @@
+package abi
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/containers/podman/v2/pkg/secrets"
+	"github.com/pkg/errors"
+)
+
+func (ic *ContainerEngine) SecretCreate(ctx context.Context, name string, reader io.Reader, options entities.SecretCreateOptions) (*entities.SecretCreateReport, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(reader, secrets.MaxSecretSize))
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading secret data")
+	}
+	manager, err := ic.Libpod.SecretsManager()
+	if err != nil {
+		return nil, err
+	}
+	id, err := manager.Store(name, data, options.Driver, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.SecretCreateReport{ID: id}, nil
+}
+
+func (ic *ContainerEngine) SecretInspect(ctx context.Context, nameOrIDs []string) ([]*entities.SecretInfoReport, []error, error) {
+	manager, err := ic.Libpod.SecretsManager()
+	if err != nil {
+		return nil, nil, err
+	}
+	var errs []error
+	reports := make([]*entities.SecretInfoReport, 0, len(nameOrIDs))
+	for _, nameOrID := range nameOrIDs {
+		secret, err := manager.Lookup(nameOrID)
+		if err != nil {
+			if errors.Cause(err) == secrets.ErrNoSuchSecret {
+				errs = append(errs, errors.Errorf("no such secret %s", nameOrID))
+				continue
+			}
+			return nil, nil, errors.Wrapf(err, "error inspecting secret %s", nameOrID)
+		}
+		reports = append(reports, secretToReport(*secret))
+	}
+	return reports, errs, nil
+}
+
+func (ic *ContainerEngine) SecretList(ctx context.Context) ([]*entities.SecretInfoReport, error) {
+	manager, err := ic.Libpod.SecretsManager()
+	if err != nil {
+		return nil, err
+	}
+	secretList, err := manager.List()
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*entities.SecretInfoReport, 0, len(secretList))
+	for _, secret := range secretList {
+		reports = append(reports, secretToReport(secret))
+	}
+	return reports, nil
+}
+
+func (ic *ContainerEngine) SecretRm(ctx context.Context, nameOrIDs []string, options entities.SecretRmOptions) ([]*entities.SecretRmReport, error) {
+	manager, err := ic.Libpod.SecretsManager()
+	if err != nil {
+		return nil, err
+	}
+	if options.All {
+		allSecrets, err := manager.List()
+		if err != nil {
+			return nil, err
+		}
+		nameOrIDs = make([]string, 0, len(allSecrets))
+		for _, secret := range allSecrets {
+			nameOrIDs = append(nameOrIDs, secret.ID)
+		}
+	}
+
+	users, err := ic.secretUsers()
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*entities.SecretRmReport, 0, len(nameOrIDs))
+	for _, nameOrID := range nameOrIDs {
+		secret, err := manager.Lookup(nameOrID)
+		if err != nil {
+			reports = append(reports, &entities.SecretRmReport{ID: nameOrID, Err: err})
+			continue
+		}
+		if ctrs := users[secret.ID]; len(ctrs) > 0 {
+			reports = append(reports, &entities.SecretRmReport{
+				ID:  secret.ID,
+				Err: errors.Wrapf(secrets.ErrSecretInUse, "secret %s is used by container(s) %s", secret.Name, strings.Join(ctrs, ", ")),
+			})
+			continue
+		}
+		id, err := manager.Delete(secret.ID)
+		reports = append(reports, &entities.SecretRmReport{ID: id, Err: err})
+	}
+	return reports, nil
+}
+
+// secretUsers maps the IDs of the secrets used by containers to the IDs of
+// these containers.
+func (ic *ContainerEngine) secretUsers() (map[string][]string, error) {
+	ctrs, err := ic.Libpod.GetAllContainers()
+	if err != nil {
+		return nil, err
+	}
+	users := make(map[string][]string)
+	for _, ctr := range ctrs {
+		config := ctr.Config()
+		used := make(map[string]bool)
+		for _, secret := range config.Secrets {
+			used[secret.Secret.ID] = true
+		}
+		for _, secret := range config.EnvSecrets {
+			used[secret.ID] = true
+		}
+		for id := range used {
+			users[id] = append(users[id], ctr.ID())
+		}
+	}
+	return users, nil
+}
+
+func secretToReport(secret secrets.Secret) *entities.SecretInfoReport {
+	return &entities.SecretInfoReport{
+		ID:        secret.ID,
+		CreatedAt: secret.CreatedAt,
+		Spec: entities.SecretSpec{
+			Name: secret.Name,
+			Driver: entities.SecretDriverSpec{
+				Name:    secret.Driver,
+				Options: secret.DriverOptions,
+			},
+		},
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/pkg/cgroups"
 	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/containers/podman/v2/pkg/hostdevices"
 	"github.com/containers/podman/v2/pkg/rootless"
 	"github.com/containers/podman/v2/pkg/util"
 	"github.com/containers/podman/v2/utils"
@@ -235,9 +237,65 @@ func (ic *ContainerEngine) SystemPrune(ctx context.Context, options entities.Sys
 			systemPruneReport.VolumePruneReport = append(systemPruneReport.VolumePruneReport, volumePruneReport...)
 		}
 	}
+	if options.Interrupted {
+		report, err := ic.Libpod.ImageRuntime().PruneInterruptedPulls()
+		if err != nil {
+			return nil, err
+		}
+		systemPruneReport.InterruptedPullsPruneReport = report
+	}
 	return systemPruneReport, nil
 }
 
+// SystemDevices lists the host devices commonly passed into containers.
+func (ic *ContainerEngine) SystemDevices(ctx context.Context, options entities.SystemDevicesOptions) (*entities.SystemDevicesReport, error) {
+	devices, err := hostdevices.List()
+	if err != nil {
+		return nil, err
+	}
+	return &entities.SystemDevicesReport{Devices: devices}, nil
+}
+
+// SystemRestore starts the containers that are restarted after a system
+// restart because of their restart policy.
+func (ic *ContainerEngine) SystemRestore(ctx context.Context) ([]*entities.SystemRestoreReport, error) {
+	started, err := ic.Libpod.StartRestartPolicyContainers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*entities.SystemRestoreReport, 0, len(started))
+	for id, err := range started {
+		reports = append(reports, &entities.SystemRestoreReport{Id: id, Err: err})
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Id < reports[j].Id
+	})
+	return reports, nil
+}
+
+// SystemBackup writes an archive of the containers, pods, volumes, networks
+// and secrets of the host to path.
+func (ic *ContainerEngine) SystemBackup(ctx context.Context, path string, options entities.SystemBackupOptions) error {
+	return ic.Libpod.Backup(ctx, path, options.Volumes)
+}
+
+// SystemRestoreBackup re-creates the containers, pods, volumes and networks of
+// the archive at path written by SystemBackup.
+func (ic *ContainerEngine) SystemRestoreBackup(ctx context.Context, path string) ([]*entities.SystemRestoreBackupReport, error) {
+	restored, err := ic.Libpod.RestoreBackup(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*entities.SystemRestoreBackupReport, 0, len(restored))
+	for resource, err := range restored {
+		reports = append(reports, &entities.SystemRestoreBackupReport{Resource: resource, Err: err})
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Resource < reports[j].Resource
+	})
+	return reports, nil
+}
+
 func (ic *ContainerEngine) SystemDf(ctx context.Context, options entities.SystemDfOptions) (*entities.SystemDfReport, error) {
 	var (
 		dfImages = []*entities.SystemDfImageReport{}
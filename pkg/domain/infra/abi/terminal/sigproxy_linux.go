@@ -12,8 +12,10 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-// ProxySignals ...
-func ProxySignals(ctr *libpod.Container) {
+// ProxySignals forwards the signals received by podman to the init process of
+// the container. If tty is set, podman is attached to the container's TTY and
+// the signals generated by the terminal are not forwarded.
+func ProxySignals(ctr *libpod.Container, tty bool) {
 	// Stop catching the shutdown signals (SIGINT, SIGTERM) - they're going
 	// to the container now.
 	shutdown.Stop()
@@ -25,12 +27,10 @@ func ProxySignals(ctr *libpod.Container) {
 
 	go func() {
 		for s := range sigBuffer {
-			// Ignore SIGCHLD and SIGPIPE - these are mostly likely
-			// intended for the podman command itself.
-			// SIGURG was added because of golang 1.14 and its preemptive changes
-			// causing more signals to "show up".
-			// https://github.com/containers/podman/issues/5483
-			if s == syscall.SIGCHLD || s == syscall.SIGPIPE || s == syscall.SIGURG {
+			if signal.IsSignalIgnoredBySigProxy(s.(syscall.Signal)) {
+				continue
+			}
+			if tty && signal.IsTerminalSignal(s.(syscall.Signal)) {
 				continue
 			}
 
@@ -43,11 +43,11 @@ func ExecAttachCtr(ctx context.Context, ctr *libpod.Container, execConfig *libpo
 func StartAttachCtr(ctx context.Context, ctr *libpod.Container, stdout, stderr, stdin *os.File, detachKeys string, sigProxy bool, startContainer bool, recursive bool) error { //nolint-interfacer
 	resize := make(chan remotecommand.TerminalSize)
 
-	haveTerminal := terminal.IsTerminal(int(os.Stdin.Fd()))
+	haveTerminal := terminal.IsTerminal(int(os.Stdin.Fd())) && ctr.Spec().Process.Terminal
 
 	// Check if we are attached to a terminal. If we are, generate resize
 	// events, and set the terminal to raw mode
-	if haveTerminal && ctr.Spec().Process.Terminal {
+	if haveTerminal {
 		cancel, oldTermState, err := handleTerminalAttach(ctx, resize)
 		if err != nil {
 			return err
@@ -83,7 +83,7 @@ func StartAttachCtr(ctx context.Context, ctr *libpod.Container, stdout, stderr,
 
 	if !startContainer {
 		if sigProxy {
-			ProxySignals(ctr)
+			ProxySignals(ctr, haveTerminal)
 		}
 
 		return ctr.Attach(streams, detachKeys, resize)
@@ -95,7 +95,7 @@ func StartAttachCtr(ctx context.Context, ctr *libpod.Container, stdout, stderr,
 	}
 
 	if sigProxy {
-		ProxySignals(ctr)
+		ProxySignals(ctr, haveTerminal)
 	}
 
 	if stdout == nil && stderr == nil {
@@ -2,6 +2,7 @@ package abi
 
 import (
 	"context"
+	"os"
 
 	"github.com/containers/podman/v2/libpod"
 	"github.com/containers/podman/v2/libpod/define"
@@ -132,19 +133,21 @@ func (ic *ContainerEngine) VolumePrune(ctx context.Context, options entities.Vol
 	if err != nil {
 		return nil, err
 	}
-	return ic.pruneVolumesHelper(ctx, filterFuncs)
+	return ic.pruneVolumesHelper(ctx, filterFuncs, options.ForceUnused)
 }
 
-func (ic *ContainerEngine) pruneVolumesHelper(ctx context.Context, filterFuncs []libpod.VolumeFilter) ([]*entities.VolumePruneReport, error) {
-	pruned, err := ic.Libpod.PruneVolumes(ctx, filterFuncs)
+func (ic *ContainerEngine) pruneVolumesHelper(ctx context.Context, filterFuncs []libpod.VolumeFilter, forceUnused bool) ([]*entities.VolumePruneReport, error) {
+	pruned, err := ic.Libpod.PruneVolumes(ctx, filterFuncs, forceUnused)
 	if err != nil {
 		return nil, err
 	}
 	reports := make([]*entities.VolumePruneReport, 0, len(pruned))
-	for k, v := range pruned {
+	for _, p := range pruned {
 		reports = append(reports, &entities.VolumePruneReport{
-			Err: v,
-			Id:  k,
+			Err:        p.Err,
+			Id:         p.Name,
+			Size:       p.Size,
+			Containers: p.Containers,
 		})
 	}
 	return reports, nil
@@ -185,3 +188,32 @@ func (ic *ContainerEngine) VolumeList(ctx context.Context, opts entities.VolumeL
 	}
 	return reports, nil
 }
+
+func (ic *ContainerEngine) VolumeExport(ctx context.Context, nameOrID string, opts entities.VolumeExportOptions) error {
+	vol, err := ic.Libpod.LookupVolume(nameOrID)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(opts.Output)
+	if err != nil {
+		return err
+	}
+	if err := vol.Export(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (ic *ContainerEngine) VolumeImport(ctx context.Context, nameOrID string, opts entities.VolumeImportOptions) error {
+	vol, err := ic.Libpod.LookupVolume(nameOrID)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(opts.Input)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return vol.Import(f)
+}
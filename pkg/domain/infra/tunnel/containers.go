@@ -6,6 +6,7 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -39,18 +40,24 @@ func (ic *ContainerEngine) ContainerWait(ctx context.Context, namesOrIds []strin
 	if err != nil {
 		return nil, err
 	}
-	responses := make([]entities.WaitReport, 0, len(cons))
-	options := new(containers.WaitOptions).WithCondition(opts.Condition)
-	for _, c := range cons {
-		response := entities.WaitReport{Id: c.ID}
-		exitCode, err := containers.Wait(ic.ClientCtx, c.ID, options)
-		if err != nil {
-			response.Error = err
-		} else {
-			response.ExitCode = exitCode
-		}
-		responses = append(responses, response)
+	responses := make([]entities.WaitReport, len(cons))
+	options := new(containers.WaitOptions).WithConditions(opts.Condition)
+	var wg sync.WaitGroup
+	for i, c := range cons {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			response := entities.WaitReport{Id: id}
+			exitCode, err := containers.Wait(ic.ClientCtx, id, options)
+			if err != nil {
+				response.Error = err
+			} else {
+				response.ExitCode = exitCode
+			}
+			responses[i] = response
+		}(i, c.ID)
 	}
+	wg.Wait()
 	return responses, nil
 }
 
@@ -67,6 +74,27 @@ func (ic *ContainerEngine) ContainerPause(ctx context.Context, namesOrIds []stri
 	return reports, nil
 }
 
+func (ic *ContainerEngine) ContainerDropCaches(ctx context.Context, namesOrIds []string, opts entities.ContainerDropCachesOptions) ([]*entities.ContainerDropCachesReport, error) {
+	if opts.Latest {
+		return nil, errors.New("latest is not supported for remote clients")
+	}
+	ctrs, err := getContainersByContext(ic.ClientCtx, opts.All, false, namesOrIds)
+	if err != nil {
+		return nil, err
+	}
+	options := new(containers.DropCachesOptions).WithAmount(opts.Amount)
+	reports := make([]*entities.ContainerDropCachesReport, 0, len(ctrs))
+	for _, c := range ctrs {
+		// Only running and paused containers have caches to drop.
+		if opts.All && c.State != define.ContainerStateRunning.String() && c.State != define.ContainerStatePaused.String() {
+			continue
+		}
+		err := containers.DropCaches(ic.ClientCtx, c.ID, options)
+		reports = append(reports, &entities.ContainerDropCachesReport{Id: c.ID, Err: err})
+	}
+	return reports, nil
+}
+
 func (ic *ContainerEngine) ContainerUnpause(ctx context.Context, namesOrIds []string, options entities.PauseUnPauseOptions) ([]*entities.PauseUnpauseReport, error) {
 	ctrs, err := getContainersByContext(ic.ClientCtx, options.All, false, namesOrIds)
 	if err != nil {
@@ -81,6 +109,9 @@ func (ic *ContainerEngine) ContainerUnpause(ctx context.Context, namesOrIds []st
 }
 
 func (ic *ContainerEngine) ContainerStop(ctx context.Context, namesOrIds []string, opts entities.StopOptions) ([]*entities.StopReport, error) {
+	if len(opts.Signals) > 0 {
+		return nil, errors.New("stopping containers with a signal escalation chain is not supported for remote clients")
+	}
 	reports := []*entities.StopReport{}
 	for _, cidFile := range opts.CIDFiles {
 		content, err := ioutil.ReadFile(cidFile)
@@ -240,6 +271,36 @@ func (ic *ContainerEngine) ContainerTop(ctx context.Context, opts entities.TopOp
 	return &entities.StringSliceReport{Value: topOutput}, nil
 }
 
+func (ic *ContainerEngine) ContainerClone(ctx context.Context, nameOrID string, opts entities.ContainerCloneOptions) (*entities.ContainerCloneReport, error) {
+	options := new(containers.CloneOptions).WithName(opts.Name).WithImage(opts.Image).WithRun(opts.Run)
+	options.WithCPUPeriod(opts.CPUPeriod).WithCPUQuota(opts.CPUQuota).WithCPUShares(opts.CPUShares).WithCPUSetCPUs(opts.CPUSetCPUs).WithMemory(opts.Memory)
+	return containers.Clone(ic.ClientCtx, nameOrID, options)
+}
+
+func (ic *ContainerEngine) ContainerUpdate(ctx context.Context, nameOrID string, opts entities.ContainerUpdateOptions) (*entities.ContainerUpdateReport, error) {
+	options := new(containers.UpdateOptions).WithCPUPeriod(opts.CPUPeriod).WithCPUQuota(opts.CPUQuota).WithCPUShares(opts.CPUShares)
+	options.WithCPUSetCPUs(opts.CPUSetCPUs).WithCPUSetMems(opts.CPUSetMems).WithMemory(opts.Memory).WithMemorySwap(opts.MemorySwap)
+	options.WithPidsLimit(opts.PidsLimit).WithBlkioWeight(opts.BlkioWeight)
+	options.WithLabels(keyValues(opts.Labels)).WithRemoveLabels(opts.RemoveLabels)
+	options.WithAnnotations(keyValues(opts.Annotations)).WithRemoveAnnotations(opts.RemoveAnnotations)
+	return containers.Update(ic.ClientCtx, nameOrID, options)
+}
+
+// keyValues converts a map into a sorted list of key=value pairs.
+func keyValues(m map[string]string) []string {
+	pairs := make([]string, 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return pairs
+}
+
+func (ic *ContainerEngine) ContainerRename(ctx context.Context, nameOrID string, opts entities.ContainerRenameOptions) error {
+	options := new(containers.RenameOptions).WithName(opts.NewName)
+	return containers.Rename(ic.ClientCtx, nameOrID, options)
+}
+
 func (ic *ContainerEngine) ContainerCommit(ctx context.Context, nameOrID string, opts entities.CommitOptions) (*entities.CommitReport, error) {
 	var (
 		repo string
@@ -262,6 +323,9 @@ func (ic *ContainerEngine) ContainerCommit(ctx context.Context, nameOrID string,
 	}
 	options := new(containers.CommitOptions).WithAuthor(opts.Author).WithChanges(opts.Changes).WithComment(opts.Message)
 	options.WithFormat(opts.Format).WithPause(opts.Pause).WithRepo(repo).WithTag(tag)
+	if opts.Timestamp != nil {
+		options.WithTimestamp(opts.Timestamp.Unix())
+	}
 	response, err := containers.Commit(ic.ClientCtx, nameOrID, options)
 	if err != nil {
 		return nil, err
@@ -274,6 +338,9 @@ func (ic *ContainerEngine) ContainerExport(ctx context.Context, nameOrID string,
 		err error
 		w   io.Writer
 	)
+	if options.IncludeVolumes {
+		return errors.New("exporting the volumes of containers is not supported for remote clients")
+	}
 	if len(options.Output) > 0 {
 		w, err = os.Create(options.Output)
 		if err != nil {
@@ -289,6 +356,10 @@ func (ic *ContainerEngine) ContainerCheckpoint(ctx context.Context, namesOrIds [
 		ctrs = []entities.ListContainer{}
 	)
 
+	if opts.CreateImage != "" {
+		return nil, errors.New("creating checkpoint images is not supported for remote clients")
+	}
+
 	if opts.All {
 		allCtrs, err := getContainersByContext(ic.ClientCtx, true, false, []string{})
 		if err != nil {
@@ -309,6 +380,7 @@ func (ic *ContainerEngine) ContainerCheckpoint(ctx context.Context, namesOrIds [
 	}
 	reports := make([]*entities.CheckpointReport, 0, len(ctrs))
 	options := new(containers.CheckpointOptions).WithExport(opts.Export).WithIgnoreRootfs(opts.IgnoreRootFS).WithKeep(opts.Keep)
+	options.WithIgnoreVolumes(opts.IgnoreVolumes)
 	options.WithLeaveRunning(opts.LeaveRunning).WithTCPEstablished(opts.TCPEstablished)
 	for _, c := range ctrs {
 		report, err := containers.Checkpoint(ic.ClientCtx, c.ID, options)
@@ -325,6 +397,9 @@ func (ic *ContainerEngine) ContainerRestore(ctx context.Context, namesOrIds []st
 		err  error
 		ctrs = []entities.ListContainer{}
 	)
+	if opts.StaticIP != nil || opts.StaticMAC != nil || len(opts.PublishPorts) > 0 {
+		return nil, errors.New("changing the network settings of restored containers is not supported for remote clients")
+	}
 	if opts.All {
 		allCtrs, err := getContainersByContext(ic.ClientCtx, true, false, []string{})
 		if err != nil {
@@ -344,7 +419,9 @@ func (ic *ContainerEngine) ContainerRestore(ctx context.Context, namesOrIds []st
 		}
 	}
 	reports := make([]*entities.RestoreReport, 0, len(ctrs))
-	options := new(containers.RestoreOptions)
+	options := new(containers.RestoreOptions).WithIgnoreRootfs(opts.IgnoreRootFS).WithIgnoreVolumes(opts.IgnoreVolumes)
+	options.WithIgnoreStaticIP(opts.IgnoreStaticIP).WithIgnoreStaticMAC(opts.IgnoreStaticMAC)
+	options.WithKeep(opts.Keep).WithTCPEstablished(opts.TCPEstablished)
 	for _, c := range ctrs {
 		report, err := containers.Restore(ic.ClientCtx, c.ID, options)
 		if err != nil {
@@ -366,6 +443,22 @@ func (ic *ContainerEngine) ContainerCreate(ctx context.Context, s *specgen.SpecG
 	return &entities.ContainerCreateReport{Id: response.ID}, nil
 }
 
+func (ic *ContainerEngine) ContainerCreateDryRun(ctx context.Context, s *specgen.SpecGenerator) (*entities.ContainerCreateDryRunReport, error) {
+	return nil, errors.New("dry runs are not supported for remote clients")
+}
+
+func (ic *ContainerEngine) ContainerCreateBatch(ctx context.Context, s *specgen.SpecGenerator, opts entities.ContainerCreateBatchOptions) (*entities.ContainerCreateBatchReport, error) {
+	options := new(containers.CreateBatchOptions).WithCount(opts.Count).WithNamePattern(opts.NamePattern).WithBatchSize(opts.BatchSize)
+	response, err := containers.CreateBatchWithSpec(ic.ClientCtx, s, options)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range response.Warnings {
+		fmt.Fprintf(os.Stderr, "%s\n", w)
+	}
+	return &entities.ContainerCreateBatchReport{Ids: response.IDs}, nil
+}
+
 func (ic *ContainerEngine) ContainerLogs(_ context.Context, nameOrIDs []string, opts entities.ContainerLogsOptions) error {
 	since := opts.Since.Format(time.RFC3339)
 	tail := strconv.FormatInt(opts.Tail, 10)
@@ -409,6 +502,11 @@ func (ic *ContainerEngine) ContainerAttach(ctx context.Context, nameOrID string,
 		return errors.Errorf("you can only attach to running containers")
 	}
 	options := new(containers.AttachOptions).WithStream(true).WithDetachKeys(opts.DetachKeys)
+	if opts.SigProxy {
+		remoteProxySignals(ctr.ID, attachedToTTY(ic.ClientCtx, ctr.ID), func(signal string) error {
+			return containers.Kill(ic.ClientCtx, ctr.ID, signal, nil)
+		})
+	}
 	return containers.Attach(ic.ClientCtx, nameOrID, opts.Stdin, opts.Stdout, opts.Stderr, nil, options)
 }
 
@@ -430,6 +528,11 @@ func makeExecConfig(options entities.ExecOptions) *handlers.ExecCreateConfig {
 	createConfig.Env = env
 	createConfig.WorkingDir = options.WorkDir
 	createConfig.Cmd = options.Cmd
+	if options.Tty {
+		if size := stdinConsoleSize(); size != nil {
+			createConfig.ConsoleSize = &[2]uint{size.Height, size.Width}
+		}
+	}
 
 	return createConfig
 }
@@ -474,7 +577,45 @@ func (ic *ContainerEngine) ContainerExecDetached(ctx context.Context, nameOrID s
 	return sessionID, nil
 }
 
-func startAndAttach(ic *ContainerEngine, name string, detachKeys *string, input, output, errput *os.File) error { //nolint
+func (ic *ContainerEngine) ContainerExecSessions(ctx context.Context, namesOrIds []string, options entities.ContainerExecSessionsOptions) ([]*entities.ContainerExecSessionReport, error) {
+	all := len(namesOrIds) == 0
+	ctrs, err := getContainersByContext(ic.ClientCtx, all, false, namesOrIds)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := []*entities.ContainerExecSessionReport{}
+	for _, ctr := range ctrs {
+		data, err := containers.Inspect(ic.ClientCtx, ctr.ID, nil)
+		if err != nil {
+			if all && errorhandling.Contains(err, define.ErrNoSuchCtr) {
+				continue
+			}
+			return nil, err
+		}
+		ids := data.ExecIDs
+		sort.Strings(ids)
+		for _, id := range ids {
+			inspect, err := containers.ExecInspect(ic.ClientCtx, id, nil)
+			if err != nil {
+				if errorhandling.Contains(err, define.ErrNoSuchExecSession) {
+					continue
+				}
+				return nil, err
+			}
+			if options.Running && !inspect.Running {
+				continue
+			}
+			reports = append(reports, &entities.ContainerExecSessionReport{
+				InspectExecSession: *inspect,
+				ContainerName:      data.Name,
+			})
+		}
+	}
+	return reports, nil
+}
+
+func startAndAttach(ic *ContainerEngine, name string, detachKeys *string, sigProxy bool, input, output, errput *os.File) error { //nolint
 	attachErr := make(chan error)
 	attachReady := make(chan bool)
 	options := new(containers.AttachOptions).WithStream(true)
@@ -496,6 +637,11 @@ func startAndAttach(ic *ContainerEngine, name string, detachKeys *string, input,
 		if err := containers.Start(ic.ClientCtx, name, startOptions); err != nil {
 			return err
 		}
+		if sigProxy {
+			remoteProxySignals(name, attachedToTTY(ic.ClientCtx, name), func(signal string) error {
+				return containers.Kill(ic.ClientCtx, name, signal, nil)
+			})
+		}
 	case err := <-attachErr:
 		return err
 	}
@@ -521,7 +667,7 @@ func (ic *ContainerEngine) ContainerStart(ctx context.Context, namesOrIds []stri
 		}
 		ctrRunning := ctr.State == define.ContainerStateRunning.String()
 		if options.Attach {
-			err = startAndAttach(ic, name, &options.DetachKeys, options.Stdin, options.Stdout, options.Stderr)
+			err = startAndAttach(ic, name, &options.DetachKeys, options.SigProxy, options.Stdin, options.Stdout, options.Stderr)
 			if err == define.ErrDetach {
 				// User manually detached
 				// Exit cleanly immediately
@@ -608,11 +754,14 @@ func (ic *ContainerEngine) ContainerStart(ctx context.Context, namesOrIds []stri
 
 func (ic *ContainerEngine) ContainerList(ctx context.Context, opts entities.ContainerListOptions) ([]entities.ListContainer, error) {
 	options := new(containers.ListOptions).WithFilters(opts.Filters).WithAll(opts.All).WithLast(opts.Last)
-	options.WithNamespace(opts.Namespace).WithSize(opts.Size).WithSync(opts.Sync)
+	options.WithNamespace(opts.Namespace).WithPids(opts.Pids).WithSize(opts.Size).WithSync(opts.Sync).WithExternal(opts.Storage)
 	return containers.List(ic.ClientCtx, options)
 }
 
 func (ic *ContainerEngine) ContainerRun(ctx context.Context, opts entities.ContainerRunOptions) (*entities.ContainerRunReport, error) {
+	if opts.Spec.Terminal && !opts.Detach && opts.Spec.ConsoleSize == nil {
+		opts.Spec.ConsoleSize = stdinConsoleSize()
+	}
 	con, err := containers.CreateWithSpec(ic.ClientCtx, opts.Spec, nil)
 	if err != nil {
 		return nil, err
@@ -638,7 +787,7 @@ func (ic *ContainerEngine) ContainerRun(ctx context.Context, opts entities.Conta
 	}
 
 	// Attach
-	if err := startAndAttach(ic, con.ID, &opts.DetachKeys, opts.InputStream, opts.OutputStream, opts.ErrorStream); err != nil {
+	if err := startAndAttach(ic, con.ID, &opts.DetachKeys, opts.SigProxy, opts.InputStream, opts.OutputStream, opts.ErrorStream); err != nil {
 		if err == define.ErrDetach {
 			return &report, nil
 		}
@@ -792,8 +941,9 @@ func (ic *ContainerEngine) ContainerPort(ctx context.Context, nameOrID string, o
 	return reports, nil
 }
 
-func (ic *ContainerEngine) ContainerCopyFromArchive(ctx context.Context, nameOrID string, path string, reader io.Reader) (entities.ContainerCopyFunc, error) {
-	return containers.CopyFromArchive(ic.ClientCtx, nameOrID, path, reader)
+func (ic *ContainerEngine) ContainerCopyFromArchive(ctx context.Context, nameOrID string, path string, reader io.Reader, options entities.CopyOptions) (entities.ContainerCopyFunc, error) {
+	copyOptions := new(containers.CopyOptions).WithChown(options.Chown).WithNoOverwriteDirNonDir(options.NoOverwriteDirNonDir)
+	return containers.CopyFromArchiveWithOptions(ic.ClientCtx, nameOrID, path, reader, copyOptions)
 }
 
 func (ic *ContainerEngine) ContainerCopyToArchive(ctx context.Context, nameOrID string, path string, writer io.Writer) (entities.ContainerCopyFunc, error) {
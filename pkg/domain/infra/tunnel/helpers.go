@@ -2,13 +2,20 @@ package tunnel
 
 import (
 	"context"
+	"os"
+	"strconv"
+	"syscall"
 
 	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/pkg/bindings/containers"
 	"github.com/containers/podman/v2/pkg/bindings/pods"
 	"github.com/containers/podman/v2/pkg/domain/entities"
 	"github.com/containers/podman/v2/pkg/errorhandling"
+	"github.com/containers/podman/v2/pkg/signal"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh/terminal"
 )
 
 // FIXME: the `ignore` parameter is very likely wrong here as it should rather
@@ -116,3 +123,79 @@ func getPodsByContext(contextWithConnection context.Context, all bool, namesOrID
 	}
 	return filtered, nil
 }
+
+// remoteProxySignals forwards the signals received by the remote client to
+// the container via killFunc.  It is the remote equivalent of
+// terminal.ProxySignals.  If tty is set, the client is attached to the
+// container's TTY and the signals generated by the terminal are not
+// forwarded.
+func remoteProxySignals(ctrID string, tty bool, killFunc func(string) error) {
+	sigBuffer := make(chan os.Signal, 128)
+	signal.CatchAll(sigBuffer)
+
+	logrus.Debugf("Enabling signal proxying")
+
+	go func() {
+		for s := range sigBuffer {
+			syscallSignal := s.(syscall.Signal)
+			if signal.IsSignalIgnoredBySigProxy(syscallSignal) {
+				continue
+			}
+			if tty && signal.IsTerminalSignal(syscallSignal) {
+				continue
+			}
+
+			if err := killFunc(strconv.Itoa(int(syscallSignal))); err != nil {
+				if errorhandling.Contains(err, define.ErrCtrStateInvalid) {
+					logrus.Infof("Ceasing signal forwarding to container %s as it has stopped", ctrID)
+				} else {
+					logrus.Errorf("Error forwarding signal %d to container %s: %v", syscallSignal, ctrID, err)
+				}
+				// The container is gone, so forward the signal to
+				// ourselves so that it is not lost, stop catching
+				// signals and let the defaults play out.
+				signal.StopCatch(sigBuffer)
+				if self, err := os.FindProcess(os.Getpid()); err == nil {
+					if err := self.Signal(s); err != nil {
+						logrus.Errorf("failed to kill pid %d", os.Getpid())
+					}
+				}
+				return
+			}
+		}
+	}()
+}
+
+// attachedToTTY returns whether the local terminal is attached to the TTY of
+// the given container, in which case the terminal generates the signals
+// related to it.
+func attachedToTTY(ctx context.Context, nameOrID string) bool {
+	if !terminal.IsTerminal(int(os.Stdin.Fd())) {
+		return false
+	}
+	data, err := containers.Inspect(ctx, nameOrID, nil)
+	if err != nil {
+		logrus.Debugf("Unable to inspect container %s: %v", nameOrID, err)
+		return false
+	}
+	return data.Config != nil && data.Config.Tty
+}
+
+// stdinConsoleSize returns the size of the local terminal, if stdin is one,
+// so it can be sent to the server as the initial size of a container or exec
+// session TTY. Otherwise the TTY starts at 80x24 until the first resize.
+func stdinConsoleSize() *spec.Box {
+	fd := int(os.Stdin.Fd())
+	if !terminal.IsTerminal(fd) {
+		return nil
+	}
+	width, height, err := terminal.GetSize(fd)
+	if err != nil {
+		logrus.Debugf("Unable to obtain terminal size: %v", err)
+		return nil
+	}
+	return &spec.Box{
+		Height: uint(height),
+		Width:  uint(width),
+	}
+}
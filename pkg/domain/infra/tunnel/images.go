@@ -26,6 +26,10 @@ func (ir *ImageEngine) Exists(_ context.Context, nameOrID string) (*entities.Boo
 	return &entities.BoolReport{Value: found}, err
 }
 
+func (ir *ImageEngine) Lookup(_ context.Context, namesOrIDs []string, _ entities.ImageLookupOptions) ([]*entities.ImageLookupReport, error) {
+	return images.Lookup(ir.ClientCtx, namesOrIDs, nil)
+}
+
 func (ir *ImageEngine) Remove(ctx context.Context, imagesArg []string, opts entities.ImageRemoveOptions) (*entities.ImageRemoveReport, []error) {
 	options := new(images.RemoveOptions).WithForce(opts.Force).WithAll(opts.All)
 	return images.Remove(ir.ClientCtx, imagesArg, options)
@@ -78,12 +82,15 @@ func (ir *ImageEngine) History(ctx context.Context, nameOrID string, opts entiti
 		// Created time comes over as an int64 so needs conversion to time.time
 		t := time.Unix(layer.Created, 0)
 		hold := entities.ImageHistoryLayer{
-			ID:        layer.ID,
-			Created:   t.UTC(),
-			CreatedBy: layer.CreatedBy,
-			Tags:      layer.Tags,
-			Size:      layer.Size,
-			Comment:   layer.Comment,
+			ID:          layer.ID,
+			Created:     t.UTC(),
+			CreatedBy:   layer.CreatedBy,
+			Tags:        layer.Tags,
+			Size:        layer.Size,
+			Comment:     layer.Comment,
+			EmptyLayer:  layer.EmptyLayer,
+			Layer:       layer.Layer,
+			LayerDigest: layer.LayerDigest,
 		}
 		history.Layers[i] = hold
 	}
@@ -187,6 +194,10 @@ func (ir *ImageEngine) Untag(ctx context.Context, nameOrID string, tags []string
 	return nil
 }
 
+func (ir *ImageEngine) ExplainResolution(ctx context.Context, names []string) ([]*entities.ImageResolutionReport, error) {
+	return nil, errors.New("explaining image name resolution is not supported for remote clients")
+}
+
 func (ir *ImageEngine) Inspect(ctx context.Context, namesOrIDs []string, opts entities.InspectOptions) ([]*entities.ImageInspectReport, []error, error) {
 	options := new(images.GetOptions).WithSize(opts.Size)
 	reports := []*entities.ImageInspectReport{}
@@ -210,6 +221,9 @@ func (ir *ImageEngine) Inspect(ctx context.Context, namesOrIDs []string, opts en
 }
 
 func (ir *ImageEngine) Load(ctx context.Context, opts entities.ImageLoadOptions) (*entities.ImageLoadReport, error) {
+	if opts.Delta {
+		return nil, errors.New("loading delta archives is not supported for remote clients")
+	}
 	f, err := os.Open(opts.Input)
 	if err != nil {
 		return nil, err
@@ -235,6 +249,9 @@ func (ir *ImageEngine) Import(ctx context.Context, opts entities.ImageImportOpti
 		err error
 		f   *os.File
 	)
+	if opts.IncludeVolumes {
+		return nil, errors.New("importing the volumes of containers is not supported for remote clients")
+	}
 	options := new(images.ImportOptions).WithChanges(opts.Changes).WithMessage(opts.Message).WithReference(opts.Reference)
 	if opts.SourceIsURL {
 		options.WithURL(opts.Source)
@@ -269,6 +286,9 @@ func (ir *ImageEngine) Save(ctx context.Context, nameOrID string, tags []string,
 		f   *os.File
 		err error
 	)
+	if opts.DeltaBase != nil {
+		return errors.New("saving delta archives is not supported for remote clients")
+	}
 	options := new(images.ExportOptions).WithFormat(opts.Format).WithCompress(opts.Compress)
 
 	switch opts.Format {
@@ -346,7 +366,7 @@ func (ir *ImageEngine) Search(ctx context.Context, term string, opts entities.Im
 
 	options := new(images.SearchOptions)
 	options.WithAuthfile(opts.Authfile).WithFilters(mappedFilters).WithLimit(opts.Limit)
-	options.WithListTags(opts.ListTags).WithNoTrunc(opts.NoTrunc)
+	options.WithListTags(opts.ListTags).WithTagDigests(opts.TagDigests).WithNoTrunc(opts.NoTrunc)
 	if s := opts.SkipTLSVerify; s != types.OptionalBoolUndefined {
 		if s == types.OptionalBoolTrue {
 			options.WithSkipTLSVerify(true)
@@ -381,7 +401,7 @@ func (ir *ImageEngine) Build(_ context.Context, containerFiles []string, opts en
 }
 
 func (ir *ImageEngine) Tree(ctx context.Context, nameOrID string, opts entities.ImageTreeOptions) (*entities.ImageTreeReport, error) {
-	options := new(images.TreeOptions).WithWhatRequires(opts.WhatRequires)
+	options := new(images.TreeOptions).WithWhatRequires(opts.WhatRequires).WithShared(opts.Shared)
 	return images.Tree(ir.ClientCtx, nameOrID, options)
 }
 
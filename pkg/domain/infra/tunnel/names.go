@@ -0,0 +1,25 @@
+package tunnel
+
+import (
+	"context"
+
+	"github.com/containers/podman/v2/pkg/bindings/names"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+)
+
+func (ic *ContainerEngine) NameReserve(ctx context.Context, options entities.NameReserveOptions) (*entities.NameReservationReport, error) {
+	opts := new(names.ReserveOptions).WithName(options.Name).WithTTL(int64(options.TTL.Seconds()))
+	return names.Reserve(ic.ClientCtx, opts)
+}
+
+func (ic *ContainerEngine) NameRelease(ctx context.Context, reserved []string) ([]*entities.NameReleaseReport, error) {
+	reports := make([]*entities.NameReleaseReport, 0, len(reserved))
+	for _, name := range reserved {
+		reports = append(reports, &entities.NameReleaseReport{Name: name, Err: names.Release(ic.ClientCtx, name, nil)})
+	}
+	return reports, nil
+}
+
+func (ic *ContainerEngine) NameList(ctx context.Context) ([]*entities.NameReservationReport, error) {
+	return names.List(ic.ClientCtx, nil)
+}
@@ -77,3 +77,9 @@ func (ic *ContainerEngine) NetworkConnect(ctx context.Context, networkname strin
 	options := new(network.ConnectOptions).WithAliases(opts.Aliases)
 	return network.Connect(ic.ClientCtx, networkname, opts.Container, options)
 }
+
+// NetworkPrune removes unused networks
+func (ic *ContainerEngine) NetworkPrune(ctx context.Context, opts entities.NetworkPruneOptions) ([]*entities.NetworkPruneReport, error) {
+	options := new(network.PruneOptions).WithFilters(opts.Filters).WithLeaked(opts.Leaked)
+	return network.Prune(ic.ClientCtx, options)
+}
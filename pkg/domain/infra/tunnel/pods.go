@@ -27,7 +27,7 @@ func (ic *ContainerEngine) PodKill(ctx context.Context, namesOrIds []string, opt
 		return nil, err
 	}
 	reports := make([]*entities.PodKillReport, 0, len(foundPods))
-	options := new(pods.KillOptions).WithSignal(opts.Signal)
+	options := new(pods.KillOptions).WithSignal(opts.Signal).WithExcludeInfra(opts.ExcludeInfra)
 	for _, p := range foundPods {
 		response, err := pods.Kill(ic.ClientCtx, p.Id, options)
 		if err != nil {
@@ -85,6 +85,14 @@ func (ic *ContainerEngine) PodUnpause(ctx context.Context, namesOrIds []string,
 	return reports, nil
 }
 
+func (ic *ContainerEngine) PodCheckpoint(ctx context.Context, namesOrIds []string, options entities.PodCheckpointOptions) ([]*entities.PodCheckpointReport, error) {
+	return nil, errors.New("checkpointing pods is not supported for remote clients")
+}
+
+func (ic *ContainerEngine) PodRestore(ctx context.Context, namesOrIds []string, options entities.PodRestoreOptions) ([]*entities.PodRestoreReport, error) {
+	return nil, errors.New("restoring pods is not supported for remote clients")
+}
+
 func (ic *ContainerEngine) PodStop(ctx context.Context, namesOrIds []string, opts entities.PodStopOptions) ([]*entities.PodStopReport, error) {
 	timeout := -1
 	foundPods, err := getPodsByContext(ic.ClientCtx, opts.All, namesOrIds)
@@ -185,6 +193,19 @@ func (ic *ContainerEngine) PodCreate(ctx context.Context, opts entities.PodCreat
 	return pods.CreatePodFromSpec(ic.ClientCtx, podSpec, nil)
 }
 
+func (ic *ContainerEngine) PodUpdate(ctx context.Context, nameOrID string, opts entities.PodUpdateOptions) (*entities.PodUpdateReport, error) {
+	return pods.Update(ic.ClientCtx, nameOrID, &opts)
+}
+
+func (ic *ContainerEngine) PodClone(ctx context.Context, nameOrID string, opts entities.PodCloneOptions) (*entities.PodCloneReport, error) {
+	return pods.Clone(ic.ClientCtx, nameOrID, &opts)
+}
+
+func (ic *ContainerEngine) PodRename(ctx context.Context, nameOrID string, opts entities.PodRenameOptions) error {
+	options := new(pods.RenameOptions).WithName(opts.NewName)
+	return pods.Rename(ic.ClientCtx, nameOrID, options)
+}
+
 func (ic *ContainerEngine) PodTop(ctx context.Context, opts entities.PodTopOptions) (*entities.StringSliceReport, error) {
 	switch {
 	case opts.Latest:
@@ -216,6 +237,6 @@ func (ic *ContainerEngine) PodInspect(ctx context.Context, options entities.PodI
 }
 
 func (ic *ContainerEngine) PodStats(ctx context.Context, namesOrIds []string, opts entities.PodStatsOptions) ([]*entities.PodStatsReport, error) {
-	options := new(pods.StatsOptions).WithAll(opts.All)
+	options := new(pods.StatsOptions).WithAll(opts.All).WithSum(opts.Sum)
 	return pods.Stats(ic.ClientCtx, namesOrIds, options)
 }
@@ -0,0 +1,63 @@
+package tunnel
+
+import (
+	"context"
+	"io"
+
+	"github.com/containers/podman/v2/pkg/bindings/secrets"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/pkg/errors"
+)
+
+func (ic *ContainerEngine) SecretCreate(ctx context.Context, name string, reader io.Reader, options entities.SecretCreateOptions) (*entities.SecretCreateReport, error) {
+	opts := new(secrets.CreateOptions).WithName(name).WithDriver(options.Driver)
+	return secrets.Create(ic.ClientCtx, reader, opts)
+}
+
+func (ic *ContainerEngine) SecretInspect(ctx context.Context, nameOrIDs []string) ([]*entities.SecretInfoReport, []error, error) {
+	var (
+		reports = make([]*entities.SecretInfoReport, 0, len(nameOrIDs))
+		errs    = []error{}
+	)
+	for _, nameOrID := range nameOrIDs {
+		data, err := secrets.Inspect(ic.ClientCtx, nameOrID, nil)
+		if err != nil {
+			errModel, ok := err.(entities.ErrorModel)
+			if !ok {
+				return nil, nil, err
+			}
+			if errModel.ResponseCode == 404 {
+				errs = append(errs, errors.Errorf("no such secret %s", nameOrID))
+				continue
+			}
+			return nil, nil, err
+		}
+		reports = append(reports, data)
+	}
+	return reports, errs, nil
+}
+
+func (ic *ContainerEngine) SecretList(ctx context.Context) ([]*entities.SecretInfoReport, error) {
+	return secrets.List(ic.ClientCtx, nil)
+}
+
+func (ic *ContainerEngine) SecretRm(ctx context.Context, nameOrIDs []string, options entities.SecretRmOptions) ([]*entities.SecretRmReport, error) {
+	if options.All {
+		allSecrets, err := secrets.List(ic.ClientCtx, nil)
+		if err != nil {
+			return nil, err
+		}
+		nameOrIDs = make([]string, 0, len(allSecrets))
+		for _, secret := range allSecrets {
+			nameOrIDs = append(nameOrIDs, secret.ID)
+		}
+	}
+	reports := make([]*entities.SecretRmReport, 0, len(nameOrIDs))
+	for _, nameOrID := range nameOrIDs {
+		reports = append(reports, &entities.SecretRmReport{
+			ID:  nameOrID,
+			Err: secrets.Remove(ic.ClientCtx, nameOrID, nil),
+		})
+	}
+	return reports, nil
+}
@@ -20,14 +20,30 @@ func (ic *ContainerEngine) SetupRootless(_ context.Context, cmd *cobra.Command)
 
 // SystemPrune prunes unused data from the system.
 func (ic *ContainerEngine) SystemPrune(ctx context.Context, opts entities.SystemPruneOptions) (*entities.SystemPruneReport, error) {
-	options := new(system.PruneOptions).WithAll(opts.All).WithVolumes(opts.Volume).WithFilters(opts.Filters)
+	options := new(system.PruneOptions).WithAll(opts.All).WithVolumes(opts.Volume).WithInterrupted(opts.Interrupted).WithFilters(opts.Filters)
 	return system.Prune(ic.ClientCtx, options)
 }
 
+func (ic *ContainerEngine) SystemDevices(ctx context.Context, options entities.SystemDevicesOptions) (*entities.SystemDevicesReport, error) {
+	return system.Devices(ic.ClientCtx, nil)
+}
+
 func (ic *ContainerEngine) SystemDf(ctx context.Context, options entities.SystemDfOptions) (*entities.SystemDfReport, error) {
 	return system.DiskUsage(ic.ClientCtx, nil)
 }
 
+func (ic *ContainerEngine) SystemRestore(ctx context.Context) ([]*entities.SystemRestoreReport, error) {
+	return nil, errors.New("system restore is not supported on remote clients")
+}
+
+func (ic *ContainerEngine) SystemBackup(ctx context.Context, path string, options entities.SystemBackupOptions) error {
+	return errors.New("system backup is not supported on remote clients")
+}
+
+func (ic *ContainerEngine) SystemRestoreBackup(ctx context.Context, path string) ([]*entities.SystemRestoreBackupReport, error) {
+	return nil, errors.New("system restore is not supported on remote clients")
+}
+
 func (ic *ContainerEngine) Unshare(ctx context.Context, args []string) error {
 	return errors.New("unshare is not supported on remote clients")
 }
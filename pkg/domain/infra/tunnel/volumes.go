@@ -2,6 +2,7 @@ package tunnel
 
 import (
 	"context"
+	"os"
 
 	"github.com/containers/podman/v2/pkg/bindings/volumes"
 	"github.com/containers/podman/v2/pkg/domain/entities"
@@ -70,7 +71,7 @@ func (ic *ContainerEngine) VolumeInspect(ctx context.Context, namesOrIds []strin
 }
 
 func (ic *ContainerEngine) VolumePrune(ctx context.Context, opts entities.VolumePruneOptions) ([]*entities.VolumePruneReport, error) {
-	options := new(volumes.PruneOptions).WithFilters(opts.Filters)
+	options := new(volumes.PruneOptions).WithFilters(opts.Filters).WithForceUnused(opts.ForceUnused)
 	return volumes.Prune(ic.ClientCtx, options)
 }
 
@@ -78,3 +79,24 @@ func (ic *ContainerEngine) VolumeList(ctx context.Context, opts entities.VolumeL
 	options := new(volumes.ListOptions).WithFilters(opts.Filter)
 	return volumes.List(ic.ClientCtx, options)
 }
+
+func (ic *ContainerEngine) VolumeExport(ctx context.Context, nameOrID string, opts entities.VolumeExportOptions) error {
+	f, err := os.Create(opts.Output)
+	if err != nil {
+		return err
+	}
+	if err := volumes.Export(ic.ClientCtx, nameOrID, f, nil); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (ic *ContainerEngine) VolumeImport(ctx context.Context, nameOrID string, opts entities.VolumeImportOptions) error {
+	f, err := os.Open(opts.Input)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return volumes.Import(ic.ClientCtx, nameOrID, f, nil)
+}
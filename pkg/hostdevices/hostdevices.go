@@ -0,0 +1,203 @@
+package hostdevices
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Device classes reported by List.
+const (
+	ClassGPU    = "gpu"
+	ClassTun    = "tun"
+	ClassSerial = "serial"
+	ClassFuse   = "fuse"
+	ClassKVM    = "kvm"
+)
+
+// CDISpecDirs are the directories searched for Container Device Interface
+// specs.
+var CDISpecDirs = []string{"/etc/cdi", "/var/run/cdi"}
+
+// devicePatterns maps the glob patterns of the device nodes commonly passed
+// into containers to their class.
+var devicePatterns = []struct {
+	pattern string
+	class   string
+}{
+	{"/dev/dri/card*", ClassGPU},
+	{"/dev/dri/renderD*", ClassGPU},
+	{"/dev/nvidia*", ClassGPU},
+	{"/dev/nvidia-caps/*", ClassGPU},
+	{"/dev/net/tun", ClassTun},
+	{"/dev/ttyUSB*", ClassSerial},
+	{"/dev/ttyACM*", ClassSerial},
+	{"/dev/fuse", ClassFuse},
+	{"/dev/kvm", ClassKVM},
+}
+
+// Device describes a device node of the host.
+type Device struct {
+	// Path of the device node.
+	Path string `json:"path"`
+	// Class of the device, e.g. "gpu" or "serial".
+	Class string `json:"class"`
+	// Type of the device node, "c" for character and "b" for block
+	// devices.
+	Type string `json:"type"`
+	// Major number of the device.
+	Major int64 `json:"major"`
+	// Minor number of the device.
+	Minor int64 `json:"minor"`
+	// CgroupRule is the rule allowing access to the device, in the format
+	// of --device-cgroup-rule.
+	CgroupRule string `json:"cgroupRule"`
+	// Mode is the permission bits of the device node.
+	Mode os.FileMode `json:"mode"`
+	// UID owning the device node.
+	UID uint32 `json:"uid"`
+	// GID owning the device node.
+	GID uint32 `json:"gid"`
+	// Readable is whether the user listing the devices can read the
+	// device.
+	Readable bool `json:"readable"`
+	// Writable is whether the user listing the devices can write the
+	// device.
+	Writable bool `json:"writable"`
+	// CDIDevices are the fully qualified names of the CDI devices
+	// providing the device node, e.g. "nvidia.com/gpu=0".
+	CDIDevices []string `json:"cdiDevices,omitempty"`
+}
+
+// Access returns the access of the user listing the devices to the device as
+// "rw", "r", "w" or "none".
+func (d *Device) Access() string {
+	switch {
+	case d.Readable && d.Writable:
+		return "rw"
+	case d.Readable:
+		return "r"
+	case d.Writable:
+		return "w"
+	}
+	return "none"
+}
+
+// cgroupRule returns the cgroup rule allowing full access to a device.
+func cgroupRule(devType string, major, minor int64) string {
+	return fmt.Sprintf("%s %d:%d rwm", devType, major, minor)
+}
+
+// cdiSpec is the subset of a CDI spec needed to map CDI devices to device
+// nodes.
+type cdiSpec struct {
+	Kind    string `json:"kind"`
+	Devices []struct {
+		Name           string `json:"name"`
+		ContainerEdits struct {
+			DeviceNodes []struct {
+				Path     string `json:"path"`
+				HostPath string `json:"hostPath"`
+			} `json:"deviceNodes"`
+		} `json:"containerEdits"`
+	} `json:"devices"`
+}
+
+// parseCDISpec returns the fully qualified names of the CDI devices of the
+// JSON or YAML spec in data, keyed by the host path of their device nodes.
+func parseCDISpec(data []byte) (map[string][]string, error) {
+	var spec cdiSpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, err
+	}
+	if spec.Kind == "" {
+		return nil, errors.New("spec has no kind")
+	}
+	devices := make(map[string][]string)
+	for _, d := range spec.Devices {
+		name := spec.Kind + "=" + d.Name
+		for _, n := range d.ContainerEdits.DeviceNodes {
+			hostPath := n.HostPath
+			if hostPath == "" {
+				hostPath = n.Path
+			}
+			devices[hostPath] = append(devices[hostPath], name)
+		}
+	}
+	return devices, nil
+}
+
+// cdiDevices returns the fully qualified names of the CDI devices of the
+// specs in dirs, keyed by the host path of their device nodes. Specs that
+// cannot be parsed are skipped.
+func cdiDevices(dirs []string) map[string][]string {
+	devices := make(map[string][]string)
+	for _, dir := range dirs {
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				logrus.Debugf("Error reading CDI spec directory %s: %v", dir, err)
+			}
+			continue
+		}
+		for _, f := range files {
+			ext := filepath.Ext(f.Name())
+			if f.IsDir() || (ext != ".json" && ext != ".yaml") {
+				continue
+			}
+			path := filepath.Join(dir, f.Name())
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				logrus.Debugf("Error reading CDI spec %s: %v", path, err)
+				continue
+			}
+			specDevices, err := parseCDISpec(data)
+			if err != nil {
+				logrus.Debugf("Error parsing CDI spec %s: %v", path, err)
+				continue
+			}
+			for hostPath, names := range specDevices {
+				devices[hostPath] = append(devices[hostPath], names...)
+			}
+		}
+	}
+	for hostPath := range devices {
+		sort.Strings(devices[hostPath])
+	}
+	return devices
+}
+
+// devicePaths returns the device nodes matching devicePatterns, sorted by
+// path, with their class.
+func devicePaths() ([]string, map[string]string) {
+	paths := []string{}
+	classes := make(map[string]string)
+	for _, p := range devicePatterns {
+		matches, err := filepath.Glob(p.pattern)
+		if err != nil {
+			continue
+		}
+		for _, m := range matches {
+			if _, ok := classes[m]; ok {
+				continue
+			}
+			// /dev/nvidia* also matches the /dev/nvidia-caps directory.
+			if strings.HasSuffix(p.pattern, "*") {
+				if fi, err := os.Stat(m); err == nil && fi.IsDir() {
+					continue
+				}
+			}
+			classes[m] = p.class
+			paths = append(paths, m)
+		}
+	}
+	sort.Strings(paths)
+	return paths, classes
+}
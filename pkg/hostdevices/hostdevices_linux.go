@@ -0,0 +1,51 @@
+package hostdevices
+
+import (
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// List returns the device nodes of the host commonly passed into containers,
+// like GPUs, /dev/net/tun, USB serial adapters, /dev/fuse and /dev/kvm, sorted
+// by path.
+func List() ([]*Device, error) {
+	paths, classes := devicePaths()
+	cdi := cdiDevices(CDISpecDirs)
+
+	devices := make([]*Device, 0, len(paths))
+	for _, path := range paths {
+		var st unix.Stat_t
+		if err := unix.Stat(path, &st); err != nil {
+			logrus.Debugf("Error examining device %s: %v", path, err)
+			continue
+		}
+		var devType string
+		switch st.Mode & unix.S_IFMT {
+		case unix.S_IFCHR:
+			devType = "c"
+		case unix.S_IFBLK:
+			devType = "b"
+		default:
+			continue
+		}
+		major := int64(unix.Major(st.Rdev))
+		minor := int64(unix.Minor(st.Rdev))
+		devices = append(devices, &Device{
+			Path:       path,
+			Class:      classes[path],
+			Type:       devType,
+			Major:      major,
+			Minor:      minor,
+			CgroupRule: cgroupRule(devType, major, minor),
+			Mode:       os.FileMode(st.Mode & 0777),
+			UID:        st.Uid,
+			GID:        st.Gid,
+			Readable:   unix.Access(path, unix.R_OK) == nil,
+			Writable:   unix.Access(path, unix.W_OK) == nil,
+			CDIDevices: cdi[path],
+		})
+	}
+	return devices, nil
+}
@@ -0,0 +1,71 @@
+package hostdevices
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCDISpecJSON(t *testing.T) {
+	spec := `{
+  "cdiVersion": "0.3.0",
+  "kind": "nvidia.com/gpu",
+  "devices": [
+    {"name": "0", "containerEdits": {"deviceNodes": [{"path": "/dev/nvidia0"}, {"path": "/dev/nvidiactl"}]}},
+    {"name": "1", "containerEdits": {"deviceNodes": [{"path": "/dev/nvidia1"}, {"path": "/dev/nvidiactl"}]}}
+  ]
+}`
+	devices, err := parseCDISpec([]byte(spec))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"nvidia.com/gpu=0"}, devices["/dev/nvidia0"])
+	assert.Equal(t, []string{"nvidia.com/gpu=1"}, devices["/dev/nvidia1"])
+	assert.Equal(t, []string{"nvidia.com/gpu=0", "nvidia.com/gpu=1"}, devices["/dev/nvidiactl"])
+}
+
+func TestParseCDISpecYAMLHostPath(t *testing.T) {
+	spec := `cdiVersion: 0.3.0
+kind: vendor.com/serial
+devices:
+- name: console
+  containerEdits:
+    deviceNodes:
+    - path: /dev/ttyS0
+      hostPath: /dev/ttyUSB0
+`
+	devices, err := parseCDISpec([]byte(spec))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"/dev/ttyUSB0": {"vendor.com/serial=console"}}, devices)
+}
+
+func TestParseCDISpecNoKind(t *testing.T) {
+	_, err := parseCDISpec([]byte(`{"devices": []}`))
+	assert.Error(t, err)
+}
+
+func TestCDIDevices(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cdi")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "b.yaml"), []byte("kind: b.com/dev\ndevices:\n- name: x\n  containerEdits:\n    deviceNodes:\n    - path: /dev/fuse\n"), 0644)
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, "a.json"), []byte(`{"kind": "a.com/dev", "devices": [{"name": "y", "containerEdits": {"deviceNodes": [{"path": "/dev/fuse"}]}}]}`), 0644)
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0644)
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, "README"), []byte("kind: c.com/dev"), 0644)
+	assert.NoError(t, err)
+
+	devices := cdiDevices([]string{dir, filepath.Join(dir, "missing")})
+	assert.Equal(t, map[string][]string{"/dev/fuse": {"a.com/dev=y", "b.com/dev=x"}}, devices)
+}
+
+func TestAccess(t *testing.T) {
+	assert.Equal(t, "rw", (&Device{Readable: true, Writable: true}).Access())
+	assert.Equal(t, "r", (&Device{Readable: true}).Access())
+	assert.Equal(t, "w", (&Device{Writable: true}).Access())
+	assert.Equal(t, "none", (&Device{}).Access())
+}
@@ -0,0 +1,13 @@
+// +build !linux
+
+package hostdevices
+
+import (
+	"github.com/containers/podman/v2/libpod/define"
+)
+
+// List returns the device nodes of the host commonly passed into containers.
+// It is only supported on Linux.
+func List() ([]*Device, error) {
+	return nil, define.ErrOSNotSupported
+}
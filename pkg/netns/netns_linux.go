@@ -179,12 +179,17 @@ func NewNS() (ns.NetNS, error) {
 
 // UnmountNS unmounts the NS held by the netns object
 func UnmountNS(ns ns.NetNS) error {
+	return UnmountNSPath(ns.Path())
+}
+
+// UnmountNSPath unmounts and removes the bind-mounted namespace at the given
+// path without requiring an open handle to it.
+func UnmountNSPath(nsPath string) error {
 	nsRunDir, err := getNSRunDir()
 	if err != nil {
 		return err
 	}
 
-	nsPath := ns.Path()
 	// Only unmount if it's been bind-mounted (don't touch namespaces in /proc...)
 	if strings.HasPrefix(nsPath, nsRunDir) {
 		if err := unix.Unmount(nsPath, unix.MNT_DETACH); err != nil {
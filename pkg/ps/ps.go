@@ -14,7 +14,6 @@ import (
 	lpfilters "github.com/containers/podman/v2/libpod/filters"
 	"github.com/containers/podman/v2/pkg/domain/entities"
 	psdefine "github.com/containers/podman/v2/pkg/ps/define"
-	"github.com/containers/storage"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
@@ -35,6 +34,14 @@ func GetContainerLists(runtime *libpod.Runtime, options entities.ContainerListOp
 		}
 	}
 
+	// The owner mode hides the containers of other users unless they are
+	// explicitly asked for, which is only allowed in the "filter" mode.
+	if _, ok := options.Filters["owner"]; !ok || runtime.OwnerMode() == define.OwnerModeEnforce {
+		filterFuncs = append(filterFuncs, func(c *libpod.Container) bool {
+			return runtime.OwnerVisible(c.Owner())
+		})
+	}
+
 	// Docker thinks that if status is given as an input, then we should override
 	// the all setting and always deal with all containers.
 	if len(options.Filters["status"]) > 0 {
@@ -48,10 +55,21 @@ func GetContainerLists(runtime *libpod.Runtime, options entities.ContainerListOp
 		filterFuncs = append(filterFuncs, runningOnly)
 	}
 
-	cons, err := runtime.GetContainers(filterFuncs...)
+	// Listing reads a snapshot of the state, so it does not wait for
+	// operations on individual containers.  Syncing requires locking.
+	getContainers := runtime.GetContainersSnapshot
+	if options.Sync {
+		getContainers = runtime.GetContainers
+	}
+	cons, err := getContainers(filterFuncs...)
 	if err != nil {
 		return nil, err
 	}
+	if options.Sync {
+		if err := runtime.SyncContainers(cons); err != nil {
+			return nil, errors.Wrapf(err, "unable to update container state from OCI runtime")
+		}
+	}
 	if options.Last > 0 {
 		// Sort the libpod containers
 		sort.Sort(SortCreateTime{SortContainers: cons})
@@ -69,18 +87,14 @@ func GetContainerLists(runtime *libpod.Runtime, options entities.ContainerListOp
 		pss = append(pss, listCon)
 	}
 
-	if options.All && options.Storage {
-		externCons, err := runtime.StorageContainers()
+	if options.Storage {
+		externCons, err := runtime.ExternalContainers()
 		if err != nil {
 			return nil, err
 		}
 
 		for _, con := range externCons {
-			listCon, err := ListStorageContainer(runtime, con, options)
-			if err != nil {
-				return nil, err
-			}
-			pss = append(pss, listCon)
+			pss = append(pss, ListExternalContainer(con))
 		}
 	}
 
@@ -106,6 +120,8 @@ func ListContainerBatch(rt *libpod.Runtime, ctr *libpod.Container, opts entities
 		exitCode                                int32
 		exited                                  bool
 		pid                                     int
+		pidsCurrent, pidsLimit                  uint64
+		restartCount                            uint
 		size                                    *psdefine.ContainerSize
 		startedTime                             time.Time
 		exitedTime                              time.Time
@@ -137,6 +153,18 @@ func ListContainerBatch(rt *libpod.Runtime, ctr *libpod.Container, opts entities
 			return errors.Wrapf(err, "unable to obtain container pid")
 		}
 
+		restartCount, err = c.RestartCount()
+		if err != nil {
+			return errors.Wrapf(err, "unable to obtain container restart count")
+		}
+
+		if opts.Pids {
+			pidsCurrent, pidsLimit, err = c.PidsUsage()
+			if err != nil {
+				logrus.Errorf("error getting pids usage for %q: %v", c.ID(), err)
+			}
+		}
+
 		if !opts.Size && !opts.Namespace {
 			return nil
 		}
@@ -179,25 +207,29 @@ func ListContainerBatch(rt *libpod.Runtime, ctr *libpod.Container, opts entities
 	}
 
 	ps := entities.ListContainer{
-		AutoRemove: ctr.AutoRemove(),
-		Command:    conConfig.Command,
-		Created:    conConfig.CreatedTime,
-		Exited:     exited,
-		ExitCode:   exitCode,
-		ExitedAt:   exitedTime.Unix(),
-		ID:         conConfig.ID,
-		Image:      conConfig.RootfsImageName,
-		ImageID:    conConfig.RootfsImageID,
-		IsInfra:    conConfig.IsInfra,
-		Labels:     conConfig.Labels,
-		Mounts:     ctr.UserVolumes(),
-		Names:      []string{conConfig.Name},
-		Pid:        pid,
-		Pod:        conConfig.Pod,
-		Ports:      portMappings,
-		Size:       size,
-		StartedAt:  startedTime.Unix(),
-		State:      conState.String(),
+		AutoRemove:   ctr.AutoRemove(),
+		Command:      conConfig.Command,
+		Created:      conConfig.CreatedTime,
+		Exited:       exited,
+		ExitCode:     exitCode,
+		ExitedAt:     exitedTime.Unix(),
+		ID:           conConfig.ID,
+		Image:        conConfig.RootfsImageName,
+		ImageID:      conConfig.RootfsImageID,
+		IsInfra:      conConfig.IsInfra,
+		Labels:       conConfig.Labels,
+		Mounts:       ctr.UserVolumes(),
+		Names:        []string{conConfig.Name},
+		Owner:        conConfig.Owner,
+		Pid:          pid,
+		PidsCurrent:  pidsCurrent,
+		PidsLimit:    pidsLimit,
+		Pod:          conConfig.Pod,
+		Ports:        portMappings,
+		RestartCount: restartCount,
+		Size:         size,
+		StartedAt:    startedTime.Unix(),
+		State:        conState.String(),
 	}
 	if opts.Pod && len(conConfig.Pod) > 0 {
 		podName, err := rt.GetName(conConfig.Pod)
@@ -224,46 +256,23 @@ func ListContainerBatch(rt *libpod.Runtime, ctr *libpod.Container, opts entities
 	return ps, nil
 }
 
-func ListStorageContainer(rt *libpod.Runtime, ctr storage.Container, opts entities.ContainerListOptions) (entities.ListContainer, error) {
+// ListExternalContainer describes a container of the storage not created by
+// Podman.  The engine which created it is given as its command.
+func ListExternalContainer(ctr *libpod.ExternalContainer) entities.ListContainer {
 	name := "unknown"
 	if len(ctr.Names) > 0 {
 		name = ctr.Names[0]
 	}
-
-	ps := entities.ListContainer{
+	return entities.ListContainer{
+		Command: []string{ctr.Engine},
 		ID:      ctr.ID,
 		Created: ctr.Created,
+		Image:   ctr.Image,
 		ImageID: ctr.ImageID,
 		State:   "storage",
 		Names:   []string{name},
+		PodName: ctr.Pod,
 	}
-
-	buildahCtr, err := rt.IsBuildahContainer(ctr.ID)
-	if err != nil {
-		return ps, errors.Wrapf(err, "error determining buildah container for container %s", ctr.ID)
-	}
-
-	if buildahCtr {
-		ps.Command = []string{"buildah"}
-	} else {
-		ps.Command = []string{"storage"}
-	}
-
-	imageName := ""
-	if ctr.ImageID != "" {
-		names, err := rt.ImageRuntime().ImageNames(ctr.ImageID)
-		if err != nil {
-			return ps, err
-		}
-		if len(names) > 0 {
-			imageName = names[0]
-		}
-	} else if buildahCtr {
-		imageName = "scratch"
-	}
-
-	ps.Image = imageName
-	return ps, nil
 }
 
 func getNamespaceInfo(path string) (string, error) {
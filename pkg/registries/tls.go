@@ -0,0 +1,164 @@
+package registries
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/containers/image/v5/pkg/sysregistriesv2"
+	"github.com/containers/image/v5/pkg/tlsclientconfig"
+	"github.com/containers/image/v5/types"
+	"github.com/containers/storage/pkg/homedir"
+	"github.com/pkg/errors"
+)
+
+// perHostCertDirs are the directories containing the per-registry
+// certificates, in the order in which they are looked up by c/image.
+var perHostCertDirs = []string{
+	filepath.Join(homedir.Get(), ".config/containers/certs.d"),
+	"/etc/containers/certs.d",
+	"/etc/docker/certs.d",
+}
+
+// tlsCheckTimeout is the timeout for connecting to a registry.
+const tlsCheckTimeout = 30 * time.Second
+
+// TLSCheckReport describes the result of testing the TLS connection to a
+// registry.
+type TLSCheckReport struct {
+	// Registry is the host (and port) of the registry.
+	Registry string
+	// CertDir is the directory the CA and client certificates were loaded
+	// from.  Empty if only the system CA pool is used.
+	CertDir string
+	// Insecure is set if the registry is marked as insecure in
+	// registries.conf.
+	Insecure bool
+	// NotAfter is the expiry of the certificate presented by the registry.
+	NotAfter time.Time
+	// Error is set if the TLS connection or the API check failed.
+	Error error
+	// Hint explains the likely cause of Error.
+	Hint string
+}
+
+// CertDirForRegistry returns the per-registry certificate directory (e.g.,
+// /etc/containers/certs.d/quay.io) used for the specified registry.  Empty if
+// none exists.
+func CertDirForRegistry(registry string) string {
+	for _, dir := range perHostCertDirs {
+		certDir := filepath.Join(dir, registry)
+		if _, err := os.Stat(certDir); err == nil {
+			return certDir
+		}
+	}
+	return ""
+}
+
+// CheckTLS tests the TLS connection to the specified registry and whether the
+// registry serves the v2 API.  The CA and client certificates are loaded from
+// certDir or, if empty, from the per-registry certs.d directory.  Failures are
+// explained in the returned report.
+func CheckTLS(ctx context.Context, registry, certDir string) (*TLSCheckReport, error) {
+	report := &TLSCheckReport{Registry: registry}
+
+	if certDir == "" {
+		certDir = CertDirForRegistry(registry)
+	}
+	report.CertDir = certDir
+
+	sys := &types.SystemContext{SystemRegistriesConfPath: SystemRegistriesConfPath()}
+	reg, err := sysregistriesv2.FindRegistry(sys, registry)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse the registries.conf file")
+	}
+	if reg != nil {
+		report.Insecure = reg.Insecure
+	}
+
+	host, port, err := net.SplitHostPort(registry)
+	if err != nil {
+		host, port = registry, "443"
+	}
+	tlsConfig := &tls.Config{ServerName: host}
+	if certDir != "" {
+		if err := tlsclientconfig.SetupCertificates(certDir, tlsConfig); err != nil {
+			report.Error = err
+			report.Hint = fmt.Sprintf("fix the certificates in %s", certDir)
+			return report, nil
+		}
+	}
+
+	dialer := &net.Dialer{Timeout: tlsCheckTimeout}
+	conn, err := tls.DialWithDialer(dialer, "tcp", net.JoinHostPort(host, port), tlsConfig)
+	if err != nil {
+		report.Error = err
+		report.Hint = tlsErrorHint(err, host, certDir, report.Insecure)
+		return report, nil
+	}
+	if certs := conn.ConnectionState().PeerCertificates; len(certs) > 0 {
+		report.NotAfter = certs[0].NotAfter
+	}
+	conn.Close()
+
+	client := &http.Client{
+		Timeout:   tlsCheckTimeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("https://%s/v2/", net.JoinHostPort(host, port)), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		report.Error = err
+		return report, nil
+	}
+	defer resp.Body.Close()
+	// The registry may require authentication, which still proves that it
+	// serves the API.
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusUnauthorized {
+		report.Error = errors.Errorf("pinging the registry API returned %s", resp.Status)
+		report.Hint = "the server does not seem to be a container registry implementing the v2 API"
+	}
+	return report, nil
+}
+
+// tlsErrorHint explains the likely cause of a failed TLS connection.
+func tlsErrorHint(err error, host, certDir string, insecure bool) string {
+	var (
+		unknownAuthority x509.UnknownAuthorityError
+		invalidCert      x509.CertificateInvalidError
+		hostnameErr      x509.HostnameError
+		recordHeaderErr  tls.RecordHeaderError
+		opErr            *net.OpError
+	)
+	switch {
+	case errors.As(err, &unknownAuthority):
+		dir := certDir
+		if dir == "" {
+			dir = filepath.Join("/etc/containers/certs.d", host)
+		}
+		return fmt.Sprintf("the certificate is signed by an unknown authority; add the CA certificate as a *.crt file to %s", dir)
+	case errors.As(err, &invalidCert) && invalidCert.Reason == x509.Expired:
+		return "the certificate of the registry or of its CA has expired or is not yet valid; renew it or check the system clock"
+	case errors.As(err, &invalidCert):
+		return "the certificate of the registry is invalid"
+	case errors.As(err, &hostnameErr):
+		return fmt.Sprintf("the certificate is not valid for %q; use the host name the certificate was issued for", host)
+	case errors.As(err, &recordHeaderErr):
+		if insecure {
+			return "the registry does not speak TLS on this port; it is marked as insecure in registries.conf, so plain HTTP will be used"
+		}
+		return "the registry does not speak TLS on this port; if it uses plain HTTP, mark it as insecure in registries.conf or use --tls-verify=false"
+	case errors.As(err, &opErr):
+		return "cannot connect to the registry; check the host name, the port and the network"
+	}
+	return ""
+}
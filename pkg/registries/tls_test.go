@@ -0,0 +1,58 @@
+package registries
+
+import (
+	"context"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckTLS(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+	tlsServer := httptest.NewTLSServer(handler)
+	defer tlsServer.Close()
+	tlsRegistry := strings.TrimPrefix(tlsServer.URL, "https://")
+
+	// The CA of the test server is unknown.
+	emptyDir, err := ioutil.TempDir("", "certs")
+	require.NoError(t, err)
+	defer os.RemoveAll(emptyDir)
+	report, err := CheckTLS(context.Background(), tlsRegistry, emptyDir)
+	require.NoError(t, err)
+	assert.Error(t, report.Error)
+	assert.Contains(t, report.Hint, "unknown authority")
+
+	// With the CA in the certificate directory, the check succeeds.
+	certDir, err := ioutil.TempDir("", "certs")
+	require.NoError(t, err)
+	defer os.RemoveAll(certDir)
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tlsServer.Certificate().Raw})
+	require.NoError(t, ioutil.WriteFile(filepath.Join(certDir, "ca.crt"), caPEM, 0600))
+	report, err = CheckTLS(context.Background(), tlsRegistry, certDir)
+	require.NoError(t, err)
+	assert.NoError(t, report.Error)
+	assert.Equal(t, certDir, report.CertDir)
+	assert.False(t, report.NotAfter.IsZero())
+
+	// A registry serving plain HTTP.
+	httpServer := httptest.NewServer(handler)
+	defer httpServer.Close()
+	report, err = CheckTLS(context.Background(), strings.TrimPrefix(httpServer.URL, "http://"), emptyDir)
+	require.NoError(t, err)
+	assert.Error(t, report.Error)
+	assert.Contains(t, report.Hint, "does not speak TLS")
+}
@@ -250,7 +250,11 @@ outer:
 
 func exposePorts(pm rkport.Manager, portMappings []ocicni.PortMapping) error {
 	ctx := context.TODO()
-	for _, i := range portMappings {
+	// Validate all the specs against each other first, so a conflict in a
+	// large range is reported before any of its ports is exposed.
+	specs := make([]rkport.Spec, 0, len(portMappings))
+	existing := make(map[int]*rkport.Status, len(portMappings))
+	for id, i := range portMappings {
 		hostIP := i.HostIP
 		if hostIP == "" {
 			hostIP = "0.0.0.0"
@@ -261,9 +265,13 @@ func exposePorts(pm rkport.Manager, portMappings []ocicni.PortMapping) error {
 			ParentPort: int(i.HostPort),
 			ChildPort:  int(i.ContainerPort),
 		}
-		if err := rkportutil.ValidatePortSpec(spec, nil); err != nil {
+		if err := rkportutil.ValidatePortSpec(spec, existing); err != nil {
 			return err
 		}
+		existing[id] = &rkport.Status{ID: id, Spec: spec}
+		specs = append(specs, spec)
+	}
+	for _, spec := range specs {
 		if _, err := pm.AddPort(ctx, spec); err != nil {
 			return err
 		}
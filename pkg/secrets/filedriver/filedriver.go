@@ -0,0 +1,134 @@
+// Package filedriver implements a secrets driver which stores the data of
+// the secrets unencrypted in a file only readable by its owner.
+package filedriver
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/containers/storage/pkg/lockfile"
+	"github.com/pkg/errors"
+)
+
+// secretsDataFile is the file where the data of the secrets is stored.
+const secretsDataFile = "secretsdata.json"
+
+// errNoSecretData is returned if no data is stored for a secret.
+var errNoSecretData = errors.New("no secret data with ID")
+
+// errSecretIDExists is returned if data is already stored for a secret.
+var errSecretIDExists = errors.New("secret data with ID already exists")
+
+// Driver is the file secrets driver.
+type Driver struct {
+	// secretsDataFilePath is the path to the file holding the data.
+	secretsDataFilePath string
+	// lockfile serializes the access to the data file.
+	lockfile lockfile.Locker
+}
+
+// NewDriver creates a new file driver storing the data of the secrets in
+// rootPath, which is created if it does not exist.
+func NewDriver(rootPath string) (*Driver, error) {
+	if err := os.MkdirAll(rootPath, 0700); err != nil {
+		return nil, errors.Wrapf(err, "error creating secrets directory %s", rootPath)
+	}
+	lock, err := lockfile.GetLockfile(filepath.Join(rootPath, "secretsdata.lock"))
+	if err != nil {
+		return nil, err
+	}
+	return &Driver{
+		secretsDataFilePath: filepath.Join(rootPath, secretsDataFile),
+		lockfile:            lock,
+	}, nil
+}
+
+// List returns the IDs of all secrets the driver stores data for.
+func (d *Driver) List() ([]string, error) {
+	d.lockfile.Lock()
+	defer d.lockfile.Unlock()
+	secretData, err := d.getAllData()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(secretData))
+	for id := range secretData {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// Lookup returns the data of the secret with the given ID.
+func (d *Driver) Lookup(id string) ([]byte, error) {
+	d.lockfile.Lock()
+	defer d.lockfile.Unlock()
+	secretData, err := d.getAllData()
+	if err != nil {
+		return nil, err
+	}
+	data, ok := secretData[id]
+	if !ok {
+		return nil, errors.Wrapf(errNoSecretData, "%s", id)
+	}
+	return data, nil
+}
+
+// Store stores the data of the secret with the given ID.
+func (d *Driver) Store(id string, data []byte) error {
+	d.lockfile.Lock()
+	defer d.lockfile.Unlock()
+	secretData, err := d.getAllData()
+	if err != nil {
+		return err
+	}
+	if _, ok := secretData[id]; ok {
+		return errors.Wrapf(errSecretIDExists, "%s", id)
+	}
+	secretData[id] = data
+	return d.writeAllData(secretData)
+}
+
+// Delete deletes the data of the secret with the given ID.
+func (d *Driver) Delete(id string) error {
+	d.lockfile.Lock()
+	defer d.lockfile.Unlock()
+	secretData, err := d.getAllData()
+	if err != nil {
+		return err
+	}
+	if _, ok := secretData[id]; !ok {
+		return errors.Wrapf(errNoSecretData, "%s", id)
+	}
+	delete(secretData, id)
+	return d.writeAllData(secretData)
+}
+
+// getAllData reads the data of all secrets.  The caller must hold the lock.
+func (d *Driver) getAllData() (map[string][]byte, error) {
+	secretData := make(map[string][]byte)
+	content, err := ioutil.ReadFile(d.secretsDataFilePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return secretData, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &secretData); err != nil {
+		return nil, errors.Wrapf(err, "error parsing %s", d.secretsDataFilePath)
+	}
+	return secretData, nil
+}
+
+// writeAllData writes the data of all secrets.  The caller must hold the
+// lock.
+func (d *Driver) writeAllData(secretData map[string][]byte) error {
+	content, err := json.Marshal(secretData)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(d.secretsDataFilePath, content, 0600)
+}
@@ -0,0 +1,333 @@
+// Package secrets implements a store of secrets, which can be made available
+// to containers without being part of their image or configuration.  The
+// metadata of the secrets is kept by the SecretsManager while their data is
+// kept by a pluggable driver.
+package secrets
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v2/pkg/secrets/filedriver"
+	"github.com/containers/storage/pkg/lockfile"
+	"github.com/containers/storage/pkg/stringid"
+	"github.com/pkg/errors"
+)
+
+// MaxSecretSize is the size limit of the data of a secret.
+const MaxSecretSize = 512000
+
+// secretsFile is the file where the metadata of the secrets is stored.
+const secretsFile = "secrets.json"
+
+// FileDriver is the name of the driver storing secrets in a file.
+const FileDriver = "file"
+
+var (
+	// ErrNoSuchSecret indicates that a secret does not exist.
+	ErrNoSuchSecret = errors.New("no such secret")
+	// ErrSecretNameInUse indicates that a secret with the name exists
+	// already.
+	ErrSecretNameInUse = errors.New("secret name in use")
+	// ErrInvalidSecretName indicates that a secret name is not valid.
+	ErrInvalidSecretName = errors.New("invalid secret name")
+	// ErrInvalidSecretData indicates that the data of a secret is not
+	// valid.
+	ErrInvalidSecretData = errors.New("invalid secret data")
+	// ErrAmbiguousSecret indicates that a secret ID prefix matches more
+	// than one secret.
+	ErrAmbiguousSecret = errors.New("more than one result for secret ID")
+	// ErrUnknownDriver indicates that a secrets driver is not known.
+	ErrUnknownDriver = errors.New("unknown secrets driver")
+	// ErrSecretInUse indicates that a secret cannot be removed as it is
+	// used by containers.
+	ErrSecretInUse = errors.New("secret is being used")
+)
+
+// secretNameRegexp matches valid secret names.
+var secretNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// Secret holds the metadata of a secret.
+type Secret struct {
+	// Name is the name of the secret.
+	Name string `json:"name"`
+	// ID is the unique ID of the secret.
+	ID string `json:"id"`
+	// CreatedAt is the time the secret was created at.
+	CreatedAt time.Time `json:"createdAt"`
+	// Driver is the name of the driver storing the data of the secret.
+	Driver string `json:"driver"`
+	// DriverOptions are the options of the driver.
+	DriverOptions map[string]string `json:"driverOptions"`
+}
+
+// SecretsDriver stores the data of secrets.  Secrets are identified by their
+// ID.
+type SecretsDriver interface {
+	// List returns the IDs of all secrets the driver stores data for.
+	List() ([]string, error)
+	// Lookup returns the data of a secret.
+	Lookup(id string) ([]byte, error)
+	// Store stores the data of a secret.
+	Store(id string, data []byte) error
+	// Delete deletes the data of a secret.
+	Delete(id string) error
+}
+
+// SecretsManager manages the secrets stored below its root directory.
+type SecretsManager struct {
+	// rootPath is the directory holding the metadata and, for the file
+	// driver, the data of the secrets.
+	rootPath string
+	// secretsFilePath is the path to the metadata of the secrets.
+	secretsFilePath string
+	// lockfile serializes the access to the secrets.
+	lockfile lockfile.Locker
+}
+
+// NewManager creates a new SecretsManager storing the secrets in rootPath,
+// which is created if it does not exist.
+func NewManager(rootPath string) (*SecretsManager, error) {
+	if !filepath.IsAbs(rootPath) {
+		return nil, errors.Errorf("path of the secrets directory must be absolute: %s", rootPath)
+	}
+	if err := os.MkdirAll(rootPath, 0700); err != nil {
+		return nil, errors.Wrapf(err, "error creating secrets directory %s", rootPath)
+	}
+	lock, err := lockfile.GetLockfile(filepath.Join(rootPath, "secrets.lock"))
+	if err != nil {
+		return nil, err
+	}
+	return &SecretsManager{
+		rootPath:        rootPath,
+		secretsFilePath: filepath.Join(rootPath, secretsFile),
+		lockfile:        lock,
+	}, nil
+}
+
+// Store stores a new secret with the given name and data using the named
+// driver, and returns the ID of the secret.
+func (s *SecretsManager) Store(name string, data []byte, driverType string, driverOpts map[string]string) (string, error) {
+	if err := validateSecretName(name); err != nil {
+		return "", err
+	}
+	if len(data) == 0 || len(data) >= MaxSecretSize {
+		return "", errors.Wrapf(ErrInvalidSecretData, "secret data must be larger than 0 and less than %d bytes", MaxSecretSize)
+	}
+	if driverType == "" {
+		driverType = FileDriver
+	}
+	if driverOpts == nil {
+		driverOpts = make(map[string]string)
+	}
+
+	s.lockfile.Lock()
+	defer s.lockfile.Unlock()
+
+	secrets, err := s.loadSecrets()
+	if err != nil {
+		return "", err
+	}
+	for _, secret := range secrets {
+		if secret.Name == name {
+			return "", errors.Wrapf(ErrSecretNameInUse, "%s", name)
+		}
+	}
+
+	var id string
+	for {
+		id = stringid.GenerateNonCryptoID()
+		if _, ok := secrets[id]; !ok {
+			break
+		}
+	}
+
+	driver, err := s.getDriver(driverType, driverOpts)
+	if err != nil {
+		return "", err
+	}
+	if err := driver.Store(id, data); err != nil {
+		return "", errors.Wrapf(err, "error storing data of secret %s", name)
+	}
+
+	secrets[id] = &Secret{
+		Name:          name,
+		ID:            id,
+		CreatedAt:     time.Now(),
+		Driver:        driverType,
+		DriverOptions: driverOpts,
+	}
+	if err := s.saveSecrets(secrets); err != nil {
+		if err2 := driver.Delete(id); err2 != nil {
+			err = errors.Wrapf(err, "error deleting data of secret %s: %v", name, err2)
+		}
+		return "", err
+	}
+	return id, nil
+}
+
+// Delete deletes the secret with the given name, ID or unique ID prefix, and
+// returns its ID.
+func (s *SecretsManager) Delete(nameOrID string) (string, error) {
+	s.lockfile.Lock()
+	defer s.lockfile.Unlock()
+
+	secrets, err := s.loadSecrets()
+	if err != nil {
+		return "", err
+	}
+	secret, err := lookupSecret(secrets, nameOrID)
+	if err != nil {
+		return "", err
+	}
+	driver, err := s.getDriver(secret.Driver, secret.DriverOptions)
+	if err != nil {
+		return "", err
+	}
+	if err := driver.Delete(secret.ID); err != nil {
+		return "", errors.Wrapf(err, "error deleting data of secret %s", secret.Name)
+	}
+	delete(secrets, secret.ID)
+	if err := s.saveSecrets(secrets); err != nil {
+		return "", err
+	}
+	return secret.ID, nil
+}
+
+// Lookup returns the metadata of the secret with the given name, ID or unique
+// ID prefix.
+func (s *SecretsManager) Lookup(nameOrID string) (*Secret, error) {
+	s.lockfile.Lock()
+	defer s.lockfile.Unlock()
+
+	secrets, err := s.loadSecrets()
+	if err != nil {
+		return nil, err
+	}
+	return lookupSecret(secrets, nameOrID)
+}
+
+// LookupSecretData returns the metadata and the data of the secret with the
+// given name, ID or unique ID prefix.
+func (s *SecretsManager) LookupSecretData(nameOrID string) (*Secret, []byte, error) {
+	s.lockfile.Lock()
+	defer s.lockfile.Unlock()
+
+	secrets, err := s.loadSecrets()
+	if err != nil {
+		return nil, nil, err
+	}
+	secret, err := lookupSecret(secrets, nameOrID)
+	if err != nil {
+		return nil, nil, err
+	}
+	driver, err := s.getDriver(secret.Driver, secret.DriverOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := driver.Lookup(secret.ID)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "error reading data of secret %s", secret.Name)
+	}
+	return secret, data, nil
+}
+
+// List returns the metadata of all secrets sorted by name.
+func (s *SecretsManager) List() ([]Secret, error) {
+	s.lockfile.Lock()
+	defer s.lockfile.Unlock()
+
+	secrets, err := s.loadSecrets()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]Secret, 0, len(secrets))
+	for _, secret := range secrets {
+		list = append(list, *secret)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list, nil
+}
+
+// getDriver returns the driver with the given name and options.
+func (s *SecretsManager) getDriver(name string, opts map[string]string) (SecretsDriver, error) {
+	switch name {
+	case FileDriver:
+		path, ok := opts["path"]
+		if !ok {
+			path = filepath.Join(s.rootPath, "filedriver")
+		}
+		return filedriver.NewDriver(path)
+	default:
+		return nil, errors.Wrapf(ErrUnknownDriver, "%s", name)
+	}
+}
+
+// loadSecrets reads the metadata of all secrets, mapped by their ID.  The
+// caller must hold the lock.
+func (s *SecretsManager) loadSecrets() (map[string]*Secret, error) {
+	secrets := make(map[string]*Secret)
+	content, err := ioutil.ReadFile(s.secretsFilePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return secrets, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &secrets); err != nil {
+		return nil, errors.Wrapf(err, "error parsing %s", s.secretsFilePath)
+	}
+	return secrets, nil
+}
+
+// saveSecrets writes the metadata of all secrets.  The caller must hold the
+// lock.
+func (s *SecretsManager) saveSecrets(secrets map[string]*Secret) error {
+	content, err := json.MarshalIndent(secrets, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(s.secretsFilePath, content, 0600)
+}
+
+// lookupSecret returns the secret with the given name, ID or unique ID prefix.
+func lookupSecret(secrets map[string]*Secret, nameOrID string) (*Secret, error) {
+	if nameOrID == "" {
+		return nil, errors.Wrapf(ErrNoSuchSecret, "no secret name or ID given")
+	}
+	if secret, ok := secrets[nameOrID]; ok {
+		return secret, nil
+	}
+	for _, secret := range secrets {
+		if secret.Name == nameOrID {
+			return secret, nil
+		}
+	}
+	var found *Secret
+	for id, secret := range secrets {
+		if strings.HasPrefix(id, nameOrID) {
+			if found != nil {
+				return nil, errors.Wrapf(ErrAmbiguousSecret, "%s", nameOrID)
+			}
+			found = secret
+		}
+	}
+	if found == nil {
+		return nil, errors.Wrapf(ErrNoSuchSecret, "%s", nameOrID)
+	}
+	return found, nil
+}
+
+// validateSecretName checks that the name of a secret is valid.
+func validateSecretName(name string) error {
+	if len(name) == 0 || len(name) > 253 || !secretNameRegexp.MatchString(name) {
+		return errors.Wrapf(ErrInvalidSecretName, "%q must be 1 to 253 characters long, start with a letter or digit and may only contain letters, digits, '_', '.' and '-'", name)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package secrets
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setup(t *testing.T) (*SecretsManager, func()) {
+	testpath, err := ioutil.TempDir("", "secretsdata")
+	require.NoError(t, err)
+	manager, err := NewManager(testpath)
+	require.NoError(t, err)
+	return manager, func() { os.RemoveAll(testpath) }
+}
+
+func TestStoreAndLookupSecret(t *testing.T) {
+	manager, cleanup := setup(t)
+	defer cleanup()
+
+	id, err := manager.Store("mysecret", []byte("mydata"), "", nil)
+	require.NoError(t, err)
+
+	for _, nameOrID := range []string{"mysecret", id, id[:12]} {
+		secret, data, err := manager.LookupSecretData(nameOrID)
+		require.NoError(t, err)
+		assert.Equal(t, "mysecret", secret.Name)
+		assert.Equal(t, id, secret.ID)
+		assert.Equal(t, FileDriver, secret.Driver)
+		assert.Equal(t, []byte("mydata"), data)
+	}
+
+	_, err = manager.Lookup("nosecret")
+	assert.Equal(t, ErrNoSuchSecret, errors.Cause(err))
+}
+
+func TestStoreSecretNameInUse(t *testing.T) {
+	manager, cleanup := setup(t)
+	defer cleanup()
+
+	_, err := manager.Store("mysecret", []byte("mydata"), "", nil)
+	require.NoError(t, err)
+	_, err = manager.Store("mysecret", []byte("otherdata"), "", nil)
+	assert.Equal(t, ErrSecretNameInUse, errors.Cause(err))
+}
+
+func TestStoreInvalidSecret(t *testing.T) {
+	manager, cleanup := setup(t)
+	defer cleanup()
+
+	for _, name := range []string{"", "-secret", "my/secret", "my secret", strings.Repeat("a", 254)} {
+		_, err := manager.Store(name, []byte("mydata"), "", nil)
+		assert.Equal(t, ErrInvalidSecretName, errors.Cause(err), name)
+	}
+
+	_, err := manager.Store("mysecret", []byte{}, "", nil)
+	assert.Equal(t, ErrInvalidSecretData, errors.Cause(err))
+	_, err = manager.Store("mysecret", make([]byte, MaxSecretSize), "", nil)
+	assert.Equal(t, ErrInvalidSecretData, errors.Cause(err))
+
+	_, err = manager.Store("mysecret", []byte("mydata"), "vault", nil)
+	assert.Equal(t, ErrUnknownDriver, errors.Cause(err))
+
+	secrets, err := manager.List()
+	require.NoError(t, err)
+	assert.Len(t, secrets, 0)
+}
+
+func TestDeleteSecret(t *testing.T) {
+	manager, cleanup := setup(t)
+	defer cleanup()
+
+	id, err := manager.Store("mysecret", []byte("mydata"), "", nil)
+	require.NoError(t, err)
+	_, err = manager.Store("othersecret", []byte("otherdata"), "", nil)
+	require.NoError(t, err)
+
+	deleted, err := manager.Delete("mysecret")
+	require.NoError(t, err)
+	assert.Equal(t, id, deleted)
+
+	_, err = manager.Delete("mysecret")
+	assert.Equal(t, ErrNoSuchSecret, errors.Cause(err))
+
+	secrets, err := manager.List()
+	require.NoError(t, err)
+	require.Len(t, secrets, 1)
+	assert.Equal(t, "othersecret", secrets[0].Name)
+
+	// The name can be reused once the secret is deleted.
+	_, err = manager.Store("mysecret", []byte("newdata"), "", nil)
+	require.NoError(t, err)
+	_, data, err := manager.LookupSecretData("mysecret")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("newdata"), data)
+}
+
+func TestListSecretsSorted(t *testing.T) {
+	manager, cleanup := setup(t)
+	defer cleanup()
+
+	for _, name := range []string{"b", "c", "a"} {
+		_, err := manager.Store(name, []byte("mydata"), "", nil)
+		require.NoError(t, err)
+	}
+	secrets, err := manager.List()
+	require.NoError(t, err)
+	require.Len(t, secrets, 3)
+	assert.Equal(t, "a", secrets[0].Name)
+	assert.Equal(t, "b", secrets[1].Name)
+	assert.Equal(t, "c", secrets[2].Name)
+}
@@ -39,3 +39,20 @@ func ParseSignalNameOrNumber(rawSignal string) (syscall.Signal, error) {
 	}
 	return -1, fmt.Errorf("invalid signal: %s", basename)
 }
+
+// IsSignalIgnoredBySigProxy determines whether sig-proxy should ignore the
+// given signal. SIGCHLD and SIGPIPE are most likely intended for the podman
+// command itself. SIGURG was added because of golang 1.14 and its preemptive
+// changes causing more signals to "show up".
+// https://github.com/containers/podman/issues/5483
+func IsSignalIgnoredBySigProxy(s syscall.Signal) bool {
+	return s == SIGCHLD || s == SIGPIPE || s == SIGURG
+}
+
+// IsTerminalSignal determines whether the given signal is generated by the
+// terminal podman is attached to. When attached to a container with a TTY,
+// these are handled through the terminal itself (resize events and raw
+// input), so sig-proxy should not forward them.
+func IsTerminalSignal(s syscall.Signal) bool {
+	return s == SIGWINCH || s == SIGTSTP || s == SIGTTIN || s == SIGTTOU
+}
@@ -21,6 +21,12 @@ const (
 	sigrtmax = 64
 
 	SIGWINCH = syscall.SIGWINCH // For cross-compilation with Windows
+	SIGCHLD  = syscall.SIGCHLD
+	SIGPIPE  = syscall.SIGPIPE
+	SIGURG   = syscall.SIGURG
+	SIGTSTP  = syscall.SIGTSTP
+	SIGTTIN  = syscall.SIGTTIN
+	SIGTTOU  = syscall.SIGTTOU
 )
 
 // signalMap is a map of Linux signals.
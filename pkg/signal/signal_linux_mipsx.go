@@ -19,6 +19,14 @@ import (
 const (
 	sigrtmin = 34
 	sigrtmax = 127
+
+	SIGWINCH = syscall.SIGWINCH
+	SIGCHLD  = syscall.SIGCHLD
+	SIGPIPE  = syscall.SIGPIPE
+	SIGURG   = syscall.SIGURG
+	SIGTSTP  = syscall.SIGTSTP
+	SIGTTIN  = syscall.SIGTTIN
+	SIGTTOU  = syscall.SIGTTOU
 )
 
 // signalMap is a map of Linux signals.
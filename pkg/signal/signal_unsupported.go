@@ -13,6 +13,12 @@ const (
 	sigrtmax = 64
 
 	SIGWINCH = syscall.Signal(0xff)
+	SIGCHLD  = syscall.Signal(0x11)
+	SIGPIPE  = syscall.Signal(0xd)
+	SIGURG   = syscall.Signal(0x17)
+	SIGTSTP  = syscall.Signal(0x14)
+	SIGTTIN  = syscall.Signal(0x15)
+	SIGTTOU  = syscall.Signal(0x16)
 )
 
 // signalMap is a map of Linux signals.
@@ -31,10 +31,10 @@ func exclusiveOptions(opt1, opt2 string) error {
 func (s *SpecGenerator) Validate() error {
 
 	if rootless.IsRootless() {
-		if s.StaticIP != nil || s.StaticIPv6 != nil {
+		if s.StaticIP != nil || s.StaticIPv6 != nil || len(s.StaticIPs) > 0 {
 			return ErrNoStaticIPRootless
 		}
-		if s.StaticMAC != nil {
+		if s.StaticMAC != nil || len(s.StaticMACs) > 0 {
 			return ErrNoStaticMACRootless
 		}
 	}
@@ -42,10 +42,10 @@ func (s *SpecGenerator) Validate() error {
 	// Containers being added to a pod cannot have certain network attributes
 	// associated with them because those should be on the infra container.
 	if len(s.Pod) > 0 && s.NetNS.NSMode == FromPod {
-		if s.StaticIP != nil || s.StaticIPv6 != nil {
+		if s.StaticIP != nil || s.StaticIPv6 != nil || len(s.StaticIPs) > 0 {
 			return errors.Wrap(define.ErrNetworkOnPodContainer, "static ip addresses must be defined when the pod is created")
 		}
-		if s.StaticMAC != nil {
+		if s.StaticMAC != nil || len(s.StaticMACs) > 0 {
 			return errors.Wrap(define.ErrNetworkOnPodContainer, "MAC addresses must be defined when the pod is created")
 		}
 		if len(s.CNINetworks) > 0 {
@@ -78,6 +78,15 @@ func (s *SpecGenerator) Validate() error {
 	if len(s.ContainerBasicConfig.SdNotifyMode) > 0 && !util.StringInSlice(strings.ToLower(s.ContainerBasicConfig.SdNotifyMode), SdNotifyModeValues) {
 		return errors.Wrapf(ErrInvalidSpecConfig, "--sdnotify values must be one of %q", strings.Join(SdNotifyModeValues, ", "))
 	}
+	// init containers must be "always" or "once" and be part of a pod
+	if len(s.ContainerBasicConfig.InitContainerType) > 0 {
+		if !util.StringInSlice(s.ContainerBasicConfig.InitContainerType, []string{define.AlwaysInitContainer, define.OneShotInitContainer}) {
+			return errors.Wrapf(ErrInvalidSpecConfig, "init container type must be %q or %q", define.AlwaysInitContainer, define.OneShotInitContainer)
+		}
+		if len(s.Pod) == 0 {
+			return errors.Wrap(ErrInvalidSpecConfig, "init containers must be part of a pod")
+		}
+	}
 
 	//
 	// ContainerStorageConfig
@@ -133,6 +142,10 @@ func (s *SpecGenerator) Validate() error {
 	if s.UseImageHosts && len(s.HostAdd) > 0 {
 		return exclusiveOptions("UseImageHosts", "HostAdd")
 	}
+	// UseImageHosts and BaseHostsFile are exclusive
+	if s.UseImageHosts && s.BaseHostsFile != "" {
+		return exclusiveOptions("UseImageHosts", "BaseHostsFile")
+	}
 
 	// TODO the specgen does not appear to handle this?  Should it
 	//switch config.Cgroup.Cgroups {
@@ -0,0 +1,189 @@
+package generate
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v2/libpod"
+	"github.com/containers/podman/v2/pkg/specgen"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// BatchIndexPlaceholder is replaced with the index of a container in
+	// the name pattern of a batch of containers.
+	BatchIndexPlaceholder = "%d"
+	// MaxBatchCount is the maximum number of containers created in one
+	// batch.
+	MaxBatchCount = 1000
+)
+
+// MakeContainerBatch creates count containers from the template s.  The
+// containers are added to the database batchSize at a time, each batch in a
+// single transaction; a batchSize of 0 adds all of them at once.  If a batch
+// fails, the containers of the earlier batches are removed again, so either
+// all containers are created or none.  See BatchSpec for how the containers
+// differ.
+func MakeContainerBatch(ctx context.Context, rt *libpod.Runtime, s *specgen.SpecGenerator, count uint, namePattern string, batchSize uint) (_ []*libpod.Container, retErr error) {
+	if s.Pod != "" {
+		return nil, errors.Wrapf(specgen.ErrInvalidSpecConfig, "containers in a pod cannot be created in a batch")
+	}
+	if count == 0 || count > MaxBatchCount {
+		return nil, errors.Wrapf(specgen.ErrInvalidSpecConfig, "count must be between 1 and %d", MaxBatchCount)
+	}
+	unlock, err := lockPortAllocation(rt, s.PortMappings, s.PublishExposedPorts)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	if batchSize == 0 || batchSize > count {
+		batchSize = count
+	}
+
+	ctrs := make([]*libpod.Container, 0, count)
+	defer func() {
+		if retErr == nil {
+			return
+		}
+		for _, ctr := range ctrs {
+			if err := rt.RemoveContainer(ctx, ctr, true, true); err != nil {
+				logrus.Errorf("Error removing container %s after creating its batch failed: %v", ctr.ID(), err)
+			}
+		}
+	}()
+	for first := uint(0); first < count; first += batchSize {
+		last := first + batchSize
+		if last > count {
+			last = count
+		}
+		entries := make([]libpod.CtrBatchEntry, 0, last-first)
+		for i := first; i < last; i++ {
+			ctrSpec, err := BatchSpec(s, namePattern, i)
+			if err != nil {
+				return nil, err
+			}
+			runtimeSpec, _, _, options, err := makeContainerSpec(ctx, rt, ctrSpec)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error generating spec of container %d", i)
+			}
+			entries = append(entries, libpod.CtrBatchEntry{Spec: runtimeSpec, Options: options})
+		}
+		batch, err := rt.NewContainers(ctx, entries)
+		if err != nil {
+			return nil, err
+		}
+		ctrs = append(ctrs, batch...)
+	}
+	return ctrs, nil
+}
+
+// BatchSpec returns the spec of the container with the given index in a batch
+// created from the template s.  The index, starting at 0, replaces
+// BatchIndexPlaceholder in namePattern and is added to the host ports, the
+// static IP addresses and the static MAC address of the template, so every
+// container of the batch gets its own.  An empty namePattern keeps the name of
+// the template, which must then be empty so names are generated.
+func BatchSpec(s *specgen.SpecGenerator, namePattern string, index uint) (*specgen.SpecGenerator, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	ctrSpec := new(specgen.SpecGenerator)
+	if err := json.Unmarshal(b, ctrSpec); err != nil {
+		return nil, err
+	}
+
+	if namePattern != "" {
+		if strings.Count(namePattern, BatchIndexPlaceholder) != 1 {
+			return nil, errors.Wrapf(specgen.ErrInvalidSpecConfig, "name pattern %q must contain %q exactly once", namePattern, BatchIndexPlaceholder)
+		}
+		ctrSpec.Name = strings.Replace(namePattern, BatchIndexPlaceholder, strconv.FormatUint(uint64(index), 10), 1)
+	} else if s.Name != "" && index > 0 {
+		return nil, errors.Wrapf(specgen.ErrInvalidSpecConfig, "a name pattern is required to create multiple containers named %q", s.Name)
+	}
+
+	for i, port := range ctrSpec.PortMappings {
+		if port.HostPort == 0 {
+			continue
+		}
+		step := uint64(port.Range)
+		if step == 0 {
+			step = 1
+		}
+		hostPort := uint64(port.HostPort) + uint64(index)*step
+		if hostPort+step-1 > 65535 {
+			return nil, errors.Wrapf(specgen.ErrInvalidSpecConfig, "host port %d of container %d is out of range", hostPort, index)
+		}
+		ctrSpec.PortMappings[i].HostPort = uint16(hostPort)
+	}
+
+	if s.StaticIP != nil {
+		ip, err := addToIP(*s.StaticIP, index)
+		if err != nil {
+			return nil, err
+		}
+		ctrSpec.StaticIP = &ip
+	}
+	if s.StaticIPv6 != nil {
+		ip, err := addToIP(*s.StaticIPv6, index)
+		if err != nil {
+			return nil, err
+		}
+		ctrSpec.StaticIPv6 = &ip
+	}
+	if s.StaticMAC != nil {
+		mac, ok := addToBytes(*s.StaticMAC, index)
+		if !ok {
+			return nil, errors.Wrapf(specgen.ErrInvalidSpecConfig, "static MAC address of container %d is out of range", index)
+		}
+		hwAddr := net.HardwareAddr(mac)
+		ctrSpec.StaticMAC = &hwAddr
+	}
+	for netName, staticIP := range s.StaticIPs {
+		ip, err := addToIP(staticIP, index)
+		if err != nil {
+			return nil, err
+		}
+		ctrSpec.StaticIPs[netName] = ip
+	}
+	for netName, staticMAC := range s.StaticMACs {
+		mac, ok := addToBytes(staticMAC, index)
+		if !ok {
+			return nil, errors.Wrapf(specgen.ErrInvalidSpecConfig, "static MAC address of container %d in network %s is out of range", index, netName)
+		}
+		ctrSpec.StaticMACs[netName] = net.HardwareAddr(mac)
+	}
+	ctrSpec.PreserveFDs = s.PreserveFDs
+	return ctrSpec, nil
+}
+
+// addToIP returns ip incremented by n.
+func addToIP(ip net.IP, n uint) (net.IP, error) {
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	res, ok := addToBytes(ip, n)
+	if !ok {
+		return nil, errors.Wrapf(specgen.ErrInvalidSpecConfig, "IP address %s incremented by %d is out of range", ip, n)
+	}
+	return res, nil
+}
+
+// addToBytes interprets b as a big-endian number and returns it incremented by
+// n.  It returns false if the result does not fit in len(b) bytes.
+func addToBytes(b []byte, n uint) ([]byte, bool) {
+	sum := new(big.Int).SetBytes(b)
+	sum.Add(sum, new(big.Int).SetUint64(uint64(n)))
+	sumBytes := sum.Bytes()
+	if len(sumBytes) > len(b) {
+		return nil, false
+	}
+	res := make([]byte, len(b))
+	copy(res[len(b)-len(sumBytes):], sumBytes)
+	return res, true
+}
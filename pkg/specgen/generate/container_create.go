@@ -8,10 +8,13 @@ import (
 
 	"github.com/containers/common/pkg/config"
 	"github.com/containers/podman/v2/libpod"
+	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/libpod/image"
+	"github.com/containers/podman/v2/pkg/secrets"
 	"github.com/containers/podman/v2/pkg/specgen"
 	"github.com/containers/podman/v2/pkg/util"
 	"github.com/containers/storage"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/opencontainers/selinux/go-selinux/label"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -21,65 +24,121 @@ import (
 // Returns the created, container and any warnings resulting from creating the
 // container, or an error.
 func MakeContainer(ctx context.Context, rt *libpod.Runtime, s *specgen.SpecGenerator) (*libpod.Container, error) {
-	rtc, err := rt.GetConfig()
+	unlock, err := lockPortAllocation(rt, s.PortMappings, s.PublishExposedPorts)
 	if err != nil {
 		return nil, err
 	}
+	defer unlock()
+	// The first container created in a pod without infra container holds
+	// the namespaces shared by the pod.  If another container became the
+	// holder while the container was generated, generate it again from
+	// the original spec so that it joins the namespaces of the holder.
+	orig := *s
+	for attempt := 0; ; attempt++ {
+		runtimeSpec, _, _, options, err := makeContainerSpec(ctx, rt, s)
+		if err != nil {
+			return nil, err
+		}
+		ctr, err := rt.NewContainer(ctx, runtimeSpec, options...)
+		if errors.Cause(err) == define.ErrPodNamespaceHolderExists && attempt < maxNamespaceHolderAttempts {
+			logrus.Debugf("Generating container again to join the pod namespaces: %v", err)
+			*s = orig
+			continue
+		}
+		return ctr, err
+	}
+}
+
+// maxNamespaceHolderAttempts is how many times a container is generated again
+// when another container became the holder of the namespaces of its pod.
+const maxNamespaceHolderAttempts = 3
+
+// DryRunContainer performs the same validation and spec generation as
+// MakeContainer but does not create the container.  Neither the storage nor
+// the OCI runtime are touched.  Returns the OCI spec the container would be
+// created with and the named and overlay volumes which would be mounted into
+// it.
+func DryRunContainer(ctx context.Context, rt *libpod.Runtime, s *specgen.SpecGenerator) (*spec.Spec, []*specgen.NamedVolume, []*specgen.OverlayVolume, error) {
+	runtimeSpec, volumes, overlays, _, err := makeContainerSpec(ctx, rt, s)
+	return runtimeSpec, volumes, overlays, err
+}
+
+func makeContainerSpec(ctx context.Context, rt *libpod.Runtime, s *specgen.SpecGenerator) (*spec.Spec, []*specgen.NamedVolume, []*specgen.OverlayVolume, []libpod.CtrCreateOption, error) {
+	rtc, err := rt.GetConfig()
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
 
 	// If joining a pod, retrieve the pod for use.
 	var pod *libpod.Pod
 	if s.Pod != "" {
 		pod, err = rt.LookupPod(s.Pod)
 		if err != nil {
-			return nil, errors.Wrapf(err, "error retrieving pod %s", s.Pod)
+			return nil, nil, nil, nil, errors.Wrapf(err, "error retrieving pod %s", s.Pod)
 		}
 	}
 
+	// A container created in a pod without infra container sharing
+	// namespaces holds them if no container holds them yet.  The holder is
+	// looked up before the namespace defaults are set, so that creating
+	// the container fails if another container became the holder since.
+	holdsPodNamespaces := false
+	if pod != nil && !pod.HasInfraContainer() && pod.SharesNamespaces() {
+		holderID, err := pod.NamespaceHolderID()
+		if err != nil {
+			return nil, nil, nil, nil, errors.Wrapf(err, "error looking up pod %s namespace holder", pod.ID())
+		}
+		holdsPodNamespaces = holderID == ""
+	}
+
 	// Set defaults for unset namespaces
 	if s.PidNS.IsDefault() {
 		defaultNS, err := GetDefaultNamespaceMode("pid", rtc, pod)
 		if err != nil {
-			return nil, err
+			return nil, nil, nil, nil, err
 		}
 		s.PidNS = defaultNS
 	}
 	if s.IpcNS.IsDefault() {
 		defaultNS, err := GetDefaultNamespaceMode("ipc", rtc, pod)
 		if err != nil {
-			return nil, err
+			return nil, nil, nil, nil, err
 		}
 		s.IpcNS = defaultNS
 	}
 	if s.UtsNS.IsDefault() {
 		defaultNS, err := GetDefaultNamespaceMode("uts", rtc, pod)
 		if err != nil {
-			return nil, err
+			return nil, nil, nil, nil, err
 		}
 		s.UtsNS = defaultNS
 	}
 	if s.UserNS.IsDefault() {
 		defaultNS, err := GetDefaultNamespaceMode("user", rtc, pod)
 		if err != nil {
-			return nil, err
+			return nil, nil, nil, nil, err
 		}
 		s.UserNS = defaultNS
 	}
 	if s.NetNS.IsDefault() {
 		defaultNS, err := GetDefaultNamespaceMode("net", rtc, pod)
 		if err != nil {
-			return nil, err
+			return nil, nil, nil, nil, err
 		}
 		s.NetNS = defaultNS
 	}
 	if s.CgroupNS.IsDefault() {
 		defaultNS, err := GetDefaultNamespaceMode("cgroup", rtc, pod)
 		if err != nil {
-			return nil, err
+			return nil, nil, nil, nil, err
 		}
 		s.CgroupNS = defaultNS
 	}
 
 	options := []libpod.CtrCreateOption{}
+	if holdsPodNamespaces {
+		options = append(options, libpod.WithPodNamespaceHolder())
+	}
 	if s.ContainerCreateCommand != nil {
 		options = append(options, libpod.WithCreateCommand(s.ContainerCreateCommand))
 	}
@@ -90,7 +149,7 @@ func MakeContainer(ctx context.Context, rt *libpod.Runtime, s *specgen.SpecGener
 	} else {
 		newImage, err = rt.ImageRuntime().NewFromLocal(s.Image)
 		if err != nil {
-			return nil, err
+			return nil, nil, nil, nil, err
 		}
 		// If the input name changed, we could properly resolve the
 		// image. Otherwise, it must have been an ID where we're
@@ -104,31 +163,38 @@ func MakeContainer(ctx context.Context, rt *libpod.Runtime, s *specgen.SpecGener
 			}
 		}
 
+		if err := validateImagePlatform(ctx, newImage, s, rt.ExtraConfig().ImagePlatformPolicy); err != nil {
+			return nil, nil, nil, nil, err
+		}
+		if err := newImage.CheckAdmission(ctx); err != nil {
+			return nil, nil, nil, nil, err
+		}
+
 		options = append(options, libpod.WithRootFSFromImage(newImage.ID(), imgName, s.RawImageName))
 	}
 	if err := s.Validate(); err != nil {
-		return nil, errors.Wrap(err, "invalid config provided")
+		return nil, nil, nil, nil, errors.Wrap(err, "invalid config provided")
 	}
 
 	finalMounts, finalVolumes, finalOverlays, err := finalizeMounts(ctx, s, rt, rtc, newImage)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, nil, err
 	}
 
 	command, err := makeCommand(ctx, s, newImage, rtc)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, nil, err
 	}
 
 	opts, err := createContainerOptions(ctx, rt, s, pod, finalVolumes, finalOverlays, newImage, command)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, nil, err
 	}
 	options = append(options, opts...)
 
 	exitCommandArgs, err := CreateExitCommandArgs(rt.StorageConfig(), rtc, logrus.IsLevelEnabled(logrus.DebugLevel), s.Remove, false)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, nil, err
 	}
 	options = append(options, libpod.WithExitCommand(exitCommandArgs))
 
@@ -138,9 +204,9 @@ func MakeContainer(ctx context.Context, rt *libpod.Runtime, s *specgen.SpecGener
 
 	runtimeSpec, err := SpecGenToOCI(ctx, s, rt, rtc, newImage, finalMounts, pod, command)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, nil, err
 	}
-	return rt.NewContainer(ctx, runtimeSpec, options...)
+	return runtimeSpec, finalVolumes, finalOverlays, options, nil
 }
 
 func createContainerOptions(ctx context.Context, rt *libpod.Runtime, s *specgen.SpecGenerator, pod *libpod.Pod, volumes []*specgen.NamedVolume, overlays []*specgen.OverlayVolume, img *image.Image, command []string) ([]libpod.CtrCreateOption, error) {
@@ -266,6 +332,14 @@ func createContainerOptions(ctx context.Context, rt *libpod.Runtime, s *specgen.
 		options = append(options, libpod.WithImageVolumes(vols))
 	}
 
+	if len(s.Secrets) != 0 || len(s.EnvSecrets) != 0 {
+		secretOptions, err := secretsOptions(rt, s)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, secretOptions...)
+	}
+
 	if s.Command != nil {
 		options = append(options, libpod.WithCommand(s.Command))
 	}
@@ -277,12 +351,21 @@ func createContainerOptions(ctx context.Context, rt *libpod.Runtime, s *specgen.
 	if s.WorkDir == "" && img != nil {
 		options = append(options, libpod.WithCreateWorkingDir())
 	}
+	if s.Timeout > 0 {
+		options = append(options, libpod.WithTimeout(s.Timeout))
+	}
+	if s.NoDefaultMounts {
+		options = append(options, libpod.WithNoDefaultMounts())
+	}
 	if s.StopSignal != nil {
 		options = append(options, libpod.WithStopSignal(*s.StopSignal))
 	}
 	if s.StopTimeout != nil {
 		options = append(options, libpod.WithStopTimeout(*s.StopTimeout))
 	}
+	if len(s.StopSignals) > 0 {
+		options = append(options, libpod.WithStopSignals(s.StopSignals))
+	}
 	if s.LogConfiguration != nil {
 		if len(s.LogConfiguration.Path) > 0 {
 			options = append(options, libpod.WithLogPath(s.LogConfiguration.Path))
@@ -331,10 +414,16 @@ func createContainerOptions(ctx context.Context, rt *libpod.Runtime, s *specgen.
 	if len(s.ConmonPidFile) > 0 {
 		options = append(options, libpod.WithConmonPidFile(s.ConmonPidFile))
 	}
+	if len(s.PidFile) > 0 {
+		options = append(options, libpod.WithPidFile(s.PidFile))
+	}
 	options = append(options, libpod.WithLabels(s.Labels))
 	if s.ShmSize != nil {
 		options = append(options, libpod.WithShmSize(*s.ShmSize))
 	}
+	if s.ShmSizeSystemd != nil {
+		options = append(options, libpod.WithShmSizeSystemd(*s.ShmSizeSystemd))
+	}
 	if s.Rootfs != "" {
 		options = append(options, libpod.WithRootFS(s.Rootfs))
 	}
@@ -347,6 +436,34 @@ func createContainerOptions(ctx context.Context, rt *libpod.Runtime, s *specgen.
 		options = append(options, libpod.WithRestartPolicy(s.RestartPolicy))
 	}
 
+	if s.InitContainerType != "" {
+		options = append(options, libpod.WithInitCtrType(s.InitContainerType))
+	}
+
+	if len(s.DependencyContainers) > 0 {
+		deps := make([]*libpod.Container, 0, len(s.DependencyContainers))
+		for _, ctr := range s.DependencyContainers {
+			depCtr, err := rt.LookupContainer(ctr)
+			if err != nil {
+				return nil, errors.Wrapf(err, "%q is not a valid container, cannot be used as a dependency", ctr)
+			}
+			deps = append(deps, depCtr)
+		}
+		options = append(options, libpod.WithDependencyCtrs(deps))
+	}
+
+	if len(s.HealthyDependencyContainers) > 0 {
+		deps := make([]*libpod.Container, 0, len(s.HealthyDependencyContainers))
+		for _, ctr := range s.HealthyDependencyContainers {
+			depCtr, err := rt.LookupContainer(ctr)
+			if err != nil {
+				return nil, errors.Wrapf(err, "%q is not a valid container, cannot be used as a dependency", ctr)
+			}
+			deps = append(deps, depCtr)
+		}
+		options = append(options, libpod.WithHealthyDependencyCtrs(deps, s.HealthyDependencyTimeout))
+	}
+
 	if s.ContainerHealthCheckConfig.HealthConfig != nil {
 		options = append(options, libpod.WithHealthCheck(s.ContainerHealthCheckConfig.HealthConfig))
 		logrus.Debugf("New container has a health check")
@@ -403,3 +520,45 @@ func CreateExitCommandArgs(storageConfig storage.StoreOptions, config *config.Co
 
 	return command, nil
 }
+
+// secretsOptions looks up the secrets of the container and returns the
+// options to make them available to it.
+func secretsOptions(rt *libpod.Runtime, s *specgen.SpecGenerator) ([]libpod.CtrCreateOption, error) {
+	manager, err := rt.SecretsManager()
+	if err != nil {
+		return nil, err
+	}
+
+	var options []libpod.CtrCreateOption
+	if len(s.Secrets) != 0 {
+		ctrSecrets := make([]*libpod.ContainerSecret, 0, len(s.Secrets))
+		for _, secret := range s.Secrets {
+			found, err := manager.Lookup(secret.Source)
+			if err != nil {
+				return nil, err
+			}
+			target := secret.Target
+			if target == "" {
+				target = found.Name
+			}
+			if !filepath.IsAbs(target) {
+				target = filepath.Join("/run/secrets", target)
+			}
+			ctrSecrets = append(ctrSecrets, &libpod.ContainerSecret{Secret: found, Target: target})
+		}
+		options = append(options, libpod.WithSecrets(ctrSecrets))
+	}
+
+	if len(s.EnvSecrets) != 0 {
+		envSecrets := make(map[string]*secrets.Secret, len(s.EnvSecrets))
+		for name, source := range s.EnvSecrets {
+			found, err := manager.Lookup(source)
+			if err != nil {
+				return nil, err
+			}
+			envSecrets[name] = found
+		}
+		options = append(options, libpod.WithEnvSecrets(envSecrets))
+	}
+	return options, nil
+}
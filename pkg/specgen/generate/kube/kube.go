@@ -3,11 +3,13 @@ package kube
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/containers/buildah/pkg/parse"
 	"github.com/containers/podman/v2/libpod/image"
 	ann "github.com/containers/podman/v2/pkg/annotations"
+	"github.com/containers/podman/v2/pkg/inspect"
 	"github.com/containers/podman/v2/pkg/specgen"
 	"github.com/containers/podman/v2/pkg/util"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
@@ -16,6 +18,9 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
+// minCPUShares is the minimum CPU shares of a container, as in Kubernetes.
+const minCPUShares = 2
+
 func ToPodGen(ctx context.Context, podName string, podYAML *v1.PodTemplateSpec) (*specgen.PodSpecGenerator, error) {
 	p := specgen.NewPodSpecGenerator()
 	p.Name = podName
@@ -62,12 +67,18 @@ type CtrSpecGenOptions struct {
 	PodInfraID string
 	// ConfigMaps the configuration maps for environment variables
 	ConfigMaps []v1.ConfigMap
+	// Secrets for environment variables
+	Secrets []v1.Secret
 	// SeccompPaths for finding the seccomp profile path
 	SeccompPaths *KubeSeccompPaths
 	// RestartPolicy defines the restart policy of the container
 	RestartPolicy string
 	// NetNSIsHost tells the container to use the host netns
 	NetNSIsHost bool
+	// PodSecurityContext is the security context of the pod, whose fields
+	// apply to the container unless set in the security context of the
+	// container
+	PodSecurityContext *v1.PodSecurityContext
 }
 
 func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGenerator, error) {
@@ -85,7 +96,7 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 
 	s.Pod = opts.PodID
 
-	setupSecurityContext(s, opts.Container)
+	setupSecurityContext(s, opts.Container, opts.PodSecurityContext)
 
 	// Since we prefix the container name with pod name to work-around the uniqueness requirement,
 	// the seccomp profile should reference the actual container name from the YAML
@@ -93,17 +104,25 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 	s.SeccompProfilePath = opts.SeccompPaths.FindForContainer(opts.Container.Name)
 
 	s.ResourceLimits = &spec.LinuxResources{}
-	milliCPU, err := quantityToInt64(opts.Container.Resources.Limits.Cpu())
-	if err != nil {
-		return nil, errors.Wrap(err, "Failed to set CPU quota")
-	}
-	if milliCPU > 0 {
+	if milliCPU := opts.Container.Resources.Limits.Cpu().MilliValue(); milliCPU > 0 {
 		period, quota := util.CoresToPeriodAndQuota(float64(milliCPU) / 1000)
 		s.ResourceLimits.CPU = &spec.LinuxCPU{
 			Quota:  &quota,
 			Period: &period,
 		}
 	}
+	// As in Kubernetes, the CPU request is the relative weight of the
+	// container when CPU time is contended.
+	if milliCPU := opts.Container.Resources.Requests.Cpu().MilliValue(); milliCPU > 0 {
+		shares := uint64(milliCPU * 1024 / 1000)
+		if shares < minCPUShares {
+			shares = minCPUShares
+		}
+		if s.ResourceLimits.CPU == nil {
+			s.ResourceLimits.CPU = &spec.LinuxCPU{}
+		}
+		s.ResourceLimits.CPU.Shares = &shares
+	}
 
 	limit, err := quantityToInt64(opts.Container.Resources.Limits.Memory())
 	if err != nil {
@@ -168,6 +187,21 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 	}
 
 	s.Command = append(entrypoint, cmd...)
+
+	if err := checkRunAsNonRoot(s, opts.Container, opts.PodSecurityContext, imageData); err != nil {
+		return nil, err
+	}
+
+	healthCheck, err := containerHealthCheck(opts.Container)
+	if err != nil {
+		return nil, err
+	}
+	if healthCheck != nil {
+		if err := checkProbeTool(opts.Container, opts.Image, imageData, healthCheck); err != nil {
+			return nil, err
+		}
+		s.HealthConfig = healthCheck
+	}
 	// FIXME,
 	// we are currently ignoring imageData.Config.ExposedPorts
 	if opts.Container.WorkingDir != "" {
@@ -188,17 +222,32 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 		envs[keyval[0]] = keyval[1]
 	}
 
-	for _, env := range opts.Container.Env {
-		value := envVarValue(env, opts.ConfigMaps)
-
-		envs[env.Name] = value
-	}
+	// As in Kubernetes, the variables of envFrom are overridden by the
+	// variables of env.
 	for _, envFrom := range opts.Container.EnvFrom {
-		cmEnvs := envVarsFromConfigMap(envFrom, opts.ConfigMaps)
-
+		cmEnvs, err := envVarsFromConfigMap(envFrom, opts.ConfigMaps)
+		if err != nil {
+			return nil, err
+		}
 		for k, v := range cmEnvs {
 			envs[k] = v
 		}
+		secretEnvs, err := envVarsFromSecret(envFrom, opts.Secrets)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range secretEnvs {
+			envs[k] = v
+		}
+	}
+	for _, env := range opts.Container.Env {
+		value, err := envVarValue(env, opts.ConfigMaps, opts.Secrets)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			envs[env.Name] = *value
+		}
 	}
 	s.Env = envs
 
@@ -208,6 +257,14 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 			return nil, errors.Errorf("Volume mount %s specified for container but not configured in volumes", volume.Name)
 		}
 		switch volumeSource.Type {
+		case KubeVolumeTypeConfigMap, KubeVolumeTypeSecret:
+			// The volumes of ConfigMaps and Secrets are always
+			// read-only in Kubernetes.
+			s.Volumes = append(s.Volumes, &specgen.NamedVolume{
+				Dest:    volume.MountPath,
+				Name:    volumeSource.Source,
+				Options: []string{"ro"},
+			})
 		case KubeVolumeTypeBindMount:
 			if err := parse.ValidateVolumeCtrDir(volume.MountPath); err != nil {
 				return nil, errors.Wrapf(err, "error in parsing MountPath")
@@ -216,18 +273,14 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 				Destination: volume.MountPath,
 				Source:      volumeSource.Source,
 				Type:        "bind",
-			}
-			if volume.ReadOnly {
-				mount.Options = []string{"ro"}
+				Options:     volumeMountOptions(volumeSource, volume),
 			}
 			s.Mounts = append(s.Mounts, mount)
 		case KubeVolumeTypeNamed:
 			namedVolume := specgen.NamedVolume{
-				Dest: volume.MountPath,
-				Name: volumeSource.Source,
-			}
-			if volume.ReadOnly {
-				namedVolume.Options = []string{"ro"}
+				Dest:    volume.MountPath,
+				Name:    volumeSource.Source,
+				Options: volumeMountOptions(volumeSource, volume),
 			}
 			s.Volumes = append(s.Volumes, &namedVolume)
 		default:
@@ -244,36 +297,59 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 	return s, nil
 }
 
-func setupSecurityContext(s *specgen.SpecGenerator, containerYAML v1.Container) {
-	if containerYAML.SecurityContext == nil {
-		return
+func setupSecurityContext(s *specgen.SpecGenerator, containerYAML v1.Container, podSecurityContext *v1.PodSecurityContext) {
+	// The fields of the security context of the pod apply to all its
+	// containers, unless set for the container.
+	if podSecurityContext != nil {
+		for _, group := range podSecurityContext.SupplementalGroups {
+			s.Groups = append(s.Groups, strconv.FormatInt(group, 10))
+		}
 	}
-	if containerYAML.SecurityContext.ReadOnlyRootFilesystem != nil {
-		s.ReadOnlyFilesystem = *containerYAML.SecurityContext.ReadOnlyRootFilesystem
+	securityContext := containerYAML.SecurityContext
+	if securityContext == nil {
+		securityContext = &v1.SecurityContext{}
 	}
-	if containerYAML.SecurityContext.Privileged != nil {
-		s.Privileged = *containerYAML.SecurityContext.Privileged
+	runAsUser := securityContext.RunAsUser
+	runAsGroup := securityContext.RunAsGroup
+	seopt := securityContext.SELinuxOptions
+	if podSecurityContext != nil {
+		if runAsUser == nil {
+			runAsUser = podSecurityContext.RunAsUser
+		}
+		if runAsGroup == nil {
+			runAsGroup = podSecurityContext.RunAsGroup
+		}
+		if seopt == nil {
+			seopt = podSecurityContext.SELinuxOptions
+		}
 	}
 
-	if containerYAML.SecurityContext.AllowPrivilegeEscalation != nil {
-		s.NoNewPrivileges = !*containerYAML.SecurityContext.AllowPrivilegeEscalation
+	if securityContext.ReadOnlyRootFilesystem != nil {
+		s.ReadOnlyFilesystem = *securityContext.ReadOnlyRootFilesystem
+	}
+	if securityContext.Privileged != nil {
+		s.Privileged = *securityContext.Privileged
 	}
 
-	if seopt := containerYAML.SecurityContext.SELinuxOptions; seopt != nil {
+	if securityContext.AllowPrivilegeEscalation != nil {
+		s.NoNewPrivileges = !*securityContext.AllowPrivilegeEscalation
+	}
+
+	if seopt != nil {
 		if seopt.User != "" {
-			s.SelinuxOpts = append(s.SelinuxOpts, fmt.Sprintf("role:%s", seopt.User))
+			s.SelinuxOpts = append(s.SelinuxOpts, fmt.Sprintf("user:%s", seopt.User))
 		}
 		if seopt.Role != "" {
 			s.SelinuxOpts = append(s.SelinuxOpts, fmt.Sprintf("role:%s", seopt.Role))
 		}
 		if seopt.Type != "" {
-			s.SelinuxOpts = append(s.SelinuxOpts, fmt.Sprintf("role:%s", seopt.Type))
+			s.SelinuxOpts = append(s.SelinuxOpts, fmt.Sprintf("type:%s", seopt.Type))
 		}
 		if seopt.Level != "" {
-			s.SelinuxOpts = append(s.SelinuxOpts, fmt.Sprintf("role:%s", seopt.Level))
+			s.SelinuxOpts = append(s.SelinuxOpts, fmt.Sprintf("level:%s", seopt.Level))
 		}
 	}
-	if caps := containerYAML.SecurityContext.Capabilities; caps != nil {
+	if caps := securityContext.Capabilities; caps != nil {
 		for _, capability := range caps.Add {
 			s.CapAdd = append(s.CapAdd, string(capability))
 		}
@@ -281,17 +357,41 @@ func setupSecurityContext(s *specgen.SpecGenerator, containerYAML v1.Container)
 			s.CapDrop = append(s.CapDrop, string(capability))
 		}
 	}
-	if containerYAML.SecurityContext.RunAsUser != nil {
-		s.User = fmt.Sprintf("%d", *containerYAML.SecurityContext.RunAsUser)
+	if runAsUser != nil {
+		s.User = fmt.Sprintf("%d", *runAsUser)
 	}
-	if containerYAML.SecurityContext.RunAsGroup != nil {
+	if runAsGroup != nil {
 		if s.User == "" {
 			s.User = "0"
 		}
-		s.User = fmt.Sprintf("%s:%d", s.User, *containerYAML.SecurityContext.RunAsGroup)
+		s.User = fmt.Sprintf("%s:%d", s.User, *runAsGroup)
 	}
 }
 
+// checkRunAsNonRoot returns an error if the container must run as a non-root
+// user but runs as root, either as set in the YAML or by the image.
+func checkRunAsNonRoot(s *specgen.SpecGenerator, containerYAML v1.Container, podSecurityContext *v1.PodSecurityContext, imageData *inspect.ImageData) error {
+	var runAsNonRoot *bool
+	if containerYAML.SecurityContext != nil {
+		runAsNonRoot = containerYAML.SecurityContext.RunAsNonRoot
+	}
+	if runAsNonRoot == nil && podSecurityContext != nil {
+		runAsNonRoot = podSecurityContext.RunAsNonRoot
+	}
+	if runAsNonRoot == nil || !*runAsNonRoot {
+		return nil
+	}
+	user := s.User
+	if user == "" && imageData != nil && imageData.Config != nil {
+		user = imageData.Config.User
+	}
+	user = strings.SplitN(user, ":", 2)[0]
+	if user == "" || user == "0" || user == "root" {
+		return errors.Errorf("container %s must run as a non-root user: set runAsUser or a non-root USER in the image", containerYAML.Name)
+	}
+	return nil
+}
+
 func quantityToInt64(quantity *resource.Quantity) (int64, error) {
 	if i, ok := quantity.AsInt64(); ok {
 		return i, nil
@@ -305,39 +405,141 @@ func quantityToInt64(quantity *resource.Quantity) (int64, error) {
 }
 
 // envVarsFromConfigMap returns all key-value pairs as env vars from a configMap that matches the envFrom setting of a container
-func envVarsFromConfigMap(envFrom v1.EnvFromSource, configMaps []v1.ConfigMap) map[string]string {
+func envVarsFromConfigMap(envFrom v1.EnvFromSource, configMaps []v1.ConfigMap) (map[string]string, error) {
 	envs := map[string]string{}
 
 	if envFrom.ConfigMapRef != nil {
 		cmName := envFrom.ConfigMapRef.Name
+		cm := findConfigMap(cmName, configMaps)
+		if cm == nil {
+			if isOptional(envFrom.ConfigMapRef.Optional) {
+				return envs, nil
+			}
+			return nil, errors.Errorf("configmap %q not found", cmName)
+		}
+		for k, v := range cm.Data {
+			envs[envFrom.Prefix+k] = v
+		}
+	}
+
+	return envs, nil
+}
 
-		for _, c := range configMaps {
-			if cmName == c.Name {
-				envs = c.Data
-				break
+// envVarsFromSecret returns all key-value pairs as env vars from a secret that matches the envFrom setting of a container
+func envVarsFromSecret(envFrom v1.EnvFromSource, secrets []v1.Secret) (map[string]string, error) {
+	envs := map[string]string{}
+
+	if envFrom.SecretRef != nil {
+		secretName := envFrom.SecretRef.Name
+		secret := findSecret(secretName, secrets)
+		if secret == nil {
+			if isOptional(envFrom.SecretRef.Optional) {
+				return envs, nil
 			}
+			return nil, errors.Errorf("secret %q not found", secretName)
+		}
+		for k, v := range secretData(secret) {
+			envs[envFrom.Prefix+k] = string(v)
 		}
 	}
 
-	return envs
+	return envs, nil
 }
 
 // envVarValue returns the environment variable value configured within the container's env setting.
-// It gets the value from a configMap if specified, otherwise returns env.Value
-func envVarValue(env v1.EnvVar, configMaps []v1.ConfigMap) string {
-	for _, c := range configMaps {
-		if env.ValueFrom != nil {
-			if env.ValueFrom.ConfigMapKeyRef != nil {
-				if env.ValueFrom.ConfigMapKeyRef.Name == c.Name {
-					if value, ok := c.Data[env.ValueFrom.ConfigMapKeyRef.Key]; ok {
-						return value
-					}
+// It gets the value from a configMap or a secret if specified, otherwise returns env.Value.
+// It returns nil if the variable refers to a missing optional key, as the variable is then not set.
+func envVarValue(env v1.EnvVar, configMaps []v1.ConfigMap, secrets []v1.Secret) (*string, error) {
+	if env.ValueFrom != nil {
+		if ref := env.ValueFrom.ConfigMapKeyRef; ref != nil {
+			cm := findConfigMap(ref.Name, configMaps)
+			if cm == nil {
+				if isOptional(ref.Optional) {
+					return nil, nil
 				}
+				return nil, errors.Errorf("cannot set env %s: configmap %q not found", env.Name, ref.Name)
+			}
+			if value, ok := cm.Data[ref.Key]; ok {
+				return &value, nil
+			}
+			if isOptional(ref.Optional) {
+				return nil, nil
+			}
+			return nil, errors.Errorf("cannot set env %s: key %q not found in configmap %q", env.Name, ref.Key, ref.Name)
+		}
+		if ref := env.ValueFrom.SecretKeyRef; ref != nil {
+			secret := findSecret(ref.Name, secrets)
+			if secret == nil {
+				if isOptional(ref.Optional) {
+					return nil, nil
+				}
+				return nil, errors.Errorf("cannot set env %s: secret %q not found", env.Name, ref.Name)
+			}
+			if value, ok := secretData(secret)[ref.Key]; ok {
+				s := string(value)
+				return &s, nil
 			}
+			if isOptional(ref.Optional) {
+				return nil, nil
+			}
+			return nil, errors.Errorf("cannot set env %s: key %q not found in secret %q", env.Name, ref.Key, ref.Name)
+		}
+	}
+
+	return &env.Value, nil
+}
+
+// findConfigMap returns the configMap with the given name, or nil.
+func findConfigMap(name string, configMaps []v1.ConfigMap) *v1.ConfigMap {
+	for i := range configMaps {
+		if configMaps[i].Name == name {
+			return &configMaps[i]
+		}
+	}
+	return nil
+}
+
+// findSecret returns the secret with the given name, or nil.
+func findSecret(name string, secrets []v1.Secret) *v1.Secret {
+	for i := range secrets {
+		if secrets[i].Name == name {
+			return &secrets[i]
 		}
 	}
+	return nil
+}
+
+// secretData returns the data of a secret.  As in Kubernetes, the values of
+// stringData override the values of data with the same key.  The values of
+// data are base64-decoded when the secret is read from YAML.
+func secretData(secret *v1.Secret) map[string][]byte {
+	data := make(map[string][]byte, len(secret.Data)+len(secret.StringData))
+	for k, v := range secret.Data {
+		data[k] = v
+	}
+	for k, v := range secret.StringData {
+		data[k] = []byte(v)
+	}
+	return data
+}
+
+// volumeMountOptions returns the options of the volume followed by "ro" if the
+// volume is mounted read-only.
+func volumeMountOptions(volume *KubeVolume, mount v1.VolumeMount) []string {
+	options := append([]string{}, volume.Options...)
+	if mount.ReadOnly && !util.StringInSlice("ro", options) {
+		options = append(options, "ro")
+	}
+	if len(options) == 0 {
+		return nil
+	}
+	return options
+}
 
-	return env.Value
+// isOptional returns true if a reference to a configMap or a secret is
+// optional.  References are required by default.
+func isOptional(optional *bool) bool {
+	return optional != nil && *optional
 }
 
 // getPodPorts converts a slice of kube container descriptions to an
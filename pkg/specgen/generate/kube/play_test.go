@@ -1,11 +1,21 @@
 package kube
 
 import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/containers/podman/v2/pkg/inspect"
+	"github.com/containers/podman/v2/pkg/specgen"
+	v1image "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func TestEnvVarsFromConfigMap(t *testing.T) {
@@ -13,6 +23,7 @@ func TestEnvVarsFromConfigMap(t *testing.T) {
 		name          string
 		envFrom       v1.EnvFromSource
 		configMapList []v1.ConfigMap
+		expectError   bool
 		expected      map[string]string
 	}{
 		{
@@ -25,10 +36,27 @@ func TestEnvVarsFromConfigMap(t *testing.T) {
 				},
 			},
 			configMapList,
+			false,
 			map[string]string{
 				"myvar": "foo",
 			},
 		},
+		{
+			"ConfigMapExistsWithPrefix",
+			v1.EnvFromSource{
+				Prefix: "PRE_",
+				ConfigMapRef: &v1.ConfigMapEnvSource{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: "foo",
+					},
+				},
+			},
+			configMapList,
+			false,
+			map[string]string{
+				"PRE_myvar": "foo",
+			},
+		},
 		{
 			"ConfigMapDoesNotExist",
 			v1.EnvFromSource{
@@ -39,6 +67,21 @@ func TestEnvVarsFromConfigMap(t *testing.T) {
 				},
 			},
 			configMapList,
+			true,
+			nil,
+		},
+		{
+			"OptionalConfigMapDoesNotExist",
+			v1.EnvFromSource{
+				ConfigMapRef: &v1.ConfigMapEnvSource{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: "doesnotexist",
+					},
+					Optional: &optional,
+				},
+			},
+			configMapList,
+			false,
 			map[string]string{},
 		},
 		{
@@ -51,6 +94,75 @@ func TestEnvVarsFromConfigMap(t *testing.T) {
 				},
 			},
 			[]v1.ConfigMap{},
+			true,
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			result, err := envVarsFromConfigMap(test.envFrom, test.configMapList)
+			if test.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestEnvVarsFromSecret(t *testing.T) {
+	tests := []struct {
+		name        string
+		envFrom     v1.EnvFromSource
+		secretList  []v1.Secret
+		expectError bool
+		expected    map[string]string
+	}{
+		{
+			"SecretExists",
+			v1.EnvFromSource{
+				SecretRef: &v1.SecretEnvSource{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: "foo",
+					},
+				},
+			},
+			secretList,
+			false,
+			map[string]string{
+				"myvar":     "foo",
+				"plain":     "override",
+				"plainonly": "plain",
+			},
+		},
+		{
+			"SecretDoesNotExist",
+			v1.EnvFromSource{
+				SecretRef: &v1.SecretEnvSource{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: "doesnotexist",
+					},
+				},
+			},
+			secretList,
+			true,
+			nil,
+		},
+		{
+			"OptionalSecretDoesNotExist",
+			v1.EnvFromSource{
+				SecretRef: &v1.SecretEnvSource{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: "doesnotexist",
+					},
+					Optional: &optional,
+				},
+			},
+			secretList,
+			false,
 			map[string]string{},
 		},
 	}
@@ -58,7 +170,12 @@ func TestEnvVarsFromConfigMap(t *testing.T) {
 	for _, test := range tests {
 		test := test
 		t.Run(test.name, func(t *testing.T) {
-			result := envVarsFromConfigMap(test.envFrom, test.configMapList)
+			result, err := envVarsFromSecret(test.envFrom, test.secretList)
+			if test.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
 			assert.Equal(t, test.expected, result)
 		})
 	}
@@ -69,7 +186,8 @@ func TestEnvVarValue(t *testing.T) {
 		name          string
 		envVar        v1.EnvVar
 		configMapList []v1.ConfigMap
-		expected      string
+		expectError   bool
+		expected      *string
 	}{
 		{
 			"ConfigMapExists",
@@ -85,7 +203,8 @@ func TestEnvVarValue(t *testing.T) {
 				},
 			},
 			configMapList,
-			"foo",
+			false,
+			stringPtr("foo"),
 		},
 		{
 			"ContainerKeyDoesNotExistInConfigMap",
@@ -101,7 +220,26 @@ func TestEnvVarValue(t *testing.T) {
 				},
 			},
 			configMapList,
-			"",
+			true,
+			nil,
+		},
+		{
+			"OptionalContainerKeyDoesNotExistInConfigMap",
+			v1.EnvVar{
+				Name: "FOO",
+				ValueFrom: &v1.EnvVarSource{
+					ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{
+							Name: "foo",
+						},
+						Key:      "doesnotexist",
+						Optional: &optional,
+					},
+				},
+			},
+			configMapList,
+			false,
+			nil,
 		},
 		{
 			"ConfigMapDoesNotExist",
@@ -117,7 +255,8 @@ func TestEnvVarValue(t *testing.T) {
 				},
 			},
 			configMapList,
-			"",
+			true,
+			nil,
 		},
 		{
 			"EmptyConfigMapList",
@@ -133,19 +272,508 @@ func TestEnvVarValue(t *testing.T) {
 				},
 			},
 			[]v1.ConfigMap{},
-			"",
+			true,
+			nil,
+		},
+		{
+			"SecretExists",
+			v1.EnvVar{
+				Name: "FOO",
+				ValueFrom: &v1.EnvVarSource{
+					SecretKeyRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{
+							Name: "foo",
+						},
+						Key: "myvar",
+					},
+				},
+			},
+			configMapList,
+			false,
+			stringPtr("foo"),
+		},
+		{
+			"OptionalSecretDoesNotExist",
+			v1.EnvVar{
+				Name: "FOO",
+				ValueFrom: &v1.EnvVarSource{
+					SecretKeyRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{
+							Name: "doesnotexist",
+						},
+						Key:      "myvar",
+						Optional: &optional,
+					},
+				},
+			},
+			configMapList,
+			false,
+			nil,
+		},
+		{
+			"PlainValue",
+			v1.EnvVar{
+				Name:  "FOO",
+				Value: "bar",
+			},
+			configMapList,
+			false,
+			stringPtr("bar"),
 		},
 	}
 
 	for _, test := range tests {
 		test := test
 		t.Run(test.name, func(t *testing.T) {
-			result := envVarValue(test.envVar, test.configMapList)
+			result, err := envVarValue(test.envVar, test.configMapList, secretList)
+			if test.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
 			assert.Equal(t, test.expected, result)
 		})
 	}
 }
 
+func TestVolumeFromConfigMap(t *testing.T) {
+	mode := int32(0600)
+	tests := []struct {
+		name        string
+		volume      v1.ConfigMapVolumeSource
+		expectError bool
+		expected    []KubeVolumeItem
+	}{
+		{
+			"AllKeys",
+			v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{
+					Name: "foo",
+				},
+			},
+			false,
+			[]KubeVolumeItem{
+				{Path: "myvar", Data: []byte("foo"), Mode: 0644},
+			},
+		},
+		{
+			"KeyToPath",
+			v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{
+					Name: "foo",
+				},
+				Items: []v1.KeyToPath{
+					{Key: "myvar", Path: "conf/myvar.conf", Mode: &mode},
+				},
+			},
+			false,
+			[]KubeVolumeItem{
+				{Path: "conf/myvar.conf", Data: []byte("foo"), Mode: 0600},
+			},
+		},
+		{
+			"KeyDoesNotExist",
+			v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{
+					Name: "foo",
+				},
+				Items: []v1.KeyToPath{
+					{Key: "doesnotexist", Path: "doesnotexist"},
+				},
+			},
+			true,
+			nil,
+		},
+		{
+			"PathOutsideOfVolume",
+			v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{
+					Name: "foo",
+				},
+				Items: []v1.KeyToPath{
+					{Key: "myvar", Path: "../myvar"},
+				},
+			},
+			true,
+			nil,
+		},
+		{
+			"ConfigMapDoesNotExist",
+			v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{
+					Name: "doesnotexist",
+				},
+			},
+			true,
+			nil,
+		},
+		{
+			"OptionalConfigMapDoesNotExist",
+			v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{
+					Name: "doesnotexist",
+				},
+				Optional: &optional,
+			},
+			false,
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			result, err := VolumeFromConfigMap(&test.volume, configMapList)
+			if test.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, KubeVolumeTypeConfigMap, result.Type)
+			assert.Equal(t, test.volume.Name, result.Source)
+			assert.Equal(t, test.expected, result.Items)
+		})
+	}
+}
+
+func TestVolumeFromSecret(t *testing.T) {
+	mode := int32(0400)
+	volume := v1.SecretVolumeSource{
+		SecretName:  "foo",
+		DefaultMode: &mode,
+	}
+	result, err := VolumeFromSecret(&volume, secretList)
+	assert.NoError(t, err)
+	assert.Equal(t, KubeVolumeTypeSecret, result.Type)
+	assert.Equal(t, "secret/foo", result.SourceLabel())
+	assert.Equal(t, []KubeVolumeItem{
+		{Path: "myvar", Data: []byte("foo"), Mode: os.FileMode(0400)},
+		{Path: "plain", Data: []byte("override"), Mode: os.FileMode(0400)},
+		{Path: "plainonly", Data: []byte("plain"), Mode: os.FileMode(0400)},
+	}, result.Items)
+}
+
+func TestVolumeFromSecretInvalidKey(t *testing.T) {
+	secrets := []v1.Secret{
+		{
+			TypeMeta:   v12.TypeMeta{Kind: "Secret"},
+			ObjectMeta: v12.ObjectMeta{Name: "evil"},
+			StringData: map[string]string{"../../../../etc/cron.d/x": "foo"},
+		},
+	}
+	// Without items, the keys are used as file names.
+	_, err := VolumeFromSecret(&v1.SecretVolumeSource{SecretName: "evil"}, secrets)
+	assert.Error(t, err)
+}
+
+func TestVolumeFromHostPath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hostpath")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "file")
+	require.NoError(t, ioutil.WriteFile(file, []byte("foo"), 0644))
+	socket := filepath.Join(dir, "socket")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	tests := []struct {
+		name        string
+		path        string
+		pathType    v1.HostPathType
+		expectError bool
+		expected    []string
+	}{
+		{"Directory", dir, v1.HostPathDirectory, false, nil},
+		{"NotADirectory", file, v1.HostPathDirectory, true, nil},
+		{"File", file, v1.HostPathFile, false, nil},
+		{"NotAFile", dir, v1.HostPathFile, true, nil},
+		{"Socket", socket, v1.HostPathSocket, false, nil},
+		{"NotASocket", file, v1.HostPathSocket, true, nil},
+		{"UnsetSocket", socket, v1.HostPathUnset, false, nil},
+		{"DirectoryOrCreate", filepath.Join(dir, "a", "b"), v1.HostPathDirectoryOrCreate, false, []string{"z"}},
+		{"DirectoryOrCreateExisting", dir, v1.HostPathDirectoryOrCreate, false, nil},
+		{"FileOrCreate", filepath.Join(dir, "newfile"), v1.HostPathFileOrCreate, false, []string{"z"}},
+		{"FileOrCreateExisting", file, v1.HostPathFileOrCreate, false, nil},
+		{"DoesNotExist", filepath.Join(dir, "doesnotexist"), v1.HostPathDirectory, true, nil},
+		{"InvalidType", dir, v1.HostPathType("Invalid"), true, nil},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			result, err := VolumeFromHostPath(&v1.HostPathVolumeSource{Path: test.path, Type: &test.pathType})
+			if test.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, KubeVolumeTypeBindMount, result.Type)
+			assert.Equal(t, test.path, result.Source)
+			assert.Equal(t, test.expected, result.Options)
+		})
+	}
+}
+
+func TestVolumeOptionsFromPVC(t *testing.T) {
+	claim := v1.PersistentVolumeClaim{
+		ObjectMeta: v12.ObjectMeta{
+			Name: "claim",
+			Annotations: map[string]string{
+				VolumeDriverAnnotation:       "local",
+				VolumeDeviceAnnotation:       "tmpfs",
+				VolumeTypeAnnotation:         "tmpfs",
+				VolumeMountOptionsAnnotation: "size=2M",
+				VolumeUIDAnnotation:          "1000",
+				"other":                      "ignored",
+			},
+		},
+	}
+	opts, err := VolumeOptionsFromPVC(&claim)
+	assert.NoError(t, err)
+	assert.Equal(t, "local", opts.Driver)
+	assert.Equal(t, map[string]string{"device": "tmpfs", "type": "tmpfs", "o": "size=2M"}, opts.Options)
+	require.NotNil(t, opts.UID)
+	assert.Equal(t, 1000, *opts.UID)
+	assert.Nil(t, opts.GID)
+
+	claim.Annotations = map[string]string{VolumeGIDAnnotation: "-1"}
+	_, err = VolumeOptionsFromPVC(&claim)
+	assert.Error(t, err)
+}
+
+func TestContainerHealthCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		container   v1.Container
+		expectError bool
+		expected    []string
+	}{
+		{
+			"NoProbe",
+			v1.Container{},
+			false,
+			nil,
+		},
+		{
+			"ReadinessProbeOnly",
+			v1.Container{
+				ReadinessProbe: &v1.Probe{Handler: v1.Handler{Exec: &v1.ExecAction{Command: []string{"cat", "/ready"}}}},
+			},
+			false,
+			nil,
+		},
+		{
+			"Exec",
+			v1.Container{
+				LivenessProbe: &v1.Probe{Handler: v1.Handler{Exec: &v1.ExecAction{Command: []string{"cat", "/ready"}}}},
+			},
+			false,
+			[]string{"CMD", "cat", "/ready"},
+		},
+		{
+			"HTTPGetNamedPort",
+			v1.Container{
+				Ports: []v1.ContainerPort{{Name: "web", ContainerPort: 8080}},
+				LivenessProbe: &v1.Probe{Handler: v1.Handler{HTTPGet: &v1.HTTPGetAction{
+					Path:        "healthz",
+					Port:        intstr.FromString("web"),
+					HTTPHeaders: []v1.HTTPHeader{{Name: "X-Probe", Value: "yes"}},
+				}}},
+			},
+			false,
+			[]string{"CMD", "curl", "-f", "-s", "-o", "/dev/null", "-H", "X-Probe: yes", "http://localhost:8080/healthz"},
+		},
+		{
+			"HTTPSGet",
+			v1.Container{
+				LivenessProbe: &v1.Probe{Handler: v1.Handler{HTTPGet: &v1.HTTPGetAction{
+					Path:   "/",
+					Port:   intstr.FromInt(8443),
+					Scheme: v1.URISchemeHTTPS,
+				}}},
+			},
+			false,
+			[]string{"CMD", "curl", "-f", "-s", "-o", "/dev/null", "-k", "https://localhost:8443/"},
+		},
+		{
+			"TCPSocket",
+			v1.Container{
+				LivenessProbe: &v1.Probe{Handler: v1.Handler{TCPSocket: &v1.TCPSocketAction{Port: intstr.FromInt(5432)}}},
+			},
+			false,
+			[]string{"CMD", "nc", "-z", "localhost", "5432"},
+		},
+		{
+			"UnknownPortName",
+			v1.Container{
+				LivenessProbe: &v1.Probe{Handler: v1.Handler{TCPSocket: &v1.TCPSocketAction{Port: intstr.FromString("db")}}},
+			},
+			true,
+			nil,
+		},
+		{
+			"NoHandler",
+			v1.Container{LivenessProbe: &v1.Probe{}},
+			true,
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			result, err := containerHealthCheck(test.container)
+			if test.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if test.expected == nil {
+				assert.Nil(t, result)
+				return
+			}
+			require.NotNil(t, result)
+			assert.Equal(t, test.expected, result.Test)
+		})
+	}
+}
+
+func TestContainerHealthCheckTimings(t *testing.T) {
+	container := v1.Container{
+		LivenessProbe: &v1.Probe{
+			Handler:             v1.Handler{Exec: &v1.ExecAction{Command: []string{"true"}}},
+			InitialDelaySeconds: 5,
+			PeriodSeconds:       30,
+			FailureThreshold:    4,
+		},
+		// The readiness probe is ignored.
+		ReadinessProbe: &v1.Probe{
+			Handler: v1.Handler{Exec: &v1.ExecAction{Command: []string{"false"}}},
+		},
+	}
+	result, err := containerHealthCheck(container)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CMD", "true"}, result.Test)
+	assert.Equal(t, 5*time.Second, result.StartPeriod)
+	assert.Equal(t, 30*time.Second, result.Interval)
+	assert.Equal(t, time.Second, result.Timeout)
+	assert.Equal(t, 4, result.Retries)
+}
+
+func TestHasExecutable(t *testing.T) {
+	rootfs, err := ioutil.TempDir("", "rootfs")
+	require.NoError(t, err)
+	defer os.RemoveAll(rootfs)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(rootfs, "usr/bin"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(rootfs, "usr/bin/busybox"), nil, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(rootfs, "usr/bin/curl"), nil, 0644))
+	// Absolute links are resolved within the image.
+	require.NoError(t, os.Symlink("/usr/bin/busybox", filepath.Join(rootfs, "usr/bin/nc")))
+	require.NoError(t, os.Symlink("usr/bin", filepath.Join(rootfs, "bin")))
+
+	assert.True(t, hasExecutable(rootfs, "/usr/bin", "nc"))
+	assert.True(t, hasExecutable(rootfs, "/sbin:/bin", "busybox"))
+	assert.False(t, hasExecutable(rootfs, "/usr/bin", "curl"))
+	assert.False(t, hasExecutable(rootfs, "/usr/local/bin", "nc"))
+}
+
+func TestProbePath(t *testing.T) {
+	imageData := &inspect.ImageData{Config: &v1image.ImageConfig{Env: []string{"PATH=/opt/bin"}}}
+	assert.Equal(t, defaultProbePath, probePath(v1.Container{}, nil))
+	assert.Equal(t, "/opt/bin", probePath(v1.Container{}, imageData))
+	assert.Equal(t, "/app/bin", probePath(v1.Container{Env: []v1.EnvVar{{Name: "PATH", Value: "/app/bin"}}}, imageData))
+}
+
+func TestSetupSecurityContext(t *testing.T) {
+	podUser, podGroup, ctrUser := int64(1000), int64(100), int64(2000)
+	readOnly := true
+	container := v1.Container{
+		SecurityContext: &v1.SecurityContext{
+			RunAsUser:              &ctrUser,
+			ReadOnlyRootFilesystem: &readOnly,
+			Capabilities: &v1.Capabilities{
+				Add:  []v1.Capability{"NET_ADMIN"},
+				Drop: []v1.Capability{"ALL"},
+			},
+			SELinuxOptions: &v1.SELinuxOptions{Type: "spc_t", Level: "s0:c1,c2"},
+		},
+	}
+	podSecurityContext := &v1.PodSecurityContext{
+		RunAsUser:          &podUser,
+		RunAsGroup:         &podGroup,
+		SupplementalGroups: []int64{10, 20},
+		SELinuxOptions:     &v1.SELinuxOptions{Type: "container_t"},
+	}
+
+	s := specgen.NewSpecGenerator("", false)
+	setupSecurityContext(s, container, podSecurityContext)
+	assert.Equal(t, "2000:100", s.User)
+	assert.Equal(t, []string{"10", "20"}, s.Groups)
+	assert.True(t, s.ReadOnlyFilesystem)
+	assert.Equal(t, []string{"NET_ADMIN"}, s.CapAdd)
+	assert.Equal(t, []string{"ALL"}, s.CapDrop)
+	assert.Equal(t, []string{"type:spc_t", "level:s0:c1,c2"}, s.SelinuxOpts)
+
+	nonRoot := true
+	podSecurityContext = &v1.PodSecurityContext{RunAsNonRoot: &nonRoot}
+	s = specgen.NewSpecGenerator("", false)
+	setupSecurityContext(s, v1.Container{}, podSecurityContext)
+	assert.Error(t, checkRunAsNonRoot(s, v1.Container{}, podSecurityContext, nil))
+	s.User = "1000"
+	assert.NoError(t, checkRunAsNonRoot(s, v1.Container{}, podSecurityContext, nil))
+}
+
+func TestSetSeccompProfileAnnotations(t *testing.T) {
+	deployment := []byte(`
+kind: Deployment
+spec:
+  template:
+    spec:
+      securityContext:
+        seccompProfile:
+          type: RuntimeDefault
+      containers:
+      - name: web
+        securityContext:
+          seccompProfile:
+            type: Localhost
+            localhostProfile: profiles/web.json
+      - name: debug
+        securityContext:
+          seccompProfile:
+            type: Unconfined
+`)
+	meta := v12.ObjectMeta{Annotations: map[string]string{v1.SeccompPodAnnotationKey: "unconfined"}}
+	require.NoError(t, SetSeccompProfileAnnotations(&meta, deployment))
+	assert.Equal(t, map[string]string{
+		v1.SeccompPodAnnotationKey:                       v1.SeccompProfileRuntimeDefault,
+		v1.SeccompContainerAnnotationKeyPrefix + "web":   "localhost/profiles/web.json",
+		v1.SeccompContainerAnnotationKeyPrefix + "debug": "unconfined",
+	}, meta.Annotations)
+
+	paths, err := InitializeSeccompPaths(meta.Annotations, "/profiles")
+	require.NoError(t, err)
+	assert.Equal(t, "/profiles/profiles/web.json", paths.FindForContainer("web"))
+
+	pod := []byte(`
+kind: Pod
+spec:
+  securityContext:
+    seccompProfile:
+      type: Localhost
+`)
+	meta = v12.ObjectMeta{}
+	assert.Error(t, SetSeccompProfileAnnotations(&meta, pod))
+}
+
+var optional = true
+
+func stringPtr(s string) *string {
+	return &s
+}
+
 var configMapList = []v1.ConfigMap{
 	{
 		TypeMeta: v12.TypeMeta{
@@ -170,3 +798,22 @@ var configMapList = []v1.ConfigMap{
 		},
 	},
 }
+
+var secretList = []v1.Secret{
+	{
+		TypeMeta: v12.TypeMeta{
+			Kind: "Secret",
+		},
+		ObjectMeta: v12.ObjectMeta{
+			Name: "foo",
+		},
+		Data: map[string][]byte{
+			"myvar": []byte("foo"),
+			"plain": []byte("data"),
+		},
+		StringData: map[string]string{
+			"plain":     "override",
+			"plainonly": "plain",
+		},
+	},
+}
@@ -0,0 +1,208 @@
+package kube
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containers/image/v5/manifest"
+	"github.com/containers/podman/v2/libpod/image"
+	"github.com/containers/podman/v2/pkg/inspect"
+	securejoin "github.com/cyphar/filepath-securejoin"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Defaults of the fields of a probe in Kubernetes
+const (
+	defaultProbePeriodSeconds    = 10
+	defaultProbeTimeoutSeconds   = 1
+	defaultProbeFailureThreshold = 3
+)
+
+// defaultProbePath is searched for the tool run by a probe when neither the
+// container nor its image set PATH.
+const defaultProbePath = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+
+// containerHealthCheck returns the healthcheck of a container translated from
+// its liveness probe.  Readiness probes only gate the traffic sent to a pod in
+// Kubernetes, so they are not translated.  Returns nil if the container has no
+// liveness probe.
+func containerHealthCheck(container v1.Container) (*manifest.Schema2HealthConfig, error) {
+	probe := container.LivenessProbe
+	if probe == nil {
+		return nil, nil
+	}
+	healthCheck, err := probeToHealthCheck(probe, container.Ports)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid probe of container %s", container.Name)
+	}
+	return healthCheck, nil
+}
+
+// probeToHealthCheck translates a probe into a healthcheck running a command
+// in the container.  HTTP probes run curl and TCP probes run nc, which must
+// be available in the image.
+func probeToHealthCheck(probe *v1.Probe, ports []v1.ContainerPort) (*manifest.Schema2HealthConfig, error) {
+	var test []string
+	switch {
+	case probe.Exec != nil:
+		if len(probe.Exec.Command) == 0 {
+			return nil, errors.New("exec probe has no command")
+		}
+		test = append([]string{"CMD"}, probe.Exec.Command...)
+	case probe.HTTPGet != nil:
+		port, err := probePort(probe.HTTPGet.Port, ports)
+		if err != nil {
+			return nil, err
+		}
+		scheme := "http"
+		if probe.HTTPGet.Scheme != "" {
+			scheme = strings.ToLower(string(probe.HTTPGet.Scheme))
+		}
+		path := probe.HTTPGet.Path
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		test = []string{"CMD", "curl", "-f", "-s", "-o", "/dev/null"}
+		if scheme == "https" {
+			// Kubernetes does not verify the certificate either.
+			test = append(test, "-k")
+		}
+		for _, header := range probe.HTTPGet.HTTPHeaders {
+			test = append(test, "-H", fmt.Sprintf("%s: %s", header.Name, header.Value))
+		}
+		test = append(test, fmt.Sprintf("%s://%s%s", scheme, net.JoinHostPort(probeHost(probe.HTTPGet.Host), strconv.Itoa(port)), path))
+	case probe.TCPSocket != nil:
+		port, err := probePort(probe.TCPSocket.Port, ports)
+		if err != nil {
+			return nil, err
+		}
+		test = []string{"CMD", "nc", "-z", probeHost(probe.TCPSocket.Host), strconv.Itoa(port)}
+	default:
+		return nil, errors.New("probe has no exec, httpGet or tcpSocket handler")
+	}
+
+	healthCheck := &manifest.Schema2HealthConfig{
+		Test:        test,
+		StartPeriod: time.Duration(probe.InitialDelaySeconds) * time.Second,
+		Interval:    defaultProbePeriodSeconds * time.Second,
+		Timeout:     defaultProbeTimeoutSeconds * time.Second,
+		Retries:     defaultProbeFailureThreshold,
+	}
+	if probe.PeriodSeconds > 0 {
+		healthCheck.Interval = time.Duration(probe.PeriodSeconds) * time.Second
+	}
+	if probe.TimeoutSeconds > 0 {
+		healthCheck.Timeout = time.Duration(probe.TimeoutSeconds) * time.Second
+	}
+	if probe.FailureThreshold > 0 {
+		healthCheck.Retries = int(probe.FailureThreshold)
+	}
+	return healthCheck, nil
+}
+
+// probeHost returns the host probed, the container itself by default.
+func probeHost(host string) string {
+	if host == "" {
+		return "localhost"
+	}
+	return host
+}
+
+// probePort returns the number of the port probed, which may be given by the
+// name of a port of the container.
+func probePort(port intstr.IntOrString, ports []v1.ContainerPort) (int, error) {
+	if port.Type == intstr.Int {
+		if port.IntVal <= 0 || port.IntVal > 65535 {
+			return 0, errors.Errorf("invalid port %d", port.IntVal)
+		}
+		return int(port.IntVal), nil
+	}
+	for _, p := range ports {
+		if p.Name == port.StrVal {
+			return int(p.ContainerPort), nil
+		}
+	}
+	if n, err := strconv.Atoi(port.StrVal); err == nil && n > 0 && n <= 65535 {
+		return n, nil
+	}
+	return 0, errors.Errorf("container has no port named %q", port.StrVal)
+}
+
+// probeTool returns the tool a healthcheck translated from an httpGet or
+// tcpSocket probe runs, or "" for an exec probe.
+func probeTool(healthCheck *manifest.Schema2HealthConfig) string {
+	if len(healthCheck.Test) < 2 || healthCheck.Test[0] != "CMD" {
+		return ""
+	}
+	switch tool := healthCheck.Test[1]; tool {
+	case "curl", "nc":
+		return tool
+	}
+	return ""
+}
+
+// checkProbeTool returns an error if the tool run by the healthcheck of a
+// container is not installed in its image.  The check is skipped with a
+// warning if the image cannot be mounted.
+func checkProbeTool(container v1.Container, img *image.Image, imageData *inspect.ImageData, healthCheck *manifest.Schema2HealthConfig) error {
+	tool := probeTool(healthCheck)
+	if tool == "" {
+		return nil
+	}
+	mountPoint, err := img.Mount(nil, "")
+	if err != nil {
+		logrus.Warnf("unable to mount image %s to look for %s, run by the liveness probe of container %s: %v", img.InputName, tool, container.Name, err)
+		return nil
+	}
+	defer func() {
+		if err := img.Unmount(false); err != nil {
+			logrus.Errorf("unable to unmount image %s: %v", img.InputName, err)
+		}
+	}()
+	if !hasExecutable(mountPoint, probePath(container, imageData), tool) {
+		return errors.Errorf("liveness probe of container %s runs %s, which is not installed in image %s", container.Name, tool, img.InputName)
+	}
+	return nil
+}
+
+// probePath returns the PATH of a container, set by the container itself, by
+// its image or by default.
+func probePath(container v1.Container, imageData *inspect.ImageData) string {
+	for _, env := range container.Env {
+		if env.Name == "PATH" {
+			return env.Value
+		}
+	}
+	if imageData != nil && imageData.Config != nil {
+		for _, env := range imageData.Config.Env {
+			if strings.HasPrefix(env, "PATH=") {
+				return strings.TrimPrefix(env, "PATH=")
+			}
+		}
+	}
+	return defaultProbePath
+}
+
+// hasExecutable returns whether an executable named name is found in one of
+// the directories of path, resolved within rootfs.
+func hasExecutable(rootfs, path, name string) bool {
+	for _, dir := range filepath.SplitList(path) {
+		file, err := securejoin.SecureJoin(rootfs, filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err == nil && info.Mode().IsRegular() && info.Mode().Perm()&0111 != 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -5,8 +5,10 @@ import (
 	"strings"
 
 	"github.com/containers/podman/v2/libpod"
+	"github.com/ghodss/yaml"
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // KubeSeccompPaths holds information about a pod YAML's seccomp configuration
@@ -75,10 +77,86 @@ func verifySeccompPath(path string, profileRoot string) (string, error) {
 	case "unconfined":
 		return path, nil
 	default:
-		parts := strings.Split(path, "/")
-		if parts[0] == "localhost" {
+		parts := strings.SplitN(path, "/", 2)
+		if parts[0] == "localhost" && len(parts) == 2 {
 			return filepath.Join(profileRoot, parts[1]), nil
 		}
 		return "", errors.Errorf("invalid seccomp path: %s", path)
 	}
 }
+
+// seccompProfile is the seccompProfile field of a security context, which the
+// vendored Kubernetes API does not know yet.
+type seccompProfile struct {
+	Type             string `json:"type"`
+	LocalhostProfile string `json:"localhostProfile"`
+}
+
+type seccompSecurityContext struct {
+	SeccompProfile *seccompProfile `json:"seccompProfile"`
+}
+
+type seccompContainer struct {
+	Name            string                  `json:"name"`
+	SecurityContext *seccompSecurityContext `json:"securityContext"`
+}
+
+type seccompPodSpec struct {
+	SecurityContext *seccompSecurityContext `json:"securityContext"`
+	InitContainers  []seccompContainer      `json:"initContainers"`
+	Containers      []seccompContainer      `json:"containers"`
+}
+
+// SetSeccompProfileAnnotations translates the seccompProfile fields of the
+// security contexts of a Pod, or of the pod template of a Deployment or a
+// DaemonSet, into the seccomp annotations of the pod.  The fields take
+// precedence over the annotations, as in Kubernetes.
+func SetSeccompProfileAnnotations(meta *metav1.ObjectMeta, document []byte) error {
+	var object struct {
+		Spec struct {
+			seccompPodSpec `json:",inline"`
+			Template       struct {
+				Spec seccompPodSpec `json:"spec"`
+			} `json:"template"`
+		} `json:"spec"`
+	}
+	if err := yaml.Unmarshal(document, &object); err != nil {
+		return err
+	}
+	for _, podSpec := range []seccompPodSpec{object.Spec.seccompPodSpec, object.Spec.Template.Spec} {
+		if err := setSeccompProfileAnnotation(meta, v1.SeccompPodAnnotationKey, podSpec.SecurityContext); err != nil {
+			return err
+		}
+		for _, ctr := range append(podSpec.InitContainers, podSpec.Containers...) {
+			if err := setSeccompProfileAnnotation(meta, v1.SeccompContainerAnnotationKeyPrefix+ctr.Name, ctr.SecurityContext); err != nil {
+				return errors.Wrapf(err, "container %s", ctr.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func setSeccompProfileAnnotation(meta *metav1.ObjectMeta, key string, securityContext *seccompSecurityContext) error {
+	if securityContext == nil || securityContext.SeccompProfile == nil {
+		return nil
+	}
+	var value string
+	switch profile := securityContext.SeccompProfile; profile.Type {
+	case "RuntimeDefault":
+		value = v1.SeccompProfileRuntimeDefault
+	case "Unconfined":
+		value = "unconfined"
+	case "Localhost":
+		if profile.LocalhostProfile == "" {
+			return errors.New("localhostProfile must be set for a Localhost seccomp profile")
+		}
+		value = "localhost/" + profile.LocalhostProfile
+	default:
+		return errors.Errorf("invalid seccomp profile type %q", profile.Type)
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = make(map[string]string)
+	}
+	meta.Annotations[key] = value
+	return nil
+}
@@ -2,6 +2,11 @@ package kube
 
 import (
 	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/containers/buildah/pkg/parse"
 	"github.com/containers/podman/v2/libpod"
@@ -22,6 +27,29 @@ type KubeVolumeType int
 const (
 	KubeVolumeTypeBindMount KubeVolumeType = iota
 	KubeVolumeTypeNamed     KubeVolumeType = iota
+	KubeVolumeTypeConfigMap KubeVolumeType = iota
+	KubeVolumeTypeSecret    KubeVolumeType = iota
+)
+
+// VolumeSourceLabel is set on the named volumes holding the files of a
+// ConfigMap or a Secret, to the kind and the name of the object, for example
+// configmap/foo.
+const VolumeSourceLabel = "io.podman.kube.volume-source"
+
+// Annotations of a PersistentVolumeClaim configuring its named volume
+const (
+	// VolumeDriverAnnotation is the driver of the volume
+	VolumeDriverAnnotation = "volume.podman.io/driver"
+	// VolumeDeviceAnnotation is the device mounted by the local driver
+	VolumeDeviceAnnotation = "volume.podman.io/device"
+	// VolumeTypeAnnotation is the filesystem type of the device
+	VolumeTypeAnnotation = "volume.podman.io/type"
+	// VolumeMountOptionsAnnotation are the options to mount the device
+	VolumeMountOptionsAnnotation = "volume.podman.io/mount-options"
+	// VolumeUIDAnnotation is the UID owning the volume
+	VolumeUIDAnnotation = "volume.podman.io/uid"
+	// VolumeGIDAnnotation is the GID owning the volume
+	VolumeGIDAnnotation = "volume.podman.io/gid"
 )
 
 type KubeVolume struct {
@@ -29,90 +57,327 @@ type KubeVolume struct {
 	Type KubeVolumeType
 	// Path for bind mount or volume name for named volume
 	Source string
+	// Items are the files of the named volume of a ConfigMap or a Secret
+	Items []KubeVolumeItem
+	// Options for mounting the volume, such as SELinux relabeling
+	Options []string
+}
+
+// PVCVolumeOptions configure the named volume of a PersistentVolumeClaim
+type PVCVolumeOptions struct {
+	// Driver of the volume, the default driver if empty
+	Driver string
+	// Options of the driver
+	Options map[string]string
+	// UID owning the volume, if set
+	UID *int
+	// GID owning the volume, if set
+	GID *int
+}
+
+// KubeVolumeItem is a file projected from a key of a ConfigMap or a Secret
+type KubeVolumeItem struct {
+	// Path of the file relative to the volume
+	Path string
+	// Data is the content of the file
+	Data []byte
+	// Mode of the file
+	Mode os.FileMode
+}
+
+// SourceLabel returns the value of VolumeSourceLabel for the named volume of
+// a ConfigMap or a Secret
+func (v *KubeVolume) SourceLabel() string {
+	switch v.Type {
+	case KubeVolumeTypeConfigMap:
+		return "configmap/" + v.Source
+	case KubeVolumeTypeSecret:
+		return "secret/" + v.Source
+	}
+	return ""
 }
 
 // Create a KubeVolume from an HostPathVolumeSource
 func VolumeFromHostPath(hostPath *v1.HostPathVolumeSource) (*KubeVolume, error) {
+	hostPathType := v1.HostPathUnset
 	if hostPath.Type != nil {
-		switch *hostPath.Type {
-		case v1.HostPathDirectoryOrCreate:
-			if _, err := os.Stat(hostPath.Path); os.IsNotExist(err) {
-				if err := os.Mkdir(hostPath.Path, kubeDirectoryPermission); err != nil {
-					return nil, err
-				}
-			}
-			// Label a newly created volume
-			if err := libpod.LabelVolumePath(hostPath.Path); err != nil {
-				return nil, errors.Wrapf(err, "error giving %s a label", hostPath.Path)
-			}
-		case v1.HostPathFileOrCreate:
-			if _, err := os.Stat(hostPath.Path); os.IsNotExist(err) {
-				f, err := os.OpenFile(hostPath.Path, os.O_RDONLY|os.O_CREATE, kubeFilePermission)
-				if err != nil {
-					return nil, errors.Wrap(err, "error creating HostPath")
-				}
-				if err := f.Close(); err != nil {
-					logrus.Warnf("Error in closing newly created HostPath file: %v", err)
-				}
-			}
-			// unconditionally label a newly created volume
-			if err := libpod.LabelVolumePath(hostPath.Path); err != nil {
-				return nil, errors.Wrapf(err, "error giving %s a label", hostPath.Path)
+		hostPathType = *hostPath.Type
+	}
+	// Whether the path was created for the pod
+	created := false
+	switch hostPathType {
+	case v1.HostPathDirectoryOrCreate:
+		if _, err := os.Stat(hostPath.Path); os.IsNotExist(err) {
+			if err := os.MkdirAll(hostPath.Path, kubeDirectoryPermission); err != nil {
+				return nil, err
 			}
-		case v1.HostPathSocket:
-			st, err := os.Stat(hostPath.Path)
+			created = true
+		}
+		// Label a newly created volume
+		if err := libpod.LabelVolumePath(hostPath.Path); err != nil {
+			return nil, errors.Wrapf(err, "error giving %s a label", hostPath.Path)
+		}
+	case v1.HostPathFileOrCreate:
+		if _, err := os.Stat(hostPath.Path); os.IsNotExist(err) {
+			f, err := os.OpenFile(hostPath.Path, os.O_RDONLY|os.O_CREATE, kubeFilePermission)
 			if err != nil {
-				return nil, errors.Wrap(err, "error checking HostPathSocket")
+				return nil, errors.Wrap(err, "error creating HostPath")
 			}
-			if st.Mode()&os.ModeSocket != os.ModeSocket {
-				return nil, errors.Errorf("error checking HostPathSocket: path %s is not a socket", hostPath.Path)
+			if err := f.Close(); err != nil {
+				logrus.Warnf("Error in closing newly created HostPath file: %v", err)
 			}
-
-		case v1.HostPathDirectory:
-		case v1.HostPathFile:
-		case v1.HostPathUnset:
-			// do nothing here because we will verify the path exists in validateVolumeHostDir
-			break
-		default:
-			return nil, errors.Errorf("Invalid HostPath type %v", hostPath.Type)
+			created = true
 		}
+		// unconditionally label a newly created volume
+		if err := libpod.LabelVolumePath(hostPath.Path); err != nil {
+			return nil, errors.Wrapf(err, "error giving %s a label", hostPath.Path)
+		}
+	case v1.HostPathDirectory, v1.HostPathFile, v1.HostPathSocket, v1.HostPathCharDev, v1.HostPathBlockDev:
+		if err := checkHostPathType(hostPath.Path, hostPathType); err != nil {
+			return nil, err
+		}
+	case v1.HostPathUnset:
+		// do nothing here because we will verify the path exists in validateVolumeHostDir
+	default:
+		return nil, errors.Errorf("Invalid HostPath type %v", hostPathType)
 	}
 
 	if err := parse.ValidateVolumeHostDir(hostPath.Path); err != nil {
 		return nil, errors.Wrapf(err, "error in parsing HostPath in YAML")
 	}
 
-	return &KubeVolume{
+	volume := &KubeVolume{
 		Type:   KubeVolumeTypeBindMount,
 		Source: hostPath.Path,
-	}, nil
+	}
+	// Directories and files created for the pod are given a shared label,
+	// as they may be used by several pods.  Existing paths are not
+	// relabeled, which would break the host services using them.
+	if created {
+		volume.Options = []string{"z"}
+	}
+	return volume, nil
+}
+
+// checkHostPathType returns an error if the host path is not of the given
+// type.
+func checkHostPathType(path string, hostPathType v1.HostPathType) error {
+	st, err := os.Stat(path)
+	if err != nil {
+		return errors.Wrapf(err, "error checking HostPath%s", hostPathType)
+	}
+	mode := st.Mode()
+	var ok bool
+	switch hostPathType {
+	case v1.HostPathDirectory:
+		ok = mode.IsDir()
+	case v1.HostPathFile:
+		ok = mode.IsRegular()
+	case v1.HostPathSocket:
+		ok = mode&os.ModeSocket != 0
+	case v1.HostPathCharDev:
+		ok = mode&os.ModeCharDevice != 0
+	case v1.HostPathBlockDev:
+		ok = mode&os.ModeDevice != 0 && mode&os.ModeCharDevice == 0
+	}
+	if !ok {
+		return errors.Errorf("error checking HostPath%s: path %s is not a %s", hostPathType, path, hostPathType)
+	}
+	return nil
 }
 
 // Create a KubeVolume from a PersistentVolumeClaimVolumeSource
 func VolumeFromPersistentVolumeClaim(claim *v1.PersistentVolumeClaimVolumeSource) (*KubeVolume, error) {
-	return &KubeVolume{
+	volume := &KubeVolume{
 		Type:   KubeVolumeTypeNamed,
 		Source: claim.ClaimName,
-	}, nil
+	}
+	if claim.ReadOnly {
+		volume.Options = []string{"ro"}
+	}
+	return volume, nil
+}
+
+// VolumeOptionsFromPVC returns the configuration of the named volume of a
+// PersistentVolumeClaim given by the annotations of the claim.
+func VolumeOptionsFromPVC(claim *v1.PersistentVolumeClaim) (*PVCVolumeOptions, error) {
+	opts := &PVCVolumeOptions{Options: make(map[string]string)}
+	for key, value := range claim.Annotations {
+		switch key {
+		case VolumeDriverAnnotation:
+			opts.Driver = value
+		case VolumeDeviceAnnotation:
+			opts.Options["device"] = value
+		case VolumeTypeAnnotation:
+			opts.Options["type"] = value
+		case VolumeMountOptionsAnnotation:
+			opts.Options["o"] = value
+		case VolumeUIDAnnotation, VolumeGIDAnnotation:
+			id, err := strconv.Atoi(value)
+			if err != nil || id < 0 {
+				return nil, errors.Errorf("invalid value %q of annotation %s of PersistentVolumeClaim %s", value, key, claim.Name)
+			}
+			if key == VolumeUIDAnnotation {
+				opts.UID = &id
+			} else {
+				opts.GID = &id
+			}
+		}
+	}
+	return opts, nil
+}
+
+// Create a KubeVolume from a ConfigMapVolumeSource.  The keys of the
+// ConfigMap become the files of a named volume named after the ConfigMap.
+func VolumeFromConfigMap(cmVolume *v1.ConfigMapVolumeSource, configMaps []v1.ConfigMap) (*KubeVolume, error) {
+	kv := &KubeVolume{
+		Type:   KubeVolumeTypeConfigMap,
+		Source: cmVolume.Name,
+	}
+	optional := isOptional(cmVolume.Optional)
+	cm := findConfigMap(cmVolume.Name, configMaps)
+	if cm == nil {
+		// An optional ConfigMap which does not exist is mounted as an
+		// empty volume.
+		if optional {
+			return kv, nil
+		}
+		return nil, errors.Errorf("configmap %q not found", cmVolume.Name)
+	}
+
+	data := make(map[string][]byte, len(cm.Data)+len(cm.BinaryData))
+	for k, v := range cm.Data {
+		data[k] = []byte(v)
+	}
+	for k, v := range cm.BinaryData {
+		data[k] = v
+	}
+	items, err := volumeItems(data, cmVolume.Items, cmVolume.DefaultMode, v1.ConfigMapVolumeSourceDefaultMode, optional)
+	if err != nil {
+		return nil, errors.Wrapf(err, "configmap %q", cmVolume.Name)
+	}
+	kv.Items = items
+	return kv, nil
+}
+
+// Create a KubeVolume from a SecretVolumeSource.  The keys of the Secret
+// become the files of a named volume named after the Secret.
+func VolumeFromSecret(secretVolume *v1.SecretVolumeSource, secrets []v1.Secret) (*KubeVolume, error) {
+	kv := &KubeVolume{
+		Type:   KubeVolumeTypeSecret,
+		Source: secretVolume.SecretName,
+	}
+	optional := isOptional(secretVolume.Optional)
+	secret := findSecret(secretVolume.SecretName, secrets)
+	if secret == nil {
+		// An optional Secret which does not exist is mounted as an
+		// empty volume.
+		if optional {
+			return kv, nil
+		}
+		return nil, errors.Errorf("secret %q not found", secretVolume.SecretName)
+	}
+
+	items, err := volumeItems(secretData(secret), secretVolume.Items, secretVolume.DefaultMode, v1.SecretVolumeSourceDefaultMode, optional)
+	if err != nil {
+		return nil, errors.Wrapf(err, "secret %q", secretVolume.SecretName)
+	}
+	kv.Items = items
+	return kv, nil
+}
+
+// volumeItems returns the files projected from data.  Without keyToPaths,
+// every key becomes a file of the same name.  Otherwise, only the listed keys
+// are projected, to the given paths; a missing key is an error unless the
+// volume is optional.
+func volumeItems(data map[string][]byte, keyToPaths []v1.KeyToPath, defaultMode *int32, fallbackMode int32, optional bool) ([]KubeVolumeItem, error) {
+	mode := os.FileMode(fallbackMode)
+	if defaultMode != nil {
+		mode = os.FileMode(*defaultMode)
+	}
+
+	for k := range data {
+		if err := validateKey(k); err != nil {
+			return nil, err
+		}
+	}
+
+	var items []KubeVolumeItem
+	if len(keyToPaths) == 0 {
+		for k, v := range data {
+			items = append(items, KubeVolumeItem{Path: k, Data: v, Mode: mode})
+		}
+		sort.Slice(items, func(i, j int) bool { return items[i].Path < items[j].Path })
+		return items, nil
+	}
+	for _, keyToPath := range keyToPaths {
+		value, ok := data[keyToPath.Key]
+		if !ok {
+			if optional {
+				continue
+			}
+			return nil, errors.Errorf("key %q not found", keyToPath.Key)
+		}
+		if err := validateItemPath(keyToPath.Path); err != nil {
+			return nil, err
+		}
+		item := KubeVolumeItem{Path: keyToPath.Path, Data: value, Mode: mode}
+		if keyToPath.Mode != nil {
+			item.Mode = os.FileMode(*keyToPath.Mode)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// keyRegexp matches the valid keys of a ConfigMap or a Secret in Kubernetes.
+var keyRegexp = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+
+// validateKey checks that a key of a ConfigMap or a Secret is valid, as
+// Kubernetes does.  Keys are used as file names in the volumes.
+func validateKey(key string) error {
+	if !keyRegexp.MatchString(key) || key == "." || key == ".." {
+		return errors.Errorf("invalid key %q: must consist of alphanumeric characters, '-', '_' or '.'", key)
+	}
+	return nil
+}
+
+// validateItemPath checks that the path of a file of a volume stays within
+// the volume, as Kubernetes does.
+func validateItemPath(path string) error {
+	if path == "" || filepath.IsAbs(path) {
+		return errors.Errorf("invalid path %q: must be a relative path", path)
+	}
+	for _, element := range strings.Split(path, "/") {
+		if element == ".." {
+			return errors.Errorf("invalid path %q: must not contain '..'", path)
+		}
+	}
+	return nil
 }
 
 // Create a KubeVolume from one of the supported VolumeSource
-func VolumeFromSource(volumeSource v1.VolumeSource) (*KubeVolume, error) {
-	if volumeSource.HostPath != nil {
+func VolumeFromSource(volumeSource v1.VolumeSource, configMaps []v1.ConfigMap, secrets []v1.Secret) (*KubeVolume, error) {
+	switch {
+	case volumeSource.HostPath != nil:
 		return VolumeFromHostPath(volumeSource.HostPath)
-	} else if volumeSource.PersistentVolumeClaim != nil {
+	case volumeSource.PersistentVolumeClaim != nil:
 		return VolumeFromPersistentVolumeClaim(volumeSource.PersistentVolumeClaim)
-	} else {
-		return nil, errors.Errorf("HostPath and PersistentVolumeClaim are currently the only supported VolumeSource")
+	case volumeSource.ConfigMap != nil:
+		return VolumeFromConfigMap(volumeSource.ConfigMap, configMaps)
+	case volumeSource.Secret != nil:
+		return VolumeFromSecret(volumeSource.Secret, secrets)
+	default:
+		return nil, errors.Errorf("HostPath, PersistentVolumeClaim, ConfigMap and Secret are currently the only supported VolumeSource")
 	}
 }
 
 // Create a map of volume name to KubeVolume
-func InitializeVolumes(specVolumes []v1.Volume) (map[string]*KubeVolume, error) {
+func InitializeVolumes(specVolumes []v1.Volume, configMaps []v1.ConfigMap, secrets []v1.Secret) (map[string]*KubeVolume, error) {
 	volumes := make(map[string]*KubeVolume)
 
 	for _, specVolume := range specVolumes {
-		volume, err := VolumeFromSource(specVolume.VolumeSource)
+		volume, err := VolumeFromSource(specVolume.VolumeSource, configMaps, secrets)
 		if err != nil {
 			return nil, err
 		}
@@ -28,8 +28,18 @@ func GetDefaultNamespaceMode(nsType string, cfg *config.Config, pod *libpod.Pod)
 	// Ensure case insensitivity
 	nsType = strings.ToLower(nsType)
 
-	// If the pod is not nil - check shared namespaces
-	if pod != nil && pod.HasInfraContainer() {
+	// If the pod is not nil - check shared namespaces.
+	// Pods without infra container share the namespaces of their first
+	// container, which creates them.
+	sharesFromPod := false
+	if pod != nil {
+		nsHolderID, err := pod.NamespaceHolderID()
+		if err != nil {
+			return toReturn, errors.Wrapf(err, "error looking up pod %s namespace holder", pod.ID())
+		}
+		sharesFromPod = pod.HasInfraContainer() || nsHolderID != ""
+	}
+	if sharesFromPod {
 		podMode := false
 		switch {
 		case nsType == "pid" && pod.SharesPID():
@@ -85,7 +95,7 @@ func namespaceOptions(ctx context.Context, s *specgen.SpecGenerator, rt *libpod.
 	// If pod is not nil, get infra container.
 	var infraCtr *libpod.Container
 	if pod != nil {
-		infraID, err := pod.InfraContainerID()
+		infraID, err := pod.NamespaceHolderID()
 		if err != nil {
 			// This is likely to be of the fatal kind (pod was
 			// removed) so hard fail
@@ -100,7 +110,7 @@ func namespaceOptions(ctx context.Context, s *specgen.SpecGenerator, rt *libpod.
 		}
 	}
 
-	errNoInfra := errors.Wrapf(define.ErrInvalidArg, "cannot use pod namespace as container is not joining a pod or pod has no infra container")
+	errNoInfra := errors.Wrapf(define.ErrInvalidArg, "cannot use pod namespace as container is not joining a pod or pod has no infra container or container holding its namespaces")
 
 	// PID
 	switch s.PidNS.NSMode {
@@ -166,6 +176,9 @@ func namespaceOptions(ctx context.Context, s *specgen.SpecGenerator, rt *libpod.
 			return nil, errNoInfra
 		}
 		toReturn = append(toReturn, libpod.WithUserNSFrom(infraCtr))
+		if pod.KeepID() && rootless.IsRootless() {
+			toReturn = append(toReturn, libpod.WithAddCurrentUserPasswdEntry())
+		}
 	case specgen.FromContainer:
 		userCtr, err := rt.LookupContainer(s.UserNS.Value)
 		if err != nil {
@@ -174,7 +187,9 @@ func namespaceOptions(ctx context.Context, s *specgen.SpecGenerator, rt *libpod.
 		toReturn = append(toReturn, libpod.WithUserNSFrom(userCtr))
 	}
 
-	if s.IDMappings != nil {
+	// Containers joining the user namespace of another container use its
+	// ID mappings.
+	if s.IDMappings != nil && s.UserNS.NSMode != specgen.FromPod && s.UserNS.NSMode != specgen.FromContainer {
 		toReturn = append(toReturn, libpod.WithIDMappings(*s.IDMappings))
 	}
 	if s.User != "" {
@@ -224,7 +239,7 @@ func namespaceOptions(ctx context.Context, s *specgen.SpecGenerator, rt *libpod.
 		}
 		toReturn = append(toReturn, libpod.WithNetNSFrom(netCtr))
 	case specgen.Slirp:
-		portMappings, err := createPortMappings(ctx, s, img)
+		portMappings, err := createPortMappings(ctx, s, rt, img)
 		if err != nil {
 			return nil, err
 		}
@@ -236,7 +251,7 @@ func namespaceOptions(ctx context.Context, s *specgen.SpecGenerator, rt *libpod.
 	case specgen.Private:
 		fallthrough
 	case specgen.Bridge:
-		portMappings, err := createPortMappings(ctx, s, img)
+		portMappings, err := createPortMappings(ctx, s, rt, img)
 		if err != nil {
 			return nil, err
 		}
@@ -248,6 +263,9 @@ func namespaceOptions(ctx context.Context, s *specgen.SpecGenerator, rt *libpod.
 	} else if len(s.HostAdd) > 0 {
 		toReturn = append(toReturn, libpod.WithHosts(s.HostAdd))
 	}
+	if s.BaseHostsFile != "" {
+		toReturn = append(toReturn, libpod.WithBaseHostsFile(s.BaseHostsFile))
+	}
 	if len(s.DNSSearch) > 0 {
 		toReturn = append(toReturn, libpod.WithDNSSearch(s.DNSSearch))
 	}
@@ -266,9 +284,18 @@ func namespaceOptions(ctx context.Context, s *specgen.SpecGenerator, rt *libpod.
 	if s.StaticIP != nil {
 		toReturn = append(toReturn, libpod.WithStaticIP(*s.StaticIP))
 	}
+	if s.StaticIPv6 != nil {
+		toReturn = append(toReturn, libpod.WithStaticIPv6(*s.StaticIPv6))
+	}
 	if s.StaticMAC != nil {
 		toReturn = append(toReturn, libpod.WithStaticMAC(*s.StaticMAC))
 	}
+	if len(s.StaticIPs) > 0 {
+		toReturn = append(toReturn, libpod.WithNetworkStaticIPs(s.StaticIPs))
+	}
+	if len(s.StaticMACs) > 0 {
+		toReturn = append(toReturn, libpod.WithNetworkStaticMACs(s.StaticMACs))
+	}
 	if s.NetworkOptions != nil {
 		toReturn = append(toReturn, libpod.WithNetworkOptions(s.NetworkOptions))
 	}
@@ -382,6 +409,13 @@ func specConfigureNamespaces(s *specgen.SpecGenerator, g *generate.Generator, rt
 		if err := g.RemoveLinuxNamespace(string(spec.UserNamespace)); err != nil {
 			return err
 		}
+	case specgen.FromPod:
+		// The user who created a keep-id pod runs the containers
+		// joining its user namespace, as with keep-id containers.
+		if pod != nil && pod.KeepID() && rootless.IsRootless() {
+			g.SetProcessUID(uint32(rootless.GetRootlessUID()))
+			g.SetProcessGID(uint32(rootless.GetRootlessGID()))
+		}
 	case specgen.KeepID:
 		var (
 			err      error
@@ -480,7 +514,7 @@ func GetNamespaceOptions(ns []string) ([]libpod.PodCreateOption, error) {
 		case "pid":
 			options = append(options, libpod.WithPodPID())
 		case "user":
-			continue
+			options = append(options, libpod.WithPodUser())
 		case "ipc":
 			options = append(options, libpod.WithPodIPC())
 		case "uts":
@@ -489,7 +523,7 @@ func GetNamespaceOptions(ns []string) ([]libpod.PodCreateOption, error) {
 		case "none":
 			return erroredOptions, nil
 		default:
-			return erroredOptions, errors.Errorf("Invalid kernel namespace to share: %s. Options are: net, pid, ipc, uts or none", toShare)
+			return erroredOptions, errors.Errorf("Invalid kernel namespace to share: %s. Options are: cgroup, ipc, net, pid, user, uts or none", toShare)
 		}
 	}
 	return options, nil
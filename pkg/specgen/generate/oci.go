@@ -267,6 +267,9 @@ func SpecGenToOCI(ctx context.Context, s *specgen.SpecGenerator, rt *libpod.Runt
 	g.SetProcessArgs(finalCmd)
 
 	g.SetProcessTerminal(s.Terminal)
+	if s.Terminal && s.ConsoleSize != nil {
+		g.SetProcessConsoleSize(s.ConsoleSize.Width, s.ConsoleSize.Height)
+	}
 
 	for key, val := range s.Annotations {
 		g.AddAnnotation(key, val)
@@ -285,9 +288,11 @@ func SpecGenToOCI(ctx context.Context, s *specgen.SpecGenerator, rt *libpod.Runt
 		}
 	} else {
 		// add default devices from containers.conf
-		for _, device := range rtc.Containers.Devices {
-			if err := DevicesFromPath(&g, device); err != nil {
-				return nil, err
+		if !s.NoDefaultDevices {
+			for _, device := range rtc.Containers.Devices {
+				if err := DevicesFromPath(&g, device); err != nil {
+					return nil, err
+				}
 			}
 		}
 		// add default devices specified by caller
@@ -314,7 +319,7 @@ func SpecGenToOCI(ctx context.Context, s *specgen.SpecGenerator, rt *libpod.Runt
 	}
 	configSpec := g.Config
 
-	if err := securityConfigureGenerator(s, &g, newImage, rtc); err != nil {
+	if err := securityConfigureGenerator(s, &g, newImage, rtc, rt.ExtraConfig().UnprivilegedPing); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,88 @@
+package generate
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/containers/podman/v2/libpod/image"
+	"github.com/containers/podman/v2/pkg/specgen"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// qemuArchitectures maps architectures to the names of the qemu user mode
+// emulators, which are registered with binfmt_misc as "qemu-NAME".
+var qemuArchitectures = map[string]string{
+	"386":      "i386",
+	"amd64":    "x86_64",
+	"arm":      "arm",
+	"arm64":    "aarch64",
+	"mips64le": "mips64el",
+	"ppc64le":  "ppc64le",
+	"riscv64":  "riscv64",
+	"s390x":    "s390x",
+}
+
+// validateImagePlatform checks that the image of the container can run on
+// the host, which is the case if it is built for the platform of the host or
+// an emulator for its architecture is registered.  Depending on policy, the
+// image_platform_policy of containers.conf, a mismatch is ignored, logged as
+// a warning or an error.  Images explicitly selected for a platform are not
+// validated.
+func validateImagePlatform(ctx context.Context, img *image.Image, s *specgen.SpecGenerator, policy string) error {
+	if s.ImagePlatform != "" {
+		return nil
+	}
+	if policy == define.ImagePlatformPolicyIgnore {
+		return nil
+	}
+	data, err := img.InspectNoSize(ctx)
+	if err != nil {
+		return err
+	}
+	if platformSupported(data.Os, data.Architecture) {
+		return nil
+	}
+
+	msg := fmt.Sprintf("image %s is built for platform %s/%s which does not match the host platform %s/%s and no emulation is available, so the container will likely fail with \"exec format error\" (use --platform to select the platform explicitly)",
+		s.Image, data.Os, data.Architecture, runtime.GOOS, runtime.GOARCH)
+	if policy == define.ImagePlatformPolicyError {
+		return errors.New(msg)
+	}
+	logrus.Warn(msg)
+	return nil
+}
+
+// platformSupported returns whether the host can run binaries of the given
+// OS and architecture.  Unknown values are assumed to be supported.
+func platformSupported(imgOS, arch string) bool {
+	if imgOS != "" && imgOS != runtime.GOOS {
+		return false
+	}
+	if arch == "" || arch == runtime.GOARCH {
+		return true
+	}
+	if arch == "386" && runtime.GOARCH == "amd64" {
+		return true
+	}
+	return emulationAvailable(arch)
+}
+
+// emulationAvailable returns whether an emulator for arch is registered and
+// enabled with binfmt_misc.
+func emulationAvailable(arch string) bool {
+	name, ok := qemuArchitectures[arch]
+	if !ok {
+		return false
+	}
+	content, err := ioutil.ReadFile(filepath.Join("/proc/sys/fs/binfmt_misc", "qemu-"+name))
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(string(content), "enabled")
+}
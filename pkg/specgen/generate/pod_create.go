@@ -13,6 +13,11 @@ func MakePod(p *specgen.PodSpecGenerator, rt *libpod.Runtime) (*libpod.Pod, erro
 	if err := p.Validate(); err != nil {
 		return nil, err
 	}
+	unlock, err := lockPortAllocation(rt, p.PortMappings, false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
 	options, err := createPodOptions(p, rt)
 	if err != nil {
 		return nil, err
@@ -24,13 +29,15 @@ func createPodOptions(p *specgen.PodSpecGenerator, rt *libpod.Runtime) ([]libpod
 	var (
 		options []libpod.PodCreateOption
 	)
-	if !p.NoInfra {
-		options = append(options, libpod.WithInfraContainer())
+	if !p.NoInfra || len(p.SharedNamespaces) > 0 {
 		nsOptions, err := GetNamespaceOptions(p.SharedNamespaces)
 		if err != nil {
 			return nil, err
 		}
 		options = append(options, nsOptions...)
+	}
+	if !p.NoInfra {
+		options = append(options, libpod.WithInfraContainer())
 
 		// Make our exit command
 		storageConfig := rt.StorageConfig()
@@ -47,6 +54,9 @@ func createPodOptions(p *specgen.PodSpecGenerator, rt *libpod.Runtime) ([]libpod
 	if len(p.CgroupParent) > 0 {
 		options = append(options, libpod.WithPodCgroupParent(p.CgroupParent))
 	}
+	if p.ResourceLimits != nil {
+		options = append(options, libpod.WithPodResourceLimits(p.ResourceLimits))
+	}
 	if len(p.Labels) > 0 {
 		options = append(options, libpod.WithPodLabels(p.Labels))
 	}
@@ -75,6 +85,9 @@ func createPodOptions(p *specgen.PodSpecGenerator, rt *libpod.Runtime) ([]libpod
 	if p.StaticIP != nil {
 		options = append(options, libpod.WithPodStaticIP(*p.StaticIP))
 	}
+	if p.StaticIPv6 != nil {
+		options = append(options, libpod.WithPodStaticIPv6(*p.StaticIPv6))
+	}
 	if p.StaticMAC != nil {
 		options = append(options, libpod.WithPodStaticMAC(*p.StaticMAC))
 	}
@@ -93,6 +106,18 @@ func createPodOptions(p *specgen.PodSpecGenerator, rt *libpod.Runtime) ([]libpod
 		options = append(options, libpod.WithInfraCommand(p.InfraCommand))
 	}
 
+	if len(p.InfraName) > 0 {
+		options = append(options, libpod.WithInfraName(p.InfraName))
+	}
+
+	if p.ShmSize != nil {
+		options = append(options, libpod.WithPodShmSize(*p.ShmSize))
+	}
+
+	if p.Userns.NSMode == specgen.KeepID {
+		options = append(options, libpod.WithPodKeepID())
+	}
+
 	switch p.NetNS.NSMode {
 	case specgen.Bridge, specgen.Default, "":
 		logrus.Debugf("Pod using default network mode")
@@ -109,8 +134,15 @@ func createPodOptions(p *specgen.PodSpecGenerator, rt *libpod.Runtime) ([]libpod
 	if p.NoManageHosts {
 		options = append(options, libpod.WithPodUseImageHosts())
 	}
+	if p.BaseHostsFile != "" {
+		options = append(options, libpod.WithPodBaseHostsFile(p.BaseHostsFile))
+	}
 	if len(p.PortMappings) > 0 {
-		ports, _, _, err := parsePortMapping(p.PortMappings)
+		inUse, err := hostPortsInUse(rt, p.PortMappings, false)
+		if err != nil {
+			return nil, err
+		}
+		ports, _, _, err := parsePortMapping(p.PortMappings, inUse)
 		if err != nil {
 			return nil, err
 		}
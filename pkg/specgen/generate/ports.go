@@ -2,10 +2,12 @@ package generate
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"strconv"
 	"strings"
 
+	"github.com/containers/podman/v2/libpod"
 	"github.com/containers/podman/v2/libpod/image"
 	"github.com/containers/podman/v2/pkg/specgen"
 	"github.com/cri-o/ocicni/pkg/ocicni"
@@ -19,16 +21,20 @@ const (
 	protoSCTP = "sctp"
 )
 
+// hostPortSet is a set of host ports by protocol.
+type hostPortSet map[string]map[uint16]struct{}
+
 // Parse port maps to OCICNI port mappings.
 // Returns a set of OCICNI port mappings, and maps of utilized container and
-// host ports.
-func parsePortMapping(portMappings []specgen.PortMapping) ([]ocicni.PortMapping, map[string]map[string]map[uint16]uint16, map[string]map[string]map[uint16]uint16, error) {
+// host ports.  Random host ports are not picked from inUse.
+func parsePortMapping(portMappings []specgen.PortMapping, inUse hostPortSet) ([]ocicni.PortMapping, map[string]map[string]map[uint16]uint16, map[string]map[string]map[uint16]uint16, error) {
 	// First, we need to validate the ports passed in the specgen, and then
 	// convert them into CNI port mappings.
 	type tempMapping struct {
 		mapping      ocicni.PortMapping
 		startOfRange bool
 		isInRange    bool
+		rangeLen     uint16
 	}
 	tempMappings := []tempMapping{}
 
@@ -168,6 +174,7 @@ func parsePortMapping(portMappings []specgen.PortMapping) ([]ocicni.PortMapping,
 						mapping:      cniPort,
 						startOfRange: port.Range > 1 && index == 0,
 						isInRange:    port.Range > 1,
+						rangeLen:     len,
 					},
 				)
 			}
@@ -217,11 +224,16 @@ func parsePortMapping(portMappings []specgen.PortMapping) ([]ocicni.PortMapping,
 			for i := 0; i < 15; i++ {
 				// Only get a random candidate for single entries or the start
 				// of a range. Otherwise we just increment the candidate.
+				// The whole range is checked at its start, so the host
+				// ports of a range stay contiguous.
 				if !tmp.isInRange || tmp.startOfRange {
 					candidate, err = getRandomPort()
 					if err != nil {
 						return nil, nil, nil, errors.Wrapf(err, "error getting candidate host port for container port %d", p.ContainerPort)
 					}
+					if !hostPortRangeFree(p.Protocol, candidate, int(tmp.rangeLen), hostPortMap, inUse) {
+						continue
+					}
 				} else {
 					candidate++
 				}
@@ -250,9 +262,25 @@ func parsePortMapping(portMappings []specgen.PortMapping) ([]ocicni.PortMapping,
 	return finalMappings, containerPortValidate, hostPortValidate, nil
 }
 
+// ParsePortMappings parses the given port mappings into the format used by
+// libpod.  Unlike the port mappings of a new container, they are not merged
+// with the ports exposed by its image.
+func ParsePortMappings(rt *libpod.Runtime, portMappings []specgen.PortMapping) ([]ocicni.PortMapping, error) {
+	inUse, err := hostPortsInUse(rt, portMappings, false)
+	if err != nil {
+		return nil, err
+	}
+	mappings, _, _, err := parsePortMapping(portMappings, inUse)
+	return mappings, err
+}
+
 // Make final port mappings for the container
-func createPortMappings(ctx context.Context, s *specgen.SpecGenerator, img *image.Image) ([]ocicni.PortMapping, error) {
-	finalMappings, containerPortValidate, hostPortValidate, err := parsePortMapping(s.PortMappings)
+func createPortMappings(ctx context.Context, s *specgen.SpecGenerator, rt *libpod.Runtime, img *image.Image) ([]ocicni.PortMapping, error) {
+	inUse, err := hostPortsInUse(rt, s.PortMappings, s.PublishExposedPorts)
+	if err != nil {
+		return nil, err
+	}
+	finalMappings, containerPortValidate, hostPortValidate, err := parsePortMapping(s.PortMappings, inUse)
 	if err != nil {
 		return nil, err
 	}
@@ -356,7 +384,7 @@ func createPortMappings(ctx context.Context, s *specgen.SpecGenerator, img *imag
 					hostPortValidate[p]["0.0.0.0"] = hostPortMap
 				}
 
-				if checkPort := hostPortMap[uint16(candidate)]; checkPort != 0 {
+				if checkPort := hostPortMap[uint16(candidate)]; checkPort != 0 || !hostPortFree(p, candidate, inUse) {
 					// Host port is already allocated, try again
 					tries--
 					continue
@@ -437,3 +465,96 @@ func getRandomPort() (int, error) {
 	}
 	return rp, nil
 }
+
+// needsRandomHostPorts returns whether random host ports are picked for the
+// given port mappings.
+func needsRandomHostPorts(portMappings []specgen.PortMapping, publishExposed bool) bool {
+	if publishExposed {
+		return true
+	}
+	for _, port := range portMappings {
+		if port.HostPort == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// lockPortAllocation takes the port allocation lock of the runtime if random
+// host ports are picked for the given port mappings.  The returned function
+// releases it.
+func lockPortAllocation(rt *libpod.Runtime, portMappings []specgen.PortMapping, publishExposed bool) (func(), error) {
+	if !needsRandomHostPorts(portMappings, publishExposed) {
+		return func() {}, nil
+	}
+	lock, err := rt.PortAllocationLock()
+	if err != nil {
+		return nil, err
+	}
+	lock.Lock()
+	return lock.Unlock, nil
+}
+
+// hostPortsInUse returns the host ports published by the existing containers,
+// if random host ports are picked for the given port mappings.  They are not
+// bound until the containers are started, so a random port must not be taken
+// from them.
+func hostPortsInUse(rt *libpod.Runtime, portMappings []specgen.PortMapping, publishExposed bool) (hostPortSet, error) {
+	if rt == nil || !needsRandomHostPorts(portMappings, publishExposed) {
+		return nil, nil
+	}
+	ctrs, err := rt.GetAllContainers()
+	if err != nil {
+		return nil, errors.Wrapf(err, "error retrieving containers to find used host ports")
+	}
+	inUse := make(hostPortSet)
+	for _, ctr := range ctrs {
+		for _, port := range ctr.Config().PortMappings {
+			ports, ok := inUse[port.Protocol]
+			if !ok {
+				ports = make(map[uint16]struct{})
+				inUse[port.Protocol] = ports
+			}
+			ports[uint16(port.HostPort)] = struct{}{}
+		}
+	}
+	return inUse, nil
+}
+
+// hostPortFree returns whether the given host port can be picked as a random
+// port: it is neither published by an existing container nor bound on the
+// host.
+func hostPortFree(protocol string, port int, inUse hostPortSet) bool {
+	if _, ok := inUse[protocol][uint16(port)]; ok {
+		return false
+	}
+	switch protocol {
+	case protoTCP:
+		l, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+		if err != nil {
+			return false
+		}
+		l.Close()
+	case protoUDP:
+		l, err := net.ListenPacket("udp", fmt.Sprintf(":%d", port))
+		if err != nil {
+			return false
+		}
+		l.Close()
+	}
+	return true
+}
+
+// hostPortRangeFree returns whether all length host ports starting at start
+// can be picked as random ports.
+func hostPortRangeFree(protocol string, start, length int, hostPortMap map[uint16]uint16, inUse hostPortSet) bool {
+	if start+length-1 > 65535 {
+		return false
+	}
+	for port := start; port < start+length; port++ {
+		if hostPortMap[uint16(port)] != 0 || !hostPortFree(protocol, port, inUse) {
+			return false
+		}
+	}
+	return true
+}
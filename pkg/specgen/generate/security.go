@@ -11,6 +11,7 @@ import (
 	"github.com/containers/podman/v2/libpod/image"
 	"github.com/containers/podman/v2/pkg/specgen"
 	"github.com/containers/podman/v2/pkg/util"
+	"github.com/containers/storage/pkg/idtools"
 	"github.com/opencontainers/runtime-tools/generate"
 	"github.com/opencontainers/selinux/go-selinux/label"
 	"github.com/pkg/errors"
@@ -80,7 +81,7 @@ func setupApparmor(s *specgen.SpecGenerator, rtc *config.Config, g *generate.Gen
 	return nil
 }
 
-func securityConfigureGenerator(s *specgen.SpecGenerator, g *generate.Generator, newImage *image.Image, rtc *config.Config) error {
+func securityConfigureGenerator(s *specgen.SpecGenerator, g *generate.Generator, newImage *image.Image, rtc *config.Config, unprivilegedPing bool) error {
 	var (
 		caplist []string
 		err     error
@@ -207,6 +208,10 @@ func securityConfigureGenerator(s *specgen.SpecGenerator, g *generate.Generator,
 		g.AddLinuxSysctl(sysctlKey, sysctlVal)
 	}
 
+	if err := setUnprivilegedPing(s, g, unprivilegedPing); err != nil {
+		return err
+	}
+
 	for sysctlKey, sysctlVal := range s.Sysctl {
 
 		if s.IpcNS.IsHost() && strings.HasPrefix(sysctlKey, "fs.mqueue.") {
@@ -228,3 +233,32 @@ func securityConfigureGenerator(s *specgen.SpecGenerator, g *generate.Generator,
 
 	return nil
 }
+
+// setUnprivilegedPing allows all users of the container to ping by setting
+// net.ipv4.ping_group_range to the GIDs of its user namespace, if
+// unprivileged_ping is set in containers.conf.  Containers joining an existing
+// network namespace, or whose user namespace is only chosen when the container
+// is created, are left alone.
+func setUnprivilegedPing(s *specgen.SpecGenerator, g *generate.Generator, enabled bool) error {
+	if !enabled {
+		return nil
+	}
+	switch s.NetNS.NSMode {
+	case specgen.Host, specgen.FromContainer, specgen.FromPod, specgen.Path:
+		return nil
+	}
+	if s.UserNS.NSMode == specgen.Auto {
+		logrus.Debugf("Not setting %s for a container with an automatically chosen user namespace", util.PingGroupRangeSysctl)
+		return nil
+	}
+	var gidMap []idtools.IDMap
+	if s.IDMappings != nil {
+		gidMap = s.IDMappings.GIDMap
+	}
+	pingGroupRange, err := util.UserNSPingGroupRange(gidMap)
+	if err != nil {
+		return errors.Wrapf(err, "error computing %s for unprivileged_ping", util.PingGroupRangeSysctl)
+	}
+	g.AddLinuxSysctl(util.PingGroupRangeSysctl, pingGroupRange)
+	return nil
+}
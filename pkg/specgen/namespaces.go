@@ -1,6 +1,7 @@
 package specgen
 
 import (
+	"net"
 	"strings"
 
 	"github.com/containers/podman/v2/pkg/cgroups"
@@ -250,6 +251,78 @@ func ParseUserNamespace(ns string) (Namespace, error) {
 	return ParseNamespace(ns)
 }
 
+// ParseCNINetworks parses a comma-separated list of CNI networks.  A network
+// can be followed by its static addresses, separated by a colon from its name
+// and by commas from each other, as in
+// net1:ip=10.89.0.5,mac=92:d0:c6:0a:29:33,net2:ip=10.90.0.7.  The ip option
+// takes an IPv4 or IPv6 address.
+// Returns the networks and their static IP and MAC addresses, formatted as
+// maps of network name to address.
+func ParseCNINetworks(list string) ([]string, map[string]net.IP, map[string]net.HardwareAddr, error) {
+	var (
+		networks   []string
+		staticIPs  map[string]net.IP
+		staticMACs map[string]net.HardwareAddr
+	)
+	for _, item := range strings.Split(list, ",") {
+		option := item
+		colon := strings.Index(item, ":")
+		equals := strings.Index(item, "=")
+		switch {
+		case colon >= 0 && (equals < 0 || colon < equals):
+			// A network followed by its first option.
+			networks = append(networks, item[:colon])
+			option = item[colon+1:]
+		case equals < 0:
+			// A network without options.
+			networks = append(networks, item)
+			continue
+		case len(networks) == 0:
+			return nil, nil, nil, errors.Errorf("network option %q must follow the name of a network", item)
+		}
+
+		netName := networks[len(networks)-1]
+		parts := strings.SplitN(option, "=", 2)
+		if len(parts) != 2 {
+			return nil, nil, nil, errors.Errorf("invalid option %q for network %s, must be in the form key=value", option, netName)
+		}
+		switch parts[0] {
+		case "ip":
+			ip := net.ParseIP(parts[1])
+			if ip == nil {
+				return nil, nil, nil, errors.Errorf("invalid IP address %q for network %s", parts[1], netName)
+			}
+			if _, ok := staticIPs[netName]; ok {
+				return nil, nil, nil, errors.Errorf("more than one IP address for network %s", netName)
+			}
+			if staticIPs == nil {
+				staticIPs = make(map[string]net.IP)
+			}
+			staticIPs[netName] = ip
+		case "mac":
+			mac, err := net.ParseMAC(parts[1])
+			if err != nil {
+				return nil, nil, nil, errors.Wrapf(err, "invalid MAC address %q for network %s", parts[1], netName)
+			}
+			if _, ok := staticMACs[netName]; ok {
+				return nil, nil, nil, errors.Errorf("more than one MAC address for network %s", netName)
+			}
+			if staticMACs == nil {
+				staticMACs = make(map[string]net.HardwareAddr)
+			}
+			staticMACs[netName] = mac
+		default:
+			return nil, nil, nil, errors.Errorf("unknown option %q for network %s", parts[0], netName)
+		}
+	}
+	for _, netName := range networks {
+		if netName == "" {
+			return nil, nil, nil, errors.Errorf("invalid network list %q, network names cannot be empty", list)
+		}
+	}
+	return networks, staticIPs, staticMACs, nil
+}
+
 // ParseNetworkNamespace parses a network namespace specification in string
 // form.
 // Returns a namespace and (optionally) a list of CNI networks to join.
@@ -0,0 +1,45 @@
+package specgen
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCNINetworks(t *testing.T) {
+	networks, ips, macs, err := ParseCNINetworks("net1,net2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"net1", "net2"}, networks)
+	assert.Nil(t, ips)
+	assert.Nil(t, macs)
+
+	networks, ips, macs, err = ParseCNINetworks("net1:ip=10.89.0.5,mac=92:d0:c6:0a:29:33,net2,net3:ip=fd00::5")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"net1", "net2", "net3"}, networks)
+	assert.Equal(t, map[string]net.IP{
+		"net1": net.ParseIP("10.89.0.5"),
+		"net3": net.ParseIP("fd00::5"),
+	}, ips)
+	mac, err := net.ParseMAC("92:d0:c6:0a:29:33")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]net.HardwareAddr{"net1": mac}, macs)
+}
+
+func TestParseCNINetworksInvalid(t *testing.T) {
+	for _, list := range []string{
+		"ip=10.89.0.5",
+		"net1:ip=10.89.0.500",
+		"net1:mac=92:d0",
+		"net1:ip=10.89.0.5,ip=10.89.0.6",
+		"net1:mac=92:d0:c6:0a:29:33,mac=92:d0:c6:0a:29:34",
+		"net1:foo=bar",
+		"net1:ip",
+		"net1,,net2",
+		":ip=10.89.0.5",
+	} {
+		_, _, _, err := ParseCNINetworks(list)
+		assert.Error(t, err, list)
+	}
+}
@@ -21,7 +21,7 @@ func exclusivePodOptions(opt1, opt2 string) error {
 func (p *PodSpecGenerator) Validate() error {
 
 	if rootless.IsRootless() {
-		if p.StaticIP != nil {
+		if p.StaticIP != nil || p.StaticIPv6 != nil {
 			return ErrNoStaticIPRootless
 		}
 		if p.StaticMAC != nil {
@@ -37,9 +37,20 @@ func (p *PodSpecGenerator) Validate() error {
 		if len(p.InfraImage) > 0 {
 			return exclusivePodOptions("NoInfra", "InfraImage")
 		}
-		if len(p.SharedNamespaces) > 0 {
-			return exclusivePodOptions("NoInfra", "SharedNamespaces")
+		if len(p.InfraName) > 0 {
+			return exclusivePodOptions("NoInfra", "InfraName")
+		}
+		if p.ShmSize != nil {
+			return exclusivePodOptions("NoInfra", "ShmSize")
 		}
+		if !p.Userns.IsDefault() {
+			return exclusivePodOptions("NoInfra", "Userns")
+		}
+	}
+	switch p.Userns.NSMode {
+	case "", Default, Host, KeepID:
+	default:
+		return errors.Errorf("pods presently do not support user namespace mode %s", p.Userns.NSMode)
 	}
 
 	// PodNetworkConfig
@@ -53,6 +64,9 @@ func (p *PodSpecGenerator) Validate() error {
 		if p.StaticIP != nil {
 			return exclusivePodOptions("NoInfra", "StaticIP")
 		}
+		if p.StaticIPv6 != nil {
+			return exclusivePodOptions("NoInfra", "StaticIPv6")
+		}
 		if p.StaticMAC != nil {
 			return exclusivePodOptions("NoInfra", "StaticMAC")
 		}
@@ -68,6 +82,9 @@ func (p *PodSpecGenerator) Validate() error {
 		if len(p.HostAdd) > 0 {
 			return exclusivePodOptions("NoInfra", "HostAdd")
 		}
+		if p.BaseHostsFile != "" {
+			return exclusivePodOptions("NoInfra", "BaseHostsFile")
+		}
 		if p.NoManageResolvConf {
 			return exclusivePodOptions("NoInfra", "NoManageResolvConf")
 		}
@@ -94,6 +111,28 @@ func (p *PodSpecGenerator) Validate() error {
 	if p.NoManageHosts && len(p.HostAdd) > 0 {
 		return exclusivePodOptions("NoManageHosts", "HostAdd")
 	}
+	if p.NoManageHosts && p.BaseHostsFile != "" {
+		return exclusivePodOptions("NoManageHosts", "BaseHostsFile")
+	}
+
+	// PodCgroupConfig
+	if res := p.ResourceLimits; res != nil {
+		if res.BlockIO != nil || res.Network != nil || len(res.Devices) > 0 || len(res.HugepageLimits) > 0 || len(res.Rdma) > 0 {
+			return errors.Wrapf(ErrInvalidPodSpecConfig, "only CPU, memory and pids limits can be set on pods")
+		}
+		if res.CPU != nil && (res.CPU.Shares != nil || res.CPU.RealtimePeriod != nil || res.CPU.RealtimeRuntime != nil || res.CPU.Cpus != "" || res.CPU.Mems != "") {
+			return errors.Wrapf(ErrInvalidPodSpecConfig, "only the CPU quota and period can be set on pods")
+		}
+		if res.Memory != nil && res.Memory.Limit == nil {
+			return errors.Wrapf(ErrInvalidPodSpecConfig, "only the memory limit can be set on pods")
+		}
+		if res.Memory != nil && res.Memory.Limit != nil && *res.Memory.Limit < 0 {
+			return errors.Wrapf(ErrInvalidPodSpecConfig, "memory limit of pods must not be negative")
+		}
+		if res.Pids != nil && res.Pids.Limit < 0 {
+			return errors.Wrapf(ErrInvalidPodSpecConfig, "pids limit of pods must not be negative")
+		}
+	}
 
 	return nil
 }
@@ -2,6 +2,8 @@ package specgen
 
 import (
 	"net"
+
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 )
 
 // PodBasicConfig contains basic configuration options for pods.
@@ -41,14 +43,34 @@ type PodBasicConfig struct {
 	// Conflicts with NoInfra=true.
 	// Optional.
 	InfraImage string `json:"infra_image,omitempty"`
+	// InfraName is the name of the infra container.
+	// If not set, the name is made of the first characters of the pod ID
+	// followed by "-infra".
+	// Conflicts with NoInfra=true.
+	// Optional.
+	InfraName string `json:"infra_name,omitempty"`
+	// ShmSize is the size of the tmpfs mounted at /dev/shm in the infra
+	// container, in bytes. All containers sharing the pod's IPC namespace
+	// share this /dev/shm. If not set, the containers.conf default is used.
+	// Conflicts with NoInfra=true.
+	// Optional.
+	ShmSize *int64 `json:"shm_size,omitempty"`
 	// SharedNamespaces instructs the pod to share a set of namespaces.
 	// Shared namespaces will be joined (by default) by every container
 	// which joins the pod.
 	// If not set and NoInfra is false, the pod will set a default set of
 	// namespaces to share.
-	// Conflicts with NoInfra=true.
+	// If NoInfra is true, the namespaces are created by the first container
+	// created in the pod, and joined by the containers created after it.
 	// Optional.
 	SharedNamespaces []string `json:"shared_namespaces,omitempty"`
+	// Userns is the user namespace of the infra container. Only host and
+	// keep-id are supported. With keep-id, the user namespace is shared
+	// by the containers of the pod, even if it is not included in
+	// SharedNamespaces.
+	// Conflicts with NoInfra=true.
+	// Optional.
+	Userns Namespace `json:"userns,omitempty"`
 	// PodCreateCommand is the command used to create this pod.
 	// This will be shown in the output of Inspect() on the pod, and may
 	// also be used by some tools that wish to recreate the pod
@@ -74,6 +96,14 @@ type PodNetworkConfig struct {
 	// As such, conflicts with NoInfra=true by proxy.
 	// Optional.
 	StaticIP *net.IP `json:"static_ip,omitempty"`
+	// StaticIPv6 sets a static IPv6 address for the infra container. As
+	// the infra container's network is used for the entire pod by
+	// default, this will thus be a static IPv6 address for the whole pod.
+	// Only available if NetNS is set to Bridge (the default for root).
+	// As such, conflicts with NoInfra=true by proxy.
+	// Conflicts with StaticIP.
+	// Optional.
+	StaticIPv6 *net.IP `json:"static_ipv6,omitempty"`
 	// StaticMAC sets a static MAC for the infra container. As the infra
 	// container's network is used for the entire pod by default, this will
 	// thus be a static MAC for the entire pod.
@@ -128,6 +158,12 @@ type PodNetworkConfig struct {
 	// they would if not in a pod.
 	// Conflicts with HostAdd.
 	NoManageHosts bool `json:"no_manage_hosts,omitempty"`
+	// BaseHostsFile is the base of the infra container's /etc/hosts:
+	// "image" for the /etc/hosts file of the infra image, "none" for no
+	// base file or the path of a file on the host.
+	// Conflicts with NoInfra=true and NoManageHosts.
+	// Optional.
+	BaseHostsFile string `json:"base_hosts_file,omitempty"`
 	// HostAdd is a set of hosts that will be added to the infra container's
 	// /etc/hosts that will, by default, be shared with all containers in
 	// the pod.
@@ -147,6 +183,12 @@ type PodCgroupConfig struct {
 	// containers in the pod.
 	// Optional.
 	CgroupParent string `json:"cgroup_parent,omitempty"`
+	// ResourceLimits are the CPU, memory and pids limits of the pod's
+	// cgroup. They are enforced on all containers of the pod together.
+	// Only CPU quota and period, memory limit and pids limit are
+	// supported.
+	// Optional.
+	ResourceLimits *spec.LinuxResources `json:"resource_limits,omitempty"`
 }
 
 // PodSpecGenerator describes options to create a pod
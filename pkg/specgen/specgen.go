@@ -5,6 +5,7 @@ import (
 	"syscall"
 
 	"github.com/containers/image/v5/manifest"
+	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/storage"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
@@ -60,6 +61,10 @@ type ContainerBasicConfig struct {
 	// Terminal is whether the container will create a PTY.
 	// Optional.
 	Terminal bool `json:"terminal,omitempty"`
+	// ConsoleSize is the initial size of the container's PTY. Only used
+	// if Terminal is set.
+	// Optional.
+	ConsoleSize *spec.Box `json:"console_size,omitempty"`
 	// Stdin is whether the container will keep its STDIN open.
 	Stdin bool `json:"stdin,omitempty"`
 	// Labels are key-value pairs that are used to add metadata to
@@ -83,6 +88,17 @@ type ContainerBasicConfig struct {
 	// instead.
 	// Optional.
 	StopTimeout *uint `json:"stop_timeout,omitempty"`
+	// StopSignals is a signal escalation chain used to stop the container.
+	// Each signal is sent in order, and the container is given the step's
+	// timeout to exit before the next one is sent. SIGKILL is sent after
+	// the last step.
+	// If set, takes precedence over StopSignal and StopTimeout.
+	// Optional.
+	StopSignals []define.StopStep `json:"stop_signals,omitempty"`
+	// Timeout is the maximum number of seconds the container may run
+	// before it is killed. 0 means that the runtime is not limited.
+	// Optional.
+	Timeout uint `json:"timeout,omitempty"`
 	// LogConfiguration describes the logging for a container including
 	// driver, path, and options.
 	// Optional
@@ -92,6 +108,11 @@ type ContainerBasicConfig struct {
 	// If not given, a default location will be used.
 	// Optional.
 	ConmonPidFile string `json:"conmon_pid_file,omitempty"`
+	// PidFile is a path at which the PID of the container process will be
+	// written when it is started.  It is removed when the container is
+	// cleaned up.
+	// Optional.
+	PidFile string `json:"pid_file,omitempty"`
 	// RawImageName is the user-specified and unprocessed input referring
 	// to a local or a remote image.
 	RawImageName string `json:"raw_image_name,omitempty"`
@@ -164,6 +185,27 @@ type ContainerBasicConfig struct {
 	// Timezone is the timezone inside the container.
 	// Local means it has the same timezone as the host machine
 	Timezone string `json:"timezone,omitempty"`
+	// InitContainerType makes the container an init container of its pod,
+	// which is run to completion before the other containers of the pod
+	// are started.  Must be "always" or "once" and requires Pod to be set.
+	// Optional.
+	InitContainerType string `json:"init_container_type,omitempty"`
+	// DependencyContainers is an array of containers this container
+	// requires.  They are started together with this container and
+	// cannot be stopped while it is running.  Dependencies can be
+	// specified by name or full/partial ID.
+	// Optional.
+	DependencyContainers []string `json:"dependencyContainers,omitempty"`
+	// HealthyDependencyContainers is an array of containers this container
+	// requires, which must report healthy before this container is
+	// started.  They must have a healthcheck.
+	// Optional.
+	HealthyDependencyContainers []string `json:"healthyDependencyContainers,omitempty"`
+	// HealthyDependencyTimeout is the number of seconds to wait for the
+	// HealthyDependencyContainers to become healthy.  0 waits
+	// indefinitely.
+	// Optional.
+	HealthyDependencyTimeout uint `json:"healthyDependencyTimeout,omitempty"`
 }
 
 // ContainerStorageConfig contains information on the storage configuration of a
@@ -175,6 +217,11 @@ type ContainerStorageConfig struct {
 	// Conflicts with Rootfs.
 	// At least one of Image or Rootfs must be specified.
 	Image string `json:"image"`
+	// ImagePlatform is the OS/ARCH[/VARIANT] platform the image was
+	// explicitly selected for.  If set, the platform of the image is not
+	// validated against the host.
+	// Optional.
+	ImagePlatform string `json:"image_platform,omitempty"`
 	// Rootfs is the path to a directory that will be used as the
 	// container's root filesystem. No modification will be made to the
 	// directory, it will be directly mounted into the container as root.
@@ -217,9 +264,24 @@ type ContainerStorageConfig struct {
 	// Image volumes bind-mount a container-image mount into the container.
 	// Optional.
 	ImageVolumes []*ImageVolume `json:"image_volumes,omitempty"`
+	// Secrets are secrets that will be mounted into the container.
+	// Optional.
+	Secrets []Secret `json:"secrets,omitempty"`
+	// EnvSecrets maps the names of environment variables to the names or
+	// IDs of the secrets they will be set to.
+	// Optional.
+	EnvSecrets map[string]string `json:"secret_env,omitempty"`
+	// NoDefaultMounts indicates that the default mounts from the mounts
+	// files (mounts.conf) will not be added to the container.
+	// Optional.
+	NoDefaultMounts bool `json:"no_default_mounts,omitempty"`
 	// Devices are devices that will be added to the container.
 	// Optional.
 	Devices []spec.LinuxDevice `json:"devices,omitempty"`
+	// NoDefaultDevices indicates that the default devices from
+	// containers.conf will not be added to the container.
+	// Optional.
+	NoDefaultDevices bool `json:"no_default_devices,omitempty"`
 	// IpcNS is the container's IPC namespace.
 	// Default is private.
 	// Conflicts with ShmSize if not set to private.
@@ -229,6 +291,11 @@ type ContainerStorageConfig struct {
 	// Conflicts with ShmSize if IpcNS is not private.
 	// Optional.
 	ShmSize *int64 `json:"shm_size,omitempty"`
+	// ShmSizeSystemd is the size of the tmpfs mounts created for
+	// containers running systemd (/run, /run/lock, /tmp and
+	// /var/log/journal), in bytes. If unset, the tmpfs default is used.
+	// Optional.
+	ShmSizeSystemd *int64 `json:"shm_size_systemd,omitempty"`
 	// WorkDir is the container's working directory.
 	// If unset, the default, /, will be used.
 	// Optional.
@@ -354,6 +421,18 @@ type ContainerNetworkConfig struct {
 	// Only available if NetNS is set to bridge.
 	// Optional.
 	StaticMAC *net.HardwareAddr `json:"static_mac,omitempty"`
+	// StaticIPs are static IPv4 or IPv6 addresses of the container in the
+	// given CNI networks, formatted as map of network name to address.
+	// Only available if NetNS is set to bridge, and the networks must be
+	// in CNINetworks.
+	// Optional.
+	StaticIPs map[string]net.IP `json:"static_ips,omitempty"`
+	// StaticMACs are static MAC addresses of the container in the given
+	// CNI networks, formatted as map of network name to address.
+	// Only available if NetNS is set to bridge, and the networks must be
+	// in CNINetworks.
+	// Optional.
+	StaticMACs map[string]net.HardwareAddr `json:"static_macs,omitempty"`
 	// PortBindings is a set of ports to map into the container.
 	// Only available if NetNS is set to bridge or slirp.
 	// Optional.
@@ -407,6 +486,13 @@ type ContainerNetworkConfig struct {
 	// Podman, and instead sourced from the image.
 	// Conflicts with HostAdd.
 	UseImageHosts bool `json:"use_image_hosts,omitempty"`
+	// BaseHostsFile is the base of the /etc/hosts file managed by Podman:
+	// "image" for the /etc/hosts file of the image, "none" for no base
+	// file or the path of a file on the host. If not set, base_hosts_file
+	// of containers.conf is used.
+	// Conflicts with UseImageHosts.
+	// Optional.
+	BaseHostsFile string `json:"base_hosts_file,omitempty"`
 	// HostAdd is a set of hosts which will be added to the container's
 	// /etc/hosts file.
 	// Conflicts with UseImageHosts.
@@ -46,6 +46,16 @@ type ImageVolume struct {
 	ReadWrite bool
 }
 
+// Secret is a secret mounted into a container.
+type Secret struct {
+	// Source is the name or ID of the secret.
+	Source string `json:"source"`
+	// Target is the path of the mount in the container.  Relative paths
+	// are relative to /run/secrets.  If empty, the secret is mounted at
+	// /run/secrets/<Source>.
+	Target string `json:"target,omitempty"`
+}
+
 // GenVolumeMounts parses user input into mounts, volumes and overlay volumes
 func GenVolumeMounts(volumeFlag []string) (map[string]spec.Mount, map[string]*NamedVolume, map[string]*OverlayVolume, error) {
 	errDuplicateDest := errors.Errorf("duplicate mount destination")
@@ -10,8 +10,10 @@ import (
 )
 
 // Init returns an instance of Jaeger Tracer that samples 100% of traces and logs all spans to stdout.
+// The spans are reported to the Jaeger agent, which can be configured via the
+// JAEGER_* environment variables (e.g., JAEGER_AGENT_HOST or JAEGER_ENDPOINT).
 func Init(service string) (opentracing.Tracer, io.Closer) {
-	cfg := &config.Configuration{
+	defaults := &config.Configuration{
 		ServiceName: service,
 		Sampler: &config.SamplerConfig{
 			Type:  "const",
@@ -21,6 +23,10 @@ func Init(service string) (opentracing.Tracer, io.Closer) {
 			LogSpans: true,
 		},
 	}
+	cfg, err := defaults.FromEnv()
+	if err != nil {
+		panic(fmt.Sprintf("ERROR: cannot init Jaeger: %v\n", err))
+	}
 	tracer, closer, err := cfg.NewTracer(config.Logger(jaeger.StdLogger))
 	if err != nil {
 		panic(fmt.Sprintf("ERROR: cannot init Jaeger: %v\n", err))
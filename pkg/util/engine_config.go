@@ -0,0 +1,250 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/common/pkg/config"
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/containers/podman/v2/pkg/admission"
+	"github.com/containers/podman/v2/pkg/rootless"
+	"github.com/pkg/errors"
+)
+
+// extraEngineConfig holds the settings of the [containers], [engine], [names],
+// [presets] and [state] tables of a containers.conf file that are not (yet)
+// known to containers/common.
+type extraEngineConfig struct {
+	Containers struct {
+		// BaseHostsFile is the base of the /etc/hosts file of
+		// containers.
+		BaseHostsFile string `toml:"base_hosts_file"`
+		// UnprivilegedPing allows all users of containers with a
+		// private network namespace to ping.
+		UnprivilegedPing *bool `toml:"unprivileged_ping"`
+	} `toml:"containers"`
+	Engine struct {
+		// FallbackGraphRoot is the graph root to use if the file system
+		// of the configured one does not support the storage driver.
+		FallbackGraphRoot string `toml:"fallback_graphroot"`
+		// ImageAdmissionPolicy is the path of the image admission
+		// policy.
+		ImageAdmissionPolicy string `toml:"image_admission_policy"`
+		// ImageCopyRateLimit limits the bandwidth for copying images.
+		ImageCopyRateLimit string `toml:"image_copy_rate_limit"`
+		// ImagePlatformPolicy controls what happens when creating
+		// containers from images of another platform.
+		ImagePlatformPolicy string `toml:"image_platform_policy"`
+		// OwnerMode controls whether users sharing the storage see
+		// and may remove the containers and pods of each other.
+		OwnerMode string `toml:"owner_mode"`
+	} `toml:"engine"`
+	Names   NameGeneration             `toml:"names"`
+	Presets map[string]ContainerPreset `toml:"presets"`
+	State   struct {
+		// Root is the directory of all persistent mutable state.
+		Root string `toml:"root"`
+		// RunRoot is the directory of all volatile mutable state.
+		RunRoot string `toml:"runroot"`
+	} `toml:"state"`
+}
+
+// ExtraConfig holds the settings of containers.conf that are not (yet) known
+// to containers/common, validated and with their defaults applied.  It is
+// loaded once with LoadExtraConfig when the runtime is set up.
+type ExtraConfig struct {
+	// BaseHostsFile is the base of the /etc/hosts file of containers set
+	// by base_hosts_file in the [containers] table: "image", "none" or the
+	// path of a file on the host, which is /etc/hosts if not set.
+	BaseHostsFile string
+	// UnprivilegedPing is set by unprivileged_ping in the [containers]
+	// table, in which case net.ipv4.ping_group_range of containers with a
+	// private network namespace covers all GIDs of their user namespace.
+	UnprivilegedPing bool
+	// FallbackGraphRoot is the graph root set by fallback_graphroot in the
+	// [engine] table, to be used if the file system of the configured
+	// graph root is not supported by the storage driver.
+	FallbackGraphRoot string
+	// ImageAdmissionPolicy is the path of the image admission policy set
+	// by image_admission_policy in the [engine] table, which is
+	// /etc/containers/image-admission.json if not set.
+	ImageAdmissionPolicy string
+	// ImageCopyRateLimit is the global bandwidth limit, in bytes per
+	// second, for copying images as set by image_copy_rate_limit in the
+	// [engine] table.  Zero means unlimited.
+	ImageCopyRateLimit int64
+	// ImagePlatformPolicy is the policy set by image_platform_policy in
+	// the [engine] table for images whose platform does not match the
+	// host, which is "warn" if not set.
+	ImagePlatformPolicy string
+	// OwnerMode is the owner mode set by owner_mode in the [engine] table,
+	// which is "shared" if not set.
+	OwnerMode string
+	// Names are the settings of the [names] table.  An empty template
+	// means the builtin name generator is used.
+	Names NameGeneration
+	// StateRoot and StateRunRoot are the directories set by root and
+	// runroot in the [state] table, below which all persistent and
+	// volatile mutable state of Podman is kept.  Empty strings mean the
+	// state is not relocated.
+	StateRoot    string
+	StateRunRoot string
+	// Presets are the presets of podman create and run set in the
+	// [presets.NAME] tables, keyed by name.
+	Presets map[string]ContainerPreset
+}
+
+// containersConfPaths returns the paths of the containers.conf files in the
+// order they are read by containers/common.
+func containersConfPaths() []string {
+	if path := os.Getenv("CONTAINERS_CONF"); path != "" {
+		return []string{path}
+	}
+	paths := []string{config.DefaultContainersConfig, config.OverrideContainersConfig}
+	if rootless.IsRootless() {
+		paths = append(paths, config.Path())
+	}
+	return paths
+}
+
+// extraConfigFiles returns the files holding the extra settings: the
+// containers.conf files in the same order as containers/common, each followed
+// by the *.conf drop-in files in the containers.conf.d directory next to it.
+func extraConfigFiles() ([]string, error) {
+	var files []string
+	for _, path := range containersConfPaths() {
+		if _, err := os.Stat(path); err == nil {
+			files = append(files, path)
+		}
+		dropIns, err := filepath.Glob(filepath.Join(path+".d", "*.conf"))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, dropIns...)
+	}
+	return files, nil
+}
+
+// readExtraEngineConfig reads the extra settings of the given files, fields
+// set in later files override earlier ones.
+func readExtraEngineConfig(files []string) (*extraEngineConfig, error) {
+	merged := &extraEngineConfig{Presets: make(map[string]ContainerPreset)}
+	for _, path := range files {
+		conf := extraEngineConfig{}
+		if _, err := toml.DecodeFile(path, &conf); err != nil {
+			return nil, errors.Wrapf(err, "error decoding configuration file %s", path)
+		}
+		if conf.Containers.BaseHostsFile != "" {
+			merged.Containers.BaseHostsFile = conf.Containers.BaseHostsFile
+		}
+		if conf.Containers.UnprivilegedPing != nil {
+			merged.Containers.UnprivilegedPing = conf.Containers.UnprivilegedPing
+		}
+		if conf.Engine.FallbackGraphRoot != "" {
+			merged.Engine.FallbackGraphRoot = conf.Engine.FallbackGraphRoot
+		}
+		if conf.Engine.ImageAdmissionPolicy != "" {
+			merged.Engine.ImageAdmissionPolicy = conf.Engine.ImageAdmissionPolicy
+		}
+		if conf.Engine.ImageCopyRateLimit != "" {
+			merged.Engine.ImageCopyRateLimit = conf.Engine.ImageCopyRateLimit
+		}
+		if conf.Engine.ImagePlatformPolicy != "" {
+			merged.Engine.ImagePlatformPolicy = conf.Engine.ImagePlatformPolicy
+		}
+		if conf.Engine.OwnerMode != "" {
+			merged.Engine.OwnerMode = conf.Engine.OwnerMode
+		}
+		if conf.Names.Template != "" {
+			merged.Names.Template = conf.Names.Template
+		}
+		if len(conf.Names.Adjectives) > 0 {
+			merged.Names.Adjectives = conf.Names.Adjectives
+		}
+		if len(conf.Names.Nouns) > 0 {
+			merged.Names.Nouns = conf.Names.Nouns
+		}
+		if conf.State.Root != "" {
+			merged.State.Root = conf.State.Root
+		}
+		if conf.State.RunRoot != "" {
+			merged.State.RunRoot = conf.State.RunRoot
+		}
+		for name, preset := range conf.Presets {
+			merged.Presets[name] = preset
+		}
+	}
+	return merged, nil
+}
+
+// LoadExtraConfig reads the settings of containers.conf and of its drop-in
+// files that are not (yet) known to containers/common.  The files are read in
+// the same order as containers/common, settings in later files override
+// earlier ones.
+func LoadExtraConfig() (*ExtraConfig, error) {
+	files, err := extraConfigFiles()
+	if err != nil {
+		return nil, err
+	}
+	conf, err := readExtraEngineConfig(files)
+	if err != nil {
+		return nil, err
+	}
+
+	extra := &ExtraConfig{
+		BaseHostsFile:        conf.Containers.BaseHostsFile,
+		UnprivilegedPing:     conf.Containers.UnprivilegedPing != nil && *conf.Containers.UnprivilegedPing,
+		FallbackGraphRoot:    conf.Engine.FallbackGraphRoot,
+		ImageAdmissionPolicy: conf.Engine.ImageAdmissionPolicy,
+		ImagePlatformPolicy:  conf.Engine.ImagePlatformPolicy,
+		OwnerMode:            conf.Engine.OwnerMode,
+		Names:                conf.Names,
+		StateRoot:            conf.State.Root,
+		StateRunRoot:         conf.State.RunRoot,
+		Presets:              conf.Presets,
+	}
+	if extra.BaseHostsFile == "" {
+		extra.BaseHostsFile = "/etc/hosts"
+	}
+	if extra.ImageAdmissionPolicy == "" {
+		extra.ImageAdmissionPolicy = admission.DefaultPolicyPath
+	}
+	if conf.Engine.ImageCopyRateLimit != "" {
+		extra.ImageCopyRateLimit, err = ParseRateLimit(conf.Engine.ImageCopyRateLimit)
+		if err != nil {
+			return nil, errors.Wrapf(err, "image_copy_rate_limit in containers.conf")
+		}
+	}
+	switch extra.ImagePlatformPolicy {
+	case "":
+		extra.ImagePlatformPolicy = define.ImagePlatformPolicyWarn
+	case define.ImagePlatformPolicyIgnore, define.ImagePlatformPolicyWarn, define.ImagePlatformPolicyError:
+	default:
+		return nil, errors.Errorf("invalid image_platform_policy %q in containers.conf: must be %q, %q or %q", extra.ImagePlatformPolicy, define.ImagePlatformPolicyIgnore, define.ImagePlatformPolicyWarn, define.ImagePlatformPolicyError)
+	}
+	switch extra.OwnerMode {
+	case "":
+		extra.OwnerMode = define.OwnerModeShared
+	case define.OwnerModeShared, define.OwnerModeFilter, define.OwnerModeEnforce:
+	default:
+		return nil, errors.Errorf("invalid owner_mode %q in containers.conf: must be %q, %q or %q", extra.OwnerMode, define.OwnerModeShared, define.OwnerModeFilter, define.OwnerModeEnforce)
+	}
+	for key, path := range map[string]string{"root": extra.StateRoot, "runroot": extra.StateRunRoot} {
+		if path != "" && !filepath.IsAbs(path) {
+			return nil, errors.Errorf("invalid %s %q in the [state] table of containers.conf: must be an absolute path", key, path)
+		}
+	}
+	return extra, nil
+}
+
+// NameGeneration describes how names of containers and pods are generated if
+// none is given.
+type NameGeneration struct {
+	// Template is a Go template rendering the name.
+	Template string `toml:"template"`
+	// Adjectives replace the builtin adjectives picked for the template.
+	Adjectives []string `toml:"adjectives"`
+	// Nouns replace the builtin nouns picked for the template.
+	Nouns []string `toml:"nouns"`
+}
@@ -1,8 +1,11 @@
 package util
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/docker/go-units"
 	"github.com/pkg/errors"
 )
 
@@ -69,6 +72,24 @@ func ProcessOptions(options []string, isTmpfs bool, sourcePath string) ([]string
 			if foundSize {
 				return nil, errors.Wrapf(ErrDupeMntOption, "only one tmpfs size can be specified")
 			}
+			if len(splitOpt) != 2 || splitOpt[1] == "" {
+				return nil, errors.Wrapf(ErrBadMntOption, "the 'size' option requires a value")
+			}
+			if strings.HasSuffix(splitOpt[1], "%") {
+				// The kernel accepts a percentage of physical RAM.
+				pct, err := strconv.ParseUint(strings.TrimSuffix(splitOpt[1], "%"), 10, 32)
+				if err != nil || pct == 0 {
+					return nil, errors.Wrapf(ErrBadMntOption, "invalid tmpfs size %q", splitOpt[1])
+				}
+			} else {
+				size, err := units.RAMInBytes(splitOpt[1])
+				if err != nil || size <= 0 {
+					return nil, errors.Wrapf(ErrBadMntOption, "invalid tmpfs size %q", splitOpt[1])
+				}
+				// Pass the size in bytes so the OCI runtime doesn't
+				// need to understand human-readable suffixes.
+				opt = fmt.Sprintf("size=%d", size)
+			}
 			foundSize = true
 		case "mode":
 			if !isTmpfs {
@@ -77,6 +98,12 @@ func ProcessOptions(options []string, isTmpfs bool, sourcePath string) ([]string
 			if foundMode {
 				return nil, errors.Wrapf(ErrDupeMntOption, "only one tmpfs mode can be specified")
 			}
+			if len(splitOpt) != 2 || splitOpt[1] == "" {
+				return nil, errors.Wrapf(ErrBadMntOption, "the 'mode' option requires a value")
+			}
+			if _, err := strconv.ParseUint(splitOpt[1], 8, 32); err != nil {
+				return nil, errors.Wrapf(ErrBadMntOption, "invalid tmpfs mode %q: must be an octal number", splitOpt[1])
+			}
 			foundMode = true
 		case "tmpcopyup":
 			if !isTmpfs {
@@ -0,0 +1,88 @@
+package util
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v2/pkg/rootless"
+	"github.com/containers/storage/pkg/idtools"
+	"github.com/pkg/errors"
+)
+
+const (
+	// PingGroupRangeSysctl is the sysctl listing the groups allowed to
+	// create unprivileged ICMP echo sockets.
+	PingGroupRangeSysctl = "net.ipv4.ping_group_range"
+	// MaxPingGroupRangeGID is the highest GID accepted by the kernel in
+	// net.ipv4.ping_group_range.
+	MaxPingGroupRangeGID = 2147483647
+
+	pingGroupRangePath = "/proc/sys/net/ipv4/ping_group_range"
+)
+
+// ParsePingGroupRange parses a net.ipv4.ping_group_range value, e.g. "0 1000",
+// and returns the first and last GID of the range.
+func ParsePingGroupRange(value string) (uint32, uint32, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 2 {
+		return 0, 0, errors.Errorf("invalid %s %q: must be two GIDs", PingGroupRangeSysctl, value)
+	}
+	var gids [2]uint32
+	for i, f := range fields {
+		gid, err := strconv.ParseUint(f, 10, 32)
+		if err != nil || gid > MaxPingGroupRangeGID {
+			return 0, 0, errors.Errorf("invalid %s %q: %q is not a valid GID", PingGroupRangeSysctl, value, f)
+		}
+		gids[i] = uint32(gid)
+	}
+	return gids[0], gids[1], nil
+}
+
+// PingGroupRangeContains returns whether gid is in the net.ipv4.ping_group_range
+// value.  The kernel default "1 0" contains no GID.
+func PingGroupRangeContains(value string, gid uint32) (bool, error) {
+	first, last, err := ParsePingGroupRange(value)
+	if err != nil {
+		return false, err
+	}
+	return first <= gid && gid <= last, nil
+}
+
+// UserNSPingGroupRange returns the net.ipv4.ping_group_range value allowing all
+// GIDs of a user namespace with the gidMap mappings to ping.  Without
+// mappings, the user namespace Podman runs in is used.
+func UserNSPingGroupRange(gidMap []idtools.IDMap) (string, error) {
+	var maxGID int64
+	if len(gidMap) > 0 {
+		for _, m := range gidMap {
+			if last := int64(m.ContainerID + m.Size - 1); last > maxGID {
+				maxGID = last
+			}
+		}
+	} else {
+		n, err := rootless.GetAvailableGids()
+		if err != nil {
+			return "", err
+		}
+		maxGID = n - 1
+	}
+	if maxGID > MaxPingGroupRangeGID {
+		maxGID = MaxPingGroupRangeGID
+	}
+	if maxGID < 0 {
+		maxGID = 0
+	}
+	return fmt.Sprintf("0 %d", maxGID), nil
+}
+
+// HostPingGroupRange returns net.ipv4.ping_group_range of the network namespace
+// Podman runs in.
+func HostPingGroupRange() (string, error) {
+	b, err := ioutil.ReadFile(pingGroupRangePath)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(strings.Fields(string(b)), " "), nil
+}
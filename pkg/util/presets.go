@@ -0,0 +1,5 @@
+package util
+
+// ContainerPreset is a named set of flags of podman create and run, keyed by
+// flag name.  Values are strings, booleans, numbers or arrays of them.
+type ContainerPreset map[string]interface{}
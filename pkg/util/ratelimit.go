@@ -0,0 +1,19 @@
+package util
+
+import (
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+)
+
+// ParseRateLimit parses a bandwidth limit given as a size per second (e.g.,
+// "10m" or "512KB") and returns the number of bytes per second.
+func ParseRateLimit(limit string) (int64, error) {
+	bytesPerSecond, err := units.RAMInBytes(limit)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid rate limit %q", limit)
+	}
+	if bytesPerSecond <= 0 {
+		return 0, errors.Errorf("invalid rate limit %q: must be greater than 0", limit)
+	}
+	return bytesPerSecond, nil
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/BurntSushi/toml"
 	"github.com/containers/common/pkg/config"
 	"github.com/containers/image/v5/types"
+	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/pkg/errorhandling"
 	"github.com/containers/podman/v2/pkg/namespaces"
 	"github.com/containers/podman/v2/pkg/rootless"
@@ -342,6 +343,30 @@ func ParseSignal(rawSignal string) (syscall.Signal, error) {
 	return sig, nil
 }
 
+// ParseStopSignals parses a signal escalation chain used to stop a container.
+// Each step has the form SIGNAL:SECONDS, where SIGNAL is a signal name or
+// number and SECONDS the time to wait for the container to exit after sending
+// it.
+func ParseStopSignals(rawSteps []string) ([]define.StopStep, error) {
+	steps := make([]define.StopStep, 0, len(rawSteps))
+	for _, rawStep := range rawSteps {
+		split := strings.SplitN(rawStep, ":", 2)
+		if len(split) != 2 {
+			return nil, errors.Errorf("invalid stop signal %q: must be in the form SIGNAL:SECONDS", rawStep)
+		}
+		sig, err := ParseSignal(split[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid stop signal %q", rawStep)
+		}
+		timeout, err := strconv.ParseUint(split[1], 10, 32)
+		if err != nil || timeout == 0 {
+			return nil, errors.Errorf("invalid stop signal %q: timeout must be a positive number of seconds", rawStep)
+		}
+		steps = append(steps, define.StopStep{Signal: uint(sig), Timeout: uint(timeout)})
+	}
+	return steps, nil
+}
+
 // GetKeepIDMapping returns the mappings and the user to use when keep-id is used
 func GetKeepIDMapping() (*storage.IDMappingOptions, int, int, error) {
 	options := storage.IDMappingOptions{
@@ -543,6 +568,25 @@ func ParseInputTime(inputTime string) (time.Time, error) {
 	return time.Now().Add(-duration), nil
 }
 
+// SourceDateEpochEnv is the environment variable which sets the timestamp of
+// reproducible images, see https://reproducible-builds.org/specs/source-date-epoch/.
+const SourceDateEpochEnv = "SOURCE_DATE_EPOCH"
+
+// SourceDateEpoch returns the time set in the SOURCE_DATE_EPOCH environment
+// variable in UTC, or nil if it is not set.
+func SourceDateEpoch() (*time.Time, error) {
+	epoch, ok := os.LookupEnv(SourceDateEpochEnv)
+	if !ok || epoch == "" {
+		return nil, nil
+	}
+	seconds, err := strconv.ParseInt(epoch, 10, 64)
+	if err != nil || seconds < 0 {
+		return nil, errors.Errorf("invalid %s %q: must be a non-negative number of seconds since the epoch", SourceDateEpochEnv, epoch)
+	}
+	t := time.Unix(seconds, 0).UTC()
+	return &t, nil
+}
+
 // OpenExclusiveFile opens a file for writing and ensure it doesn't already exist
 func OpenExclusiveFile(path string) (*os.File, error) {
 	baseDir := filepath.Dir(path)
@@ -563,14 +607,52 @@ var (
 	PullImageMissing = config.PullImageMissing
 	// PullImageNever will never pull new image
 	PullImageNever = config.PullImageNever
+	// PullImageNewer pulls the image if it is not available locally or if
+	// the registry serves a different image than the local one
+	PullImageNewer = config.PullImageNever + 1
 )
 
 // ValidatePullType check if the pullType from CLI is valid and returns the valid enum type
 // if the value from CLI is invalid returns the error
 func ValidatePullType(pullType string) (PullType, error) {
+	if strings.ToLower(pullType) == "newer" {
+		return PullImageNewer, nil
+	}
 	return config.ValidatePullPolicy(pullType)
 }
 
+// PullTypeToString returns the string representation of pullType as accepted
+// by ValidatePullType
+func PullTypeToString(pullType PullType) string {
+	switch pullType {
+	case PullImageAlways:
+		return "always"
+	case PullImageNever:
+		return "never"
+	case PullImageNewer:
+		return "newer"
+	default:
+		return "missing"
+	}
+}
+
+// ParsePlatform parses a platform as accepted by the --platform flag, in the
+// form "os[/arch[/variant]]", and returns its parts.
+func ParsePlatform(platform string) (string, string, string, error) {
+	split := strings.Split(platform, "/")
+	if len(split) > 3 {
+		return "", "", "", errors.Errorf("invalid platform %q: expected os[/arch[/variant]]", platform)
+	}
+	for _, part := range split {
+		if part == "" {
+			return "", "", "", errors.Errorf("invalid platform %q: expected os[/arch[/variant]]", platform)
+		}
+	}
+	// Pad to three elements for the missing architecture and variant.
+	split = append(split, "", "")
+	return split[0], split[1], split[2], nil
+}
+
 // ExitCode reads the error message when failing to executing container process
 // and then returns 0 if no error, 126 if command does not exist, or 127 for
 // all other errors
@@ -1,8 +1,16 @@
 package util
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
 
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/containers/storage/pkg/idtools"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -277,3 +285,235 @@ func TestPeriodAndQuotaToCores(t *testing.T) {
 
 	assert.Equal(t, PeriodAndQuotaToCores(period, quota), expectedCores)
 }
+
+func TestProcessOptionsTmpfs(t *testing.T) {
+	opts, err := ProcessOptions([]string{"size=64m", "mode=1777", "noexec"}, true, "")
+	require.Nil(t, err)
+	assert.Contains(t, opts, "size=67108864")
+	assert.Contains(t, opts, "mode=1777")
+	assert.Contains(t, opts, "noexec")
+	assert.NotContains(t, opts, "exec")
+
+	opts, err = ProcessOptions([]string{"size=50%"}, true, "")
+	require.Nil(t, err)
+	assert.Contains(t, opts, "size=50%")
+
+	for _, bad := range [][]string{
+		{"size=foo"},
+		{"size="},
+		{"size=0%"},
+		{"mode=999"},
+		{"mode"},
+		{"noexec", "exec"},
+	} {
+		_, err := ProcessOptions(bad, true, "")
+		assert.Error(t, err, "options %v", bad)
+	}
+}
+
+func TestValidatePullType(t *testing.T) {
+	for _, policy := range []string{"always", "missing", "never", "newer", "Newer"} {
+		pullType, err := ValidatePullType(policy)
+		require.Nil(t, err)
+		assert.Equal(t, strings.ToLower(policy), PullTypeToString(pullType))
+	}
+
+	_, err := ValidatePullType("sometimes")
+	assert.Error(t, err)
+}
+
+func TestParsePlatform(t *testing.T) {
+	os, arch, variant, err := ParsePlatform("linux")
+	require.Nil(t, err)
+	assert.Equal(t, []string{"linux", "", ""}, []string{os, arch, variant})
+
+	os, arch, variant, err = ParsePlatform("linux/arm64")
+	require.Nil(t, err)
+	assert.Equal(t, []string{"linux", "arm64", ""}, []string{os, arch, variant})
+
+	os, arch, variant, err = ParsePlatform("linux/arm/v7")
+	require.Nil(t, err)
+	assert.Equal(t, []string{"linux", "arm", "v7"}, []string{os, arch, variant})
+
+	for _, platform := range []string{"", "linux/", "/arm64", "linux//v7", "linux/arm/v7/extra"} {
+		_, _, _, err = ParsePlatform(platform)
+		assert.Error(t, err, platform)
+	}
+}
+
+func TestParseStopSignals(t *testing.T) {
+	steps, err := ParseStopSignals([]string{"SIGTERM:10", "INT:5", "1:2"})
+	require.Nil(t, err)
+	assert.Equal(t, []define.StopStep{
+		{Signal: uint(syscall.SIGTERM), Timeout: 10},
+		{Signal: uint(syscall.SIGINT), Timeout: 5},
+		{Signal: uint(syscall.SIGHUP), Timeout: 2},
+	}, steps)
+
+	for _, step := range []string{"", "SIGTERM", "SIGTERM:", "SIGTERM:0", "SIGTERM:-1", "SIGFOO:10", "65:10"} {
+		_, err := ParseStopSignals([]string{step})
+		assert.Error(t, err, step)
+	}
+}
+
+func TestParseRateLimit(t *testing.T) {
+	for limit, expected := range map[string]int64{
+		"1024":  1024,
+		"10k":   10 * 1024,
+		"10m":   10 * 1024 * 1024,
+		"512KB": 512 * 1024,
+	} {
+		bytesPerSecond, err := ParseRateLimit(limit)
+		require.Nil(t, err, limit)
+		assert.Equal(t, expected, bytesPerSecond, limit)
+	}
+
+	for _, limit := range []string{"", "fast", "0", "-1m"} {
+		_, err := ParseRateLimit(limit)
+		assert.Error(t, err, limit)
+	}
+}
+
+func TestPingGroupRange(t *testing.T) {
+	first, last, err := ParsePingGroupRange("0\t1000\n")
+	require.Nil(t, err)
+	assert.Equal(t, uint32(0), first)
+	assert.Equal(t, uint32(1000), last)
+
+	for _, value := range []string{"", "0", "0 1 2", "a 1", "0 -1", "0 4294967295"} {
+		_, _, err := ParsePingGroupRange(value)
+		assert.Error(t, err, value)
+	}
+
+	for value, expected := range map[string]bool{
+		"0 2147483647": true,
+		"1000 1000":    true,
+		"0 999":        false,
+		"1 0":          false,
+	} {
+		contains, err := PingGroupRangeContains(value, 1000)
+		require.Nil(t, err, value)
+		assert.Equal(t, expected, contains, value)
+	}
+
+	pingGroupRange, err := UserNSPingGroupRange([]idtools.IDMap{
+		{ContainerID: 0, HostID: 1000, Size: 1},
+		{ContainerID: 1, HostID: 100000, Size: 65536},
+	})
+	require.Nil(t, err)
+	assert.Equal(t, "0 65536", pingGroupRange)
+}
+
+func TestLoadExtraConfig(t *testing.T) {
+	conf, err := ioutil.TempFile("", "containers.conf")
+	require.Nil(t, err)
+	defer os.Remove(conf.Name())
+	_, err = conf.WriteString("[containers]\nunprivileged_ping = true\n[engine]\nimage_copy_rate_limit = \"2m\"\nfallback_graphroot = \"/var/lib/containers/local\"\nowner_mode = \"enforce\"\nimage_platform_policy = \"error\"\nimage_admission_policy = \"/etc/admission.json\"\n")
+	require.Nil(t, err)
+	require.Nil(t, conf.Close())
+
+	os.Setenv("CONTAINERS_CONF", conf.Name())
+	defer os.Unsetenv("CONTAINERS_CONF")
+	extra, err := LoadExtraConfig()
+	require.Nil(t, err)
+	assert.Equal(t, int64(2*1024*1024), extra.ImageCopyRateLimit)
+	assert.Equal(t, "/var/lib/containers/local", extra.FallbackGraphRoot)
+	assert.Equal(t, "enforce", extra.OwnerMode)
+	assert.Equal(t, "error", extra.ImagePlatformPolicy)
+	assert.Equal(t, "/etc/admission.json", extra.ImageAdmissionPolicy)
+	assert.True(t, extra.UnprivilegedPing)
+	assert.Equal(t, "/etc/hosts", extra.BaseHostsFile)
+}
+
+func TestLoadExtraConfigDropIns(t *testing.T) {
+	dir, err := ioutil.TempDir("", "extraconfig")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	confPath := filepath.Join(dir, "containers.conf")
+	require.Nil(t, ioutil.WriteFile(confPath, []byte("[engine]\nowner_mode = \"filter\"\nimage_platform_policy = \"ignore\"\n"), 0644))
+	require.Nil(t, os.Mkdir(confPath+".d", 0755))
+	require.Nil(t, ioutil.WriteFile(filepath.Join(confPath+".d", "10-owner.conf"), []byte("[engine]\nowner_mode = \"enforce\"\n"), 0644))
+	require.Nil(t, ioutil.WriteFile(filepath.Join(confPath+".d", "20-hosts.conf"), []byte("[containers]\nbase_hosts_file = \"none\"\n"), 0644))
+
+	os.Setenv("CONTAINERS_CONF", confPath)
+	defer os.Unsetenv("CONTAINERS_CONF")
+	extra, err := LoadExtraConfig()
+	require.Nil(t, err)
+	assert.Equal(t, "enforce", extra.OwnerMode)
+	assert.Equal(t, "ignore", extra.ImagePlatformPolicy)
+	assert.Equal(t, "none", extra.BaseHostsFile)
+}
+
+func TestOwnerModeInvalid(t *testing.T) {
+	conf, err := ioutil.TempFile("", "containers.conf")
+	require.Nil(t, err)
+	defer os.Remove(conf.Name())
+	_, err = conf.WriteString("[engine]\nowner_mode = \"private\"\n")
+	require.Nil(t, err)
+	require.Nil(t, conf.Close())
+
+	os.Setenv("CONTAINERS_CONF", conf.Name())
+	defer os.Unsetenv("CONTAINERS_CONF")
+	_, err = LoadExtraConfig()
+	assert.NotNil(t, err)
+}
+
+func TestStateRoots(t *testing.T) {
+	conf, err := ioutil.TempFile("", "containers.conf")
+	require.Nil(t, err)
+	defer os.Remove(conf.Name())
+	_, err = conf.WriteString("[state]\nroot = \"/var/podman\"\n")
+	require.Nil(t, err)
+	require.Nil(t, conf.Close())
+
+	os.Setenv("CONTAINERS_CONF", conf.Name())
+	defer os.Unsetenv("CONTAINERS_CONF")
+	extra, err := LoadExtraConfig()
+	require.Nil(t, err)
+	assert.Equal(t, "/var/podman", extra.StateRoot)
+	assert.Equal(t, "", extra.StateRunRoot)
+
+	require.Nil(t, ioutil.WriteFile(conf.Name(), []byte("[state]\nrunroot = \"run/podman\"\n"), 0644))
+	_, err = LoadExtraConfig()
+	assert.NotNil(t, err)
+}
+
+func TestContainerPresets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "presets")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	confPath := filepath.Join(dir, "containers.conf")
+	require.Nil(t, ioutil.WriteFile(confPath, []byte("[engine]\n"), 0644))
+	require.Nil(t, os.Mkdir(confPath+".d", 0755))
+	require.Nil(t, ioutil.WriteFile(filepath.Join(confPath+".d", "10-base.conf"), []byte("[presets.hardened]\nread-only = true\ncap-drop = [\"all\"]\n[presets.web]\nnetwork = \"web\"\n"), 0644))
+	require.Nil(t, ioutil.WriteFile(filepath.Join(confPath+".d", "20-site.conf"), []byte("[presets.web]\nnetwork = \"frontend\"\n"), 0644))
+
+	os.Setenv("CONTAINERS_CONF", confPath)
+	defer os.Unsetenv("CONTAINERS_CONF")
+	extra, err := LoadExtraConfig()
+	require.Nil(t, err)
+	assert.Equal(t, map[string]ContainerPreset{
+		"hardened": {"read-only": true, "cap-drop": []interface{}{"all"}},
+		"web":      {"network": "frontend"},
+	}, extra.Presets)
+}
+
+func TestSourceDateEpoch(t *testing.T) {
+	os.Unsetenv(SourceDateEpochEnv)
+	ts, err := SourceDateEpoch()
+	assert.Nil(t, err)
+	assert.Nil(t, ts)
+
+	os.Setenv(SourceDateEpochEnv, "1600000000")
+	defer os.Unsetenv(SourceDateEpochEnv)
+	ts, err = SourceDateEpoch()
+	require.Nil(t, err)
+	assert.Equal(t, int64(1600000000), ts.Unix())
+	assert.Equal(t, time.UTC, ts.Location())
+
+	for _, invalid := range []string{"yesterday", "-1", "1.5"} {
+		os.Setenv(SourceDateEpochEnv, invalid)
+		_, err = SourceDateEpoch()
+		assert.NotNil(t, err, invalid)
+	}
+}
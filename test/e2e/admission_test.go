@@ -0,0 +1,102 @@
+package integration
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/containers/podman/v2/test/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman image admission policy", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest *PodmanTestIntegration
+	)
+
+	// writePolicy configures the admission policy for the test.
+	writePolicy := func(policy string) {
+		policyPath := filepath.Join(podmanTest.TempDir, "image-admission.json")
+		err := ioutil.WriteFile(policyPath, []byte(policy), 0644)
+		Expect(err).To(BeNil())
+		conf := filepath.Join(podmanTest.TempDir, "containers.conf")
+		err = ioutil.WriteFile(conf, []byte("[engine]\nimage_admission_policy = \""+policyPath+"\"\n"), 0644)
+		Expect(err).To(BeNil())
+		os.Setenv("CONTAINERS_CONF", conf)
+	}
+
+	BeforeEach(func() {
+		SkipIfRemote("containers.conf of the server cannot be changed by the remote client")
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanTestCreate(tempdir)
+		podmanTest.Setup()
+		podmanTest.SeedImages()
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("CONTAINERS_CONF")
+		podmanTest.Cleanup()
+		f := CurrentGinkgoTestDescription()
+		processTestResult(f)
+
+	})
+
+	It("podman pull denied by the admission policy", func() {
+		writePolicy(`{"rules": [{"registries": ["quay.io/libpod"], "action": "deny", "rootUser": true}]}`)
+
+		session := podmanTest.Podman([]string{"pull", BB})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError())
+		Expect(session.ErrorToString()).To(ContainSubstring("image denied by admission policy"))
+		Expect(session.ErrorToString()).To(ContainSubstring("image runs as root"))
+
+		session = podmanTest.Podman([]string{"image", "exists", BB})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(1))
+	})
+
+	It("podman pull denied by the admission policy keeps a stored image", func() {
+		writePolicy(`{"rules": [{"registries": ["quay.io/libpod/alpine"], "action": "deny"}]}`)
+
+		session := podmanTest.Podman([]string{"pull", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError())
+		Expect(session.ErrorToString()).To(ContainSubstring("image denied by admission policy"))
+
+		session = podmanTest.Podman([]string{"image", "exists", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+	})
+
+	It("podman create denied by the admission policy", func() {
+		writePolicy(`{"rules": [{"action": "deny", "requiredLabels": ["org.example.approved=true"]}]}`)
+
+		session := podmanTest.Podman([]string{"create", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError())
+		Expect(session.ErrorToString()).To(ContainSubstring("image is missing required label org.example.approved=true"))
+	})
+
+	It("podman create allowed or warned by the admission policy", func() {
+		writePolicy(`{"rules": [
+			{"registries": ["quay.io/libpod/alpine"], "action": "allow"},
+			{"action": "warn", "rootUser": true}
+		]}`)
+
+		session := podmanTest.Podman([]string{"create", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.ErrorToString()).To(Not(ContainSubstring("admission policy")))
+
+		session = podmanTest.Podman([]string{"create", fedoraMinimal, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.ErrorToString()).To(ContainSubstring("violates the admission policy"))
+	})
+})
@@ -373,6 +373,61 @@ var _ = Describe("Podman checkpoint", func() {
 		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(0))
 	})
 
+	It("podman restore container with changed network settings", func() {
+		localRunString := getRunString([]string{"-p", "8080:80", ALPINE, "top"})
+		session := podmanTest.Podman(localRunString)
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		cid := session.OutputToString()
+		fileName := "/tmp/checkpoint-" + cid + ".tar.gz"
+
+		result := podmanTest.Podman([]string{"container", "checkpoint", "-e", fileName, cid})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+
+		result = podmanTest.Podman([]string{"rm", cid})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+
+		// Established TCP connections cannot be moved to another address
+		result = podmanTest.Podman([]string{"container", "restore", "-i", fileName, "--ip", "10.88.0.2", "--tcp-established"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).To(ExitWithError())
+
+		ip := GetRandomIPAddress()
+		mac := "92:d0:c6:0a:29:33"
+		result = podmanTest.Podman([]string{"container", "restore", "-i", fileName, "--ip", ip, "--mac-address", mac, "-p", "8081:80"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(1))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.NetworkSettings.IPAddress}} {{.NetworkSettings.MacAddress}}", cid})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal(ip + " " + mac))
+
+		port := podmanTest.Podman([]string{"port", cid})
+		port.WaitWithDefaultTimeout()
+		Expect(port.ExitCode()).To(Equal(0))
+		Expect(port.OutputToString()).To(ContainSubstring("8081"))
+		Expect(port.OutputToString()).To(Not(ContainSubstring("8080")))
+
+		// The published ports of existing containers cannot be changed
+		result = podmanTest.Podman([]string{"container", "checkpoint", cid})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		result = podmanTest.Podman([]string{"container", "restore", "-p", "8082:80", cid})
+		result.WaitWithDefaultTimeout()
+		Expect(result).To(ExitWithError())
+
+		result = podmanTest.Podman([]string{"rm", "-fa"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+
+		// Remove exported checkpoint
+		os.Remove(fileName)
+	})
+
 	// This test does the same steps which are necessary for migrating
 	// a container from one host to another
 	It("podman checkpoint container with export (migration)", func() {
@@ -425,6 +480,41 @@ var _ = Describe("Podman checkpoint", func() {
 		os.Remove(fileName)
 	})
 
+	It("podman checkpoint container with create-image (migration)", func() {
+		localRunString := getRunString([]string{"--rm", ALPINE, "top"})
+		session := podmanTest.Podman(localRunString)
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(1))
+		cid := session.OutputToString()
+		checkpointImage := "localhost/checkpoint-" + cid[:12]
+
+		result := podmanTest.Podman([]string{"container", "checkpoint", "--create-image", checkpointImage, cid})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainers()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"image", "inspect", "--format", "{{.Annotations}}", checkpointImage})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(ContainSubstring("io.podman.annotations.checkpoint.name"))
+
+		result = podmanTest.Podman([]string{"container", "restore", "--ignore-static-ip", checkpointImage})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		Expect(result.OutputToString()).To(Equal(cid))
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(1))
+		Expect(podmanTest.GetContainerStatus()).To(ContainSubstring("Up"))
+
+		result = podmanTest.Podman([]string{"rm", "-fa"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+
+		result = podmanTest.Podman([]string{"rmi", checkpointImage})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+	})
+
 	It("podman checkpoint and restore container with root file-system changes", func() {
 		// Start the container
 		localRunString := getRunString([]string{"--rm", ALPINE, "top"})
@@ -652,4 +742,132 @@ var _ = Describe("Podman checkpoint", func() {
 		// Remove exported checkpoint
 		os.Remove(fileName)
 	})
+
+	It("podman checkpoint and restore container with volumes", func() {
+		// Start the container with a named volume
+		localRunString := getRunString([]string{"-v", "my-test-vol:/volume", "--rm", ALPINE, "top"})
+		session := podmanTest.Podman(localRunString)
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(1))
+		cid := session.OutputToString()
+		fileName := "/tmp/checkpoint-" + cid + ".tar.gz"
+
+		// Write to the volume
+		result := podmanTest.Podman([]string{"exec", "-l", "/bin/sh", "-c", "echo " + cid + " > /volume/test.output"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+
+		// Checkpoint the container, the volume is part of the archive
+		result = podmanTest.Podman([]string{"container", "checkpoint", "-l", "-e", fileName})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainers()).To(Equal(0))
+
+		// Remove the volume to simulate a restore on a different host
+		result = podmanTest.Podman([]string{"volume", "rm", "my-test-vol"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+
+		result = podmanTest.Podman([]string{"container", "restore", "-i", fileName})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(1))
+
+		// The volume was re-created with its content
+		result = podmanTest.Podman([]string{"exec", "-l", "cat", "/volume/test.output"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		Expect(result.OutputToString()).To(ContainSubstring(cid))
+
+		result = podmanTest.Podman([]string{"rm", "-fa"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+
+		// Restore again without the content of the volume
+		result = podmanTest.Podman([]string{"volume", "rm", "my-test-vol"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+
+		result = podmanTest.Podman([]string{"container", "restore", "-i", fileName, "--ignore-volumes"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+
+		result = podmanTest.Podman([]string{"exec", "-l", "ls", "/volume/test.output"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).To(ExitWithError())
+
+		result = podmanTest.Podman([]string{"rm", "-fa"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+
+		// Remove exported checkpoint
+		os.Remove(fileName)
+	})
+
+	It("podman checkpoint and restore pod", func() {
+		session := podmanTest.Podman([]string{"pod", "create", "--name", "testpod"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.RunTopContainerInPod("", "testpod")
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		session = podmanTest.RunTopContainerInPod("", "testpod")
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(3))
+
+		result := podmanTest.Podman([]string{"pod", "checkpoint", "testpod"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainers()).To(Equal(3))
+
+		result = podmanTest.Podman([]string{"pod", "restore", "testpod"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(3))
+
+		result = podmanTest.Podman([]string{"pod", "rm", "-f", "testpod"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+	})
+
+	It("podman checkpoint pod with export (migration)", func() {
+		session := podmanTest.Podman([]string{"pod", "create", "--name", "testpod"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		podID := session.OutputToString()
+
+		session = podmanTest.RunTopContainerInPod("", "testpod")
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(2))
+		fileName := "/tmp/checkpoint-" + podID + ".tar"
+
+		result := podmanTest.Podman([]string{"pod", "checkpoint", "-e", fileName, "testpod"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(0))
+
+		// Remove the pod to simulate a restore on a different host
+		result = podmanTest.Podman([]string{"pod", "rm", "-f", "testpod"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainers()).To(Equal(0))
+
+		result = podmanTest.Podman([]string{"pod", "restore", "-i", fileName})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		Expect(result.OutputToString()).To(Equal(podID))
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(2))
+
+		result = podmanTest.Podman([]string{"pod", "rm", "-f", "testpod"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+
+		// Remove exported checkpoint
+		os.Remove(fileName)
+	})
 })
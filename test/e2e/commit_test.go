@@ -279,4 +279,31 @@ var _ = Describe("Podman commit", func() {
 		data := check.InspectImageJSON()
 		Expect(data[0].ID).To(Equal(string(id)))
 	})
+	It("podman commit with --timestamp is reproducible", func() {
+		session := podmanTest.Podman([]string{"run", "--name", "test1", ALPINE, "touch", "/foo"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		var ids []string
+		for _, name := range []string{"repro1", "repro2"} {
+			session = podmanTest.Podman([]string{"commit", "-q", "--timestamp", "1600000000", "test1", name})
+			session.WaitWithDefaultTimeout()
+			Expect(session.ExitCode()).To(Equal(0))
+			ids = append(ids, session.OutputToString())
+		}
+		Expect(ids[0]).To(Equal(ids[1]))
+
+		check := podmanTest.Podman([]string{"image", "inspect", "--format", "{{.Created.Unix}}", "repro1"})
+		check.WaitWithDefaultTimeout()
+		Expect(check.ExitCode()).To(Equal(0))
+		Expect(check.OutputToString()).To(Equal("1600000000"))
+
+		// SOURCE_DATE_EPOCH is used when --timestamp is not set
+		os.Setenv("SOURCE_DATE_EPOCH", "1600000000")
+		defer os.Unsetenv("SOURCE_DATE_EPOCH")
+		session = podmanTest.Podman([]string{"commit", "-q", "test1", "repro3"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal(ids[0]))
+	})
 })
@@ -0,0 +1,104 @@
+package integration
+
+import (
+	"os"
+
+	. "github.com/containers/podman/v2/test/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman container clone", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest *PodmanTestIntegration
+	)
+
+	BeforeEach(func() {
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanTestCreate(tempdir)
+		podmanTest.Setup()
+		podmanTest.SeedImages()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+		f := CurrentGinkgoTestDescription()
+		processTestResult(f)
+
+	})
+
+	It("podman container clone bogus container", func() {
+		session := podmanTest.Podman([]string{"container", "clone", "123456"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+	})
+
+	It("podman container clone keeps the configuration", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "source", "--env", "FOO=bar", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		clone := podmanTest.Podman([]string{"container", "clone", "--name", "copy", "source"})
+		clone.WaitWithDefaultTimeout()
+		Expect(clone.ExitCode()).To(Equal(0))
+		cid := clone.OutputToString()
+
+		inspect := podmanTest.Podman([]string{"inspect", cid})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		data := inspect.InspectContainerToJSON()
+		Expect(data[0].Name).To(Equal("copy"))
+		Expect(data[0].State.Status).To(Equal("created"))
+		Expect(data[0].Config.Cmd).To(Equal([]string{"top"}))
+		Expect(data[0].Config.Env).To(ContainElement("FOO=bar"))
+	})
+
+	It("podman container clone with resource limits and run", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "source", "--memory", "100m", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		// The new limit is above the memory+swap limit of the source.
+		clone := podmanTest.Podman([]string{"container", "clone", "--memory", "500m", "--cpus", "0.5", "--run", "source"})
+		clone.WaitWithDefaultTimeout()
+		Expect(clone.ExitCode()).To(Equal(0))
+		cid := clone.OutputToString()
+
+		inspect := podmanTest.Podman([]string{"inspect", cid})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		data := inspect.InspectContainerToJSON()
+		Expect(data[0].State.Running).To(BeTrue())
+		Expect(data[0].HostConfig.Memory).To(Equal(int64(500 * 1024 * 1024)))
+		Expect(data[0].HostConfig.MemorySwap).To(Equal(int64(1000 * 1024 * 1024)))
+		Expect(data[0].HostConfig.CpuQuota).To(Equal(int64(50000)))
+
+		// The source container is unchanged.
+		inspect = podmanTest.Podman([]string{"inspect", "source"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		data = inspect.InspectContainerToJSON()
+		Expect(data[0].HostConfig.Memory).To(Equal(int64(100 * 1024 * 1024)))
+	})
+
+	It("podman container clone with a different image", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "source", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		clone := podmanTest.Podman([]string{"container", "clone", "--image", BB, "source"})
+		clone.WaitWithDefaultTimeout()
+		Expect(clone.ExitCode()).To(Equal(0))
+		cid := clone.OutputToString()
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.ImageName}}", cid})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal(BB))
+	})
+})
@@ -73,6 +73,15 @@ var _ = Describe("Podman run", func() {
 		Expect(session.OutputToString()).To(ContainSubstring("notone"))
 	})
 
+	It("podman run --no-default-devices", func() {
+		//containers.conf devices includes notone
+		session := podmanTest.Podman([]string{"run", "--no-default-devices", "--device", "/dev/null:/dev/bar", ALPINE, "ls", "/dev"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring("bar"))
+		Expect(session.OutputToString()).To(Not(ContainSubstring("notone")))
+	})
+
 	It("podman run shm-size", func() {
 		//containers.conf default sets shm-size=201k, which ends up as 200k
 		session := podmanTest.Podman([]string{"run", ALPINE, "grep", "shm", "/proc/self/mounts"})
@@ -201,6 +210,27 @@ var _ = Describe("Podman run", func() {
 		Expect(session.OutputToString()).ToNot((ContainSubstring("1000")))
 	})
 
+	It("podman run containers.conf unprivileged_ping", func() {
+		conffile := filepath.Join(podmanTest.TempDir, "container.conf")
+		err := ioutil.WriteFile(conffile, []byte("[containers]\nunprivileged_ping = true\n"), 0755)
+		Expect(err).To(BeNil())
+
+		os.Setenv("CONTAINERS_CONF", conffile)
+		if IsRemote() {
+			podmanTest.RestartRemoteService()
+		}
+		session := podmanTest.Podman([]string{"run", "--rm", ALPINE, "cat", "/proc/sys/net/ipv4/ping_group_range"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(MatchRegexp("^0\\s+[1-9][0-9]*$"))
+
+		// --sysctl takes precedence
+		session = podmanTest.Podman([]string{"run", "--rm", "--sysctl", "net.ipv4.ping_group_range=0 0", ALPINE, "cat", "/proc/sys/net/ipv4/ping_group_range"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(MatchRegexp("^0\\s+0$"))
+	})
+
 	It("podman run containers.conf search domain", func() {
 		session := podmanTest.Podman([]string{"run", ALPINE, "cat", "/etc/resolv.conf"})
 		session.WaitWithDefaultTimeout()
@@ -266,6 +296,29 @@ var _ = Describe("Podman run", func() {
 		Expect(session.ExitCode()).To(Equal(0))
 	})
 
+	It("podman run with presets of containers.conf.d", func() {
+		session := podmanTest.Podman([]string{"run", "--rm", "--preset", "hardened", ALPINE, "sh", "-c", "echo $PRESET; touch /foo"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).ToNot(Equal(0))
+		Expect(session.OutputToString()).To(Equal("hardened"))
+		Expect(session.ErrorToString()).To(ContainSubstring("Read-only file system"))
+
+		// later presets and explicit flags override earlier presets
+		session = podmanTest.Podman([]string{"create", "--preset", "hardened", "--preset", "labeled", "--read-only=false", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.Config.Labels.preset}} {{.HostConfig.ReadonlyRootfs}} {{range .Config.Env}}{{.}} {{end}}", session.OutputToString()})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(HavePrefix("labeled false"))
+		Expect(inspect.OutputToString()).To(ContainSubstring("PRESET=labeled"))
+
+		session = podmanTest.Podman([]string{"create", "--preset", "unknown", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+		Expect(session.ErrorToString()).To(ContainSubstring("no such preset"))
+	})
+
 	It("podman-remote test localcontainers.conf versus remote containers.conf", func() {
 		if !IsRemote() {
 			Skip("this test is only for remote")
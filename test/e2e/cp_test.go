@@ -210,6 +210,40 @@ var _ = Describe("Podman cp", func() {
 		Expect(session.OutputToString()).To(ContainSubstring("root"))
 	})
 
+	// Copy a file owned by another user between two containers and make
+	// sure that its ownership is only kept with --archive=false.
+	It("podman cp --archive=false keeps ownership", func() {
+		setup := podmanTest.RunTopContainer("testctr")
+		setup.WaitWithDefaultTimeout()
+		Expect(setup.ExitCode()).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"exec", "testctr", "adduser", "-S", "testuser"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"exec", "testctr", "id", "-u", "testuser"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		uid := session.OutputToString()
+
+		session = podmanTest.Podman([]string{"exec", "-u", "testuser", "testctr", "touch", "/tmp/testfile"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"cp", "testctr:/tmp/testfile", "testctr:/chowned"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"cp", "--archive=false", "testctr:/tmp/testfile", "testctr:/kept"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"exec", "testctr", "stat", "-c", "%u", "/chowned", "/kept"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToStringArray()).To(Equal([]string{"0", uid}))
+	})
+
 	// Copy the root dir "/" of a container to the host.
 	It("podman cp the root directory from the ctr to an existing directory on the host ", func() {
 		container := "copyroottohost"
@@ -239,4 +273,51 @@ var _ = Describe("Podman cp", func() {
 		Expect(lsOutput).To(ContainSubstring("bin"))
 		Expect(lsOutput).To(ContainSubstring("usr"))
 	})
+
+	// Copy a file from one container to another, also renaming it on the
+	// way, and make sure that the contents match.
+	It("podman cp file from container to container", func() {
+		originalContent := []byte("podman cp container to container test")
+		srcFile, err := ioutil.TempFile("", "")
+		Expect(err).To(BeNil())
+		defer srcFile.Close()
+		defer os.Remove(srcFile.Name())
+		err = ioutil.WriteFile(srcFile.Name(), originalContent, 0644)
+		Expect(err).To(BeNil())
+
+		session := podmanTest.Podman([]string{"create", "--name", "srcctr", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"create", "--name", "destctr", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"cp", srcFile.Name(), "srcctr:/tmp/file"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		// Both containers are stopped.
+		session = podmanTest.Podman([]string{"cp", "srcctr:/tmp/file", "destctr:/tmp/renamed"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		destFile, err := ioutil.TempFile("", "")
+		Expect(err).To(BeNil())
+		defer destFile.Close()
+		defer os.Remove(destFile.Name())
+
+		session = podmanTest.Podman([]string{"cp", "destctr:/tmp/renamed", destFile.Name()})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		roundtripContent, err := ioutil.ReadFile(destFile.Name())
+		Expect(err).To(BeNil())
+		Expect(roundtripContent).To(Equal(originalContent))
+
+		// Copying between two paths on the host is not supported.
+		session = podmanTest.Podman([]string{"cp", srcFile.Name(), destFile.Name()})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError())
+	})
 })
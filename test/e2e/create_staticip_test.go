@@ -48,6 +48,18 @@ var _ = Describe("Podman create with --ip flag", func() {
 		Expect(result).To(ExitWithError())
 	})
 
+	It("Podman create --ip6 with v4 address", func() {
+		result := podmanTest.Podman([]string{"create", "--name", "test", "--ip6", "10.88.64.128", ALPINE, "ls"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).To(ExitWithError())
+	})
+
+	It("Podman create --ip and --ip6", func() {
+		result := podmanTest.Podman([]string{"create", "--name", "test", "--ip", "10.88.64.128", "--ip6", "fd00:4:4:4:4::20", ALPINE, "ls"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).To(ExitWithError())
+	})
+
 	It("Podman create --ip with non-allocatable IP", func() {
 		SkipIfRootless("--ip is not supported in rootless mode")
 		result := podmanTest.Podman([]string{"create", "--name", "test", "--ip", "203.0.113.124", ALPINE, "ls"})
@@ -492,6 +492,10 @@ var _ = Describe("Podman create", func() {
 		session.WaitWithDefaultTimeout()
 		Expect(session.ExitCode()).To(Not(Equal(0)))
 
+		session = podmanTest.Podman([]string{"create", "--tz", "../../../etc/passwd", "--name", "escape", ALPINE, "date"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+
 		session = podmanTest.Podman([]string{"create", "--tz", "Pacific/Honolulu", "--name", "zone", ALPINE, "date"})
 		session.WaitWithDefaultTimeout()
 		inspect := podmanTest.Podman([]string{"inspect", "zone"})
@@ -655,7 +659,7 @@ var _ = Describe("Podman create", func() {
 		session = podmanTest.Podman([]string{"create", "--platform=linux/arm64", "--override-os", "windows", ALPINE})
 		session.WaitWithDefaultTimeout()
 		Expect(session.ExitCode()).To(Equal(125))
-		expectedError = "--platform option can not be specified with --override-arch or --override-os"
+		expectedError = "--platform option can not be specified with --arch, --os or --variant"
 		Expect(session.ErrorToString()).To(ContainSubstring(expectedError))
 
 		session = podmanTest.Podman([]string{"create", "-q", "--platform=linux/arm64", ALPINE})
@@ -676,4 +680,25 @@ var _ = Describe("Podman create", func() {
 		Expect(idata[0].Os).To(Equal(runtime.GOOS))
 		Expect(idata[0].Architecture).To(Equal("arm64"))
 	})
+
+	It("podman create --dry-run", func() {
+		SkipIfRemote("--dry-run is not supported for remote clients")
+		session := podmanTest.Podman([]string{"create", "--dry-run", "--memory", "100m", "-v", "/tmp:/dryrun", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.IsJSONOutputValid()).To(BeTrue())
+		Expect(session.OutputToString()).To(ContainSubstring(`"destination": "/dryrun"`))
+		Expect(session.OutputToString()).To(ContainSubstring(`"limit": 104857600`))
+		Expect(podmanTest.NumberOfContainers()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"run", "--dry-run", "--name", "dryrun", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainers()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"create", "--dry-run", "--pull", "always", "quay.io/libpod/does-not-exist"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+		Expect(session.ErrorToString()).To(ContainSubstring("--dry-run does not pull images"))
+	})
 })
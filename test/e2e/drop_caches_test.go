@@ -0,0 +1,87 @@
+package integration
+
+import (
+	"os"
+
+	. "github.com/containers/podman/v2/test/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman container drop-caches", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest *PodmanTestIntegration
+	)
+
+	BeforeEach(func() {
+		SkipIfRootlessCgroupsV1("Dropping caches is not supported in cgroups v1")
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanTestCreate(tempdir)
+		podmanTest.Setup()
+		podmanTest.SeedImages()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+		f := CurrentGinkgoTestDescription()
+		processTestResult(f)
+
+	})
+
+	It("podman container drop-caches bogus container", func() {
+		session := podmanTest.Podman([]string{"container", "drop-caches", "123456"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+	})
+
+	It("podman container drop-caches created container", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "test", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		drop := podmanTest.Podman([]string{"container", "drop-caches", "test"})
+		drop.WaitWithDefaultTimeout()
+		Expect(drop).To(ExitWithError())
+	})
+
+	It("podman container drop-caches running container", func() {
+		if CGROUPSV2 {
+			if _, err := os.Stat("/sys/fs/cgroup/system.slice/memory.reclaim"); err != nil {
+				Skip("memory.reclaim requires Linux 5.19 or newer")
+			}
+		}
+		session := podmanTest.Podman([]string{"run", "-d", "--name", "test", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		cid := session.OutputToString()
+
+		drop := podmanTest.Podman([]string{"container", "drop-caches", "test"})
+		drop.WaitWithDefaultTimeout()
+		Expect(drop.ExitCode()).To(Equal(0))
+		Expect(drop.OutputToString()).To(Equal(cid))
+
+		drop = podmanTest.Podman([]string{"container", "drop-caches", "--all"})
+		drop.WaitWithDefaultTimeout()
+		Expect(drop.ExitCode()).To(Equal(0))
+		Expect(drop.OutputToString()).To(Equal(cid))
+
+		drop = podmanTest.Podman([]string{"container", "drop-caches", "--amount", "1m", "test"})
+		drop.WaitWithDefaultTimeout()
+		if CGROUPSV2 {
+			Expect(drop.ExitCode()).To(Equal(0))
+		} else {
+			Expect(drop).To(ExitWithError())
+		}
+	})
+
+	It("podman container drop-caches invalid amount", func() {
+		drop := podmanTest.Podman([]string{"container", "drop-caches", "--amount", "abc", "test"})
+		drop.WaitWithDefaultTimeout()
+		Expect(drop).To(ExitWithError())
+	})
+})
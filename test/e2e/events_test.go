@@ -154,6 +154,33 @@ var _ = Describe("Podman events", func() {
 		Expect(eventsMap).To(HaveKey("Status"))
 	})
 
+	It("podman events correlation of pod rm", func() {
+		SkipIfNotFedora()
+		session := podmanTest.Podman([]string{"pod", "create", "--name", "correlated"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(Exit(0))
+		session = podmanTest.Podman([]string{"run", "-d", "--pod", "correlated", "--name", "correlated-ctr", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(Exit(0))
+		session = podmanTest.Podman([]string{"pod", "rm", "--force", "correlated"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(Exit(0))
+
+		result := podmanTest.Podman([]string{"events", "--stream=false", "--filter", "type=pod", "--filter", "event=remove", "--format", "{{.CorrelationID}}"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).To(Exit(0))
+		ids := result.OutputToStringArray()
+		Expect(ids).ToNot(BeEmpty())
+		correlationID := ids[len(ids)-1]
+		Expect(correlationID).ToNot(BeEmpty())
+
+		result = podmanTest.Podman([]string{"events", "--stream=false", "--filter", "correlation=" + correlationID, "--format", "{{.Type}} {{.Status}} {{.Name}}"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).To(Exit(0))
+		Expect(result.OutputToStringArray()).To(ContainElement("container remove correlated-ctr"))
+		Expect(result.OutputToStringArray()).To(ContainElement("pod remove correlated"))
+	})
+
 	It("podman events --until future", func() {
 		name1 := stringid.GenerateNonCryptoID()
 		name2 := stringid.GenerateNonCryptoID()
@@ -56,6 +56,17 @@ var _ = Describe("Podman exec", func() {
 		Expect(session.ExitCode()).To(Equal(0))
 	})
 
+	It("podman exec uses the umask of the container", func() {
+		setup := podmanTest.Podman([]string{"run", "-d", "--umask", "0002", "--name", "test1", ALPINE, "top"})
+		setup.WaitWithDefaultTimeout()
+		Expect(setup.ExitCode()).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"exec", "test1", "sh", "-c", "umask"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("0002"))
+	})
+
 	It("podman container exec simple command", func() {
 		setup := podmanTest.RunTopContainer("test1")
 		setup.WaitWithDefaultTimeout()
@@ -304,6 +315,32 @@ RUN useradd -u 1000 auser`
 		Expect(kill.ExitCode()).To(Equal(0))
 	})
 
+	It("podman container exec-sessions", func() {
+		ctrName := "testctr"
+		ctr := podmanTest.Podman([]string{"run", "-d", "--name", ctrName, ALPINE, "top"})
+		ctr.WaitWithDefaultTimeout()
+		Expect(ctr.ExitCode()).To(Equal(0))
+
+		exec1 := podmanTest.Podman([]string{"exec", "-d", ctrName, "sleep", "100"})
+		exec1.WaitWithDefaultTimeout()
+		Expect(exec1.ExitCode()).To(Equal(0))
+		execID := exec1.OutputToString()
+
+		exec2 := podmanTest.Podman([]string{"exec", ctrName, "true"})
+		exec2.WaitWithDefaultTimeout()
+		Expect(exec2.ExitCode()).To(Equal(0))
+
+		sessions := podmanTest.Podman([]string{"container", "exec-sessions", "--format", "{{.ID}} {{.Status}}", ctrName})
+		sessions.WaitWithDefaultTimeout()
+		Expect(sessions.ExitCode()).To(Equal(0))
+		Expect(sessions.OutputToString()).To(ContainSubstring(execID + " running"))
+
+		running := podmanTest.Podman([]string{"container", "exec-sessions", "--running", "-q"})
+		running.WaitWithDefaultTimeout()
+		Expect(running.ExitCode()).To(Equal(0))
+		Expect(running.OutputToStringArray()).To(Equal([]string{execID}))
+	})
+
 	It("podman exec --detach", func() {
 		ctrName := "testctr"
 		ctr := podmanTest.Podman([]string{"run", "-t", "-i", "-d", "--name", ctrName, ALPINE, "top"})
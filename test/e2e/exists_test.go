@@ -47,6 +47,15 @@ var _ = Describe("Podman image|container exists", func() {
 		session.WaitWithDefaultTimeout()
 		Expect(session).Should(Exit(1))
 	})
+	It("podman image exists with multiple images", func() {
+		session := podmanTest.Podman([]string{"image", "exists", ALPINE, "alpine"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		session = podmanTest.Podman([]string{"image", "exists", ALPINE, "alpine9999"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(1))
+	})
 	It("podman container exists in local storage by name", func() {
 		setup := podmanTest.RunTopContainer("foobar")
 		setup.WaitWithDefaultTimeout()
@@ -63,6 +63,39 @@ var _ = Describe("Podman export", func() {
 		Expect(err).To(BeNil())
 	})
 
+	It("podman export and import with volumes", func() {
+		SkipIfRemote("exporting the volumes of containers is not supported for remote clients")
+		session := podmanTest.Podman([]string{"run", "--name", "test", "-v", "myvol:/data", ALPINE, "sh", "-c", "echo hello > /data/file"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		outfile := filepath.Join(podmanTest.TempDir, "container.tar")
+		result := podmanTest.Podman([]string{"export", "--include-volumes", "-o", outfile, "test"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+
+		// the volume must not exist when importing
+		result = podmanTest.Podman([]string{"import", "--include-volumes", outfile, "imported"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).To(ExitWithError())
+
+		result = podmanTest.Podman([]string{"rm", "test"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		result = podmanTest.Podman([]string{"volume", "rm", "myvol"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+
+		result = podmanTest.Podman([]string{"import", "--include-volumes", outfile, "imported"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+
+		result = podmanTest.Podman([]string{"run", "--rm", "-v", "myvol:/data", "imported", "cat", "/data/file"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		Expect(result.OutputToString()).To(Equal("hello"))
+	})
+
 	It("podman export bad filename", func() {
 		_, ec, cid := podmanTest.RunLsContainer("")
 		Expect(ec).To(Equal(0))
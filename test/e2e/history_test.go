@@ -67,6 +67,13 @@ var _ = Describe("Podman history", func() {
 		Expect(len(session.OutputToStringArray())).To(BeNumerically(">", 0))
 	})
 
+	It("podman history with layer format", func() {
+		session := podmanTest.Podman([]string{"history", "--no-trunc", "--format", "{{.Layer}} {{.LayerDigest}}", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring("sha256:"))
+	})
+
 	It("podman history with json flag", func() {
 		session := podmanTest.Podman([]string{"history", "--format=json", ALPINE})
 		session.WaitWithDefaultTimeout()
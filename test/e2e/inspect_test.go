@@ -44,6 +44,19 @@ var _ = Describe("Podman inspect", func() {
 		Expect(imageData[0].RepoTags[0]).To(Equal("quay.io/libpod/alpine:latest"))
 	})
 
+	It("podman image inspect --explain-resolution", func() {
+		SkipIfRemote("explaining image name resolution is not supported for remote clients")
+		session := podmanTest.Podman([]string{"image", "inspect", "--explain-resolution", "--format", "{{.ResolvedName}} {{(index .Steps 0).Method}} {{(index .Steps 0).Found}}", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal(ALPINE + " id-or-name true"))
+
+		session = podmanTest.Podman([]string{"image", "inspect", "--explain-resolution", "foobar4321"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError())
+		Expect(session.OutputToString()).To(ContainSubstring("repotag"))
+	})
+
 	It("podman inspect bogus container", func() {
 		session := podmanTest.Podman([]string{"inspect", "foobar4321"})
 		session.WaitWithDefaultTimeout()
@@ -443,4 +456,16 @@ var _ = Describe("Podman inspect", func() {
 		Expect(inspect.OutputToString()).To(Equal(`"{"80/tcp":[{"HostIp":"","HostPort":"8080"}]}"`))
 	})
 
+	It("podman inspect container OOM kill count", func() {
+		ctrName := "testctr"
+		create := podmanTest.Podman([]string{"create", "--name", ctrName, ALPINE})
+		create.WaitWithDefaultTimeout()
+		Expect(create).Should(Exit(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.State.OOMKilled}} {{.State.OOMKills}}", ctrName})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(Exit(0))
+		Expect(inspect.OutputToString()).To(Equal("false 0"))
+	})
+
 })
@@ -176,6 +176,47 @@ var _ = Describe("Podman login and logout", func() {
 		session = podmanTest.Podman([]string{"logout", "--authfile", authFile, server})
 	})
 
+	It("podman login with --verbose and an identity token", func() {
+		authFile := filepath.Join(podmanTest.TempDir, "auth.json")
+		session := podmanTest.Podman([]string{"login", "--verbose", "--username", "podmantest", "--password", "test", "--authfile", authFile, server})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring("Used: " + authFile))
+
+		// Replace the credentials with an identity token
+		err := ioutil.WriteFile(authFile, []byte(`{"auths": {"`+server+`": {"identitytoken": "token"}}}`), 0600)
+		Expect(err).To(BeNil())
+
+		session = podmanTest.Podman([]string{"login", "--authfile", authFile, server})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring("Already logged in"))
+
+		session = podmanTest.Podman([]string{"login", "--get-login", "--authfile", authFile, server})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError())
+
+		// Rejected credentials keep the identity token
+		session = podmanTest.Podman([]string{"login", "--username", "podmantest", "--password", "wrong", "--authfile", authFile, server})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError())
+
+		session = podmanTest.Podman([]string{"login", "--authfile", authFile, server})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring("Already logged in"))
+
+		// New credentials replace the identity token
+		session = podmanTest.Podman([]string{"login", "--username", "podmantest", "--password", "test", "--authfile", authFile, server})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"login", "--get-login", "--authfile", authFile, server})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("podmantest"))
+	})
+
 	It("podman login and logout with --tls-verify", func() {
 		session := podmanTest.Podman([]string{"login", "--username", "podmantest", "--password", "test", "--tls-verify=false", server})
 		session.WaitWithDefaultTimeout()
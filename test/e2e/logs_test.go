@@ -377,4 +377,27 @@ var _ = Describe("Podman logs", func() {
 		Expect(results.OutputToString()).To(Equal("stdout"))
 		Expect(results.ErrorToString()).To(Equal("stderr"))
 	})
+	It("podman logs --stdout-only and --stderr-only", func() {
+		cname := "log-streams"
+		logc := podmanTest.Podman([]string{"run", "--name", cname, ALPINE, "sh", "-c", "echo out1; echo err1 >&2; echo out2; echo err2 >&2"})
+		logc.WaitWithDefaultTimeout()
+		Expect(logc).To(Exit(0))
+
+		results := podmanTest.Podman([]string{"logs", "--stdout-only", cname})
+		results.WaitWithDefaultTimeout()
+		Expect(results).To(Exit(0))
+		Expect(results.OutputToStringArray()).To(Equal([]string{"out1", "out2"}))
+		Expect(results.ErrorToString()).To(BeEmpty())
+
+		// the tail counts the lines of the selected stream only
+		results = podmanTest.Podman([]string{"logs", "--stderr-only", "--tail", "2", cname})
+		results.WaitWithDefaultTimeout()
+		Expect(results).To(Exit(0))
+		Expect(results.OutputToString()).To(BeEmpty())
+		Expect(results.ErrorToString()).To(Equal("err1 err2"))
+
+		results = podmanTest.Podman([]string{"logs", "--stdout-only", "--stderr-only", cname})
+		results.WaitWithDefaultTimeout()
+		Expect(results).To(ExitWithError())
+	})
 })
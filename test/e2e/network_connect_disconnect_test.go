@@ -214,4 +214,42 @@ var _ = Describe("Podman network connect and disconnect", func() {
 		exec.WaitWithDefaultTimeout()
 		Expect(exec.ExitCode()).ToNot(BeZero())
 	})
+
+	It("podman network connect and disconnect update /etc/hosts", func() {
+		SkipIfRootless("network connect and disconnect are only rootful")
+		netName1 := "aliasTest" + stringid.GenerateNonCryptoID()
+		session := podmanTest.Podman([]string{"network", "create", netName1})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(BeZero())
+		defer podmanTest.removeCNINetwork(netName1)
+
+		netName2 := "aliasTest" + stringid.GenerateNonCryptoID()
+		session = podmanTest.Podman([]string{"network", "create", netName2})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(BeZero())
+		defer podmanTest.removeCNINetwork(netName2)
+
+		ctr := podmanTest.Podman([]string{"run", "-dt", "--name", "test", "--network", netName1, ALPINE, "top"})
+		ctr.WaitWithDefaultTimeout()
+		Expect(ctr.ExitCode()).To(BeZero())
+
+		connect := podmanTest.Podman([]string{"network", "connect", netName2, "test"})
+		connect.WaitWithDefaultTimeout()
+		Expect(connect.ExitCode()).To(BeZero())
+
+		dis := podmanTest.Podman([]string{"network", "disconnect", netName1, "test"})
+		dis.WaitWithDefaultTimeout()
+		Expect(dis.ExitCode()).To(BeZero())
+
+		inspect := podmanTest.Podman([]string{"container", "inspect", "test", "--format", "{{(index .NetworkSettings.Networks \"" + netName2 + "\").IPAddress}}"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(BeZero())
+		ip := inspect.OutputToString()
+		Expect(ip).ToNot(BeEmpty())
+
+		hosts := podmanTest.Podman([]string{"exec", "test", "cat", "/etc/hosts"})
+		hosts.WaitWithDefaultTimeout()
+		Expect(hosts.ExitCode()).To(BeZero())
+		Expect(hosts.OutputToString()).To(ContainSubstring(ip))
+	})
 })
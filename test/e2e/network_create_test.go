@@ -262,6 +262,56 @@ var _ = Describe("Podman network create", func() {
 		Expect(containerIP.To4()).To(Not(BeNil()))
 	})
 
+	It("podman network create with IPv4 and IPv6 subnets", func() {
+		SkipIfRootless("FIXME It needs the ip6tables modules loaded")
+		var (
+			results []network.NcList
+		)
+		nc := podmanTest.Podman([]string{"network", "create", "--subnet", "10.11.14.0/24", "--subnet", "fd00:4:4:4:4::/64",
+			"--gateway", "fd00:4:4:4:4::10", "--gateway", "10.11.14.10", "--ip-range", "10.11.14.128/25", "--ipv6", "newMultiSubnetNetwork"})
+		nc.WaitWithDefaultTimeout()
+		Expect(nc.ExitCode()).To(BeZero())
+
+		defer podmanTest.removeCNINetwork("newMultiSubnetNetwork")
+
+		inspect := podmanTest.Podman([]string{"network", "inspect", "newMultiSubnetNetwork"})
+		inspect.WaitWithDefaultTimeout()
+		err := json.Unmarshal([]byte(inspect.OutputToString()), &results)
+		Expect(err).To(BeNil())
+
+		bridgePlugin, err := genericPluginsToBridge(results[0]["plugins"], "bridge")
+		Expect(err).To(BeNil())
+		Expect(len(bridgePlugin.IPAM.Routes)).To(Equal(2))
+		Expect(bridgePlugin.IPAM.Routes[0].Dest).To(Equal("0.0.0.0/0"))
+		Expect(bridgePlugin.IPAM.Routes[1].Dest).To(Equal("::/0"))
+		Expect(len(bridgePlugin.IPAM.Ranges)).To(Equal(2))
+		Expect(bridgePlugin.IPAM.Ranges[0][0].Subnet).To(Equal("10.11.14.0/24"))
+		Expect(bridgePlugin.IPAM.Ranges[0][0].Gateway).To(Equal("10.11.14.10"))
+		Expect(bridgePlugin.IPAM.Ranges[0][0].RangeStart).To(Equal("10.11.14.129"))
+		Expect(bridgePlugin.IPAM.Ranges[1][0].Subnet).To(Equal("fd00:4:4:4::/64"))
+		Expect(bridgePlugin.IPAM.Ranges[1][0].Gateway).To(Equal("fd00:4:4:4:4::10"))
+
+		defer removeNetworkDevice(bridgePlugin.BrName)
+
+		try := podmanTest.Podman([]string{"run", "--rm", "--network", "newMultiSubnetNetwork", "--ip6", "fd00:4:4:4:4::20", ALPINE, "sh", "-c", "ip addr show eth0"})
+		try.WaitWithDefaultTimeout()
+		Expect(try.ExitCode()).To(BeZero())
+		Expect(try.OutputToString()).To(ContainSubstring("inet 10.11.14."))
+		Expect(try.OutputToString()).To(ContainSubstring("inet6 fd00:4:4:4:4::20/64"))
+	})
+
+	It("podman network create with overlapping subnets", func() {
+		nc := podmanTest.Podman([]string{"network", "create", "--subnet", "10.11.15.0/24", "--subnet", "10.11.15.128/25", "newOverlapNetwork"})
+		nc.WaitWithDefaultTimeout()
+		Expect(nc).To(ExitWithError())
+	})
+
+	It("podman network create with gateway outside of all subnets", func() {
+		nc := podmanTest.Podman([]string{"network", "create", "--subnet", "10.11.16.0/24", "--subnet", "fd00:4:4:4:5::/64", "--gateway", "10.11.17.1", "newGatewayNetwork"})
+		nc.WaitWithDefaultTimeout()
+		Expect(nc).To(ExitWithError())
+	})
+
 	It("podman network create with invalid subnet", func() {
 		nc := podmanTest.Podman([]string{"network", "create", "--subnet", "10.11.12.0/17000", "fail"})
 		nc.WaitWithDefaultTimeout()
@@ -2,7 +2,9 @@ package integration
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -267,6 +269,38 @@ var _ = Describe("Podman network", func() {
 		Expect(rmAll.ExitCode()).To(BeZero())
 	})
 
+	It("podman network inspect lists attached containers", func() {
+		netName := "inspectCtrs" + stringid.GenerateNonCryptoID()
+		network := podmanTest.Podman([]string{"network", "create", "--subnet", "10.50.51.0/24", netName})
+		network.WaitWithDefaultTimeout()
+		Expect(network.ExitCode()).To(BeZero())
+		defer podmanTest.removeCNINetwork(netName)
+
+		ctr := podmanTest.Podman([]string{"run", "-d", "--network", netName, "--ip", "10.50.51.5", "--name", "attached", ALPINE, "top"})
+		ctr.WaitWithDefaultTimeout()
+		Expect(ctr.ExitCode()).To(BeZero())
+
+		other := podmanTest.Podman([]string{"create", "--name", "unattached", ALPINE, "top"})
+		other.WaitWithDefaultTimeout()
+		Expect(other.ExitCode()).To(BeZero())
+
+		inspect := podmanTest.Podman([]string{"network", "inspect", netName, "--format", "{{range $id, $c := .containers}}{{$id}} {{$c.Name}} {{$c.IPv4Address}}{{end}}"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(BeZero())
+		Expect(inspect.OutputToString()).To(Equal(ctr.OutputToString() + " attached 10.50.51.5/24"))
+
+		inspect = podmanTest.Podman([]string{"network", "inspect", netName})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(BeZero())
+		Expect(inspect.IsJSONOutputValid()).To(BeTrue())
+		Expect(inspect.OutputToString()).To(ContainSubstring(`"MacAddress"`))
+		Expect(inspect.OutputToString()).ToNot(ContainSubstring(other.OutputToString()))
+
+		rm := podmanTest.Podman([]string{"rm", "-f", "attached"})
+		rm.WaitWithDefaultTimeout()
+		Expect(rm.ExitCode()).To(BeZero())
+	})
+
 	It("podman inspect container two CNI networks (container not running)", func() {
 		netName1 := "testNetThreeCNI1"
 		network1 := podmanTest.Podman([]string{"network", "create", netName1})
@@ -407,6 +441,99 @@ var _ = Describe("Podman network", func() {
 		Expect(lines[0]).To(Equal(netName1))
 		Expect(lines[1]).To(Equal(netName2))
 	})
+	It("podman network prune", func() {
+		usedNet := "used" + stringid.GenerateNonCryptoID()
+		session := podmanTest.Podman([]string{"network", "create", usedNet})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(BeZero())
+		defer podmanTest.removeCNINetwork(usedNet)
+
+		unusedNet := "unused" + stringid.GenerateNonCryptoID()
+		session = podmanTest.Podman([]string{"network", "create", "--label", "prune=yes", unusedNet})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(BeZero())
+		defer podmanTest.removeCNINetwork(unusedNet)
+
+		ctr := podmanTest.Podman([]string{"create", "--network", usedNet, ALPINE, "true"})
+		ctr.WaitWithDefaultTimeout()
+		Expect(ctr.ExitCode()).To(BeZero())
+
+		// The until filter keeps networks created within the last hour
+		session = podmanTest.Podman([]string{"network", "prune", "--force", "--filter", "until=1h"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(BeZero())
+		Expect(session.OutputToString()).To(BeEmpty())
+
+		session = podmanTest.Podman([]string{"network", "prune", "--force", "--filter", "label=prune=yes"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(BeZero())
+		Expect(session.OutputToStringArray()).To(Equal([]string{unusedNet}))
+
+		session = podmanTest.Podman([]string{"network", "ls", "--quiet"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(BeZero())
+		Expect(session.OutputToStringArray()).To(ContainElement(usedNet))
+		Expect(session.OutputToStringArray()).ToNot(ContainElement(unusedNet))
+
+		session = podmanTest.Podman([]string{"network", "prune", "--force", "--filter", "name=" + usedNet})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+	})
+
+	It("podman network prune --leaked", func() {
+		SkipIfRootless("leaked network resources can only be pruned as root")
+		netName := "leaked" + stringid.GenerateNonCryptoID()
+		session := podmanTest.Podman([]string{"network", "create", "--subnet", "10.25.40.0/24", netName})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(BeZero())
+		defer podmanTest.removeCNINetwork(netName)
+
+		ctr := podmanTest.Podman([]string{"run", "-d", "--network", netName, ALPINE, "top"})
+		ctr.WaitWithDefaultTimeout()
+		Expect(ctr.ExitCode()).To(BeZero())
+
+		// Simulate an address reservation of a container that was
+		// removed without cleaning up
+		leakedID := stringid.GenerateNonCryptoID()
+		allocation := filepath.Join("/var/lib/cni/networks", netName, "10.25.40.200")
+		err := ioutil.WriteFile(allocation, []byte(leakedID+"\neth0"), 0644)
+		Expect(err).To(BeNil())
+		recordsDir := filepath.Join(podmanTest.CrioRoot, "libpod", "network-records")
+		err = os.MkdirAll(recordsDir, 0700)
+		Expect(err).To(BeNil())
+		record := fmt.Sprintf(`{"netns":"","networks":["%s"]}`, netName)
+		err = ioutil.WriteFile(filepath.Join(recordsDir, leakedID+".json"), []byte(record), 0600)
+		Expect(err).To(BeNil())
+
+		// An address reservation podman did not record is not touched
+		foreignID := stringid.GenerateNonCryptoID()
+		foreign := filepath.Join("/var/lib/cni/networks", netName, "10.25.40.201")
+		err = ioutil.WriteFile(foreign, []byte(foreignID+"\neth0"), 0644)
+		Expect(err).To(BeNil())
+		defer os.Remove(foreign)
+
+		session = podmanTest.Podman([]string{"network", "prune", "--force", "--leaked"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(BeZero())
+		Expect(session.OutputToString()).To(ContainSubstring(leakedID))
+		Expect(session.OutputToString()).ToNot(ContainSubstring(foreignID))
+		Expect(session.OutputToString()).ToNot(ContainSubstring(ctr.OutputToString()))
+		_, err = os.Stat(allocation)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+		_, err = os.Stat(foreign)
+		Expect(err).To(BeNil())
+
+		// The running container keeps its address
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{(index .NetworkSettings.Networks \"" + netName + "\").IPAddress}}", ctr.OutputToString()})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(BeZero())
+		Expect(inspect.OutputToString()).ToNot(BeEmpty())
+
+		session = podmanTest.Podman([]string{"network", "prune", "--force", "--leaked", "--filter", "label=a"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+	})
+
 	It("podman network with multiple aliases", func() {
 		Skip("Until DNSName is updated on our CI images")
 		var worked bool
@@ -446,6 +573,32 @@ var _ = Describe("Podman network", func() {
 		Expect(c3.ExitCode()).To(BeZero())
 	})
 
+	It("podman run network alias requires user-defined network", func() {
+		session := podmanTest.Podman([]string{"run", "--network-alias=web1", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+		Expect(session.ErrorToString()).To(ContainSubstring("user-defined networks"))
+	})
+
+	It("podman run containers in a user-defined network resolve each other by name and alias", func() {
+		SkipIfRootless("network aliases are not supported by rootless CNI")
+		netName := "aliasResolve" + stringid.GenerateNonCryptoID()
+		session := podmanTest.Podman([]string{"network", "create", netName})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(BeZero())
+		defer podmanTest.removeCNINetwork(netName)
+
+		top := podmanTest.Podman([]string{"run", "-d", "--name", "web", "--network", netName, "--network-alias", "web1", ALPINE, "top"})
+		top.WaitWithDefaultTimeout()
+		Expect(top.ExitCode()).To(BeZero())
+
+		for _, name := range []string{"web", "web1"} {
+			session = podmanTest.Podman([]string{"run", "--rm", "--network", netName, ALPINE, "nslookup", name})
+			session.WaitWithDefaultTimeout()
+			Expect(session.ExitCode()).To(BeZero())
+		}
+	})
+
 	It("podman network create/remove macvlan", func() {
 		net := "macvlan" + stringid.GenerateNonCryptoID()
 		nc := podmanTest.Podman([]string{"network", "create", "--macvlan", "lo", net})
@@ -39,6 +39,270 @@ data:
 {{ end }}
 `
 
+var secretAndConfigMapPodYaml = `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: kubesecret
+data:
+  password: c2VjcmV0
+stringData:
+  user: admin
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: kubeconfig
+data:
+  app.conf: debug=true
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: kubedata
+spec:
+  containers:
+  - name: ctr
+    image: ` + ALPINE + `
+    command: ["top"]
+    env:
+    - name: PASSWORD
+      valueFrom:
+        secretKeyRef:
+          name: kubesecret
+          key: password
+    - name: MISSING
+      valueFrom:
+        configMapKeyRef:
+          name: kubeconfig
+          key: missing
+          optional: true
+    envFrom:
+    - secretRef:
+        name: kubesecret
+      prefix: SECRET_
+    volumeMounts:
+    - name: config
+      mountPath: /etc/app
+    - name: secret
+      mountPath: /etc/secret
+  volumes:
+  - name: config
+    configMap:
+      name: kubeconfig
+  - name: secret
+    secret:
+      secretName: kubesecret
+      items:
+      - key: password
+        path: db/password
+`
+
+var missingSecretKeyPodYaml = `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: kubesecret
+stringData:
+  user: admin
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: kubedata
+spec:
+  containers:
+  - name: ctr
+    image: ` + ALPINE + `
+    command: ["top"]
+    env:
+    - name: PASSWORD
+      valueFrom:
+        secretKeyRef:
+          name: kubesecret
+          key: password
+`
+
+var multiKindYaml = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: multisvc
+spec:
+  type: NodePort
+  selector:
+    app: multideploy
+  ports:
+  - port: 80
+    targetPort: 8080
+    nodePort: 30080
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: multideploy
+spec:
+  replicas: 2
+  selector:
+    matchLabels:
+      app: multideploy
+  template:
+    metadata:
+      labels:
+        app: multideploy
+    spec:
+      containers:
+      - name: ctr
+        image: ` + ALPINE + `
+        command: ["top"]
+        ports:
+        - containerPort: 8080
+---
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: multids
+spec:
+  selector:
+    matchLabels:
+      app: multids
+  template:
+    metadata:
+      labels:
+        app: multids
+    spec:
+      containers:
+      - name: ctr
+        image: ` + ALPINE + `
+        command: ["top"]
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: multipod
+spec:
+  containers:
+  - name: ctr
+    image: ` + ALPINE + `
+    command: ["top"]
+    volumeMounts:
+    - name: data
+      mountPath: /data
+  volumes:
+  - name: data
+    persistentVolumeClaim:
+      claimName: multiclaim
+---
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: multiclaim
+  labels:
+    app: multipod
+spec:
+  accessModes:
+  - ReadWriteOnce
+  resources:
+    requests:
+      storage: 1Gi
+`
+
+var securityProbePodYaml = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: securepod
+spec:
+  securityContext:
+    runAsUser: 1000
+    supplementalGroups: [10]
+    seccompProfile:
+      type: RuntimeDefault
+  containers:
+  - name: ctr
+    image: ` + ALPINE + `
+    command: ["top"]
+    resources:
+      requests:
+        cpu: 500m
+      limits:
+        cpu: "2"
+    securityContext:
+      runAsGroup: 100
+      readOnlyRootFilesystem: true
+    livenessProbe:
+      exec:
+        command: ["cat", "/etc/hostname"]
+      initialDelaySeconds: 5
+      periodSeconds: 30
+    readinessProbe:
+      exec:
+        command: ["false"]
+`
+
+var httpProbePodYaml = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: httpprobepod
+spec:
+  containers:
+  - name: ctr
+    image: ` + ALPINE + `
+    command: ["top"]
+    livenessProbe:
+      httpGet:
+        path: /healthz
+        port: 8080
+`
+
+var nonRootPodYaml = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: nonrootpod
+spec:
+  securityContext:
+    runAsNonRoot: true
+  containers:
+  - name: ctr
+    image: ` + ALPINE + `
+    command: ["top"]
+`
+
+var pvcAnnotationsPodYaml = `
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: tmpfsclaim
+  annotations:
+    volume.podman.io/device: tmpfs
+    volume.podman.io/type: tmpfs
+    volume.podman.io/mount-options: size=2M
+    volume.podman.io/uid: "1000"
+spec:
+  accessModes:
+  - ReadWriteOnce
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: tmpfspod
+spec:
+  containers:
+  - name: ctr
+    image: ` + ALPINE + `
+    command: ["top"]
+    volumeMounts:
+    - name: data
+      mountPath: /data
+  volumes:
+  - name: data
+    persistentVolumeClaim:
+      claimName: tmpfsclaim
+      readOnly: true
+`
+
 var podYamlTemplate = `
 apiVersion: v1
 kind: Pod
@@ -991,6 +1255,220 @@ var _ = Describe("Podman play kube", func() {
 		Expect(inspect.OutputToString()).To(ContainSubstring(`FOO2=foo2`))
 	})
 
+	It("podman play kube with secrets and configmaps in the yaml file", func() {
+		err := writeYaml(secretAndConfigMapPodYaml, kubeYaml)
+		Expect(err).To(BeNil())
+
+		kube := podmanTest.Podman([]string{"play", "kube", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "kubedata-ctr", "--format", "'{{ .Config.Env }}'"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(ContainSubstring(`PASSWORD=secret`))
+		Expect(inspect.OutputToString()).To(ContainSubstring(`SECRET_user=admin`))
+		Expect(inspect.OutputToString()).To(ContainSubstring(`SECRET_password=secret`))
+		Expect(inspect.OutputToString()).To(Not(ContainSubstring(`MISSING`)))
+
+		exec := podmanTest.Podman([]string{"exec", "kubedata-ctr", "cat", "/etc/app/app.conf", "/etc/secret/db/password"})
+		exec.WaitWithDefaultTimeout()
+		Expect(exec.ExitCode()).To(Equal(0))
+		Expect(exec.OutputToString()).To(Equal("debug=truesecret"))
+
+		// The volumes are read-only.
+		exec = podmanTest.Podman([]string{"exec", "kubedata-ctr", "touch", "/etc/app/new"})
+		exec.WaitWithDefaultTimeout()
+		Expect(exec.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman play kube fails with a missing secret key", func() {
+		err := writeYaml(missingSecretKeyPodYaml, kubeYaml)
+		Expect(err).To(BeNil())
+
+		kube := podmanTest.Podman([]string{"play", "kube", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(125))
+		Expect(kube.ErrorToString()).To(ContainSubstring(`key "password" not found in secret "kubesecret"`))
+	})
+
+	It("podman play kube does not overwrite a volume of the user", func() {
+		session := podmanTest.Podman([]string{"volume", "create", "kubeconfig"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		err := writeYaml(secretAndConfigMapPodYaml, kubeYaml)
+		Expect(err).To(BeNil())
+
+		kube := podmanTest.Podman([]string{"play", "kube", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(125))
+	})
+
+	It("podman play kube with multiple documents of several kinds", func() {
+		err := writeYaml(multiKindYaml, kubeYaml)
+		Expect(err).To(BeNil())
+
+		kube := podmanTest.Podman([]string{"play", "kube", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+
+		for _, pod := range []string{"multipod", "multideploy-pod-0", "multideploy-pod-1", "multids-pod"} {
+			inspect := podmanTest.Podman([]string{"pod", "inspect", pod})
+			inspect.WaitWithDefaultTimeout()
+			Expect(inspect.ExitCode()).To(Equal(0))
+		}
+
+		// The volume of the claim is created before the pod mounting it.
+		inspect := podmanTest.Podman([]string{"volume", "inspect", "multiclaim", "--format", "{{.Labels.app}}"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("multipod"))
+
+		// The node port of the Service is only published by the first
+		// replica.
+		inspect = podmanTest.Podman([]string{"port", "multideploy-pod-0-ctr"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(ContainSubstring("8080/tcp -> 0.0.0.0:30080"))
+
+		inspect = podmanTest.Podman([]string{"port", "multideploy-pod-1-ctr"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(BeEmpty())
+	})
+
+	It("podman play kube removes the pods and volumes it created when a workload fails", func() {
+		failYaml := `
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: failclaim
+spec:
+  accessModes:
+  - ReadWriteOnce
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: failpod
+spec:
+  containers:
+  - name: ctr
+    image: ` + ALPINE + `
+    command: ["top"]
+    volumeMounts:
+    - name: data
+      mountPath: /data
+  volumes:
+  - name: data
+    persistentVolumeClaim:
+      claimName: failclaim
+---
+apiVersion: apps/v1
+kind: DaemonSet
+spec:
+  template:
+    spec:
+      containers:
+      - name: ctr
+        image: ` + ALPINE + `
+        command: ["top"]
+`
+		err := writeYaml(failYaml, kubeYaml)
+		Expect(err).To(BeNil())
+
+		kube := podmanTest.Podman([]string{"play", "kube", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).To(ExitWithError())
+		Expect(kube.ErrorToString()).To(ContainSubstring("DaemonSet does not have a name"))
+
+		inspect := podmanTest.Podman([]string{"pod", "exists", "failpod"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(1))
+
+		inspect = podmanTest.Podman([]string{"volume", "exists", "failclaim"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(1))
+	})
+
+	It("podman play kube uses the existing volume of a PersistentVolumeClaim", func() {
+		session := podmanTest.Podman([]string{"volume", "create", "multiclaim"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		err := writeYaml(multiKindYaml, kubeYaml)
+		Expect(err).To(BeNil())
+
+		kube := podmanTest.Podman([]string{"play", "kube", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+		Expect(kube.OutputToString()).To(Not(ContainSubstring("Volumes:")))
+	})
+
+	It("podman play kube creates the volume of a PersistentVolumeClaim from its annotations", func() {
+		SkipIfRootless("mounting a tmpfs volume requires root")
+		err := writeYaml(pvcAnnotationsPodYaml, kubeYaml)
+		Expect(err).To(BeNil())
+
+		kube := podmanTest.Podman([]string{"play", "kube", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"volume", "inspect", "tmpfsclaim", "--format", "{{.Options.type}} {{.Options.o}} {{.UID}}"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("tmpfs size=2M 1000"))
+
+		// The claim is mounted read-only.
+		exec := podmanTest.Podman([]string{"exec", "tmpfspod-ctr", "touch", "/data/file"})
+		exec.WaitWithDefaultTimeout()
+		Expect(exec.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman play kube with security context, resources and probe", func() {
+		err := writeYaml(securityProbePodYaml, kubeYaml)
+		Expect(err).To(BeNil())
+
+		kube := podmanTest.Podman([]string{"play", "kube", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "securepod-ctr", "--format", "{{ .Config.User }} {{ .HostConfig.GroupAdd }} {{ .HostConfig.ReadonlyRootfs }} {{ .HostConfig.CpuQuota }} {{ .HostConfig.CpuShares }}"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("1000:100 [10] true 200000 512"))
+
+		inspect = podmanTest.Podman([]string{"inspect", "securepod-ctr", "--format", "{{ .Config.Healthcheck.Test }} {{ .Config.Healthcheck.Interval }} {{ .Config.Healthcheck.StartPeriod }}"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("[CMD cat /etc/hostname] 30s 5s"))
+
+		hc := podmanTest.Podman([]string{"healthcheck", "run", "securepod-ctr"})
+		hc.WaitWithDefaultTimeout()
+		Expect(hc.ExitCode()).To(Equal(0))
+	})
+
+	It("podman play kube with an httpGet probe and no curl in the image should fail", func() {
+		err := writeYaml(httpProbePodYaml, kubeYaml)
+		Expect(err).To(BeNil())
+
+		kube := podmanTest.Podman([]string{"play", "kube", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(125))
+		Expect(kube.ErrorToString()).To(ContainSubstring("runs curl, which is not installed in image"))
+	})
+
+	It("podman play kube with runAsNonRoot and a root image should fail", func() {
+		err := writeYaml(nonRootPodYaml, kubeYaml)
+		Expect(err).To(BeNil())
+
+		kube := podmanTest.Podman([]string{"play", "kube", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(125))
+		Expect(kube.ErrorToString()).To(ContainSubstring("must run as a non-root user"))
+	})
+
 	It("podman play kube test hostname", func() {
 		pod := getPod()
 		err := generateKubeYaml("pod", pod, kubeYaml)
@@ -0,0 +1,121 @@
+package integration
+
+import (
+	"os"
+
+	. "github.com/containers/podman/v2/test/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman pod clone", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest *PodmanTestIntegration
+	)
+
+	BeforeEach(func() {
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanTestCreate(tempdir)
+		podmanTest.Setup()
+		podmanTest.SeedImages()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+		f := CurrentGinkgoTestDescription()
+		processTestResult(f)
+
+	})
+
+	It("podman pod clone of a bogus pod", func() {
+		session := podmanTest.Podman([]string{"pod", "clone", "foobar"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+	})
+
+	It("podman pod clone recreates the containers of the pod", func() {
+		session := podmanTest.Podman([]string{"pod", "create", "--name", "source", "--label", "env=prod", "--label", "app=web"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"create", "--pod", "source", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"pod", "clone", "--name", "copy", "--label", "env=test", "--start", "source"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		cloneID := session.OutputToString()
+
+		inspect := podmanTest.Podman([]string{"pod", "inspect", "--format", "{{.ID}} {{.NumContainers}} {{.State}} {{.Labels.env}} {{.Labels.app}}", "copy"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal(cloneID + " 2 Running test web"))
+
+		// The source pod is left untouched.
+		inspect = podmanTest.Podman([]string{"pod", "inspect", "--format", "{{.NumContainers}} {{.State}} {{.Labels.env}}", "source"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("2 Created prod"))
+
+		ps := podmanTest.Podman([]string{"ps", "-a", "--filter", "pod=copy", "--format", "{{.Command}}"})
+		ps.WaitWithDefaultTimeout()
+		Expect(ps.ExitCode()).To(Equal(0))
+		Expect(ps.OutputToString()).To(ContainSubstring("top"))
+	})
+
+	It("podman pod clone does not copy the published ports", func() {
+		session := podmanTest.Podman([]string{"pod", "create", "--name", "source", "--publish", "8080:80"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"create", "--pod", "source", "--memory", "200m", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"pod", "clone", "--name", "plain", "source"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"pod", "inspect", "--format", "{{len .InfraConfig.PortBindings}}", "plain"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("0"))
+
+		session = podmanTest.Podman([]string{"pod", "clone", "--name", "copy", "--publish", "8081:80", "--member-memory", "100m", "source"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		inspect = podmanTest.Podman([]string{"pod", "inspect", "--format", "{{range $port, $bindings := .InfraConfig.PortBindings}}{{$port}} {{(index $bindings 0).HostPort}}{{end}}", "copy"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("80/tcp 8081"))
+
+		ps := podmanTest.Podman([]string{"ps", "-a", "-q", "--no-trunc", "--filter", "pod=copy", "--filter", "ancestor=" + ALPINE})
+		ps.WaitWithDefaultTimeout()
+		Expect(ps.ExitCode()).To(Equal(0))
+		Expect(len(ps.OutputToStringArray())).To(Equal(1))
+
+		ctrInspect := podmanTest.InspectContainer(ps.OutputToString())
+		Expect(ctrInspect[0].HostConfig.Memory).To(Equal(int64(100 * 1024 * 1024)))
+	})
+
+	It("podman pod clone with a name in use", func() {
+		_, ec, _ := podmanTest.CreatePod("source")
+		Expect(ec).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"pod", "clone", "--name", "source", "source"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+
+		ps := podmanTest.Podman([]string{"pod", "ps", "-q"})
+		ps.WaitWithDefaultTimeout()
+		Expect(ps.ExitCode()).To(Equal(0))
+		Expect(len(ps.OutputToStringArray())).To(Equal(1))
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/containers/podman/v2/pkg/rootless"
@@ -476,4 +477,244 @@ entrypoint ["/fromimage"]
 		Expect(status3.ExitCode()).To(Equal(0))
 		Expect(strings.Contains(status3.OutputToString(), "Degraded")).To(BeTrue())
 	})
+
+	It("podman create pod with --shm-size", func() {
+		podName := "testShmSizePod"
+		podCreate := podmanTest.Podman([]string{"pod", "create", "--shm-size", "100m", "--name", podName})
+		podCreate.WaitWithDefaultTimeout()
+		Expect(podCreate.ExitCode()).To(Equal(0))
+
+		run := podmanTest.Podman([]string{"run", "--rm", "--pod", podName, ALPINE, "df", "/dev/shm"})
+		run.WaitWithDefaultTimeout()
+		Expect(run.ExitCode()).To(Equal(0))
+		Expect(run.OutputToString()).To(ContainSubstring("102400"))
+	})
+
+	It("podman create pod with --shm-size and --infra=false fails", func() {
+		podCreate := podmanTest.Podman([]string{"pod", "create", "--shm-size", "100m", "--infra=false"})
+		podCreate.WaitWithDefaultTimeout()
+		Expect(podCreate.ExitCode()).ToNot(Equal(0))
+	})
+
+	It("podman create pod with --infra-name", func() {
+		podName := "testInfraNamePod"
+		podCreate := podmanTest.Podman([]string{"pod", "create", "--infra-name", "testinfra", "--name", podName})
+		podCreate.WaitWithDefaultTimeout()
+		Expect(podCreate.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"pod", "inspect", "--format", "{{.InfraContainerID}}", podName})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		infraID := inspect.OutputToString()
+
+		ctrInspect := podmanTest.Podman([]string{"inspect", "--format", "{{.ID}}", "testinfra"})
+		ctrInspect.WaitWithDefaultTimeout()
+		Expect(ctrInspect.ExitCode()).To(Equal(0))
+		Expect(ctrInspect.OutputToString()).To(Equal(infraID))
+
+		podCreate = podmanTest.Podman([]string{"pod", "create", "--infra-name", "foo", "--infra=false"})
+		podCreate.WaitWithDefaultTimeout()
+		Expect(podCreate.ExitCode()).ToNot(Equal(0))
+	})
+
+	It("podman create pod without infra sharing namespaces", func() {
+		podName := "testNoInfraSharePod"
+		podCreate := podmanTest.Podman([]string{"pod", "create", "--infra=false", "--share", "uts,ipc", "--name", podName})
+		podCreate.WaitWithDefaultTimeout()
+		Expect(podCreate.ExitCode()).To(Equal(0))
+
+		first := podmanTest.Podman([]string{"create", "--pod", podName, "--name", "first", ALPINE, "top"})
+		first.WaitWithDefaultTimeout()
+		Expect(first.ExitCode()).To(Equal(0))
+
+		second := podmanTest.Podman([]string{"create", "--pod", podName, "--name", "second", ALPINE, "top"})
+		second.WaitWithDefaultTimeout()
+		Expect(second.ExitCode()).To(Equal(0))
+
+		start := podmanTest.Podman([]string{"pod", "start", podName})
+		start.WaitWithDefaultTimeout()
+		Expect(start.ExitCode()).To(Equal(0))
+
+		for _, ns := range []string{"uts", "ipc"} {
+			firstNS := podmanTest.Podman([]string{"exec", "first", "readlink", "/proc/self/ns/" + ns})
+			firstNS.WaitWithDefaultTimeout()
+			Expect(firstNS.ExitCode()).To(Equal(0))
+			secondNS := podmanTest.Podman([]string{"exec", "second", "readlink", "/proc/self/ns/" + ns})
+			secondNS.WaitWithDefaultTimeout()
+			Expect(secondNS.ExitCode()).To(Equal(0))
+			Expect(secondNS.OutputToString()).To(Equal(firstNS.OutputToString()))
+		}
+
+		inspect := podmanTest.Podman([]string{"pod", "inspect", "--format", "{{.CreateInfra}}", podName})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("false"))
+
+		// The first container holds the namespaces, but is not an
+		// infra container.
+		firstID := podmanTest.Podman([]string{"inspect", "--format", "{{.ID}}", "first"})
+		firstID.WaitWithDefaultTimeout()
+		Expect(firstID.ExitCode()).To(Equal(0))
+		inspect = podmanTest.Podman([]string{"pod", "inspect", "--format", "{{.InfraContainerID}}/{{.NamespaceHolderID}}", podName})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("/" + firstID.OutputToString()))
+
+		ps := podmanTest.Podman([]string{"pod", "ps", "--filter", "name=" + podName, "--format", "{{.InfraId}}"})
+		ps.WaitWithDefaultTimeout()
+		Expect(ps.ExitCode()).To(Equal(0))
+		Expect(ps.OutputToString()).To(BeEmpty())
+	})
+
+	It("podman create containers concurrently in a pod without infra sharing namespaces", func() {
+		podName := "testNoInfraConcurrentPod"
+		podCreate := podmanTest.Podman([]string{"pod", "create", "--infra=false", "--share", "uts", "--name", podName})
+		podCreate.WaitWithDefaultTimeout()
+		Expect(podCreate.ExitCode()).To(Equal(0))
+
+		names := []string{"first", "second", "third"}
+		sessions := make([]*PodmanSessionIntegration, 0, len(names))
+		for _, name := range names {
+			sessions = append(sessions, podmanTest.Podman([]string{"create", "--pod", podName, "--name", name, ALPINE, "top"}))
+		}
+		for _, session := range sessions {
+			session.WaitWithDefaultTimeout()
+			Expect(session.ExitCode()).To(Equal(0))
+		}
+
+		start := podmanTest.Podman([]string{"pod", "start", podName})
+		start.WaitWithDefaultTimeout()
+		Expect(start.ExitCode()).To(Equal(0))
+
+		var uts string
+		for _, name := range names {
+			session := podmanTest.Podman([]string{"exec", name, "readlink", "/proc/self/ns/uts"})
+			session.WaitWithDefaultTimeout()
+			Expect(session.ExitCode()).To(Equal(0))
+			if uts == "" {
+				uts = session.OutputToString()
+			}
+			Expect(session.OutputToString()).To(Equal(uts))
+		}
+	})
+
+	It("podman create pod sharing pid and user namespaces", func() {
+		podName := "testSharePidUserPod"
+		podCreate := podmanTest.Podman([]string{"pod", "create", "--share", "pid,user", "--name", podName})
+		podCreate.WaitWithDefaultTimeout()
+		Expect(podCreate.ExitCode()).To(Equal(0))
+
+		for _, name := range []string{"first", "second"} {
+			session := podmanTest.Podman([]string{"run", "-d", "--pod", podName, "--name", name, ALPINE, "top"})
+			session.WaitWithDefaultTimeout()
+			Expect(session.ExitCode()).To(Equal(0))
+		}
+		optOut := podmanTest.Podman([]string{"run", "-d", "--pod", podName, "--pid", "private", "--name", "optout", ALPINE, "top"})
+		optOut.WaitWithDefaultTimeout()
+		Expect(optOut.ExitCode()).To(Equal(0))
+
+		ns := func(ctr, ns string) string {
+			session := podmanTest.Podman([]string{"exec", ctr, "readlink", "/proc/self/ns/" + ns})
+			session.WaitWithDefaultTimeout()
+			Expect(session.ExitCode()).To(Equal(0))
+			return session.OutputToString()
+		}
+		Expect(ns("second", "pid")).To(Equal(ns("first", "pid")))
+		Expect(ns("second", "user")).To(Equal(ns("first", "user")))
+		Expect(ns("optout", "pid")).ToNot(Equal(ns("first", "pid")))
+
+		inspect := podmanTest.Podman([]string{"pod", "inspect", "--format", "{{.SharedNamespaces}}", podName})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(ContainSubstring("pid"))
+		Expect(inspect.OutputToString()).To(ContainSubstring("user"))
+	})
+
+	It("podman create pod with --userns keep-id", func() {
+		SkipIfNotRootless("keep-id only creates a user namespace for rootless users")
+		podName := "testKeepIDPod"
+		podCreate := podmanTest.Podman([]string{"pod", "create", "--userns", "keep-id", "--name", podName})
+		podCreate.WaitWithDefaultTimeout()
+		Expect(podCreate.ExitCode()).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"run", "--pod", podName, ALPINE, "id", "-u"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal(strconv.Itoa(os.Geteuid())))
+
+		session = podmanTest.Podman([]string{"run", "--pod", podName, "--userns", "host", ALPINE, "id", "-u"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("0"))
+
+		inspect := podmanTest.Podman([]string{"pod", "inspect", "--format", "{{.InfraConfig.Userns}}", podName})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("keep-id"))
+
+		podCreate = podmanTest.Podman([]string{"pod", "create", "--userns", "keep-id", "--infra=false"})
+		podCreate.WaitWithDefaultTimeout()
+		Expect(podCreate.ExitCode()).ToNot(Equal(0))
+	})
+
+	It("podman create pod with resource limits", func() {
+		SkipIfRootlessCgroupsV1("Setting resource limits of pods is not supported on cgroupv1 for rootless users")
+		SkipIfUnprivilegedCPULimits()
+		podName := "testLimitsPod"
+		podCreate := podmanTest.Podman([]string{"pod", "create", "--cpus", "0.5", "--memory", "100m", "--pids-limit", "100", "--name", podName})
+		podCreate.WaitWithDefaultTimeout()
+		Expect(podCreate.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"pod", "inspect", podName})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		podData := inspect.InspectPodToJSON()
+		Expect(podData.CPUPeriod).To(Equal(uint64(100000)))
+		Expect(podData.CPUQuota).To(Equal(int64(50000)))
+		Expect(podData.MemoryLimit).To(Equal(int64(100 * 1024 * 1024)))
+		Expect(podData.PidsLimit).To(Equal(int64(100)))
+
+		// The limits are set on the cgroup of the pod, which is the
+		// parent of the cgroups of its containers.
+		session := podmanTest.Podman([]string{"run", "-d", "--name", "member", "--pod", podName, "--cgroupns", "host", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		checkLimits := func() {
+			if CGROUPSV2 {
+				exec := podmanTest.Podman([]string{"exec", "member", "sh", "-c", "dir=/sys/fs/cgroup$(dirname $(cut -d: -f3 /proc/self/cgroup)); cat $dir/cpu.max $dir/memory.max $dir/pids.max"})
+				exec.WaitWithDefaultTimeout()
+				Expect(exec.ExitCode()).To(Equal(0))
+				Expect(exec.OutputToStringArray()).To(Equal([]string{"50000 100000", "104857600", "100"}))
+				return
+			}
+			// The cgroup of the pod is not mounted in its containers
+			// on cgroup v1, read the limits on the host.
+			for file, value := range map[string]string{
+				"cpu/cpu.cfs_quota_us":         "50000",
+				"memory/memory.limit_in_bytes": "104857600",
+				"pids/pids.max":                "100",
+			} {
+				content, err := ioutil.ReadFile(filepath.Join("/sys/fs/cgroup", filepath.Dir(file), podData.CgroupPath, filepath.Base(file)))
+				Expect(err).To(BeNil())
+				Expect(strings.TrimSpace(string(content))).To(Equal(value))
+			}
+		}
+		checkLimits()
+
+		session = podmanTest.Podman([]string{"pod", "restart", podName})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		checkLimits()
+	})
+
+	It("podman create pod with invalid resource limits fails", func() {
+		podCreate := podmanTest.Podman([]string{"pod", "create", "--memory", "foo"})
+		podCreate.WaitWithDefaultTimeout()
+		Expect(podCreate.ExitCode()).ToNot(Equal(0))
+
+		podCreate = podmanTest.Podman([]string{"pod", "create", "--cpus", "-1"})
+		podCreate.WaitWithDefaultTimeout()
+		Expect(podCreate.ExitCode()).ToNot(Equal(0))
+	})
 })
@@ -0,0 +1,132 @@
+package integration
+
+import (
+	"os"
+
+	. "github.com/containers/podman/v2/test/utils"
+	"github.com/ghodss/yaml"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Podman init containers", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest *PodmanTestIntegration
+	)
+
+	BeforeEach(func() {
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanTestCreate(tempdir)
+		podmanTest.Setup()
+		podmanTest.SeedImages()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+		f := CurrentGinkgoTestDescription()
+		processTestResult(f)
+
+	})
+
+	It("podman create init container without --pod should fail", func() {
+		session := podmanTest.Podman([]string{"create", "--init-ctr", "always", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+	})
+
+	It("podman create init container with bad type should fail", func() {
+		session := podmanTest.Podman([]string{"create", "--init-ctr", "unknown", "--pod", "new:foobar", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+	})
+
+	It("podman init containers run before the other containers", func() {
+		session := podmanTest.Podman([]string{"create", "--init-ctr", "always", "--pod", "new:foobar", "--name", "init", "-v", "/tmp:/tmp:z", ALPINE, "sh", "-c", "echo init > /tmp/initctr-test"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"create", "--pod", "foobar", "--name", "main", "-v", "/tmp:/tmp:z", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		start := podmanTest.Podman([]string{"pod", "start", "foobar"})
+		start.WaitWithDefaultTimeout()
+		Expect(start.ExitCode()).To(Equal(0))
+
+		check := podmanTest.Podman([]string{"exec", "main", "cat", "/tmp/initctr-test"})
+		check.WaitWithDefaultTimeout()
+		Expect(check.ExitCode()).To(Equal(0))
+		Expect(check.OutputToString()).To(Equal("init"))
+
+		// An "always" init container is kept.
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.State.Status}}", "init"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("exited"))
+	})
+
+	It("podman init container of type once is removed", func() {
+		session := podmanTest.Podman([]string{"create", "--init-ctr", "once", "--pod", "new:foobar", "--name", "init", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"create", "--pod", "foobar", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		start := podmanTest.Podman([]string{"pod", "start", "foobar"})
+		start.WaitWithDefaultTimeout()
+		Expect(start.ExitCode()).To(Equal(0))
+
+		exists := podmanTest.Podman([]string{"container", "exists", "init"})
+		exists.WaitWithDefaultTimeout()
+		Expect(exists.ExitCode()).To(Equal(1))
+	})
+
+	It("podman failing init container prevents the pod from starting", func() {
+		session := podmanTest.Podman([]string{"create", "--init-ctr", "always", "--pod", "new:foobar", ALPINE, "false"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"create", "--pod", "foobar", "--name", "main", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		start := podmanTest.Podman([]string{"pod", "start", "foobar"})
+		start.WaitWithDefaultTimeout()
+		Expect(start).To(ExitWithError())
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.State.Running}}", "main"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("false"))
+	})
+
+	It("podman generate kube with init containers", func() {
+		session := podmanTest.Podman([]string{"create", "--init-ctr", "always", "--pod", "new:foobar", "--name", "init", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"create", "--pod", "foobar", "--name", "main", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		kube := podmanTest.Podman([]string{"generate", "kube", "foobar"})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+
+		pod := new(v1.Pod)
+		err := yaml.Unmarshal(kube.Out.Contents(), pod)
+		Expect(err).To(BeNil())
+		Expect(len(pod.Spec.InitContainers)).To(Equal(1))
+		Expect(pod.Spec.InitContainers[0].Name).To(Equal("init"))
+		Expect(len(pod.Spec.Containers)).To(Equal(1))
+		Expect(pod.Spec.Containers[0].Name).To(Equal("main"))
+	})
+})
@@ -57,6 +57,28 @@ var _ = Describe("Podman pod kill", func() {
 		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(0))
 	})
 
+	It("podman pod kill a pod excluding its infra container", func() {
+		_, ec, podid := podmanTest.CreatePod("")
+		Expect(ec).To(Equal(0))
+
+		session := podmanTest.RunTopContainerInPod("", podid)
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		result := podmanTest.Podman([]string{"pod", "kill", "--exclude-infra", podid})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(1))
+
+		infra := podmanTest.Podman([]string{"pod", "inspect", "--format", "{{.InfraContainerID}}", podid})
+		infra.WaitWithDefaultTimeout()
+		Expect(infra.ExitCode()).To(Equal(0))
+		state := podmanTest.Podman([]string{"inspect", "--format", "{{.State.Status}}", infra.OutputToString()})
+		state.WaitWithDefaultTimeout()
+		Expect(state.ExitCode()).To(Equal(0))
+		Expect(state.OutputToString()).To(Equal("running"))
+	})
+
 	It("podman pod kill a pod by id with TERM", func() {
 		_, ec, podid := podmanTest.CreatePod("")
 		Expect(ec).To(Equal(0))
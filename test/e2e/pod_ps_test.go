@@ -257,6 +257,53 @@ var _ = Describe("Podman ps", func() {
 		Expect(session.OutputToString()).To(BeEmpty())
 	})
 
+	It("podman pod ps filter ctr-count and since", func() {
+		_, ec, podid1 := podmanTest.CreatePod("")
+		Expect(ec).To(Equal(0))
+
+		_, ec, podid2 := podmanTest.CreatePod("")
+		Expect(ec).To(Equal(0))
+		session := podmanTest.RunTopContainerInPod("", podid2)
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		_, ec, podid3 := podmanTest.CreatePod("")
+		Expect(ec).To(Equal(0))
+		for i := 0; i < 2; i++ {
+			session = podmanTest.RunTopContainerInPod("", podid3)
+			session.WaitWithDefaultTimeout()
+			Expect(session.ExitCode()).To(Equal(0))
+		}
+
+		session = podmanTest.Podman([]string{"pod", "ps", "-q", "--no-trunc", "--filter", "ctr-count>1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToStringArray()).To(Equal([]string{podid3}))
+
+		session = podmanTest.Podman([]string{"pod", "ps", "-q", "--no-trunc", "--filter", "ctr-count<=1", "--sort", "id"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToStringArray()).To(ConsistOf(podid1, podid2))
+
+		session = podmanTest.Podman([]string{"pod", "ps", "-q", "--no-trunc", "--filter", "ctr-count>x"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError())
+
+		session = podmanTest.Podman([]string{"pod", "ps", "-q", "--no-trunc", "--filter", "since=" + podid1})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToStringArray()).To(ConsistOf(podid2, podid3))
+
+		session = podmanTest.Podman([]string{"pod", "ps", "--filter", "id=" + podid3, "--format", "{{.ContainerSummary}}"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("2 running"))
+
+		session = podmanTest.Podman([]string{"pod", "ps", "--sort", "bogus"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError())
+	})
+
 	It("podman pod ps filter labels", func() {
 		_, ec, podid1 := podmanTest.CreatePod("")
 		Expect(ec).To(Equal(0))
@@ -0,0 +1,81 @@
+package integration
+
+import (
+	"os"
+
+	. "github.com/containers/podman/v2/test/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman pod rename", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest *PodmanTestIntegration
+	)
+
+	BeforeEach(func() {
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanTestCreate(tempdir)
+		podmanTest.Setup()
+		podmanTest.SeedImages()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+		f := CurrentGinkgoTestDescription()
+		processTestResult(f)
+
+	})
+
+	It("podman pod rename of a bogus pod", func() {
+		session := podmanTest.Podman([]string{"pod", "rename", "foobar", "barfoo"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+	})
+
+	It("podman pod rename a running pod", func() {
+		_, ec, podID := podmanTest.CreatePod("oldname")
+		Expect(ec).To(Equal(0))
+
+		session := podmanTest.RunTopContainerInPod("", "oldname")
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"pod", "rename", "oldname", "newname"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"pod", "inspect", "--format", "{{.ID}} {{.State}}", "newname"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal(podID + " Running"))
+
+		exists := podmanTest.Podman([]string{"pod", "exists", "oldname"})
+		exists.WaitWithDefaultTimeout()
+		Expect(exists.ExitCode()).To(Equal(1))
+	})
+
+	It("podman pod rename to a name in use", func() {
+		_, ec, _ := podmanTest.CreatePod("first")
+		Expect(ec).To(Equal(0))
+		_, ec, _ = podmanTest.CreatePod("second")
+		Expect(ec).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"pod", "rename", "first", "second"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+
+		session = podmanTest.Podman([]string{"create", "--name", "ctrname", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"pod", "rename", "first", "ctrname"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+	})
+})
@@ -2,6 +2,8 @@ package integration
 
 import (
 	"os"
+	"strconv"
+	"strings"
 
 	. "github.com/containers/podman/v2/test/utils"
 	. "github.com/onsi/ginkgo"
@@ -151,6 +153,46 @@ var _ = Describe("Podman pod stats", func() {
 		Expect(stats.ExitCode()).To(Equal(0))
 		Expect(stats.IsJSONOutputValid()).To(BeTrue())
 	})
+	It("podman stats --sum shows the sum of the containers of the pod", func() {
+		_, ec, podid := podmanTest.CreatePod("statspod")
+		Expect(ec).To(Equal(0))
+
+		for i := 0; i < 2; i++ {
+			session := podmanTest.RunTopContainerInPod("", podid)
+			session.WaitWithDefaultTimeout()
+			Expect(session.ExitCode()).To(Equal(0))
+		}
+
+		stats := podmanTest.Podman([]string{"pod", "stats", "--no-stream", "--sum", "--format", "{{.CID}} {{.Name}} {{.PIDS}}", "statspod"})
+		stats.WaitWithDefaultTimeout()
+		Expect(stats).To(Exit(0))
+		lines := stats.OutputToStringArray()
+		// The pod, its infra container and the two top containers.
+		Expect(len(lines)).To(Equal(4))
+
+		podFields := strings.Fields(lines[0])
+		Expect(podFields[0]).To(Equal("--"))
+		Expect(podFields[1]).To(Equal("statspod"))
+		podPids, err := strconv.Atoi(podFields[2])
+		Expect(err).To(BeNil())
+		ctrPids := 0
+		for _, line := range lines[1:] {
+			fields := strings.Fields(line)
+			Expect(fields[0]).To(Not(Equal("--")))
+			pids, err := strconv.Atoi(fields[2])
+			Expect(err).To(BeNil())
+			ctrPids += pids
+		}
+		Expect(podPids).To(Equal(ctrPids))
+
+		// Without --sum, only the containers are listed.
+		stats = podmanTest.Podman([]string{"pod", "stats", "--no-stream", "--format", "{{.CID}}", "statspod"})
+		stats.WaitWithDefaultTimeout()
+		Expect(stats).To(Exit(0))
+		Expect(len(stats.OutputToStringArray())).To(Equal(3))
+		Expect(stats.OutputToStringArray()).To(Not(ContainElement("--")))
+	})
+
 	It("podman stats with GO template", func() {
 		_, ec, podid := podmanTest.CreatePod("")
 		Expect(ec).To(Equal(0))
@@ -0,0 +1,98 @@
+package integration
+
+import (
+	"os"
+
+	. "github.com/containers/podman/v2/test/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman pod update", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest *PodmanTestIntegration
+	)
+
+	BeforeEach(func() {
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanTestCreate(tempdir)
+		podmanTest.Setup()
+		podmanTest.SeedImages()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+		f := CurrentGinkgoTestDescription()
+		processTestResult(f)
+
+	})
+
+	It("podman pod update without options", func() {
+		_, ec, podid := podmanTest.CreatePod("")
+		Expect(ec).To(Equal(0))
+
+		result := podmanTest.Podman([]string{"pod", "update", podid})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(125))
+	})
+
+	It("podman pod update on pod without infra container", func() {
+		session := podmanTest.Podman([]string{"pod", "create", "--infra=false"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		result := podmanTest.Podman([]string{"pod", "update", "-p", "8080:80", session.OutputToString()})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(125))
+	})
+
+	It("podman pod update adds and removes ports of a stopped pod", func() {
+		session := podmanTest.Podman([]string{"pod", "create", "--name", "ports", "-p", "8080:80"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		result := podmanTest.Podman([]string{"pod", "update", "--publish-rm", "8080", "-p", "8081:80", "ports"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		Expect(result.OutputToString()).To(Equal(session.OutputToString()))
+
+		inspect := podmanTest.Podman([]string{"pod", "inspect", "--format", "{{.InfraConfig.PortBindings}}", "ports"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(ContainSubstring("8081"))
+		Expect(inspect.OutputToString()).To(Not(ContainSubstring("8080")))
+
+		// The port is not published anymore
+		result = podmanTest.Podman([]string{"pod", "update", "--publish-rm", "8080", "ports"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(125))
+
+		// The port is already published
+		result = podmanTest.Podman([]string{"pod", "update", "-p", "8081:8000", "ports"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(125))
+	})
+
+	It("podman pod update publishes ports of a running pod", func() {
+		SkipIfRootless("Rootless pods publish new ports when they are restarted")
+		session := podmanTest.Podman([]string{"pod", "create", "--name", "web"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		webserver := podmanTest.Podman([]string{"run", "--pod", "web", "-dt", nginx})
+		webserver.WaitWithDefaultTimeout()
+		Expect(webserver.ExitCode()).To(Equal(0))
+
+		result := podmanTest.Podman([]string{"pod", "update", "-p", "8082:80", "web"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+
+		check := SystemExec("nc", []string{"-z", "localhost", "8082"})
+		Expect(check.ExitCode()).To(Equal(0))
+	})
+})
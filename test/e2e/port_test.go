@@ -3,6 +3,7 @@ package integration
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	. "github.com/containers/podman/v2/test/utils"
@@ -150,4 +151,61 @@ var _ = Describe("Podman port", func() {
 		Expect(result2.ExitCode()).To(BeZero())
 		Expect(result2.LineInOutputStartsWith("0.0.0.0:5001")).To(BeTrue())
 	})
+
+	It("podman port range", func() {
+		setup := podmanTest.Podman([]string{"run", "--name", "test", "-dt", "-p", "25000-25010:5000-5010", ALPINE, "top"})
+		setup.WaitWithDefaultTimeout()
+		Expect(setup.ExitCode()).To(BeZero())
+
+		result := podmanTest.Podman([]string{"port", "test"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.OutputToStringArray()).To(Equal([]string{"5000-5010/tcp -> 0.0.0.0:25000-25010"}))
+
+		result = podmanTest.Podman([]string{"port", "test", "5005"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(BeZero())
+		Expect(result.OutputToString()).To(Equal("0.0.0.0:25005"))
+	})
+
+	It("podman port random range is contiguous", func() {
+		setup := podmanTest.Podman([]string{"create", "--name", "test", "-p", "5000-5004", ALPINE, "top"})
+		setup.WaitWithDefaultTimeout()
+		Expect(setup.ExitCode()).To(BeZero())
+
+		result := podmanTest.Podman([]string{"inspect", "--format", "{{range .NetworkSettings.Ports}}{{range .}}{{.HostPort}} {{end}}{{end}}", "test"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(BeZero())
+		hostPorts := map[int]bool{}
+		for _, p := range strings.Fields(result.OutputToString()) {
+			port, err := strconv.Atoi(p)
+			Expect(err).To(BeNil())
+			hostPorts[port] = true
+		}
+		Expect(len(hostPorts)).To(Equal(5))
+		min := 65536
+		for port := range hostPorts {
+			if port < min {
+				min = port
+			}
+		}
+		for i := 0; i < 5; i++ {
+			Expect(hostPorts[min+i]).To(BeTrue())
+		}
+	})
+
+	It("podman random host ports of created containers do not conflict", func() {
+		hostPorts := map[string]bool{}
+		for i := 0; i < 10; i++ {
+			session := podmanTest.Podman([]string{"create", "-p", "80", ALPINE, "top"})
+			session.WaitWithDefaultTimeout()
+			Expect(session.ExitCode()).To(BeZero())
+
+			result := podmanTest.Podman([]string{"inspect", "--format", "{{range .NetworkSettings.Ports}}{{range .}}{{.HostPort}}{{end}}{{end}}", session.OutputToString()})
+			result.WaitWithDefaultTimeout()
+			Expect(result.ExitCode()).To(BeZero())
+			Expect(hostPorts).ToNot(HaveKey(result.OutputToString()))
+			hostPorts[result.OutputToString()] = true
+		}
+	})
 })
@@ -1,7 +1,11 @@
 package integration
 
 import (
+	"fmt"
+	"io/ioutil"
 	"os"
+	"os/exec"
+	"path/filepath"
 
 	. "github.com/containers/podman/v2/test/utils"
 	. "github.com/onsi/ginkgo"
@@ -409,4 +413,38 @@ var _ = Describe("Podman prune", func() {
 
 		podmanTest.Cleanup()
 	})
+
+	It("podman system prune --interrupted", func() {
+		info := podmanTest.Podman([]string{"info", "--format", "{{.Store.GraphRoot}}"})
+		info.WaitWithDefaultTimeout()
+		Expect(info.ExitCode()).To(Equal(0))
+		recordsDir := filepath.Join(info.OutputToString(), "libpod", "pulls")
+		Expect(os.MkdirAll(recordsDir, 0700)).To(BeNil())
+
+		// Record a pull of a process which no longer exists.
+		pullDir, err := ioutil.TempDir(tempdir, "podman-pull")
+		Expect(err).To(BeNil())
+		Expect(ioutil.WriteFile(filepath.Join(pullDir, "blob"), []byte("partial"), 0600)).To(BeNil())
+		cmd := exec.Command("true")
+		Expect(cmd.Run()).To(BeNil())
+		record := fmt.Sprintf(`{"pid":%d,"image":"quay.io/libpod/interrupted:latest","tempDir":%q}`, cmd.Process.Pid, pullDir)
+		recordPath := filepath.Join(recordsDir, "podman-pull123.json")
+		Expect(ioutil.WriteFile(recordPath, []byte(record), 0600)).To(BeNil())
+
+		session := podmanTest.Podman([]string{"system", "prune", "--force", "--interrupted"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring("quay.io/libpod/interrupted:latest"))
+
+		_, err = os.Stat(pullDir)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+		_, err = os.Stat(recordPath)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+
+		// The layers of the images are kept.
+		images := podmanTest.Podman([]string{"images", "-aq"})
+		images.WaitWithDefaultTimeout()
+		Expect(images.ExitCode()).To(Equal(0))
+		Expect(len(images.OutputToStringArray())).To(Equal(len(CACHE_IMAGES)))
+	})
 })
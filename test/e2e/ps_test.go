@@ -552,6 +552,18 @@ var _ = Describe("Podman ps", func() {
 		Expect(result.OutputToString()).To(ContainSubstring("ago"))
 	})
 
+	It("podman ps --format {{.Pids}} {{.RestartCount}}", func() {
+		SkipIfRootlessCgroupsV1("Setting pids-limit not supported on cgroupv1 for rootless users")
+		session := podmanTest.Podman([]string{"run", "-d", "--pids-limit", "100", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		result := podmanTest.Podman([]string{"ps", "--format", "{{.Pids}} {{.PidsLimit}} {{.RestartCount}}"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(0))
+		Expect(result.OutputToString()).To(Equal("1/100 100 0"))
+	})
+
 	It("podman ps filter test", func() {
 		session := podmanTest.Podman([]string{"run", "-d", "--name", "test1", "--label", "foo=1",
 			"--label", "bar=2", "--volume", "volume1:/test", ALPINE, "top"})
@@ -724,4 +736,29 @@ var _ = Describe("Podman ps", func() {
 
 	})
 
+	It("podman ps filter restart-policy", func() {
+		session := podmanTest.Podman([]string{"create", "--restart", "always", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(BeZero())
+		cid := session.OutputToString()
+
+		session = podmanTest.Podman([]string{"create", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(BeZero())
+		cid2 := session.OutputToString()
+
+		session = podmanTest.Podman([]string{"ps", "-aq", "--no-trunc", "--filter", "restart-policy=always"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(BeZero())
+		Expect(session.OutputToStringArray()).To(Equal([]string{cid}))
+
+		session = podmanTest.Podman([]string{"ps", "-aq", "--no-trunc", "--filter", "restart-policy=no"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(BeZero())
+		Expect(session.OutputToStringArray()).To(Equal([]string{cid2}))
+
+		session = podmanTest.Podman([]string{"ps", "-a", "--filter", "restart-policy=sometimes"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError())
+	})
 })
@@ -506,7 +506,7 @@ var _ = Describe("Podman pull", func() {
 		session = podmanTest.Podman([]string{"pull", "--platform=linux/arm64", "--override-os", "windows", ALPINE})
 		session.WaitWithDefaultTimeout()
 		Expect(session.ExitCode()).To(Equal(125))
-		expectedError = "--platform option can not be specified with --override-arch or --override-os"
+		expectedError = "--platform option can not be specified with --arch, --os or --variant"
 		Expect(session.ErrorToString()).To(ContainSubstring(expectedError))
 
 		session = podmanTest.Podman([]string{"pull", "-q", "--platform=linux/arm64", ALPINE})
@@ -522,4 +522,34 @@ var _ = Describe("Podman pull", func() {
 		Expect(data[0].Os).To(Equal(runtime.GOOS))
 		Expect(data[0].Architecture).To(Equal("arm64"))
 	})
+
+	It("podman pull --arch keeps the image of the other platform", func() {
+		session := podmanTest.Podman([]string{"pull", "-q", ALPINELISTTAG})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		nativeID := session.OutputToString()
+
+		session = podmanTest.Podman([]string{"pull", "-q", "--arch=arm64", ALPINELISTTAG})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		armID := session.OutputToString()
+		Expect(armID).ToNot(Equal(nativeID))
+
+		// The tag moved to the arm64 image, the native image must not
+		// become dangling.
+		session = podmanTest.Podman([]string{"images", "-q", "--no-trunc", "--filter", "dangling=true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).ToNot(ContainSubstring(nativeID))
+
+		session = podmanTest.Podman([]string{"image", "exists", nativeID})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		// A local image of another platform is not used.
+		session = podmanTest.Podman([]string{"create", "--pull=never", "--platform=linux/s390x", ALPINELISTTAG})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+		Expect(session.ErrorToString()).To(ContainSubstring("image does not match the requested platform"))
+	})
 })
@@ -2,10 +2,13 @@ package integration
 
 import (
 	"os"
+	"syscall"
+	"time"
 
 	. "github.com/containers/podman/v2/test/utils"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gexec"
 )
 
 var _ = Describe("Podman run exit", func() {
@@ -69,4 +72,83 @@ var _ = Describe("Podman run exit", func() {
 		Expect(pmount.OutputToString()).NotTo(ContainSubstring(cid))
 
 	})
+
+	// startCleanupMonitor starts podman container cleanup --watch and
+	// waits until containers started now rely on it.
+	startCleanupMonitor := func() *PodmanSessionIntegration {
+		monitor := podmanTest.Podman([]string{"container", "cleanup", "--watch"})
+		time.Sleep(2 * time.Second)
+		Expect(monitor.ExitCode()).To(Equal(-1))
+		return monitor
+	}
+
+	// mounted returns the IDs of the mounted containers.
+	mounted := func() string {
+		pmount := podmanTest.Podman([]string{"mount", "--notruncate"})
+		pmount.WaitWithDefaultTimeout()
+		Expect(pmount.ExitCode()).To(Equal(0))
+		return pmount.OutputToString()
+	}
+
+	It("podman container cleanup --watch cleans up many containers", func() {
+		SkipIfRemote("podman-remote does not support container cleanup --watch")
+		SkipIfRootless("FIXME podman mount requires podman unshare first")
+
+		monitor := startCleanupMonitor()
+		defer monitor.Kill()
+
+		const count = 20
+		for i := 0; i < count; i++ {
+			session := podmanTest.Podman([]string{"run", "-d", ALPINE, "true"})
+			session.WaitWithDefaultTimeout()
+			Expect(session.ExitCode()).To(Equal(0))
+			session = podmanTest.Podman([]string{"run", "-d", "--rm", ALPINE, "true"})
+			session.WaitWithDefaultTimeout()
+			Expect(session.ExitCode()).To(Equal(0))
+		}
+
+		// The containers run with --rm are removed, the others are
+		// unmounted.
+		Eventually(func() int {
+			ps := podmanTest.Podman([]string{"ps", "-aq"})
+			ps.WaitWithDefaultTimeout()
+			Expect(ps.ExitCode()).To(Equal(0))
+			return len(ps.OutputToStringArray())
+		}, 60*time.Second, time.Second).Should(Equal(count))
+		Eventually(mounted, 60*time.Second, time.Second).Should(BeEmpty())
+		Expect(monitor.ExitCode()).To(Equal(-1))
+	})
+
+	It("podman container cleanup --watch catches up after it was killed", func() {
+		SkipIfRemote("podman-remote does not support container cleanup --watch")
+		SkipIfRootless("FIXME podman mount requires podman unshare first")
+
+		monitor := startCleanupMonitor()
+
+		// The container relies on the monitor, which dies before the
+		// container exits.
+		session := podmanTest.Podman([]string{"run", "-d", ALPINE, "sleep", "3"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		cid := session.OutputToString()
+		monitor.Signal(syscall.SIGKILL)
+		Eventually(monitor, 10).Should(Exit())
+
+		wait := podmanTest.Podman([]string{"wait", cid})
+		wait.WaitWithDefaultTimeout()
+		Expect(wait.ExitCode()).To(Equal(0))
+
+		// Containers started without a monitor clean up themselves.
+		session = podmanTest.Podman([]string{"run", "-d", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		cid2 := session.OutputToString()
+		Eventually(mounted, 30*time.Second, time.Second).ShouldNot(ContainSubstring(cid2))
+
+		// The next monitor cleans up the container which exited while
+		// no monitor was running.
+		monitor = startCleanupMonitor()
+		defer monitor.Kill()
+		Eventually(mounted, 30*time.Second, time.Second).ShouldNot(ContainSubstring(cid))
+	})
 })
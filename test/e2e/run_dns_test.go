@@ -1,7 +1,9 @@
 package integration
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	. "github.com/containers/podman/v2/test/utils"
 	. "github.com/onsi/ginkgo"
@@ -80,6 +82,37 @@ var _ = Describe("Podman run dns", func() {
 		session.LineInOutputStartsWith("2001:db8::68 foobaz")
 	})
 
+	It("podman run add host-gateway host", func() {
+		session := podmanTest.Podman([]string{"run", "--network", "host", "--add-host=foobar:host-gateway", ALPINE, "cat", "/etc/hosts"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.LineInOutputStartsWith("127.0.0.1 foobar")).To(BeTrue())
+		Expect(session.LineInOutputStartsWith("127.0.0.1\thost.containers.internal")).To(BeTrue())
+	})
+
+	It("podman run with --hosts-file", func() {
+		hostsFile := filepath.Join(podmanTest.TempDir, "hosts")
+		err := ioutil.WriteFile(hostsFile, []byte("1.2.3.4 fromfile\n"), 0644)
+		Expect(err).To(BeNil())
+
+		session := podmanTest.Podman([]string{"run", "--hosts-file", hostsFile, ALPINE, "cat", "/etc/hosts"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.LineInOutputStartsWith("1.2.3.4 fromfile")).To(BeTrue())
+
+		session = podmanTest.Podman([]string{"run", "--hosts-file", "none", ALPINE, "cat", "/etc/hosts"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.LineInOutputStartsWith("127.0.0.1\tlocalhost")).To(BeTrue())
+		Expect(session.LineInOutputStartsWith("1.2.3.4 fromfile")).To(BeFalse())
+	})
+
+	It("podman run with --hosts-file and --no-hosts fails", func() {
+		session := podmanTest.Podman([]string{"run", "--hosts-file", "none", "--no-hosts", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError())
+	})
+
 	It("podman run add hostname", func() {
 		session := podmanTest.Podman([]string{"run", "--hostname=foobar", ALPINE, "cat", "/etc/hostname"})
 		session.WaitWithDefaultTimeout()
@@ -370,6 +370,18 @@ var _ = Describe("Podman run networking", func() {
 		Expect(session.ExitCode()).To(Not(Equal(0)))
 	})
 
+	It("podman run slirp4netns network with mtu", func() {
+		session := podmanTest.Podman([]string{"run", "--network", "slirp4netns:mtu=1500", ALPINE, "cat", "/sys/class/net/tap0/mtu"})
+		session.Wait(30)
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("1500"))
+
+		session = podmanTest.Podman([]string{"create", "--network", "slirp4netns:mtu=65522", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+		Expect(session.ErrorToString()).To(ContainSubstring("invalid mtu"))
+	})
+
 	It("podman run slirp4netns network with host loopback", func() {
 		session := podmanTest.Podman([]string{"run", "--network", "slirp4netns:allow_host_loopback=true", ALPINE, "ping", "-c1", "10.0.2.2"})
 		session.Wait(30)
@@ -392,6 +404,22 @@ var _ = Describe("Podman run networking", func() {
 		}
 	})
 
+	It("podman run slirp4netns network with different cidr sets hosts and resolv.conf", func() {
+		slirp4netnsHelp := SystemExec("slirp4netns", []string{"--help"})
+		Expect(slirp4netnsHelp.ExitCode()).To(Equal(0))
+		if !strings.Contains(slirp4netnsHelp.OutputToString(), "cidr") {
+			Skip("slirp4netns does not support --cidr")
+		}
+
+		networkConfiguration := "slirp4netns:cidr=192.168.0.0/24,allow_host_loopback=true"
+		session := podmanTest.Podman([]string{"run", "--network", networkConfiguration, "--hostname", "slirphost", ALPINE, "cat", "/etc/hosts", "/etc/resolv.conf"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.LineInOutputStartsWith("192.168.0.100\tslirphost")).To(BeTrue())
+		Expect(session.LineInOutputStartsWith("192.168.0.2\thost.containers.internal")).To(BeTrue())
+		Expect(session.LineInOutputStartsWith("nameserver 192.168.0.3")).To(BeTrue())
+	})
+
 	It("podman run network bind to 127.0.0.1", func() {
 		slirp4netnsHelp := SystemExec("slirp4netns", []string{"--help"})
 		Expect(slirp4netnsHelp.ExitCode()).To(Equal(0))
@@ -639,6 +667,55 @@ var _ = Describe("Podman run networking", func() {
 		Expect(create.ExitCode()).To(BeZero())
 	})
 
+	It("podman run in multiple CNI networks with static addresses", func() {
+		SkipIfRootless("Rootless mode does not support static addresses")
+		netName1 := "podmantestnetwork3"
+		netName2 := "podmantestnetwork4"
+		create := podmanTest.Podman([]string{"network", "create", "--subnet", "10.25.50.0/24", netName1})
+		create.WaitWithDefaultTimeout()
+		Expect(create.ExitCode()).To(BeZero())
+		defer podmanTest.removeCNINetwork(netName1)
+		create = podmanTest.Podman([]string{"network", "create", "--subnet", "10.25.60.0/24", netName2})
+		create.WaitWithDefaultTimeout()
+		Expect(create.ExitCode()).To(BeZero())
+		defer podmanTest.removeCNINetwork(netName2)
+
+		network := netName1 + ":ip=10.25.50.128," + netName2 + ":ip=10.25.60.128,mac=92:d0:c6:0a:29:38"
+		session := podmanTest.Podman([]string{"run", "-d", "--name", "test", "--network", network, "--mac-address", "92:d0:c6:0a:29:33", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(BeZero())
+
+		for i := 0; i < 2; i++ {
+			exec := podmanTest.Podman([]string{"exec", "test", "ip", "addr"})
+			exec.WaitWithDefaultTimeout()
+			Expect(exec.ExitCode()).To(BeZero())
+			Expect(exec.OutputToString()).To(ContainSubstring("10.25.50.128"))
+			Expect(exec.OutputToString()).To(ContainSubstring("10.25.60.128"))
+			Expect(exec.OutputToString()).To(ContainSubstring("92:d0:c6:0a:29:33"))
+			Expect(exec.OutputToString()).To(ContainSubstring("92:d0:c6:0a:29:38"))
+
+			// The addresses are kept across restarts.
+			restart := podmanTest.Podman([]string{"restart", "test"})
+			restart.WaitWithDefaultTimeout()
+			Expect(restart.ExitCode()).To(BeZero())
+		}
+
+		session = podmanTest.Podman([]string{"rm", "-f", "test"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(BeZero())
+	})
+
+	It("podman run --ip with multiple CNI networks fails", func() {
+		session := podmanTest.Podman([]string{"run", "--rm", "--network", "net1,net2", "--ip", "10.25.50.128", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError())
+
+		session = podmanTest.Podman([]string{"run", "--rm", "--network", "net1:ip=10.25.50.500", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError())
+		Expect(session.ErrorToString()).To(ContainSubstring("invalid IP address"))
+	})
+
 	It("podman run with new:pod and static-ip", func() {
 		SkipIfRootless("Rootless does not support --ip")
 		netName := "podmantestnetwork2"
@@ -110,7 +110,6 @@ var _ = Describe("Podman run with --sig-proxy", func() {
 	})
 
 	Specify("signals are not forwarded to container with sig-proxy false", func() {
-		SkipIfRemote("FIXME: This looks like it is supposed to work in remote")
 		signal := syscall.SIGFPE
 		if rootless.IsRootless() {
 			podmanTest.RestoreArtifact(fedoraMinimal)
@@ -137,4 +136,23 @@ var _ = Describe("Podman run with --sig-proxy", func() {
 		Expect(ok).To(BeFalse())
 	})
 
+	Specify("SIGUSR1 is forwarded to the init process of a container without TTY", func() {
+		session, pid := podmanTest.PodmanPID([]string{"run", "--name", "test3", ALPINE, "sh", "-c", "trap 'echo Received; exit 0' USR1; echo READY; while :; do sleep 0.25; done"})
+
+		for i := 0; !strings.Contains(session.OutputToString(), "READY"); i++ {
+			if i == 15 {
+				Fail("Timed out waiting for READY from container")
+			}
+			time.Sleep(1 * time.Second)
+		}
+
+		if err := unix.Kill(pid, syscall.SIGUSR1); err != nil {
+			Fail(fmt.Sprintf("error killing podman process %d: %v", pid, err))
+		}
+
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		ok, _ := session.GrepString("Received")
+		Expect(ok).To(BeTrue())
+	})
 })
@@ -6,6 +6,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
 	"syscall"
@@ -88,6 +89,25 @@ var _ = Describe("Podman run", func() {
 		Expect(session.ExitCode()).To(Not(Equal(0)))
 	})
 
+	Measure("podman run --rm latency", func(b Benchmarker) {
+		// The state of --rm containers is written once when starting
+		// them, compare with a container started the usual way.
+		b.Time("run --rm", func() {
+			session := podmanTest.Podman([]string{"run", "--rm", ALPINE, "true"})
+			session.WaitWithDefaultTimeout()
+			Expect(session.ExitCode()).To(Equal(0))
+		})
+		b.Time("run and rm", func() {
+			session := podmanTest.Podman([]string{"run", "--name", "latency", ALPINE, "true"})
+			session.WaitWithDefaultTimeout()
+			Expect(session.ExitCode()).To(Equal(0))
+			session = podmanTest.Podman([]string{"rm", "latency"})
+			session.WaitWithDefaultTimeout()
+			Expect(session.ExitCode()).To(Equal(0))
+		})
+		Expect(podmanTest.NumberOfContainers()).To(Equal(0))
+	}, 10)
+
 	It("podman run a container based on on a short name with localhost", func() {
 		tag := podmanTest.Podman([]string{"tag", nginx, "localhost/libpod/alpine_nginx:latest"})
 		tag.WaitWithDefaultTimeout()
@@ -683,6 +703,73 @@ USER bin`
 		Expect(session.OutputToString()).To(ContainSubstring("key.pem"))
 	})
 
+	It("podman run with default mounts", func() {
+		SkipIfRemote("--default-mount-file option is not supported in podman-remote")
+		containersDir := filepath.Join(podmanTest.TempDir, "containers")
+		err := os.MkdirAll(containersDir, 0755)
+		Expect(err).To(BeNil())
+
+		defaultDir := filepath.Join(podmanTest.TempDir, "default")
+		err = os.MkdirAll(defaultDir, 0755)
+		Expect(err).To(BeNil())
+		err = ioutil.WriteFile(filepath.Join(defaultDir, "ca.pem"), []byte("default"), 0755)
+		Expect(err).To(BeNil())
+
+		mountsFile := filepath.Join(containersDir, "mounts.conf")
+		err = ioutil.WriteFile(mountsFile, []byte(defaultDir+":/run/default"), 0755)
+		Expect(err).To(BeNil())
+
+		session := podmanTest.Podman([]string{"--default-mounts-file=" + mountsFile, "run", "--name", "test", ALPINE, "cat", "/run/default/ca.pem"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("default"))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{range .Mounts}}{{.Type}} {{.Destination}}{{end}}", "test"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("bind /run/default"))
+
+		session = podmanTest.Podman([]string{"--default-mounts-file=" + mountsFile, "run", "--rm", "--no-default-mounts", ALPINE, "ls", "/run/default"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError())
+
+		userDir := filepath.Join(podmanTest.TempDir, "user")
+		err = os.MkdirAll(userDir, 0755)
+		Expect(err).To(BeNil())
+		err = ioutil.WriteFile(filepath.Join(userDir, "ca.pem"), []byte("user"), 0755)
+		Expect(err).To(BeNil())
+
+		session = podmanTest.Podman([]string{"--default-mounts-file=" + mountsFile, "run", "--rm", "-v", userDir + ":/run/default:z", ALPINE, "cat", "/run/default/ca.pem"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("user"))
+	})
+
+	It("podman run with --timeout", func() {
+		session := podmanTest.Podman([]string{"run", "--name", "timeout", "--timeout", "2", ALPINE, "sleep", "100"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.State.TimedOut}} {{.Config.Timeout}}", "timeout"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("true 2"))
+
+		events := podmanTest.Podman([]string{"events", "--stream=false", "--filter", "event=timeout", "--filter", "container=timeout"})
+		events.WaitWithDefaultTimeout()
+		Expect(events.ExitCode()).To(Equal(0))
+		Expect(events.OutputToString()).To(ContainSubstring("timeout"))
+
+		session = podmanTest.Podman([]string{"run", "--name", "notimeout", "--timeout", "100", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		inspect = podmanTest.Podman([]string{"inspect", "--format", "{{.State.TimedOut}}", "notimeout"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("false"))
+	})
+
 	It("podman run with FIPS mode secrets", func() {
 		SkipIfRootless("rootless can not manipulate system-fips file")
 		fipsFile := "/etc/system-fips"
@@ -1415,4 +1502,55 @@ WORKDIR /madethis`
 		Expect(session.ExitCode()).To(Equal(0))
 		Expect(session.ErrorToString()).To(ContainSubstring("Trying to pull"))
 	})
+
+	It("podman run --pidfile", func() {
+		pidFile := filepath.Join(podmanTest.TempDir, "container.pid")
+		session := podmanTest.Podman([]string{"run", "-d", "--name", "test", "--pidfile", pidFile, ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.State.Pid}} {{.PidFile}}", "test"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+
+		content, err := ioutil.ReadFile(pidFile)
+		Expect(err).To(BeNil())
+		Expect(inspect.OutputToString()).To(Equal(strings.TrimSpace(string(content)) + " " + pidFile))
+
+		session = podmanTest.Podman([]string{"stop", "test"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		_, err = os.Stat(pidFile)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("podman run validates the platform of the image", func() {
+		SkipIfRemote("containers.conf of the server cannot be changed by the remote client")
+		arch := "arm64"
+		qemu := "aarch64"
+		if runtime.GOARCH == "arm64" {
+			arch, qemu = "amd64", "x86_64"
+		}
+		if _, err := os.Stat("/proc/sys/fs/binfmt_misc/qemu-" + qemu); err == nil {
+			Skip("emulation of " + arch + " is registered")
+		}
+		conf := filepath.Join(podmanTest.TempDir, "containers.conf")
+		err := ioutil.WriteFile(conf, []byte("[engine]\nimage_platform_policy = \"error\"\n"), 0644)
+		Expect(err).To(BeNil())
+		os.Setenv("CONTAINERS_CONF", conf)
+		defer os.Unsetenv("CONTAINERS_CONF")
+
+		session := podmanTest.Podman([]string{"pull", "--arch", arch, ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"create", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError())
+		Expect(session.ErrorToString()).To(ContainSubstring("does not match the host platform"))
+
+		session = podmanTest.Podman([]string{"create", "--platform", "linux/" + arch, ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+	})
 })
@@ -332,6 +332,30 @@ var _ = Describe("Podman run with volumes", func() {
 		Expect(arr[0]).To(Not(Equal("")))
 	})
 
+	It("podman run --rm removes anonymous volume", func() {
+		session := podmanTest.Podman([]string{"volume", "create", "named"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", "/test", "-v", "named:/named", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"run", "-d", "--rm", "-v", "/test", ALPINE, "sleep", "2"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		ctrID := session.OutputToString()
+
+		session = podmanTest.Podman([]string{"wait", "--condition", "removed", ctrID})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		list := podmanTest.Podman([]string{"volume", "list", "--quiet"})
+		list.WaitWithDefaultTimeout()
+		Expect(list.ExitCode()).To(Equal(0))
+		Expect(list.OutputToStringArray()).To(Equal([]string{"named"}))
+	})
+
 	It("podman rm -v removes anonymous volume", func() {
 		list1 := podmanTest.Podman([]string{"volume", "list", "--quiet"})
 		list1.WaitWithDefaultTimeout()
@@ -398,6 +422,35 @@ var _ = Describe("Podman run with volumes", func() {
 		Expect(session.OutputToString()).To(Not(ContainSubstring("noexec")))
 	})
 
+	It("podman run with image volume", func() {
+		session := podmanTest.Podman([]string{"run", "--rm", "--mount", "type=image,source=" + BB + ",dst=/image", ALPINE, "ls", "/image/bin/busybox"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "--mount", "type=image,source=" + BB + ",dst=/image", ALPINE, "touch", "/image/file"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "--mount", "type=image,source=" + BB + ",dst=/image,rw=true", ALPINE, "touch", "/image/file"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+	})
+
+	It("podman image of image volume cannot be removed", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "test", "--mount", "type=image,source=" + BB + ",dst=/image", ALPINE, "ls", "/image"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"rmi", BB})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(2))
+
+		session = podmanTest.Podman([]string{"rmi", "--force", BB})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainers()).To(Equal(0))
+	})
+
 	It("podman mount with invalid option fails", func() {
 		volName := "testVol"
 		volCreate := podmanTest.Podman([]string{"volume", "create", "--opt", "type=tmpfs", "--opt", "device=tmpfs", "--opt", "o=invalid", volName})
@@ -488,6 +541,17 @@ VOLUME /test/`
 		_, err = os.Stat(filepath.Join(mountPath, "container"))
 		Expect(err).To(Not(BeNil()))
 
+		// Make sure overlay volumes are shown by inspect
+		session = podmanTest.Podman([]string{"create", "--name", "overlayctr", "-v", fmt.Sprintf("%s:/run/test:O", mountPath), ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		data := podmanTest.InspectContainer("overlayctr")
+		Expect(len(data)).To(Equal(1))
+		Expect(len(data[0].Mounts)).To(Equal(1))
+		Expect(data[0].Mounts[0].Type).To(Equal("overlay"))
+		Expect(data[0].Mounts[0].Source).To(Equal(mountPath))
+		Expect(data[0].Mounts[0].Destination).To(Equal("/run/test"))
+
 		// Make sure modifications in container disappear when container is stopped
 		session = podmanTest.Podman([]string{"create", "-v", fmt.Sprintf("%s:/run/test:O", mountPath), ALPINE, "top"})
 		session.WaitWithDefaultTimeout()
@@ -70,6 +70,58 @@ var _ = Describe("Podman save", func() {
 		Expect(save.ExitCode()).To(Equal(0))
 	})
 
+	It("podman save --delta-base and load --delta", func() {
+		SkipIfRemote("podman-remote does not support delta archives")
+		dockerfile := `FROM quay.io/libpod/alpine:latest
+RUN echo delta > /delta.txt`
+		podmanTest.BuildImage(dockerfile, "localhost/deltaimage", "false")
+
+		layersFile := filepath.Join(podmanTest.TempDir, "layers.txt")
+		session := podmanTest.Podman([]string{"image", "inspect", "--format", "{{range .RootFS.Layers}}{{println .}}{{end}}", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		err := ioutil.WriteFile(layersFile, []byte(session.OutputToString()), 0644)
+		Expect(err).To(BeNil())
+
+		fullfile := filepath.Join(podmanTest.TempDir, "full.tar")
+		save := podmanTest.Podman([]string{"save", "-o", fullfile, "localhost/deltaimage"})
+		save.WaitWithDefaultTimeout()
+		Expect(save.ExitCode()).To(Equal(0))
+
+		deltafile := filepath.Join(podmanTest.TempDir, "delta.tar")
+		save = podmanTest.Podman([]string{"save", "--delta-base", layersFile, "-o", deltafile, "localhost/deltaimage"})
+		save.WaitWithDefaultTimeout()
+		Expect(save.ExitCode()).To(Equal(0))
+
+		fullInfo, err := os.Stat(fullfile)
+		Expect(err).To(BeNil())
+		deltaInfo, err := os.Stat(deltafile)
+		Expect(err).To(BeNil())
+		Expect(deltaInfo.Size()).To(BeNumerically("<", fullInfo.Size()))
+
+		session = podmanTest.Podman([]string{"rmi", "localhost/deltaimage"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		load := podmanTest.Podman([]string{"load", "--delta", "-i", deltafile})
+		load.WaitWithDefaultTimeout()
+		Expect(load.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "localhost/deltaimage", "cat", "/delta.txt"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("delta"))
+
+		// Loading fails if the omitted layers are not present.
+		session = podmanTest.Podman([]string{"rmi", "-af"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		load = podmanTest.Podman([]string{"load", "--delta", "-i", deltafile})
+		load.WaitWithDefaultTimeout()
+		Expect(load).To(ExitWithError())
+		Expect(load.ErrorToString()).To(ContainSubstring("not present in local storage"))
+	})
+
 	It("podman save bogus image", func() {
 		outfile := filepath.Join(podmanTest.TempDir, "alpine.tar")
 
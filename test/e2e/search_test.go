@@ -469,6 +469,15 @@ registries = ['{{.Host}}:{{.Port}}']`
 		Expect(len(search.OutputToStringArray()) == 0).To(BeTrue())
 	})
 
+	It("podman search repository tags with json output", func() {
+		search := podmanTest.Podman([]string{"search", "--list-tags", "--limit", "3", "--format", "json", "quay.io/libpod/alpine"})
+		search.WaitWithDefaultTimeout()
+		Expect(search.ExitCode()).To(Equal(0))
+		Expect(search.IsJSONOutputValid()).To(BeTrue())
+		Expect(search.OutputToString()).To(ContainSubstring(`"Tag"`))
+		Expect(search.OutputToString()).To(ContainSubstring(`"Digest": "sha256:`))
+	})
+
 	It("podman search with limit over 100", func() {
 		search := podmanTest.Podman([]string{"search", "--limit", "130", "registry.redhat.io/rhel"})
 		search.WaitWithDefaultTimeout()
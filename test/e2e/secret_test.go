@@ -0,0 +1,208 @@
+package integration
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/containers/podman/v2/test/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman secret", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest *PodmanTestIntegration
+		secretFile string
+	)
+
+	BeforeEach(func() {
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanTestCreate(tempdir)
+		podmanTest.Setup()
+		podmanTest.SeedImages()
+		secretFile = filepath.Join(tempdir, "secret")
+		err = ioutil.WriteFile(secretFile, []byte("mysecretdata"), 0755)
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+		f := CurrentGinkgoTestDescription()
+		processTestResult(f)
+
+	})
+
+	It("podman secret create and inspect", func() {
+		session := podmanTest.Podman([]string{"secret", "create", "mysecret", secretFile})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		secrID := session.OutputToString()
+
+		inspect := podmanTest.Podman([]string{"secret", "inspect", "--format", "{{.ID}} {{.Spec.Name}} {{.Spec.Driver.Name}}", "mysecret"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal(secrID + " mysecret file"))
+
+		inspect = podmanTest.Podman([]string{"secret", "inspect", secrID})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.IsJSONOutputValid()).To(BeTrue())
+		Expect(inspect.OutputToString()).To(Not(ContainSubstring("mysecretdata")))
+	})
+
+	It("podman secret create with invalid or duplicate name should fail", func() {
+		session := podmanTest.Podman([]string{"secret", "create", "my/secret", secretFile})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+
+		session = podmanTest.Podman([]string{"secret", "create", "mysecret", secretFile})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"secret", "create", "mysecret", secretFile})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman secret inspect with missing secret should fail", func() {
+		inspect := podmanTest.Podman([]string{"secret", "inspect", "nosecret"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Not(Equal(0)))
+		Expect(inspect.ErrorToString()).To(ContainSubstring("no such secret"))
+	})
+
+	It("podman secret ls", func() {
+		for _, name := range []string{"b", "a"} {
+			session := podmanTest.Podman([]string{"secret", "create", name, secretFile})
+			session.WaitWithDefaultTimeout()
+			Expect(session.ExitCode()).To(Equal(0))
+		}
+
+		list := podmanTest.Podman([]string{"secret", "ls"})
+		list.WaitWithDefaultTimeout()
+		Expect(list.ExitCode()).To(Equal(0))
+		Expect(len(list.OutputToStringArray())).To(Equal(3))
+
+		list = podmanTest.Podman([]string{"secret", "ls", "--format", "{{.Name}}"})
+		list.WaitWithDefaultTimeout()
+		Expect(list.ExitCode()).To(Equal(0))
+		Expect(list.OutputToStringArray()).To(Equal([]string{"a", "b"}))
+
+		list = podmanTest.Podman([]string{"secret", "ls", "--format", "json"})
+		list.WaitWithDefaultTimeout()
+		Expect(list.ExitCode()).To(Equal(0))
+		Expect(list.IsJSONOutputValid()).To(BeTrue())
+	})
+
+	It("podman secret rm", func() {
+		session := podmanTest.Podman([]string{"secret", "create", "mysecret", secretFile})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		secrID := session.OutputToString()
+
+		rm := podmanTest.Podman([]string{"secret", "rm", "mysecret"})
+		rm.WaitWithDefaultTimeout()
+		Expect(rm.ExitCode()).To(Equal(0))
+		Expect(rm.OutputToString()).To(ContainSubstring(secrID[:12]))
+
+		rm = podmanTest.Podman([]string{"secret", "rm", "mysecret"})
+		rm.WaitWithDefaultTimeout()
+		Expect(rm.ExitCode()).To(Equal(1))
+
+		list := podmanTest.Podman([]string{"secret", "ls", "--noheading"})
+		list.WaitWithDefaultTimeout()
+		Expect(list.ExitCode()).To(Equal(0))
+		Expect(len(list.OutputToStringArray())).To(Equal(0))
+	})
+
+	It("podman secret rm --all", func() {
+		for _, name := range []string{"mysecret1", "mysecret2"} {
+			session := podmanTest.Podman([]string{"secret", "create", name, secretFile})
+			session.WaitWithDefaultTimeout()
+			Expect(session.ExitCode()).To(Equal(0))
+		}
+
+		rm := podmanTest.Podman([]string{"secret", "rm", "--all"})
+		rm.WaitWithDefaultTimeout()
+		Expect(rm.ExitCode()).To(Equal(0))
+		Expect(len(rm.OutputToStringArray())).To(Equal(2))
+
+		list := podmanTest.Podman([]string{"secret", "ls", "-q"})
+		list.WaitWithDefaultTimeout()
+		Expect(list.ExitCode()).To(Equal(0))
+		Expect(len(list.OutputToStringArray())).To(Equal(0))
+	})
+
+	It("podman run --secret mounts the secret", func() {
+		session := podmanTest.Podman([]string{"secret", "create", "mysecret", secretFile})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"run", "--secret", "mysecret", "--name", "secr", ALPINE, "cat", "/run/secrets/mysecret"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("mysecretdata"))
+
+		session = podmanTest.Podman([]string{"run", "--secret", "mysecret,target=/etc/mysecret", ALPINE, "cat", "/etc/mysecret"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("mysecretdata"))
+
+		inspect := podmanTest.Podman([]string{"container", "inspect", "--format", "{{(index .Config.Secrets 0).Name}} {{(index .Config.Secrets 0).Target}}", "secr"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("mysecret /run/secrets/mysecret"))
+
+		// The secret is used by a container and cannot be removed.
+		rm := podmanTest.Podman([]string{"secret", "rm", "mysecret"})
+		rm.WaitWithDefaultTimeout()
+		Expect(rm.ExitCode()).To(Equal(2))
+	})
+
+	It("podman run --secret type=env sets the environment variable", func() {
+		session := podmanTest.Podman([]string{"secret", "create", "mysecret", secretFile})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"run", "--secret", "mysecret,type=env,target=MYSECRET", ALPINE, "printenv", "MYSECRET"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("mysecretdata"))
+	})
+
+	It("podman inspect does not show the value of a type=env secret", func() {
+		session := podmanTest.Podman([]string{"secret", "create", "mysecret", secretFile})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"run", "--name", "secr", "--secret", "mysecret,type=env,target=MYSECRET", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"container", "inspect", "secr"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Not(ContainSubstring("mysecretdata")))
+
+		inspect = podmanTest.Podman([]string{"container", "inspect", "--format", "{{range .Config.Secrets}}{{.Type}} {{.Target}}{{end}}", "secr"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("env MYSECRET"))
+	})
+
+	It("podman run --secret with missing secret should fail", func() {
+		session := podmanTest.Podman([]string{"run", "--secret", "nosecret", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+
+		session = podmanTest.Podman([]string{"run", "--secret", "nosecret,type=file", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+})
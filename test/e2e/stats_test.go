@@ -177,4 +177,38 @@ var _ = Describe("Podman stats", func() {
 
 		Expect(customLimit).To(BeNumerically("<", defaultLimit))
 	})
+
+	It("podman stats, inspect and events report OOM kills", func() {
+		// tail buffers its whole input, which has no newline, until the
+		// OOM killer kills it.
+		session := podmanTest.Podman([]string{"run", "-d", "--name", "oom", "--memory", "20m", "--memory-swap", "20m", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"exec", "oom", "tail", "/dev/zero"})
+		session.Wait(60)
+		Expect(session.ExitCode()).To(Equal(137))
+
+		session = podmanTest.Podman([]string{"stats", "--no-stream", "--format", "{{.OOMKills}}", "oom"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		oomKills, err := strconv.Atoi(session.OutputToString())
+		Expect(err).To(BeNil())
+		Expect(oomKills).To(BeNumerically(">=", 1))
+
+		// A container killed by the OOM killer emits an oom event.
+		session = podmanTest.Podman([]string{"run", "--name", "oom-killed", "--memory", "20m", "--memory-swap", "20m", ALPINE, "tail", "/dev/zero"})
+		session.Wait(60)
+		Expect(session.ExitCode()).To(Equal(137))
+
+		session = podmanTest.Podman([]string{"inspect", "--format", "{{.State.OOMKilled}} {{.State.OOMKills}}", "oom-killed"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(MatchRegexp("^true [1-9][0-9]*$"))
+
+		session = podmanTest.Podman([]string{"events", "--stream=false", "--filter", "event=oom", "--filter", "container=oom-killed"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring("oom-killed"))
+	})
 })
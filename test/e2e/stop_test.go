@@ -181,6 +181,43 @@ var _ = Describe("Podman stop", func() {
 		Expect(strings.TrimSpace(finalCtrs.OutputToString())).To(Equal(""))
 	})
 
+	It("podman stop container --signals", func() {
+		SkipIfRemote("--signals is not supported for remote clients")
+		session := podmanTest.RunTopContainer("test6")
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		cid1 := session.OutputToString()
+
+		session = podmanTest.Podman([]string{"stop", "--signals", "SIGUSR2:1,SIGINT:1", "test6"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring(cid1))
+
+		session = podmanTest.Podman([]string{"stop", "--signals", "SIGINT", "test6"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError())
+	})
+
+	It("podman stop container with --stop-signals", func() {
+		session := podmanTest.Podman([]string{"run", "-d", "--name", "test7", "--stop-signals", "SIGUSR2:1,SIGINT:2", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{range .Config.StopSignals}}{{.Signal}}:{{.Timeout}} {{end}}", "test7"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("12:1 2:2"))
+
+		session = podmanTest.Podman([]string{"stop", "test7"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		finalCtrs := podmanTest.Podman([]string{"ps", "-q"})
+		finalCtrs.WaitWithDefaultTimeout()
+		Expect(finalCtrs.ExitCode()).To(Equal(0))
+		Expect(strings.TrimSpace(finalCtrs.OutputToString())).To(Equal(""))
+	})
+
 	It("podman stop latest containers", func() {
 		SkipIfRemote("--latest flag n/a")
 		session := podmanTest.RunTopContainer("test1")
@@ -3,6 +3,7 @@ package integration
 import (
 	"fmt"
 	"io/ioutil"
+	"net/url"
 	"os"
 
 	"github.com/containers/common/pkg/config"
@@ -94,6 +95,45 @@ var _ = Describe("podman system connection", func() {
 		))
 	})
 
+	It("add tcp with token file", func() {
+		tokenFile, err := ioutil.TempFile("", "token")
+		Expect(err).ShouldNot(HaveOccurred())
+		defer os.Remove(tokenFile.Name())
+		_, err = tokenFile.WriteString("secret\n")
+		Expect(err).ShouldNot(HaveOccurred())
+		tokenFile.Close()
+
+		cmd := []string{"system", "connection", "add",
+			"--token-file", tokenFile.Name(),
+			"build",
+			"tcp://localhost:8080",
+		}
+		session := podmanTest.Podman(cmd)
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+
+		cfg, err := config.ReadCustomConfig()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cfg.Engine.ServiceDestinations["build"]).To(Equal(
+			config.Destination{
+				URI: "tcp://localhost:8080?" + url.Values{"token-file": {tokenFile.Name()}}.Encode(),
+			},
+		))
+
+		cmd = []string{"system", "connection", "add", "noport", "tcp://localhost"}
+		session = podmanTest.Podman(cmd)
+		session.WaitWithDefaultTimeout()
+		Expect(session).ShouldNot(Exit(0))
+		Expect(session.Err).Should(Say("requires a port"))
+
+		cmd = []string{"system", "connection", "list", "--check"}
+		session = podmanTest.Podman(cmd)
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		Expect(session.Out).Should(Say("Name *Identity *URI *Status"))
+		Expect(session.Out).ShouldNot(Say("build.* ok"))
+	})
+
 	It("remove", func() {
 		cmd := []string{"system", "connection", "add",
 			"--default",
@@ -0,0 +1,58 @@
+package integration
+
+import (
+	"fmt"
+	"os"
+
+	. "github.com/containers/podman/v2/test/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("podman system devices", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest *PodmanTestIntegration
+	)
+
+	BeforeEach(func() {
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanTestCreate(tempdir)
+		podmanTest.Setup()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+		f := CurrentGinkgoTestDescription()
+		timedResult := fmt.Sprintf("Test: %s completed in %f seconds", f.TestText, f.Duration.Seconds())
+		GinkgoWriter.Write([]byte(timedResult))
+	})
+
+	It("podman system devices", func() {
+		session := podmanTest.Podman([]string{"system", "devices"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToStringArray()[0]).To(ContainSubstring("CGROUP RULE"))
+	})
+
+	It("podman system devices lists /dev/fuse", func() {
+		if _, err := os.Stat("/dev/fuse"); err != nil {
+			Skip("/dev/fuse does not exist")
+		}
+		session := podmanTest.Podman([]string{"system", "devices", "--format", "{{.Path}} {{.Class}} {{.CgroupRule}}"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring("/dev/fuse fuse c 10:229 rwm"))
+	})
+
+	It("podman system devices --format json", func() {
+		session := podmanTest.Podman([]string{"system", "devices", "--format", "json"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.IsJSONOutputValid()).To(BeTrue())
+	})
+})
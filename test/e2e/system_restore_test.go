@@ -0,0 +1,73 @@
+package integration
+
+import (
+	"fmt"
+	"os"
+
+	. "github.com/containers/podman/v2/test/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("podman system restore", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest *PodmanTestIntegration
+	)
+
+	BeforeEach(func() {
+		SkipIfRemote("podman system restore is not supported on remote clients")
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanTestCreate(tempdir)
+		podmanTest.Setup()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+		f := CurrentGinkgoTestDescription()
+		timedResult := fmt.Sprintf("Test: %s completed in %f seconds", f.TestText, f.Duration.Seconds())
+		GinkgoWriter.Write([]byte(timedResult))
+	})
+
+	It("podman system restore starts containers with a restart policy", func() {
+		dep := podmanTest.Podman([]string{"create", "--name", "dep", ALPINE, "top"})
+		dep.WaitWithDefaultTimeout()
+		Expect(dep.ExitCode()).To(Equal(0))
+
+		always := podmanTest.Podman([]string{"create", "--name", "always", "--restart", "always", "--network", "container:dep", ALPINE, "top"})
+		always.WaitWithDefaultTimeout()
+		Expect(always.ExitCode()).To(Equal(0))
+
+		unlessStopped := podmanTest.Podman([]string{"run", "-d", "--name", "unless", "--restart", "unless-stopped", ALPINE, "top"})
+		unlessStopped.WaitWithDefaultTimeout()
+		Expect(unlessStopped.ExitCode()).To(Equal(0))
+
+		stop := podmanTest.Podman([]string{"stop", "unless"})
+		stop.WaitWithDefaultTimeout()
+		Expect(stop.ExitCode()).To(Equal(0))
+
+		none := podmanTest.Podman([]string{"create", "--name", "none", ALPINE, "top"})
+		none.WaitWithDefaultTimeout()
+		Expect(none.ExitCode()).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"system", "restore"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToStringArray()).To(ConsistOf(dep.OutputToString(), always.OutputToString()))
+
+		ps := podmanTest.Podman([]string{"ps", "--format", "{{.Names}}"})
+		ps.WaitWithDefaultTimeout()
+		Expect(ps.ExitCode()).To(Equal(0))
+		Expect(ps.OutputToStringArray()).To(ConsistOf("dep", "always"))
+
+		// Running containers are left alone
+		session = podmanTest.Podman([]string{"system", "restore"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(BeEmpty())
+	})
+})
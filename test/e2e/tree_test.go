@@ -3,6 +3,7 @@ package integration
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	. "github.com/containers/podman/v2/test/utils"
 	. "github.com/onsi/ginkgo"
@@ -58,4 +59,33 @@ ENV foo=bar
 		session.WaitWithDefaultTimeout()
 		Expect(session.ExitCode()).To(Equal(0))
 	})
+
+	It("podman image tree --shared", func() {
+		dockerfile := fmt.Sprintf(`FROM %s
+RUN touch /shared.txt
+`, BB)
+		podmanTest.BuildImage(dockerfile, "shared:latest", "false")
+
+		session := podmanTest.Podman([]string{"image", "tree", "--shared", BB})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring("Shared with: localhost/shared:latest"))
+		Expect(session.OutputToString()).To(ContainSubstring("Unique: 0B"))
+
+		// All images are listed, the one with the most unique data first.
+		session = podmanTest.Podman([]string{"image", "tree", "--shared"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		output := session.OutputToString()
+		Expect(output).To(ContainSubstring("Shared with: " + BB))
+		Expect(strings.Index(output, "Tags: [localhost/shared:latest]")).To(BeNumerically("<", strings.Index(output, "Tags: ["+BB+"]")))
+
+		session = podmanTest.Podman([]string{"image", "tree"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError())
+
+		session = podmanTest.Podman([]string{"image", "tree", "--shared", "--whatrequires", BB})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError())
+	})
 })
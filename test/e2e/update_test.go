@@ -0,0 +1,126 @@
+package integration
+
+import (
+	"os"
+
+	. "github.com/containers/podman/v2/test/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman update", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest *PodmanTestIntegration
+	)
+
+	BeforeEach(func() {
+		SkipIfRootlessCgroupsV1("Update is not supported in cgroups v1")
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanTestCreate(tempdir)
+		podmanTest.Setup()
+		podmanTest.SeedImages()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+		f := CurrentGinkgoTestDescription()
+		processTestResult(f)
+
+	})
+
+	It("podman update bogus container", func() {
+		session := podmanTest.Podman([]string{"update", "--memory", "100m", "123456"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+	})
+
+	It("podman update without limits", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "test", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		update := podmanTest.Podman([]string{"update", "test"})
+		update.WaitWithDefaultTimeout()
+		Expect(update).To(ExitWithError())
+	})
+
+	It("podman update with zero pids limit or invalid block IO weight", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "test", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		for _, args := range [][]string{{"--pids-limit", "0"}, {"--blkio-weight", "0"}, {"--blkio-weight", "5000"}} {
+			update := podmanTest.Podman(append(append([]string{"update"}, args...), "test"))
+			update.WaitWithDefaultTimeout()
+			Expect(update).To(ExitWithError())
+		}
+	})
+
+	It("podman update running container", func() {
+		session := podmanTest.Podman([]string{"run", "-d", "--name", "test", "--memory", "100m", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		update := podmanTest.Podman([]string{"update", "--memory", "200m", "--cpus", "0.5", "--pids-limit", "100", "test"})
+		update.WaitWithDefaultTimeout()
+		Expect(update.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "test"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		data := inspect.InspectContainerToJSON()
+		Expect(data[0].State.Running).To(BeTrue())
+		Expect(data[0].HostConfig.Memory).To(Equal(int64(200 * 1024 * 1024)))
+		Expect(data[0].HostConfig.CpuQuota).To(Equal(int64(50000)))
+		Expect(data[0].HostConfig.PidsLimit).To(Equal(int64(100)))
+	})
+
+	It("podman container update keeps limits on restart", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "test", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		update := podmanTest.Podman([]string{"container", "update", "--memory", "200m", "test"})
+		update.WaitWithDefaultTimeout()
+		Expect(update.ExitCode()).To(Equal(0))
+
+		start := podmanTest.Podman([]string{"start", "test"})
+		start.WaitWithDefaultTimeout()
+		Expect(start.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.HostConfig.Memory}}", "test"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("209715200"))
+	})
+
+	It("podman update labels and annotations", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "test", "--label", "owner=me", "--label", "stage=dev", "--annotation", "foo=bar", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		update := podmanTest.Podman([]string{"update", "--label", "stage=prod", "--label-rm", "owner", "--annotation", "team=web", "--annotation-rm", "foo", "test"})
+		update.WaitWithDefaultTimeout()
+		Expect(update.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.Config.Labels}} {{.Config.Annotations.team}} {{index .Config.Annotations \"foo\"}}", "test"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("map[stage:prod] web <no value>"))
+	})
+
+	It("podman update reserved annotation", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "test", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		update := podmanTest.Podman([]string{"update", "--annotation", "io.podman.annotations.autoremove=TRUE", "test"})
+		update.WaitWithDefaultTimeout()
+		Expect(update).To(ExitWithError())
+	})
+})
@@ -83,12 +83,86 @@ var _ = Describe("Podman volume create", func() {
 		Expect(inspectGID.ExitCode()).To(Equal(0))
 		Expect(inspectGID.OutputToString()).To(Equal(gid))
 
-		// options should containt `uid=3000,gid=4000:3000:4000`
+		// no filesystem is mounted, so uid and gid are not mount options
 		optionFormat := `{{ .Options.o }}:{{ .Options.UID }}:{{ .Options.GID }}`
-		optionStrFormatExpect := fmt.Sprintf(`uid=%s,gid=%s:%s:%s`, uid, gid, uid, gid)
+		optionStrFormatExpect := fmt.Sprintf(`<no value>:%s:%s`, uid, gid)
 		inspectOpts := podmanTest.Podman([]string{"volume", "inspect", "--format", optionFormat, volName})
 		inspectOpts.WaitWithDefaultTimeout()
 		Expect(inspectOpts.ExitCode()).To(Equal(0))
 		Expect(inspectOpts.OutputToString()).To(Equal(optionStrFormatExpect))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", volName + ":/data", ALPINE, "stat", "-c", "%u:%g", "/data"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal(uid + ":" + gid))
+	})
+
+	It("podman create volume with o=uid,gid and type=tmpfs", func() {
+		SkipIfRootless("cannot mount volumes without root privileges")
+		volName := "testVol"
+		session := podmanTest.Podman([]string{"volume", "create", "--opt", "type=tmpfs", "--opt", "device=tmpfs", "--opt", "o=uid=3000,gid=4000,size=10m", volName})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		inspectOpts := podmanTest.Podman([]string{"volume", "inspect", "--format", "{{ .Options.o }}", volName})
+		inspectOpts.WaitWithDefaultTimeout()
+		Expect(inspectOpts.ExitCode()).To(Equal(0))
+		Expect(inspectOpts.OutputToString()).To(Equal("uid=3000,gid=4000,size=10m"))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", volName + ":/data", ALPINE, "sh", "-c", "stat -c %u:%g /data && df -k /data | tail -1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring("3000:4000"))
+		Expect(session.OutputToString()).To(ContainSubstring("10240"))
+	})
+
+	It("podman create volume with invalid size", func() {
+		session := podmanTest.Podman([]string{"volume", "create", "--opt", "o=size=bogus", "testVol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError())
+	})
+
+	It("podman create volume with size and type", func() {
+		session := podmanTest.Podman([]string{"volume", "create", "--opt", "size=5g", "--opt", "type=tmpfs", "--opt", "device=tmpfs", "testVol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError())
+		Expect(session.ErrorToString()).To(ContainSubstring("size option cannot be used with the type or device options"))
+	})
+
+	It("podman create volume with size quota", func() {
+		session := podmanTest.Podman([]string{"volume", "create", "--opt", "size=5g", "testVol"})
+		session.WaitWithDefaultTimeout()
+		if session.ExitCode() != 0 {
+			// Quotas depend on the filesystem of the volume path,
+			// failures must explain what is missing and must not
+			// leave the volume behind.
+			Expect(session.ErrorToString()).To(ContainSubstring("volume size quotas"))
+			ls := podmanTest.Podman([]string{"volume", "ls", "-q"})
+			ls.WaitWithDefaultTimeout()
+			Expect(ls.ExitCode()).To(Equal(0))
+			Expect(ls.OutputToString()).To(BeEmpty())
+			Skip("volume size quotas are not available")
+		}
+
+		inspect := podmanTest.Podman([]string{"volume", "inspect", "--format", "{{ .Options.SIZE }}", "testVol"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("5g"))
+	})
+
+	It("podman volume is chowned to the user of the first container", func() {
+		session := podmanTest.Podman([]string{"volume", "create", "testVol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "--user", "1000:1000", "-v", "testVol:/data", ALPINE, "touch", "/data/file"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		// later containers do not change the owner again
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", "testVol:/data", ALPINE, "stat", "-c", "%u:%g", "/data"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("1000:1000"))
 	})
 })
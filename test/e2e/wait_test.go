@@ -130,4 +130,42 @@ var _ = Describe("Podman wait", func() {
 		Expect(session.ExitCode()).To(Equal(0))
 		Expect(session.OutputToStringArray()).To(Equal([]string{"0", "0", "0"}))
 	})
+
+	It("podman wait with bogus condition", func() {
+		session := podmanTest.Podman([]string{"run", "-d", ALPINE, "sleep", "1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		session = podmanTest.Podman([]string{"wait", "--condition", "bogus", session.OutputToString()})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+		Expect(session.ErrorToString()).To(ContainSubstring("unknown wait condition"))
+	})
+
+	It("podman wait --condition healthy on two containers", func() {
+		for _, name := range []string{"hc1", "hc2"} {
+			session := podmanTest.Podman([]string{"run", "-d", "--name", name, "--health-cmd", "true", "--health-interval", "disable", ALPINE, "top"})
+			session.WaitWithDefaultTimeout()
+			Expect(session.ExitCode()).To(Equal(0))
+		}
+
+		wait := podmanTest.Podman([]string{"wait", "--interval", "100ms", "--condition", "healthy", "hc1", "hc2"})
+		for _, name := range []string{"hc1", "hc2"} {
+			session := podmanTest.Podman([]string{"healthcheck", "run", name})
+			session.WaitWithDefaultTimeout()
+			Expect(session.ExitCode()).To(Equal(0))
+		}
+		wait.WaitWithDefaultTimeout()
+		Expect(wait.ExitCode()).To(Equal(0))
+		Expect(wait.OutputToStringArray()).To(Equal([]string{"-1", "-1"}))
+	})
+
+	It("podman wait --condition removed", func() {
+		session := podmanTest.Podman([]string{"run", "-d", "--rm", ALPINE, "sleep", "1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		session = podmanTest.Podman([]string{"wait", "--interval", "100ms", "--condition", "removed", session.OutputToString()})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainers()).To(Equal(0))
+	})
 })
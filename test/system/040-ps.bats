@@ -111,9 +111,11 @@ EOF
     run_podman ps --storage -a
     is "${#lines[@]}" "2" "podman ps -a --storage sees buildah container"
 
-    # This is what deletes the container
-    # FIXME: why doesn't "podman rm --storage $cid" do anything?
-    run_podman rm -f "$cid"
+    # Only external containers are listed and removed with --external
+    run_podman ps --external
+    is "${#lines[@]}" "2" "podman ps --external sees buildah container without -a"
+
+    run_podman rm --external "$cid"
 
     run_podman ps --storage -a
     is "${#lines[@]}" "1" "storage container has been removed"
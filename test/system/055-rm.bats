@@ -51,6 +51,36 @@ load helpers
     run_podman rm $rand $external_cid
 }
 
+@test "podman rm --external" {
+    skip_if_remote "external containers are only applicable for local podman"
+
+    rand=$(random_string 30)
+    run_podman create --name $rand $IMAGE /bin/true
+    cid="$output"
+
+    # Create containers that podman does not know about
+    external_cid=$(buildah from $IMAGE)
+    other_cid=$(buildah from $IMAGE)
+
+    # Podman containers are not removed with --external, by name or prefix
+    run_podman 1 rm --external $rand
+    run_podman rm --external ${external_cid:0:12}
+    is "$output" "$external_cid" "external container removed by ID prefix"
+
+    # --all only removes the external containers
+    run_podman rm --external --all
+    is "$output" "$other_cid" "external containers removed by --all"
+    run_podman container exists $rand
+    run_podman 1 container exists --external $other_cid
+
+    # The deprecated --storage option is an alias of --external
+    storage_cid=$(buildah from $IMAGE)
+    run_podman rm --storage $storage_cid
+    is "$output" "$storage_cid" "external container removed by --storage"
+
+    run_podman rm $rand
+}
+
 # I'm sorry! This test takes 13 seconds. There's not much I can do about it,
 # please know that I think it's justified: podman 1.5.0 had a strange bug
 # in with exit status was not preserved on some code paths with 'rm -f'